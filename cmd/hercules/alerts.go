@@ -0,0 +1,328 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// alertsExitCode is returned by "hercules alerts" if at least one rule fired.
+const alertsExitCode = 2
+
+// alertRules is the YAML file with the trend rules:
+//
+//	rules:
+//	  - name: bus factor erodes
+//	    metric: hercules_bus_factor
+//	    drop: 25      # percent
+//	    months: 6
+//	  - metric: hercules_ownership_gini
+//	    rise: 10
+//	  - name: new hotspots
+//	    new_hotspots: 5
+type alertRules struct {
+	Rules []alertRule `yaml:"rules"`
+}
+
+// alertRule is a single trend rule. It either compares a summary metric (see
+// extractSummaryMetrics()) with its value Months ago and fires if it dropped or rose
+// by the given percentage, or fires if new files entered the top NewHotspots hotspots
+// since the previous result.
+type alertRule struct {
+	Name        string  `yaml:"name"`
+	Metric      string  `yaml:"metric"`
+	Drop        float64 `yaml:"drop"`
+	Rise        float64 `yaml:"rise"`
+	Months      int     `yaml:"months"`
+	NewHotspots int     `yaml:"new_hotspots"`
+}
+
+// alertSnapshot is one dated result file.
+type alertSnapshot struct {
+	Path     string
+	Time     time.Time
+	Metrics  map[string]float64
+	Hotspots []string
+}
+
+// alertEvent is a fired rule.
+type alertEvent struct {
+	Rule          string   `json:"rule"`
+	Metric        string   `json:"metric,omitempty"`
+	Message       string   `json:"message"`
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Baseline      *float64 `json:"baseline,omitempty"`
+	Value         *float64 `json:"value,omitempty"`
+	ChangePercent *float64 `json:"change_percent,omitempty"`
+	Files         []string `json:"files,omitempty"`
+}
+
+// alertsReport is the JSON printed by "hercules alerts".
+type alertsReport struct {
+	Snapshots int          `json:"snapshots"`
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Alerts    []alertEvent `json:"alerts"`
+}
+
+var alertDateRe = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// loadAlertRules reads and validates the rules file.
+func loadAlertRules(path string) ([]alertRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules alertRules
+	if err := yaml.UnmarshalStrict(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse the rules file %s: %w", path, err)
+	}
+	for i := range rules.Rules {
+		rule := &rules.Rules[i]
+		isMetric := rule.Metric != ""
+		if isMetric == (rule.NewHotspots > 0) {
+			return nil, fmt.Errorf("rule #%d must set either metric or new_hotspots", i+1)
+		}
+		if isMetric && (rule.Drop > 0) == (rule.Rise > 0) {
+			return nil, fmt.Errorf("rule #%d must set either drop or rise", i+1)
+		}
+		if rule.Months < 0 {
+			return nil, fmt.Errorf("rule #%d has negative months", i+1)
+		}
+		if isMetric && !strings.HasPrefix(rule.Metric, "hercules_") {
+			rule.Metric = "hercules_" + rule.Metric
+		}
+		if isMetric && rule.Months == 0 {
+			rule.Months = 1
+		}
+		if rule.Name == "" {
+			switch {
+			case rule.NewHotspots > 0:
+				rule.Name = fmt.Sprintf("new top %d hotspots", rule.NewHotspots)
+			case rule.Drop > 0:
+				rule.Name = fmt.Sprintf("%s dropped %g%% over %d months", rule.Metric, rule.Drop, rule.Months)
+			default:
+				rule.Name = fmt.Sprintf("%s rose %g%% over %d months", rule.Metric, rule.Rise, rule.Months)
+			}
+		}
+	}
+	return rules.Rules, nil
+}
+
+// loadAlertSnapshots reads the result files in the directory and sorts them by date. The date
+// is taken from the file name, e.g. "2024-05-01.pb", or from the end of the analysed history.
+func loadAlertSnapshots(dir string) ([]alertSnapshot, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pb"))
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]alertSnapshot, 0, len(paths))
+	for _, path := range paths {
+		message, err := readResultsFile(path)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := alertSnapshot{Path: path, Metrics: map[string]float64{}}
+		if date := alertDateRe.FindString(filepath.Base(path)); date != "" {
+			snapshot.Time, err = time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date in %s: %w", path, err)
+			}
+		} else if message.Header != nil && message.Header.EndUnixTime != 0 {
+			snapshot.Time = time.Unix(message.Header.EndUnixTime, 0).UTC()
+		} else {
+			return nil, fmt.Errorf("%s is not dated: name it as YYYY-MM-DD.pb", path)
+		}
+		metrics, err := extractSummaryMetrics(message)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		for _, metric := range metrics {
+			snapshot.Metrics[metric.Name] = metric.Value
+		}
+		if payload, exists := message.Contents["HotspotRisk"]; exists {
+			var result pb.HotspotRiskResults
+			if err := proto.Unmarshal(payload, &result); err != nil {
+				return nil, fmt.Errorf("failed to decode HotspotRisk in %s: %w", path, err)
+			}
+			files := append([]*pb.FileRisk{}, result.Files...)
+			sort.SliceStable(files, func(i, j int) bool { return files[i].RiskScore > files[j].RiskScore })
+			snapshot.Hotspots = make([]string, len(files))
+			for i, file := range files {
+				snapshot.Hotspots[i] = file.Path
+			}
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Time.Before(snapshots[j].Time) })
+	return snapshots, nil
+}
+
+// alertBaseline returns the latest snapshot which is at least the given number of months
+// older than the last one. Zero months means the previous snapshot.
+func alertBaseline(snapshots []alertSnapshot, months int) (alertSnapshot, bool) {
+	last := len(snapshots) - 1
+	if last < 1 {
+		return alertSnapshot{}, false
+	}
+	if months == 0 {
+		return snapshots[last-1], true
+	}
+	cutoff := snapshots[last].Time.AddDate(0, -months, 0)
+	for i := last - 1; i >= 0; i-- {
+		if !snapshots[i].Time.After(cutoff) {
+			return snapshots[i], true
+		}
+	}
+	return alertSnapshot{}, false
+}
+
+// evaluateAlerts applies the rules to the snapshots sorted by date. The rules without enough
+// history do not fire.
+func evaluateAlerts(rules []alertRule, snapshots []alertSnapshot) alertsReport {
+	report := alertsReport{Snapshots: len(snapshots), Alerts: []alertEvent{}}
+	if len(snapshots) == 0 {
+		return report
+	}
+	latest := snapshots[len(snapshots)-1]
+	report.From = snapshots[0].Time.Format("2006-01-02")
+	report.To = latest.Time.Format("2006-01-02")
+	for _, rule := range rules {
+		baseline, ok := alertBaseline(snapshots, rule.Months)
+		if !ok {
+			continue
+		}
+		event := alertEvent{
+			Rule: rule.Name, From: baseline.Time.Format("2006-01-02"), To: report.To,
+		}
+		if rule.NewHotspots > 0 {
+			if baseline.Hotspots == nil || latest.Hotspots == nil {
+				continue
+			}
+			previous := map[string]bool{}
+			for _, file := range topStrings(baseline.Hotspots, rule.NewHotspots) {
+				previous[file] = true
+			}
+			for _, file := range topStrings(latest.Hotspots, rule.NewHotspots) {
+				if !previous[file] {
+					event.Files = append(event.Files, file)
+				}
+			}
+			if len(event.Files) == 0 {
+				continue
+			}
+			event.Message = fmt.Sprintf("%d new files in the top %d hotspots: %s",
+				len(event.Files), rule.NewHotspots, strings.Join(event.Files, ", "))
+			report.Alerts = append(report.Alerts, event)
+			continue
+		}
+		before, exists := baseline.Metrics[rule.Metric]
+		after, existsAfter := latest.Metrics[rule.Metric]
+		if !exists || !existsAfter || before == 0 {
+			continue
+		}
+		change := (after - before) / math.Abs(before) * 100
+		if (rule.Drop > 0 && -change < rule.Drop) || (rule.Rise > 0 && change < rule.Rise) {
+			continue
+		}
+		event.Metric = rule.Metric
+		event.Baseline, event.Value, event.ChangePercent = &before, &after, &change
+		direction := "rose"
+		if change < 0 {
+			direction = "dropped"
+		}
+		event.Message = fmt.Sprintf("%s %s by %.1f%% from %g to %g since %s",
+			rule.Metric, direction, math.Abs(change), before, after, event.From)
+		report.Alerts = append(report.Alerts, event)
+	}
+	return report
+}
+
+// topStrings returns the first n elements.
+func topStrings(values []string, n int) []string {
+	if len(values) > n {
+		return values[:n]
+	}
+	return values
+}
+
+// writeAlertsReport prints the report as JSON.
+func writeAlertsReport(writer io.Writer, report alertsReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "%s\n", data)
+	return err
+}
+
+// alertsCmd evaluates the trend rules over the history of the results.
+var alertsCmd = &cobra.Command{
+	Use:   "alerts [flags] <directory>",
+	Short: "Evaluate the trend rules over the dated result files and print the alerts as JSON.",
+	Long: `Reads the Protocol Buffers results in the directory, e.g. produced by a nightly job as
+YYYY-MM-DD.pb, and evaluates the trend rules from --rules over them: a summary metric
+(see "hercules export openmetrics") dropped or rose by the given percentage over the given
+number of months, or new files entered the top hotspots (--hotspot-risk) since the previous result.
+The alerts are printed as JSON. The exit code is 2 if any rule fired, so that CI can react.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		rulesPath, _ := flags.GetString("rules")
+		output, _ := flags.GetString("output")
+		rules, err := loadAlertRules(rulesPath)
+		if err != nil {
+			return err
+		}
+		snapshots, err := loadAlertSnapshots(args[0])
+		if err != nil {
+			return err
+		}
+		if len(snapshots) == 0 {
+			return fmt.Errorf("no result files (*.pb) in %s", args[0])
+		}
+		report := evaluateAlerts(rules, snapshots)
+		if output == "-" {
+			err = writeAlertsReport(os.Stdout, report)
+		} else {
+			var file *os.File
+			if file, err = os.Create(output); err == nil {
+				err = writeAlertsReport(file, report)
+				if closeErr := file.Close(); err == nil {
+					err = closeErr
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if len(report.Alerts) > 0 {
+			os.Exit(alertsExitCode)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(alertsCmd)
+	alertsCmd.SetUsageFunc(alertsCmd.UsageFunc())
+	alertsFlags := alertsCmd.Flags()
+	alertsFlags.String("rules", "", "Path to the YAML file with the trend rules.")
+	alertsFlags.StringP("output", "o", "-", "Path to the JSON file to write; \"-\" prints to stdout.")
+	if err := alertsCmd.MarkFlagRequired("rules"); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func writeAlertRules(t *testing.T, text string) string {
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadAlertRules(t *testing.T) {
+	rules, err := loadAlertRules(writeAlertRules(t, `rules:
+  - metric: bus_factor
+    drop: 25
+    months: 6
+  - name: concentration
+    metric: hercules_ownership_gini
+    rise: 10
+  - new_hotspots: 5
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []alertRule{
+		{Name: "hercules_bus_factor dropped 25% over 6 months", Metric: "hercules_bus_factor", Drop: 25, Months: 6},
+		{Name: "concentration", Metric: "hercules_ownership_gini", Rise: 10, Months: 1},
+		{Name: "new top 5 hotspots", NewHotspots: 5},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Fatalf("unexpected rules: got %v want %v", rules, expected)
+	}
+	for _, text := range []string{
+		"rules:\n  - metric: bus_factor\n",
+		"rules:\n  - metric: bus_factor\n    drop: 1\n    rise: 1\n",
+		"rules:\n  - metric: bus_factor\n    drop: 1\n    new_hotspots: 3\n",
+		"rules:\n  - drop: 1\n",
+		"rules:\n  - metric: bus_factor\n    drop: 1\n    months: -1\n",
+		"rules:\n  - metric: bus_factor\n    fall: 1\n",
+	} {
+		if _, err := loadAlertRules(writeAlertRules(t, text)); err == nil {
+			t.Fatalf("expected an error for %q", text)
+		}
+	}
+}
+
+func TestEvaluateAlerts(t *testing.T) {
+	date := func(value string) time.Time {
+		result, _ := time.Parse("2006-01-02", value)
+		return result
+	}
+	snapshots := []alertSnapshot{
+		{Time: date("2024-01-01"), Metrics: map[string]float64{"hercules_bus_factor": 4},
+			Hotspots: []string{"a.go", "b.go", "c.go"}},
+		{Time: date("2024-04-01"), Metrics: map[string]float64{"hercules_bus_factor": 3},
+			Hotspots: []string{"a.go", "b.go", "c.go"}},
+		{Time: date("2024-07-01"), Metrics: map[string]float64{"hercules_bus_factor": 2},
+			Hotspots: []string{"d.go", "a.go", "c.go", "b.go"}},
+	}
+	rules := []alertRule{
+		{Name: "erosion", Metric: "hercules_bus_factor", Drop: 40, Months: 6},
+		{Name: "quarterly erosion", Metric: "hercules_bus_factor", Drop: 40, Months: 3},
+		{Name: "growth", Metric: "hercules_bus_factor", Rise: 10, Months: 1},
+		{Name: "missing", Metric: "hercules_total_lines", Drop: 10, Months: 1},
+		{Name: "ancient", Metric: "hercules_bus_factor", Drop: 10, Months: 12},
+		{Name: "hotspots", NewHotspots: 2},
+	}
+	report := evaluateAlerts(rules, snapshots)
+	if report.Snapshots != 3 || report.From != "2024-01-01" || report.To != "2024-07-01" {
+		t.Fatalf("unexpected report header: %+v", report)
+	}
+	if len(report.Alerts) != 2 {
+		t.Fatalf("unexpected alerts: %+v", report.Alerts)
+	}
+	erosion := report.Alerts[0]
+	if erosion.Rule != "erosion" || erosion.From != "2024-01-01" || *erosion.Baseline != 4 ||
+		*erosion.Value != 2 || *erosion.ChangePercent != -50 {
+		t.Fatalf("unexpected alert: %+v", erosion)
+	}
+	if !strings.Contains(erosion.Message, "dropped by 50.0%") {
+		t.Fatalf("unexpected message: %s", erosion.Message)
+	}
+	hotspots := report.Alerts[1]
+	if hotspots.Rule != "hotspots" || hotspots.From != "2024-04-01" ||
+		!reflect.DeepEqual(hotspots.Files, []string{"d.go"}) {
+		t.Fatalf("unexpected alert: %+v", hotspots)
+	}
+	if report := evaluateAlerts(rules, nil); len(report.Alerts) != 0 || report.Snapshots != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func TestLoadAlertSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, message *pb.AnalysisResults) {
+		data, err := proto.Marshal(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	later := fakeBundleResults(t)
+	later.Contents["HotspotRisk"] = mustMarshal(t, &pb.HotspotRiskResults{
+		Files: []*pb.FileRisk{{Path: "a.go", RiskScore: 0.25}, {Path: "b.go", RiskScore: 0.75}},
+	})
+	write("nightly-2024-05-01.pb", &later)
+	write("undated.pb", &pb.AnalysisResults{Header: &pb.Metadata{EndUnixTime: 1700000000}})
+	snapshots, err := loadAlertSnapshots(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+	if !snapshots[0].Time.Equal(time.Unix(1700000000, 0)) || snapshots[0].Hotspots != nil {
+		t.Fatalf("unexpected snapshot: %+v", snapshots[0])
+	}
+	if snapshots[1].Time.Format("2006-01-02") != "2024-05-01" ||
+		snapshots[1].Metrics["hercules_bus_factor"] != 2 ||
+		!reflect.DeepEqual(snapshots[1].Hotspots, []string{"b.go", "a.go"}) {
+		t.Fatalf("unexpected snapshot: %+v", snapshots[1])
+	}
+	write("broken.pb", &pb.AnalysisResults{})
+	if _, err := loadAlertSnapshots(dir); err == nil {
+		t.Fatal("expected an error for the undated file")
+	}
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// yamlHeader is the "hercules" section of the YAML results.
+type yamlHeader struct {
+	Version       int32  `yaml:"version"`
+	Hash          string `yaml:"hash"`
+	Repository    string `yaml:"repository"`
+	BeginUnixTime int64  `yaml:"begin_unix_time"`
+	EndUnixTime   int64  `yaml:"end_unix_time"`
+	Commits       int32  `yaml:"commits"`
+	RunTime       int64  `yaml:"run_time"`
+}
+
+// yamlBurndown is the "Burndown" section of the YAML results. The matrices are written as
+// literal blocks of space-separated integers.
+type yamlBurndown struct {
+	Granularity        int32             `yaml:"granularity"`
+	Sampling           int32             `yaml:"sampling"`
+	TickSize           int64             `yaml:"tick_size"`
+	Resample           string            `yaml:"resample"`
+	Project            string            `yaml:"project"`
+	Files              map[string]string `yaml:"files"`
+	FilesOwnership     []map[int32]int32 `yaml:"files_ownership"`
+	DirectoriesDepth   int32             `yaml:"directories_depth"`
+	Directories        map[string]string `yaml:"directories"`
+	Languages          map[string]string `yaml:"languages"`
+	PeopleSequence     []string          `yaml:"people_sequence"`
+	People             map[string]string `yaml:"people"`
+	PeopleInteraction  string            `yaml:"people_interaction"`
+	RepositorySequence []string          `yaml:"repository_sequence"`
+	Repositories       map[string]string `yaml:"repositories"`
+}
+
+// yamlCoocc is the co-occurrence block of the "Couples" section of the YAML results.
+type yamlCoocc struct {
+	Index       []string              `yaml:"index"`
+	Lines       []int32               `yaml:"lines"`
+	Matrix      []map[int]int64       `yaml:"matrix"`
+	AuthorFiles []map[string][]string `yaml:"author_files"`
+}
+
+// yamlCouples is the "Couples" section of the YAML results.
+type yamlCouples struct {
+	FilesCoocc  yamlCoocc `yaml:"files_coocc"`
+	PeopleCoocc yamlCoocc `yaml:"people_coocc"`
+}
+
+// yamlDevs is the "Devs" section of the YAML results. Old versions of hercules called the
+// ticks "days" and did not write the languages and the tick size.
+type yamlDevs struct {
+	Ticks    map[int32]map[int32][]interface{} `yaml:"ticks"`
+	Days     map[int32]map[int32][]interface{} `yaml:"days"`
+	People   []string                          `yaml:"people"`
+	TickSize int64                             `yaml:"tick_size"`
+}
+
+// yamlResults is the whole YAML document written by hercules without --pb.
+type yamlResults struct {
+	Header   *yamlHeader            `yaml:"hercules"`
+	Burndown *yamlBurndown          `yaml:"Burndown"`
+	Couples  *yamlCouples           `yaml:"Couples"`
+	Devs     *yamlDevs              `yaml:"Devs"`
+	Other    map[string]interface{} `yaml:",inline"`
+}
+
+// parseYAMLMatrix parses a dense integer matrix written by yaml.PrintMatrix.
+func parseYAMLMatrix(text string) ([][]int64, error) {
+	var matrix [][]int64
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		row := make([]int64, len(fields))
+		for i, field := range fields {
+			val, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid matrix element %q: %w", field, err)
+			}
+			row[i] = val
+		}
+		matrix = append(matrix, row)
+	}
+	return matrix, nil
+}
+
+// yamlTickSize converts the tick size in seconds to the nanoseconds stored in Protocol Buffers.
+// Old results which do not specify the tick size used days.
+func yamlTickSize(seconds int64) int64 {
+	if seconds <= 0 {
+		return int64(24 * time.Hour)
+	}
+	return seconds * int64(time.Second)
+}
+
+func (header *yamlHeader) convert() *pb.Metadata {
+	return &pb.Metadata{
+		Version:       header.Version,
+		Hash:          header.Hash,
+		Repository:    header.Repository,
+		BeginUnixTime: header.BeginUnixTime,
+		EndUnixTime:   header.EndUnixTime,
+		Commits:       header.Commits,
+		RunTime:       header.RunTime,
+	}
+}
+
+func (section *yamlBurndown) convert() (proto.Message, error) {
+	message := &pb.BurndownAnalysisResults{
+		Granularity: section.Granularity,
+		Sampling:    section.Sampling,
+		TickSize:    yamlTickSize(section.TickSize),
+		Resample:    section.Resample,
+	}
+	toSparse := func(text, name string) (*pb.BurndownSparseMatrix, error) {
+		matrix, err := parseYAMLMatrix(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if len(matrix) == 0 {
+			return nil, nil
+		}
+		return pb.ToBurndownSparseMatrix(matrix, name), nil
+	}
+	var err error
+	if message.Project, err = toSparse(section.Project, "project"); err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(section.Files))
+	for file := range section.Files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for i, file := range files {
+		matrix, err := toSparse(section.Files[file], file)
+		if err != nil {
+			return nil, err
+		}
+		if matrix == nil {
+			continue
+		}
+		message.Files = append(message.Files, matrix)
+		ownership := &pb.FilesOwnership{Value: map[int32]int32{}}
+		if i < len(section.FilesOwnership) {
+			ownership.Value = section.FilesOwnership[i]
+		}
+		message.FilesOwnership = append(message.FilesOwnership, ownership)
+	}
+	// toSparseSorted converts the matrices in the order of their names and skips the empty ones
+	toSparseSorted := func(matrices map[string]string) ([]*pb.BurndownSparseMatrix, error) {
+		names := make([]string, 0, len(matrices))
+		for name := range matrices {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var result []*pb.BurndownSparseMatrix
+		for _, name := range names {
+			matrix, err := toSparse(matrices[name], name)
+			if err != nil {
+				return nil, err
+			}
+			if matrix != nil {
+				result = append(result, matrix)
+			}
+		}
+		return result, nil
+	}
+	if message.Directories, err = toSparseSorted(section.Directories); err != nil {
+		return nil, err
+	}
+	if len(message.Directories) > 0 {
+		message.DirectoriesDepth = section.DirectoriesDepth
+	}
+	if message.Languages, err = toSparseSorted(section.Languages); err != nil {
+		return nil, err
+	}
+	if len(section.PeopleSequence) > 0 {
+		message.People = make([]*pb.BurndownSparseMatrix, len(section.PeopleSequence))
+		for i, person := range section.PeopleSequence {
+			if message.People[i], err = toSparse(section.People[person], person); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(section.RepositorySequence) > 0 {
+		message.RepositorySequence = section.RepositorySequence
+		message.Repositories = make([]*pb.BurndownSparseMatrix, len(section.RepositorySequence))
+		for i, repository := range section.RepositorySequence {
+			if message.Repositories[i], err = toSparse(section.Repositories[repository], repository); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if section.PeopleInteraction != "" {
+		matrix, err := parseYAMLMatrix(section.PeopleInteraction)
+		if err != nil {
+			return nil, fmt.Errorf("people_interaction: %w", err)
+		}
+		if len(matrix) > 0 {
+			message.PeopleInteraction = pb.DenseToCompressedSparseRowMatrix(matrix)
+		}
+	}
+	return message, nil
+}
+
+func (section *yamlCouples) convert() (proto.Message, error) {
+	message := &pb.CouplesAnalysisResults{
+		FileCouples: &pb.Couples{
+			Index:  section.FilesCoocc.Index,
+			Matrix: pb.MapToCompressedSparseRowMatrix(section.FilesCoocc.Matrix),
+		},
+		PeopleCouples: &pb.Couples{
+			Index:  section.PeopleCoocc.Index,
+			Matrix: pb.MapToCompressedSparseRowMatrix(section.PeopleCoocc.Matrix),
+		},
+		FilesLines: section.FilesCoocc.Lines,
+	}
+	fileIndex := map[string]int32{}
+	for i, file := range section.FilesCoocc.Index {
+		fileIndex[file] = int32(i)
+	}
+	authorFiles := map[string][]string{}
+	for _, item := range section.PeopleCoocc.AuthorFiles {
+		for author, files := range item {
+			authorFiles[author] = files
+		}
+	}
+	message.PeopleFiles = make([]*pb.TouchedFiles, len(section.PeopleCoocc.Index))
+	for i, author := range section.PeopleCoocc.Index {
+		touched := &pb.TouchedFiles{Files: []int32{}}
+		for _, file := range authorFiles[author] {
+			index, exists := fileIndex[file]
+			if !exists {
+				return nil, fmt.Errorf("author_files: %s is not in files_coocc", file)
+			}
+			touched.Files = append(touched.Files, index)
+		}
+		sort.Slice(touched.Files, func(i, j int) bool { return touched.Files[i] < touched.Files[j] })
+		message.PeopleFiles[i] = touched
+	}
+	return message, nil
+}
+
+// yamlLineStats converts the [added, removed, changed] YAML sequence.
+func yamlLineStats(values []interface{}) (*pb.LineStats, error) {
+	if len(values) != 3 {
+		return nil, fmt.Errorf("expected [added, removed, changed], got %v", values)
+	}
+	var numbers [3]int32
+	for i, value := range values {
+		number, ok := value.(int)
+		if !ok {
+			return nil, fmt.Errorf("%v is not an integer", value)
+		}
+		numbers[i] = int32(number)
+	}
+	return &pb.LineStats{Added: numbers[0], Removed: numbers[1], Changed: numbers[2]}, nil
+}
+
+func (section *yamlDevs) convert() (proto.Message, error) {
+	ticks := section.Ticks
+	if ticks == nil {
+		ticks = section.Days
+	}
+	message := &pb.DevsAnalysisResults{
+		Ticks:    map[int32]*pb.TickDevs{},
+		DevIndex: section.People,
+		TickSize: yamlTickSize(section.TickSize),
+	}
+	for tick, devs := range ticks {
+		tickDevs := &pb.TickDevs{Devs: map[int32]*pb.DevTick{}}
+		message.Ticks[tick] = tickDevs
+		for dev, values := range devs {
+			if len(values) < 4 {
+				return nil, fmt.Errorf("tick %d, developer %d: expected at least 4 values, got %v",
+					tick, dev, values)
+			}
+			commits, ok := values[0].(int)
+			if !ok {
+				return nil, fmt.Errorf("tick %d, developer %d: invalid number of commits %v",
+					tick, dev, values[0])
+			}
+			stats, err := yamlLineStats(values[1:4])
+			if err != nil {
+				return nil, fmt.Errorf("tick %d, developer %d: %w", tick, dev, err)
+			}
+			devTick := &pb.DevTick{
+				Commits: int32(commits), Stats: stats, Languages: map[string]*pb.LineStats{},
+			}
+			if len(values) > 4 {
+				languages, _ := values[4].(map[interface{}]interface{})
+				for lang, langValues := range languages {
+					list, _ := langValues.([]interface{})
+					langStats, err := yamlLineStats(list)
+					if err != nil {
+						return nil, fmt.Errorf("tick %d, developer %d, %v: %w", tick, dev, lang, err)
+					}
+					name := fmt.Sprint(lang)
+					if name == "none" {
+						name = ""
+					}
+					devTick.Languages[name] = langStats
+				}
+			}
+			tickDevs.Devs[dev] = devTick
+		}
+	}
+	return message, nil
+}
+
+// convertYAMLResults parses the YAML results and converts the supported sections to Protocol
+// Buffers. The names of the skipped sections are returned as the second value.
+func convertYAMLResults(data []byte) (*pb.AnalysisResults, []string, error) {
+	var parsed yamlResults
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the YAML results: %w", err)
+	}
+	if parsed.Header == nil {
+		return nil, nil, fmt.Errorf("the \"hercules\" header section is missing")
+	}
+	message := &pb.AnalysisResults{
+		Header:   parsed.Header.convert(),
+		Contents: map[string][]byte{},
+	}
+	sections := map[string]interface {
+		convert() (proto.Message, error)
+	}{}
+	if parsed.Burndown != nil {
+		sections["Burndown"] = parsed.Burndown
+	}
+	if parsed.Couples != nil {
+		sections["Couples"] = parsed.Couples
+	}
+	if parsed.Devs != nil {
+		sections["Devs"] = parsed.Devs
+	}
+	for name, section := range sections {
+		converted, err := section.convert()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if message.Contents[name], err = proto.Marshal(converted); err != nil {
+			return nil, nil, err
+		}
+	}
+	skipped := make([]string, 0, len(parsed.Other))
+	for name := range parsed.Other {
+		skipped = append(skipped, name)
+	}
+	sort.Strings(skipped)
+	return message, skipped, nil
+}
+
+// backfillCmd converts the YAML results to Protocol Buffers.
+var backfillCmd = &cobra.Command{
+	Use:   "backfill [flags] <results.yaml>",
+	Short: "Convert the YAML analysis results to Protocol Buffers.",
+	Long: `Converts the YAML output of hercules (without --pb) to the current Protocol Buffers
+schema so that historical archives can be combined and diffed with new runs. The header,
+Burndown, Couples and Devs sections are supported, including the old schemas without the tick
+size and with "days" instead of "ticks". Other sections are skipped with a warning.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			return err
+		}
+		message, skipped, err := convertYAMLResults(data)
+		if err != nil {
+			return err
+		}
+		for _, name := range skipped {
+			fmt.Fprintf(os.Stderr, "warning: skipped the unsupported section %s\n", name)
+		}
+		serialized, err := proto.Marshal(message)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(serialized)
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+	backfillCmd.SetUsageFunc(backfillCmd.UsageFunc())
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+const backfillYAML = `hercules:
+  version: 2
+  hash: abcdef
+  repository: https://github.com/test/test
+  begin_unix_time: 1000
+  end_unix_time: 2000
+  commits: 3
+  run_time: 100
+Burndown:
+  granularity: 30
+  sampling: 30
+  tick_size: 86400
+  "project": |-
+   10  0
+    8  5
+  files:
+    "a.go": |-
+     4 0
+     3 2
+  files_ownership:
+    - 0: 4
+      1: 1
+  directories_depth: 1
+  directories:
+    "/": |-
+     4 0
+     3 2
+    "src": |-
+     6 0
+     5 3
+  languages:
+    "Go": |-
+     10  0
+      8  5
+  people_sequence:
+    - "alice"
+    - "bob"
+  people:
+    "alice": |-
+     10 0
+      8 0
+    "bob": |-
+     0 0
+     0 5
+  people_interaction: |-
+    10  0 -2  0
+     0  5  0  0
+  repository_sequence:
+    - "upstream"
+    - "fork"
+  repositories:
+    "fork": |-
+     0 0
+     0 5
+    "upstream": |-
+     10 0
+      8 0
+Couples:
+  files_coocc:
+    index:
+      - "a.go"
+      - "b.go"
+    lines:
+      - 5
+      - 7
+    matrix:
+      - {0: 2, 1: 1}
+      - {0: 1, 1: 1}
+  people_coocc:
+    index:
+      - "alice"
+      - "bob"
+    matrix:
+      - {0: 2}
+      - {1: 1}
+    author_files:
+      - "alice":
+        - "a.go"
+        - "b.go"
+      - "bob":
+        - "a.go"
+Devs:
+  ticks:
+    0:
+      0: [1, 10, 0, 0, {Go: [10, 0, 0]}]
+    2:
+      -1: [2, 3, 1, 1, {none: [3, 1, 1]}]
+  people:
+  - "alice"
+  tick_size: 86400
+Shotness:
+  - name: main
+`
+
+func TestConvertYAMLResults(t *testing.T) {
+	message, skipped, err := convertYAMLResults([]byte(backfillYAML))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(skipped, []string{"Shotness"}) {
+		t.Fatalf("unexpected skipped sections: %v", skipped)
+	}
+	if message.Header.Repository != "https://github.com/test/test" || message.Header.Commits != 3 {
+		t.Fatalf("unexpected header: %v", message.Header)
+	}
+
+	var burndown pb.BurndownAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Burndown"], &burndown); err != nil {
+		t.Fatal(err)
+	}
+	if burndown.TickSize != int64(24*time.Hour) || burndown.Granularity != 30 {
+		t.Fatalf("unexpected burndown parameters: %v", burndown)
+	}
+	if burndown.Project.NumberOfRows != 2 || burndown.Project.NumberOfColumns != 2 ||
+		!reflect.DeepEqual(burndown.Project.Rows[1].Columns, []uint32{8, 5}) {
+		t.Fatalf("unexpected project: %v", burndown.Project)
+	}
+	if len(burndown.Files) != 1 || burndown.Files[0].Name != "a.go" ||
+		!reflect.DeepEqual(burndown.FilesOwnership[0].Value, map[int32]int32{0: 4, 1: 1}) {
+		t.Fatalf("unexpected files: %v %v", burndown.Files, burndown.FilesOwnership)
+	}
+	if len(burndown.People) != 2 || burndown.People[1].Name != "bob" {
+		t.Fatalf("unexpected people: %v", burndown.People)
+	}
+	if !reflect.DeepEqual(burndown.PeopleInteraction.Data, []int64{10, -2, 5}) {
+		t.Fatalf("unexpected people interaction: %v", burndown.PeopleInteraction)
+	}
+	if burndown.DirectoriesDepth != 1 || len(burndown.Directories) != 2 ||
+		burndown.Directories[0].Name != "/" || burndown.Directories[1].Name != "src" ||
+		!reflect.DeepEqual(burndown.Directories[1].Rows[1].Columns, []uint32{5, 3}) {
+		t.Fatalf("unexpected directories: %v", burndown.Directories)
+	}
+	if len(burndown.Languages) != 1 || burndown.Languages[0].Name != "Go" {
+		t.Fatalf("unexpected languages: %v", burndown.Languages)
+	}
+	if !reflect.DeepEqual(burndown.RepositorySequence, []string{"upstream", "fork"}) ||
+		len(burndown.Repositories) != 2 || burndown.Repositories[0].Name != "upstream" ||
+		!reflect.DeepEqual(burndown.Repositories[1].Rows[1].Columns, []uint32{0, 5}) {
+		t.Fatalf("unexpected repositories: %v %v", burndown.RepositorySequence, burndown.Repositories)
+	}
+
+	var couples pb.CouplesAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Couples"], &couples); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(couples.FilesLines, []int32{5, 7}) ||
+		!reflect.DeepEqual(couples.FileCouples.Matrix.Data, []int64{2, 1, 1, 1}) {
+		t.Fatalf("unexpected file couples: %v", couples)
+	}
+	if !reflect.DeepEqual(couples.PeopleFiles[0].Files, []int32{0, 1}) ||
+		!reflect.DeepEqual(couples.PeopleFiles[1].Files, []int32{0}) {
+		t.Fatalf("unexpected people files: %v", couples.PeopleFiles)
+	}
+
+	var devs pb.DevsAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Devs"], &devs); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(devs.DevIndex, []string{"alice"}) {
+		t.Fatalf("unexpected dev index: %v", devs.DevIndex)
+	}
+	missing := devs.Ticks[2].Devs[-1]
+	if missing.Commits != 2 || missing.Stats.Changed != 1 || missing.Languages[""].Added != 3 {
+		t.Fatalf("unexpected devs: %v", devs.Ticks)
+	}
+}
+
+func TestConvertYAMLResultsLegacyDevs(t *testing.T) {
+	message, _, err := convertYAMLResults([]byte(`hercules:
+  version: 1
+Devs:
+  days:
+    1:
+      0: [4, 1, 2, 3]
+  people:
+  - "alice"
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var devs pb.DevsAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Devs"], &devs); err != nil {
+		t.Fatal(err)
+	}
+	if devs.TickSize != int64(24*time.Hour) {
+		t.Fatalf("unexpected tick size: %d", devs.TickSize)
+	}
+	stats := devs.Ticks[1].Devs[0]
+	if stats.Commits != 4 || stats.Stats.Added != 1 || stats.Stats.Removed != 2 {
+		t.Fatalf("unexpected stats: %v", stats)
+	}
+}
+
+func TestConvertYAMLResultsErrors(t *testing.T) {
+	if _, _, err := convertYAMLResults([]byte("Devs: {}\n")); err == nil {
+		t.Fatal("expected an error for the missing header")
+	}
+	_, _, err := convertYAMLResults([]byte("hercules: {}\nDevs:\n  ticks:\n    0:\n      0: [1]\n"))
+	if err == nil {
+		t.Fatal("expected an error for the truncated developer stats")
+	}
+	_, _, err = convertYAMLResults([]byte("hercules: {}\nBurndown:\n  project: |-\n    1 x\n"))
+	if err == nil {
+		t.Fatal("expected an error for the invalid matrix")
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+)
+
+// codemapRootDirectory is the name of the section with the files in the repository root.
+const codemapRootDirectory = "/"
+
+// codemapOwner is a developer who owns some of the alive lines in a directory.
+type codemapOwner struct {
+	Name  string
+	Lines int
+}
+
+// codemapHotspot is a file with its hotspot risk score.
+type codemapHotspot struct {
+	Path  string
+	Score float64
+}
+
+// codemapDirectory summarizes a top-level directory of the repository. The zero values mean
+// that the corresponding analysis is missing in the results.
+type codemapDirectory struct {
+	Path          string
+	Files         int
+	Lines         int
+	Owners        []codemapOwner
+	BusFactor     int
+	Editors       int
+	RecentEditors int
+	Hotspots      []codemapHotspot
+}
+
+// codemap is the orientation document generated by writeCodemap().
+type codemap struct {
+	Repository   string
+	Commits      int32
+	Begin, End   time.Time
+	WindowMonths int32
+	Directories  []*codemapDirectory
+}
+
+// topLevelDirectory returns the first component of the file path or codemapRootDirectory.
+func topLevelDirectory(path string) string {
+	if pos := strings.IndexByte(path, '/'); pos > 0 {
+		return path[:pos]
+	}
+	return codemapRootDirectory
+}
+
+// codemapBusFactor returns the smallest number of owners who own at least `threshold`
+// of the lines. The owners must be sorted by the number of lines in descending order.
+func codemapBusFactor(owners []codemapOwner, lines int, threshold float64) int {
+	covered := 0
+	for i, owner := range owners {
+		covered += owner.Lines
+		if float64(covered) >= threshold*float64(lines) {
+			return i + 1
+		}
+	}
+	return len(owners)
+}
+
+// buildCodemap groups the per-file results of Burndown (files ownership), BusFactor,
+// KnowledgeDiffusion and HotspotRisk by the top-level directories. At least one
+// of them must be present.
+func buildCodemap(message pb.AnalysisResults, maxOwners, maxHotspots int) (*codemap, error) {
+	result := &codemap{}
+	if message.Header != nil {
+		result.Repository = message.Header.Repository
+		result.Commits = message.Header.Commits
+		result.Begin = time.Unix(message.Header.BeginUnixTime, 0).UTC()
+		result.End = time.Unix(message.Header.EndUnixTime, 0).UTC()
+	}
+	directories := map[string]*codemapDirectory{}
+	directory := func(path string) *codemapDirectory {
+		name := topLevelDirectory(path)
+		dir := directories[name]
+		if dir == nil {
+			dir = &codemapDirectory{Path: name}
+			directories[name] = dir
+		}
+		return dir
+	}
+	decode := func(key string, msg proto.Message) (bool, error) {
+		payload, exists := message.Contents[key]
+		if !exists {
+			return false, nil
+		}
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return false, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		return true, nil
+	}
+
+	threshold := 0.8
+	var busFactor pb.BusFactorAnalysisResults
+	hasBusFactor, err := decode("BusFactor", &busFactor)
+	if err != nil {
+		return nil, err
+	}
+	if hasBusFactor && busFactor.Threshold > 0 {
+		threshold = float64(busFactor.Threshold)
+	}
+
+	var burndown pb.BurndownAnalysisResults
+	hasBurndown, err := decode("Burndown", &burndown)
+	if err != nil {
+		return nil, err
+	}
+	hasOwnership := hasBurndown && len(burndown.FilesOwnership) > 0
+	if hasOwnership {
+		ownership := map[string]map[string]int{}
+		for i, file := range burndown.Files {
+			if i >= len(burndown.FilesOwnership) {
+				break
+			}
+			dir := directory(file.Name)
+			dir.Files++
+			if ownership[dir.Path] == nil {
+				ownership[dir.Path] = map[string]int{}
+			}
+			for author, lines := range burndown.FilesOwnership[i].Value {
+				dir.Lines += int(lines)
+				name := core.AuthorMissingName
+				if int(author) < len(burndown.People) {
+					name = core.IdentityLabel(burndown.People[author].Name)
+				} else if author != core.AuthorMissing {
+					name = fmt.Sprintf("developer #%d", author)
+				}
+				ownership[dir.Path][name] += int(lines)
+			}
+		}
+		for path, owners := range ownership {
+			dir := directories[path]
+			for name, lines := range owners {
+				if lines > 0 {
+					dir.Owners = append(dir.Owners, codemapOwner{Name: name, Lines: lines})
+				}
+			}
+			sort.Slice(dir.Owners, func(i, j int) bool {
+				if dir.Owners[i].Lines != dir.Owners[j].Lines {
+					return dir.Owners[i].Lines > dir.Owners[j].Lines
+				}
+				return dir.Owners[i].Name < dir.Owners[j].Name
+			})
+			dir.BusFactor = codemapBusFactor(dir.Owners, dir.Lines, threshold)
+			if len(dir.Owners) > maxOwners {
+				dir.Owners = dir.Owners[:maxOwners]
+			}
+		}
+	} else if hasBusFactor {
+		// the per-directory values are the only source, so take the worst of the subdirectories
+		for path, value := range busFactor.SubsystemBusFactor {
+			// the keys are the directories, not the files
+			dir := directory(path + "/")
+			if dir.BusFactor == 0 || int(value) < dir.BusFactor {
+				dir.BusFactor = int(value)
+			}
+		}
+	}
+
+	var diffusion pb.KnowledgeDiffusionResults
+	hasDiffusion, err := decode("KnowledgeDiffusion", &diffusion)
+	if err != nil {
+		return nil, err
+	}
+	if hasDiffusion {
+		result.WindowMonths = diffusion.WindowMonths
+		editors := map[string]map[int32]bool{}
+		for path, file := range diffusion.Files {
+			dir := directory(path)
+			if !hasOwnership {
+				dir.Files++
+			}
+			if editors[dir.Path] == nil {
+				editors[dir.Path] = map[int32]bool{}
+			}
+			for _, author := range file.Authors {
+				editors[dir.Path][author] = true
+			}
+			// the recent editors are counted per file, so this is the lower bound
+			if int(file.RecentEditorsCount) > dir.RecentEditors {
+				dir.RecentEditors = int(file.RecentEditorsCount)
+			}
+		}
+		for path, authors := range editors {
+			directories[path].Editors = len(authors)
+		}
+	}
+
+	var hotspots pb.HotspotRiskResults
+	hasHotspots, err := decode("HotspotRisk", &hotspots)
+	if err != nil {
+		return nil, err
+	}
+	if hasHotspots {
+		files := append([]*pb.FileRisk(nil), hotspots.Files...)
+		sort.SliceStable(files, func(i, j int) bool {
+			return files[i].RiskScore > files[j].RiskScore
+		})
+		for _, file := range files {
+			dir := directory(file.Path)
+			if len(dir.Hotspots) < maxHotspots {
+				dir.Hotspots = append(dir.Hotspots, codemapHotspot{Path: file.Path, Score: file.RiskScore})
+			}
+		}
+	}
+
+	if !hasOwnership && !hasBusFactor && !hasDiffusion && !hasHotspots {
+		return nil, fmt.Errorf("the results do not contain any of Burndown with --burndown-files, " +
+			"BusFactor, KnowledgeDiffusion or HotspotRisk")
+	}
+	for _, dir := range directories {
+		result.Directories = append(result.Directories, dir)
+	}
+	sort.Slice(result.Directories, func(i, j int) bool {
+		return result.Directories[i].Path < result.Directories[j].Path
+	})
+	return result, nil
+}
+
+// writeCodemap prints the code map in Markdown, one section per top-level directory.
+func writeCodemap(writer io.Writer, result *codemap) error {
+	var builder strings.Builder
+	title := "Code map"
+	if result.Repository != "" {
+		title += " of " + result.Repository
+	}
+	fmt.Fprintf(&builder, "# %s\n\n", title)
+	fmt.Fprintf(&builder, "Generated by `hercules codemap` from the analysis of %d commits", result.Commits)
+	if !result.Begin.IsZero() && result.End.After(result.Begin) {
+		fmt.Fprintf(&builder, " between %s and %s", result.Begin.Format("2006-01-02"),
+			result.End.Format("2006-01-02"))
+	}
+	builder.WriteString(".\n")
+	for _, dir := range result.Directories {
+		name := dir.Path
+		if name == codemapRootDirectory {
+			name = "(root)"
+		}
+		fmt.Fprintf(&builder, "\n## `%s`\n\n", name)
+		if dir.Files > 0 {
+			fmt.Fprintf(&builder, "- **Files:** %d", dir.Files)
+			if dir.Lines > 0 {
+				fmt.Fprintf(&builder, " (%d lines)", dir.Lines)
+			}
+			builder.WriteString("\n")
+		}
+		if len(dir.Owners) > 0 {
+			owners := make([]string, len(dir.Owners))
+			for i, owner := range dir.Owners {
+				owners[i] = fmt.Sprintf("%s (%d%%)", owner.Name, owner.Lines*100/dir.Lines)
+			}
+			fmt.Fprintf(&builder, "- **Top owners:** %s\n", strings.Join(owners, ", "))
+		}
+		if dir.BusFactor > 0 {
+			fmt.Fprintf(&builder, "- **Bus factor:** %d\n", dir.BusFactor)
+		}
+		if dir.Editors > 0 {
+			fmt.Fprintf(&builder, "- **Editors:** %d in total", dir.Editors)
+			if dir.RecentEditors > 0 {
+				fmt.Fprintf(&builder, ", at least %d active in the last %d months", dir.RecentEditors,
+					result.WindowMonths)
+			}
+			builder.WriteString("\n")
+		}
+		if len(dir.Hotspots) > 0 {
+			hotspots := make([]string, len(dir.Hotspots))
+			for i, hotspot := range dir.Hotspots {
+				hotspots[i] = fmt.Sprintf("`%s` (%.2f)", hotspot.Path, hotspot.Score)
+			}
+			fmt.Fprintf(&builder, "- **Hotspots:** %s\n", strings.Join(hotspots, ", "))
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// codemapCmd generates the CODEMAP.md orientation document from the analysis results.
+var codemapCmd = &cobra.Command{
+	Use:   "codemap [flags] <results.pb>",
+	Short: "Generate CODEMAP.md with the owners, editors, bus factor and hotspots of each directory.",
+	Long: `Summarizes each top-level directory of the analysed repository in a Markdown document
+for newcomers: the top owners of the alive lines and the bus factor (--burndown --burndown-files
+--burndown-people), the number of editors (--knowledge-diffusion) and the riskiest files
+(--hotspot-risk). Without the files ownership, the bus factor is the lowest among the subdirectories
+(--bus-factor). The sections of the missing analyses are omitted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		output, _ := flags.GetString("output")
+		maxOwners, _ := flags.GetInt("owners")
+		maxHotspots, _ := flags.GetInt("hotspots")
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		result, err := buildCodemap(message, maxOwners, maxHotspots)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeCodemap(os.Stdout, result)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeCodemap(file, result); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(codemapCmd)
+	codemapCmd.SetUsageFunc(codemapCmd.UsageFunc())
+	codemapFlags := codemapCmd.Flags()
+	codemapFlags.StringP("output", "o", "CODEMAP.md", "Path to the Markdown file to write; \"-\" prints to stdout.")
+	codemapFlags.Int("owners", 3, "Maximum number of the top owners of each directory.")
+	codemapFlags.Int("hotspots", 3, "Maximum number of the hotspot files of each directory.")
+}
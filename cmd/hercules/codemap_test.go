@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func TestTopLevelDirectory(t *testing.T) {
+	for path, expected := range map[string]string{
+		"README.md":       codemapRootDirectory,
+		"cmd/main.go":     "cmd",
+		"internal/a/b.go": "internal",
+		"/":               codemapRootDirectory,
+	} {
+		if dir := topLevelDirectory(path); dir != expected {
+			t.Fatalf("%s: expected %s, got %s", path, expected, dir)
+		}
+	}
+}
+
+func TestBuildCodemap(t *testing.T) {
+	message := pb.AnalysisResults{
+		Header: &pb.Metadata{Repository: "repo", Commits: 10, BeginUnixTime: 1577836800, EndUnixTime: 1609459200},
+		Contents: map[string][]byte{
+			"Burndown": mustMarshal(t, &pb.BurndownAnalysisResults{
+				Files: []*pb.BurndownSparseMatrix{{Name: "README.md"}, {Name: "src/a.go"}, {Name: "src/b/c.go"}},
+				FilesOwnership: []*pb.FilesOwnership{
+					{Value: map[int32]int32{0: 10}},
+					{Value: map[int32]int32{0: 50, 1: 30}},
+					{Value: map[int32]int32{1: 10, 2: 10}},
+				},
+				People: []*pb.BurndownSparseMatrix{
+					{Name: "alice|alice@example.com"}, {Name: "bob|bob@example.com"}, {Name: "carol"},
+				},
+			}),
+			"KnowledgeDiffusion": mustMarshal(t, &pb.KnowledgeDiffusionResults{
+				WindowMonths: 6,
+				Files: map[string]*pb.KnowledgeDiffusionFileData{
+					"src/a.go":   {Authors: []int32{0, 1}, RecentEditorsCount: 1},
+					"src/b/c.go": {Authors: []int32{1, 2}, RecentEditorsCount: 2},
+				},
+			}),
+			"HotspotRisk": mustMarshal(t, &pb.HotspotRiskResults{Files: []*pb.FileRisk{
+				{Path: "src/a.go", RiskScore: 0.5},
+				{Path: "src/b/c.go", RiskScore: 0.9},
+			}}),
+		},
+	}
+	result, err := buildCodemap(message, 2, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Directories) != 2 {
+		t.Fatalf("unexpected directories: %v", result.Directories)
+	}
+	src := result.Directories[1]
+	if src.Path != "src" || src.Files != 2 || src.Lines != 100 || src.BusFactor != 2 ||
+		src.Editors != 3 || src.RecentEditors != 2 {
+		t.Fatalf("unexpected src: %+v", src)
+	}
+	if len(src.Owners) != 2 || src.Owners[0] != (codemapOwner{"alice", 50}) ||
+		src.Owners[1] != (codemapOwner{"bob", 40}) {
+		t.Fatalf("unexpected owners: %v", src.Owners)
+	}
+	if len(src.Hotspots) != 1 || src.Hotspots[0].Path != "src/b/c.go" {
+		t.Fatalf("unexpected hotspots: %v", src.Hotspots)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writeCodemap(buffer, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "# Code map of repo\n\n" +
+		"Generated by `hercules codemap` from the analysis of 10 commits between 2020-01-01 and 2021-01-01.\n" +
+		"\n## `(root)`\n\n" +
+		"- **Files:** 1 (10 lines)\n" +
+		"- **Top owners:** alice (100%)\n" +
+		"- **Bus factor:** 1\n" +
+		"\n## `src`\n\n" +
+		"- **Files:** 2 (100 lines)\n" +
+		"- **Top owners:** alice (50%), bob (40%)\n" +
+		"- **Bus factor:** 2\n" +
+		"- **Editors:** 3 in total, at least 2 active in the last 6 months\n" +
+		"- **Hotspots:** `src/b/c.go` (0.90)\n"
+	if buffer.String() != expected {
+		t.Fatalf("unexpected code map:\n%s", buffer.String())
+	}
+}
+
+func TestBuildCodemapBusFactorOnly(t *testing.T) {
+	message := pb.AnalysisResults{Contents: map[string][]byte{
+		"BusFactor": mustMarshal(t, &pb.BusFactorAnalysisResults{SubsystemBusFactor: map[string]int32{
+			"/": 1, "src": 3, "src/b": 2,
+		}}),
+	}}
+	result, err := buildCodemap(message, 3, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Directories) != 2 || result.Directories[0].BusFactor != 1 ||
+		result.Directories[1].Path != "src" || result.Directories[1].BusFactor != 2 {
+		t.Fatalf("unexpected directories: %+v %+v", result.Directories[0], result.Directories[1])
+	}
+
+	if _, err := buildCodemap(pb.AnalysisResults{}, 3, 3); err == nil {
+		t.Fatal("expected an error without the supported analyses")
+	}
+}
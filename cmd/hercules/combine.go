@@ -5,7 +5,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"os"
 	"sort"
@@ -71,16 +70,10 @@ var combineCmd = &cobra.Command{
 		//		debug.SetGCPercent(20)
 		for _, fileName = range files {
 			bar.Increment()
-			anotherResults, anotherMetadata, repoName, errs := loadMessage(fileName, &repos)
+			anotherResults, anotherMetadata, repoName, errs := loadMessage(fileName, &repos, only)
 			if anotherMetadata != nil {
-				// Initialize repository tracking for the first file or if not already set
-				if burndownResult, ok := anotherResults["Burndown"].(leaves.BurndownResult); ok {
-					if len(burndownResult.RepositoryHistories) == 0 && len(burndownResult.GlobalHistory) > 0 {
-						// This result doesn't have repository tracking yet, initialize it
-						burndownResult.ReversedRepositoryDict = []string{repoName}
-						burndownResult.RepositoryHistories = []burndown.DenseHistory{burndownResult.GlobalHistory}
-						anotherResults["Burndown"] = burndownResult
-					}
+				if result, exists := anotherResults["Burndown"]; exists {
+					anotherResults["Burndown"] = trackBurndownRepository(result, repoName)
 				}
 				mergeErrs := mergeResults(mergedResults, mergedMetadata, anotherResults, anotherMetadata, only)
 				for _, err := range mergeErrs {
@@ -123,7 +116,21 @@ var combineCmd = &cobra.Command{
 	},
 }
 
-func loadMessage(fileName string, repos *[]string) (
+// trackBurndownRepository initializes the per-repository history of the burndown result which
+// does not have it yet, so that the merged result keeps the history of each repository.
+func trackBurndownRepository(result interface{}, repoName string) interface{} {
+	burndownResult, ok := result.(leaves.BurndownResult)
+	if !ok || len(burndownResult.RepositoryHistories) > 0 || len(burndownResult.GlobalHistory) == 0 {
+		return result
+	}
+	burndownResult.ReversedRepositoryDict = []string{repoName}
+	burndownResult.RepositoryHistories = []burndown.DenseHistory{burndownResult.GlobalHistory}
+	return burndownResult
+}
+
+// loadMessage deserializes the analysis results in the file. If only is not empty, the other
+// analyses are skipped without decoding.
+func loadMessage(fileName string, repos *[]string, only string) (
 	map[string]interface{}, *hercules.CommonAnalysisResult, string, []string,
 ) {
 	var errs []string
@@ -136,25 +143,30 @@ func loadMessage(fileName string, repos *[]string) (
 		errs = append(errs, "Cannot parse "+fileName+": file size is 0")
 		return nil, nil, "", errs
 	}
-	buffer, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		errs = append(errs, "Cannot read "+fileName+": "+err.Error())
-		return nil, nil, "", errs
-	}
-	message := pb.AnalysisResults{}
-	err = proto.Unmarshal(buffer, &message)
+	file, err := openResultFile(fileName)
 	if err != nil {
 		errs = append(errs, "Cannot parse "+fileName+": "+err.Error())
 		return nil, nil, "", errs
 	}
-	if message.Header == nil {
+	// the deserialized results copy the data, so the mapping is not needed after return
+	defer file.Close()
+	if file.Header == nil {
 		errs = append(errs, "Cannot parse "+fileName+": corrupted header")
 		return nil, nil, "", errs
 	}
+	message := pb.AnalysisResults{Header: file.Header, Contents: file.Contents}
+	// the payloads are upgraded below only if they are merged
+	if _, err = renameLegacyItems(&message); err != nil {
+		errs = append(errs, "Cannot upgrade "+fileName+": "+err.Error())
+		return nil, nil, "", errs
+	}
 	repoName := message.Header.Repository
 	*repos = append(*repos, repoName)
 	results := map[string]interface{}{}
 	for key, val := range message.Contents {
+		if only != "" && key != only {
+			continue
+		}
 		summoned := hercules.Registry.Summon(key)
 		if len(summoned) == 0 {
 			errs = append(errs, fileName+": item not found: "+key)
@@ -165,6 +177,10 @@ func loadMessage(fileName string, repos *[]string) (
 			errs = append(errs, fileName+": "+key+": ResultMergeablePipelineItem is not implemented")
 			continue
 		}
+		if val, _, err = upgradeLegacyPayload(key, val); err != nil {
+			errs = append(errs, fileName+": upgrade failed: "+key+": "+err.Error())
+			continue
+		}
 		msg, err := mpi.Deserialize(val)
 		if err != nil {
 			errs = append(errs, fileName+": deserialization failed: "+key+": "+err.Error())
@@ -203,6 +219,12 @@ func mergeResults(mergedResults map[string]interface{},
 	anotherCommons *hercules.CommonAnalysisResult,
 	only string,
 ) []error {
+	if mergedCommons.CommitsNumber > 0 {
+		if err := mergedCommons.CheckTicks(anotherCommons); err != nil {
+			// the same tick means different periods, thus none of the results may be merged
+			return []error{fmt.Errorf("could not merge the results: %v", err)}
+		}
+	}
 	var errors []error
 	for key, val := range anotherResults {
 		if only != "" && key != only {
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+// resultFile is the analysis result file mapped into memory. Contents point inside the mapping
+// and are valid only until Close(), so the deserialized results must not reference them.
+type resultFile struct {
+	Header *pb.Metadata
+	// Contents maps the analysis names to their serialized results.
+	Contents map[string][]byte
+
+	release func() error
+}
+
+// openResultFile maps the file into memory and indexes the analyses without decoding them.
+// Multi-GB files are thus merged while only one analysis at a time is deserialized on the heap.
+func openResultFile(fileName string) (*resultFile, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data, release, err := mapFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result := &resultFile{release: release}
+	if err = result.index(data); err != nil {
+		result.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+// Close unmaps the file.
+func (rf *resultFile) Close() error {
+	if rf.release == nil {
+		return nil
+	}
+	release := rf.release
+	rf.release = nil
+	rf.Contents = nil
+	return release()
+}
+
+// index walks the top-level fields of pb.AnalysisResults. Only the header is decoded,
+// the values of the contents map are sliced from the data without copying.
+func (rf *resultFile) index(data []byte) error {
+	rf.Contents = map[string][]byte{}
+	for len(data) > 0 {
+		field, wireType, value, rest, err := nextProtoField(data)
+		if err != nil {
+			return err
+		}
+		data = rest
+		if wireType != proto.WireBytes {
+			continue
+		}
+		switch field {
+		case 1:
+			header := &pb.Metadata{}
+			if err = proto.Unmarshal(value, header); err != nil {
+				return err
+			}
+			rf.Header = header
+		case 2:
+			var key string
+			var payload []byte
+			for len(value) > 0 {
+				entryField, entryType, entryValue, entryRest, err := nextProtoField(value)
+				if err != nil {
+					return err
+				}
+				value = entryRest
+				if entryType != proto.WireBytes {
+					continue
+				}
+				switch entryField {
+				case 1:
+					key = string(entryValue)
+				case 2:
+					payload = entryValue
+				}
+			}
+			rf.Contents[key] = payload
+		}
+	}
+	return nil
+}
+
+// readFile is the fallback of mapFile() which reads the file into the heap.
+func readFile(file *os.File) ([]byte, func() error, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
+
+var errTruncatedMessage = errors.New("unexpected end of the message")
+
+// nextProtoField reads the field tag and skips over its value. For the length-delimited fields,
+// value is the payload.
+func nextProtoField(data []byte) (field uint64, wireType int, value, rest []byte, err error) {
+	tag, n := proto.DecodeVarint(data)
+	if n == 0 {
+		return 0, 0, nil, nil, errTruncatedMessage
+	}
+	data = data[n:]
+	field, wireType = tag>>3, int(tag&7)
+	if field == 0 {
+		return 0, 0, nil, nil, errors.New("illegal field number 0")
+	}
+	switch wireType {
+	case proto.WireVarint:
+		if _, n = proto.DecodeVarint(data); n == 0 {
+			return 0, 0, nil, nil, errTruncatedMessage
+		}
+	case proto.WireFixed64:
+		n = 8
+	case proto.WireFixed32:
+		n = 4
+	case proto.WireBytes:
+		length, m := proto.DecodeVarint(data)
+		if m == 0 || length > uint64(len(data)-m) {
+			return 0, 0, nil, nil, errTruncatedMessage
+		}
+		value = data[m : m+int(length)]
+		n = m + int(length)
+	default:
+		return 0, 0, nil, nil, fmt.Errorf("unsupported wire type %d", wireType)
+	}
+	if n > len(data) {
+		return 0, 0, nil, nil, errTruncatedMessage
+	}
+	return field, wireType, value, data[n:], nil
+}
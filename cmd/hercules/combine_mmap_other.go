@@ -0,0 +1,11 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+// +build !aix,!darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd,!solaris
+
+package main
+
+import "os"
+
+// mapFile reads the whole file since memory mapping is not supported on this platform.
+func mapFile(file *os.File) ([]byte, func() error, error) {
+	return readFile(file)
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func writeResultFile(t *testing.T, payload []byte) string {
+	path := filepath.Join(t.TempDir(), "results.pb")
+	if err := os.WriteFile(path, payload, 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	return path
+}
+
+func TestOpenResultFile(t *testing.T) {
+	devs := mustMarshal(t, &pb.DevsAnalysisResults{DevIndex: []string{"alice"}, TickSize: 1000})
+	path := writeResultFile(t, mustMarshal(t, &pb.AnalysisResults{
+		Header: &pb.Metadata{Version: 2, Repository: "repo", Commits: 10},
+		Contents: map[string][]byte{
+			"Devs":    devs,
+			"Couples": {1, 2, 3},
+			"Empty":   {},
+		},
+		RefactoringProxy: &pb.RefactoringProxyResults{},
+	}))
+	file, err := openResultFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Header.Repository != "repo" || file.Header.Commits != 10 {
+		t.Fatalf("unexpected header: %v", file.Header)
+	}
+	expected := map[string][]byte{"Devs": devs, "Couples": {1, 2, 3}, "Empty": nil}
+	if len(file.Contents) != len(expected) {
+		t.Fatalf("unexpected contents: %v", file.Contents)
+	}
+	for key, val := range expected {
+		if !bytes.Equal(file.Contents[key], val) {
+			t.Fatalf("%s: expected %v, got %v", key, val, file.Contents[key])
+		}
+	}
+	if err = file.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = file.Close(); err != nil {
+		t.Fatalf("second Close() must be a no-op: %v", err)
+	}
+}
+
+func TestOpenResultFileCorrupted(t *testing.T) {
+	payload := mustMarshal(t, &pb.AnalysisResults{
+		Header:   &pb.Metadata{Version: 2},
+		Contents: map[string][]byte{"Devs": bytes.Repeat([]byte{1}, 100)},
+	})
+	for _, corrupted := range [][]byte{
+		payload[:len(payload)-10],
+		{0x12, 0xff},
+		{0x00},
+		{0x0b},
+	} {
+		if _, err := openResultFile(writeResultFile(t, corrupted)); err == nil {
+			t.Fatalf("%v: expected an error", corrupted)
+		}
+	}
+	if _, err := openResultFile(filepath.Join(t.TempDir(), "missing.pb")); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestLoadMessageOnly(t *testing.T) {
+	path := writeResultFile(t, mustMarshal(t, &pb.AnalysisResults{
+		Header: &pb.Metadata{Version: 2, Repository: "repo"},
+		Contents: map[string][]byte{
+			"Devs":    mustMarshal(t, &pb.DevsAnalysisResults{DevIndex: []string{"alice"}, TickSize: 1000}),
+			"Couples": {0xff},
+			// the legacy payloads are not decoded unless they are merged
+			"BurndownAnalysis": {0xff},
+		},
+	}))
+	var repos []string
+	results, metadata, repoName, errs := loadMessage(path, &repos, "Devs")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if metadata == nil || repoName != "repo" || !reflect.DeepEqual(repos, []string{"repo"}) {
+		t.Fatalf("unexpected metadata: %v %s %v", metadata, repoName, repos)
+	}
+	if _, exists := results["Devs"]; !exists || len(results) != 1 {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	// without --only, the broken analyses are decoded and reported
+	if _, _, _, errs = loadMessage(path, &repos, ""); len(errs) != 2 {
+		t.Fatalf("expected two errors, got %v", errs)
+	}
+}
+
+func TestMergeResultsMixedTicks(t *testing.T) {
+	load := func(tickMode string) (map[string]interface{}, *hercules.CommonAnalysisResult) {
+		path := writeResultFile(t, mustMarshal(t, &pb.AnalysisResults{
+			Header: &pb.Metadata{
+				Version: 2, Repository: "repo", Commits: 10, TickMode: tickMode,
+				BeginUnixTime: 1600000000, EndUnixTime: 1700000000,
+			},
+			Contents: map[string][]byte{
+				"Devs": mustMarshal(t, &pb.DevsAnalysisResults{DevIndex: []string{"alice"}, TickSize: 1000}),
+			},
+		}))
+		var repos []string
+		results, metadata, _, errs := loadMessage(path, &repos, "")
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		return results, metadata
+	}
+	merged, mergedMetadata := load("month")
+	devs := merged["Devs"]
+	other, otherMetadata := load("")
+	errs := mergeResults(merged, mergedMetadata, other, otherMetadata, "")
+	if len(errs) != 1 || errs[0].Error() !=
+		"could not merge the results: mismatching tick modes (month, fixed) received" {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if !reflect.DeepEqual(merged["Devs"], devs) || mergedMetadata.CommitsNumber != 10 {
+		t.Fatal("the results with the different tick modes must not be merged")
+	}
+	other, otherMetadata = load("month")
+	if errs = mergeResults(merged, mergedMetadata, other, otherMetadata, ""); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if mergedMetadata.CommitsNumber != 20 {
+		t.Fatalf("unexpected metadata: %v", mergedMetadata)
+	}
+}
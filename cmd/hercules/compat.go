@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+// legacyItemNames maps the analysis names found in the results of the original
+// src-d/hercules to the current names. Some third party writers keyed the contents by the Go
+// type names of the leaves instead of PipelineItem.Name().
+var legacyItemNames = map[string]string{
+	"BurndownAnalysis":           "Burndown",
+	"CouplesAnalysis":            "Couples",
+	"DevsAnalysis":               "Devs",
+	"ShotnessAnalysis":           "Shotness",
+	"CommentSentimentAnalysis":   "Sentiment",
+	"CommitsAnalysis":            "CommitsStat",
+	"FileHistory":                "FileHistoryAnalysis",
+	"ImportsPerDeveloperResults": "ImportsPerDeveloper",
+	"TyposDatasetBuilder":        "TyposDataset",
+}
+
+// legacyTickSizes decode the analyses which the original src-d/hercules wrote before the tick
+// size became configurable. Those results always used days. The second returned value points
+// to the tick size field of the decoded message.
+var legacyTickSizes = map[string]func() (proto.Message, *int64){
+	"Burndown": func() (proto.Message, *int64) {
+		msg := &pb.BurndownAnalysisResults{}
+		return msg, &msg.TickSize
+	},
+	"Devs": func() (proto.Message, *int64) {
+		msg := &pb.DevsAnalysisResults{}
+		return msg, &msg.TickSize
+	},
+	"ImportsPerDeveloper": func() (proto.Message, *int64) {
+		msg := &pb.ImportsPerDeveloperResults{}
+		return msg, &msg.TickSize
+	},
+}
+
+// upgradeLegacyResults translates the results written by the original src-d/hercules or by
+// older versions of this fork to the current structures in-place: it renames the legacy
+// analysis keys and sets the implicit daily tick size. The returned notes describe the applied
+// changes and are empty if the results are already current.
+func upgradeLegacyResults(message *pb.AnalysisResults) ([]string, error) {
+	notes, err := renameLegacyItems(message)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(legacyTickSizes))
+	for key := range legacyTickSizes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		payload, exists := message.Contents[key]
+		if !exists {
+			continue
+		}
+		upgraded, note, err := upgradeLegacyPayload(key, payload)
+		if err != nil {
+			return nil, err
+		}
+		if note != "" {
+			message.Contents[key] = upgraded
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// renameLegacyItems renames the legacy analysis keys in-place. It does not touch the payloads,
+// so it is cheap to run on all the results before choosing which of them to decode.
+func renameLegacyItems(message *pb.AnalysisResults) ([]string, error) {
+	var notes []string
+	keys := make([]string, 0, len(message.Contents))
+	for key := range message.Contents {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		current, exists := legacyItemNames[key]
+		if !exists {
+			continue
+		}
+		if _, conflict := message.Contents[current]; conflict {
+			return nil, fmt.Errorf("both %s and its legacy name %s are present", current, key)
+		}
+		message.Contents[current] = message.Contents[key]
+		delete(message.Contents, key)
+		notes = append(notes, fmt.Sprintf("renamed %s to %s", key, current))
+	}
+	return notes, nil
+}
+
+// upgradeLegacyPayload sets the implicit daily tick size of the analysis under the current
+// key. It decodes only the analyses listed in legacyTickSizes and returns the other payloads
+// as they are. The returned note is empty if the payload did not change.
+func upgradeLegacyPayload(key string, payload []byte) ([]byte, string, error) {
+	decode, exists := legacyTickSizes[key]
+	if !exists {
+		return payload, "", nil
+	}
+	decoded, tickSize := decode()
+	if err := proto.Unmarshal(payload, decoded); err != nil {
+		return nil, "", fmt.Errorf("failed to decode %s: %w", key, err)
+	}
+	if *tickSize != 0 {
+		return payload, "", nil
+	}
+	*tickSize = int64(24 * time.Hour)
+	upgraded, err := proto.Marshal(decoded)
+	if err != nil {
+		return nil, "", err
+	}
+	return upgraded, fmt.Sprintf("set the missing tick size of %s to 24h", key), nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func TestUpgradeLegacyResults(t *testing.T) {
+	message := pb.AnalysisResults{
+		Header: &pb.Metadata{Version: 2},
+		Contents: map[string][]byte{
+			"BurndownAnalysis": mustMarshal(t, &pb.BurndownAnalysisResults{Granularity: 30}),
+			"Devs":             mustMarshal(t, &pb.DevsAnalysisResults{DevIndex: []string{"alice"}}),
+			"Couples":          mustMarshal(t, &pb.CouplesAnalysisResults{}),
+		},
+	}
+	notes, err := upgradeLegacyResults(&message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"renamed BurndownAnalysis to Burndown",
+		"set the missing tick size of Burndown to 24h",
+		"set the missing tick size of Devs to 24h",
+	}
+	if !reflect.DeepEqual(notes, expected) {
+		t.Fatalf("unexpected notes: %v", notes)
+	}
+	if _, exists := message.Contents["BurndownAnalysis"]; exists {
+		t.Fatal("the legacy key must be removed")
+	}
+	var burndown pb.BurndownAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Burndown"], &burndown); err != nil {
+		t.Fatal(err)
+	}
+	if burndown.TickSize != int64(24*time.Hour) || burndown.Granularity != 30 {
+		t.Fatalf("unexpected burndown: %v", burndown)
+	}
+	var devs pb.DevsAnalysisResults
+	if err := proto.Unmarshal(message.Contents["Devs"], &devs); err != nil {
+		t.Fatal(err)
+	}
+	if devs.TickSize != int64(24*time.Hour) || devs.DevIndex[0] != "alice" {
+		t.Fatalf("unexpected devs: %v", devs)
+	}
+
+	notes, err = upgradeLegacyResults(&message)
+	if err != nil || len(notes) != 0 {
+		t.Fatalf("the upgraded results must stay intact: %v %v", notes, err)
+	}
+}
+
+func TestUpgradeLegacyResultsErrors(t *testing.T) {
+	_, err := upgradeLegacyResults(&pb.AnalysisResults{Contents: map[string][]byte{
+		"Devs": {}, "DevsAnalysis": {},
+	}})
+	if err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	_, err = upgradeLegacyResults(&pb.AnalysisResults{Contents: map[string][]byte{"Devs": {0xff}}})
+	if err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
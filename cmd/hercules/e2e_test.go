@@ -0,0 +1,288 @@
+//go:build e2e
+// +build e2e
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/meko-christian/hercules/internal/test"
+)
+
+// The end-to-end tests generate the synthetic corpus or clone the pinned public repositories,
+// run the hercules binary with the default and the --all analysis sets of `hercules report`
+// and compare the key scalar outputs against the recorded baselines in test_data/e2e.
+// The public repositories need the network and git, the synthetic corpus needs neither:
+//
+//	go test -tags e2e -run TestEndToEnd -timeout 60m ./cmd/hercules
+//
+// Pass -e2e.update to record the baselines of new repositories or after an intentional change
+// of the results. The repositories without a recorded baseline fail the test otherwise.
+var e2eUpdate = flag.Bool("e2e.update", false, "Record the end-to-end baselines instead of comparing.")
+
+// e2eRepository is a public repository pinned to a tag or a synthetic corpus.
+type e2eRepository struct {
+	Name string
+	URL  string
+	Tag  string
+	// Corpus is generated instead of cloning URL.
+	Corpus *test.CorpusOptions
+}
+
+// e2eRepositories are small, so that the whole suite runs in a few minutes. A public
+// repository is listed together with its baseline, e.g.
+// {Name: "errors", URL: "https://github.com/pkg/errors", Tag: "v0.9.1"} after recording
+// test_data/e2e/errors.json with -e2e.update.
+var e2eRepositories = []e2eRepository{
+	{Name: "synthetic", Corpus: &test.CorpusOptions{
+		Commits: 60, Branches: 4, BranchCommits: 3, Authors: 4, Files: 12, Renames: 3,
+		BinaryFiles: 1, UnicodePaths: true, Seed: 7,
+	}},
+}
+
+// e2eBaseline is the content of test_data/e2e/<name>.json.
+type e2eBaseline struct {
+	// Commit is the hash which the tag resolved to; a moved tag invalidates the baseline.
+	// It is empty for the fixtures.
+	Commit string `json:"commit"`
+	// Sets maps the analysis sets to their key scalar outputs.
+	Sets map[string]map[string]interface{} `json:"sets"`
+}
+
+// e2eAnalysisSets returns the flags of the default and the --all sets of `hercules report`.
+func e2eAnalysisSets(t *testing.T) map[string][]string {
+	available := reportAvailableAnalysisFlags()
+	defaults, err := selectReportAnalysisFlags(available, nil, false)
+	if err != nil {
+		t.Fatalf("failed to select the default analyses: %v", err)
+	}
+	all, err := selectReportAnalysisFlags(available, nil, true)
+	if err != nil {
+		t.Fatalf("failed to select all the analyses: %v", err)
+	}
+	return map[string][]string{"default": defaults, "all": all}
+}
+
+func TestEndToEnd(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("needs git")
+	}
+	binary := filepath.Join(t.TempDir(), "hercules")
+	if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+		t.Fatalf("failed to build hercules: %v\n%s", err, output)
+	}
+	sets := e2eAnalysisSets(t)
+	for _, repo := range e2eRepositories {
+		repo := repo
+		t.Run(repo.Name, func(t *testing.T) {
+			path := filepath.Join("test_data", "e2e", repo.Name+".json")
+			var expected e2eBaseline
+			if !*e2eUpdate {
+				data, err := os.ReadFile(path)
+				if os.IsNotExist(err) {
+					t.Fatalf("no baseline for %s, record it with -e2e.update", repo.Name)
+				}
+				if err != nil {
+					t.Fatalf("failed to read %s: %v", path, err)
+				}
+				if err := json.Unmarshal(data, &expected); err != nil {
+					t.Fatalf("invalid baseline %s: %v", path, err)
+				}
+			}
+			actual := e2eBaseline{Sets: map[string]map[string]interface{}{}}
+			dir := filepath.Join(t.TempDir(), repo.Name)
+			if repo.Corpus != nil {
+				actual.Commit = e2eWriteCorpus(t, *repo.Corpus, dir)
+			} else {
+				e2eGit(t, "", "clone", "--quiet", "--branch", repo.Tag, repo.URL, dir)
+				actual.Commit = e2eGit(t, dir, "rev-parse", "HEAD")
+			}
+			for name, flags := range sets {
+				actual.Sets[name] = e2eRun(t, binary, dir, flags)
+			}
+			if *e2eUpdate {
+				e2eWriteBaseline(t, path, actual)
+				return
+			}
+			if expected.Commit != actual.Commit {
+				t.Fatalf("%s@%s resolved to %s instead of %s", repo.URL, repo.Tag, actual.Commit,
+					expected.Commit)
+			}
+			for name := range sets {
+				if diff := e2eCompare(expected.Sets[name], actual.Sets[name]); len(diff) > 0 {
+					t.Errorf("the %s analyses changed:\n%s", name, strings.Join(diff, "\n"))
+				}
+			}
+		})
+	}
+}
+
+// e2eWriteCorpus generates the synthetic repository, copies it to a bare repository in dir
+// and returns the hash of the head. The hash depends only on the options, so a change of
+// the generator invalidates the baseline.
+func e2eWriteCorpus(t *testing.T, options test.CorpusOptions, dir string) string {
+	corpus, err := test.GenerateCorpus(options)
+	if err != nil {
+		t.Fatalf("failed to generate the corpus: %v", err)
+	}
+	repository, err := git.PlainInit(dir, true)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	objects, err := corpus.Repository.Storer.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		t.Fatalf("failed to list the objects: %v", err)
+	}
+	err = objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := repository.Storer.SetEncodedObject(obj)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to copy the objects: %v", err)
+	}
+	refs, err := corpus.Repository.Storer.IterReferences()
+	if err != nil {
+		t.Fatalf("failed to list the references: %v", err)
+	}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		return repository.Storer.SetReference(ref)
+	})
+	if err != nil {
+		t.Fatalf("failed to copy the references: %v", err)
+	}
+	return corpus.Head.String()
+}
+
+// e2eGit runs git in the directory and returns the trimmed stdout.
+func e2eGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, stderr)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// e2eRun analyses the repository with the flags and returns the key scalar outputs.
+func e2eRun(t *testing.T, binary, dir string, flags []string) map[string]interface{} {
+	output := filepath.Join(t.TempDir(), "results.json")
+	args := []string{"--quiet", "--output", "json=" + output}
+	for _, flag := range flags {
+		args = append(args, "--"+flag)
+	}
+	args = append(args, dir)
+	if stderr, err := exec.Command(binary, args...).CombinedOutput(); err != nil {
+		t.Fatalf("hercules %s: %v\n%s", strings.Join(args, " "), err, stderr)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read the results: %v", err)
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("invalid results: %v", err)
+	}
+	return e2eScalars(document)
+}
+
+// e2eScalars extracts the key scalar outputs from the JSON results: the number of commits and
+// the time range from the header, and the top-level fields of each analysis, the scalars
+// as they are and the mappings and the lists as their lengths under "<field>#len".
+// The run time and the version are left out because they change between the runs.
+func e2eScalars(document map[string]interface{}) map[string]interface{} {
+	scalars := map[string]interface{}{}
+	for name, value := range document {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name == "hercules" {
+			for _, key := range []string{"commits", "begin_unix_time", "end_unix_time"} {
+				scalars["hercules."+key] = fields[key]
+			}
+			continue
+		}
+		// each analysis writes a single mapping under its own key
+		for key, inner := range fields {
+			prefix := name + "." + key
+			innerFields, ok := inner.(map[string]interface{})
+			if !ok {
+				e2eAddScalar(scalars, prefix, inner)
+				continue
+			}
+			for field, value := range innerFields {
+				e2eAddScalar(scalars, prefix+"."+field, value)
+			}
+		}
+	}
+	return scalars
+}
+
+func e2eAddScalar(scalars map[string]interface{}, key string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		scalars[key+"#len"] = float64(len(value))
+	case []interface{}:
+		scalars[key+"#len"] = float64(len(value))
+	default:
+		scalars[key] = value
+	}
+}
+
+// e2eCompare returns the sorted descriptions of the differences between the scalars.
+// The numbers are equal if they differ by less than one millionth.
+func e2eCompare(expected, actual map[string]interface{}) []string {
+	var diff []string
+	for key, want := range expected {
+		got, exists := actual[key]
+		if !exists {
+			diff = append(diff, fmt.Sprintf("  %s: missing, expected %v", key, want))
+			continue
+		}
+		wantNumber, wantOk := want.(float64)
+		gotNumber, gotOk := got.(float64)
+		if wantOk && gotOk {
+			if math.Abs(wantNumber-gotNumber) > 1e-6*math.Max(1, math.Abs(wantNumber)) {
+				diff = append(diff, fmt.Sprintf("  %s: %v, expected %v", key, got, want))
+			}
+		} else if fmt.Sprint(want) != fmt.Sprint(got) {
+			diff = append(diff, fmt.Sprintf("  %s: %v, expected %v", key, got, want))
+		}
+	}
+	for key, got := range actual {
+		if _, exists := expected[key]; !exists {
+			diff = append(diff, fmt.Sprintf("  %s: %v, not expected", key, got))
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func e2eWriteBaseline(t *testing.T, path string, baseline e2eBaseline) {
+	data, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode the baseline: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	t.Logf("recorded %s", path)
+}
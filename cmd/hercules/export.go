@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+)
+
+// summaryMetric is a final scalar value derived from the analysis results.
+type summaryMetric struct {
+	Name  string
+	Help  string
+	Value float64
+}
+
+// extractSummaryMetrics calculates the latest values of the key metrics: the current bus
+// factor, the ownership concentration, the top hotspot risk score and the number of
+// contributors. Metrics whose source analysis is missing are not reported.
+func extractSummaryMetrics(message pb.AnalysisResults) ([]summaryMetric, error) {
+	var metrics []summaryMetric
+	if message.Header != nil {
+		metrics = append(metrics, summaryMetric{
+			Name: "hercules_commits", Help: "Number of analysed commits.",
+			Value: float64(message.Header.Commits),
+		})
+	}
+	series, err := extractTimeSeries(message)
+	if err != nil {
+		return nil, err
+	}
+	addLast := func(seriesName, name, help string) {
+		if point, ok := series[seriesName].Last(); ok {
+			metrics = append(metrics, summaryMetric{Name: name, Help: help, Value: point.Value})
+		}
+	}
+	addLast(seriesBusFactor, "hercules_bus_factor",
+		"Smallest number of developers who own the threshold share of the alive lines.")
+	addLast(seriesTotalLines, "hercules_total_lines", "Number of alive lines.")
+	addLast(seriesGini, "hercules_ownership_gini", "Gini coefficient of the line ownership.")
+	addLast(seriesHHI, "hercules_ownership_hhi", "Herfindahl-Hirschman index of the line ownership.")
+
+	if payload, exists := message.Contents["HotspotRisk"]; exists {
+		var result pb.HotspotRiskResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode HotspotRisk: %w", err)
+		}
+		top := 0.0
+		for _, file := range result.Files {
+			if file.RiskScore > top {
+				top = file.RiskScore
+			}
+		}
+		metrics = append(metrics, summaryMetric{
+			Name: "hercules_hotspot_risk_top_score", Help: "Highest hotspot risk score among the files.",
+			Value: top,
+		})
+	}
+	if payload, exists := message.Contents["Devs"]; exists {
+		var result pb.DevsAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode Devs: %w", err)
+		}
+		contributors := map[int32]struct{}{}
+		for _, tick := range result.Ticks {
+			for dev, stats := range tick.Devs {
+				if stats.Commits > 0 {
+					contributors[dev] = struct{}{}
+				}
+			}
+		}
+		metrics = append(metrics, summaryMetric{
+			Name: "hercules_contributors", Help: "Number of developers who authored at least one commit.",
+			Value: float64(len(contributors)),
+		})
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics, nil
+}
+
+// escapeOpenMetricsLabel escapes a label value according to the exposition format.
+func escapeOpenMetricsLabel(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// writeOpenMetrics prints the metrics as gauges in the OpenMetrics text format. Every sample
+// is labelled with the repository.
+func writeOpenMetrics(writer io.Writer, repository string, metrics []summaryMetric) error {
+	labels := fmt.Sprintf(`{repository="%s"}`, escapeOpenMetricsLabel(repository))
+	for _, metric := range metrics {
+		_, err := fmt.Fprintf(writer, "# TYPE %s gauge\n# HELP %s %s\n%s%s %s\n",
+			metric.Name, metric.Name, metric.Help, metric.Name, labels,
+			strconv.FormatFloat(metric.Value, 'g', -1, 64))
+		if err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(writer, "# EOF")
+	return err
+}
+
+// exportCmd groups the converters of the analysis results to third party formats.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Convert the analysis results in Protocol Buffers format to other formats.",
+	Long:  ``,
+}
+
+// exportOpenMetricsCmd prints the final summary metrics in the OpenMetrics text format.
+var exportOpenMetricsCmd = &cobra.Command{
+	Use:   "openmetrics [flags] <results.pb>",
+	Short: "Print the final summary metrics in the OpenMetrics (Prometheus) text format.",
+	Long: `Prints the latest bus factor (--bus-factor), ownership Gini and HHI
+(--ownership-concentration), the top hotspot risk score (--hotspot-risk) and the number of
+contributors (--devs) as gauges. The output can be pushed to a Prometheus pushgateway:
+
+  hercules export openmetrics results.pb | curl --data-binary @- http://pushgateway:9091/metrics/job/hercules`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		metrics, err := extractSummaryMetrics(message)
+		if err != nil {
+			return err
+		}
+		repository := ""
+		if message.Header != nil {
+			repository = message.Header.Repository
+		}
+		return writeOpenMetrics(os.Stdout, repository, metrics)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.SetUsageFunc(exportCmd.UsageFunc())
+	exportCmd.AddCommand(exportOpenMetricsCmd)
+	exportOpenMetricsCmd.SetUsageFunc(exportOpenMetricsCmd.UsageFunc())
+}
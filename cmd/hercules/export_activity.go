@@ -0,0 +1,272 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// weeklyActivity is the aggregated temporal activity of a team during one ISO week.
+type weeklyActivity struct {
+	// Week is the Monday 00:00 UTC which starts the ISO week.
+	Week    time.Time
+	Team    string
+	Commits int64
+	Lines   int64
+}
+
+// loadTeams reads the YAML file which maps team names to the lists of developer identities.
+// The returned map is keyed by the lower-cased identity.
+func loadTeams(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var teams map[string][]string
+	if err := yaml.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("failed to parse the teams file %s: %w", path, err)
+	}
+	mapping := map[string]string{}
+	for team, members := range teams {
+		for _, member := range members {
+			mapping[strings.ToLower(member)] = team
+		}
+	}
+	return mapping, nil
+}
+
+// findTeam looks up the team of a developer identity from IdentityDetector.ReversedPeopleDict.
+// The identity may be written in any of the people display formats, see core.ParseIdentity().
+// It matches if either the whole string or any of its names and emails is listed in the mapping;
+// the hashed identity matches the hash of a listed member.
+func findTeam(identity string, teams map[string]string) (string, bool) {
+	if team, exists := teams[strings.ToLower(identity)]; exists {
+		return team, true
+	}
+	for _, key := range core.ParseIdentity(identity).Keys() {
+		if team, exists := teams[strings.ToLower(key)]; exists {
+			return team, true
+		}
+	}
+	for member, team := range teams {
+		if core.HashIdentity(member) == identity {
+			return team, true
+		}
+	}
+	return "", false
+}
+
+// resolveTeam finds the team of a developer identity with findTeam().
+// Unmapped developers form their own team.
+func resolveTeam(identity string, teams map[string]string) string {
+	if team, exists := findTeam(identity, teams); exists {
+		return team
+	}
+	return core.IdentityLabel(identity)
+}
+
+// isoWeekStart returns the Monday 00:00 UTC of the ISO week which contains the time.
+func isoWeekStart(moment time.Time) time.Time {
+	moment = moment.UTC()
+	day := time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// extractWeeklyActivity aggregates the per-tick TemporalActivity data by team and ISO week.
+// The result is sorted by week and then by team.
+func extractWeeklyActivity(message pb.AnalysisResults, teams map[string]string) ([]weeklyActivity, error) {
+	payload, exists := message.Contents["TemporalActivity"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain TemporalActivity, rerun with --temporal-activity")
+	}
+	var result pb.TemporalActivityResults
+	if err := proto.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode TemporalActivity: %w", err)
+	}
+	type key struct {
+		week int64
+		team string
+	}
+	aggregated := map[key]*weeklyActivity{}
+	for tick, devs := range result.Ticks {
+		week := isoWeekStart(time.Unix(bundleTickTime(message.Header, result.TickSize, int64(tick)), 0))
+		for dev, activity := range devs.Devs {
+			team := ""
+			if dev >= 0 && int(dev) < len(result.DevIndex) {
+				team = resolveTeam(result.DevIndex[dev], teams)
+			} else {
+				team = strconv.Itoa(int(dev))
+			}
+			k := key{week.Unix(), team}
+			item := aggregated[k]
+			if item == nil {
+				item = &weeklyActivity{Week: week, Team: team}
+				aggregated[k] = item
+			}
+			item.Commits += int64(activity.Commits)
+			item.Lines += int64(activity.Lines)
+		}
+	}
+	weeks := make([]weeklyActivity, 0, len(aggregated))
+	for _, item := range aggregated {
+		weeks = append(weeks, *item)
+	}
+	sort.Slice(weeks, func(i, j int) bool {
+		if !weeks[i].Week.Equal(weeks[j].Week) {
+			return weeks[i].Week.Before(weeks[j].Week)
+		}
+		return weeks[i].Team < weeks[j].Team
+	})
+	return weeks, nil
+}
+
+// writeActivityCSV prints the weekly activity as CSV with a header row.
+func writeActivityCSV(writer io.Writer, weeks []weeklyActivity) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write([]string{"week", "team", "commits", "lines"}); err != nil {
+		return err
+	}
+	for _, item := range weeks {
+		err := csvWriter.Write([]string{
+			item.Week.Format("2006-01-02"), item.Team,
+			strconv.FormatInt(item.Commits, 10), strconv.FormatInt(item.Lines, 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// escapeICalText escapes a TEXT property value according to RFC 5545.
+func escapeICalText(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, ";", `\;`)
+	value = strings.ReplaceAll(value, ",", `\,`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
+
+// writeActivityICal prints the weekly activity as an iCalendar with one all-day event per team
+// and week. The events are heat-coded: the category is "low", "medium" or "high" depending on
+// the share of commits relative to the busiest week of the same team.
+func writeActivityICal(writer io.Writer, repository string, weeks []weeklyActivity) error {
+	peaks := map[string]int64{}
+	for _, item := range weeks {
+		if item.Commits > peaks[item.Team] {
+			peaks[item.Team] = item.Commits
+		}
+	}
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//hercules//temporal activity//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:" + escapeICalText("Activity of "+repository),
+	}
+	stamp := time.Unix(0, 0).UTC().Format("20060102T150405Z")
+	for _, item := range weeks {
+		heat := "low"
+		if peak := peaks[item.Team]; peak > 0 {
+			switch share := float64(item.Commits) / float64(peak); {
+			case share > 2.0/3:
+				heat = "high"
+			case share > 1.0/3:
+				heat = "medium"
+			}
+		}
+		uid := fmt.Sprintf("%s-%s@%s", item.Week.Format("20060102"), item.Team, repository)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+escapeICalText(uid),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+item.Week.Format("20060102"),
+			"DTEND;VALUE=DATE:"+item.Week.AddDate(0, 0, 7).Format("20060102"),
+			"SUMMARY:"+escapeICalText(fmt.Sprintf(
+				"%s: %d commits, %d lines", item.Team, item.Commits, item.Lines)),
+			"CATEGORIES:"+heat,
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if _, err := io.WriteString(writer, line+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportActivityCmd prints the weekly temporal activity per team.
+var exportActivityCmd = &cobra.Command{
+	Use:   "activity [flags] <results.pb>",
+	Short: "Print the weekly temporal activity per team as CSV and optionally as iCal events.",
+	Long: `Aggregates the per-tick data of --temporal-activity by ISO week and team and prints
+it as CSV with the columns week, team, commits and lines. The teams are read from a YAML
+file which maps the team names to the lists of developer names or emails:
+
+  backend: [alice@example.com, bob]
+  frontend: [carol]
+
+Developers who are not listed form their own team. --ical additionally writes an iCalendar
+file with one all-day event per team and week, categorized as low, medium or high activity.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		teamsPath, _ := flags.GetString("teams")
+		icalPath, _ := flags.GetString("ical")
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		teams := map[string]string{}
+		if teamsPath != "" {
+			if teams, err = loadTeams(teamsPath); err != nil {
+				return err
+			}
+		}
+		weeks, err := extractWeeklyActivity(message, teams)
+		if err != nil {
+			return err
+		}
+		if icalPath != "" {
+			repository := ""
+			if message.Header != nil {
+				repository = message.Header.Repository
+			}
+			file, err := os.Create(icalPath)
+			if err != nil {
+				return err
+			}
+			if err := writeActivityICal(file, repository, weeks); err != nil {
+				file.Close()
+				return err
+			}
+			if err := file.Close(); err != nil {
+				return err
+			}
+		}
+		return writeActivityCSV(os.Stdout, weeks)
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportActivityCmd)
+	exportActivityCmd.SetUsageFunc(exportActivityCmd.UsageFunc())
+	exportActivityCmd.Flags().String("teams", "",
+		"Path to the YAML file which maps team names to the lists of developer names or emails.")
+	exportActivityCmd.Flags().String("ical", "", "Path to the iCalendar file to write.")
+}
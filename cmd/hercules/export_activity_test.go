@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func fakeActivityResults(t *testing.T) pb.AnalysisResults {
+	// 2024-01-01 is a Monday
+	begin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	return pb.AnalysisResults{
+		Header: &pb.Metadata{Repository: "test", BeginUnixTime: begin},
+		Contents: map[string][]byte{
+			"TemporalActivity": mustMarshal(t, &pb.TemporalActivityResults{
+				DevIndex: []string{"alice|alice@example.com", "bob", "carol"},
+				TickSize: int64(24 * time.Hour),
+				Ticks: map[int32]*pb.TemporalActivityTickDevs{
+					0: {Devs: map[int32]*pb.TemporalActivityTick{
+						0: {Commits: 1, Lines: 10},
+						1: {Commits: 2, Lines: 5},
+					}},
+					6: {Devs: map[int32]*pb.TemporalActivityTick{
+						2: {Commits: 1, Lines: 1},
+					}},
+					7: {Devs: map[int32]*pb.TemporalActivityTick{
+						0: {Commits: 3, Lines: 30},
+					}},
+				},
+			}),
+		},
+	}
+}
+
+func TestExtractWeeklyActivity(t *testing.T) {
+	weeks, err := extractWeeklyActivity(fakeActivityResults(t), map[string]string{
+		"alice@example.com": "backend", "bob": "backend",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeActivityCSV(buffer, weeks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `week,team,commits,lines
+2024-01-01,backend,3,15
+2024-01-01,carol,1,1
+2024-01-08,backend,3,30
+`
+	if buffer.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buffer.String())
+	}
+	_, err = extractWeeklyActivity(pb.AnalysisResults{}, nil)
+	if err == nil {
+		t.Fatal("expected an error for missing TemporalActivity")
+	}
+}
+
+func TestIsoWeekStart(t *testing.T) {
+	sunday := time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC)
+	if start := isoWeekStart(sunday); !start.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected week start: %v", start)
+	}
+}
+
+func TestLoadTeams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "teams.yaml")
+	if err := os.WriteFile(path, []byte("backend: [Alice, bob@example.com]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	teams, err := loadTeams(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolveTeam("alice|alice@example.com", teams) != "backend" {
+		t.Fatal("alice must belong to backend")
+	}
+	if resolveTeam("Bob|bob@example.com", teams) != "backend" {
+		t.Fatal("bob must belong to backend")
+	}
+	if resolveTeam("carol|carol@example.com", teams) != "carol" {
+		t.Fatal("unmapped carol must form a separate team")
+	}
+}
+
+func TestResolveTeamFormats(t *testing.T) {
+	teams := map[string]string{"alice": "backend", "bob@example.com": "frontend"}
+	for identity, team := range map[string]string{
+		"Alice|alice@example.com":            "backend",
+		"Alice <alice@example.com>":          "backend",
+		"bob@example.com":                    "frontend",
+		"Bob <bob@example.com>":              "frontend",
+		core.HashIdentity("bob@example.com"): "frontend",
+		"Carol <carol@example.com>":          "Carol",
+		"carol@example.com":                  "carol@example.com",
+	} {
+		if resolved := resolveTeam(identity, teams); resolved != team {
+			t.Errorf("%s: expected team %q, got %q", identity, team, resolved)
+		}
+	}
+}
+
+func TestWriteActivityICal(t *testing.T) {
+	weeks, err := extractWeeklyActivity(fakeActivityResults(t), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeActivityICal(buffer, "test", weeks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buffer.String()
+	if !strings.HasPrefix(output, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(output, "END:VCALENDAR\r\n") {
+		t.Fatalf("malformed calendar:\n%s", output)
+	}
+	if strings.Count(output, "BEGIN:VEVENT") != len(weeks) {
+		t.Fatalf("unexpected number of events:\n%s", output)
+	}
+	if !strings.Contains(output, "SUMMARY:alice: 1 commits\\, 10 lines\r\nCATEGORIES:low") {
+		t.Fatalf("missing the low activity event:\n%s", output)
+	}
+	if !strings.Contains(output, "DTSTART;VALUE=DATE:20240108\r\nDTEND;VALUE=DATE:20240115") {
+		t.Fatalf("missing the second week:\n%s", output)
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/leaves"
+	"github.com/spf13/cobra"
+)
+
+// chaossDocumentVersion is the version of the metrics.json layout.
+const chaossDocumentVersion = 1
+
+// chaossDocument is metrics.json: the CHAOSS metrics of one repository.
+type chaossDocument struct {
+	Version    int            `json:"version"`
+	Generator  string         `json:"generator"`
+	Repository string         `json:"repository"`
+	Period     *chaossPeriod  `json:"period,omitempty"`
+	Metrics    []chaossMetric `json:"metrics"`
+}
+
+// chaossMetric is a CHAOSS metric calculated from the hercules results.
+type chaossMetric struct {
+	// ID is the CHAOSS metric slug, e.g. "bus-factor".
+	ID string `json:"id"`
+	// Name is the CHAOSS metric name, e.g. "Bus Factor".
+	Name string `json:"name"`
+	// Source is the hercules analysis which the metric is calculated from.
+	Source string `json:"source"`
+	// Surrogate is true if the metric is approximated from the git history because
+	// the original data, e.g. the issues or the reviews, is not available.
+	Surrogate   bool               `json:"surrogate,omitempty"`
+	Description string             `json:"description"`
+	Value       float64            `json:"value"`
+	Unit        string             `json:"unit,omitempty"`
+	Details     map[string]float64 `json:"details,omitempty"`
+	Periods     []chaossPeriod     `json:"periods,omitempty"`
+}
+
+// chaossPeriod is a time range, optionally with the value of the metric in it.
+type chaossPeriod struct {
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+// newChaossPeriod formats the time range as RFC 3339 dates.
+func newChaossPeriod(start, end time.Time, value *float64) chaossPeriod {
+	return chaossPeriod{
+		Start: start.UTC().Format(time.RFC3339),
+		End:   end.UTC().Format(time.RFC3339),
+		Value: value,
+	}
+}
+
+// buildChaossDocument maps the analysis results onto the CHAOSS metrics: Bus Factor (--bus-factor),
+// Elephant Factor (--contribution-diversity), Time to First Response (--commits-stat, surrogate)
+// and Code Changes Lines (--devs). Metrics whose source analysis is missing are not reported.
+func buildChaossDocument(message pb.AnalysisResults) (*chaossDocument, error) {
+	doc := &chaossDocument{
+		Version:   chaossDocumentVersion,
+		Generator: "hercules",
+		Metrics:   []chaossMetric{},
+	}
+	if message.Header != nil {
+		doc.Repository = message.Header.Repository
+		if message.Header.BeginUnixTime != 0 || message.Header.EndUnixTime != 0 {
+			period := newChaossPeriod(time.Unix(message.Header.BeginUnixTime, 0),
+				time.Unix(message.Header.EndUnixTime, 0), nil)
+			doc.Period = &period
+		}
+	}
+	series, err := extractTimeSeries(message)
+	if err != nil {
+		return nil, err
+	}
+	if point, ok := series[seriesBusFactor].Last(); ok {
+		doc.Metrics = append(doc.Metrics, chaossMetric{
+			ID: "bus-factor", Name: "Bus Factor", Source: "BusFactor",
+			Description: "The smallest number of developers who own the threshold share of the alive lines.",
+			Value:       point.Value,
+		})
+	}
+	if payload, exists := message.Contents["ContributionDiversity"]; exists {
+		raw, err := (&leaves.ContributionDiversityAnalysis{}).Deserialize(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode ContributionDiversity: %w", err)
+		}
+		result := raw.(leaves.ContributionDiversityResult)
+		metric := chaossMetric{
+			ID: "elephant-factor", Name: "Elephant Factor", Source: "ContributionDiversity",
+			Description: "The smallest number of organizations which made 50% of the commits.",
+			Value:       float64(result.ElephantFactor()),
+		}
+		for _, quarter := range result.Quarters() {
+			start := time.Date(quarter.Quarter/4, time.Month(quarter.Quarter%4*3+1), 1, 0, 0, 0, 0, time.UTC)
+			value := float64(quarter.ElephantFactor)
+			metric.Periods = append(metric.Periods,
+				newChaossPeriod(start, start.AddDate(0, 3, 0), &value))
+		}
+		doc.Metrics = append(doc.Metrics, metric)
+	}
+	if payload, exists := message.Contents["CommitsStat"]; exists {
+		var result pb.CommitsAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode CommitsStat: %w", err)
+		}
+		if metric, ok := firstResponseSurrogate(result.Commits); ok {
+			doc.Metrics = append(doc.Metrics, metric)
+		}
+	}
+	if payload, exists := message.Contents["Devs"]; exists {
+		var result pb.DevsAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode Devs: %w", err)
+		}
+		var added, removed, changed float64
+		for _, tick := range result.Ticks {
+			for _, stats := range tick.Devs {
+				added += float64(stats.GetStats().GetAdded())
+				removed += float64(stats.GetStats().GetRemoved())
+				changed += float64(stats.GetStats().GetChanged())
+			}
+		}
+		doc.Metrics = append(doc.Metrics, chaossMetric{
+			ID: "code-changes-lines", Name: "Code Changes Lines", Source: "Devs",
+			Description: "The number of added and removed lines.",
+			Value:       added + removed, Unit: "lines",
+			Details: map[string]float64{"added": added, "removed": removed, "changed": changed},
+		})
+	}
+	return doc, nil
+}
+
+// firstResponseSurrogate approximates Time to First Response with the median delay between
+// authoring and committing of the first commit of each developer. The delay is the time
+// the newcomer's contribution waited to be applied or merged. The second value is false if
+// there are no commits.
+func firstResponseSurrogate(commits []*pb.Commit) (chaossMetric, bool) {
+	first := map[int32]*pb.Commit{}
+	for _, commit := range commits {
+		if prev, exists := first[commit.Author]; !exists || commit.WhenUnixTime < prev.WhenUnixTime {
+			first[commit.Author] = commit
+		}
+	}
+	if len(first) == 0 {
+		return chaossMetric{}, false
+	}
+	delays := make([]float64, 0, len(first))
+	for _, commit := range first {
+		delay := commit.CommittedUnixTime - commit.WhenUnixTime
+		if commit.CommittedUnixTime == 0 || delay < 0 {
+			delay = 0
+		}
+		delays = append(delays, float64(delay))
+	}
+	sort.Float64s(delays)
+	median := delays[len(delays)/2]
+	if len(delays)%2 == 0 {
+		median = (delays[len(delays)/2-1] + median) / 2
+	}
+	return chaossMetric{
+		ID: "time-to-first-response", Name: "Time to First Response", Source: "CommitsStat",
+		Surrogate: true,
+		Description: "The median delay between authoring and committing the first commit of " +
+			"each developer, the time a newcomer's contribution waited to be applied or merged.",
+		Value: median, Unit: "seconds",
+		Details: map[string]float64{"developers": float64(len(delays))},
+	}, true
+}
+
+// writeChaossDocument prints metrics.json.
+func writeChaossDocument(writer io.Writer, doc *chaossDocument) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "%s\n", data)
+	return err
+}
+
+// exportChaossCmd writes the CHAOSS metrics as JSON.
+var exportChaossCmd = &cobra.Command{
+	Use:   "chaoss [flags] <results.pb>",
+	Short: "Write the CHAOSS metrics as JSON.",
+	Long: `Maps the analysis results onto the CHAOSS metrics: Bus Factor (--bus-factor),
+Elephant Factor (--contribution-diversity), Time to First Response (--commits-stat) and
+Code Changes Lines (--devs). Time to First Response is a surrogate since git does not record
+the reviews: the median delay between authoring and committing the first commit of each developer.
+"--preset chaoss" enables all the required analyses:
+
+  hercules --preset chaoss . > results.pb
+  hercules export chaoss -o metrics.json results.pb`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		doc, err := buildChaossDocument(message)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeChaossDocument(os.Stdout, doc)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeChaossDocument(file, doc); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportChaossCmd)
+	exportChaossCmd.SetUsageFunc(exportChaossCmd.UsageFunc())
+	exportChaossCmd.Flags().StringP("output", "o", "-", "Path to the JSON file to write; \"-\" prints to stdout.")
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func TestBuildChaossDocument(t *testing.T) {
+	message := fakeBundleResults(t)
+	message.Contents["ContributionDiversity"] = mustMarshal(t, &pb.ContributionDiversityResults{
+		Quarters: map[int32]*pb.DiversityQuarter{
+			2024 * 4:   {Commits: map[int32]int32{0: 3, 1: 1}},
+			2024*4 + 1: {Commits: map[int32]int32{1: 2}},
+		},
+		DevIndex:      []string{"alice", "bob"},
+		Organizations: []string{"acme.com", "other.org"},
+	})
+	message.Contents["CommitsStat"] = mustMarshal(t, &pb.CommitsAnalysisResults{
+		Commits: []*pb.Commit{
+			{Author: 0, WhenUnixTime: 100, CommittedUnixTime: 400},
+			{Author: 0, WhenUnixTime: 50, CommittedUnixTime: 150},
+			{Author: 1, WhenUnixTime: 200, CommittedUnixTime: 500},
+			{Author: 2, WhenUnixTime: 300, CommittedUnixTime: 200},
+		},
+	})
+	doc, err := buildChaossDocument(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Repository != "test" || doc.Period == nil || doc.Period.Start != "1970-01-01T00:16:40Z" {
+		t.Fatalf("unexpected header: %+v %+v", doc, doc.Period)
+	}
+	metrics := map[string]chaossMetric{}
+	for _, metric := range doc.Metrics {
+		metrics[metric.ID] = metric
+	}
+	if len(metrics) != 4 {
+		t.Fatalf("unexpected metrics: %+v", doc.Metrics)
+	}
+	if metrics["bus-factor"].Value != 2 {
+		t.Fatalf("unexpected bus factor: %+v", metrics["bus-factor"])
+	}
+	elephant := metrics["elephant-factor"]
+	if elephant.Value != 1 || len(elephant.Periods) != 2 ||
+		elephant.Periods[1].Start != "2024-04-01T00:00:00Z" || elephant.Periods[1].End != "2024-07-01T00:00:00Z" ||
+		*elephant.Periods[1].Value != 1 {
+		t.Fatalf("unexpected elephant factor: %+v", elephant)
+	}
+	// the delays of the first commits are 100, 300 and 0
+	response := metrics["time-to-first-response"]
+	if !response.Surrogate || response.Value != 100 || response.Details["developers"] != 3 {
+		t.Fatalf("unexpected time to first response: %+v", response)
+	}
+	lines := metrics["code-changes-lines"]
+	if lines.Value != 14 || lines.Details["added"] != 13 || lines.Details["changed"] != 1 {
+		t.Fatalf("unexpected code changes lines: %+v", lines)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err = writeChaossDocument(buffer, doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err = json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded["generator"] != "hercules" || !strings.Contains(buffer.String(), `"id": "bus-factor"`) {
+		t.Fatalf("unexpected JSON: %s", buffer.String())
+	}
+}
+
+func TestBuildChaossDocumentEmpty(t *testing.T) {
+	doc, err := buildChaossDocument(pb.AnalysisResults{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Period != nil || len(doc.Metrics) != 0 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	if _, ok := firstResponseSurrogate(nil); ok {
+		t.Fatal("expected no metric")
+	}
+	buffer := &bytes.Buffer{}
+	if err = writeChaossDocument(buffer, doc); err != nil || !strings.Contains(buffer.String(), `"metrics": []`) {
+		t.Fatalf("unexpected JSON: %v %s", err, buffer.String())
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+)
+
+// The formats of `hercules export couples`.
+const (
+	couplesGraphFormatGraphML = "graphml"
+	couplesGraphFormatGEXF    = "gexf"
+	couplesGraphFormatDOT     = "dot"
+)
+
+// couplesGraph is the undirected coupling network of the files or the developers.
+type couplesGraph struct {
+	Nodes []couplesGraphNode
+	Edges []couplesGraphEdge
+}
+
+// couplesGraphNode is a file or a developer.
+type couplesGraphNode struct {
+	Label string
+	// Changes is the diagonal element of the co-occurrence matrix: the number of the commits
+	// which changed the file, or the sum of the commits of the developer over the files.
+	Changes int64
+}
+
+// couplesGraphEdge connects two nodes, Source < Target.
+type couplesGraphEdge struct {
+	Source int
+	Target int
+	// Weight is the number of the co-occurrences.
+	Weight int64
+}
+
+// buildCouplesGraph converts the co-occurrence matrix of Couples to the graph. The edges lighter
+// than minWeight are dropped. If top is positive, each node keeps only its top heaviest edges;
+// an edge survives if either of its nodes keeps it. The nodes without edges are dropped.
+func buildCouplesGraph(message pb.AnalysisResults, people bool, minWeight int64, top int) (*couplesGraph, error) {
+	payload, exists := message.Contents["Couples"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain Couples")
+	}
+	var couples pb.CouplesAnalysisResults
+	if err := proto.Unmarshal(payload, &couples); err != nil {
+		return nil, fmt.Errorf("failed to decode Couples: %w", err)
+	}
+	matrix := couples.FileCouples
+	if people {
+		matrix = couples.PeopleCouples
+	}
+	if matrix == nil {
+		return nil, fmt.Errorf("Couples do not contain the co-occurrence matrix")
+	}
+	if err := matrix.Matrix.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid Couples matrix: %w", err)
+	}
+	csr := matrix.Matrix
+	if int(csr.NumberOfRows) > len(matrix.Index) || int(csr.NumberOfColumns) > len(matrix.Index) {
+		return nil, fmt.Errorf("the Couples matrix %dx%d does not match %d names",
+			csr.NumberOfRows, csr.NumberOfColumns, len(matrix.Index))
+	}
+
+	nodes := make([]couplesGraphNode, len(matrix.Index))
+	for i, name := range matrix.Index {
+		if people {
+			name = core.IdentityLabel(name)
+		}
+		nodes[i].Label = name
+	}
+	var edges []couplesGraphEdge
+	for i := 0; i < int(csr.NumberOfRows); i++ {
+		for k := csr.Indptr[i]; k < csr.Indptr[i+1]; k++ {
+			j := int(csr.Indices[k])
+			switch {
+			case j == i:
+				nodes[i].Changes = csr.Data[k]
+			case j > i && csr.Data[k] >= minWeight && csr.Data[k] > 0:
+				edges = append(edges, couplesGraphEdge{Source: i, Target: j, Weight: csr.Data[k]})
+			}
+		}
+	}
+	if top > 0 {
+		edges = pruneCouplesEdges(edges, len(nodes), top)
+	}
+
+	remap := make([]int, len(nodes))
+	for _, edge := range edges {
+		remap[edge.Source] = 1
+		remap[edge.Target] = 1
+	}
+	graph := &couplesGraph{}
+	for i, node := range nodes {
+		if remap[i] == 0 {
+			continue
+		}
+		remap[i] = len(graph.Nodes)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	for _, edge := range edges {
+		edge.Source, edge.Target = remap[edge.Source], remap[edge.Target]
+		graph.Edges = append(graph.Edges, edge)
+	}
+	return graph, nil
+}
+
+// pruneCouplesEdges keeps the top heaviest edges of each node. The original order is preserved.
+func pruneCouplesEdges(edges []couplesGraphEdge, nodes int, top int) []couplesGraphEdge {
+	incident := make([][]int, nodes)
+	for e, edge := range edges {
+		incident[edge.Source] = append(incident[edge.Source], e)
+		incident[edge.Target] = append(incident[edge.Target], e)
+	}
+	keep := make([]bool, len(edges))
+	for _, list := range incident {
+		sort.SliceStable(list, func(i, j int) bool {
+			return edges[list[i]].Weight > edges[list[j]].Weight
+		})
+		if len(list) > top {
+			list = list[:top]
+		}
+		for _, e := range list {
+			keep[e] = true
+		}
+	}
+	pruned := make([]couplesGraphEdge, 0, len(edges))
+	for e, edge := range edges {
+		if keep[e] {
+			pruned = append(pruned, edge)
+		}
+	}
+	return pruned
+}
+
+// escapeXML escapes the text for the attribute values and the character data.
+func escapeXML(text string) string {
+	builder := &strings.Builder{}
+	_ = xml.EscapeText(builder, []byte(text))
+	return builder.String()
+}
+
+// escapeDOT escapes the text for a quoted DOT identifier.
+func escapeDOT(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, `"`, `\"`)
+	return strings.ReplaceAll(text, "\n", `\n`)
+}
+
+// writeCouplesGraph prints the graph in GraphML, GEXF or DOT.
+func writeCouplesGraph(writer io.Writer, graph *couplesGraph, format string) error {
+	builder := &strings.Builder{}
+	switch format {
+	case couplesGraphFormatGraphML:
+		builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="label" for="node" attr.name="label" attr.type="string"/>
+  <key id="changes" for="node" attr.name="changes" attr.type="long"/>
+  <key id="weight" for="edge" attr.name="weight" attr.type="long"/>
+  <graph id="couples" edgedefault="undirected">
+`)
+		for i, node := range graph.Nodes {
+			fmt.Fprintf(builder, "    <node id=\"n%d\"><data key=\"label\">%s</data>"+
+				"<data key=\"changes\">%d</data></node>\n", i, escapeXML(node.Label), node.Changes)
+		}
+		for _, edge := range graph.Edges {
+			fmt.Fprintf(builder, "    <edge source=\"n%d\" target=\"n%d\"><data key=\"weight\">%d</data></edge>\n",
+				edge.Source, edge.Target, edge.Weight)
+		}
+		builder.WriteString("  </graph>\n</graphml>\n")
+	case couplesGraphFormatGEXF:
+		builder.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<gexf xmlns="http://gexf.net/1.3" version="1.3">
+  <graph mode="static" defaultedgetype="undirected">
+    <attributes class="node">
+      <attribute id="0" title="changes" type="long"/>
+    </attributes>
+    <nodes>
+`)
+		for i, node := range graph.Nodes {
+			fmt.Fprintf(builder, "      <node id=\"n%d\" label=\"%s\"><attvalues>"+
+				"<attvalue for=\"0\" value=\"%d\"/></attvalues></node>\n", i, escapeXML(node.Label), node.Changes)
+		}
+		builder.WriteString("    </nodes>\n    <edges>\n")
+		for i, edge := range graph.Edges {
+			fmt.Fprintf(builder, "      <edge id=\"e%d\" source=\"n%d\" target=\"n%d\" weight=\"%d\"/>\n",
+				i, edge.Source, edge.Target, edge.Weight)
+		}
+		builder.WriteString("    </edges>\n  </graph>\n</gexf>\n")
+	case couplesGraphFormatDOT:
+		builder.WriteString("graph couples {\n")
+		for i, node := range graph.Nodes {
+			fmt.Fprintf(builder, "  n%d [label=\"%s\", changes=%d];\n", i, escapeDOT(node.Label), node.Changes)
+		}
+		for _, edge := range graph.Edges {
+			fmt.Fprintf(builder, "  n%d -- n%d [weight=%d];\n", edge.Source, edge.Target, edge.Weight)
+		}
+		builder.WriteString("}\n")
+	default:
+		return fmt.Errorf("unknown graph format: %s", format)
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// exportCouplesCmd writes the coupling network as a graph.
+var exportCouplesCmd = &cobra.Command{
+	Use:   "couples [flags] <results.pb>",
+	Short: "Write the file or the developer coupling network of --couples as GraphML, GEXF or DOT.",
+	Long: `Converts the co-occurrence matrix of --couples to an undirected weighted graph which can be
+loaded into Gephi, Neo4j, Cytoscape or Graphviz. The nodes are the files, or the developers with
+--people, and carry the number of their changes; the edges carry the number of the co-changes.
+--min-weight drops the light edges and --top keeps only the heaviest edges of each node.
+The nodes without edges are dropped.
+
+  hercules --couples --pb . > results.pb
+  hercules export couples --format gexf --min-weight 3 --top 10 -o couples.gexf results.pb`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		people, _ := cmd.Flags().GetBool("people")
+		minWeight, _ := cmd.Flags().GetInt64("min-weight")
+		top, _ := cmd.Flags().GetInt("top")
+		output, _ := cmd.Flags().GetString("output")
+		switch format {
+		case couplesGraphFormatGraphML, couplesGraphFormatGEXF, couplesGraphFormatDOT:
+		default:
+			return fmt.Errorf("unknown graph format: %s", format)
+		}
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		graph, err := buildCouplesGraph(message, people, minWeight, top)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeCouplesGraph(os.Stdout, graph, format)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeCouplesGraph(file, graph, format); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportCouplesCmd)
+	exportCouplesCmd.SetUsageFunc(exportCouplesCmd.UsageFunc())
+	exportCouplesCmd.Flags().String("format", couplesGraphFormatGraphML, "Graph format: graphml, gexf or dot.")
+	exportCouplesCmd.Flags().Bool("people", false, "Export the developer coupling instead of the files.")
+	exportCouplesCmd.Flags().Int64("min-weight", 1, "Drop the edges with fewer co-changes.")
+	exportCouplesCmd.Flags().Int("top", 0, "Keep only this number of the heaviest edges of each node; 0 keeps all.")
+	exportCouplesCmd.Flags().StringP("output", "o", "-", "Path to the file to write; \"-\" prints to stdout.")
+}
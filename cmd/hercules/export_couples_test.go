@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func fakeCouplesResults(t *testing.T) pb.AnalysisResults {
+	return pb.AnalysisResults{Contents: map[string][]byte{
+		"Couples": mustMarshal(t, &pb.CouplesAnalysisResults{
+			FileCouples: &pb.Couples{
+				Index: []string{"a.go", "b.go", "c&d.go", "e.go"},
+				Matrix: pb.MapToCompressedSparseRowMatrix([]map[int]int64{
+					{0: 5, 1: 4, 2: 1},
+					{0: 4, 1: 6, 2: 2},
+					{0: 1, 1: 2, 2: 3},
+					{3: 1},
+				}),
+			},
+			PeopleCouples: &pb.Couples{
+				Index:  []string{"alice|alice@example.com", "bob"},
+				Matrix: pb.MapToCompressedSparseRowMatrix([]map[int]int64{{0: 7, 1: 3}, {0: 3, 1: 2}}),
+			},
+		}),
+	}}
+}
+
+func TestBuildCouplesGraph(t *testing.T) {
+	message := fakeCouplesResults(t)
+	graph, err := buildCouplesGraph(message, false, 1, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(graph.Nodes) != 3 || graph.Nodes[2].Label != "c&d.go" || graph.Nodes[1].Changes != 6 {
+		t.Fatalf("unexpected nodes: %+v", graph.Nodes)
+	}
+	if len(graph.Edges) != 3 || graph.Edges[0] != (couplesGraphEdge{Source: 0, Target: 1, Weight: 4}) {
+		t.Fatalf("unexpected edges: %+v", graph.Edges)
+	}
+	graph, _ = buildCouplesGraph(message, false, 2, 0)
+	if len(graph.Edges) != 2 {
+		t.Fatalf("unexpected edges with min weight: %+v", graph.Edges)
+	}
+	// a.go keeps a-b, b.go keeps a-b, c&d.go keeps b-c
+	graph, _ = buildCouplesGraph(message, false, 1, 1)
+	if len(graph.Edges) != 2 || graph.Edges[1] != (couplesGraphEdge{Source: 1, Target: 2, Weight: 2}) {
+		t.Fatalf("unexpected edges with top: %+v", graph.Edges)
+	}
+	graph, _ = buildCouplesGraph(message, true, 1, 0)
+	if len(graph.Nodes) != 2 || graph.Nodes[0].Label != "alice" || graph.Edges[0].Weight != 3 {
+		t.Fatalf("unexpected people graph: %+v", graph)
+	}
+	if _, err = buildCouplesGraph(pb.AnalysisResults{}, false, 1, 0); err == nil {
+		t.Fatal("expected an error without Couples")
+	}
+}
+
+func TestWriteCouplesGraph(t *testing.T) {
+	graph := &couplesGraph{
+		Nodes: []couplesGraphNode{{Label: "a.go", Changes: 5}, {Label: `c&"d".go`, Changes: 3}},
+		Edges: []couplesGraphEdge{{Source: 0, Target: 1, Weight: 2}},
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeCouplesGraph(buffer, graph, couplesGraphFormatDOT); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buffer.String() != `graph couples {
+  n0 [label="a.go", changes=5];
+  n1 [label="c&\"d\".go", changes=3];
+  n0 -- n1 [weight=2];
+}
+` {
+		t.Fatalf("unexpected DOT:\n%s", buffer.String())
+	}
+	for _, format := range []string{couplesGraphFormatGraphML, couplesGraphFormatGEXF} {
+		buffer.Reset()
+		if err := writeCouplesGraph(buffer, graph, format); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var document struct{}
+		if err := xml.Unmarshal(buffer.Bytes(), &document); err != nil {
+			t.Fatalf("invalid %s: %v\n%s", format, err, buffer.String())
+		}
+		if !strings.Contains(buffer.String(), "c&amp;&#34;d&#34;.go") {
+			t.Fatalf("unescaped label in %s:\n%s", format, buffer.String())
+		}
+	}
+	if !strings.Contains(buffer.String(), `<edge id="e0" source="n0" target="n1" weight="2"/>`) {
+		t.Fatalf("unexpected GEXF:\n%s", buffer.String())
+	}
+	if err := writeCouplesGraph(buffer, graph, "svg"); err == nil {
+		t.Fatal("expected an error for the unknown format")
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+)
+
+// The formats of `hercules export ownership`.
+const (
+	ownershipMatrixFormatJSON    = "json"
+	ownershipMatrixFormatCSV     = "csv"
+	ownershipMatrixFormatParquet = "parquet"
+)
+
+// ownershipOthers is the author name of the lines which do not belong to the top owners
+// in CSV and Parquet.
+const ownershipOthers = "<others>"
+
+// ownershipMatrix is the sparse file × author ownership matrix at the final tick.
+type ownershipMatrix struct {
+	Repository string `json:"repository"`
+	// Top is the maximum number of the owners of each file.
+	Top     int                  `json:"top"`
+	Authors []string             `json:"authors"`
+	Files   []ownershipMatrixRow `json:"files"`
+}
+
+// ownershipMatrixRow is the file with its top owners.
+type ownershipMatrixRow struct {
+	Path  string `json:"path"`
+	Lines int64  `json:"lines"`
+	// Owners are sorted by Lines descending.
+	Owners []ownershipMatrixCell `json:"owners"`
+	// OtherLines is the number of the lines which belong to the rest of the authors or which
+	// have unknown authors.
+	OtherLines int64 `json:"other_lines"`
+}
+
+// ownershipMatrixCell is the number of the lines of the file owned by the author.
+type ownershipMatrixCell struct {
+	// Author is the index in ownershipMatrix.Authors.
+	Author int   `json:"author"`
+	Lines  int64 `json:"lines"`
+}
+
+// buildOwnershipMatrix extracts the ownership matrix recorded by
+// --bus-factor --bus-factor-ownership-top. The files are sorted by path.
+func buildOwnershipMatrix(message pb.AnalysisResults) (*ownershipMatrix, error) {
+	payload, exists := message.Contents["BusFactor"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain BusFactor")
+	}
+	var busFactor pb.BusFactorAnalysisResults
+	if err := proto.Unmarshal(payload, &busFactor); err != nil {
+		return nil, fmt.Errorf("failed to decode BusFactor: %w", err)
+	}
+	if busFactor.OwnershipTop <= 0 {
+		return nil, fmt.Errorf("BusFactor does not contain the ownership matrix, " +
+			"rerun with --bus-factor-ownership-top")
+	}
+	matrix := &ownershipMatrix{
+		Top:     int(busFactor.OwnershipTop),
+		Authors: make([]string, len(busFactor.DevIndex)),
+		Files:   make([]ownershipMatrixRow, 0, len(busFactor.FilesOwnership)),
+	}
+	if message.Header != nil {
+		matrix.Repository = message.Header.Repository
+	}
+	for i, name := range busFactor.DevIndex {
+		matrix.Authors[i] = core.IdentityLabel(name)
+	}
+	for path, owners := range busFactor.FilesOwnership {
+		if len(owners.Authors) != len(owners.AuthorLines) {
+			return nil, fmt.Errorf("%s: %d owners with %d line counts",
+				path, len(owners.Authors), len(owners.AuthorLines))
+		}
+		row := ownershipMatrixRow{
+			Path:       path,
+			Lines:      owners.Lines,
+			Owners:     make([]ownershipMatrixCell, len(owners.Authors)),
+			OtherLines: owners.Lines,
+		}
+		for i, author := range owners.Authors {
+			if author < 0 || int(author) >= len(matrix.Authors) {
+				return nil, fmt.Errorf("%s: author %d is out of range", path, author)
+			}
+			row.Owners[i] = ownershipMatrixCell{Author: int(author), Lines: owners.AuthorLines[i]}
+			row.OtherLines -= owners.AuthorLines[i]
+		}
+		matrix.Files = append(matrix.Files, row)
+	}
+	sort.Slice(matrix.Files, func(i, j int) bool {
+		return matrix.Files[i].Path < matrix.Files[j].Path
+	})
+	return matrix, nil
+}
+
+// longOwnershipMatrix converts the matrix to the long format: one row per file and owner with
+// an extra "<others>" row for the rest of the lines. It returns the columns.
+func longOwnershipMatrix(matrix *ownershipMatrix) (files, authors []string, lines []int64) {
+	files, authors, lines = []string{}, []string{}, []int64{}
+	for _, row := range matrix.Files {
+		for _, owner := range row.Owners {
+			files = append(files, row.Path)
+			authors = append(authors, matrix.Authors[owner.Author])
+			lines = append(lines, owner.Lines)
+		}
+		if row.OtherLines > 0 {
+			files = append(files, row.Path)
+			authors = append(authors, ownershipOthers)
+			lines = append(lines, row.OtherLines)
+		}
+	}
+	return
+}
+
+// writeOwnershipMatrix prints the matrix as JSON, or as CSV or Parquet in the long format,
+// see longOwnershipMatrix().
+func writeOwnershipMatrix(writer io.Writer, matrix *ownershipMatrix, format string) error {
+	switch format {
+	case ownershipMatrixFormatJSON:
+		data, err := json.MarshalIndent(matrix, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(writer, "%s\n", data)
+		return err
+	case ownershipMatrixFormatCSV:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write([]string{"file", "author", "lines"}); err != nil {
+			return err
+		}
+		files, authors, lines := longOwnershipMatrix(matrix)
+		for i := range files {
+			if err := csvWriter.Write([]string{files[i], authors[i], strconv.FormatInt(lines[i], 10)}); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	case ownershipMatrixFormatParquet:
+		files, authors, lines := longOwnershipMatrix(matrix)
+		return writeParquet(writer, []parquetColumn{
+			{Name: "file", Strings: files},
+			{Name: "author", Strings: authors},
+			{Name: "lines", Int64s: lines},
+		})
+	default:
+		return fmt.Errorf("unknown ownership format: %s", format)
+	}
+}
+
+// exportOwnershipCmd writes the file × author ownership matrix.
+var exportOwnershipCmd = &cobra.Command{
+	Use:   "ownership [flags] <results.pb>",
+	Short: "Write the file × author ownership matrix of --bus-factor as JSON, CSV or Parquet.",
+	Long: `Writes the current owners of each file for the treemaps and the heatmaps. The matrix is
+sparse: --bus-factor-ownership-top sets the number of the top owners recorded per file, the rest
+of the lines are summed in "other_lines" (JSON) or in the "<others>" rows (CSV and Parquet).
+The matrix comes from the same final blame pass which computes the bus factor per subsystem.
+CSV and Parquet are in the long format with the columns file, author and lines.
+
+  hercules --bus-factor --bus-factor-ownership-top 5 --pb . > results.pb
+  hercules export ownership --format parquet -o ownership.parquet results.pb`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		switch format {
+		case ownershipMatrixFormatJSON, ownershipMatrixFormatCSV, ownershipMatrixFormatParquet:
+		default:
+			return fmt.Errorf("unknown ownership format: %s", format)
+		}
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		matrix, err := buildOwnershipMatrix(message)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeOwnershipMatrix(os.Stdout, matrix, format)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeOwnershipMatrix(file, matrix, format); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	exportCmd.AddCommand(exportOwnershipCmd)
+	exportOwnershipCmd.SetUsageFunc(exportOwnershipCmd.UsageFunc())
+	exportOwnershipCmd.Flags().String("format", ownershipMatrixFormatJSON, "Output format: json, csv or parquet.")
+	exportOwnershipCmd.Flags().StringP("output", "o", "-", "Path to the file to write; \"-\" prints to stdout.")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func fakeOwnershipResults(t *testing.T, top int32) pb.AnalysisResults {
+	return pb.AnalysisResults{
+		Header: &pb.Metadata{Repository: "repo"},
+		Contents: map[string][]byte{
+			"BusFactor": mustMarshal(t, &pb.BusFactorAnalysisResults{
+				DevIndex:     []string{"alice|alice@example.com", "Bob <bob@example.com>"},
+				Threshold:    0.8,
+				OwnershipTop: top,
+				FilesOwnership: map[string]*pb.FileOwners{
+					"src/b.go": {Lines: 10, Authors: []int32{1, 0}, AuthorLines: []int64{6, 3}},
+					"a.go":     {Lines: 4, Authors: []int32{0}, AuthorLines: []int64{4}},
+				},
+			}),
+		},
+	}
+}
+
+func TestBuildOwnershipMatrix(t *testing.T) {
+	matrix, err := buildOwnershipMatrix(fakeOwnershipResults(t, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if matrix.Repository != "repo" || matrix.Top != 2 || matrix.Authors[0] != "alice" ||
+		matrix.Authors[1] != "Bob" {
+		t.Fatalf("unexpected header: %+v", matrix)
+	}
+	if len(matrix.Files) != 2 || matrix.Files[0].Path != "a.go" || matrix.Files[0].OtherLines != 0 {
+		t.Fatalf("unexpected files: %+v", matrix.Files)
+	}
+	row := matrix.Files[1]
+	if row.OtherLines != 1 || row.Owners[0] != (ownershipMatrixCell{Author: 1, Lines: 6}) {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+	if _, err = buildOwnershipMatrix(fakeOwnershipResults(t, 0)); err == nil {
+		t.Fatal("expected an error without the ownership matrix")
+	}
+	if _, err = buildOwnershipMatrix(pb.AnalysisResults{}); err == nil {
+		t.Fatal("expected an error without BusFactor")
+	}
+}
+
+func TestWriteOwnershipMatrix(t *testing.T) {
+	matrix, err := buildOwnershipMatrix(fakeOwnershipResults(t, 2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeOwnershipMatrix(buffer, matrix, ownershipMatrixFormatCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buffer.String() != `file,author,lines
+a.go,alice,4
+src/b.go,Bob,6
+src/b.go,alice,3
+src/b.go,<others>,1
+` {
+		t.Fatalf("unexpected CSV:\n%s", buffer.String())
+	}
+	buffer.Reset()
+	if err := writeOwnershipMatrix(buffer, matrix, ownershipMatrixFormatJSON); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decoded ownershipMatrix
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buffer.String())
+	}
+	if len(decoded.Files) != 2 || decoded.Files[1].Owners[1].Lines != 3 {
+		t.Fatalf("unexpected JSON:\n%s", buffer.String())
+	}
+	buffer.Reset()
+	if err := writeOwnershipMatrix(buffer, matrix, ownershipMatrixFormatParquet); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buffer.Bytes()
+	if !bytes.HasPrefix(data, []byte(parquetMagic)) || !bytes.HasSuffix(data, []byte(parquetMagic)) {
+		t.Fatalf("invalid Parquet magic: %q", data)
+	}
+	for _, value := range []string{"src/b.go", "<others>", "author", "lines"} {
+		if !bytes.Contains(data, []byte(value)) {
+			t.Fatalf("%s is missing in Parquet: %q", value, data)
+		}
+	}
+	if err := writeOwnershipMatrix(buffer, matrix, "xml"); err == nil {
+		t.Fatal("expected an error for the unknown format")
+	}
+}
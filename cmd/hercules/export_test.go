@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func TestExtractSummaryMetrics(t *testing.T) {
+	message := fakeBundleResults(t)
+	message.Contents["HotspotRisk"] = mustMarshal(t, &pb.HotspotRiskResults{
+		Files: []*pb.FileRisk{{Path: "a.go", RiskScore: 0.25}, {Path: "b.go", RiskScore: 0.75}},
+	})
+	message.Contents["OwnershipConcentration"] = mustMarshal(t, &pb.OwnershipConcentrationResults{
+		Snapshots: map[int32]*pb.OwnershipConcentrationTickSnapshot{0: {Gini: 0.5, Hhi: 0.625}},
+	})
+	metrics, err := extractSummaryMetrics(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []summaryMetric{
+		{Name: "hercules_bus_factor", Value: 2},
+		{Name: "hercules_commits", Value: 3},
+		{Name: "hercules_contributors", Value: 2},
+		{Name: "hercules_hotspot_risk_top_score", Value: 0.75},
+		{Name: "hercules_ownership_gini", Value: 0.5},
+		{Name: "hercules_ownership_hhi", Value: 0.625},
+		{Name: "hercules_total_lines", Value: 20},
+	}
+	for i := range metrics {
+		if metrics[i].Help == "" {
+			t.Fatalf("%s has no help", metrics[i].Name)
+		}
+		metrics[i].Help = ""
+	}
+	if !reflect.DeepEqual(metrics, expected) {
+		t.Fatalf("unexpected metrics: got %v want %v", metrics, expected)
+	}
+}
+
+func TestExtractSummaryMetricsMissing(t *testing.T) {
+	metrics, err := extractSummaryMetrics(pb.AnalysisResults{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(metrics) != 0 {
+		t.Fatalf("unexpected metrics: %v", metrics)
+	}
+	_, err = extractSummaryMetrics(pb.AnalysisResults{Contents: map[string][]byte{"HotspotRisk": {0xff}}})
+	if err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := writeOpenMetrics(buffer, `https://example.com/"repo"`, []summaryMetric{
+		{Name: "hercules_bus_factor", Help: "Bus factor.", Value: 2},
+		{Name: "hercules_ownership_gini", Help: "Gini.", Value: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `# TYPE hercules_bus_factor gauge
+# HELP hercules_bus_factor Bus factor.
+hercules_bus_factor{repository="https://example.com/\"repo\""} 2
+# TYPE hercules_ownership_gini gauge
+# HELP hercules_ownership_gini Gini.
+hercules_ownership_gini{repository="https://example.com/\"repo\""} 0.5
+# EOF
+`
+	if buffer.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buffer.String())
+	}
+}
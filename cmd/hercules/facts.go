@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// maxFactValueLength is the maximum length of the printed fact value.
+const maxFactValueLength = 80
+
+// formatFactValue prints the fact value compactly: the references are printed as their types
+// and the long collections as their types and lengths.
+func formatFactValue(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+	val := reflect.ValueOf(value)
+	isReference := func(kind reflect.Kind) bool {
+		switch kind {
+		case reflect.Ptr, reflect.Func, reflect.Chan, reflect.Interface, reflect.UnsafePointer,
+			reflect.Struct:
+			return true
+		}
+		return false
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		if val.Len() > 8 || isReference(val.Type().Elem().Kind()) {
+			return fmt.Sprintf("%T (len %d)", value, val.Len())
+		}
+	default:
+		if isReference(val.Kind()) {
+			return fmt.Sprintf("%T", value)
+		}
+	}
+	text := strings.ReplaceAll(fmt.Sprint(value), "\n", " ")
+	if len(text) > maxFactValueLength {
+		text = text[:maxFactValueLength-3] + "..."
+	}
+	return text
+}
+
+// factOrigin describes where the initial value of the fact comes from.
+func factOrigin(fact core.FactProvenance, flags *pflag.FlagSet, registry *core.PipelineItemRegistry) string {
+	if !fact.Initial {
+		if len(fact.Providers) == 0 {
+			return "pipeline"
+		}
+		return ""
+	}
+	flag := registry.FactFlag(fact.Name)
+	if flag == "" {
+		return "hercules"
+	}
+	if flags.Changed(flag) {
+		return "--" + flag
+	}
+	return "default of --" + flag
+}
+
+// writeFacts prints each fact with its final value and the chain of its origin and providers.
+func writeFacts(writer io.Writer, facts []core.FactProvenance, flags *pflag.FlagSet,
+	registry *core.PipelineItemRegistry,
+) error {
+	var builder strings.Builder
+	for _, fact := range facts {
+		fmt.Fprintf(&builder, "%s = %s\n", fact.Name, formatFactValue(fact.Value))
+		var chain []string
+		if origin := factOrigin(fact, flags, registry); origin != "" {
+			chain = append(chain, origin)
+		}
+		for _, provider := range fact.Providers {
+			chain = append(chain, provider.Item+"."+provider.Stage)
+		}
+		fmt.Fprintf(&builder, "    %s\n", strings.Join(chain, " -> "))
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// factsCmd explains the configuration facts of the pipeline.
+var factsCmd = &cobra.Command{
+	Use:   "facts [flags] <repository> [cache]",
+	Short: "Print the configuration facts and where each value comes from.",
+	Long: `Builds and initializes the pipeline exactly like the base command with the same flags,
+but does not run it. Prints every fact with its final value followed by its provenance: the
+command line flag or its default, then the items which set or changed the fact in Configure()
+and ConfigureUpstream(), in the order of the calls. "pipeline" marks the facts which the
+pipeline sets itself and "hercules" those which the command line tool sets, e.g. the commits.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		hercules.Deprecations.ResolveFlagAliases(flags)
+		applyPreset(flags)
+		disableStatus, _ := flags.GetBool("quiet")
+		pipeline, _, _ := initializePipeline(flags, args, disableStatus)
+		return writeFacts(os.Stdout, pipeline.ExplainFacts(), flags, hercules.Registry)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(factsCmd)
+	factsCmd.SetUsageFunc(factsCmd.UsageFunc())
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/spf13/pflag"
+)
+
+func TestFormatFactValue(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, "<nil>"},
+		{10, "10"},
+		{"text", "text"},
+		{[]string{"a", "b"}, "[a b]"},
+		{make([]int, 10), "[]int (len 10)"},
+		{[]*core.Pipeline{nil}, "[]*core.Pipeline (len 1)"},
+		{&core.Pipeline{}, "*core.Pipeline"},
+		{core.FactProvider{}, "core.FactProvider"},
+		{"a\nb", "a b"},
+		{strings.Repeat("x", 100), strings.Repeat("x", 77) + "..."},
+	}
+	for _, c := range cases {
+		if actual := formatFactValue(c.value); actual != c.expected {
+			t.Fatalf("%v: expected %q, got %q", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestWriteFacts(t *testing.T) {
+	registry := core.Registry
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	facts, _, _ := registry.AddFlags(flags)
+	if flag := registry.FactFlag(core.ConfigPipelineCommitStride); flag != "stride" {
+		t.Fatalf("unexpected flag: %s", flag)
+	}
+	if err := flags.Parse([]string{"--stride", "5"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	provenance := []core.FactProvenance{
+		{Name: core.ConfigPipelineCommitStride, Value: facts[core.ConfigPipelineCommitStride], Initial: true},
+		{Name: core.ConfigPipelineMainlineOnly, Value: facts[core.ConfigPipelineMainlineOnly], Initial: true,
+			Providers: []core.FactProvider{{Item: "Item", Stage: core.FactStageConfigureUpstream}}},
+		{Name: core.ConfigPipelineCommits, Value: []int{}, Initial: true},
+		{Name: core.ConfigLogger, Value: core.NewLogger()},
+		{Name: "Fact", Value: 1, Providers: []core.FactProvider{
+			{Item: "A", Stage: core.FactStageConfigure}, {Item: "B", Stage: core.FactStageConfigure},
+		}},
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeFacts(buffer, provenance, flags, registry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `Pipeline.CommitStride = 5
+    --stride
+Pipeline.MainlineOnly = false
+    default of --mainline-only -> Item.ConfigureUpstream
+Pipeline.Commits = []
+    hercules
+Core.Logger = *core.DefaultLogger
+    pipeline
+Fact = 1
+    A.Configure -> B.Configure
+`
+	if buffer.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buffer.String())
+	}
+}
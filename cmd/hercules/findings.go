@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+// The kinds of the findings.
+const (
+	findingBusFactor  = "bus_factor"
+	findingHotspot    = "hotspot"
+	findingGrowth     = "growth"
+	findingDepartures = "departures"
+)
+
+const (
+	// findingsPerKind is the maximum number of the findings of the same kind.
+	findingsPerKind = 10
+	// findingsWindow is the recent period of the growth and the inactivity which counts
+	// as a departure.
+	findingsWindow = 90 * 24 * time.Hour
+	// findingsHotspotPercentile selects the hotspots among the files by the risk score.
+	findingsHotspotPercentile = 0.95
+)
+
+// finding is a notable fact extracted from the analysis results.
+type finding struct {
+	Kind string `json:"kind"`
+	// Subject is the directory, the file or the quarter which the finding is about.
+	Subject string `json:"subject"`
+	// Severity is between 0 and 1 and ranks the findings of all the kinds.
+	Severity float64 `json:"severity"`
+	// Value is the metric which triggered the finding.
+	Value   float64 `json:"value"`
+	Message string  `json:"message"`
+}
+
+// buildFindings scans the analysis results for the notable findings: the subsystems with bus
+// factor 1 or 2 (--bus-factor), the files above the 95th percentile of the risk score
+// (--hotspot-risk), the fastest-growing directory in the last 90 days (--burndown-dirs-depth)
+// and the quarter with the most departures of the developers (--devs). The findings are
+// sorted by severity descending; the kinds whose source analysis is missing are not reported.
+func buildFindings(message pb.AnalysisResults) ([]finding, error) {
+	var findings []finding
+	for _, scan := range []func(pb.AnalysisResults) ([]finding, error){
+		findBusFactorSubsystems, findHotspots, findFastestGrowingDirectory, findDeparturesQuarter,
+	} {
+		found, err := scan(message)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return findings, nil
+}
+
+// limitFindings keeps the most severe findings of the same kind.
+func limitFindings(findings []finding) []finding {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		return findings[i].Subject < findings[j].Subject
+	})
+	if len(findings) > findingsPerKind {
+		findings = findings[:findingsPerKind]
+	}
+	return findings
+}
+
+// findBusFactorSubsystems reports the directories which depend on one or two developers.
+func findBusFactorSubsystems(message pb.AnalysisResults) ([]finding, error) {
+	payload, exists := message.Contents["BusFactor"]
+	if !exists {
+		return nil, nil
+	}
+	var result pb.BusFactorAnalysisResults
+	if err := proto.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode BusFactor: %w", err)
+	}
+	var findings []finding
+	for dir, busFactor := range result.SubsystemBusFactor {
+		if busFactor < 1 || busFactor > 2 {
+			continue
+		}
+		severity := 1.0
+		developers := "a single developer owns"
+		if busFactor == 2 {
+			severity = 0.75
+			developers = "two developers own"
+		}
+		findings = append(findings, finding{
+			Kind: findingBusFactor, Subject: dir, Severity: severity, Value: float64(busFactor),
+			Message: fmt.Sprintf("Bus factor of %s is %d: %s %.0f%% of its lines.",
+				dir, busFactor, developers, result.Threshold*100),
+		})
+	}
+	return limitFindings(findings), nil
+}
+
+// findHotspots reports the files whose risk score exceeds the 95th percentile.
+func findHotspots(message pb.AnalysisResults) ([]finding, error) {
+	payload, exists := message.Contents["HotspotRisk"]
+	if !exists {
+		return nil, nil
+	}
+	var result pb.HotspotRiskResults
+	if err := proto.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode HotspotRisk: %w", err)
+	}
+	if len(result.Files) == 0 {
+		return nil, nil
+	}
+	scores := make([]float64, len(result.Files))
+	for i, file := range result.Files {
+		scores[i] = file.RiskScore
+	}
+	sort.Float64s(scores)
+	// nearest-rank percentile
+	threshold := scores[int(math.Ceil(findingsHotspotPercentile*float64(len(scores))))-1]
+	var findings []finding
+	for _, file := range result.Files {
+		if file.RiskScore <= threshold {
+			continue
+		}
+		findings = append(findings, finding{
+			Kind: findingHotspot, Subject: file.Path, Severity: math.Min(file.RiskScore, 1),
+			Value: file.RiskScore,
+			Message: fmt.Sprintf("%s is a hotspot: its risk score %.2f is above the 95th percentile %.2f.",
+				file.Path, file.RiskScore, threshold),
+		})
+	}
+	return limitFindings(findings), nil
+}
+
+// findFastestGrowingDirectory reports the directory which gained the most lines in the last
+// 90 days, or in the last resampled period if it is longer. The severity is the share of its current lines which were added in that period.
+func findFastestGrowingDirectory(message pb.AnalysisResults) ([]finding, error) {
+	payload, exists := message.Contents["Burndown"]
+	if !exists {
+		return nil, nil
+	}
+	var result pb.BurndownAnalysisResults
+	if err := proto.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode Burndown: %w", err)
+	}
+	back, period := 1, "the last 90 days"
+	switch result.Resample {
+	case "month":
+		back = 3
+	case "quarter":
+		period = "the last quarter"
+	case "year":
+		period = "the last year"
+	default:
+		tickSize := time.Duration(result.TickSize)
+		if tickSize <= 0 {
+			tickSize = 24 * time.Hour
+		}
+		sampling := time.Duration(result.Sampling)
+		if sampling <= 0 {
+			sampling = 1
+		}
+		back = int(math.Ceil(float64(findingsWindow) / float64(sampling*tickSize)))
+	}
+	best := finding{}
+	for _, dir := range result.Directories {
+		if len(dir.Rows) == 0 {
+			continue
+		}
+		last := len(dir.Rows) - 1
+		before := last - back
+		if before < 0 {
+			before = 0
+		}
+		now, then := sumBurndownRow(dir.Rows[last]), sumBurndownRow(dir.Rows[before])
+		growth := now - then
+		if growth <= 0 || growth < int64(best.Value) || (growth == int64(best.Value) && dir.Name > best.Subject) {
+			continue
+		}
+		best = finding{
+			Kind: findingGrowth, Subject: dir.Name, Severity: float64(growth) / float64(now),
+			Value: float64(growth),
+			Message: fmt.Sprintf("%s is the fastest-growing directory: +%d lines in %s, %d lines now.",
+				dir.Name, growth, period, now),
+		}
+	}
+	if best.Kind == "" {
+		return nil, nil
+	}
+	return []finding{best}, nil
+}
+
+// sumBurndownRow returns the number of the alive lines in the burndown sample.
+func sumBurndownRow(row *pb.BurndownSparseMatrixRow) int64 {
+	sum := int64(0)
+	for _, value := range row.Columns {
+		sum += int64(value)
+	}
+	return sum
+}
+
+// findDeparturesQuarter reports the calendar quarter in which the most developers made their last
+// commit, counting only those who have been inactive for 90 days by the end of the history.
+// The severity is the share of all the developers who left in that quarter.
+func findDeparturesQuarter(message pb.AnalysisResults) ([]finding, error) {
+	payload, exists := message.Contents["Devs"]
+	if !exists {
+		return nil, nil
+	}
+	var result pb.DevsAnalysisResults
+	if err := proto.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode Devs: %w", err)
+	}
+	lastTicks := map[int32]int32{}
+	endTick := int32(0)
+	for tick, devs := range result.Ticks {
+		for dev, stats := range devs.Devs {
+			if stats.Commits == 0 {
+				continue
+			}
+			if last, exists := lastTicks[dev]; !exists || tick > last {
+				lastTicks[dev] = tick
+			}
+			if tick > endTick {
+				endTick = tick
+			}
+		}
+	}
+	end := bundleTickTime(message.Header, result.TickSize, int64(endTick))
+	quarters := map[string]int{}
+	for _, tick := range lastTicks {
+		last := bundleTickTime(message.Header, result.TickSize, int64(tick))
+		if time.Duration(end-last)*time.Second < findingsWindow {
+			continue
+		}
+		date := time.Unix(last, 0).UTC()
+		quarters[fmt.Sprintf("%d-Q%d", date.Year(), (int(date.Month())-1)/3+1)]++
+	}
+	best, departures := "", 0
+	for quarter, count := range quarters {
+		if count > departures || (count == departures && quarter < best) {
+			best, departures = quarter, count
+		}
+	}
+	if departures == 0 {
+		return nil, nil
+	}
+	return []finding{{
+		Kind: findingDepartures, Subject: best,
+		Severity: float64(departures) / float64(len(lastTicks)), Value: float64(departures),
+		Message: fmt.Sprintf("%s had the most departures: %d of %d developers made their last commit.",
+			best, departures, len(lastTicks)),
+	}}, nil
+}
+
+// writeFindings prints the top-level "findings" section of the YAML results.
+func writeFindings(writer io.Writer, findings []finding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(writer, "findings: []")
+		return
+	}
+	fmt.Fprintln(writer, "findings:")
+	for i, item := range findings {
+		fmt.Fprintf(writer, "  - rank: %d\n", i+1)
+		fmt.Fprintf(writer, "    kind: %s\n", item.Kind)
+		fmt.Fprintf(writer, "    subject: %s\n", yaml.SafeString(item.Subject))
+		fmt.Fprintf(writer, "    severity: %.3f\n", item.Severity)
+		fmt.Fprintf(writer, "    value: %g\n", item.Value)
+		fmt.Fprintf(writer, "    message: %s\n", yaml.SafeString(item.Message))
+	}
+}
+
+// printFindings appends the findings calculated from the results to the YAML document.
+func printFindings(
+	writer io.Writer, uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{},
+) error {
+	buffer := &bytes.Buffer{}
+	protobufResults(buffer, uri, deployed, results)
+	var message pb.AnalysisResults
+	if err := proto.Unmarshal(buffer.Bytes(), &message); err != nil {
+		return err
+	}
+	findings, err := buildFindings(message)
+	if err != nil {
+		return err
+	}
+	writeFindings(writer, findings)
+	return nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/leaves"
+)
+
+func fakeFindingsResults(t *testing.T) pb.AnalysisResults {
+	files := make([]*pb.FileRisk, 20)
+	for i := range files {
+		files[i] = &pb.FileRisk{Path: string(rune('a'+i)) + ".go", RiskScore: float64(i+1) / 100}
+	}
+	row := func(lines uint32) *pb.BurndownSparseMatrixRow {
+		return &pb.BurndownSparseMatrixRow{Columns: []uint32{lines / 2, lines - lines/2}}
+	}
+	devTicks := map[int32]*pb.TickDevs{}
+	for dev, ticks := range [][]int32{{0, 400}, {10}, {5, 20}, {100}} {
+		for _, tick := range ticks {
+			if devTicks[tick] == nil {
+				devTicks[tick] = &pb.TickDevs{Devs: map[int32]*pb.DevTick{}}
+			}
+			devTicks[tick].Devs[int32(dev)] = &pb.DevTick{Commits: 1}
+		}
+	}
+	return pb.AnalysisResults{
+		Header: &pb.Metadata{BeginUnixTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Unix()},
+		Contents: map[string][]byte{
+			"BusFactor": mustMarshal(t, &pb.BusFactorAnalysisResults{
+				SubsystemBusFactor: map[string]int32{"src": 1, "docs": 2, "lib": 5},
+				Threshold:          0.8,
+			}),
+			"HotspotRisk": mustMarshal(t, &pb.HotspotRiskResults{Files: files}),
+			"Burndown": mustMarshal(t, &pb.BurndownAnalysisResults{
+				Sampling: 30,
+				TickSize: int64(24 * time.Hour),
+				Directories: []*pb.BurndownSparseMatrix{
+					{Name: "a", Rows: []*pb.BurndownSparseMatrixRow{row(10), row(10), row(10), row(10), row(40)}},
+					{Name: "b", Rows: []*pb.BurndownSparseMatrixRow{row(0), row(0), row(0), row(100), row(90)}},
+				},
+			}),
+			"Devs": mustMarshal(t, &pb.DevsAnalysisResults{
+				Ticks:    devTicks,
+				DevIndex: []string{"alice", "bob", "carol", "dave"},
+				TickSize: int64(24 * time.Hour),
+			}),
+		},
+	}
+}
+
+func TestBuildFindings(t *testing.T) {
+	findings, err := buildFindings(fakeFindingsResults(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var kinds, subjects []string
+	for _, item := range findings {
+		kinds = append(kinds, item.Kind)
+		subjects = append(subjects, item.Subject)
+	}
+	// b gained 90 lines of 90, a gained 30 lines of 40, t.go is the only file above 0.19
+	expected := []string{"src", "b", "docs", "2020-Q1", "t.go"}
+	if strings.Join(subjects, ",") != strings.Join(expected, ",") {
+		t.Fatalf("unexpected findings: %v %v", kinds, subjects)
+	}
+	if findings[1].Kind != findingGrowth || findings[1].Value != 90 || findings[1].Severity != 1 {
+		t.Fatalf("unexpected growth: %+v", findings[1])
+	}
+	if findings[3].Value != 2 || findings[3].Severity != 0.5 {
+		t.Fatalf("unexpected departures: %+v", findings[3])
+	}
+	if findings[0].Message != "Bus factor of src is 1: a single developer owns 80% of its lines." {
+		t.Fatalf("unexpected message: %s", findings[0].Message)
+	}
+	findings, err = buildFindings(pb.AnalysisResults{})
+	if err != nil || len(findings) != 0 {
+		t.Fatalf("unexpected findings without the results: %v %v", findings, err)
+	}
+	if _, err = buildFindings(pb.AnalysisResults{Contents: map[string][]byte{"Devs": {0xff}}}); err == nil {
+		t.Fatal("expected an error for the corrupted Devs")
+	}
+}
+
+func TestWriteFindings(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writeFindings(buffer, []finding{{
+		Kind: findingHotspot, Subject: `a"b.go`, Severity: 0.5, Value: 0.25, Message: "hot",
+	}})
+	if buffer.String() != `findings:
+  - rank: 1
+    kind: hotspot
+    subject: "a\"b.go"
+    severity: 0.500
+    value: 0.25
+    message: "hot"
+` {
+		t.Fatalf("unexpected YAML:\n%s", buffer.String())
+	}
+	buffer.Reset()
+	writeFindings(buffer, nil)
+	if buffer.String() != "findings: []\n" {
+		t.Fatalf("unexpected YAML:\n%s", buffer.String())
+	}
+}
+
+func TestWriteTargetFindings(t *testing.T) {
+	leaf := &leaves.BusFactorAnalysis{}
+	deployed := []hercules.LeafPipelineItem{leaf}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil: &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3},
+		leaf: leaves.BusFactorResult{
+			Snapshots:          map[int]*leaves.BusFactorSnapshot{},
+			SubsystemBusFactor: map[string]int{"src": 1},
+			Threshold:          0.8,
+		},
+	}
+	path := filepath.Join(t.TempDir(), "out.json")
+	if err := writeTarget(outputTarget{Format: "json", Path: path, Findings: true},
+		"/repo", deployed, results); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	var document struct {
+		Findings []finding `json:"findings"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, data)
+	}
+	if len(document.Findings) != 1 || document.Findings[0].Subject != "src" {
+		t.Fatalf("unexpected findings:\n%s", data)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// authorActivity is a single resolved identity together with its commit statistics.
+type authorActivity struct {
+	ID      int       `yaml:"id"`
+	Name    string    `yaml:"name"`
+	Commits int       `yaml:"commits"`
+	First   time.Time `yaml:"first"`
+	Last    time.Time `yaml:"last"`
+}
+
+// collectIdentities runs identity.PeopleDetector over the commits and aggregates the number
+// of commits and the first and the last activity of each resolved author. The authors are sorted
+// by the number of commits in descending order. The commits which do not match any identity
+// in the people dictionary are attributed to core.AuthorMissingName.
+func collectIdentities(repository *git.Repository, commits []*object.Commit, peopleDictPath string,
+	exactSignatures bool,
+) ([]authorActivity, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	detector := &identity.PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:                     commits,
+		identity.ConfigIdentityDetectorPeopleDictPath:  peopleDictPath,
+		identity.ConfigIdentityDetectorExactSignatures: exactSignatures,
+	}
+	if err := detector.Configure(facts); err != nil {
+		return nil, err
+	}
+	if err := detector.Initialize(repository); err != nil {
+		return nil, err
+	}
+	activities := map[int]*authorActivity{}
+	for _, commit := range commits {
+		result, err := detector.Consume(map[string]interface{}{core.DependencyCommit: commit})
+		if err != nil {
+			return nil, err
+		}
+		author := result[identity.DependencyAuthor].(int)
+		when := commit.Author.When
+		activity := activities[author]
+		if activity == nil {
+			name := core.AuthorMissingName
+			if author != core.AuthorMissing {
+				name = detector.ReversedPeopleDict[author]
+			}
+			activity = &authorActivity{ID: author, Name: name, First: when, Last: when}
+			activities[author] = activity
+		}
+		activity.Commits++
+		if when.Before(activity.First) {
+			activity.First = when
+		}
+		if when.After(activity.Last) {
+			activity.Last = when
+		}
+	}
+	result := make([]authorActivity, 0, len(activities))
+	for _, activity := range activities {
+		result = append(result, *activity)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// writeIdentities prints the resolved authors in the specified format: "text" is a table,
+// "yaml" is a list of records and "people-dict" is the --people-dict file format which
+// can be edited to merge the identities and passed back to the full run.
+func writeIdentities(writer io.Writer, format string, authors []authorActivity) error {
+	var builder strings.Builder
+	switch format {
+	case "text":
+		commits := 0
+		for _, author := range authors {
+			commits += author.Commits
+		}
+		fmt.Fprintf(&builder, "%d identities in %d commits\n", len(authors), commits)
+		for _, author := range authors {
+			fmt.Fprintf(&builder, "%7d  %s  %s  %s\n", author.Commits,
+				author.First.UTC().Format("2006-01-02"), author.Last.UTC().Format("2006-01-02"), author.Name)
+		}
+	case "yaml":
+		if authors == nil {
+			authors = []authorActivity{}
+		}
+		data, err := yaml.Marshal(authors)
+		if err != nil {
+			return err
+		}
+		builder.Write(data)
+	case "people-dict":
+		for _, author := range authors {
+			if author.ID == core.AuthorMissing {
+				continue
+			}
+			builder.WriteString(author.Name)
+			builder.WriteString("\n")
+		}
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// identitiesCmd runs only the identity detection and prints the resolved authors.
+var identitiesCmd = &cobra.Command{
+	Use:   "identities [flags] <repository>",
+	Short: "Print the resolved authors with their commit counts and activity periods.",
+	Long: `Runs only the identity detection which the analyses share and prints each resolved author
+with the number of commits and the dates of the first and the last commit. This is much faster than
+a full run and shows which signatures are going to be merged. --format people-dict prints the result
+in the --people-dict file format: edit the lines to merge or split the identities and pass the file
+to the full run.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		format, _ := flags.GetString("format")
+		firstParent, _ := flags.GetBool("first-parent")
+		peopleDictPath, _ := flags.GetString("people-dict")
+		exactSignatures, _ := flags.GetBool("exact-signatures")
+		sshIdentity, _ := flags.GetString("ssh-identity")
+		repository, _, _, err := loadRepositoryWithError(args[0], "", true, sshIdentity, cloneSettings{})
+		if err != nil {
+			return err
+		}
+		commits, err := hercules.NewPipeline(repository).Commits(firstParent)
+		if err != nil {
+			return err
+		}
+		authors, err := collectIdentities(repository, commits, peopleDictPath, exactSignatures)
+		if err != nil {
+			return err
+		}
+		return writeIdentities(os.Stdout, format, authors)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(identitiesCmd)
+	identitiesCmd.SetUsageFunc(identitiesCmd.UsageFunc())
+	identitiesFlags := identitiesCmd.Flags()
+	identitiesFlags.String("format", "text", "Output format: text, yaml or people-dict.")
+	identitiesFlags.Bool("first-parent", false, "Follow only the first parent in the commit history.")
+	identitiesFlags.String("people-dict", "", "Path to the file with the known identities to apply.")
+	identitiesFlags.Bool("exact-signatures", false, "Match the signatures exactly (name and email).")
+	identitiesFlags.String("ssh-identity", "", "Path to SSH identity file to clone from an SSH remote.")
+}
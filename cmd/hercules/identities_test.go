@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules"
+)
+
+func fixtureIdentitiesRepository(t *testing.T) (*git.Repository, []*object.Commit) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	signatures := []object.Signature{
+		{Name: "Alice", Email: "alice@example.com"},
+		{Name: "Bob", Email: "bob@example.com"},
+		{Name: "Alice Smith", Email: "alice@example.com"},
+		{Name: "Alice", Email: "alice@example.com"},
+	}
+	for i, signature := range signatures {
+		signature.When = start.AddDate(0, 0, i)
+		_, err := worktree.Commit("commit", &git.CommitOptions{
+			Author: &signature, Committer: &signature, AllowEmptyCommits: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	commits, err := hercules.NewPipeline(repository).Commits(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repository, commits
+}
+
+func TestCollectIdentities(t *testing.T) {
+	repository, commits := fixtureIdentitiesRepository(t)
+	authors, err := collectIdentities(repository, commits, "", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(authors) != 2 {
+		t.Fatalf("unexpected authors: %v", authors)
+	}
+	alice, bob := authors[0], authors[1]
+	if alice.Name != "alice|alice smith|alice@example.com" || alice.Commits != 3 {
+		t.Fatalf("unexpected first author: %v", alice)
+	}
+	if !alice.First.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)) ||
+		!alice.Last.Equal(time.Date(2020, 1, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected activity period: %v - %v", alice.First, alice.Last)
+	}
+	if bob.Name != "bob|bob@example.com" || bob.Commits != 1 || !bob.First.Equal(bob.Last) {
+		t.Fatalf("unexpected second author: %v", bob)
+	}
+
+	authors, err = collectIdentities(repository, commits, "", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(authors) != 3 {
+		t.Fatalf("exact signatures must not be merged: %v", authors)
+	}
+
+	authors, err = collectIdentities(repository, nil, "", false)
+	if err != nil || len(authors) != 0 {
+		t.Fatalf("no commits must produce no authors: %v %v", authors, err)
+	}
+}
+
+func TestWriteIdentities(t *testing.T) {
+	repository, commits := fixtureIdentitiesRepository(t)
+	authors, err := collectIdentities(repository, commits, "", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeIdentities(buffer, "text", authors); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `2 identities in 4 commits
+      3  2020-01-01  2020-01-04  alice|alice smith|alice@example.com
+      1  2020-01-02  2020-01-02  bob|bob@example.com
+`
+	if buffer.String() != expected {
+		t.Fatalf("unexpected text output:\n%s", buffer.String())
+	}
+
+	buffer.Reset()
+	if err := writeIdentities(buffer, "people-dict", authors); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buffer.String() != "alice|alice smith|alice@example.com\nbob|bob@example.com\n" {
+		t.Fatalf("unexpected people-dict output:\n%s", buffer.String())
+	}
+
+	buffer.Reset()
+	if err := writeIdentities(buffer, "yaml", authors); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buffer.String(), "commits: 3") || !strings.Contains(buffer.String(), "name: bob|bob@example.com") {
+		t.Fatalf("unexpected yaml output:\n%s", buffer.String())
+	}
+
+	if err := writeIdentities(buffer, "xml", authors); err == nil {
+		t.Fatal("expected an unsupported format error")
+	}
+}
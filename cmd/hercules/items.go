@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// resolveLeaves finds the registered leaves by their command line flags or names.
+// All the leaves are returned if no names are specified.
+func resolveLeaves(registry *core.PipelineItemRegistry, names []string) ([]core.LeafPipelineItem, error) {
+	leaves := registry.GetLeaves()
+	if len(names) == 0 {
+		return leaves, nil
+	}
+	result := make([]core.LeafPipelineItem, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, leaf := range leaves {
+			if leaf.Flag() == name || leaf.Name() == name {
+				result = append(result, leaf)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown analysis: %s", name)
+		}
+	}
+	return result, nil
+}
+
+// writeItemsText prints the items which each leaf pulls in and, if there are several leaves,
+// which leaves share each of the pulled items.
+func writeItemsText(writer io.Writer, registry *core.PipelineItemRegistry, leaves []core.LeafPipelineItem) error {
+	users := map[string][]string{}
+	var builder strings.Builder
+	for _, leaf := range leaves {
+		graph := registry.Graph(leaf)
+		deps := make([]string, 0, len(graph.Items)-1)
+		for _, item := range graph.Items {
+			if item.Name == leaf.Name() {
+				continue
+			}
+			deps = append(deps, item.Name)
+			users[item.Name] = append(users[item.Name], leaf.Name())
+		}
+		sort.Strings(deps)
+		fmt.Fprintf(&builder, "--%s (%s, %s) pulls in %d item(s)", leaf.Flag(), leaf.Name(),
+			core.GetCost(leaf), len(deps))
+		if len(deps) > 0 {
+			fmt.Fprintf(&builder, ": %s", strings.Join(deps, ", "))
+		}
+		builder.WriteString("\n")
+		for _, item := range graph.Items {
+			if len(item.Features) > 0 {
+				fmt.Fprintf(&builder, "    %s depends on --feature=%s\n", item.Name, strings.Join(item.Features, ","))
+			}
+		}
+	}
+	if len(leaves) > 1 && len(users) > 0 {
+		names := make([]string, 0, len(users))
+		width := 0
+		for name := range users {
+			names = append(names, name)
+			if len(name) > width {
+				width = len(name)
+			}
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if len(users[names[i]]) != len(users[names[j]]) {
+				return len(users[names[i]]) > len(users[names[j]])
+			}
+			return names[i] < names[j]
+		})
+		fmt.Fprintf(&builder, "\n%d item(s) in total:\n", len(names))
+		for _, name := range names {
+			fmt.Fprintf(&builder, "  %-*s  used by %d: %s\n", width, name, len(users[name]),
+				strings.Join(users[name], ", "))
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// itemsCmd explains which plumbing items the analyses pull in.
+var itemsCmd = &cobra.Command{
+	Use:   "items [flags] [analysis...]",
+	Short: "Print which items each analysis pulls in through its dependencies.",
+	Long: `Resolves the Provides/Requires dependencies of the specified analyses and prints the
+plumbing items which each of them pulls in, followed by the items shared between the analyses.
+The analyses are specified by their flags (e.g. "burndown") or names (e.g. "Burndown"); all
+the registered analyses are printed if none is specified. Every pulled item runs on each commit,
+so the list explains why enabling one more analysis can double the run time.
+
+--format dot|mermaid|json prints the combined dependency graph instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		leaves, err := resolveLeaves(hercules.Registry, args)
+		if err != nil {
+			return err
+		}
+		if format == "text" {
+			return writeItemsText(os.Stdout, hercules.Registry, leaves)
+		}
+		items := make([]core.PipelineItem, len(leaves))
+		for i, leaf := range leaves {
+			items[i] = leaf
+		}
+		return hercules.Registry.Graph(items...).Export(core.GraphFormat(format), os.Stdout)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(itemsCmd)
+	itemsCmd.SetUsageFunc(itemsCmd.UsageFunc())
+	itemsCmd.Flags().String("format", "text", "Output format: text, dot, mermaid or json.")
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules"
+)
+
+func TestResolveLeaves(t *testing.T) {
+	leaves, err := resolveLeaves(hercules.Registry, nil)
+	if err != nil || len(leaves) != len(hercules.Registry.GetLeaves()) {
+		t.Fatalf("all the leaves must be returned: %d %v", len(leaves), err)
+	}
+	leaves, err = resolveLeaves(hercules.Registry, []string{"burndown", "Couples"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(leaves) != 2 || leaves[0].Name() != "Burndown" || leaves[1].Name() != "Couples" {
+		t.Fatalf("unexpected leaves: %v", leaves)
+	}
+	if _, err = resolveLeaves(hercules.Registry, []string{"whatever"}); err == nil {
+		t.Fatal("expected an error for an unknown analysis")
+	}
+}
+
+func TestWriteItemsText(t *testing.T) {
+	leaves, err := resolveLeaves(hercules.Registry, []string{"burndown", "couples"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buffer := &bytes.Buffer{}
+	if err := writeItemsText(buffer, hercules.Registry, leaves); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buffer.String()
+	for _, expected := range []string{
+		"--burndown (Burndown, heavy) pulls in ",
+		"--couples (Couples, cheap) pulls in ",
+		"item(s) in total:",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Fatalf("%q is missing in the output:\n%s", expected, output)
+		}
+	}
+	var blobCache string
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "BlobCache ") {
+			blobCache = line
+		}
+	}
+	if !strings.HasSuffix(blobCache, "used by 2: Burndown, Couples") {
+		t.Fatalf("BlobCache must be shared: %q", blobCache)
+	}
+
+	buffer.Reset()
+	if err := writeItemsText(buffer, hercules.Registry, leaves[:1]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buffer.String(), "in total") {
+		t.Fatalf("a single analysis must not print the summary:\n%s", buffer.String())
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/meko-christian/hercules"
+	"gopkg.in/yaml.v2"
+)
+
+// outputFormats are the supported values of the format in --output.
+var outputFormats = []string{"yaml", "pb", "json"}
+
+// outputTarget is the destination of the results in the specified format.
+type outputTarget struct {
+	// Format is one of outputFormats.
+	Format string
+	// Path is the output file name or "-" for stdout.
+	Path string
+	// Findings appends the ranked findings to the YAML and JSON results.
+	Findings bool
+}
+
+// parseOutputTargets parses the values of --output, each is "format=path".
+// If there are no values, the results are written to stdout in YAML or in Protocol Buffers.
+func parseOutputTargets(values []string, protobuf bool) ([]outputTarget, error) {
+	if len(values) == 0 {
+		format := "yaml"
+		if protobuf {
+			format = "pb"
+		}
+		return []outputTarget{{Format: format, Path: "-"}}, nil
+	}
+	targets := make([]outputTarget, 0, len(values))
+	stdout := false
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --output %q: the format is \"format=path\"", value)
+		}
+		known := false
+		for _, format := range outputFormats {
+			if parts[0] == format {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("invalid --output %q: the format must be one of %s",
+				value, strings.Join(outputFormats, ", "))
+		}
+		if parts[1] == "-" {
+			if stdout {
+				return nil, fmt.Errorf("invalid --output %q: only one format can be written to stdout",
+					value)
+			}
+			stdout = true
+		}
+		targets = append(targets, outputTarget{Format: parts[0], Path: parts[1]})
+	}
+	return targets, nil
+}
+
+// parseOutputFilters parses the values of --output-filter, each is "analysis.key=value" where
+// analysis is the name or the flag of a leaf or "*" for all the leaves, and key is top-files,
+// min-lines or authors (comma-separated). The later values override the earlier ones.
+func parseOutputFilters(values []string) (map[string]hercules.OutputFilter, error) {
+	filters := map[string]hercules.OutputFilter{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		dot := strings.LastIndex(parts[0], ".")
+		if len(parts) != 2 || dot <= 0 {
+			return nil, fmt.Errorf("invalid --output-filter %q: the format is \"analysis.key=value\"", value)
+		}
+		leaf, key := parts[0][:dot], parts[0][dot+1:]
+		filter := filters[leaf]
+		switch key {
+		case "top-files", "min-lines":
+			number, err := strconv.Atoi(parts[1])
+			if err != nil || number < 0 {
+				return nil, fmt.Errorf("invalid --output-filter %q: %s must be a non-negative integer",
+					value, key)
+			}
+			if key == "top-files" {
+				filter.TopFiles = number
+			} else {
+				filter.MinLines = number
+			}
+		case "authors":
+			filter.Authors = nil
+			for _, author := range strings.Split(parts[1], ",") {
+				if author = strings.TrimSpace(author); author != "" {
+					filter.Authors = append(filter.Authors, author)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid --output-filter %q: the key must be top-files, min-lines or authors",
+				value)
+		}
+		filters[leaf] = filter
+	}
+	return filters, nil
+}
+
+// filterResults applies the output filters to the results of the deployed leaves. The filter
+// of a specific leaf overrides the fields of the "*" filter. It fails if a filter names
+// a leaf which is not deployed or does not support filtering.
+func filterResults(
+	filters map[string]hercules.OutputFilter, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{},
+) (map[hercules.LeafPipelineItem]interface{}, error) {
+	if len(filters) == 0 {
+		return results, nil
+	}
+	used := map[string]bool{"*": true}
+	filtered := make(map[hercules.LeafPipelineItem]interface{}, len(results))
+	for item, result := range results {
+		filtered[item] = result
+	}
+	for _, item := range deployed {
+		filter := filters["*"]
+		specific := false
+		for _, name := range []string{item.Name(), item.Flag()} {
+			override, exists := filters[name]
+			if !exists {
+				continue
+			}
+			used[name], specific = true, true
+			if override.TopFiles > 0 {
+				filter.TopFiles = override.TopFiles
+			}
+			if override.MinLines > 0 {
+				filter.MinLines = override.MinLines
+			}
+			if override.Authors != nil {
+				filter.Authors = override.Authors
+			}
+		}
+		filterable, ok := item.(hercules.FilterablePipelineItem)
+		if !ok {
+			if specific {
+				return nil, fmt.Errorf("%s does not support --output-filter", item.Name())
+			}
+			continue
+		}
+		if filter.IsEmpty() {
+			continue
+		}
+		if result, exists := results[item]; exists && result != nil {
+			filtered[item] = filterable.FilterResult(result, filter)
+		}
+	}
+	for name := range filters {
+		if !used[name] {
+			return nil, fmt.Errorf("--output-filter: %s is not among the requested analyses", name)
+		}
+	}
+	return filtered, nil
+}
+
+// anonymizeResults replaces the file paths in the results of the deployed leaves with pseudonyms.
+// All the paths are collected first so that the pseudonyms do not depend on the order
+// of the leaves. It fails if a deployed leaf does not support the anonymization, because
+// its results could reveal the paths.
+func anonymizeResults(
+	anonymizer *hercules.PathAnonymizer, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{},
+) (map[hercules.LeafPipelineItem]interface{}, error) {
+	if anonymizer == nil {
+		return results, nil
+	}
+	anonymized := make(map[hercules.LeafPipelineItem]interface{}, len(results))
+	for item, result := range results {
+		anonymized[item] = result
+	}
+	var paths []string
+	collect := func(path string) string {
+		paths = append(paths, path)
+		return path
+	}
+	var items []hercules.PathAnonymizablePipelineItem
+	for _, item := range deployed {
+		anonymizable, ok := item.(hercules.PathAnonymizablePipelineItem)
+		if !ok {
+			return nil, fmt.Errorf("%s does not support --anonymize-paths", item.Name())
+		}
+		if result, exists := results[item]; exists && result != nil {
+			anonymizable.AnonymizePaths(result, collect)
+			items = append(items, anonymizable)
+		}
+	}
+	anonymizer.Prepare(paths)
+	for _, item := range items {
+		anonymized[item] = item.AnonymizePaths(results[item], anonymizer.Anonymize)
+	}
+	return anonymized, nil
+}
+
+// writeTarget serializes the results once in the format of the target and writes them.
+func writeTarget(
+	target outputTarget, repoUri string, deployedLeafs []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{},
+) error {
+	writer := io.Writer(os.Stdout)
+	if target.Path != "-" {
+		file, err := os.Create(target.Path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		writer = file
+	}
+	switch target.Format {
+	case "pb":
+		protobufResults(writer, repoUri, deployedLeafs, results)
+	case "json":
+		return jsonResults(writer, repoUri, deployedLeafs, results, target.Findings)
+	default:
+		printResults(writer, repoUri, deployedLeafs, results)
+		if target.Findings {
+			return printFindings(writer, repoUri, deployedLeafs, results)
+		}
+	}
+	return nil
+}
+
+// jsonResults writes the same document as printResults() in JSON, optionally with the findings.
+func jsonResults(
+	writer io.Writer, uri string, deployed []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}, findings bool,
+) error {
+	buffer := &bytes.Buffer{}
+	printResults(buffer, uri, deployed, results)
+	if findings {
+		if err := printFindings(buffer, uri, deployed, results); err != nil {
+			return err
+		}
+	}
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(buffer.Bytes(), &document); err != nil {
+		return fmt.Errorf("failed to parse the YAML results: %v", err)
+	}
+	compact := &bytes.Buffer{}
+	if err := writeJSON(compact, document); err != nil {
+		return err
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, compact.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err := indented.WriteTo(writer)
+	return err
+}
+
+// writeJSON encodes the parsed YAML value and preserves the order of the mapping keys.
+func writeJSON(buffer *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case yaml.MapSlice:
+		buffer.WriteByte('{')
+		for i, item := range value {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeJSONScalar(buffer, fmt.Sprint(item.Key)); err != nil {
+				return err
+			}
+			buffer.WriteByte(':')
+			if err := writeJSON(buffer, item.Value); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	case []interface{}:
+		buffer.WriteByte('[')
+		for i, item := range value {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeJSON(buffer, item); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	default:
+		return encodeJSONScalar(buffer, value)
+	}
+	return nil
+}
+
+// encodeJSONScalar writes the value without escaping the HTML characters, e.g. in "<unknown>".
+func encodeJSONScalar(buffer *bytes.Buffer, value interface{}) error {
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	// Encode() appends a newline
+	buffer.Truncate(buffer.Len() - 1)
+	return nil
+}
+
+// postRunData is passed to the --post-run command template. The values are quoted for
+// the shell, see shellQuote(), so that the metacharacters in them never run commands.
+type postRunData struct {
+	// Path is the output file name or "-" for stdout.
+	Path string
+	// Format is the output format: yaml, pb or json.
+	Format string
+	// Repository is the analysed repository URI.
+	Repository string
+}
+
+// parsePostRunHook parses the --post-run command template. It returns nil if the command is empty.
+func parsePostRunHook(command string) (*template.Template, error) {
+	if command == "" {
+		return nil, nil
+	}
+	hook, err := template.New("post-run").Parse(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --post-run: %v", err)
+	}
+	return hook, nil
+}
+
+// shellQuote makes the value a single literal word for the shell which runs the --post-run
+// command. cmd.exe cannot escape the double quotes and expands %VAR% even inside them,
+// so such values are rejected on Windows.
+func shellQuote(value string) (string, error) {
+	if runtime.GOOS == "windows" {
+		if strings.ContainsAny(value, "\"%") {
+			return "", fmt.Errorf("cannot quote %q for cmd.exe, use the HERCULES_* variables", value)
+		}
+		return `"` + value + `"`, nil
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'", nil
+}
+
+// newPostRunData quotes the values of the --post-run command template.
+func newPostRunData(target outputTarget, repoUri string) (postRunData, error) {
+	var data postRunData
+	var err error
+	if data.Path, err = shellQuote(target.Path); err != nil {
+		return data, err
+	}
+	if data.Format, err = shellQuote(target.Format); err != nil {
+		return data, err
+	}
+	data.Repository, err = shellQuote(repoUri)
+	return data, err
+}
+
+// runPostRunHook executes the command rendered from the template in the shell for each
+// of the output targets. The summary of the run is passed in the environment variables.
+// The command's output goes to stderr to keep the results on stdout intact.
+func runPostRunHook(
+	hook *template.Template, targets []outputTarget, repoUri string,
+	deployedLeafs []hercules.LeafPipelineItem, results map[hercules.LeafPipelineItem]interface{},
+) error {
+	names := make([]string, len(deployedLeafs))
+	for i, leaf := range deployedLeafs {
+		names[i] = leaf.Name()
+	}
+	common := results[nil].(*hercules.CommonAnalysisResult)
+	env := append(os.Environ(),
+		"HERCULES_REPOSITORY="+repoUri,
+		"HERCULES_ANALYSES="+strings.Join(names, ","),
+		fmt.Sprintf("HERCULES_COMMITS=%d", common.CommitsNumber),
+		fmt.Sprintf("HERCULES_BEGIN_TIME=%d", common.BeginTime),
+		fmt.Sprintf("HERCULES_END_TIME=%d", common.EndTime),
+		fmt.Sprintf("HERCULES_RUN_TIME=%d", common.RunTime.Milliseconds()),
+	)
+	for _, target := range targets {
+		data, err := newPostRunData(target, repoUri)
+		if err != nil {
+			return err
+		}
+		command := &strings.Builder{}
+		if err = hook.Execute(command, data); err != nil {
+			return err
+		}
+		var cmd *exec.Cmd
+		if runtime.GOOS == "windows" {
+			cmd = exec.Command("cmd", "/C", command.String())
+		} else {
+			cmd = exec.Command("sh", "-c", command.String())
+		}
+		cmd.Env = append(env,
+			"HERCULES_RESULT_PATH="+target.Path,
+			"HERCULES_RESULT_FORMAT="+target.Format,
+		)
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err = cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %v", command.String(), err)
+		}
+	}
+	return nil
+}
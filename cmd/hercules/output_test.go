@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/leaves"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputTargets(t *testing.T) {
+	targets, err := parseOutputTargets(nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, []outputTarget{{Format: "yaml", Path: "-"}}, targets)
+	targets, err = parseOutputTargets(nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, []outputTarget{{Format: "pb", Path: "-"}}, targets)
+	targets, err = parseOutputTargets([]string{"pb=out.pb", "json=-", "yaml=a=b.yml"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []outputTarget{
+		{Format: "pb", Path: "out.pb"}, {Format: "json", Path: "-"}, {Format: "yaml", Path: "a=b.yml"},
+	}, targets)
+
+	for _, invalid := range [][]string{{"xml=out.xml"}, {"pb"}, {"pb="}, {"pb=-", "yaml=-"}} {
+		_, err = parseOutputTargets(invalid, false)
+		assert.Error(t, err, strings.Join(invalid, " "))
+	}
+}
+
+func TestParseOutputFilters(t *testing.T) {
+	filters, err := parseOutputFilters(nil)
+	require.NoError(t, err)
+	assert.Empty(t, filters)
+	filters, err = parseOutputFilters([]string{
+		"*.min-lines=10", "burndown.top-files=100", "Devs.authors=alice, bob@example.com,",
+		"burndown.top-files=50",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]hercules.OutputFilter{
+		"*":        {MinLines: 10},
+		"burndown": {TopFiles: 50},
+		"Devs":     {Authors: []string{"alice", "bob@example.com"}},
+	}, filters)
+	for _, value := range []string{
+		"burndown", "top-files=1", "burndown.top-files=x", "burndown.top-files=-1", "burndown.size=1",
+	} {
+		_, err = parseOutputFilters([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestFilterResults(t *testing.T) {
+	hotspots := &leaves.HotspotRiskAnalysis{}
+	commits := &leaves.CommitsAnalysis{}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil: &hercules.CommonAnalysisResult{},
+		hotspots: leaves.HotspotRiskResult{Files: []leaves.FileRisk{
+			{Path: "a.go", Size: 10}, {Path: "b.go", Size: 1}, {Path: "c.go", Size: 20},
+		}},
+		commits: leaves.CommitsResult{},
+	}
+	deployed := []hercules.LeafPipelineItem{hotspots, commits}
+	filtered, err := filterResults(nil, deployed, results)
+	require.NoError(t, err)
+	assert.Equal(t, results, filtered)
+
+	filters, err := parseOutputFilters([]string{"*.min-lines=5", "hotspot-risk.top-files=1"})
+	require.NoError(t, err)
+	filtered, err = filterResults(filters, deployed, results)
+	require.NoError(t, err)
+	assert.Equal(t, []leaves.FileRisk{{Path: "a.go", Size: 10}},
+		filtered[hotspots].(leaves.HotspotRiskResult).Files)
+	assert.Len(t, results[hotspots].(leaves.HotspotRiskResult).Files, 3)
+	assert.Equal(t, results[nil], filtered[nil])
+	assert.Equal(t, results[commits], filtered[commits])
+
+	filters, _ = parseOutputFilters([]string{"commits-stat.top-files=1"})
+	_, err = filterResults(filters, deployed, results)
+	assert.Error(t, err)
+	filters, _ = parseOutputFilters([]string{"burndown.top-files=1"})
+	_, err = filterResults(filters, deployed, results)
+	assert.Error(t, err)
+}
+
+func TestAnonymizeResults(t *testing.T) {
+	hotspots := &leaves.HotspotRiskAnalysis{}
+	pyramid := &leaves.CodeAgePyramidAnalysis{}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil: &hercules.CommonAnalysisResult{},
+		hotspots: leaves.HotspotRiskResult{Files: []leaves.FileRisk{
+			{Path: "src/b.go"}, {Path: "src/a.go"}, {Path: "README.md"},
+		}},
+		pyramid: leaves.CodeAgePyramidResult{Subsystems: map[string][]int64{"src": {1}, "/": {2}}},
+	}
+	deployed := []hercules.LeafPipelineItem{hotspots, pyramid}
+	anonymized, err := anonymizeResults(nil, deployed, results)
+	require.NoError(t, err)
+	assert.Equal(t, results, anonymized)
+
+	anonymizer, err := hercules.NewPathAnonymizer("bucket", "")
+	require.NoError(t, err)
+	anonymized, err = anonymizeResults(anonymizer, deployed, results)
+	require.NoError(t, err)
+	assert.Equal(t, []leaves.FileRisk{{Path: "d2/f2.go"}, {Path: "d2/f1.go"}, {Path: "f1.md"}},
+		anonymized[hotspots].(leaves.HotspotRiskResult).Files)
+	assert.Equal(t, map[string][]int64{"d2": {1}, "/": {2}},
+		anonymized[pyramid].(leaves.CodeAgePyramidResult).Subsystems)
+	assert.Equal(t, "src/b.go", results[hotspots].(leaves.HotspotRiskResult).Files[0].Path)
+	assert.Equal(t, results[nil], anonymized[nil])
+
+	anonymizer, _ = hercules.NewPathAnonymizer("hash", "")
+	_, err = anonymizeResults(anonymizer, append(deployed, &leaves.CommitsAnalysis{}), results)
+	assert.Error(t, err)
+}
+
+func TestWriteTargets(t *testing.T) {
+	leaf := &leaves.CrossTimezoneAnalysis{}
+	deployed := []hercules.LeafPipelineItem{leaf}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil: &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3},
+		leaf: leaves.CrossTimezoneResult{
+			Offsets:       map[int]int{0: 60, 1: 540},
+			Pairs:         []leaves.CrossTimezonePair{{Developer1: 0, Developer2: 1, GapHours: 8, Coupling: 4}},
+			TotalCoupling: 4,
+			CrossCoupling: 4,
+			MinGapHours:   4,
+		},
+	}
+	dir := t.TempDir()
+	paths := map[string]string{}
+	for _, format := range outputFormats {
+		paths[format] = filepath.Join(dir, "out."+format)
+		require.NoError(t, writeTarget(outputTarget{Format: format, Path: paths[format]},
+			"/repo", deployed, results))
+	}
+
+	text, err := os.ReadFile(paths["yaml"])
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(text), "hercules:\n"))
+	assert.Contains(t, string(text), "CrossTimezone:\n  cross_timezone:\n")
+
+	data, err := os.ReadFile(paths["pb"])
+	require.NoError(t, err)
+	message := pb.AnalysisResults{}
+	require.NoError(t, proto.Unmarshal(data, &message))
+	assert.Equal(t, "/repo", message.Header.Repository)
+	assert.Contains(t, message.Contents, "CrossTimezone")
+
+	data, err = os.ReadFile(paths["json"])
+	require.NoError(t, err)
+	// the keys keep the order of the YAML document
+	assert.Less(t, strings.Index(string(data), `"hercules"`), strings.Index(string(data), `"CrossTimezone"`))
+	var document map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &document))
+	assert.Equal(t, "/repo", document["hercules"]["repository"])
+	assert.Contains(t, string(data), `"hash": "`+hercules.BinaryGitHash+`"`)
+	assert.Equal(t, 3.0, document["hercules"]["commits"])
+	timezone := document["CrossTimezone"]["cross_timezone"].(map[string]interface{})
+	assert.Equal(t, 4.0, timezone["total_coupling"])
+	assert.Equal(t, map[string]interface{}{"0": 60.0, "1": 540.0}, timezone["offsets"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"developers": []interface{}{0.0, 1.0}, "gap_hours": 8.0, "coupling": 4.0,
+	}}, timezone["pairs"])
+
+	assert.Error(t, writeTarget(outputTarget{Format: "yaml", Path: filepath.Join(dir, "x", "y")},
+		"/repo", deployed, results))
+}
+
+func TestPostRunHook(t *testing.T) {
+	hook, err := parsePostRunHook("")
+	require.NoError(t, err)
+	assert.Nil(t, hook)
+	_, err = parsePostRunHook("echo {{.Path")
+	assert.Error(t, err)
+	if runtime.GOOS == "windows" {
+		t.Skip("the hook command uses sh")
+	}
+
+	log := filepath.Join(t.TempDir(), "hook.log")
+	hook, err = parsePostRunHook(`echo {{.Format}} {{.Path}} {{.Repository}} ` +
+		`"$HERCULES_RESULT_PATH $HERCULES_ANALYSES $HERCULES_COMMITS $HERCULES_BEGIN_TIME" >> ` + log)
+	require.NoError(t, err)
+	leaf := &leaves.CrossTimezoneAnalysis{}
+	results := map[hercules.LeafPipelineItem]interface{}{
+		nil: &hercules.CommonAnalysisResult{BeginTime: 100, EndTime: 200, CommitsNumber: 3},
+	}
+	targets := []outputTarget{{Format: "pb", Path: "out.pb"}, {Format: "yaml", Path: "-"}}
+	require.NoError(t, runPostRunHook(hook, targets, "/repo",
+		[]hercules.LeafPipelineItem{leaf}, results))
+	text, err := os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Equal(t, "pb out.pb /repo out.pb CrossTimezone 3 100\n"+
+		"yaml - /repo - CrossTimezone 3 100\n", string(text))
+
+	// the metacharacters in the values are not interpreted
+	marker := filepath.Join(t.TempDir(), "injected")
+	hook, err = parsePostRunHook("echo {{.Repository}} >> " + log)
+	require.NoError(t, err)
+	repository := "/repo'; touch " + marker + "; echo '$(touch " + marker + ")`touch " + marker + "`"
+	require.NoError(t, runPostRunHook(hook, targets[:1], repository, nil, results))
+	_, err = os.Stat(marker)
+	assert.True(t, os.IsNotExist(err))
+	text, err = os.ReadFile(log)
+	require.NoError(t, err)
+	assert.Contains(t, string(text), repository+"\n")
+
+	hook, err = parsePostRunHook("exit 3")
+	require.NoError(t, err)
+	assert.Error(t, runPostRunHook(hook, targets, "/repo", nil, results))
+	hook, err = parsePostRunHook("echo {{.Missing}}")
+	require.NoError(t, err)
+	assert.Error(t, runPostRunHook(hook, targets, "/repo", nil, results))
+}
@@ -0,0 +1,529 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/internal/yaml"
+	"github.com/meko-christian/hercules/leaves"
+	"github.com/spf13/cobra"
+)
+
+// ownershipPlanOwner is a developer or a team with the number of the owned alive lines.
+type ownershipPlanOwner struct {
+	Name  string
+	Lines int
+}
+
+// ownershipPlanModule is a group of directories which are changed together, proposed
+// to be owned by a single team.
+type ownershipPlanModule struct {
+	// Name is the directory with the most lines in the module.
+	Name        string
+	Directories []string
+	Files       int
+	Lines       int
+	// Gini is the lines-weighted ownership concentration of the directories, -1 if unknown.
+	Gini float64
+	// Contention is the share of the files changed by several developers in the same month,
+	// -1 if unknown.
+	Contention float64
+	// Team is the proposed owner, empty if no team has the capacity.
+	Team string
+	// TeamLines is the number of the lines owned by the members of Team.
+	TeamLines int
+	// Owners are the developers by the descending number of the owned lines.
+	Owners             []ownershipPlanOwner
+	BusFactor          int
+	ProjectedBusFactor int
+
+	// teamLines maps the candidate teams to the owned lines.
+	teamLines map[string]int
+	// developerLines maps the developer indexes to the owned lines.
+	developerLines map[int]int
+}
+
+// ownershipPlanTeam summarizes the modules assigned to a team.
+type ownershipPlanTeam struct {
+	Name    string
+	Members []string
+	Modules int
+	Lines   int
+}
+
+// ownershipPlan is the module-to-team assignment generated by buildOwnershipPlan().
+type ownershipPlan struct {
+	Repository string
+	Threshold  float64
+	Coupling   float64
+	Balance    float64
+	Teams      []*ownershipPlanTeam
+	Modules    []*ownershipPlanModule
+}
+
+// ownershipPlanDirectory returns the directory of the file, "/" for the repository root.
+// The same keys are used by OwnershipConcentration and WorkingSetOverlap.
+func ownershipPlanDirectory(file string) string {
+	dir := path.Dir(file)
+	if dir == "." {
+		return "/"
+	}
+	return dir
+}
+
+// detectCouplingCommunities groups the directories which are changed together. Two directories
+// join the same community if the number of the commits which changed both of them, divided by
+// the number of the commits to the least changed of the two, is at least `coupling`.
+// The returned map is from the directory to the community representative.
+func detectCouplingCommunities(couples leaves.CouplesResult, coupling float64) map[string]string {
+	parents := map[string]string{}
+	var find func(dir string) string
+	find = func(dir string) string {
+		parent, exists := parents[dir]
+		if !exists {
+			parents[dir] = dir
+			return dir
+		}
+		if parent == dir {
+			return dir
+		}
+		root := find(parent)
+		parents[dir] = root
+		return root
+	}
+	commits := map[string]int64{}
+	pairs := map[[2]string]int64{}
+	for i, row := range couples.FilesMatrix {
+		if i >= len(couples.Files) {
+			break
+		}
+		dir := ownershipPlanDirectory(couples.Files[i])
+		find(dir)
+		for j, count := range row {
+			if j == i {
+				commits[dir] += count
+				continue
+			}
+			if j < i || j >= len(couples.Files) {
+				continue
+			}
+			other := ownershipPlanDirectory(couples.Files[j])
+			if other == dir {
+				continue
+			}
+			key := [2]string{dir, other}
+			if other < dir {
+				key = [2]string{other, dir}
+			}
+			pairs[key] += count
+		}
+	}
+	keys := make([][2]string, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		least := commits[key[0]]
+		if commits[key[1]] < least {
+			least = commits[key[1]]
+		}
+		if least <= 0 || float64(pairs[key])/float64(least) < coupling {
+			continue
+		}
+		root1, root2 := find(key[0]), find(key[1])
+		if root1 == root2 {
+			continue
+		}
+		if root2 < root1 {
+			root1, root2 = root2, root1
+		}
+		parents[root2] = root1
+	}
+	communities := make(map[string]string, len(parents))
+	for dir := range parents {
+		communities[dir] = find(dir)
+	}
+	return communities
+}
+
+// sortOwnershipPlanOwners orders the owners by the descending number of lines, then by name.
+func sortOwnershipPlanOwners(owners []ownershipPlanOwner) {
+	sort.Slice(owners, func(i, j int) bool {
+		if owners[i].Lines != owners[j].Lines {
+			return owners[i].Lines > owners[j].Lines
+		}
+		return owners[i].Name < owners[j].Name
+	})
+}
+
+// ownershipPlanBusFactor returns the smallest number of owners who own at least `threshold`
+// of the lines.
+func ownershipPlanBusFactor(owners []ownershipPlanOwner, lines int, threshold float64) int {
+	sorted := append([]ownershipPlanOwner(nil), owners...)
+	sortOwnershipPlanOwners(sorted)
+	busFactor := make([]codemapOwner, len(sorted))
+	for i, owner := range sorted {
+		busFactor[i] = codemapOwner{Name: owner.Name, Lines: owner.Lines}
+	}
+	return codemapBusFactor(busFactor, lines, threshold)
+}
+
+// buildOwnershipPlan proposes which team should own each module. The modules are the coupling
+// communities of the directories (Couples); the lines owned by each developer come from
+// Burndown with --burndown-files --burndown-people. OwnershipConcentration and WorkingSetOverlap
+// are optional and raise the priority of the concentrated and contended modules.
+//
+// The assignment is greedy: the modules are visited from the riskiest to the safest and each goes
+// to the team whose members own the most lines of it, unless the team would then own more than
+// `balance` times the fair share of all the lines. The projected bus factor assumes that the lines
+// of the assigned team spread evenly over its members.
+func buildOwnershipPlan(
+	message pb.AnalysisResults, teams map[string]string, threshold, coupling, balance float64,
+) (*ownershipPlan, error) {
+	plan := &ownershipPlan{Threshold: threshold, Coupling: coupling, Balance: balance}
+	if message.Header != nil {
+		plan.Repository = message.Header.Repository
+	}
+	decode := func(key string, msg proto.Message) (bool, error) {
+		payload, exists := message.Contents[key]
+		if !exists {
+			return false, nil
+		}
+		if err := proto.Unmarshal(payload, msg); err != nil {
+			return false, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		return true, nil
+	}
+
+	var burndown pb.BurndownAnalysisResults
+	hasBurndown, err := decode("Burndown", &burndown)
+	if err != nil {
+		return nil, err
+	}
+	if !hasBurndown || len(burndown.FilesOwnership) == 0 || len(burndown.People) == 0 {
+		return nil, fmt.Errorf("the results do not contain Burndown with --burndown-files --burndown-people")
+	}
+	payload, exists := message.Contents["Couples"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain Couples")
+	}
+	rawCouples, err := (&leaves.CouplesAnalysis{}).Deserialize(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Couples: %w", err)
+	}
+	communities := detectCouplingCommunities(rawCouples.(leaves.CouplesResult), coupling)
+
+	developers := make([]string, len(burndown.People))
+	developerTeams := make([]string, len(burndown.People))
+	members := map[string][]string{}
+	for i, person := range burndown.People {
+		developers[i] = core.IdentityLabel(person.Name)
+		if team, exists := findTeam(person.Name, teams); exists {
+			developerTeams[i] = team
+			members[team] = append(members[team], developers[i])
+		}
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("none of the developers in the results belongs to a team")
+	}
+
+	modules := map[string]*ownershipPlanModule{}
+	directoryLines := map[string]int{}
+	seen := map[string]bool{}
+	for i, file := range burndown.Files {
+		if i >= len(burndown.FilesOwnership) {
+			break
+		}
+		dir := ownershipPlanDirectory(file.Name)
+		root, exists := communities[dir]
+		if !exists {
+			// the file was not changed together with anything else
+			root = dir
+		}
+		module := modules[root]
+		if module == nil {
+			module = &ownershipPlanModule{
+				Gini: -1, Contention: -1, teamLines: map[string]int{}, developerLines: map[int]int{},
+			}
+			modules[root] = module
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			module.Directories = append(module.Directories, dir)
+		}
+		module.Files++
+		for author, lines := range burndown.FilesOwnership[i].Value {
+			if author < 0 || int(author) >= len(developers) || lines <= 0 {
+				continue
+			}
+			module.Lines += int(lines)
+			directoryLines[dir] += int(lines)
+			module.developerLines[int(author)] += int(lines)
+			if team := developerTeams[author]; team != "" {
+				module.teamLines[team] += int(lines)
+			}
+		}
+	}
+
+	var concentration pb.OwnershipConcentrationResults
+	hasConcentration, err := decode("OwnershipConcentration", &concentration)
+	if err != nil {
+		return nil, err
+	}
+	contentions := map[string]leaves.SubsystemContention{}
+	if payload, exists := message.Contents["WorkingSetOverlap"]; exists {
+		overlap, err := (&leaves.WorkingSetOverlapAnalysis{}).Deserialize(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode WorkingSetOverlap: %w", err)
+		}
+		result := overlap.(leaves.WorkingSetOverlapResult)
+		for _, contention := range result.Subsystems() {
+			contentions[contention.Subsystem] = contention
+		}
+	}
+
+	for _, module := range modules {
+		sort.Strings(module.Directories)
+		var giniSum float64
+		var giniLines, files, contended int
+		for _, dir := range module.Directories {
+			lines := directoryLines[dir]
+			if lines > 0 && (module.Name == "" || lines > directoryLines[module.Name]) {
+				module.Name = dir
+			}
+			if hasConcentration {
+				if gini, exists := concentration.SubsystemGini[dir]; exists && lines > 0 {
+					giniSum += gini * float64(lines)
+					giniLines += lines
+				}
+			}
+			if contention, exists := contentions[dir]; exists {
+				files += contention.Files
+				contended += contention.Contended
+			}
+		}
+		if module.Name == "" {
+			module.Name = module.Directories[0]
+		}
+		if giniLines > 0 {
+			module.Gini = giniSum / float64(giniLines)
+		}
+		if files > 0 {
+			module.Contention = float64(contended) / float64(files)
+		}
+		for dev, lines := range module.developerLines {
+			module.Owners = append(module.Owners, ownershipPlanOwner{Name: developers[dev], Lines: lines})
+		}
+		sortOwnershipPlanOwners(module.Owners)
+		module.BusFactor = ownershipPlanBusFactor(module.Owners, module.Lines, threshold)
+		if module.Lines > 0 {
+			plan.Modules = append(plan.Modules, module)
+		}
+	}
+	risk := func(module *ownershipPlanModule) float64 {
+		return float64(module.Lines) * (1 + math.Max(module.Gini, 0)) * (1 + math.Max(module.Contention, 0)) /
+			float64(module.BusFactor)
+	}
+	sort.Slice(plan.Modules, func(i, j int) bool {
+		ri, rj := risk(plan.Modules[i]), risk(plan.Modules[j])
+		if ri != rj {
+			return ri > rj
+		}
+		return plan.Modules[i].Name < plan.Modules[j].Name
+	})
+
+	totalLines := 0
+	for _, module := range plan.Modules {
+		totalLines += module.Lines
+	}
+	capacity := balance * float64(totalLines) / float64(len(members))
+	for name, names := range members {
+		sort.Strings(names)
+		plan.Teams = append(plan.Teams, &ownershipPlanTeam{Name: name, Members: names})
+	}
+	sort.Slice(plan.Teams, func(i, j int) bool {
+		return plan.Teams[i].Name < plan.Teams[j].Name
+	})
+	for _, module := range plan.Modules {
+		var best *ownershipPlanTeam
+		for _, team := range plan.Teams {
+			if float64(team.Lines+module.Lines) > capacity && team.Lines > 0 {
+				continue
+			}
+			if best == nil || module.teamLines[team.Name] > module.teamLines[best.Name] ||
+				(module.teamLines[team.Name] == module.teamLines[best.Name] && team.Lines < best.Lines) {
+				best = team
+			}
+		}
+		if best == nil {
+			continue
+		}
+		best.Modules++
+		best.Lines += module.Lines
+		module.Team = best.Name
+		module.TeamLines = module.teamLines[best.Name]
+
+		// spread the lines of the team evenly over its members
+		var projected []ownershipPlanOwner
+		for dev, lines := range module.developerLines {
+			if developerTeams[dev] != best.Name {
+				projected = append(projected, ownershipPlanOwner{Name: developers[dev], Lines: lines})
+			}
+		}
+		size := len(best.Members)
+		for i, member := range best.Members {
+			share := module.TeamLines / size
+			if i < module.TeamLines%size {
+				share++
+			}
+			if share > 0 {
+				projected = append(projected, ownershipPlanOwner{Name: member, Lines: share})
+			}
+		}
+		module.ProjectedBusFactor = ownershipPlanBusFactor(projected, module.Lines, threshold)
+	}
+	return plan, nil
+}
+
+// writeOwnershipPlan prints the plan as YAML which is meant to be reviewed and edited by hand.
+func writeOwnershipPlan(writer io.Writer, plan *ownershipPlan, maxOwners int) error {
+	var builder strings.Builder
+	builder.WriteString("ownership_plan:\n")
+	fmt.Fprintf(&builder, "  repository: %s\n", yaml.SafeString(plan.Repository))
+	fmt.Fprintf(&builder, "  bus_factor_threshold: %.2f\n", plan.Threshold)
+	fmt.Fprintf(&builder, "  coupling: %.2f\n", plan.Coupling)
+	fmt.Fprintf(&builder, "  balance: %.2f\n", plan.Balance)
+	builder.WriteString("  teams:\n")
+	for _, team := range plan.Teams {
+		fmt.Fprintf(&builder, "    - name: %s\n", yaml.SafeString(team.Name))
+		names := make([]string, len(team.Members))
+		for i, member := range team.Members {
+			names[i] = yaml.SafeString(member)
+		}
+		fmt.Fprintf(&builder, "      members: [%s]\n", strings.Join(names, ", "))
+		fmt.Fprintf(&builder, "      modules: %d\n", team.Modules)
+		fmt.Fprintf(&builder, "      lines: %d\n", team.Lines)
+	}
+	builder.WriteString("  modules:\n")
+	for _, module := range plan.Modules {
+		fmt.Fprintf(&builder, "    - name: %s\n", yaml.SafeString(module.Name))
+		dirs := make([]string, len(module.Directories))
+		for i, dir := range module.Directories {
+			dirs[i] = yaml.SafeString(dir)
+		}
+		fmt.Fprintf(&builder, "      directories: [%s]\n", strings.Join(dirs, ", "))
+		fmt.Fprintf(&builder, "      files: %d\n", module.Files)
+		fmt.Fprintf(&builder, "      lines: %d\n", module.Lines)
+		if module.Gini >= 0 {
+			fmt.Fprintf(&builder, "      ownership_gini: %.3f\n", module.Gini)
+		}
+		if module.Contention >= 0 {
+			fmt.Fprintf(&builder, "      contention: %.3f\n", module.Contention)
+		}
+		owners := module.Owners
+		if len(owners) > maxOwners {
+			owners = owners[:maxOwners]
+		}
+		builder.WriteString("      top_owners:\n")
+		for _, owner := range owners {
+			fmt.Fprintf(&builder, "        %s: %d\n", yaml.SafeString(owner.Name), owner.Lines)
+		}
+		if module.Team == "" {
+			builder.WriteString("      team: null\n")
+		} else {
+			fmt.Fprintf(&builder, "      team: %s\n", yaml.SafeString(module.Team))
+			fmt.Fprintf(&builder, "      team_share: %.3f\n", float64(module.TeamLines)/float64(module.Lines))
+		}
+		fmt.Fprintf(&builder, "      bus_factor: %d\n", module.BusFactor)
+		if module.Team != "" {
+			fmt.Fprintf(&builder, "      projected_bus_factor: %d\n", module.ProjectedBusFactor)
+		}
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// ownershipPlanCmd proposes the module-to-team ownership assignment.
+var ownershipPlanCmd = &cobra.Command{
+	Use:   "ownership-plan [flags] <results.pb>",
+	Short: "Propose which team should own each module, with the projected bus factor.",
+	Long: `Groups the directories which are changed together (--couples) into modules and assigns
+each module to the team whose members own the most of its alive lines (--burndown --burndown-files
+--burndown-people). The modules are visited from the riskiest to the safest: the risk grows with
+the size, the ownership concentration (--ownership-concentration) and the contention
+(--working-set-overlap), and falls with the bus factor. A team may not own more than --balance
+times the fair share of the lines. The projected bus factor assumes that the lines owned by
+the assigned team spread evenly over its members.
+
+The teams are read from a YAML file which maps the team names to the lists of developer names
+or emails, the same as in "hercules export activity". The plan is printed as YAML.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		output, _ := flags.GetString("output")
+		teamsPath, _ := flags.GetString("teams")
+		threshold, _ := flags.GetFloat64("threshold")
+		coupling, _ := flags.GetFloat64("coupling")
+		balance, _ := flags.GetFloat64("balance")
+		maxOwners, _ := flags.GetInt("owners")
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("--threshold must be in (0, 1], got %v", threshold)
+		}
+		if balance < 1 {
+			return fmt.Errorf("--balance must be at least 1, got %v", balance)
+		}
+		teams, err := loadTeams(teamsPath)
+		if err != nil {
+			return err
+		}
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		plan, err := buildOwnershipPlan(message, teams, threshold, coupling, balance)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeOwnershipPlan(os.Stdout, plan, maxOwners)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeOwnershipPlan(file, plan, maxOwners); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ownershipPlanCmd)
+	ownershipPlanCmd.SetUsageFunc(ownershipPlanCmd.UsageFunc())
+	planFlags := ownershipPlanCmd.Flags()
+	planFlags.StringP("output", "o", "-", "Path to the YAML file to write; \"-\" prints to stdout.")
+	planFlags.String("teams", "",
+		"Path to the YAML file which maps team names to the lists of developer names or emails.")
+	planFlags.Float64("threshold", 0.8, "Share of the lines which the bus factor owners must own.")
+	planFlags.Float64("coupling", 0.5,
+		"Minimum share of the co-changing commits to merge two directories into the same module.")
+	planFlags.Float64("balance", 1.5, "Maximum multiple of the fair share of the lines owned by a team.")
+	planFlags.Int("owners", 3, "Maximum number of the top owners of each module.")
+	_ = ownershipPlanCmd.MarkFlagRequired("teams")
+}
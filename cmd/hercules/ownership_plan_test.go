@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func fakeOwnershipPlanResults(t *testing.T) pb.AnalysisResults {
+	return pb.AnalysisResults{
+		Header: &pb.Metadata{Repository: "repo"},
+		Contents: map[string][]byte{
+			"Burndown": mustMarshal(t, &pb.BurndownAnalysisResults{
+				Files: []*pb.BurndownSparseMatrix{
+					{Name: "a/x.go"}, {Name: "a/y.go"}, {Name: "b/z.go"}, {Name: "c/w.go"},
+				},
+				FilesOwnership: []*pb.FilesOwnership{
+					{Value: map[int32]int32{0: 60, 1: 20}},
+					{Value: map[int32]int32{0: 20}},
+					{Value: map[int32]int32{0: 50}},
+					{Value: map[int32]int32{2: 30, 3: 10}},
+				},
+				People: []*pb.BurndownSparseMatrix{
+					{Name: "alice|alice@example.com"}, {Name: "bob"}, {Name: "carol"}, {Name: "dave"},
+				},
+			}),
+			"Couples": mustMarshal(t, &pb.CouplesAnalysisResults{
+				FileCouples: &pb.Couples{
+					Index: []string{"a/x.go", "a/y.go", "b/z.go", "c/w.go"},
+					Matrix: &pb.CompressedSparseRowMatrix{
+						NumberOfRows: 4, NumberOfColumns: 4,
+						Data:    []int64{5, 4, 2, 4, 4, 3},
+						Indices: []int32{0, 2, 1, 0, 2, 3},
+						Indptr:  []int64{0, 2, 3, 5, 6},
+					},
+				},
+				PeopleCouples: &pb.Couples{
+					Index:  []string{"alice|alice@example.com", "bob", "carol", "dave"},
+					Matrix: &pb.CompressedSparseRowMatrix{Indptr: []int64{0}},
+				},
+				FilesLines: []int32{80, 20, 50, 40},
+			}),
+			"OwnershipConcentration": mustMarshal(t, &pb.OwnershipConcentrationResults{
+				SubsystemGini: map[string]float64{"a": 0.5, "b": 0, "c": 0.25},
+			}),
+		},
+	}
+}
+
+func TestBuildOwnershipPlan(t *testing.T) {
+	teams := map[string]string{"alice@example.com": "core", "bob": "core", "carol": "web"}
+	plan, err := buildOwnershipPlan(fakeOwnershipPlanResults(t), teams, 0.8, 0.5, 1.5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Modules) != 2 {
+		t.Fatalf("unexpected modules: %v", plan.Modules)
+	}
+	first, second := plan.Modules[0], plan.Modules[1]
+	if first.Name != "a" || strings.Join(first.Directories, ",") != "a,b" || first.Files != 3 ||
+		first.Lines != 150 || first.Team != "core" || first.TeamLines != 150 ||
+		first.BusFactor != 1 || first.ProjectedBusFactor != 2 {
+		t.Fatalf("unexpected first module: %+v", first)
+	}
+	if first.Gini < 0.333 || first.Gini > 0.334 || first.Contention != -1 {
+		t.Fatalf("unexpected first module metrics: %v %v", first.Gini, first.Contention)
+	}
+	// core would exceed 1.5 times the fair share of the lines
+	if second.Name != "c" || second.Team != "web" || second.TeamLines != 30 ||
+		second.BusFactor != 2 || second.ProjectedBusFactor != 2 {
+		t.Fatalf("unexpected second module: %+v", second)
+	}
+	if len(plan.Teams) != 2 || plan.Teams[0].Name != "core" || plan.Teams[0].Lines != 150 ||
+		strings.Join(plan.Teams[0].Members, ",") != "alice,bob" || plan.Teams[1].Modules != 1 {
+		t.Fatalf("unexpected teams: %+v %+v", plan.Teams[0], plan.Teams[1])
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writeOwnershipPlan(buffer, plan, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := buffer.String()
+	for _, expected := range []string{
+		"ownership_plan:\n  repository: \"repo\"\n",
+		"    - name: \"core\"\n      members: [\"alice\", \"bob\"]\n      modules: 1\n      lines: 150\n",
+		"    - name: \"a\"\n      directories: [\"a\", \"b\"]\n      files: 3\n      lines: 150\n" +
+			"      ownership_gini: 0.333\n      top_owners:\n        \"alice\": 130\n" +
+			"      team: \"core\"\n      team_share: 1.000\n      bus_factor: 1\n      projected_bus_factor: 2\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("%q is missing in:\n%s", expected, text)
+		}
+	}
+}
+
+func TestBuildOwnershipPlanErrors(t *testing.T) {
+	message := fakeOwnershipPlanResults(t)
+	if _, err := buildOwnershipPlan(message, map[string]string{"eve": "qa"}, 0.8, 0.5, 1.5); err == nil {
+		t.Fatal("expected an error without the team members")
+	}
+	delete(message.Contents, "Couples")
+	if _, err := buildOwnershipPlan(message, map[string]string{"bob": "core"}, 0.8, 0.5, 1.5); err == nil {
+		t.Fatal("expected an error without Couples")
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// parquetColumn is a required column of the Parquet file written by writeParquet().
+// Either Strings or Int64s holds the values.
+type parquetColumn struct {
+	Name    string
+	Strings []string
+	Int64s  []int64
+}
+
+func (column parquetColumn) len() int {
+	if column.Strings != nil {
+		return len(column.Strings)
+	}
+	return len(column.Int64s)
+}
+
+// The subset of the Parquet format constants which writeParquet() uses,
+// see https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift
+const (
+	parquetMagic = "PAR1"
+
+	parquetTypeInt64     = 2
+	parquetTypeByteArray = 6
+
+	parquetRepetitionRequired = 0
+	parquetConvertedTypeUTF8  = 0
+	parquetEncodingPlain      = 0
+	parquetEncodingRLE        = 3
+	parquetCodecUncompressed  = 0
+	parquetPageTypeData       = 0
+)
+
+// writeParquet writes the columns as a Parquet file with a single row group. Each column is
+// a single uncompressed data page in the PLAIN encoding, so the file is as simple as possible
+// and any Parquet reader understands it. All the columns must have the same length.
+func writeParquet(writer io.Writer, columns []parquetColumn) error {
+	rows := 0
+	if len(columns) > 0 {
+		rows = columns[0].len()
+	}
+	for _, column := range columns {
+		if column.len() != rows {
+			return fmt.Errorf("parquet column %s has %d values instead of %d",
+				column.Name, column.len(), rows)
+		}
+	}
+	file := &bytes.Buffer{}
+	file.WriteString(parquetMagic)
+	type chunk struct {
+		offset, size int64
+	}
+	chunks := make([]chunk, len(columns))
+	if rows > 0 {
+		for i, column := range columns {
+			page := &bytes.Buffer{}
+			var scratch [8]byte
+			if column.Strings != nil {
+				for _, value := range column.Strings {
+					binary.LittleEndian.PutUint32(scratch[:4], uint32(len(value)))
+					page.Write(scratch[:4])
+					page.WriteString(value)
+				}
+			} else {
+				for _, value := range column.Int64s {
+					binary.LittleEndian.PutUint64(scratch[:], uint64(value))
+					page.Write(scratch[:])
+				}
+			}
+			header := &thriftCompactWriter{}
+			header.fieldI32(1, parquetPageTypeData)
+			header.fieldI32(2, int32(page.Len()))
+			header.fieldI32(3, int32(page.Len()))
+			header.fieldStructBegin(5)
+			header.fieldI32(1, int32(rows))
+			header.fieldI32(2, parquetEncodingPlain)
+			header.fieldI32(3, parquetEncodingRLE)
+			header.fieldI32(4, parquetEncodingRLE)
+			header.structEnd()
+			header.structEnd()
+			chunks[i] = chunk{offset: int64(file.Len()), size: int64(header.buf.Len() + page.Len())}
+			file.Write(header.buf.Bytes())
+			file.Write(page.Bytes())
+		}
+	}
+
+	meta := &thriftCompactWriter{}
+	meta.fieldI32(1, 1)
+	meta.fieldListBegin(2, thriftTypeStruct, len(columns)+1)
+	meta.structBegin()
+	meta.fieldBinary(4, "schema")
+	meta.fieldI32(5, int32(len(columns)))
+	meta.structEnd()
+	for _, column := range columns {
+		meta.structBegin()
+		if column.Strings != nil {
+			meta.fieldI32(1, parquetTypeByteArray)
+		} else {
+			meta.fieldI32(1, parquetTypeInt64)
+		}
+		meta.fieldI32(3, parquetRepetitionRequired)
+		meta.fieldBinary(4, column.Name)
+		if column.Strings != nil {
+			meta.fieldI32(6, parquetConvertedTypeUTF8)
+		}
+		meta.structEnd()
+	}
+	meta.fieldI64(3, int64(rows))
+	if rows == 0 {
+		meta.fieldListBegin(4, thriftTypeStruct, 0)
+	} else {
+		meta.fieldListBegin(4, thriftTypeStruct, 1)
+		meta.structBegin()
+		meta.fieldListBegin(1, thriftTypeStruct, len(columns))
+		var totalSize int64
+		for i, column := range columns {
+			totalSize += chunks[i].size
+			meta.structBegin()
+			meta.fieldI64(2, chunks[i].offset)
+			meta.fieldStructBegin(3)
+			if column.Strings != nil {
+				meta.fieldI32(1, parquetTypeByteArray)
+			} else {
+				meta.fieldI32(1, parquetTypeInt64)
+			}
+			meta.fieldListBegin(2, thriftTypeI32, 2)
+			meta.varint(zigzag(parquetEncodingPlain))
+			meta.varint(zigzag(parquetEncodingRLE))
+			meta.fieldListBegin(3, thriftTypeBinary, 1)
+			meta.binary(column.Name)
+			meta.fieldI32(4, parquetCodecUncompressed)
+			meta.fieldI64(5, int64(rows))
+			meta.fieldI64(6, chunks[i].size)
+			meta.fieldI64(7, chunks[i].size)
+			meta.fieldI64(9, chunks[i].offset)
+			meta.structEnd()
+			meta.structEnd()
+		}
+		meta.fieldI64(2, totalSize)
+		meta.fieldI64(3, int64(rows))
+		meta.structEnd()
+	}
+	meta.fieldBinary(6, "hercules")
+	meta.structEnd()
+
+	file.Write(meta.buf.Bytes())
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(meta.buf.Len()))
+	file.Write(length[:])
+	file.WriteString(parquetMagic)
+	_, err := writer.Write(file.Bytes())
+	return err
+}
+
+// The Thrift compact protocol types.
+const (
+	thriftTypeI32    = 5
+	thriftTypeI64    = 6
+	thriftTypeBinary = 8
+	thriftTypeList   = 9
+	thriftTypeStruct = 12
+)
+
+// thriftCompactWriter encodes the structs in the Thrift compact protocol which the Parquet
+// metadata uses. It supports only the field types which writeParquet() needs.
+type thriftCompactWriter struct {
+	buf bytes.Buffer
+	// lastFields are the previous field ids of the nested structs.
+	lastFields []int16
+	lastField  int16
+}
+
+func zigzag(value int64) uint64 {
+	return uint64((value << 1) ^ (value >> 63))
+}
+
+func (w *thriftCompactWriter) varint(value uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	w.buf.Write(scratch[:binary.PutUvarint(scratch[:], value)])
+}
+
+func (w *thriftCompactWriter) binary(value string) {
+	w.varint(uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+func (w *thriftCompactWriter) fieldHeader(id int16, kind byte) {
+	if delta := id - w.lastField; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | kind)
+	} else {
+		w.buf.WriteByte(kind)
+		w.varint(zigzag(int64(id)))
+	}
+	w.lastField = id
+}
+
+func (w *thriftCompactWriter) fieldI32(id int16, value int32) {
+	w.fieldHeader(id, thriftTypeI32)
+	w.varint(zigzag(int64(value)))
+}
+
+func (w *thriftCompactWriter) fieldI64(id int16, value int64) {
+	w.fieldHeader(id, thriftTypeI64)
+	w.varint(zigzag(value))
+}
+
+func (w *thriftCompactWriter) fieldBinary(id int16, value string) {
+	w.fieldHeader(id, thriftTypeBinary)
+	w.binary(value)
+}
+
+// fieldListBegin writes the list header; the elements follow without the field headers.
+func (w *thriftCompactWriter) fieldListBegin(id int16, elementType byte, size int) {
+	w.fieldHeader(id, thriftTypeList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	w.buf.WriteByte(0xf0 | elementType)
+	w.varint(uint64(size))
+}
+
+func (w *thriftCompactWriter) fieldStructBegin(id int16) {
+	w.fieldHeader(id, thriftTypeStruct)
+	w.structBegin()
+}
+
+// structBegin starts a nested struct: a field value or a list element.
+func (w *thriftCompactWriter) structBegin() {
+	w.lastFields = append(w.lastFields, w.lastField)
+	w.lastField = 0
+}
+
+// structEnd writes the stop field. The top-level struct is ended without structBegin().
+func (w *thriftCompactWriter) structEnd() {
+	w.buf.WriteByte(0)
+	if n := len(w.lastFields); n > 0 {
+		w.lastField = w.lastFields[n-1]
+		w.lastFields = w.lastFields[:n-1]
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestWriteParquet(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	err := writeParquet(buffer, []parquetColumn{
+		{Name: "name", Strings: []string{"a", "bc"}},
+		{Name: "value", Int64s: []int64{1, -2}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data := buffer.Bytes()
+	if !bytes.HasPrefix(data, []byte(parquetMagic)) || !bytes.HasSuffix(data, []byte(parquetMagic)) {
+		t.Fatalf("invalid magic: %q", data)
+	}
+	footer := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	if footer <= 0 || footer > len(data)-12 {
+		t.Fatalf("invalid footer length %d of %d bytes", footer, len(data))
+	}
+	meta := data[len(data)-8-footer : len(data)-8]
+	for _, value := range []string{"schema", "name", "value", "hercules"} {
+		if !bytes.Contains(meta, []byte(value)) {
+			t.Fatalf("%s is missing in the metadata: %q", value, meta)
+		}
+	}
+	// PLAIN strings are prefixed with their 4-byte length
+	if !bytes.Contains(data, []byte("\x01\x00\x00\x00a\x02\x00\x00\x00bc")) {
+		t.Fatalf("the strings are not PLAIN encoded: %q", data)
+	}
+	if !bytes.Contains(data, []byte("\xfe\xff\xff\xff\xff\xff\xff\xff")) {
+		t.Fatalf("the integers are not PLAIN encoded: %q", data)
+	}
+
+	buffer.Reset()
+	if err := writeParquet(buffer, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(buffer.Bytes(), []byte(parquetMagic)) {
+		t.Fatalf("invalid empty file: %q", buffer.Bytes())
+	}
+	err = writeParquet(buffer, []parquetColumn{
+		{Name: "name", Strings: []string{"a"}},
+		{Name: "value", Int64s: []int64{1, 2}},
+	})
+	if err == nil {
+		t.Fatal("expected an error for the columns of different lengths")
+	}
+}
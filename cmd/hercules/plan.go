@@ -0,0 +1,179 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// costPerCommit is the approximate time which an analysis of the given cost class spends
+// on a single commit of a repository with costReferenceFiles files. The values are rough
+// averages measured on mid-sized open source repositories.
+var costPerCommit = map[core.CostClass]time.Duration{
+	core.CostCheap:  2 * time.Millisecond,
+	core.CostMedium: 10 * time.Millisecond,
+	core.CostHeavy:  40 * time.Millisecond,
+}
+
+// costReferenceFiles is the number of files at HEAD which costPerCommit corresponds to.
+const costReferenceFiles = 1000
+
+// repositoryStats holds the size of the repository which the run time estimation depends on.
+type repositoryStats struct {
+	Commits int
+	Files   int
+}
+
+// collectRepositoryStats counts the commits to analyse and the files at HEAD.
+func collectRepositoryStats(repository *git.Repository, firstParent bool) (repositoryStats, error) {
+	var stats repositoryStats
+	commits, err := hercules.NewPipeline(repository).Commits(firstParent)
+	if err != nil {
+		return stats, err
+	}
+	stats.Commits = len(commits)
+	if len(commits) == 0 {
+		return stats, nil
+	}
+	tree, err := commits[len(commits)-1].Tree()
+	if err != nil {
+		return stats, err
+	}
+	files := tree.Files()
+	defer files.Close()
+	err = files.ForEach(func(*object.File) error {
+		stats.Files++
+		return nil
+	})
+	return stats, err
+}
+
+// estimateRunTime approximates how long the analysis of the given cost class takes.
+// Bigger trees make each commit more expensive because the diffs and the blobs grow.
+func estimateRunTime(cost core.CostClass, stats repositoryStats) time.Duration {
+	perCommit := costPerCommit[cost]
+	if perCommit == 0 {
+		perCommit = costPerCommit[core.CostMedium]
+	}
+	scale := 1 + float64(stats.Files)/costReferenceFiles
+	return time.Duration(float64(perCommit) * float64(stats.Commits) * scale)
+}
+
+// plannedAnalysis is a single analysis considered by planAnalyses().
+type plannedAnalysis struct {
+	Leaf     core.LeafPipelineItem
+	Cost     core.CostClass
+	Estimate time.Duration
+}
+
+// planAnalyses greedily selects the cheapest analyses until the estimated total run time
+// exceeds the budget. The rest are returned as skipped. The order among the analyses
+// with the same estimate is preserved.
+func planAnalyses(leaves []core.LeafPipelineItem, stats repositoryStats, budget time.Duration,
+) (selected, skipped []plannedAnalysis) {
+	planned := make([]plannedAnalysis, len(leaves))
+	for i, leaf := range leaves {
+		cost := core.GetCost(leaf)
+		planned[i] = plannedAnalysis{Leaf: leaf, Cost: cost, Estimate: estimateRunTime(cost, stats)}
+	}
+	sort.SliceStable(planned, func(i, j int) bool {
+		return planned[i].Estimate < planned[j].Estimate
+	})
+	var total time.Duration
+	for _, analysis := range planned {
+		if total+analysis.Estimate <= budget {
+			total += analysis.Estimate
+			selected = append(selected, analysis)
+		} else {
+			skipped = append(skipped, analysis)
+		}
+	}
+	return selected, skipped
+}
+
+// writePlan prints the selected and the skipped analyses and the command line to run.
+func writePlan(writer io.Writer, uri string, firstParent bool, stats repositoryStats, budget time.Duration,
+	selected, skipped []plannedAnalysis,
+) error {
+	var builder strings.Builder
+	var total time.Duration
+	for _, analysis := range selected {
+		total += analysis.Estimate
+	}
+	fmt.Fprintf(&builder, "%d commits, %d files at HEAD\n", stats.Commits, stats.Files)
+	fmt.Fprintf(&builder, "budget %v, estimated %v\n", budget, total.Round(time.Second))
+	section := func(title string, analyses []plannedAnalysis) {
+		if len(analyses) == 0 {
+			return
+		}
+		fmt.Fprintf(&builder, "\n%s:\n", title)
+		for _, analysis := range analyses {
+			fmt.Fprintf(&builder, "  --%-28s %-6s ~%v\n", analysis.Leaf.Flag(), analysis.Cost,
+				analysis.Estimate.Round(time.Second))
+		}
+	}
+	section("selected", selected)
+	section("skipped", skipped)
+	if len(selected) > 0 {
+		var flags []string
+		if firstParent {
+			flags = append(flags, "--first-parent")
+		}
+		for _, analysis := range selected {
+			flags = append(flags, "--"+analysis.Leaf.Flag())
+		}
+		fmt.Fprintf(&builder, "\nhercules %s %s\n", strings.Join(flags, " "), uri)
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// planCmd selects the analyses which fit the run time budget.
+var planCmd = &cobra.Command{
+	Use:   "plan [flags] <repository> [analysis...]",
+	Short: "Select the analyses which fit the approximate run time budget.",
+	Long: `Estimates the run time of each analysis from its cost class (cheap, medium or heavy)
+and from the number of commits and files in the repository, then selects the cheapest analyses
+until the --budget is exhausted. The analyses are specified by their flags or names; all
+the registered analyses are considered if none is specified. The estimation is rough and
+ignores the plumbing shared between the analyses, so the real run time is usually lower.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		budget, _ := flags.GetDuration("budget")
+		firstParent, _ := flags.GetBool("first-parent")
+		sshIdentity, _ := flags.GetString("ssh-identity")
+		leaves, err := resolveLeaves(hercules.Registry, args[1:])
+		if err != nil {
+			return err
+		}
+		repository, _, _, err := loadRepositoryWithError(args[0], "", true, sshIdentity, cloneSettings{})
+		if err != nil {
+			return err
+		}
+		stats, err := collectRepositoryStats(repository, firstParent)
+		if err != nil {
+			return err
+		}
+		selected, skipped := planAnalyses(leaves, stats, budget)
+		return writePlan(os.Stdout, args[0], firstParent, stats, budget, selected, skipped)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	planCmd.SetUsageFunc(planCmd.UsageFunc())
+	planFlags := planCmd.Flags()
+	planFlags.Duration("budget", 10*time.Minute, "Approximate run time budget.")
+	planFlags.Bool("first-parent", false, "Count only the commits which --first-parent follows.")
+	planFlags.String("ssh-identity", "", "Path to SSH identity file to clone from an SSH remote.")
+}
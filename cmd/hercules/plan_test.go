@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules"
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+func TestEstimateRunTime(t *testing.T) {
+	stats := repositoryStats{Commits: 1000, Files: 1000}
+	if estimate := estimateRunTime(core.CostCheap, stats); estimate != 4*time.Second {
+		t.Fatalf("unexpected cheap estimate: %v", estimate)
+	}
+	if estimate := estimateRunTime(core.CostHeavy, stats); estimate != 80*time.Second {
+		t.Fatalf("unexpected heavy estimate: %v", estimate)
+	}
+	if estimateRunTime("unknown", stats) != estimateRunTime(core.CostMedium, stats) {
+		t.Fatal("unknown cost classes must be treated as medium")
+	}
+}
+
+func TestPlanAnalyses(t *testing.T) {
+	leaves, err := resolveLeaves(hercules.Registry, []string{"burndown", "devs", "couples"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats := repositoryStats{Commits: 1000, Files: 0}
+	selected, skipped := planAnalyses(leaves, stats, 15*time.Second)
+	if len(selected) != 2 || selected[0].Leaf.Name() != "Couples" || selected[1].Leaf.Name() != "Devs" {
+		t.Fatalf("unexpected selection: %v", selected)
+	}
+	if len(skipped) != 1 || skipped[0].Leaf.Name() != "Burndown" || skipped[0].Cost != core.CostHeavy {
+		t.Fatalf("unexpected skipped: %v", skipped)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writePlan(buffer, "repo", true, stats, 15*time.Second, selected, skipped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `1000 commits, 0 files at HEAD
+budget 15s, estimated 12s
+
+selected:
+  --couples                      cheap  ~2s
+  --devs                         medium ~10s
+
+skipped:
+  --burndown                     heavy  ~40s
+
+hercules --first-parent --couples --devs repo
+`
+	if buffer.String() != expected {
+		t.Fatalf("unexpected output:\n%s", buffer.String())
+	}
+
+	selected, skipped = planAnalyses(leaves, stats, time.Second)
+	if len(selected) != 0 || len(skipped) != 3 {
+		t.Fatalf("nothing must fit: %v %v", selected, skipped)
+	}
+}
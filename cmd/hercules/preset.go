@@ -9,6 +9,15 @@ import (
 
 // presetDefaults maps preset names to their flag defaults.
 var presetDefaults = map[string]map[string]string{
+	// chaoss runs the analyses which "hercules export chaoss" needs.
+	"chaoss": {
+		"bus-factor":             "true",
+		"commits-stat":           "true",
+		"contribution-diversity": "true",
+		"devs":                   "true",
+		"organizations":          "true",
+		"pb":                     "true",
+	},
 	"large-repo": {
 		"first-parent":                "true",
 		"lines-hibernation-threshold": "200000",
@@ -31,7 +40,7 @@ func applyPreset(flags *pflag.FlagSet) {
 
 	defaults, ok := presetDefaults[presetName]
 	if !ok {
-		fmt.Fprintf(os.Stderr, "warning: unknown preset %q (available: large-repo, quick)\n", presetName)
+		fmt.Fprintf(os.Stderr, "warning: unknown preset %q (available: chaoss, large-repo, quick)\n", presetName)
 		return
 	}
 
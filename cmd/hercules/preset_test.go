@@ -86,3 +86,29 @@ func TestApplyPresetNone(t *testing.T) {
 	fp, _ := flags.GetBool("first-parent")
 	assert.False(t, fp)
 }
+
+func TestApplyPresetChaoss(t *testing.T) {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("preset", "", "")
+	for _, name := range []string{
+		"bus-factor", "commits-stat", "contribution-diversity", "devs", "organizations", "pb",
+	} {
+		flags.Bool(name, false, "")
+	}
+
+	err := flags.Set("preset", "chaoss")
+	assert.NoError(t, err)
+	err = flags.Set("pb", "false")
+	assert.NoError(t, err)
+
+	applyPreset(flags)
+
+	for _, name := range []string{
+		"bus-factor", "commits-stat", "contribution-diversity", "devs", "organizations",
+	} {
+		value, _ := flags.GetBool(name)
+		assert.True(t, value, name)
+	}
+	pb, _ := flags.GetBool("pb")
+	assert.False(t, pb)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/meko-christian/hercules"
+	progress "gopkg.in/cheggaaa/pb.v1"
+)
+
+// terminalProgress renders hercules.ProgressEvent-s as a single-line progress bar with the
+// current action, the estimated remaining time and the slowest item of the latest commit.
+type terminalProgress struct {
+	writer io.Writer
+	bar    *progress.ProgressBar
+	// slowest is the name of the item which took the most time on the latest commit.
+	slowest string
+	// width is the fixed width of the bar, 0 fits it into the terminal up to 80 characters.
+	width int
+}
+
+func newTerminalProgress(writer io.Writer) *terminalProgress {
+	return &terminalProgress{writer: writer}
+}
+
+// Report implements hercules.ProgressReporter.
+func (tp *terminalProgress) Report(event hercules.ProgressEvent) {
+	if tp.bar == nil {
+		tp.bar = progress.New(event.Total)
+		tp.bar.Callback = func(msg string) {
+			_, _ = io.WriteString(tp.writer, "\033[2K\r"+msg)
+		}
+		tp.bar.NotPrint = true
+		tp.bar.ShowPercent = false
+		tp.bar.ShowSpeed = false
+		tp.bar.ShowTimeLeft = false
+		if tp.width > 0 {
+			tp.bar.SetWidth(tp.width)
+		} else {
+			tp.bar.SetMaxWidth(80)
+		}
+		tp.bar.Start()
+	}
+	if event.Action == hercules.MessageFinalize {
+		tp.bar.Finish()
+		_, _ = fmt.Fprint(tp.writer, "\033[2K\rfinalizing...")
+		return
+	}
+	if event.Step >= event.Total {
+		return
+	}
+	if name := slowestItem(event.ItemTimes); name != "" {
+		tp.slowest = name
+	}
+	tp.bar.Set(event.Step).Postfix(formatProgressPostfix(event, tp.slowest))
+}
+
+// slowestItem returns the name of the item with the largest elapsed time.
+func slowestItem(times map[string]time.Duration) string {
+	var name string
+	var max time.Duration
+	for key, val := range times {
+		if val > max || (val == max && key < name) {
+			name, max = key, val
+		}
+	}
+	return name
+}
+
+func formatProgressPostfix(event hercules.ProgressEvent, slowest string) string {
+	postfix := " [" + event.Action + "]"
+	if event.ETA > 0 {
+		postfix += " ETA " + formatETA(event.ETA)
+	}
+	if slowest != "" {
+		postfix += " slowest: " + slowest
+	}
+	return postfix + " "
+}
+
+// formatETA prints the duration with at most second precision, e.g. "1h02m03s".
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	s := d / time.Second
+	if h > 0 {
+		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
+	}
+	if m > 0 {
+		return fmt.Sprintf("%dm%02ds", m, s)
+	}
+	return fmt.Sprintf("%ds", s)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatETA(t *testing.T) {
+	assert.Equal(t, "5s", formatETA(5*time.Second+200*time.Millisecond))
+	assert.Equal(t, "2m03s", formatETA(123*time.Second))
+	assert.Equal(t, "1h02m03s", formatETA(time.Hour+2*time.Minute+3*time.Second))
+}
+
+func TestSlowestItem(t *testing.T) {
+	assert.Equal(t, "", slowestItem(nil))
+	assert.Equal(t, "FileDiff", slowestItem(map[string]time.Duration{
+		"TreeDiff": time.Millisecond, "FileDiff": 5 * time.Millisecond, "BlobCache": 2 * time.Millisecond,
+	}))
+}
+
+// syncBuffer is a bytes.Buffer which the progress bar refresher can write concurrently.
+type syncBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buffer.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.buffer.String()
+}
+
+func TestTerminalProgressReport(t *testing.T) {
+	buffer := &syncBuffer{}
+	tp := newTerminalProgress(buffer)
+	tp.width = 72
+	tp.Report(hercules.ProgressEvent{Step: 1, Total: 4, Action: "emerge"})
+	tp.Report(hercules.ProgressEvent{
+		Step: 2, Total: 4, Action: "af9ddc0", ETA: 90 * time.Second,
+		ItemTimes: map[string]time.Duration{"FileDiff": time.Second, "TreeDiff": time.Millisecond},
+	})
+	// the slowest item sticks until another commit reports the item times
+	tp.Report(hercules.ProgressEvent{Step: 3, Total: 4, Action: "7f3e0b1", ETA: 45 * time.Second})
+	tp.Report(hercules.ProgressEvent{Step: 4, Total: 4, Action: hercules.MessageFinalize})
+	output := buffer.String()
+	// Finish() renders the bar for the last time before "finalizing..."
+	lines := strings.Split(output, "\033[2K\r")
+	assert.True(t, len(lines) >= 3, output)
+	assert.Equal(t, "finalizing...", lines[len(lines)-1])
+	bar := lines[len(lines)-2]
+	// the counters, the bar which fills the rest of the width, the elapsed time and the postfix
+	assert.Regexp(t, `^ 3 / 4 \[=================>-----\] \d+s \[7f3e0b1\] ETA 45s slowest: FileDiff $`, bar)
+}
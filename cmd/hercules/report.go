@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"os"
@@ -114,12 +115,39 @@ var reportValidModes = map[string]struct{}{
 	"hotspot-risk":            {},
 }
 
+// reportModeAnalyses maps each labours mode to the analysis flags whose results it reads.
+var reportModeAnalyses = map[string][]string{
+	"burndown-project":        {"burndown"},
+	"burndown-file":           {"burndown-files"},
+	"burndown-person":         {"burndown-people"},
+	"burndown-repository":     {"burndown"},
+	"burndown-repos-combined": {"burndown"},
+	"overwrites-matrix":       {"burndown-people"},
+	"ownership":               {"burndown-people"},
+	"couples-files":           {"couples"},
+	"couples-people":          {"couples"},
+	"couples-shotness":        {"shotness"},
+	"shotness":                {"shotness"},
+	"sentiment":               {"sentiment"},
+	"temporal-activity":       {"temporal-activity"},
+	"devs":                    {"devs"},
+	"devs-efforts":            {"devs"},
+	"old-vs-new":              {"devs"},
+	"languages":               {"devs"},
+	"devs-parallel":           {"burndown-people", "couples", "devs"},
+	"bus-factor":              {"bus-factor"},
+	"ownership-concentration": {"ownership-concentration"},
+	"knowledge-diffusion":     {"knowledge-diffusion"},
+	"hotspot-risk":            {"hotspot-risk"},
+}
+
 // reportCmd generates a complete labours report in one command.
 var reportCmd = &cobra.Command{
 	Use:   "report [flags] <repository> [cache-path]",
 	Short: "Generate a complete report directory with charts and summary.",
 	Long: `Runs Hercules in Protocol Buffers mode, invokes labours internally and writes
-an output directory with generated chart assets and index.html summary.`,
+an output directory with generated chart assets, index.html summary and manifest.json
+which lists every chart with its mode, source analyses and status.`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		flags := cmd.Flags()
@@ -166,14 +194,36 @@ an output directory with generated chart assets and index.html summary.`,
 		if err != nil {
 			return err
 		}
+		bundle, err := flags.GetBool("bundle")
+		if err != nil {
+			return err
+		}
+		summaryCmd, err := flags.GetString("summary-cmd")
+		if err != nil {
+			return err
+		}
+		summaryURL, err := flags.GetString("summary-url")
+		if err != nil {
+			return err
+		}
+		summaryTimeout, err := flags.GetDuration("summary-timeout")
+		if err != nil {
+			return err
+		}
+		summarizer, err := newReportSummarizer(summaryCmd, summaryURL)
+		if err != nil {
+			return err
+		}
+		if bundle && !allAnalyses && len(requestedAnalyses) == 0 {
+			requestedAnalyses = reportBundleAnalyses
+		}
 
-		availableAnalysisFlags := make(map[string]struct{})
-		for _, leaf := range hercules.Registry.GetLeaves() {
-			flag := leaf.Flag()
-			if flag != "" {
-				availableAnalysisFlags[flag] = struct{}{}
-			}
+		inferAnalyses, err := flags.GetBool("infer-analyses")
+		if err != nil {
+			return err
 		}
+
+		availableAnalysisFlags := reportAvailableAnalysisFlags()
 		analysisFlags, err := selectReportAnalysisFlags(availableAnalysisFlags, requestedAnalyses, allAnalyses)
 		if err != nil {
 			return err
@@ -182,6 +232,18 @@ an output directory with generated chart assets and index.html summary.`,
 		if err != nil {
 			return err
 		}
+		if len(requestedModes) > 0 && !bundle {
+			var added []string
+			analysisFlags, added, err = inferReportAnalysisFlags(
+				availableAnalysisFlags, analysisFlags, modes, inferAnalyses)
+			if err != nil {
+				return err
+			}
+			if len(added) > 0 {
+				_, _ = fmt.Fprintf(os.Stderr, "report: enabled --analysis %s required by the requested modes\n",
+					strings.Join(added, ","))
+			}
+		}
 
 		outputDir = filepath.Clean(outputDir)
 		if err := os.MkdirAll(outputDir, 0o755); err != nil {
@@ -211,6 +273,25 @@ an output directory with generated chart assets and index.html summary.`,
 			return fmt.Errorf("failed to parse generated protobuf report: %w", err)
 		}
 
+		if bundle {
+			written, err := writeReportBundle(outputDir, pbMessage)
+			if err != nil {
+				return err
+			}
+			indexData := newReportIndexData(pbMessage, analysisFlags, nil, nil, nil, written, "json")
+			indexData.Assets = append(indexData.Assets, "report.pb", reportManifestName)
+			sort.Strings(indexData.Assets)
+			if err := addReportSummary(summarizer, &indexData, summaryTimeout, strict); err != nil {
+				return err
+			}
+			if err := writeReportManifest(filepath.Join(outputDir, reportManifestName),
+				newReportManifest(indexData)); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "report: done. Serve %s over HTTP and open index.html\n", outputDir)
+			return nil
+		}
+
 		cwd, err := os.Getwd()
 		if err != nil {
 			return err
@@ -254,6 +335,15 @@ an output directory with generated chart assets and index.html summary.`,
 
 		indexFile := filepath.Join(outputDir, "index.html")
 		indexData := newReportIndexData(pbMessage, analysisFlags, modes, modeResults, plots, assets, format)
+		if err := addReportSummary(summarizer, &indexData, summaryTimeout, strict); err != nil {
+			return err
+		}
+		manifestFile := filepath.Join(outputDir, reportManifestName)
+		if err := writeReportManifest(manifestFile, newReportManifest(indexData)); err != nil {
+			return err
+		}
+		indexData.Assets = append(indexData.Assets, reportManifestName)
+		sort.Strings(indexData.Assets)
 		if err := writeReportIndex(indexFile, indexData); err != nil {
 			return err
 		}
@@ -266,19 +356,39 @@ an output directory with generated chart assets and index.html summary.`,
 	},
 }
 
+// isReportFlagSupportedInBuild returns false for the analysis flags which need optional build tags.
+func isReportFlagSupportedInBuild(flag string) bool {
+	if flag == "sentiment" && !tensorflowEnabled {
+		return false
+	}
+	return true
+}
+
+// reportAvailableAnalysisFlags returns the flags of the registered leaves together with
+// the switches which refine their results, like --burndown-files.
+func reportAvailableAnalysisFlags() map[string]struct{} {
+	available := make(map[string]struct{})
+	for _, leaf := range hercules.Registry.GetLeaves() {
+		flag := leaf.Flag()
+		if flag != "" {
+			available[flag] = struct{}{}
+		}
+		for _, opt := range leaf.ListConfigurationOptions() {
+			if opt.Type == hercules.BoolConfigurationOption && opt.Flag != "" {
+				available[opt.Flag] = struct{}{}
+			}
+		}
+	}
+	return available
+}
+
 func selectReportAnalysisFlags(
 	available map[string]struct{}, requested []string, includeAll bool,
 ) ([]string, error) {
 	set := map[string]struct{}{}
-	isSupportedInBuild := func(flag string) bool {
-		if flag == "sentiment" && !tensorflowEnabled {
-			return false
-		}
-		return true
-	}
 	if includeAll {
 		for _, flag := range reportAllAnalysisFlags {
-			if !isSupportedInBuild(flag) {
+			if !isReportFlagSupportedInBuild(flag) {
 				continue
 			}
 			if _, exists := available[flag]; exists {
@@ -287,7 +397,7 @@ func selectReportAnalysisFlags(
 		}
 	} else if len(requested) > 0 {
 		for _, flag := range requested {
-			if !isSupportedInBuild(flag) {
+			if !isReportFlagSupportedInBuild(flag) {
 				return nil, fmt.Errorf("analysis flag %q is unavailable in this build; rebuild with -tags tensorflow", flag)
 			}
 			if _, exists := available[flag]; !exists {
@@ -297,7 +407,7 @@ func selectReportAnalysisFlags(
 		}
 	} else {
 		for _, flag := range reportDefaultAnalysisFlags {
-			if !isSupportedInBuild(flag) {
+			if !isReportFlagSupportedInBuild(flag) {
 				continue
 			}
 			if _, exists := available[flag]; exists {
@@ -316,6 +426,54 @@ func selectReportAnalysisFlags(
 	return result, nil
 }
 
+// inferReportAnalysisFlags checks that the selected analysis flags cover every mode according
+// to reportModeAnalyses. If `infer` is true, the missing flags are added to the result and
+// returned separately, otherwise an error lists them together with the modes which need them.
+func inferReportAnalysisFlags(
+	available map[string]struct{}, selected []string, modes []string, infer bool,
+) ([]string, []string, error) {
+	set := map[string]struct{}{}
+	for _, flag := range selected {
+		set[flag] = struct{}{}
+	}
+	missing := map[string][]string{}
+	for _, mode := range modes {
+		for _, flag := range reportModeAnalyses[mode] {
+			if _, exists := set[flag]; !exists {
+				missing[flag] = append(missing[flag], mode)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return selected, nil, nil
+	}
+	added := make([]string, 0, len(missing))
+	for flag := range missing {
+		added = append(added, flag)
+	}
+	sort.Strings(added)
+	if !infer {
+		details := make([]string, len(added))
+		for i, flag := range added {
+			details[i] = fmt.Sprintf("%s (needed by %s)", flag, strings.Join(missing[flag], ", "))
+		}
+		return nil, nil, fmt.Errorf("the requested modes need analyses which are not enabled: %s; "+
+			"add --analysis %s", strings.Join(details, "; "), strings.Join(added, ","))
+	}
+	for _, flag := range added {
+		if !isReportFlagSupportedInBuild(flag) {
+			return nil, nil, fmt.Errorf("mode %s needs the analysis flag %q which is unavailable in "+
+				"this build; rebuild with -tags tensorflow", missing[flag][0], flag)
+		}
+		if _, exists := available[flag]; !exists {
+			return nil, nil, fmt.Errorf("mode %s needs the unknown analysis flag %q", missing[flag][0], flag)
+		}
+	}
+	result := append(append([]string{}, selected...), added...)
+	sort.Strings(result)
+	return result, added, nil
+}
+
 func selectReportModes(requested []string, includeAll bool) ([]string, error) {
 	var source []string
 	switch {
@@ -473,6 +631,9 @@ type reportIndexData struct {
 	Plots       []string
 	Assets      []string
 	Format      string
+	Findings    []finding
+	// Summary is the executive summary returned by --summary-cmd or --summary-url.
+	Summary string
 }
 
 func newReportIndexData(
@@ -515,6 +676,9 @@ func newReportIndexData(
 		analyses = append([]string{}, analysisFlags...)
 	}
 
+	// the results were decoded successfully before, so the errors are not expected
+	findings, _ := buildFindings(message)
+
 	return reportIndexData{
 		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 		Repository:  repository,
@@ -530,7 +694,107 @@ func newReportIndexData(
 		Plots:       plots,
 		Assets:      assets,
 		Format:      strings.ToUpper(format),
+		Findings:    findings,
+	}
+}
+
+// reportManifestName is the file name of the machine-readable report manifest.
+const reportManifestName = "manifest.json"
+
+// reportManifest is the machine-readable counterpart of index.html. It lists every requested
+// labours mode together with the chart files it produced, so that external portals can embed
+// individual charts without scraping the HTML.
+type reportManifest struct {
+	GeneratedAt string                `json:"generated_at"`
+	Repository  string                `json:"repository"`
+	Version     int32                 `json:"version"`
+	GitHash     string                `json:"hash"`
+	BeginTime   string                `json:"begin_time"`
+	EndTime     string                `json:"end_time"`
+	Commits     int32                 `json:"commits"`
+	RuntimeMS   int64                 `json:"run_time_ms"`
+	Format      string                `json:"format"`
+	Analyses    []string              `json:"analyses"`
+	Charts      []reportManifestChart `json:"charts"`
+	Assets      []string              `json:"assets"`
+	Findings    []finding             `json:"findings"`
+	Summary     string                `json:"summary,omitempty"`
+}
+
+// reportManifestChart describes the outcome of a single labours mode.
+type reportManifestChart struct {
+	Mode     string   `json:"mode"`
+	Analyses []string `json:"analyses"`
+	// Status is "ok" if the mode produced at least one chart, "failed" if labours exited
+	// with an error and "empty" if it succeeded without writing any chart.
+	Status string   `json:"status"`
+	Error  string   `json:"error,omitempty"`
+	Files  []string `json:"files"`
+}
+
+// newReportManifest builds the manifest from the same data which is rendered into index.html.
+// Chart files are attributed to modes by their path: labours writes either
+// "charts/<mode>.<ext>" or several files inside "charts/<mode>/".
+func newReportManifest(data reportIndexData) reportManifest {
+	failures := map[string]string{}
+	for _, failure := range data.Failures {
+		failures[failure.Mode] = failure.Error
 	}
+	charts := make([]reportManifestChart, 0, len(data.Modes))
+	for _, mode := range data.Modes {
+		prefix := "charts/" + sanitizePathComponent(mode)
+		files := []string{}
+		for _, plot := range data.Plots {
+			if strings.HasPrefix(plot, prefix+"/") ||
+				strings.TrimSuffix(plot, filepath.Ext(plot)) == prefix {
+				files = append(files, plot)
+			}
+		}
+		analyses := reportModeAnalyses[mode]
+		if analyses == nil {
+			analyses = []string{}
+		}
+		chart := reportManifestChart{
+			Mode:     mode,
+			Analyses: analyses,
+			Files:    files,
+		}
+		if errText, failed := failures[mode]; failed {
+			chart.Status = "failed"
+			chart.Error = errText
+		} else if len(files) == 0 {
+			chart.Status = "empty"
+		} else {
+			chart.Status = "ok"
+		}
+		charts = append(charts, chart)
+	}
+	assets := append([]string{}, data.Assets...)
+	findings := append([]finding{}, data.Findings...)
+	return reportManifest{
+		GeneratedAt: data.GeneratedAt,
+		Repository:  data.Repository,
+		Version:     data.Version,
+		GitHash:     data.GitHash,
+		BeginTime:   data.BeginTime,
+		EndTime:     data.EndTime,
+		Commits:     data.Commits,
+		RuntimeMS:   data.RuntimeMS,
+		Format:      strings.ToLower(data.Format),
+		Analyses:    append([]string{}, data.Analyses...),
+		Charts:      charts,
+		Assets:      assets,
+		Findings:    findings,
+		Summary:     data.Summary,
+	}
+}
+
+func writeReportManifest(path string, manifest reportManifest) error {
+	payload, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(payload, '\n'), 0o644)
 }
 
 const reportIndexTemplate = `<!doctype html>
@@ -577,6 +841,9 @@ const reportIndexTemplate = `<!doctype html>
     .plot {
       margin-bottom: 1.25rem;
     }
+    .summary {
+      white-space: pre-wrap;
+    }
     code {
       background: #eef3fb;
       padding: 0.1rem 0.3rem;
@@ -601,6 +868,22 @@ const reportIndexTemplate = `<!doctype html>
     </ul>
   </section>
 
+  {{if .Summary}}
+  <section class="card">
+    <h2>Executive Summary</h2>
+    <p class="summary">{{.Summary}}</p>
+  </section>
+  {{end}}
+
+  {{if .Findings}}
+  <section class="card">
+    <h2>Findings</h2>
+    <ol>
+      {{range .Findings}}<li><code>{{.Kind}}</code> {{.Message}}</li>{{end}}
+    </ol>
+  </section>
+  {{end}}
+
   <section class="card">
     <h2>Collected Analyses</h2>
     <ul>
@@ -663,7 +946,7 @@ func init() {
 	reportCmd.Flags().Bool("all", false,
 		"Enable all report analysis flags and request all labours modes.")
 	reportCmd.Flags().StringP("output", "o", "./report",
-		"Output directory for report.pb, chart assets and index.html.")
+		"Output directory for report.pb, chart assets, index.html and manifest.json.")
 	reportCmd.Flags().String("format", "png", "Chart output format: png or svg.")
 	reportCmd.Flags().Bool("strict", false,
 		"Fail immediately if any labours mode fails.")
@@ -671,10 +954,24 @@ func init() {
 		"Enable only selected analysis flags (without leading --).")
 	reportCmd.Flags().StringSlice("mode", nil,
 		"Run only selected labours modes.")
+	reportCmd.Flags().Bool("infer-analyses", true,
+		"Enable the analysis flags which the selected --mode-s need. If disabled, fail "+
+			"before running hercules when any is missing.")
 	reportCmd.Flags().StringArray("hercules-arg", nil,
 		"Additional argument passed through to the internal hercules run.")
 	reportCmd.Flags().StringArray("labours-arg", nil,
 		"Additional argument passed through to each labours mode run.")
 	reportCmd.Flags().String("labours-cmd", "",
 		"Override labours launcher, e.g. \"labours\" or \"python3 -m labours\".")
+	reportCmd.Flags().String("summary-cmd", "",
+		"Shell command which reads the findings and the key metrics as JSON from stdin and "+
+			"prints the executive summary to embed into the report.")
+	reportCmd.Flags().String("summary-url", "",
+		"HTTP endpoint which receives the findings and the key metrics as JSON in a POST "+
+			"request and returns the executive summary as text or as JSON {\"summary\": ...}.")
+	reportCmd.Flags().Duration("summary-timeout", 2*time.Minute,
+		"Maximum time to wait for the summary hook; 0 waits indefinitely.")
+	reportCmd.Flags().Bool("bundle", false,
+		"Do not invoke labours; export the burndown, devs, bus factor and temporal activity "+
+			"time series as JSON bundles in data/ with a static ECharts viewer in index.html.")
 }
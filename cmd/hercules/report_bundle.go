@@ -0,0 +1,375 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/internal/plumbing"
+)
+
+// reportBundleDir is the subdirectory of the report which contains the JSON data bundles.
+const reportBundleDir = "data"
+
+// reportBundleAnalyses lists the analysis flags which are required by the data bundle.
+var reportBundleAnalyses = []string{"burndown", "devs", "bus-factor", "temporal-activity"}
+
+// bundleIndex is written to data/index.json and lists the available bundles.
+type bundleIndex struct {
+	Repository    string   `json:"repository"`
+	BeginUnixTime int64    `json:"begin_unix_time"`
+	EndUnixTime   int64    `json:"end_unix_time"`
+	Commits       int32    `json:"commits"`
+	Bundles       []string `json:"bundles"`
+}
+
+// burndownBundle is the project burndown: Bands[i][j] is the number of lines which were
+// written in band i and were alive at Times[j].
+type burndownBundle struct {
+	Granularity int32      `json:"granularity"`
+	Sampling    int32      `json:"sampling"`
+	Times       []int64    `json:"times"`
+	Bands       [][]uint32 `json:"bands"`
+	BandTimes   []int64    `json:"band_times"`
+}
+
+// devsBundle holds the per-developer commit and changed line counts aligned with Times.
+type devsBundle struct {
+	People  []string  `json:"people"`
+	Times   []int64   `json:"times"`
+	Commits [][]int32 `json:"commits"`
+	Lines   [][]int32 `json:"lines"`
+}
+
+// busFactorBundle holds the bus factor time series and the per-subsystem values.
+type busFactorBundle struct {
+	Threshold  float32          `json:"threshold"`
+	Times      []int64          `json:"times"`
+	BusFactor  []int32          `json:"bus_factor"`
+	TotalLines []int64          `json:"total_lines"`
+	Subsystems map[string]int32 `json:"subsystems"`
+}
+
+// temporalActivityBundle sums the temporal activity of all developers.
+type temporalActivityBundle struct {
+	Weekdays temporalBundleDimension `json:"weekdays"`
+	Hours    temporalBundleDimension `json:"hours"`
+	Months   temporalBundleDimension `json:"months"`
+	Weeks    temporalBundleDimension `json:"weeks"`
+}
+
+type temporalBundleDimension struct {
+	Commits []int32 `json:"commits"`
+	Lines   []int32 `json:"lines"`
+}
+
+// bundleTickTime converts a tick index to the UNIX time in seconds of the tick start.
+// The calendar ticks start at their periods according to the header, see plumbing.TickStart().
+func bundleTickTime(header *pb.Metadata, tickSize int64, tick int64) int64 {
+	begin := time.Unix(header.GetBeginUnixTime(), 0)
+	return plumbing.TickStart(begin, int(tick), time.Duration(tickSize),
+		header.GetTickMode(), int(header.GetFiscalYearStart())).Unix()
+}
+
+// buildReportBundles decodes the analysis results which are supported by the data bundle.
+// The returned map is keyed by the bundle file name without the extension.
+func buildReportBundles(message pb.AnalysisResults) (map[string]interface{}, error) {
+	bundles := map[string]interface{}{}
+	if payload, exists := message.Contents["Burndown"]; exists {
+		var result pb.BurndownAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode Burndown: %w", err)
+		}
+		if result.Project != nil {
+			bundles["burndown"] = newBurndownBundle(&result, message.Header)
+		}
+	}
+	if payload, exists := message.Contents["Devs"]; exists {
+		var result pb.DevsAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode Devs: %w", err)
+		}
+		bundles["devs"] = newDevsBundle(&result, message.Header)
+	}
+	if payload, exists := message.Contents["BusFactor"]; exists {
+		var result pb.BusFactorAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode BusFactor: %w", err)
+		}
+		bundles["bus-factor"] = newBusFactorBundle(&result, message.Header)
+	}
+	if payload, exists := message.Contents["TemporalActivity"]; exists {
+		var result pb.TemporalActivityResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode TemporalActivity: %w", err)
+		}
+		bundles["temporal-activity"] = newTemporalActivityBundle(&result)
+	}
+	return bundles, nil
+}
+
+func newBurndownBundle(result *pb.BurndownAnalysisResults, header *pb.Metadata) burndownBundle {
+	matrix := result.Project
+	rows := int(matrix.NumberOfRows)
+	cols := int(matrix.NumberOfColumns)
+	bundle := burndownBundle{
+		Granularity: result.Granularity,
+		Sampling:    result.Sampling,
+		Times:       make([]int64, rows),
+		Bands:       make([][]uint32, cols),
+		BandTimes:   make([]int64, cols),
+	}
+	for j := range bundle.Bands {
+		bundle.Bands[j] = make([]uint32, rows)
+		bundle.BandTimes[j] = bundleTickTime(header, result.TickSize, int64(j)*int64(result.Granularity))
+	}
+	for i, row := range matrix.Rows {
+		if i >= rows {
+			break
+		}
+		bundle.Times[i] = bundleTickTime(header, result.TickSize, int64(i)*int64(result.Sampling))
+		for j, val := range row.Columns {
+			if j < cols {
+				bundle.Bands[j][i] = val
+			}
+		}
+	}
+	return bundle
+}
+
+func newDevsBundle(result *pb.DevsAnalysisResults, header *pb.Metadata) devsBundle {
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, int(tick))
+	}
+	sort.Ints(ticks)
+	bundle := devsBundle{
+		People:  append([]string{}, result.DevIndex...),
+		Times:   make([]int64, len(ticks)),
+		Commits: make([][]int32, len(result.DevIndex)),
+		Lines:   make([][]int32, len(result.DevIndex)),
+	}
+	for dev := range bundle.People {
+		bundle.Commits[dev] = make([]int32, len(ticks))
+		bundle.Lines[dev] = make([]int32, len(ticks))
+	}
+	for i, tick := range ticks {
+		bundle.Times[i] = bundleTickTime(header, result.TickSize, int64(tick))
+		for dev, stats := range result.Ticks[int32(tick)].Devs {
+			if dev < 0 || int(dev) >= len(bundle.People) {
+				continue
+			}
+			bundle.Commits[dev][i] = stats.Commits
+			if stats.Stats != nil {
+				bundle.Lines[dev][i] = stats.Stats.Added + stats.Stats.Removed + stats.Stats.Changed
+			}
+		}
+	}
+	return bundle
+}
+
+func newBusFactorBundle(result *pb.BusFactorAnalysisResults, header *pb.Metadata) busFactorBundle {
+	ticks := make([]int, 0, len(result.Snapshots))
+	for tick := range result.Snapshots {
+		ticks = append(ticks, int(tick))
+	}
+	sort.Ints(ticks)
+	bundle := busFactorBundle{
+		Threshold:  result.Threshold,
+		Times:      make([]int64, len(ticks)),
+		BusFactor:  make([]int32, len(ticks)),
+		TotalLines: make([]int64, len(ticks)),
+		Subsystems: map[string]int32{},
+	}
+	for i, tick := range ticks {
+		snapshot := result.Snapshots[int32(tick)]
+		bundle.Times[i] = bundleTickTime(header, result.TickSize, int64(tick))
+		bundle.BusFactor[i] = snapshot.BusFactor
+		bundle.TotalLines[i] = snapshot.TotalLines
+	}
+	for key, val := range result.SubsystemBusFactor {
+		bundle.Subsystems[key] = val
+	}
+	return bundle
+}
+
+func newTemporalActivityBundle(result *pb.TemporalActivityResults) temporalActivityBundle {
+	bundle := temporalActivityBundle{
+		Weekdays: newTemporalBundleDimension(7),
+		Hours:    newTemporalBundleDimension(24),
+		Months:   newTemporalBundleDimension(12),
+		Weeks:    newTemporalBundleDimension(53),
+	}
+	for _, activity := range result.Activities {
+		bundle.Weekdays.add(activity.Weekdays)
+		bundle.Hours.add(activity.Hours)
+		bundle.Months.add(activity.Months)
+		bundle.Weeks.add(activity.Weeks)
+	}
+	return bundle
+}
+
+func newTemporalBundleDimension(size int) temporalBundleDimension {
+	return temporalBundleDimension{Commits: make([]int32, size), Lines: make([]int32, size)}
+}
+
+func (dim *temporalBundleDimension) add(other *pb.TemporalDimension) {
+	if other == nil {
+		return
+	}
+	for i, val := range other.Commits {
+		if i < len(dim.Commits) {
+			dim.Commits[i] += val
+		}
+	}
+	for i, val := range other.Lines {
+		if i < len(dim.Lines) {
+			dim.Lines[i] += val
+		}
+	}
+}
+
+// writeReportBundle writes data/<bundle>.json for every supported analysis, data/index.json
+// and the static viewer into outputDir. It returns the written files relative to outputDir.
+func writeReportBundle(outputDir string, message pb.AnalysisResults) ([]string, error) {
+	bundles, err := buildReportBundles(message)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, fmt.Errorf("none of the bundle analyses (%v) were collected", reportBundleAnalyses)
+	}
+	dataDir := filepath.Join(outputDir, reportBundleDir)
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, err
+	}
+	index := bundleIndex{}
+	if message.Header != nil {
+		index.Repository = message.Header.Repository
+		index.BeginUnixTime = message.Header.BeginUnixTime
+		index.EndUnixTime = message.Header.EndUnixTime
+		index.Commits = message.Header.Commits
+	}
+	for name := range bundles {
+		index.Bundles = append(index.Bundles, name)
+	}
+	sort.Strings(index.Bundles)
+	var written []string
+	writeJSON := func(name string, value interface{}) error {
+		payload, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, name+".json"), payload, 0o644); err != nil {
+			return err
+		}
+		written = append(written, reportBundleDir+"/"+name+".json")
+		return nil
+	}
+	for _, name := range index.Bundles {
+		if err := writeJSON(name, bundles[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeJSON("index", index); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, "index.html"), []byte(reportBundleViewer), 0o644); err != nil {
+		return nil, err
+	}
+	written = append(written, "index.html")
+	sort.Strings(written)
+	return written, nil
+}
+
+// reportBundleViewer is a self-contained page which renders the JSON bundles with ECharts.
+// It must be served over HTTP(S) because it fetches the bundles at runtime.
+const reportBundleViewer = `<!doctype html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width,initial-scale=1">
+  <title>Hercules Report</title>
+  <script src="https://cdn.jsdelivr.net/npm/echarts@5/dist/echarts.min.js"></script>
+  <style>
+    body { margin: 2rem; font-family: "IBM Plex Sans", "Segoe UI", sans-serif; background: #f6f8fb; color: #111; }
+    .card { background: #fff; border: 1px solid #d8dee9; border-radius: 8px; padding: 1rem; margin-bottom: 1rem; }
+    .chart { width: 100%; height: 420px; }
+    .muted { color: #556; }
+  </style>
+</head>
+<body>
+  <h1>Hercules Report</h1>
+  <p class="muted" id="summary"></p>
+  <div id="charts"></div>
+  <script>
+  (async function () {
+    const ms = (t) => t * 1000;
+    const load = async (name) => (await fetch("data/" + name + ".json")).json();
+    const addChart = (title, option) => {
+      const card = document.createElement("section");
+      card.className = "card";
+      card.innerHTML = "<h2></h2><div class=\"chart\"></div>";
+      card.querySelector("h2").textContent = title;
+      document.getElementById("charts").appendChild(card);
+      const chart = echarts.init(card.querySelector(".chart"));
+      chart.setOption(Object.assign({tooltip: {trigger: "axis"}, legend: {type: "scroll"}}, option));
+      window.addEventListener("resize", () => chart.resize());
+    };
+    const index = await load("index");
+    document.getElementById("summary").textContent = index.repository + " — " +
+      index.commits + " commits, " + new Date(ms(index.begin_unix_time)).toISOString().slice(0, 10) +
+      " → " + new Date(ms(index.end_unix_time)).toISOString().slice(0, 10);
+    const renderers = {
+      "burndown": (d) => addChart("Project burndown", {
+        legend: {show: false},
+        xAxis: {type: "time"}, yAxis: {type: "value", name: "lines"},
+        series: d.bands.map((band, i) => ({
+          name: new Date(ms(d.band_times[i])).toISOString().slice(0, 10), type: "line", stack: "bands",
+          areaStyle: {}, showSymbol: false, lineStyle: {width: 0},
+          data: band.map((v, j) => [ms(d.times[j]), v]),
+        })),
+      }),
+      "devs": (d) => addChart("Commits per developer", {
+        xAxis: {type: "time"}, yAxis: {type: "value", name: "commits"},
+        series: d.people.map((name, i) => ({
+          name: name, type: "bar", stack: "devs",
+          data: d.commits[i].map((v, j) => [ms(d.times[j]), v]),
+        })),
+      }),
+      "bus-factor": (d) => addChart("Bus factor (threshold " + d.threshold + ")", {
+        xAxis: {type: "time"},
+        yAxis: [{type: "value", name: "bus factor"}, {type: "value", name: "lines"}],
+        series: [
+          {name: "bus factor", type: "line", step: "end", data: d.bus_factor.map((v, j) => [ms(d.times[j]), v])},
+          {name: "lines", type: "line", yAxisIndex: 1, showSymbol: false,
+           data: d.total_lines.map((v, j) => [ms(d.times[j]), v])},
+        ],
+      }),
+      "temporal-activity": (d) => {
+        const days = ["Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"];
+        addChart("Commits by weekday", {
+          xAxis: {type: "category", data: days}, yAxis: {type: "value"},
+          series: [{name: "commits", type: "bar", data: d.weekdays.commits}],
+        });
+        addChart("Commits by hour", {
+          xAxis: {type: "category", data: d.hours.commits.map((_, i) => i)}, yAxis: {type: "value"},
+          series: [{name: "commits", type: "bar", data: d.hours.commits}],
+        });
+      },
+    };
+    for (const name of index.bundles) {
+      if (renderers[name]) {
+        renderers[name](await load(name));
+      }
+    }
+  })();
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func mustMarshal(t *testing.T, message proto.Message) []byte {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	return payload
+}
+
+func fakeBundleResults(t *testing.T) pb.AnalysisResults {
+	day := int64(24 * time.Hour)
+	return pb.AnalysisResults{
+		Header: &pb.Metadata{Repository: "test", BeginUnixTime: 1000, EndUnixTime: 2000, Commits: 3},
+		Contents: map[string][]byte{
+			"Burndown": mustMarshal(t, &pb.BurndownAnalysisResults{
+				Granularity: 2,
+				Sampling:    1,
+				TickSize:    day,
+				Project: &pb.BurndownSparseMatrix{
+					NumberOfRows:    3,
+					NumberOfColumns: 2,
+					Rows: []*pb.BurndownSparseMatrixRow{
+						{Columns: []uint32{10}},
+						{Columns: []uint32{8}},
+						{Columns: []uint32{7, 5}},
+					},
+				},
+			}),
+			"Devs": mustMarshal(t, &pb.DevsAnalysisResults{
+				DevIndex: []string{"alice", "bob"},
+				TickSize: day,
+				Ticks: map[int32]*pb.TickDevs{
+					2: {Devs: map[int32]*pb.DevTick{
+						1: {Commits: 2, Stats: &pb.LineStats{Added: 3, Removed: 1, Changed: 1}},
+					}},
+					0: {Devs: map[int32]*pb.DevTick{
+						0: {Commits: 1, Stats: &pb.LineStats{Added: 10}},
+					}},
+				},
+			}),
+			"BusFactor": mustMarshal(t, &pb.BusFactorAnalysisResults{
+				Threshold: 0.8,
+				TickSize:  day,
+				Snapshots: map[int32]*pb.BusFactorTickSnapshot{
+					1: {BusFactor: 2, TotalLines: 20},
+					0: {BusFactor: 1, TotalLines: 10},
+				},
+				SubsystemBusFactor: map[string]int32{"cmd": 1},
+			}),
+			"TemporalActivity": mustMarshal(t, &pb.TemporalActivityResults{
+				Activities: map[int32]*pb.DeveloperTemporalActivity{
+					0: {Weekdays: &pb.TemporalDimension{Commits: []int32{1, 0, 0, 0, 0, 0, 2}}},
+					1: {Weekdays: &pb.TemporalDimension{Commits: []int32{1, 1}, Lines: []int32{5}}},
+				},
+			}),
+		},
+	}
+}
+
+func TestBuildReportBundles(t *testing.T) {
+	bundles, err := buildReportBundles(fakeBundleResults(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bundles) != 4 {
+		t.Fatalf("unexpected bundle count: %d", len(bundles))
+	}
+	day := int64(24 * 3600)
+
+	burndown := bundles["burndown"].(burndownBundle)
+	if !reflect.DeepEqual(burndown.Times, []int64{1000, 1000 + day, 1000 + 2*day}) {
+		t.Fatalf("unexpected burndown times: %v", burndown.Times)
+	}
+	if !reflect.DeepEqual(burndown.Bands, [][]uint32{{10, 8, 7}, {0, 0, 5}}) {
+		t.Fatalf("unexpected burndown bands: %v", burndown.Bands)
+	}
+	if !reflect.DeepEqual(burndown.BandTimes, []int64{1000, 1000 + 2*day}) {
+		t.Fatalf("unexpected burndown band times: %v", burndown.BandTimes)
+	}
+
+	devs := bundles["devs"].(devsBundle)
+	if !reflect.DeepEqual(devs.Times, []int64{1000, 1000 + 2*day}) {
+		t.Fatalf("unexpected devs times: %v", devs.Times)
+	}
+	if !reflect.DeepEqual(devs.Commits, [][]int32{{1, 0}, {0, 2}}) {
+		t.Fatalf("unexpected devs commits: %v", devs.Commits)
+	}
+	if !reflect.DeepEqual(devs.Lines, [][]int32{{10, 0}, {0, 5}}) {
+		t.Fatalf("unexpected devs lines: %v", devs.Lines)
+	}
+
+	bf := bundles["bus-factor"].(busFactorBundle)
+	if !reflect.DeepEqual(bf.BusFactor, []int32{1, 2}) || !reflect.DeepEqual(bf.TotalLines, []int64{10, 20}) {
+		t.Fatalf("unexpected bus factor bundle: %+v", bf)
+	}
+	if bf.Subsystems["cmd"] != 1 {
+		t.Fatalf("unexpected subsystems: %v", bf.Subsystems)
+	}
+
+	ta := bundles["temporal-activity"].(temporalActivityBundle)
+	if !reflect.DeepEqual(ta.Weekdays.Commits, []int32{2, 1, 0, 0, 0, 0, 2}) {
+		t.Fatalf("unexpected weekday commits: %v", ta.Weekdays.Commits)
+	}
+	if ta.Weekdays.Lines[0] != 5 || len(ta.Hours.Commits) != 24 || len(ta.Weeks.Commits) != 53 {
+		t.Fatalf("unexpected temporal activity bundle: %+v", ta)
+	}
+}
+
+func TestBundleTickTimeCalendar(t *testing.T) {
+	header := &pb.Metadata{
+		BeginUnixTime: time.Date(2020, 1, 31, 12, 0, 0, 0, time.UTC).Unix(), TickMode: "month",
+	}
+	// the average month would drift by days after a few years
+	for tick, expected := range map[int64]time.Time{
+		0:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		1:  time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		49: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if actual := bundleTickTime(header, 0, tick); actual != expected.Unix() {
+			t.Fatalf("tick %d: %v != %v", tick, time.Unix(actual, 0).UTC(), expected)
+		}
+	}
+	header.TickMode, header.FiscalYearStart = "quarter", 10
+	if actual := bundleTickTime(header, 0, 1); actual != time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected fiscal quarter start: %v", time.Unix(actual, 0).UTC())
+	}
+	if actual := bundleTickTime(nil, 0, 2); actual != 2*24*3600 {
+		t.Fatalf("unexpected fixed tick time: %d", actual)
+	}
+}
+
+func TestBuildReportBundlesSkipsMissing(t *testing.T) {
+	message := fakeBundleResults(t)
+	delete(message.Contents, "Devs")
+	delete(message.Contents, "BusFactor")
+	bundles, err := buildReportBundles(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(bundles) != 2 {
+		t.Fatalf("unexpected bundle count: %d", len(bundles))
+	}
+	message.Contents["Devs"] = []byte{0xff}
+	_, err = buildReportBundles(message)
+	if err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
+
+func TestWriteReportBundle(t *testing.T) {
+	tmp := t.TempDir()
+	written, err := writeReportBundle(tmp, fakeBundleResults(t))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"data/burndown.json", "data/bus-factor.json", "data/devs.json", "data/index.json",
+		"data/temporal-activity.json", "index.html",
+	}
+	if !reflect.DeepEqual(written, expected) {
+		t.Fatalf("unexpected files: got %v want %v", written, expected)
+	}
+	payload, err := os.ReadFile(filepath.Join(tmp, "data", "index.json"))
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	var index bundleIndex
+	if err := json.Unmarshal(payload, &index); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if index.Repository != "test" || index.Commits != 3 || len(index.Bundles) != 4 {
+		t.Fatalf("unexpected index: %+v", index)
+	}
+
+	_, err = writeReportBundle(t.TempDir(), pb.AnalysisResults{})
+	if err == nil {
+		t.Fatal("expected an error for empty results")
+	}
+}
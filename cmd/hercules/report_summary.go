@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// reportSummaryLimit is the maximum size of the summary returned by the hook.
+const reportSummaryLimit = 1 << 20
+
+// reportSummaryInput is the JSON document which the summary hook receives: the findings and
+// the key metrics of the report.
+type reportSummaryInput struct {
+	Repository string    `json:"repository"`
+	BeginTime  string    `json:"begin_time"`
+	EndTime    string    `json:"end_time"`
+	Commits    int32     `json:"commits"`
+	Analyses   []string  `json:"analyses"`
+	Findings   []finding `json:"findings"`
+}
+
+// reportSummarizer turns the findings and the key metrics into the executive summary, e.g.
+// with a language model. The implementations must not depend on any specific service.
+type reportSummarizer interface {
+	Summarize(ctx context.Context, input []byte) (string, error)
+}
+
+// commandSummarizer runs the shell command with the input on stdin and reads the summary
+// from stdout.
+type commandSummarizer struct {
+	Command string
+}
+
+// Summarize runs the command. Its stderr goes to our stderr.
+func (summarizer commandSummarizer) Summarize(ctx context.Context, input []byte) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", summarizer.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", summarizer.Command)
+	}
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", summarizer.Command, err)
+	}
+	if len(output) > reportSummaryLimit {
+		return "", fmt.Errorf("%s: the summary exceeds %d bytes", summarizer.Command, reportSummaryLimit)
+	}
+	return string(output), nil
+}
+
+// httpSummarizer POSTs the input to the endpoint. The response is either plain text or JSON
+// with the "summary" string field.
+type httpSummarizer struct {
+	URL    string
+	Client *http.Client
+}
+
+// Summarize sends the request and decodes the response.
+func (summarizer httpSummarizer) Summarize(ctx context.Context, input []byte) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, summarizer.URL, bytes.NewReader(input))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json, text/plain")
+	client := summarizer.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(response.Body, reportSummaryLimit+1))
+	if err != nil {
+		return "", err
+	}
+	if len(body) > reportSummaryLimit {
+		return "", fmt.Errorf("%s: the summary exceeds %d bytes", summarizer.URL, reportSummaryLimit)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", summarizer.URL, response.Status)
+	}
+	if mediaType, _, _ := mime.ParseMediaType(response.Header.Get("Content-Type")); mediaType != "application/json" {
+		return string(body), nil
+	}
+	var decoded struct {
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return "", fmt.Errorf("%s: invalid JSON response: %w", summarizer.URL, err)
+	}
+	return decoded.Summary, nil
+}
+
+// newReportSummarizer returns the summarizer configured with --summary-cmd or --summary-url,
+// or nil if neither is set.
+func newReportSummarizer(command, url string) (reportSummarizer, error) {
+	switch {
+	case command != "" && url != "":
+		return nil, fmt.Errorf("--summary-cmd and --summary-url are mutually exclusive")
+	case command != "":
+		return commandSummarizer{Command: command}, nil
+	case url != "":
+		if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+			return nil, fmt.Errorf("invalid --summary-url %q: expected an http or https URL", url)
+		}
+		return httpSummarizer{URL: url}, nil
+	}
+	return nil, nil
+}
+
+// summarizeReport feeds the findings and the key metrics of the report to the summarizer
+// and returns the trimmed executive summary.
+func summarizeReport(summarizer reportSummarizer, data reportIndexData, timeout time.Duration) (string, error) {
+	findings := data.Findings
+	if findings == nil {
+		findings = []finding{}
+	}
+	input, err := json.Marshal(reportSummaryInput{
+		Repository: data.Repository,
+		BeginTime:  data.BeginTime,
+		EndTime:    data.EndTime,
+		Commits:    data.Commits,
+		Analyses:   data.Analyses,
+		Findings:   findings,
+	})
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	summary, err := summarizer.Summarize(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(summary), nil
+}
+
+// addReportSummary sets the executive summary of the report if the hook is configured.
+// The failed hook stops the report only in the strict mode, otherwise it is reported to stderr.
+func addReportSummary(
+	summarizer reportSummarizer, data *reportIndexData, timeout time.Duration, strict bool,
+) error {
+	if summarizer == nil {
+		return nil
+	}
+	_, _ = fmt.Fprintln(os.Stderr, "report: running the summary hook...")
+	summary, err := summarizeReport(summarizer, *data, timeout)
+	if err != nil {
+		if strict {
+			return fmt.Errorf("the summary hook failed: %w", err)
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "report: the summary hook failed: %v\n", err)
+		return nil
+	}
+	data.Summary = summary
+	return nil
+}
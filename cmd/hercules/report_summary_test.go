@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeSummarizer struct {
+	input   []byte
+	summary string
+	err     error
+}
+
+func (summarizer *fakeSummarizer) Summarize(ctx context.Context, input []byte) (string, error) {
+	summarizer.input = input
+	return summarizer.summary, summarizer.err
+}
+
+func TestNewReportSummarizer(t *testing.T) {
+	if summarizer, err := newReportSummarizer("", ""); summarizer != nil || err != nil {
+		t.Fatalf("unexpected summarizer: %v %v", summarizer, err)
+	}
+	if summarizer, _ := newReportSummarizer("cat", ""); summarizer != (commandSummarizer{Command: "cat"}) {
+		t.Fatalf("unexpected summarizer: %v", summarizer)
+	}
+	if _, err := newReportSummarizer("cat", "http://localhost"); err == nil {
+		t.Fatal("expected an error for both hooks")
+	}
+	if _, err := newReportSummarizer("", "ftp://localhost"); err == nil {
+		t.Fatal("expected an error for the non-HTTP URL")
+	}
+}
+
+func TestSummarizeReport(t *testing.T) {
+	summarizer := &fakeSummarizer{summary: "\n  All good.\n"}
+	data := reportIndexData{
+		Repository: "repo", Commits: 42, Analyses: []string{"BusFactor"},
+		Findings: []finding{{Kind: findingBusFactor, Subject: "src", Severity: 1}},
+	}
+	summary, err := summarizeReport(summarizer, data, time.Minute)
+	if err != nil || summary != "All good." {
+		t.Fatalf("unexpected summary: %q %v", summary, err)
+	}
+	var input reportSummaryInput
+	if err := json.Unmarshal(summarizer.input, &input); err != nil {
+		t.Fatalf("invalid input: %v", err)
+	}
+	if input.Repository != "repo" || input.Commits != 42 || len(input.Findings) != 1 {
+		t.Fatalf("unexpected input: %s", summarizer.input)
+	}
+	summarizer = &fakeSummarizer{}
+	if _, err := summarizeReport(summarizer, reportIndexData{}, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(summarizer.input), `"findings":[]`) {
+		t.Fatalf("unexpected input: %s", summarizer.input)
+	}
+}
+
+func TestAddReportSummary(t *testing.T) {
+	data := reportIndexData{}
+	if err := addReportSummary(nil, &data, 0, true); err != nil || data.Summary != "" {
+		t.Fatalf("unexpected result: %q %v", data.Summary, err)
+	}
+	if err := addReportSummary(&fakeSummarizer{summary: "ok"}, &data, 0, true); err != nil || data.Summary != "ok" {
+		t.Fatalf("unexpected result: %q %v", data.Summary, err)
+	}
+	failing := &fakeSummarizer{err: errors.New("boom")}
+	if err := addReportSummary(failing, &data, 0, false); err != nil || data.Summary != "ok" {
+		t.Fatalf("unexpected result: %q %v", data.Summary, err)
+	}
+	if err := addReportSummary(failing, &data, 0, true); err == nil {
+		t.Fatal("expected an error in the strict mode")
+	}
+}
+
+func TestCommandSummarizer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs sh")
+	}
+	path := filepath.Join(t.TempDir(), "input.json")
+	summary, err := commandSummarizer{Command: "cat > " + path + " && echo summary"}.Summarize(
+		context.Background(), []byte(`{"commits":1}`))
+	if err != nil || summary != "summary\n" {
+		t.Fatalf("unexpected summary: %q %v", summary, err)
+	}
+	input, err := os.ReadFile(path)
+	if err != nil || string(input) != `{"commits":1}` {
+		t.Fatalf("unexpected input: %s %v", input, err)
+	}
+	if _, err = (commandSummarizer{Command: "exit 3"}).Summarize(context.Background(), nil); err == nil {
+		t.Fatal("expected an error for the failed command")
+	}
+}
+
+func TestHTTPSummarizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := io.ReadAll(request.Body)
+		switch request.URL.Path {
+		case "/json":
+			writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+			_, _ = writer.Write([]byte(`{"summary": "from json"}`))
+		case "/text":
+			_, _ = writer.Write(append([]byte("echo "), body...))
+		default:
+			http.Error(writer, "nope", http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+	summarize := func(path string) (string, error) {
+		return httpSummarizer{URL: server.URL + path}.Summarize(context.Background(), []byte("{}"))
+	}
+	if summary, err := summarize("/json"); err != nil || summary != "from json" {
+		t.Fatalf("unexpected summary: %q %v", summary, err)
+	}
+	if summary, err := summarize("/text"); err != nil || summary != "echo {}" {
+		t.Fatalf("unexpected summary: %q %v", summary, err)
+	}
+	if _, err := summarize("/fail"); err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("expected the status error, got %v", err)
+	}
+}
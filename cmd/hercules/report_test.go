@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -93,3 +94,110 @@ func TestCollectReportAssets(t *testing.T) {
 		t.Fatalf("unexpected assets: got %v want %v", assets, expectedAssets)
 	}
 }
+
+func TestNewReportManifest(t *testing.T) {
+	data := reportIndexData{
+		Repository: "https://github.com/src-d/hercules",
+		Format:     "PNG",
+		Analyses:   []string{"Burndown", "Devs"},
+		Modes:      []string{"burndown-file", "devs", "devs-efforts", "couples-files"},
+		Failures:   []reportModeFailure{{Mode: "couples-files", Error: "exit status 1"}},
+		Plots: []string{
+			"charts/burndown-file/a.go.png",
+			"charts/burndown-file/b.go.png",
+			"charts/devs-efforts.png",
+			"charts/devs.png",
+		},
+		Assets: []string{"report.pb"},
+	}
+	manifest := newReportManifest(data)
+	if manifest.Format != "png" {
+		t.Fatalf("unexpected format: %q", manifest.Format)
+	}
+	if len(manifest.Charts) != 4 {
+		t.Fatalf("unexpected chart count: %d", len(manifest.Charts))
+	}
+	expected := []reportManifestChart{
+		{
+			Mode: "burndown-file", Analyses: []string{"burndown-files"}, Status: "ok",
+			Files: []string{"charts/burndown-file/a.go.png", "charts/burndown-file/b.go.png"},
+		},
+		{Mode: "devs", Analyses: []string{"devs"}, Status: "ok", Files: []string{"charts/devs.png"}},
+		{
+			Mode: "devs-efforts", Analyses: []string{"devs"}, Status: "ok",
+			Files: []string{"charts/devs-efforts.png"},
+		},
+		{
+			Mode: "couples-files", Analyses: []string{"couples"}, Status: "failed",
+			Error: "exit status 1", Files: []string{},
+		},
+	}
+	if !reflect.DeepEqual(manifest.Charts, expected) {
+		t.Fatalf("unexpected charts: got %+v want %+v", manifest.Charts, expected)
+	}
+}
+
+func TestReportModeAnalysesCoverValidModes(t *testing.T) {
+	for mode := range reportValidModes {
+		if _, exists := reportModeAnalyses[mode]; !exists {
+			t.Fatalf("mode %q has no source analyses", mode)
+		}
+	}
+}
+
+func TestWriteReportManifest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), reportManifestName)
+	manifest := newReportManifest(reportIndexData{Modes: []string{"devs"}})
+	if err := writeReportManifest(path, manifest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	var decoded reportManifest
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(decoded.Charts) != 1 || decoded.Charts[0].Status != "empty" {
+		t.Fatalf("unexpected decoded manifest: %+v", decoded)
+	}
+}
+
+func TestInferReportAnalysisFlags(t *testing.T) {
+	available := map[string]struct{}{
+		"burndown": {}, "burndown-people": {}, "couples": {}, "devs": {},
+	}
+	flags, added, err := inferReportAnalysisFlags(available, []string{"devs"},
+		[]string{"devs", "couples-people", "ownership"}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(flags, []string{"burndown-people", "couples", "devs"}) {
+		t.Fatalf("unexpected flags: %v", flags)
+	}
+	if !reflect.DeepEqual(added, []string{"burndown-people", "couples"}) {
+		t.Fatalf("unexpected added flags: %v", added)
+	}
+
+	flags, added, err = inferReportAnalysisFlags(available, []string{"couples"}, []string{"couples-files"}, false)
+	if err != nil || !reflect.DeepEqual(flags, []string{"couples"}) || len(added) != 0 {
+		t.Fatalf("nothing must be inferred: %v %v %v", flags, added, err)
+	}
+
+	_, _, err = inferReportAnalysisFlags(available, []string{"devs"},
+		[]string{"couples-people", "couples-files"}, false)
+	if err == nil {
+		t.Fatal("expected an error for the missing analysis flags")
+	}
+	expected := "the requested modes need analyses which are not enabled: " +
+		"couples (needed by couples-people, couples-files); add --analysis couples"
+	if err.Error() != expected {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, _, err = inferReportAnalysisFlags(available, []string{"devs"}, []string{"shotness"}, true)
+	if err == nil {
+		t.Fatal("expected an error for the unknown analysis flag")
+	}
+}
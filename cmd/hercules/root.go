@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -10,12 +11,14 @@ import (
 	_ "net/http/pprof"
 	"net/url"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
-	"plugin"
 	"regexp"
 	"runtime/pprof"
 	"sort"
 	"strings"
+	"syscall"
 	"text/template"
 	"unicode"
 
@@ -33,12 +36,12 @@ import (
 	"github.com/meko-christian/go-billy-siva"
 	"github.com/meko-christian/hercules"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/hybrid"
 	"github.com/meko-christian/hercules/internal/pb"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"golang.org/x/crypto/ssh/terminal"
-	progress "gopkg.in/cheggaaa/pb.v1"
 )
 
 // oneLineWriter splits the output data by lines and outputs one on top of another using '\r'.
@@ -72,10 +75,63 @@ func loadSSHIdentity(sshIdentity string) (*ssh.PublicKeys, error) {
 
 var regexUri = regexp.MustCompile("^[A-Za-z]\\w*@[A-Za-z0-9][\\w.]*:")
 
+// isRemoteRepository returns true if the URI must be cloned, e.g. https:// or git@host:.
+func isRemoteRepository(uri string) bool {
+	return strings.Contains(uri, "://") || regexUri.MatchString(uri)
+}
+
+// splitRepositoryArgs separates the analysed repositories from the optional cache path.
+// The second argument is the cache path only if there are exactly two, the first is remote
+// and the second is not.
+func splitRepositoryArgs(args []string) (uris []string, cachePath string) {
+	if len(args) == 2 && isRemoteRepository(args[0]) && !isRemoteRepository(args[1]) {
+		return args[:1], args[1]
+	}
+	return args, ""
+}
+
+// cloneSettings control where the remote repositories are cloned if there is no cache path.
+type cloneSettings struct {
+	// Dir is the directory to clone into instead of the memory. The clones are removed
+	// by removeTemporaryClones().
+	Dir string
+	// MemoryLimit is the packfile size in bytes above which the in-memory clone spills
+	// to a temporary directory. 0 disables spilling.
+	MemoryLimit int64
+}
+
+// temporaryClones are removed by removeTemporaryClones().
+var temporaryClones []func() error
+
+// removeTemporaryClones removes the clones in the temporary directories.
+func removeTemporaryClones() {
+	for _, remove := range temporaryClones {
+		if err := remove(); err != nil {
+			log.Printf("failed to remove the temporary clone: %v", err)
+		}
+	}
+	temporaryClones = nil
+}
+
+// fatal removes the temporary clones and calls log.Fatal. os.Exit() skips the deferred calls,
+// so all the fatal exits go through fatal() and fatalf(); the panics unwind through main().
+func fatal(v ...interface{}) {
+	removeTemporaryClones()
+	log.Fatal(v...)
+}
+
+// fatalf removes the temporary clones and calls log.Fatalf.
+func fatalf(format string, v ...interface{}) {
+	removeTemporaryClones()
+	log.Fatalf(format, v...)
+}
+
 func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentity string,
+	clone cloneSettings,
 ) (repository *git.Repository, repoUri string, repoFeature string) {
 	var err error
-	repository, repoUri, repoFeature, err = loadRepositoryWithError(uri, cachePath, disableStatus, sshIdentity)
+	repository, repoUri, repoFeature, err = loadRepositoryWithError(
+		uri, cachePath, disableStatus, sshIdentity, clone)
 	if err != nil {
 		log.Panicf("failed to open %s: %v", uri, err)
 	}
@@ -83,6 +139,7 @@ func loadRepository(uri string, cachePath string, disableStatus bool, sshIdentit
 }
 
 func loadRepositoryWithError(uri string, cachePath string, disableStatus bool, sshIdentity string,
+	clone cloneSettings,
 ) (repository *git.Repository, repoUri string, repoFeature string, err error) {
 	repoFeature = core.FeatureGitCommits
 	repoUri = uri
@@ -90,11 +147,13 @@ func loadRepositoryWithError(uri string, cachePath string, disableStatus bool, s
 	if uri == "-" && cachePath == "" {
 		repository, err = git.Init(memory.NewStorage(), memfs.New())
 		w, _ := repository.Worktree()
-		if _, err = w.Commit("Initial", &git.CommitOptions{AllowEmptyCommits: true}); err != nil {
+		if _, err = w.Commit("Initial", &git.CommitOptions{
+			AllowEmptyCommits: true, Author: &object.Signature{Name: "hercules"},
+		}); err != nil {
 			log.Panicf("failed to create a virtual repo: %v", err)
 		}
 		repoFeature = core.FeatureGitStub
-	} else if strings.Contains(uri, "://") || regexUri.MatchString(uri) {
+	} else if isRemoteRepository(uri) {
 		var backend storage.Storer
 		if cachePath != "" {
 			backend = filesystem.NewStorage(osfs.New(cachePath), cache.NewObjectLRUDefault())
@@ -103,8 +162,17 @@ func loadRepositoryWithError(uri string, cachePath string, disableStatus bool, s
 				log.Printf("warning: deleted %s\n", cachePath)
 				_ = os.RemoveAll(cachePath)
 			}
+		} else if clone.Dir != "" {
+			var dir string
+			if dir, err = os.MkdirTemp(clone.Dir, "hercules-clone-"); err != nil {
+				return
+			}
+			temporaryClones = append(temporaryClones, func() error { return os.RemoveAll(dir) })
+			backend = filesystem.NewStorage(osfs.New(dir), cache.NewObjectLRUDefault())
 		} else {
-			backend = memory.NewStorage()
+			hybridStorage := hybrid.NewStorage(clone.MemoryLimit, "")
+			temporaryClones = append(temporaryClones, hybridStorage.Close)
+			backend = hybridStorage
 		}
 
 		cloneOptions := &git.CloneOptions{URL: uri}
@@ -154,6 +222,46 @@ func loadRepositoryWithError(uri string, cachePath string, disableStatus bool, s
 	return
 }
 
+// unshallowRepository fetches the missing history of the shallow clone at the local path.
+// go-git is unable to deepen the existing clones, so we call the git executable.
+func unshallowRepository(path string, disableStatus bool) error {
+	cmd := exec.Command("git", "-C", path, "fetch", "--unshallow")
+	if !disableStatus {
+		cmd.Stderr = os.Stderr
+	}
+	if output, err := cmd.Output(); err != nil {
+		return fmt.Errorf("git fetch --unshallow: %v %s", err, output)
+	}
+	return nil
+}
+
+// openRepository loads the repository and fetches its full history if it is a shallow clone
+// and --auto-unshallow is set.
+func openRepository(flags *pflag.FlagSet, uri string, cachePath string, disableStatus bool,
+) (repository *git.Repository, repoUri string, repoFeature string) {
+	sshIdentity, _ := flags.GetString("ssh-identity")
+	clone := cloneSettings{}
+	clone.Dir, _ = flags.GetString("clone-dir")
+	memoryLimit, _ := flags.GetInt("clone-memory-limit")
+	clone.MemoryLimit = int64(memoryLimit) << 20
+	repository, repoUri, repoFeature = loadRepository(uri, cachePath, disableStatus, sshIdentity, clone)
+	autoUnshallow, _ := flags.GetBool("auto-unshallow")
+	if shallow, err := repository.Storer.Shallow(); autoUnshallow && err == nil && len(shallow) > 0 {
+		log.Printf("%s is a shallow clone, fetching the full history\n", uri)
+		if err = unshallowRepository(uri, disableStatus); err != nil {
+			fatalf("failed to unshallow %s: %v", uri, err)
+		}
+		repository, repoUri, repoFeature = loadRepository(uri, cachePath, disableStatus, sshIdentity, clone)
+	}
+	if worktree, _ := flags.GetBool("worktree"); worktree {
+		var err error
+		if repository, err = hercules.NewWorktreeRepository(repository); err != nil {
+			fatalf("--worktree requires a local repository with a worktree: %v", err)
+		}
+	}
+	return
+}
+
 type arrayPluginFlags map[string]bool
 
 func (apf *arrayPluginFlags) String() string {
@@ -188,9 +296,8 @@ func loadPlugins() {
 	pflag.Var(&pluginFlags, pluginFlagName, pluginDesc)
 	_ = fs.Parse(os.Args[1:])
 	for path := range pluginFlags {
-		_, err := plugin.Open(path)
-		if err != nil {
-			log.Printf("Failed to load plugin from %s %s\n", path, err)
+		if err := hercules.Registry.LoadPlugin(path); err != nil {
+			log.Println(err)
 		}
 	}
 }
@@ -202,10 +309,15 @@ var rootCmd = &cobra.Command{
 	Long: `Hercules is a flexible and fast Git repository analysis engine. The base command executes
 the commit processing pipeline which is automatically generated from the dependencies of one
 or several analysis targets. The list of the available targets is printed in --help. External
-targets can be added using the --plugin system.`,
-	Args: cobra.RangeArgs(1, 2),
+targets can be added using the --plugin system.
+
+If several repositories are specified, the same pipeline runs over each of them and the results
+are merged into a single report, like "hercules combine" does. The second argument is the cache
+path instead if the first one is a remote repository and the second is local.`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		flags := cmd.Flags()
+		hercules.Deprecations.ResolveFlagAliases(flags)
 		applyPreset(flags)
 		getBool := func(name string) bool {
 			value, err := flags.GetBool(name)
@@ -214,20 +326,36 @@ targets can be added using the --plugin system.`,
 			}
 			return value
 		}
-		getString := func(name string) string {
-			value, err := flags.GetString(name)
-			if err != nil {
-				panic(err)
-			}
-			return value
-		}
-		firstParent := getBool("first-parent")
-		commitsFile := getString("commits")
-		head := getBool("head")
 		protobuf := getBool("pb")
 		profile := getBool("profile")
 		disableStatus := getBool("quiet")
-		sshIdentity := getString("ssh-identity")
+		outputs, _ := flags.GetStringArray("output")
+		targets, err := parseOutputTargets(outputs, protobuf)
+		if err != nil {
+			fatal(err)
+		}
+		if getBool("findings") {
+			for i := range targets {
+				targets[i].Findings = true
+			}
+		}
+		outputFilters, _ := flags.GetStringArray("output-filter")
+		filters, err := parseOutputFilters(outputFilters)
+		if err != nil {
+			fatal(err)
+		}
+		var anonymizer *hercules.PathAnonymizer
+		if mode, _ := flags.GetString("anonymize-paths"); mode != "" {
+			salt, _ := flags.GetString("anonymize-paths-salt")
+			if anonymizer, err = hercules.NewPathAnonymizer(mode, salt); err != nil {
+				fatal(err)
+			}
+		}
+		postRun, _ := flags.GetString("post-run")
+		hook, err := parsePostRunHook(postRun)
+		if err != nil {
+			fatal(err)
+		}
 
 		if profile {
 			go func() {
@@ -243,94 +371,270 @@ targets can be added using the --plugin system.`,
 			}
 			defer pprof.StopCPUProfile()
 		}
-		uri := args[0]
-		cachePath := ""
-		if len(args) == 2 {
-			cachePath = args[1]
-		}
-		repository, repoUri, repoFeature := loadRepository(uri, cachePath, disableStatus, sshIdentity)
-
-		// core logic
-		pipeline := hercules.NewPipeline(repository)
-		if repoFeature != "" {
-			pipeline.SetFeature(repoFeature)
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			// a second signal kills the process as usual, e.g. while --checkpoint finishes the commit
+			<-ctx.Done()
+			stop()
+		}()
+		if uris, _ := splitRepositoryArgs(args); len(uris) > 1 {
+			if checkpoint, _ := flags.GetString("checkpoint"); checkpoint != "" {
+				fatalf("--checkpoint supports a single repository")
+			}
+			repoUri, deployedLeafs, results := runMultiRepo(ctx, flags, uris, disableStatus)
+			if results, err = filterResults(filters, deployedLeafs, results); err != nil {
+				fatal(err)
+			}
+			if results, err = anonymizeResults(anonymizer, deployedLeafs, results); err != nil {
+				fatal(err)
+			}
+			writeResults(repoUri, deployedLeafs, results, targets, disableStatus)
+			postProcessResults(hook, targets, repoUri, deployedLeafs, results)
+			return
 		}
-		pipeline.SetFeaturesFromFlags()
-		var bar *progress.ProgressBar
+		pipeline, repoUri, deployedLeafs := initializePipeline(flags, args, disableStatus)
 		if !disableStatus {
-			pipeline.OnProgress = func(commit, length int, action string) {
-				if bar == nil {
-					bar = progress.New(length)
-					bar.Callback = func(msg string) {
-						_, _ = os.Stderr.WriteString("\033[2K\r" + msg)
-					}
-					bar.NotPrint = true
-					bar.ShowPercent = false
-					bar.ShowSpeed = false
-					bar.SetMaxWidth(80).Start()
-				}
-				if action == hercules.MessageFinalize {
-					bar.Finish()
-					_, _ = fmt.Fprint(os.Stderr, "\033[2K\rfinalizing...")
-				} else {
-					bar.Set(commit).Postfix(" [" + action + "] ")
-				}
-			}
+			pipeline.ProgressReporter = newTerminalProgress(os.Stderr)
 		}
 
-		if repoFeature == core.FeatureGitCommits {
-			var commits []*object.Commit
-			var err error
-			if commitsFile == "" {
-				if !head {
-					_, _ = fmt.Fprint(os.Stderr, "git log...\r")
-					commits, err = pipeline.Commits(firstParent)
-				} else {
-					commits, err = pipeline.HeadCommit()
-				}
+		results, err := pipeline.RunPreparedPlanContext(ctx)
+		common, _ := results[nil].(*hercules.CommonAnalysisResult)
+		cancelled := common != nil && common.Cancelled
+		if cancelled {
+			if pipeline.SavedCheckpoint() {
+				log.Printf("interrupted after %d commit(s); the analysis state is saved, to resume: %s",
+					common.CommitsNumber, rerunCommand(resumeArgs(os.Args)))
+			} else if pipeline.Checkpoint == "" {
+				log.Printf("interrupted after %d commit(s); the analysis state is not saved, pass "+
+					"--checkpoint to save it next time; to rerun from the first commit: %s",
+					common.CommitsNumber, rerunCommand(os.Args))
 			} else {
-				commits, err = hercules.LoadCommitsFromFile(commitsFile, repository)
+				log.Printf("interrupted after %d commit(s); the analysis state is not saved, "+
+					"to rerun from the first commit: %s", common.CommitsNumber, rerunCommand(os.Args))
 			}
-			if err != nil {
-				log.Fatalf("failed to list the commits: %v", err)
+			if len(results) == 1 {
+				removeTemporaryClones()
+				os.Exit(1)
 			}
-			cmdlineFacts[hercules.ConfigPipelineCommits] = commits
+			log.Print("writing the partial results")
+			err = nil
 		}
-
-		priorityFn := func(items []core.PipelineItem) core.PipelineItem {
-			if len(items) == 0 {
-				return nil
-			}
-			if len(items) > 1 {
-				sort.Stable(&flagSorter{items: items, flagSet: flags, featureSet: pipeline})
+		if err != nil {
+			fatalf("failed to run the pipeline: %v", err)
+		}
+		reportFailures(pipeline.Failures())
+		if results, err = filterResults(filters, deployedLeafs, results); err != nil {
+			fatal(err)
+		}
+		if results, err = anonymizeResults(anonymizer, deployedLeafs, results); err != nil {
+			fatal(err)
+		}
+		writeResults(repoUri, deployedLeafs, results, targets, disableStatus)
+		if pipeline.Resume && !cancelled {
+			// the resumed run has finished
+			if err := os.Remove(pipeline.Checkpoint); err != nil {
+				log.Printf("failed to remove the checkpoint: %v", err)
 			}
-			return items[0]
 		}
+		postProcessResults(hook, targets, repoUri, deployedLeafs, results)
+	},
+}
 
-		pipeline.DryRun, _ = cmdlineFacts[hercules.ConfigPipelineDryRun].(bool)
-		deployedLeafs := deployItemsToPipeline(pipeline, flags, priorityFn)
+// reportFailures logs the commits which were skipped with --continue-on-error.
+func reportFailures(failures []*core.PipelineError) {
+	if len(failures) == 0 {
+		return
+	}
+	log.Printf("%d commit(s) were skipped because of errors:", len(failures))
+	for _, failure := range failures {
+		log.Printf("  %v", failure)
+	}
+}
 
-		if err := pipeline.InitializeExt(cmdlineFacts, priorityFn, true); err != nil {
-			log.Fatal(err)
+// rerunCommand formats the command line which repeats the interrupted run. Each argument
+// is quoted for POSIX shells if it contains special characters.
+func rerunCommand(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_=.,/:@+%", r)
+		}) < 0 {
+			quoted[i] = arg
+			continue
 		}
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// resumeArgs appends --resume to the command line arguments unless they already contain it.
+func resumeArgs(args []string) []string {
+	for _, arg := range args {
+		if arg == "--resume" || arg == "--resume=true" {
+			return args
+		}
+	}
+	return append(append([]string{}, args...), "--resume")
+}
+
+// writeResults writes the results of the deployed leaves to each of the output targets.
+func writeResults(
+	repoUri string, deployedLeafs []hercules.LeafPipelineItem,
+	results map[hercules.LeafPipelineItem]interface{}, targets []outputTarget, disableStatus bool,
+) {
+	if !disableStatus {
+		_, _ = fmt.Fprint(os.Stderr, "\033[2K\r")
+		// if not a terminal, the user will not see the output, so show the status
+		if !terminal.IsTerminal(int(os.Stdout.Fd())) {
+			_, _ = fmt.Fprint(os.Stderr, "writing...\r")
+		}
+	}
+	for _, target := range targets {
+		if err := writeTarget(target, repoUri, deployedLeafs, results); err != nil {
+			fatalf("failed to write the %s results to %s: %v", target.Format, target.Path, err)
+		}
+	}
+}
+
+// postProcessResults runs the --post-run hook, if any, after the results are written.
+func postProcessResults(
+	hook *template.Template, targets []outputTarget, repoUri string,
+	deployedLeafs []hercules.LeafPipelineItem, results map[hercules.LeafPipelineItem]interface{},
+) {
+	if hook == nil {
+		return
+	}
+	if err := runPostRunHook(hook, targets, repoUri, deployedLeafs, results); err != nil {
+		fatalf("the post-run hook failed: %v", err)
+	}
+}
+
+// runMultiRepo analyses each repository with the same pipeline configuration and merges
+// the results. It returns the joined repository URIs, the deployed leaves and the merged results.
+func runMultiRepo(ctx context.Context, flags *pflag.FlagSet, uris []string, disableStatus bool,
+) (string, []hercules.LeafPipelineItem, map[hercules.LeafPipelineItem]interface{}) {
+	parallelism, _ := flags.GetInt("parallel-repos")
+	repositories := make([]*git.Repository, len(uris))
+	repoUris := make([]string, len(uris))
+	repoFeatures := make([]string, len(uris))
+	for i, uri := range uris {
+		repositories[i], repoUris[i], repoFeatures[i] = openRepository(flags, uri, "", disableStatus)
+	}
+	runner := &hercules.MultiRepoRunner{
+		Repositories: repositories,
+		Parallelism:  parallelism,
+		Configure: func(index int, pipeline *hercules.Pipeline) ([]hercules.LeafPipelineItem, error) {
+			if !disableStatus && parallelism <= 1 {
+				pipeline.ProgressReporter = newTerminalProgress(os.Stderr)
+			}
+			// each pipeline mutates its facts
+			facts := make(map[string]interface{}, len(cmdlineFacts))
+			for key, val := range cmdlineFacts {
+				facts[key] = val
+			}
+			return configurePipeline(pipeline, repositories[index], flags, repoFeatures[index], facts)
+		},
+		OnResults: func(index int, pipeline *hercules.Pipeline,
+			results map[hercules.LeafPipelineItem]interface{},
+		) {
+			reportFailures(pipeline.Failures())
+			for item, result := range results {
+				if item != nil && item.Name() == "Burndown" {
+					results[item] = trackBurndownRepository(result, repoUris[index])
+				}
+			}
+		},
+	}
+	deployedLeafs, results, err := runner.Run(ctx)
+	if err != nil && ctx.Err() != nil {
+		fatalf("interrupted: %v; the analysis state is not saved, to rerun from the first "+
+			"commit: %s", err, rerunCommand(os.Args))
+	}
+	if err != nil {
+		fatalf("failed to run the pipeline: %v", err)
+	}
+	return strings.Join(repoUris, " & "), deployedLeafs, results
+}
 
-		results, err := pipeline.RunPreparedPlan()
+// initializePipeline loads the repository from the command line arguments, deploys the items
+// which the flags activate and initializes the pipeline with the command line facts.
+func initializePipeline(flags *pflag.FlagSet, args []string, disableStatus bool,
+) (pipeline *hercules.Pipeline, repoUri string, deployedLeafs []hercules.LeafPipelineItem) {
+	uri := args[0]
+	cachePath := ""
+	if len(args) == 2 {
+		cachePath = args[1]
+	}
+	repository, repoUri, repoFeature := openRepository(flags, uri, cachePath, disableStatus)
+
+	pipeline = hercules.NewPipeline(repository)
+	deployedLeafs, err := configurePipeline(pipeline, repository, flags, repoFeature, cmdlineFacts)
+	if err != nil {
+		fatal(err)
+	}
+	return pipeline, repoUri, deployedLeafs
+}
+
+// configurePipeline deploys the items which the flags activate and initializes the pipeline
+// of the repository with the specified facts.
+func configurePipeline(pipeline *hercules.Pipeline, repository *git.Repository, flags *pflag.FlagSet,
+	repoFeature string, facts map[string]interface{},
+) (deployedLeafs []hercules.LeafPipelineItem, err error) {
+	firstParent, _ := flags.GetBool("first-parent")
+	commitsFile, _ := flags.GetString("commits")
+	head, _ := flags.GetBool("head")
+	if repoFeature != "" {
+		pipeline.SetFeature(repoFeature)
+	}
+	pipeline.SetFeaturesFromFlags()
+
+	if repoFeature == core.FeatureGitCommits {
+		var commits []*object.Commit
+		if commitsFile == "" {
+			if !head {
+				_, _ = fmt.Fprint(os.Stderr, "git log...\r")
+				commits, err = pipeline.Commits(firstParent)
+			} else {
+				commits, err = pipeline.HeadCommit()
+			}
+		} else {
+			commits, err = hercules.LoadCommitsFromFile(commitsFile, repository)
+		}
 		if err != nil {
-			log.Fatalf("failed to run the pipeline: %v", err)
+			return nil, fmt.Errorf("failed to list the commits: %v", err)
 		}
-		if !disableStatus {
-			_, _ = fmt.Fprint(os.Stderr, "\033[2K\r")
-			// if not a terminal, the user will not see the output, so show the status
-			if !terminal.IsTerminal(int(os.Stdout.Fd())) {
-				_, _ = fmt.Fprint(os.Stderr, "writing...\r")
+		if worktree, _ := flags.GetBool("worktree"); worktree {
+			commit, err := hercules.CommitWorktree(repository)
+			if err != nil {
+				return nil, fmt.Errorf("failed to commit the worktree: %v", err)
+			}
+			if commit != nil {
+				commits = append(commits, commit)
+			} else {
+				log.Println("the worktree is clean, analysing up to HEAD")
 			}
 		}
-		if protobuf {
-			protobufResults(repoUri, deployedLeafs, results)
-		} else {
-			printResults(repoUri, deployedLeafs, results)
+		facts[hercules.ConfigPipelineCommits] = commits
+	}
+
+	priorityFn := func(items []core.PipelineItem) core.PipelineItem {
+		if len(items) == 0 {
+			return nil
 		}
-	},
+		if len(items) > 1 {
+			sort.Stable(&flagSorter{items: items, flagSet: flags, featureSet: pipeline})
+		}
+		return items[0]
+	}
+
+	pipeline.DryRun, _ = facts[hercules.ConfigPipelineDryRun].(bool)
+	deployedLeafs = deployItemsToPipeline(pipeline, flags, priorityFn)
+
+	if err = pipeline.InitializeExt(facts, priorityFn, true); err != nil {
+		return nil, err
+	}
+	return deployedLeafs, nil
 }
 
 func deployItemsToPipeline(pipeline *core.Pipeline, flags *pflag.FlagSet,
@@ -352,7 +656,7 @@ func deployItemsToPipeline(pipeline *core.Pipeline, flags *pflag.FlagSet,
 	for _, names := range deployList {
 		switch summons := hercules.Registry.Summon(names...); {
 		case len(summons) == 0:
-			log.Fatalf("missing item(s): %v", names)
+			fatalf("missing item(s): %v", names)
 		case len(summons) > 1:
 			if len(names) == 2 {
 				log.Printf("ambigous item: %v", names)
@@ -429,31 +733,44 @@ func (v *flagSorter) weightFlagsOf(item core.PipelineItem, flagSet *pflag.FlagSe
 }
 
 func printResults(
-	uri string, deployed []hercules.LeafPipelineItem,
+	writer io.Writer, uri string, deployed []hercules.LeafPipelineItem,
 	results map[hercules.LeafPipelineItem]interface{},
 ) {
 	commonResult := results[nil].(*hercules.CommonAnalysisResult)
 
-	fmt.Println("hercules:")
-	fmt.Printf("  version: %d\n", hercules.BinaryVersion)
-	fmt.Println("  hash:", hercules.BinaryGitHash)
-	fmt.Println("  repository:", uri)
-	fmt.Println("  begin_unix_time:", commonResult.BeginTime)
-	fmt.Println("  end_unix_time:", commonResult.EndTime)
-	fmt.Println("  commits:", commonResult.CommitsNumber)
-	fmt.Println("  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	fmt.Fprintln(writer, "hercules:")
+	fmt.Fprintf(writer, "  version: %d\n", hercules.BinaryVersion)
+	fmt.Fprintln(writer, "  hash:", hercules.BinaryGitHash)
+	fmt.Fprintln(writer, "  repository:", uri)
+	fmt.Fprintln(writer, "  begin_unix_time:", commonResult.BeginTime)
+	fmt.Fprintln(writer, "  end_unix_time:", commonResult.EndTime)
+	fmt.Fprintln(writer, "  commits:", commonResult.CommitsNumber)
+	fmt.Fprintln(writer, "  run_time:", commonResult.RunTime.Nanoseconds()/1e6)
+	if commonResult.Cancelled {
+		fmt.Fprintln(writer, "  partial: true")
+	}
+	if commonResult.TickMode != "" {
+		fmt.Fprintln(writer, "  tick_mode:", commonResult.TickMode)
+	}
+	if commonResult.FiscalYearStart > 0 {
+		fmt.Fprintln(writer, "  fiscal_year_start:", commonResult.FiscalYearStart)
+	}
 
 	for _, item := range deployed {
-		result := results[item]
-		fmt.Printf("%s:\n", item.Name())
-		if err := item.Serialize(result, false, os.Stdout); err != nil {
+		result, exists := results[item]
+		if !exists {
+			// abandoned with --commit-timeout and --continue-on-error
+			continue
+		}
+		fmt.Fprintf(writer, "%s:\n", item.Name())
+		if err := item.Serialize(result, false, writer); err != nil {
 			panic(err)
 		}
 	}
 }
 
 func protobufResults(
-	uri string, deployed []hercules.LeafPipelineItem,
+	writer io.Writer, uri string, deployed []hercules.LeafPipelineItem,
 	results map[hercules.LeafPipelineItem]interface{},
 ) {
 	header := pb.Metadata{
@@ -469,7 +786,11 @@ func protobufResults(
 	}
 
 	for _, item := range deployed {
-		result := results[item]
+		result, exists := results[item]
+		if !exists {
+			// abandoned with --commit-timeout and --continue-on-error
+			continue
+		}
 		buffer := &bytes.Buffer{}
 		if err := item.Serialize(result, true, buffer); err != nil {
 			panic(err)
@@ -481,7 +802,7 @@ func protobufResults(
 	if err != nil {
 		panic(err)
 	}
-	_, _ = os.Stdout.Write(serialized)
+	_, _ = writer.Write(serialized)
 }
 
 // trimRightSpace removes the trailing whitespace characters.
@@ -637,13 +958,44 @@ func init() {
 	rootFlags.Bool("head", false, "Analyze only the latest commit.")
 	rootFlags.Bool("first-parent", false, "Follow only the first parent in the commit history - "+
 		"\"git log --first-parent\".")
+	rootFlags.Bool("worktree", false, "Analyse the uncommitted changes in the worktree, staged "+
+		"or not, as the last commit on top of HEAD. Nothing is written to the repository.")
+	rootFlags.Bool("auto-unshallow", false, "Fetch the full history if the repository "+
+		"is a shallow clone. Requires the git executable.")
 	rootFlags.Bool("pb", false, "The output format will be Protocol Buffers instead of YAML.")
+	rootFlags.StringArray("output", nil, "Write the results in the format to the path, "+
+		"\"format=path\" where format is yaml, pb or json and path \"-\" is stdout. Can be "+
+		"specified multiple times to write several formats in a single run. Overrides --pb.")
+	rootFlags.Bool("findings", false, "Append the ranked notable findings - the subsystems with "+
+		"a low bus factor, the riskiest hotspots, the fastest-growing directory and the quarter "+
+		"with the most departures - to the YAML and JSON results.")
+	rootFlags.StringArray("output-filter", nil, "Trim the results of an analysis before "+
+		"writing them, \"analysis.key=value\" where analysis is the flag, e.g. burndown, or \"*\" "+
+		"and key is top-files, min-lines or authors (comma-separated names or emails). "+
+		"Can be specified multiple times.")
+	rootFlags.String("anonymize-paths", "", "Replace the file and directory names in the results "+
+		"with pseudonyms which keep the directory depth and the extensions: \"hash\" - salted hashes, "+
+		"\"bucket\" - sequential numbers, e.g. d1/d3/f2.go.")
+	rootFlags.String("anonymize-paths-salt", "", "Salt of --anonymize-paths=hash which keeps "+
+		"the pseudonyms stable across the runs. Random if empty.")
+	rootFlags.String("post-run", "", "Shell command to execute for each output after the "+
+		"results are written. It is a Go template with {{.Path}}, {{.Format}} and "+
+		"{{.Repository}}, which are already quoted for the shell; the run summary is in "+
+		"the HERCULES_* environment variables.")
 	rootFlags.Bool("quiet", !terminal.IsTerminal(int(os.Stdin.Fd())),
 		"Do not print status updates to stderr.")
 	rootFlags.Bool("profile", false, "Collect the profile to hercules.pprof.")
+	rootFlags.Int("parallel-repos", 1, "Maximum number of the repositories which are analysed "+
+		"at the same time if several are specified.")
 	rootFlags.String("preset", "",
-		"Apply a named set of flag defaults. Available: large-repo, quick. "+
+		"Apply a named set of flag defaults. Available: chaoss, large-repo, quick. "+
 			"Explicit flags override preset values.")
+	rootFlags.String("clone-dir", "", "Clone the remote repositories into a temporary "+
+		"subdirectory of this directory instead of the memory and remove them afterwards.")
+	hercules.PathifyFlagValue(rootFlags.Lookup("clone-dir"))
+	rootFlags.Int("clone-memory-limit", 1024, "Maximum size in MiB of the packfile of a remote "+
+		"repository which is cloned into the memory; bigger packfiles spill to a temporary "+
+		"directory. 0 disables the limit.")
 	rootFlags.String("ssh-identity", "", "Path to SSH identity file (e.g., ~/.ssh/id_rsa) to clone from an SSH remote.")
 	err = rootCmd.MarkFlagFilename("ssh-identity")
 	if err != nil {
@@ -651,13 +1003,20 @@ func init() {
 	}
 	hercules.PathifyFlagValue(rootFlags.Lookup("ssh-identity"))
 	cmdlineFacts, cmdlineDeployed, activationByFlags = hercules.Registry.AddFlags(rootFlags)
+	hercules.Deprecations.AddFlagAliases(rootFlags)
 	rootCmd.SetUsageFunc(formatUsage)
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.SetUsageFunc(versionCmd.UsageFunc())
+	// facts accepts the same flags as the root command and shares their values
+	factsCmd.Flags().AddFlagSet(rootFlags)
 }
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	// the panics, e.g. in loadRepository(), skip the explicit cleanup below
+	defer removeTemporaryClones()
+	err := rootCmd.Execute()
+	removeTemporaryClones()
+	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
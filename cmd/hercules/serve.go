@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/spf13/cobra"
+)
+
+// readResultsFile loads the analysis results in Protocol Buffers format from the file system.
+// "-" reads from stdin.
+func readResultsFile(path string) (pb.AnalysisResults, error) {
+	var message pb.AnalysisResults
+	var payload []byte
+	var err error
+	if path == "-" {
+		payload, err = io.ReadAll(os.Stdin)
+	} else {
+		payload, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return message, err
+	}
+	if err := proto.Unmarshal(payload, &message); err != nil {
+		return message, fmt.Errorf("%s is not a valid hercules protobuf result: %w", path, err)
+	}
+	notes, err := upgradeLegacyResults(&message)
+	if err != nil {
+		return message, fmt.Errorf("failed to upgrade %s: %w", path, err)
+	}
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", path, note)
+	}
+	return message, nil
+}
+
+// grafanaQueryRequest is the body of POST /query in the Grafana SimpleJSON datasource protocol.
+type grafanaQueryRequest struct {
+	Range struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"range"`
+	Targets []struct {
+		Target string `json:"target"`
+	} `json:"targets"`
+	MaxDataPoints int `json:"maxDataPoints"`
+}
+
+// grafanaTimeSeries is a single element of the POST /query response.
+// Each datapoint is [value, UNIX time in milliseconds].
+type grafanaTimeSeries struct {
+	Target     string       `json:"target"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
+
+// toGrafana converts the time series to the SimpleJSON format, keeping only the points
+// inside [from, to]. Zero bounds are ignored.
+func toGrafana(ts timeSeries, from, to time.Time, maxPoints int) grafanaTimeSeries {
+	result := grafanaTimeSeries{Target: ts.Name, Datapoints: [][2]float64{}}
+	for _, point := range ts.Points {
+		if !from.IsZero() && point.Time < from.Unix() {
+			continue
+		}
+		if !to.IsZero() && point.Time > to.Unix() {
+			continue
+		}
+		result.Datapoints = append(result.Datapoints, [2]float64{point.Value, float64(point.Time * 1000)})
+	}
+	if maxPoints > 0 && len(result.Datapoints) > maxPoints {
+		result.Datapoints = result.Datapoints[len(result.Datapoints)-maxPoints:]
+	}
+	return result
+}
+
+// newGrafanaHandler implements the Grafana SimpleJSON datasource API over the given series:
+// GET / for the health check, POST /search to list the metrics, POST /query to fetch them and
+// POST /annotations which always returns an empty list.
+func newGrafanaHandler(series map[string]timeSeries) http.Handler {
+	names := sortedSeriesNames(series)
+	writeJSON := func(w http.ResponseWriter, value interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(value); err != nil {
+			log.Printf("serve: failed to write the response: %v", err)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	})
+	mux.HandleFunc("/search", func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Target string `json:"target"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		matched := []string{}
+		for _, name := range names {
+			if strings.Contains(name, request.Target) {
+				matched = append(matched, name)
+			}
+		}
+		writeJSON(w, matched)
+	})
+	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "POST is required", http.StatusMethodNotAllowed)
+			return
+		}
+		var request grafanaQueryRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response := []grafanaTimeSeries{}
+		for _, target := range request.Targets {
+			ts, exists := series[target.Target]
+			if !exists {
+				continue
+			}
+			response = append(response, toGrafana(ts, request.Range.From, request.Range.To, request.MaxDataPoints))
+		}
+		writeJSON(w, response)
+	})
+	mux.HandleFunc("/annotations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []struct{}{})
+	})
+	return mux
+}
+
+// serveCmd exposes the results as a Grafana SimpleJSON datasource.
+var serveCmd = &cobra.Command{
+	Use:   "serve [flags] <results.pb>",
+	Short: "Serve the analysis results as a Grafana SimpleJSON datasource.",
+	Long: `Loads the analysis results in Protocol Buffers format and serves the time series of
+BusFactor (bus_factor, total_lines), Devs (devs.*, churn.*) and OwnershipConcentration
+(ownership.gini, ownership.hhi) over HTTP using the SimpleJSON datasource protocol, so that
+they can be added to existing Grafana dashboards.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listen, err := cmd.Flags().GetString("listen")
+		if err != nil {
+			return err
+		}
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		series, err := extractTimeSeries(message)
+		if err != nil {
+			return err
+		}
+		if len(series) == 0 {
+			return fmt.Errorf("%s does not contain BusFactor, Devs or OwnershipConcentration results", args[0])
+		}
+		log.Printf("serving %d time series on http://%s", len(series), listen)
+		return http.ListenAndServe(listen, newGrafanaHandler(series))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.SetUsageFunc(serveCmd.UsageFunc())
+	serveCmd.Flags().String("listen", "localhost:8080", "Address to listen on.")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testGrafanaSeries() map[string]timeSeries {
+	return map[string]timeSeries{
+		"bus_factor":     {Name: "bus_factor", Points: []timePoint{{100, 1}, {200, 2}, {300, 3}}},
+		"ownership.gini": {Name: "ownership.gini", Points: []timePoint{{100, 0.5}}},
+	}
+}
+
+func TestGrafanaHandlerHealth(t *testing.T) {
+	handler := newGrafanaHandler(testGrafanaSeries())
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", recorder.Code)
+	}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("unexpected status: %d", recorder.Code)
+	}
+}
+
+func TestGrafanaHandlerSearch(t *testing.T) {
+	handler := newGrafanaHandler(testGrafanaSeries())
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"target":""}`)))
+	var names []string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &names); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"bus_factor", "ownership.gini"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/search", strings.NewReader(`{"target":"gini"}`)))
+	_ = json.Unmarshal(recorder.Body.Bytes(), &names)
+	if !reflect.DeepEqual(names, []string{"ownership.gini"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+}
+
+func TestGrafanaHandlerQuery(t *testing.T) {
+	handler := newGrafanaHandler(testGrafanaSeries())
+	body := `{"range":{"from":"1970-01-01T00:02:30Z","to":"1970-01-01T00:10:00Z"},
+		"targets":[{"target":"bus_factor"},{"target":"missing"}]}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body)))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status: %d %s", recorder.Code, recorder.Body.String())
+	}
+	var response []grafanaTimeSeries
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	expected := []grafanaTimeSeries{{Target: "bus_factor", Datapoints: [][2]float64{{2, 200000}, {3, 300000}}}}
+	if !reflect.DeepEqual(response, expected) {
+		t.Fatalf("unexpected response: %v", response)
+	}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/query", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("unexpected status: %d", recorder.Code)
+	}
+}
+
+func TestToGrafanaMaxDataPoints(t *testing.T) {
+	var zero grafanaQueryRequest
+	result := toGrafana(testGrafanaSeries()["bus_factor"], zero.Range.From, zero.Range.To, 2)
+	if !reflect.DeepEqual(result.Datapoints, [][2]float64{{2, 200000}, {3, 300000}}) {
+		t.Fatalf("unexpected datapoints: %v", result.Datapoints)
+	}
+}
+
+func TestReadResultsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results.pb")
+	message := fakeBundleResults(t)
+	if err := os.WriteFile(path, mustMarshal(t, &message), 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	loaded, err := readResultsFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Header.Repository != "test" || len(loaded.Contents) != 4 {
+		t.Fatalf("unexpected message: %v", loaded.Header)
+	}
+	if err := os.WriteFile(path, []byte{0xff, 0xff}, 0o644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if _, err := readResultsFile(path); err == nil {
+		t.Fatal("expected a parsing error")
+	}
+}
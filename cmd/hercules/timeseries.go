@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+// timePoint is a single sample of a metric time series.
+type timePoint struct {
+	// Time is the UNIX time in seconds.
+	Time  int64
+	Value float64
+}
+
+// timeSeries is the sequence of samples of a named metric sorted by time.
+type timeSeries struct {
+	Name   string
+	Points []timePoint
+}
+
+// Last returns the latest sample. The second value is false if the series is empty.
+func (ts timeSeries) Last() (timePoint, bool) {
+	if len(ts.Points) == 0 {
+		return timePoint{}, false
+	}
+	return ts.Points[len(ts.Points)-1], true
+}
+
+const (
+	seriesBusFactor      = "bus_factor"
+	seriesTotalLines     = "total_lines"
+	seriesCommits        = "devs.commits"
+	seriesActiveDevs     = "devs.active"
+	seriesChurnAdded     = "churn.added"
+	seriesChurnRemoved   = "churn.removed"
+	seriesChurnChanged   = "churn.changed"
+	seriesChurnTotal     = "churn.total"
+	seriesGini           = "ownership.gini"
+	seriesHHI            = "ownership.hhi"
+	seriesDevCommitsBase = "devs.commits."
+)
+
+// extractTimeSeries decodes the analysis results and converts the per-tick metrics of
+// BusFactor, Devs and OwnershipConcentration to time series. Churn is the sum of added,
+// removed and changed lines reported by Devs.
+func extractTimeSeries(message pb.AnalysisResults) (map[string]timeSeries, error) {
+	series := map[string]timeSeries{}
+	add := func(name string, tickSize int64, tick int32, value float64) {
+		ts := series[name]
+		ts.Name = name
+		ts.Points = append(ts.Points, timePoint{
+			Time: bundleTickTime(message.Header, tickSize, int64(tick)), Value: value,
+		})
+		series[name] = ts
+	}
+	if payload, exists := message.Contents["BusFactor"]; exists {
+		var result pb.BusFactorAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode BusFactor: %w", err)
+		}
+		ticks := make([]int32, 0, len(result.Snapshots))
+		for tick := range result.Snapshots {
+			ticks = append(ticks, tick)
+		}
+		for _, tick := range sortTicks(ticks) {
+			snapshot := result.Snapshots[tick]
+			add(seriesBusFactor, result.TickSize, tick, float64(snapshot.BusFactor))
+			add(seriesTotalLines, result.TickSize, tick, float64(snapshot.TotalLines))
+		}
+	}
+	if payload, exists := message.Contents["Devs"]; exists {
+		var result pb.DevsAnalysisResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode Devs: %w", err)
+		}
+		ticks := make([]int32, 0, len(result.Ticks))
+		for tick := range result.Ticks {
+			ticks = append(ticks, tick)
+		}
+		for _, tick := range sortTicks(ticks) {
+			var commits, added, removed, changed float64
+			devs := result.Ticks[tick].Devs
+			for dev, stats := range devs {
+				commits += float64(stats.Commits)
+				if stats.Stats != nil {
+					added += float64(stats.Stats.Added)
+					removed += float64(stats.Stats.Removed)
+					changed += float64(stats.Stats.Changed)
+				}
+				if dev >= 0 && int(dev) < len(result.DevIndex) {
+					add(seriesDevCommitsBase+result.DevIndex[dev], result.TickSize, tick,
+						float64(stats.Commits))
+				}
+			}
+			add(seriesCommits, result.TickSize, tick, commits)
+			add(seriesActiveDevs, result.TickSize, tick, float64(len(devs)))
+			add(seriesChurnAdded, result.TickSize, tick, added)
+			add(seriesChurnRemoved, result.TickSize, tick, removed)
+			add(seriesChurnChanged, result.TickSize, tick, changed)
+			add(seriesChurnTotal, result.TickSize, tick, added+removed+changed)
+		}
+	}
+	if payload, exists := message.Contents["OwnershipConcentration"]; exists {
+		var result pb.OwnershipConcentrationResults
+		if err := proto.Unmarshal(payload, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode OwnershipConcentration: %w", err)
+		}
+		ticks := make([]int32, 0, len(result.Snapshots))
+		for tick := range result.Snapshots {
+			ticks = append(ticks, tick)
+		}
+		for _, tick := range sortTicks(ticks) {
+			snapshot := result.Snapshots[tick]
+			add(seriesGini, result.TickSize, tick, snapshot.Gini)
+			add(seriesHHI, result.TickSize, tick, snapshot.Hhi)
+		}
+	}
+	return series, nil
+}
+
+// sortTicks sorts the tick indexes in ascending order and returns the same slice.
+func sortTicks(ticks []int32) []int32 {
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	return ticks
+}
+
+// sortedSeriesNames returns the names of the time series in alphabetical order.
+func sortedSeriesNames(series map[string]timeSeries) []string {
+	names := make([]string, 0, len(series))
+	for name := range series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+func TestExtractTimeSeries(t *testing.T) {
+	message := fakeBundleResults(t)
+	message.Contents["OwnershipConcentration"] = mustMarshal(t, &pb.OwnershipConcentrationResults{
+		TickSize: int64(24 * time.Hour),
+		Snapshots: map[int32]*pb.OwnershipConcentrationTickSnapshot{
+			3: {Gini: 0.5, Hhi: 0.4},
+			1: {Gini: 0.25, Hhi: 0.3},
+		},
+	})
+	series, err := extractTimeSeries(message)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	day := int64(24 * 3600)
+	expectedNames := []string{
+		"bus_factor", "churn.added", "churn.changed", "churn.removed", "churn.total",
+		"devs.active", "devs.commits", "devs.commits.alice", "devs.commits.bob",
+		"ownership.gini", "ownership.hhi", "total_lines",
+	}
+	if names := sortedSeriesNames(series); !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("unexpected series: %v", names)
+	}
+	expectPoints := func(name string, points []timePoint) {
+		if !reflect.DeepEqual(series[name].Points, points) {
+			t.Fatalf("unexpected %s: got %v want %v", name, series[name].Points, points)
+		}
+	}
+	expectPoints(seriesBusFactor, []timePoint{{1000, 1}, {1000 + day, 2}})
+	expectPoints(seriesChurnTotal, []timePoint{{1000, 10}, {1000 + 2*day, 5}})
+	expectPoints(seriesCommits, []timePoint{{1000, 1}, {1000 + 2*day, 2}})
+	expectPoints("devs.commits.bob", []timePoint{{1000 + 2*day, 2}})
+	expectPoints(seriesGini, []timePoint{{1000 + day, 0.25}, {1000 + 3*day, 0.5}})
+	last, ok := series[seriesHHI].Last()
+	if !ok || last.Value != 0.4 {
+		t.Fatalf("unexpected last value: %v", last)
+	}
+	if _, ok := (timeSeries{}).Last(); ok {
+		t.Fatal("empty series must not have the last value")
+	}
+}
+
+func TestExtractTimeSeriesInvalid(t *testing.T) {
+	message := pb.AnalysisResults{Contents: map[string][]byte{"BusFactor": {0xff}}}
+	if _, err := extractTimeSeries(message); err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
@@ -0,0 +1,614 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/leaves"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+// whatIfManifest is the YAML file with the proposed refactoring:
+//
+//	split:
+//	  - file: src/engine.go
+//	    into:
+//	      - file: src/engine/parser.go
+//	        lines: [1, 400]
+//	      - file: src/engine/runtime.go
+//	        lines: [401, 1000]
+//	merge:
+//	  - from: src/util      # a file or a directory
+//	    into: src/common
+//
+// The lines which are not listed in any part of a split stay in the original file.
+// The splits are applied before the merges.
+type whatIfManifest struct {
+	Split []whatIfSplit `yaml:"split"`
+	Merge []whatIfMerge `yaml:"merge"`
+}
+
+// whatIfSplit moves the line ranges of File to new files.
+type whatIfSplit struct {
+	File string        `yaml:"file"`
+	Into []whatIfRange `yaml:"into"`
+}
+
+// whatIfRange is the part of the split file, Lines are 1-based and inclusive.
+type whatIfRange struct {
+	File  string `yaml:"file"`
+	Lines [2]int `yaml:"lines"`
+}
+
+// whatIfMerge moves the file or all the files in the directory From to Into.
+type whatIfMerge struct {
+	From string `yaml:"from"`
+	Into string `yaml:"into"`
+}
+
+// whatIfFile is a file of the real or the hypothetical structure.
+type whatIfFile struct {
+	Lines int
+	// Commits is the number of the commits which changed the file, it stands for the churn.
+	Commits int64
+	// Couples maps the other files to the number of the commits which changed both.
+	Couples map[string]int64
+	// Owners maps the developer indexes to the owned lines.
+	Owners map[int]int
+}
+
+// whatIfStructure maps the file paths to their metrics.
+type whatIfStructure map[string]*whatIfFile
+
+// whatIfFileReport is the recomputed metrics of a single file.
+type whatIfFileReport struct {
+	Path           string  `yaml:"path"`
+	Lines          int     `yaml:"lines"`
+	Commits        int64   `yaml:"commits"`
+	CouplingDegree int     `yaml:"coupling_degree"`
+	OwnershipGini  float64 `yaml:"ownership_gini"`
+	TopOwner       string  `yaml:"top_owner,omitempty"`
+	TopOwnerShare  float64 `yaml:"top_owner_share"`
+	RiskScore      float64 `yaml:"risk_score"`
+}
+
+// whatIfSummary aggregates the metrics of all the files of a structure.
+type whatIfSummary struct {
+	Files              int     `yaml:"files"`
+	MeanRiskScore      float64 `yaml:"mean_risk_score"`
+	MaxRiskScore       float64 `yaml:"max_risk_score"`
+	MeanCouplingDegree float64 `yaml:"mean_coupling_degree"`
+	// MeanOwnershipGini is weighted by the lines.
+	MeanOwnershipGini float64 `yaml:"mean_ownership_gini"`
+}
+
+// whatIfReport compares the real structure with the hypothetical one.
+type whatIfReport struct {
+	Repository string             `yaml:"repository"`
+	Before     whatIfSummary      `yaml:"before"`
+	After      whatIfSummary      `yaml:"after"`
+	Removed    []whatIfFileReport `yaml:"removed"`
+	Added      []whatIfFileReport `yaml:"added"`
+}
+
+// loadWhatIfManifest reads and validates the refactoring manifest.
+func loadWhatIfManifest(path string) (*whatIfManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest whatIfManifest
+	if err := yaml.UnmarshalStrict(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest %s: %w", path, err)
+	}
+	for i, split := range manifest.Split {
+		if split.File == "" || len(split.Into) == 0 {
+			return nil, fmt.Errorf("split #%d must set file and into", i+1)
+		}
+		for _, part := range split.Into {
+			if part.File == "" || part.Lines[0] < 1 || part.Lines[1] < part.Lines[0] {
+				return nil, fmt.Errorf("split #%d has an invalid part %q %v", i+1, part.File, part.Lines)
+			}
+		}
+	}
+	for i, merge := range manifest.Merge {
+		if merge.From == "" || merge.Into == "" {
+			return nil, fmt.Errorf("merge #%d must set from and into", i+1)
+		}
+	}
+	return &manifest, nil
+}
+
+// newWhatIfStructure collects the files of Couples together with their ownership from
+// Burndown with --burndown-files.
+func newWhatIfStructure(
+	couples leaves.CouplesResult, burndown *pb.BurndownAnalysisResults,
+) whatIfStructure {
+	files := whatIfStructure{}
+	for i, name := range couples.Files {
+		file := &whatIfFile{Couples: map[string]int64{}, Owners: map[int]int{}}
+		if i < len(couples.FilesLines) {
+			file.Lines = couples.FilesLines[i]
+		}
+		if i < len(couples.FilesMatrix) {
+			for j, count := range couples.FilesMatrix[i] {
+				if j == i {
+					file.Commits = count
+				} else if j < len(couples.Files) && count > 0 {
+					file.Couples[couples.Files[j]] = count
+				}
+			}
+		}
+		files[name] = file
+	}
+	for i, mat := range burndown.Files {
+		if i >= len(burndown.FilesOwnership) {
+			break
+		}
+		file := files[mat.Name]
+		if file == nil {
+			continue
+		}
+		owned := 0
+		for author, lines := range burndown.FilesOwnership[i].Value {
+			if lines > 0 {
+				file.Owners[int(author)] += int(lines)
+				owned += int(lines)
+			}
+		}
+		if file.Lines == 0 {
+			file.Lines = owned
+		}
+	}
+	for name, file := range files {
+		if file.Lines <= 0 {
+			delete(files, name)
+		}
+	}
+	for _, file := range files {
+		for other := range file.Couples {
+			if files[other] == nil {
+				delete(file.Couples, other)
+			}
+		}
+	}
+	return files
+}
+
+// clone returns the deep copy of the structure.
+func (files whatIfStructure) clone() whatIfStructure {
+	result := make(whatIfStructure, len(files))
+	for name, file := range files {
+		copied := &whatIfFile{
+			Lines: file.Lines, Commits: file.Commits,
+			Couples: make(map[string]int64, len(file.Couples)),
+			Owners:  make(map[int]int, len(file.Owners)),
+		}
+		for other, count := range file.Couples {
+			copied.Couples[other] = count
+		}
+		for author, lines := range file.Owners {
+			copied.Owners[author] = lines
+		}
+		result[name] = copied
+	}
+	return result
+}
+
+// scaleWhatIfCount returns the share of the count, at least 1 if the count and the share
+// are positive.
+func scaleWhatIfCount(count int64, share float64) int64 {
+	if count <= 0 || share <= 0 {
+		return 0
+	}
+	scaled := int64(math.Round(float64(count) * share))
+	if scaled < 1 {
+		scaled = 1
+	}
+	return scaled
+}
+
+// split moves the line ranges of the file to new files. The parts get the shares of the ownership,
+// the commits and the co-changes which are proportional to their sizes, because the results do not
+// record which lines were changed together.
+func (files whatIfStructure) split(split whatIfSplit) error {
+	file := files[split.File]
+	if file == nil {
+		return fmt.Errorf("cannot split %s: no such file in the results", split.File)
+	}
+	covered := make([]bool, file.Lines)
+	shares := make([]float64, len(split.Into))
+	rest := 1.0
+	named := map[string]bool{}
+	for i, part := range split.Into {
+		if part.Lines[1] > file.Lines {
+			return fmt.Errorf("cannot split %s: the lines %v exceed its %d lines",
+				split.File, part.Lines, file.Lines)
+		}
+		if files[part.File] != nil || named[part.File] {
+			return fmt.Errorf("cannot split %s: %s already exists", split.File, part.File)
+		}
+		named[part.File] = true
+		for line := part.Lines[0] - 1; line < part.Lines[1]; line++ {
+			if covered[line] {
+				return fmt.Errorf("cannot split %s: the line %d belongs to several parts", split.File, line+1)
+			}
+			covered[line] = true
+		}
+		shares[i] = float64(part.Lines[1]-part.Lines[0]+1) / float64(file.Lines)
+		rest -= shares[i]
+	}
+	delete(files, split.File)
+	parts := make([]string, 0, len(split.Into)+1)
+	partShares := make([]float64, 0, len(split.Into)+1)
+	for i, part := range split.Into {
+		parts = append(parts, part.File)
+		partShares = append(partShares, shares[i])
+	}
+	if rest > 1e-9 {
+		parts = append(parts, split.File)
+		partShares = append(partShares, rest)
+	}
+	for _, other := range files {
+		count, exists := other.Couples[split.File]
+		if !exists {
+			continue
+		}
+		delete(other.Couples, split.File)
+		for i, name := range parts {
+			other.Couples[name] = scaleWhatIfCount(count, partShares[i])
+		}
+	}
+	for i, name := range parts {
+		share := partShares[i]
+		created := &whatIfFile{
+			Commits: scaleWhatIfCount(file.Commits, share),
+			Couples: map[string]int64{},
+			Owners:  map[int]int{},
+		}
+		for author, lines := range file.Owners {
+			if owned := int(math.Round(float64(lines) * share)); owned > 0 {
+				created.Owners[author] = owned
+			}
+		}
+		created.Lines = int(math.Round(float64(file.Lines) * share))
+		for other, count := range file.Couples {
+			created.Couples[other] = scaleWhatIfCount(count, share)
+		}
+		for j, sibling := range parts {
+			if j != i {
+				// the parts change together at most as often as the smaller of them
+				created.Couples[sibling] = scaleWhatIfCount(file.Commits, math.Min(share, partShares[j]))
+			}
+		}
+		files[name] = created
+	}
+	return nil
+}
+
+// rename moves the file to the new path. If the path is taken, the files become one:
+// the lines, the ownership and the co-changes add up and the commits which changed both
+// files count once.
+func (files whatIfStructure) rename(from, to string) {
+	if from == to {
+		return
+	}
+	file := files[from]
+	delete(files, from)
+	target := files[to]
+	if target == nil {
+		for _, other := range files {
+			if count, exists := other.Couples[from]; exists {
+				delete(other.Couples, from)
+				other.Couples[to] = count
+			}
+		}
+		files[to] = file
+		return
+	}
+	target.Lines += file.Lines
+	target.Commits += file.Commits - file.Couples[to]
+	for author, lines := range file.Owners {
+		target.Owners[author] += lines
+	}
+	delete(target.Couples, from)
+	for other, count := range file.Couples {
+		if other != to {
+			target.Couples[other] += count
+		}
+	}
+	for name, other := range files {
+		if name == to {
+			continue
+		}
+		delete(other.Couples, from)
+		if count, exists := target.Couples[name]; exists {
+			// the co-changes of the two files with the third one may overlap
+			if count > target.Commits {
+				count = target.Commits
+				target.Couples[name] = count
+			}
+			other.Couples[to] = count
+		}
+	}
+}
+
+// merge moves the file or all the files in the directory to another path.
+func (files whatIfStructure) merge(merge whatIfMerge) error {
+	from := strings.TrimSuffix(merge.From, "/")
+	into := strings.TrimSuffix(merge.Into, "/")
+	if files[from] != nil {
+		files.rename(from, into)
+		return nil
+	}
+	var moved []string
+	for name := range files {
+		if strings.HasPrefix(name, from+"/") {
+			moved = append(moved, name)
+		}
+	}
+	if len(moved) == 0 {
+		return fmt.Errorf("cannot merge %s: no such file or directory in the results", merge.From)
+	}
+	sort.Strings(moved)
+	for _, name := range moved {
+		files.rename(name, into+name[len(from):])
+	}
+	return nil
+}
+
+// evaluate computes the hotspot risk, the coupling degree and the ownership of each file.
+// The churn is the number of the commits over the whole analysed history.
+func (files whatIfStructure) evaluate(developers []string) map[string]whatIfFileReport {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	risks := make([]leaves.FileRisk, len(names))
+	reports := make(map[string]whatIfFileReport, len(names))
+	for i, name := range names {
+		file := files[name]
+		degree := 0
+		for _, count := range file.Couples {
+			if count > 0 {
+				degree++
+			}
+		}
+		report := whatIfFileReport{
+			Path: name, Lines: file.Lines, Commits: file.Commits, CouplingDegree: degree,
+			OwnershipGini: leaves.OwnershipGini(file.Owners),
+		}
+		owned, top := 0, -1
+		for author, lines := range file.Owners {
+			owned += lines
+			if top < 0 || lines > file.Owners[top] || (lines == file.Owners[top] && author < top) {
+				top = author
+			}
+		}
+		if top >= 0 && owned > 0 {
+			report.TopOwnerShare = float64(file.Owners[top]) / float64(owned)
+			if top < len(developers) {
+				report.TopOwner = developers[top]
+			}
+		}
+		reports[name] = report
+		risks[i] = leaves.FileRisk{
+			Path: name, Size: file.Lines, Churn: int(file.Commits),
+			CouplingDegree: degree, OwnershipGini: report.OwnershipGini,
+		}
+	}
+	scorer := &leaves.HotspotRiskAnalysis{
+		WeightSize: leaves.DefaultWeight, WeightChurn: leaves.DefaultWeight,
+		WeightCoupling: leaves.DefaultWeight, WeightOwnership: leaves.DefaultWeight,
+	}
+	scorer.ScoreFiles(risks)
+	for _, risk := range risks {
+		report := reports[risk.Path]
+		report.RiskScore = risk.RiskScore
+		reports[risk.Path] = report
+	}
+	return reports
+}
+
+// summarizeWhatIf aggregates the metrics of all the files.
+func summarizeWhatIf(reports map[string]whatIfFileReport) whatIfSummary {
+	summary := whatIfSummary{Files: len(reports)}
+	if len(reports) == 0 {
+		return summary
+	}
+	lines := 0
+	for _, report := range reports {
+		summary.MeanRiskScore += report.RiskScore
+		summary.MaxRiskScore = math.Max(summary.MaxRiskScore, report.RiskScore)
+		summary.MeanCouplingDegree += float64(report.CouplingDegree)
+		summary.MeanOwnershipGini += report.OwnershipGini * float64(report.Lines)
+		lines += report.Lines
+	}
+	summary.MeanRiskScore /= float64(len(reports))
+	summary.MeanCouplingDegree /= float64(len(reports))
+	if lines > 0 {
+		summary.MeanOwnershipGini /= float64(lines)
+	}
+	return summary
+}
+
+// buildWhatIfReport applies the manifest to the files from Couples and Burndown with
+// --burndown-files and compares the metrics of the files before and after. The files
+// which do not change keep their metrics except the risk score, which is normalized
+// over the whole repository.
+func buildWhatIfReport(message pb.AnalysisResults, manifest *whatIfManifest) (*whatIfReport, error) {
+	report := &whatIfReport{}
+	if message.Header != nil {
+		report.Repository = message.Header.Repository
+	}
+	payload, exists := message.Contents["Couples"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain Couples")
+	}
+	couples, err := (&leaves.CouplesAnalysis{}).Deserialize(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Couples: %w", err)
+	}
+	var burndown pb.BurndownAnalysisResults
+	payload, exists = message.Contents["Burndown"]
+	if !exists {
+		return nil, fmt.Errorf("the results do not contain Burndown with --burndown-files")
+	}
+	if err := proto.Unmarshal(payload, &burndown); err != nil {
+		return nil, fmt.Errorf("failed to decode Burndown: %w", err)
+	}
+	if len(burndown.FilesOwnership) == 0 {
+		return nil, fmt.Errorf("the results do not contain Burndown with --burndown-files")
+	}
+	developers := make([]string, len(burndown.People))
+	for i, person := range burndown.People {
+		developers[i] = core.IdentityLabel(person.Name)
+	}
+
+	before := newWhatIfStructure(couples.(leaves.CouplesResult), &burndown)
+	after := before.clone()
+	for _, split := range manifest.Split {
+		if err := after.split(split); err != nil {
+			return nil, err
+		}
+	}
+	for _, merge := range manifest.Merge {
+		if err := after.merge(merge); err != nil {
+			return nil, err
+		}
+	}
+
+	beforeReports := before.evaluate(developers)
+	afterReports := after.evaluate(developers)
+	report.Before = summarizeWhatIf(beforeReports)
+	report.After = summarizeWhatIf(afterReports)
+	changed := func(name string) bool {
+		b, a := before[name], after[name]
+		if b == nil || a == nil {
+			return true
+		}
+		if b.Lines != a.Lines || b.Commits != a.Commits || len(b.Couples) != len(a.Couples) ||
+			len(b.Owners) != len(a.Owners) {
+			return true
+		}
+		for other, count := range b.Couples {
+			if a.Couples[other] != count {
+				return true
+			}
+		}
+		for author, lines := range b.Owners {
+			if a.Owners[author] != lines {
+				return true
+			}
+		}
+		return false
+	}
+	for name, fileReport := range beforeReports {
+		if changed(name) {
+			report.Removed = append(report.Removed, fileReport)
+		}
+	}
+	for name, fileReport := range afterReports {
+		if changed(name) {
+			report.Added = append(report.Added, fileReport)
+		}
+	}
+	for _, reports := range [][]whatIfFileReport{report.Removed, report.Added} {
+		sort.Slice(reports, func(i, j int) bool {
+			return reports[i].Path < reports[j].Path
+		})
+	}
+	return report, nil
+}
+
+// roundWhatIf keeps three decimal digits so that the report is readable.
+func roundWhatIf(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+// writeWhatIfReport prints the report as YAML.
+func writeWhatIfReport(writer io.Writer, report *whatIfReport) error {
+	for _, summary := range []*whatIfSummary{&report.Before, &report.After} {
+		summary.MeanRiskScore = roundWhatIf(summary.MeanRiskScore)
+		summary.MaxRiskScore = roundWhatIf(summary.MaxRiskScore)
+		summary.MeanCouplingDegree = roundWhatIf(summary.MeanCouplingDegree)
+		summary.MeanOwnershipGini = roundWhatIf(summary.MeanOwnershipGini)
+	}
+	for _, reports := range [][]whatIfFileReport{report.Removed, report.Added} {
+		for i := range reports {
+			reports[i].OwnershipGini = roundWhatIf(reports[i].OwnershipGini)
+			reports[i].TopOwnerShare = roundWhatIf(reports[i].TopOwnerShare)
+			reports[i].RiskScore = roundWhatIf(reports[i].RiskScore)
+		}
+	}
+	data, err := yaml.Marshal(map[string]*whatIfReport{"what_if": report})
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// whatIfCmd evaluates a proposed refactoring.
+var whatIfCmd = &cobra.Command{
+	Use:   "what-if [flags] <results.pb>",
+	Short: "Recompute the hotspot risk, the coupling and the ownership after a proposed refactoring.",
+	Long: `Applies the refactoring manifest - file splits by line ranges and file or directory
+merges - to the files of --couples and --burndown --burndown-files and recomputes the hotspot
+risk (with the default weights of --hotspot-risk), the coupling degree and the ownership of
+each file. The results do not record which lines changed together, so the parts of a split
+file get the shares of the commits, the co-changes and the owned lines proportional to their
+sizes. The churn is the number of the commits over the whole analysed history.
+
+The report lists the summary of the repository before and after together with the metrics
+of the removed and the added (or changed) files. It is printed as YAML.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		flags := cmd.Flags()
+		output, _ := flags.GetString("output")
+		manifestPath, _ := flags.GetString("manifest")
+		manifest, err := loadWhatIfManifest(manifestPath)
+		if err != nil {
+			return err
+		}
+		message, err := readResultsFile(args[0])
+		if err != nil {
+			return err
+		}
+		report, err := buildWhatIfReport(message, manifest)
+		if err != nil {
+			return err
+		}
+		if output == "-" {
+			return writeWhatIfReport(os.Stdout, report)
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		if err := writeWhatIfReport(file, report); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whatIfCmd)
+	whatIfCmd.SetUsageFunc(whatIfCmd.UsageFunc())
+	whatIfFlags := whatIfCmd.Flags()
+	whatIfFlags.StringP("output", "o", "-", "Path to the YAML file to write; \"-\" prints to stdout.")
+	whatIfFlags.String("manifest", "",
+		"Path to the YAML file with the proposed file splits and file or directory merges.")
+	_ = whatIfCmd.MarkFlagRequired("manifest")
+}
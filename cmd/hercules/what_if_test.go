@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadWhatIfManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "manifest.yaml")
+	if err := os.WriteFile(path, []byte(`split:
+  - file: a/x.go
+    into:
+      - file: a/x1.go
+        lines: [1, 40]
+merge:
+  - from: b
+    into: a
+`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := loadWhatIfManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(manifest.Split) != 1 || manifest.Split[0].Into[0].Lines != [2]int{1, 40} ||
+		len(manifest.Merge) != 1 || manifest.Merge[0].Into != "a" {
+		t.Fatalf("unexpected manifest: %+v", manifest)
+	}
+	if err := os.WriteFile(path, []byte("split:\n  - file: a/x.go\n    into:\n      - file: a/x1.go\n        lines: [5, 1]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadWhatIfManifest(path); err == nil {
+		t.Fatal("expected an error for the reversed line range")
+	}
+}
+
+func TestBuildWhatIfReport(t *testing.T) {
+	manifest := &whatIfManifest{
+		Split: []whatIfSplit{{File: "a/x.go", Into: []whatIfRange{{File: "a/x1.go", Lines: [2]int{1, 40}}}}},
+		Merge: []whatIfMerge{{From: "b", Into: "a"}},
+	}
+	report, err := buildWhatIfReport(fakeOwnershipPlanResults(t), manifest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Before.Files != 4 || report.After.Files != 5 {
+		t.Fatalf("unexpected summaries: %+v %+v", report.Before, report.After)
+	}
+	var removed, added []string
+	for _, file := range report.Removed {
+		removed = append(removed, file.Path)
+	}
+	for _, file := range report.Added {
+		added = append(added, file.Path)
+	}
+	if strings.Join(removed, ",") != "a/x.go,b/z.go" || strings.Join(added, ",") != "a/x.go,a/x1.go,a/z.go" {
+		t.Fatalf("unexpected files: %v %v", removed, added)
+	}
+	x := report.Removed[0]
+	if x.Lines != 80 || x.Commits != 5 || x.CouplingDegree != 1 || x.TopOwner != "alice" ||
+		x.TopOwnerShare != 0.75 {
+		t.Fatalf("unexpected a/x.go before: %+v", x)
+	}
+	x1 := report.Added[1]
+	if x1.Lines != 40 || x1.Commits != 3 || x1.CouplingDegree != 2 || x1.TopOwnerShare != 0.75 {
+		t.Fatalf("unexpected a/x1.go: %+v", x1)
+	}
+	if z := report.Added[2]; z.CouplingDegree != 2 || z.Lines != 50 {
+		t.Fatalf("unexpected a/z.go: %+v", z)
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writeWhatIfReport(buffer, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := buffer.String()
+	for _, expected := range []string{
+		"what_if:\n  repository: repo\n  before:\n    files: 4\n",
+		"  - path: a/x1.go\n    lines: 40\n    commits: 3\n    coupling_degree: 2\n",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Fatalf("%q is missing in:\n%s", expected, text)
+		}
+	}
+}
+
+func TestWhatIfRenameJoinsFiles(t *testing.T) {
+	files := whatIfStructure{
+		"x": {Lines: 10, Commits: 5, Couples: map[string]int64{"y": 2}, Owners: map[int]int{0: 10}},
+		"y": {Lines: 4, Commits: 3, Couples: map[string]int64{"x": 2, "z": 1}, Owners: map[int]int{1: 4}},
+		"z": {Lines: 1, Commits: 1, Couples: map[string]int64{"y": 1}, Owners: map[int]int{1: 1}},
+	}
+	if err := files.merge(whatIfMerge{From: "y", Into: "x"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x := files["x"]
+	if len(files) != 2 || x.Lines != 14 || x.Commits != 6 || len(x.Couples) != 1 || x.Couples["z"] != 1 ||
+		x.Owners[1] != 4 || files["z"].Couples["x"] != 1 || len(files["z"].Couples) != 1 {
+		t.Fatalf("unexpected structure: %+v %+v", x, files["z"])
+	}
+}
+
+func TestBuildWhatIfReportErrors(t *testing.T) {
+	message := fakeOwnershipPlanResults(t)
+	for _, manifest := range []*whatIfManifest{
+		{Split: []whatIfSplit{{File: "nope.go", Into: []whatIfRange{{File: "a.go", Lines: [2]int{1, 2}}}}}},
+		{Split: []whatIfSplit{{File: "a/y.go", Into: []whatIfRange{{File: "a/y1.go", Lines: [2]int{1, 30}}}}}},
+		{Split: []whatIfSplit{{File: "a/x.go", Into: []whatIfRange{
+			{File: "a/x1.go", Lines: [2]int{1, 30}}, {File: "a/x2.go", Lines: [2]int{30, 40}}}}}},
+		{Split: []whatIfSplit{{File: "a/x.go", Into: []whatIfRange{{File: "a/y.go", Lines: [2]int{1, 2}}}}}},
+		{Merge: []whatIfMerge{{From: "nope", Into: "a"}}},
+	} {
+		if _, err := buildWhatIfReport(message, manifest); err == nil {
+			t.Fatalf("expected an error for %+v", manifest)
+		}
+	}
+	delete(message.Contents, "Couples")
+	if _, err := buildWhatIfReport(message, &whatIfManifest{}); err == nil {
+		t.Fatal("expected an error without Couples")
+	}
+}
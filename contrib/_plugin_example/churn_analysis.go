@@ -302,6 +302,7 @@ func editsToEditsMessage(edits Edits) *EditsMessage {
 	return message
 }
 
-func init() {
-	hercules.Registry.Register(&ChurnAnalysis{})
+// RegisterPipelineItems is called by Hercules after loading the plugin with --plugin.
+func RegisterPipelineItems(registry *hercules.PipelineItemRegistry) {
+	registry.Register(&ChurnAnalysis{})
 }
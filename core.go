@@ -1,6 +1,8 @@
 package hercules
 
 import (
+	"context"
+
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/meko-christian/hercules/internal/core"
@@ -39,18 +41,97 @@ type PipelineItem = core.PipelineItem
 // FeaturedPipelineItem enables switching the automatic insertion of pipeline items on or off.
 type FeaturedPipelineItem = core.FeaturedPipelineItem
 
+// ConflictingPipelineItem declares the features and the items which must not be used together with it.
+type ConflictingPipelineItem = core.ConflictingPipelineItem
+
 // LeafPipelineItem corresponds to the top level pipeline items which produce the end results.
 type LeafPipelineItem = core.LeafPipelineItem
 
+// CheckpointablePipelineItem is the PipelineItem which saves its state to Pipeline.Checkpoint.
+type CheckpointablePipelineItem = core.CheckpointablePipelineItem
+
+// CostedPipelineItem is the LeafPipelineItem which reports how expensive it is to run.
+type CostedPipelineItem = core.CostedPipelineItem
+
+// CostClass is the approximate per-commit run time category of a LeafPipelineItem.
+type CostClass = core.CostClass
+
+const (
+	// CostCheap marks the analyses which do not look into the file contents.
+	CostCheap = core.CostCheap
+	// CostMedium marks the analyses which diff the changed files.
+	CostMedium = core.CostMedium
+	// CostHeavy marks the analyses which track every line or parse the code.
+	CostHeavy = core.CostHeavy
+)
+
+// GetCost returns the CostClass of the item or CostMedium if the item does not report it.
+func GetCost(item PipelineItem) CostClass {
+	return core.GetCost(item)
+}
+
+// ConcurrentPipelineItem is the PipelineItem which declares its ThreadSafety.
+type ConcurrentPipelineItem = core.ConcurrentPipelineItem
+
+// ThreadSafety tells whether the instances of a PipelineItem in different pipelines may run
+// at the same time.
+type ThreadSafety = core.ThreadSafety
+
+const (
+	// ThreadUnsafe marks the items whose instances share some state.
+	ThreadUnsafe = core.ThreadUnsafe
+	// ThreadSafe marks the items whose instances keep all the state to themselves.
+	ThreadSafe = core.ThreadSafe
+)
+
+// GetThreadSafety returns the ThreadSafety of the item or ThreadUnsafe if the item does not
+// declare it.
+func GetThreadSafety(item PipelineItem) ThreadSafety {
+	return core.GetThreadSafety(item)
+}
+
+// ItemLocks serializes the calls of the ThreadUnsafe items across the concurrent pipelines.
+type ItemLocks = core.ItemLocks
+
 // ResultMergeablePipelineItem specifies the methods to combine several analysis results together.
 type ResultMergeablePipelineItem = core.ResultMergeablePipelineItem
 
+// FilterablePipelineItem is the LeafPipelineItem whose results can be trimmed at serialization time.
+type FilterablePipelineItem = core.FilterablePipelineItem
+
+// OutputFilter trims the result of an analysis before it is written.
+type OutputFilter = core.OutputFilter
+
+// Identity is the developer identity parsed from the results.
+type Identity = core.Identity
+
+// ParseIdentity splits the developer identity written in any of the people display formats.
+func ParseIdentity(identity string) Identity {
+	return core.ParseIdentity(identity)
+}
+
+// PathAnonymizablePipelineItem is the LeafPipelineItem whose results can have the file paths
+// anonymized at serialization time.
+type PathAnonymizablePipelineItem = core.PathAnonymizablePipelineItem
+
+// PathAnonymizer replaces the file and directory names with pseudonyms.
+type PathAnonymizer = core.PathAnonymizer
+
+// NewPathAnonymizer creates a new PathAnonymizer in "hash" or "bucket" mode.
+func NewPathAnonymizer(mode, salt string) (*PathAnonymizer, error) {
+	return core.NewPathAnonymizer(mode, salt)
+}
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult = core.CommonAnalysisResult
 
 // NoopMerger provides an empty Merge() method suitable for PipelineItem.
 type NoopMerger = core.NoopMerger
 
+// NoopCheckpointer provides empty Checkpoint() and Restore() methods suitable for the stateless
+// PipelineItem-s.
+type NoopCheckpointer = core.NoopCheckpointer
+
 // OneShotMergeProcessor provides the convenience method to consume merges only once.
 type OneShotMergeProcessor = core.OneShotMergeProcessor
 
@@ -63,10 +144,37 @@ func MetadataToCommonAnalysisResult(meta *core.Metadata) *CommonAnalysisResult {
 // See the extended example of how a Pipeline works in doc.go
 type Pipeline = core.Pipeline
 
+// ProgressEvent describes the pipeline state after executing a single step of the run plan.
+type ProgressEvent = core.ProgressEvent
+
+// ProgressReporter receives detailed progress updates from Pipeline.Run().
+type ProgressReporter = core.ProgressReporter
+
+// ProgressReporterFunc adapts an ordinary function to ProgressReporter.
+type ProgressReporterFunc = core.ProgressReporterFunc
+
+// GraphFormat is the output format of Pipeline.ExportGraph().
+type GraphFormat = core.GraphFormat
+
+// PipelineGraph is the resolved DAG of the deployed items returned by Pipeline.Graph().
+type PipelineGraph = core.PipelineGraph
+
+const (
+	// GraphFormatDOT is the Graphviz format.
+	GraphFormatDOT = core.GraphFormatDOT
+	// GraphFormatMermaid is the Mermaid flowchart format.
+	GraphFormatMermaid = core.GraphFormatMermaid
+	// GraphFormatJSON is the JSON serialization of PipelineGraph.
+	GraphFormatJSON = core.GraphFormatJSON
+)
+
 const (
 	// ConfigPipelineDAGPath is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which enables saving the items DAG to the specified file.
 	ConfigPipelineDAGPath = core.ConfigPipelineDAGPath
+	// ConfigPipelineDAGFormat is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which selects the GraphFormat of the DAG saved to ConfigPipelineDAGPath.
+	ConfigPipelineDAGFormat = core.ConfigPipelineDAGFormat
 	// ConfigPipelineDumpPlan is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which outputs the execution plan to stderr.
 	ConfigPipelineDumpPlan = core.ConfigPipelineDumpPlan
@@ -78,6 +186,30 @@ const (
 	// ConfigPipelineCommits is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which allows to specify the custom commit sequence. By default, Pipeline.Commits() is used.
 	ConfigPipelineCommits = core.ConfigPipelineCommits
+	// ConfigPipelineMainlineOnly is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which collapses the side branches into their merge commits in the run plan.
+	ConfigPipelineMainlineOnly = core.ConfigPipelineMainlineOnly
+	// ConfigPipelineCommitStride is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which analyses only every Nth mainline commit.
+	ConfigPipelineCommitStride = core.ConfigPipelineCommitStride
+	// ConfigPipelineCommitTimeout is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which limits the duration of each PipelineItem.Consume() call.
+	ConfigPipelineCommitTimeout = core.ConfigPipelineCommitTimeout
+	// ConfigPipelinePlanCache is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the cached run plan.
+	ConfigPipelinePlanCache = core.ConfigPipelinePlanCache
+	// ConfigPipelineCheckpoint is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the checkpoint of the cancelled run.
+	ConfigPipelineCheckpoint = core.ConfigPipelineCheckpoint
+	// ConfigPipelineResume is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which continues the run from the checkpoint.
+	ConfigPipelineResume = core.ConfigPipelineResume
+	// ConfigPipelinePolicy is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the Policy file.
+	ConfigPipelinePolicy = core.ConfigPipelinePolicy
+	// ConfigPipelineCaller is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which identifies the caller in the Policy file.
+	ConfigPipelineCaller = core.ConfigPipelineCaller
 	// ConfigTickSize is the number of hours per 'tick'
 	ConfigTickSize = plumbing.ConfigTicksSinceStartTickSize
 	// ConfigLogger is used to set the logger in all pipeline items.
@@ -89,6 +221,30 @@ func NewPipeline(repository *git.Repository) *Pipeline {
 	return core.NewPipeline(repository)
 }
 
+// MultiRepoRunner runs the same pipeline configuration over several repositories and merges
+// the results.
+type MultiRepoRunner = core.MultiRepoRunner
+
+// Policy restricts which analyses, repositories and output data are permitted per caller.
+type Policy = core.Policy
+
+// LoadPolicy reads the policy from the YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	return core.LoadPolicy(path)
+}
+
+// NewWorktreeRepository opens the same repository with the storage which keeps the new objects
+// in memory, so that CommitWorktree() does not write to the disk.
+func NewWorktreeRepository(repository *git.Repository) (*git.Repository, error) {
+	return core.NewWorktreeRepository(repository)
+}
+
+// CommitWorktree creates the synthetic commit on top of HEAD with the uncommitted changes
+// in the worktree. It returns nil if the worktree is clean.
+func CommitWorktree(repository *git.Repository) (*object.Commit, error) {
+	return core.CommitWorktree(repository)
+}
+
 // LoadCommitsFromFile reads the file by the specified FS path and generates the sequence of commits
 // by interpreting each line as a Git commit hash.
 func LoadCommitsFromFile(path string, repository *git.Repository) ([]*object.Commit, error) {
@@ -105,12 +261,25 @@ func ForkCopyPipelineItem(origin PipelineItem, n int) []PipelineItem {
 	return core.ForkCopyPipelineItem(origin, n)
 }
 
+// Deprecation maps the old name of a command line flag or a fact to the current one.
+type Deprecation = core.Deprecation
+
+// DeprecationRegistry keeps the renamed flags and facts working with warnings.
+type DeprecationRegistry = core.DeprecationRegistry
+
+// Deprecations contains all the known renamed flags and facts.
+var Deprecations = core.Deprecations
+
 // PipelineItemRegistry contains all the known PipelineItem-s.
 type PipelineItemRegistry = core.PipelineItemRegistry
 
 // Registry contains all known pipeline item types.
 var Registry = core.Registry
 
+// PluginRegisterSymbol is the name of the function which Go plugins loaded by
+// PipelineItemRegistry.LoadPlugin() export to register their items.
+const PluginRegisterSymbol = core.PluginRegisterSymbol
+
 const (
 	// DependencyCommit is the name of one of the three items in `deps` supplied to PipelineItem.Consume()
 	// which always exists. It corresponds to the currently analyzed commit.
@@ -122,6 +291,9 @@ const (
 	// which always exists. It indicates whether the analyzed commit is a merge commit.
 	// Checking the number of parents is not correct - we remove the back edges during the DAG simplification.
 	DependencyIsMerge = core.DependencyIsMerge
+	// DependencyContext is the name of the item in `deps` supplied to PipelineItem.Consume()
+	// which carries the context.Context of Pipeline.RunContext().
+	DependencyContext = core.DependencyContext
 	// DependencyAuthor is the name of the dependency provided by identity.PeopleDetector.
 	DependencyAuthor = identity.DependencyAuthor
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
@@ -148,6 +320,12 @@ type FileDiffData = plumbing.FileDiffData
 // Such structs are returned by DependencyBlobCache.
 type CachedBlob = plumbing.CachedBlob
 
+// ContextFromDeps returns the context.Context passed to PipelineItem.Consume() or
+// context.Background() if there is none.
+func ContextFromDeps(deps map[string]interface{}) context.Context {
+	return core.ContextFromDeps(deps)
+}
+
 // SafeYamlString escapes the string so that it can be reliably used in YAML.
 func SafeYamlString(str string) string {
 	return yaml.SafeString(str)
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+// checkpointVersion is incremented each time the format of the checkpoints changes.
+const checkpointVersion = 1
+
+// checkpoint is the file format of saveCheckpoint() and loadCheckpoint().
+type checkpoint struct {
+	Version int
+	// Key identifies the run plan and the deployed items, see checkpointKey().
+	Key string
+	// Step is the index of the first action of the run plan which was not executed.
+	Step int
+	// Commit is the last consumed commit.
+	Commit         plumbing.Hash
+	CommitIndex    int
+	NewestTime     int64
+	RunTime        time.Duration
+	RunTimePerItem map[string]float64
+	// Items are the states of Pipeline.items in the same order.
+	Items [][]byte
+}
+
+// NoopCheckpointer provides empty Checkpoint() and Restore() methods suitable for
+// the PipelineItem-s which keep no state between the commits.
+type NoopCheckpointer struct{}
+
+// Checkpoint does nothing.
+func (*NoopCheckpointer) Checkpoint(io.Writer) error {
+	return nil
+}
+
+// Restore does nothing.
+func (*NoopCheckpointer) Restore(io.Reader) error {
+	return nil
+}
+
+// checkpointKey identifies the run plan and the items which execute it.
+func (pipeline *Pipeline) checkpointKey(plan []runAction) string {
+	hasher := sha1.New()
+	_, _ = fmt.Fprintf(hasher, "%d\n", checkpointVersion)
+	for _, item := range pipeline.items {
+		_, _ = fmt.Fprintln(hasher, item.Name())
+	}
+	for _, action := range plan {
+		_, _ = fmt.Fprintf(hasher, "%d %v ", action.Action, action.Items)
+		if action.Commit != nil {
+			_, _ = hasher.Write(action.Commit.Hash[:])
+		}
+		_, _ = hasher.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// uncheckpointableItems returns the names of the items which cannot save their state,
+// including the abandoned ones.
+func uncheckpointableItems(items []PipelineItem) []string {
+	var names []string
+	for _, item := range items {
+		if _, ok := item.(CheckpointablePipelineItem); !ok {
+			names = append(names, item.Name())
+		}
+	}
+	return names
+}
+
+// saveCheckpoint writes the states of the items of the main branch together with the position
+// in the run plan to Pipeline.Checkpoint.
+func (pipeline *Pipeline) saveCheckpoint(cp *checkpoint, plan []runAction, items []PipelineItem) error {
+	if names := uncheckpointableItems(items); len(names) > 0 {
+		return fmt.Errorf("%s cannot save the state", strings.Join(names, ", "))
+	}
+	cp.Version = checkpointVersion
+	cp.Key = pipeline.checkpointKey(plan)
+	cp.Items = make([][]byte, len(items))
+	for i, item := range items {
+		var buffer bytes.Buffer
+		if err := item.(CheckpointablePipelineItem).Checkpoint(&buffer); err != nil {
+			return errors.Wrapf(err, "%s failed to save the state", item.Name())
+		}
+		cp.Items[i] = buffer.Bytes()
+	}
+	if err := saveGob(pipeline.Checkpoint, cp); err != nil {
+		return errors.Wrap(err, "failed to save the checkpoint")
+	}
+	return nil
+}
+
+// loadCheckpoint reads Pipeline.Checkpoint which saveCheckpoint() wrote and restores
+// the states of Pipeline.items. It fails with ErrCheckpointMismatch if the checkpoint
+// belongs to a different run plan.
+func (pipeline *Pipeline) loadCheckpoint(plan []runAction) (*checkpoint, error) {
+	file, err := os.Open(pipeline.Checkpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resume")
+	}
+	defer file.Close()
+	var cp checkpoint
+	if err = gob.NewDecoder(bufio.NewReader(file)).Decode(&cp); err != nil {
+		return nil, errors.Wrapf(ErrCheckpointMismatch, "%s is corrupted: %v", pipeline.Checkpoint, err)
+	}
+	if cp.Version != checkpointVersion || cp.Key != pipeline.checkpointKey(plan) ||
+		len(cp.Items) != len(pipeline.items) {
+		return nil, errors.Wrap(ErrCheckpointMismatch, pipeline.Checkpoint)
+	}
+	for i, item := range pipeline.items {
+		casted, ok := item.(CheckpointablePipelineItem)
+		if !ok {
+			return nil, fmt.Errorf("%s cannot restore the state", item.Name())
+		}
+		if err = casted.Restore(bytes.NewReader(cp.Items[i])); err != nil {
+			return nil, errors.Wrapf(err, "%s failed to restore the state", item.Name())
+		}
+	}
+	if cp.RunTimePerItem == nil {
+		cp.RunTimePerItem = map[string]float64{}
+	}
+	return &cp, nil
+}
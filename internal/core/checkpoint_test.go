@@ -0,0 +1,120 @@
+package core
+
+import (
+	"context"
+	"encoding/gob"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// checkpointTestLeaf records the consumed commits and cancels the run after Limit of them.
+type checkpointTestLeaf struct {
+	countingTestLeaf
+	Cancel context.CancelFunc
+	Limit  int
+	Hashes []plumbing.Hash
+}
+
+func (leaf *checkpointTestLeaf) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if ContextFromDeps(deps).Err() != nil {
+		return nil, errors.New("consumed after the cancellation")
+	}
+	leaf.Hashes = append(leaf.Hashes, deps[DependencyCommit].(*object.Commit).Hash)
+	if len(leaf.Hashes) == leaf.Limit {
+		leaf.Cancel()
+	}
+	return nil, nil
+}
+
+func (leaf *checkpointTestLeaf) Fork(n int) []PipelineItem { return ForkSamePipelineItem(leaf, n) }
+func (leaf *checkpointTestLeaf) Finalize() interface{}     { return leaf.Hashes }
+
+func (leaf *checkpointTestLeaf) Checkpoint(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(leaf.Hashes)
+}
+
+func (leaf *checkpointTestLeaf) Restore(reader io.Reader) error {
+	return gob.NewDecoder(reader).Decode(&leaf.Hashes)
+}
+
+func runCheckpointPipeline(t *testing.T, commits []*object.Commit, limit int, facts map[string]interface{},
+) (*Pipeline, *checkpointTestLeaf, map[LeafPipelineItem]interface{}, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	leaf := &checkpointTestLeaf{Cancel: cancel, Limit: limit}
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(leaf)
+	require.NoError(t, pipeline.Initialize(facts))
+	result, err := pipeline.RunContext(ctx, commits)
+	return pipeline, leaf, result, err
+}
+
+func TestPipelineCheckpoint(t *testing.T) {
+	commits := makePlanCacheCommits()
+	_, _, full, err := runCheckpointPipeline(t, commits, -1, map[string]interface{}{})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	facts := map[string]interface{}{ConfigPipelineCheckpoint: path}
+
+	// the run finishes the branches before it saves the checkpoint after the merge
+	pipeline, leaf, result, err := runCheckpointPipeline(t, commits, 2, facts)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.True(t, pipeline.SavedCheckpoint())
+	assert.True(t, result[nil].(*CommonAnalysisResult).Cancelled)
+	assert.Equal(t, 4, result[nil].(*CommonAnalysisResult).CommitsNumber)
+	assert.Equal(t, 6, full[nil].(*CommonAnalysisResult).CommitsNumber)
+	assert.Len(t, leaf.Hashes, 4)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	facts[ConfigPipelineResume] = true
+	pipeline, _, result, err = runCheckpointPipeline(t, commits, -1, facts)
+	require.NoError(t, err)
+	assert.False(t, pipeline.SavedCheckpoint())
+	var fullLeaf, resumedLeaf interface{}
+	for item, value := range full {
+		if item != nil {
+			fullLeaf = value
+		}
+	}
+	for item, value := range result {
+		if item != nil {
+			resumedLeaf = value
+		}
+	}
+	assert.Equal(t, fullLeaf, resumedLeaf)
+	assert.Equal(t, 6, result[nil].(*CommonAnalysisResult).CommitsNumber)
+
+	// the checkpoint belongs to a different run plan
+	_, _, _, err = runCheckpointPipeline(t, commits[:5], -1, facts)
+	assert.True(t, errors.Is(err, ErrCheckpointMismatch))
+}
+
+func TestPipelineCheckpointUnsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	pipeline := NewPipeline(test.Repository)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipeline.AddItem(&cancellingTestPipelineItem{Cancel: cancel, Limit: 2})
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{ConfigPipelineCheckpoint: path}))
+	assert.Equal(t, path, pipeline.Checkpoint)
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	_, err = pipeline.RunContext(ctx, commits[:5])
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, pipeline.SavedCheckpoint())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+
+	pipeline = NewPipeline(test.Repository)
+	assert.Error(t, pipeline.Initialize(map[string]interface{}{ConfigPipelineResume: true}))
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// Deprecation maps the old name of a command line flag or a fact to the current one.
+type Deprecation struct {
+	// Old is the deprecated name.
+	Old string
+	// New is the current name.
+	New string
+	// Removal is the release or the date after which Old stops working. It is shown in the warnings.
+	Removal string
+}
+
+// DeprecationRegistry keeps the renamed flags and facts working with warnings, so that the saved
+// command lines and the facts of the library users do not break right away.
+type DeprecationRegistry struct {
+	flags map[string]Deprecation
+	facts map[string]Deprecation
+}
+
+// Deprecations contains all the known renamed flags and facts.
+var Deprecations = &DeprecationRegistry{}
+
+// RenameFlag registers the command line flag which was renamed from `old` to `new`.
+func (registry *DeprecationRegistry) RenameFlag(old, new, removal string) {
+	if registry.flags == nil {
+		registry.flags = map[string]Deprecation{}
+	}
+	registry.flags[old] = Deprecation{Old: old, New: new, Removal: removal}
+}
+
+// RenameFact registers the fact, usually a ConfigurationOption.Name, which was renamed from `old`
+// to `new`.
+func (registry *DeprecationRegistry) RenameFact(old, new, removal string) {
+	if registry.facts == nil {
+		registry.facts = map[string]Deprecation{}
+	}
+	registry.facts[old] = Deprecation{Old: old, New: new, Removal: removal}
+}
+
+// Flags returns the renamed flags sorted by the old name.
+func (registry *DeprecationRegistry) Flags() []Deprecation {
+	return sortDeprecations(registry.flags)
+}
+
+// Facts returns the renamed facts sorted by the old name.
+func (registry *DeprecationRegistry) Facts() []Deprecation {
+	return sortDeprecations(registry.facts)
+}
+
+func sortDeprecations(deprecations map[string]Deprecation) []Deprecation {
+	result := make([]Deprecation, 0, len(deprecations))
+	for _, deprecation := range deprecations {
+		result = append(result, deprecation)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Old < result[j].Old
+	})
+	return result
+}
+
+// AddFlagAliases inserts the hidden aliases of the renamed flags into the flag set. An alias shares
+// the value with the current flag and pflag prints the deprecation warning when it is used.
+// The flags which are not in the set, e.g. because their analysis is not built, are skipped.
+func (registry *DeprecationRegistry) AddFlagAliases(flagSet *pflag.FlagSet) {
+	for _, deprecation := range registry.Flags() {
+		current := flagSet.Lookup(deprecation.New)
+		if current == nil || flagSet.Lookup(deprecation.Old) != nil {
+			continue
+		}
+		flagSet.AddFlag(&pflag.Flag{
+			Name:        deprecation.Old,
+			Usage:       current.Usage,
+			Value:       current.Value,
+			DefValue:    current.DefValue,
+			NoOptDefVal: current.NoOptDefVal,
+			Annotations: current.Annotations,
+			Hidden:      true,
+			Deprecated: fmt.Sprintf("use --%s instead, the old name will be removed after %s",
+				deprecation.New, deprecation.Removal),
+		})
+	}
+}
+
+// ResolveFlagAliases marks the current flags as changed if their aliases were passed, so that
+// flagSet.Changed() is true for both names. It must be called after parsing the command line.
+func (registry *DeprecationRegistry) ResolveFlagAliases(flagSet *pflag.FlagSet) {
+	for _, deprecation := range registry.Flags() {
+		alias, current := flagSet.Lookup(deprecation.Old), flagSet.Lookup(deprecation.New)
+		if alias != nil && current != nil && alias.Changed {
+			current.Changed = true
+		}
+	}
+}
+
+// UpgradeFacts renames the deprecated facts to the current names in-place and returns
+// the warnings. If both names are present, the current one wins.
+func (registry *DeprecationRegistry) UpgradeFacts(facts map[string]interface{}) []string {
+	var warnings []string
+	for _, deprecation := range registry.Facts() {
+		value, exists := facts[deprecation.Old]
+		if !exists {
+			continue
+		}
+		delete(facts, deprecation.Old)
+		if _, exists := facts[deprecation.New]; exists {
+			warnings = append(warnings, fmt.Sprintf(
+				"ignored the deprecated fact %s because %s is set", deprecation.Old, deprecation.New))
+			continue
+		}
+		facts[deprecation.New] = value
+		warnings = append(warnings, fmt.Sprintf(
+			"the fact %s is deprecated, use %s instead; the old name will be removed after %s",
+			deprecation.Old, deprecation.New, deprecation.Removal))
+	}
+	return warnings
+}
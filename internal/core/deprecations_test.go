@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationsFlagAliases(t *testing.T) {
+	registry := &DeprecationRegistry{}
+	registry.RenameFlag("old-threshold", "threshold", "2027-01")
+	registry.RenameFlag("old-switch", "switch", "2027-01")
+	registry.RenameFlag("old-missing", "missing", "2027-01")
+	assert.Equal(t, []Deprecation{
+		{Old: "old-missing", New: "missing", Removal: "2027-01"},
+		{Old: "old-switch", New: "switch", Removal: "2027-01"},
+		{Old: "old-threshold", New: "threshold", Removal: "2027-01"},
+	}, registry.Flags())
+
+	flagSet := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	threshold := flagSet.Int("threshold", 10, "threshold")
+	enabled := flagSet.Bool("switch", false, "switch")
+	registry.AddFlagAliases(flagSet)
+	assert.Nil(t, flagSet.Lookup("old-missing"))
+	alias := flagSet.Lookup("old-threshold")
+	require.NotNil(t, alias)
+	assert.Equal(t, "10", alias.DefValue)
+	assert.True(t, alias.Hidden)
+	assert.Contains(t, alias.Deprecated, "use --threshold instead")
+
+	require.NoError(t, flagSet.Parse([]string{"--old-threshold=20", "--old-switch"}))
+	assert.Equal(t, 20, *threshold)
+	assert.True(t, *enabled)
+	assert.False(t, flagSet.Changed("threshold"))
+	registry.ResolveFlagAliases(flagSet)
+	assert.True(t, flagSet.Changed("threshold"))
+	assert.True(t, flagSet.Changed("switch"))
+}
+
+func TestDeprecationsUpgradeFacts(t *testing.T) {
+	registry := &DeprecationRegistry{}
+	assert.Empty(t, registry.UpgradeFacts(map[string]interface{}{"Old.Fact": 1}))
+	registry.RenameFact("Old.Fact", "New.Fact", "2027-01")
+	assert.Equal(t, []Deprecation{{Old: "Old.Fact", New: "New.Fact", Removal: "2027-01"}},
+		registry.Facts())
+
+	facts := map[string]interface{}{"Old.Fact": 1, "Other": 2}
+	warnings := registry.UpgradeFacts(facts)
+	assert.Equal(t, map[string]interface{}{"New.Fact": 1, "Other": 2}, facts)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "use New.Fact instead")
+
+	facts = map[string]interface{}{"Old.Fact": 1, "New.Fact": 3}
+	warnings = registry.UpgradeFacts(facts)
+	assert.Equal(t, map[string]interface{}{"New.Fact": 3}, facts)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "ignored")
+}
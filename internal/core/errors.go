@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ErrConsumeTimeout is the PipelineError.Err of the Consume() calls which exceeded
+// Pipeline.CommitTimeout.
+var ErrConsumeTimeout = errors.New("Consume() exceeded the commit timeout")
+
+// ErrPlanCacheMismatch is returned by Pipeline.LoadPlan() if the cached run plan was prepared
+// for a different repository state or with different options.
+var ErrPlanCacheMismatch = errors.New("the cached run plan does not match")
+
+// ErrCheckpointMismatch is returned by Pipeline.Run() with Pipeline.Resume if the checkpoint
+// was saved for a different run plan or with different items.
+var ErrCheckpointMismatch = errors.New("the checkpoint does not match")
+
+// PipelineError describes the failure of a PipelineItem during Pipeline.Run().
+type PipelineError struct {
+	// Item is the name of the failed PipelineItem.
+	Item string
+	// Commit is the hash of the commit which was being processed. It is zero if the failure
+	// happened outside of Consume(), e.g. in Hibernate().
+	Commit plumbing.Hash
+	// CommitIndex is the zero-based index of the commit in the analysed sequence.
+	CommitIndex int
+	// Branch is the index of the branch in the run plan.
+	Branch int
+	// Step is the zero-based index of the action in the run plan.
+	Step int
+	// Err is the original error.
+	Err error
+}
+
+// Error formats the failure with the full context.
+func (e *PipelineError) Error() string {
+	if e.Commit.IsZero() {
+		return fmt.Sprintf("%s failed at step %d on branch %d: %v", e.Item, e.Step+1, e.Branch, e.Err)
+	}
+	return fmt.Sprintf("%s failed on commit #%d (step %d, branch %d) %s: %v",
+		e.Item, e.CommitIndex+1, e.Step+1, e.Branch, e.Commit.String(), e.Err)
+}
+
+// Unwrap returns the original error for errors.Is() and errors.As().
+func (e *PipelineError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the original error for github.com/pkg/errors.Cause().
+func (e *PipelineError) Cause() error {
+	return e.Err
+}
@@ -0,0 +1,25 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineErrorFormat(t *testing.T) {
+	cause := errors.New("boom")
+	err := &PipelineError{
+		Item: "Burndown", Commit: plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c"),
+		CommitIndex: 4, Branch: 2, Step: 9, Err: cause,
+	}
+	assert.Equal(t, "Burndown failed on commit #5 (step 10, branch 2) "+
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c: boom", err.Error())
+	assert.True(t, errors.Is(err, cause))
+	assert.Equal(t, cause, pkgerrors.Cause(err))
+
+	err = &PipelineError{Item: "Burndown", Branch: 3, Step: 0, Err: cause}
+	assert.Equal(t, "Burndown failed at step 1 on branch 3: boom", err.Error())
+}
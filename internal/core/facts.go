@@ -10,9 +10,27 @@ type (
 	TickNumber int32
 )
 
+// LineKind is the classification of a source code line, see plumbing.ClassifyLines().
+type LineKind uint8
+
+const (
+	// LineKindCode is a line with code, including the lines which mix code with a comment.
+	LineKindCode LineKind = iota
+	// LineKindComment is a line which contains only a comment.
+	LineKindComment
+	// LineKindBlank is an empty or whitespace-only line.
+	LineKindBlank
+)
+
 const (
 	FactIdentityResolver    = "Identity.Resolver"
 	FactLineHistoryResolver = "LineHistory.Resolver"
+	// FactTickMode is the calendar alignment of the ticks, empty if they have the fixed size.
+	// Pipeline copies it to CommonAnalysisResult.TickMode.
+	FactTickMode = "Ticks.Mode"
+	// FactFiscalYearStart is the first month of the fiscal year which the calendar ticks follow,
+	// 0 if it does not matter. Pipeline copies it to CommonAnalysisResult.FiscalYearStart.
+	FactFiscalYearStart = "Ticks.FiscalYearStart"
 )
 
 const (
@@ -109,6 +127,9 @@ type LineHistoryChange struct {
 	CurrTick, PrevTick     TickNumber
 	CurrAuthor, PrevAuthor AuthorId
 	Delta                  int
+	// Kind is the kind of all the inserted or removed lines. It is always LineKindCode
+	// unless the lines are classified, see plumbing.ConfigLinesStatsClassifyLines.
+	Kind LineKind
 }
 
 func (v LineHistoryChange) IsDelete() bool {
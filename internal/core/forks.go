@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"math"
@@ -37,6 +38,22 @@ func (proc *OneShotMergeProcessor) ShouldConsumeCommit(deps map[string]interface
 	return false
 }
 
+// ConsumedMerges returns the merge commits which ShouldConsumeCommit() has accepted.
+func (proc *OneShotMergeProcessor) ConsumedMerges() []plumbing.Hash {
+	hashes := make([]plumbing.Hash, 0, len(proc.merges))
+	for hash := range proc.merges {
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+// RestoreMerges marks the merge commits which ConsumedMerges() returned as consumed.
+func (proc *OneShotMergeProcessor) RestoreMerges(hashes []plumbing.Hash) {
+	for _, hash := range hashes {
+		proc.merges[hash] = struct{}{}
+	}
+}
+
 // NoopMerger provides an empty Merge() method suitable for PipelineItem.
 type NoopMerger struct{}
 
@@ -146,6 +163,52 @@ func mergeItems(branches [][]PipelineItem) {
 	}
 }
 
+// abandonedPipelineItem takes the place of the item whose Consume() exceeded
+// Pipeline.CommitTimeout with Pipeline.ContinueOnError. The stuck call goes on in the background
+// and still owns the item, so the placeholder never touches it: the item is not consumed, forked,
+// merged, hibernated, disposed or finalized anymore.
+type abandonedPipelineItem struct {
+	PipelineItem
+}
+
+// Consume is never called by runPlan(): the commits stop at the abandoned items.
+func (item *abandonedPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	return nil, ErrConsumeTimeout
+}
+
+// Fork returns the same placeholder in every branch.
+func (item *abandonedPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+// Merge does nothing.
+func (item *abandonedPipelineItem) Merge(branches []PipelineItem) {
+}
+
+func isAbandoned(item PipelineItem) bool {
+	_, abandoned := item.(*abandonedPipelineItem)
+	return abandoned
+}
+
+// abandonItem returns the branch with the item replaced by abandonedPipelineItem. The branch is
+// copied on write because the root branch shares its array with Pipeline.items.
+func abandonItem(branch []PipelineItem, item PipelineItem) []PipelineItem {
+	for i, other := range branch {
+		if other != item {
+			continue
+		}
+		copied := make([]PipelineItem, len(branch))
+		copy(copied, branch)
+		for j := i; j < len(copied); j++ {
+			if copied[j] == item {
+				copied[j] = &abandonedPipelineItem{item}
+			}
+		}
+		return copied
+	}
+	return branch
+}
+
 // getMasterBranch returns the branch with the smallest index.
 func getMasterBranch(branches map[int][]PipelineItem) []PipelineItem {
 	minKey := 1 << 31
@@ -159,9 +222,13 @@ func getMasterBranch(branches map[int][]PipelineItem) []PipelineItem {
 	return minVal
 }
 
-// prepareRunPlan schedules the actions for Pipeline.Run().
-func prepareRunPlan(commits []*object.Commit, hibernationDistance int, traceback bool,
+// prepareRunPlan schedules the actions for Pipeline.Run(). If `mainlineOnly` is set,
+// the side branches are collapsed into their merge commits first, see collapseSideBranches().
+// If `stride` is greater than 1, only every stride-th mainline commit is left, see strideCommits().
+func prepareRunPlan(commits []*object.Commit, hibernationDistance int, traceback bool, mainlineOnly bool,
+	stride int,
 ) (plan []runAction, mergeHashCount int) {
+	commits = filterRunPlanCommits(commits, mainlineOnly, stride)
 	hashes, dag := buildDag(commits)
 	leaveRootComponent(hashes, dag)
 	mergedDag, mergedSeq := mergeDag(hashes, dag)
@@ -188,6 +255,18 @@ func prepareRunPlan(commits []*object.Commit, hibernationDistance int, traceback
 	return
 }
 
+// filterRunPlanCommits leaves the commits which prepareRunPlan() schedules given `mainlineOnly`
+// and `stride`.
+func filterRunPlanCommits(commits []*object.Commit, mainlineOnly bool, stride int) []*object.Commit {
+	if mainlineOnly || stride > 1 {
+		commits = collapseSideBranches(commits)
+	}
+	if stride > 1 {
+		commits = strideCommits(commits, stride)
+	}
+	return commits
+}
+
 // printAction prints the specified action to stderr.
 func printAction(p runAction) {
 	firstItem := p.Items[0]
@@ -228,6 +307,134 @@ func getCommitParents(commit *object.Commit) []plumbing.Hash {
 	return result
 }
 
+// collapseSideBranches leaves only the commits which are reachable from the heads - the commits
+// without children - through the first parents. The merge commits lose their side parents, so
+// the whole side branch is applied at once as the diff between the first parent and the merge,
+// like a squash. The order of the commits is preserved.
+func collapseSideBranches(commits []*object.Commit) []*object.Commit {
+	byHash := make(map[plumbing.Hash]*object.Commit, len(commits))
+	hasChildren := map[plumbing.Hash]bool{}
+	for _, commit := range commits {
+		byHash[commit.Hash] = commit
+		for _, parent := range commit.ParentHashes {
+			hasChildren[parent] = true
+		}
+	}
+	mainline := make(map[plumbing.Hash]bool, len(commits))
+	for _, head := range commits {
+		if hasChildren[head.Hash] {
+			continue
+		}
+		for commit := head; commit != nil && !mainline[commit.Hash]; {
+			mainline[commit.Hash] = true
+			if len(commit.ParentHashes) == 0 {
+				break
+			}
+			commit = byHash[commit.ParentHashes[0]]
+		}
+	}
+	result := make([]*object.Commit, 0, len(mainline))
+	for _, commit := range commits {
+		if mainline[commit.Hash] {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// strideCommits leaves every stride-th commit of each linear chain together with the roots,
+// the heads, the forks and the merges. The parents of the left commits are replaced with their
+// nearest left ancestors, so the tree diff of each left commit covers all the skipped ones.
+// The order of the commits is preserved.
+func strideCommits(commits []*object.Commit, stride int) []*object.Commit {
+	byHash := make(map[plumbing.Hash]*object.Commit, len(commits))
+	for _, commit := range commits {
+		byHash[commit.Hash] = commit
+	}
+	parents := make(map[plumbing.Hash][]plumbing.Hash, len(commits))
+	children := map[plumbing.Hash]int{}
+	for _, commit := range commits {
+		for _, parent := range getCommitParents(commit) {
+			if _, exists := byHash[parent]; exists {
+				parents[commit.Hash] = append(parents[commit.Hash], parent)
+				children[parent]++
+			}
+		}
+	}
+	// visit the parents before the children
+	order := make([]*object.Commit, 0, len(commits))
+	pending := make(map[plumbing.Hash]int, len(commits))
+	var queue []*object.Commit
+	for _, commit := range commits {
+		pending[commit.Hash] = len(parents[commit.Hash])
+		if pending[commit.Hash] == 0 {
+			queue = append(queue, commit)
+		}
+	}
+	childrenOf := map[plumbing.Hash][]*object.Commit{}
+	for _, commit := range commits {
+		for _, parent := range parents[commit.Hash] {
+			childrenOf[parent] = append(childrenOf[parent], commit)
+		}
+	}
+	for len(queue) > 0 {
+		commit := queue[0]
+		queue = queue[1:]
+		order = append(order, commit)
+		for _, child := range childrenOf[commit.Hash] {
+			pending[child.Hash]--
+			if pending[child.Hash] == 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+	kept := make(map[plumbing.Hash]bool, len(commits)/stride+1)
+	distance := make(map[plumbing.Hash]int, len(commits))
+	for _, commitParents := range parents {
+		if len(commitParents) > 1 {
+			// the merged branches must not collapse into the same parent
+			for _, parent := range commitParents {
+				kept[parent] = true
+			}
+		}
+	}
+	for _, commit := range order {
+		commitParents := parents[commit.Hash]
+		if kept[commit.Hash] || len(commitParents) != 1 || children[commit.Hash] != 1 {
+			kept[commit.Hash] = true
+			continue
+		}
+		distance[commit.Hash] = distance[commitParents[0]] + 1
+		if distance[commit.Hash] >= stride {
+			kept[commit.Hash] = true
+			distance[commit.Hash] = 0
+		}
+	}
+	result := make([]*object.Commit, 0, len(kept))
+	for _, commit := range commits {
+		if !kept[commit.Hash] {
+			continue
+		}
+		var newParents []plumbing.Hash
+		changed := false
+		for _, parent := range parents[commit.Hash] {
+			for !kept[parent] {
+				// the skipped commits always have exactly one parent
+				parent = parents[parent][0]
+				changed = true
+			}
+			newParents = append(newParents, parent)
+		}
+		if changed {
+			clone := *commit
+			clone.ParentHashes = newParents
+			commit = &clone
+		}
+		result = append(result, commit)
+	}
+	return result
+}
+
 // buildDag generates the raw commit DAG and the commit hash map.
 func buildDag(commits []*object.Commit) (
 	map[string]*object.Commit, map[plumbing.Hash][]*object.Commit,
@@ -264,7 +471,13 @@ func leaveRootComponent(
 ) {
 	visited := map[plumbing.Hash]bool{}
 	var sets [][]plumbing.Hash
+	keys := make([]plumbing.Hash, 0, len(dag))
 	for key := range dag {
+		keys = append(keys, key)
+	}
+	// the largest component wins the ties in the order of the hashes
+	sortHashes(keys)
+	for _, key := range keys {
 		if visited[key] {
 			continue
 		}
@@ -356,6 +569,14 @@ func bindOrderNodes(mergedDag map[plumbing.Hash][]*object.Commit) orderer {
 	}
 }
 
+// sortHashes orders the commit hashes lexicographically so that the plan does not depend
+// on the map iteration order.
+func sortHashes(hashes []plumbing.Hash) {
+	sort.Slice(hashes, func(i, j int) bool {
+		return bytes.Compare(hashes[i][:], hashes[j][:]) < 0
+	})
+}
+
 // inverts `dag`
 func buildParents(dag map[plumbing.Hash][]*object.Commit) map[plumbing.Hash]map[plumbing.Hash]bool {
 	parents := map[plumbing.Hash]map[plumbing.Hash]bool{}
@@ -588,7 +809,12 @@ func generatePlan(
 			minBranch := math.MaxInt
 			minBranchIndex := 0
 
+			sortedParents := make([]plumbing.Hash, 0, len(commitParents))
 			for parent := range commitParents {
+				sortedParents = append(sortedParents, parent)
+			}
+			sortHashes(sortedParents)
+			for _, parent := range sortedParents {
 				parentBranch := branchers[commit.Hash][parent]
 				if !branchExists(parentBranch) {
 					if parentBranch = branches[parent]; !branchExists(parentBranch) {
@@ -713,7 +939,10 @@ func collectGarbage(plan []runAction) []runAction {
 		return plan
 	}
 	sort.Slice(lastMentionedArr, func(i, j int) bool {
-		return lastMentionedArr[i][0] < lastMentionedArr[j][0]
+		if lastMentionedArr[i][0] != lastMentionedArr[j][0] {
+			return lastMentionedArr[i][0] < lastMentionedArr[j][0]
+		}
+		return lastMentionedArr[i][1] < lastMentionedArr[j][1]
 	})
 	lastMentionedArr = append(lastMentionedArr, [2]int{len(plan) - 1, -1})
 	prevpi := -1
@@ -705,7 +705,7 @@ func TestPrepareRunPlan(t *testing.T) {
 		b := makeTestCommit("bb", "aa")
 		c := makeTestCommit("cc", "bb")
 
-		plan, mergeCount := prepareRunPlan([]*object.Commit{a, b, c}, 0, false)
+		plan, mergeCount := prepareRunPlan([]*object.Commit{a, b, c}, 0, false, false, 0)
 		assert.NotEmpty(t, plan)
 		assert.Equal(t, 0, mergeCount)
 
@@ -726,7 +726,7 @@ func TestPrepareRunPlan(t *testing.T) {
 		c := makeTestCommit("cc", "aa")
 		d := makeTestCommit("dd", "bb", "cc")
 
-		_, mergeCount := prepareRunPlan([]*object.Commit{a, b, c, d}, 0, true)
+		_, mergeCount := prepareRunPlan([]*object.Commit{a, b, c, d}, 0, true, false, 0)
 		assert.Greater(t, mergeCount, 0)
 	})
 
@@ -734,7 +734,7 @@ func TestPrepareRunPlan(t *testing.T) {
 		a := makeTestCommit("aa")
 		b := makeTestCommit("bb", "aa")
 
-		plan, _ := prepareRunPlan([]*object.Commit{a, b}, 1, false)
+		plan, _ := prepareRunPlan([]*object.Commit{a, b}, 1, false, false, 0)
 		assert.NotEmpty(t, plan)
 	})
 
@@ -744,7 +744,7 @@ func TestPrepareRunPlan(t *testing.T) {
 		c := makeTestCommit("cc", "aa")
 		d := makeTestCommit("dd", "bb", "cc")
 
-		plan, _ := prepareRunPlan([]*object.Commit{a, b, c, d}, 0, false)
+		plan, _ := prepareRunPlan([]*object.Commit{a, b, c, d}, 0, false, false, 0)
 
 		hasFork := false
 		hasMerge := false
@@ -761,6 +761,164 @@ func TestPrepareRunPlan(t *testing.T) {
 	})
 }
 
+func TestCollapseSideBranches(t *testing.T) {
+	t.Run("merged side branch", func(t *testing.T) {
+		a := makeTestCommit("aa")
+		b := makeTestCommit("bb", "aa")
+		c := makeTestCommit("cc", "aa")
+		d := makeTestCommit("dd", "cc")
+		e := makeTestCommit("ee", "bb", "dd")
+		f := makeTestCommit("ff", "ee")
+
+		result := collapseSideBranches([]*object.Commit{a, b, c, d, e, f})
+		assert.Equal(t, []*object.Commit{a, b, e, f}, result)
+	})
+
+	t.Run("linear history is intact", func(t *testing.T) {
+		a := makeTestCommit("aa")
+		b := makeTestCommit("bb", "aa")
+
+		commits := []*object.Commit{a, b}
+		assert.Equal(t, commits, collapseSideBranches(commits))
+	})
+
+	t.Run("unmerged branch is kept", func(t *testing.T) {
+		a := makeTestCommit("aa")
+		b := makeTestCommit("bb", "aa")
+		c := makeTestCommit("cc", "aa")
+
+		commits := []*object.Commit{a, b, c}
+		assert.Equal(t, commits, collapseSideBranches(commits))
+	})
+
+	t.Run("plan has no forks and merges", func(t *testing.T) {
+		a := makeTestCommit("aa")
+		b := makeTestCommit("bb", "aa")
+		c := makeTestCommit("cc", "aa")
+		d := makeTestCommit("dd", "bb", "cc")
+
+		plan, mergeCount := prepareRunPlan([]*object.Commit{a, b, c, d}, 0, true, true, 0)
+		assert.Equal(t, 0, mergeCount)
+		var commits []string
+		for _, p := range plan {
+			assert.NotEqual(t, runActionFork, p.Action)
+			assert.NotEqual(t, runActionMerge, p.Action)
+			if p.Action == runActionCommit {
+				commits = append(commits, p.Commit.Hash.String()[:2])
+			}
+		}
+		assert.Equal(t, []string{"aa", "bb", "dd"}, commits)
+	})
+}
+
+func TestStrideCommits(t *testing.T) {
+	hashes := func(commits []*object.Commit) []string {
+		var result []string
+		for _, commit := range commits {
+			result = append(result, commit.Hash.String()[:2])
+		}
+		return result
+	}
+	parents := func(commit *object.Commit) []string {
+		var result []string
+		for _, parent := range commit.ParentHashes {
+			result = append(result, parent.String()[:2])
+		}
+		return result
+	}
+
+	t.Run("linear history", func(t *testing.T) {
+		commits := []*object.Commit{
+			makeTestCommit("aa"),
+			makeTestCommit("bb", "aa"),
+			makeTestCommit("cc", "bb"),
+			makeTestCommit("dd", "cc"),
+			makeTestCommit("ee", "dd"),
+			makeTestCommit("ff", "ee"),
+		}
+		result := strideCommits(commits, 2)
+		assert.Equal(t, []string{"aa", "cc", "ee", "ff"}, hashes(result))
+		assert.Equal(t, []string{"aa"}, parents(result[1]))
+		assert.Equal(t, []string{"cc"}, parents(result[2]))
+		assert.Equal(t, []string{"ee"}, parents(result[3]))
+		// the original commits are not modified
+		assert.Equal(t, []string{"bb"}, parents(commits[2]))
+		assert.Same(t, commits[5], result[3])
+	})
+
+	t.Run("forks and merges are kept", func(t *testing.T) {
+		commits := []*object.Commit{
+			makeTestCommit("aa"),
+			makeTestCommit("bb", "aa"),
+			makeTestCommit("cc", "bb"),
+			makeTestCommit("dd", "cc"),
+			makeTestCommit("ee", "cc"),
+			makeTestCommit("ff", "dd", "ee"),
+		}
+		result := strideCommits(commits, 5)
+		assert.Equal(t, []string{"aa", "cc", "dd", "ee", "ff"}, hashes(result))
+		assert.Equal(t, []string{"aa"}, parents(result[1]))
+		assert.Equal(t, []string{"dd", "ee"}, parents(result[4]))
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		a := makeTestCommit("aa")
+		b := makeTestCommit("bb", "aa")
+		c := makeTestCommit("cc", "aa")
+		d := makeTestCommit("dd", "bb", "cc")
+		e := makeTestCommit("ee", "dd")
+		f := makeTestCommit("ff", "ee")
+		g := makeTestCommit("17", "ff")
+
+		plan, _ := prepareRunPlan([]*object.Commit{a, b, c, d, e, f, g}, 0, false, false, 2)
+		var commits []string
+		for _, p := range plan {
+			assert.NotEqual(t, runActionFork, p.Action)
+			assert.NotEqual(t, runActionMerge, p.Action)
+			if p.Action == runActionCommit {
+				commits = append(commits, p.Commit.Hash.String()[:2])
+			}
+		}
+		assert.Equal(t, []string{"aa", "dd", "ff", "17"}, commits)
+	})
+}
+
+func TestPrepareRunPlanDeterministic(t *testing.T) {
+	commits := []*object.Commit{
+		makeTestCommit("a1"),
+		makeTestCommit("b2", "a1"),
+		makeTestCommit("c3", "a1"),
+		makeTestCommit("d4", "a1"),
+		makeTestCommit("e5", "b2", "c3", "d4"),
+		makeTestCommit("f6", "e5"),
+		makeTestCommit("17", "e5"),
+		makeTestCommit("28", "c3"),
+		makeTestCommit("39", "f6", "17", "28"),
+		makeTestCommit("4a", "39", "d4"),
+	}
+	var buf bytes.Buffer
+	old := planPrintFunc
+	planPrintFunc = func(args ...interface{}) {
+		fmt.Fprintln(&buf, args...)
+	}
+	defer func() { planPrintFunc = old }()
+	dump := func() string {
+		buf.Reset()
+		plan, _ := prepareRunPlan(commits, 2, true, false, 0)
+		for _, p := range plan {
+			printAction(p)
+		}
+		return buf.String()
+	}
+	expected := dump()
+	assert.Contains(t, expected, "M ")
+	for i := 0; i < 50; i++ {
+		if !assert.Equal(t, expected, dump()) {
+			break
+		}
+	}
+}
+
 func TestInsertHibernateBootNoOp(t *testing.T) {
 	c1 := makeTestCommit("aa")
 	// All branches used consecutively - no hibernation needed
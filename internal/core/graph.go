@@ -0,0 +1,290 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// GraphFormat is the output format of Pipeline.ExportGraph().
+type GraphFormat string
+
+const (
+	// GraphFormatDOT is the Graphviz format.
+	GraphFormatDOT GraphFormat = "dot"
+	// GraphFormatMermaid is the Mermaid flowchart format.
+	GraphFormatMermaid GraphFormat = "mermaid"
+	// GraphFormatJSON is the JSON serialization of PipelineGraph.
+	GraphFormatJSON GraphFormat = "json"
+)
+
+// PipelineGraphItem describes a single deployed PipelineItem in PipelineGraph.
+type PipelineGraphItem struct {
+	// Name is the name of the item, suffixed with the ordinal number if the item is deployed
+	// several times.
+	Name string `json:"name"`
+	// Leaf indicates whether the item is a LeafPipelineItem.
+	Leaf bool `json:"leaf"`
+	// Requires is the list of the consumed entities.
+	Requires []string `json:"requires"`
+	// Provides is the list of the produced entities.
+	Provides []string `json:"provides"`
+	// Features is the list of the feature flags which the item depends on.
+	Features []string `json:"features,omitempty"`
+	// Configuration maps the names of the configuration options to the resolved values.
+	// The default value is reported if the option was not specified.
+	Configuration map[string]interface{} `json:"configuration,omitempty"`
+	// Facts is the list of the facts which the item set in Configure().
+	Facts []string `json:"facts,omitempty"`
+}
+
+// PipelineGraphEdge connects the item which provides an entity with the item which consumes it.
+type PipelineGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Entity is the name of the passed dependency.
+	Entity string `json:"entity"`
+}
+
+// PipelineGraph is the resolved DAG of the deployed items. It is intended to debug why a leaf
+// was or was not scheduled.
+type PipelineGraph struct {
+	// Features is the sorted list of the enabled feature flags.
+	Features []string            `json:"features"`
+	Items    []PipelineGraphItem `json:"items"`
+	Edges    []PipelineGraphEdge `json:"edges"`
+}
+
+// Graph returns the DAG of the items in the execution order. The configuration values and
+// the fact providers are filled only after Initialize().
+func (pipeline *Pipeline) Graph() PipelineGraph {
+	graph := PipelineGraph{
+		Features: []string{},
+		Items:    make([]PipelineGraphItem, 0, len(pipeline.items)),
+		Edges:    []PipelineGraphEdge{},
+	}
+	for feature, enabled := range pipeline.features {
+		if enabled {
+			graph.Features = append(graph.Features, feature)
+		}
+	}
+	sort.Strings(graph.Features)
+
+	itemUsages := map[string]int{}
+	providers := map[string]string{}
+	for _, item := range pipeline.items {
+		name := item.Name()
+		itemUsages[name]++
+		if itemUsages[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, itemUsages[name])
+		}
+		node := PipelineGraphItem{
+			Name:     name,
+			Requires: append([]string{}, item.Requires()...),
+			Provides: append([]string{}, item.Provides()...),
+			Facts:    pipeline.factProviders[item],
+		}
+		if _, ok := item.(LeafPipelineItem); ok {
+			node.Leaf = true
+		}
+		if featured, ok := item.(FeaturedPipelineItem); ok {
+			node.Features = append([]string{}, featured.Features()...)
+		}
+		if options := item.ListConfigurationOptions(); len(options) > 0 {
+			node.Configuration = map[string]interface{}{}
+			for _, opt := range options {
+				if val, exists := pipeline.facts[opt.Name]; exists {
+					node.Configuration[opt.Name] = val
+				} else {
+					node.Configuration[opt.Name] = opt.Default
+				}
+			}
+		}
+		// the items are sorted topologically, so the latest provider is the effective one
+		for _, key := range node.Requires {
+			if provider, exists := providers[key]; exists {
+				graph.Edges = append(graph.Edges, PipelineGraphEdge{From: provider, To: name, Entity: key})
+			}
+		}
+		for _, key := range node.Provides {
+			providers[key] = name
+		}
+		graph.Items = append(graph.Items, node)
+	}
+	return graph
+}
+
+// Graph returns the DAG of the specified items and of all the registered items which they
+// pull in to satisfy their dependencies, in the execution order. It does not require a repository
+// and reflects the registry defaults: features and configuration are not resolved.
+func (registry *PipelineItemRegistry) Graph(items ...PipelineItem) PipelineGraph {
+	graph := PipelineGraph{
+		Features: []string{},
+		Items:    []PipelineGraphItem{},
+		Edges:    []PipelineGraphEdge{},
+	}
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+	var visit func(item PipelineItem)
+	visit = func(item PipelineItem) {
+		name := item.Name()
+		visited[name] = true
+		visiting[name] = true
+		defer delete(visiting, name)
+		node := PipelineGraphItem{
+			Name:     name,
+			Requires: append([]string{}, item.Requires()...),
+			Provides: append([]string{}, item.Provides()...),
+		}
+		if _, ok := item.(LeafPipelineItem); ok {
+			node.Leaf = true
+		}
+		if featured, ok := item.(FeaturedPipelineItem); ok {
+			node.Features = append([]string{}, featured.Features()...)
+		}
+		for _, key := range node.Requires {
+			// skip the providers which would form a cycle, e.g. RenameAnalysis both requires
+			// and provides the tree changes so the upstream provider is TreeDiff
+			var provider PipelineItem
+			for _, candidate := range registry.Summon(key) {
+				if !visiting[candidate.Name()] {
+					provider = candidate
+					break
+				}
+			}
+			if provider == nil {
+				continue
+			}
+			if !visited[provider.Name()] {
+				visit(provider)
+			}
+			graph.Edges = append(graph.Edges, PipelineGraphEdge{
+				From: provider.Name(), To: name, Entity: key,
+			})
+		}
+		graph.Items = append(graph.Items, node)
+	}
+	for _, item := range items {
+		if !visited[item.Name()] {
+			visit(item)
+		}
+	}
+	return graph
+}
+
+// ExportGraph writes the DAG of the deployed items in the specified format.
+// See Graph() for details.
+func (pipeline *Pipeline) ExportGraph(format GraphFormat, writer io.Writer) error {
+	return pipeline.Graph().Export(format, writer)
+}
+
+// Export writes the graph in the specified format.
+func (graph PipelineGraph) Export(format GraphFormat, writer io.Writer) error {
+	switch format {
+	case GraphFormatDOT:
+		return graph.writeDOT(writer)
+	case GraphFormatMermaid:
+		return graph.writeMermaid(writer)
+	case GraphFormatJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	default:
+		return fmt.Errorf("unsupported graph format: %q", format)
+	}
+}
+
+// describe returns the lines with the features, configuration and facts of the item.
+func (item PipelineGraphItem) describe() []string {
+	var lines []string
+	if len(item.Features) > 0 {
+		lines = append(lines, "features: "+strings.Join(item.Features, ", "))
+	}
+	keys := make([]string, 0, len(item.Configuration))
+	for key := range item.Configuration {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s = %v", key, item.Configuration[key]))
+	}
+	if len(item.Facts) > 0 {
+		lines = append(lines, "facts: "+strings.Join(item.Facts, ", "))
+	}
+	return lines
+}
+
+func (graph PipelineGraph) writeDOT(writer io.Writer) error {
+	var builder strings.Builder
+	builder.WriteString("digraph Hercules {\n")
+	if len(graph.Features) > 0 {
+		fmt.Fprintf(&builder, "  label=%q;\n", "features: "+strings.Join(graph.Features, ", "))
+	}
+	for _, item := range graph.Items {
+		shape := "ellipse"
+		if item.Leaf {
+			shape = "box"
+		}
+		label := strings.Join(append([]string{item.Name}, item.describe()...), "\n")
+		fmt.Fprintf(&builder, "  %q [shape=%s, label=%q];\n", item.Name, shape, label)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&builder, "  %q -> %q [label=%q];\n", edge.From, edge.To, edge.Entity)
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func (graph PipelineGraph) writeMermaid(writer io.Writer) error {
+	escape := func(text string) string {
+		return strings.ReplaceAll(text, `"`, "#quot;")
+	}
+	ids := make(map[string]string, len(graph.Items))
+	var builder strings.Builder
+	builder.WriteString("flowchart TD\n")
+	for i, item := range graph.Items {
+		id := fmt.Sprintf("n%d", i)
+		ids[item.Name] = id
+		label := escape(strings.Join(append([]string{item.Name}, item.describe()...), "<br/>"))
+		if item.Leaf {
+			fmt.Fprintf(&builder, "  %s[[\"%s\"]]\n", id, label)
+		} else {
+			fmt.Fprintf(&builder, "  %s[\"%s\"]\n", id, label)
+		}
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&builder, "  %s -->|%s| %s\n", ids[edge.From], escape(edge.Entity), ids[edge.To])
+	}
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// dumpGraph exports the graph to the file or to stderr if the path is "-".
+// Nothing is written if the path is empty.
+func (pipeline *Pipeline) dumpGraph(path string, format GraphFormat) error {
+	if path == "" {
+		return nil
+	}
+	if path == "-" {
+		return pipeline.ExportGraph(format, os.Stderr)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = pipeline.ExportGraph(format, file); err != nil {
+		file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	absPath, _ := filepath.Abs(path)
+	pipeline.l.Infof("Wrote the DAG to %s\n", absPath)
+	return nil
+}
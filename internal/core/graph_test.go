@@ -0,0 +1,146 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// factSettingTestPipelineItem is testPipelineItem which publishes a fact in Configure().
+type factSettingTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *factSettingTestPipelineItem) Configure(facts map[string]interface{}) error {
+	facts["TestFact"] = true
+	return item.testPipelineItem.Configure(facts)
+}
+
+func newGraphTestPipeline(t *testing.T, facts map[string]interface{}) *Pipeline {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	pipeline.DeployItem(&factSettingTestPipelineItem{})
+	require.NoError(t, pipeline.Initialize(facts))
+	return pipeline
+}
+
+func TestPipelineGraph(t *testing.T) {
+	pipeline := newGraphTestPipeline(t, map[string]interface{}{"TestOption": 5})
+	graph := pipeline.Graph()
+	assert.Equal(t, []string{"power"}, graph.Features)
+	require.Len(t, graph.Items, 2)
+	assert.Equal(t, "Test", graph.Items[0].Name)
+	assert.True(t, graph.Items[0].Leaf)
+	assert.Equal(t, []string{"power"}, graph.Items[0].Features)
+	assert.Equal(t, map[string]interface{}{"TestOption": 5}, graph.Items[0].Configuration)
+	assert.Equal(t, []string{"TestFact"}, graph.Items[0].Facts)
+	assert.Equal(t, "Test2", graph.Items[1].Name)
+	assert.Equal(t, map[string]interface{}{"TestOption2": 10}, graph.Items[1].Configuration)
+	assert.Empty(t, graph.Items[1].Facts)
+	assert.Equal(t, []PipelineGraphEdge{{From: "Test", To: "Test2", Entity: "test"}}, graph.Edges)
+}
+
+func TestPipelineExportGraph(t *testing.T) {
+	pipeline := newGraphTestPipeline(t, map[string]interface{}{})
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, pipeline.ExportGraph(GraphFormatDOT, buffer))
+	assert.Contains(t, buffer.String(), "digraph Hercules {")
+	assert.Contains(t, buffer.String(), `"Test" -> "Test2" [label="test"];`)
+	assert.Contains(t, buffer.String(), `TestOption = 10`)
+
+	buffer.Reset()
+	require.NoError(t, pipeline.ExportGraph(GraphFormatMermaid, buffer))
+	assert.Contains(t, buffer.String(), "flowchart TD\n")
+	assert.Contains(t, buffer.String(), `n0[["Test<br/>features: power<br/>TestOption = 10<br/>facts: TestFact"]]`)
+	assert.Contains(t, buffer.String(), "n0 -->|test| n1")
+
+	buffer.Reset()
+	require.NoError(t, pipeline.ExportGraph(GraphFormatJSON, buffer))
+	var graph PipelineGraph
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &graph))
+	assert.Len(t, graph.Items, 2)
+	assert.Len(t, graph.Edges, 1)
+
+	assert.Error(t, pipeline.ExportGraph("svg", buffer))
+}
+
+func TestPipelineDAGFormat(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "hercules-dag-test-")
+	require.NoError(t, err)
+	tmpFile.Close()
+	defer os.Remove(tmpFile.Name())
+
+	newGraphTestPipeline(t, map[string]interface{}{
+		ConfigPipelineDAGPath:   tmpFile.Name(),
+		ConfigPipelineDAGFormat: string(GraphFormatJSON),
+	})
+	content, err := ioutil.ReadFile(tmpFile.Name())
+	require.NoError(t, err)
+	var graph PipelineGraph
+	require.NoError(t, json.Unmarshal(content, &graph))
+	assert.Equal(t, []string{"TestFact"}, graph.Items[0].Facts)
+
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	err = pipeline.Initialize(map[string]interface{}{ConfigPipelineDAGFormat: "svg"})
+	assert.Error(t, err)
+}
+
+// overridingDummyPipelineItem both requires and provides the entity of dummyPipelineItem.
+type overridingDummyPipelineItem struct {
+	dummyPipelineItem
+}
+
+func (item *overridingDummyPipelineItem) Name() string {
+	return "dummyOverride"
+}
+
+func (item *overridingDummyPipelineItem) Requires() []string {
+	return []string{"dummy"}
+}
+
+func TestRegistryGraph(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&dummyPipelineItem{})
+	reg.Register(&dummyPipelineItem3{})
+	reg.Register(&dummyPipelineItem4{})
+	graph := reg.Graph(&dummyPipelineItem4{}, &dummyPipelineItem3{})
+	require.Len(t, graph.Items, 3)
+	assert.Equal(t, "dummy", graph.Items[0].Name)
+	assert.Equal(t, []string{"power"}, graph.Items[0].Features)
+	assert.Equal(t, "dummy3", graph.Items[1].Name)
+	assert.Equal(t, "dummy4", graph.Items[2].Name)
+	assert.False(t, graph.Items[2].Leaf)
+	assert.Equal(t, []PipelineGraphEdge{
+		{From: "dummy", To: "dummy3", Entity: "dummy"},
+		{From: "dummy3", To: "dummy4", Entity: "dummy3"},
+	}, graph.Edges)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, graph.Export(GraphFormatDOT, buffer))
+	assert.Contains(t, buffer.String(), `"dummy3" -> "dummy4" [label="dummy3"];`)
+	assert.Len(t, reg.Graph().Items, 0)
+}
+
+func TestRegistryGraphOverride(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&dummyPipelineItem{})
+	reg.RegisterPreferred(&overridingDummyPipelineItem{}, true)
+	reg.Register(&dummyPipelineItem3{})
+	graph := reg.Graph(&dummyPipelineItem3{})
+	require.Len(t, graph.Items, 3)
+	assert.Equal(t, "dummy", graph.Items[0].Name)
+	assert.Equal(t, "dummyOverride", graph.Items[1].Name)
+	assert.Equal(t, "dummy3", graph.Items[2].Name)
+	assert.Equal(t, []PipelineGraphEdge{
+		{From: "dummy", To: "dummyOverride", Entity: "dummy"},
+		{From: "dummyOverride", To: "dummy3", Entity: "dummy"},
+	}, graph.Edges)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Identity is the developer identity parsed from the results, see ParseIdentity().
+type Identity struct {
+	// Names are the names of the developer in the order of appearance.
+	Names []string
+	// Emails are the emails of the developer in the order of appearance.
+	Emails []string
+}
+
+// ParseIdentity splits the developer identity written in any of the people display formats:
+// raw "name|name|email", full "Name <email>", name, email and hashed. The hashed identities
+// are opaque and become the single name, see HashIdentity() to match them.
+func ParseIdentity(identity string) Identity {
+	var result Identity
+	for _, part := range strings.Split(identity, "|") {
+		part = strings.TrimSpace(part)
+		if open := strings.LastIndex(part, "<"); open >= 0 && strings.HasSuffix(part, ">") &&
+			strings.Contains(part[open:], "@") {
+			if name := strings.TrimSpace(part[:open]); name != "" {
+				result.Names = append(result.Names, name)
+			}
+			part = strings.TrimSpace(part[open+1 : len(part)-1])
+		}
+		if part == "" {
+			continue
+		}
+		if strings.Contains(part, "@") {
+			result.Emails = append(result.Emails, part)
+		} else {
+			result.Names = append(result.Names, part)
+		}
+	}
+	return result
+}
+
+// Keys returns the names followed by the emails.
+func (identity Identity) Keys() []string {
+	keys := make([]string, 0, len(identity.Names)+len(identity.Emails))
+	keys = append(keys, identity.Names...)
+	return append(keys, identity.Emails...)
+}
+
+// Label returns the short human-readable name of the developer: the first name, or the first
+// email if there are no names.
+func (identity Identity) Label() string {
+	if len(identity.Names) > 0 {
+		return identity.Names[0]
+	}
+	if len(identity.Emails) > 0 {
+		return identity.Emails[0]
+	}
+	return ""
+}
+
+// IdentityLabel returns the Label() of the developer identity in any of the people display
+// formats, or the identity itself if it names nobody.
+func IdentityLabel(identity string) string {
+	if label := ParseIdentity(identity).Label(); label != "" {
+		return label
+	}
+	return identity
+}
+
+// HashIdentity returns the stable anonymous hash of the developer's email or name which
+// the hashed people display format writes.
+func HashIdentity(key string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(key)))
+	return hex.EncodeToString(hash[:6])
+}
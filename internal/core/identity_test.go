@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIdentity(t *testing.T) {
+	identity := ParseIdentity("Alice|alice smith|alice@example.com|a@b.org")
+	assert.Equal(t, []string{"Alice", "alice smith"}, identity.Names)
+	assert.Equal(t, []string{"alice@example.com", "a@b.org"}, identity.Emails)
+	assert.Equal(t, "Alice", identity.Label())
+	assert.Equal(t, []string{"Alice", "alice smith", "alice@example.com", "a@b.org"}, identity.Keys())
+
+	identity = ParseIdentity("Alice Smith <alice@example.com>")
+	assert.Equal(t, []string{"Alice Smith"}, identity.Names)
+	assert.Equal(t, []string{"alice@example.com"}, identity.Emails)
+	assert.Equal(t, "Alice Smith", identity.Label())
+
+	identity = ParseIdentity("alice@example.com")
+	assert.Nil(t, identity.Names)
+	assert.Equal(t, "alice@example.com", identity.Label())
+	assert.Equal(t, "Alice", ParseIdentity("Alice").Label())
+	hash := HashIdentity("alice@example.com")
+	assert.Equal(t, hash, ParseIdentity(hash).Label())
+	assert.Equal(t, "", ParseIdentity("").Label())
+	assert.Equal(t, []string{AuthorMissingName}, ParseIdentity(AuthorMissingName).Names)
+	assert.Equal(t, "Bob Smith", IdentityLabel("Bob Smith <bob@example.com>"))
+	assert.Equal(t, "bob", IdentityLabel("bob|bob@example.com"))
+	assert.Equal(t, " ", IdentityLabel(" "))
+	assert.Equal(t, hash, HashIdentity("Alice@Example.com"))
+	assert.Len(t, hash, 12)
+}
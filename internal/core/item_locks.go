@@ -0,0 +1,79 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// ItemLocks serializes the calls of the ThreadUnsafe items across the pipelines which run
+// at the same time. The instances of the same item are recognized by PipelineItem.Name().
+// The zero value is ready to use.
+type ItemLocks struct {
+	guard sync.Mutex
+	locks map[string]*itemLock
+}
+
+// itemLock is the lock of all the instances of the same item.
+type itemLock struct {
+	// token is sent to lock and received to unlock; the buffer holds one token.
+	token chan struct{}
+	// poisoned is closed when the owner of the lock has been abandoned.
+	poisoned chan struct{}
+}
+
+// get returns the lock of the item, creating it if needed.
+func (locks *ItemLocks) get(item PipelineItem) *itemLock {
+	locks.guard.Lock()
+	defer locks.guard.Unlock()
+	if locks.locks == nil {
+		locks.locks = map[string]*itemLock{}
+	}
+	lock := locks.locks[item.Name()]
+	if lock == nil {
+		lock = &itemLock{token: make(chan struct{}, 1), poisoned: make(chan struct{})}
+		locks.locks[item.Name()] = lock
+	}
+	return lock
+}
+
+// Lock waits until no other instance of the item is running and returns the function
+// which releases the lock. ThreadSafe items and nil ItemLocks are not locked.
+// It fails with ErrConsumeTimeout if the lock is poisoned, see Poison(), and with ctx.Err()
+// if the context is done before the lock is free.
+func (locks *ItemLocks) Lock(ctx context.Context, item PipelineItem) (unlock func(), err error) {
+	if locks == nil || GetThreadSafety(item) == ThreadSafe {
+		return func() {}, nil
+	}
+	lock := locks.get(item)
+	// select picks a random ready case, so the poisoned lock must win over the free token
+	select {
+	case <-lock.poisoned:
+		return nil, ErrConsumeTimeout
+	default:
+	}
+	select {
+	case lock.token <- struct{}{}:
+		return func() { <-lock.token }, nil
+	case <-lock.poisoned:
+		return nil, ErrConsumeTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Poison marks the lock of the item as lost: its holder is the stuck Consume() which was
+// abandoned because of Pipeline.CommitTimeout and may never release it. The pending and
+// the future Lock() calls fail with ErrConsumeTimeout instead of waiting forever.
+func (locks *ItemLocks) Poison(item PipelineItem) {
+	if locks == nil || GetThreadSafety(item) == ThreadSafe {
+		return
+	}
+	lock := locks.get(item)
+	locks.guard.Lock()
+	defer locks.guard.Unlock()
+	select {
+	case <-lock.poisoned:
+	default:
+		close(lock.poisoned)
+	}
+}
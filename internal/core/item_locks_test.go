@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type threadSafeTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *threadSafeTestPipelineItem) ThreadSafety() ThreadSafety {
+	return ThreadSafe
+}
+
+func TestGetThreadSafety(t *testing.T) {
+	assert.Equal(t, ThreadUnsafe, GetThreadSafety(&testPipelineItem{}))
+	assert.Equal(t, ThreadSafe, GetThreadSafety(&threadSafeTestPipelineItem{}))
+}
+
+// lockTestItem calls ItemLocks.Lock() which must succeed.
+func lockTestItem(t *testing.T, locks *ItemLocks, item PipelineItem) func() {
+	unlock, err := locks.Lock(context.Background(), item)
+	require.NoError(t, err)
+	return unlock
+}
+
+func TestItemLocks(t *testing.T) {
+	var locks *ItemLocks
+	// nil locks nothing
+	unlock1 := lockTestItem(t, locks, &testPipelineItem{})
+	unlock2 := lockTestItem(t, locks, &testPipelineItem{})
+	unlock1()
+	unlock2()
+
+	locks = &ItemLocks{}
+	unlock1 = lockTestItem(t, locks, &threadSafeTestPipelineItem{})
+	unlock2 = lockTestItem(t, locks, &threadSafeTestPipelineItem{})
+	unlock1()
+	unlock2()
+	// different items do not block each other
+	unlock1 = lockTestItem(t, locks, &testPipelineItem{})
+	unlock2 = lockTestItem(t, locks, &dependingTestPipelineItem{})
+	unlock1()
+	unlock2()
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := lockTestItem(t, locks, &testPipelineItem{})
+			defer unlock()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxRunning)
+}
+
+func TestItemLocksContext(t *testing.T) {
+	locks := &ItemLocks{}
+	unlock := lockTestItem(t, locks, &testPipelineItem{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := locks.Lock(ctx, &testPipelineItem{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	unlock()
+	lockTestItem(t, locks, &testPipelineItem{})()
+}
+
+func TestItemLocksPoison(t *testing.T) {
+	var locks *ItemLocks
+	// nil locks nothing
+	locks.Poison(&testPipelineItem{})
+	lockTestItem(t, locks, &testPipelineItem{})()
+
+	locks = &ItemLocks{}
+	// the abandoned holder never unlocks
+	lockTestItem(t, locks, &testPipelineItem{})
+	waiting := make(chan error)
+	go func() {
+		_, err := locks.Lock(context.Background(), &testPipelineItem{})
+		waiting <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	locks.Poison(&testPipelineItem{})
+	locks.Poison(&testPipelineItem{})
+	select {
+	case err := <-waiting:
+		assert.Equal(t, ErrConsumeTimeout, err)
+	case <-time.After(time.Second):
+		t.Fatal("the pending Lock() was not woken up")
+	}
+	_, err := locks.Lock(context.Background(), &testPipelineItem{})
+	assert.Equal(t, ErrConsumeTimeout, err)
+	// the other items are not affected
+	lockTestItem(t, locks, &dependingTestPipelineItem{})()
+	lockTestItem(t, locks, &threadSafeTestPipelineItem{})()
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/pkg/errors"
+)
+
+// MultiRepoRunner runs the same pipeline configuration over several repositories and combines
+// the results of each leaf with ResultMergeablePipelineItem.MergeResults() into a single result set,
+// the same way as `hercules combine` does with the saved results.
+type MultiRepoRunner struct {
+	// Repositories are the analysed repositories.
+	Repositories []*git.Repository
+	// Configure deploys the items into the fresh pipeline of the repository with the specified
+	// index and initializes it with the prepared run plan, see Pipeline.InitializeExt().
+	// It returns the deployed leaves which must have the same names in the same order
+	// for every repository.
+	Configure func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error)
+	// OnResults is invoked with the results of each repository before they are merged,
+	// e.g. to report Pipeline.Failures() or to annotate the results. It is optional and may be
+	// called concurrently if Parallelism is greater than 1.
+	OnResults func(index int, pipeline *Pipeline, results map[LeafPipelineItem]interface{})
+	// Parallelism is the maximum number of the pipelines which run at the same time.
+	// 0 and 1 analyse the repositories one by one. The ThreadUnsafe items run one at a time
+	// regardless, see ItemLocks.
+	Parallelism int
+
+	locks *ItemLocks
+}
+
+// multiRepoRun is the outcome of analysing one of MultiRepoRunner.Repositories.
+type multiRepoRun struct {
+	leaves  []LeafPipelineItem
+	results map[LeafPipelineItem]interface{}
+	err     error
+}
+
+// Run analyses all the repositories and merges the results. It returns the leaves deployed
+// in the pipeline of the first repository and the merged results keyed by those leaves.
+// As with Pipeline.Run(), the "nil" record is the merged CommonAnalysisResult.
+// If any repository fails, the rest are cancelled and Run() returns the first error.
+func (runner *MultiRepoRunner) Run(ctx context.Context) (
+	[]LeafPipelineItem, map[LeafPipelineItem]interface{}, error,
+) {
+	if len(runner.Repositories) == 0 {
+		return nil, nil, fmt.Errorf("no repositories to analyse")
+	}
+	if runner.Configure == nil {
+		return nil, nil, fmt.Errorf("MultiRepoRunner.Configure is not set")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	parallelism := runner.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	runner.locks = nil
+	if parallelism > 1 && len(runner.Repositories) > 1 {
+		runner.locks = &ItemLocks{}
+	}
+	runs := make([]multiRepoRun, len(runner.Repositories))
+	slots := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := range runner.Repositories {
+		slots <- struct{}{}
+		if ctx.Err() != nil {
+			<-slots
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			runs[i] = runner.runOne(ctx, i)
+			if runs[i].err != nil {
+				cancel()
+			}
+		}(i)
+	}
+	wg.Wait()
+	for i, run := range runs {
+		if run.err != nil && !errors.Is(run.err, context.Canceled) {
+			return nil, nil, errors.Wrapf(run.err, "repository #%d", i+1)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	results, err := runner.merge(runs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return runs[0].leaves, results, nil
+}
+
+// runOne configures and runs the pipeline of the repository with the specified index.
+func (runner *MultiRepoRunner) runOne(ctx context.Context, index int) multiRepoRun {
+	pipeline := NewPipeline(runner.Repositories[index])
+	pipeline.ItemLocks = runner.locks
+	leaves, err := runner.Configure(index, pipeline)
+	if err != nil {
+		return multiRepoRun{err: err}
+	}
+	if len(runner.Repositories) > 1 {
+		for _, leaf := range leaves {
+			if _, ok := leaf.(ResultMergeablePipelineItem); !ok {
+				return multiRepoRun{err: fmt.Errorf(
+					"%s cannot merge the results of several repositories", leaf.Name())}
+			}
+		}
+	}
+	results, err := pipeline.RunPreparedPlanContext(ctx)
+	if err != nil {
+		return multiRepoRun{err: err}
+	}
+	if runner.OnResults != nil {
+		runner.OnResults(index, pipeline, results)
+	}
+	return multiRepoRun{leaves: leaves, results: results}
+}
+
+// merge combines the results of all the runs in the order of the repositories.
+func (runner *MultiRepoRunner) merge(runs []multiRepoRun) (map[LeafPipelineItem]interface{}, error) {
+	leaves := runs[0].leaves
+	merged := make(map[LeafPipelineItem]interface{}, len(leaves)+1)
+	for _, leaf := range leaves {
+		if result, exists := runs[0].results[leaf]; exists {
+			merged[leaf] = result
+		}
+	}
+	mergedCommon := runs[0].results[nil].(*CommonAnalysisResult).Copy()
+	for i, run := range runs[1:] {
+		if len(run.leaves) != len(leaves) {
+			return nil, fmt.Errorf("repository #%d deployed %d leaves instead of %d",
+				i+2, len(run.leaves), len(leaves))
+		}
+		common := run.results[nil].(*CommonAnalysisResult)
+		if err := mergedCommon.CheckTicks(common); err != nil {
+			return nil, errors.Wrapf(err, "could not merge repository #%d", i+2)
+		}
+		for j, leaf := range leaves {
+			if run.leaves[j].Name() != leaf.Name() {
+				return nil, fmt.Errorf("repository #%d deployed %s instead of %s",
+					i+2, run.leaves[j].Name(), leaf.Name())
+			}
+			previous, exists := merged[leaf]
+			next, nextExists := run.results[run.leaves[j]]
+			if !exists || !nextExists {
+				// the leaf was abandoned in some repository, see Pipeline.CommitTimeout
+				delete(merged, leaf)
+				continue
+			}
+			result := leaf.(ResultMergeablePipelineItem).MergeResults(
+				previous, next, &mergedCommon, common)
+			if err, isErr := result.(error); isErr {
+				return nil, errors.Wrapf(err, "could not merge %s of repository #%d", leaf.Name(), i+2)
+			}
+			merged[leaf] = result
+		}
+		if mergedCommon.CommitsNumber == 0 {
+			mergedCommon = common.Copy()
+		} else if common.CommitsNumber > 0 {
+			mergedCommon.Merge(common)
+		}
+	}
+	merged[nil] = &mergedCommon
+	return merged, nil
+}
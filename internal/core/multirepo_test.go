@@ -0,0 +1,302 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingTestLeaf counts the consumed commits and sums the counts in MergeResults().
+type countingTestLeaf struct {
+	NoopMerger
+	commits int
+}
+
+func (leaf *countingTestLeaf) Name() string                                    { return "Counting" }
+func (leaf *countingTestLeaf) Provides() []string                              { return []string{} }
+func (leaf *countingTestLeaf) Requires() []string                              { return []string{} }
+func (leaf *countingTestLeaf) ListConfigurationOptions() []ConfigurationOption { return nil }
+func (leaf *countingTestLeaf) Configure(facts map[string]interface{}) error    { return nil }
+func (leaf *countingTestLeaf) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+func (leaf *countingTestLeaf) Flag() string        { return "counting" }
+func (leaf *countingTestLeaf) Description() string { return "Counts the commits." }
+func (leaf *countingTestLeaf) Initialize(repository *git.Repository) error {
+	leaf.commits = 0
+	return nil
+}
+
+func (leaf *countingTestLeaf) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	leaf.commits++
+	return nil, nil
+}
+
+func (leaf *countingTestLeaf) Fork(n int) []PipelineItem { return ForkSamePipelineItem(leaf, n) }
+func (leaf *countingTestLeaf) Finalize() interface{}     { return leaf.commits }
+func (leaf *countingTestLeaf) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	_, err := fmt.Fprintln(writer, result)
+	return err
+}
+
+func (leaf *countingTestLeaf) Deserialize(message []byte) (interface{}, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (leaf *countingTestLeaf) MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{} {
+	if r1.(int) < 0 || r2.(int) < 0 {
+		return errors.New("negative")
+	}
+	return r1.(int) + r2.(int)
+}
+
+// blockingTestLeaf is countingTestLeaf which ignores the context and blocks in Consume()
+// on the first commit until Release is closed. Blocked is closed when it starts blocking.
+type blockingTestLeaf struct {
+	countingTestLeaf
+	Blocked chan struct{}
+	Release chan struct{}
+}
+
+func (leaf *blockingTestLeaf) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if leaf.commits == 0 {
+		close(leaf.Blocked)
+		<-leaf.Release
+	}
+	return leaf.countingTestLeaf.Consume(deps)
+}
+
+func (leaf *blockingTestLeaf) Fork(n int) []PipelineItem { return ForkSamePipelineItem(leaf, n) }
+
+// makeMultiRepoCommits generates the linear history of `n` commits which starts at `begin`.
+func makeMultiRepoCommits(prefix string, n int, begin time.Time) []*object.Commit {
+	commits := make([]*object.Commit, n)
+	for i := range commits {
+		var parents []string
+		if i > 0 {
+			parents = append(parents, fmt.Sprintf("%s%d", prefix, i-1))
+		}
+		commits[i] = makeTestCommit(fmt.Sprintf("%s%d", prefix, i), parents...)
+		when := begin.Add(time.Duration(i) * time.Hour)
+		commits[i].Author.When = when
+		commits[i].Committer.When = when
+	}
+	return commits
+}
+
+func newTestMultiRepoRunner(histories ...[]*object.Commit) *MultiRepoRunner {
+	repositories := make([]*git.Repository, len(histories))
+	for i := range repositories {
+		repositories[i] = test.Repository
+	}
+	return &MultiRepoRunner{
+		Repositories: repositories,
+		Configure: func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error) {
+			leaf := pipeline.DeployItem(&countingTestLeaf{}).(LeafPipelineItem)
+			err := pipeline.InitializeExt(map[string]interface{}{
+				ConfigPipelineCommits: histories[index],
+			}, func(items []PipelineItem) PipelineItem { return items[0] }, true)
+			return []LeafPipelineItem{leaf}, err
+		},
+	}
+}
+
+func TestMultiRepoRunner(t *testing.T) {
+	begin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, parallelism := range []int{0, 1, 2, 5} {
+		t.Run(fmt.Sprint(parallelism), func(t *testing.T) {
+			runner := newTestMultiRepoRunner(
+				makeMultiRepoCommits("a", 3, begin),
+				makeMultiRepoCommits("b", 5, begin.Add(-time.Hour)),
+				makeMultiRepoCommits("c", 2, begin.Add(24*time.Hour)))
+			runner.Parallelism = parallelism
+			var mutex sync.Mutex
+			seen := map[int]interface{}{}
+			runner.OnResults = func(index int, pipeline *Pipeline, results map[LeafPipelineItem]interface{}) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				// the unsafe items must be serialized only if the pipelines run concurrently
+				assert.Equal(t, parallelism > 1, pipeline.ItemLocks != nil)
+				for item, result := range results {
+					if item != nil {
+						seen[index] = result
+					}
+				}
+			}
+			leaves, results, err := runner.Run(context.Background())
+			require.NoError(t, err)
+			require.Len(t, leaves, 1)
+			assert.Equal(t, "Counting", leaves[0].Name())
+			assert.Equal(t, map[int]interface{}{0: 3, 1: 5, 2: 2}, seen)
+			assert.Equal(t, 10, results[leaves[0]])
+			common := results[nil].(*CommonAnalysisResult)
+			assert.Equal(t, 10, common.CommitsNumber)
+			assert.Equal(t, begin.Add(-time.Hour).Unix(), common.BeginTime)
+			assert.Equal(t, begin.Add(25*time.Hour).Unix(), common.EndTime)
+		})
+	}
+}
+
+func TestMultiRepoRunnerSingle(t *testing.T) {
+	runner := newTestMultiRepoRunner(makeMultiRepoCommits("a", 3, time.Now()))
+	leaves, results, err := runner.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, results[leaves[0]])
+}
+
+func TestMultiRepoRunnerAbandonedLeaf(t *testing.T) {
+	history := makeMultiRepoCommits("a", 3, time.Now())
+	runner := newTestMultiRepoRunner(history, history, history)
+	runner.OnResults = func(index int, pipeline *Pipeline, results map[LeafPipelineItem]interface{}) {
+		for item := range results {
+			// the leaf was abandoned with CommitTimeout in the second repository
+			if item != nil && index == 1 {
+				delete(results, item)
+			}
+		}
+	}
+	leaves, results, err := runner.Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, leaves, 1)
+	assert.NotContains(t, results, leaves[0])
+	assert.Equal(t, 9, results[nil].(*CommonAnalysisResult).CommitsNumber)
+}
+
+func TestMultiRepoRunnerStuckLeaf(t *testing.T) {
+	history := makeMultiRepoCommits("a", 3, time.Now())
+	stuck := &blockingTestLeaf{Blocked: make(chan struct{}), Release: make(chan struct{})}
+	defer close(stuck.Release)
+	runner := newTestMultiRepoRunner(history, history)
+	runner.Parallelism = 2
+	runner.Configure = func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error) {
+		var leaf LeafPipelineItem
+		if index == 0 {
+			leaf = pipeline.DeployItem(stuck).(LeafPipelineItem)
+		} else {
+			// the second repository starts while the first holds the lock of the leaf
+			<-stuck.Blocked
+			leaf = pipeline.DeployItem(&countingTestLeaf{}).(LeafPipelineItem)
+		}
+		err := pipeline.InitializeExt(map[string]interface{}{
+			ConfigPipelineCommits:         history,
+			ConfigPipelineCommitTimeout:   50 * time.Millisecond,
+			ConfigPipelineContinueOnError: true,
+			ConfigLogger: &DefaultLogger{
+				I: log.New(ioutil.Discard, "", 0), W: log.New(ioutil.Discard, "", 0),
+				E: log.New(ioutil.Discard, "", 0),
+			},
+		}, func(items []PipelineItem) PipelineItem { return items[0] }, true)
+		return []LeafPipelineItem{leaf}, err
+	}
+	var mutex sync.Mutex
+	failures := map[int][]*PipelineError{}
+	runner.OnResults = func(index int, pipeline *Pipeline, results map[LeafPipelineItem]interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		failures[index] = pipeline.Failures()
+	}
+	type runResult struct {
+		leaves  []LeafPipelineItem
+		results map[LeafPipelineItem]interface{}
+		err     error
+	}
+	done := make(chan runResult, 1)
+	go func() {
+		leaves, results, err := runner.Run(context.Background())
+		done <- runResult{leaves, results, err}
+	}()
+	var run runResult
+	select {
+	case run = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the stuck leaf deadlocked the other repository")
+	}
+	require.NoError(t, run.err)
+	require.Len(t, run.leaves, 1)
+	// the leaf is abandoned in both repositories
+	assert.NotContains(t, run.results, run.leaves[0])
+	assert.Equal(t, 6, run.results[nil].(*CommonAnalysisResult).CommitsNumber)
+	for index := 0; index < 2; index++ {
+		require.Len(t, failures[index], 1)
+		assert.Equal(t, ErrConsumeTimeout, failures[index][0].Err)
+		assert.Equal(t, 0, failures[index][0].CommitIndex)
+	}
+}
+
+func TestMultiRepoRunnerMixedTicks(t *testing.T) {
+	history := makeMultiRepoCommits("a", 3, time.Now())
+	runner := newTestMultiRepoRunner(history, history)
+	runner.Configure = func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error) {
+		leaf := pipeline.DeployItem(&countingTestLeaf{}).(LeafPipelineItem)
+		facts := map[string]interface{}{ConfigPipelineCommits: history}
+		if index == 1 {
+			facts[FactTickMode] = "month"
+		}
+		err := pipeline.InitializeExt(facts, func(items []PipelineItem) PipelineItem { return items[0] }, true)
+		return []LeafPipelineItem{leaf}, err
+	}
+	_, _, err := runner.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mismatching tick modes (fixed, month)")
+}
+
+func TestMultiRepoRunnerErrors(t *testing.T) {
+	_, _, err := (&MultiRepoRunner{}).Run(context.Background())
+	assert.Error(t, err)
+	_, _, err = (&MultiRepoRunner{Repositories: []*git.Repository{test.Repository}}).Run(
+		context.Background())
+	assert.Error(t, err)
+
+	history := makeMultiRepoCommits("a", 3, time.Now())
+	runner := newTestMultiRepoRunner(history, history)
+	configure := runner.Configure
+	runner.Configure = func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error) {
+		if index == 1 {
+			return nil, errors.New("configure failed")
+		}
+		return configure(index, pipeline)
+	}
+	_, _, err = runner.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "repository #2")
+	assert.Contains(t, err.Error(), "configure failed")
+
+	runner = newTestMultiRepoRunner(history, history)
+	runner.Configure = func(index int, pipeline *Pipeline) ([]LeafPipelineItem, error) {
+		item := &testPipelineItem{}
+		pipeline.AddItem(item)
+		return []LeafPipelineItem{item}, nil
+	}
+	_, _, err = runner.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot merge")
+
+	runner = newTestMultiRepoRunner(history, history)
+	runner.OnResults = func(index int, pipeline *Pipeline, results map[LeafPipelineItem]interface{}) {
+		for item := range results {
+			if item != nil && index == 1 {
+				results[item] = -1
+			}
+		}
+	}
+	_, _, err = runner.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not merge Counting")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = newTestMultiRepoRunner(history, history).Run(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// OutputFilter trims the result of an analysis before it is written, so that the huge payloads
+// can be reduced without changing the analysis itself. The zero value keeps everything.
+type OutputFilter struct {
+	// TopFiles keeps only the given number of the biggest or the riskiest files; 0 keeps all.
+	TopFiles int
+	// MinLines drops the files and the developers with fewer lines.
+	MinLines int
+	// Authors keeps only the listed developers. Each entry is matched case-insensitively
+	// against the names and the emails of the developer. Empty keeps all.
+	Authors []string
+}
+
+// FilterablePipelineItem is the LeafPipelineItem whose results can be trimmed
+// with an OutputFilter at serialization time.
+type FilterablePipelineItem interface {
+	LeafPipelineItem
+	// FilterResult returns the copy of the result returned by Finalize() with the filter applied.
+	// The original result must not be modified.
+	FilterResult(result interface{}, filter OutputFilter) interface{}
+}
+
+// IsEmpty returns true if the filter keeps everything.
+func (filter OutputFilter) IsEmpty() bool {
+	return filter.TopFiles <= 0 && filter.MinLines <= 0 && len(filter.Authors) == 0
+}
+
+// AllowsAuthor checks the developer identity in any of the people display formats,
+// see ParseIdentity(), against Authors. The hashed identities match the hashes of Authors.
+func (filter OutputFilter) AllowsAuthor(identity string) bool {
+	if len(filter.Authors) == 0 {
+		return true
+	}
+	keys := ParseIdentity(identity).Keys()
+	for _, author := range filter.Authors {
+		author = strings.TrimSpace(author)
+		if identity == HashIdentity(author) {
+			return true
+		}
+		for _, key := range keys {
+			if strings.EqualFold(key, author) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// KeepFiles returns the set of the files which pass TopFiles and MinLines given the number
+// of lines in each file. The ties are broken by the file name.
+func (filter OutputFilter) KeepFiles(lines map[string]int) map[string]bool {
+	files := make([]string, 0, len(lines))
+	for file, size := range lines {
+		if size >= filter.MinLines {
+			files = append(files, file)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if lines[files[i]] != lines[files[j]] {
+			return lines[files[i]] > lines[files[j]]
+		}
+		return files[i] < files[j]
+	})
+	if filter.TopFiles > 0 && len(files) > filter.TopFiles {
+		files = files[:filter.TopFiles]
+	}
+	result := make(map[string]bool, len(files))
+	for _, file := range files {
+		result[file] = true
+	}
+	return result
+}
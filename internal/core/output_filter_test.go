@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFilterIsEmpty(t *testing.T) {
+	assert.True(t, OutputFilter{}.IsEmpty())
+	assert.False(t, OutputFilter{TopFiles: 1}.IsEmpty())
+	assert.False(t, OutputFilter{MinLines: 1}.IsEmpty())
+	assert.False(t, OutputFilter{Authors: []string{"alice"}}.IsEmpty())
+}
+
+func TestOutputFilterAllowsAuthor(t *testing.T) {
+	assert.True(t, OutputFilter{}.AllowsAuthor("anyone|any@one.com"))
+	filter := OutputFilter{Authors: []string{"Alice@Example.com", " bob "}}
+	assert.True(t, filter.AllowsAuthor("alice|alice@example.com"))
+	assert.True(t, filter.AllowsAuthor("Bob"))
+	assert.False(t, filter.AllowsAuthor("carol|bob@example.com"))
+}
+
+func TestOutputFilterAllowsAuthorFormats(t *testing.T) {
+	filter := OutputFilter{Authors: []string{"Alice@Example.com"}}
+	// raw, full, name, email and hashed
+	assert.True(t, filter.AllowsAuthor("alice|alice@example.com"))
+	assert.True(t, filter.AllowsAuthor("Alice <alice@example.com>"))
+	assert.True(t, filter.AllowsAuthor("alice@example.com"))
+	assert.True(t, filter.AllowsAuthor(HashIdentity("alice@example.com")))
+	assert.False(t, filter.AllowsAuthor("Alice <bob@example.com>"))
+	assert.False(t, filter.AllowsAuthor(HashIdentity("bob@example.com")))
+	filter = OutputFilter{Authors: []string{"Alice Smith"}}
+	assert.True(t, filter.AllowsAuthor("Alice Smith|alice@example.com"))
+	assert.True(t, filter.AllowsAuthor("Alice Smith <alice@example.com>"))
+	assert.True(t, filter.AllowsAuthor("alice smith"))
+	assert.False(t, filter.AllowsAuthor("alice@example.com"))
+	// the hashed identity of the developer without an email
+	assert.True(t, filter.AllowsAuthor(HashIdentity("Alice Smith")))
+}
+
+func TestOutputFilterKeepFiles(t *testing.T) {
+	lines := map[string]int{"a.go": 10, "b.go": 30, "c.go": 20, "d.go": 20, "e.go": 1}
+	assert.Equal(t, map[string]bool{"b.go": true, "c.go": true},
+		OutputFilter{TopFiles: 2}.KeepFiles(lines))
+	assert.Equal(t, map[string]bool{"a.go": true, "b.go": true, "c.go": true, "d.go": true},
+		OutputFilter{MinLines: 10}.KeepFiles(lines))
+	assert.Len(t, OutputFilter{}.KeepFiles(lines), 5)
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// PathAnonymizationHash replaces each file and directory name with the salted hash
+	// of its path.
+	PathAnonymizationHash = "hash"
+	// PathAnonymizationBucket numbers the files and the directories in each directory,
+	// e.g. "d1/d3/f2.go".
+	PathAnonymizationBucket = "bucket"
+)
+
+// PathAnonymizationModes are the supported modes of PathAnonymizer.
+var PathAnonymizationModes = []string{PathAnonymizationHash, PathAnonymizationBucket}
+
+// PathAnonymizablePipelineItem is the LeafPipelineItem whose results can have the file paths
+// replaced with pseudonyms at serialization time.
+type PathAnonymizablePipelineItem interface {
+	LeafPipelineItem
+	// AnonymizePaths returns the copy of the result returned by Finalize() with each path
+	// of a file or a directory replaced by anonymize(path). The original result must not
+	// be modified.
+	AnonymizePaths(result interface{}, anonymize func(path string) string) interface{}
+}
+
+// PathAnonymizer replaces the file and directory names with pseudonyms, similar to
+// --people-anonymity, so that the architectural metrics can be shared without revealing
+// the structure of the repository. The directory depth and the file extensions are kept,
+// the same path always maps to the same pseudonym and the root directory "/" is not changed.
+type PathAnonymizer struct {
+	mode string
+	salt string
+	// pseudonyms maps the original paths to the anonymized ones.
+	pseudonyms map[string]string
+	// dirs are the paths which are known to be directories.
+	dirs map[string]bool
+	// children counts the named children of each original directory in bucket mode.
+	children map[string]int
+}
+
+// NewPathAnonymizer creates a new PathAnonymizer in one of PathAnonymizationModes.
+// The salt keeps the hashes stable across the runs; a random salt is generated if it is empty.
+func NewPathAnonymizer(mode, salt string) (*PathAnonymizer, error) {
+	known := false
+	for _, value := range PathAnonymizationModes {
+		if mode == value {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown path anonymization mode %q, must be one of %s",
+			mode, strings.Join(PathAnonymizationModes, ", "))
+	}
+	if salt == "" {
+		random := make([]byte, 16)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+		salt = hex.EncodeToString(random)
+	}
+	return &PathAnonymizer{
+		mode:       mode,
+		salt:       salt,
+		pseudonyms: map[string]string{},
+		dirs:       map[string]bool{},
+		children:   map[string]int{},
+	}, nil
+}
+
+// Prepare registers all the paths which are going to be anonymized. It tells apart
+// the directories, which never keep an extension, and numbers the children of each
+// directory in the alphabetical order in bucket mode. The pseudonyms of the paths
+// which were not prepared depend on the order of Anonymize() calls in bucket mode.
+func (anonymizer *PathAnonymizer) Prepare(paths []string) {
+	prefixes := map[string]bool{}
+	for _, name := range paths {
+		parts := strings.Split(name, "/")
+		for i := range parts {
+			prefix := strings.Join(parts[:i+1], "/")
+			prefixes[prefix] = true
+			if i < len(parts)-1 {
+				anonymizer.dirs[prefix] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(prefixes))
+	for prefix := range prefixes {
+		sorted = append(sorted, prefix)
+	}
+	sort.Strings(sorted)
+	for _, prefix := range sorted {
+		anonymizer.Anonymize(prefix)
+	}
+}
+
+// Anonymize returns the pseudonym of the path of a file or a directory.
+func (anonymizer *PathAnonymizer) Anonymize(name string) string {
+	if name == "" || name == "/" {
+		return name
+	}
+	if pseudonym, exists := anonymizer.pseudonyms[name]; exists {
+		return pseudonym
+	}
+	parent := ""
+	if slash := strings.LastIndexByte(name, '/'); slash >= 0 {
+		anonymizer.dirs[name[:slash]] = true
+		parent = anonymizer.Anonymize(name[:slash]) + "/"
+	}
+	pseudonym := parent + anonymizer.pseudonym(name)
+	anonymizer.pseudonyms[name] = pseudonym
+	return pseudonym
+}
+
+// pseudonym replaces the last element of the path.
+func (anonymizer *PathAnonymizer) pseudonym(name string) string {
+	parent, base := "", name
+	if slash := strings.LastIndexByte(name, '/'); slash >= 0 {
+		parent, base = name[:slash], name[slash+1:]
+	}
+	if base == "" {
+		return ""
+	}
+	ext := ""
+	if !anonymizer.dirs[name] {
+		// ".gitignore" has no extension, it is the name
+		if ext = path.Ext(base); ext == base {
+			ext = ""
+		}
+	}
+	if anonymizer.mode == PathAnonymizationBucket {
+		anonymizer.children[parent]++
+		kind := "f"
+		if anonymizer.dirs[name] {
+			kind = "d"
+		}
+		return fmt.Sprintf("%s%d%s", kind, anonymizer.children[parent], ext)
+	}
+	hash := sha256.Sum256([]byte(anonymizer.salt + "\x00" + name))
+	return hex.EncodeToString(hash[:5]) + ext
+}
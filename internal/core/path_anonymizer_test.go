@@ -0,0 +1,57 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPathAnonymizer(t *testing.T) {
+	_, err := NewPathAnonymizer("scramble", "")
+	assert.Error(t, err)
+	anonymizer1, err := NewPathAnonymizer(PathAnonymizationHash, "")
+	require.NoError(t, err)
+	anonymizer2, err := NewPathAnonymizer(PathAnonymizationHash, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, anonymizer1.salt, anonymizer2.salt)
+	assert.NotEqual(t, anonymizer1.Anonymize("src/main.go"), anonymizer2.Anonymize("src/main.go"))
+}
+
+func TestPathAnonymizerHash(t *testing.T) {
+	anonymizer, err := NewPathAnonymizer(PathAnonymizationHash, "pepper")
+	require.NoError(t, err)
+	anonymizer.Prepare([]string{"src/core.d/main.go", "README.md"})
+	file := anonymizer.Anonymize("src/core.d/main.go")
+	parts := strings.Split(file, "/")
+	require.Len(t, parts, 3)
+	assert.Len(t, parts[0], 10)
+	assert.Len(t, parts[1], 10, "the directories keep no extension")
+	assert.True(t, strings.HasSuffix(parts[2], ".go"))
+	assert.Equal(t, parts[0]+"/"+parts[1], anonymizer.Anonymize("src/core.d"))
+	assert.Equal(t, parts[0], anonymizer.Anonymize("src"))
+	assert.NotContains(t, file, "src")
+	assert.True(t, strings.HasSuffix(anonymizer.Anonymize("README.md"), ".md"))
+	assert.Len(t, anonymizer.Anonymize(".gitignore"), 10)
+	assert.Equal(t, "/", anonymizer.Anonymize("/"))
+	assert.Equal(t, "", anonymizer.Anonymize(""))
+
+	same, err := NewPathAnonymizer(PathAnonymizationHash, "pepper")
+	require.NoError(t, err)
+	assert.Equal(t, file, same.Anonymize("src/core.d/main.go"))
+}
+
+func TestPathAnonymizerBucket(t *testing.T) {
+	anonymizer, err := NewPathAnonymizer(PathAnonymizationBucket, "")
+	require.NoError(t, err)
+	anonymizer.Prepare([]string{"src/b.go", "src/a.go", "lib/util/x.c", "README.md", "src/a.go"})
+	assert.Equal(t, "f1.md", anonymizer.Anonymize("README.md"))
+	assert.Equal(t, "d2", anonymizer.Anonymize("lib"))
+	assert.Equal(t, "d2/d1/f1.c", anonymizer.Anonymize("lib/util/x.c"))
+	assert.Equal(t, "d3/f1.go", anonymizer.Anonymize("src/a.go"))
+	assert.Equal(t, "d3/f2.go", anonymizer.Anonymize("src/b.go"))
+	// not prepared
+	assert.Equal(t, "d3/f3.go", anonymizer.Anonymize("src/c.go"))
+	assert.Equal(t, "d3/f3.go", anonymizer.Anonymize("src/c.go"))
+}
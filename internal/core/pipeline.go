@@ -2,12 +2,14 @@ package core
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"runtime/debug"
 	"sort"
 	"strings"
@@ -169,6 +171,71 @@ type ResultMergeablePipelineItem interface {
 	MergeResults(r1, r2 interface{}, c1, c2 *CommonAnalysisResult) interface{}
 }
 
+// CostClass is the approximate per-commit run time category of a LeafPipelineItem.
+type CostClass string
+
+const (
+	// CostCheap marks the analyses which do not look into the file contents.
+	CostCheap CostClass = "cheap"
+	// CostMedium marks the analyses which diff the changed files.
+	CostMedium CostClass = "medium"
+	// CostHeavy marks the analyses which track every line or parse the code.
+	CostHeavy CostClass = "heavy"
+)
+
+// CostedPipelineItem is the LeafPipelineItem which reports how expensive it is to run.
+type CostedPipelineItem interface {
+	LeafPipelineItem
+	// Cost returns the approximate per-commit run time category of the analysis
+	// including the plumbing which it pulls in.
+	Cost() CostClass
+}
+
+// GetCost returns the CostClass of the item or CostMedium if the item does not report it.
+func GetCost(item PipelineItem) CostClass {
+	if costed, ok := item.(CostedPipelineItem); ok {
+		return costed.Cost()
+	}
+	return CostMedium
+}
+
+// ThreadSafety tells whether the instances of a PipelineItem in different pipelines may run
+// at the same time.
+type ThreadSafety string
+
+const (
+	// ThreadUnsafe marks the items whose instances share some state, e.g. a global cache or
+	// a non-reentrant library. The parallel execution modes serialize their calls.
+	ThreadUnsafe ThreadSafety = "unsafe"
+	// ThreadSafe marks the items whose instances keep all the state to themselves.
+	ThreadSafe ThreadSafety = "safe"
+)
+
+// ConcurrentPipelineItem is the PipelineItem which declares its ThreadSafety.
+type ConcurrentPipelineItem interface {
+	PipelineItem
+	// ThreadSafety returns whether the instances of the item may run concurrently.
+	ThreadSafety() ThreadSafety
+}
+
+// GetThreadSafety returns the ThreadSafety of the item or ThreadUnsafe if the item does not
+// declare it: the older items were written with the sequential execution in mind.
+func GetThreadSafety(item PipelineItem) ThreadSafety {
+	if concurrent, ok := item.(ConcurrentPipelineItem); ok {
+		return concurrent.ThreadSafety()
+	}
+	return ThreadUnsafe
+}
+
+// ConflictingPipelineItem declares the features and the items which must not be used together
+// with it. Pipeline.Initialize() fails with a descriptive error if there is a conflict.
+type ConflictingPipelineItem interface {
+	PipelineItem
+	// ConflictsWith returns the names of the features and of the other items
+	// (PipelineItem.Name()) which this item cannot run with.
+	ConflictsWith() []string
+}
+
 // HibernateablePipelineItem is the interface to allow pipeline items to be frozen (compacted, unloaded)
 // while they are not needed in the hosting branch.
 type HibernateablePipelineItem interface {
@@ -179,18 +246,39 @@ type HibernateablePipelineItem interface {
 	Boot() error
 }
 
+// CheckpointablePipelineItem is the interface to allow pipeline items to save their state when
+// the run is cancelled and to restore it in the next run, see Pipeline.Checkpoint.
+type CheckpointablePipelineItem interface {
+	PipelineItem
+	// Checkpoint writes the state which the item has accumulated over the consumed commits.
+	Checkpoint(writer io.Writer) error
+	// Restore reads the state which Checkpoint() wrote. It is called after Initialize()
+	// instead of consuming the commits which the checkpoint covers.
+	Restore(reader io.Reader) error
+}
+
 // CommonAnalysisResult holds the information which is always extracted at Pipeline.Run().
 type CommonAnalysisResult struct {
 	// BeginTime is the time of the first commit in the analysed sequence.
 	BeginTime int64
 	// EndTime is the time of the last commit in the analysed sequence.
 	EndTime int64
-	// CommitsNumber is the number of commits in the analysed sequence.
+	// CommitsNumber is the number of commits in the analysed sequence. The commits which
+	// MainlineOnly or CommitStride skip are not counted.
 	CommitsNumber int
 	// RunTime is the duration of Pipeline.Run().
 	RunTime time.Duration
 	// RunTimePerItem is the time elapsed by each PipelineItem.
 	RunTimePerItem map[string]float64
+	// Cancelled indicates that the context of Pipeline.RunContext() was cancelled and
+	// the analysis stopped prematurely. CommitsNumber and EndTime reflect the consumed commits.
+	Cancelled bool
+	// TickMode is the calendar alignment of the ticks, empty if they have the fixed size.
+	// See FactTickMode.
+	TickMode string
+	// FiscalYearStart is the first month of the fiscal year of the quarter and year ticks,
+	// 0 otherwise. See FactFiscalYearStart.
+	FiscalYearStart int
 }
 
 // Copy produces a deep clone of the object.
@@ -215,7 +303,7 @@ func (car *CommonAnalysisResult) EndTimeAsTime() time.Time {
 
 // Merge combines the CommonAnalysisResult with an other one.
 // We choose the earlier BeginTime, the later EndTime, sum the number of commits and the
-// elapsed run times.
+// elapsed run times. The result is cancelled if any of the two is cancelled.
 func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	if car.EndTime == 0 || other.BeginTime == 0 {
 		panic("Merging with an uninitialized CommonAnalysisResult")
@@ -228,11 +316,33 @@ func (car *CommonAnalysisResult) Merge(other *CommonAnalysisResult) {
 	}
 	car.CommitsNumber += other.CommitsNumber
 	car.RunTime += other.RunTime
+	car.Cancelled = car.Cancelled || other.Cancelled
 	for key, val := range other.RunTimePerItem {
 		car.RunTimePerItem[key] += val
 	}
 }
 
+// CheckTicks returns an error if the ticks of the other CommonAnalysisResult are aligned
+// differently, so that the same tick index means different periods and the results
+// cannot be merged.
+func (car *CommonAnalysisResult) CheckTicks(other *CommonAnalysisResult) error {
+	if car.TickMode != other.TickMode || car.FiscalYearStart != other.FiscalYearStart {
+		return fmt.Errorf("mismatching tick modes (%s, %s) received",
+			car.tickModeString(), other.tickModeString())
+	}
+	return nil
+}
+
+func (car *CommonAnalysisResult) tickModeString() string {
+	switch {
+	case car.TickMode == "":
+		return "fixed"
+	case car.FiscalYearStart > 0:
+		return fmt.Sprintf("%s from month %d", car.TickMode, car.FiscalYearStart)
+	}
+	return car.TickMode
+}
+
 // FillMetadata copies the data to a Protobuf message.
 func (car *CommonAnalysisResult) FillMetadata(meta *pb.Metadata) *pb.Metadata {
 	meta.BeginUnixTime = car.BeginTime
@@ -240,6 +350,9 @@ func (car *CommonAnalysisResult) FillMetadata(meta *pb.Metadata) *pb.Metadata {
 	meta.Commits = int32(car.CommitsNumber)
 	meta.RunTime = car.RunTime.Nanoseconds() / 1e6
 	meta.RunTimePerItem = car.RunTimePerItem
+	meta.Partial = car.Cancelled
+	meta.TickMode = car.TickMode
+	meta.FiscalYearStart = int32(car.FiscalYearStart)
 	return meta
 }
 
@@ -249,11 +362,14 @@ type Metadata = pb.Metadata
 // MetadataToCommonAnalysisResult copies the data from a Protobuf message.
 func MetadataToCommonAnalysisResult(meta *Metadata) *CommonAnalysisResult {
 	return &CommonAnalysisResult{
-		BeginTime:      meta.BeginUnixTime,
-		EndTime:        meta.EndUnixTime,
-		CommitsNumber:  int(meta.Commits),
-		RunTime:        time.Duration(meta.RunTime * 1e6),
-		RunTimePerItem: meta.RunTimePerItem,
+		BeginTime:       meta.BeginUnixTime,
+		EndTime:         meta.EndUnixTime,
+		CommitsNumber:   int(meta.Commits),
+		RunTime:         time.Duration(meta.RunTime * 1e6),
+		RunTimePerItem:  meta.RunTimePerItem,
+		Cancelled:       meta.Partial,
+		TickMode:        meta.TickMode,
+		FiscalYearStart: int(meta.FiscalYearStart),
 	}
 }
 
@@ -265,10 +381,24 @@ type Pipeline struct {
 	// second is the total number of steps and the third is some description of the current action.
 	OnProgress func(int, int, string)
 
+	// ProgressReporter receives the detailed progress of Run(): the commit hash, the branch,
+	// the time spent in each item and the estimated remaining time. It is invoked after each
+	// step, in addition to OnProgress.
+	ProgressReporter ProgressReporter
+
 	// HibernationDistance is the minimum number of actions between two sequential usages of
 	// a branch to activate the hibernation optimization (cpu-memory trade-off). 0 disables.
 	HibernationDistance int
 
+	// MainlineOnly indicates whether the side branches are collapsed into their merge commits
+	// in the run plan. See ConfigPipelineMainlineOnly.
+	MainlineOnly bool
+
+	// CommitStride is the distance between the analysed mainline commits; the skipped commits
+	// are applied at once by the next analysed commit. 0 and 1 analyse every commit.
+	// See ConfigPipelineCommitStride.
+	CommitStride int
+
 	// DryRun indicates whether the items are not executed.
 	DryRun bool
 
@@ -278,9 +408,55 @@ type Pipeline struct {
 	// PrintActions indicates whether to print the taken actions during the execution.
 	PrintActions bool
 
+	// ContinueOnError indicates whether a failed Consume() should skip the rest of the commit
+	// instead of aborting Run(). The failures are available through Failures().
+	// Only the failed item and the items after it skip the commit: the items before it have
+	// already consumed the commit and are not rolled back, because Fork() does not copy the state
+	// of most items. Thus the downstream items see the next commit relative to the state
+	// of their upstream, e.g. the tree diff of the next commit is taken against the skipped one.
+	ContinueOnError bool
+
+	// CommitTimeout is the maximum duration of each Consume() call. The stuck items are reported
+	// together with the goroutine dump and fail with ErrConsumeTimeout. With ContinueOnError,
+	// the stuck item is abandoned: it and the items after it skip the rest of the commits and
+	// the abandoned leaves have no results. The ItemLocks of the stuck item are poisoned, so
+	// the other pipelines fail or abandon it as well. 0 disables. See ConfigPipelineCommitTimeout.
+	CommitTimeout time.Duration
+
+	// PartialResults indicates whether the cancelled Run() should finalize the leaves which
+	// consumed the commits so far instead of discarding them. See ConfigPipelinePartialResults.
+	PartialResults bool
+
+	// ItemLocks serializes the calls of the ThreadUnsafe items if several pipelines run
+	// at the same time, see MultiRepoRunner. The pipelines must share the same instance.
+	// nil disables the locking.
+	ItemLocks *ItemLocks
+
+	// PlanCache is the path to the file with the cached run plan which InitializeExt() loads
+	// instead of scheduling the commits, or saves if it is missing or stale. Empty disables.
+	// See ConfigPipelinePlanCache.
+	PlanCache string
+
+	// Checkpoint is the path to the file where the cancelled Run() saves the state of the items
+	// and the position in the run plan, so that the next run with Resume continues from there.
+	// The commit which is being analysed is finished first; if there are several open branches,
+	// the run continues until they merge. Empty disables. See ConfigPipelineCheckpoint.
+	Checkpoint string
+
+	// Resume indicates whether Run() restores the Checkpoint and skips the commits which it covers.
+	// The pipeline must be initialized the same way as in the run which saved the checkpoint.
+	// See ConfigPipelineResume.
+	Resume bool
+
+	// checkpointSaved is true if the latest Run() saved the Checkpoint.
+	checkpointSaved bool
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 
+	// shallow is the list of the boundary commits if the repository is a shallow clone.
+	shallow []plumbing.Hash
+
 	// Items are the registered building blocks in the pipeline. The order defines the
 	// execution sequence.
 	items []PipelineItem
@@ -288,8 +464,27 @@ type Pipeline struct {
 	// Feature flags which enable the corresponding items.
 	features map[string]bool
 
+	// disabledFeatures are the features which DisableFeature() switched off; SetFeature()
+	// does not enable them again.
+	disabledFeatures map[string]bool
+
 	preparedRun *preparedRun
 
+	// failures are the errors which were skipped due to ContinueOnError during the latest Run().
+	failures []*PipelineError
+
+	// facts are the configuration facts passed to the latest Initialize().
+	facts map[string]interface{}
+
+	// factProviders maps the items to the names of the facts which they set in Configure().
+	factProviders map[PipelineItem][]string
+
+	// initialFacts are the names of the facts passed to the latest Initialize().
+	initialFacts map[string]struct{}
+
+	// factHistory maps the facts to the items which set them during the latest Initialize().
+	factHistory map[string][]FactProvider
+
 	// The logger for printing output.
 	l Logger
 }
@@ -304,6 +499,10 @@ const (
 	// ConfigPipelineDAGPath is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which enables saving the items DAG to the specified file.
 	ConfigPipelineDAGPath = "Pipeline.DAGPath"
+	// ConfigPipelineDAGFormat is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which selects the GraphFormat of the DAG saved to ConfigPipelineDAGPath. If it is empty,
+	// the plain topological dump of the items and the entities is written.
+	ConfigPipelineDAGFormat = "Pipeline.DAGFormat"
 	// ConfigPipelineDryRun is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which disables Configure() and Initialize() invocation on each PipelineItem during the
 	// Pipeline initialization.
@@ -322,6 +521,50 @@ const (
 	// ConfigPipelinePrintActions is the name of the Pipeline configuration option (Pipeline.Initialize())
 	// which enables printing the taken actions of the execution plan to stderr.
 	ConfigPipelinePrintActions = "Pipeline.PrintActions"
+	// ConfigPipelineContinueOnError is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which makes Run() skip the commits on which some PipelineItem fails instead of aborting.
+	// See Pipeline.ContinueOnError for which items skip the commit.
+	ConfigPipelineContinueOnError = "Pipeline.ContinueOnError"
+	// ConfigPipelineMainlineOnly is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which collapses the side branches into their merge commits in the run plan: each merge
+	// is analysed as a single commit which squashes the merged branch. This shrinks the plan
+	// of the repositories with many short-lived branches; the lines are attributed to the authors
+	// of the merges instead of the authors of the squashed commits.
+	ConfigPipelineMainlineOnly = "Pipeline.MainlineOnly"
+	// ConfigPipelineCommitStride is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which analyses only every Nth mainline commit, implying ConfigPipelineMainlineOnly. The tree
+	// diff of each analysed commit includes the skipped commits, so the results are approximate:
+	// the lines are attributed to the authors of the analysed commits and the time resolution drops.
+	ConfigPipelineCommitStride = "Pipeline.CommitStride"
+	// ConfigPipelineCommitTimeout is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which limits the duration of each PipelineItem.Consume() call. The item which exceeds it is
+	// logged with the commit and the goroutine dump; then Run() either aborts or, if
+	// ConfigPipelineContinueOnError is set, abandons the item without waiting for it to return.
+	// The context in the deps of the stuck item is cancelled. The abandoned item and the items after it
+	// skip the rest of the commits, so that the stuck call never runs concurrently with another one.
+	ConfigPipelineCommitTimeout = "Pipeline.CommitTimeout"
+	// ConfigPipelinePartialResults is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which makes the cancelled Run() return the results of the commits consumed so far. They are
+	// available only if the run was cancelled between two commits and the hibernation is disabled.
+	ConfigPipelinePartialResults = "Pipeline.PartialResults"
+	// ConfigPipelinePlanCache is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the cached run plan. The plan is keyed by the repository HEAD,
+	// the commits and the plan options, so the cache is reused only while they stay the same.
+	ConfigPipelinePlanCache = "Pipeline.PlanCache"
+	// ConfigPipelineCheckpoint is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the checkpoint of the cancelled run. The checkpoint is saved only if
+	// every deployed item supports it, see CheckpointablePipelineItem, and the hibernation is disabled.
+	ConfigPipelineCheckpoint = "Pipeline.Checkpoint"
+	// ConfigPipelineResume is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which continues the run from ConfigPipelineCheckpoint.
+	ConfigPipelineResume = "Pipeline.Resume"
+	// ConfigPipelinePolicy is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which sets the path to the Policy file. The deployed analyses and the repository are checked
+	// against the rules of ConfigPipelineCaller before anything is configured.
+	ConfigPipelinePolicy = "Pipeline.Policy"
+	// ConfigPipelineCaller is the name of the Pipeline configuration option (Pipeline.Initialize())
+	// which identifies the caller in the Policy file.
+	ConfigPipelineCaller = "Pipeline.Caller"
 	// DependencyCommit is the name of one of the three items in `deps` supplied to PipelineItem.Consume()
 	// which always exists. It corresponds to the currently analyzed commit.
 	DependencyCommit = "commit"
@@ -332,6 +575,10 @@ const (
 	// which always exists. It indicates whether the analyzed commit is a merge commit.
 	// Checking the number of parents is not correct - we remove the back edges during the DAG simplification.
 	DependencyIsMerge = "is_merge"
+	// DependencyContext is the name of the item in `deps` supplied to PipelineItem.Consume()
+	// which carries the context.Context of Pipeline.RunContext(). Long loops inside Consume()
+	// should check it and return early if it is done. Use ContextFromDeps() to read it.
+	DependencyContext = "context"
 	// MessageFinalize is the status text reported before calling LeafPipelineItem.Finalize()-s.
 	MessageFinalize = "finalize"
 
@@ -342,12 +589,29 @@ const (
 
 // NewPipeline initializes a new instance of Pipeline struct.
 func NewPipeline(repository *git.Repository) *Pipeline {
-	return &Pipeline{
+	pipeline := &Pipeline{
 		repository: repository,
 		items:      []PipelineItem{},
 		features:   map[string]bool{},
 		l:          NewLogger(),
+
+		disabledFeatures: map[string]bool{},
 	}
+	if repository != nil {
+		if shallow, err := repository.Storer.Shallow(); err == nil && len(shallow) > 0 {
+			pipeline.shallow = shallow
+			pipeline.l.Warnf("the repository is a shallow clone, the history is truncated at %d "+
+				"commit(s) and the results are going to be incomplete; pass --auto-unshallow or run "+
+				"\"git fetch --unshallow\"", len(shallow))
+		}
+	}
+	return pipeline
+}
+
+// Shallow returns the boundary commits of the shallow clone which NewPipeline() detected,
+// that is, the commits whose parents are missing. It is empty if the history is complete.
+func (pipeline *Pipeline) Shallow() []plumbing.Hash {
+	return pipeline.shallow
 }
 
 // GetFeature returns the state of the feature with the specified name (enabled/disabled) and
@@ -358,13 +622,25 @@ func (pipeline *Pipeline) GetFeature(name string) (bool, bool) {
 }
 
 // SetFeature sets the value of the feature with the specified name.
+// It has no effect if the feature was disabled with DisableFeature().
 // See also: FeaturedPipelineItem.
 func (pipeline *Pipeline) SetFeature(name string) {
+	if pipeline.disabledFeatures[name] {
+		return
+	}
 	pipeline.features[name] = true
 }
 
+// DisableFeature switches off the feature with the specified name. Initialize() fails if some
+// deployed item depends on it.
+func (pipeline *Pipeline) DisableFeature(name string) {
+	pipeline.disabledFeatures[name] = true
+	pipeline.features[name] = false
+}
+
 // SetFeaturesFromFlags enables the features which were specified through the command line flags
-// which belong to the given PipelineItemRegistry instance.
+// which belong to the given PipelineItemRegistry instance, and then disables those which were
+// specified with --disable-feature.
 // See also: AddItem().
 func (pipeline *Pipeline) SetFeaturesFromFlags(registry ...*PipelineItemRegistry) {
 	var ffr *PipelineItemRegistry
@@ -378,6 +654,9 @@ func (pipeline *Pipeline) SetFeaturesFromFlags(registry ...*PipelineItemRegistry
 	for _, feature := range ffr.featureFlags.Flags {
 		pipeline.SetFeature(feature)
 	}
+	for _, feature := range ffr.disabledFeatureFlags.Flags {
+		pipeline.DisableFeature(feature)
+	}
 }
 
 // DeployItem inserts a PipelineItem into the pipeline. It also recursively creates all of it's
@@ -440,6 +719,92 @@ func (pipeline *Pipeline) deployItem(item PipelineItem, once bool) PipelineItem
 	return item
 }
 
+// DeployForFacts finds the registered items which provide the specified entities
+// (PipelineItem.Provides()) and deploys them together with their dependencies. The entities which
+// are already provided by the deployed items are skipped. If several items provide the same
+// entity, the one which pulls in the fewest new items is chosen; an equal choice is reported as
+// a conflict. Returns the newly deployed items which provide the requested entities.
+func (pipeline *Pipeline) DeployForFacts(requiredFacts []string) ([]PipelineItem, error) {
+	var deployed []PipelineItem
+	for _, fact := range requiredFacts {
+		provided := map[string]bool{}
+		names := map[string]bool{}
+		for _, item := range pipeline.items {
+			names[item.Name()] = true
+			for _, key := range item.Provides() {
+				provided[key] = true
+			}
+		}
+		if provided[fact] {
+			continue
+		}
+		var best []PipelineItem
+		bestCost := -1
+		for _, candidate := range Registry.Summon(fact) {
+			providesFact := false
+			for _, key := range candidate.Provides() {
+				if key == fact {
+					providesFact = true
+					break
+				}
+			}
+			if !providesFact {
+				continue
+			}
+			if !pipeline.featuresEnabled(candidate) {
+				continue
+			}
+			cost := 1
+			for _, dep := range Registry.CollectAllDependencies(candidate) {
+				if !pipeline.featuresEnabled(dep) {
+					cost = -1
+					break
+				}
+				if !names[dep.Name()] {
+					cost++
+				}
+			}
+			if cost < 0 {
+				continue
+			}
+			switch {
+			case bestCost < 0 || cost < bestCost:
+				best = []PipelineItem{candidate}
+				bestCost = cost
+			case cost == bestCost:
+				best = append(best, candidate)
+			}
+		}
+		switch len(best) {
+		case 0:
+			return deployed, fmt.Errorf("no enabled item provides %s", fact)
+		case 1:
+			deployed = append(deployed, pipeline.DeployItemOnce(best[0]))
+		default:
+			conflicts := make([]string, len(best))
+			for i, item := range best {
+				conflicts[i] = item.Name()
+			}
+			sort.Strings(conflicts)
+			return deployed, fmt.Errorf("%s is provided by several items, deploy one of them explicitly: %s",
+				fact, strings.Join(conflicts, ", "))
+		}
+	}
+	return deployed, nil
+}
+
+// featuresEnabled checks whether all the features of a FeaturedPipelineItem are enabled.
+func (pipeline *Pipeline) featuresEnabled(item PipelineItem) bool {
+	if fpi, ok := item.(FeaturedPipelineItem); ok {
+		for _, feature := range fpi.Features() {
+			if !pipeline.features[feature] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // AddItem inserts a PipelineItem into the pipeline. It does not check any dependencies.
 // See also: DeployItem().
 func (pipeline *Pipeline) AddItem(item PipelineItem) PipelineItem {
@@ -457,6 +822,17 @@ func (pipeline *Pipeline) RemoveItem(item PipelineItem) {
 	}
 }
 
+// Failures returns the errors which were recorded instead of aborting the latest Run()
+// because ContinueOnError was set. Each failure corresponds to a skipped commit.
+func (pipeline *Pipeline) Failures() []*PipelineError {
+	return pipeline.failures
+}
+
+// SavedCheckpoint returns true if the latest Run() was cancelled and saved the Checkpoint.
+func (pipeline *Pipeline) SavedCheckpoint() bool {
+	return pipeline.checkpointSaved
+}
+
 // Len returns the number of items in the pipeline.
 func (pipeline *Pipeline) Len() int {
 	return len(pipeline.items)
@@ -551,7 +927,73 @@ func (items sortablePipelineItems) Swap(i, j int) {
 	items[i], items[j] = items[j], items[i]
 }
 
+// checkConflicts rejects the deployed items which depend on the disabled features,
+// which conflict with the enabled features or with the other deployed items
+// (ConflictingPipelineItem), or whose dependencies are provided only by the registered items
+// which need the features that are not enabled.
+func (pipeline *Pipeline) checkConflicts() error {
+	deployed := map[string]bool{}
+	provided := map[string]bool{}
+	for _, item := range pipeline.items {
+		deployed[item.Name()] = true
+		for _, key := range item.Provides() {
+			provided[key] = true
+		}
+	}
+	for _, item := range pipeline.items {
+		if fpi, ok := item.(FeaturedPipelineItem); ok {
+			for _, feature := range fpi.Features() {
+				if pipeline.disabledFeatures[feature] {
+					return fmt.Errorf("%s depends on the disabled feature %s", item.Name(), feature)
+				}
+			}
+		}
+		if cpi, ok := item.(ConflictingPipelineItem); ok {
+			for _, name := range cpi.ConflictsWith() {
+				if pipeline.features[name] {
+					return fmt.Errorf("%s conflicts with the enabled feature %s", item.Name(), name)
+				}
+				if deployed[name] {
+					return fmt.Errorf("%s conflicts with %s", item.Name(), name)
+				}
+			}
+		}
+		for _, key := range item.Requires() {
+			if provided[key] {
+				continue
+			}
+			var missing []string
+			enabled := false
+			for _, candidate := range Registry.Summon(key) {
+				disabled := ""
+				if fpi, ok := candidate.(FeaturedPipelineItem); ok {
+					for _, feature := range fpi.Features() {
+						if !pipeline.features[feature] {
+							disabled = feature
+							break
+						}
+					}
+				}
+				if disabled == "" {
+					enabled = true
+					break
+				}
+				missing = append(missing, fmt.Sprintf("%s (feature %s)", candidate.Name(), disabled))
+			}
+			if !enabled && len(missing) > 0 {
+				return fmt.Errorf("%s requires %s which is provided only by %s",
+					item.Name(), key, strings.Join(missing, ", "))
+			}
+		}
+	}
+	return nil
+}
+
 func (pipeline *Pipeline) resolve(dumpPath string, priorityFn DependencyPriorityFunc) error {
+	if err := pipeline.checkConflicts(); err != nil {
+		pipeline.l.Critical(err)
+		return err
+	}
 	sort.Sort(sortablePipelineItems(pipeline.items))
 
 	name2item := make(map[string]PipelineItem, len(pipeline.items))
@@ -776,6 +1218,13 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 		}
 	}()
 
+	deprecationWarnings := Deprecations.UpgradeFacts(facts)
+	pipeline.initialFacts = make(map[string]struct{}, len(facts))
+	for key := range facts {
+		pipeline.initialFacts[key] = struct{}{}
+	}
+	pipeline.factHistory = map[string][]FactProvider{}
+
 	// set logger from facts, otherwise set the pipeline's logger as the logger
 	// to be used by all analysis tasks by setting the fact
 	if l, exists := facts[ConfigLogger].(Logger); exists {
@@ -783,8 +1232,17 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 	} else {
 		facts[ConfigLogger] = pipeline.l
 	}
+	for _, warning := range deprecationWarnings {
+		pipeline.l.Warn(warning)
+	}
 
 	pipeline.PrintActions, _ = facts[ConfigPipelinePrintActions].(bool)
+	if val, exists := facts[ConfigPipelineContinueOnError].(bool); exists {
+		pipeline.ContinueOnError = val
+	}
+	if val, exists := facts[ConfigPipelinePartialResults].(bool); exists {
+		pipeline.PartialResults = val
+	}
 	if val, exists := facts[ConfigPipelineHibernationDistance].(int); exists {
 		if val < 0 {
 			err := fmt.Errorf("--hibernation-distance cannot be negative (got %d)", val)
@@ -793,8 +1251,63 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 		}
 		pipeline.HibernationDistance = val
 	}
+	if val, exists := facts[ConfigPipelineMainlineOnly].(bool); exists {
+		pipeline.MainlineOnly = val
+	}
+	if val, exists := facts[ConfigPipelineCommitStride].(int); exists {
+		if val < 0 {
+			err := fmt.Errorf("--stride cannot be negative (got %d)", val)
+			pipeline.l.Error(err)
+			return err
+		}
+		pipeline.CommitStride = val
+	}
+	if val, exists := facts[ConfigPipelineCommitTimeout].(time.Duration); exists {
+		if val < 0 {
+			err := fmt.Errorf("--commit-timeout cannot be negative (got %v)", val)
+			pipeline.l.Error(err)
+			return err
+		}
+		pipeline.CommitTimeout = val
+	}
+	if val, exists := facts[ConfigPipelinePlanCache].(string); exists {
+		pipeline.PlanCache = val
+	}
+	if val, exists := facts[ConfigPipelineCheckpoint].(string); exists {
+		pipeline.Checkpoint = val
+	}
+	if val, exists := facts[ConfigPipelineResume].(bool); exists {
+		pipeline.Resume = val
+	}
+	if pipeline.Resume && pipeline.Checkpoint == "" {
+		err := errors.New("--resume requires --checkpoint")
+		pipeline.l.Error(err)
+		return err
+	}
+	if path, exists := facts[ConfigPipelinePolicy].(string); exists && path != "" {
+		caller, _ := facts[ConfigPipelineCaller].(string)
+		if err := pipeline.enforcePolicy(path, caller, facts); err != nil {
+			pipeline.l.Error(err)
+			return err
+		}
+	}
+	pipeline.facts = facts
+	pipeline.factProviders = map[PipelineItem][]string{}
 	dumpPath, _ := facts[ConfigPipelineDAGPath].(string)
-	if err := pipeline.resolve(dumpPath, priorityFn); err != nil {
+	dumpFormat, _ := facts[ConfigPipelineDAGFormat].(string)
+	switch GraphFormat(dumpFormat) {
+	case "", GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON:
+	default:
+		err := fmt.Errorf("unsupported --dag-format %q, must be one of dot, mermaid, json", dumpFormat)
+		pipeline.l.Error(err)
+		return err
+	}
+	if dumpFormat != "" {
+		// the graph is exported after Configure() to include the fact providers
+		if err := pipeline.resolve("", priorityFn); err != nil {
+			return err
+		}
+	} else if err := pipeline.resolve(dumpPath, priorityFn); err != nil {
 		return err
 	}
 
@@ -813,13 +1326,31 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 
 	planCooker := func() {
 		if commits, ok := facts[ConfigPipelineCommits].([]*object.Commit); ok {
+			if pipeline.PlanCache != "" {
+				err := pipeline.LoadPlan(pipeline.PlanCache)
+				if err == nil {
+					pipeline.l.Infof("loaded the run plan from %s", pipeline.PlanCache)
+					return
+				}
+				if !os.IsNotExist(err) {
+					pipeline.l.Warnf("failed to load the run plan: %v", err)
+				}
+			}
 			var prepared preparedRun
-			prepared.commitCount = len(commits)
-			prepared.plan, prepared.mergeHashCount = prepareRunPlan(commits, pipeline.HibernationDistance, mergeTracks)
+			prepared.commitCount = len(filterRunPlanCommits(
+				commits, pipeline.MainlineOnly, pipeline.CommitStride))
+			prepared.plan, prepared.mergeHashCount = prepareRunPlan(
+				commits, pipeline.HibernationDistance, mergeTracks, pipeline.MainlineOnly,
+				pipeline.CommitStride)
 			if mergeTracks {
 				facts[FactMergeHashCount] = prepared.mergeHashCount
 			}
 			pipeline.preparedRun = &prepared
+			if pipeline.PlanCache != "" {
+				if err := pipeline.SavePlan(pipeline.PlanCache); err != nil {
+					pipeline.l.Warnf("%v", err)
+				}
+			}
 			return
 		}
 		pipeline.preparedRun = nil
@@ -831,14 +1362,24 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 
 	if pipeline.DryRun {
 		cleanReturn = true
+		if dumpFormat != "" {
+			return pipeline.dumpGraph(dumpPath, GraphFormat(dumpFormat))
+		}
 		return nil
 	}
 
 	for _, item := range pipeline.items {
-		if err := item.Configure(facts); err != nil {
+		snapshot := copyFacts(facts)
+		unlock := pipeline.lockSetup(item)
+		err := item.Configure(facts)
+		unlock()
+		if err != nil {
 			cleanReturn = true
 			return errors.Wrapf(err, "%s failed to configure", item.Name())
 		}
+		if provided := pipeline.recordFactChanges(item, FactStageConfigure, snapshot, facts); len(provided) > 0 {
+			pipeline.factProviders[item] = provided
+		}
 	}
 
 	if pipeline.preparedRun == nil && preparePlan {
@@ -850,14 +1391,21 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 
 	for i := len(pipeline.items) - 1; i >= 0; i-- {
 		item := pipeline.items[i]
-		if err := item.ConfigureUpstream(facts); err != nil {
+		snapshot := copyFacts(facts)
+		unlock := pipeline.lockSetup(item)
+		err := item.ConfigureUpstream(facts)
+		unlock()
+		if err != nil {
 			cleanReturn = true
 			return errors.Wrapf(err, "%s failed to configure upstream", item.Name())
 		}
+		pipeline.recordFactChanges(item, FactStageConfigureUpstream, snapshot, facts)
 	}
 
 	for _, item := range pipeline.items {
+		unlock := pipeline.lockSetup(item)
 		err := item.Initialize(pipeline.repository)
+		unlock()
 		if err != nil {
 			cleanReturn = true
 			return errors.Wrapf(err, "%s failed to initialize", item.Name())
@@ -868,9 +1416,22 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 		debug.SetGCPercent(20) // the default is 100
 	}
 	cleanReturn = true
+	if dumpFormat != "" {
+		return pipeline.dumpGraph(dumpPath, GraphFormat(dumpFormat))
+	}
 	return nil
 }
 
+// ContextFromDeps returns the context.Context which Pipeline.RunContext() passes
+// to PipelineItem.Consume() as DependencyContext. If there is none, e.g. when Consume()
+// is called directly, it returns context.Background().
+func ContextFromDeps(deps map[string]interface{}) context.Context {
+	if ctx, ok := deps[DependencyContext].(context.Context); ok && ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
 // Run method executes the pipeline.
 //
 // `commits` is a slice with the git commits to analyse. Multiple branches are supported.
@@ -878,20 +1439,37 @@ func (pipeline *Pipeline) InitializeExt(facts map[string]interface{},
 // Returns the mapping from each LeafPipelineItem to the corresponding analysis result.
 // There is always a "nil" record with CommonAnalysisResult.
 func (pipeline *Pipeline) Run(commits []*object.Commit) (map[LeafPipelineItem]interface{}, error) {
-	plan, _ := prepareRunPlan(commits, pipeline.HibernationDistance, false)
-	return pipeline.runPlan(plan, len(commits), -1)
+	return pipeline.RunContext(context.Background(), commits)
+}
+
+// RunContext is the same as Run() but stops as soon as `ctx` is done. In that case
+// it returns only the "nil" record with the partial CommonAnalysisResult which has Cancelled set,
+// together with the error of the context. If PartialResults is set, the leaves of the main branch
+// are finalized as well, provided that no commit was interrupted halfway.
+func (pipeline *Pipeline) RunContext(ctx context.Context, commits []*object.Commit) (map[LeafPipelineItem]interface{}, error) {
+	plan, _ := prepareRunPlan(commits, pipeline.HibernationDistance, false, pipeline.MainlineOnly,
+		pipeline.CommitStride)
+	commitCount := len(filterRunPlanCommits(commits, pipeline.MainlineOnly, pipeline.CommitStride))
+	return pipeline.runPlan(ctx, plan, commitCount, -1)
 }
 
+// RunPreparedPlan executes the run plan which Initialize() prepared.
 func (pipeline *Pipeline) RunPreparedPlan() (map[LeafPipelineItem]interface{}, error) {
+	return pipeline.RunPreparedPlanContext(context.Background())
+}
+
+// RunPreparedPlanContext is the same as RunPreparedPlan() but supports cancellation
+// the same way as RunContext().
+func (pipeline *Pipeline) RunPreparedPlanContext(ctx context.Context) (map[LeafPipelineItem]interface{}, error) {
 	prepared := pipeline.preparedRun
 	pipeline.preparedRun = nil
 	if prepared == nil {
 		return nil, fmt.Errorf("run plan was not prepared")
 	}
-	return pipeline.runPlan(prepared.plan, prepared.commitCount, prepared.mergeHashCount)
+	return pipeline.runPlan(ctx, prepared.plan, prepared.commitCount, prepared.mergeHashCount)
 }
 
-func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCount int) (map[LeafPipelineItem]interface{}, error) {
+func (pipeline *Pipeline) runPlan(ctx context.Context, plan []runAction, commitCount int, mergeHashCount int) (map[LeafPipelineItem]interface{}, error) {
 	startRunTime := time.Now()
 	cleanReturn := false
 	defer func() {
@@ -902,11 +1480,6 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 			}
 		}
 	}()
-	onProgress := pipeline.OnProgress
-	if onProgress == nil {
-		onProgress = func(int, int, string) {}
-	}
-
 	if pipeline.DumpPlan {
 		for _, p := range plan {
 			printAction(p)
@@ -914,6 +1487,9 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 	}
 
 	progressSteps := len(plan) + 2
+	progress := newProgressTracker(pipeline, progressSteps, startRunTime)
+	pipeline.failures = nil
+	pipeline.checkpointSaved = false
 	branches := map[int][]PipelineItem{}
 
 	// we will need rootClone if there is more than one root branch
@@ -924,6 +1500,15 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 	var newestTime int64
 	runTimePerItem := map[string]float64{}
 
+	// abandon replaces the item in all the branches after its Consume() has got stuck.
+	// Fork() may have put the same instance in several branches, see ForkSamePipelineItem().
+	abandon := func(item PipelineItem) {
+		for key, branch := range branches {
+			branches[key] = abandonItem(branch, item)
+		}
+		rootClone = abandonItem(rootClone, item)
+	}
+
 	isMerge := func(index int, commit plumbing.Hash) bool {
 		// look for the same hash forward
 		for i := index + 1; i < len(plan); i++ {
@@ -941,11 +1526,120 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 	}
 
 	commitIndex := 0
+	var lastCommit plumbing.Hash
+	// saveState is true if the cancelled run saves Pipeline.Checkpoint
+	saveState := pipeline.Checkpoint != "" && !pipeline.DryRun
+	if saveState {
+		if names := uncheckpointableItems(pipeline.items); len(names) > 0 {
+			pipeline.l.Warnf("the checkpoint is disabled: %s cannot save the state\n", strings.Join(names, ", "))
+			saveState = false
+		} else if pipeline.HibernationDistance > 0 {
+			pipeline.l.Warn("the checkpoint is disabled together with the hibernation")
+			saveState = false
+		}
+	}
+	// consumeCtx lets the current commit finish after the cancellation if the checkpoint is saved
+	consumeCtx := ctx
+	if saveState {
+		consumeCtx = context.Background()
+	}
+	// cancel returns the partial results; `consistent` is false if some items have consumed
+	// the current commit and the others have not; `step` is the first step which was not executed
+	cancel := func(consistent bool, step int) (map[LeafPipelineItem]interface{}, error) {
+		pipeline.l.Warnf("the analysis was cancelled after %d commits: %v\n", commitIndex, ctx.Err())
+		cleanReturn = true
+		if master, ok := branches[rootBranchIndex]; ok && consistent && saveState && len(branches) == 1 {
+			err := pipeline.saveCheckpoint(&checkpoint{
+				Step:           step,
+				Commit:         lastCommit,
+				CommitIndex:    commitIndex,
+				NewestTime:     newestTime,
+				RunTime:        time.Since(startRunTime),
+				RunTimePerItem: runTimePerItem,
+			}, plan, master)
+			if err != nil {
+				pipeline.l.Warn(err)
+			} else {
+				pipeline.checkpointSaved = true
+				pipeline.l.Infof("saved the checkpoint after commit #%d %s to %s",
+					commitIndex, lastCommit.String(), pipeline.Checkpoint)
+			}
+		}
+		result := map[LeafPipelineItem]interface{}{}
+		if pipeline.PartialResults {
+			if master, ok := branches[rootBranchIndex]; ok && consistent && pipeline.HibernationDistance == 0 {
+				progress.Step(len(plan)+1, MessageFinalize)
+				for index, item := range master {
+					if casted, ok := item.(DisposablePipelineItem); ok {
+						casted.Dispose()
+					}
+					if casted, ok := item.(LeafPipelineItem); ok {
+						if finalized, ok := pipeline.finalize(casted); ok {
+							result[pipeline.items[index].(LeafPipelineItem)] = finalized
+						}
+					}
+				}
+			} else {
+				pipeline.l.Warn("the partial results are not available: the cancellation interrupted " +
+					"a commit, the main branch has not started yet or the hibernation is enabled")
+			}
+		}
+		result[nil] = &CommonAnalysisResult{
+			BeginTime:      plan[0].Commit.Committer.When.Unix(),
+			EndTime:        newestTime,
+			CommitsNumber:  commitIndex,
+			RunTime:        time.Since(startRunTime),
+			RunTimePerItem: runTimePerItem,
+			Cancelled:      true,
+		}
+		pipeline.fillTicks(result[nil].(*CommonAnalysisResult))
+		return result, ctx.Err()
+	}
+	startStep := 0
+	if pipeline.Resume && !pipeline.DryRun {
+		cp, err := pipeline.loadCheckpoint(plan)
+		if err != nil {
+			pipeline.l.Error(err)
+			return nil, err
+		}
+		branches[rootBranchIndex] = pipeline.items
+		startStep, lastCommit, commitIndex, newestTime = cp.Step, cp.Commit, cp.CommitIndex, cp.NewestTime
+		runTimePerItem = cp.RunTimePerItem
+		startRunTime = startRunTime.Add(-cp.RunTime)
+		pipeline.l.Infof("resumed after commit #%d %s\n", commitIndex, lastCommit.String())
+	}
+	finishing := false
 	for index, step := range plan {
-		onProgress(index+1, progressSteps, step.String())
+		if index < startStep {
+			continue
+		}
+		action := step.String()
+		var commitHash plumbing.Hash
+		if step.Action == runActionCommit {
+			commitHash = step.Commit.Hash
+		}
+		progress.Begin(index+1, action)
 		if pipeline.DryRun {
+			// nothing is executed, so the reporters receive the step without the timings
+			progress.End(index+1, action, commitHash, step.Items[0], nil)
 			continue
 		}
+		if ctx.Err() != nil {
+			if !saveState || len(branches) == 0 {
+				return cancel(true, index)
+			}
+			if _, ok := branches[rootBranchIndex]; ok && len(branches) == 1 {
+				return cancel(true, index)
+			}
+			if !finishing {
+				pipeline.l.Warnf("finishing the %d open branches to save the checkpoint\n", len(branches))
+				finishing = true
+			}
+		}
+		var itemTimes map[string]time.Duration
+		if progress.Detailed() && step.Action == runActionCommit {
+			itemTimes = make(map[string]time.Duration, len(branches[step.Items[0]]))
+		}
 		if pipeline.PrintActions {
 			printAction(step)
 		}
@@ -959,25 +1653,59 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 				DependencyCommit:  step.Commit,
 				DependencyIndex:   commitIndex,
 				DependencyIsMerge: isMerge(index, step.Commit.Hash),
+				DependencyContext: consumeCtx,
 			}
 
 			if mergeHashCount >= 0 {
 				state[DependencyNextMerge] = step.NextMerge
 			}
 
+		consumeLoop:
 			for _, item := range branches[firstItem] {
+				if isAbandoned(item) {
+					// the stuck Consume() may still be running, so the item and the items after it
+					// skip the rest of the commits
+					break consumeLoop
+				}
 				startTime := time.Now()
-				update, err := item.Consume(state)
-				runTimePerItem[item.Name()] += time.Now().Sub(startTime).Seconds()
+				update, err := pipeline.consumeWithTimeout(consumeCtx, item, state, step.Commit.Hash)
+				elapsed := time.Now().Sub(startTime)
+				runTimePerItem[item.Name()] += elapsed.Seconds()
+				if itemTimes != nil {
+					itemTimes[item.Name()] += elapsed
+				}
 				if err != nil {
-					pipeline.l.Errorf("%s failed on commit #%d (%d) %s: %v\n",
-						item.Name(), commitIndex+1, index+1, step.Commit.Hash.String(), err)
-					return nil, err
+					if ctx.Err() != nil {
+						return cancel(false, index)
+					}
+					pipelineErr := &PipelineError{
+						Item: item.Name(), Commit: step.Commit.Hash, CommitIndex: commitIndex,
+						Branch: firstItem, Step: index, Err: err,
+					}
+					if pipeline.ContinueOnError {
+						// the previous items have consumed the commit and keep it
+						if err == ErrConsumeTimeout {
+							pipeline.l.Warnf("%v; abandoning %s: it and the items after it skip "+
+								"the rest of the commits\n", pipelineErr, item.Name())
+							abandon(item)
+						} else {
+							pipeline.l.Warnf("%v; skipping the commit in %s and the items after it\n",
+								pipelineErr, item.Name())
+						}
+						pipeline.failures = append(pipeline.failures, pipelineErr)
+						break consumeLoop
+					}
+					pipeline.l.Error(pipelineErr)
+					return nil, pipelineErr
 				}
 				for _, key := range item.Provides() {
 					val, ok := update[key]
 					if !ok {
-						err := fmt.Errorf("%s: Consume() did not return %s", item.Name(), key)
+						err := &PipelineError{
+							Item: item.Name(), Commit: step.Commit.Hash, CommitIndex: commitIndex,
+							Branch: firstItem, Step: index,
+							Err: fmt.Errorf("Consume() did not return %s", key),
+						}
 						pipeline.l.Critical(err)
 						return nil, err
 					}
@@ -988,6 +1716,7 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 			if commitTime > newestTime {
 				newestTime = commitTime
 			}
+			lastCommit = step.Commit.Hash
 			commitIndex++
 		case runActionFork:
 			startTime := time.Now()
@@ -997,6 +1726,20 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 			runTimePerItem["*.Fork"] += time.Now().Sub(startTime).Seconds()
 		case runActionMerge:
 			startTime := time.Now()
+			// the item abandoned in any of the merged branches is abandoned in the result
+			for i := range branches[firstItem] {
+				for _, b := range step.Items {
+					if !isAbandoned(branches[b][i]) {
+						continue
+					}
+					for _, other := range step.Items {
+						if item := branches[other][i]; !isAbandoned(item) {
+							abandon(item)
+						}
+					}
+					break
+				}
+			}
 			merged := make([][]PipelineItem, len(step.Items))
 			for i, b := range step.Items {
 				merged[i] = branches[b]
@@ -1018,8 +1761,12 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 						startTime := time.Now()
 						err := hi.Hibernate()
 						if err != nil {
-							pipeline.l.Errorf("Failed to hibernate %s: %v\n", item.Name(), err)
-							return nil, err
+							pipelineErr := &PipelineError{
+								Item: item.Name(), Branch: firstItem, Step: index,
+								Err: errors.Wrap(err, "failed to hibernate"),
+							}
+							pipeline.l.Error(pipelineErr)
+							return nil, pipelineErr
 						}
 						runTimePerItem[item.Name()+".Hibernation"] += time.Now().Sub(startTime).Seconds()
 					}
@@ -1032,16 +1779,21 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 						startTime := time.Now()
 						err := hi.Boot()
 						if err != nil {
-							pipeline.l.Errorf("Failed to boot %s: %v\n", item.Name(), err)
-							return nil, err
+							pipelineErr := &PipelineError{
+								Item: item.Name(), Branch: firstItem, Step: index,
+								Err: errors.Wrap(err, "failed to boot"),
+							}
+							pipeline.l.Error(pipelineErr)
+							return nil, pipelineErr
 						}
 						runTimePerItem[item.Name()+".Hibernation"] += time.Now().Sub(startTime).Seconds()
 					}
 				}
 			}
 		}
+		progress.End(index+1, action, commitHash, firstItem, itemTimes)
 	}
-	onProgress(len(plan)+1, progressSteps, MessageFinalize)
+	progress.Step(len(plan)+1, MessageFinalize)
 	result := map[LeafPipelineItem]interface{}{}
 	if !pipeline.DryRun {
 		for index, item := range getMasterBranch(branches) {
@@ -1049,11 +1801,13 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 				casted.Dispose()
 			}
 			if casted, ok := item.(LeafPipelineItem); ok {
-				result[pipeline.items[index].(LeafPipelineItem)] = casted.Finalize()
+				if finalized, ok := pipeline.finalize(casted); ok {
+					result[pipeline.items[index].(LeafPipelineItem)] = finalized
+				}
 			}
 		}
 	}
-	onProgress(progressSteps, progressSteps, "")
+	progress.Step(progressSteps, "")
 	result[nil] = &CommonAnalysisResult{
 		BeginTime:      plan[0].Commit.Committer.When.Unix(),
 		EndTime:        newestTime,
@@ -1061,10 +1815,113 @@ func (pipeline *Pipeline) runPlan(plan []runAction, commitCount int, mergeHashCo
 		RunTime:        time.Since(startRunTime),
 		RunTimePerItem: runTimePerItem,
 	}
+	pipeline.fillTicks(result[nil].(*CommonAnalysisResult))
 	cleanReturn = true
 	return result, nil
 }
 
+// fillTicks copies the alignment of the ticks from the facts.
+func (pipeline *Pipeline) fillTicks(car *CommonAnalysisResult) {
+	car.TickMode, _ = pipeline.facts[FactTickMode].(string)
+	car.FiscalYearStart, _ = pipeline.facts[FactFiscalYearStart].(int)
+}
+
+// lockSetup takes the ItemLocks for the calls which precede the commits, e.g. Initialize().
+// The item whose lock is poisoned is set up without the lock: its first Consume() fails
+// with ErrConsumeTimeout anyway, see ItemLocks.Poison().
+func (pipeline *Pipeline) lockSetup(item PipelineItem) (unlock func()) {
+	unlock, err := pipeline.ItemLocks.Lock(context.Background(), item)
+	if err != nil {
+		return func() {}
+	}
+	return unlock
+}
+
+// finalize calls leaf.Finalize() under the ItemLocks. The leaf has no result if another
+// pipeline has abandoned it with its lock, see ItemLocks.Poison().
+func (pipeline *Pipeline) finalize(leaf LeafPipelineItem) (interface{}, bool) {
+	unlock, err := pipeline.ItemLocks.Lock(context.Background(), leaf)
+	if err != nil {
+		pipeline.l.Warnf("%s was abandoned in another pipeline and has no results\n", leaf.Name())
+		return nil, false
+	}
+	defer unlock()
+	return leaf.Finalize(), true
+}
+
+// consumeWithTimeout calls item.Consume() and gives up after CommitTimeout, which includes
+// the wait for the ItemLocks. The item receives a copy of the state with its own context
+// which is cancelled on timeout. The ItemLocks are held until Consume() returns, even if
+// we give up waiting, so the lock of the stuck item is poisoned.
+func (pipeline *Pipeline) consumeWithTimeout(ctx context.Context, item PipelineItem,
+	state map[string]interface{}, commit plumbing.Hash,
+) (map[string]interface{}, error) {
+	if pipeline.CommitTimeout <= 0 {
+		unlock, err := pipeline.ItemLocks.Lock(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		return item.Consume(state)
+	}
+	deadline := time.Now().Add(pipeline.CommitTimeout)
+	lockCtx, cancelLock := context.WithDeadline(ctx, deadline)
+	defer cancelLock()
+	unlock, err := pipeline.ItemLocks.Lock(lockCtx, item)
+	if err != nil {
+		if err == context.DeadlineExceeded && ctx.Err() == nil {
+			pipeline.l.Errorf("%s has been waiting for the lock at commit %s for more than %v\n",
+				item.Name(), commit.String(), pipeline.CommitTimeout)
+			return nil, ErrConsumeTimeout
+		}
+		return nil, err
+	}
+	itemCtx, cancelItem := context.WithCancel(ctx)
+	defer cancelItem()
+	// the stuck item may still read its deps after we abort, so it must not share the map
+	itemState := make(map[string]interface{}, len(state))
+	for key, val := range state {
+		itemState[key] = val
+	}
+	itemState[DependencyContext] = itemCtx
+	type consumeResult struct {
+		update map[string]interface{}
+		err    error
+	}
+	done := make(chan consumeResult, 1)
+	go func() {
+		defer unlock()
+		update, err := item.Consume(itemState)
+		done <- consumeResult{update, err}
+	}()
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case result := <-done:
+		return result.update, result.err
+	case <-timer.C:
+	}
+	cancelItem()
+	// the other pipelines must not wait for the lock which the stuck call may never release
+	pipeline.ItemLocks.Poison(item)
+	pipeline.l.Errorf("%s has been consuming commit %s for more than %v; goroutines:\n%s\n",
+		item.Name(), commit.String(), pipeline.CommitTimeout, dumpGoroutines())
+	// we do not wait: nothing guarantees that the item honours the cancelled context
+	return nil, ErrConsumeTimeout
+}
+
+// dumpGoroutines returns the stack traces of all the goroutines.
+func dumpGoroutines() []byte {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
 func (pipeline *Pipeline) resolveAlternatives(graph *toposort.Graph, nodes []string, itemMap map[string]PipelineItem,
 	priorityFn DependencyPriorityFunc, excludes map[string]struct{},
 ) {
@@ -2,10 +2,12 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"os"
 	"testing"
 	"time"
@@ -269,6 +271,57 @@ func TestPipelineFeatures(t *testing.T) {
 	})
 }
 
+func TestPipelineDisableFeature(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.SetFeature("power")
+	pipeline.DisableFeature("power")
+	val, exists := pipeline.GetFeature("power")
+	assert.False(t, val)
+	assert.True(t, exists)
+	pipeline.SetFeature("power")
+	val, _ = pipeline.GetFeature("power")
+	assert.False(t, val)
+	pipeline.AddItem(&testPipelineItem{})
+	err := pipeline.Initialize(map[string]interface{}{})
+	assert.EqualError(t, err, "Test depends on the disabled feature power")
+}
+
+// conflictingTestPipelineItem is testPipelineItem which conflicts with a feature and an item.
+type conflictingTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *conflictingTestPipelineItem) ConflictsWith() []string {
+	return []string{"conflict", "Test2"}
+}
+
+func TestPipelineConflictsWith(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&conflictingTestPipelineItem{})
+	pipeline.SetFeature("power")
+	pipeline.SetFeature("conflict")
+	err := pipeline.Initialize(map[string]interface{}{})
+	assert.EqualError(t, err, "Test conflicts with the enabled feature conflict")
+
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(&conflictingTestPipelineItem{})
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	pipeline.SetFeature("power")
+	err = pipeline.Initialize(map[string]interface{}{})
+	assert.EqualError(t, err, "Test conflicts with Test2")
+}
+
+func TestPipelineRequirementFeatureDisabled(t *testing.T) {
+	backup := Registry
+	defer func() { Registry = backup }()
+	Registry = getRegistry()
+	Registry.Register(&testPipelineItem{})
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&dependingTestPipelineItem{})
+	err := pipeline.Initialize(map[string]interface{}{})
+	assert.EqualError(t, err, "Test2 requires test which is provided only by Test (feature power)")
+}
+
 func TestPipelineErrors(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item := &testPipelineItem{}
@@ -381,6 +434,24 @@ func TestPipelineRunBranches(t *testing.T) {
 	assert.Equal(t, 6, *item.MergeState)
 }
 
+func TestPipelineRunCorpusBranches(t *testing.T) {
+	corpus, err := test.GenerateCorpus(test.CorpusOptions{Commits: 8, Branches: 2})
+	require.NoError(t, err)
+	commits, err := corpus.CommitObjects()
+	require.NoError(t, err)
+	pipeline := NewPipeline(corpus.Repository)
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	result, err := pipeline.Run(commits)
+	require.NoError(t, err)
+	assert.True(t, item.Forked)
+	assert.True(t, *item.Merged)
+	assert.Equal(t, 12, result[nil].(*CommonAnalysisResult).CommitsNumber)
+	// each commit is consumed once and the merges count twice
+	assert.Equal(t, 12+2, *item.MergeState)
+}
+
 func TestPipelineOnProgress(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	progressOk := 0
@@ -410,6 +481,54 @@ func TestPipelineOnProgress(t *testing.T) {
 	assert.Equal(t, 4, progressOk)
 }
 
+func TestPipelineProgressReporter(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &testPipelineItem{}
+	pipeline.AddItem(item)
+	assert.Nil(t, pipeline.Initialize(map[string]interface{}{}))
+	var events []ProgressEvent
+	pipeline.ProgressReporter = ProgressReporterFunc(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	_, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, events, 4)
+	assert.Equal(t, "emerge", events[0].Action)
+	assert.Equal(t, 1, events[0].Branch)
+	assert.Equal(t, "af9ddc0", events[1].Action)
+	assert.Equal(t, commits[0].Hash, events[1].Commit)
+	assert.Contains(t, events[1].ItemTimes, item.Name())
+	assert.Equal(t, MessageFinalize, events[2].Action)
+	assert.Equal(t, 4, events[3].Step)
+	assert.Equal(t, 4, events[3].Total)
+	assert.Equal(t, time.Duration(0), events[3].ETA)
+}
+
+func TestPipelineDryRunProgressReporter(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	pipeline.DryRun = true
+	assert.Nil(t, pipeline.Initialize(map[string]interface{}{}))
+	var begun, ended []int
+	pipeline.OnProgress = func(step, total int, action string) {
+		begun = append(begun, step)
+	}
+	pipeline.ProgressReporter = ProgressReporterFunc(func(event ProgressEvent) {
+		assert.Nil(t, event.ItemTimes)
+		ended = append(ended, event.Step)
+	})
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	_, err = pipeline.Run(commits[:3])
+	require.NoError(t, err)
+	// every step which began also ends, although nothing is executed
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, ended)
+	assert.Equal(t, begun, ended)
+}
+
 func TestPipelineCommitsFull(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	commits, err := pipeline.Commits(false)
@@ -513,6 +632,46 @@ func TestPipelineDeployFeatures(t *testing.T) {
 	assert.True(t, f)
 }
 
+// alternativeTestPipelineItem provides the same entity as testPipelineItem.
+type alternativeTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *alternativeTestPipelineItem) Name() string {
+	return "TestAlt"
+}
+
+func TestPipelineDeployForFacts(t *testing.T) {
+	backup := Registry
+	defer func() { Registry = backup }()
+	Registry = getRegistry()
+	Registry.Register(&testPipelineItem{})
+	Registry.Register(&dependingTestPipelineItem{})
+
+	pipeline := NewPipeline(test.Repository)
+	_, err := pipeline.DeployForFacts([]string{"test2"})
+	assert.Error(t, err) // "power" is not enabled
+	assert.Equal(t, 0, pipeline.Len())
+
+	pipeline.SetFeature("power")
+	deployed, err := pipeline.DeployForFacts([]string{"test2", "test"})
+	assert.NoError(t, err)
+	require.Len(t, deployed, 1)
+	assert.Equal(t, "Test2", deployed[0].Name())
+	assert.Equal(t, 2, pipeline.Len())
+
+	_, err = pipeline.DeployForFacts([]string{"missing"})
+	assert.EqualError(t, err, "no enabled item provides missing")
+
+	Registry.Register(&alternativeTestPipelineItem{})
+	pipeline = NewPipeline(test.Repository)
+	pipeline.SetFeature("power")
+	_, err = pipeline.DeployForFacts([]string{"test"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test, TestAlt")
+	assert.Equal(t, 0, pipeline.Len())
+}
+
 func TestPipelineError(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item := &testPipelineItem{}
@@ -525,6 +684,211 @@ func TestPipelineError(t *testing.T) {
 	result, err := pipeline.Run(commits)
 	assert.Nil(t, result)
 	assert.NotNil(t, err)
+	var pipelineErr *PipelineError
+	assert.True(t, errors.As(err, &pipelineErr))
+	assert.Equal(t, item.Name(), pipelineErr.Item)
+	assert.Equal(t, commits[0].Hash, pipelineErr.Commit)
+	assert.Equal(t, 0, pipelineErr.CommitIndex)
+	assert.Equal(t, 1, pipelineErr.Branch)
+	assert.Equal(t, 1, pipelineErr.Step)
+	assert.Equal(t, "error", errors.Unwrap(err).Error())
+	assert.Contains(t, err.Error(), "af9ddc0db70f09f3f27b4b98e415592a7485171c")
+}
+
+func TestPipelineContinueOnError(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &testPipelineItem{}
+	item.TestError = true
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineContinueOnError: true,
+	}))
+	assert.True(t, pipeline.ContinueOnError)
+	commits := make([]*object.Commit, 1)
+	commits[0], _ = test.Repository.CommitObject(plumbing.NewHash(
+		"af9ddc0db70f09f3f27b4b98e415592a7485171c"))
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, pipeline.Failures(), 1)
+	assert.Equal(t, commits[0].Hash, pipeline.Failures()[0].Commit)
+}
+
+// sequenceTestPipelineItem provides the index of each consumed commit under Key and records
+// the indexes which it consumed and which it received from Dependency. It fails on FailIndex.
+type sequenceTestPipelineItem struct {
+	Key        string
+	Dependency string
+	FailIndex  int
+	Consumed   []int
+	Received   []int
+}
+
+func (item *sequenceTestPipelineItem) Name() string {
+	return "Sequence" + item.Key
+}
+
+func (item *sequenceTestPipelineItem) Provides() []string {
+	return []string{item.Key}
+}
+
+func (item *sequenceTestPipelineItem) Requires() []string {
+	if item.Dependency == "" {
+		return []string{}
+	}
+	return []string{item.Dependency}
+}
+
+func (item *sequenceTestPipelineItem) ListConfigurationOptions() []ConfigurationOption {
+	return nil
+}
+
+func (item *sequenceTestPipelineItem) Configure(facts map[string]interface{}) error {
+	return nil
+}
+
+func (item *sequenceTestPipelineItem) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+func (item *sequenceTestPipelineItem) Initialize(repository *git.Repository) error {
+	return nil
+}
+
+func (item *sequenceTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	index := deps[DependencyIndex].(int)
+	if index == item.FailIndex {
+		return nil, errors.New("error")
+	}
+	item.Consumed = append(item.Consumed, index)
+	if item.Dependency != "" {
+		item.Received = append(item.Received, deps[item.Dependency].(int))
+	}
+	return map[string]interface{}{item.Key: index}, nil
+}
+
+func (item *sequenceTestPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+func (item *sequenceTestPipelineItem) Merge(branches []PipelineItem) {
+}
+
+func TestPipelineContinueOnErrorDownstream(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	upstream := &sequenceTestPipelineItem{Key: "first", FailIndex: -1}
+	failing := &sequenceTestPipelineItem{Key: "second", Dependency: "first", FailIndex: 2}
+	downstream := &sequenceTestPipelineItem{Key: "third", Dependency: "second", FailIndex: -1}
+	pipeline.AddItem(downstream)
+	pipeline.AddItem(failing)
+	pipeline.AddItem(upstream)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineContinueOnError: true,
+		ConfigLogger: &DefaultLogger{
+			I: log.New(ioutil.Discard, "", 0), W: log.New(ioutil.Discard, "", 0),
+			E: log.New(ioutil.Discard, "", 0),
+		},
+	}))
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	_, err = pipeline.Run(commits[:5])
+	require.NoError(t, err)
+	require.Len(t, pipeline.Failures(), 1)
+	assert.Equal(t, failing.Name(), pipeline.Failures()[0].Item)
+	assert.Equal(t, 2, pipeline.Failures()[0].CommitIndex)
+	// the previous item keeps the failed commit
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, upstream.Consumed)
+	// the failed item and the items after it skip it and never see its data
+	assert.Equal(t, []int{0, 1, 3, 4}, failing.Consumed)
+	assert.Equal(t, []int{0, 1, 3, 4}, failing.Received)
+	assert.Equal(t, []int{0, 1, 3, 4}, downstream.Consumed)
+	assert.Equal(t, []int{0, 1, 3, 4}, downstream.Received)
+}
+
+// cancellingTestPipelineItem is testPipelineItem which cancels the run after consuming
+// the specified number of commits.
+type cancellingTestPipelineItem struct {
+	testPipelineItem
+	Cancel   context.CancelFunc
+	Limit    int
+	Consumed int
+}
+
+func (item *cancellingTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if ContextFromDeps(deps).Err() != nil {
+		return nil, errors.New("consumed after the cancellation")
+	}
+	item.Consumed++
+	if item.Consumed == item.Limit {
+		item.Cancel()
+	}
+	return item.testPipelineItem.Consume(deps)
+}
+
+func TestPipelineRunContext(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	item := &cancellingTestPipelineItem{Cancel: cancel, Limit: 2}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	commits = commits[:5]
+	result, err := pipeline.RunContext(ctx, commits)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Len(t, result, 1)
+	common := result[nil].(*CommonAnalysisResult)
+	assert.True(t, common.Cancelled)
+	assert.Equal(t, 2, common.CommitsNumber)
+	assert.Equal(t, commits[0].Committer.When.Unix(), common.BeginTime)
+	assert.Equal(t, 2, item.Consumed)
+
+	item = &cancellingTestPipelineItem{Cancel: cancel, Limit: -1}
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	result, err = pipeline.RunContext(ctx, commits)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, result[nil].(*CommonAnalysisResult).CommitsNumber)
+	assert.Equal(t, 0, item.Consumed)
+}
+
+func TestPipelineRunContextPartialResults(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	item := &cancellingTestPipelineItem{Cancel: cancel, Limit: 3}
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{ConfigPipelinePartialResults: true}))
+	assert.True(t, pipeline.PartialResults)
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	result, err := pipeline.RunContext(ctx, commits[:5])
+	assert.True(t, errors.Is(err, context.Canceled))
+	require.Len(t, result, 2)
+	assert.Equal(t, &item.testPipelineItem, result[item])
+	assert.True(t, item.Disposed)
+	common := result[nil].(*CommonAnalysisResult)
+	assert.True(t, common.Cancelled)
+	assert.Equal(t, 3, common.CommitsNumber)
+
+	// the results are inconsistent if the commit was interrupted halfway
+	item = &cancellingTestPipelineItem{Cancel: cancel, Limit: -1}
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(item)
+	pipeline.PartialResults = true
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{}))
+	result, err = pipeline.RunContext(ctx, commits[:5])
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Len(t, result, 1)
+}
+
+func TestContextFromDeps(t *testing.T) {
+	assert.Equal(t, context.Background(), ContextFromDeps(map[string]interface{}{}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	assert.Equal(t, ctx, ContextFromDeps(map[string]interface{}{DependencyContext: ctx}))
 }
 
 func TestPipelineDryRun(t *testing.T) {
@@ -630,6 +994,10 @@ func TestCommonAnalysisResultMerge(t *testing.T) {
 	assert.Equal(t, c1.CommitsNumber, 3)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(300))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 6, "three": 8})
+	assert.False(t, c1.Cancelled)
+	c2.Cancelled = true
+	c1.Merge(&c2)
+	assert.True(t, c1.Cancelled)
 }
 
 func TestCommonAnalysisResultMetadata(t *testing.T) {
@@ -644,6 +1012,25 @@ func TestCommonAnalysisResultMetadata(t *testing.T) {
 	assert.Equal(t, c1.CommitsNumber, 1)
 	assert.Equal(t, c1.RunTime.Nanoseconds(), int64(100*1e6))
 	assert.Equal(t, c1.RunTimePerItem, map[string]float64{"one": 1, "two": 2})
+	assert.False(t, c1.Cancelled)
+	c1.Cancelled = true
+	c1.TickMode = "quarter"
+	c1.FiscalYearStart = 7
+	c1 = MetadataToCommonAnalysisResult(c1.FillMetadata(meta))
+	assert.True(t, c1.Cancelled)
+	assert.Equal(t, "quarter", c1.TickMode)
+	assert.Equal(t, 7, c1.FiscalYearStart)
+}
+
+func TestCommonAnalysisResultCheckTicks(t *testing.T) {
+	fixed := &CommonAnalysisResult{}
+	assert.NoError(t, fixed.CheckTicks(&CommonAnalysisResult{}))
+	month := &CommonAnalysisResult{TickMode: "month"}
+	assert.NoError(t, month.CheckTicks(&CommonAnalysisResult{TickMode: "month"}))
+	assert.EqualError(t, fixed.CheckTicks(month), "mismatching tick modes (fixed, month) received")
+	quarter := &CommonAnalysisResult{TickMode: "quarter", FiscalYearStart: 4}
+	assert.EqualError(t, quarter.CheckTicks(&CommonAnalysisResult{TickMode: "quarter", FiscalYearStart: 1}),
+		"mismatching tick modes (quarter from month 4, quarter from month 1) received")
 }
 
 func TestConfigurationOptionTypeString(t *testing.T) {
@@ -680,7 +1067,7 @@ func TestPrepareRunPlanTiny(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	plan, _ := prepareRunPlan([]*object.Commit{rootCommit}, 0, false)
+	plan, _ := prepareRunPlan([]*object.Commit{rootCommit}, 0, false, false, 0)
 	assert.Len(t, plan, 2)
 	assert.Equal(t, runActionEmerge, plan[0].Action)
 	assert.Equal(t, rootBranchIndex, plan[0].Items[0])
@@ -707,7 +1094,7 @@ func TestPrepareRunPlanSmall(t *testing.T) {
 		}
 		return nil
 	})
-	plan, _ := prepareRunPlan(commits, 0, false)
+	plan, _ := prepareRunPlan(commits, 0, false, false, 0)
 	/*for _, p := range plan {
 		if p.Commit != nil {
 			fmt.Println(p.Action, p.Commit.Hash.String(), p.Items)
@@ -835,7 +1222,7 @@ func TestPrepareRunPlanBig(t *testing.T) {
 				}
 				return nil
 			})
-			plan, _ := prepareRunPlan(commits, 0, false)
+			plan, _ := prepareRunPlan(commits, 0, false, false, 0)
 			/*for _, p := range plan {
 				if p.Commit != nil {
 					fmt.Println(p.Action, p.Commit.Hash.String(), p.Items)
@@ -999,6 +1386,21 @@ func TestCommonAnalysisResultMergeNoTimeChange(t *testing.T) {
 	assert.Equal(t, 3, c1.CommitsNumber)
 }
 
+// costedTestPipelineItem is testPipelineItem which reports its cost.
+type costedTestPipelineItem struct {
+	testPipelineItem
+}
+
+func (item *costedTestPipelineItem) Cost() CostClass {
+	return CostHeavy
+}
+
+func TestGetCost(t *testing.T) {
+	assert.Equal(t, CostMedium, GetCost(&testPipelineItem{}))
+	assert.Equal(t, CostHeavy, GetCost(&costedTestPipelineItem{}))
+	assert.Equal(t, CostMedium, GetCost(&dummyPipelineItem{}))
+}
+
 func TestPipelineDeployItemOnce(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item1 := &testPipelineItem{}
@@ -1076,6 +1478,172 @@ func TestPipelineHibernationDistanceFromFact(t *testing.T) {
 	assert.Equal(t, 5, pipeline.HibernationDistance)
 }
 
+func TestPipelineNegativeCommitStride(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	err := pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitStride: -1,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "stride")
+}
+
+func TestPipelineCommitStrideFromFact(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	err := pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitStride: 10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, pipeline.CommitStride)
+}
+
+func TestPipelineCommitStrideCommitsNumber(t *testing.T) {
+	commits := makePlanCacheCommits()
+	facts := map[string]interface{}{ConfigPipelineCommitStride: 2}
+	_, leaf, result, err := runCheckpointPipeline(t, commits, -1, facts)
+	require.NoError(t, err)
+	assert.Len(t, leaf.Hashes, 3)
+	assert.Equal(t, 3, result[nil].(*CommonAnalysisResult).CommitsNumber)
+
+	facts[ConfigPipelineCommits] = commits
+	pipeline := initializePlanCachePipeline(t, facts)
+	result, err = pipeline.RunPreparedPlan()
+	require.NoError(t, err)
+	assert.Equal(t, 3, result[nil].(*CommonAnalysisResult).CommitsNumber)
+}
+
+func TestPipelineNegativeCommitTimeout(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	err := pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitTimeout: -time.Second,
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "commit-timeout")
+}
+
+// stuckTestPipelineItem is testPipelineItem which blocks in Consume() until its context
+// is cancelled on the specified commit. Returned is closed after that.
+type stuckTestPipelineItem struct {
+	testPipelineItem
+	StuckIndex int
+	Returned   chan struct{}
+}
+
+func (item *stuckTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if deps[DependencyIndex].(int) == item.StuckIndex {
+		<-ContextFromDeps(deps).Done()
+		close(item.Returned)
+		return nil, ContextFromDeps(deps).Err()
+	}
+	return item.testPipelineItem.Consume(deps)
+}
+
+// hungTestPipelineItem is sequenceTestPipelineItem which ignores the context and blocks
+// in Consume() on HangIndex until Release is closed.
+type hungTestPipelineItem struct {
+	sequenceTestPipelineItem
+	HangIndex int
+	Release   chan struct{}
+}
+
+func (item *hungTestPipelineItem) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if deps[DependencyIndex].(int) == item.HangIndex {
+		<-item.Release
+	}
+	return item.sequenceTestPipelineItem.Consume(deps)
+}
+
+func (item *hungTestPipelineItem) Fork(n int) []PipelineItem {
+	return ForkSamePipelineItem(item, n)
+}
+
+func TestPipelineCommitTimeout(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	item := &stuckTestPipelineItem{StuckIndex: 1, Returned: make(chan struct{})}
+	pipeline.AddItem(item)
+	logs := &bytes.Buffer{}
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitTimeout: 50 * time.Millisecond,
+		ConfigLogger: &DefaultLogger{
+			I: log.New(ioutil.Discard, "", 0), W: log.New(logs, "", 0), E: log.New(logs, "", 0),
+		},
+	}))
+	assert.Equal(t, 50*time.Millisecond, pipeline.CommitTimeout)
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	commits = commits[:3]
+	result, err := pipeline.Run(commits)
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrConsumeTimeout))
+	var pipelineErr *PipelineError
+	require.True(t, errors.As(err, &pipelineErr))
+	assert.Equal(t, item.Name(), pipelineErr.Item)
+	assert.Equal(t, commits[1].Hash, pipelineErr.Commit)
+	assert.Contains(t, logs.String(), commits[1].Hash.String())
+	assert.Contains(t, logs.String(), "goroutine ")
+
+	item = &stuckTestPipelineItem{StuckIndex: 1, Returned: make(chan struct{})}
+	pipeline = NewPipeline(test.Repository)
+	pipeline.AddItem(item)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitTimeout:   50 * time.Millisecond,
+		ConfigPipelineContinueOnError: true,
+		ConfigLogger: &DefaultLogger{
+			I: log.New(ioutil.Discard, "", 0), W: log.New(ioutil.Discard, "", 0),
+			E: log.New(ioutil.Discard, "", 0),
+		},
+	}))
+	result, err = pipeline.Run(commits)
+	assert.NoError(t, err)
+	// the abandoned leaf has no result
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, nil)
+	require.Len(t, pipeline.Failures(), 1)
+	assert.Equal(t, commits[1].Hash, pipeline.Failures()[0].Commit)
+	assert.Equal(t, ErrConsumeTimeout, pipeline.Failures()[0].Err)
+	select {
+	case <-item.Returned:
+	case <-time.After(time.Second):
+		t.Fatal("the context of the abandoned item was not cancelled")
+	}
+}
+
+func TestPipelineCommitTimeoutAbandon(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	upstream := &sequenceTestPipelineItem{Key: "first", FailIndex: -1}
+	hung := &hungTestPipelineItem{
+		sequenceTestPipelineItem: sequenceTestPipelineItem{Key: "second", Dependency: "first", FailIndex: -1},
+		HangIndex:                2,
+		Release:                  make(chan struct{}),
+	}
+	downstream := &sequenceTestPipelineItem{Key: "third", Dependency: "second", FailIndex: -1}
+	pipeline.AddItem(downstream)
+	pipeline.AddItem(hung)
+	pipeline.AddItem(upstream)
+	assert.NoError(t, pipeline.Initialize(map[string]interface{}{
+		ConfigPipelineCommitTimeout:   50 * time.Millisecond,
+		ConfigPipelineContinueOnError: true,
+		ConfigLogger: &DefaultLogger{
+			I: log.New(ioutil.Discard, "", 0), W: log.New(ioutil.Discard, "", 0),
+			E: log.New(ioutil.Discard, "", 0),
+		},
+	}))
+	commits, err := pipeline.Commits(true)
+	require.NoError(t, err)
+	// the run does not wait for the item which ignores the cancelled context
+	_, err = pipeline.Run(commits[:5])
+	require.NoError(t, err)
+	require.Len(t, pipeline.Failures(), 1)
+	assert.Equal(t, hung.Name(), pipeline.Failures()[0].Item)
+	assert.Equal(t, 2, pipeline.Failures()[0].CommitIndex)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, upstream.Consumed)
+	// the abandoned item and the items after it skip the rest of the commits
+	assert.Equal(t, []int{0, 1}, downstream.Consumed)
+	close(hung.Release)
+}
+
 func TestPipelinePrintActionsFromFact(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	pipeline.AddItem(&testPipelineItem{})
@@ -1211,6 +1779,16 @@ func TestGetSensibleRemoteNoRemote(t *testing.T) {
 	assert.Equal(t, "<no remote>", remote)
 }
 
+func TestNewPipelineShallow(t *testing.T) {
+	assert.Empty(t, NewPipeline(test.Repository).Shallow())
+	assert.Empty(t, NewPipeline(nil).Shallow())
+	repo, err := git.Init(memory.NewStorage(), nil)
+	require.NoError(t, err)
+	boundary := []plumbing.Hash{plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c")}
+	require.NoError(t, repo.Storer.SetShallow(boundary))
+	assert.Equal(t, boundary, NewPipeline(repo).Shallow())
+}
+
 func TestPipelineInitializeWithCommitsFact(t *testing.T) {
 	pipeline := NewPipeline(test.Repository)
 	item := &testPipelineItem{}
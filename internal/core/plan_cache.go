@@ -0,0 +1,175 @@
+package core
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+// planCacheVersion is incremented each time the format of the cached run plans
+// or prepareRunPlan() changes.
+const planCacheVersion = 2
+
+// planCacheAction is runAction with the commits replaced by their hashes.
+// The zero hash stands for nil.
+type planCacheAction struct {
+	Action    int
+	Commit    plumbing.Hash
+	NextMerge plumbing.Hash
+	Items     []int
+}
+
+// planCache is the file format of SavePlan() and LoadPlan().
+type planCache struct {
+	Version        int
+	Key            string
+	CommitCount    int
+	MergeHashCount int
+	Actions        []planCacheAction
+}
+
+// planCacheKey identifies the run plan of the commits. It includes the repository HEAD, the commits
+// and every option which affects prepareRunPlan().
+func (pipeline *Pipeline) planCacheKey(commits []*object.Commit, mergeTracks bool) string {
+	var head plumbing.Hash
+	if pipeline.repository != nil {
+		if ref, err := pipeline.repository.Head(); err == nil {
+			head = ref.Hash()
+		}
+	}
+	hasher := sha1.New()
+	_, _ = fmt.Fprintf(hasher, "%d %s %d %t %t %d\n", planCacheVersion, head.String(),
+		pipeline.HibernationDistance, mergeTracks, pipeline.MainlineOnly, pipeline.CommitStride)
+	for _, commit := range commits {
+		_, _ = hasher.Write(commit.Hash[:])
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// SavePlan writes the run plan which InitializeExt() or LoadPlan() prepared to the file at `path`
+// so that the subsequent runs against the same repository state can LoadPlan() it instead of
+// scheduling the commits again. It must be called before RunPreparedPlan().
+func (pipeline *Pipeline) SavePlan(path string) error {
+	prepared := pipeline.preparedRun
+	if prepared == nil {
+		return fmt.Errorf("run plan was not prepared")
+	}
+	commits, ok := pipeline.facts[ConfigPipelineCommits].([]*object.Commit)
+	if !ok {
+		return fmt.Errorf("commits are not available")
+	}
+	mergeTracks, _ := pipeline.GetFeature(FeatureMergeTracks)
+	cache := planCache{
+		Version:        planCacheVersion,
+		Key:            pipeline.planCacheKey(commits, mergeTracks),
+		CommitCount:    prepared.commitCount,
+		MergeHashCount: prepared.mergeHashCount,
+		Actions:        make([]planCacheAction, len(prepared.plan)),
+	}
+	for i, action := range prepared.plan {
+		cached := planCacheAction{Action: action.Action, Items: action.Items}
+		if action.Commit != nil {
+			cached.Commit = action.Commit.Hash
+		}
+		if action.NextMerge != nil {
+			cached.NextMerge = action.NextMerge.Hash
+		}
+		cache.Actions[i] = cached
+	}
+	if err := saveGob(path, &cache); err != nil {
+		return errors.Wrap(err, "failed to save the run plan")
+	}
+	return nil
+}
+
+// saveGob encodes the value to the file at `path`. It writes to a temporary file first
+// so that the concurrent runs never read a partial file.
+func saveGob(path string, value interface{}) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	err = gob.NewEncoder(writer).Encode(value)
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+	}
+	return err
+}
+
+// LoadPlan reads the run plan which SavePlan() wrote to the file at `path` and prepares it for
+// RunPreparedPlan(), skipping the expensive scheduling of the commits. The pipeline must be
+// initialized with the same ConfigPipelineCommits and plan options; otherwise, as well as
+// when the repository HEAD has moved, LoadPlan() fails with ErrPlanCacheMismatch.
+func (pipeline *Pipeline) LoadPlan(path string) error {
+	commits, ok := pipeline.facts[ConfigPipelineCommits].([]*object.Commit)
+	if !ok {
+		return fmt.Errorf("commits are not available")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var cache planCache
+	if err = gob.NewDecoder(bufio.NewReader(file)).Decode(&cache); err != nil {
+		return errors.Wrapf(ErrPlanCacheMismatch, "%s is corrupted: %v", path, err)
+	}
+	mergeTracks, _ := pipeline.GetFeature(FeatureMergeTracks)
+	if cache.Version != planCacheVersion || cache.Key != pipeline.planCacheKey(commits, mergeTracks) {
+		return errors.Wrap(ErrPlanCacheMismatch, path)
+	}
+	// the filtered commits may be rewritten, e.g. by strideCommits()
+	scheduled := filterRunPlanCommits(commits, pipeline.MainlineOnly, pipeline.CommitStride)
+	byHash := make(map[plumbing.Hash]*object.Commit, len(scheduled))
+	for _, commit := range scheduled {
+		byHash[commit.Hash] = commit
+	}
+	resolve := func(hash plumbing.Hash) (*object.Commit, error) {
+		if hash.IsZero() {
+			return nil, nil
+		}
+		commit := byHash[hash]
+		if commit == nil {
+			return nil, errors.Wrapf(ErrPlanCacheMismatch, "%s: unknown commit %s", path, hash.String())
+		}
+		return commit, nil
+	}
+	plan := make([]runAction, len(cache.Actions))
+	for i, cached := range cache.Actions {
+		action := runAction{Action: cached.Action, Items: cached.Items}
+		if action.Commit, err = resolve(cached.Commit); err != nil {
+			return err
+		}
+		if action.NextMerge, err = resolve(cached.NextMerge); err != nil {
+			return err
+		}
+		plan[i] = action
+	}
+	pipeline.preparedRun = &preparedRun{
+		plan:           plan,
+		commitCount:    cache.CommitCount,
+		mergeHashCount: cache.MergeHashCount,
+	}
+	if mergeTracks {
+		pipeline.facts[FactMergeHashCount] = cache.MergeHashCount
+	}
+	return nil
+}
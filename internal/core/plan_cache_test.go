@@ -0,0 +1,111 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makePlanCacheCommits() []*object.Commit {
+	return []*object.Commit{
+		makeTestCommit("aa"),
+		makeTestCommit("bb", "aa"),
+		makeTestCommit("cc", "aa"),
+		makeTestCommit("dd", "bb", "cc"),
+		makeTestCommit("ee", "dd"),
+		makeTestCommit("ff", "ee"),
+	}
+}
+
+func initializePlanCachePipeline(t *testing.T, facts map[string]interface{}) *Pipeline {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&testPipelineItem{})
+	require.NoError(t, pipeline.InitializeExt(facts,
+		func(items []PipelineItem) PipelineItem { return items[0] }, true))
+	require.NotNil(t, pipeline.preparedRun)
+	return pipeline
+}
+
+func TestPipelinePlanCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan")
+	commits := makePlanCacheCommits()
+	first := initializePlanCachePipeline(t, map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelinePlanCache: path,
+	})
+	assert.Equal(t, path, first.PlanCache)
+	assert.FileExists(t, path)
+
+	second := initializePlanCachePipeline(t, map[string]interface{}{
+		ConfigPipelineCommits: commits, ConfigPipelinePlanCache: path,
+	})
+	assert.Equal(t, first.preparedRun, second.preparedRun)
+	for _, action := range second.preparedRun.plan {
+		if action.Commit != nil {
+			assert.Contains(t, commits, action.Commit)
+		}
+	}
+
+	// the stale cache is replaced
+	third := initializePlanCachePipeline(t, map[string]interface{}{
+		ConfigPipelineCommits: commits[:5], ConfigPipelinePlanCache: path,
+	})
+	assert.Equal(t, 5, third.preparedRun.commitCount)
+	fourth := NewPipeline(test.Repository)
+	fourth.facts = map[string]interface{}{ConfigPipelineCommits: commits[:5]}
+	require.NoError(t, fourth.LoadPlan(path))
+	assert.Equal(t, third.preparedRun, fourth.preparedRun)
+}
+
+func TestPipelineLoadPlanMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan")
+	commits := makePlanCacheCommits()
+	pipeline := initializePlanCachePipeline(t, map[string]interface{}{ConfigPipelineCommits: commits})
+	require.NoError(t, pipeline.SavePlan(path))
+
+	pipeline.MainlineOnly = true
+	err := pipeline.LoadPlan(path)
+	assert.Equal(t, ErrPlanCacheMismatch, errors.Cause(err))
+	pipeline.MainlineOnly = false
+	pipeline.facts[ConfigPipelineCommits] = commits[1:]
+	err = pipeline.LoadPlan(path)
+	assert.Equal(t, ErrPlanCacheMismatch, errors.Cause(err))
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0666))
+	err = pipeline.LoadPlan(path)
+	assert.Equal(t, ErrPlanCacheMismatch, errors.Cause(err))
+
+	err = pipeline.LoadPlan(filepath.Join(t.TempDir(), "missing"))
+	assert.True(t, os.IsNotExist(err))
+
+	delete(pipeline.facts, ConfigPipelineCommits)
+	assert.Error(t, pipeline.LoadPlan(path))
+	assert.Error(t, pipeline.SavePlan(path))
+}
+
+func TestPipelinePlanCacheStride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan")
+	commits := makePlanCacheCommits()
+	facts := func() map[string]interface{} {
+		return map[string]interface{}{
+			ConfigPipelineCommits: commits, ConfigPipelineCommitStride: 2, ConfigPipelinePlanCache: path,
+		}
+	}
+	first := initializePlanCachePipeline(t, facts())
+	second := initializePlanCachePipeline(t, facts())
+	// the rewritten parents of the strided commits are restored, too
+	assert.Equal(t, first.preparedRun, second.preparedRun)
+}
+
+func TestPipelineSavePlanNotPrepared(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.facts = map[string]interface{}{ConfigPipelineCommits: makePlanCacheCommits()}
+	err := pipeline.SavePlan(filepath.Join(t.TempDir(), "plan"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not prepared")
+}
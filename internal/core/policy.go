@@ -0,0 +1,148 @@
+package core
+
+import (
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// FactPolicyAnonymizePeople is the name of the fact which Pipeline.InitializeExt() sets to true
+// if the policy does not permit the per-person data. The identity detector replaces the names
+// of the developers with sequential numbers then.
+const FactPolicyAnonymizePeople = "Policy.AnonymizePeople"
+
+// ErrPolicyViolation is the cause of the errors returned by Pipeline.InitializeExt() if the policy
+// does not permit the requested analyses or the repository.
+var ErrPolicyViolation = errors.New("forbidden by the policy")
+
+// Policy restricts which analyses, repositories and output data are permitted per caller.
+// It is intended for the centrally operated services which run hercules on behalf of others.
+//
+//	default:
+//	  analyses: {allow: ["burndown", "couples"]}
+//	  people: false
+//	callers:
+//	  release-team:
+//	    repositories: {allow: ["https://github.com/org/*"]}
+type Policy struct {
+	// Default applies to the callers which are not listed in Callers. If it is nil, such callers
+	// are rejected.
+	Default *PolicyRules `yaml:"default"`
+	// Callers maps the caller names to their rules. The rules of a listed caller replace Default.
+	Callers map[string]*PolicyRules `yaml:"callers"`
+}
+
+// PolicyRules are the restrictions of a single caller.
+type PolicyRules struct {
+	// Analyses are matched against the flags and the names of the deployed leaves,
+	// e.g. "burndown" or "BurndownAnalysis".
+	Analyses PolicyList `yaml:"analyses"`
+	// Repositories are matched against the remote URL, see GetSensibleRemote().
+	Repositories PolicyList `yaml:"repositories"`
+	// People permits the per-person data in the results. The names are anonymized if it is false.
+	// Nil means true.
+	People *bool `yaml:"people"`
+}
+
+// PolicyList is the pair of allow and deny glob patterns where "*" matches any sequence
+// of characters. An empty Allow permits everything which is not denied; Deny always wins.
+type PolicyList struct {
+	Allow []string `yaml:"allow"`
+	Deny  []string `yaml:"deny"`
+}
+
+// LoadPolicy reads the policy from the YAML file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	if err = yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, errors.Wrapf(err, "invalid policy %s", path)
+	}
+	return policy, nil
+}
+
+// Rules returns the rules of the caller. It fails if the caller is unknown and there are
+// no default rules.
+func (policy *Policy) Rules(caller string) (*PolicyRules, error) {
+	if rules, exists := policy.Callers[caller]; exists && rules != nil {
+		return rules, nil
+	}
+	if policy.Default == nil {
+		return nil, errors.Wrapf(ErrPolicyViolation, "unknown caller %q", caller)
+	}
+	return policy.Default, nil
+}
+
+// Permits checks whether any of the values passes the list.
+func (list PolicyList) Permits(values ...string) bool {
+	for _, pattern := range list.Deny {
+		for _, value := range values {
+			if matchPolicyPattern(pattern, value) {
+				return false
+			}
+		}
+	}
+	if len(list.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range list.Allow {
+		for _, value := range values {
+			if matchPolicyPattern(pattern, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PeopleAllowed returns true if the results may contain the per-person data.
+func (rules *PolicyRules) PeopleAllowed() bool {
+	return rules.People == nil || *rules.People
+}
+
+// matchPolicyPattern matches the whole value against the glob pattern. Unlike path.Match(),
+// "*" crosses the slashes so that the patterns work with URLs.
+func matchPolicyPattern(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(value)
+}
+
+// enforcePolicy loads the policy and checks the deployed leaves and the repository against
+// the rules of the caller. It sets FactPolicyAnonymizePeople if the per-person data is forbidden.
+func (pipeline *Pipeline) enforcePolicy(path, caller string, facts map[string]interface{}) error {
+	policy, err := LoadPolicy(path)
+	if err != nil {
+		return err
+	}
+	rules, err := policy.Rules(caller)
+	if err != nil {
+		return err
+	}
+	if remote := GetSensibleRemote(pipeline.repository); !rules.Repositories.Permits(remote) {
+		return errors.Wrapf(ErrPolicyViolation, "caller %q may not analyse %s", caller, remote)
+	}
+	var forbidden []string
+	for _, item := range pipeline.items {
+		if leaf, ok := item.(LeafPipelineItem); ok && !rules.Analyses.Permits(leaf.Flag(), leaf.Name()) {
+			forbidden = append(forbidden, leaf.Flag())
+		}
+	}
+	if len(forbidden) > 0 {
+		return errors.Wrapf(ErrPolicyViolation, "caller %q may not run %s",
+			caller, strings.Join(forbidden, ", "))
+	}
+	if !rules.PeopleAllowed() {
+		pipeline.l.Infof("the policy forbids the per-person data, anonymizing the developers")
+		facts[FactPolicyAnonymizePeople] = true
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPolicy(t *testing.T, text string) string {
+	path := filepath.Join(t.TempDir(), "policy.yml")
+	require.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+	return path
+}
+
+func TestLoadPolicy(t *testing.T) {
+	policy, err := LoadPolicy(writeTestPolicy(t, `
+default:
+  analyses: {allow: ["burndown*"], deny: ["burndown-people"]}
+  people: false
+callers:
+  ci:
+    repositories: {deny: ["https://github.com/secret/*"]}
+`))
+	require.NoError(t, err)
+	rules, err := policy.Rules("anybody")
+	require.NoError(t, err)
+	assert.Equal(t, policy.Default, rules)
+	assert.False(t, rules.PeopleAllowed())
+	assert.True(t, rules.Analyses.Permits("burndown"))
+	assert.True(t, rules.Analyses.Permits("burndown-files"))
+	assert.False(t, rules.Analyses.Permits("burndown-people"))
+	assert.False(t, rules.Analyses.Permits("couples", "CouplesAnalysis"))
+
+	rules, err = policy.Rules("ci")
+	require.NoError(t, err)
+	assert.True(t, rules.PeopleAllowed())
+	assert.True(t, rules.Analyses.Permits("couples"))
+	assert.True(t, rules.Repositories.Permits("https://github.com/public/repo"))
+	assert.False(t, rules.Repositories.Permits("https://github.com/secret/nested/repo"))
+
+	policy.Default = nil
+	_, err = policy.Rules("anybody")
+	assert.Equal(t, ErrPolicyViolation, errors.Cause(err))
+
+	_, err = LoadPolicy(writeTestPolicy(t, "default:\n  analysis: {allow: [burndown]}\n"))
+	assert.Error(t, err)
+	_, err = LoadPolicy(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.Error(t, err)
+}
+
+func TestMatchPolicyPattern(t *testing.T) {
+	assert.True(t, matchPolicyPattern("*", ""))
+	assert.True(t, matchPolicyPattern("a.b", "a.b"))
+	assert.False(t, matchPolicyPattern("a.b", "axb"))
+	assert.True(t, matchPolicyPattern("*/repo", "https://host/org/repo"))
+	assert.False(t, matchPolicyPattern("repo", "repo2"))
+}
+
+func TestPipelinePolicy(t *testing.T) {
+	initialize := func(policy string, caller string) (map[string]interface{}, error) {
+		pipeline := NewPipeline(test.Repository)
+		pipeline.AddItem(&testPipelineItem{})
+		facts := map[string]interface{}{
+			ConfigPipelinePolicy: writeTestPolicy(t, policy),
+			ConfigPipelineCaller: caller,
+		}
+		return facts, pipeline.Initialize(facts)
+	}
+	facts, err := initialize("default: {people: false}", "")
+	require.NoError(t, err)
+	assert.Equal(t, true, facts[FactPolicyAnonymizePeople])
+	facts, err = initialize("callers: {ci: {analyses: {allow: [Test]}}}", "ci")
+	require.NoError(t, err)
+	assert.NotContains(t, facts, FactPolicyAnonymizePeople)
+
+	_, err = initialize("callers: {ci: {}}", "other")
+	assert.Equal(t, ErrPolicyViolation, errors.Cause(err))
+	_, err = initialize("default: {analyses: {deny: [mytest]}}", "")
+	assert.Equal(t, ErrPolicyViolation, errors.Cause(err))
+	assert.Contains(t, err.Error(), "mytest")
+	remote := GetSensibleRemote(test.Repository)
+	_, err = initialize("default: {repositories: {deny: [\""+remote+"\"]}}", "")
+	assert.Equal(t, ErrPolicyViolation, errors.Cause(err))
+}
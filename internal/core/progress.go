@@ -0,0 +1,139 @@
+package core
+
+import (
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ProgressEvent describes the pipeline state after executing a single step of the run plan.
+type ProgressEvent struct {
+	// Step is the number of complete steps.
+	Step int
+	// Total is the overall number of steps, including finalization.
+	Total int
+	// Action is the short description of the step, the same as passed to Pipeline.OnProgress.
+	Action string
+	// Commit is the hash of the consumed commit. It is zero for steps which are not commits.
+	Commit plumbing.Hash
+	// Branch is the index of the branch which executed the step.
+	Branch int
+	// ItemTimes is the time elapsed by each PipelineItem during the step.
+	ItemTimes map[string]time.Duration
+	// Elapsed is the time since the run started.
+	Elapsed time.Duration
+	// ETA is the estimated remaining time calculated from the recent throughput.
+	// It is zero until at least two steps were executed.
+	ETA time.Duration
+}
+
+// ProgressReporter receives detailed progress updates from Pipeline.Run().
+// Report() is called synchronously from the pipeline loop and should be fast.
+type ProgressReporter interface {
+	Report(event ProgressEvent)
+}
+
+// ProgressReporterFunc adapts an ordinary function to ProgressReporter.
+type ProgressReporterFunc func(event ProgressEvent)
+
+// Report calls the function.
+func (f ProgressReporterFunc) Report(event ProgressEvent) {
+	f(event)
+}
+
+// DefaultProgressWindow is the number of the latest steps which are considered
+// to estimate the throughput.
+const DefaultProgressWindow = 256
+
+// throughputWindow keeps the completion times of the latest steps in a ring buffer.
+type throughputWindow struct {
+	times []time.Time
+	next  int
+	count int
+}
+
+func newThroughputWindow(size int) *throughputWindow {
+	if size < 2 {
+		size = 2
+	}
+	return &throughputWindow{times: make([]time.Time, size)}
+}
+
+// Add records the completion of a step.
+func (w *throughputWindow) Add(t time.Time) {
+	w.times[w.next] = t
+	w.next = (w.next + 1) % len(w.times)
+	if w.count < len(w.times) {
+		w.count++
+	}
+}
+
+// Estimate returns the expected time to complete `remaining` steps.
+func (w *throughputWindow) Estimate(remaining int) time.Duration {
+	if w.count < 2 || remaining <= 0 {
+		return 0
+	}
+	newest := w.times[(w.next-1+len(w.times))%len(w.times)]
+	oldest := w.times[(w.next-w.count+len(w.times))%len(w.times)]
+	perStep := newest.Sub(oldest) / time.Duration(w.count-1)
+	return perStep * time.Duration(remaining)
+}
+
+// progressTracker dispatches the progress of runPlan() to Pipeline.OnProgress and
+// Pipeline.ProgressReporter.
+type progressTracker struct {
+	onProgress func(int, int, string)
+	reporter   ProgressReporter
+	total      int
+	start      time.Time
+	window     *throughputWindow
+}
+
+func newProgressTracker(pipeline *Pipeline, total int, start time.Time) *progressTracker {
+	return &progressTracker{
+		onProgress: pipeline.OnProgress,
+		reporter:   pipeline.ProgressReporter,
+		total:      total,
+		start:      start,
+		window:     newThroughputWindow(DefaultProgressWindow),
+	}
+}
+
+// Detailed indicates whether per-item timings should be collected.
+func (tracker *progressTracker) Detailed() bool {
+	return tracker.reporter != nil
+}
+
+// Begin is called before executing the step.
+func (tracker *progressTracker) Begin(step int, action string) {
+	if tracker.onProgress != nil {
+		tracker.onProgress(step, tracker.total, action)
+	}
+}
+
+// End is called after executing the step.
+func (tracker *progressTracker) End(step int, action string, commit plumbing.Hash, branch int,
+	itemTimes map[string]time.Duration,
+) {
+	if tracker.reporter == nil {
+		return
+	}
+	now := time.Now()
+	tracker.window.Add(now)
+	tracker.reporter.Report(ProgressEvent{
+		Step:      step,
+		Total:     tracker.total,
+		Action:    action,
+		Commit:    commit,
+		Branch:    branch,
+		ItemTimes: itemTimes,
+		Elapsed:   now.Sub(tracker.start),
+		ETA:       tracker.window.Estimate(tracker.total - step),
+	})
+}
+
+// Step combines Begin() and End() for the steps which do not consume commits.
+func (tracker *progressTracker) Step(step int, action string) {
+	tracker.Begin(step, action)
+	tracker.End(step, action, plumbing.ZeroHash, 0, nil)
+}
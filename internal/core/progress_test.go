@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThroughputWindowEstimate(t *testing.T) {
+	window := newThroughputWindow(3)
+	start := time.Unix(1000, 0)
+	assert.Equal(t, time.Duration(0), window.Estimate(10))
+	window.Add(start)
+	assert.Equal(t, time.Duration(0), window.Estimate(10))
+	window.Add(start.Add(time.Second))
+	assert.Equal(t, 10*time.Second, window.Estimate(10))
+	window.Add(start.Add(3 * time.Second))
+	assert.Equal(t, 15*time.Second, window.Estimate(10))
+	// the first sample falls out of the window
+	window.Add(start.Add(4 * time.Second))
+	assert.Equal(t, 15*time.Second, window.Estimate(10))
+	assert.Equal(t, time.Duration(0), window.Estimate(0))
+}
+
+func TestProgressTrackerDispatch(t *testing.T) {
+	var legacy []string
+	var events []ProgressEvent
+	pipeline := &Pipeline{
+		OnProgress: func(step, total int, action string) {
+			legacy = append(legacy, action)
+		},
+	}
+	tracker := newProgressTracker(pipeline, 3, time.Now())
+	assert.False(t, tracker.Detailed())
+	tracker.Step(1, "emerge")
+	assert.Equal(t, []string{"emerge"}, legacy)
+
+	pipeline.ProgressReporter = ProgressReporterFunc(func(event ProgressEvent) {
+		events = append(events, event)
+	})
+	tracker = newProgressTracker(pipeline, 3, time.Now())
+	assert.True(t, tracker.Detailed())
+	hash := plumbing.NewHash("af9ddc0db70f09f3f27b4b98e415592a7485171c")
+	tracker.Begin(2, "af9ddc0")
+	tracker.End(2, "af9ddc0", hash, 1, map[string]time.Duration{"item": time.Millisecond})
+	assert.Equal(t, []string{"emerge", "af9ddc0"}, legacy)
+	assert.Len(t, events, 1)
+	assert.Equal(t, hash, events[0].Commit)
+	assert.Equal(t, 1, events[0].Branch)
+	assert.Equal(t, 3, events[0].Total)
+	assert.Equal(t, time.Millisecond, events[0].ItemTimes["item"])
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"reflect"
+	"sort"
+)
+
+const (
+	// FactStageConfigure is FactProvider.Stage of the facts set in PipelineItem.Configure().
+	FactStageConfigure = "Configure"
+	// FactStageConfigureUpstream is FactProvider.Stage of the facts set in
+	// PipelineItem.ConfigureUpstream().
+	FactStageConfigureUpstream = "ConfigureUpstream"
+)
+
+// FactProvider is the PipelineItem which set or changed a fact during Pipeline.Initialize().
+type FactProvider struct {
+	// Item is the name of the PipelineItem.
+	Item string
+	// Stage is either FactStageConfigure or FactStageConfigureUpstream.
+	Stage string
+}
+
+// FactProvenance describes the final value of a fact and how it was obtained.
+type FactProvenance struct {
+	// Name is the key of the fact.
+	Name string
+	// Value is the value of the fact after Pipeline.Initialize().
+	Value interface{}
+	// Initial indicates whether the fact was passed to Pipeline.Initialize(), e.g. from
+	// the command line flags. The facts which are neither initial nor provided by an item
+	// are set by the Pipeline itself.
+	Initial bool
+	// Providers are the items which set or changed the fact, in the order of the calls.
+	Providers []FactProvider
+}
+
+// ExplainFacts returns the provenance of each fact after the latest Initialize(),
+// sorted by name. The facts which the items changed in place, e.g. by appending to a map,
+// are not recognized as changed.
+func (pipeline *Pipeline) ExplainFacts() []FactProvenance {
+	result := make([]FactProvenance, 0, len(pipeline.facts))
+	for name, value := range pipeline.facts {
+		_, initial := pipeline.initialFacts[name]
+		result = append(result, FactProvenance{
+			Name:      name,
+			Value:     value,
+			Initial:   initial,
+			Providers: pipeline.factHistory[name],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// copyFacts returns the shallow copy of the facts to compare with after an item
+// is configured.
+func copyFacts(facts map[string]interface{}) map[string]interface{} {
+	snapshot := make(map[string]interface{}, len(facts))
+	for key, val := range facts {
+		snapshot[key] = val
+	}
+	return snapshot
+}
+
+// changedFacts returns the sorted names of the facts which were added or replaced
+// since the snapshot.
+func changedFacts(snapshot, facts map[string]interface{}) []string {
+	var changed []string
+	for key, val := range facts {
+		if before, exists := snapshot[key]; !exists || factValueChanged(before, val) {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// factValueChanged compares two fact values without deep traversal of the references:
+// the slices, maps and pointers are equal only if they point to the same data.
+func factValueChanged(before, after interface{}) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+	bv, av := reflect.ValueOf(before), reflect.ValueOf(after)
+	if bv.Type() != av.Type() {
+		return true
+	}
+	switch bv.Kind() {
+	case reflect.Slice:
+		return bv.Len() != av.Len() || bv.Pointer() != av.Pointer()
+	case reflect.Map, reflect.Ptr, reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return bv.Pointer() != av.Pointer()
+	case reflect.Struct, reflect.Array, reflect.Interface:
+		return !reflect.DeepEqual(before, after)
+	default:
+		return before != after
+	}
+}
+
+// recordFactChanges appends the item to the providers of the facts which changed
+// since the snapshot and returns their names.
+func (pipeline *Pipeline) recordFactChanges(item PipelineItem, stage string,
+	snapshot, facts map[string]interface{},
+) []string {
+	changed := changedFacts(snapshot, facts)
+	for _, key := range changed {
+		pipeline.factHistory[key] = append(pipeline.factHistory[key],
+			FactProvider{Item: item.Name(), Stage: stage})
+	}
+	return changed
+}
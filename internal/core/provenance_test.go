@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upstreamFactTestPipelineItem is dependingTestPipelineItem which overrides the upstream
+// fact in ConfigureUpstream().
+type upstreamFactTestPipelineItem struct {
+	dependingTestPipelineItem
+}
+
+func (item *upstreamFactTestPipelineItem) ConfigureUpstream(facts map[string]interface{}) error {
+	facts["TestFact"] = false
+	facts["TestOption"] = 7
+	return nil
+}
+
+func TestPipelineExplainFacts(t *testing.T) {
+	pipeline := NewPipeline(test.Repository)
+	pipeline.AddItem(&upstreamFactTestPipelineItem{})
+	pipeline.DeployItem(&factSettingTestPipelineItem{})
+	require.NoError(t, pipeline.Initialize(map[string]interface{}{
+		"TestOption": 5, "Unused": "value",
+	}))
+	explained := map[string]FactProvenance{}
+	names := []string{}
+	for _, fact := range pipeline.ExplainFacts() {
+		explained[fact.Name] = fact
+		names = append(names, fact.Name)
+	}
+	assert.IsNonDecreasing(t, names)
+
+	fact := explained["TestFact"]
+	assert.Equal(t, false, fact.Value)
+	assert.False(t, fact.Initial)
+	assert.Equal(t, []FactProvider{
+		{Item: "Test", Stage: FactStageConfigure},
+		{Item: "Test2", Stage: FactStageConfigureUpstream},
+	}, fact.Providers)
+
+	fact = explained["TestOption"]
+	assert.Equal(t, 7, fact.Value)
+	assert.True(t, fact.Initial)
+	assert.Equal(t, []FactProvider{{Item: "Test2", Stage: FactStageConfigureUpstream}}, fact.Providers)
+
+	fact = explained["Unused"]
+	assert.True(t, fact.Initial)
+	assert.Empty(t, fact.Providers)
+
+	fact = explained[ConfigLogger]
+	assert.False(t, fact.Initial)
+	assert.Empty(t, fact.Providers)
+}
+
+func TestFactValueChanged(t *testing.T) {
+	slice := []int{1, 2, 3}
+	dict := map[string]int{}
+	assert.False(t, factValueChanged(nil, nil))
+	assert.True(t, factValueChanged(nil, 1))
+	assert.True(t, factValueChanged(1, nil))
+	assert.False(t, factValueChanged(1, 1))
+	assert.True(t, factValueChanged(1, 2))
+	assert.True(t, factValueChanged(1, int64(1)))
+	assert.False(t, factValueChanged(slice, slice))
+	assert.True(t, factValueChanged(slice, slice[:2]))
+	assert.True(t, factValueChanged(slice, []int{1, 2, 3}))
+	assert.False(t, factValueChanged(dict, dict))
+	assert.True(t, factValueChanged(dict, map[string]int{}))
+	assert.False(t, factValueChanged(FactProvider{Item: "a"}, FactProvider{Item: "a"}))
+	assert.True(t, factValueChanged(FactProvider{Item: "a"}, FactProvider{Item: "b"}))
+	assert.False(t, factValueChanged(TestFactValueChanged, TestFactValueChanged))
+}
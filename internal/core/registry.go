@@ -2,9 +2,11 @@ package core
 
 import (
 	"fmt"
+	"plugin"
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 	"unsafe"
 
 	"github.com/spf13/cobra"
@@ -18,6 +20,10 @@ type PipelineItemRegistry struct {
 	preferred    map[string]struct{}
 	flags        map[string]reflect.Type
 	featureFlags arrayFeatureFlags
+	// disabledFeatureFlags share Choices with featureFlags.
+	disabledFeatureFlags arrayFeatureFlags
+	// factFlags maps the facts to the command line flags which AddFlags() created for them.
+	factFlags map[string]string
 }
 
 // Register adds another PipelineItem to the registry.
@@ -226,6 +232,7 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 	deployed = map[string]*bool{}
 	activations = map[string][]string{}
 	reusableOptions := map[string]ConfigurationOption{}
+	registry.factFlags = map[string]string{}
 
 	for name, it := range registry.registered {
 		formatHelp := func(desc string) string {
@@ -312,6 +319,7 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 			}
 
 			flags[opt.Name] = iface
+			registry.factFlags[opt.Name] = opt.Flag
 			addFlagActivation(opt.Flag)
 		}
 	}
@@ -341,6 +349,96 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 		ptr5 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
 		*ptr5 = flagSet.Bool("print-actions", false, "Print the executed actions to stderr.")
 		flags[ConfigPipelinePrintActions] = iface
+		iface = interface{}(true)
+		ptr6 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr6 = flagSet.Bool("continue-on-error", false,
+			"Skip the commits on which an analysis fails instead of aborting: the failed analysis "+
+				"and every item scheduled after it skip the commit, including the unrelated ones, "+
+				"the previous items keep it; the failures are reported at the end.")
+		flags[ConfigPipelineContinueOnError] = iface
+		iface = interface{}("")
+		ptr7 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr7 = flagSet.String("dag-format", "", "Format of --dump-dag: dot, mermaid or json. "+
+			"These include the configuration, features and facts of each item. "+
+			"By default, the plain topological dump is written.")
+		flags[ConfigPipelineDAGFormat] = iface
+		iface = interface{}(true)
+		ptr8 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr8 = flagSet.Bool("mainline-only", false,
+			"Collapse the side branches into their merge commits, which are analysed as squashed "+
+				"single commits. Faster on repositories with many branches but less precise.")
+		flags[ConfigPipelineMainlineOnly] = iface
+		iface = interface{}(0)
+		ptr9 := (**int)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr9 = flagSet.Int("stride", 0,
+			"Analyse only every Nth mainline commit, applying the skipped commits at once. "+
+				"Implies --mainline-only. Gives approximate results much faster. 0 disables.")
+		flags[ConfigPipelineCommitStride] = iface
+		iface = interface{}(time.Duration(0))
+		ptr10 := (**time.Duration)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr10 = flagSet.Duration("commit-timeout", 0,
+			"Maximum duration of each analysis step on a commit, e.g. 10m. The stuck analysis is "+
+				"reported with the goroutine dump; the run aborts or, with --continue-on-error, the "+
+				"stuck analysis and every item scheduled after it skip the rest of the commits. "+
+				"0 disables.")
+		flags[ConfigPipelineCommitTimeout] = iface
+		iface = interface{}("")
+		ptr11 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr11 = flagSet.String("plan-cache", "",
+			"Cache the run plan in the specified file and reuse it while the repository HEAD, "+
+				"the commits and the plan options stay the same. Saves minutes on huge histories.")
+		flags[ConfigPipelinePlanCache] = iface
+		PathifyFlagValue(flagSet.Lookup("plan-cache"))
+		iface = interface{}("")
+		ptr12 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr12 = flagSet.String("policy", "",
+			"YAML file which restricts the permitted analyses, repositories and per-person data "+
+				"for each --caller. The forbidden runs fail before the analysis starts.")
+		flags[ConfigPipelinePolicy] = iface
+		PathifyFlagValue(flagSet.Lookup("policy"))
+		iface = interface{}("")
+		ptr13 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr13 = flagSet.String("caller", "", "Name of the caller in the --policy file.")
+		flags[ConfigPipelineCaller] = iface
+		iface = interface{}(true)
+		ptr14 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr14 = flagSet.Bool("partial-results", false,
+			"Write the results of the commits analysed so far if the run is interrupted with "+
+				"SIGINT or SIGTERM.")
+		flags[ConfigPipelinePartialResults] = iface
+		iface = interface{}("")
+		ptr15 := (**string)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr15 = flagSet.String("checkpoint", "",
+			"Save the analysis state to the specified file if the run is interrupted with SIGINT "+
+				"or SIGTERM, so that --resume continues from the last analysed commit.")
+		flags[ConfigPipelineCheckpoint] = iface
+		PathifyFlagValue(flagSet.Lookup("checkpoint"))
+		iface = interface{}(true)
+		ptr16 := (**bool)(unsafe.Pointer(uintptr(unsafe.Pointer(&iface)) + unsafe.Sizeof(&iface)))
+		*ptr16 = flagSet.Bool("resume", false,
+			"Continue the interrupted run from the --checkpoint file. The other arguments must "+
+				"stay the same.")
+		flags[ConfigPipelineResume] = iface
+		for fact, flag := range map[string]string{
+			ConfigPipelineDAGPath:             "dump-dag",
+			ConfigPipelineDryRun:              "dry-run",
+			ConfigPipelineDumpPlan:            "dump-plan",
+			ConfigPipelineHibernationDistance: "hibernation-distance",
+			ConfigPipelinePrintActions:        "print-actions",
+			ConfigPipelineContinueOnError:     "continue-on-error",
+			ConfigPipelineDAGFormat:           "dag-format",
+			ConfigPipelineMainlineOnly:        "mainline-only",
+			ConfigPipelineCommitStride:        "stride",
+			ConfigPipelineCommitTimeout:       "commit-timeout",
+			ConfigPipelinePlanCache:           "plan-cache",
+			ConfigPipelinePolicy:              "policy",
+			ConfigPipelineCaller:              "caller",
+			ConfigPipelinePartialResults:      "partial-results",
+			ConfigPipelineCheckpoint:          "checkpoint",
+			ConfigPipelineResume:              "resume",
+		} {
+			registry.factFlags[fact] = flag
+		}
 	}
 	var features []string
 	for f := range registry.featureFlags.Choices {
@@ -352,10 +450,66 @@ func (registry *PipelineItemRegistry) AddFlags(flagSet *pflag.FlagSet) (
 		strings.Join(features, ", "))
 	flagSet.Var(&registry.featureFlags, "feature",
 		featureHelp)
+	registry.disabledFeatureFlags.Choices = registry.featureFlags.Choices
+	flagSet.Var(&registry.disabledFeatureFlags, "disable-feature",
+		"Disables the specified feature even if it is enabled by default or by an analysis. "+
+			"The analyses which depend on it are rejected. Can be specified multiple times.")
 
 	return
 }
 
+// FactFlag returns the command line flag which the latest AddFlags() created for the fact,
+// or an empty string if there is none.
+func (registry *PipelineItemRegistry) FactFlag(fact string) string {
+	return registry.factFlags[fact]
+}
+
+// PluginRegisterSymbol is the name of the function which Go plugins loaded by LoadPlugin()
+// export to register their items. The signature must be either
+// func(*PipelineItemRegistry) or func(*PipelineItemRegistry) error.
+const PluginRegisterSymbol = "RegisterPipelineItems"
+
+// pluginSymbols is the part of *plugin.Plugin which LoadPlugin() uses.
+type pluginSymbols interface {
+	Lookup(symName string) (plugin.Symbol, error)
+}
+
+// LoadPlugin opens the Go plugin shared object at `path` and calls its exported
+// RegisterPipelineItems function with the registry. Older plugins which register
+// their items into the global Registry in init() are accepted, too.
+func (registry *PipelineItemRegistry) LoadPlugin(path string) error {
+	registeredBefore := len(Registry.registered)
+	plug, err := plugin.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to load plugin %s: %w", path, err)
+	}
+	return registry.registerPlugin(path, plug, len(Registry.registered) > registeredBefore)
+}
+
+func (registry *PipelineItemRegistry) registerPlugin(
+	path string, plug pluginSymbols, registeredInInit bool,
+) error {
+	symbol, err := plug.Lookup(PluginRegisterSymbol)
+	if err != nil {
+		if registeredInInit {
+			return nil
+		}
+		return fmt.Errorf("plugin %s does not export %s: %w", path, PluginRegisterSymbol, err)
+	}
+	switch register := symbol.(type) {
+	case func(*PipelineItemRegistry):
+		register(registry)
+	case func(*PipelineItemRegistry) error:
+		if err := register(registry); err != nil {
+			return fmt.Errorf("plugin %s failed to register the items: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("plugin %s exports %s of the unsupported type %T",
+			path, PluginRegisterSymbol, symbol)
+	}
+	return nil
+}
+
 // Registry contains all known pipeline item types.
 var Registry = &PipelineItemRegistry{
 	provided:     map[string][]reflect.Type{},
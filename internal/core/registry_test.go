@@ -1,9 +1,12 @@
 package core
 
 import (
+	"errors"
 	"os"
+	"plugin"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/meko-christian/hercules/internal/test"
@@ -225,22 +228,41 @@ func TestRegistryAddFlags(t *testing.T) {
 	}
 	facts, deployed, activations := reg.AddFlags(testCmd.Flags())
 	assert.Equal(t, map[string][]string{"test-option": {"Test"}}, activations)
-	assert.Len(t, facts, 7)
+	assert.Len(t, facts, 18)
 	assert.IsType(t, 0, facts[(&testPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.IsType(t, true, facts[(&dummyPipelineItem{}).ListConfigurationOptions()[0].Name])
 	assert.Contains(t, facts, ConfigPipelineDryRun)
 	assert.Contains(t, facts, ConfigPipelineDAGPath)
+	assert.Contains(t, facts, ConfigPipelineDAGFormat)
 	assert.Contains(t, facts, ConfigPipelineDumpPlan)
 	assert.Contains(t, facts, ConfigPipelineHibernationDistance)
+	assert.Contains(t, facts, ConfigPipelineContinueOnError)
+	assert.Contains(t, facts, ConfigPipelineMainlineOnly)
+	assert.Contains(t, facts, ConfigPipelineCommitStride)
+	assert.IsType(t, time.Duration(0), facts[ConfigPipelineCommitTimeout])
+	assert.IsType(t, "", facts[ConfigPipelinePlanCache])
+	assert.IsType(t, "", facts[ConfigPipelinePolicy])
+	assert.IsType(t, "", facts[ConfigPipelineCaller])
 	assert.Len(t, deployed, 1)
 	assert.Contains(t, deployed, (&testPipelineItem{}).Name())
 	assert.NotNil(t, testCmd.Flags().Lookup((&testPipelineItem{}).Flag()))
 	assert.NotNil(t, testCmd.Flags().Lookup("feature"))
+	assert.NotNil(t, testCmd.Flags().Lookup("disable-feature"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dump-dag"))
+	assert.NotNil(t, testCmd.Flags().Lookup("dag-format"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dump-plan"))
 	assert.NotNil(t, testCmd.Flags().Lookup("dry-run"))
 	assert.NotNil(t, testCmd.Flags().Lookup("hibernation-distance"))
 	assert.NotNil(t, testCmd.Flags().Lookup("print-actions"))
+	assert.NotNil(t, testCmd.Flags().Lookup("continue-on-error"))
+	assert.NotNil(t, testCmd.Flags().Lookup("mainline-only"))
+	assert.NotNil(t, testCmd.Flags().Lookup("stride"))
+	assert.NotNil(t, testCmd.Flags().Lookup("commit-timeout"))
+	assert.NotNil(t, testCmd.Flags().Lookup("plan-cache"))
+	for fact := range facts {
+		assert.NotNil(t, testCmd.Flags().Lookup(reg.FactFlag(fact)), fact)
+	}
+	assert.Equal(t, "", reg.FactFlag("unknown"))
 	assert.NotNil(t, testCmd.Flags().Lookup(
 		(&testPipelineItem{}).ListConfigurationOptions()[0].Flag))
 	assert.NotNil(t, testCmd.Flags().Lookup(
@@ -274,6 +296,32 @@ func TestRegistryFeatures(t *testing.T) {
 	assert.True(t, val)
 }
 
+func TestRegistryDisableFeature(t *testing.T) {
+	reg := getRegistry()
+	reg.Register(&dummyPipelineItem{})
+	reg.Register(&dummyPipelineItem2{})
+	testCmd := &cobra.Command{
+		Use:   "test",
+		Short: "Temporary command to test the stuff.",
+		Long:  ``,
+		Args:  cobra.MaximumNArgs(0),
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+	reg.AddFlags(testCmd.Flags())
+	assert.NoError(t, testCmd.ParseFlags([]string{"--feature", "power", "--disable-feature", "other"}))
+	pipeline := NewPipeline(test.Repository)
+	pipeline.SetFeature("other")
+	pipeline.SetFeaturesFromFlags(reg)
+	val, _ := pipeline.GetFeature("power")
+	assert.True(t, val)
+	val, exists := pipeline.GetFeature("other")
+	assert.False(t, val)
+	assert.True(t, exists)
+	err := testCmd.ParseFlags([]string{"--disable-feature", "missing"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not registered")
+}
+
 func TestRegistryFeaturesUnknownFeature(t *testing.T) {
 	reg := getRegistry()
 	reg.Register(&dummyPipelineItem{})
@@ -354,3 +402,54 @@ func TestRegistryPathMasquerade(t *testing.T) {
 	assert.Equal(t, flag.Value.Type(), "path")
 	assert.Equal(t, flag.Value.String(), "xxx")
 }
+
+type fakePluginSymbols map[string]plugin.Symbol
+
+func (symbols fakePluginSymbols) Lookup(name string) (plugin.Symbol, error) {
+	if symbol, exists := symbols[name]; exists {
+		return symbol, nil
+	}
+	return nil, errors.New("symbol not found")
+}
+
+func TestRegistryRegisterPlugin(t *testing.T) {
+	reg := getRegistry()
+	err := reg.registerPlugin("test.so", fakePluginSymbols{
+		PluginRegisterSymbol: func(registry *PipelineItemRegistry) {
+			registry.Register(&dummyPipelineItem{})
+		},
+	}, false)
+	assert.NoError(t, err)
+	assert.Len(t, reg.Summon("dummy"), 2)
+
+	err = reg.registerPlugin("test.so", fakePluginSymbols{
+		PluginRegisterSymbol: func(registry *PipelineItemRegistry) error {
+			registry.Register(&dummyPipelineItem3{})
+			return nil
+		},
+	}, false)
+	assert.NoError(t, err)
+	assert.Len(t, reg.Summon("dummy3"), 2)
+
+	err = reg.registerPlugin("test.so", fakePluginSymbols{
+		PluginRegisterSymbol: func(registry *PipelineItemRegistry) error {
+			return errors.New("license expired")
+		},
+	}, false)
+	assert.EqualError(t, err, "plugin test.so failed to register the items: license expired")
+
+	err = reg.registerPlugin("test.so", fakePluginSymbols{PluginRegisterSymbol: 7}, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported type int")
+
+	assert.NoError(t, reg.registerPlugin("test.so", fakePluginSymbols{}, true))
+	err = reg.registerPlugin("test.so", fakePluginSymbols{}, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "does not export RegisterPipelineItems")
+}
+
+func TestRegistryLoadPluginMissing(t *testing.T) {
+	err := getRegistry().LoadPlugin("/does/not/exist.so")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "/does/not/exist.so")
+}
@@ -0,0 +1,222 @@
+package core
+
+import (
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/hybrid"
+	"github.com/pkg/errors"
+)
+
+// WorktreeCommitMessage is the message of the synthetic commit created by CommitWorktree().
+const WorktreeCommitMessage = "Uncommitted changes in the worktree"
+
+// NewWorktreeRepository opens the same repository with the storage which keeps the new objects
+// in memory. CommitWorktree() writes the synthetic commit there without touching the disk.
+func NewWorktreeRepository(repository *git.Repository) (*git.Repository, error) {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, errors.Wrap(err, "the repository has no worktree")
+	}
+	return git.Open(hybrid.NewOverlay(repository.Storer), worktree.Filesystem)
+}
+
+// CommitWorktree creates the synthetic commit on top of HEAD with all the uncommitted changes
+// in the worktree, staged or not, including the untracked files which are not ignored - like
+// `git add -A && git commit` would. It returns nil if the worktree is clean. The repository
+// should be opened with NewWorktreeRepository() to avoid writing the objects to the disk.
+func CommitWorktree(repository *git.Repository) (*object.Commit, error) {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil, errors.Wrap(err, "the repository has no worktree")
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the worktree status")
+	}
+	if status.IsClean() {
+		return nil, nil
+	}
+	head, err := repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve HEAD")
+	}
+	headCommit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	entries := map[string]object.TreeEntry{}
+	walker := object.NewTreeWalker(headTree, true, nil)
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			walker.Close()
+			return nil, err
+		}
+		if entry.Mode != filemode.Dir {
+			entries[name] = entry
+		}
+	}
+	walker.Close()
+	for name := range status {
+		if _, err := worktree.Filesystem.Lstat(name); os.IsNotExist(err) {
+			delete(entries, name)
+			continue
+		}
+		entry, err := storeWorktreeFile(repository, worktree.Filesystem, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", name)
+		}
+		entries[name] = entry
+	}
+	treeHash, err := storeWorktreeTree(repository, entries)
+	if err != nil {
+		return nil, err
+	}
+	signature := object.Signature{Name: "Worktree", Email: "worktree@localhost", When: time.Now()}
+	if cfg, err := repository.ConfigScoped(config.GlobalScope); err == nil && cfg.User.Name != "" {
+		signature.Name, signature.Email = cfg.User.Name, cfg.User.Email
+	}
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      WorktreeCommitMessage,
+		TreeHash:     treeHash,
+		ParentHashes: []plumbing.Hash{head.Hash()},
+	}
+	hash, err := storeWorktreeObject(repository, commit)
+	if err != nil {
+		return nil, err
+	}
+	return repository.CommitObject(hash)
+}
+
+// storeWorktreeFile saves the contents of the file or the target of the symbolic link as a blob.
+func storeWorktreeFile(repository *git.Repository, fs billy.Filesystem, name string) (
+	object.TreeEntry, error,
+) {
+	info, err := fs.Lstat(name)
+	if err != nil {
+		return object.TreeEntry{}, err
+	}
+	mode, err := filemode.NewFromOSFileMode(info.Mode())
+	if err != nil {
+		return object.TreeEntry{}, err
+	}
+	var data []byte
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := fs.Readlink(name)
+		if err != nil {
+			return object.TreeEntry{}, err
+		}
+		data = []byte(target)
+	} else {
+		file, err := fs.Open(name)
+		if err != nil {
+			return object.TreeEntry{}, err
+		}
+		data, err = io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return object.TreeEntry{}, err
+		}
+	}
+	blob := repository.Storer.NewEncodedObject()
+	blob.SetType(plumbing.BlobObject)
+	writer, err := blob.Writer()
+	if err != nil {
+		return object.TreeEntry{}, err
+	}
+	if _, err = writer.Write(data); err != nil {
+		return object.TreeEntry{}, err
+	}
+	if err = writer.Close(); err != nil {
+		return object.TreeEntry{}, err
+	}
+	hash, err := repository.Storer.SetEncodedObject(blob)
+	if err != nil {
+		return object.TreeEntry{}, err
+	}
+	return object.TreeEntry{Name: name, Mode: mode, Hash: hash}, nil
+}
+
+// storeWorktreeTree saves the trees of the entries which are keyed by the full paths and returns
+// the hash of the root tree.
+func storeWorktreeTree(repository *git.Repository, entries map[string]object.TreeEntry) (
+	plumbing.Hash, error,
+) {
+	// children maps the directories, "" is the root, to their entries
+	children := map[string][]object.TreeEntry{}
+	for name, entry := range entries {
+		dir, base := path.Split(name)
+		if _, exists := children[dir]; !exists {
+			children[dir] = nil
+			// register the new directory in its parents
+			for child := dir; child != ""; {
+				parent, base := path.Split(strings.TrimSuffix(child, "/"))
+				_, known := children[parent]
+				children[parent] = append(children[parent], object.TreeEntry{Name: base, Mode: filemode.Dir})
+				if known {
+					break
+				}
+				child = parent
+			}
+		}
+		entry.Name = base
+		children[dir] = append(children[dir], entry)
+	}
+	var store func(dir string) (plumbing.Hash, error)
+	store = func(dir string) (plumbing.Hash, error) {
+		tree := object.Tree{Entries: children[dir]}
+		for i, entry := range tree.Entries {
+			if entry.Mode != filemode.Dir {
+				continue
+			}
+			hash, err := store(dir + entry.Name + "/")
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			tree.Entries[i].Hash = hash
+		}
+		// Git sorts the directories as if their names ended with a slash
+		sortKey := func(entry object.TreeEntry) string {
+			if entry.Mode == filemode.Dir {
+				return entry.Name + "/"
+			}
+			return entry.Name
+		}
+		sort.Slice(tree.Entries, func(i, j int) bool {
+			return sortKey(tree.Entries[i]) < sortKey(tree.Entries[j])
+		})
+		return storeWorktreeObject(repository, &tree)
+	}
+	return store("")
+}
+
+func storeWorktreeObject(repository *git.Repository, obj interface {
+	Encode(plumbing.EncodedObject) error
+},
+) (plumbing.Hash, error) {
+	encoded := repository.Storer.NewEncodedObject()
+	if err := obj.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return repository.Storer.SetEncodedObject(encoded)
+}
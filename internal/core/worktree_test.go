@@ -0,0 +1,93 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitWorktree(t *testing.T) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	write := func(name, text string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644))
+	}
+	write("a.txt", "a\n")
+	write("dir/b.txt", "b\n")
+	write("dir/sub/c.txt", "c\n")
+	write("dir.txt", "sorted after dir/\n")
+	write(".gitignore", "*.log\n")
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	require.NoError(t, worktree.AddGlob("."))
+	signature := &object.Signature{Name: "test", Email: "test@test", When: time.Unix(1000, 0)}
+	headHash, err := worktree.Commit("initial", &git.CommitOptions{Author: signature})
+	require.NoError(t, err)
+
+	overlay, err := NewWorktreeRepository(repository)
+	require.NoError(t, err)
+	commit, err := CommitWorktree(overlay)
+	require.NoError(t, err)
+	assert.Nil(t, commit)
+
+	write("a.txt", "a\nchanged\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "dir", "b.txt")))
+	write("new/d.txt", "d\n")
+	write("debug.log", "ignored\n")
+	commit, err = CommitWorktree(overlay)
+	require.NoError(t, err)
+	require.NotNil(t, commit)
+	assert.Equal(t, WorktreeCommitMessage, commit.Message)
+	assert.Equal(t, []plumbing.Hash{headHash}, commit.ParentHashes)
+
+	files := map[string]string{}
+	iter, err := commit.Files()
+	require.NoError(t, err)
+	require.NoError(t, iter.ForEach(func(file *object.File) error {
+		files[file.Name], err = file.Contents()
+		return err
+	}))
+	assert.Equal(t, map[string]string{
+		".gitignore":    "*.log\n",
+		"a.txt":         "a\nchanged\n",
+		"dir.txt":       "sorted after dir/\n",
+		"dir/sub/c.txt": "c\n",
+		"new/d.txt":     "d\n",
+	}, files)
+
+	// the unchanged subtree is encoded exactly like Git does
+	head, err := repository.CommitObject(headHash)
+	require.NoError(t, err)
+	headTree, err := head.Tree()
+	require.NoError(t, err)
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+	headSub, err := headTree.FindEntry("dir/sub")
+	require.NoError(t, err)
+	sub, err := tree.FindEntry("dir/sub")
+	require.NoError(t, err)
+	assert.Equal(t, headSub.Hash, sub.Hash)
+
+	// nothing is written to the disk
+	_, err = repository.CommitObject(commit.Hash)
+	assert.Error(t, err)
+	ref, err := repository.Head()
+	require.NoError(t, err)
+	assert.Equal(t, headHash, ref.Hash())
+}
+
+func TestNewWorktreeRepositoryBare(t *testing.T) {
+	repository, err := git.PlainInit(t.TempDir(), true)
+	require.NoError(t, err)
+	_, err = NewWorktreeRepository(repository)
+	assert.Error(t, err)
+}
@@ -26,21 +26,22 @@ func TestPipelineSerialize(t *testing.T) {
 	bdot, _ := ioutil.ReadFile(dotpath)
 	dot := string(bdot)
 	assert.Equal(t, `digraph Hercules {
-  "5 BlobCache_1" -> "6 [blob_cache]"
-  "8 [changes]" -> "9 FileDiff_1"
-  "8 [changes]" -> "11 LegacyBurndown_1"
-  "6 [blob_cache]" -> "9 FileDiff_1"
-  "6 [blob_cache]" -> "11 LegacyBurndown_1"
-  "6 [blob_cache]" -> "7 RenameAnalysis_1"
-  "9 FileDiff_1" -> "10 [file_diff]"
-  "10 [file_diff]" -> "11 LegacyBurndown_1"
-  "4 [tick]" -> "11 LegacyBurndown_1"
-  "3 [author]" -> "11 LegacyBurndown_1"
+  "6 BlobCache_1" -> "7 [blob_cache]"
+  "9 [changes]" -> "10 FileDiff_1"
+  "9 [changes]" -> "12 LegacyBurndown_1"
+  "7 [blob_cache]" -> "10 FileDiff_1"
+  "7 [blob_cache]" -> "12 LegacyBurndown_1"
+  "7 [blob_cache]" -> "8 RenameAnalysis_1"
+  "10 FileDiff_1" -> "11 [file_diff]"
+  "11 [file_diff]" -> "12 LegacyBurndown_1"
+  "4 [tick]" -> "12 LegacyBurndown_1"
+  "3 [author]" -> "12 LegacyBurndown_1"
+  "5 PathFilter_1" -> "6 BlobCache_1"
+  "5 PathFilter_1" -> "8 RenameAnalysis_1"
   "0 PeopleDetector_1" -> "3 [author]"
-  "7 RenameAnalysis_1" -> "8 [changes]"
+  "8 RenameAnalysis_1" -> "9 [changes]"
   "1 TicksSinceStart_1" -> "4 [tick]"
-  "2 TreeDiff_1" -> "5 BlobCache_1"
-  "2 TreeDiff_1" -> "7 RenameAnalysis_1"
+  "2 TreeDiff_1" -> "5 PathFilter_1"
 }`, dot)
 }
 
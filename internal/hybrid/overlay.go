@@ -0,0 +1,65 @@
+package hybrid
+
+import (
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// Overlay is the go-git storage which writes the new objects to memory and reads the rest
+// from the underlying storage. The references and the configuration are not affected,
+// so the objects of the synthetic commits never reach the repository on disk.
+type Overlay struct {
+	// Storer is the underlying storage.
+	storage.Storer
+
+	objects *memory.ObjectStorage
+}
+
+// NewOverlay creates the overlay on top of the storage.
+func NewOverlay(base storage.Storer) *Overlay {
+	return &Overlay{Storer: base, objects: &memory.NewStorage().ObjectStorage}
+}
+
+// SetEncodedObject saves the object in memory.
+func (o *Overlay) SetEncodedObject(obj plumbing.EncodedObject) (plumbing.Hash, error) {
+	return o.objects.SetEncodedObject(obj)
+}
+
+// EncodedObject looks up the object in memory and then in the underlying storage.
+func (o *Overlay) EncodedObject(t plumbing.ObjectType, hash plumbing.Hash) (plumbing.EncodedObject, error) {
+	if obj, err := o.objects.EncodedObject(t, hash); err == nil {
+		return obj, nil
+	}
+	return o.Storer.EncodedObject(t, hash)
+}
+
+// HasEncodedObject checks both the memory and the underlying storage.
+func (o *Overlay) HasEncodedObject(hash plumbing.Hash) error {
+	if o.objects.HasEncodedObject(hash) == nil {
+		return nil
+	}
+	return o.Storer.HasEncodedObject(hash)
+}
+
+// EncodedObjectSize returns the size of the object from the memory or the underlying storage.
+func (o *Overlay) EncodedObjectSize(hash plumbing.Hash) (int64, error) {
+	if size, err := o.objects.EncodedObjectSize(hash); err == nil {
+		return size, nil
+	}
+	return o.Storer.EncodedObjectSize(hash)
+}
+
+// IterEncodedObjects iterates over the objects in memory and then in the underlying storage.
+func (o *Overlay) IterEncodedObjects(t plumbing.ObjectType) (storer.EncodedObjectIter, error) {
+	own, err := o.objects.IterEncodedObjects(t)
+	if err != nil {
+		return nil, err
+	}
+	base, err := o.Storer.IterEncodedObjects(t)
+	if err != nil {
+		return nil, err
+	}
+	return storer.NewMultiEncodedObjectIter([]storer.EncodedObjectIter{own, base}), nil
+}
@@ -0,0 +1,57 @@
+package hybrid
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func storeTestBlob(t *testing.T, storer interface {
+	NewEncodedObject() plumbing.EncodedObject
+	SetEncodedObject(plumbing.EncodedObject) (plumbing.Hash, error)
+}, text string,
+) plumbing.Hash {
+	obj := storer.NewEncodedObject()
+	obj.SetType(plumbing.BlobObject)
+	writer, err := obj.Writer()
+	require.NoError(t, err)
+	_, err = writer.Write([]byte(text))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	hash, err := storer.SetEncodedObject(obj)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestOverlay(t *testing.T) {
+	base := memory.NewStorage()
+	baseHash := storeTestBlob(t, base, "base")
+	overlay := NewOverlay(base)
+	ownHash := storeTestBlob(t, overlay, "own")
+
+	assert.NoError(t, overlay.HasEncodedObject(baseHash))
+	assert.NoError(t, overlay.HasEncodedObject(ownHash))
+	assert.Equal(t, plumbing.ErrObjectNotFound, base.HasEncodedObject(ownHash))
+	obj, err := overlay.EncodedObject(plumbing.BlobObject, ownHash)
+	require.NoError(t, err)
+	assert.Equal(t, ownHash, obj.Hash())
+	_, err = overlay.EncodedObject(plumbing.BlobObject, baseHash)
+	assert.NoError(t, err)
+	size, err := overlay.EncodedObjectSize(ownHash)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), size)
+	_, err = overlay.EncodedObject(plumbing.AnyObject, plumbing.NewHash("1111111111111111111111111111111111111111"))
+	assert.Equal(t, plumbing.ErrObjectNotFound, err)
+
+	iter, err := overlay.IterEncodedObjects(plumbing.BlobObject)
+	require.NoError(t, err)
+	count := 0
+	require.NoError(t, iter.ForEach(func(plumbing.EncodedObject) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,157 @@
+package hybrid
+
+import (
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// Storage is the go-git storage which keeps everything in memory until a received packfile
+// exceeds Limit bytes. Then it moves the objects, the references and the configuration
+// to a temporary directory and continues as the file system storage. Thus the small repositories
+// are cloned fast and the large ones do not run out of memory. Close() removes the directory.
+type Storage struct {
+	// Storer is the current backend, either the memory or the file system storage.
+	storage.Storer
+	// Limit is the maximum size of a packfile in bytes which is kept in memory.
+	// 0 disables spilling.
+	Limit int64
+	// TempDir is the parent of the temporary directory, os.TempDir() if empty.
+	TempDir string
+
+	// dir is the temporary directory of the file system storage, empty while in memory.
+	dir string
+}
+
+// NewStorage creates the hybrid storage which spills the packfiles bigger than `limit` bytes
+// to a new directory in `tempDir`.
+func NewStorage(limit int64, tempDir string) *Storage {
+	return &Storage{Storer: memory.NewStorage(), Limit: limit, TempDir: tempDir}
+}
+
+// Dir returns the temporary directory of the spilled storage. It is empty while the storage
+// is in memory.
+func (s *Storage) Dir() string {
+	return s.dir
+}
+
+// PackfileWriter implements storer.PackfileWriter. go-git writes the fetched packfiles there.
+func (s *Storage) PackfileWriter() (io.WriteCloser, error) {
+	if pw, ok := s.Storer.(storer.PackfileWriter); ok {
+		return pw.PackfileWriter()
+	}
+	return &packfileWriter{storage: s}, nil
+}
+
+// Close releases the files of the spilled storage and removes its directory.
+func (s *Storage) Close() error {
+	if s.dir == "" {
+		return nil
+	}
+	if closer, ok := s.Storer.(io.Closer); ok {
+		_ = closer.Close()
+	}
+	err := os.RemoveAll(s.dir)
+	s.Storer = memory.NewStorage()
+	s.dir = ""
+	return err
+}
+
+// spill moves the contents of the memory storage to a new temporary directory.
+func (s *Storage) spill() error {
+	dir, err := os.MkdirTemp(s.TempDir, "hercules-clone-")
+	if err != nil {
+		return err
+	}
+	disk := filesystem.NewStorage(osfs.New(dir), cache.NewObjectLRUDefault())
+	if err = copyStorage(s.Storer, disk); err != nil {
+		_ = disk.Close()
+		_ = os.RemoveAll(dir)
+		return err
+	}
+	s.Storer = disk
+	s.dir = dir
+	return nil
+}
+
+// copyStorage copies the configuration, the references, the shallow commits and the objects.
+func copyStorage(from, to storage.Storer) error {
+	config, err := from.Config()
+	if err != nil {
+		return err
+	}
+	if err = to.SetConfig(config); err != nil {
+		return err
+	}
+	refs, err := from.IterReferences()
+	if err != nil {
+		return err
+	}
+	if err = refs.ForEach(to.SetReference); err != nil {
+		return err
+	}
+	shallow, err := from.Shallow()
+	if err != nil {
+		return err
+	}
+	if len(shallow) > 0 {
+		if err = to.SetShallow(shallow); err != nil {
+			return err
+		}
+	}
+	objects, err := from.IterEncodedObjects(plumbing.AnyObject)
+	if err != nil {
+		return err
+	}
+	return objects.ForEach(func(obj plumbing.EncodedObject) error {
+		_, err := to.SetEncodedObject(obj)
+		return err
+	})
+}
+
+// packfileWriter buffers the packfile in memory and switches the storage to disk
+// once the buffer grows beyond the limit.
+type packfileWriter struct {
+	storage *Storage
+	buffer  bytes.Buffer
+	// disk is the packfile writer of the spilled storage.
+	disk io.WriteCloser
+}
+
+func (w *packfileWriter) Write(p []byte) (int, error) {
+	if w.disk != nil {
+		return w.disk.Write(p)
+	}
+	n, _ := w.buffer.Write(p)
+	if w.storage.Limit <= 0 || int64(w.buffer.Len()) <= w.storage.Limit {
+		return n, nil
+	}
+	if err := w.storage.spill(); err != nil {
+		return n, err
+	}
+	disk, err := w.storage.Storer.(storer.PackfileWriter).PackfileWriter()
+	if err != nil {
+		return n, err
+	}
+	w.disk = disk
+	if _, err = w.buffer.WriteTo(disk); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+func (w *packfileWriter) Close() error {
+	if w.disk != nil {
+		return w.disk.Close()
+	}
+	return packfile.UpdateObjectStorage(w.storage.Storer, &w.buffer)
+}
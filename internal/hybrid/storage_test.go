@@ -0,0 +1,151 @@
+package hybrid
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeTestPackfile commits several files and returns the packfile with all the objects
+// and the hash of the last commit.
+func makeTestPackfile(t *testing.T) ([]byte, plumbing.Hash) {
+	source := memory.NewStorage()
+	fs := memfs.New()
+	repo, err := git.Init(source, fs)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	var head plumbing.Hash
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("file%d.txt", i)
+		require.NoError(t, util.WriteFile(fs, name, bytes.Repeat([]byte(name+"\n"), 100), 0644))
+		_, err = worktree.Add(name)
+		require.NoError(t, err)
+		head, err = worktree.Commit(name, &git.CommitOptions{Author: &object.Signature{
+			Name: "test", Email: "test@test", When: time.Unix(int64(i), 0),
+		}})
+		require.NoError(t, err)
+	}
+	var hashes []plumbing.Hash
+	objects, err := source.IterEncodedObjects(plumbing.AnyObject)
+	require.NoError(t, err)
+	require.NoError(t, objects.ForEach(func(obj plumbing.EncodedObject) error {
+		hashes = append(hashes, obj.Hash())
+		return nil
+	}))
+	buffer := &bytes.Buffer{}
+	_, err = packfile.NewEncoder(buffer, source, false).Encode(hashes, 10)
+	require.NoError(t, err)
+	return buffer.Bytes(), head
+}
+
+// writeTestPackfile writes the packfile in small chunks like the network transport.
+func writeTestPackfile(t *testing.T, storage *Storage, pack []byte) {
+	writer, err := storage.PackfileWriter()
+	require.NoError(t, err)
+	for len(pack) > 0 {
+		chunk := 100
+		if chunk > len(pack) {
+			chunk = len(pack)
+		}
+		_, err = writer.Write(pack[:chunk])
+		require.NoError(t, err)
+		pack = pack[chunk:]
+	}
+	require.NoError(t, writer.Close())
+}
+
+func TestStorageInMemory(t *testing.T) {
+	pack, head := makeTestPackfile(t)
+	for _, limit := range []int64{0, int64(len(pack))} {
+		storage := NewStorage(limit, t.TempDir())
+		writeTestPackfile(t, storage, pack)
+		assert.Empty(t, storage.Dir())
+		assert.IsType(t, &memory.Storage{}, storage.Storer)
+		commit, err := object.GetCommit(storage, head)
+		require.NoError(t, err)
+		assert.Equal(t, "file4.txt", commit.Message)
+		assert.NoError(t, storage.Close())
+	}
+}
+
+func TestStorageSpill(t *testing.T) {
+	pack, head := makeTestPackfile(t)
+	storage := NewStorage(int64(len(pack)/2), t.TempDir())
+	ref := plumbing.NewHashReference("refs/heads/master", head)
+	require.NoError(t, storage.SetReference(ref))
+	config, err := storage.Config()
+	require.NoError(t, err)
+	config.Core.IsBare = true
+	require.NoError(t, storage.SetConfig(config))
+
+	writeTestPackfile(t, storage, pack)
+	dir := storage.Dir()
+	require.NotEmpty(t, dir)
+	assert.DirExists(t, dir)
+	commit, err := object.GetCommit(storage, head)
+	require.NoError(t, err)
+	assert.Equal(t, "file4.txt", commit.Message)
+	restoredRef, err := storage.Reference("refs/heads/master")
+	require.NoError(t, err)
+	assert.Equal(t, head, restoredRef.Hash())
+	config, err = storage.Config()
+	require.NoError(t, err)
+	assert.True(t, config.Core.IsBare)
+
+	// the second packfile goes straight to disk
+	writer, err := storage.PackfileWriter()
+	require.NoError(t, err)
+	assert.NotContains(t, fmt.Sprintf("%T", writer), "packfileWriter")
+	require.NoError(t, writer.Close())
+
+	require.NoError(t, storage.Close())
+	assert.Empty(t, storage.Dir())
+	_, err = os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+	assert.NoError(t, storage.Close())
+}
+
+func TestStorageClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	pack, head := makeTestPackfile(t)
+	origin := t.TempDir()
+	originRepo, err := git.PlainInit(origin, true)
+	require.NoError(t, err)
+	require.NoError(t, packfile.UpdateObjectStorage(originRepo.Storer, bytes.NewReader(pack)))
+	require.NoError(t, originRepo.Storer.SetReference(
+		plumbing.NewHashReference("refs/heads/master", head)))
+	require.NoError(t, originRepo.Storer.SetReference(
+		plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/master")))
+
+	for _, limit := range []int64{0, 100} {
+		storage := NewStorage(limit, t.TempDir())
+		repo, err := git.Clone(storage, nil, &git.CloneOptions{URL: origin})
+		require.NoError(t, err)
+		assert.Equal(t, limit > 0, storage.Dir() != "")
+		commits, err := repo.Log(&git.LogOptions{})
+		require.NoError(t, err)
+		count := 0
+		require.NoError(t, commits.ForEach(func(*object.Commit) error {
+			count++
+			return nil
+		}))
+		assert.Equal(t, 5, count)
+		assert.NoError(t, storage.Close())
+	}
+}
@@ -30,6 +30,9 @@ type File struct {
 	tree     *rbtree.RBTree
 	updaters []Updater
 	Id       FileId
+	// kinds is the kind of each line, nil if the lines are not classified. It is never modified
+	// in place, see replaceKinds(), so that the clones share it.
+	kinds []core.LineKind
 }
 
 // TreeEnd denotes the value of the last leaf in the tree.
@@ -111,23 +114,51 @@ func NewFileFromTree(keys []int, vals []int, allocator *rbtree.Allocator, update
 	return file
 }
 
+// dump returns the keys and the values of the tree nodes, see newFileFromDump().
+func (file *File) dump() (keys []uint32, vals []uint32) {
+	for iter := file.tree.Min(); !iter.Limit(); iter = iter.Next() {
+		keys = append(keys, iter.Item().Key)
+		vals = append(vals, iter.Item().Value)
+	}
+	return keys, vals
+}
+
+// newFileFromDump creates the File from the tree nodes which dump() returned.
+func newFileFromDump(id FileId, keys []uint32, vals []uint32, allocator *rbtree.Allocator,
+	updaters ...Updater,
+) *File {
+	file := &File{tree: rbtree.NewRBTree(allocator), updaters: updaters, Id: id}
+	for i, key := range keys {
+		file.tree.Insert(rbtree.Item{Key: key, Value: vals[i]})
+	}
+	return file
+}
+
 // CloneShallow copies the file. It performs a shallow copy of the tree: the allocator
 // must be Clone()-d beforehand.
 func (file *File) CloneShallow(allocator *rbtree.Allocator) *File {
-	return &File{tree: file.tree.CloneShallow(allocator), updaters: file.updaters, Id: file.Id}
+	return &File{
+		tree: file.tree.CloneShallow(allocator), updaters: file.updaters, Id: file.Id, kinds: file.kinds,
+	}
 }
 
 func (file *File) CloneShallowWithUpdaters(allocator *rbtree.Allocator, updaters ...Updater) *File {
-	return &File{tree: file.tree.CloneShallow(allocator), updaters: updaters, Id: file.Id}
+	return &File{
+		tree: file.tree.CloneShallow(allocator), updaters: updaters, Id: file.Id, kinds: file.kinds,
+	}
 }
 
 // CloneDeep copies the file. It performs a deep copy of the tree.
 func (file *File) CloneDeep(allocator *rbtree.Allocator) *File {
-	return &File{tree: file.tree.CloneDeep(allocator), updaters: file.updaters, Id: file.Id}
+	return &File{
+		tree: file.tree.CloneDeep(allocator), updaters: file.updaters, Id: file.Id, kinds: file.kinds,
+	}
 }
 
 func (file *File) CloneDeepWithUpdaters(allocator *rbtree.Allocator, updaters ...Updater) *File {
-	return &File{tree: file.tree.CloneDeep(allocator), updaters: updaters, Id: file.Id}
+	return &File{
+		tree: file.tree.CloneDeep(allocator), updaters: updaters, Id: file.Id, kinds: file.kinds,
+	}
 }
 
 // Delete deallocates the file.
@@ -148,6 +179,23 @@ func (file File) Nodes() int {
 	return file.tree.Len()
 }
 
+// replaceKinds replaces the kinds of delLength lines after pos with the inserted ones and returns
+// the removed kinds. It must be called before the matching Update(). The lines of the files
+// which were not classified before are considered code.
+func (file *File) replaceKinds(pos int, inserted []core.LineKind, delLength int) []core.LineKind {
+	kinds := file.kinds
+	if size := file.Len(); len(kinds) < size {
+		kinds = make([]core.LineKind, size)
+		copy(kinds, file.kinds)
+	}
+	removed := kinds[pos : pos+delLength]
+	replaced := make([]core.LineKind, 0, len(kinds)+len(inserted)-delLength)
+	replaced = append(replaced, kinds[:pos]...)
+	replaced = append(replaced, inserted...)
+	file.kinds = append(replaced, kinds[pos+delLength:]...)
+	return removed
+}
+
 // Update modifies the underlying tree to adapt to the specified line changes.
 //
 // time is the time when the requested changes are made. Sets the values of the
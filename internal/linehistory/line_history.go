@@ -1,8 +1,11 @@
 package linehistory
 
 import (
+	"compress/flate"
+	"encoding/gob"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
@@ -42,6 +45,10 @@ type LineHistoryAnalyser struct {
 	// violations.
 	Debug bool
 
+	// ClassifyLines records the kind of each line, so that the changes tell the code from
+	// the comments and the blank lines. See items.ConfigLinesStatsClassifyLines.
+	ClassifyLines bool
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 
@@ -67,6 +74,10 @@ type LineHistoryAnalyser struct {
 	previousTick core.TickNumber
 
 	changes []core.LineHistoryChange
+	// insertedKinds and removedKinds are the kinds of the lines which the next File.Update()
+	// inserts and removes, in the order of updateChangeList() calls.
+	insertedKinds []core.LineKind
+	removedKinds  []core.LineKind
 
 	l core.Logger
 }
@@ -232,6 +243,9 @@ func (analyser *LineHistoryAnalyser) Configure(facts map[string]interface{}) err
 	if val, exists := facts[ConfigLinesDebug].(bool); exists {
 		analyser.Debug = val
 	}
+	if val, exists := facts[items.ConfigLinesStatsClassifyLines].(bool); exists {
+		analyser.ClassifyLines = val
+	}
 
 	var resolver core.FileIdResolver = FileIdResolver{analyser}
 	facts[core.FactLineHistoryResolver] = resolver
@@ -494,6 +508,48 @@ func (analyser *LineHistoryAnalyser) updateChangeList(f *File, currentTime, prev
 		PrevAuthor: prevAuthor,
 	}
 
+	var kinds []core.LineKind
+	if delta > 0 {
+		kinds, analyser.insertedKinds = takeLineKinds(analyser.insertedKinds, delta)
+	} else if delta < 0 {
+		kinds, analyser.removedKinds = takeLineKinds(analyser.removedKinds, -delta)
+	}
+	if kinds == nil {
+		analyser.appendChange(change, delta)
+		return
+	}
+	sign := 1
+	if delta < 0 {
+		sign = -1
+	}
+	for start := 0; start < len(kinds); {
+		end := start + 1
+		for end < len(kinds) && kinds[end] == kinds[start] {
+			end++
+		}
+		change.Kind = kinds[start]
+		analyser.appendChange(change, sign*(end-start))
+		start = end
+	}
+}
+
+// takeLineKinds splits the first n kinds from the pending ones. The lines which are missing
+// from a non-empty list are considered code.
+func takeLineKinds(pending []core.LineKind, n int) (taken, rest []core.LineKind) {
+	if pending == nil {
+		return nil, nil
+	}
+	if len(pending) < n {
+		taken = make([]core.LineKind, n)
+		copy(taken, pending)
+		return taken, nil
+	}
+	return pending[:n], pending[n:]
+}
+
+// appendChange adds the change of delta lines to the list, merging it with the last one
+// if they differ only in the number of lines.
+func (analyser *LineHistoryAnalyser) appendChange(change core.LineHistoryChange, delta int) {
 	if len(analyser.changes) > 0 {
 		last := &analyser.changes[len(analyser.changes)-1]
 		if (delta <= 0 && last.Delta < 0) || (delta >= 0 && last.Delta > 0) {
@@ -551,9 +607,18 @@ func (analyser *LineHistoryAnalyser) handleInsertion(
 		return fmt.Errorf("file %s already exists", name)
 	}
 
+	var kinds []core.LineKind
+	if analyser.ClassifyLines {
+		kinds = items.ClassifyBlob(name, blob)
+		analyser.insertedKinds = kinds
+	}
 	hash := blob.Hash
 	file, err = analyser.newFile(hash, name, author, analyser.tick, lines)
-	return err
+	if err != nil {
+		return err
+	}
+	file.kinds = kinds
+	return nil
 }
 
 func (analyser *LineHistoryAnalyser) handleDeletion(
@@ -578,6 +643,9 @@ func (analyser *LineHistoryAnalyser) handleDeletion(
 	// Parallel independent file removals are incorrectly handled. The solution seems to be quite
 	// complex, but feel free to suggest your ideas.
 	// These edge cases happen *very* rarely, so we don't bother for now.
+	if analyser.ClassifyLines {
+		analyser.removedKinds = file.replaceKinds(0, nil, file.Len())
+	}
 	file.Update(packPersonWithTick(author, analyser.tick), 0, 0, lines)
 	file.Delete()
 
@@ -630,6 +698,24 @@ func (analyser *LineHistoryAnalyser) handleModification(
 			change.From.TreeEntry.Hash.String(), change.To.TreeEntry.Hash.String())
 	}
 
+	var newKinds []core.LineKind
+	if analyser.ClassifyLines {
+		newKinds = items.ClassifyBlob(change.To.Name, blobTo)
+	}
+	// prepareKinds sets the kinds of the lines which the following File.Update() inserts
+	// and removes at the position
+	prepareKinds := func(position, insLength, delLength int) {
+		if !analyser.ClassifyLines {
+			return
+		}
+		inserted := make([]core.LineKind, insLength)
+		if position < len(newKinds) {
+			copy(inserted, newKinds[position:])
+		}
+		analyser.insertedKinds = inserted
+		analyser.removedKinds = file.replaceKinds(position, inserted, delLength)
+	}
+
 	// we do not call RunesToDiffLines so the number of lines equals
 	// to the rune count
 	position := 0
@@ -638,9 +724,11 @@ func (analyser *LineHistoryAnalyser) handleModification(
 	apply := func(edit diffmatchpatch.Diff) {
 		length := utf8.RuneCountInString(edit.Text)
 		if edit.Type == diffmatchpatch.DiffInsert {
+			prepareKinds(position, length, 0)
 			file.Update(packPersonWithTick(author, analyser.tick), position, length, 0)
 			position += length
 		} else {
+			prepareKinds(position, 0, length)
 			file.Update(packPersonWithTick(author, analyser.tick), position, 0, length)
 		}
 		if analyser.Debug {
@@ -676,6 +764,7 @@ func (analyser *LineHistoryAnalyser) handleModification(
 					debugError()
 					return errors.New("DiffInsert may not appear after DiffInsert")
 				}
+				prepareKinds(position, length, utf8.RuneCountInString(pending.Text))
 				file.Update(packPersonWithTick(author, analyser.tick), position, length,
 					utf8.RuneCountInString(pending.Text))
 				if analyser.Debug {
@@ -725,6 +814,78 @@ func (analyser *LineHistoryAnalyser) handleRename(from, to string) error {
 	return nil
 }
 
+// lineHistoryCheckpoint is the state of LineHistoryAnalyser which Checkpoint() saves.
+type lineHistoryCheckpoint struct {
+	FileIdCounter  int32
+	FileNames      map[FileId]string
+	AbandonedNames map[FileId]string
+	Files          map[string]fileCheckpoint
+	Tick           core.TickNumber
+	PreviousTick   core.TickNumber
+}
+
+// fileCheckpoint is the File with the tree nodes, see File.dump().
+type fileCheckpoint struct {
+	Id     FileId
+	Keys   []uint32
+	Values []uint32
+	// Kinds are the kinds of the lines if they are classified.
+	Kinds []core.LineKind
+}
+
+// Checkpoint writes the line trees of the files and the file ids.
+func (analyser *LineHistoryAnalyser) Checkpoint(writer io.Writer) error {
+	state := lineHistoryCheckpoint{
+		FileIdCounter:  atomic.LoadInt32(&analyser.fileIdCounter.atomicCounter),
+		FileNames:      analyser.fileNames,
+		AbandonedNames: analyser.inheritAbandonedNames(),
+		Files:          make(map[string]fileCheckpoint, len(analyser.files)),
+		Tick:           analyser.tick,
+		PreviousTick:   analyser.previousTick,
+	}
+	for name, file := range analyser.files {
+		keys, vals := file.dump()
+		state.Files[name] = fileCheckpoint{Id: file.Id, Keys: keys, Values: vals, Kinds: file.kinds}
+	}
+	fw, err := flate.NewWriter(writer, flate.DefaultCompression)
+	if err != nil {
+		return err
+	}
+	if err = gob.NewEncoder(fw).Encode(state); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
+
+// Restore reads the state which Checkpoint() wrote.
+func (analyser *LineHistoryAnalyser) Restore(reader io.Reader) error {
+	fr := flate.NewReader(reader)
+	defer fr.Close()
+	var state lineHistoryCheckpoint
+	if err := gob.NewDecoder(fr).Decode(&state); err != nil {
+		return err
+	}
+	atomic.StoreInt32(&analyser.fileIdCounter.atomicCounter, state.FileIdCounter)
+	if state.FileNames != nil {
+		analyser.fileNames = state.FileNames
+	}
+	analyser.fileAbandonedNames = state.AbandonedNames
+	analyser.fileAbandonedNamesOfParent = nil
+	for name, file := range state.Files {
+		restored := newFileFromDump(
+			file.Id, file.Keys, file.Values, analyser.fileAllocator, analyser.updateChangeList)
+		restored.kinds = file.Kinds
+		analyser.files[name] = restored
+	}
+	analyser.tick = state.Tick
+	analyser.previousTick = state.PreviousTick
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&LineHistoryAnalyser{})
+	// the hibernation moved from Burndown to LineHistory
+	core.Deprecations.RenameFlag("burndown-hibernation-threshold", "lines-hibernation-threshold", "2027-06")
+	core.Deprecations.RenameFact("Burndown.HibernationThreshold", ConfigLinesHibernationThreshold, "2027-06")
 }
@@ -1,6 +1,7 @@
 package linehistory
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/go-git/go-git/v5/plumbing"
@@ -10,7 +11,9 @@ import (
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"github.com/meko-christian/hercules/internal/test"
 	"github.com/meko-christian/hercules/internal/test/fixtures"
+	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func AddHash(t *testing.T, cache map[plumbing.Hash]*items.CachedBlob, hash string) {
@@ -486,3 +489,129 @@ func TestLinesHibernateBootSerialize(t *testing.T) {
 	assert.Equal(t, bd.fileAllocator.Used(), 155)
 	assert.Empty(t, bd.hibernatedFileName)
 }
+
+func TestLinesCheckpointRestore(t *testing.T) {
+	bd := &LineHistoryAnalyser{}
+	assert.Nil(t, bd.Initialize(test.Repository))
+	bd.tick, bd.previousTick = 3, 2
+	file, err := bd.newFile(plumbing.ZeroHash, "a.go", 0, 0, 100)
+	assert.Nil(t, err)
+	file.Update(packPersonWithTick(1, 2), 10, 5, 20)
+	_, err = bd.newFile(plumbing.ZeroHash, "b.go", 1, 1, 10)
+	assert.Nil(t, err)
+	_, err = bd.newFile(plumbing.ZeroHash, "c.go", 1, 2, 10)
+	assert.Nil(t, err)
+	bd.forgetFileName("c.go")
+	var buffer bytes.Buffer
+	assert.Nil(t, bd.Checkpoint(&buffer))
+	restored := &LineHistoryAnalyser{}
+	assert.Nil(t, restored.Initialize(test.Repository))
+	assert.Nil(t, restored.Restore(&buffer))
+	assert.Equal(t, bd.tick, restored.tick)
+	assert.Equal(t, bd.previousTick, restored.previousTick)
+	assert.Equal(t, bd.fileNames, restored.fileNames)
+	assert.Equal(t, bd.inheritAbandonedNames(), restored.fileAbandonedNames)
+	assert.Len(t, restored.files, len(bd.files))
+	for name, file := range bd.files {
+		assert.Equal(t, file.Id, restored.files[name].Id, name)
+		assert.Equal(t, file.flatten(), restored.files[name].flatten(), name)
+		restored.files[name].Validate()
+	}
+	assert.Equal(t, bd.fileIdCounter.next(), restored.fileIdCounter.next())
+	assert.NotNil(t, restored.Restore(bytes.NewReader([]byte("garbage"))))
+}
+
+func TestLinesClassifyLines(t *testing.T) {
+	cache := map[plumbing.Hash]*items.CachedBlob{}
+	entry := func(contents string) object.ChangeEntry {
+		if contents == "" {
+			return object.ChangeEntry{}
+		}
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(contents))
+		cache[hash] = &items.CachedBlob{Data: []byte(contents)}
+		return object.ChangeEntry{Name: "a.go", TreeEntry: object.TreeEntry{Name: "a.go", Hash: hash}}
+	}
+	consume := func(bd *LineHistoryAnalyser, author, tick int, from, to string,
+		diffs ...diffmatchpatch.Diff,
+	) []core.LineHistoryChange {
+		change := &object.Change{From: entry(from), To: entry(to)}
+		result, err := bd.Consume(map[string]interface{}{
+			identity.DependencyAuthor:   author,
+			items.DependencyTick:        tick,
+			items.DependencyBlobCache:   cache,
+			items.DependencyTreeChanges: object.Changes{change},
+			items.DependencyFileDiff: map[string]items.FileDiffData{"a.go": {
+				OldLinesOfCode: bytes.Count([]byte(from), []byte{'\n'}),
+				NewLinesOfCode: bytes.Count([]byte(to), []byte{'\n'}),
+				Diffs:          diffs,
+			}},
+		})
+		require.NoError(t, err)
+		return result[DependencyLineHistory].(core.LineHistoryChanges).Changes
+	}
+	// the number of alive lines of each kind
+	alive := map[core.LineKind]int{}
+	count := func(changes []core.LineHistoryChange) {
+		for _, change := range changes {
+			if !change.IsDelete() {
+				alive[change.Kind] += change.Delta
+			}
+		}
+	}
+	v1 := "// header\n\npackage a\n"
+	v2 := "// header\n// more\npackage a\nfunc f() {}\n"
+
+	bd := &LineHistoryAnalyser{}
+	require.NoError(t, bd.Configure(map[string]interface{}{items.ConfigLinesStatsClassifyLines: true}))
+	assert.True(t, bd.ClassifyLines)
+	require.NoError(t, bd.Initialize(nil))
+	changes := consume(bd, 0, 0, "", v1)
+	assert.Equal(t, []core.LineHistoryChange{
+		{FileId: 1, Delta: 1, Kind: core.LineKindComment},
+		{FileId: 1, Delta: 1, Kind: core.LineKindBlank},
+		{FileId: 1, Delta: 1, Kind: core.LineKindCode},
+	}, changes)
+	count(changes)
+
+	// the blank line becomes a comment and a line of code is appended
+	changes = consume(bd, 1, 1, v1, v2,
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "b"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "c"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: "d"},
+		diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: "e"})
+	assert.Equal(t, []core.LineHistoryChange{
+		{FileId: 1, CurrTick: 1, PrevTick: 1, CurrAuthor: 1, PrevAuthor: 1, Delta: 1, Kind: core.LineKindComment},
+		{FileId: 1, CurrTick: 1, CurrAuthor: 1, Delta: -1, Kind: core.LineKindBlank},
+		{FileId: 1, CurrTick: 1, PrevTick: 1, CurrAuthor: 1, PrevAuthor: 1, Delta: 1, Kind: core.LineKindCode},
+	}, changes)
+	count(changes)
+	assert.Equal(t, map[core.LineKind]int{
+		core.LineKindCode: 2, core.LineKindComment: 2, core.LineKindBlank: 0,
+	}, alive)
+	assert.Equal(t, []core.LineKind{
+		core.LineKindComment, core.LineKindComment, core.LineKindCode, core.LineKindCode,
+	}, bd.files["a.go"].kinds)
+
+	// the forks keep their own kinds
+	fork := bd.Fork(1)[0].(*LineHistoryAnalyser)
+	count(consume(fork, 0, 2, v2, "", diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: "abcd"}))
+	assert.Equal(t, map[core.LineKind]int{
+		core.LineKindCode: 0, core.LineKindComment: 0, core.LineKindBlank: 0,
+	}, alive)
+	assert.Len(t, bd.files["a.go"].kinds, 4)
+
+	var buffer bytes.Buffer
+	require.NoError(t, bd.Checkpoint(&buffer))
+	restored := &LineHistoryAnalyser{}
+	require.NoError(t, restored.Initialize(nil))
+	require.NoError(t, restored.Restore(&buffer))
+	assert.Equal(t, bd.files["a.go"].kinds, restored.files["a.go"].kinds)
+
+	// all the lines are code without the classification
+	bd = &LineHistoryAnalyser{}
+	require.NoError(t, bd.Configure(map[string]interface{}{}))
+	require.NoError(t, bd.Initialize(nil))
+	assert.Equal(t, []core.LineHistoryChange{{FileId: 1, Delta: 3}}, consume(bd, 0, 0, "", v1))
+	assert.Nil(t, bd.files["a.go"].kinds)
+}
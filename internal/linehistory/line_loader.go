@@ -2,30 +2,56 @@ package linehistory
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"gopkg.in/yaml.v2"
 )
 
+// DumpFormatVersion is the version of the binary line history dump written by
+// LineHistoryDumper. LineHistoryLoader refuses to load the dumps of the newer versions.
+const DumpFormatVersion = 1
+
+// dumperName is the key of LineHistoryDumper's results in the binary output.
+const dumperName = "LineHistoryDumper"
+
 // LineHistoryLoader allows to gather per-line history and statistics for a Git repository.
 // It is a PipelineItem.
+// It replays the line history dumped by LineDumper (YAML) or by LineHistoryDumper (Protocol Buffers)
+// instead of analysing the commits, so it runs with the Git stub "-". The streamed dumps
+// (see DumpStreamWriter) are read lazily: only the commit headers stay in memory, and the changes
+// are decoded in Consume().
 type LineHistoryLoader struct {
-	files   map[FileId]fileInfo
-	authors []string
-	commits []commitInfo
-
+	// files are the names of the files after the last commit.
+	files    map[FileId]fileInfo
+	authors  []string
+	commits  []commitInfo
+	tickSize time.Duration
+
+	// replayed is the state of the files after the consumed commits.
+	replayed   map[FileId]*replayedFile
 	nextCommit int
 
+	// stream is the path to the streamed dump, empty if the whole history is loaded.
+	stream string
+	reader *dumpStreamReader
+
 	l core.Logger
 }
 
@@ -35,13 +61,43 @@ type fileInfo struct {
 
 type commitInfo struct {
 	Hash    plumbing.Hash
+	When    time.Time
 	Tick    core.TickNumber
 	Author  core.AuthorId
 	Changes []core.LineHistoryChange
+	// Names are the names of the changed files after the commit, if known.
+	Names map[FileId]string
 }
 
-func (v fileInfo) ForEach(func(line int, value int)) {
-	panic("not implemented")
+// replayedFile is the state of a file restored from the line history changes. The changes do not
+// carry the line positions, so only the numbers of lines per author and tick are known.
+type replayedFile struct {
+	Name string
+	// Lines maps the packed author and tick to the number of lines.
+	Lines map[int]int
+}
+
+// ForEach visits the runs of the lines written by the same author at the same tick, ordered
+// by the tick and the author, followed by the end marker, the same way as File.ForEach.
+func (v replayedFile) ForEach(callback func(line int, value int)) {
+	values := make([]int, 0, len(v.Lines))
+	for value := range v.Lines {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		authorI, tickI := unpackPersonWithTick(values[i])
+		authorJ, tickJ := unpackPersonWithTick(values[j])
+		if tickI != tickJ {
+			return tickI < tickJ
+		}
+		return authorI < authorJ
+	})
+	line := 0
+	for _, value := range values {
+		callback(line, value)
+		line += v.Lines[value]
+	}
+	callback(line, -1)
 }
 
 var _ core.FileIdResolver = loadedFileIdResolver{}
@@ -55,6 +111,9 @@ func (v loadedFileIdResolver) NameOf(id FileId) string {
 		return ""
 	}
 
+	if f, ok := v.analyser.replayed[id]; ok && f.Name != "" {
+		return f.Name
+	}
 	return v.analyser.files[id].Name
 }
 
@@ -63,8 +122,8 @@ func (v loadedFileIdResolver) MergedWith(id FileId) (FileId, string, bool) {
 		return 0, "", false
 	}
 
-	if f, ok := v.analyser.files[id]; ok {
-		return id, f.Name, true
+	if _, ok := v.analyser.replayed[id]; ok {
+		return id, v.NameOf(id), true
 	}
 	return 0, "", false
 }
@@ -74,8 +133,8 @@ func (v loadedFileIdResolver) ForEachFile(callback func(id FileId, name string))
 		return false
 	}
 
-	for id, file := range v.analyser.files {
-		callback(id, file.Name)
+	for id := range v.analyser.replayed {
+		callback(id, v.NameOf(id))
 	}
 	return true
 }
@@ -85,7 +144,7 @@ func (v loadedFileIdResolver) ScanFile(id FileId, callback func(line int, tick c
 		return false
 	}
 
-	file, ok := v.analyser.files[id]
+	file, ok := v.analyser.replayed[id]
 	if !ok {
 		return false
 	}
@@ -164,15 +223,22 @@ func (*LineHistoryLoader) Features() []string {
 	return []string{core.FeatureGitStub}
 }
 
+// ConflictsWith returns the features which cannot be enabled together with this item:
+// the loaded line history would clash with the one calculated from the real commits.
+func (*LineHistoryLoader) ConflictsWith() []string {
+	return []string{core.FeatureGitCommits}
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (analyser *LineHistoryLoader) ListConfigurationOptions() []core.ConfigurationOption {
 	return []core.ConfigurationOption{
 		{
-			Name:        ConfigLinesLoadFrom,
-			Description: "Temporary directory where to save the hibernated RBTree allocators.",
-			Flag:        "history-line-load",
-			Type:        core.PathConfigurationOption,
-			Default:     "",
+			Name: ConfigLinesLoadFrom,
+			Description: "Replay the line history from the file written by --linedump, --history-line-dump --pb " +
+				"or --history-line-dump-stream.",
+			Flag:    "history-line-load",
+			Type:    core.PathConfigurationOption,
+			Default: "",
 		},
 	}
 }
@@ -192,6 +258,12 @@ func (analyser *LineHistoryLoader) Configure(facts map[string]interface{}) error
 
 	facts[core.FactLineHistoryResolver] = loadedFileIdResolver{analyser}
 	facts[core.FactIdentityResolver] = authorResolver{analyser}
+	if _, exists := facts[identity.FactIdentityDetectorReversedPeopleDict]; !exists {
+		facts[identity.FactIdentityDetectorReversedPeopleDict] = analyser.authors
+	}
+	if analyser.tickSize != 0 {
+		facts[items.FactTickSize] = analyser.tickSize
+	}
 
 	facts[core.ConfigPipelineCommits] = analyser.buildCommits()
 
@@ -206,8 +278,25 @@ func (analyser *LineHistoryLoader) ConfigureUpstream(_ map[string]interface{}) e
 // calls. The repository which is going to be analysed is supplied as an argument.
 func (analyser *LineHistoryLoader) Initialize(*git.Repository) error {
 	analyser.l = core.NewLogger()
-	analyser.files = map[FileId]fileInfo{}
+	if analyser.files == nil {
+		analyser.files = map[FileId]fileInfo{}
+	}
+	analyser.replayed = map[FileId]*replayedFile{}
 	analyser.nextCommit = 0
+	if analyser.reader != nil {
+		_ = analyser.reader.Close()
+		analyser.reader = nil
+	}
+	if analyser.stream != "" {
+		reader, _, err := openDumpStream(analyser.stream)
+		if err != nil {
+			return err
+		}
+		if reader == nil {
+			return fmt.Errorf("%s is no longer a line history stream", analyser.stream)
+		}
+		analyser.reader = reader
+	}
 
 	return nil
 }
@@ -217,9 +306,23 @@ func (analyser *LineHistoryLoader) Consume(map[string]interface{}) (map[string]i
 	if analyser.nextCommit < len(analyser.commits) {
 		commit = analyser.commits[analyser.nextCommit]
 		analyser.nextCommit++
+		if analyser.reader != nil {
+			if err := analyser.readStreamedChanges(&commit); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		commit.Author = core.AuthorMissing
 	}
+	analyser.replay(&commit)
+	if analyser.nextCommit == len(analyser.commits) {
+		// the files could be renamed without changing the lines
+		for id, file := range analyser.replayed {
+			if info, exists := analyser.files[id]; exists {
+				file.Name = info.Name
+			}
+		}
+	}
 
 	result := map[string]interface{}{
 		DependencyLineHistory: core.LineHistoryChanges{
@@ -241,13 +344,168 @@ func (analyser *LineHistoryLoader) Merge([]core.PipelineItem) {
 	analyser.l.Critical("cant be merged")
 }
 
+// replay applies the changes of the commit to the replayed files.
+func (analyser *LineHistoryLoader) replay(commit *commitInfo) {
+	if analyser.replayed == nil {
+		analyser.replayed = map[FileId]*replayedFile{}
+	}
+	for _, change := range commit.Changes {
+		if change.IsDelete() {
+			delete(analyser.replayed, change.FileId)
+			continue
+		}
+		file := analyser.replayed[change.FileId]
+		if file == nil {
+			file = &replayedFile{Lines: map[int]int{}}
+			analyser.replayed[change.FileId] = file
+		}
+		// the inserted lines belong to the current author, the removed lines to the previous
+		value := packPersonWithTick(change.CurrAuthor, change.CurrTick)
+		if change.Delta < 0 {
+			value = packPersonWithTick(change.PrevAuthor, change.PrevTick)
+		}
+		if file.Lines[value] += change.Delta; file.Lines[value] <= 0 {
+			delete(file.Lines, value)
+		}
+	}
+	for id, name := range commit.Names {
+		if file := analyser.replayed[id]; file != nil {
+			file.Name = name
+		}
+	}
+}
+
+// readStreamedChanges decodes the changes of the next commit from the streamed dump.
+func (analyser *LineHistoryLoader) readStreamedChanges(commit *commitInfo) error {
+	for {
+		record, err := analyser.reader.Next()
+		if err == io.EOF {
+			return fmt.Errorf("%s: the line history stream ended before commit %s",
+				analyser.stream, commit.Hash)
+		}
+		if err != nil {
+			return err
+		}
+		if len(record.Commits) == 0 {
+			continue
+		}
+		commit.loadChangesFromPb(record.Commits[0])
+		break
+	}
+	if analyser.nextCommit == len(analyser.commits) {
+		err := analyser.reader.Close()
+		analyser.reader = nil
+		return err
+	}
+	return nil
+}
+
 func (analyser *LineHistoryLoader) loadChangesFrom(name string) error {
-	if input, err := os.Open(name); err == nil {
-		defer func() { _ = input.Close() }()
-		return analyser.loadChangesFromYaml(yaml.NewDecoder(input))
-	} else {
+	stream, header, err := openDumpStream(name)
+	if err != nil {
 		return err
 	}
+	if stream != nil {
+		defer func() { _ = stream.Close() }()
+		return analyser.loadChangesFromStream(name, stream, header)
+	}
+	analyser.stream = ""
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	results := pb.AnalysisResults{}
+	if proto.Unmarshal(data, &results) == nil {
+		if dump, exists := results.Contents[dumperName]; exists {
+			return analyser.loadChangesFromDump(dump)
+		}
+	}
+	return analyser.loadChangesFromYaml(yaml.NewDecoder(bytes.NewReader(data)))
+}
+
+// loadChangesFromDump reads the results of LineHistoryDumper.
+func (analyser *LineHistoryLoader) loadChangesFromDump(data []byte) error {
+	message := pb.LineHistoryDumpResults{}
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	if message.Version > DumpFormatVersion {
+		return fmt.Errorf("unsupported line history dump version %d, the latest supported is %d",
+			message.Version, DumpFormatVersion)
+	}
+	analyser.authors = message.DevIndex
+	analyser.tickSize = time.Duration(message.TickSize)
+	analyser.files = make(map[FileId]fileInfo, len(message.Files))
+	for id, name := range message.Files {
+		analyser.files[FileId(id)] = fileInfo{Name: name}
+	}
+	analyser.commits = make([]commitInfo, len(message.Commits))
+	for i, pbCommit := range message.Commits {
+		analyser.commits[i] = commitHeaderFromPb(pbCommit)
+		analyser.commits[i].loadChangesFromPb(pbCommit)
+	}
+	return nil
+}
+
+// loadChangesFromStream reads the headers of the commits from the streamed dump.
+// Consume() reads the changes later.
+func (analyser *LineHistoryLoader) loadChangesFromStream(
+	name string, stream *dumpStreamReader, header *pb.LineHistoryDumpResults,
+) error {
+	analyser.stream = name
+	analyser.authors = header.DevIndex
+	analyser.tickSize = time.Duration(header.TickSize)
+	analyser.files = map[FileId]fileInfo{}
+	analyser.commits = nil
+	for {
+		record, err := stream.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, pbCommit := range record.Commits {
+			analyser.commits = append(analyser.commits, commitHeaderFromPb(pbCommit))
+		}
+		for id, name := range record.Files {
+			analyser.files[FileId(id)] = fileInfo{Name: name}
+		}
+	}
+}
+
+// commitHeaderFromPb converts the commit from the binary dump without the changes.
+func commitHeaderFromPb(pbCommit *pb.LineHistoryCommit) commitInfo {
+	return commitInfo{
+		Hash:   plumbing.NewHash(pbCommit.GetHash()),
+		When:   time.Unix(pbCommit.GetWhenUnixTime(), 0),
+		Tick:   core.TickNumber(pbCommit.GetTick()),
+		Author: core.AuthorId(pbCommit.GetAuthor()),
+	}
+}
+
+// loadChangesFromPb sets the changes and the file names of the commit from the binary dump.
+func (info *commitInfo) loadChangesFromPb(pbCommit *pb.LineHistoryCommit) {
+	info.Changes = make([]core.LineHistoryChange, len(pbCommit.GetChanges()))
+	for j, pbChange := range pbCommit.GetChanges() {
+		change := core.LineHistoryChange{
+			FileId:     FileId(pbChange.GetFileId()),
+			PrevAuthor: core.AuthorId(pbChange.GetPrevAuthor()),
+			PrevTick:   core.TickNumber(pbChange.GetPrevTick()),
+			CurrAuthor: core.AuthorId(pbChange.GetCurrAuthor()),
+			CurrTick:   core.TickNumber(pbChange.GetCurrTick()),
+			Delta:      int(pbChange.GetDelta()),
+			Kind:       core.LineKind(pbChange.GetKind()),
+		}
+		if pbChange.GetDelta() == math.MinInt64 {
+			change.Delta = math.MinInt
+		}
+		info.Changes[j] = change
+	}
+	info.Names = make(map[FileId]string, len(pbCommit.GetNames()))
+	for id, name := range pbCommit.GetNames() {
+		info.Names[FileId(id)] = name
+	}
 }
 
 var regexSplitBySpace = regexp.MustCompile("\\s+")
@@ -275,12 +533,21 @@ func (analyser *LineHistoryLoader) loadChangesFromYaml(decoder *yaml.Decoder) er
 
 	analyser.commits = make([]commitInfo, 0, len(values.LineDumper.Commits))
 	for _, yamlCommit := range values.LineDumper.Commits {
+		hash, isString := yamlCommit.Key.(string)
+		if !isString {
+			return fmt.Errorf("commit hash must be a string: %v", yamlCommit.Key)
+		}
+		changes, isString := yamlCommit.Value.(string)
+		if !isString {
+			return fmt.Errorf("changes of commit %s must be a string: %v", hash, yamlCommit.Value)
+		}
 		analyser.commits = append(analyser.commits, commitInfo{})
 		info := &analyser.commits[len(analyser.commits)-1]
-		for r := bufio.NewScanner(strings.NewReader(yamlCommit.Value.(string))); r.Scan(); {
+		for r := bufio.NewScanner(strings.NewReader(changes)); r.Scan(); {
 			line := r.Text()
 			chunks := regexSplitBySpace.Split(line, -1)
-			if len(chunks) != 6 {
+			// the optional seventh field is the kind of the lines
+			if len(chunks) != 6 && len(chunks) != 7 {
 				return fmt.Errorf("unexpected number of fields '%d' from: %s", len(chunks), line)
 			}
 			vals := make([]int, len(chunks))
@@ -299,11 +566,17 @@ func (analyser *LineHistoryLoader) loadChangesFromYaml(decoder *yaml.Decoder) er
 				CurrTick:   core.TickNumber(vals[4]),
 				Delta:      vals[5],
 			}
+			if len(vals) == 7 {
+				change.Kind = core.LineKind(vals[6])
+			}
 			info.Changes = append(info.Changes, change)
 		}
+		if len(info.Changes) == 0 {
+			return fmt.Errorf("commit %s has no changes", hash)
+		}
 		info.Tick = info.Changes[0].CurrTick
 		info.Author = info.Changes[0].CurrAuthor
-		info.Hash = plumbing.NewHash(yamlCommit.Key.(string))
+		info.Hash = plumbing.NewHash(hash)
 	}
 
 	return nil
@@ -316,8 +589,8 @@ func (analyser *LineHistoryLoader) buildCommits() (result []*object.Commit) {
 	for _, commit := range analyser.commits {
 		result = append(result, &object.Commit{
 			Hash:         commit.Hash,
-			Author:       object.Signature{},
-			Committer:    object.Signature{},
+			Author:       object.Signature{When: commit.When},
+			Committer:    object.Signature{When: commit.When},
 			PGPSignature: "",
 			Message:      "",
 			TreeHash:     plumbing.Hash{},
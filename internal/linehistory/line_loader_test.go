@@ -2,12 +2,16 @@ package linehistory
 
 import (
 	"bytes"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"github.com/stretchr/testify/assert"
@@ -91,7 +95,9 @@ func TestLineHistoryLoaderInitialize(t *testing.T) {
 
 	err := loader.Initialize(nil)
 	assert.NoError(t, err)
-	assert.Empty(t, loader.files)
+	// the files are loaded in Configure()
+	assert.Len(t, loader.files, 1)
+	assert.Empty(t, loader.replayed)
 	assert.Equal(t, 0, loader.nextCommit)
 	assert.NotNil(t, loader.l)
 }
@@ -159,6 +165,8 @@ func TestLineHistoryLoaderConsumeWithCommits(t *testing.T) {
 	changes = result[DependencyLineHistory].(core.LineHistoryChanges)
 	assert.Len(t, changes.Changes, 1)
 	assert.Equal(t, -10, changes.Changes[0].Delta)
+	assert.Equal(t, map[int]int{packPersonWithTick(1, 10): 90}, loader.replayed[1].Lines)
+	assert.Equal(t, map[int]int{packPersonWithTick(1, 10): 50}, loader.replayed[2].Lines)
 
 	// Third consume (past end)
 	result, err = loader.Consume(map[string]interface{}{})
@@ -215,6 +223,7 @@ func TestLoadedFileIdResolverMergedWith(t *testing.T) {
 		1: {Name: "file1.go"},
 		2: {Name: "file2.go"},
 	}
+	loader.replayed = map[FileId]*replayedFile{1: {}, 2: {}}
 
 	resolver := loadedFileIdResolver{analyser: loader}
 
@@ -245,7 +254,10 @@ func TestLoadedFileIdResolverForEachFile(t *testing.T) {
 		1: {Name: "file1.go"},
 		2: {Name: "file2.go"},
 		3: {Name: "file3.go"},
+		4: {Name: "deleted.go"},
 	}
+	// the deleted files are not visited
+	loader.replayed = map[FileId]*replayedFile{1: {}, 2: {}, 3: {}}
 
 	resolver := loadedFileIdResolver{analyser: loader}
 
@@ -267,18 +279,26 @@ func TestLoadedFileIdResolverForEachFileNil(t *testing.T) {
 	assert.False(t, result)
 }
 
-func TestLoadedFileIdResolverScanFileNotImplemented(t *testing.T) {
+func TestLoadedFileIdResolverScanFile(t *testing.T) {
 	loader := &LineHistoryLoader{}
 	loader.files = map[FileId]fileInfo{
 		1: {Name: "file1.go"},
 	}
+	loader.replayed = map[FileId]*replayedFile{1: {Lines: map[int]int{packPersonWithTick(2, 7): 5}}}
 
 	resolver := loadedFileIdResolver{analyser: loader}
 
-	// ScanFile calls ForEach which panics "not implemented"
-	assert.Panics(t, func() {
-		resolver.ScanFile(1, func(line int, tick core.TickNumber, author core.AuthorId) {})
-	})
+	var lines []int
+	var ticks []core.TickNumber
+	var authors []core.AuthorId
+	assert.True(t, resolver.ScanFile(1, func(line int, tick core.TickNumber, author core.AuthorId) {
+		lines = append(lines, line)
+		ticks = append(ticks, tick)
+		authors = append(authors, author)
+	}))
+	assert.Equal(t, []int{0, 5}, lines)
+	assert.Equal(t, core.TickNumber(7), ticks[0])
+	assert.Equal(t, core.AuthorId(2), authors[0])
 }
 
 func TestLoadedFileIdResolverScanFileNil(t *testing.T) {
@@ -453,7 +473,7 @@ LineDumper:
       2 -1 -1 0 10 50
     bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb: |
       1 0 10 1 20 -10
-      2 0 10 1 20 5
+      2 0 10 1 20 5 1
 `
 
 	loader := &LineHistoryLoader{}
@@ -503,6 +523,9 @@ LineDumper:
 	assert.Equal(t, core.AuthorId(1), change.CurrAuthor)
 	assert.Equal(t, core.TickNumber(20), change.CurrTick)
 	assert.Equal(t, -10, change.Delta)
+	assert.Equal(t, core.LineKindCode, change.Kind)
+	// the optional kind of the lines
+	assert.Equal(t, core.LineKindComment, loader.commits[1].Changes[1].Kind)
 }
 
 func TestLineHistoryLoaderLoadChangesFromYamlInvalidFieldCount(t *testing.T) {
@@ -555,6 +578,30 @@ func TestLineHistoryLoaderLoadChangesFromYamlInvalidYaml(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLineHistoryLoaderLoadChangesFromYamlMalformed(t *testing.T) {
+	for name, commits := range map[string]string{
+		"key":     "[1, 2]: \"1 -1 -1 0 10 100\"",
+		"value":   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa: [1, 2]",
+		"changes": "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa: \"\"",
+	} {
+		loader := &LineHistoryLoader{}
+		decoder := yaml.NewDecoder(bytes.NewReader([]byte("LineDumper:\n  commits:\n    " + commits + "\n")))
+		assert.Error(t, loader.loadChangesFromYaml(decoder), name)
+	}
+}
+
+// FuzzLoadChangesFromYaml checks that the malformed line history dumps are rejected with
+// an error instead of a panic.
+func FuzzLoadChangesFromYaml(f *testing.F) {
+	f.Add([]byte("LineDumper:\n  author_sequence: [Alice]\n  file_sequence: {1: file1.go}\n" +
+		"  commits:\n    aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa: |\n      1 -1 -1 0 10 100\n"))
+	f.Add([]byte("LineDumper:\n  commits:\n    1: 2\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		loader := &LineHistoryLoader{}
+		_ = loader.loadChangesFromYaml(yaml.NewDecoder(bytes.NewReader(data)))
+	})
+}
+
 func TestLineHistoryLoaderLoadChangesFrom(t *testing.T) {
 	yamlData := `
 LineDumper:
@@ -581,6 +628,51 @@ LineDumper:
 	assert.Len(t, loader.commits, 1)
 }
 
+func TestLineHistoryLoaderLoadChangesFromDump(t *testing.T) {
+	dump := pb.LineHistoryDumpResults{
+		Version:  DumpFormatVersion,
+		DevIndex: []string{"Alice"},
+		TickSize: int64(time.Hour),
+		Files:    map[int32]string{1: "renamed.go"},
+		Commits: []*pb.LineHistoryCommit{{
+			Hash:         "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			WhenUnixTime: 1600000000,
+			Tick:         3,
+			Changes: []*pb.LineHistoryChange{
+				{FileId: 1, CurrTick: 3, PrevTick: 3, Delta: 10},
+				{FileId: 2, CurrTick: 3, PrevTick: 3, Delta: 5, Kind: int32(core.LineKindBlank)},
+				{FileId: 2, CurrTick: 3, PrevAuthor: int32(core.AuthorMissing), PrevTick: 3, Delta: math.MinInt64},
+			},
+			Names: map[int32]string{1: "file1.go", 2: "file2.go"},
+		}},
+	}
+	data, err := proto.Marshal(&dump)
+	require.NoError(t, err)
+
+	loader := &LineHistoryLoader{}
+	require.NoError(t, loader.loadChangesFromDump(data))
+	assert.Equal(t, []string{"Alice"}, loader.authors)
+	assert.Equal(t, time.Hour, loader.tickSize)
+	require.Len(t, loader.commits, 1)
+	assert.Equal(t, core.TickNumber(3), loader.commits[0].Tick)
+	assert.Equal(t, int64(1600000000), loader.commits[0].When.Unix())
+	assert.Equal(t, core.LineKindBlank, loader.commits[0].Changes[1].Kind)
+	assert.True(t, loader.commits[0].Changes[2].IsDelete())
+
+	require.NoError(t, loader.Initialize(nil))
+	_, err = loader.Consume(nil)
+	require.NoError(t, err)
+	assert.Len(t, loader.replayed, 1)
+	// the name after the last commit
+	assert.Equal(t, "renamed.go", loadedFileIdResolver{loader}.NameOf(1))
+
+	dump.Version = DumpFormatVersion + 1
+	data, err = proto.Marshal(&dump)
+	require.NoError(t, err)
+	assert.Error(t, loader.loadChangesFromDump(data))
+	assert.Error(t, loader.loadChangesFromDump([]byte{0x12, 0x7f, 0x00}))
+}
+
 func TestLineHistoryLoaderLoadChangesFromNonExistent(t *testing.T) {
 	loader := &LineHistoryLoader{}
 	err := loader.loadChangesFrom("/nonexistent/file.yml")
@@ -630,11 +722,21 @@ func TestLineHistoryLoaderRegistration(t *testing.T) {
 	assert.Equal(t, "LineHistoryLoader", summoned[0].Name())
 }
 
-func TestFileInfoForEachPanics(t *testing.T) {
-	fi := fileInfo{Name: "test.go"}
-	assert.PanicsWithValue(t, "not implemented", func() {
-		fi.ForEach(func(line int, value int) {})
+func TestReplayedFileForEach(t *testing.T) {
+	file := replayedFile{Name: "test.go", Lines: map[int]int{
+		packPersonWithTick(1, 5): 3,
+		packPersonWithTick(0, 5): 2,
+		packPersonWithTick(1, 2): 4,
+	}}
+	var lines, values []int
+	file.ForEach(func(line int, value int) {
+		lines = append(lines, line)
+		values = append(values, value)
 	})
+	assert.Equal(t, []int{0, 4, 6, 9}, lines)
+	assert.Equal(t, []int{
+		packPersonWithTick(1, 2), packPersonWithTick(0, 5), packPersonWithTick(1, 5), -1,
+	}, values)
 }
 
 // testLogger is a minimal logger implementation for testing
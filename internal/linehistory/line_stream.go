@@ -0,0 +1,113 @@
+package linehistory
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/meko-christian/hercules/internal/pb"
+)
+
+const (
+	// dumpStreamMagic starts the streamed line history dumps, see DumpStreamWriter.
+	dumpStreamMagic = "hercules line history stream\n"
+	// maxDumpStreamRecordSize limits the size of a single record in the streamed dump.
+	maxDumpStreamRecordSize = 1 << 30
+)
+
+// DumpStreamWriter writes the line history dump as a stream of length-delimited
+// pb.LineHistoryDumpResults records, so that neither the writer nor LineHistoryLoader
+// have to keep the whole history in memory. The first record is the header with the version,
+// the developers and the tick size, then each commit follows in its own record, and the last
+// record carries the names of the files which exist after the last commit.
+type DumpStreamWriter struct {
+	writer protoio.WriteCloser
+	buffer *bufio.Writer
+}
+
+// NewDumpStreamWriter writes the header of the streamed dump and returns the writer
+// of the commit records.
+func NewDumpStreamWriter(writer io.Writer, devs []string, tickSize int64) (*DumpStreamWriter, error) {
+	buffer := bufio.NewWriter(writer)
+	if _, err := buffer.WriteString(dumpStreamMagic); err != nil {
+		return nil, err
+	}
+	stream := &DumpStreamWriter{writer: protoio.NewDelimitedWriter(buffer), buffer: buffer}
+	err := stream.writer.WriteMsg(&pb.LineHistoryDumpResults{
+		Version:  DumpFormatVersion,
+		DevIndex: devs,
+		TickSize: tickSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// WriteCommit appends the line history of the next commit.
+func (stream *DumpStreamWriter) WriteCommit(commit *pb.LineHistoryCommit) error {
+	return stream.writer.WriteMsg(&pb.LineHistoryDumpResults{Commits: []*pb.LineHistoryCommit{commit}})
+}
+
+// Finish writes the final names of the files and flushes the stream. The underlying writer
+// is not closed.
+func (stream *DumpStreamWriter) Finish(files map[int32]string) error {
+	if err := stream.writer.WriteMsg(&pb.LineHistoryDumpResults{Files: files}); err != nil {
+		return err
+	}
+	return stream.buffer.Flush()
+}
+
+// dumpStreamReader reads the records written by DumpStreamWriter.
+type dumpStreamReader struct {
+	file   *os.File
+	reader protoio.ReadCloser
+}
+
+// openDumpStream opens the streamed dump and reads its header. It returns nil and no error
+// if the file is not a streamed dump.
+func openDumpStream(name string) (*dumpStreamReader, *pb.LineHistoryDumpResults, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	buffer := bufio.NewReader(file)
+	magic, err := buffer.Peek(len(dumpStreamMagic))
+	if err != nil || string(magic) != dumpStreamMagic {
+		_ = file.Close()
+		return nil, nil, nil
+	}
+	_, _ = buffer.Discard(len(dumpStreamMagic))
+	stream := &dumpStreamReader{file: file, reader: protoio.NewDelimitedReader(buffer, maxDumpStreamRecordSize)}
+	header := &pb.LineHistoryDumpResults{}
+	if err = stream.reader.ReadMsg(header); err != nil {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("%s: failed to read the header of the line history stream: %v", name, err)
+	}
+	if header.Version > DumpFormatVersion {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("unsupported line history dump version %d, the latest supported is %d",
+			header.Version, DumpFormatVersion)
+	}
+	return stream, header, nil
+}
+
+// Next returns the next record or io.EOF after the last one.
+func (stream *dumpStreamReader) Next() (*pb.LineHistoryDumpResults, error) {
+	record := &pb.LineHistoryDumpResults{}
+	if err := stream.reader.ReadMsg(record); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("%s: corrupted line history stream: %v", stream.file.Name(), err)
+	}
+	return record, nil
+}
+
+// Close releases the file.
+func (stream *dumpStreamReader) Close() error {
+	return stream.file.Close()
+}
@@ -0,0 +1,95 @@
+package linehistory
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestDumpStream(t *testing.T, version int32) []byte {
+	buffer := &bytes.Buffer{}
+	stream, err := NewDumpStreamWriter(buffer, []string{"one", "two"}, 3600)
+	require.NoError(t, err)
+	for i, commit := range []*pb.LineHistoryCommit{
+		{Tick: 0, Author: 0, Changes: []*pb.LineHistoryChange{{FileId: 0, Delta: 5}},
+			Names: map[int32]string{0: "a.go"}},
+		{Tick: 1, Author: 1, Changes: []*pb.LineHistoryChange{
+			{FileId: 0, CurrAuthor: 1, CurrTick: 1, Delta: 2},
+		}},
+	} {
+		commit.Hash = string(rune('a'+i)) + "000000000000000000000000000000000000000"
+		require.NoError(t, stream.WriteCommit(commit))
+	}
+	require.NoError(t, stream.Finish(map[int32]string{0: "b.go"}))
+	data := buffer.Bytes()
+	if version != DumpFormatVersion {
+		// the version is the first field of the header
+		require.Equal(t, byte(0x8), data[len(dumpStreamMagic)+1])
+		data[len(dumpStreamMagic)+2] = byte(version)
+	}
+	return data
+}
+
+func TestLineHistoryLoaderStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.stream")
+	require.NoError(t, os.WriteFile(path, writeTestDumpStream(t, DumpFormatVersion), 0o644))
+	loader := &LineHistoryLoader{}
+	require.NoError(t, loader.Configure(map[string]interface{}{ConfigLinesLoadFrom: path}))
+	assert.Equal(t, path, loader.stream)
+	assert.Equal(t, []string{"one", "two"}, loader.authors)
+	assert.Equal(t, map[FileId]fileInfo{0: {Name: "b.go"}}, loader.files)
+	// only the headers are loaded
+	require.Len(t, loader.commits, 2)
+	assert.Nil(t, loader.commits[1].Changes)
+	assert.Equal(t, core.AuthorId(1), loader.commits[1].Author)
+
+	for run := 0; run < 2; run++ {
+		require.NoError(t, loader.Initialize(nil))
+		_, err := loader.Consume(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "a.go", loader.replayed[0].Name)
+		deps, err := loader.Consume(nil)
+		require.NoError(t, err)
+		assert.Len(t, deps[DependencyLineHistory].(core.LineHistoryChanges).Changes, 1)
+		assert.Equal(t, map[int]int{packPersonWithTick(0, 0): 5, packPersonWithTick(1, 1): 2},
+			loader.replayed[0].Lines)
+		assert.Equal(t, "b.go", loader.replayed[0].Name)
+		assert.Nil(t, loader.reader)
+	}
+}
+
+func TestLineHistoryLoaderStreamErrors(t *testing.T) {
+	dir := t.TempDir()
+	data := writeTestDumpStream(t, DumpFormatVersion+1)
+	path := filepath.Join(dir, "future.stream")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	loader := &LineHistoryLoader{}
+	assert.Error(t, loader.Configure(map[string]interface{}{ConfigLinesLoadFrom: path}))
+
+	// the second commit is cut in the middle
+	data = writeTestDumpStream(t, DumpFormatVersion)
+	path = filepath.Join(dir, "truncated.stream")
+	require.NoError(t, os.WriteFile(path, data[:len(data)-20], 0o644))
+	loader = &LineHistoryLoader{}
+	assert.Error(t, loader.Configure(map[string]interface{}{ConfigLinesLoadFrom: path}))
+
+	path = filepath.Join(dir, "history.stream")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	loader = &LineHistoryLoader{}
+	require.NoError(t, loader.Configure(map[string]interface{}{ConfigLinesLoadFrom: path}))
+	require.NoError(t, os.WriteFile(path, data[:len(data)-20], 0o644))
+	require.NoError(t, loader.Initialize(nil))
+	_, err := loader.Consume(nil)
+	require.NoError(t, err)
+	_, err = loader.Consume(nil)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("LineDumper: {}"), 0o644))
+	assert.Error(t, loader.Initialize(nil))
+}
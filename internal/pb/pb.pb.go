@@ -36,10 +36,17 @@ type Metadata struct {
 	// duration of the analysis in milliseconds
 	RunTime int64 `protobuf:"varint,7,opt,name=run_time,json=runTime,proto3" json:"run_time,omitempty"`
 	// time taken by each pipeline item in seconds
-	RunTimePerItem       map[string]float64 `protobuf:"bytes,8,rep,name=run_time_per_item,json=runTimePerItem,proto3" json:"run_time_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	RunTimePerItem map[string]float64 `protobuf:"bytes,8,rep,name=run_time_per_item,json=runTimePerItem,proto3" json:"run_time_per_item,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// whether the analysis was interrupted and the results cover only the consumed commits
+	Partial bool `protobuf:"varint,9,opt,name=partial,proto3" json:"partial,omitempty"`
+	// calendar alignment of the ticks: "week", "month", "quarter" or "year", empty if the ticks
+	// have the fixed size
+	TickMode string `protobuf:"bytes,10,opt,name=tick_mode,json=tickMode,proto3" json:"tick_mode,omitempty"`
+	// first month of the fiscal year, 1-12, if tick_mode is "quarter" or "year", otherwise 0
+	FiscalYearStart      int32    `protobuf:"varint,11,opt,name=fiscal_year_start,json=fiscalYearStart,proto3" json:"fiscal_year_start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Metadata) Reset()         { *m = Metadata{} }
@@ -122,6 +129,27 @@ func (m *Metadata) GetRunTimePerItem() map[string]float64 {
 	return nil
 }
 
+func (m *Metadata) GetPartial() bool {
+	if m != nil {
+		return m.Partial
+	}
+	return false
+}
+
+func (m *Metadata) GetTickMode() string {
+	if m != nil {
+		return m.TickMode
+	}
+	return ""
+}
+
+func (m *Metadata) GetFiscalYearStart() int32 {
+	if m != nil {
+		return m.FiscalYearStart
+	}
+	return 0
+}
+
 type BurndownSparseMatrixRow struct {
 	// the first `len(column)` elements are stored,
 	// the rest `number_of_columns - len(column)` values are zeros
@@ -284,10 +312,22 @@ type BurndownAnalysisResults struct {
 	// List of repository names in the same order as `repositories`
 	RepositorySequence []string `protobuf:"bytes,9,rep,name=repository_sequence,json=repositorySequence,proto3" json:"repository_sequence,omitempty"`
 	// Per-repository burndown matrices (included when combining multiple repositories)
-	Repositories         []*BurndownSparseMatrix `protobuf:"bytes,10,rep,name=repositories,proto3" json:"repositories,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Repositories []*BurndownSparseMatrix `protobuf:"bytes,10,rep,name=repositories,proto3" json:"repositories,omitempty"`
+	// per-directory burndown matrices, included if `--burndown-dirs-depth` was specified
+	Directories []*BurndownSparseMatrix `protobuf:"bytes,11,rep,name=directories,proto3" json:"directories,omitempty"`
+	// the number of the leading path components in the names of `directories`
+	DirectoriesDepth int32 `protobuf:"varint,12,opt,name=directories_depth,json=directoriesDepth,proto3" json:"directories_depth,omitempty"`
+	// "month", "quarter" or "year" if `--burndown-resample` was specified: each band and each
+	// sample is a calendar period since the first commit, granularity and sampling do not apply
+	Resample string `protobuf:"bytes,13,opt,name=resample,proto3" json:"resample,omitempty"`
+	// per-language burndown matrices, included if `--burndown-languages` was specified
+	Languages []*BurndownSparseMatrix `protobuf:"bytes,14,rep,name=languages,proto3" json:"languages,omitempty"`
+	// the comment and the blank lines among `project`, included if `--classify-lines` was specified
+	Comments             *BurndownSparseMatrix `protobuf:"bytes,15,opt,name=comments,proto3" json:"comments,omitempty"`
+	Blanks               *BurndownSparseMatrix `protobuf:"bytes,16,opt,name=blanks,proto3" json:"blanks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *BurndownAnalysisResults) Reset()         { *m = BurndownAnalysisResults{} }
@@ -384,6 +424,48 @@ func (m *BurndownAnalysisResults) GetRepositories() []*BurndownSparseMatrix {
 	return nil
 }
 
+func (m *BurndownAnalysisResults) GetDirectories() []*BurndownSparseMatrix {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetDirectoriesDepth() int32 {
+	if m != nil {
+		return m.DirectoriesDepth
+	}
+	return 0
+}
+
+func (m *BurndownAnalysisResults) GetResample() string {
+	if m != nil {
+		return m.Resample
+	}
+	return ""
+}
+
+func (m *BurndownAnalysisResults) GetLanguages() []*BurndownSparseMatrix {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetComments() *BurndownSparseMatrix {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *BurndownAnalysisResults) GetBlanks() *BurndownSparseMatrix {
+	if m != nil {
+		return m.Blanks
+	}
+	return nil
+}
+
 type CompressedSparseRowMatrix struct {
 	NumberOfRows    int32 `protobuf:"varint,1,opt,name=number_of_rows,json=numberOfRows,proto3" json:"number_of_rows,omitempty"`
 	NumberOfColumns int32 `protobuf:"varint,2,opt,name=number_of_columns,json=numberOfColumns,proto3" json:"number_of_columns,omitempty"`
@@ -547,10 +629,14 @@ type CouplesAnalysisResults struct {
 	// order corresponds to `people_couples::index`
 	PeopleFiles []*TouchedFiles `protobuf:"bytes,8,rep,name=people_files,json=peopleFiles,proto3" json:"people_files,omitempty"`
 	// order corresponds to `file_couples::index`
-	FilesLines           []int32  `protobuf:"varint,9,rep,packed,name=files_lines,json=filesLines,proto3" json:"files_lines,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	FilesLines []int32 `protobuf:"varint,9,rep,packed,name=files_lines,json=filesLines,proto3" json:"files_lines,omitempty"`
+	// `--couples-half-life` in days, 0 if the decay is disabled
+	HalfLife int32 `protobuf:"varint,10,opt,name=half_life,json=halfLife,proto3" json:"half_life,omitempty"`
+	// the decayed co-change weights in the order of `file_couples::matrix::data`
+	FilesDecayedWeights  []float32 `protobuf:"fixed32,11,rep,packed,name=files_decayed_weights,json=filesDecayedWeights,proto3" json:"files_decayed_weights,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *CouplesAnalysisResults) Reset()         { *m = CouplesAnalysisResults{} }
@@ -605,6 +691,20 @@ func (m *CouplesAnalysisResults) GetFilesLines() []int32 {
 	return nil
 }
 
+func (m *CouplesAnalysisResults) GetHalfLife() int32 {
+	if m != nil {
+		return m.HalfLife
+	}
+	return 0
+}
+
+func (m *CouplesAnalysisResults) GetFilesDecayedWeights() []float32 {
+	if m != nil {
+		return m.FilesDecayedWeights
+	}
+	return nil
+}
+
 type ShotnessRecord struct {
 	Type                 string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Name                 string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -706,11 +806,21 @@ func (m *ShotnessAnalysisResults) GetRecords() []*ShotnessRecord {
 }
 
 type FileHistory struct {
-	Commits              []string             `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
-	ChangesByDeveloper   map[int32]*LineStats `protobuf:"bytes,2,rep,name=changes_by_developer,json=changesByDeveloper,proto3" json:"changes_by_developer,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Commits            []string             `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	ChangesByDeveloper map[int32]*LineStats `protobuf:"bytes,2,rep,name=changes_by_developer,json=changesByDeveloper,proto3" json:"changes_by_developer,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// stable identifier which survives the renames
+	FileId int32 `protobuf:"varint,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// rename chain, the current name is the last
+	Names []string `protobuf:"bytes,4,rep,name=names,proto3" json:"names,omitempty"`
+	// tick when the file appeared
+	CreatedTick int32 `protobuf:"varint,5,opt,name=created_tick,json=createdTick,proto3" json:"created_tick,omitempty"`
+	// tick when the file was deleted or -1 if it exists at the last commit
+	DeletedTick int32 `protobuf:"varint,6,opt,name=deleted_tick,json=deletedTick,proto3" json:"deleted_tick,omitempty"`
+	// distinct developers who touched the file, sorted
+	Authors              []int32  `protobuf:"varint,7,rep,packed,name=authors,proto3" json:"authors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FileHistory) Reset()         { *m = FileHistory{} }
@@ -751,11 +861,48 @@ func (m *FileHistory) GetChangesByDeveloper() map[int32]*LineStats {
 	return nil
 }
 
+func (m *FileHistory) GetFileId() int32 {
+	if m != nil {
+		return m.FileId
+	}
+	return 0
+}
+
+func (m *FileHistory) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *FileHistory) GetCreatedTick() int32 {
+	if m != nil {
+		return m.CreatedTick
+	}
+	return 0
+}
+
+func (m *FileHistory) GetDeletedTick() int32 {
+	if m != nil {
+		return m.DeletedTick
+	}
+	return 0
+}
+
+func (m *FileHistory) GetAuthors() []int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
 type FileHistoryResultMessage struct {
-	Files                map[string]*FileHistory `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Files map[string]*FileHistory `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// deleted files sorted by the last name and by the deletion tick
+	Deleted              []*FileHistory `protobuf:"bytes,2,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *FileHistoryResultMessage) Reset()         { *m = FileHistoryResultMessage{} }
@@ -789,13 +936,23 @@ func (m *FileHistoryResultMessage) GetFiles() map[string]*FileHistory {
 	return nil
 }
 
+func (m *FileHistoryResultMessage) GetDeleted() []*FileHistory {
+	if m != nil {
+		return m.Deleted
+	}
+	return nil
+}
+
 type LineStats struct {
-	Added                int32    `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
-	Removed              int32    `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
-	Changed              int32    `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Added   int32 `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed int32 `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Changed int32 `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	// the comment and blank lines among the above, set only with --classify-lines
+	Comments             *LineCounts `protobuf:"bytes,4,opt,name=comments,proto3" json:"comments,omitempty"`
+	Blanks               *LineCounts `protobuf:"bytes,5,opt,name=blanks,proto3" json:"blanks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *LineStats) Reset()         { *m = LineStats{} }
@@ -843,10 +1000,81 @@ func (m *LineStats) GetChanged() int32 {
 	return 0
 }
 
+func (m *LineStats) GetComments() *LineCounts {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *LineStats) GetBlanks() *LineCounts {
+	if m != nil {
+		return m.Blanks
+	}
+	return nil
+}
+
+type LineCounts struct {
+	Added                int32    `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Removed              int32    `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"`
+	Changed              int32    `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineCounts) Reset()         { *m = LineCounts{} }
+func (m *LineCounts) String() string { return proto.CompactTextString(m) }
+func (*LineCounts) ProtoMessage()    {}
+func (*LineCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{14}
+}
+func (m *LineCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineCounts.Unmarshal(m, b)
+}
+func (m *LineCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineCounts.Marshal(b, m, deterministic)
+}
+func (m *LineCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineCounts.Merge(m, src)
+}
+func (m *LineCounts) XXX_Size() int {
+	return xxx_messageInfo_LineCounts.Size(m)
+}
+func (m *LineCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineCounts proto.InternalMessageInfo
+
+func (m *LineCounts) GetAdded() int32 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *LineCounts) GetRemoved() int32 {
+	if m != nil {
+		return m.Removed
+	}
+	return 0
+}
+
+func (m *LineCounts) GetChanged() int32 {
+	if m != nil {
+		return m.Changed
+	}
+	return 0
+}
+
 type DevTick struct {
-	Commits              int32                 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
-	Stats                *LineStats            `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
-	Languages            map[string]*LineStats `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Commits   int32                 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	Stats     *LineStats            `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	Languages map[string]*LineStats `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// line stats per change category: "new", "modified", "deleted" and "renamed", with
+	// the "test_" prefix for the test paths; set only with --devs-categories
+	Categories           map[string]*LineStats `protobuf:"bytes,4,rep,name=categories,proto3" json:"categories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
 	XXX_unrecognized     []byte                `json:"-"`
 	XXX_sizecache        int32                 `json:"-"`
@@ -856,7 +1084,7 @@ func (m *DevTick) Reset()         { *m = DevTick{} }
 func (m *DevTick) String() string { return proto.CompactTextString(m) }
 func (*DevTick) ProtoMessage()    {}
 func (*DevTick) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{14}
+	return fileDescriptor_f80abaa17e25ccc8, []int{15}
 }
 func (m *DevTick) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DevTick.Unmarshal(m, b)
@@ -897,6 +1125,13 @@ func (m *DevTick) GetLanguages() map[string]*LineStats {
 	return nil
 }
 
+func (m *DevTick) GetCategories() map[string]*LineStats {
+	if m != nil {
+		return m.Categories
+	}
+	return nil
+}
+
 type TickDevs struct {
 	Devs                 map[int32]*DevTick `protobuf:"bytes,1,rep,name=devs,proto3" json:"devs,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
@@ -908,7 +1143,7 @@ func (m *TickDevs) Reset()         { *m = TickDevs{} }
 func (m *TickDevs) String() string { return proto.CompactTextString(m) }
 func (*TickDevs) ProtoMessage()    {}
 func (*TickDevs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{15}
+	return fileDescriptor_f80abaa17e25ccc8, []int{16}
 }
 func (m *TickDevs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TickDevs.Unmarshal(m, b)
@@ -940,7 +1175,9 @@ type DevsAnalysisResults struct {
 	// developer identities, the indexes correspond to TickDevs' keys.
 	DevIndex []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
 	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	TickSize int64 `protobuf:"varint,8,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// organizations of the developers, parallel to dev_index; empty unless --organizations
+	Organizations        []string `protobuf:"bytes,3,rep,name=organizations,proto3" json:"organizations,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -950,7 +1187,7 @@ func (m *DevsAnalysisResults) Reset()         { *m = DevsAnalysisResults{} }
 func (m *DevsAnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*DevsAnalysisResults) ProtoMessage()    {}
 func (*DevsAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{16}
+	return fileDescriptor_f80abaa17e25ccc8, []int{17}
 }
 func (m *DevsAnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DevsAnalysisResults.Unmarshal(m, b)
@@ -991,6 +1228,13 @@ func (m *DevsAnalysisResults) GetTickSize() int64 {
 	return 0
 }
 
+func (m *DevsAnalysisResults) GetOrganizations() []string {
+	if m != nil {
+		return m.Organizations
+	}
+	return nil
+}
+
 type Sentiment struct {
 	Value                float32  `protobuf:"fixed32,1,opt,name=value,proto3" json:"value,omitempty"`
 	Comments             []string `protobuf:"bytes,2,rep,name=comments,proto3" json:"comments,omitempty"`
@@ -1004,7 +1248,7 @@ func (m *Sentiment) Reset()         { *m = Sentiment{} }
 func (m *Sentiment) String() string { return proto.CompactTextString(m) }
 func (*Sentiment) ProtoMessage()    {}
 func (*Sentiment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{17}
+	return fileDescriptor_f80abaa17e25ccc8, []int{18}
 }
 func (m *Sentiment) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Sentiment.Unmarshal(m, b)
@@ -1056,7 +1300,7 @@ func (m *CommentSentimentResults) Reset()         { *m = CommentSentimentResults
 func (m *CommentSentimentResults) String() string { return proto.CompactTextString(m) }
 func (*CommentSentimentResults) ProtoMessage()    {}
 func (*CommentSentimentResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{18}
+	return fileDescriptor_f80abaa17e25ccc8, []int{19}
 }
 func (m *CommentSentimentResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommentSentimentResults.Unmarshal(m, b)
@@ -1096,7 +1340,7 @@ func (m *CommitFile) Reset()         { *m = CommitFile{} }
 func (m *CommitFile) String() string { return proto.CompactTextString(m) }
 func (*CommitFile) ProtoMessage()    {}
 func (*CommitFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{19}
+	return fileDescriptor_f80abaa17e25ccc8, []int{20}
 }
 func (m *CommitFile) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommitFile.Unmarshal(m, b)
@@ -1138,20 +1382,23 @@ func (m *CommitFile) GetStats() *LineStats {
 }
 
 type Commit struct {
-	Hash                 string        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
-	WhenUnixTime         int64         `protobuf:"varint,2,opt,name=when_unix_time,json=whenUnixTime,proto3" json:"when_unix_time,omitempty"`
-	Author               int32         `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
-	Files                []*CommitFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the author time
+	WhenUnixTime int64         `protobuf:"varint,2,opt,name=when_unix_time,json=whenUnixTime,proto3" json:"when_unix_time,omitempty"`
+	Author       int32         `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
+	Files        []*CommitFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	// the committer time, later than when_unix_time if the commit was applied or merged afterwards
+	CommittedUnixTime    int64    `protobuf:"varint,5,opt,name=committed_unix_time,json=committedUnixTime,proto3" json:"committed_unix_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{20}
+	return fileDescriptor_f80abaa17e25ccc8, []int{21}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Commit.Unmarshal(m, b)
@@ -1199,6 +1446,13 @@ func (m *Commit) GetFiles() []*CommitFile {
 	return nil
 }
 
+func (m *Commit) GetCommittedUnixTime() int64 {
+	if m != nil {
+		return m.CommittedUnixTime
+	}
+	return 0
+}
+
 type CommitsAnalysisResults struct {
 	Commits              []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
 	AuthorIndex          []string  `protobuf:"bytes,2,rep,name=author_index,json=authorIndex,proto3" json:"author_index,omitempty"`
@@ -1211,7 +1465,7 @@ func (m *CommitsAnalysisResults) Reset()         { *m = CommitsAnalysisResults{}
 func (m *CommitsAnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*CommitsAnalysisResults) ProtoMessage()    {}
 func (*CommitsAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{21}
+	return fileDescriptor_f80abaa17e25ccc8, []int{22}
 }
 func (m *CommitsAnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CommitsAnalysisResults.Unmarshal(m, b)
@@ -1260,7 +1514,7 @@ func (m *Typo) Reset()         { *m = Typo{} }
 func (m *Typo) String() string { return proto.CompactTextString(m) }
 func (*Typo) ProtoMessage()    {}
 func (*Typo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{22}
+	return fileDescriptor_f80abaa17e25ccc8, []int{23}
 }
 func (m *Typo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Typo.Unmarshal(m, b)
@@ -1326,7 +1580,7 @@ func (m *TyposDataset) Reset()         { *m = TyposDataset{} }
 func (m *TyposDataset) String() string { return proto.CompactTextString(m) }
 func (*TyposDataset) ProtoMessage()    {}
 func (*TyposDataset) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{23}
+	return fileDescriptor_f80abaa17e25ccc8, []int{24}
 }
 func (m *TyposDataset) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TyposDataset.Unmarshal(m, b)
@@ -1364,7 +1618,7 @@ func (m *ImportsPerTick) Reset()         { *m = ImportsPerTick{} }
 func (m *ImportsPerTick) String() string { return proto.CompactTextString(m) }
 func (*ImportsPerTick) ProtoMessage()    {}
 func (*ImportsPerTick) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{24}
+	return fileDescriptor_f80abaa17e25ccc8, []int{25}
 }
 func (m *ImportsPerTick) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportsPerTick.Unmarshal(m, b)
@@ -1402,7 +1656,7 @@ func (m *ImportsPerLanguage) Reset()         { *m = ImportsPerLanguage{} }
 func (m *ImportsPerLanguage) String() string { return proto.CompactTextString(m) }
 func (*ImportsPerLanguage) ProtoMessage()    {}
 func (*ImportsPerLanguage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{25}
+	return fileDescriptor_f80abaa17e25ccc8, []int{26}
 }
 func (m *ImportsPerLanguage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportsPerLanguage.Unmarshal(m, b)
@@ -1440,7 +1694,7 @@ func (m *ImportsPerDeveloper) Reset()         { *m = ImportsPerDeveloper{} }
 func (m *ImportsPerDeveloper) String() string { return proto.CompactTextString(m) }
 func (*ImportsPerDeveloper) ProtoMessage()    {}
 func (*ImportsPerDeveloper) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{26}
+	return fileDescriptor_f80abaa17e25ccc8, []int{27}
 }
 func (m *ImportsPerDeveloper) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportsPerDeveloper.Unmarshal(m, b)
@@ -1481,7 +1735,7 @@ func (m *ImportsPerDeveloperResults) Reset()         { *m = ImportsPerDeveloperR
 func (m *ImportsPerDeveloperResults) String() string { return proto.CompactTextString(m) }
 func (*ImportsPerDeveloperResults) ProtoMessage()    {}
 func (*ImportsPerDeveloperResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{27}
+	return fileDescriptor_f80abaa17e25ccc8, []int{28}
 }
 func (m *ImportsPerDeveloperResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ImportsPerDeveloperResults.Unmarshal(m, b)
@@ -1536,7 +1790,7 @@ func (m *TemporalDimension) Reset()         { *m = TemporalDimension{} }
 func (m *TemporalDimension) String() string { return proto.CompactTextString(m) }
 func (*TemporalDimension) ProtoMessage()    {}
 func (*TemporalDimension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{28}
+	return fileDescriptor_f80abaa17e25ccc8, []int{29}
 }
 func (m *TemporalDimension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TemporalDimension.Unmarshal(m, b)
@@ -1588,7 +1842,7 @@ func (m *DeveloperTemporalActivity) Reset()         { *m = DeveloperTemporalActi
 func (m *DeveloperTemporalActivity) String() string { return proto.CompactTextString(m) }
 func (*DeveloperTemporalActivity) ProtoMessage()    {}
 func (*DeveloperTemporalActivity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{29}
+	return fileDescriptor_f80abaa17e25ccc8, []int{30}
 }
 func (m *DeveloperTemporalActivity) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeveloperTemporalActivity.Unmarshal(m, b)
@@ -1653,7 +1907,7 @@ func (m *TemporalActivityTick) Reset()         { *m = TemporalActivityTick{} }
 func (m *TemporalActivityTick) String() string { return proto.CompactTextString(m) }
 func (*TemporalActivityTick) ProtoMessage()    {}
 func (*TemporalActivityTick) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{30}
+	return fileDescriptor_f80abaa17e25ccc8, []int{31}
 }
 func (m *TemporalActivityTick) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TemporalActivityTick.Unmarshal(m, b)
@@ -1727,7 +1981,7 @@ func (m *TemporalActivityTickDevs) Reset()         { *m = TemporalActivityTickDe
 func (m *TemporalActivityTickDevs) String() string { return proto.CompactTextString(m) }
 func (*TemporalActivityTickDevs) ProtoMessage()    {}
 func (*TemporalActivityTickDevs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{31}
+	return fileDescriptor_f80abaa17e25ccc8, []int{32}
 }
 func (m *TemporalActivityTickDevs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TemporalActivityTickDevs.Unmarshal(m, b)
@@ -1763,17 +2017,23 @@ type TemporalActivityResults struct {
 	// This allows filtering by date range in post-processing
 	Ticks map[int32]*TemporalActivityTickDevs `protobuf:"bytes,3,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TickSize int64 `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// the time zone of the hours: "local", "utc", "author" or an IANA time zone name
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// developer index -> the dominant UTC offset of their commits in minutes
+	Offsets map[int32]int32 `protobuf:"bytes,6,rep,name=offsets,proto3" json:"offsets,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// developer index -> work-life balance summary
+	Summaries            map[int32]*TemporalActivitySummary `protobuf:"bytes,7,rep,name=summaries,proto3" json:"summaries,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
 }
 
 func (m *TemporalActivityResults) Reset()         { *m = TemporalActivityResults{} }
 func (m *TemporalActivityResults) String() string { return proto.CompactTextString(m) }
 func (*TemporalActivityResults) ProtoMessage()    {}
 func (*TemporalActivityResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{32}
+	return fileDescriptor_f80abaa17e25ccc8, []int{33}
 }
 func (m *TemporalActivityResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TemporalActivityResults.Unmarshal(m, b)
@@ -1821,1025 +2081,5208 @@ func (m *TemporalActivityResults) GetTickSize() int64 {
 	return 0
 }
 
-// Per-tick ownership snapshot for bus factor computation
-type BusFactorTickSnapshot struct {
-	// bus factor value at this tick (smallest k where top-k owners cover >= threshold)
-	BusFactor int32 `protobuf:"varint,1,opt,name=bus_factor,json=busFactor,proto3" json:"bus_factor,omitempty"`
-	// total alive lines at this tick
-	TotalLines int64 `protobuf:"varint,2,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
-	// per-author alive line counts at this tick, keyed by author index
-	AuthorLines          map[int32]int64 `protobuf:"bytes,3,rep,name=author_lines,json=authorLines,proto3" json:"author_lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *TemporalActivityResults) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
+	}
+	return ""
 }
 
-func (m *BusFactorTickSnapshot) Reset()         { *m = BusFactorTickSnapshot{} }
-func (m *BusFactorTickSnapshot) String() string { return proto.CompactTextString(m) }
-func (*BusFactorTickSnapshot) ProtoMessage()    {}
-func (*BusFactorTickSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{33}
-}
-func (m *BusFactorTickSnapshot) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BusFactorTickSnapshot.Unmarshal(m, b)
+func (m *TemporalActivityResults) GetOffsets() map[int32]int32 {
+	if m != nil {
+		return m.Offsets
+	}
+	return nil
 }
-func (m *BusFactorTickSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BusFactorTickSnapshot.Marshal(b, m, deterministic)
+
+func (m *TemporalActivityResults) GetSummaries() map[int32]*TemporalActivitySummary {
+	if m != nil {
+		return m.Summaries
+	}
+	return nil
 }
-func (m *BusFactorTickSnapshot) XXX_Merge(src proto.Message) {
+
+// Work-life balance summary of a developer's temporal activity
+type TemporalActivitySummary struct {
+	// share of the commits on Saturdays and Sundays
+	WeekendShare float32 `protobuf:"fixed32,1,opt,name=weekend_share,json=weekendShare,proto3" json:"weekend_share,omitempty"`
+	// share of the commits outside of the working hours
+	AfterHoursShare float32 `protobuf:"fixed32,2,opt,name=after_hours_share,json=afterHoursShare,proto3" json:"after_hours_share,omitempty"`
+	// the longest run of consecutive days with commits
+	LongestStreak        int32    `protobuf:"varint,3,opt,name=longest_streak,json=longestStreak,proto3" json:"longest_streak,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TemporalActivitySummary) Reset()         { *m = TemporalActivitySummary{} }
+func (m *TemporalActivitySummary) String() string { return proto.CompactTextString(m) }
+func (*TemporalActivitySummary) ProtoMessage()    {}
+func (*TemporalActivitySummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{34}
+}
+func (m *TemporalActivitySummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TemporalActivitySummary.Unmarshal(m, b)
+}
+func (m *TemporalActivitySummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TemporalActivitySummary.Marshal(b, m, deterministic)
+}
+func (m *TemporalActivitySummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TemporalActivitySummary.Merge(m, src)
+}
+func (m *TemporalActivitySummary) XXX_Size() int {
+	return xxx_messageInfo_TemporalActivitySummary.Size(m)
+}
+func (m *TemporalActivitySummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_TemporalActivitySummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TemporalActivitySummary proto.InternalMessageInfo
+
+func (m *TemporalActivitySummary) GetWeekendShare() float32 {
+	if m != nil {
+		return m.WeekendShare
+	}
+	return 0
+}
+
+func (m *TemporalActivitySummary) GetAfterHoursShare() float32 {
+	if m != nil {
+		return m.AfterHoursShare
+	}
+	return 0
+}
+
+func (m *TemporalActivitySummary) GetLongestStreak() int32 {
+	if m != nil {
+		return m.LongestStreak
+	}
+	return 0
+}
+
+// Line series of one developer sampled every CodeChurnResults.sampling ticks
+type CodeChurnSeries struct {
+	// cumulative lines inserted by the developer
+	Inserted []int64 `protobuf:"varint,1,rep,packed,name=inserted,proto3" json:"inserted,omitempty"`
+	// lines of the developer which are alive
+	Owned []int64 `protobuf:"varint,2,rep,packed,name=owned,proto3" json:"owned,omitempty"`
+	// cumulative lines of the developer deleted by themselves
+	DeletedBySelf []int64 `protobuf:"varint,3,rep,packed,name=deleted_by_self,json=deletedBySelf,proto3" json:"deleted_by_self,omitempty"`
+	// cumulative lines of the developer deleted by the others
+	DeletedByOthers []int64 `protobuf:"varint,4,rep,packed,name=deleted_by_others,json=deletedByOthers,proto3" json:"deleted_by_others,omitempty"`
+	// the number of the owned lines the developer is estimated to remember
+	Awareness []float32 `protobuf:"fixed32,5,rep,packed,name=awareness,proto3" json:"awareness,omitempty"`
+	// the mean memorability of the developer's files weighted by the owned lines, from 0 to 1
+	Memorability []float32 `protobuf:"fixed32,6,rep,packed,name=memorability,proto3" json:"memorability,omitempty"`
+	// the comment and the blank lines among the above, included if `--classify-lines` was specified
+	Comments             *CodeChurnKindSeries `protobuf:"bytes,7,opt,name=comments,proto3" json:"comments,omitempty"`
+	Blanks               *CodeChurnKindSeries `protobuf:"bytes,8,opt,name=blanks,proto3" json:"blanks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CodeChurnSeries) Reset()         { *m = CodeChurnSeries{} }
+func (m *CodeChurnSeries) String() string { return proto.CompactTextString(m) }
+func (*CodeChurnSeries) ProtoMessage()    {}
+func (*CodeChurnSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{35}
+}
+func (m *CodeChurnSeries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeChurnSeries.Unmarshal(m, b)
+}
+func (m *CodeChurnSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeChurnSeries.Marshal(b, m, deterministic)
+}
+func (m *CodeChurnSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeChurnSeries.Merge(m, src)
+}
+func (m *CodeChurnSeries) XXX_Size() int {
+	return xxx_messageInfo_CodeChurnSeries.Size(m)
+}
+func (m *CodeChurnSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeChurnSeries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeChurnSeries proto.InternalMessageInfo
+
+func (m *CodeChurnSeries) GetInserted() []int64 {
+	if m != nil {
+		return m.Inserted
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetOwned() []int64 {
+	if m != nil {
+		return m.Owned
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetDeletedBySelf() []int64 {
+	if m != nil {
+		return m.DeletedBySelf
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetDeletedByOthers() []int64 {
+	if m != nil {
+		return m.DeletedByOthers
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetAwareness() []float32 {
+	if m != nil {
+		return m.Awareness
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetMemorability() []float32 {
+	if m != nil {
+		return m.Memorability
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetComments() *CodeChurnKindSeries {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+func (m *CodeChurnSeries) GetBlanks() *CodeChurnKindSeries {
+	if m != nil {
+		return m.Blanks
+	}
+	return nil
+}
+
+// Line series of the comment or the blank lines of one developer, see CodeChurnSeries
+type CodeChurnKindSeries struct {
+	// cumulative lines inserted by the developer
+	Inserted []int64 `protobuf:"varint,1,rep,packed,name=inserted,proto3" json:"inserted,omitempty"`
+	// lines of the developer which are alive
+	Owned []int64 `protobuf:"varint,2,rep,packed,name=owned,proto3" json:"owned,omitempty"`
+	// cumulative lines of the developer deleted by themselves
+	DeletedBySelf []int64 `protobuf:"varint,3,rep,packed,name=deleted_by_self,json=deletedBySelf,proto3" json:"deleted_by_self,omitempty"`
+	// cumulative lines of the developer deleted by the others
+	DeletedByOthers      []int64  `protobuf:"varint,4,rep,packed,name=deleted_by_others,json=deletedByOthers,proto3" json:"deleted_by_others,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeChurnKindSeries) Reset()         { *m = CodeChurnKindSeries{} }
+func (m *CodeChurnKindSeries) String() string { return proto.CompactTextString(m) }
+func (*CodeChurnKindSeries) ProtoMessage()    {}
+func (*CodeChurnKindSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{36}
+}
+func (m *CodeChurnKindSeries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeChurnKindSeries.Unmarshal(m, b)
+}
+func (m *CodeChurnKindSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeChurnKindSeries.Marshal(b, m, deterministic)
+}
+func (m *CodeChurnKindSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeChurnKindSeries.Merge(m, src)
+}
+func (m *CodeChurnKindSeries) XXX_Size() int {
+	return xxx_messageInfo_CodeChurnKindSeries.Size(m)
+}
+func (m *CodeChurnKindSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeChurnKindSeries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeChurnKindSeries proto.InternalMessageInfo
+
+func (m *CodeChurnKindSeries) GetInserted() []int64 {
+	if m != nil {
+		return m.Inserted
+	}
+	return nil
+}
+
+func (m *CodeChurnKindSeries) GetOwned() []int64 {
+	if m != nil {
+		return m.Owned
+	}
+	return nil
+}
+
+func (m *CodeChurnKindSeries) GetDeletedBySelf() []int64 {
+	if m != nil {
+		return m.DeletedBySelf
+	}
+	return nil
+}
+
+func (m *CodeChurnKindSeries) GetDeletedByOthers() []int64 {
+	if m != nil {
+		return m.DeletedByOthers
+	}
+	return nil
+}
+
+type CodeChurnRework struct {
+	// own lines younger than self_churn_days which the developer deleted
+	SelfChurn int64 `protobuf:"varint,1,opt,name=self_churn,json=selfChurn,proto3" json:"self_churn,omitempty"`
+	// own older lines which the developer deleted
+	OldSelfChurn int64 `protobuf:"varint,2,opt,name=old_self_churn,json=oldSelfChurn,proto3" json:"old_self_churn,omitempty"`
+	// lines of the others which the developer deleted
+	Disruptive           int64    `protobuf:"varint,3,opt,name=disruptive,proto3" json:"disruptive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeChurnRework) Reset()         { *m = CodeChurnRework{} }
+func (m *CodeChurnRework) String() string { return proto.CompactTextString(m) }
+func (*CodeChurnRework) ProtoMessage()    {}
+func (*CodeChurnRework) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{37}
+}
+func (m *CodeChurnRework) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeChurnRework.Unmarshal(m, b)
+}
+func (m *CodeChurnRework) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeChurnRework.Marshal(b, m, deterministic)
+}
+func (m *CodeChurnRework) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeChurnRework.Merge(m, src)
+}
+func (m *CodeChurnRework) XXX_Size() int {
+	return xxx_messageInfo_CodeChurnRework.Size(m)
+}
+func (m *CodeChurnRework) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeChurnRework.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeChurnRework proto.InternalMessageInfo
+
+func (m *CodeChurnRework) GetSelfChurn() int64 {
+	if m != nil {
+		return m.SelfChurn
+	}
+	return 0
+}
+
+func (m *CodeChurnRework) GetOldSelfChurn() int64 {
+	if m != nil {
+		return m.OldSelfChurn
+	}
+	return 0
+}
+
+func (m *CodeChurnRework) GetDisruptive() int64 {
+	if m != nil {
+		return m.Disruptive
+	}
+	return 0
+}
+
+type CodeChurnReworkTicks struct {
+	// tick -> the deleted lines
+	Ticks                map[int32]*CodeChurnRework `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *CodeChurnReworkTicks) Reset()         { *m = CodeChurnReworkTicks{} }
+func (m *CodeChurnReworkTicks) String() string { return proto.CompactTextString(m) }
+func (*CodeChurnReworkTicks) ProtoMessage()    {}
+func (*CodeChurnReworkTicks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{38}
+}
+func (m *CodeChurnReworkTicks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeChurnReworkTicks.Unmarshal(m, b)
+}
+func (m *CodeChurnReworkTicks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeChurnReworkTicks.Marshal(b, m, deterministic)
+}
+func (m *CodeChurnReworkTicks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeChurnReworkTicks.Merge(m, src)
+}
+func (m *CodeChurnReworkTicks) XXX_Size() int {
+	return xxx_messageInfo_CodeChurnReworkTicks.Size(m)
+}
+func (m *CodeChurnReworkTicks) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeChurnReworkTicks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeChurnReworkTicks proto.InternalMessageInfo
+
+func (m *CodeChurnReworkTicks) GetTicks() map[int32]*CodeChurnRework {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+type CodeChurnResults struct {
+	// how many ticks are between two consecutive samples
+	Sampling int32 `protobuf:"varint,1,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// developer index -> their series
+	People map[int32]*CodeChurnSeries `protobuf:"bytes,2,rep,name=people,proto3" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize int64 `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// the age of the own lines in days under which deleting them is self-churn
+	SelfChurnDays int32 `protobuf:"varint,5,opt,name=self_churn_days,json=selfChurnDays,proto3" json:"self_churn_days,omitempty"`
+	// developer index -> the deleted lines per tick
+	Rework               map[int32]*CodeChurnReworkTicks `protobuf:"bytes,6,rep,name=rework,proto3" json:"rework,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *CodeChurnResults) Reset()         { *m = CodeChurnResults{} }
+func (m *CodeChurnResults) String() string { return proto.CompactTextString(m) }
+func (*CodeChurnResults) ProtoMessage()    {}
+func (*CodeChurnResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{39}
+}
+func (m *CodeChurnResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeChurnResults.Unmarshal(m, b)
+}
+func (m *CodeChurnResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeChurnResults.Marshal(b, m, deterministic)
+}
+func (m *CodeChurnResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeChurnResults.Merge(m, src)
+}
+func (m *CodeChurnResults) XXX_Size() int {
+	return xxx_messageInfo_CodeChurnResults.Size(m)
+}
+func (m *CodeChurnResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeChurnResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeChurnResults proto.InternalMessageInfo
+
+func (m *CodeChurnResults) GetSampling() int32 {
+	if m != nil {
+		return m.Sampling
+	}
+	return 0
+}
+
+func (m *CodeChurnResults) GetPeople() map[int32]*CodeChurnSeries {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *CodeChurnResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *CodeChurnResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *CodeChurnResults) GetSelfChurnDays() int32 {
+	if m != nil {
+		return m.SelfChurnDays
+	}
+	return 0
+}
+
+func (m *CodeChurnResults) GetRework() map[int32]*CodeChurnReworkTicks {
+	if m != nil {
+		return m.Rework
+	}
+	return nil
+}
+
+// Per-tick ownership snapshot for bus factor computation
+type BusFactorTickSnapshot struct {
+	// bus factor value at this tick (smallest k where top-k owners cover >= threshold)
+	BusFactor int32 `protobuf:"varint,1,opt,name=bus_factor,json=busFactor,proto3" json:"bus_factor,omitempty"`
+	// total alive lines at this tick
+	TotalLines int64 `protobuf:"varint,2,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	// per-author alive line counts at this tick, keyed by author index
+	AuthorLines map[int32]int64 `protobuf:"bytes,3,rep,name=author_lines,json=authorLines,proto3" json:"author_lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// directory -> bus factor at this tick, only with --bus-factor-subsystem-every
+	Subsystems           map[string]int32 `protobuf:"bytes,4,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *BusFactorTickSnapshot) Reset()         { *m = BusFactorTickSnapshot{} }
+func (m *BusFactorTickSnapshot) String() string { return proto.CompactTextString(m) }
+func (*BusFactorTickSnapshot) ProtoMessage()    {}
+func (*BusFactorTickSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+}
+func (m *BusFactorTickSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusFactorTickSnapshot.Unmarshal(m, b)
+}
+func (m *BusFactorTickSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusFactorTickSnapshot.Marshal(b, m, deterministic)
+}
+func (m *BusFactorTickSnapshot) XXX_Merge(src proto.Message) {
 	xxx_messageInfo_BusFactorTickSnapshot.Merge(m, src)
 }
-func (m *BusFactorTickSnapshot) XXX_Size() int {
-	return xxx_messageInfo_BusFactorTickSnapshot.Size(m)
+func (m *BusFactorTickSnapshot) XXX_Size() int {
+	return xxx_messageInfo_BusFactorTickSnapshot.Size(m)
+}
+func (m *BusFactorTickSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusFactorTickSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusFactorTickSnapshot proto.InternalMessageInfo
+
+func (m *BusFactorTickSnapshot) GetBusFactor() int32 {
+	if m != nil {
+		return m.BusFactor
+	}
+	return 0
+}
+
+func (m *BusFactorTickSnapshot) GetTotalLines() int64 {
+	if m != nil {
+		return m.TotalLines
+	}
+	return 0
+}
+
+func (m *BusFactorTickSnapshot) GetAuthorLines() map[int32]int64 {
+	if m != nil {
+		return m.AuthorLines
+	}
+	return nil
+}
+
+func (m *BusFactorTickSnapshot) GetSubsystems() map[string]int32 {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type BusFactorAnalysisResults struct {
+	// bus factor value per tick (index = tick number)
+	Snapshots map[int32]*BusFactorTickSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// bus factor per directory/subsystem prefix
+	SubsystemBusFactor map[string]int32 `protobuf:"bytes,2,rep,name=subsystem_bus_factor,json=subsystemBusFactor,proto3" json:"subsystem_bus_factor,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize int64 `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// threshold used (e.g. 0.8 for 80%)
+	Threshold float32 `protobuf:"fixed32,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// file -> top owners at the final tick, only with --bus-factor-ownership-top
+	FilesOwnership map[string]*FileOwners `protobuf:"bytes,6,rep,name=files_ownership,json=filesOwnership,proto3" json:"files_ownership,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the maximum number of the owners per file, 0 if the ownership matrix is disabled
+	OwnershipTop int32 `protobuf:"varint,7,opt,name=ownership_top,json=ownershipTop,proto3" json:"ownership_top,omitempty"`
+	// the removals of the top owners at the final tick, only with --bus-factor-simulate-top
+	Simulation []*BusFactorRemoval `protobuf:"bytes,8,rep,name=simulation,proto3" json:"simulation,omitempty"`
+	// the maximum number of the simulated removals, 0 if the simulation is disabled
+	SimulateTop int32 `protobuf:"varint,9,opt,name=simulate_top,json=simulateTop,proto3" json:"simulate_top,omitempty"`
+	// the minimum number of ticks between the per-directory snapshots, 0 if they are disabled
+	SubsystemEvery int32 `protobuf:"varint,10,opt,name=subsystem_every,json=subsystemEvery,proto3" json:"subsystem_every,omitempty"`
+	// the number of the path components in the directories, 0 means the whole directory
+	SubsystemDepth       int32    `protobuf:"varint,11,opt,name=subsystem_depth,json=subsystemDepth,proto3" json:"subsystem_depth,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BusFactorAnalysisResults) Reset()         { *m = BusFactorAnalysisResults{} }
+func (m *BusFactorAnalysisResults) String() string { return proto.CompactTextString(m) }
+func (*BusFactorAnalysisResults) ProtoMessage()    {}
+func (*BusFactorAnalysisResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{41}
+}
+func (m *BusFactorAnalysisResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusFactorAnalysisResults.Unmarshal(m, b)
+}
+func (m *BusFactorAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusFactorAnalysisResults.Marshal(b, m, deterministic)
+}
+func (m *BusFactorAnalysisResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BusFactorAnalysisResults.Merge(m, src)
+}
+func (m *BusFactorAnalysisResults) XXX_Size() int {
+	return xxx_messageInfo_BusFactorAnalysisResults.Size(m)
+}
+func (m *BusFactorAnalysisResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusFactorAnalysisResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusFactorAnalysisResults proto.InternalMessageInfo
+
+func (m *BusFactorAnalysisResults) GetSnapshots() map[int32]*BusFactorTickSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetSubsystemBusFactor() map[string]int32 {
+	if m != nil {
+		return m.SubsystemBusFactor
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *BusFactorAnalysisResults) GetThreshold() float32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *BusFactorAnalysisResults) GetFilesOwnership() map[string]*FileOwners {
+	if m != nil {
+		return m.FilesOwnership
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetOwnershipTop() int32 {
+	if m != nil {
+		return m.OwnershipTop
+	}
+	return 0
+}
+
+func (m *BusFactorAnalysisResults) GetSimulation() []*BusFactorRemoval {
+	if m != nil {
+		return m.Simulation
+	}
+	return nil
+}
+
+func (m *BusFactorAnalysisResults) GetSimulateTop() int32 {
+	if m != nil {
+		return m.SimulateTop
+	}
+	return 0
+}
+
+func (m *BusFactorAnalysisResults) GetSubsystemEvery() int32 {
+	if m != nil {
+		return m.SubsystemEvery
+	}
+	return 0
+}
+
+func (m *BusFactorAnalysisResults) GetSubsystemDepth() int32 {
+	if m != nil {
+		return m.SubsystemDepth
+	}
+	return 0
+}
+
+// The outcome of removing a single developer at the final tick
+type BusFactorRemoval struct {
+	// developer index
+	Author int32 `protobuf:"varint,1,opt,name=author,proto3" json:"author,omitempty"`
+	// alive lines owned by the developer
+	Lines int64 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	// share of the alive lines owned by the remaining developers
+	Coverage float32 `protobuf:"fixed32,3,opt,name=coverage,proto3" json:"coverage,omitempty"`
+	// bus factor of the remaining developers, 0 if they cannot cover the threshold
+	BusFactor int32 `protobuf:"varint,4,opt,name=bus_factor,json=busFactor,proto3" json:"bus_factor,omitempty"`
+	// directory -> share of its lines which nobody else owns
+	Gaps                 map[string]float32 `protobuf:"bytes,5,rep,name=gaps,proto3" json:"gaps,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed32,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *BusFactorRemoval) Reset()         { *m = BusFactorRemoval{} }
+func (m *BusFactorRemoval) String() string { return proto.CompactTextString(m) }
+func (*BusFactorRemoval) ProtoMessage()    {}
+func (*BusFactorRemoval) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{42}
+}
+func (m *BusFactorRemoval) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BusFactorRemoval.Unmarshal(m, b)
+}
+func (m *BusFactorRemoval) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BusFactorRemoval.Marshal(b, m, deterministic)
+}
+func (m *BusFactorRemoval) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BusFactorRemoval.Merge(m, src)
+}
+func (m *BusFactorRemoval) XXX_Size() int {
+	return xxx_messageInfo_BusFactorRemoval.Size(m)
+}
+func (m *BusFactorRemoval) XXX_DiscardUnknown() {
+	xxx_messageInfo_BusFactorRemoval.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BusFactorRemoval proto.InternalMessageInfo
+
+func (m *BusFactorRemoval) GetAuthor() int32 {
+	if m != nil {
+		return m.Author
+	}
+	return 0
+}
+
+func (m *BusFactorRemoval) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *BusFactorRemoval) GetCoverage() float32 {
+	if m != nil {
+		return m.Coverage
+	}
+	return 0
+}
+
+func (m *BusFactorRemoval) GetBusFactor() int32 {
+	if m != nil {
+		return m.BusFactor
+	}
+	return 0
+}
+
+func (m *BusFactorRemoval) GetGaps() map[string]float32 {
+	if m != nil {
+		return m.Gaps
+	}
+	return nil
+}
+
+// Top owners of a file, the row of the sparse file x author ownership matrix
+type FileOwners struct {
+	// alive lines in the file, including the lines of the other authors
+	Lines int64 `protobuf:"varint,1,opt,name=lines,proto3" json:"lines,omitempty"`
+	// author indices sorted by the owned lines descending
+	Authors []int32 `protobuf:"varint,2,rep,packed,name=authors,proto3" json:"authors,omitempty"`
+	// owned lines of each author in authors
+	AuthorLines          []int64  `protobuf:"varint,3,rep,packed,name=author_lines,json=authorLines,proto3" json:"author_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileOwners) Reset()         { *m = FileOwners{} }
+func (m *FileOwners) String() string { return proto.CompactTextString(m) }
+func (*FileOwners) ProtoMessage()    {}
+func (*FileOwners) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+}
+func (m *FileOwners) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileOwners.Unmarshal(m, b)
+}
+func (m *FileOwners) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileOwners.Marshal(b, m, deterministic)
+}
+func (m *FileOwners) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileOwners.Merge(m, src)
+}
+func (m *FileOwners) XXX_Size() int {
+	return xxx_messageInfo_FileOwners.Size(m)
+}
+func (m *FileOwners) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileOwners.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileOwners proto.InternalMessageInfo
+
+func (m *FileOwners) GetLines() int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return 0
+}
+
+func (m *FileOwners) GetAuthors() []int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *FileOwners) GetAuthorLines() []int64 {
+	if m != nil {
+		return m.AuthorLines
+	}
+	return nil
+}
+
+// Per-tick ownership concentration snapshot
+type OwnershipConcentrationTickSnapshot struct {
+	// Gini coefficient (0 = perfectly equal, 1 = one person owns everything)
+	Gini float64 `protobuf:"fixed64,1,opt,name=gini,proto3" json:"gini,omitempty"`
+	// Herfindahl-Hirschman Index (1/n = perfectly equal, 1.0 = single author)
+	Hhi float64 `protobuf:"fixed64,2,opt,name=hhi,proto3" json:"hhi,omitempty"`
+	// total alive lines at this tick
+	TotalLines int64 `protobuf:"varint,3,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	// per-author alive line counts at this tick, keyed by author index
+	AuthorLines          map[int32]int64 `protobuf:"bytes,4,rep,name=author_lines,json=authorLines,proto3" json:"author_lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *OwnershipConcentrationTickSnapshot) Reset()         { *m = OwnershipConcentrationTickSnapshot{} }
+func (m *OwnershipConcentrationTickSnapshot) String() string { return proto.CompactTextString(m) }
+func (*OwnershipConcentrationTickSnapshot) ProtoMessage()    {}
+func (*OwnershipConcentrationTickSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{44}
+}
+func (m *OwnershipConcentrationTickSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Unmarshal(m, b)
+}
+func (m *OwnershipConcentrationTickSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Marshal(b, m, deterministic)
+}
+func (m *OwnershipConcentrationTickSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnershipConcentrationTickSnapshot.Merge(m, src)
+}
+func (m *OwnershipConcentrationTickSnapshot) XXX_Size() int {
+	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Size(m)
+}
+func (m *OwnershipConcentrationTickSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnershipConcentrationTickSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnershipConcentrationTickSnapshot proto.InternalMessageInfo
+
+func (m *OwnershipConcentrationTickSnapshot) GetGini() float64 {
+	if m != nil {
+		return m.Gini
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationTickSnapshot) GetHhi() float64 {
+	if m != nil {
+		return m.Hhi
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationTickSnapshot) GetTotalLines() int64 {
+	if m != nil {
+		return m.TotalLines
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationTickSnapshot) GetAuthorLines() map[int32]int64 {
+	if m != nil {
+		return m.AuthorLines
+	}
+	return nil
+}
+
+type OwnershipConcentrationResults struct {
+	// concentration metrics per tick (index = tick number)
+	Snapshots map[int32]*OwnershipConcentrationTickSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// per-directory/subsystem Gini coefficient at final tick
+	SubsystemGini map[string]float64 `protobuf:"bytes,2,rep,name=subsystem_gini,json=subsystemGini,proto3" json:"subsystem_gini,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// per-directory/subsystem HHI at final tick
+	SubsystemHhi map[string]float64 `protobuf:"bytes,5,rep,name=subsystem_hhi,json=subsystemHhi,proto3" json:"subsystem_hhi,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize int64 `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// organizations of the developers, parallel to dev_index; empty unless --organizations
+	Organizations []string `protobuf:"bytes,6,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	// teams of the developers, parallel to dev_index; empty unless --identity-service sets them
+	Teams                []string `protobuf:"bytes,7,rep,name=teams,proto3" json:"teams,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OwnershipConcentrationResults) Reset()         { *m = OwnershipConcentrationResults{} }
+func (m *OwnershipConcentrationResults) String() string { return proto.CompactTextString(m) }
+func (*OwnershipConcentrationResults) ProtoMessage()    {}
+func (*OwnershipConcentrationResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{45}
+}
+func (m *OwnershipConcentrationResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OwnershipConcentrationResults.Unmarshal(m, b)
+}
+func (m *OwnershipConcentrationResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OwnershipConcentrationResults.Marshal(b, m, deterministic)
+}
+func (m *OwnershipConcentrationResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OwnershipConcentrationResults.Merge(m, src)
+}
+func (m *OwnershipConcentrationResults) XXX_Size() int {
+	return xxx_messageInfo_OwnershipConcentrationResults.Size(m)
+}
+func (m *OwnershipConcentrationResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_OwnershipConcentrationResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OwnershipConcentrationResults proto.InternalMessageInfo
+
+func (m *OwnershipConcentrationResults) GetSnapshots() map[int32]*OwnershipConcentrationTickSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *OwnershipConcentrationResults) GetSubsystemGini() map[string]float64 {
+	if m != nil {
+		return m.SubsystemGini
+	}
+	return nil
+}
+
+func (m *OwnershipConcentrationResults) GetSubsystemHhi() map[string]float64 {
+	if m != nil {
+		return m.SubsystemHhi
+	}
+	return nil
+}
+
+func (m *OwnershipConcentrationResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *OwnershipConcentrationResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *OwnershipConcentrationResults) GetOrganizations() []string {
+	if m != nil {
+		return m.Organizations
+	}
+	return nil
+}
+
+func (m *OwnershipConcentrationResults) GetTeams() []string {
+	if m != nil {
+		return m.Teams
+	}
+	return nil
+}
+
+// Per-file knowledge diffusion data
+type KnowledgeDiffusionFileData struct {
+	// total unique editors who ever touched this file
+	UniqueEditorsCount int32 `protobuf:"varint,1,opt,name=unique_editors_count,json=uniqueEditorsCount,proto3" json:"unique_editors_count,omitempty"`
+	// tick -> cumulative unique editor count at that tick
+	UniqueEditorsOverTime map[int32]int32 `protobuf:"bytes,2,rep,name=unique_editors_over_time,json=uniqueEditorsOverTime,proto3" json:"unique_editors_over_time,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// editors active within the recent window
+	RecentEditorsCount int32 `protobuf:"varint,3,opt,name=recent_editors_count,json=recentEditorsCount,proto3" json:"recent_editors_count,omitempty"`
+	// author indices who touched this file
+	Authors []int32 `protobuf:"varint,4,rep,packed,name=authors,proto3" json:"authors,omitempty"`
+	// stable identifier which survives the renames
+	FileId int32 `protobuf:"varint,5,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// rename chain, the current name is the last
+	Names                []string `protobuf:"bytes,6,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KnowledgeDiffusionFileData) Reset()         { *m = KnowledgeDiffusionFileData{} }
+func (m *KnowledgeDiffusionFileData) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeDiffusionFileData) ProtoMessage()    {}
+func (*KnowledgeDiffusionFileData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{46}
+}
+func (m *KnowledgeDiffusionFileData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeDiffusionFileData.Unmarshal(m, b)
+}
+func (m *KnowledgeDiffusionFileData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeDiffusionFileData.Marshal(b, m, deterministic)
+}
+func (m *KnowledgeDiffusionFileData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeDiffusionFileData.Merge(m, src)
+}
+func (m *KnowledgeDiffusionFileData) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeDiffusionFileData.Size(m)
+}
+func (m *KnowledgeDiffusionFileData) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeDiffusionFileData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnowledgeDiffusionFileData proto.InternalMessageInfo
+
+func (m *KnowledgeDiffusionFileData) GetUniqueEditorsCount() int32 {
+	if m != nil {
+		return m.UniqueEditorsCount
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionFileData) GetUniqueEditorsOverTime() map[int32]int32 {
+	if m != nil {
+		return m.UniqueEditorsOverTime
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionFileData) GetRecentEditorsCount() int32 {
+	if m != nil {
+		return m.RecentEditorsCount
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionFileData) GetAuthors() []int32 {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionFileData) GetFileId() int32 {
+	if m != nil {
+		return m.FileId
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionFileData) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type KnowledgeDiffusionResults struct {
+	// per-file diffusion data
+	Files map[string]*KnowledgeDiffusionFileData `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// histogram: editor_count -> number_of_files
+	Distribution map[int32]int32 `protobuf:"bytes,2,rep,name=distribution,proto3" json:"distribution,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// sliding window size in months for "recent" editors
+	WindowMonths int32 `protobuf:"varint,3,opt,name=window_months,json=windowMonths,proto3" json:"window_months,omitempty"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
+	TickSize int64 `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// per-directory roll-up, only with --knowledge-diffusion-dirs-depth
+	Directories map[string]*KnowledgeDiffusionDirectoryData `protobuf:"bytes,6,rep,name=directories,proto3" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the number of the path components in the directories, 0 if the roll-up is disabled
+	DirsDepth int32 `protobuf:"varint,7,opt,name=dirs_depth,json=dirsDepth,proto3" json:"dirs_depth,omitempty"`
+	// the minimum share of the recent edits by a single author to report a silo
+	SiloThreshold        float32  `protobuf:"fixed32,8,opt,name=silo_threshold,json=siloThreshold,proto3" json:"silo_threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KnowledgeDiffusionResults) Reset()         { *m = KnowledgeDiffusionResults{} }
+func (m *KnowledgeDiffusionResults) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeDiffusionResults) ProtoMessage()    {}
+func (*KnowledgeDiffusionResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{47}
+}
+func (m *KnowledgeDiffusionResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeDiffusionResults.Unmarshal(m, b)
+}
+func (m *KnowledgeDiffusionResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeDiffusionResults.Marshal(b, m, deterministic)
+}
+func (m *KnowledgeDiffusionResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeDiffusionResults.Merge(m, src)
+}
+func (m *KnowledgeDiffusionResults) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeDiffusionResults.Size(m)
+}
+func (m *KnowledgeDiffusionResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeDiffusionResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnowledgeDiffusionResults proto.InternalMessageInfo
+
+func (m *KnowledgeDiffusionResults) GetFiles() map[string]*KnowledgeDiffusionFileData {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionResults) GetDistribution() map[int32]int32 {
+	if m != nil {
+		return m.Distribution
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionResults) GetWindowMonths() int32 {
+	if m != nil {
+		return m.WindowMonths
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionResults) GetDirectories() map[string]*KnowledgeDiffusionDirectoryData {
+	if m != nil {
+		return m.Directories
+	}
+	return nil
+}
+
+func (m *KnowledgeDiffusionResults) GetDirsDepth() int32 {
+	if m != nil {
+		return m.DirsDepth
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionResults) GetSiloThreshold() float32 {
+	if m != nil {
+		return m.SiloThreshold
+	}
+	return 0
+}
+
+// Knowledge diffusion of all the files in a directory
+type KnowledgeDiffusionDirectoryData struct {
+	Files int32 `protobuf:"varint,1,opt,name=files,proto3" json:"files,omitempty"`
+	// distinct authors who ever touched the files
+	UniqueEditorsCount int32 `protobuf:"varint,2,opt,name=unique_editors_count,json=uniqueEditorsCount,proto3" json:"unique_editors_count,omitempty"`
+	// distinct authors active within the recent window
+	RecentEditorsCount int32 `protobuf:"varint,3,opt,name=recent_editors_count,json=recentEditorsCount,proto3" json:"recent_editors_count,omitempty"`
+	// file edits within the recent window
+	RecentEdits int32 `protobuf:"varint,4,opt,name=recent_edits,json=recentEdits,proto3" json:"recent_edits,omitempty"`
+	// author with the most recent edits, -1 if there are none
+	TopAuthor int32 `protobuf:"varint,5,opt,name=top_author,json=topAuthor,proto3" json:"top_author,omitempty"`
+	// share of the recent edits by top_author
+	SiloScore float32 `protobuf:"fixed32,6,opt,name=silo_score,json=siloScore,proto3" json:"silo_score,omitempty"`
+	// silo_score exceeds the threshold
+	Silo                 bool     `protobuf:"varint,7,opt,name=silo,proto3" json:"silo,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KnowledgeDiffusionDirectoryData) Reset()         { *m = KnowledgeDiffusionDirectoryData{} }
+func (m *KnowledgeDiffusionDirectoryData) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeDiffusionDirectoryData) ProtoMessage()    {}
+func (*KnowledgeDiffusionDirectoryData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{48}
+}
+func (m *KnowledgeDiffusionDirectoryData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeDiffusionDirectoryData.Unmarshal(m, b)
+}
+func (m *KnowledgeDiffusionDirectoryData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeDiffusionDirectoryData.Marshal(b, m, deterministic)
+}
+func (m *KnowledgeDiffusionDirectoryData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeDiffusionDirectoryData.Merge(m, src)
+}
+func (m *KnowledgeDiffusionDirectoryData) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeDiffusionDirectoryData.Size(m)
+}
+func (m *KnowledgeDiffusionDirectoryData) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeDiffusionDirectoryData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnowledgeDiffusionDirectoryData proto.InternalMessageInfo
+
+func (m *KnowledgeDiffusionDirectoryData) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetUniqueEditorsCount() int32 {
+	if m != nil {
+		return m.UniqueEditorsCount
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetRecentEditorsCount() int32 {
+	if m != nil {
+		return m.RecentEditorsCount
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetRecentEdits() int32 {
+	if m != nil {
+		return m.RecentEdits
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetTopAuthor() int32 {
+	if m != nil {
+		return m.TopAuthor
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetSiloScore() float32 {
+	if m != nil {
+		return m.SiloScore
+	}
+	return 0
+}
+
+func (m *KnowledgeDiffusionDirectoryData) GetSilo() bool {
+	if m != nil {
+		return m.Silo
+	}
+	return false
+}
+
+// Snapshot of onboarding metrics at a specific milestone
+type OnboardingSnapshot struct {
+	DaysSinceJoin int32 `protobuf:"varint,1,opt,name=days_since_join,json=daysSinceJoin,proto3" json:"days_since_join,omitempty"`
+	// All commits
+	TotalCommits int32 `protobuf:"varint,2,opt,name=total_commits,json=totalCommits,proto3" json:"total_commits,omitempty"`
+	TotalFiles   int32 `protobuf:"varint,3,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
+	TotalLines   int32 `protobuf:"varint,4,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	// Meaningful commits only (>= threshold)
+	MeaningfulCommits    int32    `protobuf:"varint,5,opt,name=meaningful_commits,json=meaningfulCommits,proto3" json:"meaningful_commits,omitempty"`
+	MeaningfulFiles      int32    `protobuf:"varint,6,opt,name=meaningful_files,json=meaningfulFiles,proto3" json:"meaningful_files,omitempty"`
+	MeaningfulLines      int32    `protobuf:"varint,7,opt,name=meaningful_lines,json=meaningfulLines,proto3" json:"meaningful_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OnboardingSnapshot) Reset()         { *m = OnboardingSnapshot{} }
+func (m *OnboardingSnapshot) String() string { return proto.CompactTextString(m) }
+func (*OnboardingSnapshot) ProtoMessage()    {}
+func (*OnboardingSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{49}
+}
+func (m *OnboardingSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OnboardingSnapshot.Unmarshal(m, b)
+}
+func (m *OnboardingSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OnboardingSnapshot.Marshal(b, m, deterministic)
+}
+func (m *OnboardingSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OnboardingSnapshot.Merge(m, src)
+}
+func (m *OnboardingSnapshot) XXX_Size() int {
+	return xxx_messageInfo_OnboardingSnapshot.Size(m)
+}
+func (m *OnboardingSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_OnboardingSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OnboardingSnapshot proto.InternalMessageInfo
+
+func (m *OnboardingSnapshot) GetDaysSinceJoin() int32 {
+	if m != nil {
+		return m.DaysSinceJoin
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetTotalCommits() int32 {
+	if m != nil {
+		return m.TotalCommits
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetTotalFiles() int32 {
+	if m != nil {
+		return m.TotalFiles
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetTotalLines() int32 {
+	if m != nil {
+		return m.TotalLines
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetMeaningfulCommits() int32 {
+	if m != nil {
+		return m.MeaningfulCommits
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetMeaningfulFiles() int32 {
+	if m != nil {
+		return m.MeaningfulFiles
+	}
+	return 0
+}
+
+func (m *OnboardingSnapshot) GetMeaningfulLines() int32 {
+	if m != nil {
+		return m.MeaningfulLines
+	}
+	return 0
+}
+
+// Average snapshot of onboarding metrics (for cohort aggregates)
+type OnboardingAverageSnapshot struct {
+	DaysSinceJoin        int32    `protobuf:"varint,1,opt,name=days_since_join,json=daysSinceJoin,proto3" json:"days_since_join,omitempty"`
+	AvgTotalCommits      float64  `protobuf:"fixed64,2,opt,name=avg_total_commits,json=avgTotalCommits,proto3" json:"avg_total_commits,omitempty"`
+	AvgTotalFiles        float64  `protobuf:"fixed64,3,opt,name=avg_total_files,json=avgTotalFiles,proto3" json:"avg_total_files,omitempty"`
+	AvgTotalLines        float64  `protobuf:"fixed64,4,opt,name=avg_total_lines,json=avgTotalLines,proto3" json:"avg_total_lines,omitempty"`
+	AvgMeaningfulCommits float64  `protobuf:"fixed64,5,opt,name=avg_meaningful_commits,json=avgMeaningfulCommits,proto3" json:"avg_meaningful_commits,omitempty"`
+	AvgMeaningfulFiles   float64  `protobuf:"fixed64,6,opt,name=avg_meaningful_files,json=avgMeaningfulFiles,proto3" json:"avg_meaningful_files,omitempty"`
+	AvgMeaningfulLines   float64  `protobuf:"fixed64,7,opt,name=avg_meaningful_lines,json=avgMeaningfulLines,proto3" json:"avg_meaningful_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OnboardingAverageSnapshot) Reset()         { *m = OnboardingAverageSnapshot{} }
+func (m *OnboardingAverageSnapshot) String() string { return proto.CompactTextString(m) }
+func (*OnboardingAverageSnapshot) ProtoMessage()    {}
+func (*OnboardingAverageSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{50}
+}
+func (m *OnboardingAverageSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OnboardingAverageSnapshot.Unmarshal(m, b)
+}
+func (m *OnboardingAverageSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OnboardingAverageSnapshot.Marshal(b, m, deterministic)
+}
+func (m *OnboardingAverageSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OnboardingAverageSnapshot.Merge(m, src)
+}
+func (m *OnboardingAverageSnapshot) XXX_Size() int {
+	return xxx_messageInfo_OnboardingAverageSnapshot.Size(m)
+}
+func (m *OnboardingAverageSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_OnboardingAverageSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OnboardingAverageSnapshot proto.InternalMessageInfo
+
+func (m *OnboardingAverageSnapshot) GetDaysSinceJoin() int32 {
+	if m != nil {
+		return m.DaysSinceJoin
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgTotalCommits() float64 {
+	if m != nil {
+		return m.AvgTotalCommits
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgTotalFiles() float64 {
+	if m != nil {
+		return m.AvgTotalFiles
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgTotalLines() float64 {
+	if m != nil {
+		return m.AvgTotalLines
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgMeaningfulCommits() float64 {
+	if m != nil {
+		return m.AvgMeaningfulCommits
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgMeaningfulFiles() float64 {
+	if m != nil {
+		return m.AvgMeaningfulFiles
+	}
+	return 0
+}
+
+func (m *OnboardingAverageSnapshot) GetAvgMeaningfulLines() float64 {
+	if m != nil {
+		return m.AvgMeaningfulLines
+	}
+	return 0
+}
+
+// Per-author onboarding progression data
+type AuthorOnboardingData struct {
+	FirstCommitTick int32  `protobuf:"varint,1,opt,name=first_commit_tick,json=firstCommitTick,proto3" json:"first_commit_tick,omitempty"`
+	JoinCohort      string `protobuf:"bytes,2,opt,name=join_cohort,json=joinCohort,proto3" json:"join_cohort,omitempty"`
+	// Snapshots at configured window milestones (keyed by days)
+	Snapshots map[int32]*OnboardingSnapshot `protobuf:"bytes,3,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Existing author index -> their lines changed by the author in the first mentorship_days days
+	Mentors map[int32]int32 `protobuf:"bytes,4,rep,name=mentors,proto3" json:"mentors,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of distinct directories touched in the first mentorship_days days
+	DirectoriesReached int32 `protobuf:"varint,5,opt,name=directories_reached,json=directoriesReached,proto3" json:"directories_reached,omitempty"`
+	// Tick of the first change of the lines written by another author, -1 if none
+	FirstTouchTick int32 `protobuf:"varint,6,opt,name=first_touch_tick,json=firstTouchTick,proto3" json:"first_touch_tick,omitempty"`
+	// Tick of the last commit
+	LastActivityTick     int32    `protobuf:"varint,7,opt,name=last_activity_tick,json=lastActivityTick,proto3" json:"last_activity_tick,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AuthorOnboardingData) Reset()         { *m = AuthorOnboardingData{} }
+func (m *AuthorOnboardingData) String() string { return proto.CompactTextString(m) }
+func (*AuthorOnboardingData) ProtoMessage()    {}
+func (*AuthorOnboardingData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{51}
+}
+func (m *AuthorOnboardingData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AuthorOnboardingData.Unmarshal(m, b)
+}
+func (m *AuthorOnboardingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AuthorOnboardingData.Marshal(b, m, deterministic)
+}
+func (m *AuthorOnboardingData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AuthorOnboardingData.Merge(m, src)
+}
+func (m *AuthorOnboardingData) XXX_Size() int {
+	return xxx_messageInfo_AuthorOnboardingData.Size(m)
+}
+func (m *AuthorOnboardingData) XXX_DiscardUnknown() {
+	xxx_messageInfo_AuthorOnboardingData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AuthorOnboardingData proto.InternalMessageInfo
+
+func (m *AuthorOnboardingData) GetFirstCommitTick() int32 {
+	if m != nil {
+		return m.FirstCommitTick
+	}
+	return 0
+}
+
+func (m *AuthorOnboardingData) GetJoinCohort() string {
+	if m != nil {
+		return m.JoinCohort
+	}
+	return ""
+}
+
+func (m *AuthorOnboardingData) GetSnapshots() map[int32]*OnboardingSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *AuthorOnboardingData) GetMentors() map[int32]int32 {
+	if m != nil {
+		return m.Mentors
+	}
+	return nil
+}
+
+func (m *AuthorOnboardingData) GetDirectoriesReached() int32 {
+	if m != nil {
+		return m.DirectoriesReached
+	}
+	return 0
+}
+
+func (m *AuthorOnboardingData) GetFirstTouchTick() int32 {
+	if m != nil {
+		return m.FirstTouchTick
+	}
+	return 0
+}
+
+func (m *AuthorOnboardingData) GetLastActivityTick() int32 {
+	if m != nil {
+		return m.LastActivityTick
+	}
+	return 0
+}
+
+// Aggregated cohort statistics
+type CohortStats struct {
+	Cohort      string `protobuf:"bytes,1,opt,name=cohort,proto3" json:"cohort,omitempty"`
+	AuthorCount int32  `protobuf:"varint,2,opt,name=author_count,json=authorCount,proto3" json:"author_count,omitempty"`
+	// Average snapshots across cohort (keyed by days)
+	AverageSnapshots map[int32]*OnboardingAverageSnapshot `protobuf:"bytes,3,rep,name=average_snapshots,json=averageSnapshots,proto3" json:"average_snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Retention at the configured milestones (keyed by months)
+	Retention            map[int32]*CohortRetention `protobuf:"bytes,4,rep,name=retention,proto3" json:"retention,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
+	XXX_unrecognized     []byte                     `json:"-"`
+	XXX_sizecache        int32                      `json:"-"`
+}
+
+func (m *CohortStats) Reset()         { *m = CohortStats{} }
+func (m *CohortStats) String() string { return proto.CompactTextString(m) }
+func (*CohortStats) ProtoMessage()    {}
+func (*CohortStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{52}
+}
+func (m *CohortStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CohortStats.Unmarshal(m, b)
+}
+func (m *CohortStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CohortStats.Marshal(b, m, deterministic)
+}
+func (m *CohortStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CohortStats.Merge(m, src)
+}
+func (m *CohortStats) XXX_Size() int {
+	return xxx_messageInfo_CohortStats.Size(m)
+}
+func (m *CohortStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CohortStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CohortStats proto.InternalMessageInfo
+
+func (m *CohortStats) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
+}
+
+func (m *CohortStats) GetAuthorCount() int32 {
+	if m != nil {
+		return m.AuthorCount
+	}
+	return 0
+}
+
+func (m *CohortStats) GetAverageSnapshots() map[int32]*OnboardingAverageSnapshot {
+	if m != nil {
+		return m.AverageSnapshots
+	}
+	return nil
+}
+
+func (m *CohortStats) GetRetention() map[int32]*CohortRetention {
+	if m != nil {
+		return m.Retention
+	}
+	return nil
+}
+
+// Share of a cohort's authors still active some months after their first commit
+type CohortRetention struct {
+	// authors whose first commit is at least that many months before the end of the history
+	Eligible int32 `protobuf:"varint,1,opt,name=eligible,proto3" json:"eligible,omitempty"`
+	// eligible authors who committed at or after that many months since their first commit
+	Retained int32 `protobuf:"varint,2,opt,name=retained,proto3" json:"retained,omitempty"`
+	// retained / eligible, 0 if there are no eligible authors
+	Fraction             float32  `protobuf:"fixed32,3,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CohortRetention) Reset()         { *m = CohortRetention{} }
+func (m *CohortRetention) String() string { return proto.CompactTextString(m) }
+func (*CohortRetention) ProtoMessage()    {}
+func (*CohortRetention) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{53}
+}
+func (m *CohortRetention) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CohortRetention.Unmarshal(m, b)
+}
+func (m *CohortRetention) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CohortRetention.Marshal(b, m, deterministic)
+}
+func (m *CohortRetention) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CohortRetention.Merge(m, src)
+}
+func (m *CohortRetention) XXX_Size() int {
+	return xxx_messageInfo_CohortRetention.Size(m)
+}
+func (m *CohortRetention) XXX_DiscardUnknown() {
+	xxx_messageInfo_CohortRetention.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CohortRetention proto.InternalMessageInfo
+
+func (m *CohortRetention) GetEligible() int32 {
+	if m != nil {
+		return m.Eligible
+	}
+	return 0
+}
+
+func (m *CohortRetention) GetRetained() int32 {
+	if m != nil {
+		return m.Retained
+	}
+	return 0
+}
+
+func (m *CohortRetention) GetFraction() float32 {
+	if m != nil {
+		return m.Fraction
+	}
+	return 0
+}
+
+// Top-level onboarding analysis results
+type OnboardingResults struct {
+	// Per-author detailed data (keyed by author index)
+	Authors map[int32]*AuthorOnboardingData `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Cohort aggregates (keyed by "YYYY-MM")
+	Cohorts map[string]*CohortStats `protobuf:"bytes,2,rep,name=cohorts,proto3" json:"cohorts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Configuration used
+	WindowDays          []int32 `protobuf:"varint,3,rep,packed,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	MeaningfulThreshold int32   `protobuf:"varint,4,opt,name=meaningful_threshold,json=meaningfulThreshold,proto3" json:"meaningful_threshold,omitempty"`
+	MentorshipDays      int32   `protobuf:"varint,7,opt,name=mentorship_days,json=mentorshipDays,proto3" json:"mentorship_days,omitempty"`
+	RetentionMonths     []int32 `protobuf:"varint,8,rep,packed,name=retention_months,json=retentionMonths,proto3" json:"retention_months,omitempty"`
+	// Developer identities
+	DevIndex []string `protobuf:"bytes,5,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// Tick size as nanosecond count
+	TickSize             int64    `protobuf:"varint,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *OnboardingResults) Reset()         { *m = OnboardingResults{} }
+func (m *OnboardingResults) String() string { return proto.CompactTextString(m) }
+func (*OnboardingResults) ProtoMessage()    {}
+func (*OnboardingResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{54}
+}
+func (m *OnboardingResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_OnboardingResults.Unmarshal(m, b)
+}
+func (m *OnboardingResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_OnboardingResults.Marshal(b, m, deterministic)
+}
+func (m *OnboardingResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_OnboardingResults.Merge(m, src)
+}
+func (m *OnboardingResults) XXX_Size() int {
+	return xxx_messageInfo_OnboardingResults.Size(m)
+}
+func (m *OnboardingResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_OnboardingResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_OnboardingResults proto.InternalMessageInfo
+
+func (m *OnboardingResults) GetAuthors() map[int32]*AuthorOnboardingData {
+	if m != nil {
+		return m.Authors
+	}
+	return nil
+}
+
+func (m *OnboardingResults) GetCohorts() map[string]*CohortStats {
+	if m != nil {
+		return m.Cohorts
+	}
+	return nil
+}
+
+func (m *OnboardingResults) GetWindowDays() []int32 {
+	if m != nil {
+		return m.WindowDays
+	}
+	return nil
+}
+
+func (m *OnboardingResults) GetMeaningfulThreshold() int32 {
+	if m != nil {
+		return m.MeaningfulThreshold
+	}
+	return 0
+}
+
+func (m *OnboardingResults) GetMentorshipDays() int32 {
+	if m != nil {
+		return m.MentorshipDays
+	}
+	return 0
+}
+
+func (m *OnboardingResults) GetRetentionMonths() []int32 {
+	if m != nil {
+		return m.RetentionMonths
+	}
+	return nil
+}
+
+func (m *OnboardingResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *OnboardingResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+// Per-file risk assessment
+type FileRisk struct {
+	Path                string  `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	RiskScore           float64 `protobuf:"fixed64,2,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	Size_               int32   `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Churn               int32   `protobuf:"varint,4,opt,name=churn,proto3" json:"churn,omitempty"`
+	CouplingDegree      int32   `protobuf:"varint,5,opt,name=coupling_degree,json=couplingDegree,proto3" json:"coupling_degree,omitempty"`
+	OwnershipGini       float64 `protobuf:"fixed64,6,opt,name=ownership_gini,json=ownershipGini,proto3" json:"ownership_gini,omitempty"`
+	SizeNormalized      float64 `protobuf:"fixed64,7,opt,name=size_normalized,json=sizeNormalized,proto3" json:"size_normalized,omitempty"`
+	ChurnNormalized     float64 `protobuf:"fixed64,8,opt,name=churn_normalized,json=churnNormalized,proto3" json:"churn_normalized,omitempty"`
+	CouplingNormalized  float64 `protobuf:"fixed64,9,opt,name=coupling_normalized,json=couplingNormalized,proto3" json:"coupling_normalized,omitempty"`
+	OwnershipNormalized float64 `protobuf:"fixed64,10,opt,name=ownership_normalized,json=ownershipNormalized,proto3" json:"ownership_normalized,omitempty"`
+	Language            string  `protobuf:"bytes,11,opt,name=language,proto3" json:"language,omitempty"`
+	// stable identifier which survives the renames
+	FileId int32 `protobuf:"varint,12,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// rename chain, the current name is the last
+	Names []string `protobuf:"bytes,13,rep,name=names,proto3" json:"names,omitempty"`
+	// days since the file was created
+	AgeDays int32 `protobuf:"varint,14,opt,name=age_days,json=ageDays,proto3" json:"age_days,omitempty"`
+	// mean indentation level of the non-blank lines at HEAD
+	Complexity           float64  `protobuf:"fixed64,15,opt,name=complexity,proto3" json:"complexity,omitempty"`
+	AgeNormalized        float64  `protobuf:"fixed64,16,opt,name=age_normalized,json=ageNormalized,proto3" json:"age_normalized,omitempty"`
+	ComplexityNormalized float64  `protobuf:"fixed64,17,opt,name=complexity_normalized,json=complexityNormalized,proto3" json:"complexity_normalized,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileRisk) Reset()         { *m = FileRisk{} }
+func (m *FileRisk) String() string { return proto.CompactTextString(m) }
+func (*FileRisk) ProtoMessage()    {}
+func (*FileRisk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{55}
+}
+func (m *FileRisk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileRisk.Unmarshal(m, b)
+}
+func (m *FileRisk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileRisk.Marshal(b, m, deterministic)
+}
+func (m *FileRisk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileRisk.Merge(m, src)
+}
+func (m *FileRisk) XXX_Size() int {
+	return xxx_messageInfo_FileRisk.Size(m)
+}
+func (m *FileRisk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileRisk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileRisk proto.InternalMessageInfo
+
+func (m *FileRisk) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileRisk) GetRiskScore() float64 {
+	if m != nil {
+		return m.RiskScore
+	}
+	return 0
+}
+
+func (m *FileRisk) GetSize_() int32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *FileRisk) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+func (m *FileRisk) GetCouplingDegree() int32 {
+	if m != nil {
+		return m.CouplingDegree
+	}
+	return 0
+}
+
+func (m *FileRisk) GetOwnershipGini() float64 {
+	if m != nil {
+		return m.OwnershipGini
+	}
+	return 0
+}
+
+func (m *FileRisk) GetSizeNormalized() float64 {
+	if m != nil {
+		return m.SizeNormalized
+	}
+	return 0
+}
+
+func (m *FileRisk) GetChurnNormalized() float64 {
+	if m != nil {
+		return m.ChurnNormalized
+	}
+	return 0
+}
+
+func (m *FileRisk) GetCouplingNormalized() float64 {
+	if m != nil {
+		return m.CouplingNormalized
+	}
+	return 0
+}
+
+func (m *FileRisk) GetOwnershipNormalized() float64 {
+	if m != nil {
+		return m.OwnershipNormalized
+	}
+	return 0
+}
+
+func (m *FileRisk) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *FileRisk) GetFileId() int32 {
+	if m != nil {
+		return m.FileId
+	}
+	return 0
+}
+
+func (m *FileRisk) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *FileRisk) GetAgeDays() int32 {
+	if m != nil {
+		return m.AgeDays
+	}
+	return 0
+}
+
+func (m *FileRisk) GetComplexity() float64 {
+	if m != nil {
+		return m.Complexity
+	}
+	return 0
+}
+
+func (m *FileRisk) GetAgeNormalized() float64 {
+	if m != nil {
+		return m.AgeNormalized
+	}
+	return 0
+}
+
+func (m *FileRisk) GetComplexityNormalized() float64 {
+	if m != nil {
+		return m.ComplexityNormalized
+	}
+	return 0
+}
+
+// Hotspot risk of all the files in the same programming language
+type LanguageRisk struct {
+	Language             string   `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Files                int32    `protobuf:"varint,2,opt,name=files,proto3" json:"files,omitempty"`
+	Size_                int32    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Churn                int32    `protobuf:"varint,4,opt,name=churn,proto3" json:"churn,omitempty"`
+	MeanRiskScore        float64  `protobuf:"fixed64,5,opt,name=mean_risk_score,json=meanRiskScore,proto3" json:"mean_risk_score,omitempty"`
+	MaxRiskScore         float64  `protobuf:"fixed64,6,opt,name=max_risk_score,json=maxRiskScore,proto3" json:"max_risk_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LanguageRisk) Reset()         { *m = LanguageRisk{} }
+func (m *LanguageRisk) String() string { return proto.CompactTextString(m) }
+func (*LanguageRisk) ProtoMessage()    {}
+func (*LanguageRisk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{56}
+}
+func (m *LanguageRisk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LanguageRisk.Unmarshal(m, b)
+}
+func (m *LanguageRisk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LanguageRisk.Marshal(b, m, deterministic)
+}
+func (m *LanguageRisk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LanguageRisk.Merge(m, src)
+}
+func (m *LanguageRisk) XXX_Size() int {
+	return xxx_messageInfo_LanguageRisk.Size(m)
+}
+func (m *LanguageRisk) XXX_DiscardUnknown() {
+	xxx_messageInfo_LanguageRisk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LanguageRisk proto.InternalMessageInfo
+
+func (m *LanguageRisk) GetLanguage() string {
+	if m != nil {
+		return m.Language
+	}
+	return ""
+}
+
+func (m *LanguageRisk) GetFiles() int32 {
+	if m != nil {
+		return m.Files
+	}
+	return 0
+}
+
+func (m *LanguageRisk) GetSize_() int32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *LanguageRisk) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+func (m *LanguageRisk) GetMeanRiskScore() float64 {
+	if m != nil {
+		return m.MeanRiskScore
+	}
+	return 0
+}
+
+func (m *LanguageRisk) GetMaxRiskScore() float64 {
+	if m != nil {
+		return m.MaxRiskScore
+	}
+	return 0
+}
+
+// Hotspot risk analysis results
+type HotspotRiskResults struct {
+	WindowDays int32       `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	Files      []*FileRisk `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// empty unless --hotspot-risk-languages
+	Languages []*LanguageRisk `protobuf:"bytes,3,rep,name=languages,proto3" json:"languages,omitempty"`
+	// top-N rankings over time sorted by tick, empty unless --hotspot-risk-snapshot-every
+	Snapshots []*HotspotRiskSnapshot `protobuf:"bytes,4,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	// the minimum number of ticks between the snapshots
+	SnapshotEvery        int32    `protobuf:"varint,5,opt,name=snapshot_every,json=snapshotEvery,proto3" json:"snapshot_every,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HotspotRiskResults) Reset()         { *m = HotspotRiskResults{} }
+func (m *HotspotRiskResults) String() string { return proto.CompactTextString(m) }
+func (*HotspotRiskResults) ProtoMessage()    {}
+func (*HotspotRiskResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{57}
+}
+func (m *HotspotRiskResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HotspotRiskResults.Unmarshal(m, b)
+}
+func (m *HotspotRiskResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HotspotRiskResults.Marshal(b, m, deterministic)
+}
+func (m *HotspotRiskResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HotspotRiskResults.Merge(m, src)
+}
+func (m *HotspotRiskResults) XXX_Size() int {
+	return xxx_messageInfo_HotspotRiskResults.Size(m)
+}
+func (m *HotspotRiskResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_HotspotRiskResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HotspotRiskResults proto.InternalMessageInfo
+
+func (m *HotspotRiskResults) GetWindowDays() int32 {
+	if m != nil {
+		return m.WindowDays
+	}
+	return 0
+}
+
+func (m *HotspotRiskResults) GetFiles() []*FileRisk {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *HotspotRiskResults) GetLanguages() []*LanguageRisk {
+	if m != nil {
+		return m.Languages
+	}
+	return nil
+}
+
+func (m *HotspotRiskResults) GetSnapshots() []*HotspotRiskSnapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+func (m *HotspotRiskResults) GetSnapshotEvery() int32 {
+	if m != nil {
+		return m.SnapshotEvery
+	}
+	return 0
+}
+
+// Ranking of the riskiest files at the end of a tick
+type HotspotRiskSnapshot struct {
+	Tick                 int32               `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Files                []*HotspotRiskEntry `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *HotspotRiskSnapshot) Reset()         { *m = HotspotRiskSnapshot{} }
+func (m *HotspotRiskSnapshot) String() string { return proto.CompactTextString(m) }
+func (*HotspotRiskSnapshot) ProtoMessage()    {}
+func (*HotspotRiskSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{58}
+}
+func (m *HotspotRiskSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HotspotRiskSnapshot.Unmarshal(m, b)
+}
+func (m *HotspotRiskSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HotspotRiskSnapshot.Marshal(b, m, deterministic)
+}
+func (m *HotspotRiskSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HotspotRiskSnapshot.Merge(m, src)
+}
+func (m *HotspotRiskSnapshot) XXX_Size() int {
+	return xxx_messageInfo_HotspotRiskSnapshot.Size(m)
+}
+func (m *HotspotRiskSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_HotspotRiskSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HotspotRiskSnapshot proto.InternalMessageInfo
+
+func (m *HotspotRiskSnapshot) GetTick() int32 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *HotspotRiskSnapshot) GetFiles() []*HotspotRiskEntry {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type HotspotRiskEntry struct {
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// the same stable identifier as in FileRisk
+	FileId               int32    `protobuf:"varint,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	RiskScore            float64  `protobuf:"fixed64,3,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HotspotRiskEntry) Reset()         { *m = HotspotRiskEntry{} }
+func (m *HotspotRiskEntry) String() string { return proto.CompactTextString(m) }
+func (*HotspotRiskEntry) ProtoMessage()    {}
+func (*HotspotRiskEntry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{59}
+}
+func (m *HotspotRiskEntry) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HotspotRiskEntry.Unmarshal(m, b)
+}
+func (m *HotspotRiskEntry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HotspotRiskEntry.Marshal(b, m, deterministic)
+}
+func (m *HotspotRiskEntry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HotspotRiskEntry.Merge(m, src)
+}
+func (m *HotspotRiskEntry) XXX_Size() int {
+	return xxx_messageInfo_HotspotRiskEntry.Size(m)
+}
+func (m *HotspotRiskEntry) XXX_DiscardUnknown() {
+	xxx_messageInfo_HotspotRiskEntry.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HotspotRiskEntry proto.InternalMessageInfo
+
+func (m *HotspotRiskEntry) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *HotspotRiskEntry) GetFileId() int32 {
+	if m != nil {
+		return m.FileId
+	}
+	return 0
+}
+
+func (m *HotspotRiskEntry) GetRiskScore() float64 {
+	if m != nil {
+		return m.RiskScore
+	}
+	return 0
+}
+
+type RefactoringProxyResults struct {
+	Ticks                []int32   `protobuf:"varint,1,rep,packed,name=ticks,proto3" json:"ticks,omitempty"`
+	RenameRatios         []float32 `protobuf:"fixed32,2,rep,packed,name=rename_ratios,json=renameRatios,proto3" json:"rename_ratios,omitempty"`
+	IsRefactoring        []bool    `protobuf:"varint,3,rep,packed,name=is_refactoring,json=isRefactoring,proto3" json:"is_refactoring,omitempty"`
+	TotalChanges         []int32   `protobuf:"varint,4,rep,packed,name=total_changes,json=totalChanges,proto3" json:"total_changes,omitempty"`
+	Threshold            float32   `protobuf:"fixed32,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	TickSize             int64     `protobuf:"varint,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *RefactoringProxyResults) Reset()         { *m = RefactoringProxyResults{} }
+func (m *RefactoringProxyResults) String() string { return proto.CompactTextString(m) }
+func (*RefactoringProxyResults) ProtoMessage()    {}
+func (*RefactoringProxyResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{60}
+}
+func (m *RefactoringProxyResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RefactoringProxyResults.Unmarshal(m, b)
+}
+func (m *RefactoringProxyResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RefactoringProxyResults.Marshal(b, m, deterministic)
+}
+func (m *RefactoringProxyResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RefactoringProxyResults.Merge(m, src)
+}
+func (m *RefactoringProxyResults) XXX_Size() int {
+	return xxx_messageInfo_RefactoringProxyResults.Size(m)
+}
+func (m *RefactoringProxyResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_RefactoringProxyResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RefactoringProxyResults proto.InternalMessageInfo
+
+func (m *RefactoringProxyResults) GetTicks() []int32 {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RefactoringProxyResults) GetRenameRatios() []float32 {
+	if m != nil {
+		return m.RenameRatios
+	}
+	return nil
+}
+
+func (m *RefactoringProxyResults) GetIsRefactoring() []bool {
+	if m != nil {
+		return m.IsRefactoring
+	}
+	return nil
+}
+
+func (m *RefactoringProxyResults) GetTotalChanges() []int32 {
+	if m != nil {
+		return m.TotalChanges
+	}
+	return nil
+}
+
+func (m *RefactoringProxyResults) GetThreshold() float32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *RefactoringProxyResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+// Comment and code lines of a file or a subsystem
+type CommentDensityStats struct {
+	CommentLines int32 `protobuf:"varint,1,opt,name=comment_lines,json=commentLines,proto3" json:"comment_lines,omitempty"`
+	CodeLines    int32 `protobuf:"varint,2,opt,name=code_lines,json=codeLines,proto3" json:"code_lines,omitempty"`
+	// Added, removed and changed lines
+	Churn                int32    `protobuf:"varint,3,opt,name=churn,proto3" json:"churn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommentDensityStats) Reset()         { *m = CommentDensityStats{} }
+func (m *CommentDensityStats) String() string { return proto.CompactTextString(m) }
+func (*CommentDensityStats) ProtoMessage()    {}
+func (*CommentDensityStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{61}
+}
+func (m *CommentDensityStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentDensityStats.Unmarshal(m, b)
+}
+func (m *CommentDensityStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentDensityStats.Marshal(b, m, deterministic)
+}
+func (m *CommentDensityStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentDensityStats.Merge(m, src)
+}
+func (m *CommentDensityStats) XXX_Size() int {
+	return xxx_messageInfo_CommentDensityStats.Size(m)
+}
+func (m *CommentDensityStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentDensityStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentDensityStats proto.InternalMessageInfo
+
+func (m *CommentDensityStats) GetCommentLines() int32 {
+	if m != nil {
+		return m.CommentLines
+	}
+	return 0
+}
+
+func (m *CommentDensityStats) GetCodeLines() int32 {
+	if m != nil {
+		return m.CodeLines
+	}
+	return 0
+}
+
+func (m *CommentDensityStats) GetChurn() int32 {
+	if m != nil {
+		return m.Churn
+	}
+	return 0
+}
+
+type CommentDensityTick struct {
+	// Lines at the end of the tick and the churn during the tick, keyed by directory
+	Subsystems           map[string]*CommentDensityStats `protobuf:"bytes,1,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *CommentDensityTick) Reset()         { *m = CommentDensityTick{} }
+func (m *CommentDensityTick) String() string { return proto.CompactTextString(m) }
+func (*CommentDensityTick) ProtoMessage()    {}
+func (*CommentDensityTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{62}
+}
+func (m *CommentDensityTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentDensityTick.Unmarshal(m, b)
+}
+func (m *CommentDensityTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentDensityTick.Marshal(b, m, deterministic)
+}
+func (m *CommentDensityTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentDensityTick.Merge(m, src)
+}
+func (m *CommentDensityTick) XXX_Size() int {
+	return xxx_messageInfo_CommentDensityTick.Size(m)
+}
+func (m *CommentDensityTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentDensityTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentDensityTick proto.InternalMessageInfo
+
+func (m *CommentDensityTick) GetSubsystems() map[string]*CommentDensityStats {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+// Subsystem which comment density decreases while the churn grows
+type CommentDensityErosion struct {
+	Subsystem            string   `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	DensityBefore        float32  `protobuf:"fixed32,2,opt,name=density_before,json=densityBefore,proto3" json:"density_before,omitempty"`
+	DensityAfter         float32  `protobuf:"fixed32,3,opt,name=density_after,json=densityAfter,proto3" json:"density_after,omitempty"`
+	ChurnBefore          int32    `protobuf:"varint,4,opt,name=churn_before,json=churnBefore,proto3" json:"churn_before,omitempty"`
+	ChurnAfter           int32    `protobuf:"varint,5,opt,name=churn_after,json=churnAfter,proto3" json:"churn_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommentDensityErosion) Reset()         { *m = CommentDensityErosion{} }
+func (m *CommentDensityErosion) String() string { return proto.CompactTextString(m) }
+func (*CommentDensityErosion) ProtoMessage()    {}
+func (*CommentDensityErosion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{63}
+}
+func (m *CommentDensityErosion) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentDensityErosion.Unmarshal(m, b)
+}
+func (m *CommentDensityErosion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentDensityErosion.Marshal(b, m, deterministic)
+}
+func (m *CommentDensityErosion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentDensityErosion.Merge(m, src)
+}
+func (m *CommentDensityErosion) XXX_Size() int {
+	return xxx_messageInfo_CommentDensityErosion.Size(m)
+}
+func (m *CommentDensityErosion) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentDensityErosion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentDensityErosion proto.InternalMessageInfo
+
+func (m *CommentDensityErosion) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+func (m *CommentDensityErosion) GetDensityBefore() float32 {
+	if m != nil {
+		return m.DensityBefore
+	}
+	return 0
+}
+
+func (m *CommentDensityErosion) GetDensityAfter() float32 {
+	if m != nil {
+		return m.DensityAfter
+	}
+	return 0
+}
+
+func (m *CommentDensityErosion) GetChurnBefore() int32 {
+	if m != nil {
+		return m.ChurnBefore
+	}
+	return 0
+}
+
+func (m *CommentDensityErosion) GetChurnAfter() int32 {
+	if m != nil {
+		return m.ChurnAfter
+	}
+	return 0
+}
+
+type CommentDensityResults struct {
+	Ticks map[int32]*CommentDensityTick `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Final lines and total churn of each alive file
+	Files                map[string]*CommentDensityStats `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Eroding              []*CommentDensityErosion        `protobuf:"bytes,3,rep,name=eroding,proto3" json:"eroding,omitempty"`
+	Threshold            float32                         `protobuf:"fixed32,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	TickSize             int64                           `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
+}
+
+func (m *CommentDensityResults) Reset()         { *m = CommentDensityResults{} }
+func (m *CommentDensityResults) String() string { return proto.CompactTextString(m) }
+func (*CommentDensityResults) ProtoMessage()    {}
+func (*CommentDensityResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{64}
+}
+func (m *CommentDensityResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommentDensityResults.Unmarshal(m, b)
+}
+func (m *CommentDensityResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommentDensityResults.Marshal(b, m, deterministic)
+}
+func (m *CommentDensityResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommentDensityResults.Merge(m, src)
+}
+func (m *CommentDensityResults) XXX_Size() int {
+	return xxx_messageInfo_CommentDensityResults.Size(m)
+}
+func (m *CommentDensityResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommentDensityResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommentDensityResults proto.InternalMessageInfo
+
+func (m *CommentDensityResults) GetTicks() map[int32]*CommentDensityTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *CommentDensityResults) GetFiles() map[string]*CommentDensityStats {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *CommentDensityResults) GetEroding() []*CommentDensityErosion {
+	if m != nil {
+		return m.Eroding
+	}
+	return nil
+}
+
+func (m *CommentDensityResults) GetThreshold() float32 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *CommentDensityResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type RegexMetricsTick struct {
+	// Matches of each metric at the end of the tick in the order of RegexMetricsResults.metrics,
+	// keyed by directory
+	Subsystems           map[string]*RegexMetricsCounts `protobuf:"bytes,1,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *RegexMetricsTick) Reset()         { *m = RegexMetricsTick{} }
+func (m *RegexMetricsTick) String() string { return proto.CompactTextString(m) }
+func (*RegexMetricsTick) ProtoMessage()    {}
+func (*RegexMetricsTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{65}
+}
+func (m *RegexMetricsTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegexMetricsTick.Unmarshal(m, b)
+}
+func (m *RegexMetricsTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegexMetricsTick.Marshal(b, m, deterministic)
+}
+func (m *RegexMetricsTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegexMetricsTick.Merge(m, src)
+}
+func (m *RegexMetricsTick) XXX_Size() int {
+	return xxx_messageInfo_RegexMetricsTick.Size(m)
+}
+func (m *RegexMetricsTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegexMetricsTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegexMetricsTick proto.InternalMessageInfo
+
+func (m *RegexMetricsTick) GetSubsystems() map[string]*RegexMetricsCounts {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type RegexMetricsCounts struct {
+	Counts               []int32  `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegexMetricsCounts) Reset()         { *m = RegexMetricsCounts{} }
+func (m *RegexMetricsCounts) String() string { return proto.CompactTextString(m) }
+func (*RegexMetricsCounts) ProtoMessage()    {}
+func (*RegexMetricsCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{66}
+}
+func (m *RegexMetricsCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegexMetricsCounts.Unmarshal(m, b)
+}
+func (m *RegexMetricsCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegexMetricsCounts.Marshal(b, m, deterministic)
+}
+func (m *RegexMetricsCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegexMetricsCounts.Merge(m, src)
+}
+func (m *RegexMetricsCounts) XXX_Size() int {
+	return xxx_messageInfo_RegexMetricsCounts.Size(m)
+}
+func (m *RegexMetricsCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegexMetricsCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegexMetricsCounts proto.InternalMessageInfo
+
+func (m *RegexMetricsCounts) GetCounts() []int32 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
+type RegexMetricsResults struct {
+	Metrics []string                    `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	Ticks   map[int32]*RegexMetricsTick `protobuf:"bytes,2,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Matches of each metric in the alive files
+	Totals               []int32  `protobuf:"varint,3,rep,packed,name=totals,proto3" json:"totals,omitempty"`
+	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegexMetricsResults) Reset()         { *m = RegexMetricsResults{} }
+func (m *RegexMetricsResults) String() string { return proto.CompactTextString(m) }
+func (*RegexMetricsResults) ProtoMessage()    {}
+func (*RegexMetricsResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{67}
+}
+func (m *RegexMetricsResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegexMetricsResults.Unmarshal(m, b)
+}
+func (m *RegexMetricsResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegexMetricsResults.Marshal(b, m, deterministic)
+}
+func (m *RegexMetricsResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegexMetricsResults.Merge(m, src)
+}
+func (m *RegexMetricsResults) XXX_Size() int {
+	return xxx_messageInfo_RegexMetricsResults.Size(m)
+}
+func (m *RegexMetricsResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegexMetricsResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegexMetricsResults proto.InternalMessageInfo
+
+func (m *RegexMetricsResults) GetMetrics() []string {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *RegexMetricsResults) GetTicks() map[int32]*RegexMetricsTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *RegexMetricsResults) GetTotals() []int32 {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+func (m *RegexMetricsResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type TestChurnTick struct {
+	// Added, removed and changed lines in the test and production files
+	TestChurn            int32    `protobuf:"varint,1,opt,name=test_churn,json=testChurn,proto3" json:"test_churn,omitempty"`
+	ProductionChurn      int32    `protobuf:"varint,2,opt,name=production_churn,json=productionChurn,proto3" json:"production_churn,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestChurnTick) Reset()         { *m = TestChurnTick{} }
+func (m *TestChurnTick) String() string { return proto.CompactTextString(m) }
+func (*TestChurnTick) ProtoMessage()    {}
+func (*TestChurnTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{68}
+}
+func (m *TestChurnTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestChurnTick.Unmarshal(m, b)
+}
+func (m *TestChurnTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestChurnTick.Marshal(b, m, deterministic)
+}
+func (m *TestChurnTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestChurnTick.Merge(m, src)
+}
+func (m *TestChurnTick) XXX_Size() int {
+	return xxx_messageInfo_TestChurnTick.Size(m)
+}
+func (m *TestChurnTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestChurnTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestChurnTick proto.InternalMessageInfo
+
+func (m *TestChurnTick) GetTestChurn() int32 {
+	if m != nil {
+		return m.TestChurn
+	}
+	return 0
+}
+
+func (m *TestChurnTick) GetProductionChurn() int32 {
+	if m != nil {
+		return m.ProductionChurn
+	}
+	return 0
+}
+
+// Test file paired with the production file it covers
+type TestChurnSuite struct {
+	TestFile          string `protobuf:"bytes,1,opt,name=test_file,json=testFile,proto3" json:"test_file,omitempty"`
+	ProductionFile    string `protobuf:"bytes,2,opt,name=production_file,json=productionFile,proto3" json:"production_file,omitempty"`
+	TestCommits       int32  `protobuf:"varint,3,opt,name=test_commits,json=testCommits,proto3" json:"test_commits,omitempty"`
+	ProductionCommits int32  `protobuf:"varint,4,opt,name=production_commits,json=productionCommits,proto3" json:"production_commits,omitempty"`
+	TestChurn         int32  `protobuf:"varint,5,opt,name=test_churn,json=testChurn,proto3" json:"test_churn,omitempty"`
+	ProductionChurn   int32  `protobuf:"varint,6,opt,name=production_churn,json=productionChurn,proto3" json:"production_churn,omitempty"`
+	// test_commits / production_commits
+	Ratio                float32  `protobuf:"fixed32,7,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Brittle              bool     `protobuf:"varint,8,opt,name=brittle,proto3" json:"brittle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TestChurnSuite) Reset()         { *m = TestChurnSuite{} }
+func (m *TestChurnSuite) String() string { return proto.CompactTextString(m) }
+func (*TestChurnSuite) ProtoMessage()    {}
+func (*TestChurnSuite) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{69}
+}
+func (m *TestChurnSuite) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestChurnSuite.Unmarshal(m, b)
+}
+func (m *TestChurnSuite) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestChurnSuite.Marshal(b, m, deterministic)
+}
+func (m *TestChurnSuite) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestChurnSuite.Merge(m, src)
+}
+func (m *TestChurnSuite) XXX_Size() int {
+	return xxx_messageInfo_TestChurnSuite.Size(m)
+}
+func (m *TestChurnSuite) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestChurnSuite.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestChurnSuite proto.InternalMessageInfo
+
+func (m *TestChurnSuite) GetTestFile() string {
+	if m != nil {
+		return m.TestFile
+	}
+	return ""
+}
+
+func (m *TestChurnSuite) GetProductionFile() string {
+	if m != nil {
+		return m.ProductionFile
+	}
+	return ""
+}
+
+func (m *TestChurnSuite) GetTestCommits() int32 {
+	if m != nil {
+		return m.TestCommits
+	}
+	return 0
+}
+
+func (m *TestChurnSuite) GetProductionCommits() int32 {
+	if m != nil {
+		return m.ProductionCommits
+	}
+	return 0
+}
+
+func (m *TestChurnSuite) GetTestChurn() int32 {
+	if m != nil {
+		return m.TestChurn
+	}
+	return 0
+}
+
+func (m *TestChurnSuite) GetProductionChurn() int32 {
+	if m != nil {
+		return m.ProductionChurn
+	}
+	return 0
+}
+
+func (m *TestChurnSuite) GetRatio() float32 {
+	if m != nil {
+		return m.Ratio
+	}
+	return 0
+}
+
+func (m *TestChurnSuite) GetBrittle() bool {
+	if m != nil {
+		return m.Brittle
+	}
+	return false
+}
+
+type TestChurnResults struct {
+	Ticks                map[int32]*TestChurnTick `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Suites               []*TestChurnSuite        `protobuf:"bytes,2,rep,name=suites,proto3" json:"suites,omitempty"`
+	RatioThreshold       float32                  `protobuf:"fixed32,3,opt,name=ratio_threshold,json=ratioThreshold,proto3" json:"ratio_threshold,omitempty"`
+	MinCommits           int32                    `protobuf:"varint,4,opt,name=min_commits,json=minCommits,proto3" json:"min_commits,omitempty"`
+	TickSize             int64                    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *TestChurnResults) Reset()         { *m = TestChurnResults{} }
+func (m *TestChurnResults) String() string { return proto.CompactTextString(m) }
+func (*TestChurnResults) ProtoMessage()    {}
+func (*TestChurnResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{70}
+}
+func (m *TestChurnResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TestChurnResults.Unmarshal(m, b)
+}
+func (m *TestChurnResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TestChurnResults.Marshal(b, m, deterministic)
+}
+func (m *TestChurnResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TestChurnResults.Merge(m, src)
+}
+func (m *TestChurnResults) XXX_Size() int {
+	return xxx_messageInfo_TestChurnResults.Size(m)
+}
+func (m *TestChurnResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_TestChurnResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TestChurnResults proto.InternalMessageInfo
+
+func (m *TestChurnResults) GetTicks() map[int32]*TestChurnTick {
+	if m != nil {
+		return m.Ticks
+	}
+	return nil
+}
+
+func (m *TestChurnResults) GetSuites() []*TestChurnSuite {
+	if m != nil {
+		return m.Suites
+	}
+	return nil
+}
+
+func (m *TestChurnResults) GetRatioThreshold() float32 {
+	if m != nil {
+		return m.RatioThreshold
+	}
+	return 0
+}
+
+func (m *TestChurnResults) GetMinCommits() int32 {
+	if m != nil {
+		return m.MinCommits
+	}
+	return 0
+}
+
+func (m *TestChurnResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+// Alive lines in each age bucket
+type CodeAgePyramidCounts struct {
+	Lines                []int64  `protobuf:"varint,1,rep,packed,name=lines,proto3" json:"lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeAgePyramidCounts) Reset()         { *m = CodeAgePyramidCounts{} }
+func (m *CodeAgePyramidCounts) String() string { return proto.CompactTextString(m) }
+func (*CodeAgePyramidCounts) ProtoMessage()    {}
+func (*CodeAgePyramidCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{71}
+}
+func (m *CodeAgePyramidCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeAgePyramidCounts.Unmarshal(m, b)
+}
+func (m *CodeAgePyramidCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeAgePyramidCounts.Marshal(b, m, deterministic)
+}
+func (m *CodeAgePyramidCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeAgePyramidCounts.Merge(m, src)
+}
+func (m *CodeAgePyramidCounts) XXX_Size() int {
+	return xxx_messageInfo_CodeAgePyramidCounts.Size(m)
+}
+func (m *CodeAgePyramidCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeAgePyramidCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeAgePyramidCounts proto.InternalMessageInfo
+
+func (m *CodeAgePyramidCounts) GetLines() []int64 {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+type CodeAgePyramidResults struct {
+	// tick of the snapshot
+	Tick int32 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	// names of the age buckets, from the youngest to the oldest
+	Buckets []string `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// alive lines per age bucket, keyed by subsystem (directory)
+	Subsystems map[string]*CodeAgePyramidCounts `protobuf:"bytes,3,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// alive lines per age bucket in the whole repository
+	Totals               []int64  `protobuf:"varint,4,rep,packed,name=totals,proto3" json:"totals,omitempty"`
+	TickSize             int64    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CodeAgePyramidResults) Reset()         { *m = CodeAgePyramidResults{} }
+func (m *CodeAgePyramidResults) String() string { return proto.CompactTextString(m) }
+func (*CodeAgePyramidResults) ProtoMessage()    {}
+func (*CodeAgePyramidResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{72}
+}
+func (m *CodeAgePyramidResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CodeAgePyramidResults.Unmarshal(m, b)
+}
+func (m *CodeAgePyramidResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CodeAgePyramidResults.Marshal(b, m, deterministic)
+}
+func (m *CodeAgePyramidResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeAgePyramidResults.Merge(m, src)
+}
+func (m *CodeAgePyramidResults) XXX_Size() int {
+	return xxx_messageInfo_CodeAgePyramidResults.Size(m)
+}
+func (m *CodeAgePyramidResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeAgePyramidResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeAgePyramidResults proto.InternalMessageInfo
+
+func (m *CodeAgePyramidResults) GetTick() int32 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+func (m *CodeAgePyramidResults) GetBuckets() []string {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
+func (m *CodeAgePyramidResults) GetSubsystems() map[string]*CodeAgePyramidCounts {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+func (m *CodeAgePyramidResults) GetTotals() []int64 {
+	if m != nil {
+		return m.Totals
+	}
+	return nil
+}
+
+func (m *CodeAgePyramidResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+// Added lines and those of them which were rewritten within the window
+type RewriteStats struct {
+	Added                int64    `protobuf:"varint,1,opt,name=added,proto3" json:"added,omitempty"`
+	Rewritten            int64    `protobuf:"varint,2,opt,name=rewritten,proto3" json:"rewritten,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewriteStats) Reset()         { *m = RewriteStats{} }
+func (m *RewriteStats) String() string { return proto.CompactTextString(m) }
+func (*RewriteStats) ProtoMessage()    {}
+func (*RewriteStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{73}
+}
+func (m *RewriteStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewriteStats.Unmarshal(m, b)
+}
+func (m *RewriteStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewriteStats.Marshal(b, m, deterministic)
+}
+func (m *RewriteStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewriteStats.Merge(m, src)
+}
+func (m *RewriteStats) XXX_Size() int {
+	return xxx_messageInfo_RewriteStats.Size(m)
+}
+func (m *RewriteStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewriteStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewriteStats proto.InternalMessageInfo
+
+func (m *RewriteStats) GetAdded() int64 {
+	if m != nil {
+		return m.Added
+	}
+	return 0
+}
+
+func (m *RewriteStats) GetRewritten() int64 {
+	if m != nil {
+		return m.Rewritten
+	}
+	return 0
+}
+
+type RewriteRatioResults struct {
+	// keyed by developer index
+	People map[int32]*RewriteStats `protobuf:"bytes,1,rep,name=people,proto3" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// keyed by subsystem (directory)
+	Subsystems map[string]*RewriteStats `protobuf:"bytes,2,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Total      *RewriteStats            `protobuf:"bytes,3,opt,name=total,proto3" json:"total,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	WindowDays           int32    `protobuf:"varint,5,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
+	TickSize             int64    `protobuf:"varint,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RewriteRatioResults) Reset()         { *m = RewriteRatioResults{} }
+func (m *RewriteRatioResults) String() string { return proto.CompactTextString(m) }
+func (*RewriteRatioResults) ProtoMessage()    {}
+func (*RewriteRatioResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{74}
+}
+func (m *RewriteRatioResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RewriteRatioResults.Unmarshal(m, b)
+}
+func (m *RewriteRatioResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RewriteRatioResults.Marshal(b, m, deterministic)
+}
+func (m *RewriteRatioResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RewriteRatioResults.Merge(m, src)
+}
+func (m *RewriteRatioResults) XXX_Size() int {
+	return xxx_messageInfo_RewriteRatioResults.Size(m)
+}
+func (m *RewriteRatioResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_RewriteRatioResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RewriteRatioResults proto.InternalMessageInfo
+
+func (m *RewriteRatioResults) GetPeople() map[int32]*RewriteStats {
+	if m != nil {
+		return m.People
+	}
+	return nil
+}
+
+func (m *RewriteRatioResults) GetSubsystems() map[string]*RewriteStats {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+func (m *RewriteRatioResults) GetTotal() *RewriteStats {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *RewriteRatioResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *RewriteRatioResults) GetWindowDays() int32 {
+	if m != nil {
+		return m.WindowDays
+	}
+	return 0
+}
+
+func (m *RewriteRatioResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type CrossTimezonePair struct {
+	Developer1 int32 `protobuf:"varint,1,opt,name=developer1,proto3" json:"developer1,omitempty"`
+	Developer2 int32 `protobuf:"varint,2,opt,name=developer2,proto3" json:"developer2,omitempty"`
+	// the distance between the UTC offsets of the developers on the 24h circle
+	GapHours float64 `protobuf:"fixed64,3,opt,name=gap_hours,json=gapHours,proto3" json:"gap_hours,omitempty"`
+	// the sum of min(commits) of both developers over the files they both changed
+	Coupling             int64    `protobuf:"varint,4,opt,name=coupling,proto3" json:"coupling,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossTimezonePair) Reset()         { *m = CrossTimezonePair{} }
+func (m *CrossTimezonePair) String() string { return proto.CompactTextString(m) }
+func (*CrossTimezonePair) ProtoMessage()    {}
+func (*CrossTimezonePair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{75}
+}
+func (m *CrossTimezonePair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossTimezonePair.Unmarshal(m, b)
+}
+func (m *CrossTimezonePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossTimezonePair.Marshal(b, m, deterministic)
+}
+func (m *CrossTimezonePair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossTimezonePair.Merge(m, src)
+}
+func (m *CrossTimezonePair) XXX_Size() int {
+	return xxx_messageInfo_CrossTimezonePair.Size(m)
+}
+func (m *CrossTimezonePair) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossTimezonePair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossTimezonePair proto.InternalMessageInfo
+
+func (m *CrossTimezonePair) GetDeveloper1() int32 {
+	if m != nil {
+		return m.Developer1
+	}
+	return 0
+}
+
+func (m *CrossTimezonePair) GetDeveloper2() int32 {
+	if m != nil {
+		return m.Developer2
+	}
+	return 0
+}
+
+func (m *CrossTimezonePair) GetGapHours() float64 {
+	if m != nil {
+		return m.GapHours
+	}
+	return 0
+}
+
+func (m *CrossTimezonePair) GetCoupling() int64 {
+	if m != nil {
+		return m.Coupling
+	}
+	return 0
+}
+
+type CrossTimezoneResults struct {
+	// the dominant UTC offset of each developer in minutes, keyed by developer index
+	Offsets       map[int32]int32      `protobuf:"bytes,1,rep,name=offsets,proto3" json:"offsets,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Pairs         []*CrossTimezonePair `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	TotalCoupling int64                `protobuf:"varint,3,opt,name=total_coupling,json=totalCoupling,proto3" json:"total_coupling,omitempty"`
+	// the coupling of the pairs with the gap greater than min_gap_hours
+	CrossCoupling int64 `protobuf:"varint,4,opt,name=cross_coupling,json=crossCoupling,proto3" json:"cross_coupling,omitempty"`
+	MinGapHours   int32 `protobuf:"varint,5,opt,name=min_gap_hours,json=minGapHours,proto3" json:"min_gap_hours,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,6,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossTimezoneResults) Reset()         { *m = CrossTimezoneResults{} }
+func (m *CrossTimezoneResults) String() string { return proto.CompactTextString(m) }
+func (*CrossTimezoneResults) ProtoMessage()    {}
+func (*CrossTimezoneResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{76}
+}
+func (m *CrossTimezoneResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CrossTimezoneResults.Unmarshal(m, b)
+}
+func (m *CrossTimezoneResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CrossTimezoneResults.Marshal(b, m, deterministic)
+}
+func (m *CrossTimezoneResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossTimezoneResults.Merge(m, src)
+}
+func (m *CrossTimezoneResults) XXX_Size() int {
+	return xxx_messageInfo_CrossTimezoneResults.Size(m)
+}
+func (m *CrossTimezoneResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossTimezoneResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossTimezoneResults proto.InternalMessageInfo
+
+func (m *CrossTimezoneResults) GetOffsets() map[int32]int32 {
+	if m != nil {
+		return m.Offsets
+	}
+	return nil
+}
+
+func (m *CrossTimezoneResults) GetPairs() []*CrossTimezonePair {
+	if m != nil {
+		return m.Pairs
+	}
+	return nil
+}
+
+func (m *CrossTimezoneResults) GetTotalCoupling() int64 {
+	if m != nil {
+		return m.TotalCoupling
+	}
+	return 0
+}
+
+func (m *CrossTimezoneResults) GetCrossCoupling() int64 {
+	if m != nil {
+		return m.CrossCoupling
+	}
+	return 0
+}
+
+func (m *CrossTimezoneResults) GetMinGapHours() int32 {
+	if m != nil {
+		return m.MinGapHours
+	}
+	return 0
+}
+
+func (m *CrossTimezoneResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+// Inclusive range of ticks
+type AbsencePeriod struct {
+	Begin                int32    `protobuf:"varint,1,opt,name=begin,proto3" json:"begin,omitempty"`
+	End                  int32    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AbsencePeriod) Reset()         { *m = AbsencePeriod{} }
+func (m *AbsencePeriod) String() string { return proto.CompactTextString(m) }
+func (*AbsencePeriod) ProtoMessage()    {}
+func (*AbsencePeriod) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{77}
+}
+func (m *AbsencePeriod) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AbsencePeriod.Unmarshal(m, b)
+}
+func (m *AbsencePeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AbsencePeriod.Marshal(b, m, deterministic)
+}
+func (m *AbsencePeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AbsencePeriod.Merge(m, src)
+}
+func (m *AbsencePeriod) XXX_Size() int {
+	return xxx_messageInfo_AbsencePeriod.Size(m)
+}
+func (m *AbsencePeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_AbsencePeriod.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AbsencePeriod proto.InternalMessageInfo
+
+func (m *AbsencePeriod) GetBegin() int32 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *AbsencePeriod) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type DeveloperAbsences struct {
+	// the median distance in ticks between the consecutive active ticks
+	Baseline             float64          `protobuf:"fixed64,1,opt,name=baseline,proto3" json:"baseline,omitempty"`
+	Periods              []*AbsencePeriod `protobuf:"bytes,2,rep,name=periods,proto3" json:"periods,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *DeveloperAbsences) Reset()         { *m = DeveloperAbsences{} }
+func (m *DeveloperAbsences) String() string { return proto.CompactTextString(m) }
+func (*DeveloperAbsences) ProtoMessage()    {}
+func (*DeveloperAbsences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{78}
+}
+func (m *DeveloperAbsences) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeveloperAbsences.Unmarshal(m, b)
+}
+func (m *DeveloperAbsences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeveloperAbsences.Marshal(b, m, deterministic)
+}
+func (m *DeveloperAbsences) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeveloperAbsences.Merge(m, src)
+}
+func (m *DeveloperAbsences) XXX_Size() int {
+	return xxx_messageInfo_DeveloperAbsences.Size(m)
+}
+func (m *DeveloperAbsences) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeveloperAbsences.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeveloperAbsences proto.InternalMessageInfo
+
+func (m *DeveloperAbsences) GetBaseline() float64 {
+	if m != nil {
+		return m.Baseline
+	}
+	return 0
+}
+
+func (m *DeveloperAbsences) GetPeriods() []*AbsencePeriod {
+	if m != nil {
+		return m.Periods
+	}
+	return nil
+}
+
+// Absence of the only owner of a subsystem
+type CoverageGap struct {
+	Subsystem            string   `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	Owner                int32    `protobuf:"varint,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Begin                int32    `protobuf:"varint,3,opt,name=begin,proto3" json:"begin,omitempty"`
+	End                  int32    `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CoverageGap) Reset()         { *m = CoverageGap{} }
+func (m *CoverageGap) String() string { return proto.CompactTextString(m) }
+func (*CoverageGap) ProtoMessage()    {}
+func (*CoverageGap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{79}
+}
+func (m *CoverageGap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CoverageGap.Unmarshal(m, b)
+}
+func (m *CoverageGap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CoverageGap.Marshal(b, m, deterministic)
+}
+func (m *CoverageGap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CoverageGap.Merge(m, src)
+}
+func (m *CoverageGap) XXX_Size() int {
+	return xxx_messageInfo_CoverageGap.Size(m)
+}
+func (m *CoverageGap) XXX_DiscardUnknown() {
+	xxx_messageInfo_CoverageGap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CoverageGap proto.InternalMessageInfo
+
+func (m *CoverageGap) GetSubsystem() string {
+	if m != nil {
+		return m.Subsystem
+	}
+	return ""
+}
+
+func (m *CoverageGap) GetOwner() int32 {
+	if m != nil {
+		return m.Owner
+	}
+	return 0
+}
+
+func (m *CoverageGap) GetBegin() int32 {
+	if m != nil {
+		return m.Begin
+	}
+	return 0
+}
+
+func (m *CoverageGap) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+type AbsenceResults struct {
+	// keyed by developer index
+	Developers map[int32]*DeveloperAbsences `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the owners of the single-owner subsystems (directories)
+	Owners             map[string]int32 `protobuf:"bytes,2,rep,name=owners,proto3" json:"owners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	CoverageGaps       []*CoverageGap   `protobuf:"bytes,3,rep,name=coverage_gaps,json=coverageGaps,proto3" json:"coverage_gaps,omitempty"`
+	OwnershipThreshold float32          `protobuf:"fixed32,4,opt,name=ownership_threshold,json=ownershipThreshold,proto3" json:"ownership_threshold,omitempty"`
+	TickSize           int64            `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,6,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AbsenceResults) Reset()         { *m = AbsenceResults{} }
+func (m *AbsenceResults) String() string { return proto.CompactTextString(m) }
+func (*AbsenceResults) ProtoMessage()    {}
+func (*AbsenceResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{80}
+}
+func (m *AbsenceResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AbsenceResults.Unmarshal(m, b)
+}
+func (m *AbsenceResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AbsenceResults.Marshal(b, m, deterministic)
+}
+func (m *AbsenceResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AbsenceResults.Merge(m, src)
+}
+func (m *AbsenceResults) XXX_Size() int {
+	return xxx_messageInfo_AbsenceResults.Size(m)
+}
+func (m *AbsenceResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_AbsenceResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AbsenceResults proto.InternalMessageInfo
+
+func (m *AbsenceResults) GetDevelopers() map[int32]*DeveloperAbsences {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+func (m *AbsenceResults) GetOwners() map[string]int32 {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *AbsenceResults) GetCoverageGaps() []*CoverageGap {
+	if m != nil {
+		return m.CoverageGaps
+	}
+	return nil
+}
+
+func (m *AbsenceResults) GetOwnershipThreshold() float32 {
+	if m != nil {
+		return m.OwnershipThreshold
+	}
+	return 0
+}
+
+func (m *AbsenceResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+func (m *AbsenceResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+type DiversityQuarter struct {
+	// the number of commits in the quarter, keyed by developer index
+	Commits              map[int32]int32 `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *DiversityQuarter) Reset()         { *m = DiversityQuarter{} }
+func (m *DiversityQuarter) String() string { return proto.CompactTextString(m) }
+func (*DiversityQuarter) ProtoMessage()    {}
+func (*DiversityQuarter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{81}
+}
+func (m *DiversityQuarter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DiversityQuarter.Unmarshal(m, b)
+}
+func (m *DiversityQuarter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DiversityQuarter.Marshal(b, m, deterministic)
+}
+func (m *DiversityQuarter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DiversityQuarter.Merge(m, src)
+}
+func (m *DiversityQuarter) XXX_Size() int {
+	return xxx_messageInfo_DiversityQuarter.Size(m)
+}
+func (m *DiversityQuarter) XXX_DiscardUnknown() {
+	xxx_messageInfo_DiversityQuarter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DiversityQuarter proto.InternalMessageInfo
+
+func (m *DiversityQuarter) GetCommits() map[int32]int32 {
+	if m != nil {
+		return m.Commits
+	}
+	return nil
+}
+
+type ContributionDiversityResults struct {
+	// keyed by year * 4 + quarter - 1, e.g. 8097 is 2024-Q2
+	Quarters map[int32]*DiversityQuarter `protobuf:"bytes,1,rep,name=quarters,proto3" json:"quarters,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// organizations of the developers, parallel to dev_index
+	Organizations []string `protobuf:"bytes,3,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	// the organizations whose developers are not external contributors
+	InternalOrganizations []string `protobuf:"bytes,4,rep,name=internal_organizations,json=internalOrganizations,proto3" json:"internal_organizations,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *ContributionDiversityResults) Reset()         { *m = ContributionDiversityResults{} }
+func (m *ContributionDiversityResults) String() string { return proto.CompactTextString(m) }
+func (*ContributionDiversityResults) ProtoMessage()    {}
+func (*ContributionDiversityResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{82}
+}
+func (m *ContributionDiversityResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContributionDiversityResults.Unmarshal(m, b)
+}
+func (m *ContributionDiversityResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContributionDiversityResults.Marshal(b, m, deterministic)
+}
+func (m *ContributionDiversityResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContributionDiversityResults.Merge(m, src)
+}
+func (m *ContributionDiversityResults) XXX_Size() int {
+	return xxx_messageInfo_ContributionDiversityResults.Size(m)
+}
+func (m *ContributionDiversityResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContributionDiversityResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContributionDiversityResults proto.InternalMessageInfo
+
+func (m *ContributionDiversityResults) GetQuarters() map[int32]*DiversityQuarter {
+	if m != nil {
+		return m.Quarters
+	}
+	return nil
+}
+
+func (m *ContributionDiversityResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *ContributionDiversityResults) GetOrganizations() []string {
+	if m != nil {
+		return m.Organizations
+	}
+	return nil
+}
+
+func (m *ContributionDiversityResults) GetInternalOrganizations() []string {
+	if m != nil {
+		return m.InternalOrganizations
+	}
+	return nil
+}
+
+type FunnelContributions struct {
+	// Unix times of the first contributions in the ascending order, up to the largest milestone
+	Times                []int64  `protobuf:"varint,1,rep,packed,name=times,proto3" json:"times,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FunnelContributions) Reset()         { *m = FunnelContributions{} }
+func (m *FunnelContributions) String() string { return proto.CompactTextString(m) }
+func (*FunnelContributions) ProtoMessage()    {}
+func (*FunnelContributions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{83}
+}
+func (m *FunnelContributions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FunnelContributions.Unmarshal(m, b)
+}
+func (m *FunnelContributions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FunnelContributions.Marshal(b, m, deterministic)
+}
+func (m *FunnelContributions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FunnelContributions.Merge(m, src)
+}
+func (m *FunnelContributions) XXX_Size() int {
+	return xxx_messageInfo_FunnelContributions.Size(m)
+}
+func (m *FunnelContributions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FunnelContributions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FunnelContributions proto.InternalMessageInfo
+
+func (m *FunnelContributions) GetTimes() []int64 {
+	if m != nil {
+		return m.Times
+	}
+	return nil
+}
+
+type ContributionFunnelResults struct {
+	// the numbers of contributions which make the funnel steps, e.g. 2, 5, 10
+	Milestones []int32 `protobuf:"varint,1,rep,packed,name=milestones,proto3" json:"milestones,omitempty"`
+	// the first contributions keyed by developer index
+	Contributions map[int32]*FunnelContributions `protobuf:"bytes,2,rep,name=contributions,proto3" json:"contributions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// organizations of the developers, parallel to dev_index
+	Organizations []string `protobuf:"bytes,4,rep,name=organizations,proto3" json:"organizations,omitempty"`
+	// the organizations whose developers are not external contributors
+	InternalOrganizations []string `protobuf:"bytes,5,rep,name=internal_organizations,json=internalOrganizations,proto3" json:"internal_organizations,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
+}
+
+func (m *ContributionFunnelResults) Reset()         { *m = ContributionFunnelResults{} }
+func (m *ContributionFunnelResults) String() string { return proto.CompactTextString(m) }
+func (*ContributionFunnelResults) ProtoMessage()    {}
+func (*ContributionFunnelResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{84}
+}
+func (m *ContributionFunnelResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContributionFunnelResults.Unmarshal(m, b)
+}
+func (m *ContributionFunnelResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContributionFunnelResults.Marshal(b, m, deterministic)
+}
+func (m *ContributionFunnelResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContributionFunnelResults.Merge(m, src)
+}
+func (m *ContributionFunnelResults) XXX_Size() int {
+	return xxx_messageInfo_ContributionFunnelResults.Size(m)
+}
+func (m *ContributionFunnelResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContributionFunnelResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContributionFunnelResults proto.InternalMessageInfo
+
+func (m *ContributionFunnelResults) GetMilestones() []int32 {
+	if m != nil {
+		return m.Milestones
+	}
+	return nil
+}
+
+func (m *ContributionFunnelResults) GetContributions() map[int32]*FunnelContributions {
+	if m != nil {
+		return m.Contributions
+	}
+	return nil
+}
+
+func (m *ContributionFunnelResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *ContributionFunnelResults) GetOrganizations() []string {
+	if m != nil {
+		return m.Organizations
+	}
+	return nil
+}
+
+func (m *ContributionFunnelResults) GetInternalOrganizations() []string {
+	if m != nil {
+		return m.InternalOrganizations
+	}
+	return nil
+}
+
+type SelfMergeCounts struct {
+	// the number of changes which landed on the main line
+	Changes int32 `protobuf:"varint,1,opt,name=changes,proto3" json:"changes,omitempty"`
+	// the changes which nobody except the author merged or approved
+	SelfMerged int32 `protobuf:"varint,2,opt,name=self_merged,json=selfMerged,proto3" json:"self_merged,omitempty"`
+	// the changes which exactly one other developer merged or approved
+	SingleApprover int32 `protobuf:"varint,3,opt,name=single_approver,json=singleApprover,proto3" json:"single_approver,omitempty"`
+	// the changes merged by somebody who is not a known developer, e.g. a bot
+	Unknown              int32    `protobuf:"varint,4,opt,name=unknown,proto3" json:"unknown,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SelfMergeCounts) Reset()         { *m = SelfMergeCounts{} }
+func (m *SelfMergeCounts) String() string { return proto.CompactTextString(m) }
+func (*SelfMergeCounts) ProtoMessage()    {}
+func (*SelfMergeCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{85}
+}
+func (m *SelfMergeCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfMergeCounts.Unmarshal(m, b)
+}
+func (m *SelfMergeCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfMergeCounts.Marshal(b, m, deterministic)
+}
+func (m *SelfMergeCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfMergeCounts.Merge(m, src)
+}
+func (m *SelfMergeCounts) XXX_Size() int {
+	return xxx_messageInfo_SelfMergeCounts.Size(m)
+}
+func (m *SelfMergeCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfMergeCounts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfMergeCounts proto.InternalMessageInfo
+
+func (m *SelfMergeCounts) GetChanges() int32 {
+	if m != nil {
+		return m.Changes
+	}
+	return 0
+}
+
+func (m *SelfMergeCounts) GetSelfMerged() int32 {
+	if m != nil {
+		return m.SelfMerged
+	}
+	return 0
+}
+
+func (m *SelfMergeCounts) GetSingleApprover() int32 {
+	if m != nil {
+		return m.SingleApprover
+	}
+	return 0
+}
+
+func (m *SelfMergeCounts) GetUnknown() int32 {
+	if m != nil {
+		return m.Unknown
+	}
+	return 0
+}
+
+type SelfMergeResults struct {
+	Total *SelfMergeCounts `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	// keyed by "YYYY-MM"
+	Months map[string]*SelfMergeCounts `protobuf:"bytes,2,rep,name=months,proto3" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// keyed by the directory
+	Subsystems           map[string]*SelfMergeCounts `protobuf:"bytes,3,rep,name=subsystems,proto3" json:"subsystems,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *SelfMergeResults) Reset()         { *m = SelfMergeResults{} }
+func (m *SelfMergeResults) String() string { return proto.CompactTextString(m) }
+func (*SelfMergeResults) ProtoMessage()    {}
+func (*SelfMergeResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{86}
+}
+func (m *SelfMergeResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SelfMergeResults.Unmarshal(m, b)
+}
+func (m *SelfMergeResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SelfMergeResults.Marshal(b, m, deterministic)
+}
+func (m *SelfMergeResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SelfMergeResults.Merge(m, src)
+}
+func (m *SelfMergeResults) XXX_Size() int {
+	return xxx_messageInfo_SelfMergeResults.Size(m)
+}
+func (m *SelfMergeResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_SelfMergeResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SelfMergeResults proto.InternalMessageInfo
+
+func (m *SelfMergeResults) GetTotal() *SelfMergeCounts {
+	if m != nil {
+		return m.Total
+	}
+	return nil
+}
+
+func (m *SelfMergeResults) GetMonths() map[string]*SelfMergeCounts {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+func (m *SelfMergeResults) GetSubsystems() map[string]*SelfMergeCounts {
+	if m != nil {
+		return m.Subsystems
+	}
+	return nil
+}
+
+type WorkingSet struct {
+	// indexes in WorkingSetOverlapResults.files of the touched files
+	Files                []int32  `protobuf:"varint,1,rep,packed,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkingSet) Reset()         { *m = WorkingSet{} }
+func (m *WorkingSet) String() string { return proto.CompactTextString(m) }
+func (*WorkingSet) ProtoMessage()    {}
+func (*WorkingSet) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{87}
+}
+func (m *WorkingSet) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkingSet.Unmarshal(m, b)
+}
+func (m *WorkingSet) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkingSet.Marshal(b, m, deterministic)
+}
+func (m *WorkingSet) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkingSet.Merge(m, src)
+}
+func (m *WorkingSet) XXX_Size() int {
+	return xxx_messageInfo_WorkingSet.Size(m)
+}
+func (m *WorkingSet) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkingSet.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkingSet proto.InternalMessageInfo
+
+func (m *WorkingSet) GetFiles() []int32 {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type MonthlyWorkingSets struct {
+	// keyed by developer index
+	Developers           map[int32]*WorkingSet `protobuf:"bytes,1,rep,name=developers,proto3" json:"developers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *MonthlyWorkingSets) Reset()         { *m = MonthlyWorkingSets{} }
+func (m *MonthlyWorkingSets) String() string { return proto.CompactTextString(m) }
+func (*MonthlyWorkingSets) ProtoMessage()    {}
+func (*MonthlyWorkingSets) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{88}
+}
+func (m *MonthlyWorkingSets) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MonthlyWorkingSets.Unmarshal(m, b)
+}
+func (m *MonthlyWorkingSets) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MonthlyWorkingSets.Marshal(b, m, deterministic)
+}
+func (m *MonthlyWorkingSets) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MonthlyWorkingSets.Merge(m, src)
+}
+func (m *MonthlyWorkingSets) XXX_Size() int {
+	return xxx_messageInfo_MonthlyWorkingSets.Size(m)
+}
+func (m *MonthlyWorkingSets) XXX_DiscardUnknown() {
+	xxx_messageInfo_MonthlyWorkingSets.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MonthlyWorkingSets proto.InternalMessageInfo
+
+func (m *MonthlyWorkingSets) GetDevelopers() map[int32]*WorkingSet {
+	if m != nil {
+		return m.Developers
+	}
+	return nil
+}
+
+type WorkingSetOverlapResults struct {
+	// keyed by "YYYY-MM"
+	Months map[string]*MonthlyWorkingSets `protobuf:"bytes,1,rep,name=months,proto3" json:"months,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the touched files referenced by WorkingSet.files
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// developer identities
+	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	// the number of the reported pairs and subsystems, 0 means all
+	Top                  int32    `protobuf:"varint,4,opt,name=top,proto3" json:"top,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WorkingSetOverlapResults) Reset()         { *m = WorkingSetOverlapResults{} }
+func (m *WorkingSetOverlapResults) String() string { return proto.CompactTextString(m) }
+func (*WorkingSetOverlapResults) ProtoMessage()    {}
+func (*WorkingSetOverlapResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{89}
+}
+func (m *WorkingSetOverlapResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WorkingSetOverlapResults.Unmarshal(m, b)
+}
+func (m *WorkingSetOverlapResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WorkingSetOverlapResults.Marshal(b, m, deterministic)
+}
+func (m *WorkingSetOverlapResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WorkingSetOverlapResults.Merge(m, src)
+}
+func (m *WorkingSetOverlapResults) XXX_Size() int {
+	return xxx_messageInfo_WorkingSetOverlapResults.Size(m)
+}
+func (m *WorkingSetOverlapResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_WorkingSetOverlapResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WorkingSetOverlapResults proto.InternalMessageInfo
+
+func (m *WorkingSetOverlapResults) GetMonths() map[string]*MonthlyWorkingSets {
+	if m != nil {
+		return m.Months
+	}
+	return nil
+}
+
+func (m *WorkingSetOverlapResults) GetFiles() []string {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *WorkingSetOverlapResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *WorkingSetOverlapResults) GetTop() int32 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
 }
-func (m *BusFactorTickSnapshot) XXX_DiscardUnknown() {
-	xxx_messageInfo_BusFactorTickSnapshot.DiscardUnknown(m)
+
+// Run of consecutive lines written by the same author at the same tick
+type BlameSegment struct {
+	// zero-based index of the first line
+	Line int32 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// number of lines
+	Lines int32 `protobuf:"varint,2,opt,name=lines,proto3" json:"lines,omitempty"`
+	// index in dev_index, -1 if unknown
+	Author               int32    `protobuf:"varint,3,opt,name=author,proto3" json:"author,omitempty"`
+	Tick                 int32    `protobuf:"varint,4,opt,name=tick,proto3" json:"tick,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-var xxx_messageInfo_BusFactorTickSnapshot proto.InternalMessageInfo
+func (m *BlameSegment) Reset()         { *m = BlameSegment{} }
+func (m *BlameSegment) String() string { return proto.CompactTextString(m) }
+func (*BlameSegment) ProtoMessage()    {}
+func (*BlameSegment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{90}
+}
+func (m *BlameSegment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlameSegment.Unmarshal(m, b)
+}
+func (m *BlameSegment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlameSegment.Marshal(b, m, deterministic)
+}
+func (m *BlameSegment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlameSegment.Merge(m, src)
+}
+func (m *BlameSegment) XXX_Size() int {
+	return xxx_messageInfo_BlameSegment.Size(m)
+}
+func (m *BlameSegment) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlameSegment.DiscardUnknown(m)
+}
 
-func (m *BusFactorTickSnapshot) GetBusFactor() int32 {
+var xxx_messageInfo_BlameSegment proto.InternalMessageInfo
+
+func (m *BlameSegment) GetLine() int32 {
 	if m != nil {
-		return m.BusFactor
+		return m.Line
 	}
 	return 0
 }
 
-func (m *BusFactorTickSnapshot) GetTotalLines() int64 {
+func (m *BlameSegment) GetLines() int32 {
 	if m != nil {
-		return m.TotalLines
+		return m.Lines
 	}
 	return 0
 }
 
-func (m *BusFactorTickSnapshot) GetAuthorLines() map[int32]int64 {
+func (m *BlameSegment) GetAuthor() int32 {
 	if m != nil {
-		return m.AuthorLines
+		return m.Author
+	}
+	return 0
+}
+
+func (m *BlameSegment) GetTick() int32 {
+	if m != nil {
+		return m.Tick
+	}
+	return 0
+}
+
+type BlameFile struct {
+	// sorted by line
+	Segments             []*BlameSegment `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BlameFile) Reset()         { *m = BlameFile{} }
+func (m *BlameFile) String() string { return proto.CompactTextString(m) }
+func (*BlameFile) ProtoMessage()    {}
+func (*BlameFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{91}
+}
+func (m *BlameFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlameFile.Unmarshal(m, b)
+}
+func (m *BlameFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlameFile.Marshal(b, m, deterministic)
+}
+func (m *BlameFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlameFile.Merge(m, src)
+}
+func (m *BlameFile) XXX_Size() int {
+	return xxx_messageInfo_BlameFile.Size(m)
+}
+func (m *BlameFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlameFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlameFile proto.InternalMessageInfo
+
+func (m *BlameFile) GetSegments() []*BlameSegment {
+	if m != nil {
+		return m.Segments
 	}
 	return nil
 }
 
-type BusFactorAnalysisResults struct {
-	// bus factor value per tick (index = tick number)
-	Snapshots map[int32]*BusFactorTickSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// bus factor per directory/subsystem prefix
-	SubsystemBusFactor map[string]int32 `protobuf:"bytes,2,rep,name=subsystem_bus_factor,json=subsystemBusFactor,proto3" json:"subsystem_bus_factor,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+type BlameDumperResults struct {
+	// keyed by file name
+	Files map[string]*BlameFile `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// developer identities
-	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
-	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize int64 `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
-	// threshold used (e.g. 0.8 for 80%)
-	Threshold            float32  `protobuf:"fixed32,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	DevIndex             []string `protobuf:"bytes,2,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	TickSize             int64    `protobuf:"varint,3,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *BusFactorAnalysisResults) Reset()         { *m = BusFactorAnalysisResults{} }
-func (m *BusFactorAnalysisResults) String() string { return proto.CompactTextString(m) }
-func (*BusFactorAnalysisResults) ProtoMessage()    {}
-func (*BusFactorAnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{34}
+func (m *BlameDumperResults) Reset()         { *m = BlameDumperResults{} }
+func (m *BlameDumperResults) String() string { return proto.CompactTextString(m) }
+func (*BlameDumperResults) ProtoMessage()    {}
+func (*BlameDumperResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{92}
 }
-func (m *BusFactorAnalysisResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BusFactorAnalysisResults.Unmarshal(m, b)
+func (m *BlameDumperResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlameDumperResults.Unmarshal(m, b)
 }
-func (m *BusFactorAnalysisResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BusFactorAnalysisResults.Marshal(b, m, deterministic)
+func (m *BlameDumperResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlameDumperResults.Marshal(b, m, deterministic)
 }
-func (m *BusFactorAnalysisResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BusFactorAnalysisResults.Merge(m, src)
+func (m *BlameDumperResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlameDumperResults.Merge(m, src)
 }
-func (m *BusFactorAnalysisResults) XXX_Size() int {
-	return xxx_messageInfo_BusFactorAnalysisResults.Size(m)
+func (m *BlameDumperResults) XXX_Size() int {
+	return xxx_messageInfo_BlameDumperResults.Size(m)
 }
-func (m *BusFactorAnalysisResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_BusFactorAnalysisResults.DiscardUnknown(m)
+func (m *BlameDumperResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlameDumperResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_BusFactorAnalysisResults proto.InternalMessageInfo
+var xxx_messageInfo_BlameDumperResults proto.InternalMessageInfo
 
-func (m *BusFactorAnalysisResults) GetSnapshots() map[int32]*BusFactorTickSnapshot {
+func (m *BlameDumperResults) GetFiles() map[string]*BlameFile {
 	if m != nil {
-		return m.Snapshots
+		return m.Files
 	}
 	return nil
 }
 
-func (m *BusFactorAnalysisResults) GetSubsystemBusFactor() map[string]int32 {
+func (m *BlameDumperResults) GetDevIndex() []string {
 	if m != nil {
-		return m.SubsystemBusFactor
+		return m.DevIndex
 	}
 	return nil
 }
 
-func (m *BusFactorAnalysisResults) GetDevIndex() []string {
+func (m *BlameDumperResults) GetTickSize() int64 {
 	if m != nil {
-		return m.DevIndex
+		return m.TickSize
 	}
-	return nil
+	return 0
 }
 
-func (m *BusFactorAnalysisResults) GetTickSize() int64 {
+type LineHistoryChange struct {
+	FileId int32 `protobuf:"varint,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// the owner of the lines before the change, equal to curr_* for the insertions
+	PrevAuthor int32 `protobuf:"varint,2,opt,name=prev_author,json=prevAuthor,proto3" json:"prev_author,omitempty"`
+	PrevTick   int32 `protobuf:"varint,3,opt,name=prev_tick,json=prevTick,proto3" json:"prev_tick,omitempty"`
+	// who changed the lines and when
+	CurrAuthor int32 `protobuf:"varint,4,opt,name=curr_author,json=currAuthor,proto3" json:"curr_author,omitempty"`
+	CurrTick   int32 `protobuf:"varint,5,opt,name=curr_tick,json=currTick,proto3" json:"curr_tick,omitempty"`
+	// the number of inserted (positive) or removed (negative) lines,
+	// the minimal int64 means that the file was deleted
+	Delta int64 `protobuf:"varint,6,opt,name=delta,proto3" json:"delta,omitempty"`
+	// the kind of the lines: 0 - code, 1 - comments, 2 - blank, see `--classify-lines`
+	Kind                 int32    `protobuf:"varint,7,opt,name=kind,proto3" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LineHistoryChange) Reset()         { *m = LineHistoryChange{} }
+func (m *LineHistoryChange) String() string { return proto.CompactTextString(m) }
+func (*LineHistoryChange) ProtoMessage()    {}
+func (*LineHistoryChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{93}
+}
+func (m *LineHistoryChange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineHistoryChange.Unmarshal(m, b)
+}
+func (m *LineHistoryChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineHistoryChange.Marshal(b, m, deterministic)
+}
+func (m *LineHistoryChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineHistoryChange.Merge(m, src)
+}
+func (m *LineHistoryChange) XXX_Size() int {
+	return xxx_messageInfo_LineHistoryChange.Size(m)
+}
+func (m *LineHistoryChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineHistoryChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LineHistoryChange proto.InternalMessageInfo
+
+func (m *LineHistoryChange) GetFileId() int32 {
 	if m != nil {
-		return m.TickSize
+		return m.FileId
 	}
 	return 0
 }
 
-func (m *BusFactorAnalysisResults) GetThreshold() float32 {
+func (m *LineHistoryChange) GetPrevAuthor() int32 {
 	if m != nil {
-		return m.Threshold
+		return m.PrevAuthor
 	}
 	return 0
 }
 
-// Per-tick ownership concentration snapshot
-type OwnershipConcentrationTickSnapshot struct {
-	// Gini coefficient (0 = perfectly equal, 1 = one person owns everything)
-	Gini float64 `protobuf:"fixed64,1,opt,name=gini,proto3" json:"gini,omitempty"`
-	// Herfindahl-Hirschman Index (1/n = perfectly equal, 1.0 = single author)
-	Hhi float64 `protobuf:"fixed64,2,opt,name=hhi,proto3" json:"hhi,omitempty"`
-	// total alive lines at this tick
-	TotalLines int64 `protobuf:"varint,3,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
-	// per-author alive line counts at this tick, keyed by author index
-	AuthorLines          map[int32]int64 `protobuf:"bytes,4,rep,name=author_lines,json=authorLines,proto3" json:"author_lines,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+func (m *LineHistoryChange) GetPrevTick() int32 {
+	if m != nil {
+		return m.PrevTick
+	}
+	return 0
 }
 
-func (m *OwnershipConcentrationTickSnapshot) Reset()         { *m = OwnershipConcentrationTickSnapshot{} }
-func (m *OwnershipConcentrationTickSnapshot) String() string { return proto.CompactTextString(m) }
-func (*OwnershipConcentrationTickSnapshot) ProtoMessage()    {}
-func (*OwnershipConcentrationTickSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{35}
+func (m *LineHistoryChange) GetCurrAuthor() int32 {
+	if m != nil {
+		return m.CurrAuthor
+	}
+	return 0
 }
-func (m *OwnershipConcentrationTickSnapshot) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Unmarshal(m, b)
+
+func (m *LineHistoryChange) GetCurrTick() int32 {
+	if m != nil {
+		return m.CurrTick
+	}
+	return 0
 }
-func (m *OwnershipConcentrationTickSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Marshal(b, m, deterministic)
+
+func (m *LineHistoryChange) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
 }
-func (m *OwnershipConcentrationTickSnapshot) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OwnershipConcentrationTickSnapshot.Merge(m, src)
+
+func (m *LineHistoryChange) GetKind() int32 {
+	if m != nil {
+		return m.Kind
+	}
+	return 0
 }
-func (m *OwnershipConcentrationTickSnapshot) XXX_Size() int {
-	return xxx_messageInfo_OwnershipConcentrationTickSnapshot.Size(m)
+
+type LineHistoryCommit struct {
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// the author time
+	WhenUnixTime int64                `protobuf:"varint,2,opt,name=when_unix_time,json=whenUnixTime,proto3" json:"when_unix_time,omitempty"`
+	Tick         int32                `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Author       int32                `protobuf:"varint,4,opt,name=author,proto3" json:"author,omitempty"`
+	Changes      []*LineHistoryChange `protobuf:"bytes,5,rep,name=changes,proto3" json:"changes,omitempty"`
+	// the names of the changed files after the commit, keyed by file_id
+	Names                map[int32]string `protobuf:"bytes,6,rep,name=names,proto3" json:"names,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
-func (m *OwnershipConcentrationTickSnapshot) XXX_DiscardUnknown() {
-	xxx_messageInfo_OwnershipConcentrationTickSnapshot.DiscardUnknown(m)
+
+func (m *LineHistoryCommit) Reset()         { *m = LineHistoryCommit{} }
+func (m *LineHistoryCommit) String() string { return proto.CompactTextString(m) }
+func (*LineHistoryCommit) ProtoMessage()    {}
+func (*LineHistoryCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{94}
+}
+func (m *LineHistoryCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineHistoryCommit.Unmarshal(m, b)
+}
+func (m *LineHistoryCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineHistoryCommit.Marshal(b, m, deterministic)
+}
+func (m *LineHistoryCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineHistoryCommit.Merge(m, src)
+}
+func (m *LineHistoryCommit) XXX_Size() int {
+	return xxx_messageInfo_LineHistoryCommit.Size(m)
+}
+func (m *LineHistoryCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineHistoryCommit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OwnershipConcentrationTickSnapshot proto.InternalMessageInfo
+var xxx_messageInfo_LineHistoryCommit proto.InternalMessageInfo
 
-func (m *OwnershipConcentrationTickSnapshot) GetGini() float64 {
+func (m *LineHistoryCommit) GetHash() string {
 	if m != nil {
-		return m.Gini
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *LineHistoryCommit) GetWhenUnixTime() int64 {
+	if m != nil {
+		return m.WhenUnixTime
 	}
 	return 0
 }
 
-func (m *OwnershipConcentrationTickSnapshot) GetHhi() float64 {
+func (m *LineHistoryCommit) GetTick() int32 {
 	if m != nil {
-		return m.Hhi
+		return m.Tick
 	}
 	return 0
 }
 
-func (m *OwnershipConcentrationTickSnapshot) GetTotalLines() int64 {
+func (m *LineHistoryCommit) GetAuthor() int32 {
 	if m != nil {
-		return m.TotalLines
+		return m.Author
 	}
 	return 0
 }
 
-func (m *OwnershipConcentrationTickSnapshot) GetAuthorLines() map[int32]int64 {
+func (m *LineHistoryCommit) GetChanges() []*LineHistoryChange {
 	if m != nil {
-		return m.AuthorLines
+		return m.Changes
 	}
 	return nil
 }
 
-type OwnershipConcentrationResults struct {
-	// concentration metrics per tick (index = tick number)
-	Snapshots map[int32]*OwnershipConcentrationTickSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// per-directory/subsystem Gini coefficient at final tick
-	SubsystemGini map[string]float64 `protobuf:"bytes,2,rep,name=subsystem_gini,json=subsystemGini,proto3" json:"subsystem_gini,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
-	// per-directory/subsystem HHI at final tick
-	SubsystemHhi map[string]float64 `protobuf:"bytes,5,rep,name=subsystem_hhi,json=subsystemHhi,proto3" json:"subsystem_hhi,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
-	// developer identities
-	DevIndex []string `protobuf:"bytes,3,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
-	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,4,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+func (m *LineHistoryCommit) GetNames() map[int32]string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type LineHistoryDumpResults struct {
+	// the version of the format, incremented on the incompatible changes
+	Version int32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// the commits in the order of the analysis
+	Commits []*LineHistoryCommit `protobuf:"bytes,2,rep,name=commits,proto3" json:"commits,omitempty"`
+	// the names of the files which exist after the last commit, keyed by file_id
+	Files map[int32]string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer identities, the indexes correspond to the authors
+	DevIndex             []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	TickSize             int64    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OwnershipConcentrationResults) Reset()         { *m = OwnershipConcentrationResults{} }
-func (m *OwnershipConcentrationResults) String() string { return proto.CompactTextString(m) }
-func (*OwnershipConcentrationResults) ProtoMessage()    {}
-func (*OwnershipConcentrationResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{36}
+func (m *LineHistoryDumpResults) Reset()         { *m = LineHistoryDumpResults{} }
+func (m *LineHistoryDumpResults) String() string { return proto.CompactTextString(m) }
+func (*LineHistoryDumpResults) ProtoMessage()    {}
+func (*LineHistoryDumpResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{95}
 }
-func (m *OwnershipConcentrationResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OwnershipConcentrationResults.Unmarshal(m, b)
+func (m *LineHistoryDumpResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LineHistoryDumpResults.Unmarshal(m, b)
 }
-func (m *OwnershipConcentrationResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OwnershipConcentrationResults.Marshal(b, m, deterministic)
+func (m *LineHistoryDumpResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LineHistoryDumpResults.Marshal(b, m, deterministic)
 }
-func (m *OwnershipConcentrationResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OwnershipConcentrationResults.Merge(m, src)
+func (m *LineHistoryDumpResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LineHistoryDumpResults.Merge(m, src)
 }
-func (m *OwnershipConcentrationResults) XXX_Size() int {
-	return xxx_messageInfo_OwnershipConcentrationResults.Size(m)
+func (m *LineHistoryDumpResults) XXX_Size() int {
+	return xxx_messageInfo_LineHistoryDumpResults.Size(m)
 }
-func (m *OwnershipConcentrationResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_OwnershipConcentrationResults.DiscardUnknown(m)
+func (m *LineHistoryDumpResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_LineHistoryDumpResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OwnershipConcentrationResults proto.InternalMessageInfo
+var xxx_messageInfo_LineHistoryDumpResults proto.InternalMessageInfo
 
-func (m *OwnershipConcentrationResults) GetSnapshots() map[int32]*OwnershipConcentrationTickSnapshot {
+func (m *LineHistoryDumpResults) GetVersion() int32 {
 	if m != nil {
-		return m.Snapshots
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-func (m *OwnershipConcentrationResults) GetSubsystemGini() map[string]float64 {
+func (m *LineHistoryDumpResults) GetCommits() []*LineHistoryCommit {
 	if m != nil {
-		return m.SubsystemGini
+		return m.Commits
 	}
 	return nil
 }
 
-func (m *OwnershipConcentrationResults) GetSubsystemHhi() map[string]float64 {
+func (m *LineHistoryDumpResults) GetFiles() map[int32]string {
 	if m != nil {
-		return m.SubsystemHhi
+		return m.Files
 	}
 	return nil
 }
 
-func (m *OwnershipConcentrationResults) GetDevIndex() []string {
+func (m *LineHistoryDumpResults) GetDevIndex() []string {
 	if m != nil {
 		return m.DevIndex
 	}
 	return nil
 }
 
-func (m *OwnershipConcentrationResults) GetTickSize() int64 {
+func (m *LineHistoryDumpResults) GetTickSize() int64 {
 	if m != nil {
 		return m.TickSize
 	}
 	return 0
 }
 
-// Per-file knowledge diffusion data
-type KnowledgeDiffusionFileData struct {
-	// total unique editors who ever touched this file
-	UniqueEditorsCount int32 `protobuf:"varint,1,opt,name=unique_editors_count,json=uniqueEditorsCount,proto3" json:"unique_editors_count,omitempty"`
-	// tick -> cumulative unique editor count at that tick
-	UniqueEditorsOverTime map[int32]int32 `protobuf:"bytes,2,rep,name=unique_editors_over_time,json=uniqueEditorsOverTime,proto3" json:"unique_editors_over_time,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	// editors active within the recent window
-	RecentEditorsCount int32 `protobuf:"varint,3,opt,name=recent_editors_count,json=recentEditorsCount,proto3" json:"recent_editors_count,omitempty"`
-	// author indices who touched this file
-	Authors              []int32  `protobuf:"varint,4,rep,packed,name=authors,proto3" json:"authors,omitempty"`
+// Project of a monorepo detected by its manifest files
+type TopologyProject struct {
+	// the root directory of the project, "/" for the repository root
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// the names of the manifest files in the root directory
+	Manifests []string `protobuf:"bytes,2,rep,name=manifests,proto3" json:"manifests,omitempty"`
+	// the number of files and lines in the last commit
+	Files int32 `protobuf:"varint,3,opt,name=files,proto3" json:"files,omitempty"`
+	Lines int32 `protobuf:"varint,4,opt,name=lines,proto3" json:"lines,omitempty"`
+	// the number of commits which changed the project
+	Commits              int32    `protobuf:"varint,5,opt,name=commits,proto3" json:"commits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KnowledgeDiffusionFileData) Reset()         { *m = KnowledgeDiffusionFileData{} }
-func (m *KnowledgeDiffusionFileData) String() string { return proto.CompactTextString(m) }
-func (*KnowledgeDiffusionFileData) ProtoMessage()    {}
-func (*KnowledgeDiffusionFileData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{37}
+func (m *TopologyProject) Reset()         { *m = TopologyProject{} }
+func (m *TopologyProject) String() string { return proto.CompactTextString(m) }
+func (*TopologyProject) ProtoMessage()    {}
+func (*TopologyProject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{96}
 }
-func (m *KnowledgeDiffusionFileData) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KnowledgeDiffusionFileData.Unmarshal(m, b)
+func (m *TopologyProject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopologyProject.Unmarshal(m, b)
 }
-func (m *KnowledgeDiffusionFileData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KnowledgeDiffusionFileData.Marshal(b, m, deterministic)
+func (m *TopologyProject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopologyProject.Marshal(b, m, deterministic)
 }
-func (m *KnowledgeDiffusionFileData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KnowledgeDiffusionFileData.Merge(m, src)
+func (m *TopologyProject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologyProject.Merge(m, src)
 }
-func (m *KnowledgeDiffusionFileData) XXX_Size() int {
-	return xxx_messageInfo_KnowledgeDiffusionFileData.Size(m)
+func (m *TopologyProject) XXX_Size() int {
+	return xxx_messageInfo_TopologyProject.Size(m)
 }
-func (m *KnowledgeDiffusionFileData) XXX_DiscardUnknown() {
-	xxx_messageInfo_KnowledgeDiffusionFileData.DiscardUnknown(m)
+func (m *TopologyProject) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologyProject.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KnowledgeDiffusionFileData proto.InternalMessageInfo
+var xxx_messageInfo_TopologyProject proto.InternalMessageInfo
 
-func (m *KnowledgeDiffusionFileData) GetUniqueEditorsCount() int32 {
+func (m *TopologyProject) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *TopologyProject) GetManifests() []string {
 	if m != nil {
-		return m.UniqueEditorsCount
+		return m.Manifests
 	}
-	return 0
+	return nil
 }
 
-func (m *KnowledgeDiffusionFileData) GetUniqueEditorsOverTime() map[int32]int32 {
+func (m *TopologyProject) GetFiles() int32 {
 	if m != nil {
-		return m.UniqueEditorsOverTime
+		return m.Files
 	}
-	return nil
+	return 0
 }
 
-func (m *KnowledgeDiffusionFileData) GetRecentEditorsCount() int32 {
+func (m *TopologyProject) GetLines() int32 {
 	if m != nil {
-		return m.RecentEditorsCount
+		return m.Lines
 	}
 	return 0
 }
 
-func (m *KnowledgeDiffusionFileData) GetAuthors() []int32 {
+func (m *TopologyProject) GetCommits() int32 {
 	if m != nil {
-		return m.Authors
+		return m.Commits
 	}
-	return nil
+	return 0
 }
 
-type KnowledgeDiffusionResults struct {
-	// per-file diffusion data
-	Files map[string]*KnowledgeDiffusionFileData `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// histogram: editor_count -> number_of_files
-	Distribution map[int32]int32 `protobuf:"bytes,2,rep,name=distribution,proto3" json:"distribution,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	// sliding window size in months for "recent" editors
-	WindowMonths int32 `protobuf:"varint,3,opt,name=window_months,json=windowMonths,proto3" json:"window_months,omitempty"`
-	// developer identities
-	DevIndex []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
-	// how long each tick is, as an int64 nanosecond count (Go's time.Duration)
-	TickSize             int64    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+// The number of commits which changed both projects
+type TopologyEdge struct {
+	// indexes in TopologyResults.projects
+	First                int32    `protobuf:"varint,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second               int32    `protobuf:"varint,2,opt,name=second,proto3" json:"second,omitempty"`
+	Commits              int32    `protobuf:"varint,3,opt,name=commits,proto3" json:"commits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *KnowledgeDiffusionResults) Reset()         { *m = KnowledgeDiffusionResults{} }
-func (m *KnowledgeDiffusionResults) String() string { return proto.CompactTextString(m) }
-func (*KnowledgeDiffusionResults) ProtoMessage()    {}
-func (*KnowledgeDiffusionResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{38}
+func (m *TopologyEdge) Reset()         { *m = TopologyEdge{} }
+func (m *TopologyEdge) String() string { return proto.CompactTextString(m) }
+func (*TopologyEdge) ProtoMessage()    {}
+func (*TopologyEdge) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{97}
 }
-func (m *KnowledgeDiffusionResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KnowledgeDiffusionResults.Unmarshal(m, b)
+func (m *TopologyEdge) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopologyEdge.Unmarshal(m, b)
 }
-func (m *KnowledgeDiffusionResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KnowledgeDiffusionResults.Marshal(b, m, deterministic)
+func (m *TopologyEdge) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopologyEdge.Marshal(b, m, deterministic)
 }
-func (m *KnowledgeDiffusionResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KnowledgeDiffusionResults.Merge(m, src)
+func (m *TopologyEdge) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologyEdge.Merge(m, src)
 }
-func (m *KnowledgeDiffusionResults) XXX_Size() int {
-	return xxx_messageInfo_KnowledgeDiffusionResults.Size(m)
+func (m *TopologyEdge) XXX_Size() int {
+	return xxx_messageInfo_TopologyEdge.Size(m)
 }
-func (m *KnowledgeDiffusionResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_KnowledgeDiffusionResults.DiscardUnknown(m)
+func (m *TopologyEdge) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologyEdge.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KnowledgeDiffusionResults proto.InternalMessageInfo
+var xxx_messageInfo_TopologyEdge proto.InternalMessageInfo
 
-func (m *KnowledgeDiffusionResults) GetFiles() map[string]*KnowledgeDiffusionFileData {
+func (m *TopologyEdge) GetFirst() int32 {
 	if m != nil {
-		return m.Files
+		return m.First
 	}
-	return nil
+	return 0
 }
 
-func (m *KnowledgeDiffusionResults) GetDistribution() map[int32]int32 {
+func (m *TopologyEdge) GetSecond() int32 {
 	if m != nil {
-		return m.Distribution
+		return m.Second
 	}
-	return nil
+	return 0
 }
 
-func (m *KnowledgeDiffusionResults) GetWindowMonths() int32 {
+func (m *TopologyEdge) GetCommits() int32 {
 	if m != nil {
-		return m.WindowMonths
+		return m.Commits
 	}
 	return 0
 }
 
-func (m *KnowledgeDiffusionResults) GetDevIndex() []string {
+type TopologyResults struct {
+	// sorted by path
+	Projects []*TopologyProject `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	// sorted by commits in descending order
+	Edges                []*TopologyEdge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *TopologyResults) Reset()         { *m = TopologyResults{} }
+func (m *TopologyResults) String() string { return proto.CompactTextString(m) }
+func (*TopologyResults) ProtoMessage()    {}
+func (*TopologyResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{98}
+}
+func (m *TopologyResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TopologyResults.Unmarshal(m, b)
+}
+func (m *TopologyResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TopologyResults.Marshal(b, m, deterministic)
+}
+func (m *TopologyResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopologyResults.Merge(m, src)
+}
+func (m *TopologyResults) XXX_Size() int {
+	return xxx_messageInfo_TopologyResults.Size(m)
+}
+func (m *TopologyResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopologyResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopologyResults proto.InternalMessageInfo
+
+func (m *TopologyResults) GetProjects() []*TopologyProject {
 	if m != nil {
-		return m.DevIndex
+		return m.Projects
 	}
 	return nil
 }
 
-func (m *KnowledgeDiffusionResults) GetTickSize() int64 {
+func (m *TopologyResults) GetEdges() []*TopologyEdge {
 	if m != nil {
-		return m.TickSize
+		return m.Edges
 	}
-	return 0
+	return nil
 }
 
-// Snapshot of onboarding metrics at a specific milestone
-type OnboardingSnapshot struct {
-	DaysSinceJoin int32 `protobuf:"varint,1,opt,name=days_since_join,json=daysSinceJoin,proto3" json:"days_since_join,omitempty"`
-	// All commits
-	TotalCommits int32 `protobuf:"varint,2,opt,name=total_commits,json=totalCommits,proto3" json:"total_commits,omitempty"`
-	TotalFiles   int32 `protobuf:"varint,3,opt,name=total_files,json=totalFiles,proto3" json:"total_files,omitempty"`
-	TotalLines   int32 `protobuf:"varint,4,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
-	// Meaningful commits only (>= threshold)
-	MeaningfulCommits    int32    `protobuf:"varint,5,opt,name=meaningful_commits,json=meaningfulCommits,proto3" json:"meaningful_commits,omitempty"`
-	MeaningfulFiles      int32    `protobuf:"varint,6,opt,name=meaningful_files,json=meaningfulFiles,proto3" json:"meaningful_files,omitempty"`
-	MeaningfulLines      int32    `protobuf:"varint,7,opt,name=meaningful_lines,json=meaningfulLines,proto3" json:"meaningful_lines,omitempty"`
+// The number of commits in each bucket of CommitSizeResults.files_bounds and lines_bounds
+type CommitSizeHistogram struct {
+	Commits int32 `protobuf:"varint,1,opt,name=commits,proto3" json:"commits,omitempty"`
+	// the last bucket is unbounded
+	Files                []int32  `protobuf:"varint,2,rep,packed,name=files,proto3" json:"files,omitempty"`
+	Lines                []int32  `protobuf:"varint,3,rep,packed,name=lines,proto3" json:"lines,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OnboardingSnapshot) Reset()         { *m = OnboardingSnapshot{} }
-func (m *OnboardingSnapshot) String() string { return proto.CompactTextString(m) }
-func (*OnboardingSnapshot) ProtoMessage()    {}
-func (*OnboardingSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{39}
+func (m *CommitSizeHistogram) Reset()         { *m = CommitSizeHistogram{} }
+func (m *CommitSizeHistogram) String() string { return proto.CompactTextString(m) }
+func (*CommitSizeHistogram) ProtoMessage()    {}
+func (*CommitSizeHistogram) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{99}
 }
-func (m *OnboardingSnapshot) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OnboardingSnapshot.Unmarshal(m, b)
+func (m *CommitSizeHistogram) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSizeHistogram.Unmarshal(m, b)
 }
-func (m *OnboardingSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OnboardingSnapshot.Marshal(b, m, deterministic)
+func (m *CommitSizeHistogram) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSizeHistogram.Marshal(b, m, deterministic)
 }
-func (m *OnboardingSnapshot) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OnboardingSnapshot.Merge(m, src)
+func (m *CommitSizeHistogram) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSizeHistogram.Merge(m, src)
 }
-func (m *OnboardingSnapshot) XXX_Size() int {
-	return xxx_messageInfo_OnboardingSnapshot.Size(m)
+func (m *CommitSizeHistogram) XXX_Size() int {
+	return xxx_messageInfo_CommitSizeHistogram.Size(m)
 }
-func (m *OnboardingSnapshot) XXX_DiscardUnknown() {
-	xxx_messageInfo_OnboardingSnapshot.DiscardUnknown(m)
+func (m *CommitSizeHistogram) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSizeHistogram.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OnboardingSnapshot proto.InternalMessageInfo
+var xxx_messageInfo_CommitSizeHistogram proto.InternalMessageInfo
 
-func (m *OnboardingSnapshot) GetDaysSinceJoin() int32 {
+func (m *CommitSizeHistogram) GetCommits() int32 {
 	if m != nil {
-		return m.DaysSinceJoin
+		return m.Commits
 	}
 	return 0
 }
 
-func (m *OnboardingSnapshot) GetTotalCommits() int32 {
+func (m *CommitSizeHistogram) GetFiles() []int32 {
 	if m != nil {
-		return m.TotalCommits
+		return m.Files
 	}
-	return 0
+	return nil
 }
 
-func (m *OnboardingSnapshot) GetTotalFiles() int32 {
+func (m *CommitSizeHistogram) GetLines() []int32 {
 	if m != nil {
-		return m.TotalFiles
+		return m.Lines
 	}
-	return 0
+	return nil
 }
 
-func (m *OnboardingSnapshot) GetTotalLines() int32 {
+type CommitSizeTick struct {
+	Histogram *CommitSizeHistogram `protobuf:"bytes,1,opt,name=histogram,proto3" json:"histogram,omitempty"`
+	// nearest-rank percentiles of the commit sizes in the tick
+	MedianFiles          int32    `protobuf:"varint,2,opt,name=median_files,json=medianFiles,proto3" json:"median_files,omitempty"`
+	P90Files             int32    `protobuf:"varint,3,opt,name=p90_files,json=p90Files,proto3" json:"p90_files,omitempty"`
+	MedianLines          int32    `protobuf:"varint,4,opt,name=median_lines,json=medianLines,proto3" json:"median_lines,omitempty"`
+	P90Lines             int32    `protobuf:"varint,5,opt,name=p90_lines,json=p90Lines,proto3" json:"p90_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommitSizeTick) Reset()         { *m = CommitSizeTick{} }
+func (m *CommitSizeTick) String() string { return proto.CompactTextString(m) }
+func (*CommitSizeTick) ProtoMessage()    {}
+func (*CommitSizeTick) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{100}
+}
+func (m *CommitSizeTick) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSizeTick.Unmarshal(m, b)
+}
+func (m *CommitSizeTick) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSizeTick.Marshal(b, m, deterministic)
+}
+func (m *CommitSizeTick) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSizeTick.Merge(m, src)
+}
+func (m *CommitSizeTick) XXX_Size() int {
+	return xxx_messageInfo_CommitSizeTick.Size(m)
+}
+func (m *CommitSizeTick) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSizeTick.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitSizeTick proto.InternalMessageInfo
+
+func (m *CommitSizeTick) GetHistogram() *CommitSizeHistogram {
 	if m != nil {
-		return m.TotalLines
+		return m.Histogram
+	}
+	return nil
+}
+
+func (m *CommitSizeTick) GetMedianFiles() int32 {
+	if m != nil {
+		return m.MedianFiles
 	}
 	return 0
 }
 
-func (m *OnboardingSnapshot) GetMeaningfulCommits() int32 {
+func (m *CommitSizeTick) GetP90Files() int32 {
 	if m != nil {
-		return m.MeaningfulCommits
+		return m.P90Files
 	}
 	return 0
 }
 
-func (m *OnboardingSnapshot) GetMeaningfulFiles() int32 {
+func (m *CommitSizeTick) GetMedianLines() int32 {
 	if m != nil {
-		return m.MeaningfulFiles
+		return m.MedianLines
 	}
 	return 0
 }
 
-func (m *OnboardingSnapshot) GetMeaningfulLines() int32 {
+func (m *CommitSizeTick) GetP90Lines() int32 {
 	if m != nil {
-		return m.MeaningfulLines
+		return m.P90Lines
 	}
 	return 0
 }
 
-// Average snapshot of onboarding metrics (for cohort aggregates)
-type OnboardingAverageSnapshot struct {
-	DaysSinceJoin        int32    `protobuf:"varint,1,opt,name=days_since_join,json=daysSinceJoin,proto3" json:"days_since_join,omitempty"`
-	AvgTotalCommits      float64  `protobuf:"fixed64,2,opt,name=avg_total_commits,json=avgTotalCommits,proto3" json:"avg_total_commits,omitempty"`
-	AvgTotalFiles        float64  `protobuf:"fixed64,3,opt,name=avg_total_files,json=avgTotalFiles,proto3" json:"avg_total_files,omitempty"`
-	AvgTotalLines        float64  `protobuf:"fixed64,4,opt,name=avg_total_lines,json=avgTotalLines,proto3" json:"avg_total_lines,omitempty"`
-	AvgMeaningfulCommits float64  `protobuf:"fixed64,5,opt,name=avg_meaningful_commits,json=avgMeaningfulCommits,proto3" json:"avg_meaningful_commits,omitempty"`
-	AvgMeaningfulFiles   float64  `protobuf:"fixed64,6,opt,name=avg_meaningful_files,json=avgMeaningfulFiles,proto3" json:"avg_meaningful_files,omitempty"`
-	AvgMeaningfulLines   float64  `protobuf:"fixed64,7,opt,name=avg_meaningful_lines,json=avgMeaningfulLines,proto3" json:"avg_meaningful_lines,omitempty"`
+// The commit which changed more files or lines than the thresholds
+type MegaCommit struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Author               int32    `protobuf:"varint,2,opt,name=author,proto3" json:"author,omitempty"`
+	Tick                 int32    `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Files                int32    `protobuf:"varint,4,opt,name=files,proto3" json:"files,omitempty"`
+	Added                int32    `protobuf:"varint,5,opt,name=added,proto3" json:"added,omitempty"`
+	Removed              int32    `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+	Changed              int32    `protobuf:"varint,7,opt,name=changed,proto3" json:"changed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OnboardingAverageSnapshot) Reset()         { *m = OnboardingAverageSnapshot{} }
-func (m *OnboardingAverageSnapshot) String() string { return proto.CompactTextString(m) }
-func (*OnboardingAverageSnapshot) ProtoMessage()    {}
-func (*OnboardingAverageSnapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{40}
+func (m *MegaCommit) Reset()         { *m = MegaCommit{} }
+func (m *MegaCommit) String() string { return proto.CompactTextString(m) }
+func (*MegaCommit) ProtoMessage()    {}
+func (*MegaCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{101}
 }
-func (m *OnboardingAverageSnapshot) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OnboardingAverageSnapshot.Unmarshal(m, b)
+func (m *MegaCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MegaCommit.Unmarshal(m, b)
 }
-func (m *OnboardingAverageSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OnboardingAverageSnapshot.Marshal(b, m, deterministic)
+func (m *MegaCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MegaCommit.Marshal(b, m, deterministic)
 }
-func (m *OnboardingAverageSnapshot) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OnboardingAverageSnapshot.Merge(m, src)
+func (m *MegaCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MegaCommit.Merge(m, src)
 }
-func (m *OnboardingAverageSnapshot) XXX_Size() int {
-	return xxx_messageInfo_OnboardingAverageSnapshot.Size(m)
+func (m *MegaCommit) XXX_Size() int {
+	return xxx_messageInfo_MegaCommit.Size(m)
 }
-func (m *OnboardingAverageSnapshot) XXX_DiscardUnknown() {
-	xxx_messageInfo_OnboardingAverageSnapshot.DiscardUnknown(m)
+func (m *MegaCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_MegaCommit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OnboardingAverageSnapshot proto.InternalMessageInfo
+var xxx_messageInfo_MegaCommit proto.InternalMessageInfo
 
-func (m *OnboardingAverageSnapshot) GetDaysSinceJoin() int32 {
+func (m *MegaCommit) GetHash() string {
 	if m != nil {
-		return m.DaysSinceJoin
+		return m.Hash
 	}
-	return 0
+	return ""
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgTotalCommits() float64 {
+func (m *MegaCommit) GetAuthor() int32 {
 	if m != nil {
-		return m.AvgTotalCommits
+		return m.Author
 	}
 	return 0
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgTotalFiles() float64 {
+func (m *MegaCommit) GetTick() int32 {
 	if m != nil {
-		return m.AvgTotalFiles
+		return m.Tick
 	}
 	return 0
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgTotalLines() float64 {
+func (m *MegaCommit) GetFiles() int32 {
 	if m != nil {
-		return m.AvgTotalLines
+		return m.Files
 	}
 	return 0
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgMeaningfulCommits() float64 {
+func (m *MegaCommit) GetAdded() int32 {
 	if m != nil {
-		return m.AvgMeaningfulCommits
+		return m.Added
 	}
 	return 0
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgMeaningfulFiles() float64 {
+func (m *MegaCommit) GetRemoved() int32 {
 	if m != nil {
-		return m.AvgMeaningfulFiles
+		return m.Removed
 	}
 	return 0
 }
 
-func (m *OnboardingAverageSnapshot) GetAvgMeaningfulLines() float64 {
+func (m *MegaCommit) GetChanged() int32 {
 	if m != nil {
-		return m.AvgMeaningfulLines
+		return m.Changed
 	}
 	return 0
 }
 
-// Per-author onboarding progression data
-type AuthorOnboardingData struct {
-	FirstCommitTick int32  `protobuf:"varint,1,opt,name=first_commit_tick,json=firstCommitTick,proto3" json:"first_commit_tick,omitempty"`
-	JoinCohort      string `protobuf:"bytes,2,opt,name=join_cohort,json=joinCohort,proto3" json:"join_cohort,omitempty"`
-	// Snapshots at configured window milestones (keyed by days)
-	Snapshots            map[int32]*OnboardingSnapshot `protobuf:"bytes,3,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+type CommitSizeResults struct {
+	// keyed by developer index
+	People map[int32]*CommitSizeHistogram `protobuf:"bytes,1,rep,name=people,proto3" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Ticks  map[int32]*CommitSizeTick      `protobuf:"bytes,2,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// sorted by tick and hash
+	MegaCommits []*MegaCommit `protobuf:"bytes,3,rep,name=mega_commits,json=megaCommits,proto3" json:"mega_commits,omitempty"`
+	MegaFiles   int32         `protobuf:"varint,4,opt,name=mega_files,json=megaFiles,proto3" json:"mega_files,omitempty"`
+	MegaLines   int32         `protobuf:"varint,5,opt,name=mega_lines,json=megaLines,proto3" json:"mega_lines,omitempty"`
+	// inclusive upper bounds of the histogram buckets
+	FilesBounds []int32 `protobuf:"varint,6,rep,packed,name=files_bounds,json=filesBounds,proto3" json:"files_bounds,omitempty"`
+	LinesBounds []int32 `protobuf:"varint,7,rep,packed,name=lines_bounds,json=linesBounds,proto3" json:"lines_bounds,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,8,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	TickSize             int64    `protobuf:"varint,9,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AuthorOnboardingData) Reset()         { *m = AuthorOnboardingData{} }
-func (m *AuthorOnboardingData) String() string { return proto.CompactTextString(m) }
-func (*AuthorOnboardingData) ProtoMessage()    {}
-func (*AuthorOnboardingData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{41}
+func (m *CommitSizeResults) Reset()         { *m = CommitSizeResults{} }
+func (m *CommitSizeResults) String() string { return proto.CompactTextString(m) }
+func (*CommitSizeResults) ProtoMessage()    {}
+func (*CommitSizeResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{102}
 }
-func (m *AuthorOnboardingData) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AuthorOnboardingData.Unmarshal(m, b)
+func (m *CommitSizeResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSizeResults.Unmarshal(m, b)
 }
-func (m *AuthorOnboardingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AuthorOnboardingData.Marshal(b, m, deterministic)
+func (m *CommitSizeResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSizeResults.Marshal(b, m, deterministic)
 }
-func (m *AuthorOnboardingData) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AuthorOnboardingData.Merge(m, src)
+func (m *CommitSizeResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSizeResults.Merge(m, src)
 }
-func (m *AuthorOnboardingData) XXX_Size() int {
-	return xxx_messageInfo_AuthorOnboardingData.Size(m)
+func (m *CommitSizeResults) XXX_Size() int {
+	return xxx_messageInfo_CommitSizeResults.Size(m)
 }
-func (m *AuthorOnboardingData) XXX_DiscardUnknown() {
-	xxx_messageInfo_AuthorOnboardingData.DiscardUnknown(m)
+func (m *CommitSizeResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSizeResults.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AuthorOnboardingData proto.InternalMessageInfo
-
-func (m *AuthorOnboardingData) GetFirstCommitTick() int32 {
-	if m != nil {
-		return m.FirstCommitTick
-	}
-	return 0
-}
+var xxx_messageInfo_CommitSizeResults proto.InternalMessageInfo
 
-func (m *AuthorOnboardingData) GetJoinCohort() string {
+func (m *CommitSizeResults) GetPeople() map[int32]*CommitSizeHistogram {
 	if m != nil {
-		return m.JoinCohort
+		return m.People
 	}
-	return ""
+	return nil
 }
 
-func (m *AuthorOnboardingData) GetSnapshots() map[int32]*OnboardingSnapshot {
+func (m *CommitSizeResults) GetTicks() map[int32]*CommitSizeTick {
 	if m != nil {
-		return m.Snapshots
+		return m.Ticks
 	}
-	return nil
-}
-
-// Aggregated cohort statistics
-type CohortStats struct {
-	Cohort      string `protobuf:"bytes,1,opt,name=cohort,proto3" json:"cohort,omitempty"`
-	AuthorCount int32  `protobuf:"varint,2,opt,name=author_count,json=authorCount,proto3" json:"author_count,omitempty"`
-	// Average snapshots across cohort (keyed by days)
-	AverageSnapshots     map[int32]*OnboardingAverageSnapshot `protobuf:"bytes,3,rep,name=average_snapshots,json=averageSnapshots,proto3" json:"average_snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
-	XXX_unrecognized     []byte                               `json:"-"`
-	XXX_sizecache        int32                                `json:"-"`
-}
-
-func (m *CohortStats) Reset()         { *m = CohortStats{} }
-func (m *CohortStats) String() string { return proto.CompactTextString(m) }
-func (*CohortStats) ProtoMessage()    {}
-func (*CohortStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{42}
-}
-func (m *CohortStats) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CohortStats.Unmarshal(m, b)
-}
-func (m *CohortStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CohortStats.Marshal(b, m, deterministic)
-}
-func (m *CohortStats) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CohortStats.Merge(m, src)
-}
-func (m *CohortStats) XXX_Size() int {
-	return xxx_messageInfo_CohortStats.Size(m)
-}
-func (m *CohortStats) XXX_DiscardUnknown() {
-	xxx_messageInfo_CohortStats.DiscardUnknown(m)
+	return nil
 }
 
-var xxx_messageInfo_CohortStats proto.InternalMessageInfo
+func (m *CommitSizeResults) GetMegaCommits() []*MegaCommit {
+	if m != nil {
+		return m.MegaCommits
+	}
+	return nil
+}
 
-func (m *CohortStats) GetCohort() string {
+func (m *CommitSizeResults) GetMegaFiles() int32 {
 	if m != nil {
-		return m.Cohort
+		return m.MegaFiles
 	}
-	return ""
+	return 0
 }
 
-func (m *CohortStats) GetAuthorCount() int32 {
+func (m *CommitSizeResults) GetMegaLines() int32 {
 	if m != nil {
-		return m.AuthorCount
+		return m.MegaLines
 	}
 	return 0
 }
 
-func (m *CohortStats) GetAverageSnapshots() map[int32]*OnboardingAverageSnapshot {
+func (m *CommitSizeResults) GetFilesBounds() []int32 {
 	if m != nil {
-		return m.AverageSnapshots
+		return m.FilesBounds
 	}
 	return nil
 }
 
-// Top-level onboarding analysis results
-type OnboardingResults struct {
-	// Per-author detailed data (keyed by author index)
-	Authors map[int32]*AuthorOnboardingData `protobuf:"bytes,1,rep,name=authors,proto3" json:"authors,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Cohort aggregates (keyed by "YYYY-MM")
-	Cohorts map[string]*CohortStats `protobuf:"bytes,2,rep,name=cohorts,proto3" json:"cohorts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Configuration used
-	WindowDays          []int32 `protobuf:"varint,3,rep,packed,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
-	MeaningfulThreshold int32   `protobuf:"varint,4,opt,name=meaningful_threshold,json=meaningfulThreshold,proto3" json:"meaningful_threshold,omitempty"`
-	// Developer identities
-	DevIndex []string `protobuf:"bytes,5,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
-	// Tick size as nanosecond count
-	TickSize             int64    `protobuf:"varint,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+func (m *CommitSizeResults) GetLinesBounds() []int32 {
+	if m != nil {
+		return m.LinesBounds
+	}
+	return nil
+}
+
+func (m *CommitSizeResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *CommitSizeResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
+	}
+	return 0
+}
+
+type ReviewLatencyStats struct {
+	Merges int32 `protobuf:"varint,1,opt,name=merges,proto3" json:"merges,omitempty"`
+	// number of the commits in the merged branches
+	Commits int32 `protobuf:"varint,2,opt,name=commits,proto3" json:"commits,omitempty"`
+	// seconds from the first commit of the branch to the merge
+	MedianLeadTime int64 `protobuf:"varint,3,opt,name=median_lead_time,json=medianLeadTime,proto3" json:"median_lead_time,omitempty"`
+	P90LeadTime    int64 `protobuf:"varint,4,opt,name=p90_lead_time,json=p90LeadTime,proto3" json:"p90_lead_time,omitempty"`
+	// seconds from the last commit of the branch to the merge
+	MedianReviewWait     int64    `protobuf:"varint,5,opt,name=median_review_wait,json=medianReviewWait,proto3" json:"median_review_wait,omitempty"`
+	P90ReviewWait        int64    `protobuf:"varint,6,opt,name=p90_review_wait,json=p90ReviewWait,proto3" json:"p90_review_wait,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *OnboardingResults) Reset()         { *m = OnboardingResults{} }
-func (m *OnboardingResults) String() string { return proto.CompactTextString(m) }
-func (*OnboardingResults) ProtoMessage()    {}
-func (*OnboardingResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{43}
+func (m *ReviewLatencyStats) Reset()         { *m = ReviewLatencyStats{} }
+func (m *ReviewLatencyStats) String() string { return proto.CompactTextString(m) }
+func (*ReviewLatencyStats) ProtoMessage()    {}
+func (*ReviewLatencyStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{103}
 }
-func (m *OnboardingResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_OnboardingResults.Unmarshal(m, b)
+func (m *ReviewLatencyStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewLatencyStats.Unmarshal(m, b)
 }
-func (m *OnboardingResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_OnboardingResults.Marshal(b, m, deterministic)
+func (m *ReviewLatencyStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewLatencyStats.Marshal(b, m, deterministic)
 }
-func (m *OnboardingResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_OnboardingResults.Merge(m, src)
+func (m *ReviewLatencyStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewLatencyStats.Merge(m, src)
 }
-func (m *OnboardingResults) XXX_Size() int {
-	return xxx_messageInfo_OnboardingResults.Size(m)
+func (m *ReviewLatencyStats) XXX_Size() int {
+	return xxx_messageInfo_ReviewLatencyStats.Size(m)
 }
-func (m *OnboardingResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_OnboardingResults.DiscardUnknown(m)
+func (m *ReviewLatencyStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewLatencyStats.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_OnboardingResults proto.InternalMessageInfo
+var xxx_messageInfo_ReviewLatencyStats proto.InternalMessageInfo
 
-func (m *OnboardingResults) GetAuthors() map[int32]*AuthorOnboardingData {
+func (m *ReviewLatencyStats) GetMerges() int32 {
 	if m != nil {
-		return m.Authors
+		return m.Merges
 	}
-	return nil
+	return 0
 }
 
-func (m *OnboardingResults) GetCohorts() map[string]*CohortStats {
+func (m *ReviewLatencyStats) GetCommits() int32 {
 	if m != nil {
-		return m.Cohorts
+		return m.Commits
 	}
-	return nil
+	return 0
 }
 
-func (m *OnboardingResults) GetWindowDays() []int32 {
+func (m *ReviewLatencyStats) GetMedianLeadTime() int64 {
 	if m != nil {
-		return m.WindowDays
+		return m.MedianLeadTime
 	}
-	return nil
+	return 0
 }
 
-func (m *OnboardingResults) GetMeaningfulThreshold() int32 {
+func (m *ReviewLatencyStats) GetP90LeadTime() int64 {
 	if m != nil {
-		return m.MeaningfulThreshold
+		return m.P90LeadTime
 	}
 	return 0
 }
 
-func (m *OnboardingResults) GetDevIndex() []string {
+func (m *ReviewLatencyStats) GetMedianReviewWait() int64 {
 	if m != nil {
-		return m.DevIndex
+		return m.MedianReviewWait
 	}
-	return nil
+	return 0
 }
 
-func (m *OnboardingResults) GetTickSize() int64 {
+func (m *ReviewLatencyStats) GetP90ReviewWait() int64 {
 	if m != nil {
-		return m.TickSize
+		return m.P90ReviewWait
 	}
 	return 0
 }
 
-// Per-file risk assessment
-type FileRisk struct {
-	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	RiskScore            float64  `protobuf:"fixed64,2,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
-	Size_                int32    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	Churn                int32    `protobuf:"varint,4,opt,name=churn,proto3" json:"churn,omitempty"`
-	CouplingDegree       int32    `protobuf:"varint,5,opt,name=coupling_degree,json=couplingDegree,proto3" json:"coupling_degree,omitempty"`
-	OwnershipGini        float64  `protobuf:"fixed64,6,opt,name=ownership_gini,json=ownershipGini,proto3" json:"ownership_gini,omitempty"`
-	SizeNormalized       float64  `protobuf:"fixed64,7,opt,name=size_normalized,json=sizeNormalized,proto3" json:"size_normalized,omitempty"`
-	ChurnNormalized      float64  `protobuf:"fixed64,8,opt,name=churn_normalized,json=churnNormalized,proto3" json:"churn_normalized,omitempty"`
-	CouplingNormalized   float64  `protobuf:"fixed64,9,opt,name=coupling_normalized,json=couplingNormalized,proto3" json:"coupling_normalized,omitempty"`
-	OwnershipNormalized  float64  `protobuf:"fixed64,10,opt,name=ownership_normalized,json=ownershipNormalized,proto3" json:"ownership_normalized,omitempty"`
+type Integration struct {
+	// merge commit
+	Hash string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// author of the tip of the merged branch
+	Author               int32    `protobuf:"varint,2,opt,name=author,proto3" json:"author,omitempty"`
+	Tick                 int32    `protobuf:"varint,3,opt,name=tick,proto3" json:"tick,omitempty"`
+	Commits              int32    `protobuf:"varint,4,opt,name=commits,proto3" json:"commits,omitempty"`
+	LeadTime             int64    `protobuf:"varint,5,opt,name=lead_time,json=leadTime,proto3" json:"lead_time,omitempty"`
+	ReviewWait           int64    `protobuf:"varint,6,opt,name=review_wait,json=reviewWait,proto3" json:"review_wait,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FileRisk) Reset()         { *m = FileRisk{} }
-func (m *FileRisk) String() string { return proto.CompactTextString(m) }
-func (*FileRisk) ProtoMessage()    {}
-func (*FileRisk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{44}
+func (m *Integration) Reset()         { *m = Integration{} }
+func (m *Integration) String() string { return proto.CompactTextString(m) }
+func (*Integration) ProtoMessage()    {}
+func (*Integration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{104}
 }
-func (m *FileRisk) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FileRisk.Unmarshal(m, b)
+func (m *Integration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Integration.Unmarshal(m, b)
 }
-func (m *FileRisk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FileRisk.Marshal(b, m, deterministic)
+func (m *Integration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Integration.Marshal(b, m, deterministic)
 }
-func (m *FileRisk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FileRisk.Merge(m, src)
+func (m *Integration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Integration.Merge(m, src)
 }
-func (m *FileRisk) XXX_Size() int {
-	return xxx_messageInfo_FileRisk.Size(m)
+func (m *Integration) XXX_Size() int {
+	return xxx_messageInfo_Integration.Size(m)
 }
-func (m *FileRisk) XXX_DiscardUnknown() {
-	xxx_messageInfo_FileRisk.DiscardUnknown(m)
+func (m *Integration) XXX_DiscardUnknown() {
+	xxx_messageInfo_Integration.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FileRisk proto.InternalMessageInfo
+var xxx_messageInfo_Integration proto.InternalMessageInfo
 
-func (m *FileRisk) GetPath() string {
+func (m *Integration) GetHash() string {
 	if m != nil {
-		return m.Path
+		return m.Hash
 	}
 	return ""
 }
 
-func (m *FileRisk) GetRiskScore() float64 {
+func (m *Integration) GetAuthor() int32 {
 	if m != nil {
-		return m.RiskScore
+		return m.Author
 	}
 	return 0
 }
 
-func (m *FileRisk) GetSize_() int32 {
+func (m *Integration) GetTick() int32 {
 	if m != nil {
-		return m.Size_
+		return m.Tick
 	}
 	return 0
 }
 
-func (m *FileRisk) GetChurn() int32 {
+func (m *Integration) GetCommits() int32 {
 	if m != nil {
-		return m.Churn
+		return m.Commits
 	}
 	return 0
 }
 
-func (m *FileRisk) GetCouplingDegree() int32 {
+func (m *Integration) GetLeadTime() int64 {
 	if m != nil {
-		return m.CouplingDegree
+		return m.LeadTime
 	}
 	return 0
 }
 
-func (m *FileRisk) GetOwnershipGini() float64 {
+func (m *Integration) GetReviewWait() int64 {
 	if m != nil {
-		return m.OwnershipGini
+		return m.ReviewWait
 	}
 	return 0
 }
 
-func (m *FileRisk) GetSizeNormalized() float64 {
+type ReviewLatencyResults struct {
+	Ticks map[int32]*ReviewLatencyStats `protobuf:"bytes,1,rep,name=ticks,proto3" json:"ticks,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// keyed by developer index
+	People map[int32]*ReviewLatencyStats `protobuf:"bytes,2,rep,name=people,proto3" json:"people,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// sorted by tick and hash
+	Integrations []*Integration `protobuf:"bytes,3,rep,name=integrations,proto3" json:"integrations,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	TickSize             int64    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReviewLatencyResults) Reset()         { *m = ReviewLatencyResults{} }
+func (m *ReviewLatencyResults) String() string { return proto.CompactTextString(m) }
+func (*ReviewLatencyResults) ProtoMessage()    {}
+func (*ReviewLatencyResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{105}
+}
+func (m *ReviewLatencyResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewLatencyResults.Unmarshal(m, b)
+}
+func (m *ReviewLatencyResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewLatencyResults.Marshal(b, m, deterministic)
+}
+func (m *ReviewLatencyResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewLatencyResults.Merge(m, src)
+}
+func (m *ReviewLatencyResults) XXX_Size() int {
+	return xxx_messageInfo_ReviewLatencyResults.Size(m)
+}
+func (m *ReviewLatencyResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewLatencyResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReviewLatencyResults proto.InternalMessageInfo
+
+func (m *ReviewLatencyResults) GetTicks() map[int32]*ReviewLatencyStats {
 	if m != nil {
-		return m.SizeNormalized
+		return m.Ticks
 	}
-	return 0
+	return nil
 }
 
-func (m *FileRisk) GetChurnNormalized() float64 {
+func (m *ReviewLatencyResults) GetPeople() map[int32]*ReviewLatencyStats {
 	if m != nil {
-		return m.ChurnNormalized
+		return m.People
 	}
-	return 0
+	return nil
 }
 
-func (m *FileRisk) GetCouplingNormalized() float64 {
+func (m *ReviewLatencyResults) GetIntegrations() []*Integration {
 	if m != nil {
-		return m.CouplingNormalized
+		return m.Integrations
 	}
-	return 0
+	return nil
 }
 
-func (m *FileRisk) GetOwnershipNormalized() float64 {
+func (m *ReviewLatencyResults) GetDevIndex() []string {
 	if m != nil {
-		return m.OwnershipNormalized
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *ReviewLatencyResults) GetTickSize() int64 {
+	if m != nil {
+		return m.TickSize
 	}
 	return 0
 }
 
-// Hotspot risk analysis results
-type HotspotRiskResults struct {
-	WindowDays           int32       `protobuf:"varint,1,opt,name=window_days,json=windowDays,proto3" json:"window_days,omitempty"`
-	Files                []*FileRisk `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+type KnowledgeLossCounts struct {
+	// alive lines
+	TotalLines int64 `protobuf:"varint,1,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	// alive lines owned by the departed developers
+	OrphanedLines        int64    `protobuf:"varint,2,opt,name=orphaned_lines,json=orphanedLines,proto3" json:"orphaned_lines,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HotspotRiskResults) Reset()         { *m = HotspotRiskResults{} }
-func (m *HotspotRiskResults) String() string { return proto.CompactTextString(m) }
-func (*HotspotRiskResults) ProtoMessage()    {}
-func (*HotspotRiskResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{45}
+func (m *KnowledgeLossCounts) Reset()         { *m = KnowledgeLossCounts{} }
+func (m *KnowledgeLossCounts) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeLossCounts) ProtoMessage()    {}
+func (*KnowledgeLossCounts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{106}
 }
-func (m *HotspotRiskResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HotspotRiskResults.Unmarshal(m, b)
+func (m *KnowledgeLossCounts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeLossCounts.Unmarshal(m, b)
 }
-func (m *HotspotRiskResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HotspotRiskResults.Marshal(b, m, deterministic)
+func (m *KnowledgeLossCounts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeLossCounts.Marshal(b, m, deterministic)
 }
-func (m *HotspotRiskResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HotspotRiskResults.Merge(m, src)
+func (m *KnowledgeLossCounts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeLossCounts.Merge(m, src)
 }
-func (m *HotspotRiskResults) XXX_Size() int {
-	return xxx_messageInfo_HotspotRiskResults.Size(m)
+func (m *KnowledgeLossCounts) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeLossCounts.Size(m)
 }
-func (m *HotspotRiskResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_HotspotRiskResults.DiscardUnknown(m)
+func (m *KnowledgeLossCounts) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeLossCounts.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HotspotRiskResults proto.InternalMessageInfo
+var xxx_messageInfo_KnowledgeLossCounts proto.InternalMessageInfo
 
-func (m *HotspotRiskResults) GetWindowDays() int32 {
+func (m *KnowledgeLossCounts) GetTotalLines() int64 {
 	if m != nil {
-		return m.WindowDays
+		return m.TotalLines
 	}
 	return 0
 }
 
-func (m *HotspotRiskResults) GetFiles() []*FileRisk {
+func (m *KnowledgeLossCounts) GetOrphanedLines() int64 {
 	if m != nil {
-		return m.Files
+		return m.OrphanedLines
 	}
-	return nil
+	return 0
 }
 
-type RefactoringProxyResults struct {
-	Ticks                []int32   `protobuf:"varint,1,rep,packed,name=ticks,proto3" json:"ticks,omitempty"`
-	RenameRatios         []float32 `protobuf:"fixed32,2,rep,packed,name=rename_ratios,json=renameRatios,proto3" json:"rename_ratios,omitempty"`
-	IsRefactoring        []bool    `protobuf:"varint,3,rep,packed,name=is_refactoring,json=isRefactoring,proto3" json:"is_refactoring,omitempty"`
-	TotalChanges         []int32   `protobuf:"varint,4,rep,packed,name=total_changes,json=totalChanges,proto3" json:"total_changes,omitempty"`
-	Threshold            float32   `protobuf:"fixed32,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
-	TickSize             int64     `protobuf:"varint,6,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+type KnowledgeLossSnapshot struct {
+	TotalLines    int64 `protobuf:"varint,1,opt,name=total_lines,json=totalLines,proto3" json:"total_lines,omitempty"`
+	OrphanedLines int64 `protobuf:"varint,2,opt,name=orphaned_lines,json=orphanedLines,proto3" json:"orphaned_lines,omitempty"`
+	// directory -> its own lines
+	Directories          map[string]*KnowledgeLossCounts `protobuf:"bytes,3,rep,name=directories,proto3" json:"directories,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
-func (m *RefactoringProxyResults) Reset()         { *m = RefactoringProxyResults{} }
-func (m *RefactoringProxyResults) String() string { return proto.CompactTextString(m) }
-func (*RefactoringProxyResults) ProtoMessage()    {}
-func (*RefactoringProxyResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{46}
+func (m *KnowledgeLossSnapshot) Reset()         { *m = KnowledgeLossSnapshot{} }
+func (m *KnowledgeLossSnapshot) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeLossSnapshot) ProtoMessage()    {}
+func (*KnowledgeLossSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{107}
 }
-func (m *RefactoringProxyResults) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RefactoringProxyResults.Unmarshal(m, b)
+func (m *KnowledgeLossSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeLossSnapshot.Unmarshal(m, b)
 }
-func (m *RefactoringProxyResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RefactoringProxyResults.Marshal(b, m, deterministic)
+func (m *KnowledgeLossSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeLossSnapshot.Marshal(b, m, deterministic)
 }
-func (m *RefactoringProxyResults) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RefactoringProxyResults.Merge(m, src)
+func (m *KnowledgeLossSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeLossSnapshot.Merge(m, src)
 }
-func (m *RefactoringProxyResults) XXX_Size() int {
-	return xxx_messageInfo_RefactoringProxyResults.Size(m)
+func (m *KnowledgeLossSnapshot) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeLossSnapshot.Size(m)
 }
-func (m *RefactoringProxyResults) XXX_DiscardUnknown() {
-	xxx_messageInfo_RefactoringProxyResults.DiscardUnknown(m)
+func (m *KnowledgeLossSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeLossSnapshot.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RefactoringProxyResults proto.InternalMessageInfo
+var xxx_messageInfo_KnowledgeLossSnapshot proto.InternalMessageInfo
 
-func (m *RefactoringProxyResults) GetTicks() []int32 {
+func (m *KnowledgeLossSnapshot) GetTotalLines() int64 {
 	if m != nil {
-		return m.Ticks
+		return m.TotalLines
 	}
-	return nil
+	return 0
 }
 
-func (m *RefactoringProxyResults) GetRenameRatios() []float32 {
+func (m *KnowledgeLossSnapshot) GetOrphanedLines() int64 {
 	if m != nil {
-		return m.RenameRatios
+		return m.OrphanedLines
+	}
+	return 0
+}
+
+func (m *KnowledgeLossSnapshot) GetDirectories() map[string]*KnowledgeLossCounts {
+	if m != nil {
+		return m.Directories
 	}
 	return nil
 }
 
-func (m *RefactoringProxyResults) GetIsRefactoring() []bool {
+type KnowledgeLossResults struct {
+	Snapshots map[int32]*KnowledgeLossSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// developer index -> last active tick of the developers departed at the last tick
+	Departed map[int32]int32 `protobuf:"bytes,2,rep,name=departed,proto3" json:"departed,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// inactivity window in days
+	InactiveDays int32 `protobuf:"varint,3,opt,name=inactive_days,json=inactiveDays,proto3" json:"inactive_days,omitempty"`
+	// developer identities
+	DevIndex             []string `protobuf:"bytes,4,rep,name=dev_index,json=devIndex,proto3" json:"dev_index,omitempty"`
+	TickSize             int64    `protobuf:"varint,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KnowledgeLossResults) Reset()         { *m = KnowledgeLossResults{} }
+func (m *KnowledgeLossResults) String() string { return proto.CompactTextString(m) }
+func (*KnowledgeLossResults) ProtoMessage()    {}
+func (*KnowledgeLossResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f80abaa17e25ccc8, []int{108}
+}
+func (m *KnowledgeLossResults) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KnowledgeLossResults.Unmarshal(m, b)
+}
+func (m *KnowledgeLossResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KnowledgeLossResults.Marshal(b, m, deterministic)
+}
+func (m *KnowledgeLossResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KnowledgeLossResults.Merge(m, src)
+}
+func (m *KnowledgeLossResults) XXX_Size() int {
+	return xxx_messageInfo_KnowledgeLossResults.Size(m)
+}
+func (m *KnowledgeLossResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_KnowledgeLossResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KnowledgeLossResults proto.InternalMessageInfo
+
+func (m *KnowledgeLossResults) GetSnapshots() map[int32]*KnowledgeLossSnapshot {
 	if m != nil {
-		return m.IsRefactoring
+		return m.Snapshots
 	}
 	return nil
 }
 
-func (m *RefactoringProxyResults) GetTotalChanges() []int32 {
+func (m *KnowledgeLossResults) GetDeparted() map[int32]int32 {
 	if m != nil {
-		return m.TotalChanges
+		return m.Departed
 	}
 	return nil
 }
 
-func (m *RefactoringProxyResults) GetThreshold() float32 {
+func (m *KnowledgeLossResults) GetInactiveDays() int32 {
 	if m != nil {
-		return m.Threshold
+		return m.InactiveDays
 	}
 	return 0
 }
 
-func (m *RefactoringProxyResults) GetTickSize() int64 {
+func (m *KnowledgeLossResults) GetDevIndex() []string {
+	if m != nil {
+		return m.DevIndex
+	}
+	return nil
+}
+
+func (m *KnowledgeLossResults) GetTickSize() int64 {
 	if m != nil {
 		return m.TickSize
 	}
@@ -2860,7 +7303,7 @@ func (m *AnalysisResults) Reset()         { *m = AnalysisResults{} }
 func (m *AnalysisResults) String() string { return proto.CompactTextString(m) }
 func (*AnalysisResults) ProtoMessage()    {}
 func (*AnalysisResults) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f80abaa17e25ccc8, []int{47}
+	return fileDescriptor_f80abaa17e25ccc8, []int{109}
 }
 func (m *AnalysisResults) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnalysisResults.Unmarshal(m, b)
@@ -2921,7 +7364,9 @@ func init() {
 	proto.RegisterType((*FileHistoryResultMessage)(nil), "FileHistoryResultMessage")
 	proto.RegisterMapType((map[string]*FileHistory)(nil), "FileHistoryResultMessage.FilesEntry")
 	proto.RegisterType((*LineStats)(nil), "LineStats")
+	proto.RegisterType((*LineCounts)(nil), "LineCounts")
 	proto.RegisterType((*DevTick)(nil), "DevTick")
+	proto.RegisterMapType((map[string]*LineStats)(nil), "DevTick.CategoriesEntry")
 	proto.RegisterMapType((map[string]*LineStats)(nil), "DevTick.LanguagesEntry")
 	proto.RegisterType((*TickDevs)(nil), "TickDevs")
 	proto.RegisterMapType((map[int32]*DevTick)(nil), "TickDevs.DevsEntry")
@@ -2949,12 +7394,28 @@ func init() {
 	proto.RegisterMapType((map[int32]*TemporalActivityTick)(nil), "TemporalActivityTickDevs.DevsEntry")
 	proto.RegisterType((*TemporalActivityResults)(nil), "TemporalActivityResults")
 	proto.RegisterMapType((map[int32]*DeveloperTemporalActivity)(nil), "TemporalActivityResults.ActivitiesEntry")
+	proto.RegisterMapType((map[int32]int32)(nil), "TemporalActivityResults.OffsetsEntry")
+	proto.RegisterMapType((map[int32]*TemporalActivitySummary)(nil), "TemporalActivityResults.SummariesEntry")
 	proto.RegisterMapType((map[int32]*TemporalActivityTickDevs)(nil), "TemporalActivityResults.TicksEntry")
+	proto.RegisterType((*TemporalActivitySummary)(nil), "TemporalActivitySummary")
+	proto.RegisterType((*CodeChurnSeries)(nil), "CodeChurnSeries")
+	proto.RegisterType((*CodeChurnKindSeries)(nil), "CodeChurnKindSeries")
+	proto.RegisterType((*CodeChurnRework)(nil), "CodeChurnRework")
+	proto.RegisterType((*CodeChurnReworkTicks)(nil), "CodeChurnReworkTicks")
+	proto.RegisterMapType((map[int32]*CodeChurnRework)(nil), "CodeChurnReworkTicks.TicksEntry")
+	proto.RegisterType((*CodeChurnResults)(nil), "CodeChurnResults")
+	proto.RegisterMapType((map[int32]*CodeChurnSeries)(nil), "CodeChurnResults.PeopleEntry")
+	proto.RegisterMapType((map[int32]*CodeChurnReworkTicks)(nil), "CodeChurnResults.ReworkEntry")
 	proto.RegisterType((*BusFactorTickSnapshot)(nil), "BusFactorTickSnapshot")
 	proto.RegisterMapType((map[int32]int64)(nil), "BusFactorTickSnapshot.AuthorLinesEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "BusFactorTickSnapshot.SubsystemsEntry")
 	proto.RegisterType((*BusFactorAnalysisResults)(nil), "BusFactorAnalysisResults")
+	proto.RegisterMapType((map[string]*FileOwners)(nil), "BusFactorAnalysisResults.FilesOwnershipEntry")
 	proto.RegisterMapType((map[int32]*BusFactorTickSnapshot)(nil), "BusFactorAnalysisResults.SnapshotsEntry")
 	proto.RegisterMapType((map[string]int32)(nil), "BusFactorAnalysisResults.SubsystemBusFactorEntry")
+	proto.RegisterType((*BusFactorRemoval)(nil), "BusFactorRemoval")
+	proto.RegisterMapType((map[string]float32)(nil), "BusFactorRemoval.GapsEntry")
+	proto.RegisterType((*FileOwners)(nil), "FileOwners")
 	proto.RegisterType((*OwnershipConcentrationTickSnapshot)(nil), "OwnershipConcentrationTickSnapshot")
 	proto.RegisterMapType((map[int32]int64)(nil), "OwnershipConcentrationTickSnapshot.AuthorLinesEntry")
 	proto.RegisterType((*OwnershipConcentrationResults)(nil), "OwnershipConcentrationResults")
@@ -2964,20 +7425,105 @@ func init() {
 	proto.RegisterType((*KnowledgeDiffusionFileData)(nil), "KnowledgeDiffusionFileData")
 	proto.RegisterMapType((map[int32]int32)(nil), "KnowledgeDiffusionFileData.UniqueEditorsOverTimeEntry")
 	proto.RegisterType((*KnowledgeDiffusionResults)(nil), "KnowledgeDiffusionResults")
+	proto.RegisterMapType((map[string]*KnowledgeDiffusionDirectoryData)(nil), "KnowledgeDiffusionResults.DirectoriesEntry")
 	proto.RegisterMapType((map[int32]int32)(nil), "KnowledgeDiffusionResults.DistributionEntry")
 	proto.RegisterMapType((map[string]*KnowledgeDiffusionFileData)(nil), "KnowledgeDiffusionResults.FilesEntry")
+	proto.RegisterType((*KnowledgeDiffusionDirectoryData)(nil), "KnowledgeDiffusionDirectoryData")
 	proto.RegisterType((*OnboardingSnapshot)(nil), "OnboardingSnapshot")
 	proto.RegisterType((*OnboardingAverageSnapshot)(nil), "OnboardingAverageSnapshot")
 	proto.RegisterType((*AuthorOnboardingData)(nil), "AuthorOnboardingData")
+	proto.RegisterMapType((map[int32]int32)(nil), "AuthorOnboardingData.MentorsEntry")
 	proto.RegisterMapType((map[int32]*OnboardingSnapshot)(nil), "AuthorOnboardingData.SnapshotsEntry")
 	proto.RegisterType((*CohortStats)(nil), "CohortStats")
 	proto.RegisterMapType((map[int32]*OnboardingAverageSnapshot)(nil), "CohortStats.AverageSnapshotsEntry")
+	proto.RegisterMapType((map[int32]*CohortRetention)(nil), "CohortStats.RetentionEntry")
+	proto.RegisterType((*CohortRetention)(nil), "CohortRetention")
 	proto.RegisterType((*OnboardingResults)(nil), "OnboardingResults")
 	proto.RegisterMapType((map[int32]*AuthorOnboardingData)(nil), "OnboardingResults.AuthorsEntry")
 	proto.RegisterMapType((map[string]*CohortStats)(nil), "OnboardingResults.CohortsEntry")
 	proto.RegisterType((*FileRisk)(nil), "FileRisk")
+	proto.RegisterType((*LanguageRisk)(nil), "LanguageRisk")
 	proto.RegisterType((*HotspotRiskResults)(nil), "HotspotRiskResults")
+	proto.RegisterType((*HotspotRiskSnapshot)(nil), "HotspotRiskSnapshot")
+	proto.RegisterType((*HotspotRiskEntry)(nil), "HotspotRiskEntry")
 	proto.RegisterType((*RefactoringProxyResults)(nil), "RefactoringProxyResults")
+	proto.RegisterType((*CommentDensityStats)(nil), "CommentDensityStats")
+	proto.RegisterType((*CommentDensityTick)(nil), "CommentDensityTick")
+	proto.RegisterMapType((map[string]*CommentDensityStats)(nil), "CommentDensityTick.SubsystemsEntry")
+	proto.RegisterType((*CommentDensityErosion)(nil), "CommentDensityErosion")
+	proto.RegisterType((*CommentDensityResults)(nil), "CommentDensityResults")
+	proto.RegisterMapType((map[string]*CommentDensityStats)(nil), "CommentDensityResults.FilesEntry")
+	proto.RegisterMapType((map[int32]*CommentDensityTick)(nil), "CommentDensityResults.TicksEntry")
+	proto.RegisterType((*RegexMetricsTick)(nil), "RegexMetricsTick")
+	proto.RegisterMapType((map[string]*RegexMetricsCounts)(nil), "RegexMetricsTick.SubsystemsEntry")
+	proto.RegisterType((*RegexMetricsCounts)(nil), "RegexMetricsCounts")
+	proto.RegisterType((*RegexMetricsResults)(nil), "RegexMetricsResults")
+	proto.RegisterMapType((map[int32]*RegexMetricsTick)(nil), "RegexMetricsResults.TicksEntry")
+	proto.RegisterType((*TestChurnTick)(nil), "TestChurnTick")
+	proto.RegisterType((*TestChurnSuite)(nil), "TestChurnSuite")
+	proto.RegisterType((*TestChurnResults)(nil), "TestChurnResults")
+	proto.RegisterMapType((map[int32]*TestChurnTick)(nil), "TestChurnResults.TicksEntry")
+	proto.RegisterType((*CodeAgePyramidCounts)(nil), "CodeAgePyramidCounts")
+	proto.RegisterType((*CodeAgePyramidResults)(nil), "CodeAgePyramidResults")
+	proto.RegisterMapType((map[string]*CodeAgePyramidCounts)(nil), "CodeAgePyramidResults.SubsystemsEntry")
+	proto.RegisterType((*RewriteStats)(nil), "RewriteStats")
+	proto.RegisterType((*RewriteRatioResults)(nil), "RewriteRatioResults")
+	proto.RegisterMapType((map[int32]*RewriteStats)(nil), "RewriteRatioResults.PeopleEntry")
+	proto.RegisterMapType((map[string]*RewriteStats)(nil), "RewriteRatioResults.SubsystemsEntry")
+	proto.RegisterType((*CrossTimezonePair)(nil), "CrossTimezonePair")
+	proto.RegisterType((*CrossTimezoneResults)(nil), "CrossTimezoneResults")
+	proto.RegisterMapType((map[int32]int32)(nil), "CrossTimezoneResults.OffsetsEntry")
+	proto.RegisterType((*AbsencePeriod)(nil), "AbsencePeriod")
+	proto.RegisterType((*DeveloperAbsences)(nil), "DeveloperAbsences")
+	proto.RegisterType((*CoverageGap)(nil), "CoverageGap")
+	proto.RegisterType((*AbsenceResults)(nil), "AbsenceResults")
+	proto.RegisterMapType((map[int32]*DeveloperAbsences)(nil), "AbsenceResults.DevelopersEntry")
+	proto.RegisterMapType((map[string]int32)(nil), "AbsenceResults.OwnersEntry")
+	proto.RegisterType((*DiversityQuarter)(nil), "DiversityQuarter")
+	proto.RegisterMapType((map[int32]int32)(nil), "DiversityQuarter.CommitsEntry")
+	proto.RegisterType((*ContributionDiversityResults)(nil), "ContributionDiversityResults")
+	proto.RegisterMapType((map[int32]*DiversityQuarter)(nil), "ContributionDiversityResults.QuartersEntry")
+	proto.RegisterType((*FunnelContributions)(nil), "FunnelContributions")
+	proto.RegisterType((*ContributionFunnelResults)(nil), "ContributionFunnelResults")
+	proto.RegisterMapType((map[int32]*FunnelContributions)(nil), "ContributionFunnelResults.ContributionsEntry")
+	proto.RegisterType((*SelfMergeCounts)(nil), "SelfMergeCounts")
+	proto.RegisterType((*SelfMergeResults)(nil), "SelfMergeResults")
+	proto.RegisterMapType((map[string]*SelfMergeCounts)(nil), "SelfMergeResults.MonthsEntry")
+	proto.RegisterMapType((map[string]*SelfMergeCounts)(nil), "SelfMergeResults.SubsystemsEntry")
+	proto.RegisterType((*WorkingSet)(nil), "WorkingSet")
+	proto.RegisterType((*MonthlyWorkingSets)(nil), "MonthlyWorkingSets")
+	proto.RegisterMapType((map[int32]*WorkingSet)(nil), "MonthlyWorkingSets.DevelopersEntry")
+	proto.RegisterType((*WorkingSetOverlapResults)(nil), "WorkingSetOverlapResults")
+	proto.RegisterMapType((map[string]*MonthlyWorkingSets)(nil), "WorkingSetOverlapResults.MonthsEntry")
+	proto.RegisterType((*BlameSegment)(nil), "BlameSegment")
+	proto.RegisterType((*BlameFile)(nil), "BlameFile")
+	proto.RegisterType((*BlameDumperResults)(nil), "BlameDumperResults")
+	proto.RegisterMapType((map[string]*BlameFile)(nil), "BlameDumperResults.FilesEntry")
+	proto.RegisterType((*LineHistoryChange)(nil), "LineHistoryChange")
+	proto.RegisterType((*LineHistoryCommit)(nil), "LineHistoryCommit")
+	proto.RegisterMapType((map[int32]string)(nil), "LineHistoryCommit.NamesEntry")
+	proto.RegisterType((*LineHistoryDumpResults)(nil), "LineHistoryDumpResults")
+	proto.RegisterMapType((map[int32]string)(nil), "LineHistoryDumpResults.FilesEntry")
+	proto.RegisterType((*TopologyProject)(nil), "TopologyProject")
+	proto.RegisterType((*TopologyEdge)(nil), "TopologyEdge")
+	proto.RegisterType((*TopologyResults)(nil), "TopologyResults")
+	proto.RegisterType((*CommitSizeHistogram)(nil), "CommitSizeHistogram")
+	proto.RegisterType((*CommitSizeTick)(nil), "CommitSizeTick")
+	proto.RegisterType((*MegaCommit)(nil), "MegaCommit")
+	proto.RegisterType((*CommitSizeResults)(nil), "CommitSizeResults")
+	proto.RegisterMapType((map[int32]*CommitSizeHistogram)(nil), "CommitSizeResults.PeopleEntry")
+	proto.RegisterMapType((map[int32]*CommitSizeTick)(nil), "CommitSizeResults.TicksEntry")
+	proto.RegisterType((*ReviewLatencyStats)(nil), "ReviewLatencyStats")
+	proto.RegisterType((*Integration)(nil), "Integration")
+	proto.RegisterType((*ReviewLatencyResults)(nil), "ReviewLatencyResults")
+	proto.RegisterMapType((map[int32]*ReviewLatencyStats)(nil), "ReviewLatencyResults.PeopleEntry")
+	proto.RegisterMapType((map[int32]*ReviewLatencyStats)(nil), "ReviewLatencyResults.TicksEntry")
+	proto.RegisterType((*KnowledgeLossCounts)(nil), "KnowledgeLossCounts")
+	proto.RegisterType((*KnowledgeLossSnapshot)(nil), "KnowledgeLossSnapshot")
+	proto.RegisterMapType((map[string]*KnowledgeLossCounts)(nil), "KnowledgeLossSnapshot.DirectoriesEntry")
+	proto.RegisterType((*KnowledgeLossResults)(nil), "KnowledgeLossResults")
+	proto.RegisterMapType((map[int32]int32)(nil), "KnowledgeLossResults.DepartedEntry")
+	proto.RegisterMapType((map[int32]*KnowledgeLossSnapshot)(nil), "KnowledgeLossResults.SnapshotsEntry")
 	proto.RegisterType((*AnalysisResults)(nil), "AnalysisResults")
 	proto.RegisterMapType((map[string][]byte)(nil), "AnalysisResults.ContentsEntry")
 }
@@ -2985,206 +7531,462 @@ func init() {
 func init() { proto.RegisterFile("pb.proto", fileDescriptor_f80abaa17e25ccc8) }
 
 var fileDescriptor_f80abaa17e25ccc8 = []byte{
-	// 3209 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4f, 0x6c, 0x1b, 0xc7,
-	0xd5, 0xc7, 0xf2, 0x8f, 0x48, 0x3e, 0x52, 0xa4, 0x35, 0xa2, 0x2d, 0x9a, 0xfe, 0x6c, 0x2b, 0xb4,
-	0x13, 0x2b, 0x76, 0xbc, 0xb6, 0x95, 0xe4, 0xfb, 0xe2, 0x04, 0xf8, 0xbe, 0x4f, 0x96, 0xec, 0xca,
-	0x49, 0x1c, 0xdb, 0x2b, 0x39, 0x69, 0x2e, 0x59, 0xac, 0xb8, 0x23, 0x72, 0x63, 0x72, 0x97, 0x99,
-	0xd9, 0xa5, 0xac, 0xa0, 0x05, 0x7a, 0xe8, 0xa1, 0x87, 0x5e, 0x7b, 0x2d, 0x50, 0xf4, 0x52, 0xb4,
-	0xc7, 0xf6, 0xd8, 0xde, 0x8a, 0x02, 0x45, 0x6f, 0x05, 0x7a, 0x28, 0x7a, 0x2c, 0xd0, 0x6b, 0x81,
-	0xa2, 0xa7, 0x9c, 0x8a, 0xf9, 0xb7, 0x3b, 0xbb, 0x5c, 0x52, 0x52, 0x81, 0xde, 0x38, 0xef, 0xfd,
-	0x66, 0xe6, 0xbd, 0x37, 0xef, 0xdf, 0xcc, 0x12, 0xaa, 0x93, 0x03, 0x73, 0x42, 0x82, 0x30, 0xe8,
-	0xfd, 0xad, 0x00, 0xd5, 0x27, 0x38, 0x74, 0x5c, 0x27, 0x74, 0x50, 0x07, 0x2a, 0x53, 0x4c, 0xa8,
-	0x17, 0xf8, 0x1d, 0x63, 0xdd, 0xd8, 0x28, 0x5b, 0x6a, 0x88, 0x10, 0x94, 0x86, 0x0e, 0x1d, 0x76,
-	0x0a, 0xeb, 0xc6, 0x46, 0xcd, 0xe2, 0xbf, 0xd1, 0x15, 0x00, 0x82, 0x27, 0x01, 0xf5, 0xc2, 0x80,
-	0x1c, 0x77, 0x8a, 0x9c, 0xa3, 0x51, 0xd0, 0x1b, 0xd0, 0x3a, 0xc0, 0x03, 0xcf, 0xb7, 0x23, 0xdf,
-	0x7b, 0x65, 0x87, 0xde, 0x18, 0x77, 0x4a, 0xeb, 0xc6, 0x46, 0xd1, 0x5a, 0xe6, 0xe4, 0x17, 0xbe,
-	0xf7, 0x6a, 0xdf, 0x1b, 0x63, 0xd4, 0x83, 0x65, 0xec, 0xbb, 0x1a, 0xaa, 0xcc, 0x51, 0x75, 0xec,
-	0xbb, 0x31, 0xa6, 0x03, 0x95, 0x7e, 0x30, 0x1e, 0x7b, 0x21, 0xed, 0x2c, 0x09, 0xc9, 0xe4, 0x10,
-	0x5d, 0x84, 0x2a, 0x89, 0x7c, 0x31, 0xb1, 0xc2, 0x27, 0x56, 0x48, 0xe4, 0xf3, 0x49, 0xbb, 0xb0,
-	0xa2, 0x58, 0xf6, 0x04, 0x13, 0xdb, 0x0b, 0xf1, 0xb8, 0x53, 0x5d, 0x2f, 0x6e, 0xd4, 0x37, 0x2f,
-	0x9b, 0x4a, 0x69, 0xd3, 0x12, 0xe8, 0x67, 0x98, 0x3c, 0x0e, 0xf1, 0xf8, 0xa1, 0x1f, 0x92, 0x63,
-	0xab, 0x49, 0x52, 0xc4, 0xee, 0x16, 0xac, 0xe6, 0xc0, 0xd0, 0x39, 0x28, 0xbe, 0xc4, 0xc7, 0xdc,
-	0x56, 0x35, 0x8b, 0xfd, 0x44, 0x6d, 0x28, 0x4f, 0x9d, 0x51, 0x84, 0xb9, 0xa1, 0x0c, 0x4b, 0x0c,
-	0xde, 0x2f, 0xbc, 0x67, 0xf4, 0xde, 0x86, 0xb5, 0x07, 0x11, 0xf1, 0xdd, 0xe0, 0xc8, 0xdf, 0x9b,
-	0x38, 0x84, 0xe2, 0x27, 0x4e, 0x48, 0xbc, 0x57, 0x56, 0x70, 0x24, 0x94, 0x1b, 0x45, 0x63, 0x9f,
-	0x76, 0x8c, 0xf5, 0xe2, 0xc6, 0xb2, 0xa5, 0x86, 0xbd, 0x9f, 0x1b, 0xd0, 0xce, 0x9b, 0xc5, 0xce,
-	0xc3, 0x77, 0xc6, 0x58, 0x6e, 0xcd, 0x7f, 0xa3, 0xeb, 0xd0, 0xf4, 0xa3, 0xf1, 0x01, 0x26, 0x76,
-	0x70, 0x68, 0x93, 0xe0, 0x88, 0x72, 0x21, 0xca, 0x56, 0x43, 0x50, 0x9f, 0x1e, 0x5a, 0xc1, 0x11,
-	0x45, 0x37, 0x61, 0x25, 0x41, 0xa9, 0x6d, 0x8b, 0x1c, 0xd8, 0x52, 0xc0, 0x6d, 0x41, 0x46, 0x6f,
-	0x41, 0x89, 0xaf, 0x53, 0xe2, 0x36, 0xeb, 0x98, 0x73, 0x14, 0xb0, 0x38, 0xaa, 0xf7, 0x1d, 0x68,
-	0x3e, 0xf2, 0x46, 0x98, 0x3e, 0x3d, 0xf2, 0x31, 0xa1, 0x43, 0x6f, 0x82, 0xee, 0x2a, 0x6b, 0x18,
-	0x7c, 0x81, 0xae, 0x99, 0xe6, 0x9b, 0x9f, 0x32, 0xa6, 0xb0, 0xb8, 0x00, 0x76, 0xdf, 0x03, 0x48,
-	0x88, 0xba, 0x7d, 0xcb, 0x39, 0xf6, 0x2d, 0xeb, 0xf6, 0xfd, 0x47, 0x31, 0x31, 0xf0, 0x96, 0xef,
-	0x8c, 0x8e, 0xa9, 0x47, 0x2d, 0x4c, 0xa3, 0x51, 0x48, 0xd1, 0x3a, 0xd4, 0x07, 0xc4, 0xf1, 0xa3,
-	0x91, 0x43, 0xbc, 0x50, 0xad, 0xa7, 0x93, 0x50, 0x17, 0xaa, 0xd4, 0x19, 0x4f, 0x46, 0x9e, 0x3f,
-	0x90, 0x4b, 0xc7, 0x63, 0x74, 0x07, 0x2a, 0x13, 0x12, 0x7c, 0x89, 0xfb, 0x21, 0xb7, 0x53, 0x7d,
-	0xf3, 0x7c, 0xbe, 0x21, 0x14, 0x0a, 0xdd, 0x82, 0xf2, 0x21, 0x53, 0x54, 0xda, 0x6d, 0x0e, 0x5c,
-	0x60, 0xd0, 0x6d, 0x58, 0x9a, 0xe0, 0x60, 0x32, 0x62, 0x6e, 0xbf, 0x00, 0x2d, 0x41, 0xe8, 0x31,
-	0x20, 0xf1, 0xcb, 0xf6, 0xfc, 0x10, 0x13, 0xa7, 0x1f, 0xb2, 0x68, 0x5d, 0xe2, 0x72, 0x75, 0xcd,
-	0xed, 0x60, 0x3c, 0x21, 0x98, 0x52, 0xec, 0x8a, 0xc9, 0x56, 0x70, 0x24, 0xe7, 0xaf, 0x88, 0x59,
-	0x8f, 0x93, 0x49, 0xe8, 0x3d, 0x68, 0x71, 0x11, 0xec, 0x40, 0x1d, 0x48, 0xa7, 0xc2, 0x45, 0x68,
-	0x65, 0xce, 0xc9, 0x6a, 0x1e, 0xa6, 0xcf, 0xf5, 0x12, 0xd4, 0x42, 0xaf, 0xff, 0xd2, 0xa6, 0xde,
-	0xd7, 0xb8, 0x53, 0xe5, 0x41, 0x57, 0x65, 0x84, 0x3d, 0xef, 0x6b, 0x8c, 0xee, 0xc0, 0x6a, 0x92,
-	0x04, 0x6c, 0x8a, 0xbf, 0x8a, 0xb0, 0xdf, 0xc7, 0x9d, 0xda, 0x7a, 0x71, 0xa3, 0x66, 0xa1, 0x84,
-	0xb5, 0x27, 0x39, 0xe8, 0x3e, 0x34, 0x62, 0xaa, 0x87, 0x69, 0x07, 0x16, 0xd9, 0x21, 0x05, 0xed,
-	0xfd, 0xd2, 0x80, 0x8b, 0x73, 0x75, 0xce, 0x09, 0x08, 0xe3, 0xb4, 0x01, 0x51, 0xc8, 0x0f, 0x08,
-	0x04, 0x25, 0x96, 0x33, 0x3a, 0xc5, 0xf5, 0xe2, 0x46, 0xd1, 0x2a, 0xa9, 0xa4, 0xe9, 0xf9, 0xae,
-	0xd7, 0x97, 0xe7, 0x5d, 0xb6, 0xd4, 0x10, 0x5d, 0x80, 0x25, 0xcf, 0x77, 0x27, 0x21, 0xe1, 0x47,
-	0x5b, 0xb4, 0xe4, 0xa8, 0xb7, 0x07, 0x95, 0xed, 0x20, 0x9a, 0xb0, 0xd3, 0x6f, 0x43, 0xd9, 0xf3,
-	0x5d, 0xfc, 0x8a, 0x47, 0x48, 0xcd, 0x12, 0x03, 0xb4, 0x09, 0x4b, 0x63, 0xae, 0x02, 0x97, 0x63,
-	0xf1, 0xc1, 0x4a, 0x64, 0xef, 0x3a, 0x34, 0xf6, 0x83, 0xa8, 0x3f, 0xc4, 0x2e, 0x3f, 0x3c, 0xb6,
-	0xb2, 0x70, 0x42, 0x83, 0x0b, 0x25, 0x06, 0xbd, 0xdf, 0x1b, 0x70, 0x41, 0xee, 0x9d, 0x0d, 0x92,
-	0x5b, 0xd0, 0x60, 0x18, 0xbb, 0x2f, 0xd8, 0xd2, 0xa7, 0xaa, 0xa6, 0x84, 0x5b, 0x75, 0xc6, 0x55,
-	0x72, 0xdf, 0x81, 0xa6, 0x74, 0x43, 0x05, 0xaf, 0x64, 0xe0, 0xcb, 0x82, 0xaf, 0x26, 0xdc, 0x85,
-	0x86, 0x9c, 0x20, 0xa4, 0x12, 0x69, 0x78, 0xd9, 0xd4, 0x65, 0xb6, 0xea, 0x02, 0x22, 0x14, 0xb8,
-	0x0a, 0x75, 0xe1, 0x9e, 0x23, 0xcf, 0xc7, 0x94, 0xfb, 0x4f, 0xd9, 0x02, 0x4e, 0xfa, 0x98, 0x51,
-	0x7a, 0xbf, 0x35, 0xa0, 0xb9, 0x37, 0x0c, 0x42, 0x1f, 0x53, 0x6a, 0xe1, 0x7e, 0x40, 0x5c, 0x76,
-	0x3e, 0xe1, 0xf1, 0x24, 0x4e, 0x8b, 0xec, 0x77, 0x9c, 0x2a, 0x0b, 0x5a, 0xaa, 0x44, 0x50, 0x62,
-	0x0b, 0xc9, 0xa2, 0xc5, 0x7f, 0xa3, 0xfb, 0x50, 0xed, 0x07, 0x11, 0x8b, 0x0f, 0x15, 0xb8, 0x97,
-	0xcd, 0xf4, 0xf2, 0x4c, 0x37, 0xce, 0x17, 0x29, 0x2b, 0x86, 0x77, 0x3f, 0x80, 0xe5, 0x14, 0xeb,
-	0x4c, 0x89, 0x6b, 0x07, 0xd6, 0xd4, 0x36, 0xd9, 0x23, 0x79, 0x13, 0x2a, 0x84, 0xef, 0x4c, 0x65,
-	0x06, 0x6d, 0x65, 0x24, 0xb2, 0x14, 0xbf, 0xf7, 0x47, 0x03, 0xea, 0xcc, 0x6e, 0xbb, 0x1e, 0xe5,
-	0xc5, 0x57, 0x2b, 0x98, 0xc2, 0xb5, 0xe2, 0x82, 0xf9, 0x29, 0xb4, 0xfb, 0x43, 0xc7, 0x1f, 0x60,
-	0x6a, 0x1f, 0x1c, 0xdb, 0x2e, 0x9e, 0xe2, 0x51, 0x30, 0xc1, 0xa4, 0x53, 0xe0, 0x3b, 0x5c, 0x37,
-	0xb5, 0x55, 0xcc, 0x6d, 0x01, 0x7c, 0x70, 0xbc, 0xa3, 0x60, 0x42, 0x75, 0xd4, 0x9f, 0x61, 0x74,
-	0x9f, 0xc3, 0xda, 0x1c, 0x78, 0x8e, 0x39, 0xd6, 0x75, 0x73, 0xd4, 0x37, 0xc1, 0x64, 0x47, 0xba,
-	0x17, 0x3a, 0x21, 0xd5, 0x4d, 0xf3, 0x63, 0x03, 0x3a, 0x9a, 0x38, 0xc2, 0x2c, 0x4f, 0x30, 0xa5,
-	0xce, 0x00, 0xa3, 0xf7, 0x75, 0x07, 0xcf, 0x08, 0x9e, 0x42, 0x8a, 0x6c, 0x26, 0xcb, 0x0c, 0x9f,
-	0xd2, 0x7d, 0x04, 0x90, 0x10, 0x73, 0xca, 0x78, 0x2f, 0x2d, 0x5e, 0x23, 0xb5, 0xb6, 0x26, 0xe0,
-	0x0b, 0xa8, 0xc5, 0x82, 0xb3, 0x23, 0x76, 0x5c, 0x17, 0xbb, 0x52, 0x4f, 0x31, 0x60, 0x07, 0x41,
-	0xf0, 0x38, 0x98, 0x62, 0x57, 0x1e, 0xbd, 0x1a, 0xf2, 0x23, 0xe2, 0x06, 0x73, 0x65, 0xfd, 0x55,
-	0xc3, 0xde, 0xef, 0x0c, 0xa8, 0xec, 0xe0, 0xe9, 0xbe, 0xd7, 0x7f, 0x99, 0x3e, 0xc8, 0x54, 0xe7,
-	0xb3, 0x0e, 0x65, 0xca, 0x36, 0xce, 0xb3, 0x21, 0x67, 0xa0, 0x77, 0xa1, 0x36, 0x72, 0xfc, 0x41,
-	0xe4, 0x0c, 0x30, 0xe5, 0x39, 0xab, 0xbe, 0xb9, 0x66, 0xca, 0x85, 0xcd, 0x8f, 0x15, 0x47, 0x58,
-	0x26, 0x41, 0x76, 0x77, 0xa1, 0x99, 0x66, 0xe6, 0x58, 0xe8, 0x74, 0x07, 0x38, 0x85, 0x2a, 0xdb,
-	0x6b, 0x07, 0x4f, 0x29, 0xba, 0x01, 0x25, 0x17, 0x4f, 0xd5, 0x71, 0xad, 0x9a, 0x8a, 0xc1, 0x04,
-	0x92, 0x32, 0x70, 0x40, 0x77, 0x0b, 0x6a, 0x31, 0x29, 0xc7, 0x75, 0xae, 0xa4, 0x77, 0xae, 0x2a,
-	0x85, 0xf4, 0x7d, 0xff, 0x60, 0xc0, 0x2a, 0x5b, 0x23, 0x1b, 0x50, 0xef, 0x42, 0x99, 0xd5, 0x29,
-	0x25, 0xc4, 0x55, 0x33, 0x07, 0xc4, 0x05, 0x53, 0xee, 0xc2, 0xd1, 0xac, 0xde, 0xb9, 0x78, 0x6a,
-	0x8b, 0x4c, 0x5d, 0xe0, 0xe1, 0x54, 0x75, 0xf1, 0xf4, 0x31, 0x4f, 0xd6, 0x8b, 0x8a, 0x61, 0x77,
-	0x1b, 0x20, 0x59, 0x2e, 0x47, 0x99, 0xab, 0x69, 0x65, 0x6a, 0xb1, 0x55, 0x74, 0x6d, 0x3e, 0x83,
-	0xda, 0x1e, 0xf6, 0x59, 0x1b, 0xeb, 0x87, 0x49, 0x22, 0x61, 0xab, 0x14, 0x24, 0x8c, 0xf5, 0x2f,
-	0xcc, 0x2d, 0xb0, 0xcf, 0xdd, 0x81, 0x0b, 0xa8, 0xc6, 0xba, 0x07, 0x15, 0x53, 0xa9, 0x80, 0x65,
-	0xd0, 0xb5, 0x6d, 0x01, 0x8b, 0x37, 0x50, 0xa6, 0xfa, 0x1c, 0x56, 0xa8, 0xa2, 0xb1, 0x44, 0xc1,
-	0x54, 0x92, 0x66, 0xbb, 0x6d, 0xce, 0x99, 0x64, 0xc6, 0x84, 0x07, 0xc7, 0x4c, 0x11, 0x61, 0xc4,
-	0x16, 0x4d, 0x53, 0xbb, 0x9f, 0x40, 0x3b, 0x0f, 0x78, 0x9a, 0x34, 0x91, 0xec, 0xa8, 0xd9, 0xe7,
-	0x0b, 0x80, 0x6d, 0xae, 0x11, 0x8b, 0xd2, 0xdc, 0xd6, 0xb8, 0x0b, 0x55, 0xe5, 0xde, 0x32, 0xe7,
-	0xc7, 0xe3, 0x24, 0x8c, 0x4a, 0x73, 0xc2, 0xa8, 0xf7, 0x5d, 0x58, 0x12, 0xeb, 0xc7, 0xd7, 0x20,
-	0x43, 0xbb, 0x06, 0x5d, 0x87, 0xe6, 0xd1, 0x10, 0xeb, 0xb7, 0x9c, 0x02, 0x77, 0x82, 0x06, 0xa3,
-	0xc6, 0x17, 0x98, 0x0b, 0xb0, 0xe4, 0x44, 0xe1, 0x30, 0x20, 0x32, 0xd6, 0xe5, 0x08, 0xbd, 0x96,
-	0xee, 0x15, 0xeb, 0x66, 0xa2, 0x89, 0xaa, 0xd9, 0x5f, 0xb0, 0x92, 0xcd, 0x0f, 0x2c, 0xeb, 0xce,
-	0xaf, 0xa5, 0x93, 0x7c, 0x7d, 0xb3, 0x22, 0xa7, 0x27, 0x49, 0xe2, 0x35, 0x68, 0x88, 0x9d, 0x52,
-	0xde, 0x5b, 0x17, 0x34, 0xee, 0xc0, 0xbd, 0x29, 0x94, 0xf6, 0x8f, 0x27, 0x01, 0xf3, 0xac, 0x23,
-	0x12, 0xf8, 0x03, 0xa9, 0x9d, 0x18, 0x08, 0xef, 0x21, 0x84, 0x75, 0xbf, 0xa2, 0x82, 0xaa, 0x21,
-	0x53, 0x49, 0xec, 0x22, 0x4d, 0x2a, 0x47, 0x71, 0x71, 0x2d, 0x69, 0xc5, 0x15, 0x41, 0x89, 0x95,
-	0x71, 0x7e, 0xb5, 0x2b, 0x5b, 0xfc, 0x77, 0xef, 0x16, 0x34, 0xd8, 0xbe, 0x74, 0xc7, 0x09, 0x1d,
-	0x8a, 0x43, 0x74, 0x09, 0xca, 0x21, 0x1b, 0x4b, 0x5d, 0xca, 0x26, 0xe3, 0x5a, 0x82, 0xd6, 0xfb,
-	0x9e, 0x01, 0xcd, 0xc7, 0xe3, 0x49, 0x40, 0x42, 0xfa, 0x0c, 0x13, 0x9e, 0x19, 0xdf, 0x66, 0xfb,
-	0x47, 0x7e, 0xac, 0xfc, 0x25, 0x33, 0x0d, 0x10, 0xe5, 0x5a, 0x46, 0xb2, 0x84, 0x76, 0xef, 0x43,
-	0x5d, 0x23, 0x9f, 0x54, 0xa8, 0x8b, 0xba, 0x9b, 0xfd, 0xc8, 0x00, 0x94, 0xec, 0xa0, 0x32, 0x24,
-	0x7a, 0x27, 0x9d, 0x53, 0xae, 0x98, 0xb3, 0x98, 0xd9, 0x94, 0xd2, 0x7d, 0x3c, 0x2f, 0x31, 0xc8,
-	0xfc, 0xfa, 0x7a, 0xda, 0xf3, 0x5b, 0x19, 0xdd, 0x74, 0xb9, 0x7e, 0x61, 0xc0, 0x6a, 0xc2, 0x8d,
-	0x4b, 0x2f, 0xda, 0xd2, 0xb3, 0xbf, 0x10, 0xee, 0x9a, 0x99, 0x03, 0x5c, 0x50, 0x09, 0x9e, 0x9f,
-	0xa2, 0x12, 0xbc, 0x99, 0x96, 0x74, 0x35, 0x47, 0x7f, 0x5d, 0xda, 0x1f, 0x1a, 0xd0, 0xcd, 0x11,
-	0x42, 0xb9, 0xb4, 0x09, 0x15, 0x4f, 0x70, 0xa5, 0xc8, 0xed, 0x3c, 0x91, 0x2d, 0x05, 0x3a, 0x85,
-	0x7f, 0xa7, 0x13, 0x74, 0x31, 0x9d, 0xa0, 0x7b, 0xdb, 0xb0, 0xb2, 0x8f, 0xd9, 0x5a, 0xce, 0x68,
-	0x87, 0x25, 0x16, 0xfe, 0xda, 0x91, 0x69, 0x9e, 0xb4, 0x9a, 0xdb, 0x86, 0xb2, 0x68, 0x47, 0x0b,
-	0xa2, 0xab, 0xe6, 0x03, 0x56, 0x6e, 0x2e, 0xc6, 0xb2, 0xa9, 0xe5, 0xb6, 0xfa, 0xa1, 0x37, 0x65,
-	0x77, 0x4b, 0x13, 0xaa, 0x47, 0x18, 0xbf, 0x74, 0x9d, 0x63, 0x51, 0xc2, 0xeb, 0x9b, 0xc8, 0x9c,
-	0xd9, 0xd3, 0x8a, 0x31, 0x68, 0x03, 0xca, 0xc3, 0x20, 0x22, 0xaa, 0xae, 0xe7, 0x81, 0x05, 0x00,
-	0xdd, 0x84, 0xa5, 0x71, 0xe0, 0x87, 0x43, 0x2a, 0x2f, 0xa6, 0x79, 0x50, 0x89, 0x60, 0xab, 0xb2,
-	0x1d, 0x54, 0x9a, 0xcb, 0x5d, 0x95, 0x03, 0x58, 0xd7, 0xd5, 0xce, 0x2a, 0x71, 0x42, 0x2b, 0xa2,
-	0x99, 0xc5, 0x88, 0xcd, 0xc2, 0xf0, 0x52, 0x29, 0xd5, 0xe0, 0xc8, 0x21, 0xcf, 0xa3, 0x41, 0x44,
-	0xb8, 0x2c, 0x65, 0x8b, 0xff, 0x66, 0x6b, 0x70, 0x51, 0x65, 0x8e, 0x10, 0x03, 0x86, 0x64, 0x93,
-	0xe4, 0xab, 0x0f, 0xff, 0xdd, 0xfb, 0xa9, 0x01, 0x9d, 0x3c, 0x01, 0x79, 0x9b, 0xf1, 0x3f, 0xa9,
-	0x36, 0xe3, 0x9a, 0x39, 0x0f, 0x38, 0xd3, 0x76, 0x7c, 0xb2, 0xb8, 0xed, 0xb8, 0x95, 0x76, 0xf3,
-	0xf3, 0xb9, 0x0b, 0xeb, 0x8e, 0xfe, 0x83, 0x22, 0xac, 0x65, 0x31, 0xca, 0xcb, 0x77, 0x01, 0x1c,
-	0x41, 0xf2, 0xe2, 0xd8, 0xdc, 0x30, 0xe7, 0xa0, 0xcd, 0xad, 0x18, 0x2a, 0xe4, 0xd5, 0xe6, 0x2e,
-	0x6e, 0x4d, 0xee, 0xab, 0xd4, 0x54, 0x9c, 0x63, 0x8c, 0x85, 0x2d, 0x4f, 0x12, 0x34, 0xa5, 0x4c,
-	0x57, 0xf3, 0x39, 0xb4, 0x32, 0x32, 0xe5, 0x18, 0xec, 0x6e, 0xda, 0x60, 0x5d, 0x73, 0x6e, 0x84,
-	0x68, 0x56, 0xeb, 0xee, 0x9d, 0xd0, 0x30, 0xdd, 0x49, 0xaf, 0x7a, 0x71, 0xee, 0xf9, 0xea, 0x47,
-	0xf1, 0x57, 0x03, 0xce, 0x3f, 0x88, 0xe8, 0x23, 0xa7, 0x1f, 0x06, 0x3c, 0x7d, 0xee, 0xf9, 0xce,
-	0x84, 0x0e, 0x83, 0x10, 0x5d, 0x06, 0x38, 0x88, 0xa8, 0x7d, 0xc8, 0x39, 0x72, 0x9f, 0xda, 0x81,
-	0x82, 0xb2, 0x3b, 0x68, 0x18, 0x84, 0xce, 0xc8, 0x4e, 0xbc, 0xbb, 0x68, 0x01, 0x27, 0xf1, 0x3b,
-	0x28, 0xfa, 0x30, 0x4e, 0x3f, 0x02, 0x21, 0x0c, 0x7d, 0xc3, 0xcc, 0xdd, 0xcd, 0xdc, 0xe2, 0x50,
-	0x3e, 0x53, 0x18, 0x5b, 0xe6, 0x29, 0x4e, 0xe9, 0xfe, 0x2f, 0x9c, 0xcb, 0x02, 0xce, 0x54, 0x9f,
-	0x7e, 0x5d, 0x84, 0x4e, 0xbc, 0x6f, 0xb6, 0x55, 0x78, 0x04, 0x35, 0x2a, 0xc5, 0x48, 0x1c, 0x6e,
-	0x1e, 0xda, 0x54, 0x12, 0xab, 0x8a, 0x10, 0x4f, 0x45, 0x7d, 0x68, 0xd3, 0xe8, 0x80, 0x1e, 0xd3,
-	0x10, 0x8f, 0x6d, 0xcd, 0x74, 0xe2, 0xf6, 0x78, 0x6f, 0xc1, 0x92, 0x6a, 0x56, 0x8c, 0x90, 0x57,
-	0x49, 0x3a, 0xc3, 0x48, 0x3b, 0x75, 0x71, 0x51, 0xbf, 0x9d, 0xf1, 0x4c, 0xf4, 0x5f, 0x50, 0x0b,
-	0x87, 0x04, 0xd3, 0x61, 0x30, 0x72, 0x79, 0x22, 0x29, 0x58, 0x09, 0xa1, 0xbb, 0x0f, 0xcd, 0xb4,
-	0x66, 0x39, 0xf6, 0x7d, 0x2b, 0xed, 0x60, 0x17, 0xf2, 0x8f, 0x52, 0x77, 0xd9, 0x87, 0xb0, 0x36,
-	0x47, 0xb9, 0x93, 0x1e, 0x88, 0x53, 0xef, 0x00, 0xdf, 0x2f, 0x40, 0x2f, 0x7e, 0x62, 0xdb, 0x0e,
-	0xfc, 0x3e, 0xf6, 0x43, 0xe2, 0x84, 0x5e, 0xe0, 0xa7, 0x3c, 0x16, 0x41, 0x69, 0xe0, 0xf9, 0x1e,
-	0x5f, 0xd3, 0xb0, 0xf8, 0x6f, 0xb6, 0xcd, 0x70, 0xe8, 0xc9, 0x37, 0x67, 0xf6, 0x33, 0xeb, 0xb8,
-	0xc5, 0x19, 0xc7, 0xfd, 0x2c, 0xe3, 0xb8, 0xa2, 0xfd, 0x7c, 0xc7, 0x3c, 0x59, 0x82, 0xff, 0xb0,
-	0x17, 0xff, 0xa6, 0x04, 0x97, 0xf3, 0x85, 0x50, 0xae, 0xfc, 0xd1, 0xac, 0x2b, 0xdf, 0x36, 0x17,
-	0x4e, 0x59, 0xe0, 0xcf, 0xdf, 0x86, 0x66, 0xe2, 0xcf, 0xdc, 0xb0, 0xca, 0x93, 0x4f, 0x58, 0x51,
-	0x4d, 0xfa, 0x96, 0xe7, 0x7b, 0x62, 0xd5, 0x65, 0xaa, 0xd3, 0xd0, 0x0b, 0x48, 0x08, 0x36, 0x3b,
-	0x1e, 0xf1, 0xbe, 0x7b, 0xf7, 0xb4, 0x0b, 0xef, 0x0e, 0xe5, 0xba, 0x0d, 0xaa, 0x91, 0xfe, 0xfd,
-	0xd8, 0xe8, 0x3a, 0xa7, 0xf0, 0xfe, 0xfb, 0x69, 0xef, 0xbf, 0x76, 0x0a, 0x7f, 0xd0, 0x43, 0xe1,
-	0xff, 0x01, 0xcd, 0x1a, 0xe6, 0x2c, 0x9f, 0x49, 0xba, 0xff, 0x07, 0x2b, 0x33, 0x16, 0x38, 0xd3,
-	0x77, 0x96, 0x3f, 0x15, 0xa0, 0xfb, 0x91, 0x1f, 0x1c, 0x8d, 0xb0, 0x3b, 0xc0, 0x3b, 0xde, 0xe1,
-	0x61, 0xc4, 0x7a, 0x1b, 0x76, 0x9f, 0x62, 0xf7, 0x0c, 0x74, 0x17, 0xda, 0x91, 0xef, 0x7d, 0x15,
-	0x61, 0x1b, 0xbb, 0x5e, 0x18, 0x10, 0x6a, 0xf3, 0x8b, 0x81, 0xb4, 0x01, 0x12, 0xbc, 0x87, 0x82,
-	0xc5, 0x2f, 0x0a, 0x28, 0x80, 0x4e, 0x66, 0x46, 0x30, 0xc5, 0x44, 0xdd, 0xf4, 0xd8, 0x91, 0xfe,
-	0xb7, 0x39, 0x7f, 0x43, 0xf3, 0x85, 0xbe, 0xe2, 0xd3, 0x29, 0x6b, 0xdf, 0xc7, 0xf2, 0x9b, 0xc7,
-	0xf9, 0x28, 0x8f, 0xc7, 0x44, 0x24, 0x98, 0xd9, 0x3a, 0x23, 0xa2, 0xe8, 0xa1, 0x90, 0xe0, 0xa5,
-	0x44, 0xec, 0x40, 0x45, 0x84, 0x60, 0xfc, 0x04, 0x2d, 0x87, 0xdd, 0x5d, 0xe8, 0xce, 0x17, 0xe0,
-	0x4c, 0xcf, 0x94, 0x3f, 0x29, 0xc2, 0xc5, 0x59, 0x35, 0x55, 0x4c, 0x7e, 0x90, 0x7e, 0x8c, 0x7b,
-	0xdd, 0x9c, 0x0b, 0x9d, 0x7d, 0x8d, 0x43, 0xcf, 0xa0, 0xe1, 0x7a, 0x34, 0x24, 0xde, 0x41, 0xc4,
-	0xbf, 0x66, 0x08, 0xab, 0xbe, 0xb5, 0x60, 0x8d, 0x1d, 0x0d, 0x2e, 0x83, 0x44, 0x5f, 0x01, 0x5d,
-	0x83, 0xe5, 0x23, 0xcf, 0x77, 0x83, 0x23, 0x5b, 0xeb, 0x8f, 0xcb, 0x56, 0x43, 0x10, 0x9f, 0x88,
-	0x8e, 0x38, 0x15, 0x49, 0xa5, 0x45, 0x91, 0x54, 0xce, 0x44, 0xd2, 0x8b, 0x13, 0x9e, 0x0f, 0xef,
-	0xa5, 0xa3, 0xe8, 0xd2, 0x02, 0xff, 0xc8, 0xf8, 0xfe, 0x8c, 0x62, 0x67, 0x3a, 0xa3, 0x9f, 0x15,
-	0x00, 0x3d, 0xf5, 0x0f, 0x02, 0x87, 0xb8, 0x9e, 0x3f, 0x88, 0x4b, 0xc6, 0x1b, 0xd0, 0x62, 0x17,
-	0x0b, 0x9b, 0x7a, 0x7e, 0x1f, 0xdb, 0x5f, 0x06, 0x9e, 0xfa, 0xbc, 0xbb, 0xcc, 0xc8, 0x7b, 0x8c,
-	0xfa, 0x61, 0xe0, 0x71, 0xab, 0x89, 0xa2, 0xa1, 0xba, 0x7c, 0xf9, 0xfd, 0x90, 0x13, 0xe5, 0x13,
-	0x44, 0x52, 0x59, 0xc4, 0x79, 0x0b, 0xc3, 0x8a, 0xca, 0x12, 0xbf, 0xdb, 0xeb, 0xa5, 0xa7, 0xa4,
-	0x01, 0x44, 0xe9, 0xb9, 0x0d, 0x68, 0x8c, 0x1d, 0xdf, 0xf3, 0x07, 0x87, 0x51, 0xb2, 0x97, 0xe8,
-	0xfa, 0x57, 0x12, 0x8e, 0xda, 0xf0, 0x4d, 0x38, 0xa7, 0xc1, 0xc5, 0xae, 0xe2, 0x36, 0xd0, 0x4a,
-	0xe8, 0x62, 0xeb, 0x34, 0x54, 0xec, 0x5f, 0xc9, 0x42, 0xc5, 0xc7, 0x83, 0x3f, 0x17, 0xe0, 0x62,
-	0x62, 0xaa, 0xad, 0x29, 0x26, 0xce, 0x00, 0x9f, 0xd9, 0x62, 0x37, 0x61, 0xc5, 0x99, 0x0e, 0xec,
-	0x59, 0xab, 0x19, 0x56, 0xcb, 0x99, 0x0e, 0xf6, 0x75, 0xc3, 0xbd, 0x01, 0xad, 0x04, 0x9b, 0x18,
-	0xcf, 0xb0, 0x96, 0x15, 0x52, 0x28, 0x91, 0xc2, 0x25, 0x36, 0xd4, 0x70, 0xc2, 0x8c, 0xef, 0xc0,
-	0x05, 0x86, 0x9b, 0x63, 0x4a, 0xc3, 0x6a, 0x3b, 0xd3, 0xc1, 0x93, 0x19, 0x6b, 0xde, 0x85, 0x76,
-	0x66, 0x56, 0x62, 0x51, 0xc3, 0x42, 0xa9, 0x39, 0x42, 0x9e, 0xd9, 0x19, 0x89, 0x61, 0xb3, 0x33,
-	0x84, 0x6d, 0xbf, 0x31, 0xa0, 0x2d, 0x7a, 0x80, 0xc4, 0xc2, 0x3c, 0xf9, 0xde, 0x84, 0x95, 0x43,
-	0x8f, 0xd0, 0x50, 0x4a, 0xaa, 0xde, 0x14, 0xf9, 0x01, 0x71, 0x86, 0x90, 0x92, 0x5f, 0x36, 0xaf,
-	0x42, 0x9d, 0xd9, 0xdd, 0xee, 0x07, 0xc3, 0x80, 0xa8, 0xb7, 0x27, 0x60, 0xa4, 0x6d, 0x4e, 0x41,
-	0x0f, 0xf4, 0x36, 0xa0, 0x28, 0xbf, 0x01, 0xe4, 0x6d, 0x3b, 0xbf, 0xfa, 0x77, 0x9f, 0x9f, 0xa2,
-	0x24, 0xce, 0xbc, 0x6f, 0xcc, 0x46, 0x98, 0x1e, 0x83, 0xdf, 0x18, 0x50, 0x17, 0x12, 0x8a, 0xaf,
-	0x02, 0xfc, 0x95, 0x8c, 0xab, 0x60, 0xa8, 0x57, 0x32, 0x2e, 0x7e, 0xf2, 0x70, 0x21, 0xb2, 0xbb,
-	0x88, 0x35, 0xd9, 0x4a, 0x89, 0xb4, 0xfe, 0x94, 0x79, 0x17, 0x77, 0x4c, 0x3b, 0xab, 0x69, 0xcf,
-	0xd4, 0xf6, 0x30, 0x33, 0xee, 0x2b, 0xf5, 0x3c, 0xe7, 0x64, 0xc8, 0x5d, 0x1b, 0xce, 0xe7, 0x42,
-	0x4f, 0x73, 0x7b, 0x9b, 0x1b, 0x2c, 0xba, 0xf2, 0xbf, 0x2a, 0xc2, 0x4a, 0x02, 0x54, 0xc5, 0xe1,
-	0x7e, 0x52, 0x9e, 0xd4, 0xbb, 0xfb, 0x0c, 0x48, 0x9e, 0x9c, 0x14, 0x5d, 0xe1, 0xd9, 0x54, 0x61,
-	0x2f, 0x2a, 0xab, 0x42, 0xde, 0x54, 0x61, 0x0a, 0x35, 0x55, 0xe2, 0x99, 0x03, 0xc9, 0x1a, 0xc0,
-	0x5f, 0x5e, 0x8a, 0xe2, 0xfb, 0xa1, 0x20, 0xed, 0x38, 0xc7, 0x14, 0xdd, 0x83, 0xb6, 0xe6, 0xd4,
-	0xc9, 0xb5, 0x41, 0x64, 0xac, 0xd5, 0x84, 0xb7, 0xaf, 0x58, 0xe9, 0x92, 0x51, 0x5e, 0x54, 0x32,
-	0x96, 0x32, 0x25, 0xe3, 0x39, 0x34, 0x74, 0x0d, 0x4f, 0xf3, 0xc0, 0x90, 0xe7, 0xcb, 0x7a, 0xb9,
-	0xd8, 0x85, 0x86, 0xae, 0xf9, 0x69, 0x3e, 0x63, 0x69, 0x4e, 0xa3, 0x1f, 0xdb, 0xdf, 0x0b, 0x50,
-	0xe5, 0x2f, 0xce, 0x1e, 0x7d, 0xc9, 0x2e, 0x18, 0x13, 0x27, 0x8c, 0xdf, 0xb8, 0xd9, 0x6f, 0x76,
-	0x4d, 0x26, 0x1e, 0x7d, 0x69, 0xd3, 0x7e, 0x40, 0x54, 0xcf, 0x55, 0x63, 0x94, 0x3d, 0x46, 0x60,
-	0x53, 0xe2, 0xc7, 0xb5, 0xb2, 0xc5, 0x7f, 0xb3, 0x2a, 0xd5, 0x1f, 0x46, 0xc4, 0x97, 0xe6, 0x14,
-	0x03, 0x74, 0x03, 0x5a, 0xfc, 0x83, 0xb1, 0xe7, 0x0f, 0x6c, 0x17, 0x0f, 0x08, 0x56, 0x4f, 0xc2,
-	0x4d, 0x45, 0xde, 0xe1, 0x54, 0xf4, 0x3a, 0x34, 0xe3, 0xbf, 0x25, 0x88, 0xbe, 0x5c, 0x64, 0xa8,
-	0xe5, 0x98, 0xca, 0x9b, 0xec, 0x1b, 0xd0, 0x62, 0xbb, 0xd9, 0x7e, 0x40, 0xc6, 0xce, 0xc8, 0xfb,
-	0x1a, 0xbb, 0x32, 0x2f, 0x35, 0x19, 0xf9, 0x93, 0x98, 0xca, 0x4a, 0x03, 0x97, 0x40, 0x47, 0x56,
-	0x45, 0xa2, 0xe6, 0x74, 0x0d, 0x7a, 0x07, 0x56, 0x63, 0x19, 0x35, 0x74, 0x4d, 0xe4, 0x3b, 0xc5,
-	0xd2, 0x26, 0xdc, 0x83, 0x76, 0x22, 0xab, 0x36, 0x03, 0xf8, 0x8c, 0xd5, 0x98, 0x97, 0x4c, 0xe9,
-	0x7d, 0x0a, 0x68, 0x37, 0x08, 0xe9, 0x24, 0x08, 0x99, 0xcd, 0x55, 0xa0, 0x64, 0x5c, 0x56, 0x38,
-	0x87, 0xee, 0xb2, 0x57, 0x55, 0x9b, 0x25, 0x82, 0xa1, 0x66, 0xaa, 0x53, 0x53, 0xdf, 0x0a, 0xfe,
-	0x62, 0xc0, 0x9a, 0x85, 0xc5, 0x9d, 0xdc, 0xf3, 0x07, 0xcf, 0x48, 0xf0, 0x2a, 0x7e, 0x74, 0x6a,
-	0xeb, 0x0f, 0xd5, 0x65, 0xf5, 0xd0, 0x73, 0x0d, 0x96, 0x09, 0xf6, 0x9d, 0x31, 0xb6, 0x79, 0x6b,
-	0x2f, 0x96, 0x2e, 0x58, 0x0d, 0x41, 0xb4, 0x38, 0x8d, 0x9d, 0x86, 0x47, 0x6d, 0x92, 0x2c, 0xcc,
-	0xc3, 0xa9, 0x6a, 0x2d, 0xb3, 0xfb, 0x7d, 0x4c, 0xd4, 0x1a, 0x08, 0xf1, 0x21, 0x58, 0x76, 0xa3,
-	0xb2, 0x81, 0x10, 0xb4, 0xc5, 0x57, 0xf4, 0x85, 0x41, 0xd4, 0xfb, 0xa7, 0x01, 0xad, 0xd9, 0x6f,
-	0x20, 0x4b, 0x43, 0xec, 0xb8, 0x98, 0xc8, 0xb7, 0xd5, 0x5a, 0xfc, 0xcf, 0x2e, 0x4b, 0x32, 0xd0,
-	0xfb, 0x50, 0xed, 0x07, 0x7e, 0x18, 0x7f, 0x1c, 0xab, 0x6f, 0x5e, 0x31, 0xb3, 0xcf, 0x13, 0xdb,
-	0x12, 0x10, 0x7f, 0xda, 0x17, 0x43, 0xf4, 0x10, 0x56, 0x34, 0xb5, 0xed, 0x09, 0x33, 0xa8, 0x7c,
-	0x6d, 0xed, 0x98, 0x73, 0x2c, 0x6d, 0x9d, 0x23, 0x19, 0x86, 0xf8, 0x87, 0x80, 0xb6, 0xc3, 0x49,
-	0x57, 0x9a, 0x86, 0x16, 0x9e, 0x07, 0x4b, 0xfc, 0xaf, 0x7a, 0x6f, 0xff, 0x2b, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0xc8, 0x00, 0xe8, 0xb6, 0x27, 0x00, 0x00,
+	// 7298 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x7d, 0x4d, 0x8c, 0x1c, 0x49,
+	0x56, 0xb0, 0xb2, 0x7e, 0xba, 0xab, 0x5e, 0xfd, 0x75, 0x67, 0xb7, 0xed, 0x72, 0xd9, 0xe3, 0x9f,
+	0xb4, 0xc7, 0xee, 0x59, 0x7b, 0x72, 0xfc, 0x33, 0x3f, 0xf6, 0xec, 0xee, 0x37, 0x9f, 0xdd, 0x6d,
+	0x8f, 0x3d, 0x33, 0xfe, 0x99, 0xec, 0x1e, 0x0f, 0x23, 0xc4, 0x26, 0xd9, 0x95, 0xd1, 0xd5, 0xb9,
+	0xae, 0xca, 0xac, 0xcd, 0xcc, 0xea, 0x76, 0x8f, 0x38, 0xec, 0x61, 0x91, 0x96, 0x7f, 0x90, 0x58,
+	0xb4, 0x5a, 0x04, 0x42, 0x20, 0x24, 0xfe, 0x16, 0x69, 0xe1, 0x02, 0x37, 0x0e, 0x80, 0xb4, 0xec,
+	0x89, 0xbd, 0x21, 0x24, 0x24, 0x90, 0x90, 0x10, 0x07, 0x24, 0x24, 0x2e, 0x1c, 0x90, 0xd0, 0x8b,
+	0x9f, 0xcc, 0x88, 0xcc, 0xac, 0xea, 0xea, 0xf5, 0x1e, 0xb8, 0x55, 0xbc, 0x78, 0x11, 0xf1, 0xe2,
+	0xc5, 0x7b, 0x2f, 0x5e, 0xbc, 0x78, 0x19, 0x05, 0xb5, 0xf1, 0xb6, 0x39, 0x0e, 0x83, 0x38, 0x30,
+	0xbe, 0x5f, 0x86, 0xda, 0x23, 0x12, 0x3b, 0xae, 0x13, 0x3b, 0x7a, 0x17, 0x16, 0xf7, 0x48, 0x18,
+	0x79, 0x81, 0xdf, 0xd5, 0xce, 0x69, 0x6b, 0x55, 0x4b, 0x14, 0x75, 0x1d, 0x2a, 0xbb, 0x4e, 0xb4,
+	0xdb, 0x2d, 0x9d, 0xd3, 0xd6, 0xea, 0x16, 0xfd, 0xad, 0x9f, 0x01, 0x08, 0xc9, 0x38, 0x88, 0xbc,
+	0x38, 0x08, 0x0f, 0xba, 0x65, 0x5a, 0x23, 0x41, 0xf4, 0x4b, 0xd0, 0xd9, 0x26, 0x03, 0xcf, 0xb7,
+	0x27, 0xbe, 0xf7, 0xc2, 0x8e, 0xbd, 0x11, 0xe9, 0x56, 0xce, 0x69, 0x6b, 0x65, 0xab, 0x45, 0xc1,
+	0x9f, 0xf8, 0xde, 0x8b, 0x2d, 0x6f, 0x44, 0x74, 0x03, 0x5a, 0xc4, 0x77, 0x25, 0xac, 0x2a, 0xc5,
+	0x6a, 0x10, 0xdf, 0x4d, 0x70, 0xba, 0xb0, 0xd8, 0x0f, 0x46, 0x23, 0x2f, 0x8e, 0xba, 0x0b, 0x8c,
+	0x32, 0x5e, 0xd4, 0x4f, 0x42, 0x2d, 0x9c, 0xf8, 0xac, 0xe1, 0x22, 0x6d, 0xb8, 0x18, 0x4e, 0x7c,
+	0xda, 0xe8, 0x01, 0x2c, 0x8b, 0x2a, 0x7b, 0x4c, 0x42, 0xdb, 0x8b, 0xc9, 0xa8, 0x5b, 0x3b, 0x57,
+	0x5e, 0x6b, 0xdc, 0x78, 0xc5, 0x14, 0x93, 0x36, 0x2d, 0x86, 0xfd, 0x94, 0x84, 0x0f, 0x63, 0x32,
+	0xba, 0xe7, 0xc7, 0xe1, 0x81, 0xd5, 0x0e, 0x15, 0x20, 0x0e, 0x3f, 0x76, 0xc2, 0xd8, 0x73, 0x86,
+	0xdd, 0xfa, 0x39, 0x6d, 0xad, 0x66, 0x89, 0xa2, 0x7e, 0x0a, 0xea, 0xb1, 0xd7, 0x7f, 0x6e, 0x8f,
+	0x02, 0x97, 0x74, 0x81, 0xf2, 0xa0, 0x86, 0x80, 0x47, 0x81, 0x4b, 0xf4, 0x2f, 0xc0, 0xf2, 0x8e,
+	0x17, 0xf5, 0x9d, 0xa1, 0x7d, 0x40, 0x9c, 0xd0, 0x8e, 0x62, 0x27, 0x8c, 0xbb, 0x0d, 0x4a, 0x7f,
+	0x87, 0x55, 0x7c, 0x46, 0x9c, 0x70, 0x13, 0xc1, 0xbd, 0x3b, 0xb0, 0x52, 0x40, 0x89, 0xbe, 0x04,
+	0xe5, 0xe7, 0xe4, 0x80, 0x2e, 0x47, 0xdd, 0xc2, 0x9f, 0xfa, 0x2a, 0x54, 0xf7, 0x9c, 0xe1, 0x84,
+	0xd0, 0xb5, 0xd0, 0x2c, 0x56, 0x78, 0xb7, 0x74, 0x4b, 0x33, 0x6e, 0xc2, 0x89, 0xbb, 0x93, 0xd0,
+	0x77, 0x83, 0x7d, 0x7f, 0x73, 0xec, 0x84, 0x11, 0x79, 0xe4, 0xc4, 0xa1, 0xf7, 0xc2, 0x0a, 0xf6,
+	0x19, 0xff, 0x86, 0x93, 0x91, 0x1f, 0x75, 0xb5, 0x73, 0xe5, 0xb5, 0x96, 0x25, 0x8a, 0xc6, 0x1f,
+	0x69, 0xb0, 0x5a, 0xd4, 0x0a, 0x97, 0xdc, 0x77, 0x46, 0x84, 0x0f, 0x4d, 0x7f, 0xeb, 0x17, 0xa1,
+	0xed, 0x4f, 0x46, 0xdb, 0x24, 0xb4, 0x83, 0x1d, 0x3b, 0x0c, 0xf6, 0x23, 0x4a, 0x44, 0xd5, 0x6a,
+	0x32, 0xe8, 0x93, 0x1d, 0x2b, 0xd8, 0x8f, 0x70, 0xda, 0x29, 0x96, 0x18, 0xb6, 0xcc, 0xa6, 0x2d,
+	0x10, 0xd7, 0x19, 0x58, 0xbf, 0x0a, 0x15, 0xda, 0x4f, 0x85, 0x2e, 0x4b, 0xd7, 0x9c, 0x32, 0x01,
+	0x8b, 0x62, 0x19, 0x3f, 0x03, 0xed, 0xfb, 0xde, 0x90, 0x44, 0x4f, 0xf6, 0x7d, 0x12, 0x46, 0xbb,
+	0xde, 0x58, 0xbf, 0x26, 0xb8, 0xa1, 0xd1, 0x0e, 0x7a, 0xa6, 0x5a, 0x6f, 0x3e, 0xc3, 0x4a, 0xb6,
+	0xa8, 0x0c, 0xb1, 0x77, 0x0b, 0x20, 0x05, 0xca, 0xfc, 0xad, 0x16, 0xf0, 0xb7, 0x2a, 0xf3, 0xf7,
+	0x17, 0x16, 0x52, 0x06, 0xdf, 0xf1, 0x9d, 0xe1, 0x41, 0xe4, 0x45, 0x16, 0x89, 0x26, 0xc3, 0x38,
+	0xd2, 0xcf, 0x41, 0x63, 0x10, 0x3a, 0xfe, 0x64, 0xe8, 0x84, 0x5e, 0x2c, 0xfa, 0x93, 0x41, 0x7a,
+	0x0f, 0x6a, 0x91, 0x33, 0x1a, 0x0f, 0x3d, 0x7f, 0xc0, 0xbb, 0x4e, 0xca, 0xfa, 0x1b, 0xb0, 0x38,
+	0x0e, 0x83, 0xaf, 0x92, 0x7e, 0x4c, 0xf9, 0xd4, 0xb8, 0x71, 0xac, 0x98, 0x11, 0x02, 0x4b, 0xbf,
+	0x02, 0xd5, 0x1d, 0x9c, 0x28, 0xe7, 0xdb, 0x14, 0x74, 0x86, 0xa3, 0xbf, 0x0e, 0x0b, 0x63, 0x12,
+	0x8c, 0x87, 0xa8, 0x59, 0x33, 0xb0, 0x39, 0x92, 0xfe, 0x10, 0x74, 0xf6, 0xcb, 0xf6, 0xfc, 0x98,
+	0x84, 0x4e, 0x3f, 0x46, 0x83, 0xb0, 0x40, 0xe9, 0xea, 0x99, 0xeb, 0xc1, 0x68, 0x1c, 0x92, 0x28,
+	0x22, 0x2e, 0x6b, 0x6c, 0x05, 0xfb, 0xbc, 0xfd, 0x32, 0x6b, 0xf5, 0x30, 0x6d, 0xa4, 0xdf, 0x82,
+	0x0e, 0x25, 0xc1, 0x0e, 0xc4, 0x82, 0x74, 0x17, 0x29, 0x09, 0x9d, 0xcc, 0x3a, 0x59, 0xed, 0x1d,
+	0x75, 0x5d, 0x85, 0x5e, 0x45, 0xde, 0xe7, 0xa4, 0x5b, 0xa3, 0x7a, 0x4d, 0xf5, 0x6a, 0xd3, 0xfb,
+	0x9c, 0xe8, 0x6f, 0xc0, 0x4a, 0x6a, 0x67, 0xec, 0x88, 0x7c, 0x6d, 0x42, 0xfc, 0x3e, 0xe9, 0xd6,
+	0xcf, 0x95, 0xd7, 0xea, 0x96, 0x9e, 0x56, 0x6d, 0xf2, 0x1a, 0xfd, 0x36, 0x34, 0x13, 0xa8, 0x47,
+	0xa2, 0x2e, 0xcc, 0xe2, 0x83, 0x82, 0xaa, 0xbf, 0x03, 0x0d, 0xd7, 0x0b, 0x49, 0x9f, 0xb7, 0x6c,
+	0xcc, 0x6a, 0x29, 0x63, 0xea, 0x57, 0x60, 0x59, 0x2a, 0xda, 0x2e, 0x19, 0xc7, 0xbb, 0xdd, 0x26,
+	0x5d, 0xf8, 0x25, 0xa9, 0x62, 0x03, 0xe1, 0x28, 0x1c, 0x21, 0xa1, 0xe2, 0x40, 0xba, 0x2d, 0x66,
+	0x45, 0x44, 0x59, 0xbf, 0x09, 0xf5, 0xa1, 0xe3, 0x0f, 0x26, 0xce, 0x80, 0x44, 0xdd, 0xf6, 0xac,
+	0xf1, 0x53, 0x3c, 0xfd, 0x3a, 0xd4, 0xd0, 0x42, 0x12, 0x3f, 0x8e, 0xba, 0x9d, 0x59, 0x22, 0x95,
+	0xa0, 0xa1, 0x98, 0x6c, 0x0f, 0x1d, 0xff, 0x79, 0xd4, 0x5d, 0x9a, 0xd5, 0x80, 0x23, 0x19, 0x7f,
+	0xae, 0xc1, 0xc9, 0xa9, 0xc2, 0x50, 0x60, 0x29, 0xb4, 0x79, 0x2d, 0x45, 0xa9, 0xd8, 0x52, 0xe8,
+	0x50, 0x41, 0x7b, 0xdd, 0x2d, 0x9f, 0x2b, 0xaf, 0x95, 0xad, 0x8a, 0xd8, 0xb0, 0x3c, 0xdf, 0xf5,
+	0xfa, 0x5c, 0x11, 0xaa, 0x96, 0x28, 0xea, 0xc7, 0x61, 0xc1, 0xf3, 0xdd, 0x71, 0x1c, 0x52, 0x99,
+	0x2f, 0x5b, 0xbc, 0x64, 0x6c, 0xc2, 0xe2, 0x7a, 0x30, 0x19, 0xa3, 0x5a, 0xac, 0x42, 0xd5, 0xf3,
+	0x5d, 0xf2, 0x82, 0x9a, 0x8e, 0xba, 0xc5, 0x0a, 0xfa, 0x0d, 0x58, 0x18, 0xd1, 0x29, 0x74, 0x4b,
+	0x87, 0x4a, 0x3c, 0xc7, 0x34, 0x2e, 0x42, 0x73, 0x2b, 0x98, 0xf4, 0x77, 0x89, 0x7b, 0xdf, 0xe3,
+	0x3d, 0x33, 0xed, 0xd4, 0x28, 0x51, 0xac, 0x60, 0x7c, 0xa7, 0x04, 0xc7, 0xf9, 0xd8, 0x59, 0xeb,
+	0x71, 0x05, 0x9a, 0x88, 0x63, 0xf7, 0x59, 0x35, 0x57, 0xb6, 0x9a, 0xc9, 0xd1, 0xad, 0x06, 0xd6,
+	0x0a, 0xba, 0xdf, 0x80, 0x36, 0xd7, 0x4f, 0x81, 0xbe, 0x98, 0x41, 0x6f, 0xb1, 0x7a, 0xd1, 0xe0,
+	0x1a, 0x34, 0x79, 0x03, 0x46, 0x15, 0xdb, 0x02, 0x5b, 0xa6, 0x4c, 0xb3, 0xd5, 0x60, 0x28, 0x6c,
+	0x02, 0x67, 0xa1, 0xc1, 0xf4, 0x76, 0xe8, 0xf9, 0x24, 0xa2, 0x8a, 0x55, 0xb5, 0x80, 0x82, 0x3e,
+	0x42, 0x08, 0xaa, 0xe7, 0xae, 0x33, 0xdc, 0xb1, 0x87, 0xde, 0x0e, 0xdb, 0xf6, 0xaa, 0x56, 0x0d,
+	0x01, 0x1f, 0x79, 0x3b, 0x44, 0xbf, 0x01, 0xc7, 0x58, 0x6b, 0x97, 0xf4, 0x9d, 0x03, 0xe2, 0xda,
+	0xfb, 0xc4, 0x1b, 0xec, 0xc6, 0x4c, 0x79, 0x4a, 0xd6, 0x0a, 0xad, 0xdc, 0x60, 0x75, 0x9f, 0xb2,
+	0x2a, 0xe3, 0xaf, 0x35, 0x68, 0x6f, 0xee, 0x06, 0xb1, 0x4f, 0xa2, 0xc8, 0x22, 0xfd, 0x20, 0x74,
+	0x71, 0xc1, 0xe3, 0x83, 0x71, 0xb2, 0x01, 0xe1, 0xef, 0x64, 0x53, 0x2a, 0x49, 0x9b, 0x92, 0x0e,
+	0x15, 0xec, 0x91, 0x7b, 0x20, 0xf4, 0xb7, 0x7e, 0x1b, 0xc5, 0x7f, 0x82, 0x96, 0x48, 0x98, 0xc8,
+	0x57, 0x4c, 0xb5, 0x7b, 0x73, 0x9d, 0xd7, 0xb3, 0xcd, 0x21, 0x41, 0xef, 0x7d, 0x11, 0x5a, 0x4a,
+	0xd5, 0x91, 0xb6, 0x88, 0x0d, 0x38, 0x21, 0x86, 0xc9, 0xae, 0xf1, 0x6b, 0xb0, 0x18, 0xd2, 0x91,
+	0x23, 0xbe, 0x57, 0x75, 0x32, 0x14, 0x59, 0xa2, 0xde, 0xf8, 0xe7, 0x12, 0x34, 0x70, 0x21, 0x1e,
+	0x78, 0x11, 0xf5, 0xa4, 0x24, 0xef, 0x87, 0xc9, 0xaa, 0x28, 0xea, 0xcf, 0x60, 0xb5, 0xbf, 0xeb,
+	0xf8, 0x03, 0x12, 0xd9, 0xdb, 0x07, 0xb6, 0x4b, 0xf6, 0xc8, 0x30, 0x18, 0x93, 0xb0, 0x5b, 0xa2,
+	0x23, 0x5c, 0x34, 0xa5, 0x5e, 0xcc, 0x75, 0x86, 0x78, 0xf7, 0x60, 0x43, 0xa0, 0xb1, 0xa9, 0xeb,
+	0xfd, 0x5c, 0x85, 0x7e, 0x02, 0x16, 0xa9, 0x40, 0x7a, 0x2e, 0xdf, 0xb8, 0x17, 0xb0, 0xf8, 0xd0,
+	0xc5, 0xa9, 0x23, 0xd3, 0x19, 0x57, 0xeb, 0x16, 0x2b, 0xe8, 0xe7, 0xa1, 0xd9, 0x0f, 0x89, 0x13,
+	0x13, 0xd7, 0x46, 0x23, 0x4d, 0x3d, 0xb8, 0xaa, 0xd5, 0xe0, 0xb0, 0x2d, 0xaf, 0xff, 0x1c, 0x51,
+	0x5c, 0x32, 0x24, 0x09, 0x0a, 0x73, 0xe3, 0x1a, 0x1c, 0x46, 0x51, 0xba, 0xb0, 0xe8, 0x4c, 0xe2,
+	0xdd, 0x20, 0x8c, 0xe8, 0x2e, 0x51, 0xb5, 0x44, 0xb1, 0xf7, 0x31, 0x9c, 0x98, 0x42, 0x7d, 0xc1,
+	0xea, 0x9c, 0x93, 0x57, 0xa7, 0x71, 0x03, 0x4c, 0x14, 0xd9, 0xcd, 0xd8, 0x89, 0x23, 0x79, 0xa5,
+	0xfe, 0x56, 0x83, 0xae, 0xc4, 0x1d, 0xb6, 0x4a, 0x8f, 0x48, 0x14, 0x39, 0x03, 0xa2, 0xbf, 0x2b,
+	0x2b, 0x70, 0x86, 0x8f, 0x0a, 0x26, 0xad, 0xe0, 0x22, 0xc4, 0x9a, 0xe8, 0x97, 0x60, 0x91, 0x4f,
+	0x8a, 0xaf, 0x42, 0x53, 0x69, 0x2d, 0x2a, 0x7b, 0xf7, 0x01, 0xd2, 0xc6, 0x05, 0x7e, 0x9e, 0xa1,
+	0x4e, 0x43, 0xed, 0x45, 0x9a, 0xc8, 0xef, 0x69, 0x50, 0x4f, 0x66, 0x88, 0xeb, 0xe3, 0xb8, 0x2e,
+	0x71, 0x39, 0x43, 0x58, 0x01, 0x39, 0x1b, 0x92, 0x51, 0xb0, 0x47, 0x69, 0xa2, 0xee, 0x33, 0x2f,
+	0x52, 0xd1, 0xa2, 0x9c, 0x15, 0x0b, 0x2d, 0x8a, 0xfa, 0x65, 0x69, 0x07, 0xa9, 0x50, 0x12, 0x1a,
+	0x94, 0x93, 0x54, 0x39, 0x22, 0x69, 0xdf, 0xb8, 0x90, 0xec, 0x1b, 0xd5, 0x3c, 0x9a, 0xd8, 0x2d,
+	0x9e, 0x01, 0xa4, 0xd0, 0x1f, 0x1f, 0x95, 0xc6, 0x0f, 0x4a, 0xb0, 0xb8, 0x41, 0xf6, 0x84, 0xfc,
+	0xa4, 0x6a, 0xa2, 0x1c, 0x12, 0xce, 0x41, 0x35, 0x42, 0xf6, 0x14, 0x89, 0x04, 0xad, 0xd0, 0xdf,
+	0x92, 0x37, 0xd9, 0x32, 0x5d, 0xb7, 0x13, 0x26, 0xef, 0xd8, 0xfc, 0x48, 0xd4, 0xb0, 0x85, 0x4e,
+	0x31, 0xf5, 0x5b, 0x00, 0x7d, 0x27, 0x26, 0x03, 0xe6, 0x1c, 0x08, 0x27, 0x56, 0xb4, 0x5b, 0x4f,
+	0xaa, 0x58, 0x43, 0x09, 0xb7, 0xf7, 0x00, 0xda, 0x6a, 0xb7, 0x05, 0x22, 0x30, 0x97, 0x24, 0xf7,
+	0x1e, 0x42, 0x27, 0x33, 0xd0, 0x8f, 0xda, 0x95, 0xb1, 0x07, 0x35, 0x24, 0x7c, 0x83, 0xec, 0x45,
+	0xfa, 0x65, 0xa8, 0xb8, 0x64, 0x4f, 0xa8, 0xc0, 0x8a, 0x29, 0x2a, 0x70, 0x76, 0x7c, 0x3e, 0x14,
+	0xa1, 0x77, 0x07, 0xea, 0x09, 0xa8, 0x40, 0x1d, 0xcf, 0xa8, 0x23, 0xd7, 0x04, 0x77, 0xe4, 0x71,
+	0xff, 0x53, 0x83, 0x15, 0xec, 0x23, 0x6b, 0x33, 0xdf, 0x82, 0x2a, 0x1a, 0x0b, 0x41, 0xc4, 0x59,
+	0xb3, 0x00, 0x89, 0x12, 0x26, 0x54, 0x90, 0x62, 0xe3, 0xee, 0xe4, 0x92, 0x3d, 0x9b, 0xed, 0xee,
+	0x25, 0x6a, 0xa8, 0x6a, 0x2e, 0xd9, 0x7b, 0x88, 0xe5, 0xd9, 0x9e, 0xe5, 0x45, 0x68, 0x05, 0xe1,
+	0xc0, 0xf1, 0xbd, 0xcf, 0x1d, 0x74, 0x60, 0x99, 0x28, 0xd4, 0x2d, 0x15, 0xd8, 0x5b, 0x07, 0x48,
+	0x07, 0x2d, 0x98, 0xf2, 0x59, 0x75, 0xca, 0xf5, 0x84, 0x77, 0xf2, 0x9c, 0x3f, 0x85, 0xfa, 0x26,
+	0xf1, 0xf1, 0x70, 0xea, 0xc7, 0xe9, 0x8e, 0x82, 0xbd, 0x94, 0x38, 0x1a, 0x7a, 0x85, 0x89, 0x0a,
+	0xf2, 0x69, 0x88, 0xb2, 0x2c, 0xec, 0x65, 0x65, 0x4f, 0xc0, 0xad, 0xf4, 0xc4, 0x3a, 0x43, 0x4b,
+	0x06, 0x10, 0x0c, 0xfd, 0x0c, 0x96, 0x23, 0x01, 0xc3, 0x1d, 0x83, 0x9a, 0x62, 0xc6, 0xdc, 0xd7,
+	0xcd, 0x29, 0x8d, 0xcc, 0x04, 0x70, 0xf7, 0x00, 0x27, 0xc2, 0x58, 0xdd, 0x89, 0x54, 0x68, 0xef,
+	0x31, 0xac, 0x16, 0x21, 0xce, 0x63, 0xa0, 0xd3, 0x11, 0x25, 0xfe, 0x7c, 0x05, 0x60, 0x9d, 0xce,
+	0x08, 0xed, 0x5e, 0xe1, 0x69, 0xb4, 0x07, 0x35, 0xa1, 0x89, 0x7c, 0xf3, 0x4f, 0xca, 0xa9, 0xc6,
+	0x57, 0xa6, 0x68, 0xbc, 0xf1, 0x5d, 0x0d, 0x16, 0xd8, 0x00, 0x49, 0x74, 0x43, 0x93, 0xa2, 0x1b,
+	0x17, 0xa1, 0xbd, 0xbf, 0x4b, 0xe4, 0xe0, 0x45, 0x89, 0xca, 0x4a, 0x13, 0xa1, 0x49, 0x5c, 0xe2,
+	0x38, 0x2c, 0xb0, 0x3d, 0x4a, 0x6c, 0x93, 0xac, 0xa4, 0x9f, 0x57, 0xcf, 0x67, 0x0d, 0x33, 0x9d,
+	0x8a, 0xd8, 0x27, 0x4c, 0x58, 0x61, 0x2b, 0x86, 0x5b, 0x62, 0x36, 0xf8, 0xb1, 0x9c, 0x54, 0x89,
+	0xa1, 0x8c, 0xaf, 0xa0, 0xf7, 0x88, 0xc0, 0x9c, 0x96, 0x9c, 0x57, 0xdd, 0x83, 0xc6, 0x8d, 0x45,
+	0x3e, 0x5c, 0x6a, 0x00, 0xcf, 0x43, 0x93, 0x51, 0xa6, 0x28, 0x45, 0x83, 0xc1, 0xa8, 0x5e, 0x18,
+	0x7b, 0x50, 0xd9, 0x3a, 0x18, 0x07, 0x28, 0x8a, 0xfb, 0x61, 0xe0, 0x0f, 0x38, 0x37, 0x58, 0x81,
+	0x89, 0x5b, 0x88, 0xa7, 0x16, 0xee, 0x7b, 0x89, 0x22, 0xb2, 0x80, 0x8d, 0xc2, 0xd7, 0x60, 0xa1,
+	0x9f, 0x30, 0x95, 0xba, 0x65, 0x15, 0xc9, 0x2d, 0xd3, 0xa1, 0x82, 0x1e, 0x25, 0xf7, 0x0f, 0xe8,
+	0x6f, 0xe3, 0x0a, 0x34, 0x71, 0xdc, 0x68, 0xc3, 0x89, 0x9d, 0x88, 0xc4, 0xfa, 0x29, 0xa8, 0xc6,
+	0x58, 0xe6, 0x73, 0xa9, 0x9a, 0x58, 0x6b, 0x31, 0x98, 0xf1, 0x75, 0x0d, 0xda, 0x0f, 0x47, 0xe3,
+	0x20, 0x8c, 0xa3, 0xa7, 0x24, 0xa4, 0x56, 0xff, 0x26, 0x8e, 0x8f, 0xbb, 0x0a, 0x6f, 0x70, 0xca,
+	0x54, 0x11, 0x98, 0xa3, 0xc7, 0x0d, 0x04, 0x47, 0xed, 0xdd, 0x86, 0x86, 0x04, 0x3e, 0xcc, 0xc5,
+	0x2b, 0xcb, 0x72, 0xf9, 0x2d, 0x0d, 0xf4, 0x74, 0x04, 0x61, 0xc3, 0xf5, 0x37, 0x55, 0x53, 0x75,
+	0xc6, 0xcc, 0xe3, 0xe4, 0x2d, 0x55, 0xef, 0xe1, 0x34, 0x4b, 0xc2, 0xcd, 0xf6, 0xab, 0xaa, 0xaa,
+	0x74, 0x32, 0x73, 0x93, 0xe9, 0xfa, 0x63, 0x0d, 0x56, 0xd2, 0xda, 0xd4, 0x95, 0xbb, 0x23, 0xef,
+	0x6c, 0x8c, 0xb8, 0x0b, 0x66, 0x01, 0xe2, 0xf4, 0x5d, 0xae, 0xf7, 0xf1, 0x1c, 0x7b, 0xd5, 0x6b,
+	0x2a, 0xa5, 0x2b, 0x05, 0xf3, 0x97, 0xa9, 0xfd, 0x45, 0x0d, 0x7a, 0x05, 0x44, 0x08, 0x91, 0x36,
+	0x61, 0xd1, 0x63, 0xb5, 0x9c, 0xe4, 0xd5, 0x22, 0x92, 0x2d, 0x81, 0x34, 0x87, 0x7c, 0xab, 0x76,
+	0xbf, 0xac, 0xda, 0x7d, 0x63, 0x1d, 0x96, 0xb7, 0x08, 0xf6, 0xe5, 0x0c, 0x37, 0xd0, 0x12, 0xd1,
+	0xa0, 0x67, 0xc6, 0xed, 0x96, 0xfc, 0x89, 0x55, 0xa8, 0xb2, 0x93, 0x51, 0x89, 0xc2, 0x59, 0xc1,
+	0xf8, 0x81, 0x06, 0x27, 0x13, 0xda, 0x44, 0x77, 0x77, 0xfa, 0xb1, 0xb7, 0x87, 0xf1, 0x1f, 0x13,
+	0x6a, 0xfb, 0x84, 0x3c, 0x77, 0x9d, 0x03, 0xe6, 0x9e, 0x34, 0x6e, 0xe8, 0x66, 0x6e, 0x4c, 0x2b,
+	0xc1, 0xd1, 0xd7, 0xa0, 0xba, 0x1b, 0x4c, 0x42, 0xe1, 0xb3, 0x14, 0x21, 0x33, 0x04, 0xfd, 0x0b,
+	0xb0, 0x30, 0x0a, 0xfc, 0x78, 0x37, 0xea, 0x96, 0xa7, 0xa2, 0x72, 0x0c, 0xec, 0x15, 0x47, 0x10,
+	0x76, 0xb1, 0xb0, 0x57, 0x8a, 0x60, 0xfc, 0xb6, 0x06, 0xab, 0xd9, 0x49, 0x1c, 0xe2, 0x66, 0x49,
+	0x6c, 0xd1, 0x12, 0xb6, 0x20, 0x3e, 0x9f, 0x94, 0x70, 0xde, 0x78, 0x91, 0xda, 0xdd, 0x60, 0x12,
+	0x52, 0x5a, 0xaa, 0x16, 0xfd, 0x8d, 0x7d, 0x50, 0x52, 0xb9, 0x8d, 0x60, 0x05, 0xc4, 0xc4, 0x46,
+	0xfc, 0xd4, 0x40, 0x7f, 0xa3, 0xe3, 0xdb, 0x2d, 0x22, 0x90, 0x7a, 0x2f, 0xef, 0x28, 0xde, 0xcb,
+	0x05, 0x73, 0x1a, 0x62, 0xce, 0x9b, 0x79, 0x3c, 0xdb, 0x9b, 0xb9, 0xa2, 0x8a, 0xf9, 0xb1, 0xc2,
+	0x8e, 0x65, 0x41, 0xff, 0xab, 0x2a, 0x9c, 0xc8, 0xe2, 0x08, 0x29, 0x7f, 0x00, 0xe0, 0x30, 0x90,
+	0x97, 0xe8, 0xe6, 0x9a, 0x39, 0x05, 0xdb, 0xbc, 0x93, 0xa0, 0x72, 0x6f, 0x32, 0x6d, 0x3b, 0xdb,
+	0xe3, 0xb9, 0x2d, 0x4c, 0x53, 0x79, 0x0a, 0x33, 0x66, 0x7a, 0x52, 0xa9, 0xd2, 0x54, 0x32, 0xce,
+	0x52, 0x0f, 0x6a, 0xb8, 0x65, 0x7d, 0x1e, 0x70, 0x8b, 0x5e, 0xb7, 0x92, 0xb2, 0xfe, 0x1e, 0x2c,
+	0x06, 0x3b, 0x3b, 0x11, 0xa1, 0x01, 0x7b, 0x1c, 0xf5, 0xd5, 0xa9, 0xa3, 0x3e, 0x61, 0x78, 0x6c,
+	0x5c, 0xd1, 0x4a, 0xbf, 0x07, 0xf5, 0x68, 0x32, 0x1a, 0x39, 0xd4, 0xb1, 0x66, 0x41, 0xc3, 0xcb,
+	0x53, 0xbb, 0xd8, 0x14, 0x98, 0xdc, 0x74, 0x25, 0x2d, 0x7b, 0x9f, 0x41, 0x27, 0xc3, 0xb7, 0x82,
+	0x45, 0xbd, 0xa6, 0x2e, 0x6a, 0xcf, 0x9c, 0xaa, 0xc5, 0xb2, 0xdf, 0xbd, 0x79, 0x88, 0x17, 0xf8,
+	0x86, 0xda, 0xeb, 0xc9, 0xa9, 0x32, 0x28, 0x77, 0xfa, 0x2e, 0x34, 0x65, 0x7e, 0x1c, 0x25, 0xf8,
+	0xd0, 0x7b, 0x06, 0x6d, 0x95, 0x11, 0x05, 0xad, 0x4d, 0x95, 0xa8, 0x6e, 0x8e, 0x28, 0xd6, 0x83,
+	0x72, 0xc2, 0xfc, 0x35, 0x0d, 0x4e, 0x4c, 0x41, 0xd3, 0x2f, 0x40, 0x0b, 0x95, 0x11, 0x2f, 0x70,
+	0xa2, 0x5d, 0x27, 0x14, 0x0e, 0x6c, 0x93, 0x03, 0x37, 0x11, 0x86, 0x61, 0x3e, 0x67, 0x27, 0x26,
+	0xa1, 0x4d, 0xed, 0x15, 0x47, 0x2c, 0x51, 0xc4, 0x0e, 0xad, 0x78, 0x80, 0x70, 0x86, 0xfb, 0x2a,
+	0xb4, 0x87, 0x01, 0x9e, 0xf4, 0x63, 0x3b, 0x8a, 0x43, 0xe2, 0x3c, 0xe7, 0x46, 0xa3, 0xc5, 0xa1,
+	0x9b, 0x14, 0x68, 0xfc, 0x65, 0x09, 0x3a, 0xeb, 0x81, 0x4b, 0xd6, 0x77, 0x27, 0xa1, 0xbf, 0x49,
+	0x70, 0xca, 0x28, 0x8f, 0x9e, 0x1f, 0x91, 0x30, 0xa6, 0x07, 0x4b, 0x8c, 0xfa, 0x25, 0x65, 0xe4,
+	0x1a, 0xc6, 0xa0, 0xd9, 0x99, 0xbc, 0x6c, 0xb1, 0x02, 0x5e, 0x51, 0x89, 0xa0, 0xc4, 0x36, 0x06,
+	0x92, 0x87, 0x3b, 0x3c, 0xbc, 0xd8, 0xe2, 0xe0, 0xbb, 0x07, 0x9b, 0x64, 0xb8, 0x83, 0x13, 0x90,
+	0xf0, 0x82, 0x78, 0x57, 0xc4, 0x95, 0xca, 0x56, 0x27, 0xc1, 0x7c, 0x42, 0xc1, 0xfa, 0x69, 0xa8,
+	0x3b, 0xfb, 0x4e, 0x48, 0x7c, 0x12, 0x45, 0x34, 0xf8, 0x58, 0xb2, 0x52, 0x80, 0x6e, 0x40, 0x73,
+	0x44, 0x46, 0x41, 0xe8, 0x6c, 0x7b, 0x43, 0xbc, 0x28, 0x58, 0xa0, 0x08, 0x0a, 0x4c, 0xbf, 0x26,
+	0xb9, 0xfd, 0x2c, 0xb4, 0xb7, 0x6a, 0x26, 0x73, 0xfd, 0xd0, 0xf3, 0x5d, 0x36, 0x5f, 0xe9, 0x30,
+	0x70, 0x35, 0x39, 0x82, 0xd7, 0x66, 0xe0, 0x8b, 0xb3, 0xf8, 0x6f, 0x6a, 0xb0, 0x52, 0x50, 0xff,
+	0x7f, 0x83, 0x7f, 0xc6, 0x9e, 0xb4, 0xb0, 0x16, 0xd9, 0x0f, 0xc2, 0xe7, 0xfa, 0x2b, 0x00, 0xd8,
+	0xb7, 0xdd, 0x47, 0x18, 0x95, 0xb0, 0xb2, 0x55, 0x47, 0x08, 0x45, 0x42, 0x57, 0x3d, 0x18, 0xba,
+	0xb6, 0x84, 0xc2, 0x5d, 0xf5, 0x60, 0xe8, 0x6e, 0x26, 0x58, 0x67, 0x00, 0x5c, 0x2f, 0x0a, 0x27,
+	0xe3, 0xd8, 0xdb, 0x13, 0x0e, 0x80, 0x04, 0x31, 0xbe, 0xa3, 0xc1, 0x6a, 0x66, 0x60, 0xaa, 0xde,
+	0xfa, 0xdb, 0xaa, 0x67, 0x77, 0xce, 0x2c, 0xc2, 0x2a, 0xf0, 0xed, 0x3e, 0x38, 0xc4, 0x3e, 0x5c,
+	0x52, 0x55, 0x71, 0x29, 0xdb, 0xaf, 0xac, 0x82, 0xbf, 0x55, 0x86, 0x25, 0xa9, 0x9a, 0x6d, 0x1f,
+	0xf2, 0x8d, 0x92, 0x96, 0xb9, 0x51, 0x7a, 0x2b, 0xb9, 0xf3, 0x29, 0xf1, 0xf0, 0x67, 0xb6, 0xb9,
+	0xf9, 0x94, 0xd6, 0x73, 0xbf, 0x98, 0x21, 0xab, 0xfb, 0x48, 0x79, 0xd6, 0xc9, 0x39, 0xbb, 0x19,
+	0x5c, 0x82, 0x4e, 0xba, 0x00, 0x36, 0xf5, 0x72, 0xd8, 0x0e, 0xde, 0x4a, 0x16, 0x6a, 0x03, 0xdd,
+	0x9a, 0xb7, 0x60, 0x21, 0xa4, 0xd3, 0xeb, 0x2e, 0x4c, 0x23, 0x8c, 0x4d, 0x9f, 0x13, 0xc6, 0x90,
+	0x7b, 0x1f, 0x42, 0x43, 0xa2, 0xf7, 0x48, 0xdc, 0xe4, 0xd2, 0x2f, 0x19, 0xca, 0xa7, 0xd0, 0x90,
+	0xc6, 0x98, 0x67, 0x97, 0x2f, 0x5a, 0x72, 0x79, 0x7d, 0xfe, 0xbd, 0x04, 0xc7, 0xee, 0x4e, 0xa2,
+	0xfb, 0x0e, 0xde, 0xea, 0x60, 0xed, 0xa6, 0xef, 0x8c, 0xa3, 0xdd, 0x20, 0x46, 0xd9, 0xdd, 0x9e,
+	0x44, 0xf6, 0x0e, 0xad, 0xe1, 0x63, 0xd4, 0xb7, 0x05, 0x2a, 0x46, 0xda, 0xe3, 0x20, 0x76, 0x86,
+	0x76, 0xea, 0x38, 0x95, 0x2d, 0xa0, 0x20, 0x16, 0x69, 0xff, 0x20, 0xf1, 0x6c, 0x19, 0x46, 0x99,
+	0x6f, 0x85, 0x85, 0xa3, 0x99, 0x77, 0x28, 0x2a, 0x6d, 0xc9, 0xf8, 0xd7, 0x70, 0x52, 0x88, 0x7e,
+	0x1f, 0x20, 0x9a, 0x6c, 0x47, 0x07, 0x51, 0x4c, 0x46, 0xe2, 0x68, 0x7a, 0x69, 0x4a, 0x4f, 0x9b,
+	0x09, 0x22, 0xeb, 0x48, 0x6a, 0xd9, 0xfb, 0x7f, 0xb0, 0x94, 0x1d, 0xe8, 0x28, 0x47, 0xa8, 0xde,
+	0x97, 0xa1, 0x93, 0xe9, 0xfe, 0xb0, 0x7b, 0x6e, 0x25, 0xc8, 0xfe, 0xbd, 0x05, 0xe8, 0x26, 0x44,
+	0x67, 0x0f, 0xc3, 0xf7, 0xa1, 0x1e, 0xf1, 0x39, 0xa4, 0x2e, 0xd5, 0x34, 0x6c, 0x53, 0x4c, 0x37,
+	0x71, 0x1c, 0x44, 0x59, 0xef, 0xc3, 0x6a, 0x32, 0x63, 0x5b, 0x5a, 0x41, 0xa6, 0x4e, 0xd7, 0x67,
+	0x74, 0x29, 0x5a, 0x25, 0x18, 0xac, 0x6f, 0x3d, 0xca, 0x55, 0xbc, 0x84, 0xba, 0x9d, 0x86, 0x7a,
+	0xbc, 0x1b, 0x92, 0x68, 0x37, 0x18, 0xba, 0x54, 0xd1, 0x4a, 0x56, 0x0a, 0xd0, 0x9f, 0xe5, 0xef,
+	0x5d, 0x17, 0x78, 0x90, 0x67, 0x2a, 0xdd, 0xea, 0x85, 0x2c, 0xcf, 0x83, 0xc8, 0xdc, 0xca, 0x5e,
+	0x80, 0x56, 0xd2, 0xa3, 0x1d, 0x07, 0x63, 0xba, 0x3d, 0x55, 0xad, 0x66, 0x02, 0xdc, 0x0a, 0xc6,
+	0xfa, 0x75, 0x80, 0xc8, 0x1b, 0x4d, 0x86, 0x34, 0x58, 0xc6, 0x2f, 0x9b, 0x96, 0xd3, 0x71, 0x2d,
+	0x8c, 0xe9, 0x3a, 0x43, 0x4b, 0x42, 0xc2, 0xe3, 0x1b, 0x2f, 0x11, 0xda, 0x6d, 0x9d, 0x5d, 0x0e,
+	0x08, 0x18, 0xf6, 0x7a, 0x19, 0x3a, 0xe9, 0x7a, 0x90, 0x3d, 0x12, 0x1e, 0xf0, 0x7b, 0xa7, 0x76,
+	0x02, 0xbe, 0x87, 0x50, 0x15, 0x91, 0xdd, 0xba, 0x36, 0x32, 0x88, 0xf4, 0xce, 0xb5, 0xb7, 0x05,
+	0x6d, 0x75, 0xf9, 0x0b, 0x64, 0xf8, 0xaa, 0x6a, 0x08, 0x8e, 0x17, 0x2b, 0x8b, 0x2c, 0xdb, 0xf7,
+	0xe0, 0xc4, 0x14, 0x09, 0x38, 0x8a, 0x8c, 0xf7, 0x1e, 0xc3, 0x4a, 0xc1, 0x82, 0x14, 0x74, 0x71,
+	0x5e, 0xa5, 0xb0, 0x41, 0xd7, 0x91, 0xb5, 0x92, 0x75, 0xe6, 0x5f, 0x35, 0x58, 0xca, 0x2e, 0x81,
+	0x14, 0xbd, 0xd2, 0x94, 0xe8, 0x95, 0x72, 0x8e, 0x2b, 0x8b, 0x73, 0x1c, 0x8d, 0x46, 0xee, 0x91,
+	0x50, 0x84, 0xdb, 0x4a, 0x56, 0x52, 0xce, 0x58, 0xb9, 0x4a, 0xd6, 0xca, 0xbd, 0x01, 0x95, 0x81,
+	0x33, 0x8e, 0x78, 0xfe, 0xc1, 0xa9, 0x9c, 0x30, 0x98, 0xef, 0x3b, 0x63, 0x71, 0x0a, 0x43, 0xc4,
+	0xde, 0x3b, 0x50, 0x4f, 0x40, 0x87, 0xf1, 0xad, 0x24, 0xcf, 0xd3, 0x06, 0x48, 0x19, 0x90, 0x4e,
+	0x44, 0x93, 0x27, 0x22, 0xdd, 0x33, 0x95, 0x94, 0x7b, 0x26, 0x29, 0x8c, 0x90, 0x1a, 0xdb, 0xb2,
+	0x62, 0x43, 0x8d, 0x6f, 0x94, 0xc0, 0x48, 0x16, 0x65, 0x3d, 0xf0, 0xfb, 0xc4, 0x8f, 0x43, 0x2a,
+	0xc5, 0x8a, 0xd9, 0xd7, 0xa1, 0x32, 0xf0, 0x7c, 0x8f, 0x0e, 0xac, 0x59, 0xf4, 0x37, 0xce, 0x63,
+	0x77, 0xd7, 0xe3, 0x79, 0x3b, 0xf8, 0x33, 0x6b, 0xfd, 0xcb, 0x39, 0xeb, 0xff, 0x69, 0x86, 0x20,
+	0x66, 0xb3, 0xdf, 0x34, 0x0f, 0xa7, 0x60, 0xf6, 0x56, 0xf0, 0xb2, 0x26, 0xdc, 0xf8, 0xef, 0x0a,
+	0xbc, 0x52, 0x4c, 0x84, 0x30, 0xc4, 0x1f, 0xe6, 0x0d, 0xf1, 0xeb, 0xe6, 0xcc, 0x26, 0x33, 0xac,
+	0xf1, 0x4f, 0x40, 0xaa, 0xbd, 0x36, 0x65, 0xac, 0xb0, 0xc3, 0x87, 0xf4, 0x28, 0x1a, 0xbd, 0xef,
+	0xf9, 0x1e, 0xeb, 0xb5, 0x15, 0xc9, 0x30, 0xfd, 0x13, 0x48, 0x01, 0x36, 0x2e, 0x0f, 0x93, 0xd1,
+	0x6b, 0xf3, 0x76, 0xfc, 0x60, 0x97, 0xf7, 0xdb, 0x8c, 0x24, 0xd0, 0x4b, 0x58, 0xf6, 0xdc, 0x15,
+	0xc4, 0x42, 0xc1, 0x15, 0x04, 0xae, 0x4c, 0x4c, 0x9c, 0x11, 0x3b, 0x1a, 0xd7, 0x2d, 0x56, 0xe8,
+	0x39, 0x73, 0x98, 0xb4, 0xdb, 0xaa, 0xc1, 0xb8, 0x30, 0x87, 0x2c, 0xc9, 0x86, 0xe9, 0xff, 0x83,
+	0x9e, 0x67, 0xea, 0x51, 0xd2, 0xd4, 0x7a, 0xef, 0xc1, 0x72, 0x8e, 0x7b, 0x47, 0xca, 0x73, 0xfb,
+	0x46, 0x19, 0x7a, 0x1f, 0xfa, 0xc1, 0xfe, 0x90, 0xb8, 0x03, 0xb2, 0xe1, 0xed, 0xec, 0x4c, 0x22,
+	0x2f, 0xf0, 0x51, 0xed, 0x31, 0x86, 0xac, 0x5f, 0x83, 0xd5, 0x89, 0xef, 0x7d, 0x6d, 0x42, 0x6c,
+	0xe2, 0x7a, 0x71, 0x10, 0x46, 0x36, 0x0d, 0xfa, 0x72, 0x1e, 0xe8, 0xac, 0xee, 0x1e, 0xab, 0xa2,
+	0x41, 0x60, 0x3d, 0x80, 0x6e, 0xa6, 0x05, 0xda, 0x35, 0x11, 0xf5, 0x47, 0x71, 0x78, 0xdb, 0x9c,
+	0x3e, 0xa0, 0xf9, 0x89, 0xdc, 0xe3, 0x93, 0x3d, 0x0c, 0xcd, 0x8e, 0xb8, 0x5f, 0x7d, 0x6c, 0x52,
+	0x54, 0x87, 0x24, 0x86, 0x04, 0x79, 0x9d, 0x21, 0x91, 0x1d, 0x75, 0x75, 0x56, 0xa7, 0x90, 0x28,
+	0xd9, 0xac, 0x8a, 0x6a, 0xb3, 0xa4, 0xab, 0xfa, 0x6a, 0xf1, 0x55, 0xfd, 0x82, 0x74, 0x55, 0xdf,
+	0x7b, 0x00, 0xbd, 0xe9, 0xf4, 0x1e, 0x29, 0xd7, 0xe1, 0xdb, 0x55, 0x38, 0x99, 0xe7, 0x8a, 0x50,
+	0xff, 0x2f, 0xaa, 0x57, 0xe8, 0xaf, 0x9a, 0x53, 0x51, 0x0b, 0xee, 0xd0, 0x9f, 0x42, 0xd3, 0xf5,
+	0xa2, 0x38, 0xf4, 0xb6, 0x27, 0xd4, 0x89, 0x60, 0x8b, 0x70, 0x75, 0x46, 0x1f, 0x1b, 0x12, 0x3a,
+	0xd7, 0x47, 0xb9, 0x07, 0x1a, 0xa7, 0xf0, 0x30, 0x99, 0xc9, 0x96, 0x42, 0xa5, 0x55, 0xab, 0xc9,
+	0x80, 0x8f, 0x28, 0x4c, 0x55, 0xda, 0xca, 0x2c, 0xa5, 0xad, 0x66, 0x94, 0xf6, 0x91, 0x9a, 0x25,
+	0xc6, 0x9c, 0xad, 0x2b, 0x33, 0xe9, 0x4d, 0xb0, 0xb9, 0x75, 0x96, 0xda, 0xe3, 0x76, 0xea, 0x7a,
+	0xa1, 0x48, 0x1a, 0x63, 0x4e, 0x56, 0x1d, 0x21, 0x2c, 0x5b, 0xec, 0x55, 0x68, 0x47, 0xde, 0x30,
+	0xb0, 0x53, 0x0f, 0xb0, 0x46, 0xf7, 0xc1, 0x16, 0x42, 0xb7, 0x04, 0xb0, 0xf7, 0xc9, 0x21, 0x19,
+	0x06, 0xd7, 0x55, 0x4b, 0x70, 0x6a, 0x86, 0x8c, 0x67, 0xf4, 0x37, 0xc7, 0xed, 0x23, 0xc5, 0xa9,
+	0x7e, 0x1a, 0x96, 0xb2, 0xd3, 0x2f, 0xa0, 0xee, 0x6d, 0x95, 0xba, 0x73, 0x05, 0xd4, 0x89, 0x5e,
+	0x0e, 0x32, 0x24, 0x1a, 0xbf, 0x5a, 0x82, 0xb3, 0x87, 0xa0, 0xcb, 0x49, 0x5a, 0x5a, 0x92, 0xa4,
+	0x35, 0xd5, 0x78, 0x94, 0xa6, 0x1a, 0x8f, 0xa3, 0xeb, 0xf2, 0x79, 0x68, 0x32, 0x28, 0x6d, 0x11,
+	0x71, 0x77, 0xa9, 0x91, 0x62, 0x52, 0x01, 0x88, 0x83, 0xb1, 0xcd, 0xbd, 0x33, 0xa6, 0xd7, 0xf5,
+	0x38, 0x18, 0xb3, 0x3d, 0x1b, 0xab, 0xa9, 0x00, 0x44, 0xfd, 0x20, 0x24, 0x34, 0x28, 0x5e, 0xb2,
+	0xea, 0x08, 0xd9, 0x44, 0x00, 0x3a, 0x1f, 0x58, 0xa0, 0x82, 0x53, 0xb3, 0xe8, 0x6f, 0xe3, 0x0f,
+	0x4a, 0xa0, 0x3f, 0xf1, 0xb7, 0x03, 0x27, 0x74, 0x3d, 0x7f, 0x90, 0xf8, 0x29, 0x18, 0xc1, 0x71,
+	0x0e, 0x22, 0x3b, 0xf2, 0xfc, 0x3e, 0xb1, 0xbf, 0x1a, 0x78, 0x22, 0xf5, 0xbb, 0x85, 0xe0, 0x4d,
+	0x84, 0x7e, 0x10, 0x78, 0x54, 0x7f, 0x98, 0xa7, 0x22, 0x42, 0xff, 0x3c, 0xf1, 0x97, 0x02, 0xf9,
+	0xbd, 0x64, 0xea, 0xce, 0x30, 0xc6, 0x32, 0x0e, 0x30, 0x77, 0x26, 0xc9, 0x2b, 0x93, 0xfd, 0x9d,
+	0x8a, 0x84, 0xc0, 0xfc, 0x9d, 0xd7, 0x41, 0x1f, 0x11, 0xc7, 0xf7, 0xfc, 0xc1, 0xce, 0x24, 0x1d,
+	0x8b, 0xcd, 0x7f, 0x39, 0xad, 0x11, 0x03, 0xbe, 0x06, 0x4b, 0x12, 0x3a, 0x1b, 0x95, 0x5d, 0x11,
+	0x74, 0x52, 0x38, 0x1b, 0x5a, 0x45, 0x65, 0xe3, 0x2f, 0x66, 0x51, 0x99, 0x8b, 0xf7, 0x0f, 0x25,
+	0x38, 0x99, 0xb2, 0xea, 0x0e, 0x73, 0x71, 0x8f, 0xcc, 0x31, 0x0c, 0x7a, 0xee, 0x0d, 0xec, 0x3c,
+	0xd7, 0x34, 0xab, 0xe3, 0xec, 0x0d, 0xb6, 0x64, 0xc6, 0x5d, 0x82, 0x4e, 0x8a, 0x9b, 0x32, 0x4f,
+	0xb3, 0x5a, 0x02, 0xf3, 0x3e, 0xcf, 0x2d, 0x92, 0xf0, 0x52, 0x1e, 0x4a, 0x78, 0x8c, 0x8d, 0x6f,
+	0xc2, 0x71, 0xc4, 0x9b, 0xc2, 0x4a, 0xcd, 0x5a, 0x75, 0xf6, 0x06, 0x8f, 0x72, 0xdc, 0xbc, 0x06,
+	0xab, 0x99, 0x56, 0x29, 0x47, 0x35, 0x4b, 0x57, 0xda, 0xdc, 0x17, 0xda, 0x92, 0x69, 0x91, 0x32,
+	0x36, 0xdb, 0x82, 0xf1, 0xf6, 0x7f, 0xca, 0xb0, 0xca, 0x84, 0x38, 0xe5, 0x30, 0x55, 0x47, 0x9a,
+	0x2b, 0x1f, 0x46, 0x31, 0xa7, 0x54, 0x64, 0x26, 0xf0, 0x5c, 0xf9, 0x30, 0x8a, 0x19, 0x95, 0xf4,
+	0x06, 0xea, 0x2c, 0x34, 0x90, 0xef, 0x76, 0x3f, 0xd8, 0x0d, 0x42, 0x71, 0x21, 0x0d, 0x08, 0x5a,
+	0xa7, 0x10, 0xfd, 0xae, 0xec, 0x7b, 0x96, 0x79, 0x0e, 0x57, 0xd1, 0xb0, 0x33, 0x5c, 0xce, 0x2f,
+	0xc1, 0x22, 0x06, 0x57, 0x83, 0x24, 0x83, 0xd0, 0x28, 0xee, 0xe1, 0x11, 0x43, 0xe2, 0xd7, 0x17,
+	0xbc, 0x09, 0xa6, 0x28, 0xcb, 0xd9, 0xbf, 0x21, 0x71, 0x30, 0xd5, 0x92, 0x4b, 0xb2, 0x2e, 0x55,
+	0x59, 0xac, 0x46, 0x5f, 0x83, 0x25, 0x36, 0xff, 0x18, 0xb3, 0x32, 0xe5, 0x1c, 0xb9, 0x36, 0x85,
+	0xd3, 0x64, 0x4d, 0x3a, 0xfb, 0xab, 0xa0, 0x0f, 0x9d, 0x28, 0xb6, 0xf9, 0xf5, 0x0f, 0x4f, 0xe2,
+	0x60, 0xb2, 0xbc, 0x84, 0x35, 0xf2, 0xfd, 0x02, 0xde, 0xdd, 0x1e, 0xea, 0x12, 0xe6, 0xee, 0x6e,
+	0xf3, 0x86, 0x22, 0x73, 0x47, 0x21, 0x4f, 0xfa, 0x48, 0x4e, 0xc3, 0xcf, 0x96, 0xa1, 0xc1, 0x16,
+	0x89, 0xe5, 0xab, 0xd1, 0xec, 0x01, 0x2c, 0x72, 0xd3, 0xcf, 0x4b, 0xd2, 0x49, 0x4c, 0xb6, 0xbf,
+	0xfc, 0x08, 0xc3, 0xcc, 0xe8, 0x13, 0x54, 0x30, 0xaa, 0x9b, 0x76, 0x76, 0xb1, 0x0d, 0x53, 0x1a,
+	0xc3, 0xcc, 0x68, 0x30, 0x5f, 0xaa, 0x25, 0x27, 0x03, 0xd6, 0x6f, 0x43, 0x3d, 0x24, 0x31, 0xf1,
+	0xa9, 0xcb, 0x51, 0xe1, 0x47, 0x55, 0xb9, 0x23, 0x4b, 0xd4, 0x72, 0x61, 0x49, 0xb0, 0x7b, 0x36,
+	0x1c, 0x2b, 0x1c, 0x65, 0x9e, 0xcb, 0xa6, 0xa9, 0xa6, 0x46, 0x8d, 0x07, 0xb4, 0xd5, 0xd1, 0xe7,
+	0x0b, 0x81, 0x22, 0xed, 0x49, 0x3b, 0x79, 0x1d, 0x08, 0x74, 0x32, 0xb5, 0x78, 0xbe, 0x27, 0x43,
+	0x6f, 0xe0, 0x6d, 0x0f, 0x89, 0x08, 0x27, 0x8b, 0xb2, 0x4e, 0xf3, 0xd3, 0x63, 0xc7, 0xf3, 0x93,
+	0xdc, 0xbc, 0xa4, 0x8c, 0x75, 0x3b, 0xe2, 0x2b, 0x01, 0x1e, 0x17, 0x10, 0x65, 0xe3, 0x9b, 0x15,
+	0x58, 0x4e, 0xe7, 0x27, 0x7c, 0xc3, 0xdb, 0xa9, 0x33, 0x2b, 0x12, 0xbb, 0x72, 0x48, 0x5c, 0xd9,
+	0x84, 0x5e, 0x71, 0x7c, 0x6c, 0xca, 0x24, 0x24, 0xea, 0x96, 0xa6, 0x36, 0x65, 0x33, 0x13, 0x4d,
+	0x39, 0x3e, 0x5a, 0x0d, 0xee, 0x02, 0xd2, 0xe8, 0x74, 0x99, 0x25, 0x35, 0x33, 0x10, 0x0d, 0x4d,
+	0x5f, 0x87, 0x55, 0xc9, 0x92, 0xa5, 0xce, 0x15, 0xdb, 0xa6, 0x56, 0xd2, 0xba, 0xc4, 0xc5, 0xc2,
+	0x60, 0x13, 0xd7, 0x78, 0x8c, 0x88, 0xd1, 0x7e, 0x99, 0x22, 0xb6, 0x53, 0x30, 0xed, 0xfb, 0x35,
+	0x58, 0x4a, 0xa4, 0x45, 0xb8, 0xa0, 0x35, 0x4a, 0x41, 0x27, 0x81, 0x17, 0x79, 0xa1, 0xd5, 0x59,
+	0x5e, 0xe8, 0x82, 0xea, 0x85, 0xf6, 0x3e, 0x86, 0xa6, 0xcc, 0xb5, 0x79, 0x02, 0xdb, 0x45, 0x26,
+	0x4d, 0x96, 0xbb, 0x07, 0xd0, 0x94, 0xb9, 0x39, 0x4f, 0x9e, 0xaa, 0xa4, 0x31, 0xb2, 0xc4, 0xfd,
+	0x7d, 0x05, 0x6a, 0x34, 0xff, 0xc9, 0x8b, 0x9e, 0xa3, 0x87, 0x32, 0x76, 0xe2, 0x24, 0xe3, 0x0a,
+	0x7f, 0xa3, 0x53, 0x13, 0x7a, 0xd1, 0x73, 0xee, 0xd4, 0xb0, 0x9d, 0xb2, 0x8e, 0x10, 0xc9, 0xa9,
+	0xe1, 0xa9, 0x1b, 0x55, 0x8b, 0xfe, 0x46, 0x3b, 0xc3, 0x2e, 0x7c, 0xd8, 0x12, 0xb1, 0x02, 0x2e,
+	0x0a, 0xcd, 0x8c, 0xf7, 0xfc, 0x81, 0xed, 0x92, 0x41, 0x48, 0x44, 0xc2, 0x51, 0x5b, 0x80, 0x37,
+	0x28, 0x14, 0xfd, 0xe8, 0x34, 0x9c, 0x49, 0xa3, 0x0a, 0x6c, 0xab, 0x4b, 0x83, 0x9c, 0x34, 0x44,
+	0x80, 0x11, 0x45, 0xef, 0x73, 0x62, 0xfb, 0x41, 0x38, 0x72, 0x86, 0xde, 0xe7, 0xc4, 0xe5, 0x1b,
+	0x5c, 0x1b, 0xc1, 0x8f, 0x13, 0x28, 0x2e, 0x32, 0xbb, 0xfe, 0x90, 0x30, 0x6b, 0x6c, 0xc7, 0xa7,
+	0x70, 0x09, 0xf5, 0x0d, 0x58, 0x11, 0xc4, 0xc8, 0xd8, 0x75, 0x8a, 0xad, 0x8b, 0x2a, 0xa9, 0xc1,
+	0x75, 0x58, 0x4d, 0x69, 0x95, 0x5a, 0x00, 0x6d, 0xb1, 0x92, 0xd4, 0x49, 0x4d, 0xe4, 0xfc, 0xb8,
+	0x46, 0x26, 0x3f, 0x4e, 0x3a, 0x35, 0x36, 0x8b, 0x4f, 0x8d, 0x2d, 0x39, 0xc1, 0xfb, 0x24, 0xd4,
+	0xd0, 0xce, 0x52, 0x01, 0x6f, 0x53, 0xfc, 0x45, 0x67, 0x40, 0xa8, 0x64, 0x9f, 0x01, 0xe8, 0x07,
+	0xf8, 0xa1, 0xca, 0x0b, 0xbc, 0xcf, 0xec, 0x50, 0x72, 0x24, 0x08, 0x32, 0x19, 0x9b, 0x4a, 0x24,
+	0x2f, 0x71, 0x97, 0x65, 0x20, 0xf3, 0xee, 0x26, 0x1c, 0x4b, 0x1b, 0xc9, 0xd8, 0xcb, 0xcc, 0x63,
+	0x49, 0x2b, 0xd3, 0x46, 0xc6, 0x5f, 0x68, 0xd0, 0x4c, 0xb2, 0x8b, 0x50, 0xae, 0xe4, 0x29, 0x6b,
+	0x99, 0x29, 0x27, 0x0e, 0x7f, 0x49, 0x76, 0xf8, 0xe7, 0x17, 0xab, 0x4b, 0x40, 0x3d, 0x45, 0x5b,
+	0x12, 0x52, 0xe6, 0x4d, 0xb5, 0x10, 0x6c, 0x25, 0x82, 0x7a, 0x11, 0xda, 0x23, 0xe7, 0x85, 0x8c,
+	0xc6, 0xa4, 0xaa, 0x39, 0x72, 0x5e, 0x24, 0x58, 0xc6, 0x3f, 0x69, 0xa0, 0x3f, 0x08, 0xe2, 0x68,
+	0x1c, 0xc4, 0x08, 0x14, 0xa6, 0x31, 0x63, 0xa4, 0x98, 0xea, 0xca, 0x46, 0xea, 0x6c, 0x3a, 0x8b,
+	0x32, 0xcd, 0x2d, 0x15, 0x3a, 0x25, 0x26, 0x74, 0x25, 0x9f, 0xc9, 0xdc, 0x32, 0x65, 0x26, 0xc9,
+	0xf9, 0xcb, 0x37, 0x64, 0x47, 0xa9, 0xc2, 0x33, 0xad, 0x24, 0xb2, 0x92, 0xad, 0x28, 0x45, 0xa3,
+	0xa7, 0x4f, 0x5e, 0xe0, 0x81, 0x78, 0x71, 0xd1, 0xc7, 0xa1, 0x34, 0x0e, 0x6f, 0x58, 0xb0, 0x52,
+	0xd0, 0x11, 0xf2, 0x5b, 0x72, 0xed, 0xe8, 0x6f, 0xfd, 0xb2, 0x3a, 0xa7, 0x65, 0x99, 0x02, 0x39,
+	0x2e, 0x60, 0x7c, 0x05, 0x96, 0xb2, 0x55, 0x85, 0xa6, 0x44, 0x92, 0xee, 0x92, 0x22, 0xdd, 0xaa,
+	0x8d, 0x29, 0x67, 0x6c, 0x8c, 0xf1, 0x8f, 0x1a, 0x9c, 0xb0, 0x08, 0x8b, 0x62, 0x7b, 0xfe, 0xe0,
+	0x69, 0x18, 0xbc, 0x48, 0x92, 0x75, 0x56, 0xe5, 0x6b, 0xe0, 0xaa, 0x48, 0x90, 0xb9, 0x00, 0xad,
+	0x90, 0xa0, 0x8e, 0xd8, 0x34, 0x6c, 0xc6, 0xa6, 0x50, 0xb2, 0x9a, 0x0c, 0x68, 0x51, 0x18, 0x72,
+	0xcc, 0x43, 0x1f, 0x30, 0xe9, 0x98, 0xae, 0x4b, 0xcd, 0x6a, 0x79, 0x91, 0x34, 0x9a, 0x74, 0xc6,
+	0x62, 0xdf, 0x3a, 0xf0, 0x48, 0x0f, 0x3f, 0x63, 0x31, 0xd8, 0x21, 0x17, 0x3f, 0xb3, 0xb6, 0x07,
+	0x23, 0xc0, 0x6b, 0x7f, 0x9a, 0x31, 0xb0, 0x41, 0xfc, 0x08, 0x93, 0x38, 0xa8, 0x0b, 0x76, 0x01,
+	0x5a, 0x3c, 0x91, 0xc0, 0x4e, 0x83, 0xe5, 0x55, 0xab, 0xc9, 0x81, 0xec, 0x44, 0xf1, 0x0a, 0x6a,
+	0xb9, 0x4b, 0x6c, 0x39, 0xbf, 0xab, 0x8e, 0x10, 0x56, 0x9d, 0x68, 0x4c, 0x59, 0xd2, 0x18, 0xe3,
+	0x4f, 0x35, 0xd0, 0xd5, 0x11, 0xa9, 0x03, 0xbb, 0xae, 0x5c, 0x43, 0x8a, 0x0c, 0xad, 0x3c, 0xe2,
+	0xcc, 0x3b, 0xc8, 0xcd, 0x79, 0xee, 0x10, 0xbf, 0xa0, 0xee, 0x4d, 0xab, 0x66, 0xc1, 0xfc, 0xe5,
+	0x3d, 0xea, 0x6f, 0x34, 0x38, 0xa6, 0xa2, 0xdc, 0x0b, 0x03, 0x9a, 0x0b, 0x78, 0x1a, 0xd3, 0x91,
+	0xf8, 0x70, 0x7c, 0x84, 0x14, 0x80, 0x0b, 0xec, 0x32, 0x7c, 0x7b, 0x9b, 0xec, 0x04, 0x49, 0x76,
+	0x4b, 0x8b, 0x43, 0xef, 0x52, 0x20, 0x72, 0x5a, 0xa0, 0xd1, 0xb4, 0x17, 0xee, 0x2e, 0x35, 0x39,
+	0xf0, 0x0e, 0xc2, 0xe8, 0xb7, 0x34, 0x74, 0x13, 0xe1, 0x3d, 0xf1, 0xe8, 0x00, 0x85, 0xf1, 0x7e,
+	0xce, 0x02, 0x2b, 0xf2, 0x5e, 0x98, 0xfa, 0x01, 0x05, 0xd1, 0x3e, 0x8c, 0x6f, 0x95, 0xb3, 0xf3,
+	0x10, 0x52, 0xfc, 0x8e, 0x9a, 0xcc, 0x70, 0xde, 0x2c, 0x44, 0x2b, 0xc8, 0x04, 0x7b, 0x47, 0xd5,
+	0xd1, 0x69, 0x0d, 0xf3, 0xb1, 0xbc, 0x6b, 0xb0, 0x48, 0xc2, 0xc0, 0x15, 0x52, 0x8f, 0x97, 0x68,
+	0x85, 0x2c, 0xb6, 0x04, 0x9a, 0x2a, 0xe2, 0x95, 0x99, 0x22, 0x9e, 0x89, 0xc3, 0xf5, 0x1e, 0x1d,
+	0x92, 0x73, 0x91, 0x3b, 0xe9, 0xe4, 0xa5, 0x4e, 0xf5, 0xba, 0x67, 0x47, 0xd0, 0x8e, 0x2a, 0x5f,
+	0x7f, 0xa8, 0xc1, 0x92, 0x45, 0x06, 0xe4, 0xc5, 0x23, 0x12, 0x87, 0x5e, 0x3f, 0xa2, 0xea, 0x70,
+	0xa7, 0x40, 0x1d, 0xce, 0x9b, 0x59, 0xb4, 0x99, 0xca, 0x60, 0xcd, 0xa3, 0x0c, 0xb9, 0xb9, 0xcb,
+	0x43, 0xf0, 0xcf, 0x75, 0x24, 0x5a, 0xaf, 0x82, 0x9e, 0x47, 0x60, 0xe7, 0xb5, 0x24, 0xdb, 0xba,
+	0x2a, 0x12, 0xaa, 0x8d, 0x7f, 0xd3, 0x60, 0x45, 0x46, 0x17, 0xf2, 0xd6, 0xc5, 0x53, 0x34, 0x85,
+	0x88, 0x4f, 0xd7, 0x78, 0x31, 0xfd, 0xb6, 0x43, 0xf8, 0xf1, 0x05, 0xcd, 0x0b, 0xe4, 0xf0, 0x38,
+	0x2c, 0x50, 0x7b, 0x28, 0x1c, 0x78, 0x5e, 0x9a, 0x79, 0xa7, 0xd2, 0xfb, 0xf0, 0x10, 0xb1, 0xb8,
+	0xac, 0xb2, 0x66, 0x39, 0xc7, 0x7d, 0x99, 0x31, 0x9f, 0x41, 0x6b, 0x8b, 0x44, 0x31, 0x4d, 0x07,
+	0xa1, 0x0b, 0x88, 0xc1, 0x3a, 0x82, 0x91, 0x8b, 0x24, 0x3d, 0x09, 0x83, 0x75, 0x02, 0x05, 0xbd,
+	0xc2, 0x71, 0x18, 0xb8, 0x13, 0x7a, 0x22, 0x92, 0x12, 0x94, 0xaa, 0x56, 0x27, 0x85, 0x53, 0x54,
+	0xe3, 0x77, 0x4b, 0xd0, 0x4e, 0xfa, 0xde, 0x9c, 0x78, 0x31, 0xcd, 0xc8, 0xa1, 0x9d, 0xd3, 0x5c,
+	0x7a, 0xee, 0xd2, 0x20, 0x80, 0x7e, 0x15, 0x71, 0x19, 0xa4, 0x2e, 0x18, 0x0a, 0x0b, 0x86, 0xb4,
+	0x53, 0x30, 0x45, 0x3c, 0x0f, 0x4d, 0x46, 0x62, 0xf2, 0xc9, 0x08, 0x35, 0x2a, 0x94, 0x48, 0x06,
+	0xc2, 0xd0, 0x9b, 0x4c, 0x26, 0x47, 0x64, 0xd6, 0x67, 0x59, 0x22, 0x94, 0xa3, 0xab, 0x93, 0xae,
+	0xce, 0x33, 0xe9, 0x85, 0xc2, 0x49, 0xe3, 0xde, 0x41, 0xf7, 0x4e, 0xea, 0x54, 0x97, 0x2c, 0x56,
+	0x40, 0xc1, 0xd9, 0x0e, 0xbd, 0x38, 0x1e, 0xb2, 0x8f, 0x74, 0x6a, 0x96, 0x28, 0x1a, 0xbf, 0x53,
+	0x82, 0xa5, 0x84, 0x49, 0x42, 0xce, 0x6e, 0xa8, 0x76, 0xed, 0xb4, 0x99, 0xc5, 0x28, 0x10, 0xa5,
+	0xcb, 0xb0, 0x10, 0x21, 0x8f, 0x85, 0x08, 0x76, 0x4c, 0x95, 0xf7, 0x16, 0xaf, 0x46, 0x36, 0x53,
+	0xa2, 0xa4, 0x33, 0x21, 0xb3, 0xdc, 0x6d, 0x0a, 0x4e, 0x8f, 0x83, 0x67, 0xa1, 0x31, 0xf2, 0xb2,
+	0xcc, 0x83, 0x91, 0x97, 0x70, 0x6d, 0xa6, 0xf1, 0x7a, 0x70, 0x88, 0x94, 0x5e, 0x54, 0xa5, 0xb4,
+	0x6d, 0x2a, 0x62, 0xa8, 0xea, 0x2e, 0x4d, 0x65, 0xbb, 0x33, 0x20, 0x4f, 0x0f, 0x42, 0x67, 0xe4,
+	0xb9, 0xe9, 0x77, 0x77, 0x62, 0x8b, 0x2f, 0x27, 0xf7, 0xe1, 0xc6, 0xb7, 0x4b, 0x70, 0x4c, 0x45,
+	0x17, 0x5c, 0x2d, 0x72, 0xd6, 0x70, 0x61, 0x26, 0xfd, 0xe7, 0x24, 0xf9, 0x26, 0x49, 0x14, 0x33,
+	0xe9, 0x45, 0x65, 0x9e, 0x5e, 0x54, 0xd8, 0xf3, 0x2c, 0x6b, 0x26, 0xa9, 0x38, 0x4b, 0x11, 0x2c,
+	0x54, 0xf1, 0x2c, 0xf3, 0xb6, 0xe6, 0x31, 0x81, 0x85, 0x79, 0x5d, 0x59, 0x2e, 0xc9, 0x8c, 0xbc,
+	0x0b, 0x4d, 0x8b, 0xec, 0x87, 0x5e, 0x5c, 0xf4, 0x79, 0x65, 0x59, 0x7c, 0xb8, 0x78, 0x1a, 0x03,
+	0x47, 0x88, 0x15, 0x13, 0x91, 0x7b, 0x98, 0x02, 0x8c, 0xef, 0x96, 0xd1, 0x34, 0xd2, 0x4e, 0xa8,
+	0x3f, 0x28, 0x98, 0x7b, 0x2b, 0x49, 0xd1, 0x13, 0x89, 0x85, 0x05, 0x58, 0x85, 0x59, 0x7a, 0x1b,
+	0x0a, 0xa3, 0xc5, 0xb7, 0xbe, 0x45, 0xad, 0x67, 0xb1, 0xf9, 0x02, 0x54, 0x29, 0x63, 0xf9, 0x57,
+	0x03, 0x2d, 0x53, 0x9e, 0xa9, 0xc5, 0xea, 0x66, 0x5f, 0x89, 0x65, 0x0e, 0x2b, 0xd5, 0xdc, 0x61,
+	0x65, 0x66, 0xb4, 0xe2, 0xc1, 0x61, 0x29, 0x7d, 0x17, 0xd4, 0xd5, 0xca, 0x12, 0x98, 0x6e, 0xd3,
+	0x1f, 0xcd, 0xb3, 0xf6, 0xf3, 0xf6, 0x86, 0x9f, 0xa6, 0x2c, 0xaf, 0x87, 0x41, 0x14, 0x6d, 0xf1,
+	0x64, 0xf6, 0xa7, 0x8e, 0x17, 0xd2, 0xf4, 0x51, 0x91, 0x15, 0x7e, 0x5d, 0x9c, 0xcb, 0x52, 0x88,
+	0x52, 0x7f, 0x83, 0xdb, 0x77, 0x09, 0x82, 0xac, 0x18, 0x38, 0x63, 0x96, 0x01, 0xcd, 0x0f, 0x1e,
+	0xb5, 0x81, 0x33, 0xa6, 0x99, 0xcf, 0x2c, 0xb5, 0x86, 0x1d, 0xf9, 0xc5, 0xde, 0x25, 0xca, 0xc6,
+	0xf7, 0x4b, 0xb0, 0xaa, 0x90, 0x23, 0xe4, 0xe7, 0x4b, 0x69, 0x8a, 0xbd, 0x26, 0xa2, 0x9e, 0x05,
+	0x78, 0x53, 0xf2, 0xeb, 0xd7, 0xa0, 0x3a, 0x76, 0xbc, 0x50, 0x88, 0x8f, 0x6e, 0xe6, 0xa6, 0x6c,
+	0x31, 0x04, 0x74, 0x6e, 0xc5, 0x25, 0x06, 0x27, 0x91, 0xe5, 0xa9, 0xb4, 0xf8, 0xdd, 0x0f, 0x03,
+	0x22, 0x5a, 0x1f, 0xbb, 0xb0, 0x33, 0x33, 0x69, 0x51, 0x68, 0x82, 0x66, 0x40, 0x0b, 0x4d, 0x64,
+	0xca, 0x0b, 0xfe, 0xad, 0xf8, 0xc8, 0xf3, 0xdf, 0x17, 0xec, 0x50, 0x84, 0x6e, 0x41, 0x15, 0xba,
+	0x97, 0xc9, 0x90, 0x37, 0xde, 0x81, 0xd6, 0x9d, 0xed, 0x88, 0xf8, 0x7d, 0x7c, 0x64, 0xc7, 0x0b,
+	0x68, 0xb4, 0x83, 0x3e, 0x46, 0xc4, 0x9b, 0xb3, 0x02, 0x76, 0x49, 0x7c, 0x71, 0x74, 0xc4, 0x9f,
+	0xc6, 0x67, 0xb0, 0x9c, 0x7c, 0x13, 0xc0, 0x7b, 0xa0, 0xab, 0xb6, 0xed, 0x44, 0x84, 0x7e, 0xd1,
+	0xc6, 0xf2, 0x7c, 0x92, 0xb2, 0xbe, 0x06, 0x8b, 0x63, 0x3a, 0x84, 0x60, 0x70, 0xdb, 0x54, 0x46,
+	0xb6, 0x44, 0xb5, 0xe1, 0x61, 0x40, 0x9c, 0x05, 0x7e, 0xdf, 0x77, 0xc6, 0x87, 0x1c, 0x34, 0x78,
+	0x1a, 0x76, 0x28, 0xa6, 0x46, 0x0b, 0xe9, 0x2c, 0xca, 0x05, 0xb3, 0xa8, 0xa4, 0xb3, 0xf8, 0xb3,
+	0x32, 0xb4, 0x39, 0x15, 0x42, 0x88, 0xde, 0x93, 0xc4, 0x36, 0x8d, 0xc6, 0xaa, 0x48, 0xe9, 0xe7,
+	0x10, 0xc2, 0x8a, 0xa4, 0x4d, 0xf0, 0xf3, 0x3b, 0x4a, 0x84, 0x98, 0xe7, 0xa9, 0x6c, 0x63, 0x96,
+	0x5e, 0xc2, 0x0d, 0x18, 0x43, 0xd5, 0xaf, 0xe3, 0x91, 0x93, 0xc7, 0xee, 0x69, 0x62, 0x58, 0x99,
+	0x7f, 0x29, 0x2f, 0x71, 0x02, 0x0f, 0xa0, 0x49, 0x81, 0x5e, 0xa8, 0x48, 0xa9, 0x87, 0x99, 0xe3,
+	0x81, 0x9e, 0x54, 0x6d, 0xcd, 0x75, 0x4e, 0x98, 0x2d, 0x61, 0x1f, 0x43, 0x27, 0x33, 0xe3, 0x02,
+	0x21, 0x5b, 0x53, 0xcd, 0x89, 0x6e, 0xe6, 0xe4, 0x43, 0xb6, 0x50, 0xb7, 0xa1, 0x21, 0xf1, 0xe1,
+	0x48, 0xd9, 0xae, 0xdf, 0xd4, 0xf0, 0xba, 0x9c, 0x3e, 0xc4, 0x15, 0x1f, 0x7c, 0x3c, 0x71, 0x42,
+	0x3c, 0x24, 0xde, 0xca, 0x7e, 0xf2, 0x79, 0xc6, 0xcc, 0xe2, 0xf0, 0x6f, 0x40, 0xd3, 0x28, 0x38,
+	0x2d, 0xa1, 0xfa, 0xc8, 0x15, 0x47, 0x52, 0x9f, 0xef, 0x95, 0xe0, 0xf4, 0x7a, 0xe0, 0x27, 0x57,
+	0xff, 0xc9, 0x90, 0x42, 0x9a, 0xde, 0x87, 0xda, 0xd7, 0xd8, 0xe8, 0x82, 0xae, 0x2b, 0xe6, 0xac,
+	0x06, 0x26, 0xa7, 0x55, 0x3c, 0xc2, 0x21, 0x1a, 0xcf, 0xfe, 0x9e, 0x69, 0xae, 0x8f, 0xb4, 0xf5,
+	0xb7, 0xe0, 0x38, 0x7d, 0xbf, 0xc8, 0x77, 0x86, 0xb6, 0x8a, 0xce, 0xb6, 0xb1, 0x63, 0xa2, 0xf6,
+	0x89, 0x5c, 0xd9, 0x7b, 0x0c, 0x2d, 0x85, 0xa8, 0x79, 0x4e, 0x0b, 0x59, 0xd6, 0xcb, 0x3c, 0xbb,
+	0x02, 0x2b, 0xf7, 0x27, 0xbe, 0x4f, 0x86, 0x32, 0x1f, 0x78, 0x34, 0x69, 0x94, 0x7a, 0x62, 0xb4,
+	0x60, 0xfc, 0x4b, 0x09, 0x4e, 0xca, 0x78, 0xac, 0xa5, 0xe0, 0xee, 0x19, 0x80, 0x91, 0x37, 0x24,
+	0x51, 0x1c, 0xf8, 0xc9, 0xdb, 0x32, 0x12, 0x44, 0xdf, 0x44, 0xad, 0x92, 0x06, 0xe9, 0x96, 0x92,
+	0x0f, 0xbb, 0xa7, 0x74, 0xa9, 0xd4, 0xf0, 0x45, 0x50, 0xfb, 0x98, 0x9d, 0xc8, 0x96, 0x5b, 0x89,
+	0xca, 0xd1, 0x56, 0xa2, 0x3a, 0x6b, 0x25, 0x9e, 0x61, 0xf0, 0x28, 0x4b, 0x5e, 0xc1, 0x72, 0xe4,
+	0x0e, 0xe1, 0x05, 0xfc, 0x96, 0x57, 0xe4, 0x57, 0x34, 0xe8, 0xe0, 0x67, 0x21, 0x8f, 0x48, 0x38,
+	0x10, 0x0f, 0x52, 0x24, 0x0f, 0x4c, 0xa4, 0xdf, 0x34, 0xb2, 0x22, 0xfa, 0x38, 0xf4, 0xbb, 0x86,
+	0x11, 0x62, 0x8b, 0x3d, 0x01, 0x22, 0xd1, 0xde, 0x65, 0xb7, 0x03, 0xfe, 0x60, 0x48, 0x6c, 0x67,
+	0x3c, 0x0e, 0xd1, 0x64, 0x71, 0x33, 0xdc, 0x66, 0xe0, 0x3b, 0x1c, 0x8a, 0x63, 0x4c, 0xfc, 0xe7,
+	0x7e, 0xb0, 0x2f, 0xe2, 0xca, 0xa2, 0x68, 0xfc, 0xb0, 0x04, 0x4b, 0x09, 0x45, 0x62, 0xb5, 0x2f,
+	0x09, 0xf7, 0x4c, 0xe3, 0xb7, 0x79, 0x19, 0x9a, 0x85, 0x87, 0xf6, 0x56, 0xf2, 0xf5, 0xa7, 0xf8,
+	0xd2, 0x23, 0xdb, 0x95, 0xc9, 0x2e, 0x96, 0xb8, 0x09, 0x66, 0xc8, 0x99, 0xa8, 0x43, 0x99, 0x47,
+	0x1d, 0x72, 0x4d, 0x67, 0x45, 0x1d, 0x3e, 0x84, 0x86, 0xd4, 0x73, 0x81, 0x51, 0xcb, 0x5d, 0x48,
+	0xe6, 0xa6, 0x90, 0x5a, 0xc8, 0x27, 0xf3, 0xf8, 0x70, 0x47, 0xe8, 0xd0, 0x30, 0x00, 0x3e, 0x0d,
+	0xc2, 0xe7, 0x78, 0x87, 0x4d, 0xe2, 0x29, 0x4f, 0x32, 0xfd, 0xbe, 0x06, 0x3a, 0x9d, 0xc2, 0xf0,
+	0x20, 0xc5, 0x8d, 0x30, 0x40, 0x99, 0xdb, 0x14, 0x2f, 0x98, 0x79, 0xc4, 0x59, 0x1b, 0x63, 0xef,
+	0x83, 0x79, 0x76, 0x91, 0x5c, 0xf6, 0x76, 0xda, 0xbb, 0x3c, 0x97, 0xff, 0xd0, 0xa0, 0x9b, 0xd6,
+	0x60, 0xca, 0xde, 0xd0, 0x19, 0x0b, 0x41, 0xf9, 0x72, 0x22, 0x00, 0x22, 0xd5, 0x6e, 0x1a, 0x6a,
+	0xa1, 0x20, 0xac, 0xca, 0x81, 0xbd, 0xba, 0x88, 0xda, 0xcd, 0x54, 0xfb, 0x25, 0x28, 0x63, 0x96,
+	0x3e, 0xf7, 0x2c, 0xe2, 0x60, 0xdc, 0x7b, 0x7c, 0x98, 0x28, 0xe4, 0x82, 0x4f, 0x79, 0x6e, 0xca,
+	0x13, 0x76, 0xa1, 0x79, 0x77, 0xe8, 0x8c, 0xc8, 0x26, 0x19, 0xd0, 0xf7, 0x31, 0xc4, 0xc3, 0x01,
+	0x5a, 0xfa, 0x70, 0xc0, 0x94, 0xaf, 0x8d, 0xa7, 0xbd, 0xc8, 0x20, 0x8e, 0xb2, 0x95, 0xf4, 0x28,
+	0x6b, 0xbc, 0x0d, 0x75, 0x3a, 0x0a, 0x0d, 0x91, 0xbc, 0x06, 0xb5, 0x88, 0x8d, 0x26, 0x18, 0xd9,
+	0x32, 0x65, 0x1a, 0xac, 0xa4, 0xda, 0xf8, 0x3b, 0x0d, 0x74, 0x5a, 0xb5, 0x31, 0x19, 0x49, 0x1f,
+	0xad, 0xbf, 0xa9, 0xa6, 0x3c, 0x9e, 0x31, 0xf3, 0x38, 0x05, 0xf1, 0xd1, 0xf9, 0x1f, 0x2b, 0xc9,
+	0x7c, 0xb4, 0xde, 0xdb, 0x38, 0x24, 0x3a, 0x99, 0x7b, 0x67, 0x23, 0x99, 0xac, 0xcc, 0xea, 0x1f,
+	0x6a, 0xb0, 0x8c, 0x41, 0x7c, 0xfe, 0xb4, 0x10, 0xbb, 0x67, 0x90, 0x6f, 0x50, 0x34, 0xe5, 0x06,
+	0xe5, 0x2c, 0x34, 0xc6, 0x21, 0xd9, 0x13, 0xa9, 0x69, 0xdc, 0x1e, 0x22, 0x88, 0xe7, 0xa6, 0x9d,
+	0x82, 0x3a, 0x45, 0xa0, 0xdc, 0x66, 0x6b, 0x50, 0x43, 0x80, 0xc8, 0xdc, 0xe9, 0x4f, 0xc2, 0x50,
+	0xb4, 0xe6, 0x01, 0x12, 0x04, 0xa5, 0xad, 0x29, 0x82, 0xf4, 0x8c, 0x54, 0x0d, 0x01, 0xb4, 0xf5,
+	0x2a, 0x54, 0x5d, 0x32, 0x8c, 0x1d, 0x7e, 0x94, 0x64, 0x05, 0x5c, 0xd9, 0xe7, 0x9e, 0xef, 0xf2,
+	0x8b, 0x77, 0xfa, 0xdb, 0xf8, 0x8d, 0x92, 0x3a, 0xa9, 0x97, 0x7d, 0xe7, 0x43, 0x48, 0x4f, 0x59,
+	0x0a, 0x84, 0xa4, 0x92, 0x56, 0x51, 0x24, 0xed, 0x6a, 0xba, 0x97, 0x54, 0xf9, 0xd9, 0x2a, 0xc7,
+	0xdf, 0x74, 0x7f, 0xb9, 0x29, 0x67, 0xe9, 0xa2, 0xf5, 0xce, 0x91, 0x6d, 0x3e, 0x76, 0x46, 0x7c,
+	0x91, 0x45, 0x12, 0xef, 0x2d, 0x80, 0x14, 0x78, 0x98, 0x0b, 0x57, 0x97, 0x57, 0xfb, 0x97, 0x4b,
+	0x70, 0x5c, 0x1a, 0x01, 0x85, 0x53, 0x0a, 0xd5, 0x4e, 0x79, 0xfd, 0xf5, 0x6a, 0xea, 0x6d, 0x96,
+	0x0a, 0x66, 0x94, 0x79, 0x6b, 0xe4, 0x96, 0x50, 0x03, 0x91, 0x8b, 0x53, 0x3c, 0xde, 0x61, 0xaa,
+	0x70, 0x94, 0xfc, 0x5b, 0x64, 0x48, 0xb1, 0x2a, 0x1c, 0xca, 0x90, 0x9f, 0xd3, 0xa0, 0xb3, 0x15,
+	0x8c, 0x83, 0x61, 0x30, 0x38, 0x78, 0xca, 0x5f, 0xd7, 0x2c, 0xba, 0x52, 0x3c, 0x0d, 0xf5, 0x91,
+	0xe3, 0x7b, 0x3b, 0x24, 0x4a, 0x02, 0x5f, 0x29, 0x20, 0x35, 0xa2, 0x65, 0xf9, 0x6e, 0x39, 0xb1,
+	0x50, 0x95, 0xcc, 0x7b, 0x08, 0x6a, 0x62, 0xa3, 0x28, 0x1a, 0xcf, 0xa0, 0x29, 0x48, 0xb9, 0xe7,
+	0x8a, 0x1b, 0xeb, 0x30, 0x8a, 0xd3, 0x14, 0xd5, 0x30, 0xa2, 0x0f, 0xae, 0x44, 0xa4, 0x1f, 0x24,
+	0x07, 0x54, 0x5e, 0x52, 0x5f, 0x04, 0x52, 0xfa, 0x75, 0xd3, 0x29, 0x8a, 0xc5, 0xbe, 0x0a, 0x35,
+	0xfe, 0x96, 0xa8, 0x30, 0x57, 0x4b, 0x66, 0x86, 0x0d, 0x56, 0x82, 0x81, 0xb1, 0x13, 0xcc, 0xa4,
+	0x15, 0xcb, 0xdf, 0x32, 0x65, 0x32, 0x2d, 0x56, 0x67, 0xfc, 0x24, 0xbb, 0x5e, 0xf4, 0x62, 0x5c,
+	0x11, 0xba, 0xde, 0x83, 0xd0, 0x19, 0xcd, 0x7e, 0x2e, 0x22, 0xdd, 0x79, 0xf2, 0x4c, 0x2b, 0xcb,
+	0x6f, 0x6b, 0xe0, 0xfb, 0x80, 0x69, 0xef, 0xd4, 0x1a, 0xdc, 0x80, 0xfa, 0xae, 0x18, 0xa5, 0xab,
+	0x49, 0x17, 0x30, 0x19, 0x0a, 0xac, 0x14, 0x0d, 0xe3, 0xe0, 0x23, 0xe2, 0x7a, 0x8e, 0x6f, 0xcb,
+	0xa9, 0x00, 0x0d, 0x06, 0xbb, 0x2f, 0x84, 0x70, 0x7c, 0xfb, 0x9a, 0x92, 0xc2, 0x5a, 0x1b, 0xdf,
+	0xbe, 0xc6, 0x2a, 0xd3, 0xf6, 0xf2, 0xc2, 0xf2, 0xf6, 0xc9, 0xd3, 0x88, 0xd8, 0x9e, 0xd5, 0x57,
+	0x93, 0xf6, 0xb4, 0xd2, 0xf8, 0x13, 0x0d, 0xe0, 0x11, 0x19, 0x38, 0x33, 0x0c, 0x52, 0x6a, 0x56,
+	0x4a, 0x85, 0x1b, 0x98, 0x6c, 0x82, 0x56, 0xd3, 0x67, 0x86, 0x54, 0xb1, 0x63, 0x41, 0xca, 0xea,
+	0x94, 0xd7, 0xd5, 0x16, 0xa6, 0xbe, 0xae, 0xb6, 0xa8, 0xbe, 0xae, 0xf6, 0xf3, 0x15, 0x58, 0x4e,
+	0x39, 0x2a, 0x64, 0xe7, 0xed, 0x4c, 0xe0, 0xf2, 0x8c, 0x99, 0xc3, 0x29, 0x0c, 0x5b, 0xde, 0x54,
+	0x6f, 0x7c, 0x5e, 0x29, 0x68, 0x96, 0x0f, 0xd2, 0x9b, 0xc8, 0xf1, 0x81, 0x63, 0xcb, 0x8f, 0x5d,
+	0xa1, 0xa3, 0x94, 0x72, 0x11, 0xd9, 0x3f, 0x70, 0xa4, 0x7b, 0x09, 0x8a, 0x2f, 0xf3, 0xa5, 0x8e,
+	0x10, 0xb6, 0x80, 0xa2, 0x5a, 0x5e, 0x1e, 0x5a, 0xcd, 0x16, 0xef, 0x3c, 0x7b, 0x88, 0x33, 0xb2,
+	0xb7, 0x83, 0x89, 0xef, 0x32, 0xa3, 0x5c, 0x65, 0xcf, 0x6f, 0x46, 0x77, 0x29, 0x08, 0x51, 0x68,
+	0x63, 0x81, 0xc2, 0x9e, 0x2a, 0x6c, 0x50, 0x18, 0x47, 0x51, 0xec, 0x58, 0x6d, 0x96, 0x1d, 0xab,
+	0x67, 0xec, 0xd8, 0x93, 0xc3, 0x62, 0xa2, 0x85, 0x37, 0x8e, 0x59, 0x81, 0x57, 0x1e, 0x87, 0x9b,
+	0x7d, 0xa7, 0x90, 0x7b, 0x60, 0x48, 0x55, 0x32, 0xe5, 0x1b, 0x67, 0x0d, 0x6f, 0x04, 0xf7, 0x3c,
+	0xb2, 0xff, 0x91, 0x13, 0x13, 0xbf, 0x7f, 0x90, 0x64, 0x70, 0xd2, 0xb3, 0x91, 0x50, 0x6f, 0x5e,
+	0x92, 0xf5, 0xbe, 0xa4, 0xea, 0xfd, 0x1a, 0x2c, 0x31, 0x85, 0xb1, 0x87, 0xc4, 0x71, 0xd9, 0xa6,
+	0xcb, 0x7c, 0x9b, 0x36, 0x57, 0x24, 0xe2, 0xb8, 0xe2, 0x69, 0x70, 0xaa, 0x4b, 0x09, 0x1a, 0x0b,
+	0x29, 0x36, 0x50, 0x9f, 0x04, 0xce, 0x55, 0xd0, 0x59, 0x2b, 0x3b, 0xa4, 0xc4, 0xd9, 0xfb, 0x8e,
+	0x17, 0xf3, 0x0d, 0x82, 0x8f, 0xc3, 0xa8, 0xfe, 0xd4, 0xf1, 0x68, 0xf6, 0x36, 0xf6, 0x28, 0xa3,
+	0x32, 0x67, 0x02, 0x07, 0x4a, 0xf1, 0xf0, 0x64, 0xd0, 0xc0, 0x97, 0x8c, 0x07, 0xec, 0x6b, 0xa8,
+	0x97, 0xd6, 0x54, 0x89, 0x1b, 0x15, 0x95, 0x1b, 0xa7, 0xa0, 0x9e, 0xce, 0x8f, 0xef, 0x6b, 0x43,
+	0x31, 0xb9, 0xb3, 0xd0, 0xc8, 0x93, 0x0a, 0x61, 0x4a, 0xe7, 0xaf, 0x97, 0x61, 0x55, 0x59, 0x94,
+	0x54, 0x49, 0x33, 0xaf, 0x16, 0x14, 0x61, 0x15, 0xe8, 0xdb, 0xed, 0xcc, 0xc3, 0x01, 0xe7, 0x8b,
+	0x1b, 0x16, 0xe9, 0xf7, 0x35, 0x68, 0x7a, 0x29, 0xcb, 0xd2, 0xa0, 0x9e, 0xc4, 0x47, 0x4b, 0xc1,
+	0x78, 0x89, 0x0d, 0xff, 0xc8, 0x17, 0xfd, 0x79, 0xc9, 0x55, 0x2f, 0xfa, 0x0f, 0xd1, 0xbb, 0xa3,
+	0xf5, 0x67, 0xfc, 0x14, 0xac, 0x24, 0xdf, 0x9f, 0x7c, 0xc4, 0xc2, 0xdf, 0x7e, 0x9c, 0xfb, 0xfe,
+	0x41, 0xcb, 0x7d, 0xef, 0x89, 0x19, 0x89, 0xe1, 0x78, 0xd7, 0xf1, 0x89, 0xab, 0xbc, 0x08, 0xd0,
+	0x12, 0x50, 0xb6, 0x8d, 0x7c, 0xbd, 0x04, 0xc7, 0x94, 0xfe, 0x93, 0xf4, 0xaa, 0x1f, 0xd3, 0x08,
+	0xfa, 0x43, 0xf5, 0x83, 0x26, 0xf1, 0xea, 0x40, 0xe1, 0xa0, 0xb3, 0x3f, 0x66, 0xea, 0x6d, 0xcd,
+	0xf5, 0xb9, 0x4f, 0xce, 0xb0, 0x15, 0xf0, 0x4f, 0xe6, 0xf0, 0x2f, 0x95, 0x61, 0x55, 0x41, 0x11,
+	0x82, 0x7f, 0x37, 0xff, 0xdd, 0xe9, 0x45, 0xb3, 0x08, 0x73, 0x46, 0xee, 0xff, 0x7b, 0x50, 0x73,
+	0xc9, 0xd8, 0x09, 0xd3, 0x47, 0x5c, 0x2f, 0x14, 0x77, 0xb1, 0xc1, 0xb1, 0x78, 0xfc, 0x52, 0x34,
+	0xc2, 0x4c, 0x1f, 0xcf, 0xa7, 0x09, 0xfa, 0x44, 0x64, 0x1b, 0xd3, 0x9c, 0x2a, 0x01, 0x14, 0xb7,
+	0x63, 0x3f, 0xa2, 0xf4, 0xff, 0x48, 0x9f, 0xae, 0x17, 0xae, 0x9d, 0xac, 0x04, 0x5f, 0x84, 0x96,
+	0x32, 0x9f, 0x23, 0xc5, 0x86, 0xff, 0x4b, 0x83, 0x4e, 0xfe, 0x61, 0xc2, 0x85, 0x5d, 0xe2, 0xb8,
+	0x24, 0xe4, 0xee, 0x59, 0x3d, 0xf9, 0xd7, 0x05, 0x8b, 0x57, 0xe8, 0xef, 0xe2, 0xcd, 0x97, 0x1f,
+	0x27, 0x4f, 0x5c, 0xa2, 0x37, 0x91, 0xe9, 0xc6, 0x5c, 0xe7, 0x08, 0xc9, 0x4b, 0xcd, 0xac, 0xa8,
+	0xdf, 0x83, 0x65, 0x29, 0xa7, 0xce, 0x1e, 0x63, 0xb6, 0x1e, 0xbf, 0xcc, 0xec, 0x9a, 0x53, 0xd2,
+	0xf8, 0xac, 0xa5, 0x30, 0x53, 0xc1, 0x1e, 0x7c, 0x96, 0x46, 0x38, 0x2c, 0x3a, 0xdf, 0x94, 0xa6,
+	0xbd, 0xbd, 0x40, 0xff, 0x46, 0xe3, 0xe6, 0xff, 0x0e, 0x00, 0xe6, 0x74, 0x01, 0xc4, 0x52, 0x63,
+	0x00, 0x00,
 }
@@ -13,7 +13,7 @@ _sym_db = _symbol_database.Default()
 
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x08pb.proto\"\x81\x02\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"i\n\x0e\x46ilesOwnership\x12)\n\x05value\x18\x01 \x03(\x0b\x32\x1a.FilesOwnership.ValueEntry\x1a,\n\nValueEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xf4\x02\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12(\n\x0f\x66iles_ownership\x18\x07 \x03(\x0b\x32\x0f.FilesOwnership\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x12\x1b\n\x13repository_sequence\x18\t \x03(\t\x12+\n\x0crepositories\x18\n \x03(\x0b\x32\x15.BurndownSparseMatrix\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\x94\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12\x13\n\x0b\x66iles_lines\x18\t \x03(\x05\"\x9c\x01\n\x0eShotnessRecord\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12/\n\x08\x63ounters\x18\x04 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\xa9\x01\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12\x42\n\x14\x63hanges_by_developer\x18\x02 \x03(\x0b\x32$.FileHistory.ChangesByDeveloperEntry\x1a\x45\n\x17\x43hangesByDeveloperEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"\x8b\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"<\n\tLineStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\"\x9f\x01\n\x07\x44\x65vTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05stats\x18\x02 \x01(\x0b\x32\n.LineStats\x12*\n\tlanguages\x18\x03 \x03(\x0b\x32\x17.DevTick.LanguagesEntry\x1a<\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"d\n\x08TickDevs\x12!\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32\x13.TickDevs.DevsEntry\x1a\x35\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.DevTick:\x02\x38\x01\"\xa4\x01\n\x13\x44\x65vsAnalysisResults\x12.\n\x05ticks\x18\x01 \x03(\x0b\x32\x1f.DevsAnalysisResults.TicksEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x1a\x37\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.TickDevs:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa7\x01\n\x17\x43ommentSentimentResults\x12H\n\x11sentiment_by_tick\x18\x01 \x03(\x0b\x32-.CommentSentimentResults.SentimentByTickEntry\x1a\x42\n\x14SentimentByTickEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"G\n\nCommitFile\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08language\x18\x03 \x01(\t\x12\x19\n\x05stats\x18\x04 \x01(\x0b\x32\n.LineStats\"Z\n\x06\x43ommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x16\n\x0ewhen_unix_time\x18\x02 \x01(\x03\x12\x0e\n\x06\x61uthor\x18\x03 \x01(\x05\x12\x1a\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x0b.CommitFile\"H\n\x16\x43ommitsAnalysisResults\x12\x18\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x07.Commit\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\"R\n\x04Typo\x12\r\n\x05wrong\x18\x01 \x01(\t\x12\x0f\n\x07\x63orrect\x18\x02 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\x0c\n\x04line\x18\x05 \x01(\x05\"$\n\x0cTyposDataset\x12\x14\n\x05typos\x18\x01 \x03(\x0b\x32\x05.Typo\"l\n\x0eImportsPerTick\x12+\n\x06\x63ounts\x18\x01 \x03(\x0b\x32\x1b.ImportsPerTick.CountsEntry\x1a-\n\x0b\x43ountsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x82\x01\n\x12ImportsPerLanguage\x12-\n\x05ticks\x18\x01 \x03(\x0b\x32\x1e.ImportsPerLanguage.TicksEntry\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ImportsPerTick:\x02\x38\x01\"\x94\x01\n\x13ImportsPerDeveloper\x12\x36\n\tlanguages\x18\x01 \x03(\x0b\x32#.ImportsPerDeveloper.LanguagesEntry\x1a\x45\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ImportsPerLanguage:\x02\x38\x01\"l\n\x1aImportsPerDeveloperResults\x12%\n\x07imports\x18\x01 \x03(\x0b\x32\x14.ImportsPerDeveloper\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"3\n\x11TemporalDimension\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\x05\x12\r\n\x05lines\x18\x02 \x03(\x05\"\xab\x01\n\x19\x44\x65veloperTemporalActivity\x12$\n\x08weekdays\x18\x01 \x01(\x0b\x32\x12.TemporalDimension\x12!\n\x05hours\x18\x02 \x01(\x0b\x32\x12.TemporalDimension\x12\"\n\x06months\x18\x03 \x01(\x0b\x32\x12.TemporalDimension\x12!\n\x05weeks\x18\x04 \x01(\x0b\x32\x12.TemporalDimension\"r\n\x14TemporalActivityTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\x0f\n\x07weekday\x18\x03 \x01(\x05\x12\x0c\n\x04hour\x18\x04 \x01(\x05\x12\r\n\x05month\x18\x05 \x01(\x05\x12\x0c\n\x04week\x18\x06 \x01(\x05\"\x91\x01\n\x18TemporalActivityTickDevs\x12\x31\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32#.TemporalActivityTickDevs.DevsEntry\x1a\x42\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.TemporalActivityTick:\x02\x38\x01\"\xc9\x02\n\x17TemporalActivityResults\x12<\n\nactivities\x18\x01 \x03(\x0b\x32(.TemporalActivityResults.ActivitiesEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x32\n\x05ticks\x18\x03 \x03(\x0b\x32#.TemporalActivityResults.TicksEntry\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x1aM\n\x0f\x41\x63tivitiesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.DeveloperTemporalActivity:\x02\x38\x01\x1aG\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12(\n\x05value\x18\x02 \x01(\x0b\x32\x19.TemporalActivityTickDevs:\x02\x38\x01\"\xb3\x01\n\x15\x42usFactorTickSnapshot\x12\x12\n\nbus_factor\x18\x01 \x01(\x05\x12\x13\n\x0btotal_lines\x18\x02 \x01(\x03\x12=\n\x0c\x61uthor_lines\x18\x03 \x03(\x0b\x32\'.BusFactorTickSnapshot.AuthorLinesEntry\x1a\x32\n\x10\x41uthorLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\xe6\x02\n\x18\x42usFactorAnalysisResults\x12;\n\tsnapshots\x18\x01 \x03(\x0b\x32(.BusFactorAnalysisResults.SnapshotsEntry\x12O\n\x14subsystem_bus_factor\x18\x02 \x03(\x0b\x32\x31.BusFactorAnalysisResults.SubsystemBusFactorEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x12\x11\n\tthreshold\x18\x05 \x01(\x02\x1aH\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12%\n\x05value\x18\x02 \x01(\x0b\x32\x16.BusFactorTickSnapshot:\x02\x38\x01\x1a\x39\n\x17SubsystemBusFactorEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xd4\x01\n\"OwnershipConcentrationTickSnapshot\x12\x0c\n\x04gini\x18\x01 \x01(\x01\x12\x0b\n\x03hhi\x18\x02 \x01(\x01\x12\x13\n\x0btotal_lines\x18\x03 \x01(\x03\x12J\n\x0c\x61uthor_lines\x18\x04 \x03(\x0b\x32\x34.OwnershipConcentrationTickSnapshot.AuthorLinesEntry\x1a\x32\n\x10\x41uthorLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\xdd\x03\n\x1dOwnershipConcentrationResults\x12@\n\tsnapshots\x18\x01 \x03(\x0b\x32-.OwnershipConcentrationResults.SnapshotsEntry\x12I\n\x0esubsystem_gini\x18\x02 \x03(\x0b\x32\x31.OwnershipConcentrationResults.SubsystemGiniEntry\x12G\n\rsubsystem_hhi\x18\x05 \x03(\x0b\x32\x30.OwnershipConcentrationResults.SubsystemHhiEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x1aU\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x32\n\x05value\x18\x02 \x01(\x0b\x32#.OwnershipConcentrationTickSnapshot:\x02\x38\x01\x1a\x34\n\x12SubsystemGiniEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\x1a\x33\n\x11SubsystemHhiEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"\x81\x02\n\x1aKnowledgeDiffusionFileData\x12\x1c\n\x14unique_editors_count\x18\x01 \x01(\x05\x12X\n\x18unique_editors_over_time\x18\x02 \x03(\x0b\x32\x36.KnowledgeDiffusionFileData.UniqueEditorsOverTimeEntry\x12\x1c\n\x14recent_editors_count\x18\x03 \x01(\x05\x12\x0f\n\x07\x61uthors\x18\x04 \x03(\x05\x1a<\n\x1aUniqueEditorsOverTimeEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xd2\x02\n\x19KnowledgeDiffusionResults\x12\x34\n\x05\x66iles\x18\x01 \x03(\x0b\x32%.KnowledgeDiffusionResults.FilesEntry\x12\x42\n\x0c\x64istribution\x18\x02 \x03(\x0b\x32,.KnowledgeDiffusionResults.DistributionEntry\x12\x15\n\rwindow_months\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1aI\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12*\n\x05value\x18\x02 \x01(\x0b\x32\x1b.KnowledgeDiffusionFileData:\x02\x38\x01\x1a\x33\n\x11\x44istributionEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xbe\x01\n\x12OnboardingSnapshot\x12\x17\n\x0f\x64\x61ys_since_join\x18\x01 \x01(\x05\x12\x15\n\rtotal_commits\x18\x02 \x01(\x05\x12\x13\n\x0btotal_files\x18\x03 \x01(\x05\x12\x13\n\x0btotal_lines\x18\x04 \x01(\x05\x12\x1a\n\x12meaningful_commits\x18\x05 \x01(\x05\x12\x18\n\x10meaningful_files\x18\x06 \x01(\x05\x12\x18\n\x10meaningful_lines\x18\x07 \x01(\x05\"\xdd\x01\n\x19OnboardingAverageSnapshot\x12\x17\n\x0f\x64\x61ys_since_join\x18\x01 \x01(\x05\x12\x19\n\x11\x61vg_total_commits\x18\x02 \x01(\x01\x12\x17\n\x0f\x61vg_total_files\x18\x03 \x01(\x01\x12\x17\n\x0f\x61vg_total_lines\x18\x04 \x01(\x01\x12\x1e\n\x16\x61vg_meaningful_commits\x18\x05 \x01(\x01\x12\x1c\n\x14\x61vg_meaningful_files\x18\x06 \x01(\x01\x12\x1c\n\x14\x61vg_meaningful_lines\x18\x07 \x01(\x01\"\xc6\x01\n\x14\x41uthorOnboardingData\x12\x19\n\x11\x66irst_commit_tick\x18\x01 \x01(\x05\x12\x13\n\x0bjoin_cohort\x18\x02 \x01(\t\x12\x37\n\tsnapshots\x18\x03 \x03(\x0b\x32$.AuthorOnboardingData.SnapshotsEntry\x1a\x45\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.OnboardingSnapshot:\x02\x38\x01\"\xc7\x01\n\x0b\x43ohortStats\x12\x0e\n\x06\x63ohort\x18\x01 \x01(\t\x12\x14\n\x0c\x61uthor_count\x18\x02 \x01(\x05\x12=\n\x11\x61verage_snapshots\x18\x03 \x03(\x0b\x32\".CohortStats.AverageSnapshotsEntry\x1aS\n\x15\x41verageSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.OnboardingAverageSnapshot:\x02\x38\x01\"\xd5\x02\n\x11OnboardingResults\x12\x30\n\x07\x61uthors\x18\x01 \x03(\x0b\x32\x1f.OnboardingResults.AuthorsEntry\x12\x30\n\x07\x63ohorts\x18\x02 \x03(\x0b\x32\x1f.OnboardingResults.CohortsEntry\x12\x13\n\x0bwindow_days\x18\x03 \x03(\x05\x12\x1c\n\x14meaningful_threshold\x18\x04 \x01(\x05\x12\x11\n\tdev_index\x18\x05 \x03(\t\x12\x11\n\ttick_size\x18\x06 \x01(\x03\x1a\x45\n\x0c\x41uthorsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.AuthorOnboardingData:\x02\x38\x01\x1a<\n\x0c\x43ohortsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.CohortStats:\x02\x38\x01\"\xe8\x01\n\x08\x46ileRisk\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x12\n\nrisk_score\x18\x02 \x01(\x01\x12\x0c\n\x04size\x18\x03 \x01(\x05\x12\r\n\x05\x63hurn\x18\x04 \x01(\x05\x12\x17\n\x0f\x63oupling_degree\x18\x05 \x01(\x05\x12\x16\n\x0eownership_gini\x18\x06 \x01(\x01\x12\x17\n\x0fsize_normalized\x18\x07 \x01(\x01\x12\x18\n\x10\x63hurn_normalized\x18\x08 \x01(\x01\x12\x1b\n\x13\x63oupling_normalized\x18\t \x01(\x01\x12\x1c\n\x14ownership_normalized\x18\n \x01(\x01\"C\n\x12HotspotRiskResults\x12\x13\n\x0bwindow_days\x18\x01 \x01(\x05\x12\x18\n\x05\x66iles\x18\x02 \x03(\x0b\x32\t.FileRisk\"\x94\x01\n\x17RefactoringProxyResults\x12\r\n\x05ticks\x18\x01 \x03(\x05\x12\x15\n\rrename_ratios\x18\x02 \x03(\x02\x12\x16\n\x0eis_refactoring\x18\x03 \x03(\x08\x12\x15\n\rtotal_changes\x18\x04 \x03(\x05\x12\x11\n\tthreshold\x18\x05 \x01(\x02\x12\x11\n\ttick_size\x18\x06 \x01(\x03\"\xc4\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x12\x33\n\x11refactoring_proxy\x18\x03 \x01(\x0b\x32\x18.RefactoringProxyResults\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x08pb.proto\"\xc0\x02\n\x08Metadata\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x0c\n\x04hash\x18\x02 \x01(\t\x12\x12\n\nrepository\x18\x03 \x01(\t\x12\x17\n\x0f\x62\x65gin_unix_time\x18\x04 \x01(\x03\x12\x15\n\rend_unix_time\x18\x05 \x01(\x03\x12\x0f\n\x07\x63ommits\x18\x06 \x01(\x05\x12\x10\n\x08run_time\x18\x07 \x01(\x03\x12\x38\n\x11run_time_per_item\x18\x08 \x03(\x0b\x32\x1d.Metadata.RunTimePerItemEntry\x12\x0f\n\x07partial\x18\t \x01(\x08\x12\x11\n\ttick_mode\x18\n \x01(\t\x12\x19\n\x11\x66iscal_year_start\x18\x0b \x01(\x05\x1a\x35\n\x13RunTimePerItemEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"*\n\x17\x42urndownSparseMatrixRow\x12\x0f\n\x07\x63olumns\x18\x01 \x03(\r\"\x7f\n\x14\x42urndownSparseMatrix\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x16\n\x0enumber_of_rows\x18\x02 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x03 \x01(\x05\x12&\n\x04rows\x18\x04 \x03(\x0b\x32\x18.BurndownSparseMatrixRow\"i\n\x0e\x46ilesOwnership\x12)\n\x05value\x18\x01 \x03(\x0b\x32\x1a.FilesOwnership.ValueEntry\x1a,\n\nValueEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xc7\x04\n\x17\x42urndownAnalysisResults\x12\x13\n\x0bgranularity\x18\x01 \x01(\x05\x12\x10\n\x08sampling\x18\x02 \x01(\x05\x12&\n\x07project\x18\x03 \x01(\x0b\x32\x15.BurndownSparseMatrix\x12$\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06people\x18\x05 \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x36\n\x12people_interaction\x18\x06 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\x12(\n\x0f\x66iles_ownership\x18\x07 \x03(\x0b\x32\x0f.FilesOwnership\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x12\x1b\n\x13repository_sequence\x18\t \x03(\t\x12+\n\x0crepositories\x18\n \x03(\x0b\x32\x15.BurndownSparseMatrix\x12*\n\x0b\x64irectories\x18\x0b \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\x19\n\x11\x64irectories_depth\x18\x0c \x01(\x05\x12\x10\n\x08resample\x18\r \x01(\t\x12(\n\tlanguages\x18\x0e \x03(\x0b\x32\x15.BurndownSparseMatrix\x12\'\n\x08\x63omments\x18\x0f \x01(\x0b\x32\x15.BurndownSparseMatrix\x12%\n\x06\x62lanks\x18\x10 \x01(\x0b\x32\x15.BurndownSparseMatrix\"}\n\x19\x43ompressedSparseRowMatrix\x12\x16\n\x0enumber_of_rows\x18\x01 \x01(\x05\x12\x19\n\x11number_of_columns\x18\x02 \x01(\x05\x12\x0c\n\x04\x64\x61ta\x18\x03 \x03(\x03\x12\x0f\n\x07indices\x18\x04 \x03(\x05\x12\x0e\n\x06indptr\x18\x05 \x03(\x03\"D\n\x07\x43ouples\x12\r\n\x05index\x18\x01 \x03(\t\x12*\n\x06matrix\x18\x02 \x01(\x0b\x32\x1a.CompressedSparseRowMatrix\"\x1d\n\x0cTouchedFiles\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\xc6\x01\n\x16\x43ouplesAnalysisResults\x12\x1e\n\x0c\x66ile_couples\x18\x06 \x01(\x0b\x32\x08.Couples\x12 \n\x0epeople_couples\x18\x07 \x01(\x0b\x32\x08.Couples\x12#\n\x0cpeople_files\x18\x08 \x03(\x0b\x32\r.TouchedFiles\x12\x13\n\x0b\x66iles_lines\x18\t \x03(\x05\x12\x11\n\thalf_life\x18\n \x01(\x05\x12\x1d\n\x15\x66iles_decayed_weights\x18\x0b \x03(\x02\"\x9c\x01\n\x0eShotnessRecord\x12\x0c\n\x04type\x18\x01 \x01(\t\x12\x0c\n\x04name\x18\x02 \x01(\t\x12\x0c\n\x04\x66ile\x18\x03 \x01(\t\x12/\n\x08\x63ounters\x18\x04 \x03(\x0b\x32\x1d.ShotnessRecord.CountersEntry\x1a/\n\rCountersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\";\n\x17ShotnessAnalysisResults\x12 \n\x07records\x18\x01 \x03(\x0b\x32\x0f.ShotnessRecord\"\x86\x02\n\x0b\x46ileHistory\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\t\x12\x42\n\x14\x63hanges_by_developer\x18\x02 \x03(\x0b\x32$.FileHistory.ChangesByDeveloperEntry\x12\x0f\n\x07\x66ile_id\x18\x03 \x01(\x05\x12\r\n\x05names\x18\x04 \x03(\t\x12\x14\n\x0c\x63reated_tick\x18\x05 \x01(\x05\x12\x14\n\x0c\x64\x65leted_tick\x18\x06 \x01(\x05\x12\x0f\n\x07\x61uthors\x18\x07 \x03(\x05\x1a\x45\n\x17\x43hangesByDeveloperEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"\xaa\x01\n\x18\x46ileHistoryResultMessage\x12\x33\n\x05\x66iles\x18\x01 \x03(\x0b\x32$.FileHistoryResultMessage.FilesEntry\x12\x1d\n\x07\x64\x65leted\x18\x02 \x03(\x0b\x32\x0c.FileHistory\x1a:\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.FileHistory:\x02\x38\x01\"x\n\tLineStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\x12\x1d\n\x08\x63omments\x18\x04 \x01(\x0b\x32\x0b.LineCounts\x12\x1b\n\x06\x62lanks\x18\x05 \x01(\x0b\x32\x0b.LineCounts\"=\n\nLineCounts\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x05\x12\x0f\n\x07removed\x18\x02 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x03 \x01(\x05\"\x8c\x02\n\x07\x44\x65vTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\x19\n\x05stats\x18\x02 \x01(\x0b\x32\n.LineStats\x12*\n\tlanguages\x18\x03 \x03(\x0b\x32\x17.DevTick.LanguagesEntry\x12,\n\ncategories\x18\x04 \x03(\x0b\x32\x18.DevTick.CategoriesEntry\x1a<\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\x1a=\n\x0f\x43\x61tegoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.LineStats:\x02\x38\x01\"d\n\x08TickDevs\x12!\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32\x13.TickDevs.DevsEntry\x1a\x35\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x17\n\x05value\x18\x02 \x01(\x0b\x32\x08.DevTick:\x02\x38\x01\"\xbb\x01\n\x13\x44\x65vsAnalysisResults\x12.\n\x05ticks\x18\x01 \x03(\x0b\x32\x1f.DevsAnalysisResults.TicksEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x08 \x01(\x03\x12\x15\n\rorganizations\x18\x03 \x03(\t\x1a\x37\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x18\n\x05value\x18\x02 \x01(\x0b\x32\t.TickDevs:\x02\x38\x01\"=\n\tSentiment\x12\r\n\x05value\x18\x01 \x01(\x02\x12\x10\n\x08\x63omments\x18\x02 \x03(\t\x12\x0f\n\x07\x63ommits\x18\x03 \x03(\t\"\xa7\x01\n\x17\x43ommentSentimentResults\x12H\n\x11sentiment_by_tick\x18\x01 \x03(\x0b\x32-.CommentSentimentResults.SentimentByTickEntry\x1a\x42\n\x14SentimentByTickEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.Sentiment:\x02\x38\x01\"G\n\nCommitFile\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x10\n\x08language\x18\x03 \x01(\t\x12\x19\n\x05stats\x18\x04 \x01(\x0b\x32\n.LineStats\"w\n\x06\x43ommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x16\n\x0ewhen_unix_time\x18\x02 \x01(\x03\x12\x0e\n\x06\x61uthor\x18\x03 \x01(\x05\x12\x1a\n\x05\x66iles\x18\x04 \x03(\x0b\x32\x0b.CommitFile\x12\x1b\n\x13\x63ommitted_unix_time\x18\x05 \x01(\x03\"H\n\x16\x43ommitsAnalysisResults\x12\x18\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x07.Commit\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\"R\n\x04Typo\x12\r\n\x05wrong\x18\x01 \x01(\t\x12\x0f\n\x07\x63orrect\x18\x02 \x01(\t\x12\x0e\n\x06\x63ommit\x18\x03 \x01(\t\x12\x0c\n\x04\x66ile\x18\x04 \x01(\t\x12\x0c\n\x04line\x18\x05 \x01(\x05\"$\n\x0cTyposDataset\x12\x14\n\x05typos\x18\x01 \x03(\x0b\x32\x05.Typo\"l\n\x0eImportsPerTick\x12+\n\x06\x63ounts\x18\x01 \x03(\x0b\x32\x1b.ImportsPerTick.CountsEntry\x1a-\n\x0b\x43ountsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x82\x01\n\x12ImportsPerLanguage\x12-\n\x05ticks\x18\x01 \x03(\x0b\x32\x1e.ImportsPerLanguage.TicksEntry\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.ImportsPerTick:\x02\x38\x01\"\x94\x01\n\x13ImportsPerDeveloper\x12\x36\n\tlanguages\x18\x01 \x03(\x0b\x32#.ImportsPerDeveloper.LanguagesEntry\x1a\x45\n\x0eLanguagesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ImportsPerLanguage:\x02\x38\x01\"l\n\x1aImportsPerDeveloperResults\x12%\n\x07imports\x18\x01 \x03(\x0b\x32\x14.ImportsPerDeveloper\x12\x14\n\x0c\x61uthor_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\"3\n\x11TemporalDimension\x12\x0f\n\x07\x63ommits\x18\x01 \x03(\x05\x12\r\n\x05lines\x18\x02 \x03(\x05\"\xab\x01\n\x19\x44\x65veloperTemporalActivity\x12$\n\x08weekdays\x18\x01 \x01(\x0b\x32\x12.TemporalDimension\x12!\n\x05hours\x18\x02 \x01(\x0b\x32\x12.TemporalDimension\x12\"\n\x06months\x18\x03 \x01(\x0b\x32\x12.TemporalDimension\x12!\n\x05weeks\x18\x04 \x01(\x0b\x32\x12.TemporalDimension\"r\n\x14TemporalActivityTick\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\x0f\n\x07weekday\x18\x03 \x01(\x05\x12\x0c\n\x04hour\x18\x04 \x01(\x05\x12\r\n\x05month\x18\x05 \x01(\x05\x12\x0c\n\x04week\x18\x06 \x01(\x05\"\x91\x01\n\x18TemporalActivityTickDevs\x12\x31\n\x04\x64\x65vs\x18\x01 \x03(\x0b\x32#.TemporalActivityTickDevs.DevsEntry\x1a\x42\n\tDevsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.TemporalActivityTick:\x02\x38\x01\"\xcb\x04\n\x17TemporalActivityResults\x12<\n\nactivities\x18\x01 \x03(\x0b\x32(.TemporalActivityResults.ActivitiesEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x32\n\x05ticks\x18\x03 \x03(\x0b\x32#.TemporalActivityResults.TicksEntry\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x12\x10\n\x08timezone\x18\x05 \x01(\t\x12\x36\n\x07offsets\x18\x06 \x03(\x0b\x32%.TemporalActivityResults.OffsetsEntry\x12:\n\tsummaries\x18\x07 \x03(\x0b\x32\'.TemporalActivityResults.SummariesEntry\x1aM\n\x0f\x41\x63tivitiesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.DeveloperTemporalActivity:\x02\x38\x01\x1aG\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12(\n\x05value\x18\x02 \x01(\x0b\x32\x19.TemporalActivityTickDevs:\x02\x38\x01\x1a.\n\x0cOffsetsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1aJ\n\x0eSummariesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\'\n\x05value\x18\x02 \x01(\x0b\x32\x18.TemporalActivitySummary:\x02\x38\x01\"c\n\x17TemporalActivitySummary\x12\x15\n\rweekend_share\x18\x01 \x01(\x02\x12\x19\n\x11\x61\x66ter_hours_share\x18\x02 \x01(\x02\x12\x16\n\x0elongest_streak\x18\x03 \x01(\x05\"\xdd\x01\n\x0f\x43odeChurnSeries\x12\x10\n\x08inserted\x18\x01 \x03(\x03\x12\r\n\x05owned\x18\x02 \x03(\x03\x12\x17\n\x0f\x64\x65leted_by_self\x18\x03 \x03(\x03\x12\x19\n\x11\x64\x65leted_by_others\x18\x04 \x03(\x03\x12\x11\n\tawareness\x18\x05 \x03(\x02\x12\x14\n\x0cmemorability\x18\x06 \x03(\x02\x12&\n\x08\x63omments\x18\x07 \x01(\x0b\x32\x14.CodeChurnKindSeries\x12$\n\x06\x62lanks\x18\x08 \x01(\x0b\x32\x14.CodeChurnKindSeries\"j\n\x13\x43odeChurnKindSeries\x12\x10\n\x08inserted\x18\x01 \x03(\x03\x12\r\n\x05owned\x18\x02 \x03(\x03\x12\x17\n\x0f\x64\x65leted_by_self\x18\x03 \x03(\x03\x12\x19\n\x11\x64\x65leted_by_others\x18\x04 \x03(\x03\"Q\n\x0f\x43odeChurnRework\x12\x12\n\nself_churn\x18\x01 \x01(\x03\x12\x16\n\x0eold_self_churn\x18\x02 \x01(\x03\x12\x12\n\ndisruptive\x18\x03 \x01(\x03\"\x87\x01\n\x14\x43odeChurnReworkTicks\x12/\n\x05ticks\x18\x01 \x03(\x0b\x32 .CodeChurnReworkTicks.TicksEntry\x1a>\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.CodeChurnRework:\x02\x38\x01\"\xc8\x02\n\x10\x43odeChurnResults\x12\x10\n\x08sampling\x18\x01 \x01(\x05\x12-\n\x06people\x18\x02 \x03(\x0b\x32\x1d.CodeChurnResults.PeopleEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x12\x17\n\x0fself_churn_days\x18\x05 \x01(\x05\x12-\n\x06rework\x18\x06 \x03(\x0b\x32\x1d.CodeChurnResults.ReworkEntry\x1a?\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.CodeChurnSeries:\x02\x38\x01\x1a\x44\n\x0bReworkEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.CodeChurnReworkTicks:\x02\x38\x01\"\xa2\x02\n\x15\x42usFactorTickSnapshot\x12\x12\n\nbus_factor\x18\x01 \x01(\x05\x12\x13\n\x0btotal_lines\x18\x02 \x01(\x03\x12=\n\x0c\x61uthor_lines\x18\x03 \x03(\x0b\x32\'.BusFactorTickSnapshot.AuthorLinesEntry\x12:\n\nsubsystems\x18\x04 \x03(\x0b\x32&.BusFactorTickSnapshot.SubsystemsEntry\x1a\x32\n\x10\x41uthorLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\x1a\x31\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xf8\x04\n\x18\x42usFactorAnalysisResults\x12;\n\tsnapshots\x18\x01 \x03(\x0b\x32(.BusFactorAnalysisResults.SnapshotsEntry\x12O\n\x14subsystem_bus_factor\x18\x02 \x03(\x0b\x32\x31.BusFactorAnalysisResults.SubsystemBusFactorEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x12\x11\n\tthreshold\x18\x05 \x01(\x02\x12\x46\n\x0f\x66iles_ownership\x18\x06 \x03(\x0b\x32-.BusFactorAnalysisResults.FilesOwnershipEntry\x12\x15\n\rownership_top\x18\x07 \x01(\x05\x12%\n\nsimulation\x18\x08 \x03(\x0b\x32\x11.BusFactorRemoval\x12\x14\n\x0csimulate_top\x18\t \x01(\x05\x12\x17\n\x0fsubsystem_every\x18\n \x01(\x05\x12\x17\n\x0fsubsystem_depth\x18\x0b \x01(\x05\x1aH\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12%\n\x05value\x18\x02 \x01(\x0b\x32\x16.BusFactorTickSnapshot:\x02\x38\x01\x1a\x39\n\x17SubsystemBusFactorEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1a\x42\n\x13\x46ilesOwnershipEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.FileOwners:\x02\x38\x01\"\xaf\x01\n\x10\x42usFactorRemoval\x12\x0e\n\x06\x61uthor\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x03\x12\x10\n\x08\x63overage\x18\x03 \x01(\x02\x12\x12\n\nbus_factor\x18\x04 \x01(\x05\x12)\n\x04gaps\x18\x05 \x03(\x0b\x32\x1b.BusFactorRemoval.GapsEntry\x1a+\n\tGapsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x02:\x02\x38\x01\"B\n\nFileOwners\x12\r\n\x05lines\x18\x01 \x01(\x03\x12\x0f\n\x07\x61uthors\x18\x02 \x03(\x05\x12\x14\n\x0c\x61uthor_lines\x18\x03 \x03(\x03\"\xd4\x01\n\"OwnershipConcentrationTickSnapshot\x12\x0c\n\x04gini\x18\x01 \x01(\x01\x12\x0b\n\x03hhi\x18\x02 \x01(\x01\x12\x13\n\x0btotal_lines\x18\x03 \x01(\x03\x12J\n\x0c\x61uthor_lines\x18\x04 \x03(\x0b\x32\x34.OwnershipConcentrationTickSnapshot.AuthorLinesEntry\x1a\x32\n\x10\x41uthorLinesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x03:\x02\x38\x01\"\x83\x04\n\x1dOwnershipConcentrationResults\x12@\n\tsnapshots\x18\x01 \x03(\x0b\x32-.OwnershipConcentrationResults.SnapshotsEntry\x12I\n\x0esubsystem_gini\x18\x02 \x03(\x0b\x32\x31.OwnershipConcentrationResults.SubsystemGiniEntry\x12G\n\rsubsystem_hhi\x18\x05 \x03(\x0b\x32\x30.OwnershipConcentrationResults.SubsystemHhiEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x12\x15\n\rorganizations\x18\x06 \x03(\t\x12\r\n\x05teams\x18\x07 \x03(\t\x1aU\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x32\n\x05value\x18\x02 \x01(\x0b\x32#.OwnershipConcentrationTickSnapshot:\x02\x38\x01\x1a\x34\n\x12SubsystemGiniEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\x1a\x33\n\x11SubsystemHhiEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x01:\x02\x38\x01\"\xa1\x02\n\x1aKnowledgeDiffusionFileData\x12\x1c\n\x14unique_editors_count\x18\x01 \x01(\x05\x12X\n\x18unique_editors_over_time\x18\x02 \x03(\x0b\x32\x36.KnowledgeDiffusionFileData.UniqueEditorsOverTimeEntry\x12\x1c\n\x14recent_editors_count\x18\x03 \x01(\x05\x12\x0f\n\x07\x61uthors\x18\x04 \x03(\x05\x12\x0f\n\x07\x66ile_id\x18\x05 \x01(\x05\x12\r\n\x05names\x18\x06 \x03(\t\x1a<\n\x1aUniqueEditorsOverTimeEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\x96\x04\n\x19KnowledgeDiffusionResults\x12\x34\n\x05\x66iles\x18\x01 \x03(\x0b\x32%.KnowledgeDiffusionResults.FilesEntry\x12\x42\n\x0c\x64istribution\x18\x02 \x03(\x0b\x32,.KnowledgeDiffusionResults.DistributionEntry\x12\x15\n\rwindow_months\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x12@\n\x0b\x64irectories\x18\x06 \x03(\x0b\x32+.KnowledgeDiffusionResults.DirectoriesEntry\x12\x12\n\ndirs_depth\x18\x07 \x01(\x05\x12\x16\n\x0esilo_threshold\x18\x08 \x01(\x02\x1aI\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12*\n\x05value\x18\x02 \x01(\x0b\x32\x1b.KnowledgeDiffusionFileData:\x02\x38\x01\x1a\x33\n\x11\x44istributionEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\x1aT\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12/\n\x05value\x18\x02 \x01(\x0b\x32 .KnowledgeDiffusionDirectoryData:\x02\x38\x01\"\xb8\x01\n\x1fKnowledgeDiffusionDirectoryData\x12\r\n\x05\x66iles\x18\x01 \x01(\x05\x12\x1c\n\x14unique_editors_count\x18\x02 \x01(\x05\x12\x1c\n\x14recent_editors_count\x18\x03 \x01(\x05\x12\x14\n\x0crecent_edits\x18\x04 \x01(\x05\x12\x12\n\ntop_author\x18\x05 \x01(\x05\x12\x12\n\nsilo_score\x18\x06 \x01(\x02\x12\x0c\n\x04silo\x18\x07 \x01(\x08\"\xbe\x01\n\x12OnboardingSnapshot\x12\x17\n\x0f\x64\x61ys_since_join\x18\x01 \x01(\x05\x12\x15\n\rtotal_commits\x18\x02 \x01(\x05\x12\x13\n\x0btotal_files\x18\x03 \x01(\x05\x12\x13\n\x0btotal_lines\x18\x04 \x01(\x05\x12\x1a\n\x12meaningful_commits\x18\x05 \x01(\x05\x12\x18\n\x10meaningful_files\x18\x06 \x01(\x05\x12\x18\n\x10meaningful_lines\x18\x07 \x01(\x05\"\xdd\x01\n\x19OnboardingAverageSnapshot\x12\x17\n\x0f\x64\x61ys_since_join\x18\x01 \x01(\x05\x12\x19\n\x11\x61vg_total_commits\x18\x02 \x01(\x01\x12\x17\n\x0f\x61vg_total_files\x18\x03 \x01(\x01\x12\x17\n\x0f\x61vg_total_lines\x18\x04 \x01(\x01\x12\x1e\n\x16\x61vg_meaningful_commits\x18\x05 \x01(\x01\x12\x1c\n\x14\x61vg_meaningful_files\x18\x06 \x01(\x01\x12\x1c\n\x14\x61vg_meaningful_lines\x18\x07 \x01(\x01\"\xfe\x02\n\x14\x41uthorOnboardingData\x12\x19\n\x11\x66irst_commit_tick\x18\x01 \x01(\x05\x12\x13\n\x0bjoin_cohort\x18\x02 \x01(\t\x12\x37\n\tsnapshots\x18\x03 \x03(\x0b\x32$.AuthorOnboardingData.SnapshotsEntry\x12\x33\n\x07mentors\x18\x04 \x03(\x0b\x32\".AuthorOnboardingData.MentorsEntry\x12\x1b\n\x13\x64irectories_reached\x18\x05 \x01(\x05\x12\x18\n\x10\x66irst_touch_tick\x18\x06 \x01(\x05\x12\x1a\n\x12last_activity_tick\x18\x07 \x01(\x05\x1a\x45\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.OnboardingSnapshot:\x02\x38\x01\x1a.\n\x0cMentorsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xbb\x02\n\x0b\x43ohortStats\x12\x0e\n\x06\x63ohort\x18\x01 \x01(\t\x12\x14\n\x0c\x61uthor_count\x18\x02 \x01(\x05\x12=\n\x11\x61verage_snapshots\x18\x03 \x03(\x0b\x32\".CohortStats.AverageSnapshotsEntry\x12.\n\tretention\x18\x04 \x03(\x0b\x32\x1b.CohortStats.RetentionEntry\x1aS\n\x15\x41verageSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12)\n\x05value\x18\x02 \x01(\x0b\x32\x1a.OnboardingAverageSnapshot:\x02\x38\x01\x1a\x42\n\x0eRetentionEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.CohortRetention:\x02\x38\x01\"G\n\x0f\x43ohortRetention\x12\x10\n\x08\x65ligible\x18\x01 \x01(\x05\x12\x10\n\x08retained\x18\x02 \x01(\x05\x12\x10\n\x08\x66raction\x18\x03 \x01(\x02\"\x88\x03\n\x11OnboardingResults\x12\x30\n\x07\x61uthors\x18\x01 \x03(\x0b\x32\x1f.OnboardingResults.AuthorsEntry\x12\x30\n\x07\x63ohorts\x18\x02 \x03(\x0b\x32\x1f.OnboardingResults.CohortsEntry\x12\x13\n\x0bwindow_days\x18\x03 \x03(\x05\x12\x1c\n\x14meaningful_threshold\x18\x04 \x01(\x05\x12\x17\n\x0fmentorship_days\x18\x07 \x01(\x05\x12\x18\n\x10retention_months\x18\x08 \x03(\x05\x12\x11\n\tdev_index\x18\x05 \x03(\t\x12\x11\n\ttick_size\x18\x06 \x01(\x03\x1a\x45\n\x0c\x41uthorsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.AuthorOnboardingData:\x02\x38\x01\x1a<\n\x0c\x43ohortsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1b\n\x05value\x18\x02 \x01(\x0b\x32\x0c.CohortStats:\x02\x38\x01\"\xf7\x02\n\x08\x46ileRisk\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x12\n\nrisk_score\x18\x02 \x01(\x01\x12\x0c\n\x04size\x18\x03 \x01(\x05\x12\r\n\x05\x63hurn\x18\x04 \x01(\x05\x12\x17\n\x0f\x63oupling_degree\x18\x05 \x01(\x05\x12\x16\n\x0eownership_gini\x18\x06 \x01(\x01\x12\x17\n\x0fsize_normalized\x18\x07 \x01(\x01\x12\x18\n\x10\x63hurn_normalized\x18\x08 \x01(\x01\x12\x1b\n\x13\x63oupling_normalized\x18\t \x01(\x01\x12\x1c\n\x14ownership_normalized\x18\n \x01(\x01\x12\x10\n\x08language\x18\x0b \x01(\t\x12\x0f\n\x07\x66ile_id\x18\x0c \x01(\x05\x12\r\n\x05names\x18\r \x03(\t\x12\x10\n\x08\x61ge_days\x18\x0e \x01(\x05\x12\x12\n\ncomplexity\x18\x0f \x01(\x01\x12\x16\n\x0e\x61ge_normalized\x18\x10 \x01(\x01\x12\x1d\n\x15\x63omplexity_normalized\x18\x11 \x01(\x01\"}\n\x0cLanguageRisk\x12\x10\n\x08language\x18\x01 \x01(\t\x12\r\n\x05\x66iles\x18\x02 \x01(\x05\x12\x0c\n\x04size\x18\x03 \x01(\x05\x12\r\n\x05\x63hurn\x18\x04 \x01(\x05\x12\x17\n\x0fmean_risk_score\x18\x05 \x01(\x01\x12\x16\n\x0emax_risk_score\x18\x06 \x01(\x01\"\xa6\x01\n\x12HotspotRiskResults\x12\x13\n\x0bwindow_days\x18\x01 \x01(\x05\x12\x18\n\x05\x66iles\x18\x02 \x03(\x0b\x32\t.FileRisk\x12 \n\tlanguages\x18\x03 \x03(\x0b\x32\r.LanguageRisk\x12\'\n\tsnapshots\x18\x04 \x03(\x0b\x32\x14.HotspotRiskSnapshot\x12\x16\n\x0esnapshot_every\x18\x05 \x01(\x05\"E\n\x13HotspotRiskSnapshot\x12\x0c\n\x04tick\x18\x01 \x01(\x05\x12 \n\x05\x66iles\x18\x02 \x03(\x0b\x32\x11.HotspotRiskEntry\"E\n\x10HotspotRiskEntry\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0f\n\x07\x66ile_id\x18\x02 \x01(\x05\x12\x12\n\nrisk_score\x18\x03 \x01(\x01\"\x94\x01\n\x17RefactoringProxyResults\x12\r\n\x05ticks\x18\x01 \x03(\x05\x12\x15\n\rrename_ratios\x18\x02 \x03(\x02\x12\x16\n\x0eis_refactoring\x18\x03 \x03(\x08\x12\x15\n\rtotal_changes\x18\x04 \x03(\x05\x12\x11\n\tthreshold\x18\x05 \x01(\x02\x12\x11\n\ttick_size\x18\x06 \x01(\x03\"O\n\x13\x43ommentDensityStats\x12\x15\n\rcomment_lines\x18\x01 \x01(\x05\x12\x12\n\ncode_lines\x18\x02 \x01(\x05\x12\r\n\x05\x63hurn\x18\x03 \x01(\x05\"\x96\x01\n\x12\x43ommentDensityTick\x12\x37\n\nsubsystems\x18\x01 \x03(\x0b\x32#.CommentDensityTick.SubsystemsEntry\x1aG\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.CommentDensityStats:\x02\x38\x01\"\x84\x01\n\x15\x43ommentDensityErosion\x12\x11\n\tsubsystem\x18\x01 \x01(\t\x12\x16\n\x0e\x64\x65nsity_before\x18\x02 \x01(\x02\x12\x15\n\rdensity_after\x18\x03 \x01(\x02\x12\x14\n\x0c\x63hurn_before\x18\x04 \x01(\x05\x12\x13\n\x0b\x63hurn_after\x18\x05 \x01(\x05\"\xd1\x02\n\x15\x43ommentDensityResults\x12\x30\n\x05ticks\x18\x01 \x03(\x0b\x32!.CommentDensityResults.TicksEntry\x12\x30\n\x05\x66iles\x18\x02 \x03(\x0b\x32!.CommentDensityResults.FilesEntry\x12\'\n\x07\x65roding\x18\x03 \x03(\x0b\x32\x16.CommentDensityErosion\x12\x11\n\tthreshold\x18\x04 \x01(\x02\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1a\x41\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.CommentDensityTick:\x02\x38\x01\x1a\x42\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.CommentDensityStats:\x02\x38\x01\"\x91\x01\n\x10RegexMetricsTick\x12\x35\n\nsubsystems\x18\x01 \x03(\x0b\x32!.RegexMetricsTick.SubsystemsEntry\x1a\x46\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.RegexMetricsCounts:\x02\x38\x01\"$\n\x12RegexMetricsCounts\x12\x0e\n\x06\x63ounts\x18\x01 \x03(\x05\"\xba\x01\n\x13RegexMetricsResults\x12\x0f\n\x07metrics\x18\x01 \x03(\t\x12.\n\x05ticks\x18\x02 \x03(\x0b\x32\x1f.RegexMetricsResults.TicksEntry\x12\x0e\n\x06totals\x18\x03 \x03(\x05\x12\x11\n\ttick_size\x18\x04 \x01(\x03\x1a?\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.RegexMetricsTick:\x02\x38\x01\"=\n\rTestChurnTick\x12\x12\n\ntest_churn\x18\x01 \x01(\x05\x12\x18\n\x10production_churn\x18\x02 \x01(\x05\"\xbc\x01\n\x0eTestChurnSuite\x12\x11\n\ttest_file\x18\x01 \x01(\t\x12\x17\n\x0fproduction_file\x18\x02 \x01(\t\x12\x14\n\x0ctest_commits\x18\x03 \x01(\x05\x12\x1a\n\x12production_commits\x18\x04 \x01(\x05\x12\x12\n\ntest_churn\x18\x05 \x01(\x05\x12\x18\n\x10production_churn\x18\x06 \x01(\x05\x12\r\n\x05ratio\x18\x07 \x01(\x02\x12\x0f\n\x07\x62rittle\x18\x08 \x01(\x08\"\xdf\x01\n\x10TestChurnResults\x12+\n\x05ticks\x18\x01 \x03(\x0b\x32\x1c.TestChurnResults.TicksEntry\x12\x1f\n\x06suites\x18\x02 \x03(\x0b\x32\x0f.TestChurnSuite\x12\x17\n\x0fratio_threshold\x18\x03 \x01(\x02\x12\x13\n\x0bmin_commits\x18\x04 \x01(\x05\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1a<\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1d\n\x05value\x18\x02 \x01(\x0b\x32\x0e.TestChurnTick:\x02\x38\x01\"%\n\x14\x43odeAgePyramidCounts\x12\r\n\x05lines\x18\x01 \x03(\x03\"\xdf\x01\n\x15\x43odeAgePyramidResults\x12\x0c\n\x04tick\x18\x01 \x01(\x05\x12\x0f\n\x07\x62uckets\x18\x02 \x03(\t\x12:\n\nsubsystems\x18\x03 \x03(\x0b\x32&.CodeAgePyramidResults.SubsystemsEntry\x12\x0e\n\x06totals\x18\x04 \x03(\x03\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1aH\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12$\n\x05value\x18\x02 \x01(\x0b\x32\x15.CodeAgePyramidCounts:\x02\x38\x01\"0\n\x0cRewriteStats\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x01 \x01(\x03\x12\x11\n\trewritten\x18\x02 \x01(\x03\"\xda\x02\n\x13RewriteRatioResults\x12\x30\n\x06people\x18\x01 \x03(\x0b\x32 .RewriteRatioResults.PeopleEntry\x12\x38\n\nsubsystems\x18\x02 \x03(\x0b\x32$.RewriteRatioResults.SubsystemsEntry\x12\x1c\n\x05total\x18\x03 \x01(\x0b\x32\r.RewriteStats\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x13\n\x0bwindow_days\x18\x05 \x01(\x05\x12\x11\n\ttick_size\x18\x06 \x01(\x03\x1a<\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.RewriteStats:\x02\x38\x01\x1a@\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1c\n\x05value\x18\x02 \x01(\x0b\x32\r.RewriteStats:\x02\x38\x01\"`\n\x11\x43rossTimezonePair\x12\x12\n\ndeveloper1\x18\x01 \x01(\x05\x12\x12\n\ndeveloper2\x18\x02 \x01(\x05\x12\x11\n\tgap_hours\x18\x03 \x01(\x01\x12\x10\n\x08\x63oupling\x18\x04 \x01(\x03\"\xf8\x01\n\x14\x43rossTimezoneResults\x12\x33\n\x07offsets\x18\x01 \x03(\x0b\x32\".CrossTimezoneResults.OffsetsEntry\x12!\n\x05pairs\x18\x02 \x03(\x0b\x32\x12.CrossTimezonePair\x12\x16\n\x0etotal_coupling\x18\x03 \x01(\x03\x12\x16\n\x0e\x63ross_coupling\x18\x04 \x01(\x03\x12\x15\n\rmin_gap_hours\x18\x05 \x01(\x05\x12\x11\n\tdev_index\x18\x06 \x03(\t\x1a.\n\x0cOffsetsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"+\n\rAbsencePeriod\x12\r\n\x05\x62\x65gin\x18\x01 \x01(\x05\x12\x0b\n\x03\x65nd\x18\x02 \x01(\x05\"F\n\x11\x44\x65veloperAbsences\x12\x10\n\x08\x62\x61seline\x18\x01 \x01(\x01\x12\x1f\n\x07periods\x18\x02 \x03(\x0b\x32\x0e.AbsencePeriod\"K\n\x0b\x43overageGap\x12\x11\n\tsubsystem\x18\x01 \x01(\t\x12\r\n\x05owner\x18\x02 \x01(\x05\x12\r\n\x05\x62\x65gin\x18\x03 \x01(\x05\x12\x0b\n\x03\x65nd\x18\x04 \x01(\x05\"\xd0\x02\n\x0e\x41\x62senceResults\x12\x33\n\ndevelopers\x18\x01 \x03(\x0b\x32\x1f.AbsenceResults.DevelopersEntry\x12+\n\x06owners\x18\x02 \x03(\x0b\x32\x1b.AbsenceResults.OwnersEntry\x12#\n\rcoverage_gaps\x18\x03 \x03(\x0b\x32\x0c.CoverageGap\x12\x1b\n\x13ownership_threshold\x18\x04 \x01(\x02\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x12\x11\n\tdev_index\x18\x06 \x03(\t\x1a\x45\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12!\n\x05value\x18\x02 \x01(\x0b\x32\x12.DeveloperAbsences:\x02\x38\x01\x1a-\n\x0bOwnersEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"s\n\x10\x44iversityQuarter\x12/\n\x07\x63ommits\x18\x01 \x03(\x0b\x32\x1e.DiversityQuarter.CommitsEntry\x1a.\n\x0c\x43ommitsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xeb\x01\n\x1c\x43ontributionDiversityResults\x12=\n\x08quarters\x18\x01 \x03(\x0b\x32+.ContributionDiversityResults.QuartersEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x15\n\rorganizations\x18\x03 \x03(\t\x12\x1e\n\x16internal_organizations\x18\x04 \x03(\t\x1a\x42\n\rQuartersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12 \n\x05value\x18\x02 \x01(\x0b\x32\x11.DiversityQuarter:\x02\x38\x01\"$\n\x13\x46unnelContributions\x12\r\n\x05times\x18\x01 \x03(\x03\"\x8b\x02\n\x19\x43ontributionFunnelResults\x12\x12\n\nmilestones\x18\x01 \x03(\x05\x12\x44\n\rcontributions\x18\x02 \x03(\x0b\x32-.ContributionFunnelResults.ContributionsEntry\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x15\n\rorganizations\x18\x04 \x03(\t\x12\x1e\n\x16internal_organizations\x18\x05 \x03(\t\x1aJ\n\x12\x43ontributionsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.FunnelContributions:\x02\x38\x01\"a\n\x0fSelfMergeCounts\x12\x0f\n\x07\x63hanges\x18\x01 \x01(\x05\x12\x13\n\x0bself_merged\x18\x02 \x01(\x05\x12\x17\n\x0fsingle_approver\x18\x03 \x01(\x05\x12\x0f\n\x07unknown\x18\x04 \x01(\x05\"\x9f\x02\n\x10SelfMergeResults\x12\x1f\n\x05total\x18\x01 \x01(\x0b\x32\x10.SelfMergeCounts\x12-\n\x06months\x18\x02 \x03(\x0b\x32\x1d.SelfMergeResults.MonthsEntry\x12\x35\n\nsubsystems\x18\x03 \x03(\x0b\x32!.SelfMergeResults.SubsystemsEntry\x1a?\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.SelfMergeCounts:\x02\x38\x01\x1a\x43\n\x0fSubsystemsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x1f\n\x05value\x18\x02 \x01(\x0b\x32\x10.SelfMergeCounts:\x02\x38\x01\"\x1b\n\nWorkingSet\x12\r\n\x05\x66iles\x18\x01 \x03(\x05\"\x8d\x01\n\x12MonthlyWorkingSets\x12\x37\n\ndevelopers\x18\x01 \x03(\x0b\x32#.MonthlyWorkingSets.DevelopersEntry\x1a>\n\x0f\x44\x65velopersEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1a\n\x05value\x18\x02 \x01(\x0b\x32\x0b.WorkingSet:\x02\x38\x01\"\xc4\x01\n\x18WorkingSetOverlapResults\x12\x35\n\x06months\x18\x01 \x03(\x0b\x32%.WorkingSetOverlapResults.MonthsEntry\x12\r\n\x05\x66iles\x18\x02 \x03(\t\x12\x11\n\tdev_index\x18\x03 \x03(\t\x12\x0b\n\x03top\x18\x04 \x01(\x05\x1a\x42\n\x0bMonthsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.MonthlyWorkingSets:\x02\x38\x01\"I\n\x0c\x42lameSegment\x12\x0c\n\x04line\x18\x01 \x01(\x05\x12\r\n\x05lines\x18\x02 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x03 \x01(\x05\x12\x0c\n\x04tick\x18\x04 \x01(\x05\",\n\tBlameFile\x12\x1f\n\x08segments\x18\x01 \x03(\x0b\x32\r.BlameSegment\"\xa3\x01\n\x12\x42lameDumperResults\x12-\n\x05\x66iles\x18\x01 \x03(\x0b\x32\x1e.BlameDumperResults.FilesEntry\x12\x11\n\tdev_index\x18\x02 \x03(\t\x12\x11\n\ttick_size\x18\x03 \x01(\x03\x1a\x38\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\x19\n\x05value\x18\x02 \x01(\x0b\x32\n.BlameFile:\x02\x38\x01\"\x91\x01\n\x11LineHistoryChange\x12\x0f\n\x07\x66ile_id\x18\x01 \x01(\x05\x12\x13\n\x0bprev_author\x18\x02 \x01(\x05\x12\x11\n\tprev_tick\x18\x03 \x01(\x05\x12\x13\n\x0b\x63urr_author\x18\x04 \x01(\x05\x12\x11\n\tcurr_tick\x18\x05 \x01(\x05\x12\r\n\x05\x64\x65lta\x18\x06 \x01(\x03\x12\x0c\n\x04kind\x18\x07 \x01(\x05\"\xd8\x01\n\x11LineHistoryCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x16\n\x0ewhen_unix_time\x18\x02 \x01(\x03\x12\x0c\n\x04tick\x18\x03 \x01(\x05\x12\x0e\n\x06\x61uthor\x18\x04 \x01(\x05\x12#\n\x07\x63hanges\x18\x05 \x03(\x0b\x32\x12.LineHistoryChange\x12,\n\x05names\x18\x06 \x03(\x0b\x32\x1d.LineHistoryCommit.NamesEntry\x1a,\n\nNamesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"\xd5\x01\n\x16LineHistoryDumpResults\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12#\n\x07\x63ommits\x18\x02 \x03(\x0b\x32\x12.LineHistoryCommit\x12\x31\n\x05\x66iles\x18\x03 \x03(\x0b\x32\".LineHistoryDumpResults.FilesEntry\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1a,\n\nFilesEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\t:\x02\x38\x01\"a\n\x0fTopologyProject\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x11\n\tmanifests\x18\x02 \x03(\t\x12\r\n\x05\x66iles\x18\x03 \x01(\x05\x12\r\n\x05lines\x18\x04 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x05 \x01(\x05\">\n\x0cTopologyEdge\x12\r\n\x05\x66irst\x18\x01 \x01(\x05\x12\x0e\n\x06second\x18\x02 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x03 \x01(\x05\"S\n\x0fTopologyResults\x12\"\n\x08projects\x18\x01 \x03(\x0b\x32\x10.TopologyProject\x12\x1c\n\x05\x65\x64ges\x18\x02 \x03(\x0b\x32\r.TopologyEdge\"D\n\x13\x43ommitSizeHistogram\x12\x0f\n\x07\x63ommits\x18\x01 \x01(\x05\x12\r\n\x05\x66iles\x18\x02 \x03(\x05\x12\r\n\x05lines\x18\x03 \x03(\x05\"\x8b\x01\n\x0e\x43ommitSizeTick\x12\'\n\thistogram\x18\x01 \x01(\x0b\x32\x14.CommitSizeHistogram\x12\x14\n\x0cmedian_files\x18\x02 \x01(\x05\x12\x11\n\tp90_files\x18\x03 \x01(\x05\x12\x14\n\x0cmedian_lines\x18\x04 \x01(\x05\x12\x11\n\tp90_lines\x18\x05 \x01(\x05\"x\n\nMegaCommit\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0e\n\x06\x61uthor\x18\x02 \x01(\x05\x12\x0c\n\x04tick\x18\x03 \x01(\x05\x12\r\n\x05\x66iles\x18\x04 \x01(\x05\x12\r\n\x05\x61\x64\x64\x65\x64\x18\x05 \x01(\x05\x12\x0f\n\x07removed\x18\x06 \x01(\x05\x12\x0f\n\x07\x63hanged\x18\x07 \x01(\x05\"\x92\x03\n\x11\x43ommitSizeResults\x12.\n\x06people\x18\x01 \x03(\x0b\x32\x1e.CommitSizeResults.PeopleEntry\x12,\n\x05ticks\x18\x02 \x03(\x0b\x32\x1d.CommitSizeResults.TicksEntry\x12!\n\x0cmega_commits\x18\x03 \x03(\x0b\x32\x0b.MegaCommit\x12\x12\n\nmega_files\x18\x04 \x01(\x05\x12\x12\n\nmega_lines\x18\x05 \x01(\x05\x12\x14\n\x0c\x66iles_bounds\x18\x06 \x03(\x05\x12\x14\n\x0clines_bounds\x18\x07 \x03(\x05\x12\x11\n\tdev_index\x18\x08 \x03(\t\x12\x11\n\ttick_size\x18\t \x01(\x03\x1a\x43\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.CommitSizeHistogram:\x02\x38\x01\x1a=\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\x1e\n\x05value\x18\x02 \x01(\x0b\x32\x0f.CommitSizeTick:\x02\x38\x01\"\x9b\x01\n\x12ReviewLatencyStats\x12\x0e\n\x06merges\x18\x01 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x02 \x01(\x05\x12\x18\n\x10median_lead_time\x18\x03 \x01(\x03\x12\x15\n\rp90_lead_time\x18\x04 \x01(\x03\x12\x1a\n\x12median_review_wait\x18\x05 \x01(\x03\x12\x17\n\x0fp90_review_wait\x18\x06 \x01(\x03\"r\n\x0bIntegration\x12\x0c\n\x04hash\x18\x01 \x01(\t\x12\x0e\n\x06\x61uthor\x18\x02 \x01(\x05\x12\x0c\n\x04tick\x18\x03 \x01(\x05\x12\x0f\n\x07\x63ommits\x18\x04 \x01(\x05\x12\x11\n\tlead_time\x18\x05 \x01(\x03\x12\x13\n\x0breview_wait\x18\x06 \x01(\x03\"\xcb\x02\n\x14ReviewLatencyResults\x12/\n\x05ticks\x18\x01 \x03(\x0b\x32 .ReviewLatencyResults.TicksEntry\x12\x31\n\x06people\x18\x02 \x03(\x0b\x32!.ReviewLatencyResults.PeopleEntry\x12\"\n\x0cintegrations\x18\x03 \x03(\x0b\x32\x0c.Integration\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1a\x41\n\nTicksEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ReviewLatencyStats:\x02\x38\x01\x1a\x42\n\x0bPeopleEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.ReviewLatencyStats:\x02\x38\x01\"B\n\x13KnowledgeLossCounts\x12\x13\n\x0btotal_lines\x18\x01 \x01(\x03\x12\x16\n\x0eorphaned_lines\x18\x02 \x01(\x03\"\xcc\x01\n\x15KnowledgeLossSnapshot\x12\x13\n\x0btotal_lines\x18\x01 \x01(\x03\x12\x16\n\x0eorphaned_lines\x18\x02 \x01(\x03\x12<\n\x0b\x64irectories\x18\x03 \x03(\x0b\x32\'.KnowledgeLossSnapshot.DirectoriesEntry\x1aH\n\x10\x44irectoriesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12#\n\x05value\x18\x02 \x01(\x0b\x32\x14.KnowledgeLossCounts:\x02\x38\x01\"\xbe\x02\n\x14KnowledgeLossResults\x12\x37\n\tsnapshots\x18\x01 \x03(\x0b\x32$.KnowledgeLossResults.SnapshotsEntry\x12\x35\n\x08\x64\x65parted\x18\x02 \x03(\x0b\x32#.KnowledgeLossResults.DepartedEntry\x12\x15\n\rinactive_days\x18\x03 \x01(\x05\x12\x11\n\tdev_index\x18\x04 \x03(\t\x12\x11\n\ttick_size\x18\x05 \x01(\x03\x1aH\n\x0eSnapshotsEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12%\n\x05value\x18\x02 \x01(\x0b\x32\x16.KnowledgeLossSnapshot:\x02\x38\x01\x1a/\n\rDepartedEntry\x12\x0b\n\x03key\x18\x01 \x01(\x05\x12\r\n\x05value\x18\x02 \x01(\x05:\x02\x38\x01\"\xc4\x01\n\x0f\x41nalysisResults\x12\x19\n\x06header\x18\x01 \x01(\x0b\x32\t.Metadata\x12\x30\n\x08\x63ontents\x18\x02 \x03(\x0b\x32\x1e.AnalysisResults.ContentsEntry\x12\x33\n\x11refactoring_proxy\x18\x03 \x01(\x0b\x32\x18.RefactoringProxyResults\x1a/\n\rContentsEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\r\n\x05value\x18\x02 \x01(\x0c:\x02\x38\x01\x62\x06proto3')
 
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, globals())
 _builder.BuildTopDescriptorsAndMessages(DESCRIPTOR, 'pb_pb2', globals())
@@ -32,6 +32,8 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _FILEHISTORYRESULTMESSAGE_FILESENTRY._serialized_options = b'8\001'
   _DEVTICK_LANGUAGESENTRY._options = None
   _DEVTICK_LANGUAGESENTRY._serialized_options = b'8\001'
+  _DEVTICK_CATEGORIESENTRY._options = None
+  _DEVTICK_CATEGORIESENTRY._serialized_options = b'8\001'
   _TICKDEVS_DEVSENTRY._options = None
   _TICKDEVS_DEVSENTRY._serialized_options = b'8\001'
   _DEVSANALYSISRESULTS_TICKSENTRY._options = None
@@ -50,12 +52,28 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _TEMPORALACTIVITYRESULTS_ACTIVITIESENTRY._serialized_options = b'8\001'
   _TEMPORALACTIVITYRESULTS_TICKSENTRY._options = None
   _TEMPORALACTIVITYRESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _TEMPORALACTIVITYRESULTS_OFFSETSENTRY._options = None
+  _TEMPORALACTIVITYRESULTS_OFFSETSENTRY._serialized_options = b'8\001'
+  _TEMPORALACTIVITYRESULTS_SUMMARIESENTRY._options = None
+  _TEMPORALACTIVITYRESULTS_SUMMARIESENTRY._serialized_options = b'8\001'
+  _CODECHURNREWORKTICKS_TICKSENTRY._options = None
+  _CODECHURNREWORKTICKS_TICKSENTRY._serialized_options = b'8\001'
+  _CODECHURNRESULTS_PEOPLEENTRY._options = None
+  _CODECHURNRESULTS_PEOPLEENTRY._serialized_options = b'8\001'
+  _CODECHURNRESULTS_REWORKENTRY._options = None
+  _CODECHURNRESULTS_REWORKENTRY._serialized_options = b'8\001'
   _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._options = None
   _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._serialized_options = b'8\001'
+  _BUSFACTORTICKSNAPSHOT_SUBSYSTEMSENTRY._options = None
+  _BUSFACTORTICKSNAPSHOT_SUBSYSTEMSENTRY._serialized_options = b'8\001'
   _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._options = None
   _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._serialized_options = b'8\001'
   _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._options = None
   _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._serialized_options = b'8\001'
+  _BUSFACTORANALYSISRESULTS_FILESOWNERSHIPENTRY._options = None
+  _BUSFACTORANALYSISRESULTS_FILESOWNERSHIPENTRY._serialized_options = b'8\001'
+  _BUSFACTORREMOVAL_GAPSENTRY._options = None
+  _BUSFACTORREMOVAL_GAPSENTRY._serialized_options = b'8\001'
   _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._options = None
   _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._serialized_options = b'8\001'
   _OWNERSHIPCONCENTRATIONRESULTS_SNAPSHOTSENTRY._options = None
@@ -70,170 +88,440 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _KNOWLEDGEDIFFUSIONRESULTS_FILESENTRY._serialized_options = b'8\001'
   _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._options = None
   _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._serialized_options = b'8\001'
+  _KNOWLEDGEDIFFUSIONRESULTS_DIRECTORIESENTRY._options = None
+  _KNOWLEDGEDIFFUSIONRESULTS_DIRECTORIESENTRY._serialized_options = b'8\001'
   _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._options = None
   _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._serialized_options = b'8\001'
+  _AUTHORONBOARDINGDATA_MENTORSENTRY._options = None
+  _AUTHORONBOARDINGDATA_MENTORSENTRY._serialized_options = b'8\001'
   _COHORTSTATS_AVERAGESNAPSHOTSENTRY._options = None
   _COHORTSTATS_AVERAGESNAPSHOTSENTRY._serialized_options = b'8\001'
+  _COHORTSTATS_RETENTIONENTRY._options = None
+  _COHORTSTATS_RETENTIONENTRY._serialized_options = b'8\001'
   _ONBOARDINGRESULTS_AUTHORSENTRY._options = None
   _ONBOARDINGRESULTS_AUTHORSENTRY._serialized_options = b'8\001'
   _ONBOARDINGRESULTS_COHORTSENTRY._options = None
   _ONBOARDINGRESULTS_COHORTSENTRY._serialized_options = b'8\001'
+  _COMMENTDENSITYTICK_SUBSYSTEMSENTRY._options = None
+  _COMMENTDENSITYTICK_SUBSYSTEMSENTRY._serialized_options = b'8\001'
+  _COMMENTDENSITYRESULTS_TICKSENTRY._options = None
+  _COMMENTDENSITYRESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _COMMENTDENSITYRESULTS_FILESENTRY._options = None
+  _COMMENTDENSITYRESULTS_FILESENTRY._serialized_options = b'8\001'
+  _REGEXMETRICSTICK_SUBSYSTEMSENTRY._options = None
+  _REGEXMETRICSTICK_SUBSYSTEMSENTRY._serialized_options = b'8\001'
+  _REGEXMETRICSRESULTS_TICKSENTRY._options = None
+  _REGEXMETRICSRESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _TESTCHURNRESULTS_TICKSENTRY._options = None
+  _TESTCHURNRESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _CODEAGEPYRAMIDRESULTS_SUBSYSTEMSENTRY._options = None
+  _CODEAGEPYRAMIDRESULTS_SUBSYSTEMSENTRY._serialized_options = b'8\001'
+  _REWRITERATIORESULTS_PEOPLEENTRY._options = None
+  _REWRITERATIORESULTS_PEOPLEENTRY._serialized_options = b'8\001'
+  _REWRITERATIORESULTS_SUBSYSTEMSENTRY._options = None
+  _REWRITERATIORESULTS_SUBSYSTEMSENTRY._serialized_options = b'8\001'
+  _CROSSTIMEZONERESULTS_OFFSETSENTRY._options = None
+  _CROSSTIMEZONERESULTS_OFFSETSENTRY._serialized_options = b'8\001'
+  _ABSENCERESULTS_DEVELOPERSENTRY._options = None
+  _ABSENCERESULTS_DEVELOPERSENTRY._serialized_options = b'8\001'
+  _ABSENCERESULTS_OWNERSENTRY._options = None
+  _ABSENCERESULTS_OWNERSENTRY._serialized_options = b'8\001'
+  _DIVERSITYQUARTER_COMMITSENTRY._options = None
+  _DIVERSITYQUARTER_COMMITSENTRY._serialized_options = b'8\001'
+  _CONTRIBUTIONDIVERSITYRESULTS_QUARTERSENTRY._options = None
+  _CONTRIBUTIONDIVERSITYRESULTS_QUARTERSENTRY._serialized_options = b'8\001'
+  _CONTRIBUTIONFUNNELRESULTS_CONTRIBUTIONSENTRY._options = None
+  _CONTRIBUTIONFUNNELRESULTS_CONTRIBUTIONSENTRY._serialized_options = b'8\001'
+  _SELFMERGERESULTS_MONTHSENTRY._options = None
+  _SELFMERGERESULTS_MONTHSENTRY._serialized_options = b'8\001'
+  _SELFMERGERESULTS_SUBSYSTEMSENTRY._options = None
+  _SELFMERGERESULTS_SUBSYSTEMSENTRY._serialized_options = b'8\001'
+  _MONTHLYWORKINGSETS_DEVELOPERSENTRY._options = None
+  _MONTHLYWORKINGSETS_DEVELOPERSENTRY._serialized_options = b'8\001'
+  _WORKINGSETOVERLAPRESULTS_MONTHSENTRY._options = None
+  _WORKINGSETOVERLAPRESULTS_MONTHSENTRY._serialized_options = b'8\001'
+  _BLAMEDUMPERRESULTS_FILESENTRY._options = None
+  _BLAMEDUMPERRESULTS_FILESENTRY._serialized_options = b'8\001'
+  _LINEHISTORYCOMMIT_NAMESENTRY._options = None
+  _LINEHISTORYCOMMIT_NAMESENTRY._serialized_options = b'8\001'
+  _LINEHISTORYDUMPRESULTS_FILESENTRY._options = None
+  _LINEHISTORYDUMPRESULTS_FILESENTRY._serialized_options = b'8\001'
+  _COMMITSIZERESULTS_PEOPLEENTRY._options = None
+  _COMMITSIZERESULTS_PEOPLEENTRY._serialized_options = b'8\001'
+  _COMMITSIZERESULTS_TICKSENTRY._options = None
+  _COMMITSIZERESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _REVIEWLATENCYRESULTS_TICKSENTRY._options = None
+  _REVIEWLATENCYRESULTS_TICKSENTRY._serialized_options = b'8\001'
+  _REVIEWLATENCYRESULTS_PEOPLEENTRY._options = None
+  _REVIEWLATENCYRESULTS_PEOPLEENTRY._serialized_options = b'8\001'
+  _KNOWLEDGELOSSSNAPSHOT_DIRECTORIESENTRY._options = None
+  _KNOWLEDGELOSSSNAPSHOT_DIRECTORIESENTRY._serialized_options = b'8\001'
+  _KNOWLEDGELOSSRESULTS_SNAPSHOTSENTRY._options = None
+  _KNOWLEDGELOSSRESULTS_SNAPSHOTSENTRY._serialized_options = b'8\001'
+  _KNOWLEDGELOSSRESULTS_DEPARTEDENTRY._options = None
+  _KNOWLEDGELOSSRESULTS_DEPARTEDENTRY._serialized_options = b'8\001'
   _ANALYSISRESULTS_CONTENTSENTRY._options = None
   _ANALYSISRESULTS_CONTENTSENTRY._serialized_options = b'8\001'
   _METADATA._serialized_start=13
-  _METADATA._serialized_end=270
-  _METADATA_RUNTIMEPERITEMENTRY._serialized_start=217
-  _METADATA_RUNTIMEPERITEMENTRY._serialized_end=270
-  _BURNDOWNSPARSEMATRIXROW._serialized_start=272
-  _BURNDOWNSPARSEMATRIXROW._serialized_end=314
-  _BURNDOWNSPARSEMATRIX._serialized_start=316
-  _BURNDOWNSPARSEMATRIX._serialized_end=443
-  _FILESOWNERSHIP._serialized_start=445
-  _FILESOWNERSHIP._serialized_end=550
-  _FILESOWNERSHIP_VALUEENTRY._serialized_start=506
-  _FILESOWNERSHIP_VALUEENTRY._serialized_end=550
-  _BURNDOWNANALYSISRESULTS._serialized_start=553
-  _BURNDOWNANALYSISRESULTS._serialized_end=925
-  _COMPRESSEDSPARSEROWMATRIX._serialized_start=927
-  _COMPRESSEDSPARSEROWMATRIX._serialized_end=1052
-  _COUPLES._serialized_start=1054
-  _COUPLES._serialized_end=1122
-  _TOUCHEDFILES._serialized_start=1124
-  _TOUCHEDFILES._serialized_end=1153
-  _COUPLESANALYSISRESULTS._serialized_start=1156
-  _COUPLESANALYSISRESULTS._serialized_end=1304
-  _SHOTNESSRECORD._serialized_start=1307
-  _SHOTNESSRECORD._serialized_end=1463
-  _SHOTNESSRECORD_COUNTERSENTRY._serialized_start=1416
-  _SHOTNESSRECORD_COUNTERSENTRY._serialized_end=1463
-  _SHOTNESSANALYSISRESULTS._serialized_start=1465
-  _SHOTNESSANALYSISRESULTS._serialized_end=1524
-  _FILEHISTORY._serialized_start=1527
-  _FILEHISTORY._serialized_end=1696
-  _FILEHISTORY_CHANGESBYDEVELOPERENTRY._serialized_start=1627
-  _FILEHISTORY_CHANGESBYDEVELOPERENTRY._serialized_end=1696
-  _FILEHISTORYRESULTMESSAGE._serialized_start=1699
-  _FILEHISTORYRESULTMESSAGE._serialized_end=1838
-  _FILEHISTORYRESULTMESSAGE_FILESENTRY._serialized_start=1780
-  _FILEHISTORYRESULTMESSAGE_FILESENTRY._serialized_end=1838
-  _LINESTATS._serialized_start=1840
-  _LINESTATS._serialized_end=1900
-  _DEVTICK._serialized_start=1903
-  _DEVTICK._serialized_end=2062
-  _DEVTICK_LANGUAGESENTRY._serialized_start=2002
-  _DEVTICK_LANGUAGESENTRY._serialized_end=2062
-  _TICKDEVS._serialized_start=2064
-  _TICKDEVS._serialized_end=2164
-  _TICKDEVS_DEVSENTRY._serialized_start=2111
-  _TICKDEVS_DEVSENTRY._serialized_end=2164
-  _DEVSANALYSISRESULTS._serialized_start=2167
-  _DEVSANALYSISRESULTS._serialized_end=2331
-  _DEVSANALYSISRESULTS_TICKSENTRY._serialized_start=2276
-  _DEVSANALYSISRESULTS_TICKSENTRY._serialized_end=2331
-  _SENTIMENT._serialized_start=2333
-  _SENTIMENT._serialized_end=2394
-  _COMMENTSENTIMENTRESULTS._serialized_start=2397
-  _COMMENTSENTIMENTRESULTS._serialized_end=2564
-  _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY._serialized_start=2498
-  _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY._serialized_end=2564
-  _COMMITFILE._serialized_start=2566
-  _COMMITFILE._serialized_end=2637
-  _COMMIT._serialized_start=2639
-  _COMMIT._serialized_end=2729
-  _COMMITSANALYSISRESULTS._serialized_start=2731
-  _COMMITSANALYSISRESULTS._serialized_end=2803
-  _TYPO._serialized_start=2805
-  _TYPO._serialized_end=2887
-  _TYPOSDATASET._serialized_start=2889
-  _TYPOSDATASET._serialized_end=2925
-  _IMPORTSPERTICK._serialized_start=2927
-  _IMPORTSPERTICK._serialized_end=3035
-  _IMPORTSPERTICK_COUNTSENTRY._serialized_start=2990
-  _IMPORTSPERTICK_COUNTSENTRY._serialized_end=3035
-  _IMPORTSPERLANGUAGE._serialized_start=3038
-  _IMPORTSPERLANGUAGE._serialized_end=3168
-  _IMPORTSPERLANGUAGE_TICKSENTRY._serialized_start=3107
-  _IMPORTSPERLANGUAGE_TICKSENTRY._serialized_end=3168
-  _IMPORTSPERDEVELOPER._serialized_start=3171
-  _IMPORTSPERDEVELOPER._serialized_end=3319
-  _IMPORTSPERDEVELOPER_LANGUAGESENTRY._serialized_start=3250
-  _IMPORTSPERDEVELOPER_LANGUAGESENTRY._serialized_end=3319
-  _IMPORTSPERDEVELOPERRESULTS._serialized_start=3321
-  _IMPORTSPERDEVELOPERRESULTS._serialized_end=3429
-  _TEMPORALDIMENSION._serialized_start=3431
-  _TEMPORALDIMENSION._serialized_end=3482
-  _DEVELOPERTEMPORALACTIVITY._serialized_start=3485
-  _DEVELOPERTEMPORALACTIVITY._serialized_end=3656
-  _TEMPORALACTIVITYTICK._serialized_start=3658
-  _TEMPORALACTIVITYTICK._serialized_end=3772
-  _TEMPORALACTIVITYTICKDEVS._serialized_start=3775
-  _TEMPORALACTIVITYTICKDEVS._serialized_end=3920
-  _TEMPORALACTIVITYTICKDEVS_DEVSENTRY._serialized_start=3854
-  _TEMPORALACTIVITYTICKDEVS_DEVSENTRY._serialized_end=3920
-  _TEMPORALACTIVITYRESULTS._serialized_start=3923
-  _TEMPORALACTIVITYRESULTS._serialized_end=4252
-  _TEMPORALACTIVITYRESULTS_ACTIVITIESENTRY._serialized_start=4102
-  _TEMPORALACTIVITYRESULTS_ACTIVITIESENTRY._serialized_end=4179
-  _TEMPORALACTIVITYRESULTS_TICKSENTRY._serialized_start=4181
-  _TEMPORALACTIVITYRESULTS_TICKSENTRY._serialized_end=4252
-  _BUSFACTORTICKSNAPSHOT._serialized_start=4255
-  _BUSFACTORTICKSNAPSHOT._serialized_end=4434
-  _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._serialized_start=4384
-  _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._serialized_end=4434
-  _BUSFACTORANALYSISRESULTS._serialized_start=4437
-  _BUSFACTORANALYSISRESULTS._serialized_end=4795
-  _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._serialized_start=4664
-  _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._serialized_end=4736
-  _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._serialized_start=4738
-  _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._serialized_end=4795
-  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT._serialized_start=4798
-  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT._serialized_end=5010
-  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._serialized_start=4384
-  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._serialized_end=4434
-  _OWNERSHIPCONCENTRATIONRESULTS._serialized_start=5013
-  _OWNERSHIPCONCENTRATIONRESULTS._serialized_end=5490
-  _OWNERSHIPCONCENTRATIONRESULTS_SNAPSHOTSENTRY._serialized_start=5298
-  _OWNERSHIPCONCENTRATIONRESULTS_SNAPSHOTSENTRY._serialized_end=5383
-  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMGINIENTRY._serialized_start=5385
-  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMGINIENTRY._serialized_end=5437
-  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMHHIENTRY._serialized_start=5439
-  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMHHIENTRY._serialized_end=5490
-  _KNOWLEDGEDIFFUSIONFILEDATA._serialized_start=5493
-  _KNOWLEDGEDIFFUSIONFILEDATA._serialized_end=5750
-  _KNOWLEDGEDIFFUSIONFILEDATA_UNIQUEEDITORSOVERTIMEENTRY._serialized_start=5690
-  _KNOWLEDGEDIFFUSIONFILEDATA_UNIQUEEDITORSOVERTIMEENTRY._serialized_end=5750
-  _KNOWLEDGEDIFFUSIONRESULTS._serialized_start=5753
-  _KNOWLEDGEDIFFUSIONRESULTS._serialized_end=6091
-  _KNOWLEDGEDIFFUSIONRESULTS_FILESENTRY._serialized_start=5965
-  _KNOWLEDGEDIFFUSIONRESULTS_FILESENTRY._serialized_end=6038
-  _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._serialized_start=6040
-  _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._serialized_end=6091
-  _ONBOARDINGSNAPSHOT._serialized_start=6094
-  _ONBOARDINGSNAPSHOT._serialized_end=6284
-  _ONBOARDINGAVERAGESNAPSHOT._serialized_start=6287
-  _ONBOARDINGAVERAGESNAPSHOT._serialized_end=6508
-  _AUTHORONBOARDINGDATA._serialized_start=6511
-  _AUTHORONBOARDINGDATA._serialized_end=6709
-  _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._serialized_start=6640
-  _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._serialized_end=6709
-  _COHORTSTATS._serialized_start=6712
-  _COHORTSTATS._serialized_end=6911
-  _COHORTSTATS_AVERAGESNAPSHOTSENTRY._serialized_start=6828
-  _COHORTSTATS_AVERAGESNAPSHOTSENTRY._serialized_end=6911
-  _ONBOARDINGRESULTS._serialized_start=6914
-  _ONBOARDINGRESULTS._serialized_end=7255
-  _ONBOARDINGRESULTS_AUTHORSENTRY._serialized_start=7124
-  _ONBOARDINGRESULTS_AUTHORSENTRY._serialized_end=7193
-  _ONBOARDINGRESULTS_COHORTSENTRY._serialized_start=7195
-  _ONBOARDINGRESULTS_COHORTSENTRY._serialized_end=7255
-  _FILERISK._serialized_start=7258
-  _FILERISK._serialized_end=7490
-  _HOTSPOTRISKRESULTS._serialized_start=7492
-  _HOTSPOTRISKRESULTS._serialized_end=7559
-  _REFACTORINGPROXYRESULTS._serialized_start=7562
-  _REFACTORINGPROXYRESULTS._serialized_end=7710
-  _ANALYSISRESULTS._serialized_start=7713
-  _ANALYSISRESULTS._serialized_end=7909
-  _ANALYSISRESULTS_CONTENTSENTRY._serialized_start=7862
-  _ANALYSISRESULTS_CONTENTSENTRY._serialized_end=7909
+  _METADATA._serialized_end=333
+  _METADATA_RUNTIMEPERITEMENTRY._serialized_start=280
+  _METADATA_RUNTIMEPERITEMENTRY._serialized_end=333
+  _BURNDOWNSPARSEMATRIXROW._serialized_start=335
+  _BURNDOWNSPARSEMATRIXROW._serialized_end=377
+  _BURNDOWNSPARSEMATRIX._serialized_start=379
+  _BURNDOWNSPARSEMATRIX._serialized_end=506
+  _FILESOWNERSHIP._serialized_start=508
+  _FILESOWNERSHIP._serialized_end=613
+  _FILESOWNERSHIP_VALUEENTRY._serialized_start=569
+  _FILESOWNERSHIP_VALUEENTRY._serialized_end=613
+  _BURNDOWNANALYSISRESULTS._serialized_start=616
+  _BURNDOWNANALYSISRESULTS._serialized_end=1199
+  _COMPRESSEDSPARSEROWMATRIX._serialized_start=1201
+  _COMPRESSEDSPARSEROWMATRIX._serialized_end=1326
+  _COUPLES._serialized_start=1328
+  _COUPLES._serialized_end=1396
+  _TOUCHEDFILES._serialized_start=1398
+  _TOUCHEDFILES._serialized_end=1427
+  _COUPLESANALYSISRESULTS._serialized_start=1430
+  _COUPLESANALYSISRESULTS._serialized_end=1628
+  _SHOTNESSRECORD._serialized_start=1631
+  _SHOTNESSRECORD._serialized_end=1787
+  _SHOTNESSRECORD_COUNTERSENTRY._serialized_start=1740
+  _SHOTNESSRECORD_COUNTERSENTRY._serialized_end=1787
+  _SHOTNESSANALYSISRESULTS._serialized_start=1789
+  _SHOTNESSANALYSISRESULTS._serialized_end=1848
+  _FILEHISTORY._serialized_start=1851
+  _FILEHISTORY._serialized_end=2113
+  _FILEHISTORY_CHANGESBYDEVELOPERENTRY._serialized_start=2044
+  _FILEHISTORY_CHANGESBYDEVELOPERENTRY._serialized_end=2113
+  _FILEHISTORYRESULTMESSAGE._serialized_start=2116
+  _FILEHISTORYRESULTMESSAGE._serialized_end=2286
+  _FILEHISTORYRESULTMESSAGE_FILESENTRY._serialized_start=2228
+  _FILEHISTORYRESULTMESSAGE_FILESENTRY._serialized_end=2286
+  _LINESTATS._serialized_start=2288
+  _LINESTATS._serialized_end=2408
+  _LINECOUNTS._serialized_start=2410
+  _LINECOUNTS._serialized_end=2471
+  _DEVTICK._serialized_start=2474
+  _DEVTICK._serialized_end=2742
+  _DEVTICK_LANGUAGESENTRY._serialized_start=2619
+  _DEVTICK_LANGUAGESENTRY._serialized_end=2679
+  _DEVTICK_CATEGORIESENTRY._serialized_start=2681
+  _DEVTICK_CATEGORIESENTRY._serialized_end=2742
+  _TICKDEVS._serialized_start=2744
+  _TICKDEVS._serialized_end=2844
+  _TICKDEVS_DEVSENTRY._serialized_start=2791
+  _TICKDEVS_DEVSENTRY._serialized_end=2844
+  _DEVSANALYSISRESULTS._serialized_start=2847
+  _DEVSANALYSISRESULTS._serialized_end=3034
+  _DEVSANALYSISRESULTS_TICKSENTRY._serialized_start=2979
+  _DEVSANALYSISRESULTS_TICKSENTRY._serialized_end=3034
+  _SENTIMENT._serialized_start=3036
+  _SENTIMENT._serialized_end=3097
+  _COMMENTSENTIMENTRESULTS._serialized_start=3100
+  _COMMENTSENTIMENTRESULTS._serialized_end=3267
+  _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY._serialized_start=3201
+  _COMMENTSENTIMENTRESULTS_SENTIMENTBYTICKENTRY._serialized_end=3267
+  _COMMITFILE._serialized_start=3269
+  _COMMITFILE._serialized_end=3340
+  _COMMIT._serialized_start=3342
+  _COMMIT._serialized_end=3461
+  _COMMITSANALYSISRESULTS._serialized_start=3463
+  _COMMITSANALYSISRESULTS._serialized_end=3535
+  _TYPO._serialized_start=3537
+  _TYPO._serialized_end=3619
+  _TYPOSDATASET._serialized_start=3621
+  _TYPOSDATASET._serialized_end=3657
+  _IMPORTSPERTICK._serialized_start=3659
+  _IMPORTSPERTICK._serialized_end=3767
+  _IMPORTSPERTICK_COUNTSENTRY._serialized_start=3722
+  _IMPORTSPERTICK_COUNTSENTRY._serialized_end=3767
+  _IMPORTSPERLANGUAGE._serialized_start=3770
+  _IMPORTSPERLANGUAGE._serialized_end=3900
+  _IMPORTSPERLANGUAGE_TICKSENTRY._serialized_start=3839
+  _IMPORTSPERLANGUAGE_TICKSENTRY._serialized_end=3900
+  _IMPORTSPERDEVELOPER._serialized_start=3903
+  _IMPORTSPERDEVELOPER._serialized_end=4051
+  _IMPORTSPERDEVELOPER_LANGUAGESENTRY._serialized_start=3982
+  _IMPORTSPERDEVELOPER_LANGUAGESENTRY._serialized_end=4051
+  _IMPORTSPERDEVELOPERRESULTS._serialized_start=4053
+  _IMPORTSPERDEVELOPERRESULTS._serialized_end=4161
+  _TEMPORALDIMENSION._serialized_start=4163
+  _TEMPORALDIMENSION._serialized_end=4214
+  _DEVELOPERTEMPORALACTIVITY._serialized_start=4217
+  _DEVELOPERTEMPORALACTIVITY._serialized_end=4388
+  _TEMPORALACTIVITYTICK._serialized_start=4390
+  _TEMPORALACTIVITYTICK._serialized_end=4504
+  _TEMPORALACTIVITYTICKDEVS._serialized_start=4507
+  _TEMPORALACTIVITYTICKDEVS._serialized_end=4652
+  _TEMPORALACTIVITYTICKDEVS_DEVSENTRY._serialized_start=4586
+  _TEMPORALACTIVITYTICKDEVS_DEVSENTRY._serialized_end=4652
+  _TEMPORALACTIVITYRESULTS._serialized_start=4655
+  _TEMPORALACTIVITYRESULTS._serialized_end=5242
+  _TEMPORALACTIVITYRESULTS_ACTIVITIESENTRY._serialized_start=4968
+  _TEMPORALACTIVITYRESULTS_ACTIVITIESENTRY._serialized_end=5045
+  _TEMPORALACTIVITYRESULTS_TICKSENTRY._serialized_start=5047
+  _TEMPORALACTIVITYRESULTS_TICKSENTRY._serialized_end=5118
+  _TEMPORALACTIVITYRESULTS_OFFSETSENTRY._serialized_start=5120
+  _TEMPORALACTIVITYRESULTS_OFFSETSENTRY._serialized_end=5166
+  _TEMPORALACTIVITYRESULTS_SUMMARIESENTRY._serialized_start=5168
+  _TEMPORALACTIVITYRESULTS_SUMMARIESENTRY._serialized_end=5242
+  _TEMPORALACTIVITYSUMMARY._serialized_start=5244
+  _TEMPORALACTIVITYSUMMARY._serialized_end=5343
+  _CODECHURNSERIES._serialized_start=5346
+  _CODECHURNSERIES._serialized_end=5567
+  _CODECHURNKINDSERIES._serialized_start=5569
+  _CODECHURNKINDSERIES._serialized_end=5675
+  _CODECHURNREWORK._serialized_start=5677
+  _CODECHURNREWORK._serialized_end=5758
+  _CODECHURNREWORKTICKS._serialized_start=5761
+  _CODECHURNREWORKTICKS._serialized_end=5896
+  _CODECHURNREWORKTICKS_TICKSENTRY._serialized_start=5834
+  _CODECHURNREWORKTICKS_TICKSENTRY._serialized_end=5896
+  _CODECHURNRESULTS._serialized_start=5899
+  _CODECHURNRESULTS._serialized_end=6227
+  _CODECHURNRESULTS_PEOPLEENTRY._serialized_start=6094
+  _CODECHURNRESULTS_PEOPLEENTRY._serialized_end=6157
+  _CODECHURNRESULTS_REWORKENTRY._serialized_start=6159
+  _CODECHURNRESULTS_REWORKENTRY._serialized_end=6227
+  _BUSFACTORTICKSNAPSHOT._serialized_start=6230
+  _BUSFACTORTICKSNAPSHOT._serialized_end=6520
+  _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._serialized_start=6419
+  _BUSFACTORTICKSNAPSHOT_AUTHORLINESENTRY._serialized_end=6469
+  _BUSFACTORTICKSNAPSHOT_SUBSYSTEMSENTRY._serialized_start=6471
+  _BUSFACTORTICKSNAPSHOT_SUBSYSTEMSENTRY._serialized_end=6520
+  _BUSFACTORANALYSISRESULTS._serialized_start=6523
+  _BUSFACTORANALYSISRESULTS._serialized_end=7155
+  _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._serialized_start=6956
+  _BUSFACTORANALYSISRESULTS_SNAPSHOTSENTRY._serialized_end=7028
+  _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._serialized_start=7030
+  _BUSFACTORANALYSISRESULTS_SUBSYSTEMBUSFACTORENTRY._serialized_end=7087
+  _BUSFACTORANALYSISRESULTS_FILESOWNERSHIPENTRY._serialized_start=7089
+  _BUSFACTORANALYSISRESULTS_FILESOWNERSHIPENTRY._serialized_end=7155
+  _BUSFACTORREMOVAL._serialized_start=7158
+  _BUSFACTORREMOVAL._serialized_end=7333
+  _BUSFACTORREMOVAL_GAPSENTRY._serialized_start=7290
+  _BUSFACTORREMOVAL_GAPSENTRY._serialized_end=7333
+  _FILEOWNERS._serialized_start=7335
+  _FILEOWNERS._serialized_end=7401
+  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT._serialized_start=7404
+  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT._serialized_end=7616
+  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._serialized_start=7566
+  _OWNERSHIPCONCENTRATIONTICKSNAPSHOT_AUTHORLINESENTRY._serialized_end=7616
+  _OWNERSHIPCONCENTRATIONRESULTS._serialized_start=7619
+  _OWNERSHIPCONCENTRATIONRESULTS._serialized_end=8134
+  _OWNERSHIPCONCENTRATIONRESULTS_SNAPSHOTSENTRY._serialized_start=7942
+  _OWNERSHIPCONCENTRATIONRESULTS_SNAPSHOTSENTRY._serialized_end=8027
+  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMGINIENTRY._serialized_start=8029
+  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMGINIENTRY._serialized_end=8081
+  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMHHIENTRY._serialized_start=8083
+  _OWNERSHIPCONCENTRATIONRESULTS_SUBSYSTEMHHIENTRY._serialized_end=8134
+  _KNOWLEDGEDIFFUSIONFILEDATA._serialized_start=8137
+  _KNOWLEDGEDIFFUSIONFILEDATA._serialized_end=8426
+  _KNOWLEDGEDIFFUSIONFILEDATA_UNIQUEEDITORSOVERTIMEENTRY._serialized_start=8366
+  _KNOWLEDGEDIFFUSIONFILEDATA_UNIQUEEDITORSOVERTIMEENTRY._serialized_end=8426
+  _KNOWLEDGEDIFFUSIONRESULTS._serialized_start=8429
+  _KNOWLEDGEDIFFUSIONRESULTS._serialized_end=8963
+  _KNOWLEDGEDIFFUSIONRESULTS_FILESENTRY._serialized_start=8751
+  _KNOWLEDGEDIFFUSIONRESULTS_FILESENTRY._serialized_end=8824
+  _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._serialized_start=8826
+  _KNOWLEDGEDIFFUSIONRESULTS_DISTRIBUTIONENTRY._serialized_end=8877
+  _KNOWLEDGEDIFFUSIONRESULTS_DIRECTORIESENTRY._serialized_start=8879
+  _KNOWLEDGEDIFFUSIONRESULTS_DIRECTORIESENTRY._serialized_end=8963
+  _KNOWLEDGEDIFFUSIONDIRECTORYDATA._serialized_start=8966
+  _KNOWLEDGEDIFFUSIONDIRECTORYDATA._serialized_end=9150
+  _ONBOARDINGSNAPSHOT._serialized_start=9153
+  _ONBOARDINGSNAPSHOT._serialized_end=9343
+  _ONBOARDINGAVERAGESNAPSHOT._serialized_start=9346
+  _ONBOARDINGAVERAGESNAPSHOT._serialized_end=9567
+  _AUTHORONBOARDINGDATA._serialized_start=9570
+  _AUTHORONBOARDINGDATA._serialized_end=9952
+  _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._serialized_start=9835
+  _AUTHORONBOARDINGDATA_SNAPSHOTSENTRY._serialized_end=9904
+  _AUTHORONBOARDINGDATA_MENTORSENTRY._serialized_start=9906
+  _AUTHORONBOARDINGDATA_MENTORSENTRY._serialized_end=9952
+  _COHORTSTATS._serialized_start=9955
+  _COHORTSTATS._serialized_end=10270
+  _COHORTSTATS_AVERAGESNAPSHOTSENTRY._serialized_start=10119
+  _COHORTSTATS_AVERAGESNAPSHOTSENTRY._serialized_end=10202
+  _COHORTSTATS_RETENTIONENTRY._serialized_start=10204
+  _COHORTSTATS_RETENTIONENTRY._serialized_end=10270
+  _COHORTRETENTION._serialized_start=10272
+  _COHORTRETENTION._serialized_end=10343
+  _ONBOARDINGRESULTS._serialized_start=10346
+  _ONBOARDINGRESULTS._serialized_end=10738
+  _ONBOARDINGRESULTS_AUTHORSENTRY._serialized_start=10607
+  _ONBOARDINGRESULTS_AUTHORSENTRY._serialized_end=10676
+  _ONBOARDINGRESULTS_COHORTSENTRY._serialized_start=10678
+  _ONBOARDINGRESULTS_COHORTSENTRY._serialized_end=10738
+  _FILERISK._serialized_start=10741
+  _FILERISK._serialized_end=11116
+  _LANGUAGERISK._serialized_start=11118
+  _LANGUAGERISK._serialized_end=11243
+  _HOTSPOTRISKRESULTS._serialized_start=11246
+  _HOTSPOTRISKRESULTS._serialized_end=11412
+  _HOTSPOTRISKSNAPSHOT._serialized_start=11414
+  _HOTSPOTRISKSNAPSHOT._serialized_end=11483
+  _HOTSPOTRISKENTRY._serialized_start=11485
+  _HOTSPOTRISKENTRY._serialized_end=11554
+  _REFACTORINGPROXYRESULTS._serialized_start=11557
+  _REFACTORINGPROXYRESULTS._serialized_end=11705
+  _COMMENTDENSITYSTATS._serialized_start=11707
+  _COMMENTDENSITYSTATS._serialized_end=11786
+  _COMMENTDENSITYTICK._serialized_start=11789
+  _COMMENTDENSITYTICK._serialized_end=11939
+  _COMMENTDENSITYTICK_SUBSYSTEMSENTRY._serialized_start=11868
+  _COMMENTDENSITYTICK_SUBSYSTEMSENTRY._serialized_end=11939
+  _COMMENTDENSITYEROSION._serialized_start=11942
+  _COMMENTDENSITYEROSION._serialized_end=12074
+  _COMMENTDENSITYRESULTS._serialized_start=12077
+  _COMMENTDENSITYRESULTS._serialized_end=12414
+  _COMMENTDENSITYRESULTS_TICKSENTRY._serialized_start=12281
+  _COMMENTDENSITYRESULTS_TICKSENTRY._serialized_end=12346
+  _COMMENTDENSITYRESULTS_FILESENTRY._serialized_start=12348
+  _COMMENTDENSITYRESULTS_FILESENTRY._serialized_end=12414
+  _REGEXMETRICSTICK._serialized_start=12417
+  _REGEXMETRICSTICK._serialized_end=12562
+  _REGEXMETRICSTICK_SUBSYSTEMSENTRY._serialized_start=12492
+  _REGEXMETRICSTICK_SUBSYSTEMSENTRY._serialized_end=12562
+  _REGEXMETRICSCOUNTS._serialized_start=12564
+  _REGEXMETRICSCOUNTS._serialized_end=12600
+  _REGEXMETRICSRESULTS._serialized_start=12603
+  _REGEXMETRICSRESULTS._serialized_end=12789
+  _REGEXMETRICSRESULTS_TICKSENTRY._serialized_start=12726
+  _REGEXMETRICSRESULTS_TICKSENTRY._serialized_end=12789
+  _TESTCHURNTICK._serialized_start=12791
+  _TESTCHURNTICK._serialized_end=12852
+  _TESTCHURNSUITE._serialized_start=12855
+  _TESTCHURNSUITE._serialized_end=13043
+  _TESTCHURNRESULTS._serialized_start=13046
+  _TESTCHURNRESULTS._serialized_end=13269
+  _TESTCHURNRESULTS_TICKSENTRY._serialized_start=13209
+  _TESTCHURNRESULTS_TICKSENTRY._serialized_end=13269
+  _CODEAGEPYRAMIDCOUNTS._serialized_start=13271
+  _CODEAGEPYRAMIDCOUNTS._serialized_end=13308
+  _CODEAGEPYRAMIDRESULTS._serialized_start=13311
+  _CODEAGEPYRAMIDRESULTS._serialized_end=13534
+  _CODEAGEPYRAMIDRESULTS_SUBSYSTEMSENTRY._serialized_start=13462
+  _CODEAGEPYRAMIDRESULTS_SUBSYSTEMSENTRY._serialized_end=13534
+  _REWRITESTATS._serialized_start=13536
+  _REWRITESTATS._serialized_end=13584
+  _REWRITERATIORESULTS._serialized_start=13587
+  _REWRITERATIORESULTS._serialized_end=13933
+  _REWRITERATIORESULTS_PEOPLEENTRY._serialized_start=13807
+  _REWRITERATIORESULTS_PEOPLEENTRY._serialized_end=13867
+  _REWRITERATIORESULTS_SUBSYSTEMSENTRY._serialized_start=13869
+  _REWRITERATIORESULTS_SUBSYSTEMSENTRY._serialized_end=13933
+  _CROSSTIMEZONEPAIR._serialized_start=13935
+  _CROSSTIMEZONEPAIR._serialized_end=14031
+  _CROSSTIMEZONERESULTS._serialized_start=14034
+  _CROSSTIMEZONERESULTS._serialized_end=14282
+  _CROSSTIMEZONERESULTS_OFFSETSENTRY._serialized_start=14236
+  _CROSSTIMEZONERESULTS_OFFSETSENTRY._serialized_end=14282
+  _ABSENCEPERIOD._serialized_start=14284
+  _ABSENCEPERIOD._serialized_end=14327
+  _DEVELOPERABSENCES._serialized_start=14329
+  _DEVELOPERABSENCES._serialized_end=14399
+  _COVERAGEGAP._serialized_start=14401
+  _COVERAGEGAP._serialized_end=14476
+  _ABSENCERESULTS._serialized_start=14479
+  _ABSENCERESULTS._serialized_end=14815
+  _ABSENCERESULTS_DEVELOPERSENTRY._serialized_start=14699
+  _ABSENCERESULTS_DEVELOPERSENTRY._serialized_end=14768
+  _ABSENCERESULTS_OWNERSENTRY._serialized_start=14770
+  _ABSENCERESULTS_OWNERSENTRY._serialized_end=14815
+  _DIVERSITYQUARTER._serialized_start=14817
+  _DIVERSITYQUARTER._serialized_end=14932
+  _DIVERSITYQUARTER_COMMITSENTRY._serialized_start=14886
+  _DIVERSITYQUARTER_COMMITSENTRY._serialized_end=14932
+  _CONTRIBUTIONDIVERSITYRESULTS._serialized_start=14935
+  _CONTRIBUTIONDIVERSITYRESULTS._serialized_end=15170
+  _CONTRIBUTIONDIVERSITYRESULTS_QUARTERSENTRY._serialized_start=15104
+  _CONTRIBUTIONDIVERSITYRESULTS_QUARTERSENTRY._serialized_end=15170
+  _FUNNELCONTRIBUTIONS._serialized_start=15172
+  _FUNNELCONTRIBUTIONS._serialized_end=15208
+  _CONTRIBUTIONFUNNELRESULTS._serialized_start=15211
+  _CONTRIBUTIONFUNNELRESULTS._serialized_end=15478
+  _CONTRIBUTIONFUNNELRESULTS_CONTRIBUTIONSENTRY._serialized_start=15404
+  _CONTRIBUTIONFUNNELRESULTS_CONTRIBUTIONSENTRY._serialized_end=15478
+  _SELFMERGECOUNTS._serialized_start=15480
+  _SELFMERGECOUNTS._serialized_end=15577
+  _SELFMERGERESULTS._serialized_start=15580
+  _SELFMERGERESULTS._serialized_end=15867
+  _SELFMERGERESULTS_MONTHSENTRY._serialized_start=15735
+  _SELFMERGERESULTS_MONTHSENTRY._serialized_end=15798
+  _SELFMERGERESULTS_SUBSYSTEMSENTRY._serialized_start=15800
+  _SELFMERGERESULTS_SUBSYSTEMSENTRY._serialized_end=15867
+  _WORKINGSET._serialized_start=15869
+  _WORKINGSET._serialized_end=15896
+  _MONTHLYWORKINGSETS._serialized_start=15899
+  _MONTHLYWORKINGSETS._serialized_end=16040
+  _MONTHLYWORKINGSETS_DEVELOPERSENTRY._serialized_start=15978
+  _MONTHLYWORKINGSETS_DEVELOPERSENTRY._serialized_end=16040
+  _WORKINGSETOVERLAPRESULTS._serialized_start=16043
+  _WORKINGSETOVERLAPRESULTS._serialized_end=16239
+  _WORKINGSETOVERLAPRESULTS_MONTHSENTRY._serialized_start=16173
+  _WORKINGSETOVERLAPRESULTS_MONTHSENTRY._serialized_end=16239
+  _BLAMESEGMENT._serialized_start=16241
+  _BLAMESEGMENT._serialized_end=16314
+  _BLAMEFILE._serialized_start=16316
+  _BLAMEFILE._serialized_end=16360
+  _BLAMEDUMPERRESULTS._serialized_start=16363
+  _BLAMEDUMPERRESULTS._serialized_end=16526
+  _BLAMEDUMPERRESULTS_FILESENTRY._serialized_start=16470
+  _BLAMEDUMPERRESULTS_FILESENTRY._serialized_end=16526
+  _LINEHISTORYCHANGE._serialized_start=16529
+  _LINEHISTORYCHANGE._serialized_end=16674
+  _LINEHISTORYCOMMIT._serialized_start=16677
+  _LINEHISTORYCOMMIT._serialized_end=16893
+  _LINEHISTORYCOMMIT_NAMESENTRY._serialized_start=16849
+  _LINEHISTORYCOMMIT_NAMESENTRY._serialized_end=16893
+  _LINEHISTORYDUMPRESULTS._serialized_start=16896
+  _LINEHISTORYDUMPRESULTS._serialized_end=17109
+  _LINEHISTORYDUMPRESULTS_FILESENTRY._serialized_start=17065
+  _LINEHISTORYDUMPRESULTS_FILESENTRY._serialized_end=17109
+  _TOPOLOGYPROJECT._serialized_start=17111
+  _TOPOLOGYPROJECT._serialized_end=17208
+  _TOPOLOGYEDGE._serialized_start=17210
+  _TOPOLOGYEDGE._serialized_end=17272
+  _TOPOLOGYRESULTS._serialized_start=17274
+  _TOPOLOGYRESULTS._serialized_end=17357
+  _COMMITSIZEHISTOGRAM._serialized_start=17359
+  _COMMITSIZEHISTOGRAM._serialized_end=17427
+  _COMMITSIZETICK._serialized_start=17430
+  _COMMITSIZETICK._serialized_end=17569
+  _MEGACOMMIT._serialized_start=17571
+  _MEGACOMMIT._serialized_end=17691
+  _COMMITSIZERESULTS._serialized_start=17694
+  _COMMITSIZERESULTS._serialized_end=18096
+  _COMMITSIZERESULTS_PEOPLEENTRY._serialized_start=17966
+  _COMMITSIZERESULTS_PEOPLEENTRY._serialized_end=18033
+  _COMMITSIZERESULTS_TICKSENTRY._serialized_start=18035
+  _COMMITSIZERESULTS_TICKSENTRY._serialized_end=18096
+  _REVIEWLATENCYSTATS._serialized_start=18099
+  _REVIEWLATENCYSTATS._serialized_end=18254
+  _INTEGRATION._serialized_start=18256
+  _INTEGRATION._serialized_end=18370
+  _REVIEWLATENCYRESULTS._serialized_start=18373
+  _REVIEWLATENCYRESULTS._serialized_end=18704
+  _REVIEWLATENCYRESULTS_TICKSENTRY._serialized_start=18571
+  _REVIEWLATENCYRESULTS_TICKSENTRY._serialized_end=18636
+  _REVIEWLATENCYRESULTS_PEOPLEENTRY._serialized_start=18638
+  _REVIEWLATENCYRESULTS_PEOPLEENTRY._serialized_end=18704
+  _KNOWLEDGELOSSCOUNTS._serialized_start=18706
+  _KNOWLEDGELOSSCOUNTS._serialized_end=18772
+  _KNOWLEDGELOSSSNAPSHOT._serialized_start=18775
+  _KNOWLEDGELOSSSNAPSHOT._serialized_end=18979
+  _KNOWLEDGELOSSSNAPSHOT_DIRECTORIESENTRY._serialized_start=18907
+  _KNOWLEDGELOSSSNAPSHOT_DIRECTORIESENTRY._serialized_end=18979
+  _KNOWLEDGELOSSRESULTS._serialized_start=18982
+  _KNOWLEDGELOSSRESULTS._serialized_end=19300
+  _KNOWLEDGELOSSRESULTS_SNAPSHOTSENTRY._serialized_start=19179
+  _KNOWLEDGELOSSRESULTS_SNAPSHOTSENTRY._serialized_end=19251
+  _KNOWLEDGELOSSRESULTS_DEPARTEDENTRY._serialized_start=19253
+  _KNOWLEDGELOSSRESULTS_DEPARTEDENTRY._serialized_end=19300
+  _ANALYSISRESULTS._serialized_start=19303
+  _ANALYSISRESULTS._serialized_end=19499
+  _ANALYSISRESULTS_CONTENTSENTRY._serialized_start=19452
+  _ANALYSISRESULTS_CONTENTSENTRY._serialized_end=19499
 # @@protoc_insertion_point(module_scope)
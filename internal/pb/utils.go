@@ -1,6 +1,8 @@
 package pb
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 )
 
@@ -95,3 +97,67 @@ func MapToCompressedSparseRowMatrix(matrix []map[int]int64) *CompressedSparseRow
 	}
 	return &r
 }
+
+// MaxDenseMatrixCells is the maximum number of cells in a matrix which Validate() accepts.
+// The deserializers allocate the dense matrices, so a forged size must not exhaust the memory.
+const MaxDenseMatrixCells = 1 << 26
+
+// validateDenseSize checks that the matrix of the specified size can be allocated.
+func validateDenseSize(rows, columns int32) error {
+	if rows < 0 || columns < 0 {
+		return fmt.Errorf("negative matrix size %dx%d", rows, columns)
+	}
+	if int64(rows)*int64(columns) > MaxDenseMatrixCells {
+		return fmt.Errorf("matrix %dx%d is too big", rows, columns)
+	}
+	return nil
+}
+
+// Validate checks that the matrix is consistent and can be converted to the dense form.
+func (m *BurndownSparseMatrix) Validate() error {
+	if m == nil {
+		return errors.New("missing matrix")
+	}
+	if err := validateDenseSize(m.NumberOfRows, m.NumberOfColumns); err != nil {
+		return fmt.Errorf("%s: %v", m.Name, err)
+	}
+	if len(m.Rows) < int(m.NumberOfRows) {
+		return fmt.Errorf("%s: %d rows instead of %d", m.Name, len(m.Rows), m.NumberOfRows)
+	}
+	for i, row := range m.Rows[:m.NumberOfRows] {
+		if row == nil || len(row.Columns) > int(m.NumberOfColumns) {
+			return fmt.Errorf("%s: row %d does not fit in %d columns", m.Name, i, m.NumberOfColumns)
+		}
+	}
+	return nil
+}
+
+// Validate checks that the indices and the pointers of the matrix are within the bounds.
+func (m *CompressedSparseRowMatrix) Validate() error {
+	if m == nil {
+		return errors.New("missing matrix")
+	}
+	if err := validateDenseSize(m.NumberOfRows, m.NumberOfColumns); err != nil {
+		return err
+	}
+	if len(m.Indptr) != int(m.NumberOfRows)+1 {
+		return fmt.Errorf("%d row pointers for %d rows", len(m.Indptr), m.NumberOfRows)
+	}
+	if len(m.Data) != len(m.Indices) {
+		return fmt.Errorf("%d values with %d indices", len(m.Data), len(m.Indices))
+	}
+	if m.Indptr[0] != 0 {
+		return fmt.Errorf("the first row pointer is %d", m.Indptr[0])
+	}
+	for i := 1; i < len(m.Indptr); i++ {
+		if m.Indptr[i] < m.Indptr[i-1] || m.Indptr[i] > int64(len(m.Data)) {
+			return fmt.Errorf("invalid row pointer %d", m.Indptr[i])
+		}
+	}
+	for _, index := range m.Indices {
+		if index < 0 || index >= m.NumberOfColumns {
+			return fmt.Errorf("column %d is out of range [0, %d)", index, m.NumberOfColumns)
+		}
+	}
+	return nil
+}
@@ -6,10 +6,16 @@ import (
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
+	c "github.com/smacker/go-tree-sitter/c"
+	cpp "github.com/smacker/go-tree-sitter/cpp"
+	csharp "github.com/smacker/go-tree-sitter/csharp"
 	golang "github.com/smacker/go-tree-sitter/golang"
 	java "github.com/smacker/go-tree-sitter/java"
 	javascript "github.com/smacker/go-tree-sitter/javascript"
 	python "github.com/smacker/go-tree-sitter/python"
+	ruby "github.com/smacker/go-tree-sitter/ruby"
+	rust "github.com/smacker/go-tree-sitter/rust"
+	tsx "github.com/smacker/go-tree-sitter/typescript/tsx"
 	typescript "github.com/smacker/go-tree-sitter/typescript/typescript"
 )
 
@@ -33,146 +39,232 @@ type Extractor interface {
 type languageSpec struct {
 	language            *sitter.Language
 	functionNodeTypes   map[string]struct{}
+	classNodeTypes      map[string]struct{}
 	identifierNodeTypes map[string]struct{}
 	commentNodeTypes    map[string]struct{}
+	// classBodyNodeTypes, if set, are required among the children of the class nodes, so that
+	// the forward declarations and the references like "struct S *s" are skipped.
+	classBodyNodeTypes map[string]struct{}
 }
 
-var languageByExtension = map[string]languageSpec{
-	".go": {
-		language: golang.GetLanguage(),
-		functionNodeTypes: map[string]struct{}{
-			"function_declaration": {},
-			"method_declaration":   {},
-		},
-		identifierNodeTypes: map[string]struct{}{
-			"identifier":       {},
-			"field_identifier": {},
-			"type_identifier":  {},
-		},
-		commentNodeTypes: map[string]struct{}{
-			"comment": {},
-		},
+var javascriptSpec = languageSpec{
+	language: javascript.GetLanguage(),
+	functionNodeTypes: map[string]struct{}{
+		"function_declaration":           {},
+		"generator_function_declaration": {},
+		"method_definition":              {},
 	},
-	".py": {
-		language: python.GetLanguage(),
-		functionNodeTypes: map[string]struct{}{
-			"function_definition": {},
-		},
-		identifierNodeTypes: map[string]struct{}{
-			"identifier": {},
-		},
-		commentNodeTypes: map[string]struct{}{
-			"comment": {},
-		},
+	classNodeTypes: map[string]struct{}{
+		"class_declaration": {},
+		"class":             {},
+	},
+	identifierNodeTypes: map[string]struct{}{
+		"identifier":                  {},
+		"property_identifier":         {},
+		"private_property_identifier": {},
 	},
-	".js": {
-		language: javascript.GetLanguage(),
+	commentNodeTypes: map[string]struct{}{
+		"comment": {},
+	},
+}
+
+func typescriptSpec(language *sitter.Language) languageSpec {
+	return languageSpec{
+		language: language,
 		functionNodeTypes: map[string]struct{}{
+			"function":                       {},
 			"function_declaration":           {},
 			"generator_function_declaration": {},
 			"method_definition":              {},
 		},
+		classNodeTypes: map[string]struct{}{
+			"class":                      {},
+			"class_declaration":          {},
+			"abstract_class_declaration": {},
+			"interface_declaration":      {},
+			"enum_declaration":           {},
+		},
 		identifierNodeTypes: map[string]struct{}{
 			"identifier":                  {},
 			"property_identifier":         {},
 			"private_property_identifier": {},
+			"type_identifier":             {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"comment": {},
 		},
+	}
+}
+
+var cSpec = languageSpec{
+	language: c.GetLanguage(),
+	functionNodeTypes: map[string]struct{}{
+		"function_definition": {},
 	},
-	".jsx": {
-		language: javascript.GetLanguage(),
+	classNodeTypes: map[string]struct{}{
+		"struct_specifier": {},
+		"union_specifier":  {},
+		"enum_specifier":   {},
+	},
+	classBodyNodeTypes: map[string]struct{}{
+		"field_declaration_list": {},
+		"enumerator_list":        {},
+	},
+	identifierNodeTypes: map[string]struct{}{
+		"identifier":       {},
+		"field_identifier": {},
+		"type_identifier":  {},
+	},
+	commentNodeTypes: map[string]struct{}{
+		"comment": {},
+	},
+}
+
+var cppSpec = languageSpec{
+	language: cpp.GetLanguage(),
+	functionNodeTypes: map[string]struct{}{
+		"function_definition": {},
+	},
+	classNodeTypes: map[string]struct{}{
+		"class_specifier":  {},
+		"struct_specifier": {},
+		"union_specifier":  {},
+		"enum_specifier":   {},
+	},
+	classBodyNodeTypes: map[string]struct{}{
+		"field_declaration_list": {},
+		"enumerator_list":        {},
+	},
+	identifierNodeTypes: map[string]struct{}{
+		"identifier":           {},
+		"field_identifier":     {},
+		"type_identifier":      {},
+		"namespace_identifier": {},
+	},
+	commentNodeTypes: map[string]struct{}{
+		"comment": {},
+	},
+}
+
+var languageByExtension = map[string]languageSpec{
+	".go": {
+		language: golang.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"function_declaration":           {},
-			"generator_function_declaration": {},
-			"method_definition":              {},
+			"function_declaration": {},
+			"method_declaration":   {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"type_spec": {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier":                  {},
-			"property_identifier":         {},
-			"private_property_identifier": {},
+			"identifier":       {},
+			"field_identifier": {},
+			"type_identifier":  {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"comment": {},
 		},
 	},
-	".mjs": {
-		language: javascript.GetLanguage(),
+	".py": {
+		language: python.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"function_declaration":           {},
-			"generator_function_declaration": {},
-			"method_definition":              {},
+			"function_definition": {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"class_definition": {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier":                  {},
-			"property_identifier":         {},
-			"private_property_identifier": {},
+			"identifier": {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"comment": {},
 		},
 	},
-	".cjs": {
-		language: javascript.GetLanguage(),
+	".js":  javascriptSpec,
+	".jsx": javascriptSpec,
+	".mjs": javascriptSpec,
+	".cjs": javascriptSpec,
+	".ts":  typescriptSpec(typescript.GetLanguage()),
+	".tsx": typescriptSpec(tsx.GetLanguage()),
+	".java": {
+		language: java.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"function_declaration":           {},
-			"generator_function_declaration": {},
-			"method_definition":              {},
+			"method_declaration":      {},
+			"constructor_declaration": {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"class_declaration":     {},
+			"interface_declaration": {},
+			"enum_declaration":      {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier":                  {},
-			"property_identifier":         {},
-			"private_property_identifier": {},
+			"identifier": {},
 		},
 		commentNodeTypes: map[string]struct{}{
-			"comment": {},
+			"line_comment":  {},
+			"block_comment": {},
 		},
 	},
-	".ts": {
-		language: typescript.GetLanguage(),
+	".c":   cSpec,
+	".h":   cSpec,
+	".cc":  cppSpec,
+	".cpp": cppSpec,
+	".cxx": cppSpec,
+	".hh":  cppSpec,
+	".hpp": cppSpec,
+	".hxx": cppSpec,
+	".cs": {
+		language: csharp.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"function":                       {},
-			"function_declaration":           {},
-			"generator_function_declaration": {},
-			"method_definition":              {},
+			"method_declaration":      {},
+			"constructor_declaration": {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"class_declaration":     {},
+			"interface_declaration": {},
+			"struct_declaration":    {},
+			"enum_declaration":      {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier":                  {},
-			"property_identifier":         {},
-			"private_property_identifier": {},
-			"type_identifier":             {},
+			"identifier_name": {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"comment": {},
 		},
 	},
-	".tsx": {
-		language: typescript.GetLanguage(),
+	".rb": {
+		language: ruby.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"function":                       {},
-			"function_declaration":           {},
-			"generator_function_declaration": {},
-			"method_definition":              {},
+			"method":           {},
+			"singleton_method": {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"class":  {},
+			"module": {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier":                  {},
-			"property_identifier":         {},
-			"private_property_identifier": {},
-			"type_identifier":             {},
+			"identifier": {},
+			"constant":   {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"comment": {},
 		},
 	},
-	".java": {
-		language: java.GetLanguage(),
+	".rs": {
+		language: rust.GetLanguage(),
 		functionNodeTypes: map[string]struct{}{
-			"method_declaration":      {},
-			"constructor_declaration": {},
+			"function_item": {},
+		},
+		classNodeTypes: map[string]struct{}{
+			"struct_item": {},
+			"enum_item":   {},
+			"union_item":  {},
+			"trait_item":  {},
 		},
 		identifierNodeTypes: map[string]struct{}{
-			"identifier": {},
+			"identifier":       {},
+			"field_identifier": {},
+			"type_identifier":  {},
 		},
 		commentNodeTypes: map[string]struct{}{
 			"line_comment":  {},
@@ -181,6 +273,44 @@ var languageByExtension = map[string]languageSpec{
 	},
 }
 
+// functionNameNodeTypes are the node types which name the functions.
+var functionNameNodeTypes = map[string]struct{}{
+	"identifier":                  {},
+	"field_identifier":            {},
+	"property_identifier":         {},
+	"private_property_identifier": {},
+	"identifier_name":             {},
+	"scoped_identifier":           {},
+	"qualified_identifier":        {},
+	"destructor_name":             {},
+	"operator_name":               {},
+}
+
+// classNameNodeTypes are the node types which name the classes and the other types.
+var classNameNodeTypes = map[string]struct{}{
+	"identifier":      {},
+	"type_identifier": {},
+	"identifier_name": {},
+	"constant":        {},
+}
+
+// declaratorNodeTypes wrap the names of the declarations, e.g. in C, C++ and Java.
+var declaratorNodeTypes = map[string]struct{}{
+	"function_declarator":          {},
+	"pointer_declarator":           {},
+	"reference_declarator":         {},
+	"method_header":                {},
+	"method_declarator":            {},
+	"constructor_declarator":       {},
+	"normal_interface_declaration": {},
+}
+
+// IsSupported returns whether the language of the file has a tree-sitter grammar.
+func IsSupported(path string) bool {
+	_, exists := languageByExtension[strings.ToLower(filepath.Ext(path))]
+	return exists
+}
+
 // TreeSitterExtractor implements Extractor with tree-sitter grammars.
 type TreeSitterExtractor struct{}
 
@@ -193,21 +323,29 @@ func NewTreeSitterExtractor() *TreeSitterExtractor {
 func (*TreeSitterExtractor) Extract(path string, source []byte) ([]Node, error) {
 	return extractByTypes(path, source, func(spec languageSpec) map[string]struct{} {
 		return spec.functionNodeTypes
-	}, true, true)
+	}, functionNameNodeTypes, true, true)
+}
+
+// ExtractClasses returns class-like nodes for supported languages: the classes, the structs,
+// the interfaces, the enums, the traits and the modules.
+func (*TreeSitterExtractor) ExtractClasses(path string, source []byte) ([]Node, error) {
+	return extractByTypes(path, source, func(spec languageSpec) map[string]struct{} {
+		return spec.classNodeTypes
+	}, classNameNodeTypes, true, true)
 }
 
 // ExtractIdentifiers returns identifier-like nodes for supported languages.
 func (*TreeSitterExtractor) ExtractIdentifiers(path string, source []byte) ([]Node, error) {
 	return extractByTypes(path, source, func(spec languageSpec) map[string]struct{} {
 		return spec.identifierNodeTypes
-	}, false, true)
+	}, functionNameNodeTypes, false, true)
 }
 
 // ExtractComments returns comment nodes for supported languages.
 func (*TreeSitterExtractor) ExtractComments(path string, source []byte) ([]Node, error) {
 	return extractByTypes(path, source, func(spec languageSpec) map[string]struct{} {
 		return spec.commentNodeTypes
-	}, false, false)
+	}, functionNameNodeTypes, false, false)
 }
 
 // ExtractNamedNodes returns all named syntax nodes for supported languages.
@@ -260,6 +398,7 @@ func extractByTypes(
 	path string,
 	source []byte,
 	typeSelector func(spec languageSpec) map[string]struct{},
+	nameNodeTypes map[string]struct{},
 	requireName bool,
 	namedOnlyWalk bool,
 ) ([]Node, error) {
@@ -279,20 +418,11 @@ func extractByTypes(
 			return
 		}
 		if _, ok := nodeTypes[node.Type()]; ok {
-			nameNode := node.ChildByFieldName("name")
-			if nameNode == nil || nameNode.IsNull() {
-				for i := 0; i < int(node.NamedChildCount()); i++ {
-					child := node.NamedChild(i)
-					switch child.Type() {
-					case "identifier", "field_identifier", "property_identifier",
-						"private_property_identifier":
-						nameNode = child
-					}
-					if nameNode != nil && !nameNode.IsNull() {
-						break
-					}
-				}
+			if _, isClass := spec.classNodeTypes[node.Type()]; isClass && spec.classBodyNodeTypes != nil &&
+				!hasNamedChildOfTypes(node, spec.classBodyNodeTypes) {
+				goto recurse
 			}
+			nameNode := declarationName(node, nameNodeTypes)
 			name := ""
 			if nameNode != nil && !nameNode.IsNull() {
 				name = strings.TrimSpace(nameNode.Content(source))
@@ -333,3 +463,34 @@ func extractByTypes(
 	walk(root)
 	return nodes, nil
 }
+
+// declarationName finds the node which names the declaration: the "name" field, the first child
+// of the name types or the name inside the declarators, e.g. of a C function.
+func declarationName(node *sitter.Node, nameNodeTypes map[string]struct{}) *sitter.Node {
+	if nameNode := node.ChildByFieldName("name"); nameNode != nil && !nameNode.IsNull() {
+		return nameNode
+	}
+	var declarator *sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if _, ok := nameNodeTypes[child.Type()]; ok {
+			return child
+		}
+		if _, ok := declaratorNodeTypes[child.Type()]; ok && declarator == nil {
+			declarator = child
+		}
+	}
+	if declarator != nil {
+		return declarationName(declarator, nameNodeTypes)
+	}
+	return nil
+}
+
+func hasNamedChildOfTypes(node *sitter.Node, types map[string]struct{}) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if _, ok := types[node.NamedChild(i).Type()]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -177,3 +177,76 @@ func alpha() int {
 		t.Fatalf("expected function_declaration node, got %+v", nodes)
 	}
 }
+
+func TestTreeSitterExtractorLanguages(t *testing.T) {
+	extractor := NewTreeSitterExtractor()
+	tests := []struct {
+		path      string
+		source    string
+		functions []string
+		classes   []string
+	}{
+		{"demo.go", "package demo\n\ntype T struct{}\n\nfunc (t T) Alpha() {}\n",
+			[]string{"Alpha"}, []string{"T"}},
+		{"demo.py", "class T:\n    def alpha(self):\n        pass\n",
+			[]string{"alpha"}, []string{"T"}},
+		{"demo.js", "class T {\n  alpha() {}\n}\n",
+			[]string{"alpha"}, []string{"T"}},
+		{"demo.ts", "interface I {}\nclass T {\n  alpha(): void {}\n}\n",
+			[]string{"alpha"}, []string{"I", "T"}},
+		{"demo.tsx", "class T {\n  render() { return <div/>; }\n}\n",
+			[]string{"render"}, []string{"T"}},
+		{"Demo.java", "class T {\n  T() {}\n  void alpha() {}\n}\n",
+			[]string{"T", "alpha"}, []string{"T"}},
+		{"demo.c", "struct S { int x; };\nstruct S *make(void);\nint *alpha(struct S *s) { return 0; }\n",
+			[]string{"alpha"}, []string{"S"}},
+		{"demo.cpp", "class T {\n  void alpha() {}\n};\nvoid T::beta() {}\n",
+			[]string{"alpha", "T::beta"}, []string{"T"}},
+		{"Demo.cs", "class T {\n  void Alpha() {}\n}\n",
+			[]string{"Alpha"}, []string{"T"}},
+		{"demo.rb", "module M\n  class T\n    def alpha\n    end\n  end\nend\n",
+			[]string{"alpha"}, []string{"M", "T"}},
+		{"demo.rs", "struct S {}\ntrait R {}\nfn alpha() {}\n",
+			[]string{"alpha"}, []string{"S", "R"}},
+	}
+	names := func(nodes []Node) []string {
+		result := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			result = append(result, node.Name)
+		}
+		return result
+	}
+	equal := func(a, b []string) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if a[i] != b[i] {
+				return false
+			}
+		}
+		return true
+	}
+	for _, test := range tests {
+		if !IsSupported(test.path) {
+			t.Fatalf("%s must be supported", test.path)
+		}
+		functions, err := extractor.Extract(test.path, []byte(test.source))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.path, err)
+		}
+		if !equal(names(functions), test.functions) {
+			t.Fatalf("%s: expected functions %v, got %v", test.path, test.functions, names(functions))
+		}
+		classes, err := extractor.ExtractClasses(test.path, []byte(test.source))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.path, err)
+		}
+		if !equal(names(classes), test.classes) {
+			t.Fatalf("%s: expected classes %v, got %v", test.path, test.classes, names(classes))
+		}
+	}
+	if IsSupported("README.md") {
+		t.Fatal("README.md must not be supported")
+	}
+}
@@ -2,6 +2,7 @@ package plumbing
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -79,13 +80,24 @@ func (b *CachedBlob) CountLines() (int, error) {
 // the same blobs twice. Outdated objects are removed so "blobCache" never grows big.
 type BlobCache struct {
 	core.NoopMerger
+	// the blobs of the previous commit are only a cache, they are read again after Restore()
+	core.NoopCheckpointer
 	// Specifies how to handle the situation when we encounter a git submodule - an object
 	// without the blob. If true, we look inside .gitmodules and if we don't find it,
 	// raise an error. If false, we do not look inside .gitmodules and always succeed.
 	FailOnMissingSubmodules bool
+	// PromisorBatchSize is the maximum number of the missing blobs of a partial clone which
+	// are fetched from the promisor remote at once.
+	PromisorBatchSize int
+	// PromisorConcurrency is the maximum number of the simultaneous fetches from the promisor remote.
+	PromisorConcurrency int
 
 	repository *git.Repository
 	cache      map[plumbing.Hash]*CachedBlob
+	// promisor is nil unless the repository is a partial clone.
+	promisor *PromisorFetcher
+	// fetched are the blobs which were fetched from the promisor remote for the current commit.
+	fetched map[plumbing.Hash]*object.Blob
 
 	l core.Logger
 }
@@ -94,6 +106,16 @@ const (
 	// ConfigBlobCacheFailOnMissingSubmodules is the name of the configuration option for
 	// BlobCache.Configure() to check if the referenced submodules are registered in .gitignore.
 	ConfigBlobCacheFailOnMissingSubmodules = "BlobCache.FailOnMissingSubmodules"
+	// ConfigBlobCachePromisorBatchSize is the name of the configuration option for
+	// BlobCache.Configure() to set the number of the blobs fetched from the promisor remote at once.
+	ConfigBlobCachePromisorBatchSize = "BlobCache.PromisorBatchSize"
+	// ConfigBlobCachePromisorConcurrency is the name of the configuration option for
+	// BlobCache.Configure() to limit the simultaneous fetches from the promisor remote.
+	ConfigBlobCachePromisorConcurrency = "BlobCache.PromisorConcurrency"
+	// DefaultBlobCachePromisorBatchSize is the default value of BlobCache.PromisorBatchSize.
+	DefaultBlobCachePromisorBatchSize = 256
+	// DefaultBlobCachePromisorConcurrency is the default value of BlobCache.PromisorConcurrency.
+	DefaultBlobCachePromisorConcurrency = 4
 	// DependencyBlobCache identifies the dependency provided by BlobCache.
 	DependencyBlobCache = "blob_cache"
 )
@@ -117,6 +139,11 @@ func (blobCache *BlobCache) Requires() []string {
 	return []string{DependencyTreeChanges}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (blobCache *BlobCache) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (blobCache *BlobCache) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{{
@@ -127,6 +154,19 @@ func (blobCache *BlobCache) ListConfigurationOptions() []core.ConfigurationOptio
 		Flag:    "fail-on-missing-submodules",
 		Type:    core.BoolConfigurationOption,
 		Default: false,
+	}, {
+		Name: ConfigBlobCachePromisorBatchSize,
+		Description: "Maximum number of the missing blobs of a partial clone (--filter=blob:none) " +
+			"which are fetched from the promisor remote at once.",
+		Flag:    "promisor-batch-size",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultBlobCachePromisorBatchSize,
+	}, {
+		Name:        ConfigBlobCachePromisorConcurrency,
+		Description: "Maximum number of the simultaneous fetches from the promisor remote of a partial clone.",
+		Flag:        "promisor-concurrency",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultBlobCachePromisorConcurrency,
 	}}
 	return options[:]
 }
@@ -141,6 +181,12 @@ func (blobCache *BlobCache) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigBlobCacheFailOnMissingSubmodules].(bool); exists {
 		blobCache.FailOnMissingSubmodules = val
 	}
+	if val, exists := facts[ConfigBlobCachePromisorBatchSize].(int); exists {
+		blobCache.PromisorBatchSize = val
+	}
+	if val, exists := facts[ConfigBlobCachePromisorConcurrency].(int); exists {
+		blobCache.PromisorConcurrency = val
+	}
 	return nil
 }
 
@@ -154,6 +200,19 @@ func (blobCache *BlobCache) Initialize(repository *git.Repository) error {
 	blobCache.l = core.NewLogger()
 	blobCache.repository = repository
 	blobCache.cache = map[plumbing.Hash]*CachedBlob{}
+	if blobCache.PromisorBatchSize < 0 {
+		return errors.Errorf("--promisor-batch-size cannot be negative (got %d)", blobCache.PromisorBatchSize)
+	}
+	if blobCache.PromisorConcurrency < 0 {
+		return errors.Errorf("--promisor-concurrency cannot be negative (got %d)",
+			blobCache.PromisorConcurrency)
+	}
+	blobCache.promisor = NewPromisorFetcher(
+		repository, blobCache.PromisorBatchSize, blobCache.PromisorConcurrency)
+	if blobCache.promisor != nil {
+		blobCache.l.Infof("partial clone detected, the missing blobs are fetched from %s",
+			blobCache.promisor.Remote())
+	}
 	return nil
 }
 
@@ -166,9 +225,19 @@ func (blobCache *BlobCache) Initialize(repository *git.Repository) error {
 func (blobCache *BlobCache) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
 	changes := deps[DependencyTreeChanges].(object.Changes)
+	ctx := core.ContextFromDeps(deps)
 	cache := map[plumbing.Hash]*CachedBlob{}
 	newCache := map[plumbing.Hash]*CachedBlob{}
+	if blobCache.promisor != nil {
+		if err := blobCache.fetchMissing(ctx, changes); err != nil {
+			return nil, err
+		}
+		defer func() { blobCache.fetched = nil }()
+	}
 	for _, change := range changes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		action, err := change.Action()
 		if err != nil {
 			blobCache.l.Errorf("no action in %s\n", change.To.TreeEntry.Hash)
@@ -268,13 +337,47 @@ func (blobCache *BlobCache) Fork(n int) []core.PipelineItem {
 		}
 		caches[i] = &BlobCache{
 			FailOnMissingSubmodules: blobCache.FailOnMissingSubmodules,
+			PromisorBatchSize:       blobCache.PromisorBatchSize,
+			PromisorConcurrency:     blobCache.PromisorConcurrency,
 			repository:              blobCache.repository,
 			cache:                   cache,
+			promisor:                blobCache.promisor,
 		}
 	}
 	return caches
 }
 
+// fetchMissing fetches the blobs of the changes which are absent in the partial clone
+// in one go instead of failing on each of them.
+func (blobCache *BlobCache) fetchMissing(ctx context.Context, changes object.Changes) error {
+	var missing []plumbing.Hash
+	seen := map[plumbing.Hash]bool{}
+	for _, change := range changes {
+		for _, entry := range [...]object.ChangeEntry{change.From, change.To} {
+			hash := entry.TreeEntry.Hash
+			if hash.IsZero() || entry.TreeEntry.Mode == 0o160000 || seen[hash] {
+				continue
+			}
+			seen[hash] = true
+			if _, cached := blobCache.cache[hash]; cached {
+				continue
+			}
+			if blobCache.repository.Storer.HasEncodedObject(hash) == plumbing.ErrObjectNotFound {
+				missing = append(missing, hash)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	blobs, err := blobCache.promisor.Fetch(ctx, missing)
+	if err != nil {
+		return err
+	}
+	blobCache.fetched = blobs
+	return nil
+}
+
 // FileGetter defines a function which loads the Git file by
 // the specified path. The state can be arbitrary though here it always
 // corresponds to the currently processed commit.
@@ -284,6 +387,9 @@ type FileGetter func(path string) (*object.File, error)
 func (blobCache *BlobCache) getBlob(entry *object.ChangeEntry, fileGetter FileGetter) (
 	*object.Blob, error,
 ) {
+	if blob, exists := blobCache.fetched[entry.TreeEntry.Hash]; exists {
+		return blob, nil
+	}
 	blob, err := blobCache.repository.BlobObject(entry.TreeEntry.Hash)
 	if err != nil {
 		if err.Error() != plumbing.ErrObjectNotFound.Error() {
@@ -1,6 +1,7 @@
 package plumbing
 
 import (
+	"context"
 	"testing"
 
 	"github.com/go-git/go-git/v5/plumbing"
@@ -38,6 +39,15 @@ func TestBlobCacheConfigureInitialize(t *testing.T) {
 	facts = map[string]interface{}{}
 	cache.Configure(facts)
 	assert.True(t, cache.FailOnMissingSubmodules)
+	facts[ConfigBlobCachePromisorBatchSize] = 10
+	facts[ConfigBlobCachePromisorConcurrency] = 2
+	cache.Configure(facts)
+	assert.Equal(t, 10, cache.PromisorBatchSize)
+	assert.Equal(t, 2, cache.PromisorConcurrency)
+	assert.NoError(t, cache.Initialize(test.Repository))
+	assert.Nil(t, cache.promisor)
+	cache.PromisorBatchSize = -1
+	assert.Error(t, cache.Initialize(test.Repository))
 }
 
 func TestBlobCacheMetadata(t *testing.T) {
@@ -49,8 +59,10 @@ func TestBlobCacheMetadata(t *testing.T) {
 	changes := &TreeDiff{}
 	assert.Equal(t, cache.Requires()[0], changes.Provides()[0])
 	opts := cache.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, opts[0].Name, ConfigBlobCacheFailOnMissingSubmodules)
+	assert.Equal(t, opts[1].Name, ConfigBlobCachePromisorBatchSize)
+	assert.Equal(t, opts[2].Name, ConfigBlobCachePromisorConcurrency)
 }
 
 func TestBlobCacheRegistration(t *testing.T) {
@@ -105,6 +117,33 @@ func TestBlobCacheConsumeModification(t *testing.T) {
 	assert.Equal(t, blobTo.Size, int64(9481))
 }
 
+func TestBlobCacheConsumeCancelled(t *testing.T) {
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"af2d8db70f287b52d2428d9887a69a10bc4d1f46"))
+	treeTo, _ := test.Repository.TreeObject(plumbing.NewHash(
+		"63076fa0dfd93e94b6d2ef0fc8b1fdf9092f83c4"))
+	changes := object.Changes{&object.Change{To: object.ChangeEntry{
+		Name: "labours.py",
+		Tree: treeTo,
+		TreeEntry: object.TreeEntry{
+			Name: "labours.py",
+			Mode: 0o100644,
+			Hash: plumbing.NewHash("c872b8d2291a5224e2c9f6edd7f46039b96b4742"),
+		},
+	}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	deps := map[string]interface{}{}
+	deps[core.DependencyCommit] = commit
+	deps[core.DependencyContext] = ctx
+	deps[DependencyTreeChanges] = changes
+	cache := fixtureBlobCache()
+	result, err := cache.Consume(deps)
+	assert.Nil(t, result)
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, cache.cache)
+}
+
 func TestBlobCacheConsumeInsertionDeletion(t *testing.T) {
 	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
 		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
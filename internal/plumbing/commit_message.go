@@ -0,0 +1,206 @@
+package plumbing
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/pkg/errors"
+)
+
+// CommitMessageParser extracts the metadata from the commit messages: the Conventional Commits
+// type, scope and breaking change marker, the referenced issue tracker keys and whether
+// the commit reverts another one. The leaves can use it to break down the churn and
+// the efforts by the change type and by the linked ticket.
+// CommitMessageParser is a PipelineItem.
+type CommitMessageParser struct {
+	core.NoopMerger
+	// IssuePattern matches the issue tracker keys in the messages. The first capturing group,
+	// if any, is the key, otherwise the whole match.
+	IssuePattern *regexp.Regexp
+
+	l core.Logger
+}
+
+// CommitMessage is the metadata of a commit message, see CommitMessageParser.
+type CommitMessage struct {
+	// Type is the lowercase Conventional Commits type, e.g. "feat" or "fix". It is empty
+	// if the message does not follow the convention.
+	Type string
+	// Scope is the optional Conventional Commits scope, e.g. "parser" in "fix(parser): ...".
+	Scope string
+	// Breaking indicates a breaking change: "feat!: ..." or "BREAKING CHANGE:" in the body.
+	Breaking bool
+	// Issues are the unique issue tracker keys in the order of appearance, e.g. "JIRA-123" or "#456".
+	Issues []string
+	// Revert indicates that the commit reverts another one.
+	Revert bool
+	// RevertedHash is the hash of the reverted commit if the message mentions it.
+	RevertedHash string
+}
+
+const (
+	// DependencyCommitMessage is the name of the dependency provided by CommitMessageParser.
+	// It is *CommitMessage.
+	DependencyCommitMessage = "commit_message"
+	// ConfigCommitMessageIssuePattern is the name of the configuration option
+	// (CommitMessageParser.Configure()) which sets the regular expression of the issue keys.
+	ConfigCommitMessageIssuePattern = "CommitMessageParser.IssuePattern"
+	// DefaultCommitMessageIssuePattern matches the JIRA-like keys and the GitHub-like "#123" references.
+	DefaultCommitMessageIssuePattern = `\b[A-Z][A-Z0-9_]+-[1-9][0-9]*\b|(?:^|[^\w&/])(#[1-9][0-9]*)\b`
+)
+
+// conventionalCommitTypes are the types recognized in the message headers. Other words
+// followed by a colon, such as "Note:" or "Merge:", do not make the message conventional.
+var conventionalCommitTypes = map[string]bool{
+	"build": true, "chore": true, "ci": true, "docs": true, "feat": true, "fix": true,
+	"perf": true, "refactor": true, "revert": true, "style": true, "test": true,
+}
+
+// notIssueKeys are the prefixes which look like the issue keys but denote the standards,
+// e.g. "UTF-8" or "SHA-256".
+var notIssueKeys = map[string]bool{
+	"CVE": true, "ISO": true, "SHA": true, "UTF": true,
+}
+
+var (
+	conventionalHeaderRe = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?:\s`)
+	revertedHashRe       = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-f]{7,40})\b`)
+)
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (parser *CommitMessageParser) Name() string {
+	return "CommitMessageParser"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (parser *CommitMessageParser) Provides() []string {
+	return []string{DependencyCommitMessage}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (parser *CommitMessageParser) Requires() []string {
+	return []string{}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (parser *CommitMessageParser) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigCommitMessageIssuePattern,
+		Description: "Regular expression of the issue tracker keys in the commit messages. " +
+			"The first capturing group, if any, is the key.",
+		Flag:    "issue-pattern",
+		Type:    core.StringConfigurationOption,
+		Default: DefaultCommitMessageIssuePattern,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (parser *CommitMessageParser) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		parser.l = l
+	}
+	if val, exists := facts[ConfigCommitMessageIssuePattern].(string); exists && val != "" {
+		pattern, err := regexp.Compile(val)
+		if err != nil {
+			return errors.Wrapf(err, "invalid issue pattern %q", val)
+		}
+		parser.IssuePattern = pattern
+	}
+	return nil
+}
+
+func (*CommitMessageParser) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (parser *CommitMessageParser) Initialize(repository *git.Repository) error {
+	parser.l = core.NewLogger()
+	if parser.IssuePattern == nil {
+		parser.IssuePattern = regexp.MustCompile(DefaultCommitMessageIssuePattern)
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (parser *CommitMessageParser) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	return map[string]interface{}{DependencyCommitMessage: parser.Parse(commit.Message)}, nil
+}
+
+// Fork clones this PipelineItem.
+func (parser *CommitMessageParser) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(parser, n)
+}
+
+// Parse extracts the metadata from the commit message.
+func (parser *CommitMessageParser) Parse(message string) *CommitMessage {
+	result := &CommitMessage{}
+	header := message
+	if newline := strings.IndexByte(message, '\n'); newline >= 0 {
+		header = message[:newline]
+	}
+	header = strings.TrimSpace(header)
+	if match := conventionalHeaderRe.FindStringSubmatch(header); match != nil {
+		if commitType := strings.ToLower(match[1]); conventionalCommitTypes[commitType] {
+			result.Type = commitType
+			result.Scope = strings.TrimSpace(match[2])
+			result.Breaking = match[3] != ""
+		}
+	}
+	if strings.Contains(message, "BREAKING CHANGE:") || strings.Contains(message, "BREAKING-CHANGE:") {
+		result.Breaking = true
+	}
+	result.Revert = result.Type == "revert" || strings.HasPrefix(header, "Revert ")
+	if match := revertedHashRe.FindStringSubmatch(message); match != nil {
+		result.Revert = true
+		result.RevertedHash = match[1]
+	}
+	result.Issues = parser.findIssues(message)
+	return result
+}
+
+// findIssues returns the unique issue keys in the order of appearance.
+func (parser *CommitMessageParser) findIssues(message string) []string {
+	pattern := parser.IssuePattern
+	if pattern == nil {
+		pattern = regexp.MustCompile(DefaultCommitMessageIssuePattern)
+	}
+	var issues []string
+	seen := map[string]bool{}
+	for _, match := range pattern.FindAllStringSubmatch(message, -1) {
+		key := match[0]
+		for _, group := range match[1:] {
+			if group != "" {
+				key = group
+				break
+			}
+		}
+		if dash := strings.IndexByte(key, '-'); dash > 0 && notIssueKeys[key[:dash]] {
+			continue
+		}
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		issues = append(issues, key)
+	}
+	return issues
+}
+
+func init() {
+	core.Registry.Register(&CommitMessageParser{})
+}
@@ -0,0 +1,73 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitMessageParserMeta(t *testing.T) {
+	parser := &CommitMessageParser{}
+	assert.Equal(t, "CommitMessageParser", parser.Name())
+	assert.Equal(t, []string{DependencyCommitMessage}, parser.Provides())
+	assert.Len(t, parser.Requires(), 0)
+	opts := parser.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigCommitMessageIssuePattern, opts[0].Name)
+	assert.Equal(t, "issue-pattern", opts[0].Flag)
+	logger := core.NewLogger()
+	assert.NoError(t, parser.Configure(map[string]interface{}{
+		core.ConfigLogger:               logger,
+		ConfigCommitMessageIssuePattern: `\bPROJ-\d+\b`,
+	}))
+	assert.Equal(t, logger, parser.l)
+	assert.Equal(t, `\bPROJ-\d+\b`, parser.IssuePattern.String())
+	assert.Error(t, parser.Configure(map[string]interface{}{
+		ConfigCommitMessageIssuePattern: `(`,
+	}))
+	assert.NoError(t, (&CommitMessageParser{}).Initialize(nil))
+}
+
+func TestCommitMessageParserRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommitMessageParser{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "CommitMessageParser", summoned[0].Name())
+	summoned = core.Registry.Summon(DependencyCommitMessage)
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "CommitMessageParser", summoned[0].Name())
+}
+
+func TestCommitMessageParserParse(t *testing.T) {
+	parser := &CommitMessageParser{}
+	require.NoError(t, parser.Initialize(nil))
+	assert.Equal(t, &CommitMessage{Type: "feat", Scope: "parser", Issues: []string{"HERC-12", "#45"}},
+		parser.Parse("feat(parser): support UTF-8 and SHA-256\n\nCloses HERC-12, #45 and HERC-12 again."))
+	assert.Equal(t, &CommitMessage{Type: "fix", Breaking: true},
+		parser.Parse("Fix!: drop the old flag"))
+	assert.Equal(t, &CommitMessage{Type: "chore", Breaking: true},
+		parser.Parse("chore: bump\n\nBREAKING CHANGE: Go 1.18 is required"))
+	assert.Equal(t, &CommitMessage{}, parser.Parse("Note: this is not conventional"))
+	assert.Equal(t, &CommitMessage{}, parser.Parse("Merge pull request from a&#39;b see x/#1"))
+	assert.Equal(t, &CommitMessage{Issues: []string{"#7"}}, parser.Parse("Update the docs (#7)"))
+	assert.Equal(t, &CommitMessage{
+		Revert: true, RevertedHash: "0123456789abcdef0123456789abcdef01234567",
+	}, parser.Parse("Revert \"Add the cache\"\n\n"+
+		"This reverts commit 0123456789abcdef0123456789abcdef01234567.\n"))
+	assert.Equal(t, &CommitMessage{Type: "revert", Revert: true},
+		parser.Parse("revert: add the cache"))
+}
+
+func TestCommitMessageParserConsume(t *testing.T) {
+	parser := &CommitMessageParser{}
+	require.NoError(t, parser.Initialize(nil))
+	result, err := parser.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{Message: "docs: describe ABC-1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &CommitMessage{Type: "docs", Issues: []string{"ABC-1"}},
+		result[DependencyCommitMessage])
+	assert.Len(t, parser.Fork(2), 2)
+}
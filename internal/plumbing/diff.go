@@ -18,10 +18,15 @@ import (
 // It is a PipelineItem.
 type FileDiff struct {
 	core.NoopMerger
+	core.NoopCheckpointer
 	CleanupDisabled  bool
 	WhitespaceIgnore bool
 	RefineDisabled   bool
 	Timeout          time.Duration
+	// Algorithm is the line diff algorithm, one of DiffAlgorithms.
+	Algorithm string
+	// WordLevel enables the calculation of FileDiffData.WordDiffs.
+	WordLevel bool
 
 	l core.Logger
 }
@@ -46,6 +51,14 @@ const (
 	// ConfigFileDiffDisableRefine disables tree-sitter-based post-processing
 	// which tweaks ambiguous insert/equal boundaries for better structural alignment.
 	ConfigFileDiffDisableRefine = "FileDiff.NoRefine"
+
+	// ConfigFileDiffAlgorithm is the name of the configuration option (FileDiff.Configure())
+	// to choose the line diff algorithm, see DiffAlgorithms.
+	ConfigFileDiffAlgorithm = "FileDiff.Algorithm"
+
+	// ConfigFileDiffWordLevel is the name of the configuration option (FileDiff.Configure())
+	// to additionally diff the words of the changed lines, see FileDiffData.WordDiffs.
+	ConfigFileDiffWordLevel = "FileDiff.WordLevel"
 )
 
 // FileDiffData is the type of the dependency provided by FileDiff.
@@ -53,6 +66,10 @@ type FileDiffData struct {
 	OldLinesOfCode int
 	NewLinesOfCode int
 	Diffs          []diffmatchpatch.Diff
+	// WordDiffs are the word-level diffs of the hunks - the consecutive deletions and insertions
+	// in Diffs - keyed by the index of the first edit of the hunk. Unlike Diffs, they contain
+	// the text. They are calculated only if FileDiff.WordLevel is set.
+	WordDiffs map[int][]diffmatchpatch.Diff
 }
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -74,6 +91,11 @@ func (diff *FileDiff) Requires() []string {
 	return []string{DependencyTreeChanges, DependencyBlobCache}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (diff *FileDiff) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (diff *FileDiff) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{
@@ -105,6 +127,22 @@ func (diff *FileDiff) ListConfigurationOptions() []core.ConfigurationOption {
 			Type:        core.BoolConfigurationOption,
 			Default:     false,
 		},
+		{
+			Name: ConfigFileDiffAlgorithm,
+			Description: "Line diff algorithm: " + strings.Join(DiffAlgorithms, ", ") +
+				". Patience and histogram anchor the diff on the rare lines like git does.",
+			Flag:    "diff-algorithm",
+			Type:    core.StringConfigurationOption,
+			Default: DiffAlgorithmMyers,
+		},
+		{
+			Name: ConfigFileDiffWordLevel,
+			Description: "Additionally diff the words of the changed lines so that the analyses " +
+				"can tell the formatting-only changes.",
+			Flag:    "diff-words",
+			Type:    core.BoolConfigurationOption,
+			Default: false,
+		},
 	}
 
 	return options[:]
@@ -130,6 +168,15 @@ func (diff *FileDiff) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigFileDiffDisableRefine].(bool); exists {
 		diff.RefineDisabled = val
 	}
+	if val, exists := facts[ConfigFileDiffAlgorithm].(string); exists {
+		if err := validateDiffAlgorithm(val); err != nil {
+			return err
+		}
+		diff.Algorithm = val
+	}
+	if val, exists := facts[ConfigFileDiffWordLevel].(bool); exists {
+		diff.WordLevel = val
+	}
 	return nil
 }
 
@@ -188,8 +235,8 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 			strFrom, strTo := string(blobFrom.Data), string(blobTo.Data)
 			dmp := diffmatchpatch.New()
 			dmp.DiffTimeout = diff.Timeout
-			src, dst, _ := dmp.DiffLinesToRunes(stripWhitespace(strFrom, diff.WhitespaceIgnore), stripWhitespace(strTo, diff.WhitespaceIgnore))
-			diffs := dmp.DiffMainRunes(src, dst, false)
+			src, dst, lines := dmp.DiffLinesToRunes(stripWhitespace(strFrom, diff.WhitespaceIgnore), stripWhitespace(strTo, diff.WhitespaceIgnore))
+			diffs := diffLineRunes(dmp, diff.Algorithm, src, dst)
 			if !diff.CleanupDisabled {
 				diffs = dmp.DiffCleanupMerge(dmp.DiffCleanupSemanticLossless(diffs))
 			}
@@ -201,6 +248,9 @@ func (diff *FileDiff) Consume(deps map[string]interface{}) (map[string]interface
 			if !diff.RefineDisabled {
 				fileDiffData = diff.refineWithTreeSitter(change.To.Name, blobTo.Data, fileDiffData)
 			}
+			if diff.WordLevel {
+				fileDiffData.WordDiffs = calculateWordDiffs(dmp, fileDiffData.Diffs, lines)
+			}
 			result[change.To.Name] = fileDiffData
 		default:
 			continue
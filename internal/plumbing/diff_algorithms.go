@@ -0,0 +1,251 @@
+package plumbing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// DiffAlgorithmMyers is the default diff algorithm of diffmatchpatch.
+	DiffAlgorithmMyers = "myers"
+	// DiffAlgorithmPatience anchors the diff on the lines which are unique in both files.
+	DiffAlgorithmPatience = "patience"
+	// DiffAlgorithmHistogram anchors the diff on the least frequent common lines, like git.
+	DiffAlgorithmHistogram = "histogram"
+
+	// histogramMaxOccurrences is the number of occurrences of a line after which the histogram
+	// diff does not consider it as an anchor, the same as in git.
+	histogramMaxOccurrences = 64
+)
+
+// DiffAlgorithms are the supported values of FileDiff.Algorithm.
+var DiffAlgorithms = []string{DiffAlgorithmMyers, DiffAlgorithmPatience, DiffAlgorithmHistogram}
+
+// validateDiffAlgorithm returns an error if the diff algorithm is not supported.
+func validateDiffAlgorithm(algorithm string) error {
+	for _, known := range DiffAlgorithms {
+		if algorithm == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown diff algorithm %q, must be one of %s",
+		algorithm, strings.Join(DiffAlgorithms, ", "))
+}
+
+// diffLineRunes calculates the diff of the lines encoded as runes by DiffLinesToRunes()
+// with the specified algorithm.
+func diffLineRunes(
+	dmp *diffmatchpatch.DiffMatchPatch, algorithm string, src, dst []rune,
+) []diffmatchpatch.Diff {
+	var anchors func(src, dst []rune) [][2]int
+	switch algorithm {
+	case DiffAlgorithmPatience:
+		anchors = patienceAnchors
+	case DiffAlgorithmHistogram:
+		anchors = histogramAnchors
+	default:
+		return dmp.DiffMainRunes(src, dst, false)
+	}
+	return mergeAdjacentDiffs(anchoredDiff(dmp, src, dst, anchors))
+}
+
+// anchoredDiff strips the common prefix and suffix, splits the rest by the equal lines which
+// the anchors function finds and recursively diffs the gaps between them. The gaps without
+// anchors are diffed with Myers.
+func anchoredDiff(
+	dmp *diffmatchpatch.DiffMatchPatch, src, dst []rune, anchors func(src, dst []rune) [][2]int,
+) []diffmatchpatch.Diff {
+	prefix := 0
+	for prefix < len(src) && prefix < len(dst) && src[prefix] == dst[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(src)-prefix && suffix < len(dst)-prefix &&
+		src[len(src)-1-suffix] == dst[len(dst)-1-suffix] {
+		suffix++
+	}
+	var diffs []diffmatchpatch.Diff
+	if prefix > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffEqual, Text: string(src[:prefix])})
+	}
+	srcMiddle, dstMiddle := src[prefix:len(src)-suffix], dst[prefix:len(dst)-suffix]
+	switch {
+	case len(srcMiddle) == 0 && len(dstMiddle) == 0:
+	case len(srcMiddle) == 0:
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: string(dstMiddle)})
+	case len(dstMiddle) == 0:
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: string(srcMiddle)})
+	default:
+		matches := anchors(srcMiddle, dstMiddle)
+		if len(matches) == 0 {
+			diffs = append(diffs, dmp.DiffMainRunes(srcMiddle, dstMiddle, false)...)
+			break
+		}
+		srcPos, dstPos := 0, 0
+		for _, match := range matches {
+			diffs = append(diffs, anchoredDiff(
+				dmp, srcMiddle[srcPos:match[0]], dstMiddle[dstPos:match[1]], anchors)...)
+			diffs = append(diffs, diffmatchpatch.Diff{
+				Type: diffmatchpatch.DiffEqual, Text: string(srcMiddle[match[0]]),
+			})
+			srcPos, dstPos = match[0]+1, match[1]+1
+		}
+		diffs = append(diffs, anchoredDiff(dmp, srcMiddle[srcPos:], dstMiddle[dstPos:], anchors)...)
+	}
+	if suffix > 0 {
+		diffs = append(diffs, diffmatchpatch.Diff{
+			Type: diffmatchpatch.DiffEqual, Text: string(src[len(src)-suffix:]),
+		})
+	}
+	return diffs
+}
+
+// patienceAnchors finds the longest increasing sequence of the lines which occur exactly once
+// in both src and dst. It returns the pairs of the positions in src and dst.
+func patienceAnchors(src, dst []rune) [][2]int {
+	type occurrence struct {
+		count    int
+		position int
+	}
+	srcLines := map[rune]*occurrence{}
+	for i, line := range src {
+		if entry, exists := srcLines[line]; exists {
+			entry.count++
+		} else {
+			srcLines[line] = &occurrence{1, i}
+		}
+	}
+	dstLines := map[rune]*occurrence{}
+	for j, line := range dst {
+		if entry, exists := dstLines[line]; exists {
+			entry.count++
+		} else {
+			dstLines[line] = &occurrence{1, j}
+		}
+	}
+	// the unique common lines in the order of dst
+	var unique [][2]int
+	for j, line := range dst {
+		srcEntry, dstEntry := srcLines[line], dstLines[line]
+		if srcEntry != nil && srcEntry.count == 1 && dstEntry.count == 1 {
+			unique = append(unique, [2]int{srcEntry.position, j})
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+	// patience sorting by the position in src: tails[k] is the index in unique of the smallest
+	// tail of the increasing sequences of length k+1
+	tails := make([]int, 0, len(unique))
+	previous := make([]int, len(unique))
+	for i, pair := range unique {
+		k := sort.Search(len(tails), func(k int) bool { return unique[tails[k]][0] >= pair[0] })
+		if k > 0 {
+			previous[i] = tails[k-1]
+		} else {
+			previous[i] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+	anchors := make([][2]int, len(tails))
+	for i, k := tails[len(tails)-1], len(tails)-1; k >= 0; i, k = previous[i], k-1 {
+		anchors[k] = unique[i]
+	}
+	return anchors
+}
+
+// histogramAnchors finds the longest common region which contains the least frequent line
+// in src, the same way as the histogram diff in git. The lines which occur more than
+// histogramMaxOccurrences times are not considered.
+func histogramAnchors(src, dst []rune) [][2]int {
+	positions := map[rune][]int{}
+	for i, line := range src {
+		positions[line] = append(positions[line], i)
+	}
+	bestLength, bestCount, bestSrc, bestDst := 0, histogramMaxOccurrences, 0, 0
+	for j := 0; j < len(dst); {
+		occurrences := positions[dst[j]]
+		if len(occurrences) == 0 || len(occurrences) > bestCount {
+			j++
+			continue
+		}
+		next := j + 1
+		for _, i := range occurrences {
+			start, dstStart := i, j
+			for start > 0 && dstStart > 0 && src[start-1] == dst[dstStart-1] {
+				start--
+				dstStart--
+			}
+			end, dstEnd := i+1, j+1
+			for end < len(src) && dstEnd < len(dst) && src[end] == dst[dstEnd] {
+				end++
+				dstEnd++
+			}
+			count := len(occurrences)
+			for k := start; k < end; k++ {
+				if c := len(positions[src[k]]); c < count {
+					count = c
+				}
+			}
+			if count < bestCount || (count == bestCount && end-start > bestLength) {
+				bestLength, bestCount, bestSrc, bestDst = end-start, count, start, dstStart
+			}
+			if dstEnd > next {
+				next = dstEnd
+			}
+		}
+		j = next
+	}
+	if bestLength == 0 {
+		return nil
+	}
+	anchors := make([][2]int, bestLength)
+	for k := range anchors {
+		anchors[k] = [2]int{bestSrc + k, bestDst + k}
+	}
+	return anchors
+}
+
+// mergeAdjacentDiffs joins the consecutive edits of the same type and puts the deletions before
+// the insertions between the equal parts.
+func mergeAdjacentDiffs(diffs []diffmatchpatch.Diff) []diffmatchpatch.Diff {
+	merged := make([]diffmatchpatch.Diff, 0, len(diffs))
+	var deleted, inserted strings.Builder
+	flush := func() {
+		if deleted.Len() > 0 {
+			merged = append(merged, diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: deleted.String()})
+			deleted.Reset()
+		}
+		if inserted.Len() > 0 {
+			merged = append(merged, diffmatchpatch.Diff{Type: diffmatchpatch.DiffInsert, Text: inserted.String()})
+			inserted.Reset()
+		}
+	}
+	for _, edit := range diffs {
+		if edit.Text == "" {
+			continue
+		}
+		switch edit.Type {
+		case diffmatchpatch.DiffDelete:
+			deleted.WriteString(edit.Text)
+		case diffmatchpatch.DiffInsert:
+			inserted.WriteString(edit.Text)
+		default:
+			flush()
+			if len(merged) > 0 && merged[len(merged)-1].Type == diffmatchpatch.DiffEqual {
+				merged[len(merged)-1].Text += edit.Text
+			} else {
+				merged = append(merged, edit)
+			}
+		}
+	}
+	flush()
+	return merged
+}
@@ -0,0 +1,110 @@
+package plumbing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+)
+
+// lineDiff diffs the texts with the algorithm and returns the hydrated edits.
+func lineDiff(algorithm, before, after string) []diffmatchpatch.Diff {
+	dmp := diffmatchpatch.New()
+	src, dst, lines := dmp.DiffLinesToRunes(before, after)
+	return dmp.DiffCharsToLines(diffLineRunes(dmp, algorithm, src, dst), lines)
+}
+
+// applyDiff restores the old and the new texts from the edits.
+func applyDiff(diffs []diffmatchpatch.Diff) (string, string) {
+	var before, after strings.Builder
+	for _, edit := range diffs {
+		if edit.Type != diffmatchpatch.DiffInsert {
+			before.WriteString(edit.Text)
+		}
+		if edit.Type != diffmatchpatch.DiffDelete {
+			after.WriteString(edit.Text)
+		}
+	}
+	return before.String(), after.String()
+}
+
+func TestValidateDiffAlgorithm(t *testing.T) {
+	for _, algorithm := range DiffAlgorithms {
+		assert.NoError(t, validateDiffAlgorithm(algorithm))
+	}
+	assert.Error(t, validateDiffAlgorithm("minimal"))
+	assert.Error(t, validateDiffAlgorithm(""))
+}
+
+func TestDiffAlgorithmsRoundTrip(t *testing.T) {
+	cases := [][2]string{
+		{"", ""},
+		{"", "a\nb\n"},
+		{"a\nb\n", ""},
+		{"a\nb\nc\n", "a\nb\nc\n"},
+		{"a\nb\nc\nd\n", "a\nx\nc\ny\n"},
+		{"}\n}\n}\na\n}\nb\n}\n", "}\nb\n}\n}\na\n}\n"},
+		{"x\nx\nx\n", "x\ny\nx\nx\nx\n"},
+	}
+	for _, algorithm := range DiffAlgorithms {
+		for _, c := range cases {
+			diffs := lineDiff(algorithm, c[0], c[1])
+			before, after := applyDiff(diffs)
+			assert.Equal(t, c[0], before, algorithm)
+			assert.Equal(t, c[1], after, algorithm)
+			for i := 1; i < len(diffs); i++ {
+				if algorithm != DiffAlgorithmMyers {
+					assert.NotEqual(t, diffs[i-1].Type, diffs[i].Type, algorithm)
+				}
+			}
+		}
+	}
+}
+
+func TestPatienceDiffAnchorsUniqueLines(t *testing.T) {
+	// the functions are swapped; patience keeps the unique lines of one function together
+	before := "func a() {\n\treturn 1\n}\n\nfunc b() {\n\treturn 2\n}\n"
+	after := "func b() {\n\treturn 2\n}\n\nfunc a() {\n\treturn 1\n}\n"
+	diffs := lineDiff(DiffAlgorithmPatience, before, after)
+	assert.Equal(t, []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffInsert, Text: "func b() {\n\treturn 2\n}\n\n"},
+		{Type: diffmatchpatch.DiffEqual, Text: "func a() {\n\treturn 1\n"},
+		{Type: diffmatchpatch.DiffDelete, Text: "}\n\nfunc b() {\n\treturn 2\n"},
+		{Type: diffmatchpatch.DiffEqual, Text: "}\n"},
+	}, diffs)
+}
+
+func TestPatienceAnchors(t *testing.T) {
+	// "a" "c" and "a" "b" are equally long, the first found wins
+	assert.Equal(t, [][2]int{{0, 1}, {1, 3}}, patienceAnchors([]rune("abc"), []rune("xacb")))
+	assert.Nil(t, patienceAnchors([]rune("aa"), []rune("aa")))
+	assert.Nil(t, patienceAnchors([]rune("ab"), []rune("cd")))
+}
+
+func TestHistogramAnchors(t *testing.T) {
+	// "b" is the least frequent line, the region around it is "abcd"
+	assert.Equal(t, [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}},
+		histogramAnchors([]rune("abcdaa"), []rune("xabcdx")))
+	assert.Nil(t, histogramAnchors([]rune("ab"), []rune("cd")))
+	many := []rune(strings.Repeat("a", histogramMaxOccurrences+1))
+	assert.Nil(t, histogramAnchors(many, []rune("a")))
+}
+
+func TestMergeAdjacentDiffs(t *testing.T) {
+	assert.Equal(t, []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "ab"},
+		{Type: diffmatchpatch.DiffDelete, Text: "cd"},
+		{Type: diffmatchpatch.DiffInsert, Text: "ef"},
+		{Type: diffmatchpatch.DiffEqual, Text: "g"},
+	}, mergeAdjacentDiffs([]diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "a"},
+		{Type: diffmatchpatch.DiffEqual, Text: "b"},
+		{Type: diffmatchpatch.DiffInsert, Text: "e"},
+		{Type: diffmatchpatch.DiffDelete, Text: "c"},
+		{Type: diffmatchpatch.DiffEqual, Text: ""},
+		{Type: diffmatchpatch.DiffInsert, Text: "f"},
+		{Type: diffmatchpatch.DiffDelete, Text: "d"},
+		{Type: diffmatchpatch.DiffEqual, Text: "g"},
+	}))
+}
@@ -25,22 +25,32 @@ func TestFileDiffMeta(t *testing.T) {
 	assert.Equal(t, len(fd.Requires()), 2)
 	assert.Equal(t, fd.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, fd.Requires()[1], items.DependencyBlobCache)
-	assert.Len(t, fd.ListConfigurationOptions(), 4)
+	assert.Len(t, fd.ListConfigurationOptions(), 6)
 	assert.Equal(t, fd.ListConfigurationOptions()[0].Name, items.ConfigFileDiffDisableCleanup)
 	assert.Equal(t, fd.ListConfigurationOptions()[1].Name, items.ConfigFileWhitespaceIgnore)
 	assert.Equal(t, fd.ListConfigurationOptions()[2].Name, items.ConfigFileDiffTimeout)
 	assert.Equal(t, fd.ListConfigurationOptions()[3].Name, items.ConfigFileDiffDisableRefine)
+	assert.Equal(t, fd.ListConfigurationOptions()[4].Name, items.ConfigFileDiffAlgorithm)
+	assert.Equal(t, fd.ListConfigurationOptions()[4].Default, items.DiffAlgorithmMyers)
+	assert.Equal(t, fd.ListConfigurationOptions()[5].Name, items.ConfigFileDiffWordLevel)
 	assert.NoError(t, fd.Configure(map[string]interface{}{
 		core.ConfigLogger:                  core.NewLogger(),
 		items.ConfigFileDiffDisableCleanup: true,
 		items.ConfigFileWhitespaceIgnore:   true,
 		items.ConfigFileDiffTimeout:        500,
 		items.ConfigFileDiffDisableRefine:  true,
+		items.ConfigFileDiffAlgorithm:      items.DiffAlgorithmHistogram,
+		items.ConfigFileDiffWordLevel:      true,
 	}))
 	assert.True(t, fd.CleanupDisabled)
 	assert.True(t, fd.WhitespaceIgnore)
 	assert.Equal(t, 500*time.Millisecond, fd.Timeout)
 	assert.True(t, fd.RefineDisabled)
+	assert.Equal(t, items.DiffAlgorithmHistogram, fd.Algorithm)
+	assert.True(t, fd.WordLevel)
+	assert.Error(t, fd.Configure(map[string]interface{}{
+		items.ConfigFileDiffAlgorithm: "minimal",
+	}))
 }
 
 func TestFileDiffRegistration(t *testing.T) {
@@ -327,6 +337,50 @@ func TestFileDiffWhitespaceDarkMagic(t *testing.T) {
 	assert.Equal(t, magicDiffs.NewLinesOfCode, plainDiffs.NewLinesOfCode)
 }
 
+func TestFileDiffConsumeAlgorithmsAndWords(t *testing.T) {
+	cache := map[plumbing.Hash]*items.CachedBlob{}
+	entry := func(name, data string) object.ChangeEntry {
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(data))
+		cache[hash] = &items.CachedBlob{Data: []byte(data)}
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	deps := map[string]interface{}{
+		items.DependencyBlobCache: cache,
+		items.DependencyTreeChanges: object.Changes{{
+			From: entry("main.txt", "one\ncall(a, b)\ntwo\nthree\n"),
+			To:   entry("main.txt", "one\ncall( a,  b )\ntwo\nfour\n"),
+		}},
+	}
+	for _, algorithm := range items.DiffAlgorithms {
+		fd := &items.FileDiff{}
+		assert.NoError(t, fd.Initialize(nil))
+		fd.Algorithm = algorithm
+		fd.WordLevel = true
+		res, err := fd.Consume(deps)
+		assert.NoError(t, err)
+		data := res[items.DependencyFileDiff].(map[string]items.FileDiffData)["main.txt"]
+		assert.Equal(t, 4, data.OldLinesOfCode, algorithm)
+		assert.Equal(t, 4, data.NewLinesOfCode, algorithm)
+		types := make([]diffmatchpatch.Operation, len(data.Diffs))
+		for i, edit := range data.Diffs {
+			types[i] = edit.Type
+		}
+		assert.Equal(t, []diffmatchpatch.Operation{
+			diffmatchpatch.DiffEqual, diffmatchpatch.DiffDelete, diffmatchpatch.DiffInsert,
+			diffmatchpatch.DiffEqual, diffmatchpatch.DiffDelete, diffmatchpatch.DiffInsert,
+		}, types, algorithm)
+		assert.Len(t, data.WordDiffs, 2, algorithm)
+		assert.True(t, data.FormattingOnly(1), algorithm)
+		assert.False(t, data.FormattingOnly(4), algorithm)
+		assert.False(t, data.FormattingOnly(0), algorithm)
+	}
+	fd := &items.FileDiff{}
+	assert.NoError(t, fd.Initialize(nil))
+	res, err := fd.Consume(deps)
+	assert.NoError(t, err)
+	assert.Nil(t, res[items.DependencyFileDiff].(map[string]items.FileDiffData)["main.txt"].WordDiffs)
+}
+
 func TestFileDiffFork(t *testing.T) {
 	fd1 := fixtures.FileDiff()
 	clones := fd1.Fork(1)
@@ -0,0 +1,124 @@
+package plumbing
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// maxWordTokens limits the number of distinct tokens in a hunk because they are encoded as runes
+// and must stay below the UTF-16 surrogates.
+const maxWordTokens = 0xD800
+
+// FormattingOnly returns whether the hunk of Diffs which starts at the specified index changes
+// only the whitespace, e.g. reindents or rewraps the lines. WordDiffs must be calculated,
+// see FileDiff.WordLevel; otherwise, it always returns false.
+func (data FileDiffData) FormattingOnly(index int) bool {
+	words, exists := data.WordDiffs[index]
+	if !exists {
+		return false
+	}
+	for _, edit := range words {
+		if edit.Type != diffmatchpatch.DiffEqual && strings.TrimSpace(edit.Text) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// calculateWordDiffs diffs the words of each hunk - the consecutive deletions and insertions
+// between the equal lines. lines decode the runes in the line diffs, see DiffLinesToRunes().
+// The result is keyed by the index of the first edit of the hunk in diffs.
+func calculateWordDiffs(
+	dmp *diffmatchpatch.DiffMatchPatch, diffs []diffmatchpatch.Diff, lines []string,
+) map[int][]diffmatchpatch.Diff {
+	result := map[int][]diffmatchpatch.Diff{}
+	for start := 0; start < len(diffs); {
+		if diffs[start].Type == diffmatchpatch.DiffEqual {
+			start++
+			continue
+		}
+		var deleted, inserted strings.Builder
+		end := start
+		for ; end < len(diffs) && diffs[end].Type != diffmatchpatch.DiffEqual; end++ {
+			builder := &inserted
+			if diffs[end].Type == diffmatchpatch.DiffDelete {
+				builder = &deleted
+			}
+			for _, line := range diffs[end].Text {
+				if int(line) < len(lines) {
+					builder.WriteString(lines[line])
+				}
+			}
+		}
+		if words := diffWords(dmp, deleted.String(), inserted.String()); words != nil {
+			result[start] = words
+		}
+		start = end
+	}
+	return result
+}
+
+// diffWords calculates the diff of the texts split into the words, the whitespace runs and
+// the other characters. It returns nil if there are too many distinct tokens.
+func diffWords(dmp *diffmatchpatch.DiffMatchPatch, before, after string) []diffmatchpatch.Diff {
+	// index 0 is reserved to avoid the null character, the same as in DiffLinesToRunes()
+	tokens := []string{""}
+	index := map[string]rune{}
+	encode := func(text string) []rune {
+		var encoded []rune
+		for len(text) > 0 {
+			token := nextWordToken(text)
+			text = text[len(token):]
+			code, exists := index[token]
+			if !exists {
+				code = rune(len(tokens))
+				index[token] = code
+				tokens = append(tokens, token)
+			}
+			encoded = append(encoded, code)
+		}
+		return encoded
+	}
+	src, dst := encode(before), encode(after)
+	if len(tokens) >= maxWordTokens {
+		return nil
+	}
+	return dmp.DiffCharsToLines(dmp.DiffMainRunes(src, dst, false), tokens)
+}
+
+// nextWordToken returns the leading run of the whitespace or the letters, digits and underscores,
+// or else the leading character.
+func nextWordToken(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
+	class := wordTokenClass(first)
+	if class == wordTokenOther {
+		return text[:size]
+	}
+	for size < len(text) {
+		r, width := utf8.DecodeRuneInString(text[size:])
+		if wordTokenClass(r) != class {
+			break
+		}
+		size += width
+	}
+	return text[:size]
+}
+
+const (
+	wordTokenSpace = iota
+	wordTokenWord
+	wordTokenOther
+)
+
+func wordTokenClass(r rune) int {
+	switch {
+	case unicode.IsSpace(r):
+		return wordTokenSpace
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return wordTokenWord
+	}
+	return wordTokenOther
+}
@@ -0,0 +1,51 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextWordToken(t *testing.T) {
+	var tokens []string
+	for text := "foo_1(bar, \t baz)  "; len(text) > 0; {
+		token := nextWordToken(text)
+		tokens = append(tokens, token)
+		text = text[len(token):]
+	}
+	assert.Equal(t, []string{"foo_1", "(", "bar", ",", " \t ", "baz", ")", "  "}, tokens)
+	assert.Equal(t, "привет", nextWordToken("привет мир"))
+}
+
+func TestDiffWords(t *testing.T) {
+	dmp := diffmatchpatch.New()
+	assert.Equal(t, []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: "x = "},
+		{Type: diffmatchpatch.DiffDelete, Text: "foo"},
+		{Type: diffmatchpatch.DiffInsert, Text: "bar"},
+		{Type: diffmatchpatch.DiffEqual, Text: "(1)\n"},
+	}, diffWords(dmp, "x = foo(1)\n", "x = bar(1)\n"))
+}
+
+func TestCalculateWordDiffsAndFormattingOnly(t *testing.T) {
+	dmp := diffmatchpatch.New()
+	lines := []string{"", "a\n", "if (x) {\n", "if(x){\n", "\n", "b\n", "c\n"}
+	diffs := []diffmatchpatch.Diff{
+		{Type: diffmatchpatch.DiffEqual, Text: string([]rune{1})},
+		{Type: diffmatchpatch.DiffDelete, Text: string([]rune{2})},
+		{Type: diffmatchpatch.DiffInsert, Text: string([]rune{3, 4})},
+		{Type: diffmatchpatch.DiffEqual, Text: string([]rune{1})},
+		{Type: diffmatchpatch.DiffDelete, Text: string([]rune{5})},
+		{Type: diffmatchpatch.DiffInsert, Text: string([]rune{6})},
+		{Type: diffmatchpatch.DiffEqual, Text: string([]rune{1})},
+		{Type: diffmatchpatch.DiffInsert, Text: string([]rune{4})},
+	}
+	data := FileDiffData{Diffs: diffs, WordDiffs: calculateWordDiffs(dmp, diffs, lines)}
+	assert.Len(t, data.WordDiffs, 3)
+	assert.True(t, data.FormattingOnly(1))
+	assert.False(t, data.FormattingOnly(4))
+	assert.True(t, data.FormattingOnly(7))
+	assert.False(t, data.FormattingOnly(0))
+	assert.False(t, FileDiffData{Diffs: diffs}.FormattingOnly(1))
+}
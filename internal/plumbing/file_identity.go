@@ -0,0 +1,178 @@
+package plumbing
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+// FileIdentityTracker assigns each file a stable identifier which survives the renames, so that
+// the per-file outputs can be joined across time. It follows the rename chains reported by
+// TreeDiff and RenameAnalysis and remembers all the names under which each file existed.
+// It is a cheap alternative to the file identifiers of LineHistoryAnalyser and does not
+// track the lines. FileIdentityTracker is a PipelineItem.
+type FileIdentityTracker struct {
+	core.NoopMerger
+
+	identities *FileIdentities
+
+	l core.Logger
+}
+
+// FileIdentities maps the file names to the stable identifiers and back. The mapping is shared
+// by all the branches and is complete after the last commit, so the leaves should query it
+// in Finalize().
+type FileIdentities struct {
+	ids   map[string]core.FileId
+	names [][]string
+	alive []bool
+}
+
+const (
+	// DependencyFileIdentities is the name of the dependency provided by FileIdentityTracker.
+	// It is *FileIdentities.
+	DependencyFileIdentities = "file_identities"
+)
+
+// NewFileIdentities creates an empty FileIdentities.
+func NewFileIdentities() *FileIdentities {
+	return &FileIdentities{ids: map[string]core.FileId{}}
+}
+
+// Id returns the identifier of the file which was the last to have the given name, whether
+// it exists now or was deleted.
+func (fi *FileIdentities) Id(name string) (core.FileId, bool) {
+	id, exists := fi.ids[name]
+	return id, exists
+}
+
+// Names returns the rename chain of the file, the current name is the last.
+func (fi *FileIdentities) Names(id core.FileId) []string {
+	if id < 0 || int(id) >= len(fi.names) {
+		return nil
+	}
+	return fi.names[id]
+}
+
+// Len returns the number of the identified files.
+func (fi *FileIdentities) Len() int {
+	return len(fi.names)
+}
+
+// insert identifies a new file. Inserting the name of an existing file, e.g. in a merge commit,
+// keeps the identifier.
+func (fi *FileIdentities) insert(name string) core.FileId {
+	if id, exists := fi.ids[name]; exists && fi.alive[id] {
+		return id
+	}
+	id := core.FileId(len(fi.names))
+	fi.ids[name] = id
+	fi.names = append(fi.names, []string{name})
+	fi.alive = append(fi.alive, true)
+	return id
+}
+
+// rename carries the identifier of the file over to the new name.
+func (fi *FileIdentities) rename(from, to string) {
+	id, exists := fi.ids[from]
+	if !exists || !fi.alive[id] {
+		fi.insert(to)
+		return
+	}
+	if other, exists := fi.ids[to]; exists && other != id {
+		fi.alive[other] = false
+	}
+	fi.ids[to] = id
+	if names := fi.names[id]; names[len(names)-1] != to {
+		fi.names[id] = append(names, to)
+	}
+}
+
+// delete marks the file as deleted. Its names still resolve to the identifier.
+func (fi *FileIdentities) delete(name string) {
+	if id, exists := fi.ids[name]; exists {
+		fi.alive[id] = false
+	}
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (tracker *FileIdentityTracker) Name() string {
+	return "FileIdentityTracker"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (tracker *FileIdentityTracker) Provides() []string {
+	return []string{DependencyFileIdentities}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (tracker *FileIdentityTracker) Requires() []string {
+	return []string{DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (tracker *FileIdentityTracker) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (tracker *FileIdentityTracker) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		tracker.l = l
+	}
+	return nil
+}
+
+func (*FileIdentityTracker) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (tracker *FileIdentityTracker) Initialize(repository *git.Repository) error {
+	tracker.l = core.NewLogger()
+	tracker.identities = NewFileIdentities()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (tracker *FileIdentityTracker) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[DependencyTreeChanges].(object.Changes)
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			tracker.identities.insert(change.To.Name)
+		case merkletrie.Delete:
+			tracker.identities.delete(change.From.Name)
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				tracker.identities.rename(change.From.Name, change.To.Name)
+			} else if _, exists := tracker.identities.Id(change.To.Name); !exists {
+				tracker.identities.insert(change.To.Name)
+			}
+		}
+	}
+	return map[string]interface{}{DependencyFileIdentities: tracker.identities}, nil
+}
+
+// Fork clones this PipelineItem.
+func (tracker *FileIdentityTracker) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(tracker, n)
+}
+
+func init() {
+	core.Registry.Register(&FileIdentityTracker{})
+}
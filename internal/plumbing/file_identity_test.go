@@ -0,0 +1,56 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileIdentityTrackerMeta(t *testing.T) {
+	tracker := &FileIdentityTracker{}
+	assert.Equal(t, "FileIdentityTracker", tracker.Name())
+	assert.Equal(t, []string{DependencyFileIdentities}, tracker.Provides())
+	assert.Equal(t, []string{DependencyTreeChanges}, tracker.Requires())
+	assert.Len(t, tracker.ListConfigurationOptions(), 0)
+	logger := core.NewLogger()
+	assert.NoError(t, tracker.Configure(map[string]interface{}{core.ConfigLogger: logger}))
+	assert.Equal(t, logger, tracker.l)
+	summoned := core.Registry.Summon(DependencyFileIdentities)
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "FileIdentityTracker", summoned[0].Name())
+}
+
+func TestFileIdentityTrackerConsume(t *testing.T) {
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name}
+	}
+	tracker := &FileIdentityTracker{}
+	require.NoError(t, tracker.Initialize(nil))
+	var identities *FileIdentities
+	for _, changes := range []object.Changes{
+		{{To: entry("a")}, {To: entry("b")}},
+		{{From: entry("a"), To: entry("c")}, {From: entry("b"), To: entry("b")}},
+		{{From: entry("c"), To: entry("d")}, {From: entry("b")}},
+		{{To: entry("b")}, {To: entry("d")}},
+	} {
+		result, err := tracker.Consume(map[string]interface{}{DependencyTreeChanges: changes})
+		require.NoError(t, err)
+		identities = result[DependencyFileIdentities].(*FileIdentities)
+	}
+	assert.Equal(t, 3, identities.Len())
+	id, exists := identities.Id("d")
+	assert.True(t, exists)
+	assert.Equal(t, core.FileId(0), id)
+	assert.Equal(t, []string{"a", "c", "d"}, identities.Names(id))
+	id, _ = identities.Id("a")
+	assert.Equal(t, core.FileId(0), id)
+	id, _ = identities.Id("b")
+	assert.Equal(t, core.FileId(2), id)
+	assert.Equal(t, []string{"b"}, identities.Names(id))
+	_, exists = identities.Id("x")
+	assert.False(t, exists)
+	assert.Nil(t, identities.Names(7))
+}
@@ -0,0 +1,165 @@
+package identity
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+const (
+	// FactIdentityDetectorCommitAuthors is the name of the fact which is inserted in
+	// PeopleDetector.Configure() if the co-authors are enabled. It maps the commit hashes
+	// to []CommitAuthor - the commit author followed by the co-authors from the trailers.
+	// The commits without co-authors are absent.
+	FactIdentityDetectorCommitAuthors = "IdentityDetector.CommitAuthors"
+	// FactIdentityDetectorResolveSignature is the name of the fact which is inserted in
+	// PeopleDetector.Configure(). It is a func(object.Signature) int which finds the developer
+	// index of any signature, e.g. of the committer, or returns core.AuthorMissing.
+	FactIdentityDetectorResolveSignature = "IdentityDetector.ResolveSignature"
+	// ConfigIdentityDetectorCoAuthors is the name of the configuration option
+	// (PeopleDetector.Configure()) which enables the parsing of the co-author trailers.
+	ConfigIdentityDetectorCoAuthors = "PeopleDetector.CoAuthors"
+	// ConfigIdentityDetectorCoAuthorTrailers is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets the trailers naming the co-authors.
+	ConfigIdentityDetectorCoAuthorTrailers = "PeopleDetector.CoAuthorTrailers"
+	// ConfigIdentityDetectorCoAuthorShare is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets the share of the line changes attributed
+	// to the co-authors.
+	ConfigIdentityDetectorCoAuthorShare = "PeopleDetector.CoAuthorShare"
+
+	// DefaultCoAuthorShare is the default share of the line changes which the co-authors
+	// of a commit split evenly; the author keeps the rest.
+	DefaultCoAuthorShare = 0.5
+)
+
+// DefaultCoAuthorTrailers are the trailers which name the co-authors by default.
+var DefaultCoAuthorTrailers = []string{"Co-authored-by"}
+
+// CommitAuthor is a developer who contributed to a commit together with their share
+// of the commit's line changes. The shares of all the authors of a commit sum to 1.
+type CommitAuthor struct {
+	// ID is the developer index in ReversedPeopleDict.
+	ID int
+	// Share is the fraction of the line changes attributed to the developer.
+	Share float32
+}
+
+var trailerSignatureRe = regexp.MustCompile(`^\s*(.*?)\s*<([^<>]*)>\s*$`)
+
+// ParseCoAuthors extracts the signatures from the trailers of the commit message,
+// e.g. "Co-authored-by: Jane Doe <jane@example.com>". The trailer keys are matched
+// case-insensitively.
+func ParseCoAuthors(message string, trailers []string) []object.Signature {
+	var result []object.Signature
+	for _, line := range strings.Split(message, "\n") {
+		colon := strings.IndexByte(line, ':')
+		if colon <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:colon])
+		matched := false
+		for _, trailer := range trailers {
+			if strings.EqualFold(key, trailer) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		match := trailerSignatureRe.FindStringSubmatch(line[colon+1:])
+		if match == nil {
+			continue
+		}
+		name, email := match[1], strings.TrimSpace(match[2])
+		if email == "" {
+			continue
+		}
+		result = append(result, object.Signature{Name: name, Email: email})
+	}
+	return result
+}
+
+// coAuthorsOf returns the co-author signatures of the commit if the co-authors are enabled.
+func (detector *PeopleDetector) coAuthorsOf(commit *object.Commit) []object.Signature {
+	if !detector.CoAuthors {
+		return nil
+	}
+	return ParseCoAuthors(commit.Message, detector.CoAuthorTrailers)
+}
+
+// resolve finds the developer index of the signature, the same way as Consume() does.
+func (detector *PeopleDetector) resolve(signature object.Signature) int {
+	var authorID int
+	var exists bool
+	if !detector.ExactSignatures {
+		authorID, exists = detector.PeopleDict[strings.ToLower(signature.Email)]
+		if !exists {
+			authorID, exists = detector.PeopleDict[strings.ToLower(signature.Name)]
+		}
+	} else {
+		authorID, exists = detector.PeopleDict[strings.ToLower(signature.String())]
+	}
+	if !exists {
+		return core.AuthorMissing
+	}
+	return authorID
+}
+
+// CommitAuthors returns the author and the distinct known co-authors of the commit
+// with their shares of the line changes. The unknown co-authors and those who resolve
+// to the author are skipped.
+func (detector *PeopleDetector) CommitAuthors(commit *object.Commit) []CommitAuthor {
+	author := detector.resolve(commit.Author)
+	authors := []CommitAuthor{{ID: author, Share: 1}}
+	seen := map[int]bool{author: true}
+	for _, signature := range detector.coAuthorsOf(commit) {
+		id := detector.resolve(signature)
+		if id == core.AuthorMissing || seen[id] {
+			continue
+		}
+		seen[id] = true
+		authors = append(authors, CommitAuthor{ID: id})
+	}
+	if len(authors) == 1 {
+		return authors
+	}
+	coShare := detector.CoAuthorShare / float32(len(authors)-1)
+	authors[0].Share = 1 - detector.CoAuthorShare
+	for i := range authors[1:] {
+		authors[i+1].Share = coShare
+	}
+	return authors
+}
+
+// detectCommitAuthors builds FactIdentityDetectorCommitAuthors.
+func (detector *PeopleDetector) detectCommitAuthors(commits []*object.Commit) map[plumbing.Hash][]CommitAuthor {
+	result := map[plumbing.Hash][]CommitAuthor{}
+	for _, commit := range commits {
+		if authors := detector.CommitAuthors(commit); len(authors) > 1 {
+			result[commit.Hash] = authors
+		}
+	}
+	return result
+}
+
+// SplitLines distributes the number of lines among the commit authors according to their
+// shares. The rounding error goes to the first author so that the parts sum to the total.
+func SplitLines(lines int, authors []CommitAuthor) []int {
+	parts := make([]int, len(authors))
+	rest := lines
+	for i := 1; i < len(authors); i++ {
+		parts[i] = int(float32(lines)*authors[i].Share + 0.5)
+		if parts[i] > rest {
+			parts[i] = rest
+		}
+		rest -= parts[i]
+	}
+	if len(parts) > 0 {
+		parts[0] = rest
+	}
+	return parts
+}
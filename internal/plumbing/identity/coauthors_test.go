@@ -0,0 +1,89 @@
+package identity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coAuthoredMessage = `Pair on the parser
+
+Co-authored-by: Bob <bob@example.com>
+co-authored-by:  Carol Smith <Carol@Example.com>
+Signed-off-by: Dave <dave@example.com>
+Co-authored-by: nobody
+Co-authored-by: Alice <alice@example.com>
+`
+
+func TestParseCoAuthors(t *testing.T) {
+	assert.Equal(t, []object.Signature{
+		{Name: "Bob", Email: "bob@example.com"},
+		{Name: "Carol Smith", Email: "Carol@Example.com"},
+		{Name: "Alice", Email: "alice@example.com"},
+	}, ParseCoAuthors(coAuthoredMessage, DefaultCoAuthorTrailers))
+	assert.Equal(t, []object.Signature{
+		{Name: "Dave", Email: "dave@example.com"},
+	}, ParseCoAuthors(coAuthoredMessage, []string{"Signed-off-by"}))
+	assert.Nil(t, ParseCoAuthors("fix: typo\n\nSee: <https://example.com>", DefaultCoAuthorTrailers))
+}
+
+func fixtureCoAuthoredCommits() []*object.Commit {
+	when := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// GeneratePeopleDict() looks for .mailmap in the last commit
+	last := getFakeCommitWithFile("README.md", "")
+	last.Hash = plumbing.NewHash("8a03b5620b1caa72ec9cb847ea88332621e2950a")
+	last.Author = object.Signature{Name: "Bob", Email: "bob@example.com", When: when}
+	last.Message = "Solo work"
+	return []*object.Commit{{
+		Hash:    plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48"),
+		Author:  object.Signature{Name: "Alice", Email: "alice@example.com", When: when},
+		Message: coAuthoredMessage,
+	}, last}
+}
+
+func TestPeopleDetectorCoAuthors(t *testing.T) {
+	commits := fixtureCoAuthoredCommits()
+	id := &PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:          commits,
+		ConfigIdentityDetectorCoAuthors:     true,
+		ConfigIdentityDetectorCoAuthorShare: float32(0.5),
+	}
+	require.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{
+		"alice|alice@example.com", "bob|bob@example.com", "carol smith|carol@example.com",
+	}, id.ReversedPeopleDict)
+	assert.Equal(t, map[plumbing.Hash][]CommitAuthor{
+		commits[0].Hash: {{ID: 0, Share: 0.5}, {ID: 1, Share: 0.25}, {ID: 2, Share: 0.25}},
+	}, facts[FactIdentityDetectorCommitAuthors])
+	assert.Equal(t, []CommitAuthor{{ID: 1, Share: 1}}, id.CommitAuthors(commits[1]))
+
+	id = &PeopleDetector{}
+	facts = map[string]interface{}{core.ConfigPipelineCommits: commits}
+	require.NoError(t, id.Configure(facts))
+	assert.Len(t, id.ReversedPeopleDict, 2)
+	assert.NotContains(t, facts, FactIdentityDetectorCommitAuthors)
+	assert.Equal(t, []CommitAuthor{{ID: 0, Share: 1}}, id.CommitAuthors(commits[0]))
+	resolve := facts[FactIdentityDetectorResolveSignature].(func(object.Signature) int)
+	assert.Equal(t, 1, resolve(object.Signature{Name: "Robert", Email: "BOB@example.com"}))
+	assert.Equal(t, core.AuthorMissing, resolve(object.Signature{Name: "GitHub", Email: "noreply@github.com"}))
+
+	id = &PeopleDetector{}
+	assert.Error(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:          commits,
+		ConfigIdentityDetectorCoAuthorShare: float32(1.5),
+	}))
+}
+
+func TestSplitLines(t *testing.T) {
+	authors := []CommitAuthor{{ID: 0, Share: 0.4}, {ID: 1, Share: 0.3}, {ID: 2, Share: 0.3}}
+	assert.Equal(t, []int{4, 3, 3}, SplitLines(10, authors))
+	assert.Equal(t, []int{1, 1, 1}, SplitLines(3, authors))
+	assert.Equal(t, []int{0, 0, 0}, SplitLines(0, authors))
+	assert.Equal(t, []int{7}, SplitLines(7, authors[:1]))
+}
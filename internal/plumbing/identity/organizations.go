@@ -0,0 +1,137 @@
+package identity
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+const (
+	// FactIdentityDetectorOrganizations is the name of the fact which is inserted in
+	// PeopleDetector.Configure() if the organizations are enabled. It maps the author indices
+	// to the organizations inferred from their emails.
+	FactIdentityDetectorOrganizations = "IdentityDetector.Organizations"
+	// ConfigIdentityDetectorOrganizations is the name of the configuration option
+	// (PeopleDetector.Configure()) which enables the organization inference.
+	ConfigIdentityDetectorOrganizations = "PeopleDetector.Organizations"
+	// ConfigIdentityDetectorOrganizationsPath is the name of the configuration option
+	// (PeopleDetector.Configure()) which allows to set the external mapping from the email
+	// domains and addresses to the organizations. It implies ConfigIdentityDetectorOrganizations.
+	ConfigIdentityDetectorOrganizationsPath = "PeopleDetector.OrganizationsPath"
+
+	// OrganizationIndependent is assigned to the developers who commit only from
+	// the public email providers such as gmail.com.
+	OrganizationIndependent = "independent"
+	// OrganizationUnknown is assigned to the developers without a valid email.
+	OrganizationUnknown = "unknown"
+)
+
+// catchAllDomains are the email providers which do not tell anything about the employer.
+var catchAllDomains = map[string]bool{
+	"126.com": true, "163.com": true, "aol.com": true, "fastmail.com": true,
+	"foxmail.com": true, "gmail.com": true, "gmx.de": true, "gmx.net": true,
+	"googlemail.com": true, "hey.com": true, "hotmail.com": true, "icloud.com": true,
+	"live.com": true, "mac.com": true, "mail.ru": true, "me.com": true, "msn.com": true,
+	"outlook.com": true, "pm.me": true, "proton.me": true, "protonmail.com": true,
+	"qq.com": true, "users.noreply.github.com": true, "web.de": true, "yahoo.com": true,
+	"yandex.com": true, "yandex.ru": true, "zoho.com": true,
+}
+
+// secondLevelDomains are the common second level labels under the country code domains,
+// e.g. "co" in "example.co.uk".
+var secondLevelDomains = map[string]bool{
+	"ac": true, "co": true, "com": true, "edu": true, "gov": true, "ne": true, "net": true,
+	"or": true, "org": true,
+}
+
+// LoadOrganizations reads the mapping from the email domains and addresses to the organizations.
+// Each line is "Organization|domain-or-email|domain-or-email|...". The domains match
+// their subdomains, too; the addresses assign the organizations to the individual developers
+// who commit from the catch-all domains.
+func LoadOrganizations(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	mapping := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		keys := strings.Split(scanner.Text(), "|")
+		org := strings.TrimSpace(keys[0])
+		if org == "" {
+			continue
+		}
+		for _, key := range keys[1:] {
+			key = strings.ToLower(strings.TrimSpace(key))
+			if key != "" {
+				mapping[key] = org
+			}
+		}
+	}
+	return mapping, scanner.Err()
+}
+
+// detectOrganizations infers the organization of each developer in ReversedPeopleDict.
+func (detector *PeopleDetector) detectOrganizations() []string {
+	orgs := make([]string, len(detector.ReversedPeopleDict))
+	for i, description := range detector.ReversedPeopleDict {
+		orgs[i] = DetectOrganization(description, detector.OrganizationsMap)
+	}
+	return orgs
+}
+
+// DetectOrganization picks the organization from the emails in the developer's description.
+// The explicit mapping wins over the registrable domain of the first email which does not
+// belong to a catch-all provider.
+func DetectOrganization(description string, mapping map[string]string) string {
+	result := OrganizationUnknown
+	for _, key := range strings.Split(description, "|") {
+		email := strings.ToLower(strings.TrimSpace(key))
+		at := strings.LastIndex(email, "@")
+		if at < 0 {
+			continue
+		}
+		if org, exists := mapping[email]; exists {
+			return org
+		}
+		domain := strings.TrimSuffix(email[at+1:], ".")
+		if !strings.Contains(domain, ".") {
+			continue
+		}
+		sub := domain
+		for {
+			if org, exists := mapping[sub]; exists {
+				return org
+			}
+			dot := strings.IndexByte(sub, '.')
+			if dot < 0 {
+				break
+			}
+			sub = sub[dot+1:]
+		}
+		if catchAllDomains[domain] || strings.HasSuffix(domain, ".noreply.github.com") {
+			if result == OrganizationUnknown {
+				result = OrganizationIndependent
+			}
+			continue
+		}
+		if result == OrganizationUnknown || result == OrganizationIndependent {
+			result = registrableDomain(domain)
+		}
+	}
+	return result
+}
+
+// registrableDomain strips the subdomains, e.g. "mail.corp.example.co.uk" becomes "example.co.uk".
+func registrableDomain(domain string) string {
+	labels := strings.Split(domain, ".")
+	n := 2
+	if len(labels) > 2 && len(labels[len(labels)-1]) == 2 && secondLevelDomains[labels[len(labels)-2]] {
+		n = 3
+	}
+	if len(labels) <= n {
+		return domain
+	}
+	return strings.Join(labels[len(labels)-n:], ".")
+}
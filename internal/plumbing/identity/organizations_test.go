@@ -0,0 +1,74 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectOrganization(t *testing.T) {
+	mapping := map[string]string{
+		"linuxfoundation.org": "Linux Foundation",
+		"bob@gmail.com":       "Acme",
+	}
+	assert.Equal(t, "example.com", DetectOrganization("alice|alice@mail.example.com", nil))
+	assert.Equal(t, "example.co.uk", DetectOrganization("alice|alice@corp.example.co.uk", nil))
+	assert.Equal(t, OrganizationIndependent, DetectOrganization("alice|alice@gmail.com", nil))
+	assert.Equal(t, OrganizationIndependent,
+		DetectOrganization("alice|123+alice@users.noreply.github.com", nil))
+	assert.Equal(t, OrganizationUnknown, DetectOrganization("alice", nil))
+	assert.Equal(t, OrganizationUnknown, DetectOrganization("alice|alice@localhost", nil))
+	// the corporate email wins over the catch-all one
+	assert.Equal(t, "example.com",
+		DetectOrganization("alice|alice@gmail.com|alice@example.com", nil))
+	assert.Equal(t, "Linux Foundation",
+		DetectOrganization("alice|alice@example.com|alice@lists.linuxfoundation.org", mapping))
+	assert.Equal(t, "Acme", DetectOrganization("bob|bob@gmail.com", mapping))
+	assert.Equal(t, OrganizationIndependent, DetectOrganization("carol|carol@gmail.com", mapping))
+}
+
+func TestLoadOrganizations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orgs.txt")
+	require.NoError(t, os.WriteFile(path, []byte(
+		"Linux Foundation|linuxfoundation.org| LF.io \n\n|orphan.com\nAcme|Bob@gmail.com\n"), 0o644))
+	mapping, err := LoadOrganizations(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"linuxfoundation.org": "Linux Foundation",
+		"lf.io":               "Linux Foundation",
+		"bob@gmail.com":       "Acme",
+	}, mapping)
+	_, err = LoadOrganizations(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
+func TestPeopleDetectorConfigureOrganizations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orgs.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Acme|bob@gmail.com\n"), 0o644))
+	reversed := []string{"alice|alice@example.com", "bob|bob@gmail.com", "carol"}
+
+	id := PeopleDetector{}
+	facts := map[string]interface{}{FactIdentityDetectorReversedPeopleDict: reversed}
+	require.NoError(t, id.Configure(facts))
+	assert.NotContains(t, facts, FactIdentityDetectorOrganizations)
+
+	id = PeopleDetector{}
+	facts = map[string]interface{}{
+		FactIdentityDetectorReversedPeopleDict:  reversed,
+		ConfigIdentityDetectorOrganizationsPath: path,
+		ConfigIdentityDetectorDisplayFormat:     DisplayFormatHashed,
+	}
+	require.NoError(t, id.Configure(facts))
+	assert.True(t, id.Organizations)
+	assert.Equal(t, []string{"example.com", "Acme", OrganizationUnknown},
+		facts[FactIdentityDetectorOrganizations])
+
+	id = PeopleDetector{}
+	assert.Error(t, id.Configure(map[string]interface{}{
+		FactIdentityDetectorReversedPeopleDict:  reversed,
+		ConfigIdentityDetectorOrganizationsPath: path + ".missing",
+	}))
+}
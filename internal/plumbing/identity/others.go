@@ -0,0 +1,99 @@
+package identity
+
+import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigIdentityDetectorMinCommits is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets PeopleDetector.MinCommits.
+	ConfigIdentityDetectorMinCommits = "PeopleDetector.MinCommits"
+	// ConfigIdentityDetectorMinLines is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets PeopleDetector.MinLines.
+	ConfigIdentityDetectorMinLines = "PeopleDetector.MinLines"
+	// OthersName is the identity of the developers who are collapsed
+	// due to PeopleDetector.MinCommits or PeopleDetector.MinLines.
+	OthersName = "<others>"
+)
+
+// collapseOccasionalAuthors merges the developers with fewer than MinCommits commits or
+// fewer than MinLines added and removed lines into the single OthersName identity which
+// goes last. The co-authored commits count if CoAuthors is enabled. It returns the mapping
+// from the old to the new author indices, or nil if nobody was collapsed.
+func (detector *PeopleDetector) collapseOccasionalAuthors(commits []*object.Commit) ([]int, error) {
+	if (detector.MinCommits <= 1 && detector.MinLines <= 0) || len(detector.ReversedPeopleDict) == 0 {
+		return nil, nil
+	}
+	counts := make([]int, len(detector.ReversedPeopleDict))
+	var lines []int
+	if detector.MinLines > 0 {
+		lines = make([]int, len(counts))
+	}
+	for _, commit := range commits {
+		ids := []int{detector.resolve(commit.Author)}
+		if detector.CoAuthors {
+			ids = ids[:0]
+			for _, author := range detector.CommitAuthors(commit) {
+				ids = append(ids, author.ID)
+			}
+		}
+		changed := 0
+		if lines != nil {
+			var err error
+			if changed, err = commitChangedLines(commit); err != nil {
+				return nil, err
+			}
+		}
+		for _, id := range ids {
+			if id >= 0 && id < len(counts) {
+				counts[id]++
+				if lines != nil {
+					lines[id] += changed
+				}
+			}
+		}
+	}
+	remap := make([]int, len(counts))
+	var reversedPeopleDict []string
+	var collapsed []int
+	for id, count := range counts {
+		if count >= detector.MinCommits && (lines == nil || lines[id] >= detector.MinLines) {
+			remap[id] = len(reversedPeopleDict)
+			reversedPeopleDict = append(reversedPeopleDict, detector.ReversedPeopleDict[id])
+		} else {
+			collapsed = append(collapsed, id)
+		}
+	}
+	if len(collapsed) == 0 {
+		return nil, nil
+	}
+	others := len(reversedPeopleDict)
+	for _, id := range collapsed {
+		remap[id] = others
+	}
+	detector.ReversedPeopleDict = append(reversedPeopleDict, OthersName)
+	for key, id := range detector.PeopleDict {
+		detector.PeopleDict[key] = remap[id]
+	}
+	detector.l.Infof("collapsed %d developers with fewer than %d commits or %d lines into %s",
+		len(collapsed), detector.MinCommits, detector.MinLines, OthersName)
+	return remap, nil
+}
+
+// commitChangedLines returns the number of the lines which the commit added and removed
+// compared to its parent. The merge commits change nothing on their own.
+func commitChangedLines(commit *object.Commit) (int, error) {
+	if commit.NumParents() > 1 {
+		return 0, nil
+	}
+	stats, err := commit.Stats()
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to count the lines of %s", commit.Hash)
+	}
+	lines := 0
+	for _, file := range stats {
+		lines += file.Addition + file.Deletion
+	}
+	return lines, nil
+}
@@ -0,0 +1,170 @@
+package identity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureOccasionalAuthorsCommits() []*object.Commit {
+	commits := fixtureCoAuthoredCommits()
+	// Alice commits twice, Bob once and Carol only co-authors
+	return append([]*object.Commit{{
+		Hash:   plumbing.NewHash("1f8bdd7a5e0b7d0e41ef4e32f8a4e3c2e6f2bd56"),
+		Author: commits[0].Author,
+	}}, commits...)
+}
+
+func TestPeopleDetectorMinCommits(t *testing.T) {
+	commits := fixtureOccasionalAuthorsCommits()
+	id := &PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:       commits,
+		ConfigIdentityDetectorMinCommits: 2,
+	}
+	require.NoError(t, id.Configure(facts))
+	assert.Equal(t, 2, id.MinCommits)
+	assert.Equal(t, []string{"alice|alice@example.com", OthersName}, id.ReversedPeopleDict)
+	assert.Equal(t, id.ReversedPeopleDict, facts[FactIdentityDetectorReversedPeopleDict])
+	assert.Equal(t, 0, id.resolve(commits[0].Author))
+	assert.Equal(t, 1, id.resolve(commits[2].Author))
+	assert.Equal(t, 1, id.PeopleDict["bob"])
+
+	// the co-authored commits count
+	id = &PeopleDetector{}
+	facts = map[string]interface{}{
+		core.ConfigPipelineCommits:       commits,
+		ConfigIdentityDetectorMinCommits: 2,
+		ConfigIdentityDetectorCoAuthors:  true,
+	}
+	require.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{"alice|alice@example.com", "bob|bob@example.com", OthersName},
+		id.ReversedPeopleDict)
+	assert.Equal(t, []CommitAuthor{
+		{ID: 0, Share: 1 - DefaultCoAuthorShare},
+		{ID: 1, Share: DefaultCoAuthorShare / 2},
+		{ID: 2, Share: DefaultCoAuthorShare / 2},
+	}, id.CommitAuthors(commits[1]))
+
+	// nobody is collapsed
+	id = &PeopleDetector{}
+	require.NoError(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:       commits,
+		ConfigIdentityDetectorMinCommits: 1,
+	}))
+	assert.Equal(t, []string{"alice|alice@example.com", "bob|bob@example.com"}, id.ReversedPeopleDict)
+
+	id = &PeopleDetector{}
+	assert.Error(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:       commits,
+		ConfigIdentityDetectorMinCommits: -1,
+	}))
+}
+
+func TestPeopleDetectorMinCommitsTeams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identities.csv")
+	require.NoError(t, os.WriteFile(path, []byte(identitiesCSV), 0o644))
+	commits := fixtureOccasionalAuthorsCommits()
+	commits[0].Author = object.Signature{Name: "Carol", Email: "carol@example.com"}
+	commits = append([]*object.Commit{{Author: commits[0].Author}}, commits...)
+	id := &PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:            commits,
+		ConfigIdentityDetectorIdentityService: path,
+		ConfigIdentityDetectorMinCommits:      2,
+	}
+	require.NoError(t, id.Configure(facts))
+	// alice and bob are merged by the directory before counting
+	assert.Equal(t, []string{
+		"carol|carol@example.com", "Alice Liddell|alice|bob|alice@example.com|bob@example.com",
+	}, id.ReversedPeopleDict)
+	assert.Equal(t, []string{"frontend", "backend"}, facts[FactIdentityDetectorTeams])
+
+	facts[ConfigIdentityDetectorMinCommits] = 3
+	id = &PeopleDetector{}
+	delete(facts, FactIdentityDetectorReversedPeopleDict)
+	require.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{OthersName}, id.ReversedPeopleDict)
+	assert.Equal(t, []string{""}, facts[FactIdentityDetectorTeams])
+}
+
+// fixtureMinLinesCommits returns the commits of an in-memory repository where Alice writes
+// 20 lines, Bob fixes one of them twice and Carol removes one line, from the oldest.
+func fixtureMinLinesCommits(t *testing.T) []*object.Commit {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	lines := make([]string, 20)
+	for i := range lines {
+		lines[i] = "line"
+	}
+	when := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(name string, edit func()) {
+		edit()
+		require.NoError(t, util.WriteFile(worktree.Filesystem, "main.txt",
+			[]byte(strings.Join(lines, "\n")+"\n"), 0o644))
+		_, err := worktree.Add("main.txt")
+		require.NoError(t, err)
+		signature := &object.Signature{Name: name, Email: strings.ToLower(name) + "@example.com", When: when}
+		when = when.Add(time.Hour)
+		_, err = worktree.Commit("edit", &git.CommitOptions{Author: signature, Committer: signature})
+		require.NoError(t, err)
+	}
+	commit("Alice", func() {})
+	commit("Bob", func() { lines[0] = "fixed" })
+	commit("Bob", func() { lines[1] = "fixed" })
+	commit("Carol", func() { lines = lines[1:] })
+	iter, err := repository.Log(&git.LogOptions{})
+	require.NoError(t, err)
+	var commits []*object.Commit
+	require.NoError(t, iter.ForEach(func(commit *object.Commit) error {
+		commits = append([]*object.Commit{commit}, commits...)
+		return nil
+	}))
+	return commits
+}
+
+func TestPeopleDetectorMinLines(t *testing.T) {
+	commits := fixtureMinLinesCommits(t)
+	id := &PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:     commits,
+		ConfigIdentityDetectorMinLines: 4,
+	}
+	require.NoError(t, id.Configure(facts))
+	assert.Equal(t, 4, id.MinLines)
+	// Bob added and removed 4 lines, Carol only 1
+	assert.Equal(t, []string{"alice|alice@example.com", "bob|bob@example.com", OthersName},
+		id.ReversedPeopleDict)
+	assert.Equal(t, id.ReversedPeopleDict, facts[FactIdentityDetectorReversedPeopleDict])
+	assert.Equal(t, 2, id.resolve(commits[3].Author))
+
+	// the developer is merged if either threshold is not reached
+	id = &PeopleDetector{}
+	require.NoError(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:       commits,
+		ConfigIdentityDetectorMinLines:   2,
+		ConfigIdentityDetectorMinCommits: 2,
+	}))
+	assert.Equal(t, []string{"bob|bob@example.com", OthersName}, id.ReversedPeopleDict)
+	assert.Equal(t, 1, id.resolve(commits[0].Author))
+
+	id = &PeopleDetector{}
+	assert.Error(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:     commits,
+		ConfigIdentityDetectorMinLines: -1,
+	}))
+}
@@ -18,6 +18,7 @@ import (
 // It is a PipelineItem.
 type PeopleDetector struct {
 	core.NoopMerger
+	core.NoopCheckpointer
 	// PeopleDict maps email || name  -> developer id
 	PeopleDict map[string]int
 	// ReversedPeopleDict maps developer id -> description
@@ -26,6 +27,27 @@ type PeopleDetector struct {
 	// or exact email && name
 	ExactSignatures bool
 	Anonymity       bool
+	// DisplayFormat chooses how the identities are written to the results, see DisplayFormat*.
+	DisplayFormat string
+	// Organizations enables the inference of the developers' organizations from their emails.
+	Organizations bool
+	// OrganizationsMap maps the email domains and addresses to the organizations,
+	// see LoadOrganizations().
+	OrganizationsMap map[string]string
+	// CoAuthors enables the parsing of the co-author trailers, see CommitAuthors().
+	CoAuthors bool
+	// CoAuthorTrailers are the trailer keys which name the co-authors, e.g. "Co-authored-by".
+	CoAuthorTrailers []string
+	// CoAuthorShare is the share of the line changes which the co-authors split evenly.
+	CoAuthorShare float32
+	// IdentityProvider maps the emails to the canonical identities and the teams, may be nil.
+	IdentityProvider IdentityProvider
+	// MinCommits is the number of commits below which the developers are merged into
+	// the single OthersName identity. 0 disables merging.
+	MinCommits int
+	// MinLines is the number of the added and removed lines below which the developers are
+	// merged into the single OthersName identity. 0 disables merging.
+	MinLines int
 
 	l core.Logger
 }
@@ -44,6 +66,25 @@ const (
 	ConfigIdentityDetectorExactSignatures = "PeopleDetector.ExactSignatures"
 
 	ConfigIdentityDetectorAnonymity = "PeopleDetector.Anonymity"
+	// ConfigIdentityDetectorDisplayFormat is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets PeopleDetector.DisplayFormat.
+	ConfigIdentityDetectorDisplayFormat = "PeopleDetector.DisplayFormat"
+
+	// DisplayFormatRaw keeps all the names and the emails of the developer joined with "|".
+	DisplayFormatRaw = "raw"
+	// DisplayFormatFull writes the main name and email as "Name <email>".
+	DisplayFormatFull = "full"
+	// DisplayFormatName writes only the main name, or the email if there is no name.
+	DisplayFormatName = "name"
+	// DisplayFormatEmail writes only the main email, or the name if there is no email.
+	DisplayFormatEmail = "email"
+	// DisplayFormatHashed writes the stable hash of the main email, so that the same developer
+	// can be matched across the repositories without disclosing who they are.
+	DisplayFormatHashed = "hashed"
+
+	// factIdentityDetectorPrivatePeopleDict keeps the real names while
+	// FactIdentityDetectorReversedPeopleDict carries the anonymized ones due to the policy.
+	factIdentityDetectorPrivatePeopleDict = "IdentityDetector.PrivatePeopleDict"
 )
 
 var _ core.IdentityResolver = peopleResolver{}
@@ -76,8 +117,20 @@ func (v peopleResolver) nameOf(id core.AuthorId, anonymity bool) string {
 	return v.anonymizeName(id)
 }
 
+// displayName returns the public name of the developer.
+func (v peopleResolver) displayName(id core.AuthorId) string {
+	if v.identities.Anonymity {
+		return v.anonymizeName(id)
+	}
+	return formatIdentity(v.identities.ReversedPeopleDict[id], v.identities.DisplayFormat)
+}
+
 func (v peopleResolver) FriendlyNameOf(id core.AuthorId) string {
-	return v.nameOf(id, v.identities.Anonymity)
+	name := v.nameOf(id, false)
+	if name == core.AuthorMissingName {
+		return name
+	}
+	return v.displayName(id)
 }
 
 func (v peopleResolver) PrivateNameOf(id core.AuthorId) string {
@@ -92,11 +145,8 @@ func (v peopleResolver) ForEachIdentity(callback func(core.AuthorId, string)) bo
 	if v.identities == nil {
 		return false
 	}
-	for id, name := range v.identities.ReversedPeopleDict {
-		if v.identities.Anonymity {
-			name = v.anonymizeName(core.AuthorId(id))
-		}
-		callback(core.AuthorId(id), name)
+	for id := range v.identities.ReversedPeopleDict {
+		callback(core.AuthorId(id), v.displayName(core.AuthorId(id)))
 	}
 	return true
 }
@@ -105,17 +155,68 @@ func (v peopleResolver) CopyNames(privateNames bool) []string {
 	if v.identities == nil {
 		return nil
 	}
-	if privateNames || !v.identities.Anonymity {
+	if privateNames || !v.identities.hidesNames() {
 		return append([]string(nil), v.identities.ReversedPeopleDict...)
 	}
 
 	names := make([]string, len(v.identities.ReversedPeopleDict))
 	for i := range names {
-		names[i] = v.anonymizeName(core.AuthorId(i))
+		names[i] = v.displayName(core.AuthorId(i))
 	}
 	return names
 }
 
+// formatIdentity converts the description of the developer from PeopleDetector.ReversedPeopleDict
+// - the names followed by the emails, separated by "|" - to the display format.
+func formatIdentity(description, format string) string {
+	if format == "" || format == DisplayFormatRaw {
+		return description
+	}
+	var name, email string
+	for _, key := range strings.Split(description, "|") {
+		if strings.Contains(key, "@") {
+			if email == "" {
+				email = key
+			}
+		} else if name == "" {
+			name = key
+		}
+	}
+	switch format {
+	case DisplayFormatFull:
+		if name == "" || email == "" {
+			return name + email
+		}
+		return name + " <" + email + ">"
+	case DisplayFormatName:
+		if name == "" {
+			return email
+		}
+		return name
+	case DisplayFormatEmail:
+		if email == "" {
+			return name
+		}
+		return email
+	case DisplayFormatHashed:
+		key := email
+		if key == "" {
+			key = name
+		}
+		return core.HashIdentity(key)
+	}
+	return description
+}
+
+// hidesNames returns true if the public names differ from ReversedPeopleDict.
+func (detector *PeopleDetector) hidesNames() bool {
+	return detector.Anonymity || detector.formatsNames()
+}
+
+func (detector *PeopleDetector) formatsNames() bool {
+	return detector.DisplayFormat != "" && detector.DisplayFormat != DisplayFormatRaw
+}
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (detector *PeopleDetector) Name() string {
 	return "PeopleDetector"
@@ -135,6 +236,11 @@ func (detector *PeopleDetector) Requires() []string {
 	return []string{}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (detector *PeopleDetector) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 func (detector *PeopleDetector) Features() []string {
 	return []string{core.FeatureGitCommits}
 }
@@ -161,6 +267,68 @@ func (detector *PeopleDetector) ListConfigurationOptions() []core.ConfigurationO
 			Flag:        "people-anonymity",
 			Type:        core.BoolConfigurationOption,
 			Default:     false,
+		}, {
+			Name: ConfigIdentityDetectorDisplayFormat,
+			Description: "How to write the developers to the results: \"raw\" - all the names and emails, " +
+				"\"full\" - \"Name <email>\", \"name\", \"email\" or \"hashed\" - the hash of the email.",
+			Flag:    "people-format",
+			Type:    core.StringConfigurationOption,
+			Default: DisplayFormatRaw,
+		}, {
+			Name: ConfigIdentityDetectorOrganizations,
+			Description: "Infer the developers' organizations from the email domains and add " +
+				"the organization rollups to the supported analyses.",
+			Flag:    "organizations",
+			Type:    core.BoolConfigurationOption,
+			Default: false,
+		}, {
+			Name: ConfigIdentityDetectorOrganizationsPath,
+			Description: "Path to the file with organization|domain|email associations " +
+				"which override the inferred organizations. Implies --organizations.",
+			Flag:    "organizations-map",
+			Type:    core.PathConfigurationOption,
+			Default: "",
+		}, {
+			Name: ConfigIdentityDetectorCoAuthors,
+			Description: "Attribute the commits to the co-authors from the trailers such as " +
+				"\"Co-authored-by:\" in the supported analyses.",
+			Flag:    "co-authors",
+			Type:    core.BoolConfigurationOption,
+			Default: false,
+		}, {
+			Name:        ConfigIdentityDetectorCoAuthorTrailers,
+			Description: "Commit message trailers which name the co-authors, e.g. Signed-off-by.",
+			Flag:        "co-author-trailers",
+			Type:        core.StringsConfigurationOption,
+			Default:     DefaultCoAuthorTrailers,
+		}, {
+			Name: ConfigIdentityDetectorCoAuthorShare,
+			Description: "Share of each commit's line changes which the co-authors split evenly; " +
+				"the author keeps the rest.",
+			Flag:    "co-author-share",
+			Type:    core.FloatConfigurationOption,
+			Default: float32(DefaultCoAuthorShare),
+		}, {
+			Name: ConfigIdentityDetectorIdentityService,
+			Description: "URL of the identity service or path to the CSV file with email,name,team " +
+				"which sets the canonical developer names and the teams.",
+			Flag:    "identity-service",
+			Type:    core.StringConfigurationOption,
+			Default: "",
+		}, {
+			Name: ConfigIdentityDetectorMinCommits,
+			Description: "Merge the developers with fewer commits into the single \"" + OthersName +
+				"\" identity in all the per-developer analyses.",
+			Flag:    "people-min-commits",
+			Type:    core.IntConfigurationOption,
+			Default: 0,
+		}, {
+			Name: ConfigIdentityDetectorMinLines,
+			Description: "Merge the developers who added and removed fewer lines into the single \"" +
+				OthersName + "\" identity in all the per-developer analyses.",
+			Flag:    "people-min-lines",
+			Type:    core.IntConfigurationOption,
+			Default: 0,
 		},
 	}
 }
@@ -174,7 +342,9 @@ func (detector *PeopleDetector) Configure(facts map[string]interface{}) error {
 	}
 
 	detector.PeopleDict = nil
-	if val, exists := facts[FactIdentityDetectorReversedPeopleDict].([]string); exists {
+	if val, exists := facts[factIdentityDetectorPrivatePeopleDict].([]string); exists {
+		detector.ReversedPeopleDict = val
+	} else if val, exists := facts[FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		detector.ReversedPeopleDict = val
 	}
 
@@ -185,12 +355,73 @@ func (detector *PeopleDetector) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigIdentityDetectorAnonymity].(bool); exists {
 		detector.Anonymity = val
 	}
+	if val, exists := facts[ConfigIdentityDetectorDisplayFormat].(string); exists {
+		switch val {
+		case "", DisplayFormatRaw, DisplayFormatFull, DisplayFormatName, DisplayFormatEmail, DisplayFormatHashed:
+			detector.DisplayFormat = val
+		default:
+			return errors.Errorf("unknown people display format: %s", val)
+		}
+	}
+	if val, exists := facts[ConfigIdentityDetectorOrganizations].(bool); exists {
+		detector.Organizations = val
+	}
+	if path, ok := facts[ConfigIdentityDetectorOrganizationsPath].(string); ok && path != "" {
+		mapping, err := LoadOrganizations(path)
+		if err != nil {
+			return errors.Errorf("failed to load %s: %v", path, err)
+		}
+		detector.OrganizationsMap = mapping
+		detector.Organizations = true
+	}
+	if val, exists := facts[ConfigIdentityDetectorCoAuthors].(bool); exists {
+		detector.CoAuthors = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorCoAuthorTrailers].([]string); exists && len(val) > 0 {
+		detector.CoAuthorTrailers = val
+	}
+	if detector.CoAuthorTrailers == nil {
+		detector.CoAuthorTrailers = DefaultCoAuthorTrailers
+	}
+	if val, exists := facts[ConfigIdentityDetectorCoAuthorShare].(float32); exists {
+		if val < 0 || val > 1 {
+			return errors.Errorf("co-author share must be between 0 and 1, got %v", val)
+		}
+		detector.CoAuthorShare = val
+	} else if detector.CoAuthorShare == 0 {
+		detector.CoAuthorShare = DefaultCoAuthorShare
+	}
+	if val, exists := facts[ConfigIdentityDetectorIdentityService].(string); exists && val != "" {
+		provider, err := NewIdentityProvider(val)
+		if err != nil {
+			return errors.Errorf("failed to load the identity service %s: %v", val, err)
+		}
+		detector.IdentityProvider = provider
+	}
+	if val, exists := facts[ConfigIdentityDetectorMinCommits].(int); exists {
+		if val < 0 {
+			return errors.Errorf("the minimum number of commits must not be negative, got %d", val)
+		}
+		detector.MinCommits = val
+	}
+	if val, exists := facts[ConfigIdentityDetectorMinLines].(int); exists {
+		if val < 0 {
+			return errors.Errorf("the minimum number of lines must not be negative, got %d", val)
+		}
+		detector.MinLines = val
+	}
+	policyAnonymity, _ := facts[core.FactPolicyAnonymizePeople].(bool)
+	if policyAnonymity {
+		detector.Anonymity = true
+	}
 
+	detected := false
 	if peopleDictPath, ok := facts[ConfigIdentityDetectorPeopleDictPath].(string); ok && peopleDictPath != "" {
 		err := detector.LoadPeopleDict(peopleDictPath)
 		if err != nil {
 			return errors.Errorf("failed to load %s: %v", peopleDictPath, err)
 		}
+		detected = true
 	}
 
 	if detector.ReversedPeopleDict == nil {
@@ -198,8 +429,35 @@ func (detector *PeopleDetector) Configure(facts map[string]interface{}) error {
 			panic("PeopleDetector needs a list of commits to initialize.")
 		}
 		detector.GeneratePeopleDict(facts[core.ConfigPipelineCommits].([]*object.Commit))
+		detected = true
+	}
+	var teams []string
+	if detected && detector.IdentityProvider != nil {
+		var err error
+		if teams, err = detector.applyIdentityProvider(); err != nil {
+			return err
+		}
+	}
+	if commits, exists := facts[core.ConfigPipelineCommits].([]*object.Commit); exists && detected {
+		remap, err := detector.collapseOccasionalAuthors(commits)
+		if err != nil {
+			return err
+		}
+		if remap != nil && teams != nil {
+			collapsedTeams := make([]string, len(detector.ReversedPeopleDict))
+			for id, team := range teams {
+				if remap[id] < len(collapsedTeams)-1 {
+					collapsedTeams[remap[id]] = team
+				}
+			}
+			teams = collapsedTeams
+		}
+	}
+	if teams != nil {
+		facts[FactIdentityDetectorTeams] = teams
 	}
 	facts[FactIdentityDetectorReversedPeopleDict] = detector.ReversedPeopleDict
+	facts[FactIdentityDetectorResolveSignature] = detector.resolve
 
 	if detector.PeopleDict == nil {
 		detector.PeopleDict = make(map[string]int, len(detector.ReversedPeopleDict))
@@ -208,7 +466,19 @@ func (detector *PeopleDetector) Configure(facts map[string]interface{}) error {
 		}
 	}
 
+	if detector.Organizations {
+		facts[FactIdentityDetectorOrganizations] = detector.detectOrganizations()
+	}
+	if commits, exists := facts[core.ConfigPipelineCommits].([]*object.Commit); exists && detector.CoAuthors {
+		facts[FactIdentityDetectorCommitAuthors] = detector.detectCommitAuthors(commits)
+	}
+
 	var resolver core.IdentityResolver = peopleResolver{detector}
+	if policyAnonymity || detector.formatsNames() {
+		// the analyses print the names from the fact, so it must carry the public ones
+		facts[factIdentityDetectorPrivatePeopleDict] = detector.ReversedPeopleDict
+		facts[FactIdentityDetectorReversedPeopleDict] = resolver.CopyNames(false)
+	}
 	facts[core.FactIdentityResolver] = resolver
 	return nil
 }
@@ -231,21 +501,7 @@ func (detector *PeopleDetector) Initialize(*git.Repository) error {
 // in Provides(). If there was an error, nil is returned.
 func (detector *PeopleDetector) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
-	var authorID int
-	var exists bool
-	signature := commit.Author
-	if !detector.ExactSignatures {
-		authorID, exists = detector.PeopleDict[strings.ToLower(signature.Email)]
-		if !exists {
-			authorID, exists = detector.PeopleDict[strings.ToLower(signature.Name)]
-		}
-	} else {
-		authorID, exists = detector.PeopleDict[strings.ToLower(signature.String())]
-	}
-	if !exists {
-		authorID = core.AuthorMissing
-	}
-	return map[string]interface{}{DependencyAuthor: authorID}, nil
+	return map[string]interface{}{DependencyAuthor: detector.resolve(commit.Author)}, nil
 }
 
 // Fork clones this PipelineItem.
@@ -349,10 +605,15 @@ func (detector *PeopleDetector) GeneratePeopleDict(commits []*object.Commit) {
 		}
 	}
 
+	var signatures []object.Signature
 	for _, commit := range commits {
+		signatures = append(signatures, commit.Author)
+		signatures = append(signatures, detector.coAuthorsOf(commit)...)
+	}
+	for _, signature := range signatures {
 		if !detector.ExactSignatures {
-			email := strings.ToLower(commit.Author.Email)
-			name := strings.ToLower(commit.Author.Name)
+			email := strings.ToLower(signature.Email)
+			name := strings.ToLower(signature.Name)
 			id, exists := dict[email]
 			if exists {
 				_, exists := dict[name]
@@ -374,7 +635,7 @@ func (detector *PeopleDetector) GeneratePeopleDict(commits []*object.Commit) {
 			names[size] = append(names[size], name)
 			size++
 		} else { // !detector.ExactSignatures
-			sig := strings.ToLower(commit.Author.String())
+			sig := strings.ToLower(signature.String())
 			if _, exists := dict[sig]; !exists {
 				dict[sig] = size
 				size++
@@ -39,10 +39,19 @@ func TestPeopleDetectorMeta(t *testing.T) {
 	assert.Equal(t, len(id.Provides()), 1)
 	assert.Equal(t, id.Provides()[0], DependencyAuthor)
 	opts := id.ListConfigurationOptions()
-	assert.Len(t, opts, 3)
+	assert.Len(t, opts, 12)
 	assert.Equal(t, opts[0].Name, ConfigIdentityDetectorPeopleDictPath)
 	assert.Equal(t, opts[1].Name, ConfigIdentityDetectorExactSignatures)
 	assert.Equal(t, opts[2].Name, ConfigIdentityDetectorAnonymity)
+	assert.Equal(t, opts[3].Name, ConfigIdentityDetectorDisplayFormat)
+	assert.Equal(t, opts[4].Name, ConfigIdentityDetectorOrganizations)
+	assert.Equal(t, opts[5].Name, ConfigIdentityDetectorOrganizationsPath)
+	assert.Equal(t, opts[6].Name, ConfigIdentityDetectorCoAuthors)
+	assert.Equal(t, opts[7].Name, ConfigIdentityDetectorCoAuthorTrailers)
+	assert.Equal(t, opts[8].Name, ConfigIdentityDetectorCoAuthorShare)
+	assert.Equal(t, opts[9].Name, ConfigIdentityDetectorIdentityService)
+	assert.Equal(t, opts[10].Name, ConfigIdentityDetectorMinCommits)
+	assert.Equal(t, opts[11].Name, ConfigIdentityDetectorMinLines)
 	logger := core.NewLogger()
 	assert.NoError(t, id.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -423,3 +432,77 @@ func TestPeopleDetectorFork(t *testing.T) {
 	assert.True(t, id1 == id2)
 	id1.Merge([]core.PipelineItem{id2})
 }
+
+func TestPeopleDetectorConfigurePolicyAnonymity(t *testing.T) {
+	id := fixturePeopleDetector()
+	facts := map[string]interface{}{
+		FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+		core.FactPolicyAnonymizePeople:         true,
+	}
+	assert.NoError(t, id.Configure(facts))
+	assert.True(t, id.Anonymity)
+	assert.Equal(t, []string{"Author   0", "Author   1"}, facts[FactIdentityDetectorReversedPeopleDict])
+	assert.Equal(t, map[string]int{"one": 0, "two": 1}, id.PeopleDict)
+	resolver := facts[core.FactIdentityResolver].(core.IdentityResolver)
+	assert.Equal(t, "Author   1", resolver.FriendlyNameOf(1))
+	assert.Equal(t, "two", resolver.PrivateNameOf(1))
+
+	// the real names survive the second Configure()
+	id = fixturePeopleDetector()
+	assert.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{"one", "two"}, id.ReversedPeopleDict)
+	assert.Equal(t, []string{"Author   0", "Author   1"}, facts[FactIdentityDetectorReversedPeopleDict])
+}
+
+func TestPeopleDetectorConfigureDisplayFormat(t *testing.T) {
+	id := fixturePeopleDetector()
+	facts := map[string]interface{}{
+		FactIdentityDetectorReversedPeopleDict: []string{"vadim|vadim markovtsev|vadim@sourced.tech", "bot"},
+		ConfigIdentityDetectorDisplayFormat:    DisplayFormatFull,
+	}
+	assert.NoError(t, id.Configure(facts))
+	assert.Equal(t, DisplayFormatFull, id.DisplayFormat)
+	assert.Equal(t, []string{"vadim <vadim@sourced.tech>", "bot"}, facts[FactIdentityDetectorReversedPeopleDict])
+	resolver := facts[core.FactIdentityResolver].(core.IdentityResolver)
+	assert.Equal(t, "vadim <vadim@sourced.tech>", resolver.FriendlyNameOf(0))
+	assert.Equal(t, "vadim|vadim markovtsev|vadim@sourced.tech", resolver.PrivateNameOf(0))
+	assert.Equal(t, core.AuthorMissingName, resolver.FriendlyNameOf(core.AuthorMissing))
+	var names []string
+	resolver.ForEachIdentity(func(_ core.AuthorId, name string) { names = append(names, name) })
+	assert.Equal(t, []string{"vadim <vadim@sourced.tech>", "bot"}, names)
+
+	// the real names survive the second Configure()
+	id = fixturePeopleDetector()
+	facts[ConfigIdentityDetectorDisplayFormat] = DisplayFormatEmail
+	assert.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{"vadim@sourced.tech", "bot"}, facts[FactIdentityDetectorReversedPeopleDict])
+
+	// anonymity wins
+	id = fixturePeopleDetector()
+	facts[core.FactPolicyAnonymizePeople] = true
+	assert.NoError(t, id.Configure(facts))
+	assert.Equal(t, []string{"Author   0", "Author   1"}, facts[FactIdentityDetectorReversedPeopleDict])
+
+	id = fixturePeopleDetector()
+	assert.Error(t, id.Configure(map[string]interface{}{
+		FactIdentityDetectorReversedPeopleDict: []string{"one"},
+		ConfigIdentityDetectorDisplayFormat:    "nickname",
+	}))
+}
+
+func TestFormatIdentity(t *testing.T) {
+	description := "vadim|vadim markovtsev|vadim@sourced.tech|gmarkhor@gmail.com"
+	assert.Equal(t, description, formatIdentity(description, DisplayFormatRaw))
+	assert.Equal(t, description, formatIdentity(description, ""))
+	assert.Equal(t, "vadim <vadim@sourced.tech>", formatIdentity(description, DisplayFormatFull))
+	assert.Equal(t, "vadim", formatIdentity(description, DisplayFormatName))
+	assert.Equal(t, "vadim@sourced.tech", formatIdentity(description, DisplayFormatEmail))
+	hashed := formatIdentity(description, DisplayFormatHashed)
+	assert.Len(t, hashed, 12)
+	assert.Equal(t, hashed, formatIdentity("other|VADIM@sourced.tech", DisplayFormatHashed))
+	assert.NotEqual(t, hashed, formatIdentity("vadim|gmarkhor@gmail.com", DisplayFormatHashed))
+	assert.Equal(t, "bot", formatIdentity("bot", DisplayFormatFull))
+	assert.Equal(t, "bot", formatIdentity("bot", DisplayFormatEmail))
+	assert.Equal(t, "bot@example.com", formatIdentity("bot@example.com", DisplayFormatName))
+	assert.Len(t, formatIdentity("bot", DisplayFormatHashed), 12)
+}
@@ -0,0 +1,249 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FactIdentityDetectorTeams is the name of the fact which is inserted in
+	// PeopleDetector.Configure() if an identity provider is set. It maps the author indices
+	// to the team names; the developers unknown to the provider have an empty team.
+	FactIdentityDetectorTeams = "IdentityDetector.Teams"
+	// ConfigIdentityDetectorIdentityService is the name of the configuration option
+	// (PeopleDetector.Configure()) which sets the identity provider: an HTTP(S) endpoint
+	// or a path to a CSV file, see NewIdentityProvider().
+	ConfigIdentityDetectorIdentityService = "PeopleDetector.IdentityService"
+
+	// identityServiceTimeout limits the duration of a request to the HTTP identity service.
+	identityServiceTimeout = time.Minute
+)
+
+// ProvidedIdentity is the canonical identity of a developer according to an IdentityProvider.
+type ProvidedIdentity struct {
+	// Name is the canonical name of the developer. The developers with the same name are merged.
+	Name string `json:"name"`
+	// Team is the name of the developer's team.
+	Team string `json:"team"`
+}
+
+// IdentityProvider maps the emails to the canonical identities and the teams, e.g. from
+// the company directory. PeopleDetector applies it after the usual signature matching
+// and .mailmap.
+type IdentityProvider interface {
+	// Lookup returns the identities of the known emails. The keys are lowercase.
+	Lookup(emails []string) (map[string]ProvidedIdentity, error)
+}
+
+// NewIdentityProvider creates HTTPIdentityProvider if the location is an HTTP(S) URL
+// and CSVIdentityProvider otherwise.
+func NewIdentityProvider(location string) (IdentityProvider, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &HTTPIdentityProvider{URL: location}, nil
+	}
+	return LoadCSVIdentityProvider(location)
+}
+
+// CSVIdentityProvider reads the identities from a CSV file with the columns
+// "email,name,team". The header row is optional.
+type CSVIdentityProvider struct {
+	identities map[string]ProvidedIdentity
+}
+
+// LoadCSVIdentityProvider reads the CSV file with the identities.
+func LoadCSVIdentityProvider(path string) (*CSVIdentityProvider, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadCSVIdentityProvider(file)
+}
+
+// ReadCSVIdentityProvider parses the identities in CSV format, see CSVIdentityProvider.
+func ReadCSVIdentityProvider(reader io.Reader) (*CSVIdentityProvider, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	csvReader.Comment = '#'
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	provider := &CSVIdentityProvider{identities: map[string]ProvidedIdentity{}}
+	for i, record := range records {
+		email := strings.ToLower(strings.TrimSpace(record[0]))
+		if email == "" || (i == 0 && email == "email") {
+			continue
+		}
+		var identity ProvidedIdentity
+		if len(record) > 1 {
+			identity.Name = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			identity.Team = strings.TrimSpace(record[2])
+		}
+		provider.identities[email] = identity
+	}
+	return provider, nil
+}
+
+// Lookup returns the identities of the known emails.
+func (provider *CSVIdentityProvider) Lookup(emails []string) (map[string]ProvidedIdentity, error) {
+	result := map[string]ProvidedIdentity{}
+	for _, email := range emails {
+		email = strings.ToLower(email)
+		if identity, exists := provider.identities[email]; exists {
+			result[email] = identity
+		}
+	}
+	return result, nil
+}
+
+// HTTPIdentityProvider queries an external identity service. It POSTs
+// {"emails": ["jane@example.com", ...]} to URL and expects the JSON object which maps
+// the known emails to {"name": "Jane Doe", "team": "backend"} in the response.
+type HTTPIdentityProvider struct {
+	URL string
+	// Client is used for the requests; http.DefaultClient with a timeout if nil.
+	Client *http.Client
+}
+
+// Lookup queries the identity service in a single request.
+func (provider *HTTPIdentityProvider) Lookup(emails []string) (map[string]ProvidedIdentity, error) {
+	body, err := json.Marshal(map[string][]string{"emails": emails})
+	if err != nil {
+		return nil, err
+	}
+	client := provider.Client
+	if client == nil {
+		client = &http.Client{Timeout: identityServiceTimeout}
+	}
+	response, err := client.Post(provider.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to query the identity service %s", provider.URL)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("identity service %s responded with %s", provider.URL, response.Status)
+	}
+	var identities map[string]ProvidedIdentity
+	if err := json.NewDecoder(response.Body).Decode(&identities); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the response of the identity service %s", provider.URL)
+	}
+	result := make(map[string]ProvidedIdentity, len(identities))
+	for email, identity := range identities {
+		result[strings.ToLower(email)] = identity
+	}
+	return result, nil
+}
+
+// descriptionEmails extracts the lowercase emails from a ReversedPeopleDict element, both
+// "name|email|..." and the exact signatures "name <email>".
+func descriptionEmails(description string) []string {
+	var emails []string
+	for _, part := range strings.Split(description, "|") {
+		if start := strings.LastIndexByte(part, '<'); start >= 0 && strings.HasSuffix(part, ">") {
+			part = part[start+1 : len(part)-1]
+		}
+		if strings.Contains(part, "@") {
+			emails = append(emails, strings.ToLower(strings.TrimSpace(part)))
+		}
+	}
+	return emails
+}
+
+// applyIdentityProvider renames the developers to their canonical names, merges those who share
+// the same canonical name and returns the team of each developer.
+func (detector *PeopleDetector) applyIdentityProvider() ([]string, error) {
+	var emails []string
+	devEmails := make([][]string, len(detector.ReversedPeopleDict))
+	for i, description := range detector.ReversedPeopleDict {
+		devEmails[i] = descriptionEmails(description)
+		emails = append(emails, devEmails[i]...)
+	}
+	identities := map[string]ProvidedIdentity{}
+	if len(emails) > 0 {
+		var err error
+		if identities, err = detector.IdentityProvider.Lookup(emails); err != nil {
+			return nil, err
+		}
+	}
+	remap := make([]int, len(detector.ReversedPeopleDict))
+	canonical := map[string]int{}
+	var names, teams []string
+	var parts [][]string
+	for i, description := range detector.ReversedPeopleDict {
+		var identity ProvidedIdentity
+		for _, email := range devEmails[i] {
+			if val, exists := identities[email]; exists {
+				identity = val
+				break
+			}
+		}
+		key := strings.ToLower(identity.Name)
+		id, exists := canonical[key]
+		if !exists || key == "" {
+			id = len(parts)
+			if key != "" {
+				canonical[key] = id
+			}
+			names = append(names, identity.Name)
+			parts = append(parts, nil)
+			teams = append(teams, "")
+		}
+		remap[i] = id
+		parts[id] = append(parts[id], strings.Split(description, "|")...)
+		if teams[id] == "" {
+			teams[id] = identity.Team
+		}
+	}
+	reversedPeopleDict := make([]string, len(parts))
+	for id, devParts := range parts {
+		if names[id] == "" {
+			// unknown to the provider, not merged
+			reversedPeopleDict[id] = strings.Join(devParts, "|")
+			continue
+		}
+		// the canonical name goes first, then the other names, then the emails
+		seen := map[string]bool{strings.ToLower(names[id]): true}
+		var otherNames, devEmails []string
+		for _, part := range devParts {
+			if seen[strings.ToLower(part)] {
+				continue
+			}
+			seen[strings.ToLower(part)] = true
+			if strings.Contains(part, "@") {
+				devEmails = append(devEmails, part)
+			} else {
+				otherNames = append(otherNames, part)
+			}
+		}
+		sort.Strings(otherNames)
+		sort.Strings(devEmails)
+		if names[id] != "" {
+			otherNames = append([]string{names[id]}, otherNames...)
+		}
+		reversedPeopleDict[id] = strings.Join(append(otherNames, devEmails...), "|")
+	}
+	for key, id := range detector.PeopleDict {
+		detector.PeopleDict[key] = remap[id]
+	}
+	for id, name := range names {
+		if name != "" {
+			detector.PeopleDict[strings.ToLower(name)] = id
+		}
+	}
+	detector.ReversedPeopleDict = reversedPeopleDict
+	return teams, nil
+}
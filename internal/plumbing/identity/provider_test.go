@@ -0,0 +1,93 @@
+package identity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const identitiesCSV = `email,name,team
+# the directory export
+Alice@Example.com, Alice Liddell, backend
+bob@example.com,Alice Liddell
+carol@example.com,,frontend
+`
+
+func TestCSVIdentityProvider(t *testing.T) {
+	provider, err := ReadCSVIdentityProvider(strings.NewReader(identitiesCSV))
+	require.NoError(t, err)
+	identities, err := provider.Lookup([]string{"alice@example.com", "BOB@example.com", "dave@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ProvidedIdentity{
+		"alice@example.com": {Name: "Alice Liddell", Team: "backend"},
+		"bob@example.com":   {Name: "Alice Liddell"},
+	}, identities)
+	_, err = ReadCSVIdentityProvider(strings.NewReader("a,\"b"))
+	assert.Error(t, err)
+}
+
+func TestHTTPIdentityProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var request struct {
+			Emails []string `json:"emails"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, []string{"alice@example.com", "dave@example.com"}, request.Emails)
+		_, _ = w.Write([]byte(`{"Alice@example.com": {"name": "Alice Liddell", "team": "backend"}}`))
+	}))
+	defer server.Close()
+	provider, err := NewIdentityProvider(server.URL)
+	require.NoError(t, err)
+	identities, err := provider.Lookup([]string{"alice@example.com", "dave@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ProvidedIdentity{
+		"alice@example.com": {Name: "Alice Liddell", Team: "backend"},
+	}, identities)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	_, err = (&HTTPIdentityProvider{URL: failing.URL}).Lookup([]string{"alice@example.com"})
+	assert.Error(t, err)
+}
+
+func TestDescriptionEmails(t *testing.T) {
+	assert.Equal(t, []string{"a@b.com", "c@d.com"}, descriptionEmails("alice|a@b.com|C@d.com"))
+	assert.Equal(t, []string{"a@b.com"}, descriptionEmails("alice <A@b.com>"))
+	assert.Nil(t, descriptionEmails("alice"))
+}
+
+func TestPeopleDetectorIdentityService(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "identities.csv")
+	require.NoError(t, os.WriteFile(path, []byte(identitiesCSV), 0o644))
+	commits := fixtureCoAuthoredCommits()
+	id := &PeopleDetector{}
+	facts := map[string]interface{}{
+		core.ConfigPipelineCommits:            commits,
+		ConfigIdentityDetectorIdentityService: path,
+	}
+	require.NoError(t, id.Configure(facts))
+	// alice and bob are the same person according to the directory
+	assert.Equal(t, []string{"Alice Liddell|alice|bob|alice@example.com|bob@example.com"},
+		id.ReversedPeopleDict)
+	assert.Equal(t, id.ReversedPeopleDict, facts[FactIdentityDetectorReversedPeopleDict])
+	assert.Equal(t, []string{"backend"}, facts[FactIdentityDetectorTeams])
+	assert.Equal(t, 0, id.PeopleDict["bob@example.com"])
+	assert.Equal(t, 0, id.PeopleDict["alice liddell"])
+
+	id = &PeopleDetector{}
+	assert.Error(t, id.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:            commits,
+		ConfigIdentityDetectorIdentityService: filepath.Join(t.TempDir(), "missing.csv"),
+	}))
+}
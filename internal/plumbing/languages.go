@@ -15,6 +15,7 @@ import (
 // LanguagesDetection run programming language detection over the changed files.
 type LanguagesDetection struct {
 	core.NoopMerger
+	core.NoopCheckpointer
 
 	l core.Logger
 }
@@ -43,6 +44,11 @@ func (langs *LanguagesDetection) Requires() []string {
 	return []string{DependencyTreeChanges, DependencyBlobCache}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (langs *LanguagesDetection) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (langs *LanguagesDetection) ListConfigurationOptions() []core.ConfigurationOption {
 	return []core.ConfigurationOption{}
@@ -105,6 +111,11 @@ func (langs *LanguagesDetection) Fork(n int) []core.PipelineItem {
 
 // detectLanguage returns the programming language of a blob.
 func (langs *LanguagesDetection) detectLanguage(name string, blob *CachedBlob) string {
+	return detectLanguage(name, blob)
+}
+
+// detectLanguage returns the programming language of a blob.
+func detectLanguage(name string, blob *CachedBlob) string {
 	_, err := blob.CountLines()
 	if err == ErrorBinary {
 		return ""
@@ -113,6 +124,18 @@ func (langs *LanguagesDetection) detectLanguage(name string, blob *CachedBlob) s
 	return normalizeLanguage(name, lang)
 }
 
+// LanguageOfName returns the programming language of a file judging by its name alone,
+// or an empty string if the name is not recognized. It serves the analyses which do not
+// have the contents of the files.
+func LanguageOfName(name string) string {
+	base := path.Base(name)
+	lang, _ := enry.GetLanguageByFilename(base)
+	if lang == "" {
+		lang, _ = enry.GetLanguageByExtension(base)
+	}
+	return normalizeLanguage(name, lang)
+}
+
 func normalizeLanguage(name string, lang string) string {
 	filename := strings.ToLower(name)
 	switch {
@@ -122,3 +122,11 @@ func TestLanguagesDetectionGherkinLowercase(t *testing.T) {
 	lang := ls.detectLanguage("login.feature", &CachedBlob{Data: []byte("Feature: Login\n  Scenario: User logs in\n")})
 	assert.Equal(t, "gherkin", lang)
 }
+
+func TestLanguageOfName(t *testing.T) {
+	assert.Equal(t, "Go", LanguageOfName("internal/core/pipeline.go"))
+	assert.Equal(t, "Python", LanguageOfName("python/labours/cli.py"))
+	assert.Equal(t, "TypeScript", LanguageOfName("web/component.tsx"))
+	assert.Equal(t, "Dockerfile", LanguageOfName("Dockerfile"))
+	assert.Equal(t, "", LanguageOfName("LICENSE.unknown-extension"))
+}
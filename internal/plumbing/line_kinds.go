@@ -0,0 +1,114 @@
+package plumbing
+
+import (
+	"bytes"
+
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+// LineKind is the classification of a source code line.
+type LineKind = core.LineKind
+
+const (
+	// LineKindCode is a line with code, including the lines which mix code with a comment.
+	LineKindCode = core.LineKindCode
+	// LineKindComment is a line which contains only a comment.
+	LineKindComment = core.LineKindComment
+	// LineKindBlank is an empty or whitespace-only line.
+	LineKindBlank = core.LineKindBlank
+)
+
+// CommentSyntax describes how a programming language marks the comments.
+type CommentSyntax struct {
+	Line       []string
+	BlockStart string
+	BlockEnd   string
+}
+
+var (
+	cStyleComments    = CommentSyntax{Line: []string{"//"}, BlockStart: "/*", BlockEnd: "*/"}
+	hashStyleComments = CommentSyntax{Line: []string{"#"}}
+	dashStyleComments = CommentSyntax{Line: []string{"--"}}
+	xmlStyleComments  = CommentSyntax{BlockStart: "<!--", BlockEnd: "-->"}
+	// CommentSyntaxTable maps the languages reported by LanguagesDetection to their comments.
+	CommentSyntaxTable = map[string]CommentSyntax{
+		"C": cStyleComments, "C++": cStyleComments, "C#": cStyleComments, "Go": cStyleComments,
+		"Java": cStyleComments, "JavaScript": cStyleComments, "TypeScript": cStyleComments,
+		"TSX": cStyleComments, "Kotlin": cStyleComments, "Scala": cStyleComments,
+		"Swift": cStyleComments, "Rust": cStyleComments, "Dart": cStyleComments,
+		"Objective-C": cStyleComments, "Groovy": cStyleComments, "Protocol Buffer": cStyleComments,
+		"CSS":    {BlockStart: "/*", BlockEnd: "*/"},
+		"PHP":    {Line: []string{"//", "#"}, BlockStart: "/*", BlockEnd: "*/"},
+		"Python": {Line: []string{"#"}, BlockStart: `"""`, BlockEnd: `"""`},
+		"Ruby":   {Line: []string{"#"}, BlockStart: "=begin", BlockEnd: "=end"},
+		"Shell":  hashStyleComments, "Perl": hashStyleComments, "R": hashStyleComments,
+		"YAML": hashStyleComments, "Makefile": hashStyleComments, "Dockerfile": hashStyleComments,
+		"CMake": hashStyleComments, "Nix": hashStyleComments, "Elixir": hashStyleComments,
+		"SQL":     {Line: []string{"--"}, BlockStart: "/*", BlockEnd: "*/"},
+		"Lua":     {Line: []string{"--"}, BlockStart: "--[[", BlockEnd: "]]"},
+		"Haskell": {Line: []string{"--"}, BlockStart: "{-", BlockEnd: "-}"},
+		"Ada":     dashStyleComments, "Elm": dashStyleComments,
+		"HTML": xmlStyleComments, "XML": xmlStyleComments, "Vue": xmlStyleComments,
+		"Clojure": {Line: []string{";"}}, "Emacs Lisp": {Line: []string{";"}},
+		"Erlang": {Line: []string{"%"}}, "TeX": {Line: []string{"%"}},
+		"Fortran": {Line: []string{"!"}}, "Vim script": {Line: []string{`"`}},
+	}
+)
+
+// ClassifyLines returns the kind of each line in the source code. The lines are split
+// the same way as in the diffs: the trailing newline does not start another line.
+func ClassifyLines(data []byte, syntax CommentSyntax) []LineKind {
+	lines := bytes.Split(data, []byte{'\n'})
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	kinds := make([]LineKind, len(lines))
+	inBlock := false
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			kinds[i] = LineKindBlank
+			continue
+		}
+		if inBlock {
+			kinds[i] = LineKindComment
+			if end := bytes.Index(line, []byte(syntax.BlockEnd)); end >= 0 {
+				inBlock = false
+				if len(bytes.TrimSpace(line[end+len(syntax.BlockEnd):])) > 0 {
+					kinds[i] = LineKindCode
+				}
+			}
+			continue
+		}
+		isComment := false
+		// the block comments go first because "--[[" in Lua also starts with the line comment
+		if syntax.BlockStart != "" && bytes.HasPrefix(line, []byte(syntax.BlockStart)) {
+			isComment = true
+			rest := line[len(syntax.BlockStart):]
+			if end := bytes.Index(rest, []byte(syntax.BlockEnd)); end < 0 {
+				inBlock = true
+			} else if len(bytes.TrimSpace(rest[end+len(syntax.BlockEnd):])) > 0 {
+				isComment = false
+			}
+		} else {
+			for _, prefix := range syntax.Line {
+				if bytes.HasPrefix(line, []byte(prefix)) {
+					isComment = true
+					break
+				}
+			}
+		}
+		if isComment {
+			kinds[i] = LineKindComment
+		} else {
+			kinds[i] = LineKindCode
+		}
+	}
+	return kinds
+}
+
+// ClassifyBlob returns the kind of each line in the file. The comments are not recognized
+// if the language is unknown.
+func ClassifyBlob(name string, blob *CachedBlob) []LineKind {
+	return ClassifyLines(blob.Data, CommentSyntaxTable[detectLanguage(name, blob)])
+}
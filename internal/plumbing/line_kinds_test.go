@@ -0,0 +1,20 @@
+package plumbing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyLines(t *testing.T) {
+	code := "package main\n\n  // comment\n/* block\n   still */\nx := 1 // trailing\n/* a */ y := 2\n\t\n"
+	assert.Equal(t, []LineKind{
+		LineKindCode, LineKindBlank, LineKindComment, LineKindComment, LineKindComment,
+		LineKindCode, LineKindCode, LineKindBlank,
+	}, ClassifyLines([]byte(code), CommentSyntaxTable["Go"]))
+	assert.Equal(t, []LineKind{LineKindCode, LineKindBlank, LineKindCode},
+		ClassifyLines([]byte("// not a comment\n\nx"), CommentSyntax{}))
+	assert.Equal(t, []LineKind{LineKindComment, LineKindComment, LineKindCode},
+		ClassifyLines([]byte("--[[ long\n]]\nprint(1)\n"), CommentSyntaxTable["Lua"]))
+	assert.Len(t, ClassifyLines(nil, CommentSyntaxTable["Go"]), 0)
+}
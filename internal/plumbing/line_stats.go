@@ -14,6 +14,9 @@ import (
 // LinesStatsCalculator measures line statistics for each text file in the commit.
 type LinesStatsCalculator struct {
 	core.NoopMerger
+	core.NoopCheckpointer
+	// ClassifyLines enables counting the comment and blank lines among the changed ones.
+	ClassifyLines bool
 
 	l core.Logger
 }
@@ -26,12 +29,57 @@ type LineStats struct {
 	Removed int
 	// Changed is the number of changed lines by a particular developer in a particular day.
 	Changed int
+	// Comments are the comment lines among Added, Removed and Changed. They are counted only
+	// if LinesStatsCalculator.ClassifyLines is set and the language is in CommentSyntaxTable.
+	Comments LineCounts
+	// Blanks are the blank lines among Added, Removed and Changed. They are counted only
+	// if LinesStatsCalculator.ClassifyLines is set.
+	Blanks LineCounts
+}
+
+// LineCounts are the numbers of inserted, deleted and changed lines of the same kind.
+type LineCounts struct {
+	Added   int
+	Removed int
+	Changed int
+}
+
+// Code returns the numbers of the lines which are neither comments nor blank.
+func (stats LineStats) Code() LineCounts {
+	return LineCounts{
+		Added:   stats.Added - stats.Comments.Added - stats.Blanks.Added,
+		Removed: stats.Removed - stats.Comments.Removed - stats.Blanks.Removed,
+		Changed: stats.Changed - stats.Comments.Changed - stats.Blanks.Changed,
+	}
+}
+
+// Add returns the sum of the line stats, including the line kinds.
+func (stats LineStats) Add(other LineStats) LineStats {
+	return LineStats{
+		Added:    stats.Added + other.Added,
+		Removed:  stats.Removed + other.Removed,
+		Changed:  stats.Changed + other.Changed,
+		Comments: stats.Comments.add(other.Comments),
+		Blanks:   stats.Blanks.add(other.Blanks),
+	}
+}
+
+func (counts LineCounts) add(other LineCounts) LineCounts {
+	return LineCounts{
+		Added:   counts.Added + other.Added,
+		Removed: counts.Removed + other.Removed,
+		Changed: counts.Changed + other.Changed,
+	}
 }
 
 const (
 	// DependencyLineStats is the identifier of the data provided by LinesStatsCalculator - line
 	// statistics for each file in the commit.
 	DependencyLineStats = "line_stats"
+
+	// ConfigLinesStatsClassifyLines is the name of the option to count the comment and blank
+	// lines, see LinesStatsCalculator.ClassifyLines.
+	ConfigLinesStatsClassifyLines = "LinesStats.ClassifyLines"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -53,9 +101,23 @@ func (lsc *LinesStatsCalculator) Requires() []string {
 	return []string{DependencyTreeChanges, DependencyBlobCache, DependencyFileDiff}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (lsc *LinesStatsCalculator) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (lsc *LinesStatsCalculator) ListConfigurationOptions() []core.ConfigurationOption {
-	return nil
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigLinesStatsClassifyLines,
+		Description: "Classify the changed lines as code, comments or blank in the line stats " +
+			"of --devs, --commits-stat and --file-history and in the line history " +
+			"of --burndown and --codechurn.",
+		Flag:    "classify-lines",
+		Type:    core.BoolConfigurationOption,
+		Default: false,
+	}}
+	return options[:]
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -63,6 +125,9 @@ func (lsc *LinesStatsCalculator) Configure(facts map[string]interface{}) error {
 	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
 		lsc.l = l
 	}
+	if val, exists := facts[ConfigLinesStatsClassifyLines].(bool); exists {
+		lsc.ClassifyLines = val
+	}
 	return nil
 }
 
@@ -114,11 +179,13 @@ func (lsc *LinesStatsCalculator) Consume(deps map[string]interface{}) (map[strin
 				// binary
 				continue
 			}
-			result[change.To] = LineStats{
+			stats := LineStats{
 				Added:   lines,
 				Removed: 0,
 				Changed: 0,
 			}
+			stats.classify(lsc.classifyBlob(change.To.Name, blob), 0, lines, addedLines)
+			result[change.To] = stats
 		case merkletrie.Delete:
 			blob := cache[change.From.TreeEntry.Hash]
 			lines, err := blob.CountLines()
@@ -126,48 +193,89 @@ func (lsc *LinesStatsCalculator) Consume(deps map[string]interface{}) (map[strin
 				// binary
 				continue
 			}
-			result[change.From] = LineStats{
+			stats := LineStats{
 				Added:   0,
 				Removed: lines,
 				Changed: 0,
 			}
+			stats.classify(lsc.classifyBlob(change.From.Name, blob), 0, lines, removedLines)
+			result[change.From] = stats
 		case merkletrie.Modify:
 			thisDiffs := fileDiffs[change.To.Name]
-			var added, removed, changed, removedPending int
+			oldKinds := lsc.classifyBlob(change.From.Name, cache[change.From.TreeEntry.Hash])
+			newKinds := lsc.classifyBlob(change.To.Name, cache[change.To.TreeEntry.Hash])
+			var stats LineStats
+			// the positions of the current line in the old and the new file, the start of the deletion
+			var oldLine, newLine, removedStart, removedPending int
 			for _, edit := range thisDiffs.Diffs {
 				switch edit.Type {
 				case diffmatchpatch.DiffEqual:
 					if removedPending > 0 {
-						removed += removedPending
+						stats.Removed += removedPending
+						stats.classify(oldKinds, removedStart, removedPending, removedLines)
 					}
 					removedPending = 0
+					delta := utf8.RuneCountInString(edit.Text)
+					oldLine += delta
+					newLine += delta
 				case diffmatchpatch.DiffInsert:
 					delta := utf8.RuneCountInString(edit.Text)
 					if removedPending > delta {
-						changed += delta
-						removed += removedPending - delta
+						stats.Changed += delta
+						stats.classify(newKinds, newLine, delta, changedLines)
+						stats.Removed += removedPending - delta
+						stats.classify(oldKinds, removedStart+delta, removedPending-delta, removedLines)
 					} else {
-						changed += removedPending
-						added += delta - removedPending
+						stats.Changed += removedPending
+						stats.classify(newKinds, newLine, removedPending, changedLines)
+						stats.Added += delta - removedPending
+						stats.classify(newKinds, newLine+removedPending, delta-removedPending, addedLines)
 					}
 					removedPending = 0
+					newLine += delta
 				case diffmatchpatch.DiffDelete:
 					removedPending = utf8.RuneCountInString(edit.Text)
+					removedStart = oldLine
+					oldLine += removedPending
 				}
 			}
 			if removedPending > 0 {
-				removed += removedPending
-			}
-			result[change.To] = LineStats{
-				Added:   added,
-				Removed: removed,
-				Changed: changed,
+				stats.Removed += removedPending
+				stats.classify(oldKinds, removedStart, removedPending, removedLines)
 			}
+			result[change.To] = stats
 		}
 	}
 	return map[string]interface{}{DependencyLineStats: result}, nil
 }
 
+// classifyBlob returns the kinds of the lines in the blob if ClassifyLines is set.
+// The comments are not recognized if the language is unknown.
+func (lsc *LinesStatsCalculator) classifyBlob(name string, blob *CachedBlob) []LineKind {
+	if !lsc.ClassifyLines || blob == nil {
+		return nil
+	}
+	return ClassifyBlob(name, blob)
+}
+
+// addedLines, removedLines and changedLines select the field of LineCounts in classify().
+func addedLines(counts *LineCounts) *int   { return &counts.Added }
+func removedLines(counts *LineCounts) *int { return &counts.Removed }
+func changedLines(counts *LineCounts) *int { return &counts.Changed }
+
+// classify counts the comment and blank lines among kinds[start:start+count] in the field
+// of Comments and Blanks chosen by the selector. It does nothing if kinds is nil.
+func (stats *LineStats) classify(kinds []LineKind, start, count int, field func(*LineCounts) *int) {
+	for i := start; i < start+count && i < len(kinds); i++ {
+		switch kinds[i] {
+		case LineKindComment:
+			*field(&stats.Comments)++
+		case LineKindBlank:
+			*field(&stats.Blanks)++
+		}
+	}
+}
+
 // Fork clones this PipelineItem.
 func (lsc *LinesStatsCalculator) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(lsc, n)
@@ -10,6 +10,7 @@ import (
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"github.com/meko-christian/hercules/internal/test"
 	"github.com/meko-christian/hercules/internal/test/fixtures"
+	"github.com/sergi/go-diff/diffmatchpatch"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,10 +23,15 @@ func TestLinesStatsMeta(t *testing.T) {
 	assert.Equal(t, ra.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, ra.Requires()[1], items.DependencyBlobCache)
 	assert.Equal(t, ra.Requires()[2], items.DependencyFileDiff)
-	assert.Nil(t, ra.ListConfigurationOptions())
+	assert.Equal(t, core.ThreadSafe, core.GetThreadSafety(ra))
+	options := ra.ListConfigurationOptions()
+	assert.Len(t, options, 1)
+	assert.Equal(t, items.ConfigLinesStatsClassifyLines, options[0].Name)
+	assert.Equal(t, "classify-lines", options[0].Flag)
 	assert.NoError(t, ra.Configure(map[string]interface{}{
 		core.ConfigLogger: core.NewLogger(),
 	}))
+	assert.False(t, ra.ClassifyLines)
 	for _, f := range ra.Fork(10) {
 		assert.Equal(t, f, ra)
 	}
@@ -132,3 +138,81 @@ func TestLinesStatsConsume(t *testing.T) {
 		Changed: 0,
 	})
 }
+
+func TestLinesStatsClassifyLines(t *testing.T) {
+	lsc := &items.LinesStatsCalculator{}
+	assert.NoError(t, lsc.Configure(map[string]interface{}{
+		items.ConfigLinesStatsClassifyLines: true,
+	}))
+	assert.True(t, lsc.ClassifyLines)
+	assert.NoError(t, lsc.Initialize(test.Repository))
+
+	oldData := "package main\n\n// Main is the entry point.\nfunc main() {\n\tprintln()\n}\n"
+	newData := "// Copyright.\n\npackage main\n\n// Main is the entry point.\nfunc main() {\n}\n"
+	insertedData := "#!/bin/sh\n\n# say hello\necho hello\n"
+	oldHash := plumbing.NewHash("1111111111111111111111111111111111111111")
+	newHash := plumbing.NewHash("2222222222222222222222222222222222222222")
+	insertedHash := plumbing.NewHash("3333333333333333333333333333333333333333")
+	cache := map[plumbing.Hash]*items.CachedBlob{
+		oldHash:      {Data: []byte(oldData)},
+		newHash:      {Data: []byte(newData)},
+		insertedHash: {Data: []byte(insertedData)},
+	}
+	modified := &object.Change{
+		From: object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{Name: "main.go", Hash: oldHash}},
+		To:   object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{Name: "main.go", Hash: newHash}},
+	}
+	inserted := &object.Change{
+		To: object.ChangeEntry{Name: "hello.sh", TreeEntry: object.TreeEntry{Name: "hello.sh", Hash: insertedHash}},
+	}
+	dmp := diffmatchpatch.New()
+	src, dst, _ := dmp.DiffLinesToRunes(oldData, newData)
+	fileDiffs := map[string]items.FileDiffData{
+		"main.go": {Diffs: dmp.DiffMainRunes(src, dst, false)},
+	}
+	res, err := lsc.Consume(map[string]interface{}{
+		items.DependencyTreeChanges: object.Changes{modified, inserted},
+		items.DependencyBlobCache:   cache,
+		items.DependencyFileDiff:    fileDiffs,
+	})
+	assert.NoError(t, err)
+	stats := res[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	// the license comment and a blank line are added, the body line is removed
+	assert.Equal(t, items.LineStats{
+		Added: 2, Removed: 1, Changed: 0,
+		Comments: items.LineCounts{Added: 1},
+		Blanks:   items.LineCounts{Added: 1},
+	}, stats[modified.To])
+	assert.Equal(t, items.LineCounts{Added: 0, Removed: 1, Changed: 0}, stats[modified.To].Code())
+	assert.Equal(t, items.LineStats{
+		Added:    4,
+		Comments: items.LineCounts{Added: 2},
+		Blanks:   items.LineCounts{Added: 1},
+	}, stats[inserted.To])
+
+	lsc.ClassifyLines = false
+	res, err = lsc.Consume(map[string]interface{}{
+		items.DependencyTreeChanges: object.Changes{modified, inserted},
+		items.DependencyBlobCache:   cache,
+		items.DependencyFileDiff:    fileDiffs,
+	})
+	assert.NoError(t, err)
+	stats = res[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	assert.Equal(t, items.LineStats{Added: 2, Removed: 1}, stats[modified.To])
+}
+
+func TestLineStatsAdd(t *testing.T) {
+	sum := items.LineStats{
+		Added: 3, Removed: 2, Changed: 1,
+		Comments: items.LineCounts{Added: 1},
+	}.Add(items.LineStats{
+		Added: 1, Removed: 1, Changed: 1,
+		Blanks: items.LineCounts{Removed: 1},
+	})
+	assert.Equal(t, items.LineStats{
+		Added: 4, Removed: 3, Changed: 2,
+		Comments: items.LineCounts{Added: 1},
+		Blanks:   items.LineCounts{Removed: 1},
+	}, sum)
+	assert.Equal(t, items.LineCounts{Added: 3, Removed: 2, Changed: 2}, sum.Code())
+}
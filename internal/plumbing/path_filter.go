@@ -0,0 +1,450 @@
+package plumbing
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+// PathFilter removes the files which should not be analysed from the tree changes before
+// the rest of the pipeline sees them: the paths matching the exclusion globs or not matching
+// the inclusion globs, the files marked as vendored, generated or documentation in .gitattributes
+// the same way GitHub Linguist does, and the blobs bigger than the size limit, which are usually
+// binary. A file which stops passing the filter is reported as deleted and a file which starts
+// passing it is reported as added, so the analyses keep consistent state.
+// PathFilter is a PipelineItem.
+type PathFilter struct {
+	core.NoopMerger
+	// Include is the list of glob patterns. If it is not empty, only the matching files pass.
+	Include []string
+	// Exclude is the list of glob patterns of the skipped files. It takes precedence over Include.
+	Exclude []string
+	// IgnoreAttributes disables reading the linguist-* rules from .gitattributes.
+	IgnoreAttributes bool
+	// MaxFileSize is the maximum size of a blob in bytes. 0 disables the limit.
+	MaxFileSize int64
+
+	include    []gitattributes.Pattern
+	exclude    []gitattributes.Pattern
+	attributes map[string][]gitattributes.MatchAttribute
+	// root is the directory structure of the previous commit.
+	root       *pathFilterDir
+	repository *git.Repository
+
+	l core.Logger
+}
+
+const (
+	// ConfigPathFilterInclude is the name of the configuration option (PathFilter.Configure())
+	// which sets the glob patterns of the analysed files.
+	ConfigPathFilterInclude = "PathFilter.Include"
+	// ConfigPathFilterExclude is the name of the configuration option (PathFilter.Configure())
+	// which sets the glob patterns of the skipped files.
+	ConfigPathFilterExclude = "PathFilter.Exclude"
+	// ConfigPathFilterIgnoreAttributes is the name of the configuration option
+	// (PathFilter.Configure()) which disables the .gitattributes rules.
+	ConfigPathFilterIgnoreAttributes = "PathFilter.IgnoreAttributes"
+	// ConfigPathFilterMaxFileSize is the name of the configuration option
+	// (PathFilter.Configure()) which sets the maximum size of the analysed blobs.
+	ConfigPathFilterMaxFileSize = "PathFilter.MaxFileSize"
+)
+
+// pathFilterDir is the directory structure which PathFilter.loadAttributes() walks instead of
+// the trees. The nodes are immutable and shared between the commits, so only the changed trees
+// are read on each commit.
+type pathFilterDir struct {
+	hash plumbing.Hash
+	// attributes is the hash of .gitattributes in the directory, zero if there is none.
+	attributes plumbing.Hash
+	// nested indicates whether the directory or any of its subdirectories has .gitattributes.
+	nested  bool
+	subdirs map[string]*pathFilterDir
+}
+
+// pathFilterAttributes are the .gitattributes which exclude the files from the analysis.
+var pathFilterAttributes = []string{
+	"linguist-vendored",
+	"linguist-generated",
+	"linguist-documentation",
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (pf *PathFilter) Name() string {
+	return "PathFilter"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (pf *PathFilter) Provides() []string {
+	return []string{DependencyTreeChanges}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (pf *PathFilter) Requires() []string {
+	return []string{DependencyTreeChanges}
+}
+
+func (*PathFilter) Features() []string {
+	return []string{core.FeatureGitCommits}
+}
+
+// ConflictsWith returns the features which cannot be enabled together with this item:
+// the stub repository of git.stub has no trees to read .gitattributes from.
+func (*PathFilter) ConflictsWith() []string {
+	return []string{core.FeatureGitStub}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (pf *PathFilter) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{
+		{
+			Name: ConfigPathFilterInclude,
+			Description: "Glob patterns of the files to analyze, e.g. 'src/**'. " +
+				"Separated with commas \",\".",
+			Flag:    "include",
+			Type:    core.StringsConfigurationOption,
+			Default: []string{},
+		}, {
+			Name: ConfigPathFilterExclude,
+			Description: "Glob patterns of the files to skip, e.g. 'vendor/**' or '*.min.js'. " +
+				"Separated with commas \",\".",
+			Flag:    "exclude",
+			Type:    core.StringsConfigurationOption,
+			Default: []string{},
+		}, {
+			Name: ConfigPathFilterIgnoreAttributes,
+			Description: "Do not skip the files marked as linguist-vendored, linguist-generated " +
+				"or linguist-documentation in .gitattributes.",
+			Flag:    "ignore-gitattributes",
+			Type:    core.BoolConfigurationOption,
+			Default: false,
+		}, {
+			Name:        ConfigPathFilterMaxFileSize,
+			Description: "Skip the files bigger than this size in bytes. 0 disables the limit.",
+			Flag:        "max-file-size",
+			Type:        core.IntConfigurationOption,
+			Default:     0,
+		},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (pf *PathFilter) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		pf.l = l
+	}
+	if val, exists := facts[ConfigPathFilterInclude].([]string); exists {
+		pf.Include = val
+	}
+	if val, exists := facts[ConfigPathFilterExclude].([]string); exists {
+		pf.Exclude = val
+	}
+	if val, exists := facts[ConfigPathFilterIgnoreAttributes].(bool); exists {
+		pf.IgnoreAttributes = val
+	}
+	if val, exists := facts[ConfigPathFilterMaxFileSize].(int); exists {
+		pf.MaxFileSize = int64(val)
+	}
+	return nil
+}
+
+func (*PathFilter) ConfigureUpstream(map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (pf *PathFilter) Initialize(repository *git.Repository) error {
+	pf.l = core.NewLogger()
+	pf.include = compilePathGlobs(pf.Include)
+	pf.exclude = compilePathGlobs(pf.Exclude)
+	pf.attributes = map[string][]gitattributes.MatchAttribute{}
+	pf.root = nil
+	pf.repository = repository
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (pf *PathFilter) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[DependencyTreeChanges].(object.Changes)
+	var matchers [2]gitattributes.Matcher
+	if !pf.IgnoreAttributes {
+		root, err := pf.readDir(deps[core.DependencyCommit].(*object.Commit).TreeHash, pf.root)
+		if err != nil {
+			return nil, err
+		}
+		// the deleted files are checked against the rules of the previous commit
+		previousRoot := pf.root
+		pf.root = root
+		var fromNames, toNames []string
+		for _, change := range changes {
+			if change.From.Name != "" {
+				fromNames = append(fromNames, change.From.Name)
+			}
+			if change.To.Name != "" {
+				toNames = append(toNames, change.To.Name)
+			}
+		}
+		if previousRoot != nil {
+			if matchers[0], err = pf.loadAttributes(previousRoot, fromNames); err != nil {
+				return nil, err
+			}
+		}
+		if matchers[1], err = pf.loadAttributes(root, toNames); err != nil {
+			return nil, err
+		}
+	}
+	if len(pf.include) == 0 && len(pf.exclude) == 0 && pf.MaxFileSize <= 0 &&
+		matchers[0] == nil && matchers[1] == nil {
+		return map[string]interface{}{DependencyTreeChanges: changes}, nil
+	}
+	filtered := make(object.Changes, 0, len(changes))
+	for _, change := range changes {
+		passFrom := change.From.Name != "" && pf.passes(change.From, matchers[0])
+		passTo := change.To.Name != "" && pf.passes(change.To, matchers[1])
+		switch {
+		case passFrom && passTo, passFrom && change.To.Name == "", passTo && change.From.Name == "":
+			filtered = append(filtered, change)
+		case passFrom:
+			filtered = append(filtered, &object.Change{From: change.From})
+		case passTo:
+			filtered = append(filtered, &object.Change{To: change.To})
+		}
+	}
+	return map[string]interface{}{DependencyTreeChanges: filtered}, nil
+}
+
+// Fork clones this PipelineItem.
+func (pf *PathFilter) Fork(n int) []core.PipelineItem {
+	return core.ForkCopyPipelineItem(pf, n)
+}
+
+// passes returns whether the file should be analysed.
+func (pf *PathFilter) passes(entry object.ChangeEntry, matcher gitattributes.Matcher) bool {
+	parts := strings.Split(entry.Name, "/")
+	for _, pattern := range pf.exclude {
+		if pattern.Match(parts) {
+			return false
+		}
+	}
+	if len(pf.include) > 0 {
+		included := false
+		for _, pattern := range pf.include {
+			if pattern.Match(parts) {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false
+		}
+	}
+	if matcher != nil {
+		attrs, _ := matcher.Match(parts, pathFilterAttributes)
+		for _, attr := range attrs {
+			if attr.IsSet() || (attr.IsValueSet() && attr.Value() == "true") {
+				return false
+			}
+		}
+	}
+	if pf.MaxFileSize > 0 {
+		// the size of a missing blob, e.g. a submodule, is unknown, so the file passes
+		size, err := pf.repository.Storer.EncodedObjectSize(entry.TreeEntry.Hash)
+		if err == nil && size > pf.MaxFileSize {
+			return false
+		}
+	}
+	return true
+}
+
+// readDir returns the directory structure of the tree with the specified hash. The subtrees
+// which did not change since `previous` are reused instead of being read again.
+func (pf *PathFilter) readDir(hash plumbing.Hash, previous *pathFilterDir) (*pathFilterDir, error) {
+	if previous != nil && previous.hash == hash {
+		return previous, nil
+	}
+	tree, err := pf.repository.TreeObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	node := &pathFilterDir{hash: hash, subdirs: map[string]*pathFilterDir{}}
+	for _, entry := range tree.Entries {
+		switch {
+		case entry.Mode == filemode.Dir:
+			var previousChild *pathFilterDir
+			if previous != nil {
+				previousChild = previous.subdirs[entry.Name]
+			}
+			child, err := pf.readDir(entry.Hash, previousChild)
+			if err != nil {
+				return nil, err
+			}
+			node.subdirs[entry.Name] = child
+			node.nested = node.nested || child.nested
+		case entry.Name == ".gitattributes" && entry.Mode.IsFile():
+			node.attributes = entry.Hash
+			node.nested = true
+		}
+	}
+	return node, nil
+}
+
+// loadAttributes reads .gitattributes in the directories of the files and in all their parents.
+// It returns nil if there are no rules.
+func (pf *PathFilter) loadAttributes(root *pathFilterDir, names []string) (gitattributes.Matcher, error) {
+	if !root.nested {
+		return nil, nil
+	}
+	dirs := map[string]plumbing.Hash{}
+	if !root.attributes.IsZero() {
+		dirs[""] = root.attributes
+	}
+	for _, name := range names {
+		node := root
+		parts := strings.Split(name, "/")
+		// the subtrees without .gitattributes are not walked
+		for i := 0; i < len(parts)-1 && node.nested; i++ {
+			if node = node.subdirs[parts[i]]; node == nil {
+				break
+			}
+			if !node.attributes.IsZero() {
+				dirs[strings.Join(parts[:i+1], "/")] = node.attributes
+			}
+		}
+	}
+	sortedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sortedDirs = append(sortedDirs, dir)
+	}
+	// the rules in the nested directories have the higher priority
+	sort.Slice(sortedDirs, func(i, j int) bool {
+		di, dj := strings.Count(sortedDirs[i], "/"), strings.Count(sortedDirs[j], "/")
+		if sortedDirs[i] == "" || sortedDirs[j] == "" {
+			return sortedDirs[i] == "" && sortedDirs[j] != ""
+		}
+		if di != dj {
+			return di < dj
+		}
+		return sortedDirs[i] < sortedDirs[j]
+	})
+	var stack []gitattributes.MatchAttribute
+	for _, dir := range sortedDirs {
+		attrs, err := pf.readAttributes(dir, dirs[dir])
+		if err != nil {
+			return nil, err
+		}
+		stack = append(stack, attrs...)
+	}
+	if len(stack) == 0 {
+		return nil, nil
+	}
+	return gitattributes.NewMatcher(stack), nil
+}
+
+// readAttributes parses .gitattributes in the directory. The results are cached.
+func (pf *PathFilter) readAttributes(dir string, hash plumbing.Hash) ([]gitattributes.MatchAttribute, error) {
+	key := dir + "/" + hash.String()
+	if attrs, exists := pf.attributes[key]; exists {
+		return attrs, nil
+	}
+	blob, err := pf.repository.BlobObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, err
+	}
+	contents, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+	var domain []string
+	if dir != "" {
+		domain = strings.Split(dir, "/")
+	}
+	var attrs []gitattributes.MatchAttribute
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+		// skip the comments and the quoted patterns; the patterns with the trailing slash
+		// match only the directories and do not apply to the files inside
+		if len(fields) < 2 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "\"") ||
+			strings.HasSuffix(fields[0], "/") {
+			continue
+		}
+		attr, err := gitattributes.ParseAttributesLine(line, domain, dir == "")
+		if err != nil {
+			pf.l.Warnf("%s/.gitattributes: %v: %s", dir, err, line)
+			continue
+		}
+		attrs = append(attrs, attr)
+	}
+	pf.attributes[key] = attrs
+	return attrs, nil
+}
+
+// compilePathGlobs parses the glob patterns which follow the .gitignore syntax:
+// "*.go" matches in any directory, "a/*.go" is anchored at the root, "**" matches any number
+// of directories and the trailing slash selects everything inside the directory.
+func compilePathGlobs(globs []string) []gitattributes.Pattern {
+	patterns := make([]gitattributes.Pattern, 0, len(globs))
+	for _, glob := range globs {
+		glob = strings.TrimPrefix(strings.TrimSpace(glob), "/")
+		if glob == "" {
+			continue
+		}
+		if strings.HasSuffix(glob, "/") {
+			glob += "**"
+		}
+		patterns = append(patterns, gitattributes.ParsePattern(glob, nil))
+	}
+	return patterns
+}
+
+// Checkpoint writes the hash of the tree of the previous commit.
+func (pf *PathFilter) Checkpoint(writer io.Writer) error {
+	var hash plumbing.Hash
+	if pf.root != nil {
+		hash = pf.root.hash
+	}
+	_, err := writer.Write(hash[:])
+	return err
+}
+
+// Restore reads the tree hash which Checkpoint() wrote and loads the directory structure.
+func (pf *PathFilter) Restore(reader io.Reader) error {
+	var hash plumbing.Hash
+	if _, err := io.ReadFull(reader, hash[:]); err != nil {
+		return err
+	}
+	if hash.IsZero() {
+		return nil
+	}
+	root, err := pf.readDir(hash, nil)
+	if err != nil {
+		return err
+	}
+	pf.root = root
+	return nil
+}
+
+func init() {
+	core.Registry.Register(&PathFilter{})
+}
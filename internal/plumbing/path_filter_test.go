@@ -0,0 +1,214 @@
+package plumbing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixturePathFilterRepository creates three commits. The first has vendored, generated,
+// documentation and big files, the second marks main.go as vendored, the third adds
+// the documentation and the code to docs/, which did not change since the first.
+func fixturePathFilterRepository(t *testing.T) (*git.Repository, []*object.Commit) {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	signature := &object.Signature{
+		Name: "Alice", Email: "alice@example.com", When: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	var commits []*object.Commit
+	commit := func(files map[string]string) {
+		for name, contents := range files {
+			require.NoError(t, util.WriteFile(fs, name, []byte(contents), 0o644))
+		}
+		require.NoError(t, worktree.AddWithOptions(&git.AddOptions{All: true}))
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature})
+		require.NoError(t, err)
+		obj, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		commits = append(commits, obj)
+		signature.When = signature.When.AddDate(0, 0, 1)
+	}
+	commit(map[string]string{
+		".gitattributes": "*.pb.go linguist-generated=true\n# comment\n" +
+			"docs/ linguist-documentation\n\"\"\n\"quoted\" linguist-vendored\n",
+		"docs/.gitattributes": "*.md linguist-documentation\n",
+		"docs/guide.md":       "# Guide\n",
+		"main.go":             "package main\n",
+		"api.pb.go":           "package main\n",
+		"vendor/lib.go":       "package lib\n",
+		"big.bin":             strings.Repeat("x", 2000),
+	})
+	commit(map[string]string{
+		".gitattributes": "*.pb.go linguist-generated=true\nmain.go linguist-vendored\n",
+		"main.go":        "package main\n\nfunc main() {}\n",
+		"vendor/lib.go":  "package lib\n\nfunc Lib() {}\n",
+	})
+	commit(map[string]string{
+		"docs/api.md":      "# API\n",
+		"docs/example.go":  "package docs\n",
+		"docs/sub/more.md": "# More\n",
+	})
+	return repository, commits
+}
+
+func filterPaths(t *testing.T, pf *PathFilter, repository *git.Repository, commits []*object.Commit) []string {
+	treediff := &TreeDiff{}
+	require.NoError(t, treediff.Initialize(repository))
+	require.NoError(t, pf.Initialize(repository))
+	var names []string
+	for _, commit := range commits {
+		deps := map[string]interface{}{core.DependencyCommit: commit}
+		res, err := treediff.Consume(deps)
+		require.NoError(t, err)
+		deps[DependencyTreeChanges] = res[DependencyTreeChanges]
+		res, err = pf.Consume(deps)
+		require.NoError(t, err)
+		names = names[:0]
+		for _, change := range res[DependencyTreeChanges].(object.Changes) {
+			action, err := change.Action()
+			require.NoError(t, err)
+			name := change.To.Name
+			if name == "" {
+				name = change.From.Name
+			}
+			names = append(names, action.String()[:1]+" "+name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestPathFilterMeta(t *testing.T) {
+	pf := &PathFilter{}
+	assert.Equal(t, "PathFilter", pf.Name())
+	assert.Equal(t, []string{DependencyTreeChanges}, pf.Provides())
+	assert.Equal(t, []string{DependencyTreeChanges}, pf.Requires())
+	assert.Equal(t, []string{core.FeatureGitCommits}, pf.Features())
+	assert.Equal(t, []string{core.FeatureGitStub}, pf.ConflictsWith())
+	opts := pf.ListConfigurationOptions()
+	assert.Len(t, opts, 4)
+	assert.Equal(t, ConfigPathFilterInclude, opts[0].Name)
+	assert.Equal(t, ConfigPathFilterExclude, opts[1].Name)
+	assert.Equal(t, ConfigPathFilterIgnoreAttributes, opts[2].Name)
+	assert.Equal(t, ConfigPathFilterMaxFileSize, opts[3].Name)
+	logger := core.NewLogger()
+	assert.NoError(t, pf.Configure(map[string]interface{}{
+		core.ConfigLogger:                logger,
+		ConfigPathFilterInclude:          []string{"src/**"},
+		ConfigPathFilterExclude:          []string{"vendor/**"},
+		ConfigPathFilterIgnoreAttributes: true,
+		ConfigPathFilterMaxFileSize:      1000,
+	}))
+	assert.Equal(t, logger, pf.l)
+	assert.Equal(t, []string{"src/**"}, pf.Include)
+	assert.Equal(t, []string{"vendor/**"}, pf.Exclude)
+	assert.True(t, pf.IgnoreAttributes)
+	assert.Equal(t, int64(1000), pf.MaxFileSize)
+}
+
+func TestPathFilterRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&PathFilter{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, "PathFilter", summoned[0].Name())
+	summoned = core.Registry.Summon(DependencyTreeChanges)
+	matched := false
+	for _, tp := range summoned {
+		matched = matched || tp.Name() == "PathFilter"
+	}
+	assert.True(t, matched)
+}
+
+func TestPathFilterAttributes(t *testing.T) {
+	repository, commits := fixturePathFilterRepository(t)
+	assert.Equal(t, []string{
+		"I .gitattributes", "I big.bin", "I docs/.gitattributes", "I main.go", "I vendor/lib.go",
+	}, filterPaths(t, &PathFilter{}, repository, commits[:1]))
+	// main.go becomes vendored and is reported as deleted
+	assert.Equal(t, []string{
+		"D main.go", "M .gitattributes", "M vendor/lib.go",
+	}, filterPaths(t, &PathFilter{}, repository, commits[:2]))
+	assert.Equal(t, []string{
+		"M .gitattributes", "M main.go", "M vendor/lib.go",
+	}, filterPaths(t, &PathFilter{IgnoreAttributes: true}, repository, commits[:2]))
+	// docs/.gitattributes of the unchanged directory applies in the nested directories too
+	assert.Equal(t, []string{"I docs/example.go"},
+		filterPaths(t, &PathFilter{}, repository, commits))
+}
+
+func TestPathFilterGlobsAndSize(t *testing.T) {
+	repository, commits := fixturePathFilterRepository(t)
+	assert.Equal(t, []string{"I .gitattributes", "I docs/.gitattributes", "I main.go"},
+		filterPaths(t, &PathFilter{Exclude: []string{"vendor/"}, MaxFileSize: 1000},
+			repository, commits[:1]))
+	assert.Equal(t, []string{"I api.pb.go", "I main.go", "I vendor/lib.go"},
+		filterPaths(t, &PathFilter{Include: []string{"*.go"}, IgnoreAttributes: true},
+			repository, commits[:1]))
+	assert.Equal(t, []string{"I main.go"},
+		filterPaths(t, &PathFilter{Include: []string{"*.go"}, Exclude: []string{"vendor/**"}},
+			repository, commits[:1]))
+}
+
+func TestPathFilterFork(t *testing.T) {
+	pf := &PathFilter{Exclude: []string{"vendor/"}}
+	clones := pf.Fork(2)
+	assert.Len(t, clones, 2)
+	assert.Equal(t, pf.Exclude, clones[0].(*PathFilter).Exclude)
+	assert.False(t, pf == clones[1].(*PathFilter))
+}
+
+func TestCompilePathGlobs(t *testing.T) {
+	match := func(glob, name string) bool {
+		return compilePathGlobs([]string{glob})[0].Match(strings.Split(name, "/"))
+	}
+	assert.True(t, match("*.go", "main.go"))
+	assert.True(t, match("*.go", "a/b/main.go"))
+	assert.False(t, match("a/*.go", "b/a/main.go"))
+	assert.True(t, match("/a/*.go", "a/main.go"))
+	assert.True(t, match("vendor/", "vendor/a/b.go"))
+	assert.True(t, match("vendor/**", "vendor/b.go"))
+	assert.False(t, match("vendor/**", "vendor"))
+	assert.True(t, match("**/testdata/**", "a/testdata/x"))
+	assert.Len(t, compilePathGlobs([]string{"", " / "}), 0)
+}
+
+func BenchmarkPathFilterConsume(b *testing.B) {
+	commits, err := core.NewPipeline(test.Repository).Commits(true)
+	require.NoError(b, err)
+	treediff := &TreeDiff{}
+	require.NoError(b, treediff.Initialize(test.Repository))
+	deps := make([]map[string]interface{}, len(commits))
+	for i, commit := range commits {
+		deps[i] = map[string]interface{}{core.DependencyCommit: commit}
+		res, err := treediff.Consume(deps[i])
+		require.NoError(b, err)
+		deps[i][DependencyTreeChanges] = res[DependencyTreeChanges]
+	}
+	for _, ignore := range []bool{false, true} {
+		b.Run(fmt.Sprintf("IgnoreAttributes=%v", ignore), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				pf := &PathFilter{IgnoreAttributes: ignore}
+				require.NoError(b, pf.Initialize(test.Repository))
+				for _, commitDeps := range deps {
+					if _, err := pf.Consume(commitDeps); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
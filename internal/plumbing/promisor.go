@@ -0,0 +1,181 @@
+package plumbing
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/pkg/errors"
+)
+
+// PromisorFetcher loads the blobs which are missing in a partial clone, e.g. made with
+// `git clone --filter=blob:none`, from the promisor remote. go-git cannot fetch single objects,
+// so it runs the git executable: first a batched `git fetch` of the missing hashes and then
+// `git cat-file --batch` to read them.
+type PromisorFetcher struct {
+	// BatchSize is the maximum number of blobs requested by a single `git fetch`.
+	BatchSize int
+	// Concurrency is the maximum number of the simultaneous `git fetch`-es.
+	Concurrency int
+
+	gitDir string
+	remote string
+}
+
+// NewPromisorFetcher returns the fetcher if the repository is a partial clone on disk with
+// a promisor remote, otherwise nil. Zero batchSize or concurrency selects the defaults.
+func NewPromisorFetcher(repository *git.Repository, batchSize, concurrency int) *PromisorFetcher {
+	storage, ok := repository.Storer.(*filesystem.Storage)
+	if !ok {
+		return nil
+	}
+	cfg, err := repository.Config()
+	if err != nil {
+		return nil
+	}
+	remote := cfg.Raw.Section("extensions").Option("partialclone")
+	if remote == "" {
+		for _, sub := range cfg.Raw.Section("remote").Subsections {
+			if sub.Option("promisor") == "true" {
+				remote = sub.Name
+				break
+			}
+		}
+	}
+	if remote == "" {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBlobCachePromisorBatchSize
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultBlobCachePromisorConcurrency
+	}
+	return &PromisorFetcher{
+		BatchSize:   batchSize,
+		Concurrency: concurrency,
+		gitDir:      storage.Filesystem().Root(),
+		remote:      remote,
+	}
+}
+
+// Remote returns the name of the promisor remote.
+func (fetcher *PromisorFetcher) Remote() string {
+	return fetcher.remote
+}
+
+// Fetch downloads the blobs from the promisor remote and returns them mapped by their hashes.
+func (fetcher *PromisorFetcher) Fetch(ctx context.Context, hashes []plumbing.Hash) (
+	map[plumbing.Hash]*object.Blob, error,
+) {
+	var batches [][]plumbing.Hash
+	for len(hashes) > 0 {
+		size := fetcher.BatchSize
+		if size > len(hashes) {
+			size = len(hashes)
+		}
+		batches = append(batches, hashes[:size])
+		hashes = hashes[size:]
+	}
+	limiter := make(chan struct{}, fetcher.Concurrency)
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []plumbing.Hash) {
+			defer wg.Done()
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+			errs[i] = fetcher.fetchBatch(ctx, batch)
+		}(i, batch)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	var all []plumbing.Hash
+	for _, batch := range batches {
+		all = append(all, batch...)
+	}
+	return fetcher.readBlobs(ctx, all)
+}
+
+// fetchBatch runs `git fetch` of the specified objects from the promisor remote.
+func (fetcher *PromisorFetcher) fetchBatch(ctx context.Context, hashes []plumbing.Hash) error {
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", fetcher.gitDir,
+		"-c", "fetch.negotiationAlgorithm=noop", "fetch", fetcher.remote,
+		"--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", "--filter=blob:none",
+		"--stdin")
+	cmd.Stdin = strings.NewReader(joinHashes(hashes))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return errors.Errorf("failed to fetch %d blobs from %s: %v\n%s",
+			len(hashes), fetcher.remote, err, output)
+	}
+	return nil
+}
+
+// readBlobs loads the fetched objects with `git cat-file --batch`.
+func (fetcher *PromisorFetcher) readBlobs(ctx context.Context, hashes []plumbing.Hash) (
+	map[plumbing.Hash]*object.Blob, error,
+) {
+	cmd := exec.CommandContext(ctx, "git", "--git-dir", fetcher.gitDir, "cat-file", "--batch")
+	cmd.Stdin = strings.NewReader(joinHashes(hashes))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git cat-file failed")
+	}
+	reader := bufio.NewReader(bytes.NewReader(output))
+	blobs := make(map[plumbing.Hash]*object.Blob, len(hashes))
+	for range hashes {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, errors.Wrap(err, "truncated git cat-file output")
+		}
+		// "<hash> <type> <size>" or "<hash> missing"
+		fields := strings.Fields(header)
+		if len(fields) != 3 || fields[1] != "blob" {
+			return nil, errors.Errorf("the promisor remote did not return %s", strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, errors.Errorf("invalid git cat-file header %q", header)
+		}
+		obj := &plumbing.MemoryObject{}
+		obj.SetType(plumbing.BlobObject)
+		if _, err = io.CopyN(obj, reader, int64(size)); err != nil {
+			return nil, errors.Wrap(err, "truncated git cat-file output")
+		}
+		if _, err = reader.Discard(1); err != nil {
+			return nil, errors.Wrap(err, "truncated git cat-file output")
+		}
+		blob, err := object.DecodeBlob(obj)
+		if err != nil {
+			return nil, err
+		}
+		if blob.Hash.String() != fields[0] {
+			return nil, errors.Errorf("git cat-file returned %s instead of %s", blob.Hash, fields[0])
+		}
+		blobs[blob.Hash] = blob
+	}
+	return blobs, nil
+}
+
+func joinHashes(hashes []plumbing.Hash) string {
+	builder := &strings.Builder{}
+	for _, hash := range hashes {
+		builder.WriteString(hash.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
@@ -0,0 +1,102 @@
+package plumbing
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runPromisorTestGit(t *testing.T, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@test",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@test")
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return strings.TrimSpace(string(output))
+}
+
+// makeBlobLessClone creates the repository with several files changed in two commits
+// and clones it with --filter=blob:none.
+func makeBlobLessClone(t *testing.T) *git.Repository {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	origin := t.TempDir()
+	runPromisorTestGit(t, "init", "-q", origin)
+	runPromisorTestGit(t, "-C", origin, "config", "uploadpack.allowFilter", "true")
+	runPromisorTestGit(t, "-C", origin, "config", "uploadpack.allowAnySHA1InWant", "true")
+	for i, text := range []string{"first\n", "second\n"} {
+		for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+			require.NoError(t, os.WriteFile(filepath.Join(origin, name), []byte(name+text), 0o644))
+		}
+		runPromisorTestGit(t, "-C", origin, "add", ".")
+		runPromisorTestGit(t, "-C", origin, "commit", "-q", "-m", string(rune('1'+i)))
+	}
+	clone := filepath.Join(t.TempDir(), "clone.git")
+	runPromisorTestGit(t, "clone", "-q", "--bare", "--filter=blob:none", "file://"+origin, clone)
+	repository, err := git.PlainOpen(clone)
+	require.NoError(t, err)
+	return repository
+}
+
+func TestPromisorFetcherDetection(t *testing.T) {
+	repository := makeBlobLessClone(t)
+	fetcher := NewPromisorFetcher(repository, 0, 0)
+	require.NotNil(t, fetcher)
+	assert.Equal(t, "origin", fetcher.Remote())
+	assert.Equal(t, DefaultBlobCachePromisorBatchSize, fetcher.BatchSize)
+	assert.Equal(t, DefaultBlobCachePromisorConcurrency, fetcher.Concurrency)
+	plain, err := git.PlainInit(t.TempDir(), false)
+	require.NoError(t, err)
+	assert.Nil(t, NewPromisorFetcher(plain, 0, 0))
+}
+
+func TestBlobCachePromisor(t *testing.T) {
+	repository := makeBlobLessClone(t)
+	head, err := repository.Head()
+	require.NoError(t, err)
+	commit, err := repository.CommitObject(head.Hash())
+	require.NoError(t, err)
+	parent, err := commit.Parent(0)
+	require.NoError(t, err)
+	treeFrom, err := parent.Tree()
+	require.NoError(t, err)
+	treeTo, err := commit.Tree()
+	require.NoError(t, err)
+	changes, err := object.DiffTree(treeFrom, treeTo)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	_, err = repository.BlobObject(changes[0].To.TreeEntry.Hash)
+	require.Equal(t, plumbing.ErrObjectNotFound, err)
+
+	cache := &BlobCache{PromisorBatchSize: 2, PromisorConcurrency: 2}
+	require.NoError(t, cache.Initialize(repository))
+	require.NotNil(t, cache.promisor)
+	result, err := cache.Consume(map[string]interface{}{
+		core.DependencyCommit:  commit,
+		DependencyTreeChanges:  changes,
+		core.DependencyContext: context.Background(),
+	})
+	require.NoError(t, err)
+	blobs := result[DependencyBlobCache].(map[plumbing.Hash]*CachedBlob)
+	assert.Len(t, blobs, 6)
+	for _, change := range changes {
+		assert.Equal(t, change.From.Name+"first\n", string(blobs[change.From.TreeEntry.Hash].Data))
+		assert.Equal(t, change.To.Name+"second\n", string(blobs[change.To.TreeEntry.Hash].Data))
+	}
+	assert.Nil(t, cache.fetched)
+
+	_, err = cache.promisor.Fetch(context.Background(), []plumbing.Hash{plumbing.NewHash(
+		"1111111111111111111111111111111111111111")})
+	assert.Error(t, err)
+}
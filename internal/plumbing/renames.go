@@ -1,11 +1,14 @@
 package plumbing
 
 import (
+	"encoding/gob"
 	"fmt"
+	"io"
 	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -29,10 +32,27 @@ type RenameAnalysis struct {
 	// set it to the default value of 80 (80%).
 	SimilarityThreshold int
 
+	// BinarySimilarityThreshold is the same as SimilarityThreshold for the binary blobs,
+	// which are compared byte by byte. 0 means SimilarityThreshold.
+	BinarySimilarityThreshold int
+
 	// Timeout is the maximum time allowed to spend computing renames in a single commit.
 	Timeout time.Duration
 
+	// MaxCandidates is the maximum number of blobs with close sizes which are compared
+	// with each added or deleted file. The commits with more than RenameAnalysisSetSizeLimit
+	// added and deleted files compare only the most similar by name candidate.
+	MaxCandidates int
+
+	// ExactOnly disables the similarity search: only the files with identical contents
+	// are matched, which is fast regardless of the size of the commit.
+	ExactOnly bool
+
+	// ReportStatistics enables logging the Statistics() when the analysis finishes.
+	ReportStatistics bool
+
 	repository *git.Repository
+	stats      RenameAnalysisStatistics
 
 	l core.Logger
 }
@@ -46,6 +66,22 @@ const (
 	// RenameAnalysisDefaultTimeout is the default value of RenameAnalysis.Timeout (in milliseconds).
 	RenameAnalysisDefaultTimeout = 60000
 
+	// ConfigRenameAnalysisBinarySimilarityThreshold is the name of the configuration option
+	// (RenameAnalysis.Configure()) which sets the similarity threshold of the binary blobs.
+	ConfigRenameAnalysisBinarySimilarityThreshold = "RenameAnalysis.BinarySimilarityThreshold"
+
+	// ConfigRenameAnalysisMaxCandidates is the name of the configuration option
+	// (RenameAnalysis.Configure()) which sets the maximum number of compared blobs per file.
+	ConfigRenameAnalysisMaxCandidates = "RenameAnalysis.MaxCandidates"
+
+	// ConfigRenameAnalysisExactOnly is the name of the configuration option
+	// (RenameAnalysis.Configure()) which disables the similarity search.
+	ConfigRenameAnalysisExactOnly = "RenameAnalysis.ExactOnly"
+
+	// ConfigRenameAnalysisStatistics is the name of the configuration option
+	// (RenameAnalysis.Configure()) which enables logging the rename detection statistics.
+	ConfigRenameAnalysisStatistics = "RenameAnalysis.Statistics"
+
 	// ConfigRenameAnalysisSimilarityThreshold is the name of the configuration option
 	// (RenameAnalysis.Configure()) which sets the similarity threshold.
 	ConfigRenameAnalysisSimilarityThreshold = "RenameAnalysis.SimilarityThreshold"
@@ -58,7 +94,8 @@ const (
 	// RenameAnalysisMinimumSize is the minimum size of a blob to be considered.
 	RenameAnalysisMinimumSize = 32
 
-	// RenameAnalysisMaxCandidates is the maximum number of rename candidates to consider per file.
+	// RenameAnalysisMaxCandidates is the default maximum number of rename candidates
+	// to consider per file.
 	RenameAnalysisMaxCandidates = 50
 
 	// RenameAnalysisSetSizeLimit is the maximum number of added + removed files for
@@ -70,6 +107,20 @@ const (
 	RenameAnalysisByteDiffSizeThreshold = 100000
 )
 
+// RenameAnalysisStatistics summarizes the work done by RenameAnalysis during the run.
+type RenameAnalysisStatistics struct {
+	// ExactRenames is the number of renames found by matching the blob hashes.
+	ExactRenames int
+	// SimilarRenames is the number of renames found by comparing the contents.
+	SimilarRenames int
+	// Candidates is the number of compared pairs of blobs.
+	Candidates int64
+	// TimedOut is the number of commits which exceeded RenameAnalysis.Timeout.
+	TimedOut int
+	// Elapsed is the total time spent detecting the renames.
+	Elapsed time.Duration
+}
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (ra *RenameAnalysis) Name() string {
 	return "RenameAnalysis"
@@ -89,6 +140,11 @@ func (ra *RenameAnalysis) Requires() []string {
 	return []string{DependencyBlobCache, DependencyTreeChanges}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (ra *RenameAnalysis) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (ra *RenameAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{
@@ -105,6 +161,33 @@ func (ra *RenameAnalysis) ListConfigurationOptions() []core.ConfigurationOption
 			Flag:    "renames-timeout",
 			Type:    core.IntConfigurationOption,
 			Default: RenameAnalysisDefaultTimeout,
+		}, {
+			Name: ConfigRenameAnalysisBinarySimilarityThreshold,
+			Description: "The threshold on the similarity index used to detect renames of " +
+				"binary files. 0 sets the same value as -M.",
+			Flag:    "renames-binary-threshold",
+			Type:    core.IntConfigurationOption,
+			Default: 0,
+		}, {
+			Name: ConfigRenameAnalysisMaxCandidates,
+			Description: "The maximum number of files with close sizes compared with each " +
+				"added or deleted file while detecting renames. 0 sets the default.",
+			Flag:    "renames-max-candidates",
+			Type:    core.IntConfigurationOption,
+			Default: RenameAnalysisMaxCandidates,
+		}, {
+			Name: ConfigRenameAnalysisExactOnly,
+			Description: "Detect only the renames without changes in the contents; " +
+				"much faster on big commits.",
+			Flag:    "renames-exact-only",
+			Type:    core.BoolConfigurationOption,
+			Default: false,
+		}, {
+			Name:        ConfigRenameAnalysisStatistics,
+			Description: "Log the number of detected renames, compared files and the elapsed time.",
+			Flag:        "renames-stats",
+			Type:        core.BoolConfigurationOption,
+			Default:     false,
 		},
 	}
 	return options[:]
@@ -124,6 +207,21 @@ func (ra *RenameAnalysis) Configure(facts map[string]interface{}) error {
 		}
 		ra.Timeout = time.Duration(val) * time.Millisecond
 	}
+	if val, exists := facts[ConfigRenameAnalysisBinarySimilarityThreshold].(int); exists {
+		ra.BinarySimilarityThreshold = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisMaxCandidates].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("negative number of rename candidates is not allowed: %d", val)
+		}
+		ra.MaxCandidates = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisExactOnly].(bool); exists {
+		ra.ExactOnly = val
+	}
+	if val, exists := facts[ConfigRenameAnalysisStatistics].(bool); exists {
+		ra.ReportStatistics = val
+	}
 	return nil
 }
 
@@ -140,10 +238,18 @@ func (ra *RenameAnalysis) Initialize(repository *git.Repository) error {
 			RenameAnalysisDefaultThreshold)
 		ra.SimilarityThreshold = RenameAnalysisDefaultThreshold
 	}
+	if ra.BinarySimilarityThreshold < 0 || ra.BinarySimilarityThreshold > 100 {
+		ra.l.Warnf("adjusted the binary similarity threshold to %d\n", ra.SimilarityThreshold)
+		ra.BinarySimilarityThreshold = 0
+	}
 	if ra.Timeout == 0 {
 		ra.Timeout = time.Duration(RenameAnalysisDefaultTimeout) * time.Millisecond
 	}
+	if ra.MaxCandidates <= 0 {
+		ra.MaxCandidates = RenameAnalysisMaxCandidates
+	}
 	ra.repository = repository
+	ra.stats = RenameAnalysisStatistics{}
 	return nil
 }
 
@@ -154,6 +260,9 @@ func (ra *RenameAnalysis) Initialize(repository *git.Repository) error {
 // in Provides(). If there was an error, nil is returned.
 func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	beginTime := time.Now()
+	defer func() {
+		ra.stats.Elapsed += time.Since(beginTime)
+	}()
 	changes := deps[DependencyTreeChanges].(object.Changes)
 	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*CachedBlob)
 
@@ -191,6 +300,7 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 				reducedChanges = append(
 					reducedChanges,
 					&object.Change{From: deleted[d].change.From, To: added[a].change.To})
+				ra.stats.ExactRenames++
 				a++
 				d++
 			} else if added[a].Less(&deleted[d]) {
@@ -209,10 +319,16 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		}
 	}
 
+	if ra.ExactOnly {
+		reducedChanges = append(reducedChanges, stillAdded...)
+		reducedChanges = append(reducedChanges, stillDeleted...)
+		return map[string]interface{}{DependencyTreeChanges: reducedChanges}, nil
+	}
+
 	// Stage 2 - apply the similarity threshold
 	// n^2 but actually linear
 	// We sort the blobs by size and do the single linear scan.
-	maxCandidates := RenameAnalysisMaxCandidates
+	maxCandidates := ra.MaxCandidates
 	if len(stillAdded)+len(stillDeleted) > RenameAnalysisSetSizeLimit {
 		maxCandidates = 1
 	}
@@ -288,6 +404,7 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 				if ci > maxCandidates {
 					break
 				}
+				atomic.AddInt64(&ra.stats.Candidates, 1)
 				blobsAreClose, err := ra.blobsAreClose(
 					myBlob, cache[addedBlobsA[a].change.To.TreeEntry.Hash])
 				if err != nil {
@@ -347,6 +464,7 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 				if ci > maxCandidates {
 					break
 				}
+				atomic.AddInt64(&ra.stats.Candidates, 1)
 				blobsAreClose, err := ra.blobsAreClose(
 					myBlob, cache[deletedBlobsB[d].change.From.TreeEntry.Hash])
 				if err != nil {
@@ -393,6 +511,10 @@ func (ra *RenameAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		panic("Impossible happened: two functions returned without an error " +
 			"but no results from both")
 	}
+	ra.stats.SimilarRenames += len(matches)
+	if time.Since(beginTime) >= ra.Timeout {
+		ra.stats.TimedOut++
+	}
 
 	// Stage 3 - we give up, everything left are independent additions and deletions
 	for _, change := range matches {
@@ -415,6 +537,30 @@ func (ra *RenameAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(ra, n)
 }
 
+// Statistics returns the summary of the rename detection since Initialize().
+func (ra *RenameAnalysis) Statistics() RenameAnalysisStatistics {
+	return ra.stats
+}
+
+// Dispose logs the statistics if ReportStatistics is set.
+func (ra *RenameAnalysis) Dispose() {
+	if !ra.ReportStatistics {
+		return
+	}
+	stats := ra.Statistics()
+	ra.l.Infof("renames: %d exact, %d similar, %d compared pairs, %d commits timed out, %v elapsed",
+		stats.ExactRenames, stats.SimilarRenames, stats.Candidates, stats.TimedOut,
+		stats.Elapsed.Round(time.Millisecond))
+}
+
+// binarySimilarityThreshold returns the effective threshold for the binary blobs.
+func (ra *RenameAnalysis) binarySimilarityThreshold() int {
+	if ra.BinarySimilarityThreshold == 0 {
+		return ra.SimilarityThreshold
+	}
+	return ra.BinarySimilarityThreshold
+}
+
 func (ra *RenameAnalysis) sizesAreClose(size1 int64, size2 int64) bool {
 	size := internal.Max64(1, internal.Max64(size1, size2))
 	return (internal.Abs64(size1-size2)*10000)/size <= int64(100-ra.SimilarityThreshold)*100
@@ -436,7 +582,7 @@ func (ra *RenameAnalysis) blobsAreClose(blob1 *CachedBlob, blob2 *CachedBlob) (b
 		delta := int((int64(bsdifflen) * 100) / internal.Max64(
 			internal.Min64(blob1.Size, blob2.Size), 1))
 		cleanReturn = true
-		return 100-delta >= ra.SimilarityThreshold, nil
+		return 100-delta >= ra.binarySimilarityThreshold(), nil
 	}
 	src, dst := string(blob1.Data), string(blob2.Data)
 	maxSize := internal.Max(1, internal.Max(utf8.RuneCountInString(src), utf8.RuneCountInString(dst)))
@@ -615,6 +761,16 @@ func sortRenameCandidates(candidates []int, origin string, nameGetter func(int)
 	}
 }
 
+// Checkpoint writes the Statistics().
+func (ra *RenameAnalysis) Checkpoint(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(ra.stats)
+}
+
+// Restore reads the statistics which Checkpoint() wrote.
+func (ra *RenameAnalysis) Restore(reader io.Reader) error {
+	return gob.NewDecoder(reader).Decode(&ra.stats)
+}
+
 func init() {
 	core.Registry.Register(&RenameAnalysis{})
 }
@@ -3,9 +3,11 @@ package plumbing
 import (
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/meko-christian/hercules/internal/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func fixtureRenameAnalysis() *RenameAnalysis {
@@ -32,17 +35,32 @@ func TestRenameAnalysisMeta(t *testing.T) {
 	assert.Equal(t, ra.Requires()[0], DependencyBlobCache)
 	assert.Equal(t, ra.Requires()[1], DependencyTreeChanges)
 	opts := ra.ListConfigurationOptions()
-	assert.Len(t, opts, 2)
+	assert.Len(t, opts, 6)
 	assert.Equal(t, opts[0].Name, ConfigRenameAnalysisSimilarityThreshold)
 	assert.Equal(t, opts[1].Name, ConfigRenameAnalysisTimeout)
+	assert.Equal(t, opts[2].Name, ConfigRenameAnalysisBinarySimilarityThreshold)
+	assert.Equal(t, opts[3].Name, ConfigRenameAnalysisMaxCandidates)
+	assert.Equal(t, opts[4].Name, ConfigRenameAnalysisExactOnly)
+	assert.Equal(t, opts[5].Name, ConfigRenameAnalysisStatistics)
 	ra.SimilarityThreshold = 0
 
 	assert.NoError(t, ra.Configure(map[string]interface{}{
-		ConfigRenameAnalysisSimilarityThreshold: 70,
-		ConfigRenameAnalysisTimeout:             1000,
+		ConfigRenameAnalysisSimilarityThreshold:       70,
+		ConfigRenameAnalysisTimeout:                   1000,
+		ConfigRenameAnalysisBinarySimilarityThreshold: 90,
+		ConfigRenameAnalysisMaxCandidates:             10,
+		ConfigRenameAnalysisExactOnly:                 true,
+		ConfigRenameAnalysisStatistics:                true,
 	}))
 	assert.Equal(t, ra.SimilarityThreshold, 70)
 	assert.Equal(t, ra.Timeout, time.Second)
+	assert.Equal(t, ra.BinarySimilarityThreshold, 90)
+	assert.Equal(t, ra.MaxCandidates, 10)
+	assert.True(t, ra.ExactOnly)
+	assert.True(t, ra.ReportStatistics)
+	assert.Error(t, ra.Configure(map[string]interface{}{
+		ConfigRenameAnalysisMaxCandidates: -1,
+	}))
 
 	logger := core.NewLogger()
 	assert.NoError(t, ra.Configure(map[string]interface{}{
@@ -77,6 +95,83 @@ func TestRenameAnalysisInitializeInvalidThreshold(t *testing.T) {
 	ra.Initialize(test.Repository)
 	ra = RenameAnalysis{SimilarityThreshold: 100}
 	ra.Initialize(test.Repository)
+	assert.Equal(t, RenameAnalysisMaxCandidates, ra.MaxCandidates)
+	ra = RenameAnalysis{SimilarityThreshold: 70, BinarySimilarityThreshold: 101}
+	ra.Initialize(test.Repository)
+	assert.Equal(t, 0, ra.BinarySimilarityThreshold)
+	assert.Equal(t, 70, ra.binarySimilarityThreshold())
+}
+
+// fixtureRenameChanges returns the changes which delete "old_i" and add "new_i" with the
+// contents differing by the single line, and "moved" which is renamed without changes.
+func fixtureRenameChanges(n int) (object.Changes, map[plumbing.Hash]*CachedBlob) {
+	cache := map[plumbing.Hash]*CachedBlob{}
+	entry := func(name, contents string) object.ChangeEntry {
+		blob := &CachedBlob{Data: []byte(contents)}
+		blob.Hash = plumbing.ComputeHash(plumbing.BlobObject, blob.Data)
+		blob.Size = int64(len(blob.Data))
+		cache[blob.Hash] = blob
+		return object.ChangeEntry{
+			Name:      name,
+			TreeEntry: object.TreeEntry{Name: name, Mode: 0o100644, Hash: blob.Hash},
+		}
+	}
+	var changes object.Changes
+	for i := 0; i < n; i++ {
+		contents := strings.Repeat(fmt.Sprintf("line %d of the file which is renamed\n", i), 20)
+		changes = append(changes,
+			&object.Change{From: entry(fmt.Sprintf("old_%d", i), contents)},
+			&object.Change{To: entry(fmt.Sprintf("new_%d", i), contents+"the new line\n")})
+	}
+	moved := strings.Repeat("the file which is moved without changes\n", 10)
+	changes = append(changes,
+		&object.Change{From: entry("moved", moved)}, &object.Change{To: entry("dir/moved", moved)})
+	return changes, cache
+}
+
+func TestRenameAnalysisConsumeExactOnly(t *testing.T) {
+	changes, cache := fixtureRenameChanges(3)
+	deps := map[string]interface{}{
+		DependencyBlobCache:   cache,
+		DependencyTreeChanges: changes,
+	}
+	ra := fixtureRenameAnalysis()
+	res, err := ra.Consume(deps)
+	require.NoError(t, err)
+	assert.Len(t, res[DependencyTreeChanges].(object.Changes), 4)
+	stats := ra.Statistics()
+	assert.Equal(t, 1, stats.ExactRenames)
+	assert.Equal(t, 3, stats.SimilarRenames)
+	assert.True(t, stats.Candidates >= 3)
+	assert.Equal(t, 0, stats.TimedOut)
+	assert.True(t, stats.Elapsed > 0)
+
+	ra = fixtureRenameAnalysis()
+	ra.ExactOnly = true
+	res, err = ra.Consume(deps)
+	require.NoError(t, err)
+	assert.Len(t, res[DependencyTreeChanges].(object.Changes), 7)
+	stats = ra.Statistics()
+	assert.Equal(t, 1, stats.ExactRenames)
+	assert.Equal(t, 0, stats.SimilarRenames)
+	assert.Equal(t, int64(0), stats.Candidates)
+	ra.ReportStatistics = true
+	ra.Dispose()
+}
+
+func TestRenameAnalysisConsumeMaxCandidates(t *testing.T) {
+	ra := fixtureRenameAnalysis()
+	ra.MaxCandidates = 1
+	// all the files have the same size, so each has 5 candidates
+	changes, cache := fixtureRenameChanges(5)
+	res, err := ra.Consume(map[string]interface{}{
+		DependencyBlobCache:   cache,
+		DependencyTreeChanges: changes,
+	})
+	require.NoError(t, err)
+	assert.Len(t, res[DependencyTreeChanges].(object.Changes), 6)
+	// at most 2 comparisons per file in each of the two directions
+	assert.True(t, ra.Statistics().Candidates <= 2*2*5)
 }
 
 func TestRenameAnalysisConsume(t *testing.T) {
@@ -319,6 +414,11 @@ func TestBlobsAreCloseBinary(t *testing.T) {
 	result, err = ra.blobsAreClose(blob1, blob2)
 	assert.Nil(t, err)
 	assert.False(t, result)
+	ra.BinarySimilarityThreshold = 70
+	result, err = ra.blobsAreClose(blob1, blob2)
+	assert.Nil(t, err)
+	assert.True(t, result)
+	ra.BinarySimilarityThreshold = 0
 
 	blob1.Data = []byte("hello, world!")
 	blob1.Size = int64(len(blob2.Data))
@@ -0,0 +1,235 @@
+package plumbing
+
+import (
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/meko-christian/hercules/internal/core"
+	ast_items "github.com/meko-christian/hercules/internal/plumbing/ast"
+)
+
+// StructuralDiff parses the changed files with tree-sitter and finds which functions and classes
+// were inserted, deleted or modified in each commit. It is a PipelineItem.
+// Files in the languages without a tree-sitter grammar and binary files are skipped.
+type StructuralDiff struct {
+	core.NoopMerger
+
+	extractor *ast_items.TreeSitterExtractor
+	l         core.Logger
+}
+
+const (
+	// DependencyStructuralChanges is the name of the dependency provided by StructuralDiff.
+	// It is a sorted []StructuralChange.
+	DependencyStructuralChanges = "structural_changes"
+
+	// StructuralEntityFunction is StructuralChange.Kind of the functions and the methods.
+	StructuralEntityFunction = "function"
+	// StructuralEntityClass is StructuralChange.Kind of the classes, the structs, the interfaces,
+	// the enums, the traits and the modules.
+	StructuralEntityClass = "class"
+)
+
+// StructuralChange is the change of a single function or class.
+type StructuralChange struct {
+	// File is the path of the file after the change, or before the change if it was deleted.
+	File string
+	// Kind is either StructuralEntityFunction or StructuralEntityClass.
+	Kind string
+	// Type is the tree-sitter node type, e.g. "ast:function_declaration".
+	Type string
+	// Name is the name of the function or the class.
+	Name string
+	// Action is merkletrie.Insert, merkletrie.Delete or merkletrie.Modify.
+	Action merkletrie.Action
+	// Before is the entity before the change; nil if it was inserted.
+	Before *ast_items.Node
+	// After is the entity after the change; nil if it was deleted.
+	After *ast_items.Node
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sd *StructuralDiff) Name() string {
+	return "StructuralDiff"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+// Each produced entity will be inserted into `deps` of dependent Consume()-s according
+// to this list. Also used by core.Registry to build the global map of providers.
+func (sd *StructuralDiff) Provides() []string {
+	return []string{DependencyStructuralChanges}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+// Each requested entity will be inserted into `deps` of Consume(). In turn, those
+// entities are Provides() upstream.
+func (sd *StructuralDiff) Requires() []string {
+	return []string{DependencyTreeChanges, DependencyBlobCache}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sd *StructuralDiff) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sd *StructuralDiff) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		sd.l = l
+	}
+	return nil
+}
+
+func (*StructuralDiff) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sd *StructuralDiff) Initialize(repository *git.Repository) error {
+	sd.l = core.NewLogger()
+	sd.extractor = ast_items.NewTreeSitterExtractor()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sd *StructuralDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	treeDiff := deps[DependencyTreeChanges].(object.Changes)
+	cache := deps[DependencyBlobCache].(map[plumbing.Hash]*CachedBlob)
+	result := []StructuralChange{}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		switch action {
+		case merkletrie.Insert:
+			result = appendStructuralChanges(result, change.To.Name, nil, sd.parse(change.To, cache))
+		case merkletrie.Delete:
+			result = appendStructuralChanges(result, change.From.Name, sd.parse(change.From, cache), nil)
+		case merkletrie.Modify:
+			result = appendStructuralChanges(
+				result, change.To.Name, sd.parse(change.From, cache), sd.parse(change.To, cache))
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].File != result[j].File {
+			return result[i].File < result[j].File
+		}
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Action < result[j].Action
+	})
+	return map[string]interface{}{DependencyStructuralChanges: result}, nil
+}
+
+// Fork clones this PipelineItem.
+func (sd *StructuralDiff) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(sd, n)
+}
+
+// structuralEntity is a function or a class with the number of the entities of the same kind,
+// type and name before it in the file, which disambiguates the overloads.
+type structuralEntity struct {
+	kind       string
+	occurrence int
+	node       ast_items.Node
+}
+
+func (entity structuralEntity) key() [4]interface{} {
+	return [4]interface{}{entity.kind, entity.node.Type, entity.node.Name, entity.occurrence}
+}
+
+// parse extracts the functions and the classes from the blob. It returns nil if the language is
+// not supported, the file is binary or tree-sitter failed to parse it.
+func (sd *StructuralDiff) parse(entry object.ChangeEntry, cache map[plumbing.Hash]*CachedBlob) []structuralEntity {
+	if !ast_items.IsSupported(entry.Name) {
+		return nil
+	}
+	blob := cache[entry.TreeEntry.Hash]
+	if blob == nil {
+		return nil
+	}
+	if _, err := blob.CountLines(); err != nil {
+		return nil
+	}
+	functions, err := sd.extractor.Extract(entry.Name, blob.Data)
+	if err != nil {
+		sd.l.Warnf("failed to parse %s: %v", entry.Name, err)
+		return nil
+	}
+	classes, err := sd.extractor.ExtractClasses(entry.Name, blob.Data)
+	if err != nil {
+		sd.l.Warnf("failed to parse %s: %v", entry.Name, err)
+		return nil
+	}
+	entities := make([]structuralEntity, 0, len(functions)+len(classes))
+	occurrences := map[[3]string]int{}
+	add := func(kind string, nodes []ast_items.Node) {
+		for _, node := range nodes {
+			key := [3]string{kind, node.Type, node.Name}
+			entities = append(entities, structuralEntity{
+				kind: kind, occurrence: occurrences[key], node: node,
+			})
+			occurrences[key]++
+		}
+	}
+	add(StructuralEntityFunction, functions)
+	add(StructuralEntityClass, classes)
+	return entities
+}
+
+// appendStructuralChanges matches the entities before and after the change by their kind, type,
+// name and occurrence. The unmatched entities were deleted or inserted, and the matched ones were
+// modified if their text differs.
+func appendStructuralChanges(
+	result []StructuralChange, file string, before, after []structuralEntity,
+) []StructuralChange {
+	index := map[[4]interface{}]int{}
+	for i, entity := range before {
+		index[entity.key()] = i
+	}
+	matched := make([]bool, len(before))
+	for i := range after {
+		entity := &after[i]
+		change := StructuralChange{
+			File: file, Kind: entity.kind, Type: entity.node.Type, Name: entity.node.Name,
+			Action: merkletrie.Insert, After: &entity.node,
+		}
+		if j, exists := index[entity.key()]; exists {
+			matched[j] = true
+			if before[j].node.Text == entity.node.Text {
+				continue
+			}
+			change.Action = merkletrie.Modify
+			change.Before = &before[j].node
+		}
+		result = append(result, change)
+	}
+	for i := range before {
+		if matched[i] {
+			continue
+		}
+		entity := &before[i]
+		result = append(result, StructuralChange{
+			File: file, Kind: entity.kind, Type: entity.node.Type, Name: entity.node.Name,
+			Action: merkletrie.Delete, Before: &entity.node,
+		})
+	}
+	return result
+}
+
+func init() {
+	core.Registry.Register(&StructuralDiff{})
+}
@@ -0,0 +1,115 @@
+package plumbing_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuralDiffMeta(t *testing.T) {
+	sd := &items.StructuralDiff{}
+	assert.Equal(t, "StructuralDiff", sd.Name())
+	assert.Equal(t, []string{items.DependencyStructuralChanges}, sd.Provides())
+	assert.Equal(t, []string{items.DependencyTreeChanges, items.DependencyBlobCache}, sd.Requires())
+	assert.Len(t, sd.ListConfigurationOptions(), 0)
+	assert.NoError(t, sd.Configure(map[string]interface{}{
+		core.ConfigLogger: core.NewLogger(),
+	}))
+	for _, f := range sd.Fork(10) {
+		assert.Equal(t, f, sd)
+	}
+}
+
+func TestStructuralDiffRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&items.StructuralDiff{}).Name())
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, "StructuralDiff", summoned[0].Name())
+	summoned = core.Registry.Summon(items.DependencyStructuralChanges)
+	assert.Len(t, summoned, 1)
+	assert.Equal(t, "StructuralDiff", summoned[0].Name())
+}
+
+func TestStructuralDiffConsume(t *testing.T) {
+	sd := &items.StructuralDiff{}
+	require.NoError(t, sd.Initialize(nil))
+	cache := map[plumbing.Hash]*items.CachedBlob{}
+	entry := func(name, data string) object.ChangeEntry {
+		hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(data))
+		cache[hash] = &items.CachedBlob{Data: []byte(data)}
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	changes := object.Changes{
+		{
+			From: entry("main.go", `package main
+
+type T struct{}
+
+func alpha() int {
+	return 1
+}
+
+func beta() {}
+
+func gamma() {}
+`),
+			To: entry("main.go", `package main
+
+type T struct{}
+
+func alpha() int {
+	return 2
+}
+
+func gamma() {}
+
+func delta() {}
+`),
+		},
+		{To: entry("lib.py", "class C:\n    def method(self):\n        pass\n")},
+		{From: entry("old.rs", "fn removed() {}\n")},
+		{To: entry("README.md", "# title\n")},
+		{To: entry("binary.c", "int main() {}\x00")},
+	}
+	result, err := sd.Consume(map[string]interface{}{
+		items.DependencyTreeChanges: changes,
+		items.DependencyBlobCache:   cache,
+	})
+	require.NoError(t, err)
+	structural := result[items.DependencyStructuralChanges].([]items.StructuralChange)
+	type summary struct {
+		File, Kind, Name string
+		Action           merkletrie.Action
+	}
+	summaries := make([]summary, len(structural))
+	for i, change := range structural {
+		summaries[i] = summary{change.File, change.Kind, change.Name, change.Action}
+		switch change.Action {
+		case merkletrie.Insert:
+			assert.Nil(t, change.Before)
+			assert.NotNil(t, change.After)
+		case merkletrie.Delete:
+			assert.NotNil(t, change.Before)
+			assert.Nil(t, change.After)
+		case merkletrie.Modify:
+			assert.NotNil(t, change.Before)
+			assert.NotNil(t, change.After)
+		}
+	}
+	assert.Equal(t, []summary{
+		{"lib.py", items.StructuralEntityClass, "C", merkletrie.Insert},
+		{"lib.py", items.StructuralEntityFunction, "method", merkletrie.Insert},
+		{"main.go", items.StructuralEntityFunction, "alpha", merkletrie.Modify},
+		{"main.go", items.StructuralEntityFunction, "beta", merkletrie.Delete},
+		{"main.go", items.StructuralEntityFunction, "delta", merkletrie.Insert},
+		{"old.rs", items.StructuralEntityFunction, "removed", merkletrie.Delete},
+	}, summaries)
+	assert.Equal(t, "ast:function_declaration", structural[2].Type)
+	assert.Equal(t, 5, structural[2].Before.StartLine)
+	assert.Contains(t, structural[2].After.Text, "return 2")
+}
@@ -1,6 +1,9 @@
 package plumbing
 
 import (
+	"encoding/gob"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -14,9 +17,15 @@ import (
 type TicksSinceStart struct {
 	core.NoopMerger
 	TickSize time.Duration
+	// TickMode aligns the ticks to the calendar, see TickMode*. TickSize is ignored then.
+	TickMode string
+	// FiscalYearStart is the first month of the fiscal year, 1-12. It shifts the quarters and
+	// the years.
+	FiscalYearStart time.Month
 
 	remote       string
 	tick0        *time.Time
+	period0      int
 	previousTick int
 	commits      map[int][]plumbing.Hash
 
@@ -39,8 +48,37 @@ const (
 
 	// DefaultTicksSinceStartTickSize is the default number of hours in each 'tick' (24*hour = 1day).
 	DefaultTicksSinceStartTickSize = 24
+
+	// ConfigTicksSinceStartTickMode sets TicksSinceStart.TickMode.
+	ConfigTicksSinceStartTickMode = "TicksSinceStart.TickMode"
+
+	// ConfigTicksSinceStartFiscalYearStart sets TicksSinceStart.FiscalYearStart.
+	ConfigTicksSinceStartFiscalYearStart = "TicksSinceStart.FiscalYearStart"
+
+	// TickModeFixed splits the time into the periods of TickSize.
+	TickModeFixed = "fixed"
+	// TickModeWeek makes each tick an ISO week which starts on Monday.
+	TickModeWeek = "week"
+	// TickModeMonth makes each tick a calendar month.
+	TickModeMonth = "month"
+	// TickModeQuarter makes each tick a quarter of the fiscal year.
+	TickModeQuarter = "quarter"
+	// TickModeYear makes each tick a fiscal year.
+	TickModeYear = "year"
 )
 
+// averageMonth is the length of the Gregorian month on average.
+const averageMonth = time.Duration(365.2425 * 24 / 12 * float64(time.Hour))
+
+// calendarTickSizes are the average lengths of the calendar ticks which are published
+// in FactTickSize, so that the leaves can still convert the durations to ticks.
+var calendarTickSizes = map[string]time.Duration{
+	TickModeWeek:    7 * 24 * time.Hour,
+	TickModeMonth:   averageMonth,
+	TickModeQuarter: 3 * averageMonth,
+	TickModeYear:    12 * averageMonth,
+}
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (ticks *TicksSinceStart) Name() string {
 	return "TicksSinceStart"
@@ -60,6 +98,11 @@ func (ticks *TicksSinceStart) Requires() []string {
 	return []string{}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (ticks *TicksSinceStart) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 func (*TicksSinceStart) Features() []string {
 	return []string{core.FeatureGitCommits}
 }
@@ -73,6 +116,19 @@ func (ticks *TicksSinceStart) ListConfigurationOptions() []core.ConfigurationOpt
 			Flag:        "tick-size",
 			Type:        core.IntConfigurationOption,
 			Default:     DefaultTicksSinceStartTickSize,
+		}, {
+			Name: ConfigTicksSinceStartTickMode,
+			Description: "Align the ticks to the calendar: \"week\", \"month\", \"quarter\" or \"year\". " +
+				"\"fixed\" uses --tick-size.",
+			Flag:    "tick-mode",
+			Type:    core.StringConfigurationOption,
+			Default: TickModeFixed,
+		}, {
+			Name:        ConfigTicksSinceStartFiscalYearStart,
+			Description: "The first month of the fiscal year, 1-12, for --tick-mode quarter or year.",
+			Flag:        "fiscal-year-start",
+			Type:        core.IntConfigurationOption,
+			Default:     1,
 		},
 	}
 }
@@ -87,11 +143,33 @@ func (ticks *TicksSinceStart) Configure(facts map[string]interface{}) error {
 	} else {
 		ticks.TickSize = DefaultTicksSinceStartTickSize * time.Hour
 	}
+	if val, exists := facts[ConfigTicksSinceStartTickMode].(string); exists {
+		if _, known := calendarTickSizes[val]; !known && val != TickModeFixed && val != "" {
+			return fmt.Errorf("unknown tick mode: %s", val)
+		}
+		ticks.TickMode = val
+	}
+	if val, exists := facts[ConfigTicksSinceStartFiscalYearStart].(int); exists {
+		if val < 1 || val > 12 {
+			return fmt.Errorf("the fiscal year must start in month 1-12, got %d", val)
+		}
+		ticks.FiscalYearStart = time.Month(val)
+	}
+	if size, calendar := calendarTickSizes[ticks.TickMode]; calendar {
+		ticks.TickSize = size
+	}
 	if ticks.commits == nil {
 		ticks.commits = map[int][]plumbing.Hash{}
 	}
 	facts[FactCommitsByTick] = ticks.commits
 	facts[FactTickSize] = ticks.TickSize
+	facts[core.FactTickMode], facts[core.FactFiscalYearStart] = "", 0
+	if _, calendar := calendarTickSizes[ticks.TickMode]; calendar {
+		facts[core.FactTickMode] = ticks.TickMode
+		if ticks.TickMode == TickModeQuarter || ticks.TickMode == TickModeYear {
+			facts[core.FactFiscalYearStart] = int(ticks.fiscalYearStart())
+		}
+	}
 	return nil
 }
 
@@ -137,10 +215,21 @@ func (ticks *TicksSinceStart) Consume(deps map[string]interface{}) (map[string]i
 			ticks.l.Warnf("suspicious committer timestamp in %s > %s: %d",
 				ticks.remote, commit.Hash.String(), tick0.Unix())
 		}
-		*ticks.tick0 = FloorTime(tick0, ticks.TickSize)
+		_, calendar := calendarTickSizes[ticks.TickMode]
+		if calendar {
+			ticks.period0 = ticks.calendarPeriod(tick0)
+			*ticks.tick0 = ticks.calendarStart(ticks.period0)
+		} else {
+			*ticks.tick0 = FloorTime(tick0, ticks.TickSize)
+		}
 	}
 
-	tick := int(commit.Committer.When.Sub(*ticks.tick0) / ticks.TickSize)
+	var tick int
+	if _, calendar := calendarTickSizes[ticks.TickMode]; calendar {
+		tick = ticks.calendarPeriod(commit.Committer.When) - ticks.period0
+	} else {
+		tick = int(commit.Committer.When.Sub(*ticks.tick0) / ticks.TickSize)
+	}
 	if tick < ticks.previousTick {
 		// rebase works miracles, but we need the monotonous time
 		tick = ticks.previousTick
@@ -173,6 +262,78 @@ func (ticks *TicksSinceStart) Fork(n int) []core.PipelineItem {
 	return core.ForkCopyPipelineItem(ticks, n)
 }
 
+// calendarPeriod returns the absolute index of the UTC calendar period which contains the time.
+func (ticks *TicksSinceStart) calendarPeriod(t time.Time) int {
+	t = t.UTC()
+	months := t.Year()*12 + int(t.Month()-ticks.fiscalYearStart())
+	switch ticks.TickMode {
+	case TickModeWeek:
+		// 1970-01-01 is Thursday, so the ISO weeks start 3 days before the epoch
+		days := t.Unix()/(24*3600) + 3
+		if t.Unix() < 0 && t.Unix()%(24*3600) != 0 {
+			days--
+		}
+		return floorDiv(int(days), 7)
+	case TickModeMonth:
+		return t.Year()*12 + int(t.Month()-time.January)
+	case TickModeQuarter:
+		return floorDiv(months, 3)
+	case TickModeYear:
+		return floorDiv(months, 12)
+	}
+	return 0
+}
+
+// calendarStart returns the UTC beginning of the calendar period with the absolute index,
+// the inverse of calendarPeriod().
+func (ticks *TicksSinceStart) calendarStart(period int) time.Time {
+	// time.Date() normalizes the months beyond 1-12
+	switch ticks.TickMode {
+	case TickModeWeek:
+		return time.Unix(int64(period*7-3)*24*3600, 0).UTC()
+	case TickModeMonth:
+		return time.Date(0, time.Month(period)+time.January, 1, 0, 0, 0, 0, time.UTC)
+	case TickModeQuarter:
+		return time.Date(0, time.Month(period*3)+ticks.fiscalYearStart(), 1, 0, 0, 0, 0, time.UTC)
+	case TickModeYear:
+		return time.Date(0, time.Month(period*12)+ticks.fiscalYearStart(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return time.Time{}
+}
+
+// TickStart returns the beginning of the tick with the index, e.g. to convert the ticks in
+// the results to dates. `begin` is the time of the first analysed commit, tickMode and
+// fiscalYearStart are core.CommonAnalysisResult.TickMode and FiscalYearStart. The calendar
+// ticks start at their UTC periods the same way as in Consume(); the fixed ticks of tickSize
+// are counted from `begin`.
+func TickStart(begin time.Time, tick int, tickSize time.Duration, tickMode string,
+	fiscalYearStart int,
+) time.Time {
+	if _, calendar := calendarTickSizes[tickMode]; calendar {
+		ticks := &TicksSinceStart{TickMode: tickMode, FiscalYearStart: time.Month(fiscalYearStart)}
+		return ticks.calendarStart(ticks.calendarPeriod(begin) + tick)
+	}
+	if tickSize <= 0 {
+		tickSize = DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return begin.Add(time.Duration(tick) * tickSize)
+}
+
+// fiscalYearStart returns FiscalYearStart or January if it is not set.
+func (ticks *TicksSinceStart) fiscalYearStart() time.Month {
+	if ticks.FiscalYearStart < time.January {
+		return time.January
+	}
+	return ticks.FiscalYearStart
+}
+
+func floorDiv(a, b int) int {
+	if a < 0 && a%b != 0 {
+		return a/b - 1
+	}
+	return a / b
+}
+
 // FloorTime is the missing implementation of time.Time.Floor() - round to the nearest less than or equal.
 func FloorTime(t time.Time, d time.Duration) time.Time {
 	// We have check if the regular rounding resulted in Floor() + d.
@@ -183,6 +344,40 @@ func FloorTime(t time.Time, d time.Duration) time.Time {
 	return result
 }
 
+// ticksCheckpoint is the state of TicksSinceStart which Checkpoint() saves.
+type ticksCheckpoint struct {
+	Tick0        time.Time
+	Period0      int
+	PreviousTick int
+	Commits      map[int][]plumbing.Hash
+}
+
+// Checkpoint writes the beginning of the tick 0 and the commits of each tick.
+func (ticks *TicksSinceStart) Checkpoint(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(ticksCheckpoint{
+		Tick0:        *ticks.tick0,
+		Period0:      ticks.period0,
+		PreviousTick: ticks.previousTick,
+		Commits:      ticks.commits,
+	})
+}
+
+// Restore reads the state which Checkpoint() wrote.
+func (ticks *TicksSinceStart) Restore(reader io.Reader) error {
+	var state ticksCheckpoint
+	if err := gob.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+	*ticks.tick0 = state.Tick0
+	ticks.period0 = state.Period0
+	ticks.previousTick = state.PreviousTick
+	// FactCommitsByTick references the same map
+	for tick, commits := range state.Commits {
+		ticks.commits[tick] = commits
+	}
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&TicksSinceStart{})
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/meko-christian/hercules/internal/test"
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestTicksSinceStartMeta(t *testing.T) {
 	assert.Equal(t, len(tss.Provides()), 1)
 	assert.Equal(t, tss.Provides()[0], DependencyTick)
 	assert.Equal(t, len(tss.Requires()), 0)
-	assert.Len(t, tss.ListConfigurationOptions(), 1)
+	assert.Len(t, tss.ListConfigurationOptions(), 3)
 	logger := core.NewLogger()
 	assert.NoError(t, tss.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -184,6 +185,40 @@ func TestTicksCommits(t *testing.T) {
 	assert.Equal(t, tss.commits, commits)
 }
 
+func TestTicksSinceStartCheckpointRestore(t *testing.T) {
+	tss := fixtureTicksSinceStart(map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeMonth})
+	begin := time.Date(2020, 1, 15, 10, 0, 0, 0, time.UTC)
+	for i, when := range []time.Time{begin, begin.AddDate(0, 2, 0)} {
+		_, err := tss.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{
+				Hash: plumbing.NewHash(strings.Repeat(string(rune('a'+i)), 40)), Committer: object.Signature{When: when},
+			},
+			core.DependencyIndex: i,
+		})
+		assert.NoError(t, err)
+	}
+	var buffer bytes.Buffer
+	assert.NoError(t, tss.Checkpoint(&buffer))
+
+	facts := map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeMonth}
+	restored := fixtureTicksSinceStart(facts)
+	assert.NoError(t, restored.Restore(&buffer))
+	assert.True(t, tss.tick0.Equal(*restored.tick0))
+	assert.Equal(t, tss.period0, restored.period0)
+	assert.Equal(t, 2, restored.previousTick)
+	assert.Equal(t, tss.commits, restored.commits)
+	// the fact references the restored map
+	assert.Equal(t, tss.commits, facts[FactCommitsByTick])
+	res, err := restored.Consume(map[string]interface{}{
+		core.DependencyCommit: &object.Commit{
+			Hash: plumbing.NewHash(strings.Repeat("c", 40)), Committer: object.Signature{When: begin.AddDate(0, 3, 0)},
+		},
+		core.DependencyIndex: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, res[DependencyTick])
+}
+
 func TestTicksSinceStartFork(t *testing.T) {
 	tss1 := fixtureTicksSinceStart()
 	tss1.commits[0] = []plumbing.Hash{plumbing.NewHash(
@@ -230,3 +265,157 @@ func TestTicksSinceStartConsumeZero(t *testing.T) {
 	assert.Equal(t, tss.tick0.Minute(), 0)
 	assert.Equal(t, tss.tick0.Second(), 0)
 }
+
+func TestTicksSinceStartCalendarModes(t *testing.T) {
+	ticksOf := func(config map[string]interface{}, dates ...string) []int {
+		tss := fixtureTicksSinceStart(config)
+		var result []int
+		for i, date := range dates {
+			when, err := time.Parse(time.RFC3339, date)
+			assert.NoError(t, err)
+			commit := &object.Commit{
+				Hash:      plumbing.NewHash(strings.Repeat(string(rune('0'+i)), 40)),
+				Committer: object.Signature{When: when},
+			}
+			res, err := tss.Consume(map[string]interface{}{
+				core.DependencyCommit: commit,
+				core.DependencyIndex:  i,
+			})
+			assert.NoError(t, err)
+			result = append(result, res[DependencyTick].(int))
+		}
+		return result
+	}
+	dates := []string{
+		"2021-12-31T23:00:00Z", // Friday
+		"2022-01-02T23:59:59Z", // Sunday, same ISO week
+		"2022-01-03T00:00:00Z", // Monday
+		"2022-03-31T12:00:00Z",
+		"2022-04-01T00:00:00+03:00", // still March in UTC
+		"2022-07-15T00:00:00Z",
+		"2023-01-01T00:00:00Z",
+	}
+	assert.Equal(t, []int{0, 0, 1, 13, 13, 28, 52},
+		ticksOf(map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeWeek}, dates...))
+	assert.Equal(t, []int{0, 1, 1, 3, 3, 7, 13},
+		ticksOf(map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeMonth}, dates...))
+	assert.Equal(t, []int{0, 1, 1, 1, 1, 3, 5},
+		ticksOf(map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeQuarter}, dates...))
+	assert.Equal(t, []int{0, 1, 1, 1, 1, 1, 2},
+		ticksOf(map[string]interface{}{ConfigTicksSinceStartTickMode: TickModeYear}, dates...))
+	// the fiscal quarters start in November, February, May and August
+	assert.Equal(t, []int{0, 0, 0, 1, 1, 2, 4}, ticksOf(map[string]interface{}{
+		ConfigTicksSinceStartTickMode:        TickModeQuarter,
+		ConfigTicksSinceStartFiscalYearStart: 2,
+	}, dates...))
+	// the fiscal year starts in April
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 1}, ticksOf(map[string]interface{}{
+		ConfigTicksSinceStartTickMode:        TickModeYear,
+		ConfigTicksSinceStartFiscalYearStart: 4,
+	}, dates...))
+}
+
+func TestTicksSinceStartCalendarTick0(t *testing.T) {
+	tick0Of := func(mode string, fiscalYearStart int) string {
+		tss := fixtureTicksSinceStart(map[string]interface{}{
+			ConfigTicksSinceStartTickMode:        mode,
+			ConfigTicksSinceStartFiscalYearStart: fiscalYearStart,
+		})
+		commit := &object.Commit{
+			Hash:      plumbing.NewHash(strings.Repeat("0", 40)),
+			Committer: object.Signature{When: time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC)},
+		}
+		_, err := tss.Consume(map[string]interface{}{
+			core.DependencyCommit: commit,
+			core.DependencyIndex:  0,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, tss.period0, tss.calendarPeriod(*tss.tick0))
+		return tss.tick0.Format(time.RFC3339)
+	}
+	assert.Equal(t, "2021-12-27T00:00:00Z", tick0Of(TickModeWeek, 1))
+	assert.Equal(t, "2021-12-01T00:00:00Z", tick0Of(TickModeMonth, 1))
+	assert.Equal(t, "2021-10-01T00:00:00Z", tick0Of(TickModeQuarter, 1))
+	assert.Equal(t, "2021-11-01T00:00:00Z", tick0Of(TickModeQuarter, 2))
+	assert.Equal(t, "2021-01-01T00:00:00Z", tick0Of(TickModeYear, 1))
+	assert.Equal(t, "2021-04-01T00:00:00Z", tick0Of(TickModeYear, 4))
+}
+
+func TestTickStart(t *testing.T) {
+	dates := []time.Time{
+		time.Date(2021, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 2, 28, 23, 59, 59, 0, time.UTC),
+		time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 10, 31, 23, 59, 59, 0, time.UTC),
+		time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, config := range []struct {
+		mode            string
+		fiscalYearStart int
+	}{{TickModeMonth, 1}, {TickModeQuarter, 1}, {TickModeQuarter, 2}, {TickModeWeek, 1}, {TickModeYear, 4}} {
+		tss := fixtureTicksSinceStart(map[string]interface{}{
+			ConfigTicksSinceStartTickMode:        config.mode,
+			ConfigTicksSinceStartFiscalYearStart: config.fiscalYearStart,
+		})
+		for i, date := range dates {
+			res, err := tss.Consume(map[string]interface{}{
+				core.DependencyCommit: &object.Commit{
+					Hash:      plumbing.NewHash(strings.Repeat(string(rune('0'+i)), 40)),
+					Committer: object.Signature{When: date},
+				},
+				core.DependencyIndex: i,
+			})
+			assert.NoError(t, err)
+			tick := res[DependencyTick].(int)
+			start := TickStart(dates[0], tick, tss.TickSize, config.mode, config.fiscalYearStart)
+			// the tick starts at the beginning of the calendar period of the commit
+			assert.Equal(t, tss.calendarPeriod(date), tss.calendarPeriod(start), "%v %v", config, date)
+			assert.False(t, start.After(date), "%v %v", config, date)
+			assert.True(t, tss.calendarPeriod(start.Add(-time.Second)) < tss.calendarPeriod(start))
+		}
+	}
+	assert.Equal(t, "2022-03-01T00:00:00Z", TickStart(dates[0], 3, 0, TickModeMonth, 0).Format(time.RFC3339))
+	assert.Equal(t, "2022-02-01T00:00:00Z", TickStart(dates[0], 1, 0, TickModeQuarter, 2).Format(time.RFC3339))
+	assert.Equal(t, "2025-07-01T00:00:00Z", TickStart(dates[0], 15, 0, TickModeQuarter, 1).Format(time.RFC3339))
+	// the fixed ticks are counted from the first commit
+	assert.Equal(t, dates[0].Add(6*time.Hour), TickStart(dates[0], 2, 3*time.Hour, "", 0))
+	assert.Equal(t, dates[0].Add(48*time.Hour), TickStart(dates[0], 2, 0, TickModeFixed, 0))
+}
+
+func TestTicksSinceStartConfigureTickMode(t *testing.T) {
+	tss := &TicksSinceStart{}
+	facts := map[string]interface{}{
+		ConfigTicksSinceStartTickSize:        12,
+		ConfigTicksSinceStartTickMode:        TickModeWeek,
+		ConfigTicksSinceStartFiscalYearStart: 7,
+	}
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, TickModeWeek, tss.TickMode)
+	assert.Equal(t, time.July, tss.FiscalYearStart)
+	assert.Equal(t, 7*24*time.Hour, facts[FactTickSize])
+	assert.Equal(t, TickModeWeek, facts[core.FactTickMode])
+	// the weeks do not depend on the fiscal year
+	assert.Equal(t, 0, facts[core.FactFiscalYearStart])
+	facts[ConfigTicksSinceStartTickMode] = TickModeQuarter
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, TickModeQuarter, facts[core.FactTickMode])
+	assert.Equal(t, 7, facts[core.FactFiscalYearStart])
+	// FactTickSize overwrites ConfigTicksSinceStartTickSize
+	facts = map[string]interface{}{
+		ConfigTicksSinceStartTickSize: 12,
+		ConfigTicksSinceStartTickMode: TickModeFixed,
+	}
+	assert.NoError(t, tss.Configure(facts))
+	assert.Equal(t, 12*time.Hour, facts[FactTickSize])
+	assert.Equal(t, "", facts[core.FactTickMode])
+	assert.Equal(t, 0, facts[core.FactFiscalYearStart])
+	facts[ConfigTicksSinceStartTickMode] = "fortnight"
+	assert.Error(t, tss.Configure(facts))
+	facts[ConfigTicksSinceStartTickMode] = TickModeYear
+	facts[ConfigTicksSinceStartFiscalYearStart] = 13
+	assert.Error(t, tss.Configure(facts))
+}
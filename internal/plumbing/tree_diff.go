@@ -1,14 +1,17 @@
 package plumbing
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"path"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/src-d/enry/v2"
@@ -25,6 +28,10 @@ type TreeDiff struct {
 	// Languages is the set of allowed languages. The values must be lower case. The default
 	// (empty) set disables the language filter.
 	Languages map[string]bool
+	// PathPrefixes are the directories or the files to which the analysis is restricted.
+	// Only the subtrees under these prefixes are diffed. The default (empty) list analyses
+	// the whole tree.
+	PathPrefixes []string
 
 	previousTree   *object.Tree
 	previousCommit plumbing.Hash
@@ -54,6 +61,10 @@ const (
 	// ConfigTreeDiffFilterRegexp is the name of the configuration option
 	// (TreeDiff.Configure()) which makes FileDiff consider only those files which have names matching this regexp.
 	ConfigTreeDiffFilterRegexp = "TreeDiff.FilteredRegexes"
+
+	// ConfigTreeDiffPathPrefixes is the name of the configuration option (TreeDiff.Configure())
+	// which sets TreeDiff.PathPrefixes.
+	ConfigTreeDiffPathPrefixes = "TreeDiff.PathPrefixes"
 )
 
 // defaultBlacklistedPrefixes is the list of file path prefixes which should be skipped by default.
@@ -83,10 +94,21 @@ func (treediff *TreeDiff) Requires() []string {
 	return []string{}
 }
 
+// ThreadSafety returns whether the instances of this PipelineItem may run concurrently.
+func (treediff *TreeDiff) ThreadSafety() core.ThreadSafety {
+	return core.ThreadSafe
+}
+
 func (*TreeDiff) Features() []string {
 	return []string{core.FeatureGitCommits}
 }
 
+// ConflictsWith returns the features which cannot be enabled together with this item:
+// the stub repository of git.stub has no real commits to diff.
+func (*TreeDiff) ConflictsWith() []string {
+	return []string{core.FeatureGitStub}
+}
+
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (treediff *TreeDiff) ListConfigurationOptions() []core.ConfigurationOption {
 	options := [...]core.ConfigurationOption{
@@ -121,6 +143,13 @@ func (treediff *TreeDiff) ListConfigurationOptions() []core.ConfigurationOption
 			Flag:        "whitelist",
 			Type:        core.StringConfigurationOption,
 			Default:     "",
+		}, {
+			Name: ConfigTreeDiffPathPrefixes,
+			Description: "Analyze only the files under these directories or the files themselves, " +
+				"e.g. \"src/payments\". The rest of the tree is not even diffed. Separated with commas \",\".",
+			Flag:    "path-prefix",
+			Type:    core.StringsConfigurationOption,
+			Default: []string{},
 		},
 	}
 	return options[:]
@@ -147,6 +176,9 @@ func (treediff *TreeDiff) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigTreeDiffFilterRegexp].(string); exists {
 		treediff.NameFilter = regexp.MustCompile(val)
 	}
+	if val, exists := facts[ConfigTreeDiffPathPrefixes].([]string); exists {
+		treediff.PathPrefixes = normalizePathPrefixes(val)
+	}
 	return nil
 }
 
@@ -174,6 +206,7 @@ func (treediff *TreeDiff) Initialize(repository *git.Repository) error {
 // in Provides(). If there was an error, nil is returned.
 func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	commit := deps[core.DependencyCommit].(*object.Commit)
+	ctx := core.ContextFromDeps(deps)
 	pass := false
 	for _, hash := range commit.ParentHashes {
 		if hash == treediff.previousCommit {
@@ -190,9 +223,20 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 		return nil, err
 	}
 	var diffs object.Changes
-	if treediff.previousTree != nil {
-		diffs, err = object.DiffTree(treediff.previousTree, tree)
+	if len(treediff.PathPrefixes) > 0 {
+		diffs, err = treediff.diffPathPrefixes(ctx, treediff.previousTree, tree)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+	} else if treediff.previousTree != nil {
+		diffs, err = object.DiffTreeContext(ctx, treediff.previousTree, tree)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			return nil, err
 		}
 	} else {
@@ -201,6 +245,9 @@ func (treediff *TreeDiff) Consume(deps map[string]interface{}) (map[string]inter
 			fileIter := tree.Files()
 			defer fileIter.Close()
 			for {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
 				file, err := fileIter.Next()
 				if err != nil {
 					if err == io.EOF {
@@ -268,6 +315,133 @@ OUTER:
 	return filteredDiffs
 }
 
+// diffPathPrefixes compares only the subtrees and the files under PathPrefixes. The names
+// in the returned changes are relative to the root of the repository.
+func (treediff *TreeDiff) diffPathPrefixes(ctx context.Context, from, to *object.Tree) (object.Changes, error) {
+	var diffs object.Changes
+	for _, prefix := range treediff.PathPrefixes {
+		fromTree, fromEntry, err := lookupPathPrefix(from, prefix)
+		if err != nil {
+			return nil, err
+		}
+		toTree, toEntry, err := lookupPathPrefix(to, prefix)
+		if err != nil {
+			return nil, err
+		}
+		if fromTree != nil || toTree != nil {
+			changes, err := object.DiffTreeContext(ctx, fromTree, toTree)
+			if err != nil {
+				return nil, err
+			}
+			for _, change := range changes {
+				if change.From.Name != "" {
+					change.From.Name = prefix + "/" + change.From.Name
+				}
+				if change.To.Name != "" {
+					change.To.Name = prefix + "/" + change.To.Name
+				}
+				pass, err := treediff.checkPrefixedLanguage(from, change)
+				if err != nil {
+					return nil, err
+				}
+				if pass {
+					diffs = append(diffs, change)
+				}
+			}
+		}
+		if fromEntry == nil && toEntry == nil ||
+			fromEntry != nil && toEntry != nil && *fromEntry == *toEntry {
+			continue
+		}
+		// the prefix is a file which changed
+		change := &object.Change{}
+		if fromEntry != nil {
+			change.From = object.ChangeEntry{Name: prefix, Tree: from, TreeEntry: *fromEntry}
+		}
+		if toEntry != nil {
+			change.To = object.ChangeEntry{Name: prefix, Tree: to, TreeEntry: *toEntry}
+		}
+		pass, err := treediff.checkPrefixedLanguage(from, change)
+		if err != nil {
+			return nil, err
+		}
+		if pass {
+			diffs = append(diffs, change)
+		}
+	}
+	return diffs, nil
+}
+
+// checkPrefixedLanguage applies the language filter to the added files of the first commit
+// the same way as the full tree walk does. The rest is filtered by filterDiffs().
+func (treediff *TreeDiff) checkPrefixedLanguage(from *object.Tree, change *object.Change) (bool, error) {
+	if from != nil {
+		return true, nil
+	}
+	return treediff.checkLanguage(change.To.Name, change.To.TreeEntry.Hash)
+}
+
+// lookupPathPrefix returns the subtree if the prefix is a directory in the tree, or the entry
+// if it is a file. Both are nil if the prefix does not exist.
+func lookupPathPrefix(tree *object.Tree, prefix string) (*object.Tree, *object.TreeEntry, error) {
+	if tree == nil {
+		return nil, nil, nil
+	}
+	parts := strings.Split(prefix, "/")
+	for i, part := range parts {
+		entry, err := tree.FindEntry(part)
+		if err == object.ErrEntryNotFound {
+			return nil, nil, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if entry.Mode == filemode.Submodule {
+			return nil, nil, nil
+		}
+		if entry.Mode != filemode.Dir {
+			if i < len(parts)-1 {
+				return nil, nil, nil
+			}
+			return nil, entry, nil
+		}
+		if tree, err = tree.Tree(part); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree, nil, nil
+}
+
+// normalizePathPrefixes cleans the path prefixes and removes those which are inside the others
+// so that no file is reported twice. The root of the repository disables the restriction.
+func normalizePathPrefixes(prefixes []string) []string {
+	var cleaned []string
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSpace(prefix)
+		if prefix == "" {
+			continue
+		}
+		prefix = strings.Trim(path.Clean("/"+prefix), "/")
+		if prefix == "" {
+			return nil
+		}
+		cleaned = append(cleaned, prefix)
+	}
+	// the parents go before their children
+	sort.Strings(cleaned)
+	var result []string
+OUTER:
+	for _, prefix := range cleaned {
+		for _, parent := range result {
+			if prefix == parent || strings.HasPrefix(prefix, parent+"/") {
+				continue OUTER
+			}
+		}
+		result = append(result, prefix)
+	}
+	return result
+}
+
 // Fork clones this PipelineItem.
 func (treediff *TreeDiff) Fork(n int) []core.PipelineItem {
 	return core.ForkCopyPipelineItem(treediff, n)
@@ -298,6 +472,32 @@ func (treediff *TreeDiff) checkLanguage(name string, blobHash plumbing.Hash) (bo
 	return treediff.Languages[lang], nil
 }
 
+// Checkpoint writes the hash of the previous commit.
+func (treediff *TreeDiff) Checkpoint(writer io.Writer) error {
+	_, err := writer.Write(treediff.previousCommit[:])
+	return err
+}
+
+// Restore reads the hash of the previous commit which Checkpoint() wrote and loads its tree.
+func (treediff *TreeDiff) Restore(reader io.Reader) error {
+	var hash plumbing.Hash
+	if _, err := io.ReadFull(reader, hash[:]); err != nil {
+		return err
+	}
+	if hash.IsZero() {
+		return nil
+	}
+	commit, err := treediff.repository.CommitObject(hash)
+	if err != nil {
+		return err
+	}
+	if treediff.previousTree, err = commit.Tree(); err != nil {
+		return err
+	}
+	treediff.previousCommit = hash
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&TreeDiff{})
 }
@@ -1,6 +1,8 @@
 package plumbing
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"github.com/go-git/go-git/v5/plumbing"
@@ -9,6 +11,7 @@ import (
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/meko-christian/hercules/internal/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func fixtureTreeDiff() *TreeDiff {
@@ -24,8 +27,9 @@ func TestTreeDiffMeta(t *testing.T) {
 	assert.Equal(t, len(td.Requires()), 0)
 	assert.Equal(t, len(td.Provides()), 1)
 	assert.Equal(t, td.Provides()[0], DependencyTreeChanges)
+	assert.Equal(t, core.ThreadSafe, core.GetThreadSafety(td))
 	opts := td.ListConfigurationOptions()
-	assert.Len(t, opts, 4)
+	assert.Len(t, opts, 5)
 	logger := core.NewLogger()
 	assert.NoError(t, td.Configure(map[string]interface{}{
 		core.ConfigLogger: logger,
@@ -53,6 +57,9 @@ func TestTreeDiffConfigure(t *testing.T) {
 	delete(facts, ConfigTreeDiffEnableBlacklist)
 	assert.Nil(t, td.Configure(facts))
 	assert.Equal(t, td.SkipFiles, []string{"test"})
+	facts[ConfigTreeDiffPathPrefixes] = []string{"src/payments/", "./docs", "src/payments/api"}
+	assert.Nil(t, td.Configure(facts))
+	assert.Equal(t, []string{"docs", "src/payments"}, td.PathPrefixes)
 }
 
 func TestTreeDiffRegistration(t *testing.T) {
@@ -127,6 +134,29 @@ func TestTreeDiffConsumeFirst(t *testing.T) {
 	}
 }
 
+func TestTreeDiffConsumeCancelled(t *testing.T) {
+	td := fixtureTreeDiff()
+	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	deps := map[string]interface{}{
+		core.DependencyCommit:  commit,
+		core.DependencyContext: ctx,
+	}
+	res, err := td.Consume(deps)
+	assert.Nil(t, res)
+	assert.Equal(t, context.Canceled, err)
+	assert.Nil(t, td.previousTree)
+	prevCommit, _ := test.Repository.CommitObject(plumbing.NewHash(
+		"fbe766ffdc3f87f6affddc051c6f8b419beea6a2"))
+	td.previousTree, _ = prevCommit.Tree()
+	res, err = td.Consume(deps)
+	assert.Nil(t, res)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, plumbing.ZeroHash, td.previousCommit)
+}
+
 func TestTreeDiffBadCommit(t *testing.T) {
 	td := fixtureTreeDiff()
 	commit, _ := test.Repository.CommitObject(plumbing.NewHash(
@@ -249,6 +279,38 @@ func TestTreeDiffConsumeLanguageFilter(t *testing.T) {
 	assert.Equal(t, changes[0].To.Name, "labours.py")
 }
 
+func TestTreeDiffCheckpointRestore(t *testing.T) {
+	head, err := test.Repository.Head()
+	require.NoError(t, err)
+	commit, err := test.Repository.CommitObject(head.Hash())
+	require.NoError(t, err)
+	parent, err := commit.Parent(0)
+	require.NoError(t, err)
+	td := fixtureTreeDiff()
+	_, err = td.Consume(map[string]interface{}{core.DependencyCommit: parent})
+	require.NoError(t, err)
+	var buffer bytes.Buffer
+	assert.NoError(t, td.Checkpoint(&buffer))
+
+	restored := fixtureTreeDiff()
+	assert.NoError(t, restored.Restore(&buffer))
+	assert.Equal(t, parent.Hash, restored.previousCommit)
+	assert.Equal(t, parent.TreeHash, restored.previousTree.Hash)
+	res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+	require.NoError(t, err)
+	restoredRes, err := restored.Consume(map[string]interface{}{core.DependencyCommit: commit})
+	require.NoError(t, err)
+	assert.Equal(t, len(res[DependencyTreeChanges].(object.Changes)),
+		len(restoredRes[DependencyTreeChanges].(object.Changes)))
+
+	// the checkpoint before the first commit
+	buffer.Reset()
+	assert.NoError(t, fixtureTreeDiff().Checkpoint(&buffer))
+	restored = fixtureTreeDiff()
+	assert.NoError(t, restored.Restore(&buffer))
+	assert.Nil(t, restored.previousTree)
+}
+
 func TestTreeDiffFork(t *testing.T) {
 	td1 := fixtureTreeDiff()
 	td1.SkipFiles = append(td1.SkipFiles, "skip")
@@ -304,3 +366,50 @@ func TestTreeDiffCheckLanguageEmpty(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, lang)
 }
+
+func TestNormalizePathPrefixes(t *testing.T) {
+	assert.Equal(t, []string{"a", "a-b", "c/d.go"},
+		normalizePathPrefixes([]string{"a-b", "/a/", "a/b/c", "", " c/d.go", "a-b/x"}))
+	assert.Nil(t, normalizePathPrefixes([]string{"a", "/"}))
+	assert.Nil(t, normalizePathPrefixes(nil))
+}
+
+func TestTreeDiffConsumePathPrefixes(t *testing.T) {
+	repository, commits := fixturePathFilterRepository(t)
+	consume := func(prefixes ...string) [][]string {
+		td := &TreeDiff{}
+		require.NoError(t, td.Configure(map[string]interface{}{ConfigTreeDiffPathPrefixes: prefixes}))
+		require.NoError(t, td.Initialize(repository))
+		var result [][]string
+		for _, commit := range commits {
+			res, err := td.Consume(map[string]interface{}{core.DependencyCommit: commit})
+			require.NoError(t, err)
+			var names []string
+			for _, change := range res[DependencyTreeChanges].(object.Changes) {
+				action, err := change.Action()
+				require.NoError(t, err)
+				name := change.To.Name
+				if name == "" {
+					name = change.From.Name
+				} else {
+					// the prefixed entries must resolve to the right blobs
+					file, err := commit.File(name)
+					require.NoError(t, err)
+					assert.Equal(t, file.Hash, change.To.TreeEntry.Hash)
+				}
+				names = append(names, action.String()[:1]+" "+name)
+			}
+			result = append(result, names)
+		}
+		return result
+	}
+	assert.Equal(t, [][]string{
+		{"I main.go", "I vendor/lib.go"}, {"M main.go", "M vendor/lib.go"}, nil,
+	}, consume("vendor", "main.go"))
+	assert.Equal(t, [][]string{
+		{"I docs/.gitattributes", "I docs/guide.md"}, nil,
+		{"I docs/api.md", "I docs/example.go", "I docs/sub/more.md"},
+	}, consume("docs/"))
+	assert.Equal(t, [][]string{nil, nil, nil}, consume("missing", "main.go/x"))
+	assert.Len(t, consume("/")[0], 7)
+}
@@ -0,0 +1,360 @@
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// CorpusOptions describes the synthetic repository generated by GenerateCorpus().
+// The zero value produces a small linear history.
+type CorpusOptions struct {
+	// Commits is the number of the mainline commits including the merges, 10 by default.
+	Commits int
+	// Branches is the number of the side branches. Each forks from the mainline, receives
+	// BranchCommits commits while the mainline advances by one commit and is merged back.
+	// The branches touch only their own files, so the merges are clean.
+	Branches int
+	// BranchCommits is the number of the commits in each side branch, 2 by default.
+	BranchCommits int
+	// Authors is the number of the distinct authors who commit in turn, 3 by default.
+	Authors int
+	// Files is the number of the text files in the initial commit, 5 by default.
+	Files int
+	// Renames is the number of the mainline commits which rename a text file without changing it.
+	Renames int
+	// BinaryFiles is the number of the binary files in the initial commit.
+	BinaryFiles int
+	// UnicodePaths adds the text files with non-ASCII names to the initial commit.
+	UnicodePaths bool
+	// Seed initializes the pseudo-random generator of the file contents.
+	Seed int64
+	// Start is the time of the initial commit; the next commits are one hour apart.
+	// 2020-01-01 UTC by default.
+	Start time.Time
+}
+
+// Corpus is the repository generated by GenerateCorpus() together with its known properties
+// which the tests can check the analysis results against.
+type Corpus struct {
+	// Repository is the in-memory repository without the worktree. HEAD points to
+	// refs/heads/master and each side branch has refs/heads/branch<N>.
+	Repository *git.Repository
+	// Head is the last mainline commit.
+	Head plumbing.Hash
+	// Commits are all the commits in the order of their creation, the parents go first.
+	Commits []plumbing.Hash
+	// Merges are the merge commits.
+	Merges []plumbing.Hash
+	// Renames maps the old file names to the new ones.
+	Renames map[string]string
+	// BinaryFiles are the names of the binary files.
+	BinaryFiles []string
+	// Authors are the author names in the "Name <email>" format.
+	Authors []string
+}
+
+// corpusGenerator holds the state of GenerateCorpus().
+type corpusGenerator struct {
+	options CorpusOptions
+	corpus  *Corpus
+	rand    *rand.Rand
+	clock   time.Time
+	// textFiles are the names of the text files on the mainline which may be edited or renamed.
+	textFiles []string
+}
+
+// GenerateCorpus builds the synthetic repository with the requested properties. The result
+// depends only on the options, so the tests may rely on the exact shape of the history.
+func GenerateCorpus(options CorpusOptions) (*Corpus, error) {
+	if options.Commits <= 0 {
+		options.Commits = 10
+	}
+	if options.BranchCommits <= 0 {
+		options.BranchCommits = 2
+	}
+	if options.Authors <= 0 {
+		options.Authors = 3
+	}
+	if options.Files <= 0 {
+		options.Files = 5
+	}
+	if options.Start.IsZero() {
+		options.Start = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	// the initial commit, two mainline commits per branch - the concurrent one and the merge -
+	// and the renames which cannot happen in the merges
+	if options.Commits < 1+2*options.Branches+options.Renames {
+		return nil, fmt.Errorf("%d commits are not enough for %d branches and %d renames",
+			options.Commits, options.Branches, options.Renames)
+	}
+	if options.Renames > options.Files {
+		return nil, fmt.Errorf("cannot rename %d files out of %d", options.Renames, options.Files)
+	}
+	repository, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+	gen := &corpusGenerator{
+		options: options,
+		corpus:  &Corpus{Repository: repository, Renames: map[string]string{}},
+		rand:    rand.New(rand.NewSource(options.Seed)),
+		clock:   options.Start,
+	}
+	for i := 0; i < options.Authors; i++ {
+		gen.corpus.Authors = append(gen.corpus.Authors,
+			fmt.Sprintf("Author %d <author%d@example.com>", i, i))
+	}
+	if err = gen.generate(); err != nil {
+		return nil, err
+	}
+	return gen.corpus, nil
+}
+
+func (gen *corpusGenerator) generate() error {
+	files := map[string][]byte{}
+	for i := 0; i < gen.options.Files; i++ {
+		name := fmt.Sprintf("src/file%d.go", i)
+		files[name] = gen.text(name, 20)
+		gen.textFiles = append(gen.textFiles, name)
+	}
+	if gen.options.UnicodePaths {
+		for _, name := range []string{"docs/ünïcödé.md", "文档/说明.txt", "emoji/🚀 launch.txt"} {
+			files[name] = gen.text(name, 10)
+			gen.textFiles = append(gen.textFiles, name)
+		}
+	}
+	for i := 0; i < gen.options.BinaryFiles; i++ {
+		name := fmt.Sprintf("assets/blob%d.bin", i)
+		data := make([]byte, 256)
+		gen.rand.Read(data)
+		data[0] = 0
+		files[name] = data
+		gen.corpus.BinaryFiles = append(gen.corpus.BinaryFiles, name)
+	}
+	head, err := gen.commit(files, "initial commit", 0)
+	if err != nil {
+		return err
+	}
+
+	// the positions of the forks and the renames on the mainline
+	forks := map[int]int{}
+	for i := 0; i < gen.options.Branches; i++ {
+		forks[1+i*(gen.options.Commits-1)/gen.options.Branches] = i
+	}
+	renames := map[int]bool{}
+	for i, pos := 0, gen.options.Commits-1; i < gen.options.Renames; pos-- {
+		if !isMergePosition(forks, pos) {
+			renames[pos] = true
+			i++
+		}
+	}
+
+	type pendingMerge struct {
+		tip   plumbing.Hash
+		index int
+		files map[string][]byte
+	}
+	var merge *pendingMerge
+	for pos := 1; pos < gen.options.Commits; pos++ {
+		if merge != nil && isMergePosition(forks, pos) {
+			for name, data := range merge.files {
+				files[name] = data
+			}
+			message := fmt.Sprintf("Merge branch%d", merge.index)
+			if head, err = gen.commit(files, message, pos, head, merge.tip); err != nil {
+				return err
+			}
+			gen.corpus.Merges = append(gen.corpus.Merges, head)
+			merge = nil
+			continue
+		}
+		if index, isFork := forks[pos]; isFork {
+			branchFiles := map[string][]byte{}
+			for name, data := range files {
+				branchFiles[name] = data
+			}
+			own := map[string][]byte{}
+			tip := head
+			for i := 0; i < gen.options.BranchCommits; i++ {
+				name := fmt.Sprintf("feature%d/part%d.go", index, i%2)
+				own[name] = gen.edit(own[name], name)
+				branchFiles[name] = own[name]
+				message := fmt.Sprintf("branch%d: commit %d", index, i)
+				if tip, err = gen.commit(branchFiles, message, index+i+1, tip); err != nil {
+					return err
+				}
+			}
+			if err = gen.corpus.Repository.Storer.SetReference(plumbing.NewHashReference(
+				plumbing.NewBranchReferenceName(fmt.Sprintf("branch%d", index)), tip)); err != nil {
+				return err
+			}
+			merge = &pendingMerge{tip: tip, index: index, files: own}
+		}
+		var message string
+		if renames[pos] {
+			index := gen.rand.Intn(len(gen.textFiles))
+			oldName := gen.textFiles[index]
+			newName := fmt.Sprintf("renamed/%d-%s", pos, oldName[strings.LastIndexByte(oldName, '/')+1:])
+			files[newName] = files[oldName]
+			delete(files, oldName)
+			gen.textFiles[index] = newName
+			gen.corpus.Renames[oldName] = newName
+			message = fmt.Sprintf("rename %s to %s", oldName, newName)
+		} else {
+			name := gen.textFiles[gen.rand.Intn(len(gen.textFiles))]
+			files[name] = gen.edit(files[name], name)
+			message = "edit " + name
+		}
+		if head, err = gen.commit(files, message, pos, head); err != nil {
+			return err
+		}
+	}
+	gen.corpus.Head = head
+	storer := gen.corpus.Repository.Storer
+	if err = storer.SetReference(plumbing.NewHashReference("refs/heads/master", head)); err != nil {
+		return err
+	}
+	return storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/master"))
+}
+
+// isMergePosition returns true if the mainline commit at pos merges a side branch:
+// the branch forks from the commit before the fork position and is merged right after it.
+func isMergePosition(forks map[int]int, pos int) bool {
+	_, exists := forks[pos-1]
+	return exists
+}
+
+// text generates the specified number of lines.
+func (gen *corpusGenerator) text(name string, lines int) []byte {
+	builder := &strings.Builder{}
+	for i := 0; i < lines; i++ {
+		fmt.Fprintf(builder, "%s line %d value %d\n", name, i, gen.rand.Intn(1000))
+	}
+	return []byte(builder.String())
+}
+
+// edit replaces a random line and appends a new one.
+func (gen *corpusGenerator) edit(data []byte, name string) []byte {
+	lines := strings.SplitAfter(string(data), "\n")
+	lines = lines[:len(lines)-1]
+	if len(lines) > 0 {
+		lines[gen.rand.Intn(len(lines))] = fmt.Sprintf("%s changed %d\n", name, gen.rand.Intn(1000))
+	}
+	lines = append(lines, fmt.Sprintf("%s appended %d\n", name, gen.rand.Intn(1000)))
+	return []byte(strings.Join(lines, ""))
+}
+
+// commit stores the files as the tree of the new commit.
+func (gen *corpusGenerator) commit(
+	files map[string][]byte, message string, author int, parents ...plumbing.Hash,
+) (plumbing.Hash, error) {
+	tree, err := gen.writeTree(files, "")
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	name := gen.corpus.Authors[author%len(gen.corpus.Authors)]
+	signature := object.Signature{
+		Name:  name[:strings.IndexByte(name, '<')-1],
+		Email: name[strings.IndexByte(name, '<')+1 : len(name)-1],
+		When:  gen.clock,
+	}
+	gen.clock = gen.clock.Add(time.Hour)
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     tree,
+		ParentHashes: parents,
+	}
+	hash, err := gen.store(commit)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	gen.corpus.Commits = append(gen.corpus.Commits, hash)
+	return hash, nil
+}
+
+// writeTree stores the blobs and the trees of the files under the prefix.
+func (gen *corpusGenerator) writeTree(files map[string][]byte, prefix string) (plumbing.Hash, error) {
+	var entries []object.TreeEntry
+	dirs := map[string]bool{}
+	for path, data := range files {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		rest := path[len(prefix):]
+		if slash := strings.IndexByte(rest, '/'); slash >= 0 {
+			dirs[rest[:slash]] = true
+			continue
+		}
+		blob := gen.corpus.Repository.Storer.NewEncodedObject()
+		blob.SetType(plumbing.BlobObject)
+		writer, err := blob.Writer()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if _, err = writer.Write(data); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if err = writer.Close(); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		hash, err := gen.corpus.Repository.Storer.SetEncodedObject(blob)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: rest, Mode: filemode.Regular, Hash: hash})
+	}
+	for dir := range dirs {
+		hash, err := gen.writeTree(files, prefix+dir+"/")
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		entries = append(entries, object.TreeEntry{Name: dir, Mode: filemode.Dir, Hash: hash})
+	}
+	// Git sorts the directories as if their names ended with a slash
+	sortKey := func(entry object.TreeEntry) string {
+		if entry.Mode == filemode.Dir {
+			return entry.Name + "/"
+		}
+		return entry.Name
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortKey(entries[i]) < sortKey(entries[j])
+	})
+	return gen.store(&object.Tree{Entries: entries})
+}
+
+// store encodes and saves the commit or the tree.
+func (gen *corpusGenerator) store(obj interface {
+	Encode(plumbing.EncodedObject) error
+},
+) (plumbing.Hash, error) {
+	encoded := gen.corpus.Repository.Storer.NewEncodedObject()
+	if err := obj.Encode(encoded); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return gen.corpus.Repository.Storer.SetEncodedObject(encoded)
+}
+
+// CommitObjects loads all the commits of the corpus in the order of their creation.
+func (corpus *Corpus) CommitObjects() ([]*object.Commit, error) {
+	commits := make([]*object.Commit, len(corpus.Commits))
+	for i, hash := range corpus.Commits {
+		commit, err := corpus.Repository.CommitObject(hash)
+		if err != nil {
+			return nil, err
+		}
+		commits[i] = commit
+	}
+	return commits, nil
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateCorpusDefaults(t *testing.T) {
+	corpus, err := GenerateCorpus(CorpusOptions{})
+	require.NoError(t, err)
+	assert.Len(t, corpus.Commits, 10)
+	assert.Empty(t, corpus.Merges)
+	assert.Empty(t, corpus.Renames)
+	assert.Len(t, corpus.Authors, 3)
+	head, err := corpus.Repository.Head()
+	require.NoError(t, err)
+	assert.Equal(t, corpus.Head, head.Hash())
+	commits, err := corpus.CommitObjects()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), commits[0].Author.When.UTC())
+	assert.Equal(t, "Author 1", commits[1].Author.Name)
+	assert.Equal(t, "author1@example.com", commits[1].Author.Email)
+	for i, commit := range commits[1:] {
+		assert.Equal(t, []plumbing.Hash{commits[i].Hash}, commit.ParentHashes)
+	}
+	tree, err := commits[0].Tree()
+	require.NoError(t, err)
+	files := 0
+	require.NoError(t, tree.Files().ForEach(func(*object.File) error {
+		files++
+		return nil
+	}))
+	assert.Equal(t, 5, files)
+
+	again, err := GenerateCorpus(CorpusOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corpus.Commits, again.Commits)
+}
+
+func TestGenerateCorpus(t *testing.T) {
+	corpus, err := GenerateCorpus(CorpusOptions{
+		Commits: 12, Branches: 3, BranchCommits: 3, Renames: 2, BinaryFiles: 2, UnicodePaths: true,
+		Seed: 7,
+	})
+	require.NoError(t, err)
+	assert.Len(t, corpus.Commits, 12+3*3)
+	assert.Len(t, corpus.Merges, 3)
+	assert.Len(t, corpus.Renames, 2)
+	assert.Equal(t, []string{"assets/blob0.bin", "assets/blob1.bin"}, corpus.BinaryFiles)
+	for i := 0; i < 3; i++ {
+		_, err = corpus.Repository.Reference(
+			plumbing.NewBranchReferenceName(fmt.Sprintf("branch%d", i)), true)
+		assert.NoError(t, err)
+	}
+	for _, hash := range corpus.Merges {
+		merge, err := corpus.Repository.CommitObject(hash)
+		require.NoError(t, err)
+		assert.Len(t, merge.ParentHashes, 2)
+	}
+
+	head, err := corpus.Repository.CommitObject(corpus.Head)
+	require.NoError(t, err)
+	for oldName, newName := range corpus.Renames {
+		_, err = head.File(oldName)
+		assert.Error(t, err, oldName)
+		_, err = head.File(newName)
+		assert.NoError(t, err, newName)
+	}
+	for _, name := range []string{"docs/ünïcödé.md", "文档/说明.txt", "feature0/part1.go", "feature2/part0.go"} {
+		_, err = head.File(name)
+		assert.NoError(t, err, name)
+	}
+	binary, err := head.File(corpus.BinaryFiles[0])
+	require.NoError(t, err)
+	isBinary, err := binary.IsBinary()
+	require.NoError(t, err)
+	assert.True(t, isBinary)
+
+	// every commit is reachable from HEAD
+	commits, err := corpus.Repository.Log(&git.LogOptions{})
+	require.NoError(t, err)
+	count := 0
+	require.NoError(t, commits.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, len(corpus.Commits), count)
+}
+
+func TestGenerateCorpusErrors(t *testing.T) {
+	_, err := GenerateCorpus(CorpusOptions{Commits: 4, Branches: 2})
+	assert.Error(t, err)
+	_, err = GenerateCorpus(CorpusOptions{Files: 2, Renames: 3})
+	assert.Error(t, err)
+}
@@ -0,0 +1,473 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigAbsenceMinDays is the name of the option to set the minimum length of an absence.
+	ConfigAbsenceMinDays = "Absence.MinDays"
+	// ConfigAbsenceBaselineFactor is the name of the option to set how many times longer
+	// than the personal baseline a gap in the activity must be to be considered an absence.
+	ConfigAbsenceBaselineFactor = "Absence.BaselineFactor"
+	// ConfigAbsenceOwnershipThreshold is the name of the option to set the share of the alive lines
+	// which a developer must own to be the only owner of a subsystem.
+	ConfigAbsenceOwnershipThreshold = "Absence.OwnershipThreshold"
+	// DefaultAbsenceMinDays is the default value of ConfigAbsenceMinDays.
+	DefaultAbsenceMinDays = 14
+	// DefaultAbsenceBaselineFactor is the default value of ConfigAbsenceBaselineFactor.
+	DefaultAbsenceBaselineFactor = float32(3)
+	// DefaultAbsenceOwnershipThreshold is the default value of ConfigAbsenceOwnershipThreshold.
+	DefaultAbsenceOwnershipThreshold = float32(0.8)
+	// absenceMinActiveTicks is the number of the active ticks which a developer must have
+	// to establish the personal baseline.
+	absenceMinActiveTicks = 3
+)
+
+// AbsenceAnalysis infers the periods when the developers were absent, e.g. on vacation,
+// from the gaps in their activity, and reports the periods when the only owner
+// of a subsystem (directory) was away. The baseline of each developer is the median distance
+// between their consecutive active ticks; a gap is an absence if it is at least MinDays long and
+// BaselineFactor times longer than the baseline. The ownership is measured by the alive lines
+// at the end of the analysed history, the same way as in BusFactorAnalysis.
+type AbsenceAnalysis struct {
+	core.NoopMerger
+	// MinDays is the minimum length of an absence in days.
+	MinDays int
+	// BaselineFactor is how many times longer than the personal baseline a gap must be.
+	BaselineFactor float32
+	// OwnershipThreshold is the share of the alive lines in a subsystem which its only owner
+	// must have.
+	OwnershipThreshold float32
+
+	// minTicks is MinDays converted to ticks.
+	minTicks int
+	// activity maps developer indexes to the ticks when they committed.
+	activity map[int]map[int]bool
+	// fileResolver is used to scan the alive lines in Finalize().
+	fileResolver core.FileIdResolver
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// AbsencePeriod is the inclusive range of ticks without any activity.
+type AbsencePeriod struct {
+	Begin int
+	End   int
+}
+
+// CoverageGap is the absence of the only owner of a subsystem.
+type CoverageGap struct {
+	// Subsystem is the directory.
+	Subsystem string
+	// Owner is the developer index.
+	Owner int
+	// Begin is the first tick of the absence.
+	Begin int
+	// End is the last tick of the absence.
+	End int
+}
+
+// AbsenceResult is returned by AbsenceAnalysis.Finalize().
+type AbsenceResult struct {
+	// Absences maps developer indexes to their absence periods in the chronological order.
+	Absences map[int][]AbsencePeriod
+	// Baselines maps developer indexes to the median distance between their consecutive
+	// active ticks. The developers with too few active ticks are missing.
+	Baselines map[int]float64
+	// Owners maps the single-owner subsystems to their owners.
+	Owners map[string]int
+	// CoverageGaps are the absences of the owners of Owners sorted by Begin and Subsystem.
+	CoverageGaps []CoverageGap
+	// OwnershipThreshold is the share of the lines which defines the only owner.
+	OwnershipThreshold float32
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize is the duration of each tick.
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ab *AbsenceAnalysis) Name() string {
+	return "Absence"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (ab *AbsenceAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (ab *AbsenceAnalysis) Requires() []string {
+	return []string{linehistory.DependencyLineHistory, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ab *AbsenceAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigAbsenceMinDays,
+		Description: "Minimum number of days without commits to infer an absence.",
+		Flag:        "absence-min-days",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultAbsenceMinDays,
+	}, {
+		Name: ConfigAbsenceBaselineFactor,
+		Description: "How many times longer than the median distance between the developer's " +
+			"active days a gap must be to infer an absence.",
+		Flag:    "absence-factor",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultAbsenceBaselineFactor,
+	}, {
+		Name:        ConfigAbsenceOwnershipThreshold,
+		Description: "Share of the alive lines in a directory which its only owner has (0.0-1.0].",
+		Flag:        "absence-ownership-threshold",
+		Type:        core.FloatConfigurationOption,
+		Default:     DefaultAbsenceOwnershipThreshold,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ab *AbsenceAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ab.l = l
+	}
+	if val, exists := facts[ConfigAbsenceMinDays].(int); exists {
+		ab.MinDays = val
+	}
+	if val, exists := facts[ConfigAbsenceBaselineFactor].(float32); exists {
+		ab.BaselineFactor = val
+	}
+	if val, exists := facts[ConfigAbsenceOwnershipThreshold].(float32); exists {
+		ab.OwnershipThreshold = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		ab.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		ab.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*AbsenceAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ab *AbsenceAnalysis) Flag() string {
+	return "absences"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ab *AbsenceAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ab *AbsenceAnalysis) Description() string {
+	return "Infers the absences of the developers from the gaps in their activity and reports " +
+		"the periods when the only owner of a directory was away."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ab *AbsenceAnalysis) Initialize(repository *git.Repository) error {
+	ab.l = core.NewLogger()
+	if ab.MinDays < 0 {
+		return fmt.Errorf("--absence-min-days cannot be negative (got %d)", ab.MinDays)
+	}
+	if ab.BaselineFactor < 0 {
+		return fmt.Errorf("--absence-factor cannot be negative (got %f)", ab.BaselineFactor)
+	}
+	if ab.OwnershipThreshold <= 0 || ab.OwnershipThreshold > 1 {
+		return fmt.Errorf("--absence-ownership-threshold must be in (0, 1] (got %f)",
+			ab.OwnershipThreshold)
+	}
+	if ab.tickSize == 0 {
+		ab.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	ab.minTicks = int(math.Ceil(float64(time.Duration(ab.MinDays)*24*time.Hour) / float64(ab.tickSize)))
+	ab.activity = map[int]map[int]bool{}
+	ab.fileResolver = nil
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ab *AbsenceAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	ab.fileResolver = deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges).Resolver
+	author := deps[identity.DependencyAuthor].(int)
+	if author == core.AuthorMissing {
+		return nil, nil
+	}
+	ticks := ab.activity[author]
+	if ticks == nil {
+		ticks = map[int]bool{}
+		ab.activity[author] = ticks
+	}
+	ticks[deps[items.DependencyTick].(int)] = true
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ab *AbsenceAnalysis) Finalize() interface{} {
+	result := AbsenceResult{
+		Absences:           map[int][]AbsencePeriod{},
+		Baselines:          map[int]float64{},
+		Owners:             ab.findSingleOwners(),
+		CoverageGaps:       []CoverageGap{},
+		OwnershipThreshold: ab.OwnershipThreshold,
+		reversedPeopleDict: ab.reversedPeopleDict,
+		tickSize:           ab.tickSize,
+	}
+	for dev, activeTicks := range ab.activity {
+		if len(activeTicks) < absenceMinActiveTicks {
+			continue
+		}
+		ticks := make([]int, 0, len(activeTicks))
+		for tick := range activeTicks {
+			ticks = append(ticks, tick)
+		}
+		sort.Ints(ticks)
+		gaps := make([]int, len(ticks)-1)
+		for i := range gaps {
+			gaps[i] = ticks[i+1] - ticks[i]
+		}
+		baseline := medianInt(gaps)
+		result.Baselines[dev] = baseline
+		var periods []AbsencePeriod
+		for i, gap := range gaps {
+			if gap-1 >= ab.minTicks && float64(gap) > float64(ab.BaselineFactor)*baseline {
+				periods = append(periods, AbsencePeriod{Begin: ticks[i] + 1, End: ticks[i+1] - 1})
+			}
+		}
+		if len(periods) > 0 {
+			result.Absences[dev] = periods
+		}
+	}
+	for dir, owner := range result.Owners {
+		for _, period := range result.Absences[owner] {
+			result.CoverageGaps = append(result.CoverageGaps, CoverageGap{
+				Subsystem: dir, Owner: owner, Begin: period.Begin, End: period.End,
+			})
+		}
+	}
+	sort.Slice(result.CoverageGaps, func(i, j int) bool {
+		gi, gj := result.CoverageGaps[i], result.CoverageGaps[j]
+		if gi.Begin != gj.Begin {
+			return gi.Begin < gj.Begin
+		}
+		return gi.Subsystem < gj.Subsystem
+	})
+	return result
+}
+
+// findSingleOwners returns the subsystems in which one developer owns at least
+// OwnershipThreshold of the alive lines, mapped to that developer.
+func (ab *AbsenceAnalysis) findSingleOwners() map[string]int {
+	owners := map[string]int{}
+	if ab.fileResolver == nil {
+		return owners
+	}
+	subsystems := map[string]map[int]int64{}
+	ab.fileResolver.ForEachFile(func(id core.FileId, name string) {
+		dir := subsystemOf(name)
+		lines := subsystems[dir]
+		if lines == nil {
+			lines = map[int]int64{}
+			subsystems[dir] = lines
+		}
+		previousLine, previousAuthor := -1, core.AuthorId(core.AuthorMissing)
+		ab.fileResolver.ScanFile(id, func(line int, _ core.TickNumber, author core.AuthorId) {
+			if previousLine >= 0 && line > previousLine && previousAuthor < core.AuthorMissing {
+				lines[int(previousAuthor)] += int64(line - previousLine)
+			}
+			previousLine, previousAuthor = line, author
+		})
+	})
+	for dir, lines := range subsystems {
+		var total, maxLines int64
+		owner := -1
+		for dev, count := range lines {
+			total += count
+			if count > maxLines || (count == maxLines && dev < owner) {
+				owner, maxLines = dev, count
+			}
+		}
+		if total > 0 && float64(maxLines) >= float64(ab.OwnershipThreshold)*float64(total) {
+			owners[dir] = owner
+		}
+	}
+	return owners
+}
+
+// medianInt returns the median of the non-empty series.
+func medianInt(values []int) float64 {
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[middle])
+	}
+	return float64(sorted[middle-1]+sorted[middle]) / 2
+}
+
+// Fork clones this pipeline item.
+func (ab *AbsenceAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ab, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ab *AbsenceAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	absenceResult, ok := result.(AbsenceResult)
+	if !ok {
+		return fmt.Errorf("result is not an absence result: '%v'", result)
+	}
+	if binary {
+		return ab.serializeBinary(&absenceResult, writer)
+	}
+	ab.serializeText(&absenceResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to AbsenceResult.
+func (ab *AbsenceAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.AbsenceResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := AbsenceResult{
+		Absences:           map[int][]AbsencePeriod{},
+		Baselines:          make(map[int]float64, len(message.Developers)),
+		Owners:             make(map[string]int, len(message.Owners)),
+		CoverageGaps:       make([]CoverageGap, len(message.CoverageGaps)),
+		OwnershipThreshold: message.OwnershipThreshold,
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, absences := range message.Developers {
+		result.Baselines[int(dev)] = absences.GetBaseline()
+		if len(absences.GetPeriods()) == 0 {
+			continue
+		}
+		periods := make([]AbsencePeriod, len(absences.Periods))
+		for i, period := range absences.Periods {
+			periods[i] = AbsencePeriod{Begin: int(period.Begin), End: int(period.End)}
+		}
+		result.Absences[int(dev)] = periods
+	}
+	for dir, owner := range message.Owners {
+		result.Owners[dir] = int(owner)
+	}
+	for i, gap := range message.CoverageGaps {
+		result.CoverageGaps[i] = CoverageGap{
+			Subsystem: gap.Subsystem, Owner: int(gap.Owner), Begin: int(gap.Begin), End: int(gap.End),
+		}
+	}
+	return result, nil
+}
+
+func (ab *AbsenceAnalysis) serializeText(result *AbsenceResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  absence:")
+	fmt.Fprintf(writer, "    ownership_threshold: %.2f\n", result.OwnershipThreshold)
+	fmt.Fprintln(writer, "    developers:")
+	devs := make([]int, 0, len(result.Baselines))
+	for dev := range result.Baselines {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d:\n", dev)
+		fmt.Fprintf(writer, "        baseline: %.1f\n", result.Baselines[dev])
+		fmt.Fprint(writer, "        absences: [")
+		for i, period := range result.Absences[dev] {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "[%d, %d]", period.Begin, period.End)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "    owners:")
+	dirs := make([]string, 0, len(result.Owners))
+	for dir := range result.Owners {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fmt.Fprintf(writer, "      %s: %d\n", yaml.SafeString(dir), result.Owners[dir])
+	}
+	fmt.Fprintln(writer, "    coverage_gaps:")
+	for _, gap := range result.CoverageGaps {
+		fmt.Fprintf(writer, "    - {subsystem: %s, owner: %d, begin: %d, end: %d}\n",
+			yaml.SafeString(gap.Subsystem), gap.Owner, gap.Begin, gap.End)
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (ab *AbsenceAnalysis) serializeBinary(result *AbsenceResult, writer io.Writer) error {
+	message := pb.AbsenceResults{
+		Developers:         make(map[int32]*pb.DeveloperAbsences, len(result.Baselines)),
+		Owners:             make(map[string]int32, len(result.Owners)),
+		CoverageGaps:       make([]*pb.CoverageGap, len(result.CoverageGaps)),
+		OwnershipThreshold: result.OwnershipThreshold,
+		TickSize:           int64(result.tickSize),
+		DevIndex:           result.reversedPeopleDict,
+	}
+	for dev, baseline := range result.Baselines {
+		absences := &pb.DeveloperAbsences{Baseline: baseline}
+		for _, period := range result.Absences[dev] {
+			absences.Periods = append(absences.Periods, &pb.AbsencePeriod{
+				Begin: int32(period.Begin), End: int32(period.End),
+			})
+		}
+		message.Developers[int32(dev)] = absences
+	}
+	for dir, owner := range result.Owners {
+		message.Owners[dir] = int32(owner)
+	}
+	for i, gap := range result.CoverageGaps {
+		message.CoverageGaps[i] = &pb.CoverageGap{
+			Subsystem: gap.Subsystem, Owner: int32(gap.Owner), Begin: int32(gap.Begin), End: int32(gap.End),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&AbsenceAnalysis{})
+}
@@ -0,0 +1,169 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsenceMeta(t *testing.T) {
+	ab := &AbsenceAnalysis{}
+	assert.Equal(t, "Absence", ab.Name())
+	assert.Len(t, ab.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory, identity.DependencyAuthor,
+		items.DependencyTick}, ab.Requires())
+	assert.Equal(t, "absences", ab.Flag())
+	assert.Equal(t, core.CostHeavy, ab.Cost())
+	opts := ab.ListConfigurationOptions()
+	require.Len(t, opts, 3)
+	assert.Equal(t, ConfigAbsenceMinDays, opts[0].Name)
+	assert.Equal(t, DefaultAbsenceMinDays, opts[0].Default)
+	assert.Equal(t, ConfigAbsenceBaselineFactor, opts[1].Name)
+	assert.Equal(t, DefaultAbsenceBaselineFactor, opts[1].Default)
+	assert.Equal(t, ConfigAbsenceOwnershipThreshold, opts[2].Name)
+	assert.Equal(t, DefaultAbsenceOwnershipThreshold, opts[2].Default)
+	require.NoError(t, ab.Configure(map[string]interface{}{
+		ConfigAbsenceMinDays:                            7,
+		ConfigAbsenceBaselineFactor:                     float32(2),
+		ConfigAbsenceOwnershipThreshold:                 float32(0.9),
+		items.FactTickSize:                              12 * time.Hour,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice"},
+	}))
+	assert.Equal(t, 7, ab.MinDays)
+	assert.Equal(t, float32(2), ab.BaselineFactor)
+	assert.Equal(t, float32(0.9), ab.OwnershipThreshold)
+	assert.Equal(t, 12*time.Hour, ab.tickSize)
+	assert.Equal(t, []string{"Alice"}, ab.reversedPeopleDict)
+	require.NoError(t, ab.Initialize(nil))
+	assert.Equal(t, 14, ab.minTicks)
+
+	ab.MinDays = -1
+	assert.Error(t, ab.Initialize(nil))
+	ab.MinDays = 7
+	ab.BaselineFactor = -1
+	assert.Error(t, ab.Initialize(nil))
+	ab.BaselineFactor = 2
+	ab.OwnershipThreshold = 0
+	assert.Error(t, ab.Initialize(nil))
+	ab.OwnershipThreshold = 1.5
+	assert.Error(t, ab.Initialize(nil))
+}
+
+func TestAbsenceRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&AbsenceAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "Absence", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&AbsenceAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestMedianInt(t *testing.T) {
+	assert.Equal(t, 3.0, medianInt([]int{5, 1, 3}))
+	assert.Equal(t, 2.5, medianInt([]int{4, 1, 3, 2}))
+	assert.Equal(t, 7.0, medianInt([]int{7}))
+}
+
+func TestAbsenceConsumeFinalize(t *testing.T) {
+	ab := &AbsenceAnalysis{MinDays: 5, BaselineFactor: 3, OwnershipThreshold: 0.8}
+	require.NoError(t, ab.Initialize(nil))
+	resolver := &testFileIdResolver{
+		Names: []string{"src/a.go", "src/b.go", "docs/x.md", "README.md"},
+		Segments: [][]testLineSegment{
+			{{0, 0}, {10, 0}, {12, 0}},
+			{{0, 0}, {2, 0}},
+			{{0, 0}, {5, 0}, {10, 0}},
+			{{0, 0}, {3, 0}},
+		},
+		Authors: [][]core.AuthorId{
+			{0, 1, 0},
+			{0, 0},
+			{1, 0, 0},
+			{1, 0},
+		},
+	}
+	consume := func(author int, ticks ...int) {
+		for _, tick := range ticks {
+			_, err := ab.Consume(map[string]interface{}{
+				linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+				identity.DependencyAuthor:         author,
+				items.DependencyTick:              tick,
+			})
+			require.NoError(t, err)
+		}
+	}
+	consume(0, 0, 1, 2, 2, 3, 4, 20, 21, 22)
+	consume(1, 0, 10, 20, 30)
+	consume(2, 0, 1)
+	consume(core.AuthorMissing, 0, 100)
+
+	result := ab.Finalize().(AbsenceResult)
+	assert.Equal(t, map[int][]AbsencePeriod{0: {{Begin: 5, End: 19}}}, result.Absences)
+	assert.Equal(t, map[int]float64{0: 1, 1: 10}, result.Baselines)
+	assert.Equal(t, map[string]int{"src": 0, "/": 1}, result.Owners)
+	assert.Equal(t, []CoverageGap{{Subsystem: "src", Owner: 0, Begin: 5, End: 19}}, result.CoverageGaps)
+	assert.Equal(t, float32(0.8), result.OwnershipThreshold)
+	assert.Equal(t, 24*time.Hour, result.tickSize)
+
+	// a short break is not an absence
+	ab.MinDays = 20
+	require.NoError(t, ab.Initialize(nil))
+	consume(0, 0, 1, 2, 3, 4, 20, 21, 22)
+	result = ab.Finalize().(AbsenceResult)
+	assert.Empty(t, result.Absences)
+	assert.Empty(t, result.CoverageGaps)
+}
+
+func TestAbsenceSerialize(t *testing.T) {
+	ab := &AbsenceAnalysis{}
+	result := AbsenceResult{
+		Absences:           map[int][]AbsencePeriod{0: {{Begin: 5, End: 19}, {Begin: 40, End: 60}}},
+		Baselines:          map[int]float64{0: 1, 1: 10},
+		Owners:             map[string]int{"src": 0, "/": 1},
+		CoverageGaps:       []CoverageGap{{Subsystem: "src", Owner: 0, Begin: 5, End: 19}},
+		OwnershipThreshold: 0.8,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, ab.Serialize(result, false, buffer))
+	assert.Equal(t, `  absence:
+    ownership_threshold: 0.80
+    developers:
+      0:
+        baseline: 1.0
+        absences: [[5, 19], [40, 60]]
+      1:
+        baseline: 10.0
+        absences: []
+    owners:
+      "/": 1
+      "src": 0
+    coverage_gaps:
+    - {subsystem: "src", owner: 0, begin: 5, end: 19}
+    people:
+    - "Alice"
+    - "Bob"
+    tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, ab.Serialize(result, true, buffer))
+	restored, err := ab.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, ab.Serialize(nil, false, buffer))
+}
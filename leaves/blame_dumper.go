@@ -0,0 +1,304 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/join"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+// BlameDumper exports the line-level blame of every file at the last commit: who wrote
+// each line and when, as LineHistory tracked it incrementally. It saves the downstream tools
+// from running "git blame" file by file.
+type BlameDumper struct {
+	core.NoopMerger
+
+	// fileResolver references the current state of LineHistory.
+	fileResolver core.FileIdResolver
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// BlameSegment is the run of consecutive lines which were written by the same author at the same tick.
+type BlameSegment struct {
+	// Line is the zero-based index of the first line.
+	Line int
+	// Lines is the number of lines in the run.
+	Lines int
+	// Author is the index in the people list or -1 if the author is unknown.
+	Author int
+	// Tick is the tick when the lines were written.
+	Tick int
+}
+
+// BlameDumperResult is returned by BlameDumper.Finalize().
+type BlameDumperResult struct {
+	// Files maps the file names to the blame segments sorted by Line.
+	Files map[string][]BlameSegment
+
+	reversedPeopleDict []string
+	tickSize           time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (bd *BlameDumper) Name() string {
+	return "BlameDumper"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (bd *BlameDumper) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (bd *BlameDumper) Requires() []string {
+	return []string{linehistory.DependencyLineHistory}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (bd *BlameDumper) ListConfigurationOptions() []core.ConfigurationOption {
+	return nil
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (bd *BlameDumper) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		bd.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		bd.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		bd.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*BlameDumper) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (bd *BlameDumper) Flag() string {
+	return "blame"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (bd *BlameDumper) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (bd *BlameDumper) Description() string {
+	return "Dumps the author and the tick of each line of each file at the last commit."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (bd *BlameDumper) Initialize(repository *git.Repository) error {
+	bd.l = core.NewLogger()
+	bd.fileResolver = nil
+	if bd.tickSize == 0 {
+		bd.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (bd *BlameDumper) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges)
+	bd.fileResolver = changes.Resolver
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (bd *BlameDumper) Finalize() interface{} {
+	result := BlameDumperResult{
+		Files:              map[string][]BlameSegment{},
+		reversedPeopleDict: bd.reversedPeopleDict,
+		tickSize:           bd.tickSize,
+	}
+	if bd.fileResolver == nil {
+		return result
+	}
+	bd.fileResolver.ForEachFile(func(id core.FileId, name string) {
+		var segments []BlameSegment
+		previousLine, previousTick, previousAuthor := -1, 0, -1
+		bd.fileResolver.ScanFile(id, func(line int, tick core.TickNumber, author core.AuthorId) {
+			if previousLine >= 0 && line > previousLine {
+				last := len(segments) - 1
+				if last >= 0 && segments[last].Author == previousAuthor && segments[last].Tick == previousTick {
+					segments[last].Lines += line - previousLine
+				} else {
+					segments = append(segments, BlameSegment{
+						Line: previousLine, Lines: line - previousLine, Author: previousAuthor, Tick: previousTick,
+					})
+				}
+			}
+			previousLine, previousTick = line, int(tick)
+			if author >= core.AuthorMissing {
+				previousAuthor = -1
+			} else {
+				previousAuthor = int(author)
+			}
+		})
+		result.Files[name] = segments
+	})
+	return result
+}
+
+// Fork clones this pipeline item.
+func (bd *BlameDumper) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(bd, n)
+}
+
+// AnonymizePaths replaces the names of the files, see core.PathAnonymizer.
+func (bd *BlameDumper) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	blame := result.(BlameDumperResult)
+	files := make(map[string][]BlameSegment, len(blame.Files))
+	for name, segments := range blame.Files {
+		files[anonymize(name)] = segments
+	}
+	blame.Files = files
+	return blame
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (bd *BlameDumper) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	blame, ok := result.(BlameDumperResult)
+	if !ok {
+		return fmt.Errorf("result is not a blame dumper result: '%v'", result)
+	}
+	if binary {
+		return bd.serializeBinary(&blame, writer)
+	}
+	bd.serializeText(&blame, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to BlameDumperResult.
+func (bd *BlameDumper) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.BlameDumperResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := BlameDumperResult{
+		Files:              make(map[string][]BlameSegment, len(message.Files)),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for name, file := range message.Files {
+		segments := make([]BlameSegment, len(file.GetSegments()))
+		for i, segment := range file.GetSegments() {
+			segments[i] = BlameSegment{
+				Line: int(segment.Line), Lines: int(segment.Lines),
+				Author: int(segment.Author), Tick: int(segment.Tick),
+			}
+		}
+		result.Files[name] = segments
+	}
+	return result, nil
+}
+
+// MergeResults combines two BlameDumperResult-s together. The blame of the files which exist
+// in both comes from the second result and the authors are remapped to the merged people list.
+func (bd *BlameDumper) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	blame1 := r1.(BlameDumperResult)
+	blame2 := r2.(BlameDumperResult)
+	merged := BlameDumperResult{
+		Files:    map[string][]BlameSegment{},
+		tickSize: blame1.tickSize,
+	}
+	var people map[string]join.JoinedIndex
+	people, merged.reversedPeopleDict = join.PeopleIdentities(
+		blame1.reversedPeopleDict, blame2.reversedPeopleDict)
+	for _, blame := range [...]BlameDumperResult{blame1, blame2} {
+		for name, segments := range blame.Files {
+			mergedSegments := make([]BlameSegment, len(segments))
+			for i, segment := range segments {
+				if segment.Author >= 0 && segment.Author < len(blame.reversedPeopleDict) {
+					segment.Author = people[blame.reversedPeopleDict[segment.Author]].Final
+				}
+				mergedSegments[i] = segment
+			}
+			merged.Files[name] = mergedSegments
+		}
+	}
+	return merged
+}
+
+func (bd *BlameDumper) serializeText(result *BlameDumperResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  blame:")
+	fmt.Fprintf(writer, "    tick_size: %d\n", int(result.tickSize.Seconds()))
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    files:")
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		segments := result.Files[name]
+		if len(segments) == 0 {
+			fmt.Fprintf(writer, "      %s: []\n", yaml.SafeString(name))
+			continue
+		}
+		fmt.Fprintf(writer, "      %s:\n", yaml.SafeString(name))
+		for _, segment := range segments {
+			// first line, number of lines, author, tick
+			fmt.Fprintf(writer, "      - [%d, %d, %d, %d]\n",
+				segment.Line, segment.Lines, segment.Author, segment.Tick)
+		}
+	}
+}
+
+func (bd *BlameDumper) serializeBinary(result *BlameDumperResult, writer io.Writer) error {
+	message := pb.BlameDumperResults{
+		Files:    make(map[string]*pb.BlameFile, len(result.Files)),
+		DevIndex: result.reversedPeopleDict,
+		TickSize: int64(result.tickSize),
+	}
+	for name, segments := range result.Files {
+		file := &pb.BlameFile{Segments: make([]*pb.BlameSegment, len(segments))}
+		for i, segment := range segments {
+			file.Segments[i] = &pb.BlameSegment{
+				Line: int32(segment.Line), Lines: int32(segment.Lines),
+				Author: int32(segment.Author), Tick: int32(segment.Tick),
+			}
+		}
+		message.Files[name] = file
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&BlameDumper{})
+}
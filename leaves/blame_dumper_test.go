@@ -0,0 +1,161 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlameDumperMeta(t *testing.T) {
+	bd := &BlameDumper{}
+	assert.Equal(t, "BlameDumper", bd.Name())
+	assert.Len(t, bd.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory}, bd.Requires())
+	assert.Equal(t, "blame", bd.Flag())
+	assert.Equal(t, core.CostHeavy, bd.Cost())
+	assert.Len(t, bd.ListConfigurationOptions(), 0)
+	require.NoError(t, bd.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+		items.FactTickSize: 12 * time.Hour,
+	}))
+	assert.Equal(t, []string{"one", "two"}, bd.reversedPeopleDict)
+	assert.Equal(t, 12*time.Hour, bd.tickSize)
+}
+
+func TestBlameDumperRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&BlameDumper{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "BlameDumper", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&BlameDumper{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestBlameDumperFinalize(t *testing.T) {
+	bd := &BlameDumper{}
+	require.NoError(t, bd.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"one", "two"},
+	}))
+	require.NoError(t, bd.Initialize(nil))
+	assert.Equal(t, 24*time.Hour, bd.tickSize)
+	assert.Len(t, bd.Finalize().(BlameDumperResult).Files, 0)
+
+	resolver := &testFileIdResolver{
+		Names: []string{"src/a.go", "README.md", "empty"},
+		Segments: [][]testLineSegment{
+			{{0, 0}, {10, 300}, {15, 300}, {20, 5}, {35, 0}},
+			{{0, 1190}, {4, 0}},
+			{{0, 0}},
+		},
+		Authors: [][]core.AuthorId{
+			// the adjacent runs of the same author and tick are joined
+			{0, 1, 1, core.AuthorMissing, 0},
+			{1, 0},
+			{0},
+		},
+	}
+	_, err := bd.Consume(map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+	})
+	require.NoError(t, err)
+	result := bd.Finalize().(BlameDumperResult)
+	assert.Equal(t, map[string][]BlameSegment{
+		"src/a.go": {
+			{Line: 0, Lines: 10, Author: 0, Tick: 0},
+			{Line: 10, Lines: 10, Author: 1, Tick: 300},
+			{Line: 20, Lines: 15, Author: -1, Tick: 5},
+		},
+		"README.md": {{Line: 0, Lines: 4, Author: 1, Tick: 1190}},
+		"empty":     nil,
+	}, result.Files)
+	assert.Equal(t, []string{"one", "two"}, result.reversedPeopleDict)
+}
+
+func TestBlameDumperSerialize(t *testing.T) {
+	bd := &BlameDumper{}
+	result := BlameDumperResult{
+		Files: map[string][]BlameSegment{
+			"src/a.go": {
+				{Line: 0, Lines: 10, Author: 0, Tick: 0},
+				{Line: 10, Lines: 5, Author: -1, Tick: 7},
+			},
+			"empty": {},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, bd.Serialize(result, false, buffer))
+	assert.Equal(t, `  blame:
+    tick_size: 86400
+    people:
+    - "one"
+    - "two"
+    files:
+      "empty": []
+      "src/a.go":
+      - [0, 10, 0, 0]
+      - [10, 5, -1, 7]
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, bd.Serialize(result, true, buffer))
+	restored, err := bd.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, bd.Serialize(nil, false, buffer))
+}
+
+func TestBlameDumperMergeResults(t *testing.T) {
+	bd := &BlameDumper{}
+	r1 := BlameDumperResult{
+		Files: map[string][]BlameSegment{
+			"a.go": {{Line: 0, Lines: 3, Author: 0, Tick: 1}},
+			"b.go": {{Line: 0, Lines: 2, Author: 1, Tick: 2}},
+		},
+		reversedPeopleDict: []string{"one", "two"},
+		tickSize:           time.Hour,
+	}
+	r2 := BlameDumperResult{
+		Files: map[string][]BlameSegment{
+			"b.go": {{Line: 0, Lines: 4, Author: 0, Tick: 3}, {Line: 4, Lines: 1, Author: -1, Tick: 3}},
+		},
+		reversedPeopleDict: []string{"three"},
+		tickSize:           time.Hour,
+	}
+	merged := bd.MergeResults(r1, r2, nil, nil).(BlameDumperResult)
+	assert.Equal(t, []string{"one", "two", "three"}, merged.reversedPeopleDict)
+	assert.Equal(t, map[string][]BlameSegment{
+		"a.go": {{Line: 0, Lines: 3, Author: 0, Tick: 1}},
+		"b.go": {{Line: 0, Lines: 4, Author: 2, Tick: 3}, {Line: 4, Lines: 1, Author: -1, Tick: 3}},
+	}, merged.Files)
+	assert.Equal(t, time.Hour, merged.tickSize)
+	assert.Equal(t, 1, r1.Files["b.go"][0].Author)
+}
+
+func TestBlameDumperAnonymizePaths(t *testing.T) {
+	result := BlameDumperResult{
+		Files:              map[string][]BlameSegment{"a.go": {{Line: 0, Lines: 3, Author: 0, Tick: 1}}},
+		reversedPeopleDict: []string{"one"},
+	}
+	anonymized := (&BlameDumper{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(BlameDumperResult)
+	assert.Equal(t, map[string][]BlameSegment{"x/a.go": {{Line: 0, Lines: 3, Author: 0, Tick: 1}}},
+		anonymized.Files)
+	assert.Equal(t, []string{"one"}, anonymized.reversedPeopleDict)
+	assert.Contains(t, result.Files, "a.go")
+}
@@ -8,10 +8,12 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/burndown"
 	"github.com/meko-christian/hercules/internal/core"
@@ -39,6 +41,18 @@ type BurndownAnalysis struct {
 	// TrackFiles enables or disables the fine-grained per-file burndown analysis.
 	// It does not change the project level burndown results.
 	TrackFiles bool
+	// DirsDepth enables the per-directory burndown analysis if positive. The directories are
+	// made of the first DirsDepth components of the file paths.
+	DirsDepth int
+	// TrackLanguages enables the per-language burndown analysis. The languages are detected
+	// by the file names.
+	TrackLanguages bool
+	// Resample groups the bands and the samples by calendar months, quarters or years instead of
+	// Granularity and Sampling if not empty, see BurndownResample*.
+	Resample string
+	// ClassifyLines enables the burndown of the comment and the blank lines among the project
+	// lines, see items.ConfigLinesStatsClassifyLines.
+	ClassifyLines bool
 
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
@@ -56,8 +70,20 @@ type BurndownAnalysis struct {
 	// map[12][10] = -3
 	// map[12][12] = 10
 	globalHistory sparseHistory
+	// commentHistory and blankHistory are the same as globalHistory for the comment and
+	// the blank lines. They are nil unless ClassifyLines.
+	commentHistory sparseHistory
+	blankHistory   sparseHistory
 	// fileHistories is the daily deltas of each file's daily line counts.
 	fileHistories map[core.FileId]sparseHistory
+	// dirHistories is the daily deltas of each directory's daily line counts.
+	dirHistories map[string]sparseHistory
+	// fileDirs maps the alive files to the directories which own their lines in dirHistories.
+	fileDirs map[core.FileId]string
+	// langHistories is the daily deltas of each language's daily line counts.
+	langHistories map[string]sparseHistory
+	// fileLangs maps the alive files to the languages which own their lines in langHistories.
+	fileLangs map[core.FileId]string
 	// peopleHistories is the daily deltas of each person's daily line counts.
 	peopleHistories []sparseHistory
 	// matrix is the mutual deletions and self insertions.
@@ -65,6 +91,8 @@ type BurndownAnalysis struct {
 
 	// TickSize indicates the size of each time granule: day, hour, week, etc.
 	tickSize time.Duration
+	// tick0 is the beginning of the tick 0, it maps the ticks to the calendar periods of Resample.
+	tick0 time.Time
 
 	peopleResolver  core.IdentityResolver
 	primaryResolver core.FileIdResolver
@@ -97,7 +125,10 @@ func (analyser *BurndownAnalysis) Provides() []string {
 // Each requested entity will be inserted into `deps` of Consume(). In turn, those
 // entities are Provides() upstream.
 func (analyser *BurndownAnalysis) Requires() []string {
-	return []string{linehistory.DependencyLineHistory, identity.DependencyAuthor}
+	return []string{
+		linehistory.DependencyLineHistory, identity.DependencyAuthor,
+		items.DependencyTreeChanges, items.DependencyTick,
+	}
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
@@ -116,6 +147,25 @@ func (analyser *BurndownAnalysis) ListConfigurationOptions() []core.Configuratio
 		Flag:        "burndown-hibernation-dir",
 		Type:        core.PathConfigurationOption,
 		Default:     "",
+	}, core.ConfigurationOption{
+		Name:        ConfigBurndownDirsDepth,
+		Description: "Record detailed statistics per each directory of the given depth.",
+		Flag:        "burndown-dirs-depth",
+		Type:        core.IntConfigurationOption,
+		Default:     0,
+	}, core.ConfigurationOption{
+		Name:        ConfigBurndownTrackLanguages,
+		Description: "Record detailed statistics per each programming language.",
+		Flag:        "burndown-languages",
+		Type:        core.BoolConfigurationOption,
+		Default:     false,
+	}, core.ConfigurationOption{
+		Name: ConfigBurndownResample,
+		Description: "Resample the bands and the samples to calendar periods: \"month\", \"quarter\" " +
+			"or \"year\". --granularity and --sampling are ignored then.",
+		Flag:    "burndown-resample",
+		Type:    core.StringConfigurationOption,
+		Default: "",
 	})
 	return opts
 }
@@ -140,6 +190,30 @@ func (analyser *BurndownAnalysis) Configure(facts map[string]interface{}) error
 	if val, exists := facts[ConfigBurndownTrackFiles].(bool); exists {
 		analyser.TrackFiles = val
 	}
+	if val, exists := facts[ConfigBurndownDirsDepth].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s must not be negative: %d", ConfigBurndownDirsDepth, val)
+		}
+		analyser.DirsDepth = val
+	}
+	if val, exists := facts[ConfigBurndownTrackLanguages].(bool); exists {
+		analyser.TrackLanguages = val
+	}
+	if val, exists := facts[items.ConfigLinesStatsClassifyLines].(bool); exists {
+		analyser.ClassifyLines = val
+	}
+	if val, exists := facts[ConfigBurndownResample].(string); exists {
+		switch val {
+		case "", BurndownResampleMonth, BurndownResampleQuarter, BurndownResampleYear:
+			analyser.Resample = val
+		default:
+			return fmt.Errorf("unknown burndown resampling: %s", val)
+		}
+	}
+	if mode, exists := facts[items.ConfigTicksSinceStartTickMode].(string); exists &&
+		mode != "" && mode != items.TickModeFixed && analyser.Resample != "" {
+		return fmt.Errorf("%s requires the fixed tick mode, got %s", ConfigBurndownResample, mode)
+	}
 
 	if people, ok := facts[ConfigBurndownTrackPeople].(bool); people {
 		if val, ok := facts[core.FactIdentityResolver].(core.IdentityResolver); ok {
@@ -173,6 +247,11 @@ func (analyser *BurndownAnalysis) Flag() string {
 	return "burndown"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (analyser *BurndownAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (analyser *BurndownAnalysis) Description() string {
 	return "Line burndown stats indicate the numbers of lines which were last edited within " +
@@ -202,8 +281,27 @@ func (analyser *BurndownAnalysis) Initialize(repository *git.Repository) error {
 		analyser.Sampling = analyser.Granularity
 	}
 	analyser.repository = repository
+	analyser.tick0 = time.Time{}
 	analyser.globalHistory = sparseHistory{}
+	analyser.commentHistory = nil
+	analyser.blankHistory = nil
+	if analyser.ClassifyLines {
+		analyser.commentHistory = sparseHistory{}
+		analyser.blankHistory = sparseHistory{}
+	}
 	analyser.fileHistories = map[core.FileId]sparseHistory{}
+	analyser.dirHistories = nil
+	analyser.fileDirs = nil
+	if analyser.DirsDepth > 0 {
+		analyser.dirHistories = map[string]sparseHistory{}
+		analyser.fileDirs = map[core.FileId]string{}
+	}
+	analyser.langHistories = nil
+	analyser.fileLangs = nil
+	if analyser.TrackLanguages {
+		analyser.langHistories = map[string]sparseHistory{}
+		analyser.fileLangs = map[core.FileId]string{}
+	}
 
 	if analyser.peopleResolver == nil {
 		analyser.peopleResolver = core.NewIdentityResolver(nil, nil)
@@ -238,12 +336,25 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 	}
 	analyser.fileResolver = changes.Resolver
 	peopleCount := analyser.peopleResolver.MaxCount()
+	if analyser.Resample != "" && analyser.tick0.IsZero() {
+		if commit, ok := deps[core.DependencyCommit].(*object.Commit); ok {
+			tick, _ := deps[items.DependencyTick].(int)
+			analyser.tick0 = items.FloorTime(commit.Committer.When, analyser.tickSize).Add(
+				-time.Duration(tick) * analyser.tickSize)
+		}
+	}
 
 	for _, change := range changes.Changes {
 		if change.IsDelete() {
 			if analyser.TrackFiles {
 				analyser.updateFileDelete(change)
 			}
+			if analyser.fileDirs != nil {
+				delete(analyser.fileDirs, change.FileId)
+			}
+			if analyser.fileLangs != nil {
+				delete(analyser.fileLangs, change.FileId)
+			}
 			continue
 		}
 
@@ -258,17 +369,40 @@ func (analyser *BurndownAnalysis) Consume(deps map[string]interface{}) (map[stri
 		if analyser.TrackFiles {
 			analyser.updateFile(change)
 		}
+		if analyser.dirHistories != nil {
+			analyser.updateGroup(change, analyser.dirHistories, analyser.fileDirs, analyser.directoryOf)
+		}
+		if analyser.langHistories != nil {
+			analyser.updateGroup(change, analyser.langHistories, analyser.fileLangs, burndownLanguage)
+		}
 
 		analyser.updateAuthor(change)
 		analyser.updateChurnMatrix(change)
 	}
 
+	if analyser.fileDirs != nil || analyser.fileLangs != nil {
+		tick := deps[items.DependencyTick].(int)
+		treeChanges := deps[items.DependencyTreeChanges].(object.Changes)
+		if analyser.fileDirs != nil && renamedAcrossGroups(treeChanges, analyser.directoryOf) {
+			analyser.relocateGroups(tick, analyser.dirHistories, analyser.fileDirs, analyser.directoryOf)
+		}
+		if analyser.fileLangs != nil && renamedAcrossGroups(treeChanges, burndownLanguage) {
+			analyser.relocateGroups(tick, analyser.langHistories, analyser.fileLangs, burndownLanguage)
+		}
+	}
+
 	analyser.fileResolver = analyser.primaryResolver
 	return nil, nil
 }
 
 func (analyser *BurndownAnalysis) updateGlobal(change core.LineHistoryChange) {
 	analyser.globalHistory.updateDelta(int(change.PrevTick), int(change.CurrTick), change.Delta)
+	switch {
+	case change.Kind == core.LineKindComment && analyser.commentHistory != nil:
+		analyser.commentHistory.updateDelta(int(change.PrevTick), int(change.CurrTick), change.Delta)
+	case change.Kind == core.LineKindBlank && analyser.blankHistory != nil:
+		analyser.blankHistory.updateDelta(int(change.PrevTick), int(change.CurrTick), change.Delta)
+	}
 }
 
 // updateFile is bound to the specific `history` in the closure.
@@ -287,6 +421,94 @@ func (analyser *BurndownAnalysis) updateFileDelete(change core.LineHistoryChange
 	delete(analyser.fileHistories, change.FileId)
 }
 
+// burndownDirectory returns the first `depth` components of the file's directory, "/" for
+// the files in the root.
+func burndownDirectory(name string, depth int) string {
+	dir := subsystemOf(name)
+	if dir == "/" {
+		return dir
+	}
+	if parts := strings.Split(dir, "/"); len(parts) > depth {
+		dir = strings.Join(parts[:depth], "/")
+	}
+	return dir
+}
+
+// directoryOf returns the directory of the file which owns its lines in dirHistories.
+func (analyser *BurndownAnalysis) directoryOf(name string) string {
+	return burndownDirectory(name, analyser.DirsDepth)
+}
+
+// burndownLanguage returns the language of the file which owns its lines in langHistories,
+// BurndownOtherLanguage if the name is not recognized.
+func burndownLanguage(name string) string {
+	if lang := items.LanguageOfName(name); lang != "" {
+		return lang
+	}
+	return BurndownOtherLanguage
+}
+
+// renamedAcrossGroups returns true if any of the files was renamed to another group.
+func renamedAcrossGroups(changes object.Changes, groupOf func(string) string) bool {
+	for _, change := range changes {
+		if change.From.Name != "" && change.To.Name != "" &&
+			groupOf(change.From.Name) != groupOf(change.To.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateGroup applies the change to the history of the group which owns the file's lines,
+// e.g. its directory or its language.
+func (analyser *BurndownAnalysis) updateGroup(change core.LineHistoryChange,
+	histories map[string]sparseHistory, fileGroups map[core.FileId]string, groupOf func(string) string) {
+	group, exists := fileGroups[change.FileId]
+	if !exists {
+		group = groupOf(analyser.fileResolver.NameOf(change.FileId))
+		fileGroups[change.FileId] = group
+	}
+	history := histories[group]
+	if history == nil {
+		history = sparseHistory{}
+		histories[group] = history
+	}
+	history.updateDelta(int(change.PrevTick), int(change.CurrTick), change.Delta)
+}
+
+// relocateGroups moves the alive lines of the files which were renamed to another
+// group at the specified tick.
+func (analyser *BurndownAnalysis) relocateGroups(tick int,
+	histories map[string]sparseHistory, fileGroups map[core.FileId]string, groupOf func(string) string) {
+	// pure renames do not change the lines, so we must ensure that the project history
+	// reaches the tick
+	analyser.globalHistory.updateDelta(tick, tick, 0)
+	for fileId, from := range fileGroups {
+		to := groupOf(analyser.fileResolver.NameOf(fileId))
+		if to == from {
+			continue
+		}
+		fromHistory := histories[from]
+		toHistory := histories[to]
+		if toHistory == nil {
+			toHistory = sparseHistory{}
+			histories[to] = toHistory
+		}
+		previousLine := 0
+		previousTick := -1
+		analyser.fileResolver.ScanFile(fileId,
+			func(line int, lineTick core.TickNumber, author core.AuthorId) {
+				if length := line - previousLine; length > 0 && previousTick >= 0 {
+					fromHistory.updateDelta(previousTick, tick, -length)
+					toHistory.updateDelta(previousTick, tick, length)
+				}
+				previousLine = line
+				previousTick = int(lineTick)
+			})
+		fileGroups[fileId] = to
+	}
+}
+
 func (analyser *BurndownAnalysis) updateAuthor(change core.LineHistoryChange) {
 	if change.PrevAuthor == core.AuthorMissing {
 		return
@@ -318,12 +540,19 @@ func (analyser *BurndownAnalysis) updateChurnMatrix(change core.LineHistoryChang
 	row[newAuthor] += int64(change.Delta)
 }
 
-// burndownState holds the serializable state for hibernation.
+// burndownState holds the serializable state for the hibernation and the checkpoints.
 type burndownState struct {
 	GlobalHistory   map[int]map[int]int64
+	CommentHistory  map[int]map[int]int64
+	BlankHistory    map[int]map[int]int64
 	FileHistories   map[core.FileId]map[int]map[int]int64
 	PeopleHistories []map[int]map[int]int64
 	Matrix          []map[core.AuthorId]int64
+	DirHistories    map[string]map[int]map[int]int64
+	FileDirs        map[core.FileId]string
+	LangHistories   map[string]map[int]map[int]int64
+	FileLangs       map[core.FileId]string
+	Tick0           time.Time
 }
 
 func sparseHistoryToMap(sh sparseHistory) map[int]map[int]int64 {
@@ -348,11 +577,16 @@ func mapToSparseHistory(m map[int]map[int]int64) sparseHistory {
 	return sh
 }
 
-// Hibernate compresses the burndown analysis state to save memory.
-func (analyser *BurndownAnalysis) Hibernate() error {
+// writeState compresses the state of the analysis, see readState().
+func (analyser *BurndownAnalysis) writeState(writer io.Writer) error {
 	state := burndownState{
-		GlobalHistory: sparseHistoryToMap(analyser.globalHistory),
-		Matrix:        analyser.matrix,
+		GlobalHistory:  sparseHistoryToMap(analyser.globalHistory),
+		CommentHistory: sparseHistoryToMap(analyser.commentHistory),
+		BlankHistory:   sparseHistoryToMap(analyser.blankHistory),
+		Matrix:         analyser.matrix,
+		FileDirs:       analyser.fileDirs,
+		FileLangs:      analyser.fileLangs,
+		Tick0:          analyser.tick0,
 	}
 	if analyser.fileHistories != nil {
 		state.FileHistories = make(map[core.FileId]map[int]map[int]int64, len(analyser.fileHistories))
@@ -360,6 +594,18 @@ func (analyser *BurndownAnalysis) Hibernate() error {
 			state.FileHistories[k] = sparseHistoryToMap(v)
 		}
 	}
+	if analyser.dirHistories != nil {
+		state.DirHistories = make(map[string]map[int]map[int]int64, len(analyser.dirHistories))
+		for k, v := range analyser.dirHistories {
+			state.DirHistories[k] = sparseHistoryToMap(v)
+		}
+	}
+	if analyser.langHistories != nil {
+		state.LangHistories = make(map[string]map[int]map[int]int64, len(analyser.langHistories))
+		for k, v := range analyser.langHistories {
+			state.LangHistories[k] = sparseHistoryToMap(v)
+		}
+	}
 	if analyser.peopleHistories != nil {
 		state.PeopleHistories = make([]map[int]map[int]int64, len(analyser.peopleHistories))
 		for i, v := range analyser.peopleHistories {
@@ -367,8 +613,7 @@ func (analyser *BurndownAnalysis) Hibernate() error {
 		}
 	}
 
-	var buf bytes.Buffer
-	fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	fw, err := flate.NewWriter(writer, flate.DefaultCompression)
 	if err != nil {
 		return err
 	}
@@ -376,7 +621,84 @@ func (analyser *BurndownAnalysis) Hibernate() error {
 		fw.Close()
 		return err
 	}
-	if err := fw.Close(); err != nil {
+	return fw.Close()
+}
+
+// readState decompresses the state of the analysis which writeState() wrote.
+func (analyser *BurndownAnalysis) readState(reader io.Reader) error {
+	fr := flate.NewReader(reader)
+	defer fr.Close()
+
+	var state burndownState
+	if err := gob.NewDecoder(fr).Decode(&state); err != nil {
+		return err
+	}
+
+	analyser.globalHistory = mapToSparseHistory(state.GlobalHistory)
+	analyser.commentHistory = mapToSparseHistory(state.CommentHistory)
+	analyser.blankHistory = mapToSparseHistory(state.BlankHistory)
+	analyser.matrix = state.Matrix
+	analyser.fileDirs = state.FileDirs
+	analyser.fileLangs = state.FileLangs
+	analyser.tick0 = state.Tick0
+
+	if state.FileHistories != nil {
+		analyser.fileHistories = make(map[core.FileId]sparseHistory, len(state.FileHistories))
+		for k, v := range state.FileHistories {
+			analyser.fileHistories[k] = mapToSparseHistory(v)
+		}
+	}
+	if state.DirHistories != nil {
+		analyser.dirHistories = make(map[string]sparseHistory, len(state.DirHistories))
+		for k, v := range state.DirHistories {
+			analyser.dirHistories[k] = mapToSparseHistory(v)
+		}
+	}
+	if state.LangHistories != nil {
+		analyser.langHistories = make(map[string]sparseHistory, len(state.LangHistories))
+		for k, v := range state.LangHistories {
+			analyser.langHistories[k] = mapToSparseHistory(v)
+		}
+	}
+	if state.PeopleHistories != nil {
+		analyser.peopleHistories = make([]sparseHistory, len(state.PeopleHistories))
+		for i, v := range state.PeopleHistories {
+			analyser.peopleHistories[i] = mapToSparseHistory(v)
+		}
+	}
+	// gob omits the empty maps
+	if analyser.globalHistory == nil {
+		analyser.globalHistory = sparseHistory{}
+	}
+	if analyser.ClassifyLines && analyser.commentHistory == nil {
+		analyser.commentHistory = sparseHistory{}
+	}
+	if analyser.ClassifyLines && analyser.blankHistory == nil {
+		analyser.blankHistory = sparseHistory{}
+	}
+	if analyser.fileHistories == nil {
+		analyser.fileHistories = map[core.FileId]sparseHistory{}
+	}
+	if analyser.DirsDepth > 0 && analyser.dirHistories == nil {
+		analyser.dirHistories = map[string]sparseHistory{}
+	}
+	if analyser.DirsDepth > 0 && analyser.fileDirs == nil {
+		analyser.fileDirs = map[core.FileId]string{}
+	}
+	if analyser.TrackLanguages && analyser.langHistories == nil {
+		analyser.langHistories = map[string]sparseHistory{}
+	}
+	if analyser.TrackLanguages && analyser.fileLangs == nil {
+		analyser.fileLangs = map[core.FileId]string{}
+	}
+
+	return nil
+}
+
+// Hibernate compresses the burndown analysis state to save memory.
+func (analyser *BurndownAnalysis) Hibernate() error {
+	var buf bytes.Buffer
+	if err := analyser.writeState(&buf); err != nil {
 		return err
 	}
 
@@ -401,7 +723,13 @@ func (analyser *BurndownAnalysis) Hibernate() error {
 
 	// Clear state only after successful persistence.
 	analyser.globalHistory = nil
+	analyser.commentHistory = nil
+	analyser.blankHistory = nil
 	analyser.fileHistories = nil
+	analyser.dirHistories = nil
+	analyser.fileDirs = nil
+	analyser.langHistories = nil
+	analyser.fileLangs = nil
 	analyser.peopleHistories = nil
 	analyser.matrix = nil
 	return nil
@@ -428,37 +756,27 @@ func (analyser *BurndownAnalysis) Boot() error {
 	if len(data) == 0 {
 		return fmt.Errorf("burndown: Boot() called without prior Hibernate()")
 	}
+	return analyser.readState(bytes.NewReader(data))
+}
 
-	fr := flate.NewReader(bytes.NewReader(data))
-	defer fr.Close()
-
-	var state burndownState
-	if err := gob.NewDecoder(fr).Decode(&state); err != nil {
-		return err
-	}
-
-	analyser.globalHistory = mapToSparseHistory(state.GlobalHistory)
-	analyser.matrix = state.Matrix
-
-	if state.FileHistories != nil {
-		analyser.fileHistories = make(map[core.FileId]sparseHistory, len(state.FileHistories))
-		for k, v := range state.FileHistories {
-			analyser.fileHistories[k] = mapToSparseHistory(v)
-		}
-	}
-	if state.PeopleHistories != nil {
-		analyser.peopleHistories = make([]sparseHistory, len(state.PeopleHistories))
-		for i, v := range state.PeopleHistories {
-			analyser.peopleHistories[i] = mapToSparseHistory(v)
-		}
-	}
+// Checkpoint writes the same state as Hibernate().
+func (analyser *BurndownAnalysis) Checkpoint(writer io.Writer) error {
+	return analyser.writeState(writer)
+}
 
-	return nil
+// Restore reads the state which Checkpoint() wrote.
+func (analyser *BurndownAnalysis) Restore(reader io.Reader) error {
+	return analyser.readState(reader)
 }
 
 // Finalize returns the result of the analysis. Further calls to Consume() are not expected.
 func (analyser *BurndownAnalysis) Finalize() interface{} {
 	globalHistory, lastTick := analyser.groupSparseHistory(analyser.globalHistory, -1)
+	var commentHistory, blankHistory burndown.DenseHistory
+	if analyser.ClassifyLines {
+		commentHistory = analyser.groupKindHistory(analyser.commentHistory, globalHistory, lastTick)
+		blankHistory = analyser.groupKindHistory(analyser.blankHistory, globalHistory, lastTick)
+	}
 
 	fileHistories := map[string]burndown.DenseHistory{}
 	fileOwnership := map[string]map[int]int{}
@@ -471,6 +789,26 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 		}
 	}
 
+	var dirHistories map[string]burndown.DenseHistory
+	if analyser.dirHistories != nil {
+		dirHistories = map[string]burndown.DenseHistory{}
+		for dir, history := range analyser.dirHistories {
+			if len(history) > 0 {
+				dirHistories[dir], _ = analyser.groupSparseHistory(history, lastTick)
+			}
+		}
+	}
+
+	var langHistories map[string]burndown.DenseHistory
+	if analyser.langHistories != nil {
+		langHistories = map[string]burndown.DenseHistory{}
+		for lang, history := range analyser.langHistories {
+			if len(history) > 0 {
+				langHistories[lang], _ = analyser.groupSparseHistory(history, lastTick)
+			}
+		}
+	}
+
 	peopleNumber := analyser.peopleResolver.Count()
 	peopleHistories := make([]burndown.DenseHistory, peopleNumber)
 
@@ -511,14 +849,20 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 
 	result := BurndownResult{
 		GlobalHistory:      globalHistory,
+		CommentHistory:     commentHistory,
+		BlankHistory:       blankHistory,
 		FileHistories:      fileHistories,
 		FileOwnership:      fileOwnership,
 		PeopleHistories:    peopleHistories,
 		PeopleMatrix:       peopleMatrix,
+		DirectoryHistories: dirHistories,
+		LanguageHistories:  langHistories,
 		tickSize:           analyser.tickSize,
 		reversedPeopleDict: analyser.peopleResolver.CopyNames(false),
 		sampling:           analyser.Sampling,
 		granularity:        analyser.Granularity,
+		dirsDepth:          analyser.DirsDepth,
+		resample:           analyser.Resample,
 	}
 
 	// Initialize repository tracking for single-repo analysis
@@ -531,6 +875,22 @@ func (analyser *BurndownAnalysis) Finalize() interface{} {
 	return result
 }
 
+// groupKindHistory is groupSparseHistory() of the comment or the blank lines. It returns
+// zeros in the shape of the project burndown if there are no such lines.
+func (analyser *BurndownAnalysis) groupKindHistory(
+	history sparseHistory, globalHistory burndown.DenseHistory, lastTick int,
+) burndown.DenseHistory {
+	if len(history) > 0 {
+		result, _ := analyser.groupSparseHistory(history, lastTick)
+		return result
+	}
+	result := make(burndown.DenseHistory, len(globalHistory))
+	for i, gh := range globalHistory {
+		result[i] = make([]int64, len(gh))
+	}
+	return result
+}
+
 func (analyser *BurndownAnalysis) collectFileOwnership(fileOwnership map[string]map[int]int) {
 	analyser.fileResolver.ForEachFile(func(fileId core.FileId, fileName string) {
 		previousLine := 0
@@ -555,6 +915,17 @@ func (analyser *BurndownAnalysis) collectFileOwnership(fileOwnership map[string]
 	})
 }
 
+// FilterResult trims the files of the result, see core.OutputFilter. The developers
+// are not filtered because the overwrites matrix references all of them.
+func (analyser *BurndownAnalysis) FilterResult(result interface{}, filter core.OutputFilter) interface{} {
+	return filterBurndownResult(result.(BurndownResult), filter)
+}
+
+// AnonymizePaths replaces the names of the files in the result, see core.PathAnonymizer.
+func (analyser *BurndownAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	return anonymizeBurndownResult(result.(BurndownResult), anonymize)
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (analyser *BurndownAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -577,6 +948,9 @@ func (analyser *BurndownAnalysis) Deserialize(message []byte) (interface{}, erro
 		return nil, err
 	}
 	convertCSR := func(mat *pb.BurndownSparseMatrix) burndown.DenseHistory {
+		if mat == nil {
+			return nil
+		}
 		res := make(burndown.DenseHistory, mat.NumberOfRows)
 		for i := 0; i < int(mat.NumberOfRows); i++ {
 			res[i] = make([]int64, mat.NumberOfColumns)
@@ -586,14 +960,21 @@ func (analyser *BurndownAnalysis) Deserialize(message []byte) (interface{}, erro
 		}
 		return res
 	}
+	if err = validateBurndownMessage(&msg); err != nil {
+		return nil, err
+	}
 	result := BurndownResult{
-		GlobalHistory: convertCSR(msg.Project),
-		FileHistories: map[string]burndown.DenseHistory{},
-		FileOwnership: map[string]map[int]int{},
-		tickSize:      time.Duration(msg.TickSize),
+		GlobalHistory:  convertCSR(msg.Project),
+		CommentHistory: convertCSR(msg.Comments),
+		BlankHistory:   convertCSR(msg.Blanks),
+		FileHistories:  map[string]burndown.DenseHistory{},
+		FileOwnership:  map[string]map[int]int{},
+		tickSize:       time.Duration(msg.TickSize),
 
 		granularity: int(msg.Granularity),
 		sampling:    int(msg.Sampling),
+		dirsDepth:   int(msg.DirectoriesDepth),
+		resample:    msg.Resample,
 	}
 	for i, mat := range msg.Files {
 		result.FileHistories[mat.Name] = convertCSR(mat)
@@ -603,6 +984,18 @@ func (analyser *BurndownAnalysis) Deserialize(message []byte) (interface{}, erro
 			ownership[int(key)] = int(val)
 		}
 	}
+	if len(msg.Directories) > 0 {
+		result.DirectoryHistories = make(map[string]burndown.DenseHistory, len(msg.Directories))
+		for _, mat := range msg.Directories {
+			result.DirectoryHistories[mat.Name] = convertCSR(mat)
+		}
+	}
+	if len(msg.Languages) > 0 {
+		result.LanguageHistories = make(map[string]burndown.DenseHistory, len(msg.Languages))
+		for _, mat := range msg.Languages {
+			result.LanguageHistories[mat.Name] = convertCSR(mat)
+		}
+	}
 	result.reversedPeopleDict = make([]string, len(msg.People))
 	result.PeopleHistories = make([]burndown.DenseHistory, len(msg.People))
 	for i, mat := range msg.People {
@@ -641,8 +1034,17 @@ func (analyser *BurndownAnalysis) MergeResults(
 		return fmt.Errorf("mismatching tick sizes (r1: %d, r2: %d) received",
 			bar1.tickSize, bar2.tickSize)
 	}
+	if bar1.dirsDepth != bar2.dirsDepth && bar1.dirsDepth > 0 && bar2.dirsDepth > 0 {
+		return fmt.Errorf("mismatching directory depths (r1: %d, r2: %d) received",
+			bar1.dirsDepth, bar2.dirsDepth)
+	}
+	if bar1.resample != bar2.resample {
+		return fmt.Errorf("mismatching resampling (r1: %q, r2: %q) received",
+			bar1.resample, bar2.resample)
+	}
 	merged := BurndownResult{
 		tickSize: bar1.tickSize,
+		resample: bar1.resample,
 	}
 	if bar1.sampling < bar2.sampling {
 		merged.sampling = bar1.sampling
@@ -654,6 +1056,28 @@ func (analyser *BurndownAnalysis) MergeResults(
 	} else {
 		merged.granularity = bar2.granularity
 	}
+	var offset1, offset2 int
+	if merged.resample != "" && c1 != nil && c2 != nil {
+		period1 := burndownPeriod(items.FloorTime(time.Unix(c1.BeginTime, 0), bar1.tickSize), merged.resample)
+		period2 := burndownPeriod(items.FloorTime(time.Unix(c2.BeginTime, 0), bar2.tickSize), merged.resample)
+		if period1 < period2 {
+			offset2 = period2 - period1
+		} else {
+			offset1 = period1 - period2
+		}
+	}
+	mergeHistories := func(m1, m2 burndown.DenseHistory) burndown.DenseHistory {
+		if merged.resample != "" {
+			return mergeResampledHistories(m1, m2, offset1, offset2)
+		}
+		return burndown.MergeBurndownMatrices(
+			m1, m2,
+			bar1.granularity, bar1.sampling,
+			bar2.granularity, bar2.sampling,
+			bar1.tickSize,
+			c1, c2,
+		)
+	}
 	var people map[string]join.JoinedIndex
 	people, merged.reversedPeopleDict = join.PeopleIdentities(
 		bar1.reversedPeopleDict, bar2.reversedPeopleDict)
@@ -665,15 +1089,55 @@ func (analyser *BurndownAnalysis) MergeResults(
 		go func() {
 			defer wg.Done()
 			defer func() { <-sem }()
-			merged.GlobalHistory = burndown.MergeBurndownMatrices(
-				bar1.GlobalHistory, bar2.GlobalHistory,
-				bar1.granularity, bar1.sampling,
-				bar2.granularity, bar2.sampling,
-				bar1.tickSize,
-				c1, c2)
+			merged.GlobalHistory = mergeHistories(bar1.GlobalHistory, bar2.GlobalHistory)
+		}()
+	}
+	if len(bar1.CommentHistory) > 0 || len(bar2.CommentHistory) > 0 {
+		wg.Add(1)
+		sem <- 1
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			merged.CommentHistory = mergeHistories(bar1.CommentHistory, bar2.CommentHistory)
+			merged.BlankHistory = mergeHistories(bar1.BlankHistory, bar2.BlankHistory)
 		}()
 	}
+	// mergeGroups merges the union of the directories or the languages in the background
+	mergeGroups := func(h1, h2 map[string]burndown.DenseHistory) map[string]burndown.DenseHistory {
+		keys := map[string]bool{}
+		for key := range h1 {
+			keys[key] = true
+		}
+		for key := range h2 {
+			keys[key] = true
+		}
+		groups := make(map[string]burndown.DenseHistory, len(keys))
+		var mutex sync.Mutex
+		for key := range keys {
+			wg.Add(1)
+			sem <- 1
+			go func(key string) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				history := mergeHistories(h1[key], h2[key])
+				mutex.Lock()
+				groups[key] = history
+				mutex.Unlock()
+			}(key)
+		}
+		return groups
+	}
 	// we don't merge files
+	if len(bar1.DirectoryHistories) > 0 || len(bar2.DirectoryHistories) > 0 {
+		merged.dirsDepth = bar1.dirsDepth
+		if merged.dirsDepth == 0 {
+			merged.dirsDepth = bar2.dirsDepth
+		}
+		merged.DirectoryHistories = mergeGroups(bar1.DirectoryHistories, bar2.DirectoryHistories)
+	}
+	if len(bar1.LanguageHistories) > 0 || len(bar2.LanguageHistories) > 0 {
+		merged.LanguageHistories = mergeGroups(bar1.LanguageHistories, bar2.LanguageHistories)
+	}
 	if len(merged.reversedPeopleDict) > 0 {
 		if len(bar1.PeopleHistories) > 0 || len(bar2.PeopleHistories) > 0 {
 			merged.PeopleHistories = make([]burndown.DenseHistory, len(merged.reversedPeopleDict))
@@ -691,13 +1155,7 @@ func (analyser *BurndownAnalysis) MergeResults(
 					if ptrs.Second >= 0 {
 						m2 = bar2.PeopleHistories[ptrs.Second]
 					}
-					merged.PeopleHistories[i] = burndown.MergeBurndownMatrices(
-						m1, m2,
-						bar1.granularity, bar1.sampling,
-						bar2.granularity, bar2.sampling,
-						bar1.tickSize,
-						c1, c2,
-					)
+					merged.PeopleHistories[i] = mergeHistories(m1, m2)
 				}(i)
 			}
 		}
@@ -764,13 +1222,7 @@ func (analyser *BurndownAnalysis) MergeResults(
 				if ptrs.Second >= 0 {
 					m2 = bar2.RepositoryHistories[ptrs.Second]
 				}
-				merged.RepositoryHistories[i] = burndown.MergeBurndownMatrices(
-					m1, m2,
-					bar1.granularity, bar1.sampling,
-					bar2.granularity, bar2.sampling,
-					bar1.tickSize,
-					c1, c2,
-				)
+				merged.RepositoryHistories[i] = mergeHistories(m1, m2)
 			}(i)
 		}
 	}
@@ -783,7 +1235,14 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 	_, _ = fmt.Fprintln(writer, "  granularity:", result.granularity)
 	_, _ = fmt.Fprintln(writer, "  sampling:", result.sampling)
 	_, _ = fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
+	if result.resample != "" {
+		_, _ = fmt.Fprintln(writer, "  resample:", result.resample)
+	}
 	yaml.PrintMatrix(writer, result.GlobalHistory, 2, "project", true)
+	if len(result.CommentHistory) > 0 {
+		yaml.PrintMatrix(writer, result.CommentHistory, 2, "comments", true)
+		yaml.PrintMatrix(writer, result.BlankHistory, 2, "blanks", true)
+	}
 	if len(result.FileHistories) > 0 {
 		_, _ = fmt.Fprintln(writer, "  files:")
 		keys := sortedKeys(result.FileHistories)
@@ -817,6 +1276,21 @@ func (analyser *BurndownAnalysis) serializeText(result *BurndownResult, writer i
 		}
 	}
 
+	if len(result.DirectoryHistories) > 0 {
+		_, _ = fmt.Fprintln(writer, "  directories_depth:", result.dirsDepth)
+		_, _ = fmt.Fprintln(writer, "  directories:")
+		for _, key := range sortedKeys(result.DirectoryHistories) {
+			yaml.PrintMatrix(writer, result.DirectoryHistories[key], 4, key, true)
+		}
+	}
+
+	if len(result.LanguageHistories) > 0 {
+		_, _ = fmt.Fprintln(writer, "  languages:")
+		for _, key := range sortedKeys(result.LanguageHistories) {
+			yaml.PrintMatrix(writer, result.LanguageHistories[key], 4, key, true)
+		}
+	}
+
 	if len(result.PeopleHistories) > 0 {
 		_, _ = fmt.Fprintln(writer, "  people_sequence:")
 		for key := range result.PeopleHistories {
@@ -847,10 +1321,15 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 		Granularity: int32(result.granularity),
 		Sampling:    int32(result.sampling),
 		TickSize:    int64(result.tickSize),
+		Resample:    result.resample,
 	}
 	if len(result.GlobalHistory) > 0 {
 		message.Project = pb.ToBurndownSparseMatrix(result.GlobalHistory, "project")
 	}
+	if len(result.CommentHistory) > 0 {
+		message.Comments = pb.ToBurndownSparseMatrix(result.CommentHistory, "comments")
+		message.Blanks = pb.ToBurndownSparseMatrix(result.BlankHistory, "blanks")
+	}
 	if len(result.FileHistories) > 0 {
 		message.Files = make([]*pb.BurndownSparseMatrix, len(result.FileHistories))
 		message.FilesOwnership = make([]*pb.FilesOwnership, len(result.FileHistories))
@@ -867,6 +1346,23 @@ func (analyser *BurndownAnalysis) serializeBinary(result *BurndownResult, writer
 		}
 	}
 
+	if len(result.DirectoryHistories) > 0 {
+		message.DirectoriesDepth = int32(result.dirsDepth)
+		keys := sortedKeys(result.DirectoryHistories)
+		message.Directories = make([]*pb.BurndownSparseMatrix, len(keys))
+		for i, key := range keys {
+			message.Directories[i] = pb.ToBurndownSparseMatrix(result.DirectoryHistories[key], key)
+		}
+	}
+
+	if len(result.LanguageHistories) > 0 {
+		keys := sortedKeys(result.LanguageHistories)
+		message.Languages = make([]*pb.BurndownSparseMatrix, len(keys))
+		for i, key := range keys {
+			message.Languages[i] = pb.ToBurndownSparseMatrix(result.LanguageHistories[key], key)
+		}
+	}
+
 	if len(result.PeopleHistories) > 0 {
 		message.People = make(
 			[]*pb.BurndownSparseMatrix, len(result.PeopleHistories))
@@ -922,15 +1418,15 @@ func (analyser *BurndownAnalysis) groupSparseHistory(
 	// [y][x]
 	// y - sampling
 	// x - granularity
-	samples := lastTick/analyser.Sampling + 1
-	bands := lastTick/analyser.Granularity + 1
+	samples := analyser.sampleOf(lastTick) + 1
+	bands := analyser.bandOf(lastTick) + 1
 	result := make(burndown.DenseHistory, samples)
 	for i := 0; i < samples; i++ {
 		result[i] = make([]int64, bands)
 	}
 	prevSi := 0
 	for _, tick := range ticks {
-		si := tick / analyser.Sampling
+		si := analyser.sampleOf(tick)
 		if si > prevSi {
 			state := result[prevSi]
 			for i := prevSi + 1; i <= si; i++ {
@@ -940,12 +1436,34 @@ func (analyser *BurndownAnalysis) groupSparseHistory(
 		}
 		sample := result[si]
 		for t, value := range history[tick].deltas {
-			sample[t/analyser.Granularity] += value
+			sample[analyser.bandOf(t)] += value
 		}
 	}
 	return result, lastTick
 }
 
+// sampleOf returns the index of the sample which records the state after the tick.
+func (analyser *BurndownAnalysis) sampleOf(tick int) int {
+	if analyser.Resample != "" {
+		return analyser.periodOf(tick)
+	}
+	return tick / analyser.Sampling
+}
+
+// bandOf returns the index of the band which contains the lines added at the tick.
+func (analyser *BurndownAnalysis) bandOf(tick int) int {
+	if analyser.Resample != "" {
+		return analyser.periodOf(tick)
+	}
+	return tick / analyser.Granularity
+}
+
+// periodOf returns the index of the Resample calendar period of the tick since the first one.
+func (analyser *BurndownAnalysis) periodOf(tick int) int {
+	return burndownPeriod(analyser.tick0.Add(time.Duration(tick)*analyser.tickSize), analyser.Resample) -
+		burndownPeriod(analyser.tick0, analyser.Resample)
+}
+
 func init() {
 	core.Registry.RegisterPreferred(&BurndownAnalysis{}, true)
 }
@@ -246,6 +246,11 @@ func (analyser *LegacyBurndownAnalysis) Flag() string {
 	return "legacy-burndown"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (analyser *LegacyBurndownAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (analyser *LegacyBurndownAnalysis) Description() string {
 	return "Line burndown stats indicate the numbers of lines which were last edited within " +
@@ -513,6 +518,17 @@ func (analyser *LegacyBurndownAnalysis) Finalize() interface{} {
 	}
 }
 
+// FilterResult trims the files of the result, see core.OutputFilter. The developers
+// are not filtered because the overwrites matrix references all of them.
+func (analyser *LegacyBurndownAnalysis) FilterResult(result interface{}, filter core.OutputFilter) interface{} {
+	return filterBurndownResult(result.(BurndownResult), filter)
+}
+
+// AnonymizePaths replaces the names of the files in the result, see core.PathAnonymizer.
+func (analyser *LegacyBurndownAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	return anonymizeBurndownResult(result.(BurndownResult), anonymize)
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (analyser *LegacyBurndownAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -535,6 +551,9 @@ func (analyser *LegacyBurndownAnalysis) Deserialize(pbmessage []byte) (interface
 		return nil, err
 	}
 	convertCSR := func(mat *pb.BurndownSparseMatrix) DenseHistory {
+		if mat == nil {
+			return nil
+		}
 		res := make(DenseHistory, mat.NumberOfRows)
 		for i := 0; i < int(mat.NumberOfRows); i++ {
 			res[i] = make([]int64, mat.NumberOfColumns)
@@ -544,6 +563,9 @@ func (analyser *LegacyBurndownAnalysis) Deserialize(pbmessage []byte) (interface
 		}
 		return res
 	}
+	if err = validateBurndownMessage(&msg); err != nil {
+		return nil, err
+	}
 	result := BurndownResult{
 		GlobalHistory: convertCSR(msg.Project),
 		FileHistories: map[string]DenseHistory{},
@@ -1,12 +1,14 @@
 package leaves
 
 import (
+	"fmt"
 	"io"
 	"sort"
 	"time"
 
 	"github.com/meko-christian/hercules/internal/burndown"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
 )
 
 const (
@@ -28,6 +30,21 @@ const (
 	ConfigBurndownHibernationDisk = "Burndown.HibernationDisk"
 	// ConfigBurndownHibernationDir is the temp directory for hibernated burndown data.
 	ConfigBurndownHibernationDir = "Burndown.HibernationDir"
+	// ConfigBurndownDirsDepth is the name of the option to set BurndownAnalysis.DirsDepth.
+	ConfigBurndownDirsDepth = "Burndown.DirsDepth"
+	// ConfigBurndownTrackLanguages is the name of the option to set BurndownAnalysis.TrackLanguages.
+	ConfigBurndownTrackLanguages = "Burndown.TrackLanguages"
+	// BurndownOtherLanguage is the key in BurndownResult.LanguageHistories of the files whose
+	// language is not recognized.
+	BurndownOtherLanguage = "Other"
+	// ConfigBurndownResample is the name of the option to set BurndownAnalysis.Resample.
+	ConfigBurndownResample = "Burndown.Resample"
+	// BurndownResampleMonth makes each band and each sample a calendar month.
+	BurndownResampleMonth = "month"
+	// BurndownResampleQuarter makes each band and each sample a calendar quarter.
+	BurndownResampleQuarter = "quarter"
+	// BurndownResampleYear makes each band and each sample a calendar year.
+	BurndownResampleYear = "year"
 )
 
 var BurndownSharedOptions = [...]core.ConfigurationOption{
@@ -69,6 +86,10 @@ type BurndownResult struct {
 	// The number of samples depends on Sampling: the less Sampling, the bigger the number.
 	// The number of bands depends on Granularity: the less Granularity, the bigger the number.
 	GlobalHistory burndown.DenseHistory
+	// CommentHistory and BlankHistory are the comment and the blank lines among GlobalHistory,
+	// with the same dimensions. They are nil unless the lines are classified.
+	CommentHistory burndown.DenseHistory
+	BlankHistory   burndown.DenseHistory
 	// The key is a path inside the Git repository. The value's dimensions are the same as
 	// in GlobalHistory.
 	FileHistories map[string]burndown.DenseHistory
@@ -88,6 +109,12 @@ type BurndownResult struct {
 	// Per-repository burndown histories, similar to PeopleHistories but for repositories.
 	// This is populated during combine operations or for single-repo analyses.
 	RepositoryHistories []burndown.DenseHistory
+	// The key is a directory made of the leading DirsDepth components of the file paths,
+	// "/" stands for the root. The value's dimensions are the same as in GlobalHistory.
+	DirectoryHistories map[string]burndown.DenseHistory
+	// The key is a programming language detected by the file names, BurndownOtherLanguage
+	// stands for the rest. The value's dimensions are the same as in GlobalHistory.
+	LanguageHistories map[string]burndown.DenseHistory
 
 	// The following members are private.
 
@@ -106,6 +133,78 @@ type BurndownResult struct {
 	// such as merging several results together.
 	sampling    int
 	granularity int
+	// dirsDepth is the number of the path components in the keys of DirectoryHistories.
+	dirsDepth int
+	// resample is the calendar period of the bands and the samples, see BurndownResample*.
+	// sampling and granularity do not apply if it is not empty.
+	resample string
+}
+
+// filterBurndownResult keeps only the files which pass the filter's TopFiles and MinLines.
+// The size of a file is the number of its alive lines.
+func filterBurndownResult(result BurndownResult, filter core.OutputFilter) BurndownResult {
+	if filter.TopFiles <= 0 && filter.MinLines <= 0 {
+		return result
+	}
+	lines := map[string]int{}
+	for file, history := range result.FileHistories {
+		if len(history) > 0 {
+			size := int64(0)
+			for _, val := range history[len(history)-1] {
+				size += val
+			}
+			lines[file] = int(size)
+		}
+	}
+	for file, owners := range result.FileOwnership {
+		size := 0
+		for _, val := range owners {
+			size += val
+		}
+		lines[file] = size
+	}
+	keep := filter.KeepFiles(lines)
+	histories := make(map[string]burndown.DenseHistory, len(keep))
+	for file, history := range result.FileHistories {
+		if keep[file] {
+			histories[file] = history
+		}
+	}
+	ownership := make(map[string]map[int]int, len(keep))
+	for file, owners := range result.FileOwnership {
+		if keep[file] {
+			ownership[file] = owners
+		}
+	}
+	result.FileHistories = histories
+	result.FileOwnership = ownership
+	return result
+}
+
+// anonymizeBurndownResult is the shared implementation of AnonymizePaths() of the burndown analyses.
+func anonymizeBurndownResult(result BurndownResult, anonymize func(string) string) BurndownResult {
+	if result.FileHistories != nil {
+		histories := make(map[string]burndown.DenseHistory, len(result.FileHistories))
+		for file, history := range result.FileHistories {
+			histories[anonymize(file)] = history
+		}
+		result.FileHistories = histories
+	}
+	if result.FileOwnership != nil {
+		ownership := make(map[string]map[int]int, len(result.FileOwnership))
+		for file, owners := range result.FileOwnership {
+			ownership[anonymize(file)] = owners
+		}
+		result.FileOwnership = ownership
+	}
+	if result.DirectoryHistories != nil {
+		histories := make(map[string]burndown.DenseHistory, len(result.DirectoryHistories))
+		for dir, history := range result.DirectoryHistories {
+			histories[anonymize(dir)] = history
+		}
+		result.DirectoryHistories = histories
+	}
+	return result
 }
 
 // GetTickSize returns the tick size used to generate this burndown analysis result.
@@ -140,6 +239,59 @@ func (p sparseHistory) updateDelta(prevTick, curTick int, delta int) {
 	currentHistory.deltas[prevTick] += int64(delta)
 }
 
+// burndownPeriod returns the absolute index of the UTC calendar period which contains the time.
+func burndownPeriod(t time.Time, resample string) int {
+	t = t.UTC()
+	months := t.Year()*12 + int(t.Month()-time.January)
+	switch resample {
+	case BurndownResampleQuarter:
+		return months / 3
+	case BurndownResampleYear:
+		return t.Year()
+	}
+	return months
+}
+
+// mergeResampledHistories sums two burndown matrices which are resampled to the same calendar
+// periods. offset1 and offset2 are the indexes of the first periods of m1 and m2 in the result.
+// The last state of the shorter matrix is carried forward.
+func mergeResampledHistories(m1, m2 burndown.DenseHistory, offset1, offset2 int) burndown.DenseHistory {
+	size := 0
+	if len(m1) > 0 && offset1+len(m1) > size {
+		size = offset1 + len(m1)
+	}
+	if len(m2) > 0 && offset2+len(m2) > size {
+		size = offset2 + len(m2)
+	}
+	if size == 0 {
+		return nil
+	}
+	merged := make(burndown.DenseHistory, size)
+	for i := range merged {
+		merged[i] = make([]int64, size)
+	}
+	for _, part := range [...]struct {
+		matrix burndown.DenseHistory
+		offset int
+	}{{m1, offset1}, {m2, offset2}} {
+		if len(part.matrix) == 0 {
+			continue
+		}
+		for i := part.offset; i < size; i++ {
+			row := part.matrix[len(part.matrix)-1]
+			if i-part.offset < len(part.matrix) {
+				row = part.matrix[i-part.offset]
+			}
+			for j, value := range row {
+				if j+part.offset < size {
+					merged[i][j+part.offset] += value
+				}
+			}
+		}
+	}
+	return merged
+}
+
 func sortedKeys(m map[string]burndown.DenseHistory) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -154,3 +306,49 @@ func checkClose(c io.Closer) {
 		panic(err)
 	}
 }
+
+// validateBurndownMessage checks the matrices of the Protocol Buffers message before
+// BurndownAnalysis.Deserialize() converts them to the dense form.
+func validateBurndownMessage(msg *pb.BurndownAnalysisResults) error {
+	var matrices []*pb.BurndownSparseMatrix
+	if msg.Project != nil {
+		matrices = append(matrices, msg.Project)
+	}
+	if msg.Comments != nil {
+		matrices = append(matrices, msg.Comments)
+	}
+	if msg.Blanks != nil {
+		matrices = append(matrices, msg.Blanks)
+	}
+	matrices = append(matrices, msg.Files...)
+	matrices = append(matrices, msg.People...)
+	matrices = append(matrices, msg.Repositories...)
+	matrices = append(matrices, msg.Directories...)
+	matrices = append(matrices, msg.Languages...)
+	for _, mat := range matrices {
+		if err := mat.Validate(); err != nil {
+			return err
+		}
+	}
+	if msg.Granularity <= 0 {
+		return fmt.Errorf("invalid granularity %d: must be positive", msg.Granularity)
+	}
+	if msg.Sampling <= 0 {
+		return fmt.Errorf("invalid sampling %d: must be positive", msg.Sampling)
+	}
+	if msg.TickSize <= 0 {
+		return fmt.Errorf("invalid tick size %d: must be positive", msg.TickSize)
+	}
+	if len(msg.FilesOwnership) != len(msg.Files) {
+		return fmt.Errorf("%d file ownership records for %d files", len(msg.FilesOwnership), len(msg.Files))
+	}
+	if len(msg.Directories) > 0 && msg.DirectoriesDepth <= 0 {
+		return fmt.Errorf("invalid directories depth %d", msg.DirectoriesDepth)
+	}
+	if msg.PeopleInteraction != nil {
+		if err := msg.PeopleInteraction.Validate(); err != nil {
+			return fmt.Errorf("people interaction: %v", err)
+		}
+	}
+	return nil
+}
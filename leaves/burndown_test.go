@@ -51,7 +51,8 @@ func TestBurndownMeta(t *testing.T) {
 	for _, opt := range opts {
 		switch opt.Name {
 		case ConfigBurndownGranularity, ConfigBurndownSampling, ConfigBurndownTrackFiles,
-			ConfigBurndownTrackPeople, ConfigBurndownHibernationDisk, ConfigBurndownHibernationDir:
+			ConfigBurndownTrackPeople, ConfigBurndownHibernationDisk, ConfigBurndownHibernationDir,
+			ConfigBurndownDirsDepth, ConfigBurndownTrackLanguages, ConfigBurndownResample:
 			matches++
 		}
 	}
@@ -1207,3 +1208,391 @@ func TestBurndownHibernateBootDisk(t *testing.T) {
 	assert.Empty(t, bd.hibernatedFileName)
 	assert.Equal(t, int64(100), bd.globalHistory[5].deltas[0])
 }
+
+func TestBurndownCheckpointRestore(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Initialize(test.Repository))
+	bd.globalHistory.updateDelta(0, 0, 50)
+	bd.globalHistory.updateDelta(10, 10, 20)
+	bd.fileHistories[1] = sparseHistory{}
+	bd.fileHistories[1].updateDelta(0, 0, 30)
+	bd.tick0 = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	var buffer bytes.Buffer
+	assert.Nil(t, bd.Checkpoint(&buffer))
+	// the state stays in memory
+	assert.Equal(t, int64(50), bd.globalHistory[0].deltas[0])
+
+	restored := BurndownAnalysis{}
+	assert.Nil(t, restored.Initialize(test.Repository))
+	assert.Nil(t, restored.Restore(&buffer))
+	assert.Equal(t, bd.globalHistory, restored.globalHistory)
+	assert.Equal(t, bd.fileHistories, restored.fileHistories)
+	assert.True(t, bd.tick0.Equal(restored.tick0))
+
+	// the empty maps are ready for Consume()
+	empty := BurndownAnalysis{DirsDepth: 1}
+	assert.Nil(t, empty.Initialize(test.Repository))
+	buffer.Reset()
+	assert.Nil(t, empty.Checkpoint(&buffer))
+	assert.Nil(t, empty.Restore(&buffer))
+	assert.NotNil(t, empty.globalHistory)
+	assert.NotNil(t, empty.fileHistories)
+	assert.NotNil(t, empty.dirHistories)
+	assert.NotNil(t, empty.fileDirs)
+}
+
+func TestBurndownFilterResult(t *testing.T) {
+	result := BurndownResult{
+		GlobalHistory: burndown.DenseHistory{{10, 0}, {8, 4}},
+		FileHistories: map[string]burndown.DenseHistory{
+			"a.go": {{5, 0}, {4, 2}},
+			"b.go": {{5, 0}, {4, 2}},
+			"c.go": {{0, 0}, {0, 1}},
+		},
+		FileOwnership: map[string]map[int]int{
+			"a.go": {0: 6},
+			"b.go": {0: 3, 1: 4},
+			"c.go": {1: 1},
+		},
+	}
+	filtered := (&BurndownAnalysis{}).FilterResult(result, core.OutputFilter{TopFiles: 1}).(BurndownResult)
+	assert.Equal(t, map[string]burndown.DenseHistory{"b.go": {{5, 0}, {4, 2}}}, filtered.FileHistories)
+	assert.Equal(t, map[string]map[int]int{"b.go": {0: 3, 1: 4}}, filtered.FileOwnership)
+	assert.Equal(t, result.GlobalHistory, filtered.GlobalHistory)
+	filtered = (&LegacyBurndownAnalysis{}).FilterResult(result, core.OutputFilter{MinLines: 2}).(BurndownResult)
+	assert.Len(t, filtered.FileHistories, 2)
+	assert.NotContains(t, filtered.FileHistories, "c.go")
+	assert.Len(t, result.FileHistories, 3)
+	assert.Equal(t, result, (&BurndownAnalysis{}).FilterResult(result, core.OutputFilter{
+		Authors: []string{"alice"}}))
+}
+
+func TestBurndownAnonymizePaths(t *testing.T) {
+	result := BurndownResult{
+		GlobalHistory:      burndown.DenseHistory{{10, 0}},
+		FileHistories:      map[string]burndown.DenseHistory{"a.go": {{5, 0}}},
+		FileOwnership:      map[string]map[int]int{"a.go": {0: 5}},
+		DirectoryHistories: map[string]burndown.DenseHistory{"src": {{3, 0}}},
+	}
+	anonymize := func(path string) string { return "x/" + path }
+	anonymized := (&BurndownAnalysis{}).AnonymizePaths(result, anonymize).(BurndownResult)
+	assert.Equal(t, map[string]burndown.DenseHistory{"x/a.go": {{5, 0}}}, anonymized.FileHistories)
+	assert.Equal(t, map[string]map[int]int{"x/a.go": {0: 5}}, anonymized.FileOwnership)
+	assert.Equal(t, map[string]burndown.DenseHistory{"x/src": {{3, 0}}}, anonymized.DirectoryHistories)
+	assert.Equal(t, result.GlobalHistory, anonymized.GlobalHistory)
+	assert.Contains(t, result.FileHistories, "a.go")
+	anonymized = (&LegacyBurndownAnalysis{}).AnonymizePaths(BurndownResult{}, anonymize).(BurndownResult)
+	assert.Nil(t, anonymized.FileHistories)
+	assert.Nil(t, anonymized.FileOwnership)
+}
+
+func TestBurndownDirectory(t *testing.T) {
+	assert.Equal(t, "/", burndownDirectory("README.md", 2))
+	assert.Equal(t, "src", burndownDirectory("src/main.go", 2))
+	assert.Equal(t, "src/core", burndownDirectory("src/core/main.go", 2))
+	assert.Equal(t, "src/core", burndownDirectory("src/core/pipeline/main.go", 2))
+	assert.Equal(t, "src", burndownDirectory("src/core/pipeline/main.go", 1))
+}
+
+func TestBurndownConfigureDirsDepth(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{ConfigBurndownDirsDepth: 2}))
+	assert.Equal(t, 2, bd.DirsDepth)
+	assert.NotNil(t, bd.Configure(map[string]interface{}{ConfigBurndownDirsDepth: -1}))
+}
+
+func TestBurndownConsumeFinalizeDirectories(t *testing.T) {
+	resolver := &testFileIdResolver{
+		Names:    []string{"src/a/x.go", "README.md"},
+		Segments: [][]testLineSegment{{{0, 0}, {10, 0}}, {{0, 0}, {4, 0}}},
+	}
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownDirsDepth: 1, items.FactTickSize: 24 * time.Hour}))
+	assert.Nil(t, bd.Initialize(nil))
+	deps := map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{
+			Changes: []core.LineHistoryChange{
+				{FileId: 0, PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing, Delta: 10},
+				{FileId: 1, PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing, Delta: 4},
+			},
+			Resolver: resolver,
+		},
+		items.DependencyTreeChanges: object.Changes{},
+		items.DependencyTick:        0,
+	}
+	_, err := bd.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, map[core.FileId]string{0: "src", 1: "/"}, bd.fileDirs)
+
+	// the file moves to another directory without changing the lines
+	resolver.Names[0] = "lib/x.go"
+	deps[linehistory.DependencyLineHistory] = core.LineHistoryChanges{Resolver: resolver}
+	deps[items.DependencyTreeChanges] = object.Changes{&object.Change{
+		From: object.ChangeEntry{Name: "src/a/x.go"},
+		To:   object.ChangeEntry{Name: "lib/x.go"},
+	}}
+	deps[items.DependencyTick] = 30
+	_, err = bd.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, "lib", bd.fileDirs[0])
+
+	assert.Nil(t, bd.Hibernate())
+	assert.Nil(t, bd.dirHistories)
+	assert.Nil(t, bd.Boot())
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, burndown.DenseHistory{{14, 0}, {14, 0}}, result.GlobalHistory)
+	assert.Equal(t, map[string]burndown.DenseHistory{
+		"src": {{10, 0}, {0, 0}},
+		"lib": {{0, 0}, {10, 0}},
+		"/":   {{4, 0}, {4, 0}},
+	}, result.DirectoryHistories)
+	assert.Equal(t, 1, result.dirsDepth)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  directories_depth: 1
+  directories:
+    "/": |-
+      4 0
+      4 0
+    "lib": |-
+`)
+	buffer.Reset()
+	assert.Nil(t, bd.Serialize(result, true, buffer))
+	iresult, err := bd.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	deserialized := iresult.(BurndownResult)
+	assert.Equal(t, result.DirectoryHistories, deserialized.DirectoryHistories)
+	assert.Equal(t, 1, deserialized.dirsDepth)
+
+	common := core.CommonAnalysisResult{BeginTime: 600566400, EndTime: 600566400 + 59*24*3600}
+	merged := bd.MergeResults(result, deserialized, &common, &common).(BurndownResult)
+	assert.Len(t, merged.DirectoryHistories, 3)
+	assert.Equal(t, 1, merged.dirsDepth)
+	deserialized.dirsDepth = 2
+	assert.IsType(t, errors.New(""), bd.MergeResults(result, deserialized, nil, nil))
+}
+
+func TestBurndownLanguage(t *testing.T) {
+	assert.Equal(t, "Go", burndownLanguage("src/main.go"))
+	assert.Equal(t, "C", burndownLanguage("src/main.c"))
+	assert.Equal(t, BurndownOtherLanguage, burndownLanguage("data/blob.unknown-extension"))
+}
+
+func TestBurndownConsumeFinalizeLanguages(t *testing.T) {
+	resolver := &testFileIdResolver{
+		Names:    []string{"src/main.c", "data/blob.unknown-extension"},
+		Segments: [][]testLineSegment{{{0, 0}, {10, 0}}, {{0, 0}, {4, 0}}},
+	}
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownTrackLanguages: true, items.FactTickSize: 24 * time.Hour}))
+	assert.True(t, bd.TrackLanguages)
+	assert.Nil(t, bd.Initialize(nil))
+	deps := map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{
+			Changes: []core.LineHistoryChange{
+				{FileId: 0, PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing, Delta: 10},
+				{FileId: 1, PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing, Delta: 4},
+			},
+			Resolver: resolver,
+		},
+		items.DependencyTreeChanges: object.Changes{},
+		items.DependencyTick:        0,
+	}
+	_, err := bd.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, map[core.FileId]string{0: "C", 1: BurndownOtherLanguage}, bd.fileLangs)
+	assert.Nil(t, bd.fileDirs)
+
+	// the file is renamed to another language without changing the lines
+	resolver.Names[0] = "src/main.go"
+	deps[linehistory.DependencyLineHistory] = core.LineHistoryChanges{Resolver: resolver}
+	deps[items.DependencyTreeChanges] = object.Changes{&object.Change{
+		From: object.ChangeEntry{Name: "src/main.c"},
+		To:   object.ChangeEntry{Name: "src/main.go"},
+	}}
+	deps[items.DependencyTick] = 30
+	_, err = bd.Consume(deps)
+	assert.Nil(t, err)
+	assert.Equal(t, "Go", bd.fileLangs[0])
+
+	assert.Nil(t, bd.Hibernate())
+	assert.Nil(t, bd.langHistories)
+	assert.Nil(t, bd.Boot())
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, burndown.DenseHistory{{14, 0}, {14, 0}}, result.GlobalHistory)
+	assert.Equal(t, map[string]burndown.DenseHistory{
+		"C":                   {{10, 0}, {0, 0}},
+		"Go":                  {{0, 0}, {10, 0}},
+		BurndownOtherLanguage: {{4, 0}, {4, 0}},
+	}, result.LanguageHistories)
+	assert.Nil(t, result.DirectoryHistories)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  languages:
+    "C": |-
+      10  0
+       0  0
+    "Go": |-
+`)
+	assert.NotContains(t, buffer.String(), "directories")
+	buffer.Reset()
+	assert.Nil(t, bd.Serialize(result, true, buffer))
+	iresult, err := bd.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	deserialized := iresult.(BurndownResult)
+	assert.Equal(t, result.LanguageHistories, deserialized.LanguageHistories)
+
+	common := core.CommonAnalysisResult{BeginTime: 600566400, EndTime: 600566400 + 59*24*3600}
+	merged := bd.MergeResults(result, deserialized, &common, &common).(BurndownResult)
+	assert.Len(t, merged.LanguageHistories, 3)
+	assert.Equal(t, burndown.DenseHistory{{8, 0}, {8, 0}}, merged.LanguageHistories[BurndownOtherLanguage])
+}
+
+func TestBurndownConsumeFinalizeClassifyLines(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{
+		items.ConfigLinesStatsClassifyLines: true, items.FactTickSize: 24 * time.Hour}))
+	assert.True(t, bd.ClassifyLines)
+	assert.Nil(t, bd.Initialize(nil))
+	resolver := &testFileIdResolver{Names: []string{"a.go"}}
+	consume := func(tick int, changes ...core.LineHistoryChange) {
+		_, err := bd.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: changes, Resolver: resolver},
+			items.DependencyTreeChanges:       object.Changes{},
+			items.DependencyTick:              tick,
+		})
+		assert.Nil(t, err)
+	}
+	change := func(prevTick, currTick core.TickNumber, lines int, kind core.LineKind) core.LineHistoryChange {
+		return core.LineHistoryChange{PrevTick: prevTick, CurrTick: currTick, Delta: lines, Kind: kind,
+			PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing}
+	}
+	consume(0, change(0, 0, 10, core.LineKindCode), change(0, 0, 4, core.LineKindComment),
+		change(0, 0, 2, core.LineKindBlank))
+	consume(30, change(30, 30, 3, core.LineKindComment), change(0, 30, -1, core.LineKindComment),
+		change(0, 30, -2, core.LineKindBlank))
+
+	assert.Nil(t, bd.Hibernate())
+	assert.Nil(t, bd.commentHistory)
+	assert.Nil(t, bd.Boot())
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, burndown.DenseHistory{{16, 0}, {13, 3}}, result.GlobalHistory)
+	assert.Equal(t, burndown.DenseHistory{{4, 0}, {3, 3}}, result.CommentHistory)
+	assert.Equal(t, burndown.DenseHistory{{2, 0}, {0, 0}}, result.BlankHistory)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  "comments": |-
+    4 0
+    3 3
+  "blanks": |-
+    2 0
+    0 0
+`)
+	buffer.Reset()
+	assert.Nil(t, bd.Serialize(result, true, buffer))
+	iresult, err := bd.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	deserialized := iresult.(BurndownResult)
+	assert.Equal(t, result.CommentHistory, deserialized.CommentHistory)
+	assert.Equal(t, result.BlankHistory, deserialized.BlankHistory)
+
+	common := core.CommonAnalysisResult{BeginTime: 600566400, EndTime: 600566400 + 59*24*3600}
+	merged := bd.MergeResults(result, deserialized, &common, &common).(BurndownResult)
+	// the samples are interpolated the same way as in the project burndown
+	assert.Equal(t, burndown.DenseHistory{{8, 0}, {5, 5}}, merged.CommentHistory)
+	assert.Equal(t, burndown.DenseHistory{{4, 0}, {0, 0}}, merged.BlankHistory)
+
+	// no comment and blank lines without the classification
+	bd = BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{items.FactTickSize: 24 * time.Hour}))
+	assert.Nil(t, bd.Initialize(nil))
+	consume(0, change(0, 0, 4, core.LineKindComment))
+	result = bd.Finalize().(BurndownResult)
+	assert.Nil(t, result.CommentHistory)
+	assert.Nil(t, result.BlankHistory)
+	buffer.Reset()
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.NotContains(t, buffer.String(), "comments")
+}
+
+func TestBurndownConfigureResample(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{ConfigBurndownResample: BurndownResampleQuarter}))
+	assert.Equal(t, BurndownResampleQuarter, bd.Resample)
+	assert.NotNil(t, bd.Configure(map[string]interface{}{ConfigBurndownResample: "week"}))
+	assert.NotNil(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownResample:              BurndownResampleMonth,
+		items.ConfigTicksSinceStartTickMode: items.TickModeMonth,
+	}))
+	assert.Nil(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownResample:              BurndownResampleMonth,
+		items.ConfigTicksSinceStartTickMode: items.TickModeFixed,
+	}))
+}
+
+func TestBurndownConsumeFinalizeResample(t *testing.T) {
+	bd := BurndownAnalysis{}
+	assert.Nil(t, bd.Configure(map[string]interface{}{
+		ConfigBurndownResample: BurndownResampleMonth, items.FactTickSize: 24 * time.Hour}))
+	assert.Nil(t, bd.Initialize(nil))
+	resolver := &testFileIdResolver{Names: []string{"a.go"}}
+	consume := func(tick int, changes ...core.LineHistoryChange) {
+		_, err := bd.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{Committer: object.Signature{
+				When: time.Date(2020, 1, 30, 15, 0, 0, 0, time.UTC).AddDate(0, 0, tick)}},
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: changes, Resolver: resolver},
+			items.DependencyTreeChanges:       object.Changes{},
+			items.DependencyTick:              tick,
+		})
+		assert.Nil(t, err)
+	}
+	added := func(tick core.TickNumber, lines int) core.LineHistoryChange {
+		return core.LineHistoryChange{PrevTick: tick, CurrTick: tick, Delta: lines,
+			PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing}
+	}
+	consume(0, added(0, 10))
+	// 2020-02-04
+	consume(5, added(5, 4), core.LineHistoryChange{PrevTick: 0, CurrTick: 5, Delta: -3,
+		PrevAuthor: core.AuthorMissing, CurrAuthor: core.AuthorMissing})
+	// 2020-03-10
+	consume(40, added(40, 2))
+	result := bd.Finalize().(BurndownResult)
+	assert.Equal(t, burndown.DenseHistory{{10, 0, 0}, {7, 4, 0}, {7, 4, 2}}, result.GlobalHistory)
+	assert.Equal(t, BurndownResampleMonth, result.resample)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, bd.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), "  tick_size: 86400\n  resample: month\n")
+	buffer.Reset()
+	assert.Nil(t, bd.Serialize(result, true, buffer))
+	iresult, err := bd.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	deserialized := iresult.(BurndownResult)
+	assert.Equal(t, result.GlobalHistory, deserialized.GlobalHistory)
+	assert.Equal(t, BurndownResampleMonth, deserialized.resample)
+
+	c1 := core.CommonAnalysisResult{BeginTime: time.Date(2020, 1, 30, 15, 0, 0, 0, time.UTC).Unix()}
+	c2 := core.CommonAnalysisResult{BeginTime: time.Date(2020, 2, 15, 0, 0, 0, 0, time.UTC).Unix()}
+	merged := bd.MergeResults(result, deserialized, &c1, &c2).(BurndownResult)
+	assert.Equal(t, burndown.DenseHistory{
+		{10, 0, 0, 0}, {7, 14, 0, 0}, {7, 11, 6, 0}, {7, 11, 6, 2},
+	}, merged.GlobalHistory)
+	assert.Equal(t, BurndownResampleMonth, merged.resample)
+	deserialized.resample = ""
+	assert.IsType(t, errors.New(""), bd.MergeResults(result, deserialized, &c1, &c2))
+}
+
+func TestBurndownPeriod(t *testing.T) {
+	when := time.Date(2021, 5, 31, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, 2021*12+4, burndownPeriod(when, BurndownResampleMonth))
+	assert.Equal(t, (2021*12+4)/3, burndownPeriod(when, BurndownResampleQuarter))
+	assert.Equal(t, 2021, burndownPeriod(when, BurndownResampleYear))
+	// the periods are in UTC
+	assert.Equal(t, 2021*12+5, burndownPeriod(when.In(time.FixedZone("UTC+2", 2*3600)).Add(2*time.Hour),
+		BurndownResampleMonth))
+}
@@ -3,8 +3,8 @@ package leaves
 import (
 	"fmt"
 	"io"
-	"path"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -29,6 +29,18 @@ type BusFactorAnalysis struct {
 	core.NoopMerger
 	// Threshold is the ownership fraction that must be covered (default 0.8 = 80%).
 	Threshold float32
+	// OwnershipTop is the number of the top owners per file in the ownership matrix.
+	// The matrix is not recorded if it is zero.
+	OwnershipTop int
+	// SimulateTop is the number of the top owners whose removal is simulated at the final tick.
+	// The simulation is disabled if it is zero.
+	SimulateTop int
+	// SubsystemEvery is the minimum number of ticks between the snapshots of the per-directory
+	// bus factors. The final tick is always included. The series is disabled if it is zero.
+	SubsystemEvery int
+	// SubsystemDepth is the number of the path components in the directories of the per-subsystem
+	// bus factors. Zero means the whole directory of each file.
+	SubsystemDepth int
 
 	// fileResolver is used to scan files for current ownership state.
 	fileResolver core.FileIdResolver
@@ -42,6 +54,8 @@ type BusFactorAnalysis struct {
 	snapshots map[int]*BusFactorSnapshot
 	// lastTick tracks the most recent tick seen.
 	lastTick int
+	// lastSubsystemTick is the tick of the latest per-directory snapshot, -1 if there is none.
+	lastSubsystemTick int
 
 	l core.Logger
 }
@@ -49,6 +63,14 @@ type BusFactorAnalysis struct {
 const (
 	// ConfigBusFactorThreshold is the name of the option to configure the ownership threshold.
 	ConfigBusFactorThreshold = "BusFactor.Threshold"
+	// ConfigBusFactorOwnershipTop is the name of the option to set BusFactorAnalysis.OwnershipTop.
+	ConfigBusFactorOwnershipTop = "BusFactor.OwnershipTop"
+	// ConfigBusFactorSimulateTop is the name of the option to set BusFactorAnalysis.SimulateTop.
+	ConfigBusFactorSimulateTop = "BusFactor.SimulateTop"
+	// ConfigBusFactorSubsystemEvery is the name of the option to set BusFactorAnalysis.SubsystemEvery.
+	ConfigBusFactorSubsystemEvery = "BusFactor.SubsystemEvery"
+	// ConfigBusFactorSubsystemDepth is the name of the option to set BusFactorAnalysis.SubsystemDepth.
+	ConfigBusFactorSubsystemDepth = "BusFactor.SubsystemDepth"
 )
 
 // BusFactorSnapshot stores the bus factor and ownership distribution at a single tick.
@@ -59,6 +81,9 @@ type BusFactorSnapshot struct {
 	TotalLines int64
 	// AuthorLines maps author index to their alive line count.
 	AuthorLines map[int]int64
+	// Subsystems maps the directories to their bus factors at this tick. It is nil unless
+	// SubsystemEvery is positive and the tick was sampled.
+	Subsystems map[string]int
 }
 
 // BusFactorResult is returned by BusFactorAnalysis.Finalize().
@@ -69,12 +94,54 @@ type BusFactorResult struct {
 	SubsystemBusFactor map[string]int
 	// Threshold used for the computation.
 	Threshold float32
+	// FilesOwnership maps the alive files to their top owners at the final tick. It is nil unless
+	// OwnershipTop is positive.
+	FilesOwnership map[string]*FileOwners
+	// OwnershipTop is the maximum number of the owners of each file in FilesOwnership.
+	OwnershipTop int
+	// Simulation lists what happens when each of the top owners leaves, the biggest owner first.
+	// It is nil unless SimulateTop is positive.
+	Simulation []BusFactorRemoval
+	// SimulateTop is the maximum number of the owners in Simulation.
+	SimulateTop int
+	// SubsystemEvery is the minimum number of ticks between Snapshots with Subsystems,
+	// 0 if the per-directory series is disabled.
+	SubsystemEvery int
+	// SubsystemDepth is the number of the path components in the directories, 0 means
+	// the whole directory.
+	SubsystemDepth int
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
 	reversedPeopleDict []string
 	// tickSize is the duration of each tick.
 	tickSize time.Duration
 }
 
+// FileOwners is the row of the sparse file × author ownership matrix.
+type FileOwners struct {
+	// Lines is the number of alive lines in the file, including the lines of the other authors.
+	Lines int64
+	// Authors are the indices of the top owners sorted by AuthorLines descending.
+	Authors []int
+	// AuthorLines are the numbers of the lines owned by each of Authors.
+	AuthorLines []int64
+}
+
+// BusFactorRemoval is the outcome of removing a single developer at the final tick.
+type BusFactorRemoval struct {
+	// Author is the index of the removed developer.
+	Author int
+	// Lines is the number of the alive lines owned by Author.
+	Lines int64
+	// Coverage is the share of the alive lines which the remaining developers own.
+	Coverage float32
+	// BusFactor is the bus factor of the remaining developers over all the alive lines,
+	// 0 if they cannot cover the threshold anymore.
+	BusFactor int
+	// Gaps maps the directories with the lines of Author to the share of their lines
+	// which nobody else owns after the removal.
+	Gaps map[string]float32
+}
+
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
 func (bf *BusFactorAnalysis) Name() string {
 	return "BusFactor"
@@ -102,6 +169,34 @@ func (bf *BusFactorAnalysis) ListConfigurationOptions() []core.ConfigurationOpti
 		Flag:        "bus-factor-threshold",
 		Type:        core.FloatConfigurationOption,
 		Default:     float32(0.8),
+	}, {
+		Name: ConfigBusFactorOwnershipTop,
+		Description: "Record the file × author ownership matrix at the final tick with this number of " +
+			"the top owners per file; 0 disables it.",
+		Flag:    "bus-factor-ownership-top",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+	}, {
+		Name: ConfigBusFactorSimulateTop,
+		Description: "Simulate the removal of each of this number of the top owners at the final " +
+			"tick and report the bus factor and the ownership gap per directory; 0 disables it.",
+		Flag:    "bus-factor-simulate-top",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+	}, {
+		Name: ConfigBusFactorSubsystemEvery,
+		Description: "Record the per-directory bus factors every this number of ticks besides " +
+			"the final tick; 0 disables the time series.",
+		Flag:    "bus-factor-subsystem-every",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+	}, {
+		Name: ConfigBusFactorSubsystemDepth,
+		Description: "Number of the path components in the directories of the per-subsystem bus " +
+			"factors; 0 takes the whole directory of each file.",
+		Flag:    "bus-factor-subsystem-depth",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
 	}}
 	return options[:]
 }
@@ -114,6 +209,30 @@ func (bf *BusFactorAnalysis) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigBusFactorThreshold]; exists {
 		bf.Threshold = val.(float32)
 	}
+	if val, exists := facts[ConfigBusFactorOwnershipTop].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--bus-factor-ownership-top must not be negative, got %d", val)
+		}
+		bf.OwnershipTop = val
+	}
+	if val, exists := facts[ConfigBusFactorSimulateTop].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--bus-factor-simulate-top must not be negative, got %d", val)
+		}
+		bf.SimulateTop = val
+	}
+	if val, exists := facts[ConfigBusFactorSubsystemEvery].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--bus-factor-subsystem-every must not be negative, got %d", val)
+		}
+		bf.SubsystemEvery = val
+	}
+	if val, exists := facts[ConfigBusFactorSubsystemDepth].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--bus-factor-subsystem-depth must not be negative, got %d", val)
+		}
+		bf.SubsystemDepth = val
+	}
 	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		bf.reversedPeopleDict = val
 	}
@@ -136,6 +255,11 @@ func (bf *BusFactorAnalysis) Flag() string {
 	return "bus-factor"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (bf *BusFactorAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (bf *BusFactorAnalysis) Description() string {
 	return "Computes the bus factor (smallest k developers owning >= threshold of lines) over time."
@@ -147,6 +271,7 @@ func (bf *BusFactorAnalysis) Initialize(repository *git.Repository) error {
 	bf.l = core.NewLogger()
 	bf.snapshots = map[int]*BusFactorSnapshot{}
 	bf.lastTick = -1
+	bf.lastSubsystemTick = -1
 	if bf.Threshold <= 0 || bf.Threshold > 1 {
 		bf.Threshold = 0.8
 	}
@@ -164,7 +289,7 @@ func (bf *BusFactorAnalysis) Consume(deps map[string]interface{}) (map[string]in
 	// Take a snapshot when we move to a new tick
 	if tick > bf.lastTick {
 		if bf.lastTick >= 0 {
-			bf.takeSnapshot(bf.lastTick)
+			bf.takeSnapshot(bf.lastTick, false)
 		}
 		bf.lastTick = tick
 	}
@@ -173,21 +298,40 @@ func (bf *BusFactorAnalysis) Consume(deps map[string]interface{}) (map[string]in
 }
 
 // takeSnapshot scans all files and computes the bus factor for the given tick.
-func (bf *BusFactorAnalysis) takeSnapshot(tick int) {
+// The per-directory bus factors are computed, too, if their series is due or final is true.
+func (bf *BusFactorAnalysis) takeSnapshot(tick int, final bool) {
 	if bf.fileResolver == nil {
 		return
 	}
 
+	var subsystems map[string]map[int]int64
+	if bf.SubsystemEvery > 0 && (final || bf.lastSubsystemTick < 0 ||
+		tick-bf.lastSubsystemTick >= bf.SubsystemEvery) {
+		subsystems = map[string]map[int]int64{}
+		bf.lastSubsystemTick = tick
+	}
 	authorLines := map[int]int64{}
 	bf.fileResolver.ForEachFile(func(fileId core.FileId, fileName string) {
 		previousLine := 0
 		previousAuthor := int(core.AuthorMissing)
+		var dirAuthors map[int]int64
+		if subsystems != nil {
+			dir := bf.subsystemOf(fileName)
+			dirAuthors = subsystems[dir]
+			if dirAuthors == nil {
+				dirAuthors = map[int]int64{}
+				subsystems[dir] = dirAuthors
+			}
+		}
 
 		bf.fileResolver.ScanFile(fileId,
 			func(line int, _ core.TickNumber, author core.AuthorId) {
 				length := line - previousLine
 				if length > 0 && previousAuthor != int(core.AuthorMissing) {
 					authorLines[previousAuthor] += int64(length)
+					if dirAuthors != nil {
+						dirAuthors[previousAuthor] += int64(length)
+					}
 				}
 				previousLine = line
 				if author >= core.AuthorMissing {
@@ -216,6 +360,33 @@ func (bf *BusFactorAnalysis) takeSnapshot(tick int) {
 		TotalLines:  totalLines,
 		AuthorLines: snapshotLines,
 	}
+	if subsystems != nil {
+		bf.snapshots[tick].Subsystems = subsystemBusFactors(subsystems, bf.Threshold)
+	}
+}
+
+// subsystemOf returns the directory of the file cut to SubsystemDepth path components.
+func (bf *BusFactorAnalysis) subsystemOf(fileName string) string {
+	if bf.SubsystemDepth > 0 {
+		return burndownDirectory(fileName, bf.SubsystemDepth)
+	}
+	return subsystemOf(fileName)
+}
+
+// subsystemBusFactors computes the bus factor of each directory from its per-author line counts.
+// The empty directories are skipped.
+func subsystemBusFactors(subsystems map[string]map[int]int64, threshold float32) map[string]int {
+	result := make(map[string]int, len(subsystems))
+	for dir, authorLines := range subsystems {
+		var totalLines int64
+		for _, lines := range authorLines {
+			totalLines += lines
+		}
+		if totalLines > 0 {
+			result[dir] = computeBusFactor(authorLines, totalLines, threshold)
+		}
+	}
+	return result
 }
 
 // computeBusFactor returns the smallest k such that the top-k authors own >= threshold of totalLines.
@@ -246,21 +417,26 @@ func computeBusFactor(authorLines map[int]int64, totalLines int64, threshold flo
 }
 
 // computeSubsystemBusFactor computes bus factor per directory prefix at the final tick.
-func (bf *BusFactorAnalysis) computeSubsystemBusFactor() map[string]int {
+// If OwnershipTop is positive, it also collects the top owners of each file in the same pass.
+// The per-directory, per-author line counts are returned, too.
+func (bf *BusFactorAnalysis) computeSubsystemBusFactor() (
+	map[string]int, map[string]*FileOwners, map[string]map[int]int64) {
 	if bf.fileResolver == nil {
-		return nil
+		return nil, nil, nil
 	}
 
 	// Accumulate per-directory, per-author line counts
 	subsystems := map[string]map[int]int64{} // dir -> author -> lines
+	var files map[string]*FileOwners
+	if bf.OwnershipTop > 0 {
+		files = map[string]*FileOwners{}
+	}
 	bf.fileResolver.ForEachFile(func(fileId core.FileId, fileName string) {
-		dir := path.Dir(fileName)
-		if dir == "." {
-			dir = "/"
-		}
+		dir := bf.subsystemOf(fileName)
 
 		previousLine := 0
 		previousAuthor := int(core.AuthorMissing)
+		fileAuthors := map[int]int64{}
 
 		bf.fileResolver.ScanFile(fileId,
 			func(line int, _ core.TickNumber, author core.AuthorId) {
@@ -272,6 +448,7 @@ func (bf *BusFactorAnalysis) computeSubsystemBusFactor() map[string]int {
 						subsystems[dir] = dirAuthors
 					}
 					dirAuthors[previousAuthor] += int64(length)
+					fileAuthors[previousAuthor] += int64(length)
 				}
 				previousLine = line
 				if author >= core.AuthorMissing {
@@ -280,30 +457,114 @@ func (bf *BusFactorAnalysis) computeSubsystemBusFactor() map[string]int {
 					previousAuthor = int(author)
 				}
 			})
+		if files != nil && previousLine > 0 {
+			files[fileName] = topFileOwners(fileAuthors, int64(previousLine), bf.OwnershipTop)
+		}
 	})
 
-	result := make(map[string]int, len(subsystems))
-	for dir, authorLines := range subsystems {
-		var totalLines int64
-		for _, lines := range authorLines {
+	return subsystemBusFactors(subsystems, bf.Threshold), files, subsystems
+}
+
+// simulateRemovals removes each of the top owners in turn from the per-directory, per-author
+// line counts and measures what remains.
+func simulateRemovals(
+	subsystems map[string]map[int]int64, top int, threshold float32) []BusFactorRemoval {
+	authorLines := map[int]int64{}
+	var totalLines int64
+	for _, dirAuthors := range subsystems {
+		for author, lines := range dirAuthors {
+			authorLines[author] += lines
 			totalLines += lines
 		}
-		result[dir] = computeBusFactor(authorLines, totalLines, bf.Threshold)
 	}
-	return result
+	authors := make([]int, 0, len(authorLines))
+	for author := range authorLines {
+		authors = append(authors, author)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authorLines[authors[i]] != authorLines[authors[j]] {
+			return authorLines[authors[i]] > authorLines[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+	if len(authors) > top {
+		authors = authors[:top]
+	}
+	removals := make([]BusFactorRemoval, 0, len(authors))
+	for _, author := range authors {
+		remaining := make(map[int]int64, len(authorLines)-1)
+		for other, lines := range authorLines {
+			if other != author {
+				remaining[other] = lines
+			}
+		}
+		removal := BusFactorRemoval{
+			Author:   author,
+			Lines:    authorLines[author],
+			Coverage: float32(float64(totalLines-authorLines[author]) / float64(totalLines)),
+			Gaps:     map[string]float32{},
+		}
+		if totalLines-removal.Lines >= int64(float64(threshold)*float64(totalLines)) {
+			removal.BusFactor = computeBusFactor(remaining, totalLines, threshold)
+		}
+		for dir, dirAuthors := range subsystems {
+			if lines := dirAuthors[author]; lines > 0 {
+				var dirLines int64
+				for _, count := range dirAuthors {
+					dirLines += count
+				}
+				removal.Gaps[dir] = float32(float64(lines) / float64(dirLines))
+			}
+		}
+		removals = append(removals, removal)
+	}
+	return removals
+}
+
+// topFileOwners returns at most top authors who own the most lines of the file.
+func topFileOwners(authorLines map[int]int64, lines int64, top int) *FileOwners {
+	authors := make([]int, 0, len(authorLines))
+	for author := range authorLines {
+		authors = append(authors, author)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authorLines[authors[i]] != authorLines[authors[j]] {
+			return authorLines[authors[i]] > authorLines[authors[j]]
+		}
+		return authors[i] < authors[j]
+	})
+	if len(authors) > top {
+		authors = authors[:top]
+	}
+	owners := &FileOwners{Lines: lines, Authors: authors, AuthorLines: make([]int64, len(authors))}
+	for i, author := range authors {
+		owners.AuthorLines[i] = authorLines[author]
+	}
+	return owners
 }
 
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (bf *BusFactorAnalysis) Finalize() interface{} {
 	// Take the final snapshot for the last tick
 	if bf.lastTick >= 0 {
-		bf.takeSnapshot(bf.lastTick)
+		bf.takeSnapshot(bf.lastTick, true)
 	}
 
+	subsystems, files, subsystemLines := bf.computeSubsystemBusFactor()
+	var simulation []BusFactorRemoval
+	if bf.SimulateTop > 0 {
+		simulation = simulateRemovals(subsystemLines, bf.SimulateTop, bf.Threshold)
+	}
 	return BusFactorResult{
 		Snapshots:          bf.snapshots,
-		SubsystemBusFactor: bf.computeSubsystemBusFactor(),
+		SubsystemBusFactor: subsystems,
 		Threshold:          bf.Threshold,
+		FilesOwnership:     files,
+		OwnershipTop:       bf.OwnershipTop,
+		Simulation:         simulation,
+		SimulateTop:        bf.SimulateTop,
+		SubsystemEvery:     bf.SubsystemEvery,
+		SubsystemDepth:     bf.SubsystemDepth,
 		reversedPeopleDict: bf.reversedPeopleDict,
 		tickSize:           bf.tickSize,
 	}
@@ -314,6 +575,53 @@ func (bf *BusFactorAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(bf, n)
 }
 
+// AnonymizePaths replaces the names of the subsystems, see core.PathAnonymizer.
+func (bf *BusFactorAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	busFactor := result.(BusFactorResult)
+	if busFactor.SubsystemBusFactor != nil {
+		subsystems := make(map[string]int, len(busFactor.SubsystemBusFactor))
+		for dir, value := range busFactor.SubsystemBusFactor {
+			subsystems[anonymize(dir)] = value
+		}
+		busFactor.SubsystemBusFactor = subsystems
+	}
+	if busFactor.FilesOwnership != nil {
+		files := make(map[string]*FileOwners, len(busFactor.FilesOwnership))
+		for file, owners := range busFactor.FilesOwnership {
+			files[anonymize(file)] = owners
+		}
+		busFactor.FilesOwnership = files
+	}
+	if busFactor.Simulation != nil {
+		simulation := make([]BusFactorRemoval, len(busFactor.Simulation))
+		for i, removal := range busFactor.Simulation {
+			gaps := make(map[string]float32, len(removal.Gaps))
+			for dir, gap := range removal.Gaps {
+				gaps[anonymize(dir)] = gap
+			}
+			removal.Gaps = gaps
+			simulation[i] = removal
+		}
+		busFactor.Simulation = simulation
+	}
+	if busFactor.SubsystemEvery > 0 {
+		snapshots := make(map[int]*BusFactorSnapshot, len(busFactor.Snapshots))
+		for tick, snapshot := range busFactor.Snapshots {
+			if snapshot.Subsystems != nil {
+				copied := *snapshot
+				copied.Subsystems = make(map[string]int, len(snapshot.Subsystems))
+				for dir, value := range snapshot.Subsystems {
+					copied.Subsystems[anonymize(dir)] = value
+				}
+				snapshot = &copied
+			}
+			snapshots[tick] = snapshot
+		}
+		busFactor.Snapshots = snapshots
+	}
+	return busFactor
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (bf *BusFactorAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -335,19 +643,26 @@ func (bf *BusFactorAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 
 	snapshots := make(map[int]*BusFactorSnapshot, len(message.Snapshots))
 	for tick, pbSnapshot := range message.Snapshots {
-		authorLines := make(map[int]int64, len(pbSnapshot.AuthorLines))
-		for authorID, lines := range pbSnapshot.AuthorLines {
+		authorLines := make(map[int]int64, len(pbSnapshot.GetAuthorLines()))
+		for authorID, lines := range pbSnapshot.GetAuthorLines() {
 			dev := int(authorID)
 			if authorID == -1 {
 				dev = core.AuthorMissing
 			}
 			authorLines[dev] = lines
 		}
-		snapshots[int(tick)] = &BusFactorSnapshot{
-			BusFactor:   int(pbSnapshot.BusFactor),
-			TotalLines:  pbSnapshot.TotalLines,
+		snapshot := &BusFactorSnapshot{
+			BusFactor:   int(pbSnapshot.GetBusFactor()),
+			TotalLines:  pbSnapshot.GetTotalLines(),
 			AuthorLines: authorLines,
 		}
+		if message.SubsystemEvery > 0 && pbSnapshot.Subsystems != nil {
+			snapshot.Subsystems = make(map[string]int, len(pbSnapshot.Subsystems))
+			for dir, value := range pbSnapshot.Subsystems {
+				snapshot.Subsystems[dir] = int(value)
+			}
+		}
+		snapshots[int(tick)] = snapshot
 	}
 
 	subsystemBF := make(map[string]int, len(message.SubsystemBusFactor))
@@ -355,10 +670,57 @@ func (bf *BusFactorAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 		subsystemBF[dir] = int(bf)
 	}
 
+	var files map[string]*FileOwners
+	if message.OwnershipTop > 0 {
+		files = make(map[string]*FileOwners, len(message.FilesOwnership))
+		for file, pbOwners := range message.FilesOwnership {
+			if pbOwners == nil {
+				return nil, fmt.Errorf("%s: missing owners", file)
+			}
+			if len(pbOwners.Authors) != len(pbOwners.AuthorLines) {
+				return nil, fmt.Errorf("%s: %d owners with %d line counts",
+					file, len(pbOwners.Authors), len(pbOwners.AuthorLines))
+			}
+			owners := &FileOwners{
+				Lines:       pbOwners.Lines,
+				Authors:     make([]int, len(pbOwners.Authors)),
+				AuthorLines: pbOwners.AuthorLines,
+			}
+			for i, author := range pbOwners.Authors {
+				owners.Authors[i] = int(author)
+			}
+			files[file] = owners
+		}
+	}
+
+	var simulation []BusFactorRemoval
+	if message.SimulateTop > 0 {
+		simulation = make([]BusFactorRemoval, len(message.Simulation))
+		for i, pbRemoval := range message.Simulation {
+			gaps := make(map[string]float32, len(pbRemoval.Gaps))
+			for dir, gap := range pbRemoval.Gaps {
+				gaps[dir] = gap
+			}
+			simulation[i] = BusFactorRemoval{
+				Author:    int(pbRemoval.Author),
+				Lines:     pbRemoval.Lines,
+				Coverage:  pbRemoval.Coverage,
+				BusFactor: int(pbRemoval.BusFactor),
+				Gaps:      gaps,
+			}
+		}
+	}
+
 	result := BusFactorResult{
 		Snapshots:          snapshots,
 		SubsystemBusFactor: subsystemBF,
 		Threshold:          message.Threshold,
+		FilesOwnership:     files,
+		OwnershipTop:       int(message.OwnershipTop),
+		Simulation:         simulation,
+		SimulateTop:        int(message.SimulateTop),
+		SubsystemEvery:     int(message.SubsystemEvery),
+		SubsystemDepth:     int(message.SubsystemDepth),
 		reversedPeopleDict: message.DevIndex,
 		tickSize:           time.Duration(message.TickSize),
 	}
@@ -395,6 +757,65 @@ func (bf *BusFactorAnalysis) serializeText(result *BusFactorResult, writer io.Wr
 		}
 	}
 
+	if result.SubsystemEvery > 0 {
+		fmt.Fprintln(writer, "    subsystem_every:", result.SubsystemEvery)
+		fmt.Fprintln(writer, "    subsystem_depth:", result.SubsystemDepth)
+		fmt.Fprintln(writer, "    per_subsystem_per_tick:")
+		series := map[string][]string{}
+		for _, tick := range ticks {
+			for dir, value := range result.Snapshots[tick].Subsystems {
+				series[dir] = append(series[dir], fmt.Sprintf("%d: %d", tick, value))
+			}
+		}
+		dirs := make([]string, 0, len(series))
+		for dir := range series {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			fmt.Fprintf(writer, "      %s: {%s}\n", yaml.SafeString(dir), strings.Join(series[dir], ", "))
+		}
+	}
+
+	if result.FilesOwnership != nil {
+		fmt.Fprintln(writer, "    ownership_top:", result.OwnershipTop)
+		fmt.Fprintln(writer, "    files_ownership:")
+		files := make([]string, 0, len(result.FilesOwnership))
+		for file := range result.FilesOwnership {
+			files = append(files, file)
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			owners := result.FilesOwnership[file]
+			pairs := make([]string, len(owners.Authors))
+			for i, author := range owners.Authors {
+				pairs[i] = fmt.Sprintf("[%d, %d]", author, owners.AuthorLines[i])
+			}
+			fmt.Fprintf(writer, "      %s: {lines: %d, owners: [%s]}\n",
+				yaml.SafeString(file), owners.Lines, strings.Join(pairs, ", "))
+		}
+	}
+
+	if result.Simulation != nil {
+		fmt.Fprintln(writer, "    simulate_top:", result.SimulateTop)
+		fmt.Fprintln(writer, "    simulation:")
+		for _, removal := range result.Simulation {
+			dirs := make([]string, 0, len(removal.Gaps))
+			for dir := range removal.Gaps {
+				dirs = append(dirs, dir)
+			}
+			sort.Strings(dirs)
+			gaps := make([]string, len(dirs))
+			for i, dir := range dirs {
+				gaps[i] = fmt.Sprintf("%s: %.4f", yaml.SafeString(dir), removal.Gaps[dir])
+			}
+			fmt.Fprintf(writer,
+				"      - {author: %d, lines: %d, coverage: %.4f, bus_factor: %d, gaps: {%s}}\n",
+				removal.Author, removal.Lines, removal.Coverage, removal.BusFactor,
+				strings.Join(gaps, ", "))
+		}
+	}
+
 	fmt.Fprintln(writer, "    people:")
 	for _, person := range result.reversedPeopleDict {
 		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
@@ -404,9 +825,11 @@ func (bf *BusFactorAnalysis) serializeText(result *BusFactorResult, writer io.Wr
 
 func (bf *BusFactorAnalysis) serializeBinary(result *BusFactorResult, writer io.Writer) error {
 	message := pb.BusFactorAnalysisResults{
-		DevIndex:  result.reversedPeopleDict,
-		TickSize:  int64(result.tickSize),
-		Threshold: result.Threshold,
+		DevIndex:       result.reversedPeopleDict,
+		TickSize:       int64(result.tickSize),
+		Threshold:      result.Threshold,
+		SubsystemEvery: int32(result.SubsystemEvery),
+		SubsystemDepth: int32(result.SubsystemDepth),
 	}
 
 	message.Snapshots = make(map[int32]*pb.BusFactorTickSnapshot, len(result.Snapshots))
@@ -423,6 +846,12 @@ func (bf *BusFactorAnalysis) serializeBinary(result *BusFactorResult, writer io.
 			}
 			pbSnapshot.AuthorLines[authorID] = lines
 		}
+		if result.SubsystemEvery > 0 && snapshot.Subsystems != nil {
+			pbSnapshot.Subsystems = make(map[string]int32, len(snapshot.Subsystems))
+			for dir, value := range snapshot.Subsystems {
+				pbSnapshot.Subsystems[dir] = int32(value)
+			}
+		}
 		message.Snapshots[int32(tick)] = pbSnapshot
 	}
 
@@ -431,6 +860,36 @@ func (bf *BusFactorAnalysis) serializeBinary(result *BusFactorResult, writer io.
 		message.SubsystemBusFactor[dir] = int32(bf)
 	}
 
+	if result.FilesOwnership != nil {
+		message.OwnershipTop = int32(result.OwnershipTop)
+		message.FilesOwnership = make(map[string]*pb.FileOwners, len(result.FilesOwnership))
+		for file, owners := range result.FilesOwnership {
+			pbOwners := &pb.FileOwners{
+				Lines:       owners.Lines,
+				Authors:     make([]int32, len(owners.Authors)),
+				AuthorLines: owners.AuthorLines,
+			}
+			for i, author := range owners.Authors {
+				pbOwners.Authors[i] = int32(author)
+			}
+			message.FilesOwnership[file] = pbOwners
+		}
+	}
+
+	if result.Simulation != nil {
+		message.SimulateTop = int32(result.SimulateTop)
+		message.Simulation = make([]*pb.BusFactorRemoval, len(result.Simulation))
+		for i, removal := range result.Simulation {
+			message.Simulation[i] = &pb.BusFactorRemoval{
+				Author:    int32(removal.Author),
+				Lines:     removal.Lines,
+				Coverage:  removal.Coverage,
+				BusFactor: int32(removal.BusFactor),
+				Gaps:      removal.Gaps,
+			}
+		}
+	}
+
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
 		return err
@@ -450,9 +909,14 @@ func (bf *BusFactorAnalysis) MergeResults(
 		Snapshots:          make(map[int]*BusFactorSnapshot),
 		SubsystemBusFactor: make(map[string]int),
 		Threshold:          bfr1.Threshold,
+		SubsystemEvery:     bfr1.SubsystemEvery,
+		SubsystemDepth:     bfr1.SubsystemDepth,
 		reversedPeopleDict: bfr1.reversedPeopleDict,
 		tickSize:           bfr1.tickSize,
 	}
+	if merged.SubsystemEvery == 0 {
+		merged.SubsystemEvery, merged.SubsystemDepth = bfr2.SubsystemEvery, bfr2.SubsystemDepth
+	}
 
 	// Merge snapshots: take the snapshot with the larger total lines for overlapping ticks
 	for tick, snapshot := range bfr1.Snapshots {
@@ -464,6 +928,29 @@ func (bf *BusFactorAnalysis) MergeResults(
 		}
 	}
 
+	// Merge the ownership matrices: the files of the bigger revision win
+	if bfr1.FilesOwnership != nil || bfr2.FilesOwnership != nil {
+		merged.OwnershipTop = bfr1.OwnershipTop
+		if bfr2.OwnershipTop > merged.OwnershipTop {
+			merged.OwnershipTop = bfr2.OwnershipTop
+		}
+		merged.FilesOwnership = map[string]*FileOwners{}
+		for file, owners := range bfr1.FilesOwnership {
+			merged.FilesOwnership[file] = owners
+		}
+		for file, owners := range bfr2.FilesOwnership {
+			if existing, ok := merged.FilesOwnership[file]; !ok || owners.Lines > existing.Lines {
+				merged.FilesOwnership[file] = owners
+			}
+		}
+	}
+
+	// The removals cannot be simulated again from the merged results: keep the first simulation
+	merged.Simulation, merged.SimulateTop = bfr1.Simulation, bfr1.SimulateTop
+	if merged.Simulation == nil {
+		merged.Simulation, merged.SimulateTop = bfr2.Simulation, bfr2.SimulateTop
+	}
+
 	// Merge subsystem bus factors: take the max (worst case)
 	for dir, bf := range bfr1.SubsystemBusFactor {
 		merged.SubsystemBusFactor[dir] = bf
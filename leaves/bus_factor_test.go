@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"github.com/meko-christian/hercules/internal/test"
@@ -43,6 +44,10 @@ func TestBusFactorConfigure(t *testing.T) {
 	facts[identity.FactIdentityDetectorReversedPeopleDict] = []string{"Alice", "Bob"}
 	facts[items.FactTickSize] = 24 * time.Hour
 	facts[ConfigBusFactorThreshold] = float32(0.9)
+	facts[ConfigBusFactorOwnershipTop] = 3
+	facts[ConfigBusFactorSimulateTop] = 5
+	facts[ConfigBusFactorSubsystemEvery] = 7
+	facts[ConfigBusFactorSubsystemDepth] = 2
 	logger := core.NewLogger()
 	facts[core.ConfigLogger] = logger
 
@@ -50,6 +55,21 @@ func TestBusFactorConfigure(t *testing.T) {
 	assert.Equal(t, []string{"Alice", "Bob"}, bf.reversedPeopleDict)
 	assert.Equal(t, 24*time.Hour, bf.tickSize)
 	assert.InDelta(t, float32(0.9), bf.Threshold, 0.001)
+	assert.Equal(t, 3, bf.OwnershipTop)
+	assert.Equal(t, 5, bf.SimulateTop)
+	assert.Equal(t, 7, bf.SubsystemEvery)
+	assert.Equal(t, 2, bf.SubsystemDepth)
+	facts[ConfigBusFactorSubsystemEvery] = -1
+	assert.Error(t, bf.Configure(facts))
+	facts[ConfigBusFactorSubsystemEvery] = 0
+	facts[ConfigBusFactorSubsystemDepth] = -1
+	assert.Error(t, bf.Configure(facts))
+	facts[ConfigBusFactorSubsystemDepth] = 0
+	facts[ConfigBusFactorSimulateTop] = -1
+	assert.Error(t, bf.Configure(facts))
+	facts[ConfigBusFactorSimulateTop] = 0
+	facts[ConfigBusFactorOwnershipTop] = -1
+	assert.Error(t, bf.Configure(facts))
 }
 
 func TestBusFactorConfigureDefaults(t *testing.T) {
@@ -72,9 +92,46 @@ func TestBusFactorInitialize(t *testing.T) {
 func TestBusFactorListConfigurationOptions(t *testing.T) {
 	bf := BusFactorAnalysis{}
 	opts := bf.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 5)
 	assert.Equal(t, ConfigBusFactorThreshold, opts[0].Name)
 	assert.Equal(t, "bus-factor-threshold", opts[0].Flag)
+	assert.Equal(t, ConfigBusFactorOwnershipTop, opts[1].Name)
+	assert.Equal(t, "bus-factor-ownership-top", opts[1].Flag)
+	assert.Equal(t, 0, opts[1].Default)
+	assert.Equal(t, ConfigBusFactorSimulateTop, opts[2].Name)
+	assert.Equal(t, "bus-factor-simulate-top", opts[2].Flag)
+	assert.Equal(t, 0, opts[2].Default)
+	assert.Equal(t, ConfigBusFactorSubsystemEvery, opts[3].Name)
+	assert.Equal(t, "bus-factor-subsystem-every", opts[3].Flag)
+	assert.Equal(t, ConfigBusFactorSubsystemDepth, opts[4].Name)
+	assert.Equal(t, "bus-factor-subsystem-depth", opts[4].Flag)
+}
+
+func TestSimulateRemovals(t *testing.T) {
+	subsystems := map[string]map[int]int64{
+		"src":  {0: 60, 1: 20},
+		"docs": {0: 10, 1: 5, 2: 5},
+	}
+	assert.Equal(t, []BusFactorRemoval{
+		{Author: 0, Lines: 70, Coverage: 0.3, BusFactor: 0,
+			Gaps: map[string]float32{"src": 0.75, "docs": 0.5}},
+		{Author: 1, Lines: 25, Coverage: 0.75, BusFactor: 1,
+			Gaps: map[string]float32{"src": 0.25, "docs": 0.25}},
+	}, simulateRemovals(subsystems, 2, 0.5))
+	assert.Len(t, simulateRemovals(subsystems, 10, 0.5), 3)
+	assert.Empty(t, simulateRemovals(nil, 2, 0.5))
+}
+
+func TestTopFileOwners(t *testing.T) {
+	owners := topFileOwners(map[int]int64{0: 10, 1: 30, 2: 10, 3: 5}, 60, 3)
+	assert.Equal(t, &FileOwners{
+		Lines:       60,
+		Authors:     []int{1, 0, 2},
+		AuthorLines: []int64{30, 10, 10},
+	}, owners)
+	owners = topFileOwners(map[int]int64{}, 4, 3)
+	assert.Equal(t, int64(4), owners.Lines)
+	assert.Len(t, owners.Authors, 0)
 }
 
 func TestComputeBusFactor(t *testing.T) {
@@ -251,6 +308,193 @@ func TestBusFactorSerializeBinaryRoundtrip(t *testing.T) {
 	assert.Equal(t, int64(200), result2.Snapshots[5].TotalLines)
 
 	assert.Equal(t, 1, result2.SubsystemBusFactor["src"])
+	assert.Nil(t, result2.FilesOwnership)
+}
+
+func TestBusFactorSerializeOwnership(t *testing.T) {
+	bf := BusFactorAnalysis{}
+	result := BusFactorResult{
+		Snapshots:          map[int]*BusFactorSnapshot{},
+		SubsystemBusFactor: map[string]int{"src": 1},
+		Threshold:          0.8,
+		FilesOwnership: map[string]*FileOwners{
+			"src/b.go": {Lines: 10, Authors: []int{1, 0}, AuthorLines: []int64{7, 3}},
+			"src/a.go": {Lines: 5, Authors: []int{0}, AuthorLines: []int64{4}},
+		},
+		OwnershipTop:       2,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, bf.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), `    ownership_top: 2
+    files_ownership:
+      "src/a.go": {lines: 5, owners: [[0, 4]]}
+      "src/b.go": {lines: 10, owners: [[1, 7], [0, 3]]}
+`)
+
+	buf.Reset()
+	assert.Nil(t, bf.Serialize(result, true, &buf))
+	rawResult2, err := bf.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	result2 := rawResult2.(BusFactorResult)
+	assert.Equal(t, 2, result2.OwnershipTop)
+	assert.Equal(t, result.FilesOwnership, result2.FilesOwnership)
+	// the files_ownership entry without the value
+	_, err = bf.Deserialize([]byte("\x38\x01\x32\x03\x0a\x01a"))
+	assert.Error(t, err)
+
+	anonymized := bf.AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(BusFactorResult)
+	assert.Contains(t, anonymized.FilesOwnership, "x/src/a.go")
+	assert.Contains(t, result.FilesOwnership, "src/a.go")
+
+	merged := bf.MergeResults(result, BusFactorResult{
+		FilesOwnership: map[string]*FileOwners{
+			"src/a.go": {Lines: 8, Authors: []int{1}, AuthorLines: []int64{8}},
+			"src/c.go": {Lines: 1, Authors: []int{0}, AuthorLines: []int64{1}},
+		},
+		OwnershipTop: 1,
+	}, nil, nil).(BusFactorResult)
+	assert.Equal(t, 2, merged.OwnershipTop)
+	assert.Len(t, merged.FilesOwnership, 3)
+	assert.Equal(t, int64(8), merged.FilesOwnership["src/a.go"].Lines)
+	assert.Equal(t, int64(10), merged.FilesOwnership["src/b.go"].Lines)
+}
+
+func TestBusFactorSerializeSimulation(t *testing.T) {
+	bf := BusFactorAnalysis{}
+	result := BusFactorResult{
+		Snapshots:          map[int]*BusFactorSnapshot{},
+		SubsystemBusFactor: map[string]int{"src": 1},
+		Threshold:          0.5,
+		Simulation: []BusFactorRemoval{
+			{Author: 0, Lines: 70, Coverage: 0.3, Gaps: map[string]float32{"src": 0.75, "docs": 0.5}},
+			{Author: 1, Lines: 25, Coverage: 0.75, BusFactor: 1, Gaps: map[string]float32{}},
+		},
+		SimulateTop:        2,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, bf.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), `    simulate_top: 2
+    simulation:
+      - {author: 0, lines: 70, coverage: 0.3000, bus_factor: 0, gaps: {"docs": 0.5000, "src": 0.7500}}
+      - {author: 1, lines: 25, coverage: 0.7500, bus_factor: 1, gaps: {}}
+`)
+
+	buf.Reset()
+	assert.Nil(t, bf.Serialize(result, true, &buf))
+	rawResult2, err := bf.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	result2 := rawResult2.(BusFactorResult)
+	assert.Equal(t, 2, result2.SimulateTop)
+	assert.Equal(t, result.Simulation, result2.Simulation)
+
+	anonymized := bf.AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(BusFactorResult)
+	assert.Contains(t, anonymized.Simulation[0].Gaps, "x/src")
+	assert.Contains(t, result.Simulation[0].Gaps, "src")
+
+	merged := bf.MergeResults(BusFactorResult{}, result, nil, nil).(BusFactorResult)
+	assert.Equal(t, result.Simulation, merged.Simulation)
+	assert.Equal(t, 2, merged.SimulateTop)
+}
+
+func TestBusFactorSubsystemSeries(t *testing.T) {
+	bf := BusFactorAnalysis{Threshold: 0.8, SubsystemEvery: 3, SubsystemDepth: 1}
+	assert.Nil(t, bf.Initialize(test.Repository))
+	resolver := &testFileIdResolver{
+		Names: []string{"src/core/a.go", "src/b.go", "README.md"},
+		Segments: [][]testLineSegment{
+			{{0, 0}, {10, 0}, {20, 0}},
+			{{0, 0}, {10, 0}},
+			{{0, 0}, {4, 0}},
+		},
+		Authors: [][]core.AuthorId{
+			{0, 1, 0},
+			{1, 0},
+			{0, 0},
+		},
+	}
+	for _, tick := range []int{0, 1, 3, 4, 5} {
+		_, err := bf.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+			identity.DependencyAuthor:         0,
+			items.DependencyTick:              tick,
+		})
+		assert.Nil(t, err)
+	}
+	result := bf.Finalize().(BusFactorResult)
+	assert.Len(t, result.Snapshots, 5)
+	sampled := map[int]map[string]int{}
+	for tick, snapshot := range result.Snapshots {
+		if snapshot.Subsystems != nil {
+			sampled[tick] = snapshot.Subsystems
+		}
+	}
+	// tick 0, 3 after 3 ticks and the final tick 5
+	assert.Equal(t, map[int]map[string]int{
+		0: {"src": 2, "/": 1},
+		3: {"src": 2, "/": 1},
+		5: {"src": 2, "/": 1},
+	}, sampled)
+	assert.Equal(t, map[string]int{"src": 2, "/": 1}, result.SubsystemBusFactor)
+	assert.Equal(t, 3, result.SubsystemEvery)
+	assert.Equal(t, 1, result.SubsystemDepth)
+}
+
+func TestBusFactorSerializeSubsystemSeries(t *testing.T) {
+	bf := BusFactorAnalysis{}
+	result := BusFactorResult{
+		Snapshots: map[int]*BusFactorSnapshot{
+			0: {BusFactor: 1, TotalLines: 10, AuthorLines: map[int]int64{0: 10},
+				Subsystems: map[string]int{"src": 1}},
+			1: {BusFactor: 1, TotalLines: 12, AuthorLines: map[int]int64{0: 12}},
+			7: {BusFactor: 2, TotalLines: 20, AuthorLines: map[int]int64{0: 10, 1: 10},
+				Subsystems: map[string]int{"src": 2, "docs": 1}},
+		},
+		SubsystemBusFactor: map[string]int{"src": 2, "docs": 1},
+		Threshold:          0.8,
+		SubsystemEvery:     7,
+		SubsystemDepth:     1,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, bf.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), `    subsystem_every: 7
+    subsystem_depth: 1
+    per_subsystem_per_tick:
+      "docs": {7: 1}
+      "src": {0: 1, 7: 2}
+`)
+
+	buf.Reset()
+	assert.Nil(t, bf.Serialize(result, true, &buf))
+	rawResult2, err := bf.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	result2 := rawResult2.(BusFactorResult)
+	assert.Equal(t, result.Snapshots, result2.Snapshots)
+	assert.Equal(t, 7, result2.SubsystemEvery)
+	assert.Equal(t, 1, result2.SubsystemDepth)
+
+	anonymized := bf.AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(BusFactorResult)
+	assert.Equal(t, map[string]int{"x/src": 2, "x/docs": 1}, anonymized.Snapshots[7].Subsystems)
+	assert.Equal(t, map[string]int{"src": 2, "docs": 1}, result.Snapshots[7].Subsystems)
+	assert.Nil(t, anonymized.Snapshots[1].Subsystems)
+
+	merged := bf.MergeResults(BusFactorResult{}, result, nil, nil).(BusFactorResult)
+	assert.Equal(t, 7, merged.SubsystemEvery)
+	assert.Equal(t, 1, merged.SubsystemDepth)
 }
 
 func TestBusFactorFork(t *testing.T) {
@@ -311,3 +555,16 @@ func TestBusFactorMergeResults(t *testing.T) {
 	assert.Equal(t, 2, merged.SubsystemBusFactor["src"])
 	assert.Equal(t, 1, merged.SubsystemBusFactor["docs"])
 }
+
+func TestBusFactorAnonymizePaths(t *testing.T) {
+	result := BusFactorResult{
+		SubsystemBusFactor: map[string]int{"src": 1, "/": 2},
+		Threshold:          0.8,
+	}
+	anonymized := (&BusFactorAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(BusFactorResult)
+	assert.Equal(t, map[string]int{"x/src": 1, "x//": 2}, anonymized.SubsystemBusFactor)
+	assert.Equal(t, float32(0.8), anonymized.Threshold)
+	assert.Contains(t, result.SubsystemBusFactor, "src")
+}
@@ -0,0 +1,349 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigCodeAgePyramidTick is the name of the option to set the tick of the snapshot.
+	ConfigCodeAgePyramidTick = "CodeAgePyramid.Tick"
+)
+
+// CodeAgeBuckets are the exclusive upper bounds of the line age buckets of CodeAgePyramidAnalysis.
+// The lines which are older than the last bound fall into the extra last bucket.
+var CodeAgeBuckets = []time.Duration{90 * 24 * time.Hour, 365 * 24 * time.Hour, 3 * 365 * 24 * time.Hour}
+
+// CodeAgeBucketNames name each of CodeAgeBuckets and the extra last bucket.
+var CodeAgeBucketNames = []string{"0-3m", "3-12m", "1-3y", ">3y"}
+
+// CodeAgePyramidAnalysis distributes the alive lines by their age at the specified tick
+// in each subsystem (directory). The result is the data of the "code age pyramid" chart.
+type CodeAgePyramidAnalysis struct {
+	core.NoopMerger
+	// Tick is the tick of the snapshot. Negative values take the snapshot after the last commit.
+	Tick int
+
+	// fileResolver references the current state of LineHistory.
+	fileResolver core.FileIdResolver
+	// lastTick is the tick of the latest consumed commit.
+	lastTick int
+	// snapshot is taken once the analysis passes Tick.
+	snapshot *CodeAgePyramidResult
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// CodeAgePyramidResult is returned by CodeAgePyramidAnalysis.Finalize().
+type CodeAgePyramidResult struct {
+	// Tick is the tick of the snapshot.
+	Tick int
+	// Buckets are the names of the age buckets, from the youngest to the oldest.
+	Buckets []string
+	// Subsystems maps directories to the number of alive lines in each age bucket.
+	Subsystems map[string][]int64
+	// Totals is the number of alive lines in each age bucket in the whole repository.
+	Totals []int64
+
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ca *CodeAgePyramidAnalysis) Name() string {
+	return "CodeAgePyramid"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (ca *CodeAgePyramidAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (ca *CodeAgePyramidAnalysis) Requires() []string {
+	return []string{linehistory.DependencyLineHistory, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ca *CodeAgePyramidAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCodeAgePyramidTick,
+		Description: "Tick of the code age pyramid snapshot; negative values take it after the last commit.",
+		Flag:        "age-pyramid-tick",
+		Type:        core.IntConfigurationOption,
+		Default:     -1,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ca *CodeAgePyramidAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ca.l = l
+	}
+	if val, exists := facts[ConfigCodeAgePyramidTick].(int); exists {
+		ca.Tick = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		ca.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*CodeAgePyramidAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ca *CodeAgePyramidAnalysis) Flag() string {
+	return "age-pyramid"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ca *CodeAgePyramidAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ca *CodeAgePyramidAnalysis) Description() string {
+	return "Distributes the alive lines in each directory by their age (0-3 months, 3-12 months, " +
+		"1-3 years, older) at the last commit or at the specified tick."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ca *CodeAgePyramidAnalysis) Initialize(repository *git.Repository) error {
+	ca.l = core.NewLogger()
+	ca.fileResolver = nil
+	ca.lastTick = -1
+	ca.snapshot = nil
+	if ca.tickSize == 0 {
+		ca.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ca *CodeAgePyramidAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges)
+	tick := deps[items.DependencyTick].(int)
+	ca.fileResolver = changes.Resolver
+	if ca.snapshot == nil && ca.Tick >= 0 && tick > ca.Tick {
+		// LineHistory has already applied the first commit after Tick: its new lines are
+		// filtered out by their tick, but the lines which it deleted are lost
+		ca.snapshot = ca.takeSnapshot(ca.Tick)
+	}
+	ca.lastTick = tick
+	return nil, nil
+}
+
+// takeSnapshot distributes the alive lines which existed at the tick by their age.
+func (ca *CodeAgePyramidAnalysis) takeSnapshot(tick int) *CodeAgePyramidResult {
+	result := &CodeAgePyramidResult{
+		Tick:       tick,
+		Buckets:    CodeAgeBucketNames,
+		Subsystems: map[string][]int64{},
+		Totals:     make([]int64, len(CodeAgeBucketNames)),
+		tickSize:   ca.tickSize,
+	}
+	if ca.fileResolver == nil {
+		return result
+	}
+	ca.fileResolver.ForEachFile(func(id core.FileId, name string) {
+		dir := subsystemOf(name)
+		previousLine, previousTick := -1, 0
+		ca.fileResolver.ScanFile(id, func(line int, lineTick core.TickNumber, _ core.AuthorId) {
+			// the lines from the future and the merge marks have ticks after the snapshot
+			if previousLine >= 0 && line > previousLine && previousTick <= tick {
+				counts := result.Subsystems[dir]
+				if counts == nil {
+					counts = make([]int64, len(CodeAgeBucketNames))
+					result.Subsystems[dir] = counts
+				}
+				bucket := codeAgeBucket(time.Duration(tick-previousTick) * ca.tickSize)
+				counts[bucket] += int64(line - previousLine)
+				result.Totals[bucket] += int64(line - previousLine)
+			}
+			previousLine, previousTick = line, int(lineTick)
+		})
+	})
+	return result
+}
+
+// codeAgeBucket returns the index of the CodeAgeBuckets bucket which the age falls into.
+func codeAgeBucket(age time.Duration) int {
+	for i, bound := range CodeAgeBuckets {
+		if age < bound {
+			return i
+		}
+	}
+	return len(CodeAgeBuckets)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ca *CodeAgePyramidAnalysis) Finalize() interface{} {
+	if ca.snapshot == nil {
+		tick := ca.lastTick
+		if tick < 0 {
+			tick = 0
+		}
+		ca.snapshot = ca.takeSnapshot(tick)
+	}
+	return *ca.snapshot
+}
+
+// Fork clones this pipeline item.
+func (ca *CodeAgePyramidAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ca, n)
+}
+
+// AnonymizePaths replaces the names of the subsystems, see core.PathAnonymizer.
+func (ca *CodeAgePyramidAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	pyramid := result.(CodeAgePyramidResult)
+	subsystems := make(map[string][]int64, len(pyramid.Subsystems))
+	for dir, counts := range pyramid.Subsystems {
+		subsystems[anonymize(dir)] = counts
+	}
+	pyramid.Subsystems = subsystems
+	return pyramid
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ca *CodeAgePyramidAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	pyramid, ok := result.(CodeAgePyramidResult)
+	if !ok {
+		return fmt.Errorf("result is not a code age pyramid result: '%v'", result)
+	}
+	if binary {
+		return ca.serializeBinary(&pyramid, writer)
+	}
+	ca.serializeText(&pyramid, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CodeAgePyramidResult.
+func (ca *CodeAgePyramidAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CodeAgePyramidResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := CodeAgePyramidResult{
+		Tick:       int(message.Tick),
+		Buckets:    message.Buckets,
+		Subsystems: make(map[string][]int64, len(message.Subsystems)),
+		Totals:     message.Totals,
+		tickSize:   time.Duration(message.TickSize),
+	}
+	for dir, counts := range message.Subsystems {
+		result.Subsystems[dir] = counts.GetLines()
+	}
+	return result, nil
+}
+
+// MergeResults combines two CodeAgePyramidResult-s together by summing the lines.
+func (ca *CodeAgePyramidAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	pyramid1 := r1.(CodeAgePyramidResult)
+	pyramid2 := r2.(CodeAgePyramidResult)
+	merged := CodeAgePyramidResult{
+		Tick:       pyramid1.Tick,
+		Buckets:    pyramid1.Buckets,
+		Subsystems: map[string][]int64{},
+		Totals:     make([]int64, len(pyramid1.Buckets)),
+		tickSize:   pyramid1.tickSize,
+	}
+	if pyramid2.Tick > merged.Tick {
+		merged.Tick = pyramid2.Tick
+	}
+	for _, pyramid := range [...]CodeAgePyramidResult{pyramid1, pyramid2} {
+		for dir, counts := range pyramid.Subsystems {
+			mergedCounts := merged.Subsystems[dir]
+			if mergedCounts == nil {
+				mergedCounts = make([]int64, len(merged.Buckets))
+				merged.Subsystems[dir] = mergedCounts
+			}
+			for i := 0; i < len(counts) && i < len(mergedCounts); i++ {
+				mergedCounts[i] += counts[i]
+			}
+		}
+		for i := 0; i < len(pyramid.Totals) && i < len(merged.Totals); i++ {
+			merged.Totals[i] += pyramid.Totals[i]
+		}
+	}
+	return merged
+}
+
+func (ca *CodeAgePyramidAnalysis) serializeText(result *CodeAgePyramidResult, writer io.Writer) {
+	writeCounts := func(counts []int64) {
+		fmt.Fprint(writer, "[")
+		for i, value := range counts {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d", value)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintln(writer, "  code_age_pyramid:")
+	fmt.Fprintf(writer, "    tick: %d\n", result.Tick)
+	fmt.Fprintf(writer, "    tick_size: %d\n", int(result.tickSize.Seconds()))
+	fmt.Fprint(writer, "    buckets: [")
+	for i, name := range result.Buckets {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, yaml.SafeString(name))
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprint(writer, "    totals: ")
+	writeCounts(result.Totals)
+	fmt.Fprintln(writer, "    subsystems:")
+	dirs := make([]string, 0, len(result.Subsystems))
+	for dir := range result.Subsystems {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fmt.Fprintf(writer, "      %s: ", yaml.SafeString(dir))
+		writeCounts(result.Subsystems[dir])
+	}
+}
+
+func (ca *CodeAgePyramidAnalysis) serializeBinary(result *CodeAgePyramidResult, writer io.Writer) error {
+	message := pb.CodeAgePyramidResults{
+		Tick:       int32(result.Tick),
+		Buckets:    result.Buckets,
+		Subsystems: make(map[string]*pb.CodeAgePyramidCounts, len(result.Subsystems)),
+		Totals:     result.Totals,
+		TickSize:   int64(result.tickSize),
+	}
+	for dir, counts := range result.Subsystems {
+		message.Subsystems[dir] = &pb.CodeAgePyramidCounts{Lines: counts}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&CodeAgePyramidAnalysis{})
+}
@@ -0,0 +1,215 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLineSegment starts the interval of lines added at the tick; the last segment
+// of each file marks the end.
+type testLineSegment struct {
+	Line int
+	Tick core.TickNumber
+}
+
+// testFileIdResolver is core.FileIdResolver over the static line intervals.
+type testFileIdResolver struct {
+	Names    []string
+	Segments [][]testLineSegment
+	// Authors optionally set the authors of the segments, 0 by default.
+	Authors [][]core.AuthorId
+}
+
+func (r *testFileIdResolver) NameOf(id core.FileId) string {
+	return r.Names[id]
+}
+
+func (r *testFileIdResolver) MergedWith(id core.FileId) (core.FileId, string, bool) {
+	return id, r.Names[id], false
+}
+
+func (r *testFileIdResolver) ForEachFile(callback func(id core.FileId, name string)) bool {
+	for i, name := range r.Names {
+		callback(core.FileId(i), name)
+	}
+	return true
+}
+
+func (r *testFileIdResolver) ScanFile(id core.FileId, callback func(line int, tick core.TickNumber, author core.AuthorId)) bool {
+	segments := r.Segments[id]
+	for i, segment := range segments {
+		author := core.AuthorId(0)
+		if r.Authors != nil {
+			author = r.Authors[id][i]
+		}
+		if i == len(segments)-1 {
+			author = core.AuthorMissing
+		}
+		callback(segment.Line, segment.Tick, author)
+	}
+	return true
+}
+
+func newTestCodeAgeResolver() *testFileIdResolver {
+	return &testFileIdResolver{
+		Names: []string{"src/a.go", "README.md"},
+		Segments: [][]testLineSegment{
+			// 10 lines from tick 0, 5 lines from tick 300, 20 lines from tick 1000, end
+			{{0, 0}, {10, 300}, {15, 1000}, {35, 0}},
+			// 4 lines from tick 1190, 6 lines from tick 2000 which is after the snapshot, end
+			{{0, 1190}, {4, 2000}, {10, 0}},
+		},
+	}
+}
+
+func TestCodeAgePyramidMeta(t *testing.T) {
+	ca := &CodeAgePyramidAnalysis{}
+	assert.Equal(t, "CodeAgePyramid", ca.Name())
+	assert.Len(t, ca.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory, items.DependencyTick}, ca.Requires())
+	assert.Equal(t, "age-pyramid", ca.Flag())
+	assert.Equal(t, core.CostHeavy, ca.Cost())
+	opts := ca.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigCodeAgePyramidTick, opts[0].Name)
+	assert.Equal(t, -1, opts[0].Default)
+	require.NoError(t, ca.Configure(map[string]interface{}{
+		ConfigCodeAgePyramidTick: 7,
+		items.FactTickSize:       12 * time.Hour,
+	}))
+	assert.Equal(t, 7, ca.Tick)
+	assert.Equal(t, 12*time.Hour, ca.tickSize)
+}
+
+func TestCodeAgePyramidRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CodeAgePyramidAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "CodeAgePyramid", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&CodeAgePyramidAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCodeAgeBucket(t *testing.T) {
+	day := 24 * time.Hour
+	assert.Equal(t, 0, codeAgeBucket(0))
+	assert.Equal(t, 0, codeAgeBucket(89*day))
+	assert.Equal(t, 1, codeAgeBucket(90*day))
+	assert.Equal(t, 2, codeAgeBucket(365*day))
+	assert.Equal(t, 3, codeAgeBucket(3*365*day))
+	assert.Len(t, CodeAgeBucketNames, len(CodeAgeBuckets)+1)
+}
+
+func TestCodeAgePyramidHead(t *testing.T) {
+	ca := &CodeAgePyramidAnalysis{Tick: -1}
+	require.NoError(t, ca.Initialize(nil))
+	assert.Equal(t, 24*time.Hour, ca.tickSize)
+	resolver := newTestCodeAgeResolver()
+	for _, tick := range []int{0, 600, 1200} {
+		_, err := ca.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+			items.DependencyTick:              tick,
+		})
+		require.NoError(t, err)
+	}
+	result := ca.Finalize().(CodeAgePyramidResult)
+	assert.Equal(t, 1200, result.Tick)
+	assert.Equal(t, CodeAgeBucketNames, result.Buckets)
+	assert.Equal(t, map[string][]int64{
+		"src": {0, 20, 5, 10},
+		"/":   {4, 0, 0, 0},
+	}, result.Subsystems)
+	assert.Equal(t, []int64{4, 20, 5, 10}, result.Totals)
+}
+
+func TestCodeAgePyramidTick(t *testing.T) {
+	ca := &CodeAgePyramidAnalysis{Tick: 400}
+	require.NoError(t, ca.Initialize(nil))
+	resolver := newTestCodeAgeResolver()
+	for _, tick := range []int{0, 300, 1200} {
+		_, err := ca.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+			items.DependencyTick:              tick,
+		})
+		require.NoError(t, err)
+	}
+	result := ca.Finalize().(CodeAgePyramidResult)
+	assert.Equal(t, 400, result.Tick)
+	assert.Equal(t, map[string][]int64{"src": {0, 5, 10, 0}}, result.Subsystems)
+	assert.Equal(t, []int64{0, 5, 10, 0}, result.Totals)
+}
+
+func TestCodeAgePyramidSerialize(t *testing.T) {
+	ca := &CodeAgePyramidAnalysis{}
+	result := CodeAgePyramidResult{
+		Tick:       10,
+		Buckets:    CodeAgeBucketNames,
+		Subsystems: map[string][]int64{"src": {1, 2, 3, 4}, "/": {5, 0, 0, 0}},
+		Totals:     []int64{6, 2, 3, 4},
+		tickSize:   24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, ca.Serialize(result, false, buffer))
+	assert.Equal(t, `  code_age_pyramid:
+    tick: 10
+    tick_size: 86400
+    buckets: ["0-3m", "3-12m", "1-3y", ">3y"]
+    totals: [6, 2, 3, 4]
+    subsystems:
+      "/": [5, 0, 0, 0]
+      "src": [1, 2, 3, 4]
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, ca.Serialize(result, true, buffer))
+	restored, err := ca.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, ca.Serialize(nil, false, buffer))
+}
+
+func TestCodeAgePyramidMergeResults(t *testing.T) {
+	ca := &CodeAgePyramidAnalysis{}
+	r1 := CodeAgePyramidResult{
+		Tick: 10, Buckets: CodeAgeBucketNames,
+		Subsystems: map[string][]int64{"src": {1, 2, 3, 4}},
+		Totals:     []int64{1, 2, 3, 4},
+	}
+	r2 := CodeAgePyramidResult{
+		Tick: 20, Buckets: CodeAgeBucketNames,
+		Subsystems: map[string][]int64{"src": {1, 1, 1, 1}, "lib": {2, 0, 0, 0}},
+		Totals:     []int64{3, 1, 1, 1},
+	}
+	merged := ca.MergeResults(r1, r2, nil, nil).(CodeAgePyramidResult)
+	assert.Equal(t, 20, merged.Tick)
+	assert.Equal(t, map[string][]int64{"src": {2, 3, 4, 5}, "lib": {2, 0, 0, 0}}, merged.Subsystems)
+	assert.Equal(t, []int64{4, 3, 4, 5}, merged.Totals)
+	assert.Equal(t, []int64{1, 2, 3, 4}, r1.Totals)
+}
+
+func TestCodeAgePyramidAnonymizePaths(t *testing.T) {
+	result := CodeAgePyramidResult{
+		Tick: 10, Buckets: CodeAgeBucketNames,
+		Subsystems: map[string][]int64{"src": {1, 2, 3, 4}},
+		Totals:     []int64{1, 2, 3, 4},
+	}
+	anonymized := (&CodeAgePyramidAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(CodeAgePyramidResult)
+	assert.Equal(t, map[string][]int64{"x/src": {1, 2, 3, 4}}, anonymized.Subsystems)
+	assert.Equal(t, result.Totals, anonymized.Totals)
+	assert.Contains(t, result.Subsystems, "src")
+}
@@ -4,14 +4,27 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/join"
 	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigCodeChurnSelfChurnDays is the name of the option to set CodeChurnAnalysis.SelfChurnDays.
+	ConfigCodeChurnSelfChurnDays = "CodeChurn.SelfChurnDays"
+	// DefaultCodeChurnSelfChurnDays is the default value of ConfigCodeChurnSelfChurnDays.
+	DefaultCodeChurnSelfChurnDays = 21
 )
 
 // CodeChurnAnalysis allows to gather the code churn statistics for a Git repository.
@@ -30,6 +43,14 @@ type CodeChurnAnalysis struct {
 	// It does not change the project level burndown results.
 	TrackFiles bool
 
+	// SelfChurnDays is the age of the own lines in days under which deleting them counts
+	// as self-churn rather than as the rework of the old own code.
+	SelfChurnDays int
+
+	// ClassifyLines enables the series of the comment and the blank lines of each developer,
+	// see items.ConfigLinesStatsClassifyLines.
+	ClassifyLines bool
+
 	// Repository points to the analysed Git repository struct from go-git.
 	repository *git.Repository
 
@@ -39,6 +60,16 @@ type CodeChurnAnalysis struct {
 	// code churns indexed by people
 	codeChurns  []personChurnStats
 	churnDeltas map[churnDeltaKey]churnDelta
+	// series maps people to their sampled line series
+	series map[int]*CodeChurnSeries
+	// samples is the number of the recorded samples
+	samples int
+	// lastTick is the latest tick of the line changes
+	lastTick int
+	// selfChurnTicks is SelfChurnDays converted to ticks.
+	selfChurnTicks int
+	// rework maps people to ticks to the lines they deleted
+	rework map[int]map[int]*CodeChurnRework
 
 	peopleResolver core.IdentityResolver
 	fileResolver   core.FileIdResolver
@@ -54,6 +85,79 @@ type churnDeletedFileEntry struct {
 
 type personChurnStats struct {
 	files map[core.FileId]churnFileEntry
+
+	inserted        int64
+	deletedBySelf   int64
+	deletedByOthers int64
+
+	// comments and blanks are updated only if ClassifyLines
+	comments churnKindStats
+	blanks   churnKindStats
+}
+
+// churnKindStats counts the lines of one kind of a developer.
+type churnKindStats struct {
+	inserted        int64
+	owned           int64
+	deletedBySelf   int64
+	deletedByOthers int64
+}
+
+// CodeChurnSeries contains the line series of one developer. The i-th element of each series
+// is the state at the end of the ticks [i*Sampling, (i+1)*Sampling).
+type CodeChurnSeries struct {
+	// Inserted is the cumulative number of the lines inserted by the developer.
+	Inserted []int64
+	// Owned is the number of the developer's lines which are alive.
+	Owned []int64
+	// DeletedBySelf is the cumulative number of the developer's lines deleted by themselves.
+	DeletedBySelf []int64
+	// DeletedByOthers is the cumulative number of the developer's lines deleted by the others.
+	DeletedByOthers []int64
+	// Awareness is the number of the owned lines the developer is estimated to remember.
+	Awareness []float32
+	// Memorability is the mean memorability of the developer's files weighted by the owned lines,
+	// from 0 to 1.
+	Memorability []float32
+	// Comments and Blanks are the comment and the blank lines among the above.
+	// They are nil unless the lines are classified.
+	Comments *CodeChurnKindSeries
+	Blanks   *CodeChurnKindSeries
+}
+
+// CodeChurnKindSeries contains the line series of one kind of lines of one developer,
+// see CodeChurnSeries.
+type CodeChurnKindSeries struct {
+	Inserted        []int64
+	Owned           []int64
+	DeletedBySelf   []int64
+	DeletedByOthers []int64
+}
+
+// CodeChurnRework splits the lines which a developer deleted by whose and how old they were.
+type CodeChurnRework struct {
+	// SelfChurn is the number of the own lines younger than SelfChurnDays.
+	SelfChurn int64
+	// OldSelfChurn is the number of the older own lines.
+	OldSelfChurn int64
+	// Disruptive is the number of the lines written by the others.
+	Disruptive int64
+}
+
+// CodeChurnResult is returned by CodeChurnAnalysis.Finalize() and carries the per-developer
+// line series.
+type CodeChurnResult struct {
+	// People maps developer indexes to their series. All the series have the same length.
+	People map[int]*CodeChurnSeries
+	// Sampling is the number of ticks between two consecutive samples.
+	Sampling int
+	// Rework maps developer indexes to ticks to the lines they deleted in those ticks.
+	Rework map[int]map[int]*CodeChurnRework
+	// SelfChurnDays is the self-churn window which was used.
+	SelfChurnDays int
+
+	reversedPeopleDict []string
+	tickSize           time.Duration
 }
 
 func (p *personChurnStats) getFileEntry(id core.FileId) (entry churnFileEntry) {
@@ -90,7 +194,15 @@ func (analyser *CodeChurnAnalysis) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (analyser *CodeChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return BurndownSharedOptions[:]
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigCodeChurnSelfChurnDays,
+		Description: "Age of the own lines in days under which deleting them is self-churn " +
+			"rather than the rework of the old code.",
+		Flag:    "codechurn-self-churn-days",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultCodeChurnSelfChurnDays,
+	}}
+	return append(BurndownSharedOptions[:], options[:]...)
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -113,6 +225,15 @@ func (analyser *CodeChurnAnalysis) Configure(facts map[string]interface{}) error
 	if val, exists := facts[ConfigBurndownTrackFiles].(bool); exists {
 		analyser.TrackFiles = val
 	}
+	if val, exists := facts[items.ConfigLinesStatsClassifyLines].(bool); exists {
+		analyser.ClassifyLines = val
+	}
+	if val, exists := facts[ConfigCodeChurnSelfChurnDays].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--codechurn-self-churn-days must not be negative, got %d", val)
+		}
+		analyser.SelfChurnDays = val
+	}
 	if val, ok := facts[core.FactIdentityResolver].(core.IdentityResolver); ok {
 		analyser.peopleResolver = val
 	}
@@ -129,11 +250,16 @@ func (analyser *CodeChurnAnalysis) Flag() string {
 	return "codechurn"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (analyser *CodeChurnAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (analyser *CodeChurnAnalysis) Description() string {
-	// TODO description
-	return "Line burndown stats indicate the numbers of lines which were last edited within " +
-		"specific time intervals through time. Search for \"git-of-theseus\" in the internet."
+	return "Per-developer code churn: the inserted, owned and deleted lines through time together " +
+		"with the estimated awareness and memorability of the own code, and the deleted lines per tick " +
+		"split into self-churn, the rework of the old own code and the disruptive churn of the others' code."
 }
 
 // Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
@@ -155,6 +281,16 @@ func (analyser *CodeChurnAnalysis) Initialize(repository *git.Repository) error
 			analyser.Granularity)
 		analyser.Sampling = analyser.Granularity
 	}
+	if analyser.SelfChurnDays < 0 {
+		return fmt.Errorf("--codechurn-self-churn-days must not be negative, got %d",
+			analyser.SelfChurnDays)
+	}
+	tickSize := analyser.tickSize
+	if tickSize == 0 {
+		tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	analyser.selfChurnTicks = int(math.Ceil(
+		float64(time.Duration(analyser.SelfChurnDays)*24*time.Hour) / float64(tickSize)))
 	analyser.repository = repository
 
 	if analyser.peopleResolver == nil {
@@ -164,6 +300,10 @@ func (analyser *CodeChurnAnalysis) Initialize(repository *git.Repository) error
 
 	analyser.codeChurns = make([]personChurnStats, peopleCount)
 	analyser.churnDeltas = map[churnDeltaKey]churnDelta{}
+	analyser.series = map[int]*CodeChurnSeries{}
+	analyser.samples = 0
+	analyser.lastTick = 0
+	analyser.rework = map[int]map[int]*CodeChurnRework{}
 
 	return nil
 }
@@ -186,6 +326,7 @@ func (analyser *CodeChurnAnalysis) Consume(deps map[string]interface{}) (map[str
 		if change.IsDelete() {
 			continue
 		}
+		analyser.sampleUntil(int(change.CurrTick))
 		if int(change.PrevAuthor) >= peopleCount && change.PrevAuthor != core.AuthorMissing {
 			change.PrevAuthor = core.AuthorMissing
 		}
@@ -271,12 +412,20 @@ func (analyser *CodeChurnAnalysis) updateAuthor(change core.LineHistoryChange) {
 
 	analyser.updateAwareness(change, &fileEntry)
 
+	person := &analyser.codeChurns[change.PrevAuthor]
 	lineDelta := int32(change.Delta)
 	fileEntry.ownedLines += lineDelta
 	if change.Delta > 0 {
 		// PrevAuthor == CurrAuthor
 		fileEntry.insertedLines += lineDelta
+		person.inserted += int64(change.Delta)
 	} else {
+		if change.CurrAuthor == change.PrevAuthor {
+			person.deletedBySelf -= int64(change.Delta)
+		} else {
+			person.deletedByOthers -= int64(change.Delta)
+		}
+		analyser.updateRework(change)
 		history := fileEntry.deleteHistory[change.CurrAuthor]
 		if history == nil {
 			history = sparseHistory{}
@@ -284,47 +433,546 @@ func (analyser *CodeChurnAnalysis) updateAuthor(change core.LineHistoryChange) {
 		}
 		history.updateDelta(int(change.PrevTick), int(change.CurrTick), change.Delta)
 	}
+	if analyser.ClassifyLines {
+		person.updateKind(change)
+	}
 
 	analyser.codeChurns[change.PrevAuthor].files[change.FileId] = fileEntry
 }
 
-// Finalize returns the result of the analysis. Further calls to Consume() are not expected.
-func (analyser *CodeChurnAnalysis) Finalize() interface{} {
-	fmt.Fprintln(os.Stderr)
-	for pId, person := range analyser.codeChurns {
-		inserted := int32(0)
-		deletedBySelf := int32(0)
-		deletedByOthers := int32(0)
+// updateKind counts the comment and the blank lines of the change, see updateAuthor().
+func (p *personChurnStats) updateKind(change core.LineHistoryChange) {
+	var stats *churnKindStats
+	switch change.Kind {
+	case core.LineKindComment:
+		stats = &p.comments
+	case core.LineKindBlank:
+		stats = &p.blanks
+	default:
+		return
+	}
+	stats.owned += int64(change.Delta)
+	if change.Delta > 0 {
+		stats.inserted += int64(change.Delta)
+	} else if change.CurrAuthor == change.PrevAuthor {
+		stats.deletedBySelf -= int64(change.Delta)
+	} else {
+		stats.deletedByOthers -= int64(change.Delta)
+	}
+}
 
+// updateRework attributes the deleted lines to the developer who deleted them.
+func (analyser *CodeChurnAnalysis) updateRework(change core.LineHistoryChange) {
+	if change.CurrAuthor == core.AuthorMissing {
+		return
+	}
+	ticks := analyser.rework[int(change.CurrAuthor)]
+	if ticks == nil {
+		ticks = map[int]*CodeChurnRework{}
+		analyser.rework[int(change.CurrAuthor)] = ticks
+	}
+	rework := ticks[int(change.CurrTick)]
+	if rework == nil {
+		rework = &CodeChurnRework{}
+		ticks[int(change.CurrTick)] = rework
+	}
+	lines := -int64(change.Delta)
+	if change.CurrAuthor != change.PrevAuthor {
+		rework.Disruptive += lines
+	} else if int(change.CurrTick-change.PrevTick) < analyser.selfChurnTicks {
+		rework.SelfChurn += lines
+	} else {
+		rework.OldSelfChurn += lines
+	}
+}
+
+// sampleUntil records the samples of all the periods which end before the tick.
+func (analyser *CodeChurnAnalysis) sampleUntil(tick int) {
+	for (analyser.samples+1)*analyser.Sampling <= tick {
+		analyser.takeSample()
+	}
+	if tick > analyser.lastTick {
+		analyser.lastTick = tick
+	}
+}
+
+// takeSample appends the current state of each developer to their series.
+func (analyser *CodeChurnAnalysis) takeSample() {
+	for pId := range analyser.codeChurns {
+		person := &analyser.codeChurns[pId]
+		series := analyser.series[pId]
+		if series == nil {
+			if person.inserted == 0 && person.deletedBySelf == 0 && person.deletedByOthers == 0 {
+				continue
+			}
+			series = &CodeChurnSeries{}
+			if analyser.ClassifyLines {
+				series.Comments = &CodeChurnKindSeries{}
+				series.Blanks = &CodeChurnKindSeries{}
+			}
+			series.extend(analyser.samples)
+			analyser.series[pId] = series
+		}
+		owned, awareness, memorability := int64(0), 0.0, 0.0
 		for _, entry := range person.files {
-			inserted += entry.insertedLines
+			owned += int64(entry.ownedLines)
+			awareness += float64(entry.awareness)
+			if entry.ownedLines > 0 {
+				memorability += float64(entry.memorability) * float64(entry.ownedLines)
+			}
+		}
+		if owned > 0 {
+			memorability /= float64(owned)
+		} else {
+			memorability = 0
+		}
+		series.Inserted = append(series.Inserted, person.inserted)
+		series.Owned = append(series.Owned, owned)
+		series.DeletedBySelf = append(series.DeletedBySelf, person.deletedBySelf)
+		series.DeletedByOthers = append(series.DeletedByOthers, person.deletedByOthers)
+		series.Awareness = append(series.Awareness, float32(awareness))
+		series.Memorability = append(series.Memorability, float32(memorability))
+		if series.Comments != nil {
+			series.Comments.add(person.comments)
+			series.Blanks.add(person.blanks)
 		}
+	}
+	analyser.samples++
+}
 
-		name := analyser.peopleResolver.FriendlyNameOf(core.AuthorId(pId))
-		fmt.Fprintf(os.Stderr, "%s (%d):\t\t%d\t%d\t%d = %d\n", name, pId, inserted, deletedBySelf, deletedByOthers,
-			inserted+deletedBySelf+deletedByOthers)
+// add appends the sample of the lines of one kind to the series.
+func (series *CodeChurnKindSeries) add(stats churnKindStats) {
+	series.Inserted = append(series.Inserted, stats.inserted)
+	series.Owned = append(series.Owned, stats.owned)
+	series.DeletedBySelf = append(series.DeletedBySelf, stats.deletedBySelf)
+	series.DeletedByOthers = append(series.DeletedByOthers, stats.deletedByOthers)
+}
+
+// extend pads the series with zeros to the specified length.
+func (series *CodeChurnSeries) extend(length int) {
+	for len(series.Inserted) < length {
+		series.Inserted = append(series.Inserted, 0)
+		series.Owned = append(series.Owned, 0)
+		series.DeletedBySelf = append(series.DeletedBySelf, 0)
+		series.DeletedByOthers = append(series.DeletedByOthers, 0)
+		series.Awareness = append(series.Awareness, 0)
+		series.Memorability = append(series.Memorability, 0)
+	}
+	if series.Comments != nil {
+		series.Comments.extend(length)
+		series.Blanks.extend(length)
 	}
-	fmt.Fprintln(os.Stderr)
+}
 
-	return nil
+// extend pads the series with zeros to the specified length.
+func (series *CodeChurnKindSeries) extend(length int) {
+	for len(series.Inserted) < length {
+		series.add(churnKindStats{})
+	}
+}
+
+// Finalize returns the result of the analysis. Further calls to Consume() are not expected.
+func (analyser *CodeChurnAnalysis) Finalize() interface{} {
+	if analyser.hasChurn() {
+		for analyser.samples <= analyser.lastTick/analyser.Sampling {
+			analyser.takeSample()
+		}
+	}
+	return CodeChurnResult{
+		People:             analyser.series,
+		Sampling:           analyser.Sampling,
+		Rework:             analyser.rework,
+		SelfChurnDays:      analyser.SelfChurnDays,
+		reversedPeopleDict: analyser.peopleResolver.CopyNames(false),
+		tickSize:           analyser.tickSize,
+	}
+}
+
+// hasChurn returns true if any developer has inserted or deleted lines.
+func (analyser *CodeChurnAnalysis) hasChurn() bool {
+	for _, person := range analyser.codeChurns {
+		if person.inserted != 0 || person.deletedBySelf != 0 || person.deletedByOthers != 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (analyser *CodeChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult, ok := result.(CodeChurnResult)
+	if !ok {
+		return fmt.Errorf("result is not a code churn result: '%v'", result)
+	}
+	if binary {
+		return analyser.serializeBinary(&churnResult, writer)
+	}
+	analyser.serializeText(&churnResult, writer)
 	return nil
 }
 
-// Deserialize converts the specified protobuf bytes to BurndownResult.
-func (analyser *CodeChurnAnalysis) Deserialize(message []byte) (interface{}, error) {
-	return nil, nil
+func (analyser *CodeChurnAnalysis) serializeText(result *CodeChurnResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  code_churn:")
+	fmt.Fprintf(writer, "    sampling: %d\n", result.Sampling)
+	fmt.Fprintln(writer, "    people_series:")
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	formatInts := func(values []int64) string {
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = strconv.FormatInt(value, 10)
+		}
+		return strings.Join(strs, ", ")
+	}
+	formatFloats := func(values []float32) string {
+		strs := make([]string, len(values))
+		for i, value := range values {
+			strs[i] = strconv.FormatFloat(float64(value), 'f', 4, 32)
+		}
+		return strings.Join(strs, ", ")
+	}
+	for _, dev := range devs {
+		series := result.People[dev]
+		fmt.Fprintf(writer, "      %d:\n", dev)
+		fmt.Fprintf(writer, "        inserted: [%s]\n", formatInts(series.Inserted))
+		fmt.Fprintf(writer, "        owned: [%s]\n", formatInts(series.Owned))
+		fmt.Fprintf(writer, "        deleted_by_self: [%s]\n", formatInts(series.DeletedBySelf))
+		fmt.Fprintf(writer, "        deleted_by_others: [%s]\n", formatInts(series.DeletedByOthers))
+		fmt.Fprintf(writer, "        awareness: [%s]\n", formatFloats(series.Awareness))
+		fmt.Fprintf(writer, "        memorability: [%s]\n", formatFloats(series.Memorability))
+		if series.Comments != nil {
+			for _, kind := range [...]struct {
+				name   string
+				series *CodeChurnKindSeries
+			}{{"comments", series.Comments}, {"blanks", series.Blanks}} {
+				fmt.Fprintf(writer, "        %s:\n", kind.name)
+				fmt.Fprintf(writer, "          inserted: [%s]\n", formatInts(kind.series.Inserted))
+				fmt.Fprintf(writer, "          owned: [%s]\n", formatInts(kind.series.Owned))
+				fmt.Fprintf(writer, "          deleted_by_self: [%s]\n", formatInts(kind.series.DeletedBySelf))
+				fmt.Fprintf(writer, "          deleted_by_others: [%s]\n", formatInts(kind.series.DeletedByOthers))
+			}
+		}
+	}
+	fmt.Fprintf(writer, "    self_churn_days: %d\n", result.SelfChurnDays)
+	fmt.Fprintln(writer, "    rework:")
+	devs = devs[:0]
+	for dev := range result.Rework {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d:\n", dev)
+		ticks := make([]int, 0, len(result.Rework[dev]))
+		for tick := range result.Rework[dev] {
+			ticks = append(ticks, tick)
+		}
+		sort.Ints(ticks)
+		for _, tick := range ticks {
+			rework := result.Rework[dev][tick]
+			fmt.Fprintf(writer, "        %d: [%d, %d, %d]\n",
+				tick, rework.SelfChurn, rework.OldSelfChurn, rework.Disruptive)
+		}
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (analyser *CodeChurnAnalysis) serializeBinary(result *CodeChurnResult, writer io.Writer) error {
+	message := pb.CodeChurnResults{
+		Sampling:      int32(result.Sampling),
+		People:        make(map[int32]*pb.CodeChurnSeries, len(result.People)),
+		DevIndex:      result.reversedPeopleDict,
+		TickSize:      int64(result.tickSize),
+		SelfChurnDays: int32(result.SelfChurnDays),
+		Rework:        make(map[int32]*pb.CodeChurnReworkTicks, len(result.Rework)),
+	}
+	for dev, ticks := range result.Rework {
+		pbTicks := &pb.CodeChurnReworkTicks{Ticks: make(map[int32]*pb.CodeChurnRework, len(ticks))}
+		for tick, rework := range ticks {
+			pbTicks.Ticks[int32(tick)] = &pb.CodeChurnRework{
+				SelfChurn:    rework.SelfChurn,
+				OldSelfChurn: rework.OldSelfChurn,
+				Disruptive:   rework.Disruptive,
+			}
+		}
+		message.Rework[int32(dev)] = pbTicks
+	}
+	for dev, series := range result.People {
+		pbSeries := &pb.CodeChurnSeries{
+			Inserted:        series.Inserted,
+			Owned:           series.Owned,
+			DeletedBySelf:   series.DeletedBySelf,
+			DeletedByOthers: series.DeletedByOthers,
+			Awareness:       series.Awareness,
+			Memorability:    series.Memorability,
+		}
+		if series.Comments != nil {
+			pbSeries.Comments = series.Comments.toPb()
+			pbSeries.Blanks = series.Blanks.toPb()
+		}
+		message.People[int32(dev)] = pbSeries
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// Deserialize converts the specified protobuf bytes to CodeChurnResult.
+func (analyser *CodeChurnAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CodeChurnResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	if message.Sampling <= 0 {
+		return nil, fmt.Errorf("invalid sampling %d: must be positive", message.Sampling)
+	}
+	if message.TickSize <= 0 {
+		return nil, fmt.Errorf("invalid tick size %d: must be positive", message.TickSize)
+	}
+	result := CodeChurnResult{
+		People:             make(map[int]*CodeChurnSeries, len(message.People)),
+		Sampling:           int(message.Sampling),
+		Rework:             make(map[int]map[int]*CodeChurnRework, len(message.Rework)),
+		SelfChurnDays:      int(message.SelfChurnDays),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, pbTicks := range message.Rework {
+		if pbTicks == nil {
+			return nil, fmt.Errorf("developer %d: missing rework ticks", dev)
+		}
+		ticks := make(map[int]*CodeChurnRework, len(pbTicks.Ticks))
+		for tick, rework := range pbTicks.Ticks {
+			if rework == nil {
+				return nil, fmt.Errorf("developer %d: missing rework at tick %d", dev, tick)
+			}
+			ticks[int(tick)] = &CodeChurnRework{
+				SelfChurn:    rework.SelfChurn,
+				OldSelfChurn: rework.OldSelfChurn,
+				Disruptive:   rework.Disruptive,
+			}
+		}
+		result.Rework[int(dev)] = ticks
+	}
+	for dev, series := range message.People {
+		if series == nil {
+			return nil, fmt.Errorf("developer %d: missing series", dev)
+		}
+		length := len(series.Inserted)
+		if len(series.Owned) != length || len(series.DeletedBySelf) != length ||
+			len(series.DeletedByOthers) != length || len(series.Awareness) != length ||
+			len(series.Memorability) != length {
+			return nil, fmt.Errorf("developer %d: series of different lengths "+
+				"(inserted %d, owned %d, deleted by self %d, deleted by others %d, "+
+				"awareness %d, memorability %d)", dev, length, len(series.Owned),
+				len(series.DeletedBySelf), len(series.DeletedByOthers),
+				len(series.Awareness), len(series.Memorability))
+		}
+		churnSeries := &CodeChurnSeries{
+			Inserted:        series.Inserted,
+			Owned:           series.Owned,
+			DeletedBySelf:   series.DeletedBySelf,
+			DeletedByOthers: series.DeletedByOthers,
+			Awareness:       series.Awareness,
+			Memorability:    series.Memorability,
+		}
+		if series.Comments != nil || series.Blanks != nil {
+			var err error
+			if churnSeries.Comments, err = codeChurnKindSeriesFromPb(series.Comments, length); err != nil {
+				return nil, fmt.Errorf("developer %d: comments: %v", dev, err)
+			}
+			if churnSeries.Blanks, err = codeChurnKindSeriesFromPb(series.Blanks, length); err != nil {
+				return nil, fmt.Errorf("developer %d: blanks: %v", dev, err)
+			}
+		}
+		result.People[int(dev)] = churnSeries
+	}
+	return result, nil
 }
 
-// MergeResults combines two BurndownResult-s together.
+// toPb converts the series to the Protocol Buffers message.
+func (series *CodeChurnKindSeries) toPb() *pb.CodeChurnKindSeries {
+	return &pb.CodeChurnKindSeries{
+		Inserted:        series.Inserted,
+		Owned:           series.Owned,
+		DeletedBySelf:   series.DeletedBySelf,
+		DeletedByOthers: series.DeletedByOthers,
+	}
+}
+
+// codeChurnKindSeriesFromPb converts the Protocol Buffers message to the series and checks
+// that they have the specified length.
+func codeChurnKindSeriesFromPb(series *pb.CodeChurnKindSeries, length int) (*CodeChurnKindSeries, error) {
+	if series == nil {
+		return nil, fmt.Errorf("missing series")
+	}
+	if len(series.Inserted) != length || len(series.Owned) != length ||
+		len(series.DeletedBySelf) != length || len(series.DeletedByOthers) != length {
+		return nil, fmt.Errorf("series of different lengths (inserted %d, owned %d, "+
+			"deleted by self %d, deleted by others %d), expected %d", len(series.Inserted),
+			len(series.Owned), len(series.DeletedBySelf), len(series.DeletedByOthers), length)
+	}
+	return &CodeChurnKindSeries{
+		Inserted:        series.Inserted,
+		Owned:           series.Owned,
+		DeletedBySelf:   series.DeletedBySelf,
+		DeletedByOthers: series.DeletedByOthers,
+	}, nil
+}
+
+// MergeResults combines two CodeChurnResult-s together. The series are aligned by the beginnings
+// of the histories and summed, the last values of the shorter series are carried forward.
 func (analyser *CodeChurnAnalysis) MergeResults(
 	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult,
 ) interface{} {
-	return nil
+	ccr1 := r1.(CodeChurnResult)
+	ccr2 := r2.(CodeChurnResult)
+	if ccr1.tickSize != ccr2.tickSize {
+		return fmt.Errorf("mismatching tick sizes (r1: %d, r2: %d) received",
+			ccr1.tickSize, ccr2.tickSize)
+	}
+	if ccr1.Sampling != ccr2.Sampling {
+		return fmt.Errorf("mismatching samplings (r1: %d, r2: %d) received",
+			ccr1.Sampling, ccr2.Sampling)
+	}
+	if ccr1.SelfChurnDays != ccr2.SelfChurnDays {
+		return fmt.Errorf("mismatching self-churn windows (r1: %d, r2: %d) received",
+			ccr1.SelfChurnDays, ccr2.SelfChurnDays)
+	}
+	t01 := items.FloorTime(c1.BeginTimeAsTime(), ccr1.tickSize)
+	t02 := items.FloorTime(c2.BeginTimeAsTime(), ccr2.tickSize)
+	t0 := t01
+	if t02.Before(t0) {
+		t0 = t02
+	}
+	samplingDuration := ccr1.tickSize * time.Duration(ccr1.Sampling)
+	offset1 := int(t01.Sub(t0) / samplingDuration)
+	offset2 := int(t02.Sub(t0) / samplingDuration)
+	length := 0
+	for _, side := range []struct {
+		people map[int]*CodeChurnSeries
+		offset int
+	}{{ccr1.People, offset1}, {ccr2.People, offset2}} {
+		for _, series := range side.people {
+			if side.offset+len(series.Inserted) > length {
+				length = side.offset + len(series.Inserted)
+			}
+		}
+	}
+
+	merged := CodeChurnResult{
+		People:        map[int]*CodeChurnSeries{},
+		Sampling:      ccr1.Sampling,
+		Rework:        map[int]map[int]*CodeChurnRework{},
+		SelfChurnDays: ccr1.SelfChurnDays,
+		tickSize:      ccr1.tickSize,
+	}
+	tickOffset1 := int(t01.Sub(t0) / ccr1.tickSize)
+	tickOffset2 := int(t02.Sub(t0) / ccr2.tickSize)
+	var mergedIndex map[string]join.JoinedIndex
+	mergedIndex, merged.reversedPeopleDict = join.PeopleIdentities(
+		ccr1.reversedPeopleDict, ccr2.reversedPeopleDict)
+	for i, name := range merged.reversedPeopleDict {
+		ptrs := mergedIndex[name]
+		var s1, s2 *CodeChurnSeries
+		if ptrs.First >= 0 {
+			s1 = ccr1.People[ptrs.First]
+		}
+		if ptrs.Second >= 0 {
+			s2 = ccr2.People[ptrs.Second]
+		}
+		for _, side := range []struct {
+			rework map[int]*CodeChurnRework
+			offset int
+		}{{ccr1.Rework[ptrs.First], tickOffset1}, {ccr2.Rework[ptrs.Second], tickOffset2}} {
+			if len(side.rework) == 0 {
+				continue
+			}
+			ticks := merged.Rework[i]
+			if ticks == nil {
+				ticks = map[int]*CodeChurnRework{}
+				merged.Rework[i] = ticks
+			}
+			for tick, rework := range side.rework {
+				sum := ticks[tick+side.offset]
+				if sum == nil {
+					sum = &CodeChurnRework{}
+					ticks[tick+side.offset] = sum
+				}
+				sum.SelfChurn += rework.SelfChurn
+				sum.OldSelfChurn += rework.OldSelfChurn
+				sum.Disruptive += rework.Disruptive
+			}
+		}
+		if s1 == nil && s2 == nil {
+			continue
+		}
+		merged.People[i] = mergeCodeChurnSeries(s1, s2, offset1, offset2, length)
+	}
+	return merged
+}
+
+// mergeCodeChurnSeries sums two series which start at the specified offsets. The memorability
+// is averaged with the owned lines as the weights.
+func mergeCodeChurnSeries(s1, s2 *CodeChurnSeries, offset1, offset2, length int) *CodeChurnSeries {
+	merged := &CodeChurnSeries{}
+	if (s1 != nil && s1.Comments != nil) || (s2 != nil && s2.Comments != nil) {
+		merged.Comments = &CodeChurnKindSeries{}
+		merged.Blanks = &CodeChurnKindSeries{}
+	}
+	merged.extend(length)
+	for _, side := range []struct {
+		series *CodeChurnSeries
+		offset int
+	}{{s1, offset1}, {s2, offset2}} {
+		if side.series == nil || len(side.series.Inserted) == 0 {
+			continue
+		}
+		last := len(side.series.Inserted) - 1
+		for i := side.offset; i < length; i++ {
+			j := i - side.offset
+			if j > last {
+				j = last
+			}
+			merged.Inserted[i] += side.series.Inserted[j]
+			merged.Owned[i] += side.series.Owned[j]
+			merged.DeletedBySelf[i] += side.series.DeletedBySelf[j]
+			merged.DeletedByOthers[i] += side.series.DeletedByOthers[j]
+			merged.Awareness[i] += side.series.Awareness[j]
+			// the weighted sum, divided below
+			merged.Memorability[i] += side.series.Memorability[j] * float32(side.series.Owned[j])
+			if side.series.Comments != nil {
+				merged.Comments.addAt(i, side.series.Comments, j)
+				merged.Blanks.addAt(i, side.series.Blanks, j)
+			}
+		}
+	}
+	for i, owned := range merged.Owned {
+		if owned > 0 {
+			merged.Memorability[i] /= float32(owned)
+		} else {
+			merged.Memorability[i] = 0
+		}
+	}
+	return merged
+}
+
+// addAt adds the j-th sample of the other series to the i-th sample.
+func (series *CodeChurnKindSeries) addAt(i int, other *CodeChurnKindSeries, j int) {
+	series.Inserted[i] += other.Inserted[j]
+	series.Owned[i] += other.Owned[j]
+	series.DeletedBySelf[i] += other.DeletedBySelf[j]
+	series.DeletedByOthers[i] += other.DeletedByOthers[j]
 }
 
 func (analyser *CodeChurnAnalysis) memoryLoss(x float64) float64 {
@@ -1,6 +1,8 @@
 package leaves
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
@@ -40,7 +42,9 @@ func TestCodeChurnRegistration(t *testing.T) {
 func TestCodeChurnListConfigurationOptions(t *testing.T) {
 	cc := CodeChurnAnalysis{}
 	opts := cc.ListConfigurationOptions()
-	assert.Equal(t, len(BurndownSharedOptions), len(opts))
+	assert.Equal(t, len(BurndownSharedOptions)+1, len(opts))
+	assert.Equal(t, ConfigCodeChurnSelfChurnDays, opts[len(opts)-1].Name)
+	assert.Equal(t, DefaultCodeChurnSelfChurnDays, opts[len(opts)-1].Default)
 }
 
 func TestCodeChurnConfigure(t *testing.T) {
@@ -50,6 +54,7 @@ func TestCodeChurnConfigure(t *testing.T) {
 	facts[ConfigBurndownGranularity] = 15
 	facts[ConfigBurndownSampling] = 10
 	facts[ConfigBurndownTrackFiles] = true
+	facts[ConfigCodeChurnSelfChurnDays] = 14
 	logger := core.NewLogger()
 	facts[core.ConfigLogger] = logger
 
@@ -61,8 +66,23 @@ func TestCodeChurnConfigure(t *testing.T) {
 	assert.Equal(t, 15, cc.Granularity)
 	assert.Equal(t, 10, cc.Sampling)
 	assert.True(t, cc.TrackFiles)
+	assert.Equal(t, 14, cc.SelfChurnDays)
 	assert.Equal(t, logger, cc.l)
 	assert.Equal(t, resolver, cc.peopleResolver)
+
+	facts[ConfigCodeChurnSelfChurnDays] = -1
+	assert.Error(t, cc.Configure(facts))
+}
+
+func TestCodeChurnInitializeSelfChurnDays(t *testing.T) {
+	cc := CodeChurnAnalysis{SelfChurnDays: 3, tickSize: 12 * time.Hour}
+	assert.Nil(t, cc.Initialize(test.Repository))
+	assert.Equal(t, 6, cc.selfChurnTicks)
+	cc = CodeChurnAnalysis{SelfChurnDays: 3}
+	assert.Nil(t, cc.Initialize(test.Repository))
+	assert.Equal(t, 3, cc.selfChurnTicks)
+	cc.SelfChurnDays = -1
+	assert.Error(t, cc.Initialize(test.Repository))
 }
 
 func TestCodeChurnConfigureDefaults(t *testing.T) {
@@ -415,39 +435,356 @@ func TestCodeChurnConsumeMultipleFiles(t *testing.T) {
 }
 
 func TestCodeChurnFinalize(t *testing.T) {
-	cc := CodeChurnAnalysis{}
+	cc := CodeChurnAnalysis{Sampling: 2, SelfChurnDays: 7, tickSize: 24 * time.Hour}
 	cc.peopleResolver = core.NewIdentityResolver([]string{"Alice", "Bob"}, nil)
 	assert.Nil(t, cc.Initialize(test.Repository))
 
-	// Populate some data
-	cc.codeChurns[0].files = map[core.FileId]churnFileEntry{
-		0: {insertedLines: 50, ownedLines: 40},
+	consume := func(changes ...core.LineHistoryChange) {
+		_, err := cc.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: changes},
+		})
+		assert.Nil(t, err)
+	}
+	consume(core.LineHistoryChange{FileId: 0, CurrTick: 1, PrevTick: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: 20})
+	consume(
+		core.LineHistoryChange{FileId: 0, CurrTick: 2, PrevTick: 2, CurrAuthor: 1, PrevAuthor: 1, Delta: 15},
+		core.LineHistoryChange{FileId: 0, CurrTick: 2, PrevTick: 1, CurrAuthor: 1, PrevAuthor: 0, Delta: -5},
+	)
+	consume(core.LineHistoryChange{FileId: 0, CurrTick: 5, PrevTick: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: -3})
+
+	result := cc.Finalize().(CodeChurnResult)
+	assert.Equal(t, 2, result.Sampling)
+	assert.Equal(t, []string{"Alice", "Bob"}, result.reversedPeopleDict)
+	assert.Equal(t, 24*time.Hour, result.tickSize)
+	assert.Len(t, result.People, 2)
+	alice, bob := result.People[0], result.People[1]
+	assert.Equal(t, []int64{20, 20, 20}, alice.Inserted)
+	assert.Equal(t, []int64{20, 15, 12}, alice.Owned)
+	assert.Equal(t, []int64{0, 0, 3}, alice.DeletedBySelf)
+	assert.Equal(t, []int64{0, 5, 5}, alice.DeletedByOthers)
+	// Bob appears in the second sample and is padded with zeros
+	assert.Equal(t, []int64{0, 15, 15}, bob.Inserted)
+	assert.Equal(t, []int64{0, 15, 15}, bob.Owned)
+	assert.Equal(t, []int64{0, 0, 0}, bob.DeletedBySelf)
+	assert.Equal(t, []int64{0, 0, 0}, bob.DeletedByOthers)
+	for _, series := range result.People {
+		assert.Len(t, series.Awareness, 3)
+		assert.Len(t, series.Memorability, 3)
+		for _, value := range series.Memorability {
+			assert.True(t, value >= 0 && value <= 1)
+		}
 	}
-	cc.codeChurns[1].files = map[core.FileId]churnFileEntry{
-		0: {insertedLines: 30, ownedLines: 25},
+	assert.Equal(t, float32(0), bob.Memorability[0])
+	assert.Equal(t, 7, result.SelfChurnDays)
+	assert.Equal(t, map[int]map[int]*CodeChurnRework{
+		0: {5: {SelfChurn: 3}},
+		1: {2: {Disruptive: 5}},
+	}, result.Rework)
+}
+
+func TestCodeChurnRework(t *testing.T) {
+	cc := CodeChurnAnalysis{SelfChurnDays: 3}
+	cc.peopleResolver = core.NewIdentityResolver([]string{"Alice", "Bob"}, nil)
+	assert.Nil(t, cc.Initialize(test.Repository))
+	_, err := cc.Consume(map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: []core.LineHistoryChange{
+			{FileId: 0, CurrTick: 0, PrevTick: 0, CurrAuthor: 0, PrevAuthor: 0, Delta: 30},
+			{FileId: 0, CurrTick: 0, PrevTick: 0, CurrAuthor: 1, PrevAuthor: 1, Delta: 30},
+		}},
+	})
+	assert.Nil(t, err)
+	_, err = cc.Consume(map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: []core.LineHistoryChange{
+			// within the window
+			{FileId: 0, CurrTick: 2, PrevTick: 0, CurrAuthor: 0, PrevAuthor: 0, Delta: -4},
+			// a missing author is not attributed
+			{FileId: 0, CurrTick: 2, PrevTick: 0, CurrAuthor: core.AuthorMissing, PrevAuthor: 0, Delta: -1},
+			// insertions are not rework
+			{FileId: 0, CurrTick: 2, PrevTick: 2, CurrAuthor: 0, PrevAuthor: 0, Delta: 5},
+		}},
+	})
+	assert.Nil(t, err)
+	_, err = cc.Consume(map[string]interface{}{
+		linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: []core.LineHistoryChange{
+			// exactly at the window
+			{FileId: 0, CurrTick: 3, PrevTick: 0, CurrAuthor: 0, PrevAuthor: 0, Delta: -2},
+			{FileId: 0, CurrTick: 3, PrevTick: 0, CurrAuthor: 0, PrevAuthor: 1, Delta: -6},
+		}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]map[int]*CodeChurnRework{
+		0: {
+			2: {SelfChurn: 4},
+			3: {OldSelfChurn: 2, Disruptive: 6},
+		},
+	}, cc.rework)
+}
+
+func TestCodeChurnFinalizeEmpty(t *testing.T) {
+	cc := CodeChurnAnalysis{}
+	cc.peopleResolver = core.NewIdentityResolver([]string{"Alice"}, nil)
+	assert.Nil(t, cc.Initialize(test.Repository))
+	result := cc.Finalize().(CodeChurnResult)
+	assert.Empty(t, result.People)
+}
+
+func TestCodeChurnClassifyLines(t *testing.T) {
+	cc := CodeChurnAnalysis{Sampling: 2, tickSize: 24 * time.Hour}
+	assert.Nil(t, cc.Configure(map[string]interface{}{items.ConfigLinesStatsClassifyLines: true}))
+	assert.True(t, cc.ClassifyLines)
+	cc.peopleResolver = core.NewIdentityResolver([]string{"Alice", "Bob"}, nil)
+	assert.Nil(t, cc.Initialize(test.Repository))
+
+	consume := func(changes ...core.LineHistoryChange) {
+		_, err := cc.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: changes},
+		})
+		assert.Nil(t, err)
 	}
+	consume(
+		core.LineHistoryChange{FileId: 0, CurrTick: 1, PrevTick: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: 16},
+		core.LineHistoryChange{FileId: 0, CurrTick: 1, PrevTick: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: 4,
+			Kind: core.LineKindComment},
+	)
+	consume(
+		core.LineHistoryChange{FileId: 0, CurrTick: 2, PrevTick: 2, CurrAuthor: 1, PrevAuthor: 1, Delta: 13},
+		core.LineHistoryChange{FileId: 0, CurrTick: 2, PrevTick: 2, CurrAuthor: 1, PrevAuthor: 1, Delta: 2,
+			Kind: core.LineKindBlank},
+		core.LineHistoryChange{FileId: 0, CurrTick: 2, PrevTick: 1, CurrAuthor: 1, PrevAuthor: 0, Delta: -1,
+			Kind: core.LineKindComment},
+	)
+	consume(core.LineHistoryChange{FileId: 0, CurrTick: 5, PrevTick: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: -2,
+		Kind: core.LineKindComment})
+
+	result := cc.Finalize().(CodeChurnResult)
+	alice, bob := result.People[0], result.People[1]
+	assert.Equal(t, []int64{20, 19, 17}, alice.Owned)
+	assert.Equal(t, &CodeChurnKindSeries{
+		Inserted:        []int64{4, 4, 4},
+		Owned:           []int64{4, 3, 1},
+		DeletedBySelf:   []int64{0, 0, 2},
+		DeletedByOthers: []int64{0, 1, 1},
+	}, alice.Comments)
+	assert.Equal(t, &CodeChurnKindSeries{
+		Inserted:        []int64{0, 0, 0},
+		Owned:           []int64{0, 0, 0},
+		DeletedBySelf:   []int64{0, 0, 0},
+		DeletedByOthers: []int64{0, 0, 0},
+	}, alice.Blanks)
+	// Bob appears in the second sample and is padded with zeros
+	assert.Equal(t, []int64{0, 0, 0}, bob.Comments.Inserted)
+	assert.Equal(t, []int64{0, 2, 2}, bob.Blanks.Inserted)
+	assert.Equal(t, []int64{0, 2, 2}, bob.Blanks.Owned)
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cc.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `      0:
+        inserted: [20, 20, 20]
+        owned: [20, 19, 17]
+        deleted_by_self: [0, 0, 2]
+        deleted_by_others: [0, 1, 1]
+        awareness: [0.0000, 20.0000, 20.0000]
+        memorability: [0.0000, 0.0000, 0.0000]
+        comments:
+          inserted: [4, 4, 4]
+          owned: [4, 3, 1]
+          deleted_by_self: [0, 0, 2]
+          deleted_by_others: [0, 1, 1]
+        blanks:
+          inserted: [0, 0, 0]
+`)
+	buffer.Reset()
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	restored, err := cc.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result, restored)
+
+	c1 := core.CommonAnalysisResult{BeginTime: 1556224895}
+	merged := cc.MergeResults(result, restored, &c1, &c1).(CodeChurnResult)
+	assert.Equal(t, []int64{8, 6, 2}, merged.People[0].Comments.Owned)
+	assert.Equal(t, []int64{0, 4, 4}, merged.People[1].Blanks.Inserted)
+	// the result without the classification
+	unclassified := fixtureCodeChurnResult()
+	unclassified.SelfChurnDays = result.SelfChurnDays
+	merged = cc.MergeResults(result, unclassified, &c1, &c1).(CodeChurnResult)
+	assert.Equal(t, []int64{4, 3, 1}, merged.People[0].Comments.Owned)
+	merged = cc.MergeResults(unclassified, unclassified, &c1, &c1).(CodeChurnResult)
+	assert.Nil(t, merged.People[0].Comments)
+
+	// the series of different lengths would crash MergeResults()
+	alice.Blanks.Owned = alice.Blanks.Owned[:1]
+	buffer.Reset()
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	_, err = cc.Deserialize(buffer.Bytes())
+	assert.Error(t, err)
+}
 
-	result := cc.Finalize()
-	assert.Nil(t, result) // Finalize returns nil currently
+func fixtureCodeChurnResult() CodeChurnResult {
+	return CodeChurnResult{
+		People: map[int]*CodeChurnSeries{
+			0: {
+				Inserted:        []int64{20, 20},
+				Owned:           []int64{20, 15},
+				DeletedBySelf:   []int64{0, 0},
+				DeletedByOthers: []int64{0, 5},
+				Awareness:       []float32{20, 12.5},
+				Memorability:    []float32{1, 0.5},
+			},
+			1: {
+				Inserted:        []int64{0, 15},
+				Owned:           []int64{0, 15},
+				DeletedBySelf:   []int64{0, 0},
+				DeletedByOthers: []int64{0, 0},
+				Awareness:       []float32{0, 15},
+				Memorability:    []float32{0, 1},
+			},
+		},
+		Sampling: 2,
+		Rework: map[int]map[int]*CodeChurnRework{
+			0: {3: {SelfChurn: 1, OldSelfChurn: 2, Disruptive: 0}},
+			1: {2: {Disruptive: 5}, 3: {SelfChurn: 4}},
+		},
+		SelfChurnDays:      21,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
 }
 
 func TestCodeChurnSerialize(t *testing.T) {
 	cc := CodeChurnAnalysis{}
-	assert.Nil(t, cc.Serialize(nil, false, nil))
-	assert.Nil(t, cc.Serialize(nil, true, nil))
+	result := fixtureCodeChurnResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cc.Serialize(result, false, buffer))
+	assert.Equal(t, `  code_churn:
+    sampling: 2
+    people_series:
+      0:
+        inserted: [20, 20]
+        owned: [20, 15]
+        deleted_by_self: [0, 0]
+        deleted_by_others: [0, 5]
+        awareness: [20.0000, 12.5000]
+        memorability: [1.0000, 0.5000]
+      1:
+        inserted: [0, 15]
+        owned: [0, 15]
+        deleted_by_self: [0, 0]
+        deleted_by_others: [0, 0]
+        awareness: [0.0000, 15.0000]
+        memorability: [0.0000, 1.0000]
+    self_churn_days: 21
+    rework:
+      0:
+        3: [1, 2, 0]
+      1:
+        2: [0, 0, 5]
+        3: [4, 0, 0]
+    people:
+    - "Alice"
+    - "Bob"
+    tick_size: 86400
+`, buffer.String())
+	assert.Error(t, cc.Serialize(nil, false, buffer))
 }
 
 func TestCodeChurnDeserialize(t *testing.T) {
 	cc := CodeChurnAnalysis{}
-	result, err := cc.Deserialize(nil)
+	result := fixtureCodeChurnResult()
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	restored, err := cc.Deserialize(buffer.Bytes())
 	assert.Nil(t, err)
-	assert.Nil(t, result)
+	assert.Equal(t, result, restored)
+	_, err = cc.Deserialize([]byte("garbage"))
+	assert.Error(t, err)
+
+	// the series of different lengths would crash MergeResults()
+	result.People[0].Awareness = result.People[0].Awareness[:1]
+	buffer.Reset()
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	_, err = cc.Deserialize(buffer.Bytes())
+	assert.Error(t, err)
+	// the people entry without the value
+	_, err = cc.Deserialize([]byte("\x08\x01\x20\x01\x12\x02\x08\x00"))
+	assert.Error(t, err)
+
+	// the zero sampling would hang sampleUntil() and the zero tick size would divide by zero
+	result = fixtureCodeChurnResult()
+	result.Sampling = 0
+	buffer.Reset()
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	_, err = cc.Deserialize(buffer.Bytes())
+	assert.EqualError(t, err, "invalid sampling 0: must be positive")
+	result = fixtureCodeChurnResult()
+	result.tickSize = 0
+	buffer.Reset()
+	assert.Nil(t, cc.Serialize(result, true, buffer))
+	_, err = cc.Deserialize(buffer.Bytes())
+	assert.EqualError(t, err, "invalid tick size 0: must be positive")
 }
 
 func TestCodeChurnMergeResults(t *testing.T) {
 	cc := CodeChurnAnalysis{}
-	result := cc.MergeResults(nil, nil, nil, nil)
-	assert.Nil(t, result)
+	r1 := fixtureCodeChurnResult()
+	r2 := CodeChurnResult{
+		People: map[int]*CodeChurnSeries{
+			0: {
+				Inserted:        []int64{10},
+				Owned:           []int64{5},
+				DeletedBySelf:   []int64{5},
+				DeletedByOthers: []int64{0},
+				Awareness:       []float32{5},
+				Memorability:    []float32{0.5},
+			},
+		},
+		Sampling: 2,
+		Rework: map[int]map[int]*CodeChurnRework{
+			0: {0: {Disruptive: 7}},
+		},
+		SelfChurnDays:      21,
+		reversedPeopleDict: []string{"Carol"},
+		tickSize:           24 * time.Hour,
+	}
+	c1 := core.CommonAnalysisResult{BeginTime: 1556224895}
+	// two samples later
+	c2 := core.CommonAnalysisResult{BeginTime: 1556224895 + 4*24*3600}
+	merged := cc.MergeResults(r1, r2, &c1, &c2).(CodeChurnResult)
+	assert.Equal(t, 2, merged.Sampling)
+	assert.Equal(t, 24*time.Hour, merged.tickSize)
+	assert.Equal(t, []string{"Alice", "Bob", "Carol"}, merged.reversedPeopleDict)
+	assert.Len(t, merged.People, 3)
+	// the last values are carried forward
+	assert.Equal(t, []int64{20, 15, 15}, merged.People[0].Owned)
+	assert.Equal(t, []float32{1, 0.5, 0.5}, merged.People[0].Memorability)
+	assert.Equal(t, []int64{0, 0, 10}, merged.People[2].Inserted)
+	assert.Equal(t, []int64{0, 0, 5}, merged.People[2].DeletedBySelf)
+	// the ticks are shifted by the difference of the beginnings
+	assert.Equal(t, map[int]map[int]*CodeChurnRework{
+		0: {3: {SelfChurn: 1, OldSelfChurn: 2}},
+		1: {2: {Disruptive: 5}, 3: {SelfChurn: 4}},
+		2: {4: {Disruptive: 7}},
+	}, merged.Rework)
+
+	// the same developer in both results
+	r2.reversedPeopleDict = []string{"Alice"}
+	merged = cc.MergeResults(r1, r2, &c1, &c1).(CodeChurnResult)
+	assert.Equal(t, []string{"Alice", "Bob"}, merged.reversedPeopleDict)
+	assert.Equal(t, []int64{25, 20}, merged.People[0].Owned)
+	assert.Equal(t, []int64{30, 30}, merged.People[0].Inserted)
+	assert.InDelta(t, (20+2.5)/25.0, merged.People[0].Memorability[0], 1e-6)
+	assert.InDelta(t, (7.5+2.5)/20.0, merged.People[0].Memorability[1], 1e-6)
+	assert.Equal(t, &CodeChurnRework{SelfChurn: 1, OldSelfChurn: 2}, merged.Rework[0][3])
+	assert.Equal(t, &CodeChurnRework{Disruptive: 7}, merged.Rework[0][0])
+
+	r2.SelfChurnDays = 7
+	assert.IsType(t, fmt.Errorf(""), cc.MergeResults(r1, r2, &c1, &c2))
+	r2.SelfChurnDays = 21
+
+	r2.Sampling = 3
+	assert.IsType(t, fmt.Errorf(""), cc.MergeResults(r1, r2, &c1, &c2))
+	r2.Sampling = 2
+	r2.tickSize = time.Hour
+	assert.IsType(t, fmt.Errorf(""), cc.MergeResults(r1, r2, &c1, &c2))
 }
 
 func TestPersonChurnStatsGetFileEntry(t *testing.T) {
@@ -0,0 +1,491 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigCommentDensityErosionThreshold is the name of the option to set the minimum decrease
+	// of the comment density which is reported together with the rising churn.
+	ConfigCommentDensityErosionThreshold = "CommentDensity.ErosionThreshold"
+)
+
+// countCommentLines returns the number of comment and code lines in the source code.
+// Blank lines are ignored and the lines which mix code with a comment are counted as code.
+func countCommentLines(data []byte, syntax items.CommentSyntax) (comments, code int) {
+	for _, kind := range items.ClassifyLines(data, syntax) {
+		switch kind {
+		case items.LineKindComment:
+			comments++
+		case items.LineKindCode:
+			code++
+		}
+	}
+	return comments, code
+}
+
+// CommentDensityStats are the comment and code lines of a file or a subsystem.
+type CommentDensityStats struct {
+	CommentLines int
+	CodeLines    int
+	// Churn is the sum of the added, removed and changed lines.
+	Churn int
+}
+
+// Density returns the share of the comment lines among all the non-blank lines.
+func (stats CommentDensityStats) Density() float64 {
+	total := stats.CommentLines + stats.CodeLines
+	if total == 0 {
+		return 0
+	}
+	return float64(stats.CommentLines) / float64(total)
+}
+
+// CommentDensityErosion is the subsystem which comment density decreases while the churn grows.
+// "Before" is the first half of the subsystem's history and "after" is the second half.
+type CommentDensityErosion struct {
+	Subsystem     string
+	DensityBefore float64
+	DensityAfter  float64
+	ChurnBefore   int
+	ChurnAfter    int
+}
+
+// CommentDensityResult is returned by CommentDensityAnalysis.Finalize() and carries
+// the comment density time series.
+type CommentDensityResult struct {
+	// Ticks maps ticks to subsystems (directories) to their lines at the end of the tick
+	// and the churn during the tick. Only the changed subsystems are recorded.
+	Ticks map[int]map[string]CommentDensityStats
+	// Files are the final lines and the total churn of each alive file.
+	Files map[string]CommentDensityStats
+	// Eroding lists the subsystems which lose the comments while being changed more often,
+	// sorted by the decrease of the density.
+	Eroding []CommentDensityErosion
+	// Threshold is the minimum decrease of the density in Eroding.
+	Threshold float64
+
+	tickSize time.Duration
+}
+
+// CommentDensityAnalysis tracks the share of the comment lines in the changed files
+// and finds the subsystems which comment density erodes alongside the rising churn.
+type CommentDensityAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// ErosionThreshold is the minimum decrease of the comment density to report a subsystem.
+	ErosionThreshold float64
+
+	// files maps file names to their current lines and the total churn.
+	files map[string]CommentDensityStats
+	// subsystems maps directories to the sum of their files' lines.
+	subsystems map[string]CommentDensityStats
+	// ticks maps ticks to directories to the lines at the end of the tick and the tick's churn.
+	ticks    map[int]map[string]CommentDensityStats
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cd *CommentDensityAnalysis) Name() string {
+	return "CommentDensity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (cd *CommentDensityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (cd *CommentDensityAnalysis) Requires() []string {
+	return []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLanguages,
+		items.DependencyLineStats, items.DependencyTick,
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cd *CommentDensityAnalysis) Flag() string {
+	return "comment-density"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (cd *CommentDensityAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cd *CommentDensityAnalysis) Description() string {
+	return "Tracks the share of comment lines in the changed files over time and reports " +
+		"the subsystems which comment density erodes alongside rising churn."
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cd *CommentDensityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{
+		{
+			Name:        ConfigCommentDensityErosionThreshold,
+			Description: "Minimum decrease of the comment density (0.0-1.0) to report an eroding subsystem.",
+			Flag:        "comment-density-threshold",
+			Type:        core.FloatConfigurationOption,
+			Default:     float32(0.02),
+		},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cd *CommentDensityAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		cd.l = l
+	}
+	if val, exists := facts[ConfigCommentDensityErosionThreshold].(float32); exists {
+		cd.ErosionThreshold = float64(val)
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		cd.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*CommentDensityAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cd *CommentDensityAnalysis) Initialize(repository *git.Repository) error {
+	cd.l = core.NewLogger()
+	cd.files = map[string]CommentDensityStats{}
+	cd.subsystems = map[string]CommentDensityStats{}
+	cd.ticks = map[int]map[string]CommentDensityStats{}
+	cd.OneShotMergeProcessor.Initialize()
+	if cd.ErosionThreshold <= 0 {
+		cd.ErosionThreshold = 0.02
+	}
+	return nil
+}
+
+// subsystemOf returns the directory of the file or "/" for the root.
+func subsystemOf(name string) string {
+	dir := path.Dir(name)
+	if dir == "." {
+		dir = "/"
+	}
+	return dir
+}
+
+// updateSubsystem adds the signed difference of the lines to the file's directory and
+// records the churn in the current tick.
+func (cd *CommentDensityAnalysis) updateSubsystem(
+	tick int, name string, comments, code, churn int) {
+	dir := subsystemOf(name)
+	stats := cd.subsystems[dir]
+	stats.CommentLines += comments
+	stats.CodeLines += code
+	cd.subsystems[dir] = stats
+	tickStats := cd.ticks[tick]
+	if tickStats == nil {
+		tickStats = map[string]CommentDensityStats{}
+		cd.ticks[tick] = tickStats
+	}
+	tickChurn := tickStats[dir].Churn + churn
+	tickStats[dir] = CommentDensityStats{
+		CommentLines: stats.CommentLines, CodeLines: stats.CodeLines, Churn: tickChurn,
+	}
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cd *CommentDensityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cd.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	tick := deps[items.DependencyTick].(int)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	langs := deps[items.DependencyLanguages].(map[plumbing.Hash]string)
+	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		var churn int
+		entry := change.To
+		if action == merkletrie.Delete {
+			entry = change.From
+		}
+		if stats, exists := lineStats[entry]; exists {
+			churn = stats.Added + stats.Removed + stats.Changed
+		}
+		fileChurn := 0
+		if action != merkletrie.Insert {
+			if old, exists := cd.files[change.From.Name]; exists {
+				delete(cd.files, change.From.Name)
+				fileChurn = old.Churn
+				deletedChurn := 0
+				if action == merkletrie.Delete {
+					deletedChurn = churn
+				}
+				cd.updateSubsystem(tick, change.From.Name, -old.CommentLines, -old.CodeLines, deletedChurn)
+			}
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		syntax, known := items.CommentSyntaxTable[langs[change.To.TreeEntry.Hash]]
+		blob := cache[change.To.TreeEntry.Hash]
+		if !known || blob == nil {
+			continue
+		}
+		if _, err := blob.CountLines(); err != nil {
+			// binary
+			continue
+		}
+		comments, code := countCommentLines(blob.Data, syntax)
+		cd.files[change.To.Name] = CommentDensityStats{
+			CommentLines: comments, CodeLines: code, Churn: fileChurn + churn,
+		}
+		cd.updateSubsystem(tick, change.To.Name, comments, code, churn)
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cd *CommentDensityAnalysis) Finalize() interface{} {
+	files := make(map[string]CommentDensityStats, len(cd.files))
+	for name, stats := range cd.files {
+		files[name] = stats
+	}
+	return CommentDensityResult{
+		Ticks:     cd.ticks,
+		Files:     files,
+		Eroding:   findCommentDensityErosion(cd.ticks, cd.ErosionThreshold),
+		Threshold: cd.ErosionThreshold,
+		tickSize:  cd.tickSize,
+	}
+}
+
+// findCommentDensityErosion splits the history of each subsystem into two halves by the number
+// of ticks in which it changed and compares the final density and the total churn of the halves.
+func findCommentDensityErosion(
+	ticks map[int]map[string]CommentDensityStats, threshold float64) []CommentDensityErosion {
+	history := map[string][]int{}
+	for tick, subsystems := range ticks {
+		for dir := range subsystems {
+			history[dir] = append(history[dir], tick)
+		}
+	}
+	var result []CommentDensityErosion
+	for dir, dirTicks := range history {
+		if len(dirTicks) < 2 {
+			continue
+		}
+		sort.Ints(dirTicks)
+		middle := len(dirTicks) / 2
+		erosion := CommentDensityErosion{
+			Subsystem:     dir,
+			DensityBefore: ticks[dirTicks[middle-1]][dir].Density(),
+			DensityAfter:  ticks[dirTicks[len(dirTicks)-1]][dir].Density(),
+		}
+		for i, tick := range dirTicks {
+			if i < middle {
+				erosion.ChurnBefore += ticks[tick][dir].Churn
+			} else {
+				erosion.ChurnAfter += ticks[tick][dir].Churn
+			}
+		}
+		if erosion.DensityBefore-erosion.DensityAfter >= threshold &&
+			erosion.ChurnAfter > erosion.ChurnBefore {
+			result = append(result, erosion)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		di := result[i].DensityBefore - result[i].DensityAfter
+		dj := result[j].DensityBefore - result[j].DensityAfter
+		if di != dj {
+			return di > dj
+		}
+		return result[i].Subsystem < result[j].Subsystem
+	})
+	return result
+}
+
+// Fork clones this pipeline item.
+func (cd *CommentDensityAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cd, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cd *CommentDensityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	densityResult, ok := result.(CommentDensityResult)
+	if !ok {
+		return fmt.Errorf("result is not a CommentDensityResult: '%v'", result)
+	}
+	if binary {
+		return cd.serializeBinary(&densityResult, writer)
+	}
+	cd.serializeText(&densityResult, writer)
+	return nil
+}
+
+// serializeText outputs YAML format
+func (cd *CommentDensityAnalysis) serializeText(result *CommentDensityResult, writer io.Writer) {
+	writeStats := func(indent string, key string, stats CommentDensityStats) {
+		fmt.Fprintf(writer, "%s%s: [%d, %d, %d]\n", indent, yaml.SafeString(key),
+			stats.CommentLines, stats.CodeLines, stats.Churn)
+	}
+	fmt.Fprintln(writer, "  comment_density:")
+	fmt.Fprintf(writer, "    threshold: %.4f\n", result.Threshold)
+	fmt.Fprintf(writer, "    tick_size: %d\n", int(result.tickSize.Seconds()))
+	fmt.Fprintln(writer, "    # [comment lines, code lines, churn]")
+	fmt.Fprintln(writer, "    ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		fmt.Fprintf(writer, "      %d:\n", tick)
+		subsystems := result.Ticks[tick]
+		dirs := make([]string, 0, len(subsystems))
+		for dir := range subsystems {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			writeStats("        ", dir, subsystems[dir])
+		}
+	}
+	fmt.Fprintln(writer, "    files:")
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeStats("      ", name, result.Files[name])
+	}
+	fmt.Fprintln(writer, "    eroding:")
+	for _, erosion := range result.Eroding {
+		fmt.Fprintf(writer, "      - subsystem: %s\n", yaml.SafeString(erosion.Subsystem))
+		fmt.Fprintf(writer, "        density: [%.4f, %.4f]\n", erosion.DensityBefore, erosion.DensityAfter)
+		fmt.Fprintf(writer, "        churn: [%d, %d]\n", erosion.ChurnBefore, erosion.ChurnAfter)
+	}
+}
+
+func commentDensityStatsToPb(stats CommentDensityStats) *pb.CommentDensityStats {
+	return &pb.CommentDensityStats{
+		CommentLines: int32(stats.CommentLines),
+		CodeLines:    int32(stats.CodeLines),
+		Churn:        int32(stats.Churn),
+	}
+}
+
+func commentDensityStatsFromPb(stats *pb.CommentDensityStats) CommentDensityStats {
+	return CommentDensityStats{
+		CommentLines: int(stats.GetCommentLines()),
+		CodeLines:    int(stats.GetCodeLines()),
+		Churn:        int(stats.GetChurn()),
+	}
+}
+
+// serializeBinary outputs Protocol Buffers format
+func (cd *CommentDensityAnalysis) serializeBinary(result *CommentDensityResult, writer io.Writer) error {
+	message := pb.CommentDensityResults{
+		Ticks:     make(map[int32]*pb.CommentDensityTick, len(result.Ticks)),
+		Files:     make(map[string]*pb.CommentDensityStats, len(result.Files)),
+		Eroding:   make([]*pb.CommentDensityErosion, len(result.Eroding)),
+		Threshold: float32(result.Threshold),
+		TickSize:  int64(result.tickSize),
+	}
+	for tick, subsystems := range result.Ticks {
+		pbTick := &pb.CommentDensityTick{
+			Subsystems: make(map[string]*pb.CommentDensityStats, len(subsystems)),
+		}
+		for dir, stats := range subsystems {
+			pbTick.Subsystems[dir] = commentDensityStatsToPb(stats)
+		}
+		message.Ticks[int32(tick)] = pbTick
+	}
+	for name, stats := range result.Files {
+		message.Files[name] = commentDensityStatsToPb(stats)
+	}
+	for i, erosion := range result.Eroding {
+		message.Eroding[i] = &pb.CommentDensityErosion{
+			Subsystem:     erosion.Subsystem,
+			DensityBefore: float32(erosion.DensityBefore),
+			DensityAfter:  float32(erosion.DensityAfter),
+			ChurnBefore:   int32(erosion.ChurnBefore),
+			ChurnAfter:    int32(erosion.ChurnAfter),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// Deserialize converts the specified protobuf bytes to CommentDensityResult.
+func (cd *CommentDensityAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommentDensityResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := CommentDensityResult{
+		Ticks:     make(map[int]map[string]CommentDensityStats, len(message.Ticks)),
+		Files:     make(map[string]CommentDensityStats, len(message.Files)),
+		Eroding:   make([]CommentDensityErosion, len(message.Eroding)),
+		Threshold: float64(message.Threshold),
+		tickSize:  time.Duration(message.TickSize),
+	}
+	for tick, pbTick := range message.Ticks {
+		subsystems := make(map[string]CommentDensityStats, len(pbTick.GetSubsystems()))
+		for dir, stats := range pbTick.GetSubsystems() {
+			subsystems[dir] = commentDensityStatsFromPb(stats)
+		}
+		result.Ticks[int(tick)] = subsystems
+	}
+	for name, stats := range message.Files {
+		result.Files[name] = commentDensityStatsFromPb(stats)
+	}
+	for i, erosion := range message.Eroding {
+		result.Eroding[i] = CommentDensityErosion{
+			Subsystem:     erosion.Subsystem,
+			DensityBefore: float64(erosion.DensityBefore),
+			DensityAfter:  float64(erosion.DensityAfter),
+			ChurnBefore:   int(erosion.ChurnBefore),
+			ChurnAfter:    int(erosion.ChurnAfter),
+		}
+	}
+	return result, nil
+}
+
+func init() {
+	core.Registry.Register(&CommentDensityAnalysis{})
+}
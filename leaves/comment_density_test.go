@@ -0,0 +1,235 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commentDensityCommit builds the dependencies of CommentDensityAnalysis.Consume().
+type commentDensityCommit struct {
+	changes   object.Changes
+	cache     map[plumbing.Hash]*items.CachedBlob
+	langs     map[plumbing.Hash]string
+	lineStats map[object.ChangeEntry]items.LineStats
+}
+
+func newCommentDensityCommit() *commentDensityCommit {
+	return &commentDensityCommit{
+		cache:     map[plumbing.Hash]*items.CachedBlob{},
+		langs:     map[plumbing.Hash]string{},
+		lineStats: map[object.ChangeEntry]items.LineStats{},
+	}
+}
+
+func (c *commentDensityCommit) entry(name, contents, lang string) object.ChangeEntry {
+	if name == "" {
+		return object.ChangeEntry{}
+	}
+	hash := plumbing.ComputeHash(plumbing.BlobObject, []byte(name+contents))
+	c.cache[hash] = &items.CachedBlob{Data: []byte(contents)}
+	c.langs[hash] = lang
+	return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+}
+
+func (c *commentDensityCommit) change(from, to, contents, lang string, churn int) *commentDensityCommit {
+	change := &object.Change{From: c.entry(from, "old", lang), To: c.entry(to, contents, lang)}
+	key := change.To
+	if to == "" {
+		key = change.From
+	}
+	c.lineStats[key] = items.LineStats{Added: churn}
+	c.changes = append(c.changes, change)
+	return c
+}
+
+func (c *commentDensityCommit) deps(tick int) map[string]interface{} {
+	return map[string]interface{}{
+		core.DependencyCommit:       &object.Commit{},
+		items.DependencyTick:        tick,
+		items.DependencyTreeChanges: c.changes,
+		items.DependencyBlobCache:   c.cache,
+		items.DependencyLanguages:   c.langs,
+		items.DependencyLineStats:   c.lineStats,
+	}
+}
+
+func TestCommentDensityMeta(t *testing.T) {
+	cd := &CommentDensityAnalysis{}
+	assert.Equal(t, "CommentDensity", cd.Name())
+	assert.Len(t, cd.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLanguages,
+		items.DependencyLineStats, items.DependencyTick,
+	}, cd.Requires())
+	assert.Equal(t, "comment-density", cd.Flag())
+	assert.Equal(t, core.CostMedium, cd.Cost())
+	opts := cd.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigCommentDensityErosionThreshold, opts[0].Name)
+	require.NoError(t, cd.Configure(map[string]interface{}{
+		ConfigCommentDensityErosionThreshold: float32(0.1),
+		items.FactTickSize:                   24 * time.Hour,
+	}))
+	assert.InDelta(t, 0.1, cd.ErosionThreshold, 1e-6)
+	assert.Equal(t, 24*time.Hour, cd.tickSize)
+}
+
+func TestCommentDensityRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CommentDensityAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "CommentDensity", summoned[0].Name())
+	leaves := core.Registry.GetLeaves()
+	matched := false
+	for _, tp := range leaves {
+		if tp.Flag() == (&CommentDensityAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestCountCommentLines(t *testing.T) {
+	comments, code := countCommentLines([]byte(`// Package x does things.
+package x
+
+/*
+ * Block comment.
+ */
+func x() int { // trailing comment
+	/* inline */ return 1
+}
+`), items.CommentSyntaxTable["Go"])
+	assert.Equal(t, 4, comments)
+	assert.Equal(t, 4, code)
+
+	comments, code = countCommentLines([]byte(`"""Module docstring."""
+# comment
+
+def f():
+    """
+    Multi-line docstring.
+    """
+    return 1
+`), items.CommentSyntaxTable["Python"])
+	assert.Equal(t, 5, comments)
+	assert.Equal(t, 2, code)
+
+	comments, code = countCommentLines([]byte("--[[\nblock\n]]\n-- line\nprint(1)\n"),
+		items.CommentSyntaxTable["Lua"])
+	assert.Equal(t, 4, comments)
+	assert.Equal(t, 1, code)
+}
+
+func TestCommentDensityConsume(t *testing.T) {
+	cd := &CommentDensityAnalysis{}
+	require.NoError(t, cd.Initialize(nil))
+
+	commit := newCommentDensityCommit().
+		change("", "src/a.go", "// a\n// b\ncode\ncode\n", "Go", 4).
+		change("", "README.md", "# Title\n", "Markdown", 1)
+	_, err := cd.Consume(commit.deps(0))
+	require.NoError(t, err)
+	assert.Equal(t, CommentDensityStats{CommentLines: 2, CodeLines: 2, Churn: 4}, cd.files["src/a.go"])
+	assert.NotContains(t, cd.files, "README.md")
+
+	commit = newCommentDensityCommit().
+		change("src/a.go", "src/a.go", "// a\ncode\ncode\ncode\n", "Go", 3).
+		change("", "b.py", "x = 1\n", "Python", 1)
+	_, err = cd.Consume(commit.deps(1))
+	require.NoError(t, err)
+
+	commit = newCommentDensityCommit().
+		change("src/a.go", "lib/a.go", "// a\ncode\ncode\ncode\n", "Go", 0).
+		change("b.py", "", "", "Python", 1)
+	_, err = cd.Consume(commit.deps(2))
+	require.NoError(t, err)
+
+	result := cd.Finalize().(CommentDensityResult)
+	assert.Equal(t, map[string]CommentDensityStats{
+		"lib/a.go": {CommentLines: 1, CodeLines: 3, Churn: 7},
+	}, result.Files)
+	assert.Equal(t, CommentDensityStats{CommentLines: 2, CodeLines: 2, Churn: 4}, result.Ticks[0]["src"])
+	assert.Equal(t, CommentDensityStats{CommentLines: 1, CodeLines: 3, Churn: 3}, result.Ticks[1]["src"])
+	assert.Equal(t, CommentDensityStats{CommentLines: 0, CodeLines: 1, Churn: 1}, result.Ticks[1]["/"])
+	assert.Equal(t, CommentDensityStats{}, result.Ticks[2]["src"])
+	assert.Equal(t, CommentDensityStats{CommentLines: 1, CodeLines: 3}, result.Ticks[2]["lib"])
+	assert.Equal(t, CommentDensityStats{Churn: 1}, result.Ticks[2]["/"])
+	assert.Len(t, result.Eroding, 0)
+}
+
+func TestFindCommentDensityErosion(t *testing.T) {
+	ticks := map[int]map[string]CommentDensityStats{
+		0: {"src": {CommentLines: 5, CodeLines: 5, Churn: 10}, "doc": {CommentLines: 5, CodeLines: 5, Churn: 10}},
+		1: {"src": {CommentLines: 5, CodeLines: 10, Churn: 5}, "doc": {CommentLines: 5, CodeLines: 5, Churn: 1}},
+		2: {"src": {CommentLines: 5, CodeLines: 20, Churn: 20}, "doc": {CommentLines: 1, CodeLines: 9, Churn: 1}},
+		3: {"src": {CommentLines: 5, CodeLines: 45, Churn: 30}, "lib": {CommentLines: 0, CodeLines: 10, Churn: 100}},
+	}
+	eroding := findCommentDensityErosion(ticks, 0.02)
+	require.Len(t, eroding, 1)
+	assert.Equal(t, "src", eroding[0].Subsystem)
+	assert.InDelta(t, 1.0/3, eroding[0].DensityBefore, 1e-6)
+	assert.InDelta(t, 0.1, eroding[0].DensityAfter, 1e-6)
+	assert.Equal(t, 15, eroding[0].ChurnBefore)
+	assert.Equal(t, 50, eroding[0].ChurnAfter)
+	assert.Len(t, findCommentDensityErosion(ticks, 0.5), 0)
+}
+
+func TestCommentDensitySerialize(t *testing.T) {
+	cd := &CommentDensityAnalysis{}
+	result := CommentDensityResult{
+		Ticks: map[int]map[string]CommentDensityStats{
+			0: {"src": {CommentLines: 2, CodeLines: 2, Churn: 4}},
+			3: {"src": {CommentLines: 1, CodeLines: 9, Churn: 8}, "/": {CodeLines: 1, Churn: 1}},
+		},
+		Files: map[string]CommentDensityStats{"src/a.go": {CommentLines: 1, CodeLines: 9, Churn: 12}},
+		Eroding: []CommentDensityErosion{
+			{Subsystem: "src", DensityBefore: 0.5, DensityAfter: 0.1, ChurnBefore: 4, ChurnAfter: 8},
+		},
+		Threshold: 0.02,
+		tickSize:  24 * time.Hour,
+	}
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, cd.Serialize(result, false, buffer))
+	assert.Equal(t, `  comment_density:
+    threshold: 0.0200
+    tick_size: 86400
+    # [comment lines, code lines, churn]
+    ticks:
+      0:
+        "src": [2, 2, 4]
+      3:
+        "/": [0, 1, 1]
+        "src": [1, 9, 8]
+    files:
+      "src/a.go": [1, 9, 12]
+    eroding:
+      - subsystem: "src"
+        density: [0.5000, 0.1000]
+        churn: [4, 8]
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, cd.Serialize(result, true, buffer))
+	raw, err := cd.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	restored := raw.(CommentDensityResult)
+	assert.Equal(t, result.Ticks, restored.Ticks)
+	assert.Equal(t, result.Files, restored.Files)
+	require.Len(t, restored.Eroding, 1)
+	assert.InDelta(t, 0.1, restored.Eroding[0].DensityAfter, 1e-6)
+	assert.Equal(t, 8, restored.Eroding[0].ChurnAfter)
+	assert.InDelta(t, 0.02, restored.Threshold, 1e-6)
+	assert.Equal(t, 24*time.Hour, restored.tickSize)
+
+	assert.Error(t, cd.Serialize(nil, false, buffer))
+}
@@ -111,6 +111,11 @@ func (sent *CommentSentimentAnalysis) Flag() string {
 	return "sentiment"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (sent *CommentSentimentAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (sent *CommentSentimentAnalysis) Description() string {
 	return "Classifies each new or changed comment per commit as containing positive or " +
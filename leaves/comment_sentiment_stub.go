@@ -62,6 +62,8 @@ func (sent *CommentSentimentAnalysis) ListConfigurationOptions() []core.Configur
 
 func (sent *CommentSentimentAnalysis) Flag() string { return "sentiment" }
 
+func (sent *CommentSentimentAnalysis) Cost() core.CostClass { return core.CostHeavy }
+
 func (sent *CommentSentimentAnalysis) Description() string {
 	return "[EXPERIMENTAL] Unavailable in this build. Rebuild with -tags tensorflow."
 }
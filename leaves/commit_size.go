@@ -0,0 +1,493 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigCommitSizeMegaFiles is the name of the option to set the number of the changed files
+	// above which the commit is a mega-commit.
+	ConfigCommitSizeMegaFiles = "CommitSize.MegaFiles"
+	// ConfigCommitSizeMegaLines is the name of the option to set the number of the changed lines
+	// above which the commit is a mega-commit.
+	ConfigCommitSizeMegaLines = "CommitSize.MegaLines"
+	// DefaultCommitSizeMegaFiles is the default value of ConfigCommitSizeMegaFiles.
+	DefaultCommitSizeMegaFiles = 100
+	// DefaultCommitSizeMegaLines is the default value of ConfigCommitSizeMegaLines.
+	DefaultCommitSizeMegaLines = 5000
+)
+
+var (
+	// CommitSizeFilesBounds are the inclusive upper bounds of the histogram buckets by the number
+	// of the changed files. The last bucket is unbounded.
+	CommitSizeFilesBounds = []int{1, 2, 5, 10, 20, 50, 100}
+	// CommitSizeLinesBounds are the inclusive upper bounds of the histogram buckets by the number
+	// of the changed lines. The last bucket is unbounded.
+	CommitSizeLinesBounds = []int{10, 50, 100, 250, 500, 1000, 5000}
+)
+
+// CommitSizeAnalysis builds the histograms of the commit sizes per developer and per tick,
+// tracks the median and the 90th percentile of the sizes over time and flags the mega-commits.
+// The small commits are a proxy for the healthy delivery practices.
+type CommitSizeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// MegaFiles is the number of the changed files above which the commit is a mega-commit;
+	// 0 disables the check.
+	MegaFiles int
+	// MegaLines is the number of the changed lines above which the commit is a mega-commit;
+	// 0 disables the check.
+	MegaLines int
+
+	people map[int]*CommitSizeHistogram
+	// ticks are the sizes of each commit per tick.
+	ticks       map[int][]commitSize
+	megaCommits []MegaCommit
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// commitSize is the number of the files and the lines changed in a commit.
+type commitSize struct {
+	Files int
+	Lines int
+}
+
+// CommitSizeHistogram counts the commits in the buckets of CommitSizeFilesBounds and
+// CommitSizeLinesBounds.
+type CommitSizeHistogram struct {
+	Commits int
+	Files   []int
+	Lines   []int
+}
+
+// CommitSizeTick is the distribution of the sizes of the commits in one tick.
+type CommitSizeTick struct {
+	CommitSizeHistogram
+	MedianFiles int
+	P90Files    int
+	MedianLines int
+	P90Lines    int
+}
+
+// MegaCommit is the commit which changed more files or lines than the thresholds.
+type MegaCommit struct {
+	Hash    string
+	Author  int
+	Tick    int
+	Files   int
+	Added   int
+	Removed int
+	Changed int
+}
+
+// CommitSizeResult is returned by CommitSizeAnalysis.Finalize().
+type CommitSizeResult struct {
+	// People maps developer indexes to the histograms of their commits.
+	People map[int]CommitSizeHistogram
+	// Ticks maps ticks to the distributions of the commit sizes.
+	Ticks map[int]CommitSizeTick
+	// MegaCommits are sorted by tick and by hash.
+	MegaCommits []MegaCommit
+	MegaFiles   int
+	MegaLines   int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	tickSize           time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cs *CommitSizeAnalysis) Name() string {
+	return "CommitSize"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (cs *CommitSizeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (cs *CommitSizeAnalysis) Requires() []string {
+	return []string{
+		items.DependencyTreeChanges, items.DependencyLineStats, identity.DependencyAuthor,
+		items.DependencyTick,
+	}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cs *CommitSizeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCommitSizeMegaFiles,
+		Description: "Number of the changed files above which the commit is a mega-commit; 0 disables.",
+		Flag:        "commit-size-mega-files",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommitSizeMegaFiles,
+	}, {
+		Name:        ConfigCommitSizeMegaLines,
+		Description: "Number of the changed lines above which the commit is a mega-commit; 0 disables.",
+		Flag:        "commit-size-mega-lines",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCommitSizeMegaLines,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cs *CommitSizeAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		cs.l = l
+	}
+	if val, exists := facts[ConfigCommitSizeMegaFiles].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("invalid --commit-size-mega-files %d: must not be negative", val)
+		}
+		cs.MegaFiles = val
+	}
+	if val, exists := facts[ConfigCommitSizeMegaLines].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("invalid --commit-size-mega-lines %d: must not be negative", val)
+		}
+		cs.MegaLines = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		cs.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		cs.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*CommitSizeAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cs *CommitSizeAnalysis) Flag() string {
+	return "commit-size"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (cs *CommitSizeAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cs *CommitSizeAnalysis) Description() string {
+	return "Builds the histograms of the commit sizes - the changed files and lines - per developer " +
+		"and per tick, tracks the median and the 90th percentile over time and flags the mega-commits."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cs *CommitSizeAnalysis) Initialize(repository *git.Repository) error {
+	cs.l = core.NewLogger()
+	if cs.tickSize == 0 {
+		cs.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	cs.people = map[int]*CommitSizeHistogram{}
+	cs.ticks = map[int][]commitSize{}
+	cs.megaCommits = nil
+	cs.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cs *CommitSizeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cs.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	if len(treeDiff) == 0 {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	tick := deps[items.DependencyTick].(int)
+	var stats items.LineStats
+	for _, lineStats := range deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats) {
+		stats = stats.Add(lineStats)
+	}
+	size := commitSize{Files: len(treeDiff), Lines: stats.Added + stats.Removed + stats.Changed}
+	histogram := cs.people[author]
+	if histogram == nil {
+		histogram = newCommitSizeHistogram()
+		cs.people[author] = histogram
+	}
+	histogram.add(size)
+	cs.ticks[tick] = append(cs.ticks[tick], size)
+	if (cs.MegaFiles > 0 && size.Files > cs.MegaFiles) || (cs.MegaLines > 0 && size.Lines > cs.MegaLines) {
+		mega := MegaCommit{
+			Author: author, Tick: tick, Files: size.Files,
+			Added: stats.Added, Removed: stats.Removed, Changed: stats.Changed,
+		}
+		if commit, ok := deps[core.DependencyCommit].(*object.Commit); ok {
+			mega.Hash = commit.Hash.String()
+		}
+		cs.megaCommits = append(cs.megaCommits, mega)
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cs *CommitSizeAnalysis) Finalize() interface{} {
+	result := CommitSizeResult{
+		People:             make(map[int]CommitSizeHistogram, len(cs.people)),
+		Ticks:              make(map[int]CommitSizeTick, len(cs.ticks)),
+		MegaCommits:        append([]MegaCommit{}, cs.megaCommits...),
+		MegaFiles:          cs.MegaFiles,
+		MegaLines:          cs.MegaLines,
+		reversedPeopleDict: cs.reversedPeopleDict,
+		tickSize:           cs.tickSize,
+	}
+	for dev, histogram := range cs.people {
+		result.People[dev] = *histogram
+	}
+	for tick, sizes := range cs.ticks {
+		histogram := newCommitSizeHistogram()
+		files := make([]int, len(sizes))
+		lines := make([]int, len(sizes))
+		for i, size := range sizes {
+			histogram.add(size)
+			files[i] = size.Files
+			lines[i] = size.Lines
+		}
+		sort.Ints(files)
+		sort.Ints(lines)
+		result.Ticks[tick] = CommitSizeTick{
+			CommitSizeHistogram: *histogram,
+			MedianFiles:         nearestRank(files, 0.5),
+			P90Files:            nearestRank(files, 0.9),
+			MedianLines:         nearestRank(lines, 0.5),
+			P90Lines:            nearestRank(lines, 0.9),
+		}
+	}
+	sort.Slice(result.MegaCommits, func(i, j int) bool {
+		if result.MegaCommits[i].Tick != result.MegaCommits[j].Tick {
+			return result.MegaCommits[i].Tick < result.MegaCommits[j].Tick
+		}
+		return result.MegaCommits[i].Hash < result.MegaCommits[j].Hash
+	})
+	return result
+}
+
+// Fork clones this pipeline item.
+func (cs *CommitSizeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cs, n)
+}
+
+func newCommitSizeHistogram() *CommitSizeHistogram {
+	return &CommitSizeHistogram{
+		Files: make([]int, len(CommitSizeFilesBounds)+1),
+		Lines: make([]int, len(CommitSizeLinesBounds)+1),
+	}
+}
+
+func (histogram *CommitSizeHistogram) add(size commitSize) {
+	histogram.Commits++
+	histogram.Files[sort.SearchInts(CommitSizeFilesBounds, size.Files)]++
+	histogram.Lines[sort.SearchInts(CommitSizeLinesBounds, size.Lines)]++
+}
+
+// nearestRank returns the nearest-rank percentile of the sorted values, 0 if there are none.
+func nearestRank(sorted []int, percentile float64) int {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(percentile * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cs *CommitSizeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	sizeResult, ok := result.(CommitSizeResult)
+	if !ok {
+		return fmt.Errorf("result is not a commit size result: '%v'", result)
+	}
+	if binary {
+		return cs.serializeBinary(&sizeResult, writer)
+	}
+	cs.serializeText(&sizeResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CommitSizeResult.
+func (cs *CommitSizeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CommitSizeResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	fromPB := func(histogram *pb.CommitSizeHistogram) CommitSizeHistogram {
+		result := CommitSizeHistogram{
+			Files: make([]int, len(CommitSizeFilesBounds)+1),
+			Lines: make([]int, len(CommitSizeLinesBounds)+1),
+		}
+		if histogram == nil {
+			return result
+		}
+		result.Commits = int(histogram.Commits)
+		copy(result.Files, int32sToInts(histogram.Files))
+		copy(result.Lines, int32sToInts(histogram.Lines))
+		return result
+	}
+	result := CommitSizeResult{
+		People:             make(map[int]CommitSizeHistogram, len(message.People)),
+		Ticks:              make(map[int]CommitSizeTick, len(message.Ticks)),
+		MegaCommits:        make([]MegaCommit, len(message.MegaCommits)),
+		MegaFiles:          int(message.MegaFiles),
+		MegaLines:          int(message.MegaLines),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, histogram := range message.People {
+		result.People[int(dev)] = fromPB(histogram)
+	}
+	for tick, stats := range message.Ticks {
+		result.Ticks[int(tick)] = CommitSizeTick{
+			CommitSizeHistogram: fromPB(stats.Histogram),
+			MedianFiles:         int(stats.MedianFiles),
+			P90Files:            int(stats.P90Files),
+			MedianLines:         int(stats.MedianLines),
+			P90Lines:            int(stats.P90Lines),
+		}
+	}
+	for i, mega := range message.MegaCommits {
+		result.MegaCommits[i] = MegaCommit{
+			Hash: mega.Hash, Author: int(mega.Author), Tick: int(mega.Tick), Files: int(mega.Files),
+			Added: int(mega.Added), Removed: int(mega.Removed), Changed: int(mega.Changed),
+		}
+	}
+	return result, nil
+}
+
+func formatInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+	return "[" + strings.Join(strs, ", ") + "]"
+}
+
+func (cs *CommitSizeAnalysis) serializeText(result *CommitSizeResult, writer io.Writer) {
+	formatHistogram := func(histogram CommitSizeHistogram) string {
+		return fmt.Sprintf("commits: %d, files: %s, lines: %s",
+			histogram.Commits, formatInts(histogram.Files), formatInts(histogram.Lines))
+	}
+	fmt.Fprintln(writer, "  commit_size:")
+	fmt.Fprintf(writer, "    mega_files: %d\n", result.MegaFiles)
+	fmt.Fprintf(writer, "    mega_lines: %d\n", result.MegaLines)
+	fmt.Fprintf(writer, "    files_bounds: %s\n", formatInts(CommitSizeFilesBounds))
+	fmt.Fprintf(writer, "    lines_bounds: %s\n", formatInts(CommitSizeLinesBounds))
+	fmt.Fprintln(writer, "    developers:")
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d: {%s}\n", dev, formatHistogram(result.People[dev]))
+	}
+	fmt.Fprintln(writer, "    ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		stats := result.Ticks[tick]
+		fmt.Fprintf(writer, "      %d: {%s, median_files: %d, p90_files: %d, median_lines: %d, p90_lines: %d}\n",
+			tick, formatHistogram(stats.CommitSizeHistogram),
+			stats.MedianFiles, stats.P90Files, stats.MedianLines, stats.P90Lines)
+	}
+	if len(result.MegaCommits) == 0 {
+		fmt.Fprintln(writer, "    mega_commits: []")
+	} else {
+		fmt.Fprintln(writer, "    mega_commits:")
+		for _, mega := range result.MegaCommits {
+			fmt.Fprintf(writer, "      - {hash: %s, author: %d, tick: %d, files: %d, added: %d, removed: %d, changed: %d}\n",
+				yaml.SafeString(mega.Hash), mega.Author, mega.Tick, mega.Files, mega.Added, mega.Removed, mega.Changed)
+		}
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (cs *CommitSizeAnalysis) serializeBinary(result *CommitSizeResult, writer io.Writer) error {
+	toPB := func(histogram CommitSizeHistogram) *pb.CommitSizeHistogram {
+		return &pb.CommitSizeHistogram{
+			Commits: int32(histogram.Commits),
+			Files:   intsToInt32s(histogram.Files),
+			Lines:   intsToInt32s(histogram.Lines),
+		}
+	}
+	message := pb.CommitSizeResults{
+		People:      make(map[int32]*pb.CommitSizeHistogram, len(result.People)),
+		Ticks:       make(map[int32]*pb.CommitSizeTick, len(result.Ticks)),
+		MegaCommits: make([]*pb.MegaCommit, len(result.MegaCommits)),
+		MegaFiles:   int32(result.MegaFiles),
+		MegaLines:   int32(result.MegaLines),
+		FilesBounds: intsToInt32s(CommitSizeFilesBounds),
+		LinesBounds: intsToInt32s(CommitSizeLinesBounds),
+		DevIndex:    result.reversedPeopleDict,
+		TickSize:    int64(result.tickSize),
+	}
+	for dev, histogram := range result.People {
+		message.People[int32(dev)] = toPB(histogram)
+	}
+	for tick, stats := range result.Ticks {
+		message.Ticks[int32(tick)] = &pb.CommitSizeTick{
+			Histogram:   toPB(stats.CommitSizeHistogram),
+			MedianFiles: int32(stats.MedianFiles),
+			P90Files:    int32(stats.P90Files),
+			MedianLines: int32(stats.MedianLines),
+			P90Lines:    int32(stats.P90Lines),
+		}
+	}
+	for i, mega := range result.MegaCommits {
+		message.MegaCommits[i] = &pb.MegaCommit{
+			Hash: mega.Hash, Author: int32(mega.Author), Tick: int32(mega.Tick), Files: int32(mega.Files),
+			Added: int32(mega.Added), Removed: int32(mega.Removed), Changed: int32(mega.Changed),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&CommitSizeAnalysis{})
+}
@@ -0,0 +1,160 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitSizeMeta(t *testing.T) {
+	cs := &CommitSizeAnalysis{}
+	assert.Equal(t, "CommitSize", cs.Name())
+	assert.Equal(t, "commit-size", cs.Flag())
+	assert.Len(t, cs.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyLineStats, identity.DependencyAuthor,
+		items.DependencyTick,
+	}, cs.Requires())
+	opts := cs.ListConfigurationOptions()
+	require.Len(t, opts, 2)
+	assert.Equal(t, ConfigCommitSizeMegaFiles, opts[0].Name)
+	assert.Equal(t, DefaultCommitSizeMegaFiles, opts[0].Default)
+	assert.Equal(t, ConfigCommitSizeMegaLines, opts[1].Name)
+	assert.Equal(t, DefaultCommitSizeMegaLines, opts[1].Default)
+	require.NoError(t, cs.Configure(map[string]interface{}{
+		ConfigCommitSizeMegaFiles:                       10,
+		ConfigCommitSizeMegaLines:                       0,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice"},
+		items.FactTickSize:                              12 * time.Hour,
+	}))
+	assert.Equal(t, 10, cs.MegaFiles)
+	assert.Equal(t, 0, cs.MegaLines)
+	assert.Equal(t, []string{"Alice"}, cs.reversedPeopleDict)
+	assert.Error(t, cs.Configure(map[string]interface{}{ConfigCommitSizeMegaFiles: -1}))
+	assert.Error(t, cs.Configure(map[string]interface{}{ConfigCommitSizeMegaLines: -1}))
+	summoned := core.Registry.Summon(cs.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, cs.Name(), summoned[0].Name())
+}
+
+func fakeCommitSizeChanges(files int) object.Changes {
+	changes := make(object.Changes, files)
+	for i := range changes {
+		changes[i] = &object.Change{To: object.ChangeEntry{Name: fmt.Sprintf("file%d.go", i)}}
+	}
+	return changes
+}
+
+func TestCommitSizeConsume(t *testing.T) {
+	cs := &CommitSizeAnalysis{MegaFiles: 5, MegaLines: 1000}
+	require.NoError(t, cs.Initialize(nil))
+	index := 0
+	consume := func(author, tick, files int, stats ...items.LineStats) {
+		lineStats := map[object.ChangeEntry]items.LineStats{}
+		for i, s := range stats {
+			lineStats[object.ChangeEntry{Name: fmt.Sprintf("file%d.go", i)}] = s
+		}
+		index++
+		_, err := cs.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{Hash: plumbing.NewHash(fmt.Sprintf("%040d", index))},
+			items.DependencyTreeChanges: fakeCommitSizeChanges(files),
+			items.DependencyLineStats:   lineStats,
+			identity.DependencyAuthor:   author,
+			items.DependencyTick:        tick,
+		})
+		require.NoError(t, err)
+	}
+	consume(0, 0, 1, items.LineStats{Added: 5})
+	consume(0, 0, 2, items.LineStats{Added: 20, Removed: 10}, items.LineStats{Changed: 30})
+	consume(1, 0, 3, items.LineStats{Added: 200})
+	consume(1, 2, 6, items.LineStats{Added: 1})
+	consume(0, 2, 1, items.LineStats{Added: 600, Removed: 500})
+	// empty commits are ignored
+	consume(1, 2, 0)
+
+	result := cs.Finalize().(CommitSizeResult)
+	assert.Equal(t, map[int]CommitSizeHistogram{
+		0: {Commits: 3, Files: []int{2, 1, 0, 0, 0, 0, 0, 0}, Lines: []int{1, 0, 1, 0, 0, 0, 1, 0}},
+		1: {Commits: 2, Files: []int{0, 0, 1, 1, 0, 0, 0, 0}, Lines: []int{1, 0, 0, 1, 0, 0, 0, 0}},
+	}, result.People)
+	require.Len(t, result.Ticks, 2)
+	assert.Equal(t, CommitSizeTick{
+		CommitSizeHistogram: CommitSizeHistogram{
+			Commits: 3, Files: []int{1, 1, 1, 0, 0, 0, 0, 0}, Lines: []int{1, 0, 1, 1, 0, 0, 0, 0},
+		},
+		MedianFiles: 2, P90Files: 3, MedianLines: 60, P90Lines: 200,
+	}, result.Ticks[0])
+	assert.Equal(t, 1, result.Ticks[2].MedianFiles)
+	assert.Equal(t, 6, result.Ticks[2].P90Files)
+	assert.Equal(t, []MegaCommit{
+		{Hash: fmt.Sprintf("%040d", 4), Author: 1, Tick: 2, Files: 6, Added: 1},
+		{Hash: fmt.Sprintf("%040d", 5), Author: 0, Tick: 2, Files: 1, Added: 600, Removed: 500},
+	}, result.MegaCommits)
+}
+
+func TestCommitSizeNearestRank(t *testing.T) {
+	assert.Equal(t, 0, nearestRank(nil, 0.5))
+	assert.Equal(t, 7, nearestRank([]int{7}, 0.9))
+	assert.Equal(t, 2, nearestRank([]int{1, 2, 3, 4}, 0.5))
+	assert.Equal(t, 10, nearestRank([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0.91))
+	assert.Equal(t, 9, nearestRank([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0.9))
+}
+
+func TestCommitSizeSerialize(t *testing.T) {
+	cs := &CommitSizeAnalysis{}
+	result := CommitSizeResult{
+		People: map[int]CommitSizeHistogram{
+			0: {Commits: 1, Files: []int{1, 0, 0, 0, 0, 0, 0, 0}, Lines: []int{0, 0, 0, 0, 0, 0, 0, 1}},
+		},
+		Ticks: map[int]CommitSizeTick{
+			3: {
+				CommitSizeHistogram: CommitSizeHistogram{
+					Commits: 1, Files: []int{1, 0, 0, 0, 0, 0, 0, 0}, Lines: []int{0, 0, 0, 0, 0, 0, 0, 1},
+				},
+				MedianFiles: 1, P90Files: 1, MedianLines: 6000, P90Lines: 6000,
+			},
+		},
+		MegaCommits:        []MegaCommit{{Hash: "abc", Tick: 3, Files: 1, Added: 6000}},
+		MegaFiles:          100,
+		MegaLines:          5000,
+		reversedPeopleDict: []string{"Alice"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, cs.Serialize(result, false, buffer))
+	assert.Equal(t, `  commit_size:
+    mega_files: 100
+    mega_lines: 5000
+    files_bounds: [1, 2, 5, 10, 20, 50, 100]
+    lines_bounds: [10, 50, 100, 250, 500, 1000, 5000]
+    developers:
+      0: {commits: 1, files: [1, 0, 0, 0, 0, 0, 0, 0], lines: [0, 0, 0, 0, 0, 0, 0, 1]}
+    ticks:
+      3: {commits: 1, files: [1, 0, 0, 0, 0, 0, 0, 0], lines: [0, 0, 0, 0, 0, 0, 0, 1], median_files: 1, p90_files: 1, median_lines: 6000, p90_lines: 6000}
+    mega_commits:
+      - {hash: "abc", author: 0, tick: 3, files: 1, added: 6000, removed: 0, changed: 0}
+    people:
+    - "Alice"
+    tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, cs.Serialize(result, true, buffer))
+	restored, err := cs.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	buffer.Reset()
+	require.NoError(t, cs.Serialize(CommitSizeResult{}, false, buffer))
+	assert.Contains(t, buffer.String(), "    mega_commits: []\n")
+	assert.Error(t, cs.Serialize(nil, false, buffer))
+}
@@ -45,10 +45,14 @@ type FileStat struct {
 
 // CommitStat is the statistics for a commit
 type CommitStat struct {
-	Hash   string
-	When   int64
-	Author int
-	Files  []FileStat
+	Hash string
+	// When is the author time as a Unix timestamp.
+	When int64
+	// Committed is the committer time as a Unix timestamp. It is later than When if the commit
+	// was applied, rebased or merged as a squash after it had been authored.
+	Committed int64
+	Author    int
+	Files     []FileStat
 }
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -97,6 +101,11 @@ func (ca *CommitsAnalysis) Flag() string {
 	return "commits-stat"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ca *CommitsAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (ca *CommitsAnalysis) Description() string {
 	return "Extracts statistics for each commit. Identical to `git log --stat`"
@@ -120,9 +129,10 @@ func (ca *CommitsAnalysis) Consume(deps map[string]interface{}) (map[string]inte
 	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
 	langs := deps[items.DependencyLanguages].(map[plumbing.Hash]string)
 	cs := CommitStat{
-		Hash:   commit.Hash.String(),
-		When:   commit.Author.When.Unix(),
-		Author: author,
+		Hash:      commit.Hash.String(),
+		When:      commit.Author.When.Unix(),
+		Committed: commit.Committer.When.Unix(),
+		Author:    author,
 	}
 	for entry, stats := range lineStats {
 		cs.Files = append(cs.Files, FileStat{
@@ -164,6 +174,7 @@ func (ca *CommitsAnalysis) serializeText(result *CommitsResult, writer io.Writer
 	for _, c := range result.Commits {
 		fmt.Fprintf(writer, "    - hash: %s\n", c.Hash)
 		fmt.Fprintf(writer, "      when: %d\n", c.When)
+		fmt.Fprintf(writer, "      committed: %d\n", c.Committed)
 		fmt.Fprintf(writer, "      author: %d\n", c.Author)
 		fmt.Fprintf(writer, "      files:\n")
 		for _, f := range c.Files {
@@ -188,19 +199,16 @@ func (ca *CommitsAnalysis) serializeBinary(result *CommitsResult, writer io.Writ
 			files[i] = &pb.CommitFile{
 				Name:     f.Name,
 				Language: f.Language,
-				Stats: &pb.LineStats{
-					Added:   int32(f.LineStats.Added),
-					Changed: int32(f.LineStats.Changed),
-					Removed: int32(f.LineStats.Removed),
-				},
+				Stats:    lineStatsToPb(f.LineStats),
 			}
 		}
 
 		message.Commits[i] = &pb.Commit{
-			Hash:         c.Hash,
-			WhenUnixTime: c.When,
-			Author:       int32(c.Author),
-			Files:        files,
+			Hash:              c.Hash,
+			WhenUnixTime:      c.When,
+			CommittedUnixTime: c.Committed,
+			Author:            int32(c.Author),
+			Files:             files,
 		}
 	}
 	serialized, err := proto.Marshal(&message)
@@ -165,9 +165,10 @@ func fixtureCommits() *CommitsAnalysis {
 	ca.Initialize(test.Repository)
 	ca.commits = []*CommitStat{
 		{
-			Hash:   "cce947b98a050c6d356bc6ba95030254914027b1",
-			When:   1481563829,
-			Author: 0,
+			Hash:      "cce947b98a050c6d356bc6ba95030254914027b1",
+			When:      1481563829,
+			Committed: 1481563829,
+			Author:    0,
 			Files: []FileStat{
 				{
 					Name:     ".travis.yml",
@@ -190,9 +191,10 @@ func fixtureCommits() *CommitsAnalysis {
 			},
 		},
 		{
-			Hash:   "c29112dbd697ad9b401333b80c18a63951bc18d9",
-			When:   1481563999,
-			Author: 1,
+			Hash:      "c29112dbd697ad9b401333b80c18a63951bc18d9",
+			When:      1481563999,
+			Committed: 1481650399,
+			Author:    1,
 			Files: []FileStat{
 				{
 					Name:     "cmd/hercules/main.go",
@@ -228,6 +230,7 @@ func TestCommitsSerialize(t *testing.T) {
 	assert.Equal(t, `  commits:
     - hash: cce947b98a050c6d356bc6ba95030254914027b1
       when: 1481563829
+      committed: 1481563829
       author: 0
       files:
        - name: .travis.yml
@@ -238,6 +241,7 @@ func TestCommitsSerialize(t *testing.T) {
          stat: [628, 67, 9]
     - hash: c29112dbd697ad9b401333b80c18a63951bc18d9
       when: 1481563999
+      committed: 1481650399
       author: 1
       files:
        - name: cmd/hercules/main.go
@@ -271,6 +275,7 @@ func TestCommitsSerialize(t *testing.T) {
 	})
 	assert.Equal(t, msg.Commits[1].Hash, "c29112dbd697ad9b401333b80c18a63951bc18d9")
 	assert.Equal(t, msg.Commits[1].WhenUnixTime, int64(1481563999))
+	assert.Equal(t, msg.Commits[1].CommittedUnixTime, int64(1481650399))
 	assert.Equal(t, msg.Commits[1].Author, int32(1))
 	assert.Len(t, msg.Commits[1].Files, 1)
 	assert.Equal(t, msg.Commits[1].Files[0], &pb.CommitFile{
@@ -0,0 +1,479 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/join"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigContributionDiversityInternalOrganizations is the name of the option to set
+	// ContributionDiversityAnalysis.InternalOrganizations.
+	ConfigContributionDiversityInternalOrganizations = "ContributionDiversity.InternalOrganizations"
+)
+
+// internalOrganizationsOption is shared by the analyses which distinguish the external contributors.
+var internalOrganizationsOption = core.ConfigurationOption{
+	Name: ConfigContributionDiversityInternalOrganizations,
+	Description: "Organizations of the maintainers, e.g. 'example.com'; the other developers " +
+		"are external contributors. Separated with commas \",\".",
+	Flag:    "internal-organizations",
+	Type:    core.StringsConfigurationOption,
+	Shared:  true,
+	Default: []string{},
+}
+
+// ContributionDiversityAnalysis calculates the CHAOSS-style contributor diversity metrics
+// per calendar quarter: the number of contributors by organization and by the first-time status,
+// the elephant factor - the smallest number of organizations which make 50% of the commits -
+// and the retention of the external contributors from the previous quarter.
+// The organizations are inferred from the emails the same way as with --organizations.
+type ContributionDiversityAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// InternalOrganizations are the organizations of the maintainers. The developers from
+	// the other organizations are the external contributors.
+	InternalOrganizations []string
+
+	// commits maps quarters to developers to the number of commits.
+	commits map[int]map[int]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations.
+	organizations []string
+	// commitAuthors references IdentityDetector.CommitAuthors.
+	commitAuthors map[plumbing.Hash][]identity.CommitAuthor
+
+	l core.Logger
+}
+
+// ContributionDiversityResult is returned by ContributionDiversityAnalysis.Finalize().
+type ContributionDiversityResult struct {
+	// Commits maps quarters (year * 4 + quarter - 1) to developers to the number of commits.
+	Commits map[int]map[int]int
+	// InternalOrganizations are the organizations whose developers are not external.
+	InternalOrganizations []string
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// organizations maps developer indexes to their organizations.
+	organizations []string
+}
+
+// QuarterDiversity is the contributor diversity in a calendar quarter.
+type QuarterDiversity struct {
+	// Quarter is year * 4 + quarter - 1.
+	Quarter int
+	// Commits is the number of commits in the quarter.
+	Commits int
+	// Contributors is the number of developers who committed in the quarter.
+	Contributors int
+	// FirstTime is the number of developers whose first commit is in the quarter.
+	FirstTime int
+	// External is the number of contributors outside the internal organizations.
+	External int
+	// FirstTimeExternal is the number of first-time contributors outside the internal organizations.
+	FirstTimeExternal int
+	// PreviousExternal is the number of external contributors in the previous quarter.
+	PreviousExternal int
+	// RetainedExternal is the number of PreviousExternal who committed in this quarter, too.
+	RetainedExternal int
+	// ElephantFactor is the smallest number of organizations which made 50% of the commits.
+	ElephantFactor int
+	// Organizations maps organizations to the number of contributors.
+	Organizations map[string]int
+}
+
+// Retention returns the share of the external contributors from the previous quarter
+// who stayed active.
+func (quarter QuarterDiversity) Retention() float64 {
+	if quarter.PreviousExternal == 0 {
+		return 0
+	}
+	return float64(quarter.RetainedExternal) / float64(quarter.PreviousExternal)
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cd *ContributionDiversityAnalysis) Name() string {
+	return "ContributionDiversity"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (cd *ContributionDiversityAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (cd *ContributionDiversityAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cd *ContributionDiversityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{internalOrganizationsOption}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cd *ContributionDiversityAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		cd.l = l
+	}
+	if val, exists := facts[ConfigContributionDiversityInternalOrganizations].([]string); exists {
+		cd.InternalOrganizations = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		cd.reversedPeopleDict = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorCommitAuthors].(map[plumbing.Hash][]identity.CommitAuthor); exists {
+		cd.commitAuthors = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorOrganizations].([]string); exists {
+		cd.organizations = val
+	} else if resolver, exists := facts[core.FactIdentityResolver].(core.IdentityResolver); exists {
+		// the organizations are not enabled, infer them without the custom mapping
+		cd.organizations = make([]string, resolver.Count())
+		for i := range cd.organizations {
+			cd.organizations[i] = identity.DetectOrganization(
+				resolver.PrivateNameOf(core.AuthorId(i)), nil)
+		}
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*ContributionDiversityAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cd *ContributionDiversityAnalysis) Flag() string {
+	return "contribution-diversity"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (cd *ContributionDiversityAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cd *ContributionDiversityAnalysis) Description() string {
+	return "Calculates the contributor counts by organization and first-time status, " +
+		"the elephant factor and the retention of the external contributors per quarter."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cd *ContributionDiversityAnalysis) Initialize(repository *git.Repository) error {
+	cd.l = core.NewLogger()
+	cd.commits = map[int]map[int]int{}
+	cd.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cd *ContributionDiversityAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cd.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	authors := []identity.CommitAuthor{{ID: author, Share: 1}}
+	if val, exists := cd.commitAuthors[commit.Hash]; exists {
+		authors = val
+	}
+	when := commit.Author.When.UTC()
+	quarter := when.Year()*4 + (int(when.Month())-1)/3
+	for _, coauthor := range authors {
+		if coauthor.ID == core.AuthorMissing {
+			continue
+		}
+		devs := cd.commits[quarter]
+		if devs == nil {
+			devs = map[int]int{}
+			cd.commits[quarter] = devs
+		}
+		devs[coauthor.ID]++
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cd *ContributionDiversityAnalysis) Finalize() interface{} {
+	return ContributionDiversityResult{
+		Commits:               cd.commits,
+		InternalOrganizations: cd.InternalOrganizations,
+		reversedPeopleDict:    cd.reversedPeopleDict,
+		organizations:         cd.organizations,
+	}
+}
+
+// Fork clones this pipeline item.
+func (cd *ContributionDiversityAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cd, n)
+}
+
+// organizationOf returns the organization of the developer.
+func (result *ContributionDiversityResult) organizationOf(dev int) string {
+	if dev < 0 || dev >= len(result.organizations) || result.organizations[dev] == "" {
+		return identity.OrganizationUnknown
+	}
+	return result.organizations[dev]
+}
+
+// Quarters calculates the diversity metrics for each quarter from the first to the last,
+// including the quarters without commits.
+func (result *ContributionDiversityResult) Quarters() []QuarterDiversity {
+	if len(result.Commits) == 0 {
+		return nil
+	}
+	internal := map[string]bool{}
+	for _, org := range result.InternalOrganizations {
+		internal[org] = true
+	}
+	first, last := -1, -1
+	for quarter := range result.Commits {
+		if first < 0 || quarter < first {
+			first = quarter
+		}
+		if quarter > last {
+			last = quarter
+		}
+	}
+	seen := map[int]bool{}
+	var previous map[int]int
+	quarters := make([]QuarterDiversity, 0, last-first+1)
+	for quarter := first; quarter <= last; quarter++ {
+		devs := result.Commits[quarter]
+		diversity := QuarterDiversity{
+			Quarter:       quarter,
+			Contributors:  len(devs),
+			Organizations: map[string]int{},
+		}
+		orgCommits := map[string]int{}
+		for dev, commits := range devs {
+			org := result.organizationOf(dev)
+			external := !internal[org]
+			diversity.Commits += commits
+			diversity.Organizations[org]++
+			orgCommits[org] += commits
+			if !seen[dev] {
+				seen[dev] = true
+				diversity.FirstTime++
+				if external {
+					diversity.FirstTimeExternal++
+				}
+			}
+			if external {
+				diversity.External++
+			}
+		}
+		for dev := range previous {
+			if internal[result.organizationOf(dev)] {
+				continue
+			}
+			diversity.PreviousExternal++
+			if _, exists := devs[dev]; exists {
+				diversity.RetainedExternal++
+			}
+		}
+		diversity.ElephantFactor = elephantFactor(orgCommits)
+		quarters = append(quarters, diversity)
+		previous = devs
+	}
+	return quarters
+}
+
+// ElephantFactor calculates the elephant factor over the whole history.
+func (result *ContributionDiversityResult) ElephantFactor() int {
+	orgCommits := map[string]int{}
+	for _, devs := range result.Commits {
+		for dev, commits := range devs {
+			orgCommits[result.organizationOf(dev)] += commits
+		}
+	}
+	return elephantFactor(orgCommits)
+}
+
+// elephantFactor returns the smallest number of organizations which made at least half
+// of the commits. The independent developers and the unknown organizations are not companies,
+// so their commits are excluded.
+func elephantFactor(orgCommits map[string]int) int {
+	counts := make([]int, 0, len(orgCommits))
+	total := 0
+	for org, commits := range orgCommits {
+		if org == identity.OrganizationIndependent || org == identity.OrganizationUnknown {
+			continue
+		}
+		counts = append(counts, commits)
+		total += commits
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+	sum := 0
+	for i, commits := range counts {
+		sum += commits
+		if 2*sum >= total {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// formatQuarter converts year * 4 + quarter - 1 to "2024-Q1".
+func formatQuarter(quarter int) string {
+	return fmt.Sprintf("%d-Q%d", quarter/4, quarter%4+1)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cd *ContributionDiversityAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	diversityResult, ok := result.(ContributionDiversityResult)
+	if !ok {
+		return fmt.Errorf("result is not a contribution diversity result: '%v'", result)
+	}
+	if binary {
+		return cd.serializeBinary(&diversityResult, writer)
+	}
+	cd.serializeText(&diversityResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to ContributionDiversityResult.
+func (cd *ContributionDiversityAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ContributionDiversityResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := ContributionDiversityResult{
+		Commits:               make(map[int]map[int]int, len(message.Quarters)),
+		InternalOrganizations: message.InternalOrganizations,
+		reversedPeopleDict:    message.DevIndex,
+		organizations:         message.Organizations,
+	}
+	for quarter, devs := range message.Quarters {
+		commits := make(map[int]int, len(devs.GetCommits()))
+		for dev, count := range devs.GetCommits() {
+			commits[int(dev)] = int(count)
+		}
+		result.Commits[int(quarter)] = commits
+	}
+	return result, nil
+}
+
+// MergeResults combines two ContributionDiversityResult-s together.
+func (cd *ContributionDiversityAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult,
+) interface{} {
+	cdr1 := r1.(ContributionDiversityResult)
+	cdr2 := r2.(ContributionDiversityResult)
+	merged := ContributionDiversityResult{Commits: map[int]map[int]int{}}
+	var mergedIndex map[string]join.JoinedIndex
+	mergedIndex, merged.reversedPeopleDict = join.PeopleIdentities(
+		cdr1.reversedPeopleDict, cdr2.reversedPeopleDict)
+	merged.organizations = make([]string, len(merged.reversedPeopleDict))
+	internal := map[string]bool{}
+	for _, result := range [...]*ContributionDiversityResult{&cdr1, &cdr2} {
+		for quarter, devs := range result.Commits {
+			mergedDevs := merged.Commits[quarter]
+			if mergedDevs == nil {
+				mergedDevs = map[int]int{}
+				merged.Commits[quarter] = mergedDevs
+			}
+			for dev, commits := range devs {
+				if dev < 0 || dev >= len(result.reversedPeopleDict) {
+					continue
+				}
+				mergedDevs[mergedIndex[result.reversedPeopleDict[dev]].Final] += commits
+			}
+		}
+		for dev, person := range result.reversedPeopleDict {
+			if final := mergedIndex[person].Final; merged.organizations[final] == "" {
+				merged.organizations[final] = result.organizationOf(dev)
+			}
+		}
+		for _, org := range result.InternalOrganizations {
+			if !internal[org] {
+				internal[org] = true
+				merged.InternalOrganizations = append(merged.InternalOrganizations, org)
+			}
+		}
+	}
+	return merged
+}
+
+func (cd *ContributionDiversityAnalysis) serializeText(result *ContributionDiversityResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  contribution_diversity:")
+	fmt.Fprintf(writer, "    elephant_factor: %d\n", result.ElephantFactor())
+	internal := make([]string, len(result.InternalOrganizations))
+	for i, org := range result.InternalOrganizations {
+		internal[i] = yaml.SafeString(org)
+	}
+	fmt.Fprintf(writer, "    internal_organizations: [%s]\n", strings.Join(internal, ", "))
+	fmt.Fprintln(writer, "    quarters:")
+	for _, quarter := range result.Quarters() {
+		orgs := make([]string, 0, len(quarter.Organizations))
+		for org := range quarter.Organizations {
+			orgs = append(orgs, org)
+		}
+		sort.Strings(orgs)
+		for i, org := range orgs {
+			orgs[i] = fmt.Sprintf("%s: %d", yaml.SafeString(org), quarter.Organizations[org])
+		}
+		fmt.Fprintf(writer, "      %s: {commits: %d, contributors: %d, first_time: %d, "+
+			"external: %d, first_time_external: %d, retained_external: %d, previous_external: %d, "+
+			"retention: %.4f, elephant_factor: %d, organizations: {%s}}\n",
+			formatQuarter(quarter.Quarter), quarter.Commits, quarter.Contributors, quarter.FirstTime,
+			quarter.External, quarter.FirstTimeExternal, quarter.RetainedExternal,
+			quarter.PreviousExternal, quarter.Retention(), quarter.ElephantFactor,
+			strings.Join(orgs, ", "))
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    organizations:")
+	for dev := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(result.organizationOf(dev)))
+	}
+}
+
+func (cd *ContributionDiversityAnalysis) serializeBinary(result *ContributionDiversityResult, writer io.Writer) error {
+	message := pb.ContributionDiversityResults{
+		Quarters:              make(map[int32]*pb.DiversityQuarter, len(result.Commits)),
+		DevIndex:              result.reversedPeopleDict,
+		Organizations:         result.organizations,
+		InternalOrganizations: result.InternalOrganizations,
+	}
+	for quarter, devs := range result.Commits {
+		commits := make(map[int32]int32, len(devs))
+		for dev, count := range devs {
+			commits[int32(dev)] = int32(count)
+		}
+		message.Quarters[int32(quarter)] = &pb.DiversityQuarter{Commits: commits}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&ContributionDiversityAnalysis{})
+}
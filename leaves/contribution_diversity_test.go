@@ -0,0 +1,186 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixtureIdentityResolver struct {
+	core.IdentityResolver
+	names []string
+}
+
+func (r fixtureIdentityResolver) Count() int { return len(r.names) }
+
+func (r fixtureIdentityResolver) PrivateNameOf(id core.AuthorId) string { return r.names[id] }
+
+func TestContributionDiversityMeta(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	assert.Equal(t, "ContributionDiversity", cd.Name())
+	assert.Equal(t, "contribution-diversity", cd.Flag())
+	assert.Len(t, cd.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor}, cd.Requires())
+	opts := cd.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigContributionDiversityInternalOrganizations, opts[0].Name)
+	assert.Equal(t, "internal-organizations", opts[0].Flag)
+	summoned := core.Registry.Summon(cd.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, cd.Name(), summoned[0].Name())
+}
+
+func TestContributionDiversityConfigure(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	require.NoError(t, cd.Configure(map[string]interface{}{
+		ConfigContributionDiversityInternalOrganizations: []string{"acme.com"},
+		identity.FactIdentityDetectorReversedPeopleDict:  []string{"Alice", "Bob"},
+		core.FactIdentityResolver: fixtureIdentityResolver{
+			names: []string{"alice|alice@acme.com", "bob|bob@gmail.com"},
+		},
+	}))
+	assert.Equal(t, []string{"acme.com"}, cd.InternalOrganizations)
+	assert.Equal(t, []string{"Alice", "Bob"}, cd.reversedPeopleDict)
+	// inferred without --organizations
+	assert.Equal(t, []string{"acme.com", identity.OrganizationIndependent}, cd.organizations)
+	require.NoError(t, cd.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorOrganizations: []string{"Acme"},
+	}))
+	assert.Equal(t, []string{"Acme"}, cd.organizations)
+}
+
+func TestContributionDiversityConsume(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	require.NoError(t, cd.Initialize(nil))
+	consume := func(author int, when string) {
+		date, err := time.Parse(time.RFC3339, when)
+		require.NoError(t, err)
+		commit := &object.Commit{Author: object.Signature{When: date}}
+		_, err = cd.Consume(map[string]interface{}{
+			core.DependencyCommit:     commit,
+			identity.DependencyAuthor: author,
+		})
+		require.NoError(t, err)
+	}
+	consume(0, "2024-01-15T10:00:00Z")
+	consume(0, "2024-03-31T23:00:00-02:00")
+	consume(1, "2024-12-31T12:00:00Z")
+	consume(core.AuthorMissing, "2024-12-31T12:00:00Z")
+	result := cd.Finalize().(ContributionDiversityResult)
+	assert.Equal(t, map[int]map[int]int{
+		2024 * 4:   {0: 1},
+		2024*4 + 1: {0: 1},
+		2024*4 + 3: {1: 1},
+	}, result.Commits)
+}
+
+func TestContributionDiversityConsumeCoAuthors(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	commit := &object.Commit{
+		Hash:   plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48"),
+		Author: object.Signature{When: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	require.NoError(t, cd.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorCommitAuthors: map[plumbing.Hash][]identity.CommitAuthor{
+			commit.Hash: {{ID: 0, Share: 0.5}, {ID: 2, Share: 0.5}},
+		},
+	}))
+	require.NoError(t, cd.Initialize(nil))
+	_, err := cd.Consume(map[string]interface{}{
+		core.DependencyCommit:     commit,
+		identity.DependencyAuthor: 0,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]map[int]int{2024*4 + 1: {0: 1, 2: 1}}, cd.Finalize().(ContributionDiversityResult).Commits)
+}
+
+func fixtureContributionDiversityResult() ContributionDiversityResult {
+	return ContributionDiversityResult{
+		Commits: map[int]map[int]int{
+			2024 * 4:   {0: 5, 1: 3, 2: 1},
+			2024*4 + 2: {0: 2, 2: 4, 3: 1},
+		},
+		InternalOrganizations: []string{"acme.com"},
+		reversedPeopleDict:    []string{"alice", "bob", "carol", "dave"},
+		organizations:         []string{"acme.com", "other.org", identity.OrganizationIndependent, ""},
+	}
+}
+
+func TestContributionDiversityQuarters(t *testing.T) {
+	result := fixtureContributionDiversityResult()
+	quarters := result.Quarters()
+	require.Len(t, quarters, 3)
+	assert.Equal(t, QuarterDiversity{
+		Quarter: 2024 * 4, Commits: 9, Contributors: 3, FirstTime: 3, External: 2,
+		FirstTimeExternal: 2, ElephantFactor: 1,
+		Organizations: map[string]int{"acme.com": 1, "other.org": 1, identity.OrganizationIndependent: 1},
+	}, quarters[0])
+	assert.Equal(t, QuarterDiversity{
+		Quarter: 2024*4 + 1, PreviousExternal: 2, Organizations: map[string]int{},
+	}, quarters[1])
+	assert.Equal(t, QuarterDiversity{
+		Quarter: 2024*4 + 2, Commits: 7, Contributors: 3, FirstTime: 1, External: 2,
+		FirstTimeExternal: 1, ElephantFactor: 1,
+		Organizations: map[string]int{
+			"acme.com": 1, identity.OrganizationIndependent: 1, identity.OrganizationUnknown: 1,
+		},
+	}, quarters[2])
+	assert.Equal(t, 0.0, quarters[1].Retention())
+	assert.Equal(t, 0.5, QuarterDiversity{PreviousExternal: 2, RetainedExternal: 1}.Retention())
+	assert.Equal(t, 1, result.ElephantFactor())
+	assert.Nil(t, (&ContributionDiversityResult{}).Quarters())
+}
+
+func TestElephantFactor(t *testing.T) {
+	assert.Equal(t, 0, elephantFactor(nil))
+	assert.Equal(t, 0, elephantFactor(map[string]int{identity.OrganizationIndependent: 10}))
+	assert.Equal(t, 1, elephantFactor(map[string]int{"a": 5, "b": 5}))
+	assert.Equal(t, 2, elephantFactor(map[string]int{"a": 4, "b": 3, "c": 3, identity.OrganizationUnknown: 100}))
+}
+
+func TestContributionDiversitySerialize(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	result := fixtureContributionDiversityResult()
+	buffer := &bytes.Buffer{}
+	require.NoError(t, cd.Serialize(result, false, buffer))
+	text := buffer.String()
+	assert.Contains(t, text, "    elephant_factor: 1\n    internal_organizations: [\"acme.com\"]\n")
+	assert.Contains(t, text, "      2024-Q2: {commits: 0, contributors: 0, first_time: 0, external: 0, "+
+		"first_time_external: 0, retained_external: 0, previous_external: 2, retention: 0.0000, "+
+		"elephant_factor: 0, organizations: {}}\n")
+	assert.Contains(t, text, "organizations: {\"acme.com\": 1, \"independent\": 1, \"other.org\": 1}}\n")
+	assert.Contains(t, text, "    organizations:\n    - \"acme.com\"\n    - \"other.org\"\n"+
+		"    - \"independent\"\n    - \"unknown\"\n")
+	assert.Error(t, cd.Serialize(nil, false, buffer))
+
+	buffer.Reset()
+	require.NoError(t, cd.Serialize(result, true, buffer))
+	deserialized, err := cd.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestContributionDiversityMerge(t *testing.T) {
+	cd := &ContributionDiversityAnalysis{}
+	r1 := fixtureContributionDiversityResult()
+	r2 := ContributionDiversityResult{
+		Commits:               map[int]map[int]int{2024 * 4: {0: 1, 1: 2}},
+		InternalOrganizations: []string{"acme.com", "partner.com"},
+		reversedPeopleDict:    []string{"eve", "alice"},
+		organizations:         []string{"partner.com", "acme.com"},
+	}
+	merged := cd.MergeResults(r1, r2, nil, nil).(ContributionDiversityResult)
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave", "eve"}, merged.reversedPeopleDict)
+	assert.Equal(t, []string{"acme.com", "other.org", identity.OrganizationIndependent,
+		identity.OrganizationUnknown, "partner.com"}, merged.organizations)
+	assert.Equal(t, []string{"acme.com", "partner.com"}, merged.InternalOrganizations)
+	assert.Equal(t, map[int]int{0: 7, 1: 3, 2: 1, 4: 1}, merged.Commits[2024*4])
+	assert.Equal(t, map[int]int{0: 2, 2: 4, 3: 1}, merged.Commits[2024*4+2])
+}
@@ -0,0 +1,498 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/join"
+	"github.com/meko-christian/hercules/internal/pb"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigContributionFunnelMilestones is the name of the option to set
+	// ContributionFunnelAnalysis.Milestones.
+	ConfigContributionFunnelMilestones = "ContributionFunnel.Milestones"
+)
+
+// DefaultContributionFunnelMilestones are the default funnel steps: the 2nd, the 5th
+// and the 10th contribution.
+var DefaultContributionFunnelMilestones = []int{2, 5, 10}
+
+// ContributionFunnelAnalysis follows the external contributors from their first contribution:
+// per monthly cohort of the first-time contributors, it counts how many reach each milestone,
+// e.g. the 2nd, the 5th and the 10th contribution, and how long it takes them to get there.
+// The developers from InternalOrganizations are excluded, the same way as with
+// --contribution-diversity.
+type ContributionFunnelAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Milestones are the numbers of contributions which make the funnel steps, in the ascending order.
+	Milestones []int
+	// InternalOrganizations are the organizations of the maintainers.
+	InternalOrganizations []string
+
+	// contributions maps developers to the Unix times of their first contributions.
+	contributions map[int][]int64
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations.
+	organizations []string
+	// commitAuthors references IdentityDetector.CommitAuthors.
+	commitAuthors map[plumbing.Hash][]identity.CommitAuthor
+
+	l core.Logger
+}
+
+// ContributionFunnelResult is returned by ContributionFunnelAnalysis.Finalize().
+type ContributionFunnelResult struct {
+	// Contributions maps developers to the Unix times of their first contributions
+	// in the ascending order, up to the largest milestone.
+	Contributions map[int][]int64
+	// Milestones are the numbers of contributions which make the funnel steps.
+	Milestones []int
+	// InternalOrganizations are the organizations whose developers are not external.
+	InternalOrganizations []string
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// organizations maps developer indexes to their organizations.
+	organizations []string
+}
+
+// FunnelCohort is the contribution funnel of the external developers who started
+// in the same month.
+type FunnelCohort struct {
+	// Cohort is the month of the first contributions, e.g. "2024-01", or "total".
+	Cohort string
+	// Contributors is the number of the first-time contributors in the cohort.
+	Contributors int
+	// Reached is the number of contributors who made at least Milestones[i] contributions.
+	Reached []int
+	// MedianDays is the median number of days between the previous milestone - or the first
+	// contribution - and Milestones[i] among those who reached it.
+	MedianDays []float64
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (cf *ContributionFunnelAnalysis) Name() string {
+	return "ContributionFunnel"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (cf *ContributionFunnelAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (cf *ContributionFunnelAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (cf *ContributionFunnelAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	milestones := make([]string, len(DefaultContributionFunnelMilestones))
+	for i, milestone := range DefaultContributionFunnelMilestones {
+		milestones[i] = strconv.Itoa(milestone)
+	}
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigContributionFunnelMilestones,
+		Description: "Numbers of contributions which make the steps of the contribution funnel. " +
+			"Separated with commas \",\".",
+		Flag:    "funnel-milestones",
+		Type:    core.StringsConfigurationOption,
+		Default: milestones,
+	}, internalOrganizationsOption}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (cf *ContributionFunnelAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		cf.l = l
+	}
+	if val, exists := facts[ConfigContributionFunnelMilestones].([]string); exists {
+		milestones, err := parseFunnelMilestones(val)
+		if err != nil {
+			return err
+		}
+		cf.Milestones = milestones
+	}
+	if val, exists := facts[ConfigContributionDiversityInternalOrganizations].([]string); exists {
+		cf.InternalOrganizations = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		cf.reversedPeopleDict = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorCommitAuthors].(map[plumbing.Hash][]identity.CommitAuthor); exists {
+		cf.commitAuthors = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorOrganizations].([]string); exists {
+		cf.organizations = val
+	} else if resolver, exists := facts[core.FactIdentityResolver].(core.IdentityResolver); exists {
+		// the organizations are not enabled, infer them without the custom mapping
+		cf.organizations = make([]string, resolver.Count())
+		for i := range cf.organizations {
+			cf.organizations[i] = identity.DetectOrganization(
+				resolver.PrivateNameOf(core.AuthorId(i)), nil)
+		}
+	}
+	return nil
+}
+
+// parseFunnelMilestones converts the milestones to the sorted unique numbers greater than 1.
+func parseFunnelMilestones(values []string) ([]int, error) {
+	seen := map[int]bool{}
+	milestones := make([]int, 0, len(values))
+	for _, value := range values {
+		milestone, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || milestone < 2 {
+			return nil, fmt.Errorf("invalid contribution funnel milestone %q: must be an integer "+
+				"greater than 1", value)
+		}
+		if !seen[milestone] {
+			seen[milestone] = true
+			milestones = append(milestones, milestone)
+		}
+	}
+	if len(milestones) == 0 {
+		return nil, fmt.Errorf("at least one contribution funnel milestone is required")
+	}
+	sort.Ints(milestones)
+	return milestones, nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*ContributionFunnelAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (cf *ContributionFunnelAnalysis) Flag() string {
+	return "contribution-funnel"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (cf *ContributionFunnelAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (cf *ContributionFunnelAnalysis) Description() string {
+	return "Counts how many of the first-time external contributors in each monthly cohort " +
+		"make the 2nd, 5th, 10th contribution and how long it takes them."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (cf *ContributionFunnelAnalysis) Initialize(repository *git.Repository) error {
+	cf.l = core.NewLogger()
+	if len(cf.Milestones) == 0 {
+		cf.Milestones = DefaultContributionFunnelMilestones
+	}
+	cf.contributions = map[int][]int64{}
+	cf.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (cf *ContributionFunnelAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !cf.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	authors := []identity.CommitAuthor{{ID: author, Share: 1}}
+	if val, exists := cf.commitAuthors[commit.Hash]; exists {
+		authors = val
+	}
+	when := commit.Author.When.Unix()
+	limit := cf.Milestones[len(cf.Milestones)-1]
+	for _, coauthor := range authors {
+		if coauthor.ID == core.AuthorMissing {
+			continue
+		}
+		cf.contributions[coauthor.ID] = insertFunnelContribution(
+			cf.contributions[coauthor.ID], when, limit)
+	}
+	return nil, nil
+}
+
+// insertFunnelContribution adds the contribution time to the sorted series and keeps
+// only the earliest limit times.
+func insertFunnelContribution(times []int64, when int64, limit int) []int64 {
+	index := sort.Search(len(times), func(i int) bool { return times[i] > when })
+	if index >= limit {
+		return times
+	}
+	times = append(times, 0)
+	copy(times[index+1:], times[index:])
+	times[index] = when
+	if len(times) > limit {
+		times = times[:limit]
+	}
+	return times
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (cf *ContributionFunnelAnalysis) Finalize() interface{} {
+	return ContributionFunnelResult{
+		Contributions:         cf.contributions,
+		Milestones:            cf.Milestones,
+		InternalOrganizations: cf.InternalOrganizations,
+		reversedPeopleDict:    cf.reversedPeopleDict,
+		organizations:         cf.organizations,
+	}
+}
+
+// Fork clones this pipeline item.
+func (cf *ContributionFunnelAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(cf, n)
+}
+
+// isExternal returns whether the developer is an external contributor. The collapsed
+// occasional developers are not a single person, so they are excluded.
+func (result *ContributionFunnelResult) isExternal(dev int, internal map[string]bool) bool {
+	if dev >= 0 && dev < len(result.reversedPeopleDict) &&
+		result.reversedPeopleDict[dev] == identity.OthersName {
+		return false
+	}
+	org := identity.OrganizationUnknown
+	if dev >= 0 && dev < len(result.organizations) && result.organizations[dev] != "" {
+		org = result.organizations[dev]
+	}
+	return !internal[org]
+}
+
+// externalContributors groups the external developers by the month of their first contribution.
+func (result *ContributionFunnelResult) externalContributors() map[string][]int {
+	internal := map[string]bool{}
+	for _, org := range result.InternalOrganizations {
+		internal[org] = true
+	}
+	cohorts := map[string][]int{}
+	for dev, times := range result.Contributions {
+		if len(times) == 0 || !result.isExternal(dev, internal) {
+			continue
+		}
+		cohort := time.Unix(times[0], 0).UTC().Format("2006-01")
+		cohorts[cohort] = append(cohorts[cohort], dev)
+	}
+	return cohorts
+}
+
+// funnel calculates the FunnelCohort of the specified developers.
+func (result *ContributionFunnelResult) funnel(name string, devs []int) FunnelCohort {
+	cohort := FunnelCohort{
+		Cohort:       name,
+		Contributors: len(devs),
+		Reached:      make([]int, len(result.Milestones)),
+		MedianDays:   make([]float64, len(result.Milestones)),
+	}
+	for i, milestone := range result.Milestones {
+		previous := 1
+		if i > 0 {
+			previous = result.Milestones[i-1]
+		}
+		var days []int
+		for _, dev := range devs {
+			times := result.Contributions[dev]
+			if len(times) < milestone {
+				continue
+			}
+			days = append(days, int((times[milestone-1]-times[previous-1])/(24*3600)))
+		}
+		cohort.Reached[i] = len(days)
+		if len(days) > 0 {
+			cohort.MedianDays[i] = medianInt(days)
+		}
+	}
+	return cohort
+}
+
+// Cohorts calculates the funnels of the external first-time contributors per month
+// of the first contribution, in the chronological order.
+func (result *ContributionFunnelResult) Cohorts() []FunnelCohort {
+	cohorts := result.externalContributors()
+	names := make([]string, 0, len(cohorts))
+	for name := range cohorts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	funnels := make([]FunnelCohort, len(names))
+	for i, name := range names {
+		funnels[i] = result.funnel(name, cohorts[name])
+	}
+	return funnels
+}
+
+// Total calculates the funnel of all the external contributors.
+func (result *ContributionFunnelResult) Total() FunnelCohort {
+	var devs []int
+	for _, cohort := range result.externalContributors() {
+		devs = append(devs, cohort...)
+	}
+	return result.funnel("total", devs)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (cf *ContributionFunnelAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	funnelResult, ok := result.(ContributionFunnelResult)
+	if !ok {
+		return fmt.Errorf("result is not a contribution funnel result: '%v'", result)
+	}
+	if binary {
+		return cf.serializeBinary(&funnelResult, writer)
+	}
+	cf.serializeText(&funnelResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to ContributionFunnelResult.
+func (cf *ContributionFunnelAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ContributionFunnelResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := ContributionFunnelResult{
+		Contributions:         make(map[int][]int64, len(message.Contributions)),
+		Milestones:            make([]int, 0, len(message.Milestones)),
+		InternalOrganizations: message.InternalOrganizations,
+		reversedPeopleDict:    message.DevIndex,
+		organizations:         message.Organizations,
+	}
+	for _, milestone := range message.Milestones {
+		if milestone < 2 {
+			return nil, fmt.Errorf("invalid contribution funnel milestone %d", milestone)
+		}
+		result.Milestones = append(result.Milestones, int(milestone))
+	}
+	sort.Ints(result.Milestones)
+	for dev, contributions := range message.Contributions {
+		result.Contributions[int(dev)] = contributions.GetTimes()
+	}
+	return result, nil
+}
+
+// MergeResults combines two ContributionFunnelResult-s together.
+func (cf *ContributionFunnelAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult,
+) interface{} {
+	cfr1 := r1.(ContributionFunnelResult)
+	cfr2 := r2.(ContributionFunnelResult)
+	merged := ContributionFunnelResult{Contributions: map[int][]int64{}}
+	var mergedIndex map[string]join.JoinedIndex
+	mergedIndex, merged.reversedPeopleDict = join.PeopleIdentities(
+		cfr1.reversedPeopleDict, cfr2.reversedPeopleDict)
+	merged.organizations = make([]string, len(merged.reversedPeopleDict))
+	milestones := map[int]bool{}
+	internal := map[string]bool{}
+	for _, result := range [...]*ContributionFunnelResult{&cfr1, &cfr2} {
+		for _, milestone := range result.Milestones {
+			if !milestones[milestone] {
+				milestones[milestone] = true
+				merged.Milestones = append(merged.Milestones, milestone)
+			}
+		}
+		for dev, person := range result.reversedPeopleDict {
+			final := mergedIndex[person].Final
+			if merged.organizations[final] == "" && dev < len(result.organizations) {
+				merged.organizations[final] = result.organizations[dev]
+			}
+		}
+		for _, org := range result.InternalOrganizations {
+			if !internal[org] {
+				internal[org] = true
+				merged.InternalOrganizations = append(merged.InternalOrganizations, org)
+			}
+		}
+	}
+	sort.Ints(merged.Milestones)
+	limit := 0
+	if len(merged.Milestones) > 0 {
+		limit = merged.Milestones[len(merged.Milestones)-1]
+	}
+	for _, result := range [...]*ContributionFunnelResult{&cfr1, &cfr2} {
+		for dev, times := range result.Contributions {
+			if dev < 0 || dev >= len(result.reversedPeopleDict) {
+				continue
+			}
+			final := mergedIndex[result.reversedPeopleDict[dev]].Final
+			for _, when := range times {
+				merged.Contributions[final] = insertFunnelContribution(
+					merged.Contributions[final], when, limit)
+			}
+		}
+	}
+	return merged
+}
+
+func (cf *ContributionFunnelAnalysis) serializeText(result *ContributionFunnelResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  contribution_funnel:")
+	milestones := make([]string, len(result.Milestones))
+	for i, milestone := range result.Milestones {
+		milestones[i] = strconv.Itoa(milestone)
+	}
+	fmt.Fprintf(writer, "    milestones: [%s]\n", strings.Join(milestones, ", "))
+	internal := make([]string, len(result.InternalOrganizations))
+	for i, org := range result.InternalOrganizations {
+		internal[i] = yaml.SafeString(org)
+	}
+	fmt.Fprintf(writer, "    internal_organizations: [%s]\n", strings.Join(internal, ", "))
+	formatCohort := func(cohort FunnelCohort) string {
+		reached := make([]string, len(cohort.Reached))
+		days := make([]string, len(cohort.MedianDays))
+		for i := range cohort.Reached {
+			reached[i] = strconv.Itoa(cohort.Reached[i])
+			days[i] = strconv.FormatFloat(cohort.MedianDays[i], 'f', 1, 64)
+		}
+		return fmt.Sprintf("{contributors: %d, reached: [%s], median_days: [%s]}",
+			cohort.Contributors, strings.Join(reached, ", "), strings.Join(days, ", "))
+	}
+	fmt.Fprintln(writer, "    cohorts:")
+	for _, cohort := range result.Cohorts() {
+		fmt.Fprintf(writer, "      %q: %s\n", cohort.Cohort, formatCohort(cohort))
+	}
+	fmt.Fprintf(writer, "    total: %s\n", formatCohort(result.Total()))
+}
+
+func (cf *ContributionFunnelAnalysis) serializeBinary(result *ContributionFunnelResult, writer io.Writer) error {
+	message := pb.ContributionFunnelResults{
+		Milestones:            make([]int32, len(result.Milestones)),
+		Contributions:         make(map[int32]*pb.FunnelContributions, len(result.Contributions)),
+		DevIndex:              result.reversedPeopleDict,
+		Organizations:         result.organizations,
+		InternalOrganizations: result.InternalOrganizations,
+	}
+	for i, milestone := range result.Milestones {
+		message.Milestones[i] = int32(milestone)
+	}
+	for dev, times := range result.Contributions {
+		message.Contributions[int32(dev)] = &pb.FunnelContributions{Times: times}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&ContributionFunnelAnalysis{})
+}
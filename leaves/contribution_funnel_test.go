@@ -0,0 +1,168 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContributionFunnelMeta(t *testing.T) {
+	cf := &ContributionFunnelAnalysis{}
+	assert.Equal(t, "ContributionFunnel", cf.Name())
+	assert.Equal(t, "contribution-funnel", cf.Flag())
+	assert.Len(t, cf.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor}, cf.Requires())
+	opts := cf.ListConfigurationOptions()
+	require.Len(t, opts, 2)
+	assert.Equal(t, ConfigContributionFunnelMilestones, opts[0].Name)
+	assert.Equal(t, "funnel-milestones", opts[0].Flag)
+	assert.Equal(t, []string{"2", "5", "10"}, opts[0].Default)
+	// shared with ContributionDiversity
+	assert.Equal(t, (&ContributionDiversityAnalysis{}).ListConfigurationOptions()[0], opts[1])
+	summoned := core.Registry.Summon(cf.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, cf.Name(), summoned[0].Name())
+}
+
+func TestContributionFunnelConfigure(t *testing.T) {
+	cf := &ContributionFunnelAnalysis{}
+	require.NoError(t, cf.Configure(map[string]interface{}{
+		ConfigContributionFunnelMilestones:               []string{"10", " 3", "3"},
+		ConfigContributionDiversityInternalOrganizations: []string{"acme.com"},
+		identity.FactIdentityDetectorReversedPeopleDict:  []string{"Alice", "Bob"},
+		core.FactIdentityResolver: fixtureIdentityResolver{
+			names: []string{"alice|alice@acme.com", "bob|bob@gmail.com"},
+		},
+	}))
+	assert.Equal(t, []int{3, 10}, cf.Milestones)
+	assert.Equal(t, []string{"acme.com"}, cf.InternalOrganizations)
+	assert.Equal(t, []string{"Alice", "Bob"}, cf.reversedPeopleDict)
+	assert.Equal(t, []string{"acme.com", identity.OrganizationIndependent}, cf.organizations)
+	for _, milestones := range [][]string{{"1"}, {"x"}, {}} {
+		assert.Error(t, cf.Configure(map[string]interface{}{
+			ConfigContributionFunnelMilestones: milestones,
+		}), milestones)
+	}
+	cf = &ContributionFunnelAnalysis{}
+	require.NoError(t, cf.Initialize(nil))
+	assert.Equal(t, DefaultContributionFunnelMilestones, cf.Milestones)
+}
+
+func TestContributionFunnelConsume(t *testing.T) {
+	cf := &ContributionFunnelAnalysis{Milestones: []int{2, 3}}
+	hash := plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48")
+	require.NoError(t, cf.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorCommitAuthors: map[plumbing.Hash][]identity.CommitAuthor{
+			hash: {{ID: 1, Share: 0.5}, {ID: 2, Share: 0.5}},
+		},
+	}))
+	require.NoError(t, cf.Initialize(nil))
+	consume := func(author int, day int, hash plumbing.Hash) {
+		commit := &object.Commit{Hash: hash, Author: object.Signature{
+			When: time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC),
+		}}
+		_, err := cf.Consume(map[string]interface{}{
+			core.DependencyCommit:     commit,
+			identity.DependencyAuthor: author,
+		})
+		require.NoError(t, err)
+	}
+	consume(0, 5, plumbing.ZeroHash)
+	// out of order
+	consume(0, 3, plumbing.ZeroHash)
+	consume(0, 9, plumbing.ZeroHash)
+	consume(0, 1, plumbing.ZeroHash)
+	consume(1, 2, hash)
+	consume(core.AuthorMissing, 2, plumbing.ZeroHash)
+	day := func(day int) int64 {
+		return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC).Unix()
+	}
+	assert.Equal(t, map[int][]int64{
+		0: {day(1), day(3), day(5)},
+		1: {day(2)},
+		2: {day(2)},
+	}, cf.Finalize().(ContributionFunnelResult).Contributions)
+}
+
+func fixtureContributionFunnelResult() ContributionFunnelResult {
+	day := func(month time.Month, day int) int64 {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC).Unix()
+	}
+	return ContributionFunnelResult{
+		Contributions: map[int][]int64{
+			0: {day(1, 1), day(1, 2), day(1, 3), day(1, 4), day(1, 5)},
+			1: {day(1, 10), day(1, 20), day(2, 9), day(3, 1), day(3, 10)},
+			2: {day(1, 31), day(2, 4)},
+			3: {day(2, 1)},
+			4: {day(2, 3), day(2, 4)},
+		},
+		Milestones:            []int{2, 5},
+		InternalOrganizations: []string{"acme.com"},
+		reversedPeopleDict:    []string{"alice", "bob", "carol", "dave", identity.OthersName},
+		organizations:         []string{"acme.com", "other.org", identity.OrganizationIndependent, "", ""},
+	}
+}
+
+func TestContributionFunnelCohorts(t *testing.T) {
+	result := fixtureContributionFunnelResult()
+	assert.Equal(t, []FunnelCohort{{
+		Cohort: "2024-01", Contributors: 2, Reached: []int{2, 1}, MedianDays: []float64{7, 50},
+	}, {
+		Cohort: "2024-02", Contributors: 1, Reached: []int{0, 0}, MedianDays: []float64{0, 0},
+	}}, result.Cohorts())
+	assert.Equal(t, FunnelCohort{
+		Cohort: "total", Contributors: 3, Reached: []int{2, 1}, MedianDays: []float64{7, 50},
+	}, result.Total())
+	assert.Len(t, (&ContributionFunnelResult{}).Cohorts(), 0)
+}
+
+func TestContributionFunnelSerialize(t *testing.T) {
+	cf := &ContributionFunnelAnalysis{}
+	result := fixtureContributionFunnelResult()
+	buffer := &bytes.Buffer{}
+	require.NoError(t, cf.Serialize(result, false, buffer))
+	assert.Equal(t, `  contribution_funnel:
+    milestones: [2, 5]
+    internal_organizations: ["acme.com"]
+    cohorts:
+      "2024-01": {contributors: 2, reached: [2, 1], median_days: [7.0, 50.0]}
+      "2024-02": {contributors: 1, reached: [0, 0], median_days: [0.0, 0.0]}
+    total: {contributors: 3, reached: [2, 1], median_days: [7.0, 50.0]}
+`, buffer.String())
+	assert.Error(t, cf.Serialize(nil, false, buffer))
+
+	buffer.Reset()
+	require.NoError(t, cf.Serialize(result, true, buffer))
+	deserialized, err := cf.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestContributionFunnelMerge(t *testing.T) {
+	cf := &ContributionFunnelAnalysis{}
+	r1 := fixtureContributionFunnelResult()
+	r2 := ContributionFunnelResult{
+		Contributions:         map[int][]int64{0: {1, 2, 3}, 1: {100, 200}},
+		Milestones:            []int{3},
+		InternalOrganizations: []string{"acme.com", "partner.com"},
+		reversedPeopleDict:    []string{"eve", "dave"},
+		organizations:         []string{"partner.com", "other.org"},
+	}
+	merged := cf.MergeResults(r1, r2, nil, nil).(ContributionFunnelResult)
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave", identity.OthersName, "eve"},
+		merged.reversedPeopleDict)
+	assert.Equal(t, []string{"acme.com", "other.org", identity.OrganizationIndependent,
+		"other.org", "", "partner.com"}, merged.organizations)
+	assert.Equal(t, []int{2, 3, 5}, merged.Milestones)
+	assert.Equal(t, []string{"acme.com", "partner.com"}, merged.InternalOrganizations)
+	assert.Equal(t, []int64{1, 2, 3}, merged.Contributions[5])
+	assert.Equal(t, []int64{100, 200, r1.Contributions[3][0]}, merged.Contributions[3])
+	assert.Equal(t, r1.Contributions[1], merged.Contributions[1])
+}
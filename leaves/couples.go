@@ -3,7 +3,9 @@ package leaves
 import (
 	"fmt"
 	"io"
+	"math"
 	"sort"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -25,6 +27,9 @@ type CouplesAnalysis struct {
 	core.OneShotMergeProcessor
 	// PeopleNumber is the number of developers for which to build the matrix. 0 disables this analysis.
 	PeopleNumber int
+	// HalfLife is the number of days after which a co-change weighs half as much in
+	// CouplesResult.FilesDecayedMatrix. 0 disables the decay.
+	HalfLife int
 
 	// people store how many times every developer committed to every file.
 	people []map[string]int
@@ -32,6 +37,10 @@ type CouplesAnalysis struct {
 	peopleCommits []int
 	// files store every file occurred in the same commit with every other file.
 	files map[string]map[string]int
+	// decayedFiles are the co-change weights of files, relative to decayOrigin.
+	decayedFiles map[string]map[string]float64
+	// decayOrigin is the time when a co-change weighs 1 in decayedFiles.
+	decayOrigin time.Time
 	// renames point from new file name to old file name.
 	renames *[]rename
 	// lastCommit is the last commit which was consumed.
@@ -58,15 +67,27 @@ type CouplesResult struct {
 	FilesLines []int
 	// Files is the names of the files. The order matches PeopleFiles' indexes and FilesMatrix.
 	Files []string
+	// FilesDecayedMatrix is FilesMatrix where each co-change weighs 2^(-age/HalfLife), age being
+	// the number of days between the commit and the last commit. It is nil if the decay is disabled.
+	FilesDecayedMatrix []map[int]float64
 
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// halfLife is CouplesAnalysis.HalfLife.
+	halfLife int
 }
 
 const (
 	// CouplesMaximumMeaningfulContextSize is the threshold on the number of files in a commit to
 	// consider them as grouped together.
 	CouplesMaximumMeaningfulContextSize = 1000
+
+	// ConfigCouplesHalfLife is the name of the option to set CouplesAnalysis.HalfLife.
+	ConfigCouplesHalfLife = "Couples.HalfLife"
+
+	// couplesDecayRenormalization is the number of half-lives after which decayedFiles are
+	// rescaled so that the weights of the new commits do not overflow.
+	couplesDecayRenormalization = 64
 )
 
 type rename struct {
@@ -95,7 +116,14 @@ func (couples *CouplesAnalysis) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (couples *CouplesAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	return []core.ConfigurationOption{{
+		Name: ConfigCouplesHalfLife,
+		Description: "Additionally weigh the co-changes of files by their age: a co-change which is " +
+			"this number of days older than the last commit counts half. 0 disables the decay.",
+		Flag:    "couples-half-life",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+	}}
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -107,6 +135,12 @@ func (couples *CouplesAnalysis) Configure(facts map[string]interface{}) error {
 		couples.PeopleNumber = len(val)
 		couples.reversedPeopleDict = val
 	}
+	if val, exists := facts[ConfigCouplesHalfLife].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("%s must not be negative: %d", ConfigCouplesHalfLife, val)
+		}
+		couples.HalfLife = val
+	}
 	return nil
 }
 
@@ -119,6 +153,11 @@ func (couples *CouplesAnalysis) Flag() string {
 	return "couples"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (couples *CouplesAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (couples *CouplesAnalysis) Description() string {
 	return "The result is a square matrix, the value in each cell corresponds to the number " +
@@ -137,6 +176,11 @@ func (couples *CouplesAnalysis) Initialize(repository *git.Repository) error {
 	}
 	couples.peopleCommits = make([]int, couples.PeopleNumber+1)
 	couples.files = map[string]map[string]int{}
+	couples.decayedFiles = nil
+	if couples.HalfLife > 0 {
+		couples.decayedFiles = map[string]map[string]float64{}
+	}
+	couples.decayOrigin = time.Time{}
 	couples.renames = &[]rename{}
 	couples.OneShotMergeProcessor.Initialize()
 	return nil
@@ -193,10 +237,48 @@ func (couples *CouplesAnalysis) Consume(deps map[string]interface{}) (map[string
 				lane[otherFile]++
 			}
 		}
+		if couples.decayedFiles != nil {
+			weight := couples.decayWeight(couples.lastCommit.Committer.When)
+			for _, file := range context {
+				lane, exists := couples.decayedFiles[file]
+				if !exists {
+					lane = map[string]float64{}
+					couples.decayedFiles[file] = lane
+				}
+				for _, otherFile := range context {
+					lane[otherFile] += weight
+				}
+			}
+		}
 	}
 	return nil, nil
 }
 
+// decayWeight returns the weight of a co-change at the specified time relative to decayOrigin.
+// It rescales decayedFiles when the weight becomes too big.
+func (couples *CouplesAnalysis) decayWeight(when time.Time) float64 {
+	if couples.decayOrigin.IsZero() {
+		couples.decayOrigin = when
+	}
+	exponent := couples.halfLives(when.Sub(couples.decayOrigin))
+	if exponent > couplesDecayRenormalization {
+		scale := math.Exp2(-exponent)
+		for _, lane := range couples.decayedFiles {
+			for file, weight := range lane {
+				lane[file] = weight * scale
+			}
+		}
+		couples.decayOrigin = when
+		exponent = 0
+	}
+	return math.Exp2(exponent)
+}
+
+// halfLives returns the number of HalfLife-s in the duration.
+func (couples *CouplesAnalysis) halfLives(duration time.Duration) float64 {
+	return duration.Hours() / 24 / float64(couples.HalfLife)
+}
+
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (couples *CouplesAnalysis) Finalize() interface{} {
 	files, people := couples.propagateRenames(couples.currentFiles())
@@ -260,13 +342,28 @@ func (couples *CouplesAnalysis) Finalize() interface{} {
 			filesMatrix[i][filesIndex[otherFile]] = int64(cooccs)
 		}
 	}
+	var filesDecayedMatrix []map[int]float64
+	if couples.decayedFiles != nil {
+		decayed := couples.propagateDecayedRenames(files)
+		scale := math.Exp2(-couples.halfLives(couples.lastCommit.Committer.When.Sub(couples.decayOrigin)))
+		filesDecayedMatrix = make([]map[int]float64, len(filesMatrix))
+		for i, row := range filesMatrix {
+			filesDecayedMatrix[i] = make(map[int]float64, len(row))
+			lane := decayed[filesSequence[i]]
+			for j := range row {
+				filesDecayedMatrix[i][j] = lane[filesSequence[j]] * scale
+			}
+		}
+	}
 	return CouplesResult{
 		PeopleMatrix:       peopleMatrix,
 		PeopleFiles:        peopleFiles,
 		Files:              filesSequence,
 		FilesLines:         filesLines,
 		FilesMatrix:        filesMatrix,
+		FilesDecayedMatrix: filesDecayedMatrix,
 		reversedPeopleDict: couples.reversedPeopleDict,
+		halfLife:           couples.HalfLife,
 	}
 }
 
@@ -275,6 +372,17 @@ func (couples *CouplesAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkCopyPipelineItem(couples, n)
 }
 
+// AnonymizePaths replaces the names of the files, see core.PathAnonymizer.
+func (couples *CouplesAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	couplesResult := result.(CouplesResult)
+	files := make([]string, len(couplesResult.Files))
+	for i, file := range couplesResult.Files {
+		files[i] = anonymize(file)
+	}
+	couplesResult.Files = files
+	return couplesResult
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (couples *CouplesAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -293,6 +401,19 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	if err != nil {
 		return nil, err
 	}
+	if message.FileCouples == nil || message.PeopleCouples == nil {
+		return nil, fmt.Errorf("Couples PB message integrity violation: missing file or people couples")
+	}
+	if err = message.FileCouples.Matrix.Validate(); err != nil {
+		return nil, fmt.Errorf("file couples: %v", err)
+	}
+	if err = message.PeopleCouples.Matrix.Validate(); err != nil {
+		return nil, fmt.Errorf("people couples: %v", err)
+	}
+	if len(message.PeopleFiles) > len(message.PeopleCouples.Index) {
+		return nil, fmt.Errorf("Couples PB message integrity violation: people_files (%d) > people (%d)",
+			len(message.PeopleFiles), len(message.PeopleCouples.Index))
+	}
 	result := CouplesResult{
 		Files:              message.FileCouples.Index,
 		FilesLines:         make([]int, len(message.FileCouples.Index)),
@@ -310,7 +431,6 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	if len(message.FileCouples.Index) != len(message.FilesLines) {
 		err := fmt.Errorf("Couples PB message integrity violation: file_couples (%d) != file_lines (%d)",
 			len(message.FileCouples.Index), len(message.FilesLines))
-		couples.l.Critical(err)
 		return nil, err
 	}
 	for i, v := range message.FilesLines {
@@ -329,6 +449,21 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	}
 	convertCSR(result.FilesMatrix, message.FileCouples.Matrix)
 	convertCSR(result.PeopleMatrix, message.PeopleCouples.Matrix)
+	if message.HalfLife > 0 {
+		matrix := message.FileCouples.Matrix
+		if len(message.FilesDecayedWeights) != len(matrix.Data) {
+			return nil, fmt.Errorf("Couples PB message integrity violation: files_decayed_weights (%d) != "+
+				"file_couples (%d)", len(message.FilesDecayedWeights), len(matrix.Data))
+		}
+		result.halfLife = int(message.HalfLife)
+		result.FilesDecayedMatrix = make([]map[int]float64, matrix.NumberOfRows)
+		for i := range result.FilesDecayedMatrix {
+			result.FilesDecayedMatrix[i] = map[int]float64{}
+			for j := matrix.Indptr[i]; j < matrix.Indptr[i+1]; j++ {
+				result.FilesDecayedMatrix[i][int(matrix.Indices[j])] = float64(message.FilesDecayedWeights[j])
+			}
+		}
+	}
 	return result, nil
 }
 
@@ -336,7 +471,10 @@ func (couples *CouplesAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
 	cr1 := r1.(CouplesResult)
 	cr2 := r2.(CouplesResult)
-	merged := CouplesResult{}
+	if cr1.halfLife != cr2.halfLife {
+		return fmt.Errorf("mismatching half-lives (r1: %d, r2: %d) received", cr1.halfLife, cr2.halfLife)
+	}
+	merged := CouplesResult{halfLife: cr1.halfLife}
 	var people, files map[string]join.JoinedIndex
 	people, merged.reversedPeopleDict = join.PeopleIdentities(
 		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
@@ -422,6 +560,32 @@ func (couples *CouplesAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 	}
 	addFiles(cr1.FilesMatrix, cr1.Files)
 	addFiles(cr2.FilesMatrix, cr2.Files)
+	if merged.halfLife > 0 {
+		// the weights decay until the end of the later analysis
+		var end1, end2 int64
+		if c1 != nil && c2 != nil {
+			end1, end2 = c1.EndTime, c2.EndTime
+		}
+		end := end1
+		if end2 > end {
+			end = end2
+		}
+		merged.FilesDecayedMatrix = make([]map[int]float64, len(merged.Files))
+		for i := range merged.FilesDecayedMatrix {
+			merged.FilesDecayedMatrix[i] = map[int]float64{}
+		}
+		addDecayed := func(matrix []map[int]float64, reversedFilesDict []string, endTime int64) {
+			scale := math.Exp2(-float64(end-endTime) / (24 * 3600) / float64(merged.halfLife))
+			for fi, row := range matrix {
+				lane := merged.FilesDecayedMatrix[files[reversedFilesDict[fi]].Final]
+				for file, weight := range row {
+					lane[files[reversedFilesDict[file]].Final] += weight * scale
+				}
+			}
+		}
+		addDecayed(cr1.FilesDecayedMatrix, cr1.Files, end1)
+		addDecayed(cr2.FilesDecayedMatrix, cr2.Files, end2)
+	}
 	return merged
 }
 
@@ -452,6 +616,25 @@ func (couples *CouplesAnalysis) serializeText(result *CouplesResult, writer io.W
 		}
 		fmt.Fprintln(writer, "}")
 	}
+	if result.FilesDecayedMatrix != nil {
+		fmt.Fprintln(writer, "    half_life:", result.halfLife)
+		fmt.Fprintln(writer, "    decayed_matrix:")
+		for _, files := range result.FilesDecayedMatrix {
+			fmt.Fprint(writer, "      - {")
+			var indices []int
+			for file := range files {
+				indices = append(indices, file)
+			}
+			sort.Ints(indices)
+			for i, file := range indices {
+				fmt.Fprintf(writer, "%d: %.4f", file, files[file])
+				if i < len(indices)-1 {
+					fmt.Fprint(writer, ", ")
+				}
+			}
+			fmt.Fprintln(writer, "}")
+		}
+	}
 
 	fmt.Fprintln(writer, "  people_coocc:")
 	fmt.Fprintln(writer, "    index:")
@@ -549,6 +732,22 @@ func (couples *CouplesAnalysis) serializeBinary(result *CouplesResult, writer io
 	for i, l := range result.FilesLines {
 		message.FilesLines[i] = int32(l)
 	}
+	if result.FilesDecayedMatrix != nil {
+		// the same order as in MapToCompressedSparseRowMatrix()
+		message.HalfLife = int32(result.halfLife)
+		message.FilesDecayedWeights = make([]float32, 0, len(message.FileCouples.Matrix.Data))
+		for i, row := range result.FilesMatrix {
+			order := make([]int, 0, len(row))
+			for col := range row {
+				order = append(order, col)
+			}
+			sort.Ints(order)
+			for _, col := range order {
+				message.FilesDecayedWeights = append(
+					message.FilesDecayedWeights, float32(result.FilesDecayedMatrix[i][col]))
+			}
+		}
+	}
 
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
@@ -579,7 +778,6 @@ func (couples *CouplesAnalysis) currentFiles() map[string]bool {
 func (couples *CouplesAnalysis) propagateRenames(files map[string]bool) (
 	map[string]map[string]int, []map[string]int,
 ) {
-	renames := *couples.renames
 	reducedFiles := map[string]map[string]int{}
 	for file := range files {
 		fmap := map[string]int{}
@@ -594,28 +792,7 @@ func (couples *CouplesAnalysis) propagateRenames(files map[string]bool) (
 			reducedFiles[file] = fmap
 		}
 	}
-	// propagate renames
-	aliases := map[string]map[string]bool{}
-	pointers := map[string]string{}
-	for i := range renames {
-		rename := renames[len(renames)-i-1]
-		toName := rename.ToName
-		if newTo, exists := pointers[toName]; exists {
-			toName = newTo
-		}
-		if _, exists := reducedFiles[toName]; exists {
-			if rename.FromName != toName {
-				var set map[string]bool
-				if set, exists = aliases[toName]; !exists {
-					set = map[string]bool{}
-					aliases[toName] = set
-				}
-				set[rename.FromName] = true
-				pointers[rename.FromName] = toName
-			}
-			continue
-		}
-	}
+	aliases, pointers := couples.renameAliases(reducedFiles)
 	adjustments := map[string]map[string]int{}
 	for final, set := range aliases {
 		adjustment := map[string]int{}
@@ -666,6 +843,76 @@ func (couples *CouplesAnalysis) propagateRenames(files map[string]bool) (
 	return reducedFiles, people
 }
 
+// renameAliases returns the old names of the files in `reducedFiles` and the mapping from
+// each old name to the final one.
+func (couples *CouplesAnalysis) renameAliases(reducedFiles map[string]map[string]int) (
+	map[string]map[string]bool, map[string]string,
+) {
+	renames := *couples.renames
+	aliases := map[string]map[string]bool{}
+	pointers := map[string]string{}
+	for i := range renames {
+		rename := renames[len(renames)-i-1]
+		toName := rename.ToName
+		if newTo, exists := pointers[toName]; exists {
+			toName = newTo
+		}
+		if _, exists := reducedFiles[toName]; exists {
+			if rename.FromName != toName {
+				var set map[string]bool
+				if set, exists = aliases[toName]; !exists {
+					set = map[string]bool{}
+					aliases[toName] = set
+				}
+				set[rename.FromName] = true
+				pointers[rename.FromName] = toName
+			}
+			continue
+		}
+	}
+	return aliases, pointers
+}
+
+// propagateDecayedRenames is propagateRenames for decayedFiles.
+func (couples *CouplesAnalysis) propagateDecayedRenames(reducedFiles map[string]map[string]int) map[string]map[string]float64 {
+	reducedDecayed := map[string]map[string]float64{}
+	for file, lane := range reducedFiles {
+		decayedLane := map[string]float64{}
+		for other := range lane {
+			decayedLane[other] = couples.decayedFiles[file][other]
+		}
+		reducedDecayed[file] = decayedLane
+	}
+	aliases, _ := couples.renameAliases(reducedFiles)
+	adjustments := map[string]map[string]float64{}
+	for final, set := range aliases {
+		adjustment := map[string]float64{}
+		for alias := range set {
+			for k, v := range couples.decayedFiles[alias] {
+				adjustment[k] += v
+			}
+		}
+		adjustments[final] = adjustment
+	}
+	for _, adjustment := range adjustments {
+		for final, set := range aliases {
+			for alias := range set {
+				adjustment[final] += adjustment[alias]
+				delete(adjustment, alias)
+			}
+		}
+	}
+	for final, adjustment := range adjustments {
+		for key, val := range adjustment {
+			if weight, exists := reducedDecayed[final][key]; exists {
+				reducedDecayed[final][key] = weight + val
+				reducedDecayed[key][final] = weight + val
+			}
+		}
+	}
+	return reducedDecayed
+}
+
 func init() {
 	core.Registry.Register(&CouplesAnalysis{})
 }
@@ -4,12 +4,18 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"path"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
 	gitplumbing "github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/meko-christian/hercules/internal/pb"
@@ -33,12 +39,18 @@ func TestCouplesMeta(t *testing.T) {
 	assert.Equal(t, c.Requires()[0], identity.DependencyAuthor)
 	assert.Equal(t, c.Requires()[1], plumbing.DependencyTreeChanges)
 	assert.Equal(t, c.Flag(), "couples")
-	assert.Len(t, c.ListConfigurationOptions(), 0)
+	opts := c.ListConfigurationOptions()
+	assert.Len(t, opts, 1)
+	assert.Equal(t, ConfigCouplesHalfLife, opts[0].Name)
+	assert.Equal(t, "couples-half-life", opts[0].Flag)
 	logger := core.NewLogger()
 	assert.NoError(t, c.Configure(map[string]interface{}{
-		core.ConfigLogger: logger,
+		core.ConfigLogger:     logger,
+		ConfigCouplesHalfLife: 30,
 	}))
 	assert.Equal(t, logger, c.l)
+	assert.Equal(t, 30, c.HalfLife)
+	assert.Error(t, c.Configure(map[string]interface{}{ConfigCouplesHalfLife: -1}))
 }
 
 func TestCouplesRegistration(t *testing.T) {
@@ -562,3 +574,139 @@ func getCouplesMap(vals ...int) map[int]int64 {
 	}
 	return res
 }
+
+func TestCouplesAnonymizePaths(t *testing.T) {
+	result := CouplesResult{
+		FilesMatrix: []map[int]int64{{0: 1}, {1: 1}},
+		FilesLines:  []int{10, 20},
+		Files:       []string{"a.go", "b.go"},
+	}
+	anonymized := (&CouplesAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(CouplesResult)
+	assert.Equal(t, []string{"x/a.go", "x/b.go"}, anonymized.Files)
+	assert.Equal(t, result.FilesMatrix, anonymized.FilesMatrix)
+	assert.Equal(t, []string{"a.go", "b.go"}, result.Files)
+}
+
+func TestCouplesConsumeFinalizeDecay(t *testing.T) {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	c := CouplesAnalysis{PeopleNumber: 1, HalfLife: 30}
+	assert.NoError(t, c.Initialize(repository))
+	when := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, files := range [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}} {
+		for _, file := range files {
+			assert.NoError(t, util.WriteFile(fs, file, []byte(fmt.Sprintf("%s\n%d\n", file, i)), 0o644))
+		}
+		assert.NoError(t, worktree.AddWithOptions(&git.AddOptions{All: true}))
+		signature := &object.Signature{Name: "Alice", Email: "alice@example.com", When: when}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature, Committer: signature})
+		assert.NoError(t, err)
+		commit, err := repository.CommitObject(hash)
+		assert.NoError(t, err)
+		names := make([]string, len(files))
+		for j, file := range files {
+			names[j] = "=" + file
+		}
+		_, err = c.Consume(map[string]interface{}{
+			core.DependencyCommit:          commit,
+			core.DependencyIndex:           i,
+			identity.DependencyAuthor:      0,
+			plumbing.DependencyTreeChanges: generateChanges(names...),
+		})
+		assert.NoError(t, err)
+		when = when.AddDate(0, 0, 30)
+	}
+	result := c.Finalize().(CouplesResult)
+	assert.Equal(t, []string{"a", "b", "c"}, result.Files)
+	assert.Equal(t, []map[int]int64{{0: 2, 1: 1, 2: 1}, {0: 1, 1: 2, 2: 1}, {0: 1, 1: 1, 2: 2}},
+		result.FilesMatrix)
+	assert.Equal(t, []map[int]float64{
+		{0: 0.75, 1: 0.25, 2: 0.5}, {0: 0.25, 1: 1.25, 2: 1}, {0: 0.5, 1: 1, 2: 1.5},
+	}, result.FilesDecayedMatrix)
+	assert.Equal(t, 30, result.halfLife)
+}
+
+func TestCouplesDecayWeight(t *testing.T) {
+	c := CouplesAnalysis{HalfLife: 1}
+	c.decayedFiles = map[string]map[string]float64{}
+	origin := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 1.0, c.decayWeight(origin))
+	assert.Equal(t, 4.0, c.decayWeight(origin.AddDate(0, 0, 2)))
+	c.decayedFiles["a"] = map[string]float64{"a": 1}
+	// the weights are rescaled after couplesDecayRenormalization half-lives
+	assert.Equal(t, 1.0, c.decayWeight(origin.AddDate(0, 0, couplesDecayRenormalization+1)))
+	assert.Equal(t, math.Exp2(-couplesDecayRenormalization-1), c.decayedFiles["a"]["a"])
+	assert.Equal(t, origin.AddDate(0, 0, couplesDecayRenormalization+1), c.decayOrigin)
+}
+
+func TestCouplesSerializeDecay(t *testing.T) {
+	c := CouplesAnalysis{}
+	result := CouplesResult{
+		PeopleMatrix:       []map[int]int64{{0: 2}, {}},
+		PeopleFiles:        [][]int{{0, 1}},
+		FilesMatrix:        []map[int]int64{{1: 1, 0: 2}, {0: 1, 1: 1}},
+		FilesDecayedMatrix: []map[int]float64{{1: 0.25, 0: 1.25}, {0: 0.25, 1: 0.25}},
+		Files:              []string{"a", "b"},
+		FilesLines:         []int{1, 2},
+		reversedPeopleDict: []string{"p1"},
+		halfLife:           30,
+	}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, c.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `    matrix:
+      - {0: 2, 1: 1}
+      - {0: 1, 1: 1}
+    half_life: 30
+    decayed_matrix:
+      - {0: 1.2500, 1: 0.2500}
+      - {0: 0.2500, 1: 0.2500}
+  people_coocc:
+`)
+	buffer.Reset()
+	assert.Nil(t, c.Serialize(result, true, buffer))
+	iresult, err := c.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	deserialized := iresult.(CouplesResult)
+	assert.Equal(t, result.FilesDecayedMatrix, deserialized.FilesDecayedMatrix)
+	assert.Equal(t, 30, deserialized.halfLife)
+
+	msg := pb.CouplesAnalysisResults{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	msg.FilesDecayedWeights = msg.FilesDecayedWeights[1:]
+	data, err := proto.Marshal(&msg)
+	assert.Nil(t, err)
+	_, err = c.Deserialize(data)
+	assert.Error(t, err)
+}
+
+func TestCouplesMergeDecay(t *testing.T) {
+	r1 := CouplesResult{
+		Files:              []string{"a"},
+		FilesLines:         []int{1},
+		FilesMatrix:        []map[int]int64{{0: 1}},
+		FilesDecayedMatrix: []map[int]float64{{0: 1}},
+		PeopleMatrix:       []map[int]int64{{}},
+		halfLife:           30,
+	}
+	r2 := CouplesResult{
+		Files:              []string{"a", "b"},
+		FilesLines:         []int{1, 1},
+		FilesMatrix:        []map[int]int64{{0: 1, 1: 1}, {0: 1, 1: 1}},
+		FilesDecayedMatrix: []map[int]float64{{0: 1, 1: 1}, {0: 1, 1: 1}},
+		PeopleMatrix:       []map[int]int64{{}},
+		halfLife:           30,
+	}
+	c1 := core.CommonAnalysisResult{EndTime: 1577836800}
+	c2 := core.CommonAnalysisResult{EndTime: 1577836800 + 30*24*3600}
+	couples := CouplesAnalysis{}
+	merged := couples.MergeResults(r1, r2, &c1, &c2).(CouplesResult)
+	assert.Equal(t, []map[int]float64{{0: 1.5, 1: 1}, {0: 1, 1: 1}}, merged.FilesDecayedMatrix)
+	assert.Equal(t, 30, merged.halfLife)
+	r2.halfLife = 60
+	assert.Error(t, couples.MergeResults(r1, r2, &c1, &c2).(error))
+}
@@ -0,0 +1,387 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigCrossTimezoneMinGapHours is the name of the option to set the minimum distance
+	// between the UTC offsets of two developers to consider their coupled work cross-timezone.
+	ConfigCrossTimezoneMinGapHours = "CrossTimezone.MinGapHours"
+	// DefaultCrossTimezoneMinGapHours is the default value of ConfigCrossTimezoneMinGapHours.
+	DefaultCrossTimezoneMinGapHours = 4
+)
+
+// CrossTimezoneAnalysis measures how much of the coupled work happens across timezone gaps.
+// Each developer is placed in the UTC offset of the majority of their commits, and the coupling
+// of two developers is calculated the same way as in CouplesAnalysis: the sum of the minimum
+// number of commits of either developer over the files they both changed. The share of
+// the coupling between the developers whose offsets are more than MinGapHours apart quantifies
+// the follow-the-sun friction.
+type CrossTimezoneAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// MinGapHours is the distance between the UTC offsets above which the coupled work
+	// is cross-timezone.
+	MinGapHours int
+
+	// offsets maps developer indexes to the number of commits per UTC offset in minutes.
+	offsets map[int]map[int]int
+	// files maps file names to the number of commits per developer.
+	files map[string]map[int]int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+
+	l core.Logger
+}
+
+// CrossTimezonePair is the coupled work of two developers.
+type CrossTimezonePair struct {
+	// Developer1 is the index of the first developer, always less than Developer2.
+	Developer1 int
+	// Developer2 is the index of the second developer.
+	Developer2 int
+	// GapHours is the distance between the UTC offsets of the developers on the 24h circle.
+	GapHours float64
+	// Coupling is the sum of min(commits) of both developers over the files they both changed.
+	Coupling int64
+}
+
+// CrossTimezoneResult is returned by CrossTimezoneAnalysis.Finalize().
+type CrossTimezoneResult struct {
+	// Offsets maps developer indexes to their dominant UTC offsets in minutes.
+	Offsets map[int]int
+	// Pairs are the coupled developers sorted by Coupling in descending order.
+	Pairs []CrossTimezonePair
+	// TotalCoupling is the sum of Coupling over all the pairs.
+	TotalCoupling int64
+	// CrossCoupling is the sum of Coupling over the pairs with GapHours greater than MinGapHours.
+	CrossCoupling int64
+	// MinGapHours is the threshold of the cross-timezone gap.
+	MinGapHours int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+}
+
+// Index returns the share of the cross-timezone coupling, the cross-timezone collaboration index.
+func (result CrossTimezoneResult) Index() float64 {
+	if result.TotalCoupling == 0 {
+		return 0
+	}
+	return float64(result.CrossCoupling) / float64(result.TotalCoupling)
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ct *CrossTimezoneAnalysis) Name() string {
+	return "CrossTimezone"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (ct *CrossTimezoneAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (ct *CrossTimezoneAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ct *CrossTimezoneAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigCrossTimezoneMinGapHours,
+		Description: "Minimum distance in hours between the UTC offsets of two developers to consider their coupled work cross-timezone.",
+		Flag:        "timezone-gap",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultCrossTimezoneMinGapHours,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ct *CrossTimezoneAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ct.l = l
+	}
+	if val, exists := facts[ConfigCrossTimezoneMinGapHours].(int); exists {
+		ct.MinGapHours = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		ct.reversedPeopleDict = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*CrossTimezoneAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ct *CrossTimezoneAnalysis) Flag() string {
+	return "cross-timezone"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ct *CrossTimezoneAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ct *CrossTimezoneAnalysis) Description() string {
+	return "Measures the share of the coupled work between developers whose UTC offsets " +
+		"are more than the specified number of hours apart."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ct *CrossTimezoneAnalysis) Initialize(repository *git.Repository) error {
+	ct.l = core.NewLogger()
+	if ct.MinGapHours < 0 {
+		return fmt.Errorf("--timezone-gap cannot be negative (got %d)", ct.MinGapHours)
+	}
+	ct.offsets = map[int]map[int]int{}
+	ct.files = map[string]map[int]int{}
+	ct.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ct *CrossTimezoneAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !ct.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	if author == core.AuthorMissing {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	_, offset := commit.Author.When.Zone()
+	offsets := ct.offsets[author]
+	if offsets == nil {
+		offsets = map[int]int{}
+		ct.offsets[author] = offsets
+	}
+	offsets[offset/60]++
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		name := change.To.Name
+		switch action {
+		case merkletrie.Delete:
+			name = change.From.Name
+		case merkletrie.Modify:
+			if change.From.Name != name {
+				// renamed, carry over the history
+				if history, exists := ct.files[change.From.Name]; exists {
+					delete(ct.files, change.From.Name)
+					ct.files[name] = history
+				}
+			}
+		}
+		devs := ct.files[name]
+		if devs == nil {
+			devs = map[int]int{}
+			ct.files[name] = devs
+		}
+		devs[author]++
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ct *CrossTimezoneAnalysis) Finalize() interface{} {
+	result := CrossTimezoneResult{
+		Offsets:            make(map[int]int, len(ct.offsets)),
+		MinGapHours:        ct.MinGapHours,
+		reversedPeopleDict: ct.reversedPeopleDict,
+	}
+	for dev, offsets := range ct.offsets {
+		dominant, maxCommits := 0, 0
+		for offset, commits := range offsets {
+			if commits > maxCommits || (commits == maxCommits && offset < dominant) {
+				dominant, maxCommits = offset, commits
+			}
+		}
+		result.Offsets[dev] = dominant
+	}
+	couplings := map[[2]int]int64{}
+	for _, devs := range ct.files {
+		for dev1, commits1 := range devs {
+			for dev2, commits2 := range devs {
+				if dev1 >= dev2 {
+					continue
+				}
+				common := commits1
+				if commits2 < common {
+					common = commits2
+				}
+				couplings[[2]int{dev1, dev2}] += int64(common)
+			}
+		}
+	}
+	result.Pairs = make([]CrossTimezonePair, 0, len(couplings))
+	for devs, coupling := range couplings {
+		pair := CrossTimezonePair{
+			Developer1: devs[0],
+			Developer2: devs[1],
+			GapHours:   timezoneGap(result.Offsets[devs[0]], result.Offsets[devs[1]]),
+			Coupling:   coupling,
+		}
+		result.Pairs = append(result.Pairs, pair)
+		result.TotalCoupling += coupling
+		if pair.GapHours > float64(ct.MinGapHours) {
+			result.CrossCoupling += coupling
+		}
+	}
+	sort.Slice(result.Pairs, func(i, j int) bool {
+		pi, pj := result.Pairs[i], result.Pairs[j]
+		if pi.Coupling != pj.Coupling {
+			return pi.Coupling > pj.Coupling
+		}
+		if pi.Developer1 != pj.Developer1 {
+			return pi.Developer1 < pj.Developer1
+		}
+		return pi.Developer2 < pj.Developer2
+	})
+	return result
+}
+
+// timezoneGap returns the distance in hours between two UTC offsets in minutes on the 24h circle,
+// so that UTC-11 and UTC+12 are one hour apart.
+func timezoneGap(offset1, offset2 int) float64 {
+	gap := offset1 - offset2
+	if gap < 0 {
+		gap = -gap
+	}
+	gap %= 24 * 60
+	if gap > 12*60 {
+		gap = 24*60 - gap
+	}
+	return float64(gap) / 60
+}
+
+// Fork clones this pipeline item.
+func (ct *CrossTimezoneAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ct, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ct *CrossTimezoneAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	timezoneResult, ok := result.(CrossTimezoneResult)
+	if !ok {
+		return fmt.Errorf("result is not a cross-timezone result: '%v'", result)
+	}
+	if binary {
+		return ct.serializeBinary(&timezoneResult, writer)
+	}
+	ct.serializeText(&timezoneResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to CrossTimezoneResult.
+func (ct *CrossTimezoneAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.CrossTimezoneResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := CrossTimezoneResult{
+		Offsets:            make(map[int]int, len(message.Offsets)),
+		Pairs:              make([]CrossTimezonePair, len(message.Pairs)),
+		TotalCoupling:      message.TotalCoupling,
+		CrossCoupling:      message.CrossCoupling,
+		MinGapHours:        int(message.MinGapHours),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for dev, offset := range message.Offsets {
+		result.Offsets[int(dev)] = int(offset)
+	}
+	for i, pair := range message.Pairs {
+		result.Pairs[i] = CrossTimezonePair{
+			Developer1: int(pair.Developer1),
+			Developer2: int(pair.Developer2),
+			GapHours:   pair.GapHours,
+			Coupling:   pair.Coupling,
+		}
+	}
+	return result, nil
+}
+
+func (ct *CrossTimezoneAnalysis) serializeText(result *CrossTimezoneResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  cross_timezone:")
+	fmt.Fprintf(writer, "    min_gap_hours: %d\n", result.MinGapHours)
+	fmt.Fprintf(writer, "    index: %.4f\n", result.Index())
+	fmt.Fprintf(writer, "    total_coupling: %d\n", result.TotalCoupling)
+	fmt.Fprintf(writer, "    cross_coupling: %d\n", result.CrossCoupling)
+	fmt.Fprintln(writer, "    offsets:")
+	devs := make([]int, 0, len(result.Offsets))
+	for dev := range result.Offsets {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d: %d\n", dev, result.Offsets[dev])
+	}
+	fmt.Fprintln(writer, "    pairs:")
+	for _, pair := range result.Pairs {
+		fmt.Fprintf(writer, "    - {developers: [%d, %d], gap_hours: %.1f, coupling: %d}\n",
+			pair.Developer1, pair.Developer2, pair.GapHours, pair.Coupling)
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (ct *CrossTimezoneAnalysis) serializeBinary(result *CrossTimezoneResult, writer io.Writer) error {
+	message := pb.CrossTimezoneResults{
+		Offsets:       make(map[int32]int32, len(result.Offsets)),
+		Pairs:         make([]*pb.CrossTimezonePair, len(result.Pairs)),
+		TotalCoupling: result.TotalCoupling,
+		CrossCoupling: result.CrossCoupling,
+		MinGapHours:   int32(result.MinGapHours),
+		DevIndex:      result.reversedPeopleDict,
+	}
+	for dev, offset := range result.Offsets {
+		message.Offsets[int32(dev)] = int32(offset)
+	}
+	for i, pair := range result.Pairs {
+		message.Pairs[i] = &pb.CrossTimezonePair{
+			Developer1: int32(pair.Developer1),
+			Developer2: int32(pair.Developer2),
+			GapHours:   pair.GapHours,
+			Coupling:   pair.Coupling,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&CrossTimezoneAnalysis{})
+}
@@ -0,0 +1,161 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrossTimezoneMeta(t *testing.T) {
+	ct := &CrossTimezoneAnalysis{}
+	assert.Equal(t, "CrossTimezone", ct.Name())
+	assert.Len(t, ct.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTreeChanges}, ct.Requires())
+	assert.Equal(t, "cross-timezone", ct.Flag())
+	assert.Equal(t, core.CostCheap, ct.Cost())
+	opts := ct.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigCrossTimezoneMinGapHours, opts[0].Name)
+	assert.Equal(t, DefaultCrossTimezoneMinGapHours, opts[0].Default)
+	require.NoError(t, ct.Configure(map[string]interface{}{
+		ConfigCrossTimezoneMinGapHours:                  6,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice"},
+	}))
+	assert.Equal(t, 6, ct.MinGapHours)
+	assert.Equal(t, []string{"Alice"}, ct.reversedPeopleDict)
+	require.NoError(t, ct.Initialize(nil))
+	ct.MinGapHours = -1
+	assert.Error(t, ct.Initialize(nil))
+}
+
+func TestCrossTimezoneRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&CrossTimezoneAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "CrossTimezone", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&CrossTimezoneAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestTimezoneGap(t *testing.T) {
+	assert.Equal(t, 0.0, timezoneGap(60, 60))
+	assert.Equal(t, 8.0, timezoneGap(540, 60))
+	assert.Equal(t, 8.0, timezoneGap(60, 540))
+	assert.Equal(t, 5.5, timezoneGap(330, 0))
+	assert.Equal(t, 12.0, timezoneGap(540, -180))
+	assert.Equal(t, 1.0, timezoneGap(-660, 720))
+	assert.Equal(t, 2.0, timezoneGap(-720, 840))
+}
+
+func TestCrossTimezoneConsumeFinalize(t *testing.T) {
+	ct := &CrossTimezoneAnalysis{MinGapHours: 4}
+	require.NoError(t, ct.Initialize(nil))
+	index := 0
+	consume := func(author int, offsetHours int, merge bool, changes ...*object.Change) {
+		index++
+		commit := &object.Commit{
+			Hash: plumbing.NewHash(string(rune('0'+index)) + "000000000000000000000000000000000000000"),
+			Author: object.Signature{When: time.Date(2024, 1, 1, 12, 0, 0, 0,
+				time.FixedZone("", offsetHours*3600))},
+		}
+		if merge {
+			commit.ParentHashes = []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash}
+		}
+		deps := map[string]interface{}{
+			core.DependencyCommit:       commit,
+			identity.DependencyAuthor:   author,
+			items.DependencyTreeChanges: object.Changes(changes),
+		}
+		_, err := ct.Consume(deps)
+		require.NoError(t, err)
+		if merge {
+			// the same merge in the other branch
+			_, err = ct.Consume(deps)
+			require.NoError(t, err)
+		}
+	}
+	insert := func(name string) *object.Change {
+		return &object.Change{To: object.ChangeEntry{Name: name}}
+	}
+	modify := func(from, to string) *object.Change {
+		return &object.Change{From: object.ChangeEntry{Name: from}, To: object.ChangeEntry{Name: to}}
+	}
+	del := func(name string) *object.Change {
+		return &object.Change{From: object.ChangeEntry{Name: name}}
+	}
+	consume(0, 9, false, insert("a.go"), insert("b.go"))
+	consume(1, 1, false, modify("a.go", "a.go"))
+	consume(1, 1, false, modify("a.go", "a.go"), modify("b.go", "c.go"))
+	consume(1, -5, false, modify("c.go", "c.go"))
+	consume(2, -3, true, modify("a.go", "a.go"))
+	consume(core.AuthorMissing, 0, false, modify("a.go", "a.go"))
+	consume(0, 9, false, del("c.go"))
+
+	result := ct.Finalize().(CrossTimezoneResult)
+	assert.Equal(t, map[int]int{0: 540, 1: 60, 2: -180}, result.Offsets)
+	assert.Equal(t, []CrossTimezonePair{
+		{Developer1: 0, Developer2: 1, GapHours: 8, Coupling: 3},
+		{Developer1: 0, Developer2: 2, GapHours: 12, Coupling: 1},
+		{Developer1: 1, Developer2: 2, GapHours: 4, Coupling: 1},
+	}, result.Pairs)
+	assert.Equal(t, int64(5), result.TotalCoupling)
+	assert.Equal(t, int64(4), result.CrossCoupling)
+	assert.Equal(t, 4, result.MinGapHours)
+	assert.InDelta(t, 0.8, result.Index(), 1e-6)
+	assert.Equal(t, 0.0, CrossTimezoneResult{}.Index())
+}
+
+func TestCrossTimezoneSerialize(t *testing.T) {
+	ct := &CrossTimezoneAnalysis{}
+	result := CrossTimezoneResult{
+		Offsets: map[int]int{0: 540, 1: 60, 2: -180},
+		Pairs: []CrossTimezonePair{
+			{Developer1: 0, Developer2: 1, GapHours: 8, Coupling: 3},
+			{Developer1: 1, Developer2: 2, GapHours: 4, Coupling: 1},
+		},
+		TotalCoupling:      4,
+		CrossCoupling:      3,
+		MinGapHours:        4,
+		reversedPeopleDict: []string{"Alice", "Bob", "Carol"},
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, ct.Serialize(result, false, buffer))
+	assert.Equal(t, `  cross_timezone:
+    min_gap_hours: 4
+    index: 0.7500
+    total_coupling: 4
+    cross_coupling: 3
+    offsets:
+      0: 540
+      1: 60
+      2: -180
+    pairs:
+    - {developers: [0, 1], gap_hours: 8.0, coupling: 3}
+    - {developers: [1, 2], gap_hours: 4.0, coupling: 1}
+    people:
+    - "Alice"
+    - "Bob"
+    - "Carol"
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, ct.Serialize(result, true, buffer))
+	restored, err := ct.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, ct.Serialize(nil, false, buffer))
+}
@@ -0,0 +1,63 @@
+package leaves
+
+import (
+	"testing"
+
+	"github.com/meko-christian/hercules/internal/core"
+)
+
+// fuzzDeserializers returns the registered leaves which load their results from Protocol Buffers.
+func fuzzDeserializers() []core.ResultMergeablePipelineItem {
+	var items []core.ResultMergeablePipelineItem
+	for _, leaf := range core.Registry.GetLeaves() {
+		if item, ok := leaf.(core.ResultMergeablePipelineItem); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// FuzzDeserialize feeds arbitrary bytes to every Deserialize(). The malformed messages must be
+// rejected with an error; a panic fails the fuzzer. The accepted results are merged with
+// themselves, so MergeResults() must survive everything Deserialize() lets through. Run with
+//
+//	go test -run '^$' -fuzz FuzzDeserialize ./leaves
+func FuzzDeserialize(f *testing.F) {
+	items := fuzzDeserializers()
+	for i := range items {
+		f.Add(uint8(i), []byte{})
+		// field 1, length-delimited, with the nested field 1 varint
+		f.Add(uint8(i), []byte{0x0a, 0x02, 0x08, 0x01})
+		// field 2, length-delimited, which claims more bytes than available
+		f.Add(uint8(i), []byte{0x12, 0x7f, 0x00})
+	}
+	// the crashers found so far, keyed by the item name
+	crashers := map[string][][]byte{
+		// BurndownAnalysisResults without the granularity which divided by zero in MergeResults()
+		"Burndown": {[]byte("*\x00")},
+		// BusFactorAnalysisResults.files_ownership entry without the value
+		"BusFactor": {[]byte("\x38\x01\x32\x03\x0a\x01a")},
+		// CodeChurnResults.rework and CodeChurnResults.people entries without the value,
+		// CodeChurnResults without the tick size which used to divide by zero in MergeResults()
+		"CodeChurn": {
+			[]byte("\x08\x01\x20\x01" + "2\x0200"),
+			[]byte("\x08\x01\x20\x01" + "\x12\x02\x08\x00"),
+			[]byte("\x08\x01"),
+		},
+	}
+	for i, item := range items {
+		for _, message := range crashers[item.Name()] {
+			f.Add(uint8(i), message)
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, message []byte) {
+		item := items[int(index)%len(items)]
+		result, err := item.Deserialize(message)
+		if err != nil {
+			return
+		}
+		c1 := &core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500086400, CommitsNumber: 1}
+		c2 := &core.CommonAnalysisResult{BeginTime: 1500000000, EndTime: 1500172800, CommitsNumber: 2}
+		_ = item.MergeResults(result, result, c1, c2)
+	})
+}
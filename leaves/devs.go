@@ -1,9 +1,11 @@
 package leaves
 
 import (
+	"encoding/gob"
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -11,6 +13,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
 	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
 	"github.com/meko-christian/hercules/internal/join"
@@ -29,11 +32,21 @@ type DevsAnalysis struct {
 	// ConsiderEmptyCommits indicates whether empty commits (e.g., merges) should be taken
 	// into account.
 	ConsiderEmptyCommits bool
+	// Categories indicates whether the line stats should be additionally split by the kind
+	// of the change: a new file, a modification, a deleted file or a rename.
+	Categories bool
+	// TestPathRegexp matches the paths of the test files whose changes are categorized apart
+	// from the rest. It is ignored unless Categories is set.
+	TestPathRegexp *regexp.Regexp
 
 	// ticks maps ticks to developers to stats
 	ticks map[int]map[int]*DevTick
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations
+	organizations []string
+	// commitAuthors references IdentityDetector.CommitAuthors
+	commitAuthors map[plumbing.Hash][]identity.CommitAuthor
 	// TickSize references TicksSinceStart.TickSize
 	tickSize time.Duration
 
@@ -48,10 +61,22 @@ type DevsResult struct {
 
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations, it is empty unless enabled
+	organizations []string
 	// TickSize references TicksSinceStart.TickSize
 	tickSize time.Duration
 }
 
+// DevsOrganization is the rollup of the developers' statistics over the whole history
+// per organization.
+type DevsOrganization struct {
+	// Developers is the number of developers who belong to the organization.
+	Developers int
+	// Commits is the number of commits made by the organization.
+	Commits int
+	items.LineStats
+}
+
 // DevTick is the statistics for a development tick and a particular developer.
 type DevTick struct {
 	// Commits is the number of commits made by a particular developer in a particular tick.
@@ -59,11 +84,33 @@ type DevTick struct {
 	items.LineStats
 	// LanguagesDetection carries fine-grained line stats per programming language.
 	Languages map[string]items.LineStats
+	// Categories carries the line stats per change category, see DevsCategoryNew and others.
+	// It is nil unless DevsAnalysis.Categories is set.
+	Categories map[string]items.LineStats
 }
 
 const (
 	// ConfigDevsConsiderEmptyCommits is the name of the option to set DevsAnalysis.ConsiderEmptyCommits.
 	ConfigDevsConsiderEmptyCommits = "Devs.ConsiderEmptyCommits"
+	// ConfigDevsCategories is the name of the option to set DevsAnalysis.Categories.
+	ConfigDevsCategories = "Devs.Categories"
+	// ConfigDevsTestPathRegexp is the name of the option to set DevsAnalysis.TestPathRegexp.
+	ConfigDevsTestPathRegexp = "Devs.TestPathRegexp"
+)
+
+// The change categories of DevTick.Categories.
+const (
+	// DevsCategoryNew is the category of the added files.
+	DevsCategoryNew = "new"
+	// DevsCategoryModified is the category of the modified files which kept their names.
+	DevsCategoryModified = "modified"
+	// DevsCategoryDeleted is the category of the deleted files.
+	DevsCategoryDeleted = "deleted"
+	// DevsCategoryRenamed is the category of the renamed files, modified or not.
+	DevsCategoryRenamed = "renamed"
+	// DevsCategoryTestPrefix is prepended to the categories of the files which match
+	// DevsAnalysis.TestPathRegexp.
+	DevsCategoryTestPrefix = "test_"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -96,6 +143,20 @@ func (devs *DevsAnalysis) ListConfigurationOptions() []core.ConfigurationOption
 		Flag:        "empty-commits",
 		Type:        core.BoolConfigurationOption,
 		Default:     false,
+	}, {
+		Name: ConfigDevsCategories,
+		Description: "Split the line stats by the change category: new files, modified files, " +
+			"deleted files and renames.",
+		Flag:    "devs-categories",
+		Type:    core.BoolConfigurationOption,
+		Default: false,
+	}, {
+		Name: ConfigDevsTestPathRegexp,
+		Description: "Regular expression of the test file paths whose changes are categorized " +
+			"apart from the rest with --devs-categories.",
+		Flag:    "devs-test-regexp",
+		Type:    core.StringConfigurationOption,
+		Default: "",
 	}}
 	return options[:]
 }
@@ -108,9 +169,25 @@ func (devs *DevsAnalysis) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigDevsConsiderEmptyCommits].(bool); exists {
 		devs.ConsiderEmptyCommits = val
 	}
+	if val, exists := facts[ConfigDevsCategories].(bool); exists {
+		devs.Categories = val
+	}
+	if val, exists := facts[ConfigDevsTestPathRegexp].(string); exists && val != "" {
+		pattern, err := regexp.Compile(val)
+		if err != nil {
+			return fmt.Errorf("invalid --devs-test-regexp %q: %w", val, err)
+		}
+		devs.TestPathRegexp = pattern
+	}
 	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		devs.reversedPeopleDict = val
 	}
+	if val, exists := facts[identity.FactIdentityDetectorOrganizations].([]string); exists {
+		devs.organizations = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorCommitAuthors].(map[plumbing.Hash][]identity.CommitAuthor); exists {
+		devs.commitAuthors = val
+	}
 	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
 		devs.tickSize = val
 	}
@@ -126,6 +203,11 @@ func (devs *DevsAnalysis) Flag() string {
 	return "devs"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (devs *DevsAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (devs *DevsAnalysis) Description() string {
 	return "Calculates the number of commits, added, removed and changed lines per developer through time."
@@ -163,34 +245,113 @@ func (devs *DevsAnalysis) Consume(deps map[string]interface{}) (map[string]inter
 		devstick = map[int]*DevTick{}
 		devs.ticks[tick] = devstick
 	}
-	dd, exists := devstick[author]
-	if !exists {
-		dd = &DevTick{Languages: map[string]items.LineStats{}}
-		devstick[author] = dd
+	authors := []identity.CommitAuthor{{ID: author, Share: 1}}
+	if commit, ok := deps[core.DependencyCommit].(*object.Commit); ok {
+		if val, exists := devs.commitAuthors[commit.Hash]; exists {
+			authors = val
+		}
+	}
+	dds := make([]*DevTick, len(authors))
+	for i, coauthor := range authors {
+		dd, exists := devstick[coauthor.ID]
+		if !exists {
+			dd = &DevTick{Languages: map[string]items.LineStats{}}
+			devstick[coauthor.ID] = dd
+		}
+		dd.Commits++
+		dds[i] = dd
 	}
-	dd.Commits++
 	langs := deps[items.DependencyLanguages].(map[plumbing.Hash]string)
 	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	var categories map[object.ChangeEntry]string
+	if devs.Categories {
+		var err error
+		if categories, err = devs.categorizeChanges(treeDiff); err != nil {
+			return nil, err
+		}
+	}
 	for changeEntry, stats := range lineStats {
-		dd.Added += stats.Added
-		dd.Removed += stats.Removed
-		dd.Changed += stats.Changed
 		lang := langs[changeEntry.TreeEntry.Hash]
-		langStats := dd.Languages[lang]
-		dd.Languages[lang] = items.LineStats{
-			Added:   langStats.Added + stats.Added,
-			Removed: langStats.Removed + stats.Removed,
-			Changed: langStats.Changed + stats.Changed,
+		for i, part := range splitLineStats(stats, authors) {
+			dd := dds[i]
+			dd.LineStats = dd.LineStats.Add(part)
+			dd.Languages[lang] = dd.Languages[lang].Add(part)
+			if category, exists := categories[changeEntry]; exists {
+				if dd.Categories == nil {
+					dd.Categories = map[string]items.LineStats{}
+				}
+				dd.Categories[category] = dd.Categories[category].Add(part)
+			}
 		}
 	}
 	return nil, nil
 }
 
+// categorizeChanges maps the change entries which key the line stats to the change categories.
+func (devs *DevsAnalysis) categorizeChanges(treeDiff object.Changes) (map[object.ChangeEntry]string, error) {
+	categories := make(map[object.ChangeEntry]string, len(treeDiff))
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		entry, category := change.To, DevsCategoryModified
+		switch action {
+		case merkletrie.Insert:
+			category = DevsCategoryNew
+		case merkletrie.Delete:
+			entry, category = change.From, DevsCategoryDeleted
+		case merkletrie.Modify:
+			if change.From.Name != change.To.Name {
+				category = DevsCategoryRenamed
+			}
+		}
+		if devs.TestPathRegexp != nil && devs.TestPathRegexp.MatchString(entry.Name) {
+			category = DevsCategoryTestPrefix + category
+		}
+		categories[entry] = category
+	}
+	return categories, nil
+}
+
+// splitLineStats distributes the line stats of a change among the commit authors.
+func splitLineStats(stats items.LineStats, authors []identity.CommitAuthor) []items.LineStats {
+	if len(authors) == 1 {
+		return []items.LineStats{stats}
+	}
+	added := identity.SplitLines(stats.Added, authors)
+	removed := identity.SplitLines(stats.Removed, authors)
+	changed := identity.SplitLines(stats.Changed, authors)
+	comments := splitLineCounts(stats.Comments, authors)
+	blanks := splitLineCounts(stats.Blanks, authors)
+	parts := make([]items.LineStats, len(authors))
+	for i := range parts {
+		parts[i] = items.LineStats{
+			Added: added[i], Removed: removed[i], Changed: changed[i],
+			Comments: comments[i], Blanks: blanks[i],
+		}
+	}
+	return parts
+}
+
+// splitLineCounts distributes the comment or blank lines of a change among the commit authors.
+func splitLineCounts(counts items.LineCounts, authors []identity.CommitAuthor) []items.LineCounts {
+	added := identity.SplitLines(counts.Added, authors)
+	removed := identity.SplitLines(counts.Removed, authors)
+	changed := identity.SplitLines(counts.Changed, authors)
+	parts := make([]items.LineCounts, len(authors))
+	for i := range parts {
+		parts[i] = items.LineCounts{Added: added[i], Removed: removed[i], Changed: changed[i]}
+	}
+	return parts
+}
+
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (devs *DevsAnalysis) Finalize() interface{} {
 	return DevsResult{
 		Ticks:              devs.ticks,
 		reversedPeopleDict: devs.reversedPeopleDict,
+		organizations:      devs.organizations,
 		tickSize:           devs.tickSize,
 	}
 }
@@ -200,6 +361,57 @@ func (devs *DevsAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(devs, n)
 }
 
+// FilterResult drops the developers who are not in the filter's Authors or changed fewer
+// than MinLines lines, see core.OutputFilter. The remaining developers are renumbered.
+func (devs *DevsAnalysis) FilterResult(result interface{}, filter core.OutputFilter) interface{} {
+	devsResult := result.(DevsResult)
+	if filter.MinLines <= 0 && len(filter.Authors) == 0 {
+		return devsResult
+	}
+	lines := map[int]int{}
+	for _, tick := range devsResult.Ticks {
+		for dev, stats := range tick {
+			lines[dev] += stats.Added + stats.Removed + stats.Changed
+		}
+	}
+	remap := map[int]int{}
+	var people, organizations []string
+	for dev, identity := range devsResult.reversedPeopleDict {
+		if lines[dev] < filter.MinLines || !filter.AllowsAuthor(identity) {
+			continue
+		}
+		remap[dev] = len(people)
+		people = append(people, identity)
+		if dev < len(devsResult.organizations) {
+			organizations = append(organizations, devsResult.organizations[dev])
+		}
+	}
+	if len(filter.Authors) == 0 && lines[core.AuthorMissing] >= filter.MinLines {
+		remap[core.AuthorMissing] = core.AuthorMissing
+	}
+	ticks := make(map[int]map[int]*DevTick, len(devsResult.Ticks))
+	for tick, tickDevs := range devsResult.Ticks {
+		filtered := map[int]*DevTick{}
+		for dev, stats := range tickDevs {
+			if newDev, exists := remap[dev]; exists {
+				filtered[newDev] = stats
+			}
+		}
+		if len(filtered) > 0 {
+			ticks[tick] = filtered
+		}
+	}
+	devsResult.Ticks = ticks
+	devsResult.reversedPeopleDict = people
+	devsResult.organizations = organizations
+	return devsResult
+}
+
+// AnonymizePaths returns the result as is because it contains no paths, see core.PathAnonymizer.
+func (devs *DevsAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	return result
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (devs *DevsAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -218,29 +430,30 @@ func (devs *DevsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	if message.TickSize <= 0 {
+		return nil, fmt.Errorf("invalid tick size %d: must be positive", message.TickSize)
+	}
 	ticks := map[int]map[int]*DevTick{}
 	for tick, dd := range message.Ticks {
 		rdd := map[int]*DevTick{}
 		ticks[int(tick)] = rdd
-		for dev, stats := range dd.Devs {
+		for dev, stats := range dd.GetDevs() {
 			if dev == -1 {
 				dev = core.AuthorMissing
 			}
 			languages := map[string]items.LineStats{}
 			rdd[int(dev)] = &DevTick{
-				Commits: int(stats.Commits),
-				LineStats: items.LineStats{
-					Added:   int(stats.Stats.Added),
-					Removed: int(stats.Stats.Removed),
-					Changed: int(stats.Stats.Changed),
-				},
+				Commits:   int(stats.GetCommits()),
+				LineStats: lineStatsFromPb(stats.GetStats()),
 				Languages: languages,
 			}
-			for lang, ls := range stats.Languages {
-				languages[lang] = items.LineStats{
-					Added:   int(ls.Added),
-					Removed: int(ls.Removed),
-					Changed: int(ls.Changed),
+			for lang, ls := range stats.GetLanguages() {
+				languages[lang] = lineStatsFromPb(ls)
+			}
+			if len(stats.GetCategories()) > 0 {
+				rdd[int(dev)].Categories = map[string]items.LineStats{}
+				for category, ls := range stats.GetCategories() {
+					rdd[int(dev)].Categories[category] = lineStatsFromPb(ls)
 				}
 			}
 		}
@@ -248,6 +461,7 @@ func (devs *DevsAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
 	result := DevsResult{
 		Ticks:              ticks,
 		reversedPeopleDict: message.DevIndex,
+		organizations:      message.Organizations,
 		tickSize:           time.Duration(message.TickSize),
 	}
 	return result, nil
@@ -274,6 +488,8 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 	var mergedIndex map[string]join.JoinedIndex
 	mergedIndex, merged.reversedPeopleDict = join.PeopleIdentities(
 		cr1.reversedPeopleDict, cr2.reversedPeopleDict)
+	merged.organizations = mergeOrganizations(
+		mergedIndex, len(merged.reversedPeopleDict), &cr1, &cr2)
 	newticks := map[int]map[int]*DevTick{}
 	merged.Ticks = newticks
 	for tick, dd := range cr1.Ticks {
@@ -294,16 +510,15 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 				newdd[newdev] = newstats
 			}
 			newstats.Commits += stats.Commits
-			newstats.Added += stats.Added
-			newstats.Removed += stats.Removed
-			newstats.Changed += stats.Changed
+			newstats.LineStats = newstats.LineStats.Add(stats.LineStats)
 			for lang, ls := range stats.Languages {
-				prev := newstats.Languages[lang]
-				newstats.Languages[lang] = items.LineStats{
-					Added:   prev.Added + ls.Added,
-					Removed: prev.Removed + ls.Removed,
-					Changed: prev.Changed + ls.Changed,
+				newstats.Languages[lang] = newstats.Languages[lang].Add(ls)
+			}
+			for category, ls := range stats.Categories {
+				if newstats.Categories == nil {
+					newstats.Categories = map[string]items.LineStats{}
 				}
+				newstats.Categories[category] = newstats.Categories[category].Add(ls)
 			}
 		}
 	}
@@ -325,22 +540,80 @@ func (devs *DevsAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAn
 				newdd[newdev] = newstats
 			}
 			newstats.Commits += stats.Commits
-			newstats.Added += stats.Added
-			newstats.Removed += stats.Removed
-			newstats.Changed += stats.Changed
+			newstats.LineStats = newstats.LineStats.Add(stats.LineStats)
 			for lang, ls := range stats.Languages {
-				prev := newstats.Languages[lang]
-				newstats.Languages[lang] = items.LineStats{
-					Added:   prev.Added + ls.Added,
-					Removed: prev.Removed + ls.Removed,
-					Changed: prev.Changed + ls.Changed,
+				newstats.Languages[lang] = newstats.Languages[lang].Add(ls)
+			}
+			for category, ls := range stats.Categories {
+				if newstats.Categories == nil {
+					newstats.Categories = map[string]items.LineStats{}
 				}
+				newstats.Categories[category] = newstats.Categories[category].Add(ls)
+			}
+		}
+	}
+	return merged
+}
+
+// mergeOrganizations reindexes the organizations of the merged developers.
+func mergeOrganizations(
+	mergedIndex map[string]join.JoinedIndex, size int, results ...*DevsResult,
+) []string {
+	var merged []string
+	for _, result := range results {
+		for dev, org := range result.organizations {
+			if dev >= len(result.reversedPeopleDict) {
+				break
+			}
+			if merged == nil {
+				merged = make([]string, size)
+			}
+			if final := mergedIndex[result.reversedPeopleDict[dev]].Final; merged[final] == "" {
+				merged[final] = org
 			}
 		}
 	}
 	return merged
 }
 
+// OrganizationTotals sums the statistics of the developers per organization.
+// It returns nil if the organizations were not inferred.
+func (dr DevsResult) OrganizationTotals() map[string]*DevsOrganization {
+	if len(dr.organizations) == 0 {
+		return nil
+	}
+	orgOf := func(dev int) string {
+		if dev < 0 || dev >= len(dr.organizations) || dr.organizations[dev] == "" {
+			return identity.OrganizationUnknown
+		}
+		return dr.organizations[dev]
+	}
+	totals := map[string]*DevsOrganization{}
+	developers := map[int]bool{}
+	for _, dd := range dr.Ticks {
+		for dev, stats := range dd {
+			if dev == core.AuthorMissing {
+				continue
+			}
+			org := orgOf(dev)
+			total := totals[org]
+			if total == nil {
+				total = &DevsOrganization{}
+				totals[org] = total
+			}
+			if !developers[dev] {
+				developers[dev] = true
+				total.Developers++
+			}
+			total.Commits += stats.Commits
+			total.Added += stats.Added
+			total.Removed += stats.Removed
+			total.Changed += stats.Changed
+		}
+	}
+	return totals
+}
+
 func (devs *DevsAnalysis) serializeText(result *DevsResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  ticks:")
 	ticks := make([]int, len(result.Ticks))
@@ -383,16 +656,114 @@ func (devs *DevsAnalysis) serializeText(result *DevsResult, writer io.Writer) {
 				strings.Join(langs, ", "))
 		}
 	}
+	devs.serializeLineKinds(result, ticks, writer)
+	devs.serializeCategories(result, ticks, writer)
 	fmt.Fprintln(writer, "  people:")
 	for _, person := range result.reversedPeopleDict {
 		fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(person))
 	}
+	if totals := result.OrganizationTotals(); totals != nil {
+		fmt.Fprintln(writer, "  organizations:")
+		for _, org := range result.organizations {
+			fmt.Fprintf(writer, "  - %s\n", yaml.SafeString(org))
+		}
+		orgs := make([]string, 0, len(totals))
+		for org := range totals {
+			orgs = append(orgs, org)
+		}
+		sort.Strings(orgs)
+		fmt.Fprintln(writer, "  per_organization:")
+		for _, org := range orgs {
+			total := totals[org]
+			fmt.Fprintf(writer, "    %s: {developers: %d, commits: %d, added: %d, removed: %d, changed: %d}\n",
+				yaml.SafeString(org), total.Developers, total.Commits,
+				total.Added, total.Removed, total.Changed)
+		}
+	}
 	fmt.Fprintln(writer, "  tick_size:", int(result.tickSize.Seconds()))
 }
 
+// serializeLineKinds writes the comment and blank lines per tick and developer if they were
+// counted with --classify-lines. They are kept apart from "ticks" to preserve its format.
+func (devs *DevsAnalysis) serializeLineKinds(result *DevsResult, ticks []int, writer io.Writer) {
+	classified := false
+	for _, tick := range ticks {
+		for _, stats := range result.Ticks[tick] {
+			if stats.Comments != (items.LineCounts{}) || stats.Blanks != (items.LineCounts{}) {
+				classified = true
+				break
+			}
+		}
+	}
+	if !classified {
+		return
+	}
+	fmt.Fprintln(writer, "  line_kinds:")
+	for _, tick := range ticks {
+		fmt.Fprintf(writer, "    %d:\n", tick)
+		rtick := result.Ticks[tick]
+		devseq := make([]int, 0, len(rtick))
+		for dev := range rtick {
+			devseq = append(devseq, dev)
+		}
+		sort.Ints(devseq)
+		for _, dev := range devseq {
+			stats := rtick[dev]
+			if dev == core.AuthorMissing {
+				dev = -1
+			}
+			// comments added, removed, changed, blanks added, removed, changed
+			fmt.Fprintf(writer, "      %d: [%d, %d, %d, %d, %d, %d]\n", dev,
+				stats.Comments.Added, stats.Comments.Removed, stats.Comments.Changed,
+				stats.Blanks.Added, stats.Blanks.Removed, stats.Blanks.Changed)
+		}
+	}
+}
+
+// serializeCategories writes the line stats per change category, tick and developer if they were
+// split with --devs-categories.
+func (devs *DevsAnalysis) serializeCategories(result *DevsResult, ticks []int, writer io.Writer) {
+	categorized := false
+	for _, tick := range ticks {
+		for _, stats := range result.Ticks[tick] {
+			if len(stats.Categories) > 0 {
+				categorized = true
+				break
+			}
+		}
+	}
+	if !categorized {
+		return
+	}
+	fmt.Fprintln(writer, "  categories:")
+	for _, tick := range ticks {
+		fmt.Fprintf(writer, "    %d:\n", tick)
+		rtick := result.Ticks[tick]
+		devseq := make([]int, 0, len(rtick))
+		for dev := range rtick {
+			devseq = append(devseq, dev)
+		}
+		sort.Ints(devseq)
+		for _, dev := range devseq {
+			stats := rtick[dev]
+			if dev == core.AuthorMissing {
+				dev = -1
+			}
+			var categories []string
+			for category, ls := range stats.Categories {
+				categories = append(categories,
+					fmt.Sprintf("%s: [%d, %d, %d]", category, ls.Added, ls.Removed, ls.Changed))
+			}
+			sort.Strings(categories)
+			fmt.Fprintf(writer, "      %d: {%s}\n", dev, strings.Join(categories, ", "))
+		}
+	}
+}
+
 func (devs *DevsAnalysis) serializeBinary(result *DevsResult, writer io.Writer) error {
 	message := pb.DevsAnalysisResults{}
 	message.DevIndex = result.reversedPeopleDict
+	message.Organizations = result.organizations
 	message.TickSize = int64(result.tickSize)
 	message.Ticks = map[int32]*pb.TickDevs{}
 	for tick, devs := range result.Ticks {
@@ -405,20 +776,19 @@ func (devs *DevsAnalysis) serializeBinary(result *DevsResult, writer io.Writer)
 			}
 			languages := map[string]*pb.LineStats{}
 			dd.Devs[int32(dev)] = &pb.DevTick{
-				Commits: int32(stats.Commits),
-				Stats: &pb.LineStats{
-					Added:   int32(stats.Added),
-					Changed: int32(stats.Changed),
-					Removed: int32(stats.Removed),
-				},
+				Commits:   int32(stats.Commits),
+				Stats:     lineStatsToPb(stats.LineStats),
 				Languages: languages,
 			}
 			for lang, ls := range stats.Languages {
-				languages[lang] = &pb.LineStats{
-					Added:   int32(ls.Added),
-					Changed: int32(ls.Changed),
-					Removed: int32(ls.Removed),
+				languages[lang] = lineStatsToPb(ls)
+			}
+			if len(stats.Categories) > 0 {
+				categories := map[string]*pb.LineStats{}
+				for category, ls := range stats.Categories {
+					categories[category] = lineStatsToPb(ls)
 				}
+				dd.Devs[int32(dev)].Categories = categories
 			}
 		}
 	}
@@ -430,6 +800,50 @@ func (devs *DevsAnalysis) serializeBinary(result *DevsResult, writer io.Writer)
 	return err
 }
 
+// lineStatsToPb converts the line stats to the protobuf message. The line kinds are omitted
+// unless they were counted.
+func lineStatsToPb(stats items.LineStats) *pb.LineStats {
+	message := &pb.LineStats{
+		Added:   int32(stats.Added),
+		Changed: int32(stats.Changed),
+		Removed: int32(stats.Removed),
+	}
+	if stats.Comments != (items.LineCounts{}) {
+		message.Comments = &pb.LineCounts{
+			Added:   int32(stats.Comments.Added),
+			Changed: int32(stats.Comments.Changed),
+			Removed: int32(stats.Comments.Removed),
+		}
+	}
+	if stats.Blanks != (items.LineCounts{}) {
+		message.Blanks = &pb.LineCounts{
+			Added:   int32(stats.Blanks.Added),
+			Changed: int32(stats.Blanks.Changed),
+			Removed: int32(stats.Blanks.Removed),
+		}
+	}
+	return message
+}
+
+// lineStatsFromPb is the inverse of lineStatsToPb.
+func lineStatsFromPb(message *pb.LineStats) items.LineStats {
+	return items.LineStats{
+		Added:   int(message.GetAdded()),
+		Removed: int(message.GetRemoved()),
+		Changed: int(message.GetChanged()),
+		Comments: items.LineCounts{
+			Added:   int(message.GetComments().GetAdded()),
+			Removed: int(message.GetComments().GetRemoved()),
+			Changed: int(message.GetComments().GetChanged()),
+		},
+		Blanks: items.LineCounts{
+			Added:   int(message.GetBlanks().GetAdded()),
+			Removed: int(message.GetBlanks().GetRemoved()),
+			Changed: int(message.GetBlanks().GetChanged()),
+		},
+	}
+}
+
 // GetTickSize returns the tick size used to generate this devs analysis result.
 func (dr DevsResult) GetTickSize() time.Duration {
 	return dr.tickSize
@@ -441,6 +855,41 @@ func (dr DevsResult) GetIdentities() []string {
 	return dr.reversedPeopleDict
 }
 
+// devsCheckpoint is the state of DevsAnalysis which Checkpoint() saves.
+type devsCheckpoint struct {
+	Ticks  map[int]map[int]*DevTick
+	Merges []plumbing.Hash
+}
+
+// Checkpoint writes the stats of each tick and the consumed merge commits.
+func (devs *DevsAnalysis) Checkpoint(writer io.Writer) error {
+	return gob.NewEncoder(writer).Encode(devsCheckpoint{
+		Ticks: devs.ticks, Merges: devs.ConsumedMerges(),
+	})
+}
+
+// Restore reads the state which Checkpoint() wrote.
+func (devs *DevsAnalysis) Restore(reader io.Reader) error {
+	var state devsCheckpoint
+	if err := gob.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+	for tick, devstick := range state.Ticks {
+		if len(devstick) == 0 {
+			continue
+		}
+		for _, dd := range devstick {
+			// gob omits the empty maps
+			if dd.Languages == nil {
+				dd.Languages = map[string]items.LineStats{}
+			}
+		}
+		devs.ticks[tick] = devstick
+	}
+	devs.RestoreMerges(state.Merges)
+	return nil
+}
+
 func init() {
 	core.Registry.Register(&DevsAnalysis{})
 }
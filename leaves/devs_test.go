@@ -37,11 +37,13 @@ func TestDevsMeta(t *testing.T) {
 	assert.Equal(t, d.Requires()[3], items.DependencyLanguages)
 	assert.Equal(t, d.Requires()[4], items.DependencyLineStats)
 	assert.Equal(t, d.Flag(), "devs")
-	assert.Len(t, d.ListConfigurationOptions(), 1)
+	assert.Len(t, d.ListConfigurationOptions(), 3)
 	assert.Equal(t, d.ListConfigurationOptions()[0].Name, ConfigDevsConsiderEmptyCommits)
 	assert.Equal(t, d.ListConfigurationOptions()[0].Flag, "empty-commits")
 	assert.Equal(t, d.ListConfigurationOptions()[0].Type, core.BoolConfigurationOption)
 	assert.Equal(t, d.ListConfigurationOptions()[0].Default, false)
+	assert.Equal(t, d.ListConfigurationOptions()[1].Flag, "devs-categories")
+	assert.Equal(t, d.ListConfigurationOptions()[2].Flag, "devs-test-regexp")
 	assert.True(t, len(d.Description()) > 0)
 	logger := core.NewLogger()
 	assert.NoError(t, d.Configure(map[string]interface{}{
@@ -73,6 +75,15 @@ func TestDevsConfigure(t *testing.T) {
 	assert.NoError(t, devs.Configure(facts))
 	assert.True(t, devs.ConsiderEmptyCommits)
 	assert.Equal(t, 3*time.Hour, devs.tickSize)
+	assert.False(t, devs.Categories)
+	assert.Nil(t, devs.TestPathRegexp)
+	facts[ConfigDevsCategories] = true
+	facts[ConfigDevsTestPathRegexp] = `_test\.go$`
+	assert.NoError(t, devs.Configure(facts))
+	assert.True(t, devs.Categories)
+	assert.True(t, devs.TestPathRegexp.MatchString("a/b_test.go"))
+	facts[ConfigDevsTestPathRegexp] = "("
+	assert.Error(t, devs.Configure(facts))
 }
 
 func TestDevsInitialize(t *testing.T) {
@@ -82,6 +93,35 @@ func TestDevsInitialize(t *testing.T) {
 	assert.Error(t, d.Initialize(test.Repository))
 }
 
+func TestDevsConsumeCoAuthors(t *testing.T) {
+	devs := fixtureDevs()
+	commit := &object.Commit{Hash: plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48")}
+	assert.NoError(t, devs.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorCommitAuthors: map[plumbing.Hash][]identity.CommitAuthor{
+			commit.Hash: {{ID: 0, Share: 0.5}, {ID: 1, Share: 0.5}},
+		},
+	}))
+	entry := object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+		Name: "main.go", Hash: plumbing.NewHash("baa64828831d174f40140e4b3cfa77d1e917a2c1"),
+	}}
+	_, err := devs.Consume(map[string]interface{}{
+		core.DependencyCommit:       commit,
+		identity.DependencyAuthor:   0,
+		items.DependencyTick:        0,
+		items.DependencyTreeChanges: object.Changes{&object.Change{To: entry}},
+		items.DependencyLanguages:   map[plumbing.Hash]string{entry.TreeEntry.Hash: "Go"},
+		items.DependencyLineStats:   map[object.ChangeEntry]items.LineStats{entry: {Added: 11, Removed: 4, Changed: 1}},
+	})
+	assert.NoError(t, err)
+	ticks := devs.Finalize().(DevsResult).Ticks
+	assert.Len(t, ticks[0], 2)
+	assert.Equal(t, 1, ticks[0][0].Commits)
+	assert.Equal(t, 1, ticks[0][1].Commits)
+	assert.Equal(t, items.LineStats{Added: 5, Removed: 2, Changed: 0}, ticks[0][0].LineStats)
+	assert.Equal(t, items.LineStats{Added: 6, Removed: 2, Changed: 1}, ticks[0][1].LineStats)
+	assert.Equal(t, ticks[0][1].LineStats, ticks[0][1].Languages["Go"])
+}
+
 func TestDevsConsumeFinalize(t *testing.T) {
 	devs := fixtureDevs()
 	deps := map[string]interface{}{}
@@ -258,7 +298,7 @@ func ls(added, removed, changed int) items.LineStats {
 func TestDevsFinalize(t *testing.T) {
 	devs := fixtureDevs()
 	devs.ticks[1] = map[int]*DevTick{}
-	devs.ticks[1][1] = &DevTick{10, ls(20, 30, 40), nil}
+	devs.ticks[1][1] = &DevTick{10, ls(20, 30, 40), nil, nil}
 	x := devs.Finalize().(DevsResult)
 	assert.Equal(t, x.Ticks, devs.ticks)
 	assert.Equal(t, x.reversedPeopleDict, devs.reversedPeopleDict)
@@ -271,15 +311,35 @@ func TestDevsFork(t *testing.T) {
 	assert.True(t, devs == clone)
 }
 
+func TestDevsCheckpointRestore(t *testing.T) {
+	devs := fixtureDevs()
+	devs.ticks[1] = map[int]*DevTick{}
+	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(2, 3, 4)}, nil}
+	devs.ticks[2] = map[int]*DevTick{}
+	devs.ticks[2][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{}, nil}
+	merge := &object.Commit{
+		Hash:         plumbing.NewHash("cce947b98a050c6d356bc6ba95030254914027b1"),
+		ParentHashes: []plumbing.Hash{plumbing.ZeroHash, plumbing.ZeroHash},
+	}
+	assert.True(t, devs.ShouldConsumeCommit(map[string]interface{}{core.DependencyCommit: merge}))
+	var buffer bytes.Buffer
+	assert.Nil(t, devs.Checkpoint(&buffer))
+
+	restored := fixtureDevs()
+	assert.Nil(t, restored.Restore(&buffer))
+	assert.Equal(t, devs.ticks, restored.ticks)
+	assert.False(t, restored.ShouldConsumeCommit(map[string]interface{}{core.DependencyCommit: merge}))
+}
+
 func TestDevsSerialize(t *testing.T) {
 	devs := fixtureDevs()
 	devs.ticks[1] = map[int]*DevTick{}
-	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(2, 3, 4)}}
-	devs.ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(25, 35, 45)}}
+	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(2, 3, 4)}, nil}
+	devs.ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(25, 35, 45)}, nil}
 	devs.ticks[10] = map[int]*DevTick{}
-	devs.ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"": ls(12, 13, 14)}}
+	devs.ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"": ls(12, 13, 14)}, nil}
 	devs.ticks[10][core.AuthorMissing] = &DevTick{
-		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)},
+		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil,
 	}
 	res := devs.Finalize().(DevsResult)
 	buffer := &bytes.Buffer{}
@@ -329,12 +389,12 @@ func TestDevsSerialize(t *testing.T) {
 func TestDevsDeserialize(t *testing.T) {
 	devs := fixtureDevs()
 	devs.ticks[1] = map[int]*DevTick{}
-	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}}
-	devs.ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}}
+	devs.ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil}
+	devs.ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil}
 	devs.ticks[10] = map[int]*DevTick{}
-	devs.ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}}
+	devs.ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil}
 	devs.ticks[10][core.AuthorMissing] = &DevTick{
-		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)},
+		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil,
 	}
 	res := devs.Finalize().(DevsResult)
 	buffer := &bytes.Buffer{}
@@ -346,6 +406,112 @@ func TestDevsDeserialize(t *testing.T) {
 	assert.Equal(t, res, res2)
 }
 
+func TestDevsLineKinds(t *testing.T) {
+	devs := fixtureDevs()
+	commit := &object.Commit{Hash: plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48")}
+	assert.NoError(t, devs.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorCommitAuthors: map[plumbing.Hash][]identity.CommitAuthor{
+			commit.Hash: {{ID: 0, Share: 0.5}, {ID: 1, Share: 0.5}},
+		},
+	}))
+	entry := object.ChangeEntry{Name: "main.go", TreeEntry: object.TreeEntry{
+		Name: "main.go", Hash: plumbing.NewHash("baa64828831d174f40140e4b3cfa77d1e917a2c1"),
+	}}
+	_, err := devs.Consume(map[string]interface{}{
+		core.DependencyCommit:       commit,
+		identity.DependencyAuthor:   0,
+		items.DependencyTick:        0,
+		items.DependencyTreeChanges: object.Changes{&object.Change{To: entry}},
+		items.DependencyLanguages:   map[plumbing.Hash]string{entry.TreeEntry.Hash: "Go"},
+		items.DependencyLineStats: map[object.ChangeEntry]items.LineStats{entry: {
+			Added: 10, Removed: 4,
+			Comments: items.LineCounts{Added: 4, Removed: 2},
+			Blanks:   items.LineCounts{Added: 2},
+		}},
+	})
+	assert.NoError(t, err)
+	res := devs.Finalize().(DevsResult)
+	for dev := 0; dev < 2; dev++ {
+		stats := res.Ticks[0][dev]
+		assert.Equal(t, items.LineCounts{Added: 2, Removed: 1}, stats.Comments)
+		assert.Equal(t, items.LineCounts{Added: 1}, stats.Blanks)
+		assert.Equal(t, items.LineCounts{Added: 2, Removed: 1}, stats.Code())
+		assert.Equal(t, stats.LineStats, stats.Languages["Go"])
+	}
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, devs.Serialize(res, false, buffer))
+	assert.Contains(t, buffer.String(), `  line_kinds:
+    0:
+      0: [2, 1, 0, 1, 0, 0]
+      1: [2, 1, 0, 1, 0, 0]
+  people:
+`)
+	buffer.Reset()
+	assert.NoError(t, devs.Serialize(res, true, buffer))
+	restored, err := devs.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, res, restored)
+
+	merged := devs.MergeResults(res, restored, &core.CommonAnalysisResult{}, &core.CommonAnalysisResult{})
+	assert.Equal(t, items.LineCounts{Added: 4, Removed: 2}, merged.(DevsResult).Ticks[0][0].Comments)
+}
+
+func TestDevsCategories(t *testing.T) {
+	devs := fixtureDevs()
+	assert.NoError(t, devs.Configure(map[string]interface{}{
+		ConfigDevsCategories:     true,
+		ConfigDevsTestPathRegexp: `_test\.go$`,
+	}))
+	entry := func(name, hash string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: plumbing.NewHash(hash)}}
+	}
+	added := entry("new.go", "baa64828831d174f40140e4b3cfa77d1e917a2c1")
+	oldName := entry("old.go", "dc248ba2b22048cc730c571a748e8ffcf7085ab9")
+	renamed := entry("renamed.go", "c29112dbd697ad9b401333b80c18a63951bc18d9")
+	deleted := entry("gone.go", "8fe7f2bd3ae4a7fff1ab52d8d3fad8dd1bebbb1e")
+	from := entry("main_test.go", "f2e9a9a5a5b4dd2d95edf1ec8b0bbf3cde7bd6a6")
+	to := entry("main_test.go", "0ec4db7f1d2bf02e8f1fe4a0ad5e1ccba2a2c7c5")
+	_, err := devs.Consume(map[string]interface{}{
+		core.DependencyCommit:     &object.Commit{Hash: plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48")},
+		identity.DependencyAuthor: 0,
+		items.DependencyTick:      0,
+		items.DependencyTreeChanges: object.Changes{
+			&object.Change{To: added}, &object.Change{From: oldName, To: renamed},
+			&object.Change{From: deleted}, &object.Change{From: from, To: to},
+		},
+		items.DependencyLanguages: map[plumbing.Hash]string{},
+		items.DependencyLineStats: map[object.ChangeEntry]items.LineStats{
+			added: {Added: 10}, renamed: {Added: 1, Changed: 2}, deleted: {Removed: 5}, to: {Added: 3, Removed: 1},
+		},
+	})
+	assert.NoError(t, err)
+	res := devs.Finalize().(DevsResult)
+	assert.Equal(t, map[string]items.LineStats{
+		DevsCategoryNew:     {Added: 10},
+		DevsCategoryRenamed: {Added: 1, Changed: 2},
+		DevsCategoryDeleted: {Removed: 5},
+		DevsCategoryTestPrefix + DevsCategoryModified: {Added: 3, Removed: 1},
+	}, res.Ticks[0][0].Categories)
+	assert.Equal(t, items.LineStats{Added: 14, Removed: 6, Changed: 2}, res.Ticks[0][0].LineStats)
+
+	buffer := &bytes.Buffer{}
+	assert.NoError(t, devs.Serialize(res, false, buffer))
+	assert.Contains(t, buffer.String(), `  categories:
+    0:
+      0: {deleted: [0, 5, 0], new: [10, 0, 0], renamed: [1, 0, 2], test_modified: [3, 1, 0]}
+  people:
+`)
+	buffer.Reset()
+	assert.NoError(t, devs.Serialize(res, true, buffer))
+	restored, err := devs.Deserialize(buffer.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, res, restored)
+
+	merged := devs.MergeResults(res, restored, &core.CommonAnalysisResult{}, &core.CommonAnalysisResult{})
+	assert.Equal(t, items.LineStats{Added: 20}, merged.(DevsResult).Ticks[0][0].Categories[DevsCategoryNew])
+}
+
 func TestDevsMergeResults(t *testing.T) {
 	people1 := [...]string{"1@srcd", "2@srcd"}
 	people2 := [...]string{"3@srcd", "1@srcd"}
@@ -355,32 +521,32 @@ func TestDevsMergeResults(t *testing.T) {
 		tickSize:           24 * time.Hour,
 	}
 	r1.Ticks[1] = map[int]*DevTick{}
-	r1.Ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}}
-	r1.Ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}}
+	r1.Ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil}
+	r1.Ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil}
 	r1.Ticks[10] = map[int]*DevTick{}
-	r1.Ticks[10][0] = &DevTick{11, ls(21, 31, 41), nil}
+	r1.Ticks[10][0] = &DevTick{11, ls(21, 31, 41), nil, nil}
 	r1.Ticks[10][core.AuthorMissing] = &DevTick{
-		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)},
+		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil,
 	}
 	r1.Ticks[11] = map[int]*DevTick{}
-	r1.Ticks[11][1] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}}
+	r1.Ticks[11][1] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil}
 	r2 := DevsResult{
 		Ticks:              map[int]map[int]*DevTick{},
 		reversedPeopleDict: people2[:],
 		tickSize:           22 * time.Hour,
 	}
 	r2.Ticks[1] = map[int]*DevTick{}
-	r2.Ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}}
-	r2.Ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}}
+	r2.Ticks[1][0] = &DevTick{10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil}
+	r2.Ticks[1][1] = &DevTick{1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil}
 	r2.Ticks[2] = map[int]*DevTick{}
-	r2.Ticks[2][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}}
+	r2.Ticks[2][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil}
 	r2.Ticks[2][core.AuthorMissing] = &DevTick{
-		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)},
+		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil,
 	}
 	r2.Ticks[10] = map[int]*DevTick{}
-	r2.Ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}}
+	r2.Ticks[10][0] = &DevTick{11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}, nil}
 	r2.Ticks[10][core.AuthorMissing] = &DevTick{
-		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(62, 63, 64)},
+		100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(62, 63, 64)}, nil,
 	}
 
 	devs := fixtureDevs()
@@ -392,22 +558,22 @@ func TestDevsMergeResults(t *testing.T) {
 	assert.Equal(t, rm.reversedPeopleDict, peoplerm[:])
 	assert.Len(t, rm.Ticks, 4)
 	assert.Equal(t, rm.Ticks[11], map[int]*DevTick{
-		1: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}},
+		1: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[2], map[int]*DevTick{
-		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}},
-		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}},
+		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil},
+		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[1], map[int]*DevTick{
-		0: {11, ls(22, 33, 44), map[string]items.LineStats{"Go": ls(34, 36, 38)}},
-		1: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}},
-		2: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}},
+		0: {11, ls(22, 33, 44), map[string]items.LineStats{"Go": ls(34, 36, 38)}, nil},
+		1: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil},
+		2: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[10], map[int]*DevTick{
-		0: {11, ls(21, 31, 41), map[string]items.LineStats{}},
-		2: {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}},
+		0: {11, ls(21, 31, 41), map[string]items.LineStats{}, nil},
+		2: {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}, nil},
 		core.AuthorMissing: {
-			100 * 2, ls(200*2, 300*2, 400*2), map[string]items.LineStats{"Go": ls(94, 96, 98)},
+			100 * 2, ls(200*2, 300*2, 400*2), map[string]items.LineStats{"Go": ls(94, 96, 98)}, nil,
 		},
 	})
 
@@ -415,25 +581,25 @@ func TestDevsMergeResults(t *testing.T) {
 	rm = devs.MergeResults(r1, r2, &c1, &c2).(DevsResult)
 	assert.Len(t, rm.Ticks, 5)
 	assert.Equal(t, rm.Ticks[1], map[int]*DevTick{
-		0: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}},
-		1: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}},
+		0: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil},
+		1: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[2], map[int]*DevTick{
-		2: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}},
-		0: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}},
+		2: {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(12, 13, 14)}, nil},
+		0: {1, ls(2, 3, 4), map[string]items.LineStats{"Go": ls(22, 23, 24)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[3], map[int]*DevTick{
-		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}},
-		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}},
+		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil},
+		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[10], map[int]*DevTick{
-		0:                  {11, ls(21, 31, 41), map[string]items.LineStats{}},
-		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)}},
+		0:                  {11, ls(21, 31, 41), map[string]items.LineStats{}, nil},
+		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(32, 33, 34)}, nil},
 	})
 	assert.Equal(t, rm.Ticks[11], map[int]*DevTick{
-		1:                  {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}},
-		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}},
-		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(62, 63, 64)}},
+		1:                  {10, ls(20, 30, 40), map[string]items.LineStats{"Go": ls(42, 43, 44)}, nil},
+		2:                  {11, ls(21, 31, 41), map[string]items.LineStats{"Go": ls(52, 53, 54)}, nil},
+		core.AuthorMissing: {100, ls(200, 300, 400), map[string]items.LineStats{"Go": ls(62, 63, 64)}, nil},
 	})
 }
 
@@ -442,3 +608,81 @@ func TestDevsResultGetters(t *testing.T) {
 	assert.Equal(t, dr.tickSize, dr.GetTickSize())
 	assert.Equal(t, dr.GetIdentities(), dr.reversedPeopleDict)
 }
+
+func TestDevsOrganizations(t *testing.T) {
+	devs := fixtureDevs()
+	assert.Nil(t, devs.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorOrganizations: []string{"acme.com", identity.OrganizationIndependent},
+	}))
+	devs.ticks[1] = map[int]*DevTick{
+		0:                  {10, ls(20, 30, 40), nil, nil},
+		1:                  {1, ls(2, 3, 4), nil, nil},
+		core.AuthorMissing: {100, ls(200, 300, 400), nil, nil},
+	}
+	devs.ticks[2] = map[int]*DevTick{0: {5, ls(1, 1, 1), nil, nil}}
+	res := devs.Finalize().(DevsResult)
+	assert.Equal(t, map[string]*DevsOrganization{
+		"acme.com":                       {Developers: 1, Commits: 15, LineStats: ls(21, 31, 41)},
+		identity.OrganizationIndependent: {Developers: 1, Commits: 1, LineStats: ls(2, 3, 4)},
+	}, res.OrganizationTotals())
+	assert.Nil(t, DevsResult{}.OrganizationTotals())
+
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, devs.Serialize(res, false, buffer))
+	assert.Contains(t, buffer.String(), "  organizations:\n  - \"acme.com\"\n  - \"independent\"\n")
+	assert.Contains(t, buffer.String(),
+		"    \"acme.com\": {developers: 1, commits: 15, added: 21, removed: 31, changed: 41}\n")
+	buffer.Reset()
+	assert.Nil(t, devs.Serialize(res, true, buffer))
+	res2, err := devs.Deserialize(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, res.organizations, res2.(DevsResult).organizations)
+}
+
+func TestDevsMergeOrganizations(t *testing.T) {
+	r1 := DevsResult{
+		Ticks:              map[int]map[int]*DevTick{},
+		reversedPeopleDict: []string{"1@srcd", "2@srcd"},
+		organizations:      []string{"srcd", ""},
+		tickSize:           24 * time.Hour,
+	}
+	r2 := DevsResult{
+		Ticks:              map[int]map[int]*DevTick{},
+		reversedPeopleDict: []string{"3@srcd", "2@srcd"},
+		organizations:      []string{"other", "srcd"},
+		tickSize:           24 * time.Hour,
+	}
+	c := core.CommonAnalysisResult{BeginTime: 1556224895}
+	rm := fixtureDevs().MergeResults(r1, r2, &c, &c).(DevsResult)
+	assert.Equal(t, []string{"1@srcd", "2@srcd", "3@srcd"}, rm.reversedPeopleDict)
+	assert.Equal(t, []string{"srcd", "srcd", "other"}, rm.organizations)
+	r1.organizations, r2.organizations = nil, nil
+	rm = fixtureDevs().MergeResults(r1, r2, &c, &c).(DevsResult)
+	assert.Nil(t, rm.organizations)
+}
+
+func TestDevsFilterResult(t *testing.T) {
+	devs := &DevsAnalysis{}
+	result := DevsResult{
+		Ticks: map[int]map[int]*DevTick{
+			0: {0: {Commits: 1, LineStats: items.LineStats{Added: 10}},
+				1: {Commits: 1, LineStats: items.LineStats{Added: 1}}},
+			1: {2: {Commits: 2, LineStats: items.LineStats{Removed: 20}},
+				core.AuthorMissing: {Commits: 1, LineStats: items.LineStats{Added: 30}}},
+		},
+		reversedPeopleDict: []string{"alice|alice@example.com", "bob|bob@example.com", "carol|carol@example.com"},
+		organizations:      []string{"example.com", "example.com", "example.com"},
+	}
+	filtered := devs.FilterResult(result, core.OutputFilter{MinLines: 5}).(DevsResult)
+	assert.Equal(t, []string{"alice|alice@example.com", "carol|carol@example.com"}, filtered.reversedPeopleDict)
+	assert.Equal(t, []string{"example.com", "example.com"}, filtered.organizations)
+	assert.Equal(t, map[int]map[int]*DevTick{
+		0: {0: result.Ticks[0][0]},
+		1: {1: result.Ticks[1][2], core.AuthorMissing: result.Ticks[1][core.AuthorMissing]},
+	}, filtered.Ticks)
+
+	filtered = devs.FilterResult(result, core.OutputFilter{Authors: []string{"Bob@Example.com"}}).(DevsResult)
+	assert.Equal(t, []string{"bob|bob@example.com"}, filtered.reversedPeopleDict)
+	assert.Equal(t, map[int]map[int]*DevTick{0: {0: result.Ticks[0][1]}}, filtered.Ticks)
+	assert.Len(t, result.reversedPeopleDict, 3)
+}
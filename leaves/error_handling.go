@@ -0,0 +1,232 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+)
+
+const (
+	// ConfigErrorHandlingIdioms is the name of the option to select the counted idioms.
+	ConfigErrorHandlingIdioms = "ErrorHandling.Idioms"
+)
+
+// ErrorHandlingIdioms are the curated error handling patterns which ErrorHandlingAnalysis counts.
+var ErrorHandlingIdioms = []RegexMetric{
+	{
+		Name:      "go_errors_wrap",
+		Languages: []string{"Go"},
+		Pattern:   regexp.MustCompile(`\berrors\.(Wrap|Wrapf|WithMessage|WithMessagef|WithStack)\(`),
+	},
+	{
+		Name:      "go_errorf_wrap",
+		Languages: []string{"Go"},
+		Pattern:   regexp.MustCompile(`\bfmt\.Errorf\([^\n]*%w`),
+	},
+	{
+		Name:      "go_errorf",
+		Languages: []string{"Go"},
+		Pattern:   regexp.MustCompile(`\bfmt\.Errorf\(`),
+	},
+	{
+		Name:      "go_errors_is_as",
+		Languages: []string{"Go"},
+		Pattern:   regexp.MustCompile(`\berrors\.(Is|As)\(`),
+	},
+	{
+		Name:      "go_panic",
+		Languages: []string{"Go"},
+		Pattern:   regexp.MustCompile(`\bpanic\(`),
+	},
+	{
+		Name:      "python_bare_except",
+		Languages: []string{"Python"},
+		Pattern:   regexp.MustCompile(`(?m)^\s*except\s*:`),
+	},
+	{
+		Name:      "python_broad_except",
+		Languages: []string{"Python"},
+		Pattern:   regexp.MustCompile(`(?m)^\s*except\s*\(?\s*(Exception|BaseException)\b`),
+	},
+	{
+		Name:      "python_raise_from",
+		Languages: []string{"Python"},
+		Pattern:   regexp.MustCompile(`(?m)^\s*raise\s+[^\n#]+\sfrom\s`),
+	},
+	{
+		Name:      "empty_catch",
+		Languages: []string{"Java", "JavaScript", "TypeScript", "TSX", "Kotlin", "C#"},
+		Pattern:   regexp.MustCompile(`\bcatch\s*(\([^)]*\))?\s*\{\s*\}`),
+	},
+}
+
+// ErrorHandlingAnalysis counts the error handling idioms per directory over time to show how
+// the practices are adopted or abandoned.
+type ErrorHandlingAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// Idioms are the names of the counted ErrorHandlingIdioms; empty means all.
+	Idioms []string
+
+	counter  *regexMetricsCounter
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (eh *ErrorHandlingAnalysis) Name() string {
+	return "ErrorHandling"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (eh *ErrorHandlingAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (eh *ErrorHandlingAnalysis) Requires() []string {
+	return []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLanguages,
+		items.DependencyTick,
+	}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (eh *ErrorHandlingAnalysis) Flag() string {
+	return "error-handling"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (eh *ErrorHandlingAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (eh *ErrorHandlingAnalysis) Description() string {
+	return "Counts the error handling idioms (Go error wrapping and panics, Python bare excepts, " +
+		"empty catch blocks) per directory over time."
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (eh *ErrorHandlingAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	names := make([]string, len(ErrorHandlingIdioms))
+	for i, idiom := range ErrorHandlingIdioms {
+		names[i] = idiom.Name
+	}
+	options := [...]core.ConfigurationOption{
+		{
+			Name:        ConfigErrorHandlingIdioms,
+			Description: "Error handling idioms to count. Separated with commas \",\".",
+			Flag:        "error-handling-idioms",
+			Type:        core.StringsConfigurationOption,
+			Default:     names,
+		},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (eh *ErrorHandlingAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		eh.l = l
+	}
+	if val, exists := facts[ConfigErrorHandlingIdioms].([]string); exists {
+		eh.Idioms = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		eh.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*ErrorHandlingAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (eh *ErrorHandlingAnalysis) Initialize(repository *git.Repository) error {
+	eh.l = core.NewLogger()
+	metrics := ErrorHandlingIdioms
+	if len(eh.Idioms) > 0 {
+		known := map[string]RegexMetric{}
+		for _, idiom := range ErrorHandlingIdioms {
+			known[idiom.Name] = idiom
+		}
+		metrics = nil
+		for _, name := range eh.Idioms {
+			idiom, exists := known[strings.TrimSpace(name)]
+			if !exists {
+				return fmt.Errorf("unknown error handling idiom: %s", name)
+			}
+			metrics = append(metrics, idiom)
+		}
+	}
+	eh.counter = newRegexMetricsCounter(metrics)
+	eh.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (eh *ErrorHandlingAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !eh.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	tick := deps[items.DependencyTick].(int)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	cache := deps[items.DependencyBlobCache].(map[plumbing.Hash]*items.CachedBlob)
+	langs := deps[items.DependencyLanguages].(map[plumbing.Hash]string)
+	return nil, eh.counter.consume(tick, treeDiff, cache, langs)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (eh *ErrorHandlingAnalysis) Finalize() interface{} {
+	return eh.counter.result(eh.tickSize)
+}
+
+// Fork clones this pipeline item.
+func (eh *ErrorHandlingAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(eh, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (eh *ErrorHandlingAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	metricsResult, ok := result.(RegexMetricsResult)
+	if !ok {
+		return fmt.Errorf("result is not a RegexMetricsResult: '%v'", result)
+	}
+	if binary {
+		return serializeRegexMetricsBinary(&metricsResult, writer)
+	}
+	serializeRegexMetricsText("error_handling", &metricsResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to RegexMetricsResult.
+func (eh *ErrorHandlingAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	result, err := deserializeRegexMetrics(pbmessage)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func init() {
+	core.Registry.Register(&ErrorHandlingAnalysis{})
+}
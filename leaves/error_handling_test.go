@@ -0,0 +1,109 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandlingMeta(t *testing.T) {
+	eh := &ErrorHandlingAnalysis{}
+	assert.Equal(t, "ErrorHandling", eh.Name())
+	assert.Len(t, eh.Provides(), 0)
+	assert.Equal(t, []string{
+		items.DependencyTreeChanges, items.DependencyBlobCache, items.DependencyLanguages,
+		items.DependencyTick,
+	}, eh.Requires())
+	assert.Equal(t, "error-handling", eh.Flag())
+	assert.Equal(t, core.CostMedium, eh.Cost())
+	opts := eh.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigErrorHandlingIdioms, opts[0].Name)
+	assert.Len(t, opts[0].Default, len(ErrorHandlingIdioms))
+	require.NoError(t, eh.Configure(map[string]interface{}{
+		ConfigErrorHandlingIdioms: []string{"go_panic", " python_bare_except"},
+		items.FactTickSize:        time.Hour,
+	}))
+	assert.Equal(t, time.Hour, eh.tickSize)
+	require.NoError(t, eh.Initialize(nil))
+	assert.Len(t, eh.counter.metrics, 2)
+
+	eh.Idioms = []string{"missing"}
+	assert.Error(t, eh.Initialize(nil))
+}
+
+func TestErrorHandlingRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&ErrorHandlingAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "ErrorHandling", summoned[0].Name())
+}
+
+func TestErrorHandlingIdioms(t *testing.T) {
+	counter := newRegexMetricsCounter(ErrorHandlingIdioms)
+	goCounts := counter.count([]byte(`package x
+
+func f() error {
+	if err := g(); err != nil {
+		return errors.Wrap(err, "g")
+	}
+	if err := h(); errors.Is(err, io.EOF) {
+		return fmt.Errorf("h: %w", err)
+	}
+	if broken {
+		panic("broken")
+	}
+	return fmt.Errorf("failed")
+}
+`), "Go")
+	pythonCounts := counter.count([]byte(`try:
+    f()
+except:
+    pass
+try:
+    g()
+except Exception as e:
+    raise ValueError("g") from e
+`), "Python")
+	jsCounts := counter.count([]byte("try { f() } catch (e) {}\ntry { g() } catch (e) { log(e) }\n"),
+		"JavaScript")
+	expected := map[string][3]int{
+		"go_errors_wrap":      {1, 0, 0},
+		"go_errorf_wrap":      {1, 0, 0},
+		"go_errorf":           {2, 0, 0},
+		"go_errors_is_as":     {1, 0, 0},
+		"go_panic":            {1, 0, 0},
+		"python_bare_except":  {0, 1, 0},
+		"python_broad_except": {0, 1, 0},
+		"python_raise_from":   {0, 1, 0},
+		"empty_catch":         {0, 0, 1},
+	}
+	require.Len(t, expected, len(ErrorHandlingIdioms))
+	for i, idiom := range ErrorHandlingIdioms {
+		assert.Equal(t, expected[idiom.Name], [3]int{goCounts[i], pythonCounts[i], jsCounts[i]}, idiom.Name)
+	}
+}
+
+func TestErrorHandlingConsumeSerialize(t *testing.T) {
+	eh := &ErrorHandlingAnalysis{Idioms: []string{"go_panic"}}
+	require.NoError(t, eh.Initialize(nil))
+	commit := newCommentDensityCommit().change("", "main.go", "panic(1)\n", "Go", 0)
+	_, err := eh.Consume(commit.deps(0))
+	require.NoError(t, err)
+	result := eh.Finalize().(RegexMetricsResult)
+	assert.Equal(t, []int{1}, result.Totals)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, eh.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), "  error_handling:\n")
+	buffer.Reset()
+	require.NoError(t, eh.Serialize(result, true, buffer))
+	restored, err := eh.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+	assert.Error(t, eh.Serialize(nil, false, buffer))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
@@ -15,6 +16,7 @@ import (
 	"github.com/meko-christian/hercules/internal/pb"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
 )
 
 // FileHistoryAnalysis contains the intermediate state which is mutated by Consume(). It should implement
@@ -23,7 +25,9 @@ type FileHistoryAnalysis struct {
 	core.NoopMerger
 	core.OneShotMergeProcessor
 	files      map[string]*FileHistory
+	deleted    []*FileHistory
 	lastCommit *object.Commit
+	identities *items.FileIdentities
 
 	l core.Logger
 }
@@ -31,6 +35,9 @@ type FileHistoryAnalysis struct {
 // FileHistoryResult is returned by Finalize() and represents the analysis result.
 type FileHistoryResult struct {
 	Files map[string]FileHistory
+	// Deleted are the files which do not exist at the last commit, sorted by the name and
+	// by the deletion tick. The same path appears several times if it was deleted repeatedly.
+	Deleted []FileHistory
 }
 
 // FileHistory is the gathered stats about a particular file.
@@ -39,6 +46,16 @@ type FileHistory struct {
 	Hashes []plumbing.Hash
 	// People is the mapping from developers to the number of lines they altered.
 	People map[int]items.LineStats
+	// FileId is the stable identifier which survives the renames, see items.FileIdentityTracker.
+	FileId int
+	// Names is the rename chain, the current name is the last.
+	Names []string
+	// Created is the tick when the file appeared.
+	Created int
+	// Deleted is the tick when the file was deleted or -1 if it exists at the last commit.
+	Deleted int
+	// Authors are the distinct developers who touched the file, sorted.
+	Authors []int
 }
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -57,7 +74,10 @@ func (history *FileHistoryAnalysis) Provides() []string {
 // Each requested entity will be inserted into `deps` of Consume(). In turn, those
 // entities are Provides() upstream.
 func (history *FileHistoryAnalysis) Requires() []string {
-	return []string{items.DependencyTreeChanges, items.DependencyLineStats, identity.DependencyAuthor}
+	return []string{
+		items.DependencyTreeChanges, items.DependencyLineStats, identity.DependencyAuthor,
+		items.DependencyFileIdentities, items.DependencyTick,
+	}
 }
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
@@ -70,11 +90,18 @@ func (history *FileHistoryAnalysis) Flag() string {
 	return "file-history"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (history *FileHistoryAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (history *FileHistoryAnalysis) Description() string {
 	return "Each file path is mapped to the list of commits which touch that file and the mapping " +
 		"from involved developers to the corresponding line statistics: how many lines were added, " +
-		"removed and changed throughout the whole history."
+		"removed and changed throughout the whole history. Records the lifetime of every file, " +
+		"including the deleted ones: the creation and the deletion ticks, the rename chain and " +
+		"the distinct authors."
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -94,6 +121,7 @@ func (*FileHistoryAnalysis) ConfigureUpstream(facts map[string]interface{}) erro
 func (history *FileHistoryAnalysis) Initialize(repository *git.Repository) error {
 	history.l = core.NewLogger()
 	history.files = map[string]*FileHistory{}
+	history.deleted = nil
 	history.OneShotMergeProcessor.Initialize()
 	return nil
 }
@@ -106,42 +134,53 @@ func (history *FileHistoryAnalysis) Initialize(repository *git.Repository) error
 func (history *FileHistoryAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
 	history.lastCommit = deps[core.DependencyCommit].(*object.Commit)
 	commit := history.lastCommit.Hash
+	if identities, ok := deps[items.DependencyFileIdentities].(*items.FileIdentities); ok {
+		history.identities = identities
+	}
+	tick := deps[items.DependencyTick].(int)
+	author := deps[identity.DependencyAuthor].(int)
 	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	var removed []string
 	for _, change := range changes {
 		action, _ := change.Action()
-		var fh *FileHistory
-		if action != merkletrie.Delete {
-			fh = history.files[change.To.Name]
-		} else {
-			fh = history.files[change.From.Name]
+		if action == merkletrie.Modify && change.From.Name != change.To.Name {
+			// the renamed file carries over its lifetime
+			if fromFile := history.files[change.From.Name]; fromFile != nil {
+				delete(history.files, change.From.Name)
+				history.files[change.To.Name] = fromFile
+			}
 		}
+		name := change.To.Name
+		if action == merkletrie.Delete {
+			name = change.From.Name
+		}
+		fh := history.files[name]
 		if fh == nil {
-			fh = &FileHistory{}
-			history.files[change.To.Name] = fh
+			fh = &FileHistory{Created: tick}
+			history.files[name] = fh
 		}
+		fh.Authors = addFileAuthor(fh.Authors, author)
 		switch action {
 		case merkletrie.Insert:
 			fh.Hashes = []plumbing.Hash{commit}
 		case merkletrie.Delete:
 			fh.Hashes = append(fh.Hashes, commit)
-		case merkletrie.Modify:
-			var hashes []plumbing.Hash
-			if fromFile := history.files[change.From.Name]; fromFile != nil {
-				hashes = fromFile.Hashes
-			}
-			if change.From.Name != change.To.Name {
-				delete(history.files, change.From.Name)
+			fh.Deleted = tick
+			fh.FileId, fh.Names = resolveFileIdentity(history.identities, name)
+			if fh.Names == nil {
+				fh.Names = []string{name}
 			}
-			hashes = append(hashes, commit)
-			fh.Hashes = hashes
+			history.deleted = append(history.deleted, fh)
+			removed = append(removed, name)
+		case merkletrie.Modify:
+			fh.Hashes = append(fh.Hashes, commit)
 		}
 	}
 	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
-	author := deps[identity.DependencyAuthor].(int)
 	for changeEntry, stats := range lineStats {
 		file := history.files[changeEntry.Name]
 		if file == nil {
-			file = &FileHistory{}
+			file = &FileHistory{Created: tick}
 			history.files[changeEntry.Name] = file
 		}
 		people := file.People
@@ -149,12 +188,10 @@ func (history *FileHistoryAnalysis) Consume(deps map[string]interface{}) (map[st
 			people = map[int]items.LineStats{}
 			file.People = people
 		}
-		oldStats := people[author]
-		people[author] = items.LineStats{
-			Added:   oldStats.Added + stats.Added,
-			Removed: oldStats.Removed + stats.Removed,
-			Changed: oldStats.Changed + stats.Changed,
-		}
+		people[author] = people[author].Add(stats)
+	}
+	for _, name := range removed {
+		delete(history.files, name)
 	}
 	return nil, nil
 }
@@ -169,7 +206,10 @@ func (history *FileHistoryAnalysis) Finalize() interface{} {
 	}
 	err = fileIter.ForEach(func(file *object.File) error {
 		if fh := history.files[file.Name]; fh != nil {
-			files[file.Name] = *fh
+			result := *fh
+			result.FileId, result.Names = resolveFileIdentity(history.identities, file.Name)
+			result.Deleted = -1
+			files[file.Name] = result
 		}
 		return nil
 	})
@@ -177,7 +217,43 @@ func (history *FileHistoryAnalysis) Finalize() interface{} {
 		history.l.Errorf("Failed to iterate files of %s", history.lastCommit.Hash.String())
 		return err
 	}
-	return FileHistoryResult{Files: files}
+	deleted := make([]FileHistory, len(history.deleted))
+	for i, fh := range history.deleted {
+		deleted[i] = *fh
+	}
+	sortDeletedFiles(deleted)
+	return FileHistoryResult{Files: files, Deleted: deleted}
+}
+
+// addFileAuthor inserts the developer into the sorted list of the distinct authors.
+func addFileAuthor(authors []int, author int) []int {
+	i := sort.SearchInts(authors, author)
+	if i < len(authors) && authors[i] == author {
+		return authors
+	}
+	authors = append(authors, 0)
+	copy(authors[i+1:], authors[i:])
+	authors[i] = author
+	return authors
+}
+
+// deletedFileName returns the last name of the deleted file.
+func deletedFileName(fh FileHistory) string {
+	if len(fh.Names) == 0 {
+		return ""
+	}
+	return fh.Names[len(fh.Names)-1]
+}
+
+// sortDeletedFiles orders the deleted files by the name and by the deletion tick.
+func sortDeletedFiles(deleted []FileHistory) {
+	sort.SliceStable(deleted, func(i, j int) bool {
+		ni, nj := deletedFileName(deleted[i]), deletedFileName(deleted[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return deleted[i].Deleted < deleted[j].Deleted
+	})
 }
 
 // Fork clones this PipelineItem.
@@ -185,6 +261,60 @@ func (history *FileHistoryAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(history, n)
 }
 
+// FilterResult trims the files, see core.OutputFilter. The size of a file is the number
+// of lines altered in it. TopFiles applies to the existing files only, MinLines applies
+// to the deleted files too.
+func (history *FileHistoryAnalysis) FilterResult(result interface{}, filter core.OutputFilter) interface{} {
+	historyResult := result.(FileHistoryResult)
+	lines := make(map[string]int, len(historyResult.Files))
+	for file, fh := range historyResult.Files {
+		lines[file] = fileHistoryLines(fh)
+	}
+	keep := filter.KeepFiles(lines)
+	files := make(map[string]FileHistory, len(keep))
+	for file, fh := range historyResult.Files {
+		if keep[file] {
+			files[file] = fh
+		}
+	}
+	var deleted []FileHistory
+	for _, fh := range historyResult.Deleted {
+		if fileHistoryLines(fh) >= filter.MinLines {
+			deleted = append(deleted, fh)
+		}
+	}
+	return FileHistoryResult{Files: files, Deleted: deleted}
+}
+
+// fileHistoryLines returns the number of lines altered in the file.
+func fileHistoryLines(fh FileHistory) int {
+	lines := 0
+	for _, stats := range fh.People {
+		lines += stats.Added + stats.Removed + stats.Changed
+	}
+	return lines
+}
+
+// AnonymizePaths replaces the names of the files, see core.PathAnonymizer.
+func (history *FileHistoryAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	historyResult := result.(FileHistoryResult)
+	files := make(map[string]FileHistory, len(historyResult.Files))
+	for file, fh := range historyResult.Files {
+		fh.Names = anonymizeNames(fh.Names, anonymize)
+		files[anonymize(file)] = fh
+	}
+	var deleted []FileHistory
+	if historyResult.Deleted != nil {
+		deleted = make([]FileHistory, len(historyResult.Deleted))
+		for i, fh := range historyResult.Deleted {
+			fh.Names = anonymizeNames(fh.Names, anonymize)
+			deleted[i] = fh
+		}
+		sortDeletedFiles(deleted)
+	}
+	return FileHistoryResult{Files: files, Deleted: deleted}
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 // The text format is YAML and the bytes format is Protocol Buffers.
 func (history *FileHistoryAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
@@ -205,44 +335,48 @@ func (history *FileHistoryAnalysis) serializeText(result *FileHistoryResult, wri
 	}
 	sort.Strings(keys)
 	for _, key := range keys {
-		fmt.Fprintf(writer, "  - %s:\n", key)
-		file := result.Files[key]
-		hashes := file.Hashes
-		strhashes := make([]string, len(hashes))
-		for i, hash := range hashes {
-			strhashes[i] = "\"" + hash.String() + "\""
-		}
-		sort.Strings(strhashes)
-		fmt.Fprintf(writer, "    commits: [%s]\n", strings.Join(strhashes, ","))
-		strpeople := make([]string, 0, len(file.People))
-		for key, val := range file.People {
-			strpeople = append(strpeople, fmt.Sprintf("%d:[%d,%d,%d]", key, val.Added, val.Removed, val.Changed))
-		}
-		sort.Strings(strpeople)
-		fmt.Fprintf(writer, "    people: {%s}\n", strings.Join(strpeople, ","))
+		serializeFileHistoryText(key, result.Files[key], writer)
+	}
+	for _, file := range result.Deleted {
+		serializeFileHistoryText(deletedFileName(file), file, writer)
 	}
 }
 
+func serializeFileHistoryText(name string, file FileHistory, writer io.Writer) {
+	fmt.Fprintf(writer, "  - %s:\n", name)
+	hashes := file.Hashes
+	strhashes := make([]string, len(hashes))
+	for i, hash := range hashes {
+		strhashes[i] = "\"" + hash.String() + "\""
+	}
+	sort.Strings(strhashes)
+	fmt.Fprintf(writer, "    commits: [%s]\n", strings.Join(strhashes, ","))
+	strpeople := make([]string, 0, len(file.People))
+	for key, val := range file.People {
+		strpeople = append(strpeople, fmt.Sprintf("%d:[%d,%d,%d]", key, val.Added, val.Removed, val.Changed))
+	}
+	sort.Strings(strpeople)
+	fmt.Fprintf(writer, "    people: {%s}\n", strings.Join(strpeople, ","))
+	fmt.Fprintf(writer, "    id: %d\n", file.FileId)
+	fmt.Fprintf(writer, "    names: %s\n", formatNames(file.Names))
+	fmt.Fprintf(writer, "    created: %d\n", file.Created)
+	fmt.Fprintf(writer, "    deleted: %d\n", file.Deleted)
+	strauthors := make([]string, len(file.Authors))
+	for i, author := range file.Authors {
+		strauthors[i] = strconv.Itoa(author)
+	}
+	fmt.Fprintf(writer, "    authors: [%s]\n", strings.Join(strauthors, ","))
+}
+
 func (history *FileHistoryAnalysis) serializeBinary(result *FileHistoryResult, writer io.Writer) error {
 	message := pb.FileHistoryResultMessage{
 		Files: map[string]*pb.FileHistory{},
 	}
 	for key, vals := range result.Files {
-		fh := &pb.FileHistory{
-			Commits:            make([]string, len(vals.Hashes)),
-			ChangesByDeveloper: map[int32]*pb.LineStats{},
-		}
-		for i, hash := range vals.Hashes {
-			fh.Commits[i] = hash.String()
-		}
-		for key, val := range vals.People {
-			fh.ChangesByDeveloper[int32(key)] = &pb.LineStats{
-				Added:   int32(val.Added),
-				Removed: int32(val.Removed),
-				Changed: int32(val.Changed),
-			}
-		}
-		message.Files[key] = fh
+		message.Files[key] = fileHistoryToPb(vals)
+	}
+	for _, vals := range result.Deleted {
+		message.Deleted = append(message.Deleted, fileHistoryToPb(vals))
 	}
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
@@ -252,6 +386,62 @@ func (history *FileHistoryAnalysis) serializeBinary(result *FileHistoryResult, w
 	return err
 }
 
+func fileHistoryToPb(vals FileHistory) *pb.FileHistory {
+	fh := &pb.FileHistory{
+		Commits:            make([]string, len(vals.Hashes)),
+		ChangesByDeveloper: map[int32]*pb.LineStats{},
+		FileId:             int32(vals.FileId),
+		Names:              vals.Names,
+		CreatedTick:        int32(vals.Created),
+		DeletedTick:        int32(vals.Deleted),
+		Authors:            make([]int32, len(vals.Authors)),
+	}
+	for i, hash := range vals.Hashes {
+		fh.Commits[i] = hash.String()
+	}
+	for key, val := range vals.People {
+		fh.ChangesByDeveloper[int32(key)] = lineStatsToPb(val)
+	}
+	for i, author := range vals.Authors {
+		fh.Authors[i] = int32(author)
+	}
+	return fh
+}
+
+// resolveFileIdentity returns the stable identifier and the rename chain of the file,
+// see items.FileIdentityTracker. The identifier is -1 if the file is unknown.
+func resolveFileIdentity(identities *items.FileIdentities, name string) (int, []string) {
+	if identities == nil {
+		return -1, nil
+	}
+	id, exists := identities.Id(name)
+	if !exists {
+		return -1, nil
+	}
+	return int(id), identities.Names(id)
+}
+
+// anonymizeNames replaces each name in the rename chain, see core.PathAnonymizer.
+func anonymizeNames(names []string, anonymize func(string) string) []string {
+	if names == nil {
+		return nil
+	}
+	anonymized := make([]string, len(names))
+	for i, name := range names {
+		anonymized[i] = anonymize(name)
+	}
+	return anonymized
+}
+
+// formatNames writes the rename chain as a YAML flow sequence.
+func formatNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = yaml.SafeString(name)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
 func init() {
 	core.Registry.Register(&FileHistoryAnalysis{})
 }
@@ -26,10 +26,12 @@ func TestFileHistoryMeta(t *testing.T) {
 	fh := fixtureFileHistory()
 	assert.Equal(t, fh.Name(), "FileHistoryAnalysis")
 	assert.Equal(t, len(fh.Provides()), 0)
-	assert.Equal(t, len(fh.Requires()), 3)
+	assert.Equal(t, len(fh.Requires()), 5)
 	assert.Equal(t, fh.Requires()[0], items.DependencyTreeChanges)
 	assert.Equal(t, fh.Requires()[1], items.DependencyLineStats)
 	assert.Equal(t, fh.Requires()[2], identity.DependencyAuthor)
+	assert.Equal(t, fh.Requires()[3], items.DependencyFileIdentities)
+	assert.Equal(t, fh.Requires()[4], items.DependencyTick)
 	assert.Len(t, fh.ListConfigurationOptions(), 0)
 	assert.Nil(t, fh.Configure(nil))
 	logger := core.NewLogger()
@@ -57,9 +59,9 @@ func TestFileHistoryRegistration(t *testing.T) {
 func TestFileHistoryConsume(t *testing.T) {
 	fh, _ := bakeFileHistoryForSerialization(t)
 	validate := func() {
-		assert.Len(t, fh.files, 3)
-		assert.Equal(t, fh.files["cmd/hercules/main.go"].People,
-			map[int]items.LineStats{1: ls(0, 207, 0)})
+		assert.Len(t, fh.files, 2)
+		assert.Len(t, fh.deleted, 1)
+		assert.Equal(t, fh.deleted[0].People, map[int]items.LineStats{1: ls(0, 207, 0)})
 		assert.Equal(t, fh.files[".travis.yml"].People, map[int]items.LineStats{1: ls(12, 0, 0)})
 		assert.Equal(t, fh.files["analyser.go"].People, map[int]items.LineStats{1: ls(628, 9, 67)})
 		assert.Len(t, fh.files["analyser.go"].Hashes, 2)
@@ -70,18 +72,90 @@ func TestFileHistoryConsume(t *testing.T) {
 		assert.Len(t, fh.files[".travis.yml"].Hashes, 1)
 		assert.Equal(t, fh.files[".travis.yml"].Hashes[0], plumbing.NewHash(
 			"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
-		assert.Len(t, fh.files["cmd/hercules/main.go"].Hashes, 2)
-		assert.Equal(t, fh.files["cmd/hercules/main.go"].Hashes[0], plumbing.NewHash(
+		assert.Len(t, fh.deleted[0].Hashes, 2)
+		assert.Equal(t, fh.deleted[0].Hashes[0], plumbing.NewHash(
 			"0000000000000000000000000000000000000000"))
-		assert.Equal(t, fh.files["cmd/hercules/main.go"].Hashes[1], plumbing.NewHash(
+		assert.Equal(t, fh.deleted[0].Hashes[1], plumbing.NewHash(
 			"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
+		assert.Equal(t, fh.deleted[0].Deleted, 7)
+		assert.Equal(t, fh.deleted[0].Names, []string{"cmd/hercules/main.go"})
+		assert.Equal(t, fh.files[".travis.yml"].Created, 7)
+		assert.Equal(t, fh.files[".travis.yml"].Authors, []int{1})
 	}
 	validate()
 	res := fh.Finalize().(FileHistoryResult)
-	assert.Equal(t, 2, len(res.Files))
+	assert.Equal(t, 1, len(res.Files))
 	for key, val := range res.Files {
-		assert.Equal(t, val, *fh.files[key])
+		assert.Equal(t, val.Hashes, fh.files[key].Hashes)
+		assert.Equal(t, val.People, fh.files[key].People)
+		assert.Equal(t, -1, val.Deleted)
 	}
+	assert.Len(t, res.Deleted, 1)
+	assert.Equal(t, *fh.deleted[0], res.Deleted[0])
+}
+
+func TestFileHistoryLifetime(t *testing.T) {
+	fh := fixtureFileHistory()
+	entry := func(name string) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: plumbing.NewHash(
+			"291286b4ac41952cbd1389fda66420ec03c1a9fe")}}
+	}
+	consume := func(tick, author int, changes ...*object.Change) {
+		_, err := fh.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{Hash: plumbing.NewHash(
+				"2b1ed978194a94edeabbca6de7ff3b5771d4d665")},
+			items.DependencyTick:           tick,
+			identity.DependencyAuthor:      author,
+			items.DependencyTreeChanges:    object.Changes(changes),
+			items.DependencyLineStats:      map[object.ChangeEntry]items.LineStats{},
+			items.DependencyFileIdentities: items.NewFileIdentities(),
+		})
+		assert.NoError(t, err)
+	}
+	consume(1, 2, &object.Change{To: entry("a.go")})
+	consume(3, 0, &object.Change{From: entry("a.go"), To: entry("b.go")})
+	consume(4, 2, &object.Change{From: entry("b.go")})
+	consume(6, 1, &object.Change{To: entry("b.go")})
+	assert.Len(t, fh.files, 1)
+	assert.Len(t, fh.deleted, 1)
+	dead := fh.deleted[0]
+	assert.Equal(t, 1, dead.Created)
+	assert.Equal(t, 4, dead.Deleted)
+	assert.Equal(t, []int{0, 2}, dead.Authors)
+	assert.Len(t, dead.Hashes, 3)
+	assert.Equal(t, []string{"b.go"}, dead.Names)
+	alive := fh.files["b.go"]
+	assert.Equal(t, 6, alive.Created)
+	assert.Equal(t, []int{1}, alive.Authors)
+	assert.Len(t, alive.Hashes, 1)
+}
+
+func TestFileHistorySerializeDeleted(t *testing.T) {
+	fh := &FileHistoryAnalysis{}
+	result := FileHistoryResult{Files: map[string]FileHistory{}, Deleted: []FileHistory{{
+		People: map[int]items.LineStats{0: {Added: 3}}, FileId: 2, Names: []string{"a.go", "b.go"},
+		Created: 1, Deleted: 4, Authors: []int{0, 2},
+	}}}
+	buffer := &bytes.Buffer{}
+	assert.Nil(t, fh.Serialize(result, false, buffer))
+	assert.Equal(t, `  - b.go:
+    commits: []
+    people: {0:[3,0,0]}
+    id: 2
+    names: ["a.go", "b.go"]
+    created: 1
+    deleted: 4
+    authors: [0,2]
+`, buffer.String())
+	buffer.Reset()
+	assert.Nil(t, fh.Serialize(result, true, buffer))
+	msg := pb.FileHistoryResultMessage{}
+	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
+	assert.Len(t, msg.Deleted, 1)
+	assert.Equal(t, int32(1), msg.Deleted[0].CreatedTick)
+	assert.Equal(t, int32(4), msg.Deleted[0].DeletedTick)
+	assert.Equal(t, []int32{0, 2}, msg.Deleted[0].Authors)
+	assert.Equal(t, []string{"a.go", "b.go"}, msg.Deleted[0].Names)
 }
 
 func TestFileHistoryFork(t *testing.T) {
@@ -101,9 +175,19 @@ func TestFileHistorySerializeText(t *testing.T) {
 	assert.Equal(t, buffer.String(), `  - .travis.yml:
     commits: ["2b1ed978194a94edeabbca6de7ff3b5771d4d665"]
     people: {1:[12,0,0]}
+    id: -1
+    names: []
+    created: 7
+    deleted: -1
+    authors: [1]
   - cmd/hercules/main.go:
     commits: ["0000000000000000000000000000000000000000","2b1ed978194a94edeabbca6de7ff3b5771d4d665"]
     people: {1:[0,207,0]}
+    id: -1
+    names: ["cmd/hercules/main.go"]
+    created: 0
+    deleted: 7
+    authors: [1]
 `)
 }
 
@@ -114,17 +198,21 @@ func TestFileHistorySerializeBinary(t *testing.T) {
 	assert.Nil(t, fh.Serialize(res, true, buffer))
 	msg := pb.FileHistoryResultMessage{}
 	assert.Nil(t, proto.Unmarshal(buffer.Bytes(), &msg))
-	assert.Len(t, msg.Files, 2)
+	assert.Len(t, msg.Files, 1)
 	assert.Len(t, msg.Files[".travis.yml"].Commits, 1)
 	assert.Equal(t, msg.Files[".travis.yml"].Commits[0], "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
-	assert.Len(t, msg.Files["cmd/hercules/main.go"].Commits, 2)
-	assert.Equal(t, msg.Files["cmd/hercules/main.go"].Commits[0],
-		"0000000000000000000000000000000000000000")
-	assert.Equal(t, msg.Files["cmd/hercules/main.go"].Commits[1],
-		"2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, msg.Files[".travis.yml"].CreatedTick, int32(7))
+	assert.Equal(t, msg.Files[".travis.yml"].DeletedTick, int32(-1))
+	assert.Equal(t, msg.Files[".travis.yml"].Authors, []int32{1})
+	assert.Len(t, msg.Deleted, 1)
+	assert.Len(t, msg.Deleted[0].Commits, 2)
+	assert.Equal(t, msg.Deleted[0].Commits[0], "0000000000000000000000000000000000000000")
+	assert.Equal(t, msg.Deleted[0].Commits[1], "2b1ed978194a94edeabbca6de7ff3b5771d4d665")
+	assert.Equal(t, msg.Deleted[0].DeletedTick, int32(7))
+	assert.Equal(t, msg.Deleted[0].Names, []string{"cmd/hercules/main.go"})
 	assert.Equal(t, msg.Files[".travis.yml"].ChangesByDeveloper,
 		map[int32]*pb.LineStats{1: {Added: 12, Removed: 0, Changed: 0}})
-	assert.Equal(t, msg.Files["cmd/hercules/main.go"].ChangesByDeveloper,
+	assert.Equal(t, msg.Deleted[0].ChangesByDeveloper,
 		map[int32]*pb.LineStats{1: {Added: 0, Removed: 207, Changed: 0}})
 }
 
@@ -186,6 +274,7 @@ func bakeFileHistoryForSerialization(t *testing.T) (*FileHistoryAnalysis, map[st
 		"2b1ed978194a94edeabbca6de7ff3b5771d4d665"))
 	deps[core.DependencyCommit] = commit
 	deps[identity.DependencyAuthor] = 1
+	deps[items.DependencyTick] = 7
 	fd := fixtures.FileDiff()
 	result, err := fd.Consume(deps)
 	assert.Nil(t, err)
@@ -204,3 +293,41 @@ func bakeFileHistoryForSerialization(t *testing.T) (*FileHistoryAnalysis, map[st
 	assert.Nil(t, err)
 	return fh, deps
 }
+
+func TestFileHistoryFilterResult(t *testing.T) {
+	fh := &FileHistoryAnalysis{}
+	result := FileHistoryResult{Files: map[string]FileHistory{
+		"a.go": {People: map[int]items.LineStats{0: {Added: 10}, 1: {Removed: 5}}},
+		"b.go": {People: map[int]items.LineStats{0: {Changed: 3}}},
+		"c.go": {People: map[int]items.LineStats{1: {Added: 20}}},
+	}, Deleted: []FileHistory{
+		{People: map[int]items.LineStats{0: {Added: 30}}, Names: []string{"d.go"}},
+		{People: map[int]items.LineStats{0: {Added: 1}}, Names: []string{"e.go"}},
+	}}
+	filtered := fh.FilterResult(result, core.OutputFilter{TopFiles: 2}).(FileHistoryResult)
+	assert.Len(t, filtered.Files, 2)
+	assert.Contains(t, filtered.Files, "a.go")
+	assert.Contains(t, filtered.Files, "c.go")
+	assert.Len(t, filtered.Deleted, 2)
+	filtered = fh.FilterResult(result, core.OutputFilter{MinLines: 16}).(FileHistoryResult)
+	assert.Len(t, filtered.Files, 1)
+	assert.Contains(t, filtered.Files, "c.go")
+	assert.Len(t, filtered.Deleted, 1)
+	assert.Equal(t, []string{"d.go"}, filtered.Deleted[0].Names)
+	assert.Len(t, result.Files, 3)
+}
+
+func TestFileHistoryAnonymizePaths(t *testing.T) {
+	result := FileHistoryResult{Files: map[string]FileHistory{
+		"a.go": {People: map[int]items.LineStats{0: {Added: 10}}},
+	}, Deleted: []FileHistory{{Names: []string{"b.go"}, Deleted: 3}}}
+	anonymized := (&FileHistoryAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(FileHistoryResult)
+	assert.Equal(t, map[string]FileHistory{
+		"x/a.go": {People: map[int]items.LineStats{0: {Added: 10}}},
+	}, anonymized.Files)
+	assert.Equal(t, []FileHistory{{Names: []string{"x/b.go"}, Deleted: 3}}, anonymized.Deleted)
+	assert.Contains(t, result.Files, "a.go")
+	assert.Equal(t, []string{"b.go"}, result.Deleted[0].Names)
+}
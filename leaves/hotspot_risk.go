@@ -5,8 +5,10 @@ import (
 	"io"
 	"math"
 	"sort"
+	"time"
 
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/utils/merkletrie"
 	"github.com/gogo/protobuf/proto"
@@ -18,24 +20,31 @@ import (
 )
 
 // HotspotRiskAnalysis identifies high-risk files by combining multiple metrics:
-// size, churn rate, coupling degree, and ownership concentration.
+// size, churn rate, coupling degree, ownership concentration, and optionally age and
+// indentation complexity.
 type HotspotRiskAnalysis struct {
 	core.NoopMerger
 	core.OneShotMergeProcessor
 
 	// Configuration
-	TopN            int     // Number of top risky files to report
-	WindowDays      int     // Time window for churn calculation (in days)
-	WeightSize      float32 // Weight for size factor
-	WeightChurn     float32 // Weight for churn factor
-	WeightCoupling  float32 // Weight for coupling factor
-	WeightOwnership float32 // Weight for ownership concentration factor
+	TopN             int     // Number of top risky files to report
+	WindowDays       int     // Time window for churn calculation (in days)
+	WeightSize       float32 // Weight for size factor
+	WeightChurn      float32 // Weight for churn factor
+	WeightCoupling   float32 // Weight for coupling factor
+	WeightOwnership  float32 // Weight for ownership concentration factor
+	WeightAge        float32 // Weight for age factor, unlike the others it is 0 (disabled) by default
+	WeightComplexity float32 // Weight for indentation complexity factor, 0 (disabled) by default
+	GroupByLanguage  bool    // Aggregate the risk of the files per programming language
+	SnapshotEvery    int     // Record the top-N ranking every this number of ticks, 0 disables it
 
 	// Runtime state
 	fileMetrics map[string]*fileRiskMetrics
 	tickSize    int64 // Duration of one tick in seconds
 	currentTick int
 	lastCommit  *object.Commit
+	identities  *items.FileIdentities
+	snapshots   []HotspotRiskSnapshot
 
 	l core.Logger
 }
@@ -47,26 +56,68 @@ type fileRiskMetrics struct {
 	ChurnByTick   map[int]int     // Changes per tick for window calculation
 	CoupledFiles  map[string]bool // Set of files that co-changed with this one
 	AuthorLines   map[int]int     // Lines contributed by each author
+	Language      string          // Programming language of the last revision
+	CreatedTick   int             // Tick of the insertion, or of the first change if it is older
 }
 
 // HotspotRiskResult is returned by Finalize()
 type HotspotRiskResult struct {
 	Files      []FileRisk // Top-N risky files, sorted by score descending
 	WindowDays int        // Time window used for churn calculation
+	// Languages aggregates all the files, not only Top-N, sorted by the mean score descending.
+	// It is empty unless GroupByLanguage is set.
+	Languages []LanguageRisk
+	// Snapshots are the top-N rankings sampled at least SnapshotEvery ticks apart plus the final
+	// tick, sorted by tick. They are empty unless SnapshotEvery is positive.
+	Snapshots []HotspotRiskSnapshot
+	// SnapshotEvery is the minimum number of ticks between Snapshots.
+	SnapshotEvery int
+}
+
+// HotspotRiskSnapshot is the ranking of the riskiest files at the end of a tick.
+type HotspotRiskSnapshot struct {
+	Tick  int                // Tick of the ranking
+	Files []HotspotRiskEntry // Top-N risky files, sorted by score descending
+}
+
+// HotspotRiskEntry is a file in HotspotRiskSnapshot.
+type HotspotRiskEntry struct {
+	Path      string  // File path at the tick
+	FileId    int     // Stable identifier which survives the renames, the same as FileRisk.FileId
+	RiskScore float64 // Composite risk score at the tick
 }
 
 // FileRisk contains the risk assessment for a single file
 type FileRisk struct {
-	Path                string  // File path
-	RiskScore           float64 // Composite risk score
-	Size                int     // Number of lines
-	Churn               int     // Changes in window
-	CouplingDegree      int     // Number of coupled files
-	OwnershipGini       float64 // Gini coefficient for ownership concentration
-	SizeNormalized      float64 // Normalized size factor
-	ChurnNormalized     float64 // Normalized churn factor
-	CouplingNormalized  float64 // Normalized coupling factor
-	OwnershipNormalized float64 // Normalized ownership factor
+	Path                 string  // File path
+	RiskScore            float64 // Composite risk score
+	Size                 int     // Number of lines
+	Churn                int     // Changes in window
+	CouplingDegree       int     // Number of coupled files
+	OwnershipGini        float64 // Gini coefficient for ownership concentration
+	SizeNormalized       float64 // Normalized size factor
+	ChurnNormalized      float64 // Normalized churn factor
+	CouplingNormalized   float64 // Normalized coupling factor
+	OwnershipNormalized  float64 // Normalized ownership factor
+	AgeDays              int     // Days since the file was created
+	Complexity           float64 // Mean indentation level of the non-blank lines at HEAD
+	AgeNormalized        float64 // Normalized age factor, 1 for the newest file, 0 for the oldest
+	ComplexityNormalized float64 // Normalized complexity factor
+	Language             string  // Programming language, empty if unknown
+	// FileId is the stable identifier which survives the renames, see items.FileIdentityTracker.
+	FileId int
+	// Names is the rename chain, the current name is the last.
+	Names []string
+}
+
+// LanguageRisk contains the risk assessment of all the files in the same programming language
+type LanguageRisk struct {
+	Language      string  // Programming language, empty if unknown
+	Files         int     // Number of files
+	Size          int     // Total number of lines
+	Churn         int     // Total changes in window
+	MeanRiskScore float64 // Average risk score of the files
+	MaxRiskScore  float64 // Risk score of the riskiest file
 }
 
 const (
@@ -82,6 +133,14 @@ const (
 	ConfigHotspotRiskWeightCoupling = "HotspotRisk.WeightCoupling"
 	// ConfigHotspotRiskWeightOwnership sets the weight for ownership concentration factor
 	ConfigHotspotRiskWeightOwnership = "HotspotRisk.WeightOwnership"
+	// ConfigHotspotRiskWeightAge sets the weight for age factor
+	ConfigHotspotRiskWeightAge = "HotspotRisk.WeightAge"
+	// ConfigHotspotRiskWeightComplexity sets the weight for indentation complexity factor
+	ConfigHotspotRiskWeightComplexity = "HotspotRisk.WeightComplexity"
+	// ConfigHotspotRiskGroupByLanguage enables the per-language aggregation of the risk
+	ConfigHotspotRiskGroupByLanguage = "HotspotRisk.GroupByLanguage"
+	// ConfigHotspotRiskSnapshotEvery sets the number of ticks between the ranking snapshots
+	ConfigHotspotRiskSnapshotEvery = "HotspotRisk.SnapshotEvery"
 
 	// DefaultTopN is the default number of files to report
 	DefaultTopN = 20
@@ -106,8 +165,10 @@ func (hra *HotspotRiskAnalysis) Requires() []string {
 	return []string{
 		items.DependencyTreeChanges,
 		items.DependencyLineStats,
+		items.DependencyLanguages,
 		identity.DependencyAuthor,
 		items.DependencyTick,
+		items.DependencyFileIdentities,
 	}
 }
 
@@ -156,6 +217,36 @@ func (hra *HotspotRiskAnalysis) ListConfigurationOptions() []core.ConfigurationO
 			Type:        core.FloatConfigurationOption,
 			Default:     DefaultWeight,
 		},
+		{
+			Name:        ConfigHotspotRiskWeightAge,
+			Description: "Weight for age factor, the recently created files are riskier (0.0 to disable).",
+			Flag:        "hotspot-risk-weight-age",
+			Type:        core.FloatConfigurationOption,
+			Default:     float32(0),
+		},
+		{
+			Name: ConfigHotspotRiskWeightComplexity,
+			Description: "Weight for indentation complexity factor, the mean indentation level " +
+				"of the lines at HEAD (0.0 to disable).",
+			Flag:    "hotspot-risk-weight-complexity",
+			Type:    core.FloatConfigurationOption,
+			Default: float32(0),
+		},
+		{
+			Name:        ConfigHotspotRiskGroupByLanguage,
+			Description: "Aggregate the risk of all the files per programming language.",
+			Flag:        "hotspot-risk-languages",
+			Type:        core.BoolConfigurationOption,
+			Default:     false,
+		},
+		{
+			Name: ConfigHotspotRiskSnapshotEvery,
+			Description: "Record the top risky files every this number of ticks to show the trend; " +
+				"0 disables the snapshots.",
+			Flag:    "hotspot-risk-snapshot-every",
+			Type:    core.IntConfigurationOption,
+			Default: 0,
+		},
 	}
 }
 
@@ -182,8 +273,29 @@ func (hra *HotspotRiskAnalysis) Configure(facts map[string]interface{}) error {
 	if val, exists := facts[ConfigHotspotRiskWeightOwnership].(float32); exists {
 		hra.WeightOwnership = val
 	}
-	if val, exists := facts[items.FactTickSize].(int64); exists {
-		hra.tickSize = val
+	if val, exists := facts[ConfigHotspotRiskWeightAge].(float32); exists {
+		if val < 0 {
+			return fmt.Errorf("--hotspot-risk-weight-age must not be negative, got %v", val)
+		}
+		hra.WeightAge = val
+	}
+	if val, exists := facts[ConfigHotspotRiskWeightComplexity].(float32); exists {
+		if val < 0 {
+			return fmt.Errorf("--hotspot-risk-weight-complexity must not be negative, got %v", val)
+		}
+		hra.WeightComplexity = val
+	}
+	if val, exists := facts[ConfigHotspotRiskGroupByLanguage].(bool); exists {
+		hra.GroupByLanguage = val
+	}
+	if val, exists := facts[ConfigHotspotRiskSnapshotEvery].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--hotspot-risk-snapshot-every must not be negative, got %d", val)
+		}
+		hra.SnapshotEvery = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		hra.tickSize = int64(val / time.Second)
 	}
 	return nil
 }
@@ -197,9 +309,15 @@ func (hra *HotspotRiskAnalysis) Flag() string {
 	return "hotspot-risk"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (hra *HotspotRiskAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (hra *HotspotRiskAnalysis) Description() string {
-	return "Identifies high-risk files by combining size, churn rate, coupling degree, and ownership concentration metrics."
+	return "Identifies high-risk files by combining size, churn rate, coupling degree, ownership " +
+		"concentration, age, and indentation complexity metrics."
 }
 
 // Initialize prepares the analysis.
@@ -225,6 +343,8 @@ func (hra *HotspotRiskAnalysis) Initialize(repository *git.Repository) error {
 	}
 	hra.fileMetrics = make(map[string]*fileRiskMetrics)
 	hra.currentTick = 0
+	hra.lastCommit = nil
+	hra.snapshots = nil
 	hra.OneShotMergeProcessor.Initialize()
 	return nil
 }
@@ -235,12 +355,20 @@ func (hra *HotspotRiskAnalysis) Consume(deps map[string]interface{}) (map[string
 		return nil, nil
 	}
 
+	tick := deps[items.DependencyTick].(int)
+	if hra.SnapshotEvery > 0 && hra.lastCommit != nil && tick > hra.currentTick {
+		// the previous commit was the last in its tick
+		hra.takeSnapshot()
+	}
 	hra.lastCommit = deps[core.DependencyCommit].(*object.Commit)
 	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
 	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	langs := deps[items.DependencyLanguages].(map[plumbing.Hash]string)
 	author := deps[identity.DependencyAuthor].(int)
-	tick := deps[items.DependencyTick].(int)
 	hra.currentTick = tick
+	if identities, ok := deps[items.DependencyFileIdentities].(*items.FileIdentities); ok {
+		hra.identities = identities
+	}
 
 	// Track which files changed in this commit for coupling
 	changedFiles := make([]string, 0, len(treeDiff))
@@ -279,12 +407,19 @@ func (hra *HotspotRiskAnalysis) Consume(deps map[string]interface{}) (map[string
 					ChurnByTick:  make(map[int]int),
 					CoupledFiles: make(map[string]bool),
 					AuthorLines:  make(map[int]int),
+					CreatedTick:  tick,
 				}
 				hra.fileMetrics[fileName] = metrics
+			} else if action == merkletrie.Insert {
+				// the file was deleted and created again
+				metrics.CreatedTick = tick
 			}
 
 			// Update churn
 			metrics.ChurnByTick[tick]++
+			if action != merkletrie.Delete {
+				metrics.Language = langs[change.To.TreeEntry.Hash]
+			}
 
 			// Update author lines
 			if stats, exists := lineStats[object.ChangeEntry{Name: fileName}]; exists {
@@ -314,23 +449,57 @@ func (hra *HotspotRiskAnalysis) Finalize() interface{} {
 	if hra.lastCommit == nil {
 		return HotspotRiskResult{Files: []FileRisk{}, WindowDays: hra.WindowDays}
 	}
+	risks, err := hra.rank(hra.lastCommit, hra.currentTick)
+	if err != nil {
+		hra.l.Errorf("Failed to get tree: %v", err)
+		return HotspotRiskResult{Files: []FileRisk{}, WindowDays: hra.WindowDays}
+	}
+
+	var languages []LanguageRisk
+	if hra.GroupByLanguage {
+		languages = groupRisksByLanguage(risks)
+	}
 
+	var snapshots []HotspotRiskSnapshot
+	if hra.SnapshotEvery > 0 {
+		snapshots = hra.snapshots
+		if len(snapshots) == 0 || snapshots[len(snapshots)-1].Tick != hra.currentTick {
+			snapshots = append(snapshots, hra.snapshotOf(hra.currentTick, risks))
+		}
+	}
+
+	// Take top N
+	if len(risks) > hra.TopN {
+		risks = risks[:hra.TopN]
+	}
+
+	return HotspotRiskResult{
+		Files:         risks,
+		WindowDays:    hra.WindowDays,
+		Languages:     languages,
+		Snapshots:     snapshots,
+		SnapshotEvery: hra.SnapshotEvery,
+	}
+}
+
+// rank scores all the files in the tree of the commit at the given tick and sorts them
+// by the risk score descending.
+func (hra *HotspotRiskAnalysis) rank(commit *object.Commit, currentTick int) ([]FileRisk, error) {
 	// Calculate window in ticks
 	windowTicks := 0
 	if hra.tickSize > 0 {
 		windowTicks = (hra.WindowDays * 24 * 3600) / int(hra.tickSize)
 	}
-	startTick := hra.currentTick - windowTicks
+	startTick := currentTick - windowTicks
 	if startTick < 0 {
 		startTick = 0
 	}
 
 	// Get current file sizes and calculate metrics for existing files
 	var risks []FileRisk
-	tree, err := hra.lastCommit.Tree()
+	tree, err := commit.Tree()
 	if err != nil {
-		hra.l.Errorf("Failed to get tree: %v", err)
-		return HotspotRiskResult{Files: []FileRisk{}, WindowDays: hra.WindowDays}
+		return nil, err
 	}
 
 	err = tree.Files().ForEach(func(file *object.File) error {
@@ -351,6 +520,7 @@ func (hra *HotspotRiskAnalysis) Finalize() interface{} {
 			return nil // Skip binary files
 		}
 		metrics.CurrentSize = size
+		complexity := indentationComplexity(blob.Data)
 
 		// Calculate churn within window
 		churnInWindow := 0
@@ -366,12 +536,18 @@ func (hra *HotspotRiskAnalysis) Finalize() interface{} {
 		// Calculate ownership Gini coefficient
 		gini := calculateGini(metrics.AuthorLines)
 
+		fileId, names := resolveFileIdentity(hra.identities, fileName)
 		risks = append(risks, FileRisk{
 			Path:           fileName,
 			Size:           size,
 			Churn:          churnInWindow,
 			CouplingDegree: couplingDegree,
 			OwnershipGini:  gini,
+			AgeDays:        hra.ticksToDays(currentTick - metrics.CreatedTick),
+			Complexity:     complexity,
+			Language:       metrics.Language,
+			FileId:         fileId,
+			Names:          names,
 		})
 
 		return nil
@@ -387,16 +563,123 @@ func (hra *HotspotRiskAnalysis) Finalize() interface{} {
 	sort.Slice(risks, func(i, j int) bool {
 		return risks[i].RiskScore > risks[j].RiskScore
 	})
+	return risks, nil
+}
 
-	// Take top N
+// snapshotOf keeps the top N of the sorted risks at the tick.
+func (hra *HotspotRiskAnalysis) snapshotOf(tick int, risks []FileRisk) HotspotRiskSnapshot {
 	if len(risks) > hra.TopN {
 		risks = risks[:hra.TopN]
 	}
+	snapshot := HotspotRiskSnapshot{Tick: tick, Files: make([]HotspotRiskEntry, len(risks))}
+	for i, risk := range risks {
+		snapshot.Files[i] = HotspotRiskEntry{Path: risk.Path, FileId: risk.FileId, RiskScore: risk.RiskScore}
+	}
+	return snapshot
+}
 
-	return HotspotRiskResult{
-		Files:      risks,
-		WindowDays: hra.WindowDays,
+// takeSnapshot ranks the files at the end of the current tick if SnapshotEvery ticks passed
+// since the previous snapshot.
+func (hra *HotspotRiskAnalysis) takeSnapshot() {
+	if n := len(hra.snapshots); n > 0 && hra.currentTick-hra.snapshots[n-1].Tick < hra.SnapshotEvery {
+		return
+	}
+	risks, err := hra.rank(hra.lastCommit, hra.currentTick)
+	if err != nil {
+		hra.l.Errorf("Failed to get tree: %v", err)
+		return
+	}
+	hra.snapshots = append(hra.snapshots, hra.snapshotOf(hra.currentTick, risks))
+}
+
+// ticksToDays converts the number of ticks to days. The ticks are days if the tick size is unknown.
+func (hra *HotspotRiskAnalysis) ticksToDays(ticks int) int {
+	if hra.tickSize <= 0 {
+		return ticks
+	}
+	return int(int64(ticks) * hra.tickSize / (24 * 3600))
+}
+
+// indentationComplexity returns the mean indentation level of the non-blank lines, a cheap
+// language-agnostic proxy of the nesting depth. A tab is one level, four spaces are one level.
+func indentationComplexity(data []byte) float64 {
+	levels, lines := 0, 0
+	spaces, tabs, indent := 0, 0, true
+	for _, c := range data {
+		switch {
+		case c == '\n':
+			if !indent {
+				levels += tabs + spaces/4
+				lines++
+			}
+			spaces, tabs, indent = 0, 0, true
+		case !indent:
+		case c == ' ':
+			spaces++
+		case c == '\t':
+			tabs++
+		case c == '\r':
+		default:
+			indent = false
+		}
+	}
+	if !indent {
+		levels += tabs + spaces/4
+		lines++
+	}
+	if lines == 0 {
+		return 0
+	}
+	return float64(levels) / float64(lines)
+}
+
+// groupRisksByLanguage aggregates the file risks per programming language.
+func groupRisksByLanguage(risks []FileRisk) []LanguageRisk {
+	index := map[string]int{}
+	var languages []LanguageRisk
+	for _, risk := range risks {
+		i, exists := index[risk.Language]
+		if !exists {
+			i = len(languages)
+			index[risk.Language] = i
+			languages = append(languages, LanguageRisk{Language: risk.Language})
+		}
+		languages[i] = mergeLanguageRisks(languages[i], LanguageRisk{
+			Language:      risk.Language,
+			Files:         1,
+			Size:          risk.Size,
+			Churn:         risk.Churn,
+			MeanRiskScore: risk.RiskScore,
+			MaxRiskScore:  risk.RiskScore,
+		})
+	}
+	sortLanguageRisks(languages)
+	return languages
+}
+
+// mergeLanguageRisks sums two aggregates of the same language.
+func mergeLanguageRisks(lr1, lr2 LanguageRisk) LanguageRisk {
+	merged := LanguageRisk{
+		Language:     lr1.Language,
+		Files:        lr1.Files + lr2.Files,
+		Size:         lr1.Size + lr2.Size,
+		Churn:        lr1.Churn + lr2.Churn,
+		MaxRiskScore: math.Max(lr1.MaxRiskScore, lr2.MaxRiskScore),
+	}
+	if merged.Files > 0 {
+		merged.MeanRiskScore = (lr1.MeanRiskScore*float64(lr1.Files) +
+			lr2.MeanRiskScore*float64(lr2.Files)) / float64(merged.Files)
 	}
+	return merged
+}
+
+func sortLanguageRisks(languages []LanguageRisk) {
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].MeanRiskScore != languages[j].MeanRiskScore {
+			return languages[i].MeanRiskScore > languages[j].MeanRiskScore
+		}
+		return languages[i].Language < languages[j].Language
+	})
 }
 
 // normalizeAndScore normalizes all factors to [0,1] and calculates risk scores
@@ -406,7 +689,7 @@ func (hra *HotspotRiskAnalysis) normalizeAndScore(risks []FileRisk) {
 	}
 
 	// Find min/max for each factor
-	var maxSize, maxChurn, maxCoupling float64 = 0, 0, 0
+	var maxSize, maxChurn, maxCoupling, maxAge, maxComplexity float64
 
 	for _, risk := range risks {
 		if float64(risk.Size) > maxSize {
@@ -418,6 +701,8 @@ func (hra *HotspotRiskAnalysis) normalizeAndScore(risks []FileRisk) {
 		if float64(risk.CouplingDegree) > maxCoupling {
 			maxCoupling = float64(risk.CouplingDegree)
 		}
+		maxAge = math.Max(maxAge, float64(risk.AgeDays))
+		maxComplexity = math.Max(maxComplexity, risk.Complexity)
 	}
 
 	// Normalize and calculate scores
@@ -445,11 +730,25 @@ func (hra *HotspotRiskAnalysis) normalizeAndScore(risks []FileRisk) {
 		// Ownership: Gini is already in [0,1], higher = more concentrated
 		ownershipNorm := risks[i].OwnershipGini
 
+		// Age: inverted log scale, the newest files are the riskiest
+		ageNorm := 1.0
+		if maxAge > 0 {
+			ageNorm = 1 - math.Log(float64(risks[i].AgeDays)+1)/math.Log(maxAge+1)
+		}
+
+		// Complexity: linear normalization
+		var complexityNorm float64
+		if maxComplexity > 0 {
+			complexityNorm = risks[i].Complexity / maxComplexity
+		}
+
 		// Store normalized values
 		risks[i].SizeNormalized = sizeNorm
 		risks[i].ChurnNormalized = churnNorm
 		risks[i].CouplingNormalized = couplingNorm
 		risks[i].OwnershipNormalized = ownershipNorm
+		risks[i].AgeNormalized = ageNorm
+		risks[i].ComplexityNormalized = complexityNorm
 
 		// Calculate composite score with weights
 		score := 1.0
@@ -457,11 +756,25 @@ func (hra *HotspotRiskAnalysis) normalizeAndScore(risks []FileRisk) {
 		score *= math.Pow(churnNorm, float64(hra.WeightChurn))
 		score *= math.Pow(couplingNorm, float64(hra.WeightCoupling))
 		score *= math.Pow(ownershipNorm, float64(hra.WeightOwnership))
+		score *= math.Pow(ageNorm, float64(hra.WeightAge))
+		score *= math.Pow(complexityNorm, float64(hra.WeightComplexity))
 
 		risks[i].RiskScore = score
 	}
 }
 
+// ScoreFiles sets the normalized factors and RiskScore of the files with the configured
+// weights, the same way as Finalize() does. It allows to evaluate hypothetical file structures.
+func (hra *HotspotRiskAnalysis) ScoreFiles(risks []FileRisk) {
+	hra.normalizeAndScore(risks)
+}
+
+// OwnershipGini returns the Gini coefficient of the lines owned by each author, as in
+// FileRisk.OwnershipGini.
+func OwnershipGini(authorLines map[int]int) float64 {
+	return calculateGini(authorLines)
+}
+
 // calculateGini computes the Gini coefficient for line ownership distribution
 // Returns value in [0,1] where 0 = perfectly equal, 1 = one person owns everything
 func calculateGini(authorLines map[int]int) float64 {
@@ -518,6 +831,58 @@ func (hra *HotspotRiskAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(hra, n)
 }
 
+// FilterResult trims the risky files, see core.OutputFilter. The language aggregates are kept,
+// the snapshots are trimmed to TopFiles.
+func (hra *HotspotRiskAnalysis) FilterResult(result interface{}, filter core.OutputFilter) interface{} {
+	hotspots := result.(HotspotRiskResult)
+	files := make([]FileRisk, 0, len(hotspots.Files))
+	for _, file := range hotspots.Files {
+		if filter.TopFiles > 0 && len(files) >= filter.TopFiles {
+			break
+		}
+		if file.Size >= filter.MinLines {
+			files = append(files, file)
+		}
+	}
+	hotspots.Files = files
+	if filter.TopFiles > 0 && hotspots.Snapshots != nil {
+		snapshots := make([]HotspotRiskSnapshot, len(hotspots.Snapshots))
+		for i, snapshot := range hotspots.Snapshots {
+			if len(snapshot.Files) > filter.TopFiles {
+				snapshot.Files = snapshot.Files[:filter.TopFiles]
+			}
+			snapshots[i] = snapshot
+		}
+		hotspots.Snapshots = snapshots
+	}
+	return hotspots
+}
+
+// AnonymizePaths replaces the paths of the risky files, see core.PathAnonymizer.
+func (hra *HotspotRiskAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	hotspots := result.(HotspotRiskResult)
+	files := make([]FileRisk, len(hotspots.Files))
+	for i, file := range hotspots.Files {
+		file.Path = anonymize(file.Path)
+		file.Names = anonymizeNames(file.Names, anonymize)
+		files[i] = file
+	}
+	hotspots.Files = files
+	if hotspots.Snapshots != nil {
+		snapshots := make([]HotspotRiskSnapshot, len(hotspots.Snapshots))
+		for i, snapshot := range hotspots.Snapshots {
+			entries := make([]HotspotRiskEntry, len(snapshot.Files))
+			for j, entry := range snapshot.Files {
+				entry.Path = anonymize(entry.Path)
+				entries[j] = entry
+			}
+			snapshots[i] = HotspotRiskSnapshot{Tick: snapshot.Tick, Files: entries}
+		}
+		hotspots.Snapshots = snapshots
+	}
+	return hotspots
+}
+
 // Serialize converts the analysis result to text or bytes.
 func (hra *HotspotRiskAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
 	riskResult := result.(HotspotRiskResult)
@@ -538,34 +903,97 @@ func (hra *HotspotRiskAnalysis) serializeText(result *HotspotRiskResult, writer
 		fmt.Fprintf(writer, "      churn: %d\n", file.Churn)
 		fmt.Fprintf(writer, "      coupling_degree: %d\n", file.CouplingDegree)
 		fmt.Fprintf(writer, "      ownership_gini: %.6f\n", file.OwnershipGini)
+		fmt.Fprintf(writer, "      age_days: %d\n", file.AgeDays)
+		fmt.Fprintf(writer, "      complexity: %.6f\n", file.Complexity)
 		fmt.Fprintf(writer, "      normalized:\n")
 		fmt.Fprintf(writer, "        size: %.6f\n", file.SizeNormalized)
 		fmt.Fprintf(writer, "        churn: %.6f\n", file.ChurnNormalized)
 		fmt.Fprintf(writer, "        coupling: %.6f\n", file.CouplingNormalized)
 		fmt.Fprintf(writer, "        ownership: %.6f\n", file.OwnershipNormalized)
+		fmt.Fprintf(writer, "        age: %.6f\n", file.AgeNormalized)
+		fmt.Fprintf(writer, "        complexity: %.6f\n", file.ComplexityNormalized)
+		fmt.Fprintf(writer, "      language: %s\n", yaml.SafeString(file.Language))
+		fmt.Fprintf(writer, "      id: %d\n", file.FileId)
+		fmt.Fprintf(writer, "      names: %s\n", formatNames(file.Names))
+	}
+	if len(result.Languages) > 0 {
+		fmt.Fprintln(writer, "  languages:")
+		for _, lang := range result.Languages {
+			fmt.Fprintf(writer, "    - language: %s\n", yaml.SafeString(lang.Language))
+			fmt.Fprintf(writer, "      files: %d\n", lang.Files)
+			fmt.Fprintf(writer, "      size: %d\n", lang.Size)
+			fmt.Fprintf(writer, "      churn: %d\n", lang.Churn)
+			fmt.Fprintf(writer, "      mean_risk_score: %.6f\n", lang.MeanRiskScore)
+			fmt.Fprintf(writer, "      max_risk_score: %.6f\n", lang.MaxRiskScore)
+		}
+	}
+	if result.SnapshotEvery == 0 {
+		return
+	}
+	fmt.Fprintln(writer, "  snapshot_every:", result.SnapshotEvery)
+	fmt.Fprintln(writer, "  snapshots:")
+	for _, snapshot := range result.Snapshots {
+		fmt.Fprintf(writer, "    - tick: %d\n", snapshot.Tick)
+		fmt.Fprintln(writer, "      files:")
+		for _, entry := range snapshot.Files {
+			fmt.Fprintf(writer, "        - {path: %s, id: %d, risk_score: %.6f}\n",
+				yaml.SafeString(entry.Path), entry.FileId, entry.RiskScore)
+		}
 	}
 }
 
 func (hra *HotspotRiskAnalysis) serializeBinary(result *HotspotRiskResult, writer io.Writer) error {
 	message := pb.HotspotRiskResults{
-		WindowDays: int32(result.WindowDays),
-		Files:      make([]*pb.FileRisk, len(result.Files)),
+		WindowDays:    int32(result.WindowDays),
+		Files:         make([]*pb.FileRisk, len(result.Files)),
+		SnapshotEvery: int32(result.SnapshotEvery),
 	}
 
 	for i, file := range result.Files {
 		message.Files[i] = &pb.FileRisk{
-			Path:                file.Path,
-			RiskScore:           file.RiskScore,
-			Size_:               int32(file.Size),
-			Churn:               int32(file.Churn),
-			CouplingDegree:      int32(file.CouplingDegree),
-			OwnershipGini:       file.OwnershipGini,
-			SizeNormalized:      file.SizeNormalized,
-			ChurnNormalized:     file.ChurnNormalized,
-			CouplingNormalized:  file.CouplingNormalized,
-			OwnershipNormalized: file.OwnershipNormalized,
+			Path:                 file.Path,
+			RiskScore:            file.RiskScore,
+			Size_:                int32(file.Size),
+			Churn:                int32(file.Churn),
+			CouplingDegree:       int32(file.CouplingDegree),
+			OwnershipGini:        file.OwnershipGini,
+			SizeNormalized:       file.SizeNormalized,
+			ChurnNormalized:      file.ChurnNormalized,
+			CouplingNormalized:   file.CouplingNormalized,
+			OwnershipNormalized:  file.OwnershipNormalized,
+			AgeDays:              int32(file.AgeDays),
+			Complexity:           file.Complexity,
+			AgeNormalized:        file.AgeNormalized,
+			ComplexityNormalized: file.ComplexityNormalized,
+			Language:             file.Language,
+			FileId:               int32(file.FileId),
+			Names:                file.Names,
 		}
 	}
+	for _, lang := range result.Languages {
+		message.Languages = append(message.Languages, &pb.LanguageRisk{
+			Language:      lang.Language,
+			Files:         int32(lang.Files),
+			Size_:         int32(lang.Size),
+			Churn:         int32(lang.Churn),
+			MeanRiskScore: lang.MeanRiskScore,
+			MaxRiskScore:  lang.MaxRiskScore,
+		})
+	}
+	for _, snapshot := range result.Snapshots {
+		pbSnapshot := &pb.HotspotRiskSnapshot{
+			Tick:  int32(snapshot.Tick),
+			Files: make([]*pb.HotspotRiskEntry, len(snapshot.Files)),
+		}
+		for i, entry := range snapshot.Files {
+			pbSnapshot.Files[i] = &pb.HotspotRiskEntry{
+				Path:      entry.Path,
+				FileId:    int32(entry.FileId),
+				RiskScore: entry.RiskScore,
+			}
+		}
+		message.Snapshots = append(message.Snapshots, pbSnapshot)
+	}
 
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
@@ -584,24 +1012,56 @@ func (hra *HotspotRiskAnalysis) Deserialize(pbmessage []byte) (interface{}, erro
 	}
 
 	result := HotspotRiskResult{
-		WindowDays: int(message.WindowDays),
-		Files:      make([]FileRisk, len(message.Files)),
+		WindowDays:    int(message.WindowDays),
+		Files:         make([]FileRisk, len(message.Files)),
+		SnapshotEvery: int(message.SnapshotEvery),
 	}
 
 	for i, file := range message.Files {
 		result.Files[i] = FileRisk{
-			Path:                file.Path,
-			RiskScore:           file.RiskScore,
-			Size:                int(file.Size_),
-			Churn:               int(file.Churn),
-			CouplingDegree:      int(file.CouplingDegree),
-			OwnershipGini:       file.OwnershipGini,
-			SizeNormalized:      file.SizeNormalized,
-			ChurnNormalized:     file.ChurnNormalized,
-			CouplingNormalized:  file.CouplingNormalized,
-			OwnershipNormalized: file.OwnershipNormalized,
+			Path:                 file.Path,
+			RiskScore:            file.RiskScore,
+			Size:                 int(file.Size_),
+			Churn:                int(file.Churn),
+			CouplingDegree:       int(file.CouplingDegree),
+			OwnershipGini:        file.OwnershipGini,
+			SizeNormalized:       file.SizeNormalized,
+			ChurnNormalized:      file.ChurnNormalized,
+			CouplingNormalized:   file.CouplingNormalized,
+			OwnershipNormalized:  file.OwnershipNormalized,
+			AgeDays:              int(file.AgeDays),
+			Complexity:           file.Complexity,
+			AgeNormalized:        file.AgeNormalized,
+			ComplexityNormalized: file.ComplexityNormalized,
+			Language:             file.Language,
+			FileId:               int(file.FileId),
+			Names:                file.Names,
 		}
 	}
+	for _, lang := range message.Languages {
+		result.Languages = append(result.Languages, LanguageRisk{
+			Language:      lang.GetLanguage(),
+			Files:         int(lang.GetFiles()),
+			Size:          int(lang.GetSize_()),
+			Churn:         int(lang.GetChurn()),
+			MeanRiskScore: lang.GetMeanRiskScore(),
+			MaxRiskScore:  lang.GetMaxRiskScore(),
+		})
+	}
+	for _, pbSnapshot := range message.Snapshots {
+		snapshot := HotspotRiskSnapshot{
+			Tick:  int(pbSnapshot.Tick),
+			Files: make([]HotspotRiskEntry, len(pbSnapshot.Files)),
+		}
+		for i, entry := range pbSnapshot.Files {
+			snapshot.Files[i] = HotspotRiskEntry{
+				Path:      entry.Path,
+				FileId:    int(entry.FileId),
+				RiskScore: entry.RiskScore,
+			}
+		}
+		result.Snapshots = append(result.Snapshots, snapshot)
+	}
 
 	return result, nil
 }
@@ -622,10 +1082,54 @@ func (hra *HotspotRiskAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.Co
 		allFiles = allFiles[:hra.TopN]
 	}
 
-	return HotspotRiskResult{
-		Files:      allFiles,
-		WindowDays: cr1.WindowDays,
+	var languages []LanguageRisk
+	index := map[string]int{}
+	for _, lang := range append(append([]LanguageRisk{}, cr1.Languages...), cr2.Languages...) {
+		if i, exists := index[lang.Language]; exists {
+			languages[i] = mergeLanguageRisks(languages[i], lang)
+			continue
+		}
+		index[lang.Language] = len(languages)
+		languages = append(languages, lang)
+	}
+	sortLanguageRisks(languages)
+
+	merged := HotspotRiskResult{
+		Files:         allFiles,
+		WindowDays:    cr1.WindowDays,
+		Languages:     languages,
+		Snapshots:     hra.mergeSnapshots(cr1.Snapshots, cr2.Snapshots),
+		SnapshotEvery: cr1.SnapshotEvery,
 	}
+	if merged.SnapshotEvery == 0 {
+		merged.SnapshotEvery = cr2.SnapshotEvery
+	}
+	return merged
+}
+
+// mergeSnapshots joins the rankings of the same ticks and keeps the top N of each.
+func (hra *HotspotRiskAnalysis) mergeSnapshots(s1, s2 []HotspotRiskSnapshot) []HotspotRiskSnapshot {
+	if s1 == nil && s2 == nil {
+		return nil
+	}
+	byTick := map[int][]HotspotRiskEntry{}
+	for _, snapshot := range append(append([]HotspotRiskSnapshot{}, s1...), s2...) {
+		byTick[snapshot.Tick] = append(byTick[snapshot.Tick], snapshot.Files...)
+	}
+	merged := make([]HotspotRiskSnapshot, 0, len(byTick))
+	for tick, entries := range byTick {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].RiskScore > entries[j].RiskScore
+		})
+		if len(entries) > hra.TopN {
+			entries = entries[:hra.TopN]
+		}
+		merged = append(merged, HotspotRiskSnapshot{Tick: tick, Files: entries})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Tick < merged[j].Tick
+	})
+	return merged
 }
 
 func init() {
@@ -0,0 +1,345 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHotspotRiskMeta(t *testing.T) {
+	hra := &HotspotRiskAnalysis{}
+	assert.Equal(t, "HotspotRisk", hra.Name())
+	assert.Len(t, hra.Provides(), 0)
+	assert.Contains(t, hra.Requires(), items.DependencyLanguages)
+	opts := hra.ListConfigurationOptions()
+	require.Len(t, opts, 10)
+	assert.Equal(t, "hotspot-risk-weight-age", opts[6].Flag)
+	assert.Equal(t, float32(0), opts[6].Default)
+	assert.Equal(t, "hotspot-risk-weight-complexity", opts[7].Flag)
+	assert.Equal(t, ConfigHotspotRiskGroupByLanguage, opts[8].Name)
+	assert.Equal(t, "hotspot-risk-languages", opts[8].Flag)
+	assert.Equal(t, "hotspot-risk-snapshot-every", opts[9].Flag)
+	require.NoError(t, hra.Configure(map[string]interface{}{
+		ConfigHotspotRiskGroupByLanguage:  true,
+		ConfigHotspotRiskWeightAge:        float32(0.5),
+		ConfigHotspotRiskWeightComplexity: float32(2),
+		ConfigHotspotRiskSnapshotEvery:    7,
+		items.FactTickSize:                12 * time.Hour,
+	}))
+	assert.Equal(t, 7, hra.SnapshotEvery)
+	assert.Error(t, hra.Configure(map[string]interface{}{ConfigHotspotRiskSnapshotEvery: -1}))
+	assert.True(t, hra.GroupByLanguage)
+	assert.Equal(t, float32(0.5), hra.WeightAge)
+	assert.Equal(t, float32(2), hra.WeightComplexity)
+	assert.Equal(t, int64(12*3600), hra.tickSize)
+	assert.Equal(t, 5, hra.ticksToDays(10))
+	assert.Error(t, hra.Configure(map[string]interface{}{ConfigHotspotRiskWeightAge: float32(-1)}))
+	assert.Error(t, hra.Configure(map[string]interface{}{
+		ConfigHotspotRiskWeightComplexity: float32(-1)}))
+}
+
+func TestIndentationComplexity(t *testing.T) {
+	assert.Equal(t, 0.0, indentationComplexity(nil))
+	assert.Equal(t, 0.0, indentationComplexity([]byte("\n  \n\t\n")))
+	// levels 0, 1, 2 and 1, the blank lines are skipped
+	assert.Equal(t, 1.0, indentationComplexity(
+		[]byte("func f() {\n\tif x {\r\n\n        y()\n  \n    }")))
+}
+
+func TestHotspotRiskScoreAgeComplexity(t *testing.T) {
+	risks := []FileRisk{
+		{Path: "new.go", Size: 10, Churn: 1, CouplingDegree: 1, OwnershipGini: 1, AgeDays: 0, Complexity: 1},
+		{Path: "mid.go", Size: 10, Churn: 1, CouplingDegree: 1, OwnershipGini: 1, AgeDays: 9, Complexity: 2},
+		{Path: "old.go", Size: 10, Churn: 1, CouplingDegree: 1, OwnershipGini: 1, AgeDays: 99, Complexity: 4},
+	}
+	hra := &HotspotRiskAnalysis{WeightSize: 1, WeightChurn: 1, WeightCoupling: 1, WeightOwnership: 1}
+	hra.ScoreFiles(risks)
+	// the new factors are disabled by default but still normalized
+	for _, risk := range risks {
+		assert.Equal(t, 1.0, risk.RiskScore)
+	}
+	assert.Equal(t, 1.0, risks[0].AgeNormalized)
+	assert.InDelta(t, 0.5, risks[1].AgeNormalized, 1e-9)
+	assert.Equal(t, 0.0, risks[2].AgeNormalized)
+	assert.Equal(t, []float64{0.25, 0.5, 1}, []float64{risks[0].ComplexityNormalized,
+		risks[1].ComplexityNormalized, risks[2].ComplexityNormalized})
+
+	hra.WeightAge, hra.WeightComplexity = 1, 1
+	hra.ScoreFiles(risks)
+	assert.Equal(t, 0.25, risks[0].RiskScore)
+	assert.InDelta(t, 0.25, risks[1].RiskScore, 1e-9)
+	assert.Equal(t, 0.0, risks[2].RiskScore)
+}
+
+func TestHotspotRiskConsumeLanguages(t *testing.T) {
+	hra := &HotspotRiskAnalysis{}
+	require.NoError(t, hra.Initialize(nil))
+	goHash := plumbing.NewHash("1111111111111111111111111111111111111111")
+	pyHash := plumbing.NewHash("2222222222222222222222222222222222222222")
+	consume := func(langs map[plumbing.Hash]string, changes ...*object.Change) {
+		_, err := hra.Consume(map[string]interface{}{
+			core.DependencyCommit:       &object.Commit{},
+			items.DependencyTreeChanges: object.Changes(changes),
+			items.DependencyLineStats:   map[object.ChangeEntry]items.LineStats{},
+			items.DependencyLanguages:   langs,
+			identity.DependencyAuthor:   0,
+			items.DependencyTick:        0,
+		})
+		require.NoError(t, err)
+	}
+	entry := func(name string, hash plumbing.Hash) object.ChangeEntry {
+		return object.ChangeEntry{Name: name, TreeEntry: object.TreeEntry{Name: name, Hash: hash}}
+	}
+	consume(map[plumbing.Hash]string{goHash: "Go"},
+		&object.Change{To: entry("main.go", goHash)})
+	assert.Equal(t, "Go", hra.fileMetrics["main.go"].Language)
+	// the rename changes the language, the deletion keeps it
+	consume(map[plumbing.Hash]string{goHash: "Go", pyHash: "Python"},
+		&object.Change{From: entry("main.go", goHash), To: entry("main.py", pyHash)})
+	assert.Equal(t, "Python", hra.fileMetrics["main.py"].Language)
+	consume(map[plumbing.Hash]string{pyHash: "Python"},
+		&object.Change{From: entry("main.py", pyHash)})
+	assert.Equal(t, "Python", hra.fileMetrics["main.py"].Language)
+}
+
+func TestGroupRisksByLanguage(t *testing.T) {
+	languages := groupRisksByLanguage([]FileRisk{
+		{Path: "a.go", Language: "Go", RiskScore: 0.8, Size: 10, Churn: 1},
+		{Path: "b.py", Language: "Python", RiskScore: 0.6, Size: 20, Churn: 2},
+		{Path: "c.go", Language: "Go", RiskScore: 0.2, Size: 30, Churn: 3},
+		{Path: "d.bin", RiskScore: 0.1, Size: 40, Churn: 4},
+	})
+	assert.Equal(t, []LanguageRisk{
+		{Language: "Python", Files: 1, Size: 20, Churn: 2, MeanRiskScore: 0.6, MaxRiskScore: 0.6},
+		{Language: "Go", Files: 2, Size: 40, Churn: 4, MeanRiskScore: 0.5, MaxRiskScore: 0.8},
+		{Language: "", Files: 1, Size: 40, Churn: 4, MeanRiskScore: 0.1, MaxRiskScore: 0.1},
+	}, languages)
+	assert.Len(t, groupRisksByLanguage(nil), 0)
+}
+
+func TestHotspotRiskSnapshots(t *testing.T) {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	hra := &HotspotRiskAnalysis{SnapshotEvery: 2}
+	require.NoError(t, hra.Initialize(nil))
+	when := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(tick int, inserted bool, files map[string]string) {
+		changes := object.Changes{}
+		lineStats := map[object.ChangeEntry]items.LineStats{}
+		for name, content := range files {
+			require.NoError(t, util.WriteFile(fs, name, []byte(content), 0o644))
+			entry := object.ChangeEntry{Name: name}
+			if inserted {
+				changes = append(changes, &object.Change{To: entry})
+			} else {
+				changes = append(changes, &object.Change{From: entry, To: entry})
+			}
+			lineStats[entry] = items.LineStats{Added: 1}
+		}
+		require.NoError(t, worktree.AddWithOptions(&git.AddOptions{All: true}))
+		signature := &object.Signature{Name: "Alice", Email: "alice@example.com",
+			When: when.AddDate(0, 0, tick)}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature})
+		require.NoError(t, err)
+		obj, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		_, err = hra.Consume(map[string]interface{}{
+			core.DependencyCommit:       obj,
+			items.DependencyTreeChanges: changes,
+			items.DependencyLineStats:   lineStats,
+			items.DependencyLanguages:   map[plumbing.Hash]string{},
+			identity.DependencyAuthor:   0,
+			items.DependencyTick:        tick,
+		})
+		require.NoError(t, err)
+	}
+	commit(0, true, map[string]string{"a.go": "a\nb\n", "b.go": "b\n"})
+	commit(1, false, map[string]string{"a.go": "a\nb\nc\n"})
+	commit(2, false, map[string]string{"b.go": "b\nc\n"})
+	commit(3, false, map[string]string{"a.go": "a\n"})
+	result := hra.Finalize().(HotspotRiskResult)
+	assert.Equal(t, 2, result.SnapshotEvery)
+	// tick 1 is too close to tick 0, the final tick is always recorded
+	require.Len(t, result.Snapshots, 3)
+	paths := func(snapshot HotspotRiskSnapshot) []string {
+		var names []string
+		for _, entry := range snapshot.Files {
+			names = append(names, entry.Path)
+		}
+		return names
+	}
+	assert.Equal(t, 0, result.Snapshots[0].Tick)
+	assert.Equal(t, []string{"a.go", "b.go"}, paths(result.Snapshots[0]))
+	assert.Equal(t, 1.0, result.Snapshots[0].Files[0].RiskScore)
+	assert.Equal(t, 2, result.Snapshots[1].Tick)
+	assert.Equal(t, []string{"b.go", "a.go"}, paths(result.Snapshots[1]))
+	assert.Equal(t, 0.0, result.Snapshots[1].Files[1].RiskScore)
+	assert.Equal(t, 3, result.Snapshots[2].Tick)
+	assert.Equal(t, []string{"a.go", "b.go"}, paths(result.Snapshots[2]))
+	assert.Equal(t, result.Files[0].RiskScore, result.Snapshots[2].Files[0].RiskScore)
+	assert.Equal(t, -1, result.Snapshots[2].Files[0].FileId)
+
+	// disabled by default
+	hra = &HotspotRiskAnalysis{}
+	require.NoError(t, hra.Initialize(nil))
+	commit(4, false, map[string]string{"a.go": "a\nb\n"})
+	commit(5, false, map[string]string{"b.go": "b\n"})
+	result = hra.Finalize().(HotspotRiskResult)
+	assert.Nil(t, result.Snapshots)
+	assert.Equal(t, 0, result.SnapshotEvery)
+}
+
+func TestHotspotRiskSnapshotsSerialize(t *testing.T) {
+	hra := &HotspotRiskAnalysis{TopN: 2}
+	result := fixtureHotspotRiskResult()
+	result.SnapshotEvery = 7
+	result.Snapshots = []HotspotRiskSnapshot{
+		{Tick: 0, Files: []HotspotRiskEntry{{Path: "b.go", FileId: 3, RiskScore: 0.5}}},
+		{Tick: 7, Files: []HotspotRiskEntry{
+			{Path: "a.go", FileId: 3, RiskScore: 0.8}, {Path: "c.go", FileId: 4, RiskScore: 0.2}}},
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, hra.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `  snapshot_every: 7
+  snapshots:
+    - tick: 0
+      files:
+        - {path: "b.go", id: 3, risk_score: 0.500000}
+    - tick: 7
+      files:
+        - {path: "a.go", id: 3, risk_score: 0.800000}
+        - {path: "c.go", id: 4, risk_score: 0.200000}
+`)
+
+	buffer.Reset()
+	require.NoError(t, hra.Serialize(result, true, buffer))
+	deserialized, err := hra.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	filtered := hra.FilterResult(result, core.OutputFilter{TopFiles: 1}).(HotspotRiskResult)
+	assert.Len(t, filtered.Snapshots[1].Files, 1)
+	assert.Len(t, result.Snapshots[1].Files, 2)
+
+	anonymized := hra.AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(HotspotRiskResult)
+	assert.Equal(t, "x/c.go", anonymized.Snapshots[1].Files[1].Path)
+	assert.Equal(t, "c.go", result.Snapshots[1].Files[1].Path)
+
+	merged := hra.MergeResults(HotspotRiskResult{Snapshots: []HotspotRiskSnapshot{
+		{Tick: 7, Files: []HotspotRiskEntry{{Path: "d.go", RiskScore: 0.9}}},
+		{Tick: 14, Files: []HotspotRiskEntry{{Path: "d.go", RiskScore: 0.1}}},
+	}}, result, nil, nil).(HotspotRiskResult)
+	assert.Equal(t, 7, merged.SnapshotEvery)
+	require.Len(t, merged.Snapshots, 3)
+	assert.Equal(t, []HotspotRiskEntry{
+		{Path: "d.go", RiskScore: 0.9}, {Path: "a.go", FileId: 3, RiskScore: 0.8},
+	}, merged.Snapshots[1].Files)
+	assert.Equal(t, 14, merged.Snapshots[2].Tick)
+}
+
+func fixtureHotspotRiskResult() HotspotRiskResult {
+	return HotspotRiskResult{
+		WindowDays: 90,
+		Files: []FileRisk{
+			{
+				Path: "a.go", Language: "Go", RiskScore: 0.8, Size: 10, Churn: 1, CouplingDegree: 2,
+				AgeDays: 30, Complexity: 1.5, AgeNormalized: 0.25, ComplexityNormalized: 0.75,
+				FileId: 3, Names: []string{"b.go", "a.go"},
+			},
+		},
+		Languages: []LanguageRisk{
+			{Language: "Go", Files: 2, Size: 40, Churn: 4, MeanRiskScore: 0.5, MaxRiskScore: 0.8},
+		},
+	}
+}
+
+func TestHotspotRiskSerialize(t *testing.T) {
+	hra := &HotspotRiskAnalysis{}
+	result := fixtureHotspotRiskResult()
+	buffer := &bytes.Buffer{}
+	require.NoError(t, hra.Serialize(result, false, buffer))
+	text := buffer.String()
+	assert.Contains(t, text, "      language: \"Go\"\n")
+	assert.Contains(t, text, "  languages:\n    - language: \"Go\"\n      files: 2\n")
+	assert.Contains(t, text, "      mean_risk_score: 0.500000\n")
+	assert.Contains(t, text, "      id: 3\n      names: [\"b.go\", \"a.go\"]\n")
+	assert.Contains(t, text, "      age_days: 30\n      complexity: 1.500000\n")
+	assert.Contains(t, text, "        age: 0.250000\n        complexity: 0.750000\n")
+
+	buffer.Reset()
+	require.NoError(t, hra.Serialize(result, true, buffer))
+	deserialized, err := hra.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+
+	// no languages section without the grouping
+	result.Languages = nil
+	buffer.Reset()
+	require.NoError(t, hra.Serialize(result, false, buffer))
+	assert.NotContains(t, buffer.String(), "languages:")
+}
+
+func TestHotspotRiskMergeLanguages(t *testing.T) {
+	hra := &HotspotRiskAnalysis{TopN: 10}
+	r1 := fixtureHotspotRiskResult()
+	r2 := HotspotRiskResult{
+		WindowDays: 90,
+		Languages: []LanguageRisk{
+			{Language: "Go", Files: 2, Size: 10, Churn: 1, MeanRiskScore: 0.1, MaxRiskScore: 0.2},
+			{Language: "C", Files: 1, Size: 5, Churn: 1, MeanRiskScore: 0.9, MaxRiskScore: 0.9},
+		},
+	}
+	merged := hra.MergeResults(r1, r2, nil, nil).(HotspotRiskResult)
+	require.Len(t, merged.Languages, 2)
+	assert.Equal(t, LanguageRisk{
+		Language: "C", Files: 1, Size: 5, Churn: 1, MeanRiskScore: 0.9, MaxRiskScore: 0.9,
+	}, merged.Languages[0])
+	assert.Equal(t, "Go", merged.Languages[1].Language)
+	assert.Equal(t, 4, merged.Languages[1].Files)
+	assert.Equal(t, 50, merged.Languages[1].Size)
+	assert.InDelta(t, 0.3, merged.Languages[1].MeanRiskScore, 1e-9)
+	assert.Equal(t, 0.8, merged.Languages[1].MaxRiskScore)
+	assert.Len(t, merged.Files, 1)
+}
+
+func TestHotspotRiskFilterResult(t *testing.T) {
+	hra := &HotspotRiskAnalysis{}
+	result := HotspotRiskResult{Files: []FileRisk{
+		{Path: "a.go", RiskScore: 0.9, Size: 100},
+		{Path: "b.go", RiskScore: 0.8, Size: 5},
+		{Path: "c.go", RiskScore: 0.7, Size: 50},
+		{Path: "d.go", RiskScore: 0.6, Size: 70},
+	}, WindowDays: 90}
+	filtered := hra.FilterResult(result, core.OutputFilter{TopFiles: 2, MinLines: 10}).(HotspotRiskResult)
+	assert.Equal(t, []FileRisk{result.Files[0], result.Files[2]}, filtered.Files)
+	assert.Equal(t, 90, filtered.WindowDays)
+	assert.Len(t, result.Files, 4)
+}
+
+func TestHotspotRiskAnonymizePaths(t *testing.T) {
+	result := HotspotRiskResult{Files: []FileRisk{{Path: "a.go", Size: 100, Names: []string{"a.go"}}}, WindowDays: 90}
+	anonymized := (&HotspotRiskAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(HotspotRiskResult)
+	assert.Equal(t, []FileRisk{{Path: "x/a.go", Size: 100, Names: []string{"x/a.go"}}}, anonymized.Files)
+	assert.Equal(t, 90, anonymized.WindowDays)
+	assert.Equal(t, "a.go", result.Files[0].Path)
+	assert.Equal(t, []string{"a.go"}, result.Files[0].Names)
+}
@@ -77,6 +77,11 @@ func (ipd *ImportsPerDeveloper) Flag() string {
 	return "imports-per-dev"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ipd *ImportsPerDeveloper) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (ipd *ImportsPerDeveloper) Description() string {
 	return "Whenever a file is changed or added, we extract the imports from it and increment " +
@@ -235,13 +240,13 @@ func (ipd *ImportsPerDeveloper) Deserialize(pbmessage []byte) (interface{}, erro
 	for devi, dev := range msg.Imports {
 		rdev := map[string]map[string]map[int]int64{}
 		r.Imports[devi] = rdev
-		for lang, names := range dev.Languages {
+		for lang, names := range dev.GetLanguages() {
 			rlang := map[string]map[int]int64{}
 			rdev[lang] = rlang
-			for name, ticks := range names.Ticks {
+			for name, ticks := range names.GetTicks() {
 				rticks := map[int]int64{}
 				rlang[name] = rticks
-				for tick, val := range ticks.Counts {
+				for tick, val := range ticks.GetCounts() {
 					rticks[int(tick)] = val
 				}
 			}
@@ -25,6 +25,12 @@ type KnowledgeDiffusionAnalysis struct {
 	core.NoopMerger
 	// WindowMonths is the sliding window in months for "recent" editor counting (default 6).
 	WindowMonths int
+	// DirsDepth enables the per-directory roll-up if positive. The directories are made of
+	// the first DirsDepth components of the file paths.
+	DirsDepth int
+	// SiloThreshold is the share of the recent edits by a single author above which
+	// a directory is reported as a silo (default 0.8).
+	SiloThreshold float32
 
 	// fileAuthors: file -> author -> authorFileInfo (first/last tick).
 	fileAuthors map[string]map[int]*authorFileInfo
@@ -34,6 +40,8 @@ type KnowledgeDiffusionAnalysis struct {
 	tickSize time.Duration
 	// lastTick tracks the most recent tick seen.
 	lastTick int
+	// identities references FileIdentityTracker's stable file identifiers.
+	identities *items.FileIdentities
 
 	l core.Logger
 }
@@ -42,11 +50,20 @@ type KnowledgeDiffusionAnalysis struct {
 type authorFileInfo struct {
 	FirstTick int
 	LastTick  int
+	// EditTicks are the ticks of all the edits, recorded only for the per-directory roll-up.
+	EditTicks []int
 }
 
 const (
 	// ConfigKnowledgeDiffusionWindowMonths is the name of the option to configure the recent-editor window.
 	ConfigKnowledgeDiffusionWindowMonths = "KnowledgeDiffusion.WindowMonths"
+	// ConfigKnowledgeDiffusionDirsDepth is the name of the option to set KnowledgeDiffusionAnalysis.DirsDepth.
+	ConfigKnowledgeDiffusionDirsDepth = "KnowledgeDiffusion.DirsDepth"
+	// ConfigKnowledgeDiffusionSiloThreshold is the name of the option to set
+	// KnowledgeDiffusionAnalysis.SiloThreshold.
+	ConfigKnowledgeDiffusionSiloThreshold = "KnowledgeDiffusion.SiloThreshold"
+	// DefaultKnowledgeDiffusionSiloThreshold is the default KnowledgeDiffusionAnalysis.SiloThreshold.
+	DefaultKnowledgeDiffusionSiloThreshold = float32(0.8)
 )
 
 // KnowledgeDiffusionFileResult stores per-file knowledge diffusion data.
@@ -59,6 +76,29 @@ type KnowledgeDiffusionFileResult struct {
 	RecentEditorsCount int
 	// Authors is the sorted list of author indices who touched this file.
 	Authors []int
+	// FileId is the stable identifier which survives the renames, see items.FileIdentityTracker.
+	FileId int
+	// Names is the rename chain, the current name is the last.
+	Names []string
+}
+
+// KnowledgeDiffusionDirectoryResult rolls up the knowledge diffusion of all the files
+// in a directory.
+type KnowledgeDiffusionDirectoryResult struct {
+	// Files is the number of the files in the directory, including the deleted ones.
+	Files int
+	// UniqueEditorsCount is the number of distinct authors who ever touched the files.
+	UniqueEditorsCount int
+	// RecentEditorsCount is the number of distinct authors active within the recent window.
+	RecentEditorsCount int
+	// RecentEdits is the number of the file edits within the recent window.
+	RecentEdits int
+	// TopAuthor is the author with the most recent edits, -1 if there are none.
+	TopAuthor int
+	// SiloScore is the share of the recent edits by TopAuthor.
+	SiloScore float32
+	// Silo is true if SiloScore exceeds the threshold.
+	Silo bool
 }
 
 // KnowledgeDiffusionResult is returned by KnowledgeDiffusionAnalysis.Finalize().
@@ -69,6 +109,12 @@ type KnowledgeDiffusionResult struct {
 	Distribution map[int]int
 	// WindowMonths used for recent-editor computation.
 	WindowMonths int
+	// Directories maps the directories to their roll-ups. It is nil unless DirsDepth is positive.
+	Directories map[string]*KnowledgeDiffusionDirectoryResult
+	// DirsDepth is the number of the path components in Directories.
+	DirsDepth int
+	// SiloThreshold used to detect the silos.
+	SiloThreshold float32
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
 	reversedPeopleDict []string
 	// tickSize is the duration of each tick.
@@ -91,6 +137,7 @@ func (kd *KnowledgeDiffusionAnalysis) Requires() []string {
 		identity.DependencyAuthor,
 		items.DependencyTreeChanges,
 		items.DependencyTick,
+		items.DependencyFileIdentities,
 	}
 }
 
@@ -102,6 +149,20 @@ func (kd *KnowledgeDiffusionAnalysis) ListConfigurationOptions() []core.Configur
 		Flag:        "knowledge-diffusion-window",
 		Type:        core.IntConfigurationOption,
 		Default:     6,
+	}, {
+		Name: ConfigKnowledgeDiffusionDirsDepth,
+		Description: "Roll up the editors per directory made of this number of the path components " +
+			"and detect the silos; 0 disables it.",
+		Flag:    "knowledge-diffusion-dirs-depth",
+		Type:    core.IntConfigurationOption,
+		Default: 0,
+	}, {
+		Name: ConfigKnowledgeDiffusionSiloThreshold,
+		Description: "Minimum share of the recent edits in a directory by a single author " +
+			"to report it as a silo.",
+		Flag:    "knowledge-diffusion-silo-threshold",
+		Type:    core.FloatConfigurationOption,
+		Default: DefaultKnowledgeDiffusionSiloThreshold,
 	}}
 	return options[:]
 }
@@ -114,6 +175,18 @@ func (kd *KnowledgeDiffusionAnalysis) Configure(facts map[string]interface{}) er
 	if val, exists := facts[ConfigKnowledgeDiffusionWindowMonths]; exists {
 		kd.WindowMonths = val.(int)
 	}
+	if val, exists := facts[ConfigKnowledgeDiffusionDirsDepth].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--knowledge-diffusion-dirs-depth must not be negative, got %d", val)
+		}
+		kd.DirsDepth = val
+	}
+	if val, exists := facts[ConfigKnowledgeDiffusionSiloThreshold].(float32); exists {
+		if val <= 0 || val > 1 {
+			return fmt.Errorf("--knowledge-diffusion-silo-threshold must be in (0, 1], got %v", val)
+		}
+		kd.SiloThreshold = val
+	}
 	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		kd.reversedPeopleDict = val
 	}
@@ -133,6 +206,11 @@ func (kd *KnowledgeDiffusionAnalysis) Flag() string {
 	return "knowledge-diffusion"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (kd *KnowledgeDiffusionAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (kd *KnowledgeDiffusionAnalysis) Description() string {
 	return "Tracks unique editors per file over time to identify knowledge silos and single-contributor risk areas."
@@ -146,6 +224,9 @@ func (kd *KnowledgeDiffusionAnalysis) Initialize(repository *git.Repository) err
 	if kd.WindowMonths <= 0 {
 		kd.WindowMonths = 6
 	}
+	if kd.SiloThreshold <= 0 || kd.SiloThreshold > 1 {
+		kd.SiloThreshold = DefaultKnowledgeDiffusionSiloThreshold
+	}
 	return nil
 }
 
@@ -155,6 +236,9 @@ func (kd *KnowledgeDiffusionAnalysis) Consume(deps map[string]interface{}) (map[
 	changes := deps[items.DependencyTreeChanges].(object.Changes)
 	author := deps[identity.DependencyAuthor].(int)
 	tick := deps[items.DependencyTick].(int)
+	if identities, ok := deps[items.DependencyFileIdentities].(*items.FileIdentities); ok {
+		kd.identities = identities
+	}
 
 	for _, change := range changes {
 		action, _ := change.Action()
@@ -189,9 +273,12 @@ func (kd *KnowledgeDiffusionAnalysis) recordEdit(fileName string, author int, ti
 	}
 	info, exists := authors[author]
 	if !exists {
-		authors[author] = &authorFileInfo{FirstTick: tick, LastTick: tick}
-	} else {
-		info.LastTick = tick
+		info = &authorFileInfo{FirstTick: tick}
+		authors[author] = info
+	}
+	info.LastTick = tick
+	if kd.DirsDepth > 0 {
+		info.EditTicks = append(info.EditTicks, tick)
 	}
 }
 
@@ -249,24 +336,104 @@ func (kd *KnowledgeDiffusionAnalysis) Finalize() interface{} {
 			RecentEditorsCount:    recentCount,
 			Authors:               authorIndices,
 		}
+		result.FileId, result.Names = resolveFileIdentity(kd.identities, fileName)
 		files[fileName] = result
 		distribution[result.UniqueEditorsCount]++
 	}
 
+	var directories map[string]*KnowledgeDiffusionDirectoryResult
+	if kd.DirsDepth > 0 {
+		directories = kd.rollUpDirectories(cutoffTick)
+	}
+
 	return KnowledgeDiffusionResult{
 		Files:              files,
 		Distribution:       distribution,
 		WindowMonths:       kd.WindowMonths,
+		Directories:        directories,
+		DirsDepth:          kd.DirsDepth,
+		SiloThreshold:      kd.SiloThreshold,
 		reversedPeopleDict: kd.reversedPeopleDict,
 		tickSize:           kd.tickSize,
 	}
 }
 
+// rollUpDirectories aggregates the editors of the files per directory and finds the silos.
+// The edits at or after cutoffTick are recent.
+func (kd *KnowledgeDiffusionAnalysis) rollUpDirectories(
+	cutoffTick int) map[string]*KnowledgeDiffusionDirectoryResult {
+	type directoryEditors struct {
+		files   int
+		editors map[int]bool
+		recent  map[int]int // author -> recent edits
+	}
+	dirs := map[string]*directoryEditors{}
+	for fileName, authors := range kd.fileAuthors {
+		dir := burndownDirectory(fileName, kd.DirsDepth)
+		editors := dirs[dir]
+		if editors == nil {
+			editors = &directoryEditors{editors: map[int]bool{}, recent: map[int]int{}}
+			dirs[dir] = editors
+		}
+		editors.files++
+		for author, info := range authors {
+			editors.editors[author] = true
+			for _, tick := range info.EditTicks {
+				if tick >= cutoffTick {
+					editors.recent[author]++
+				}
+			}
+		}
+	}
+	result := make(map[string]*KnowledgeDiffusionDirectoryResult, len(dirs))
+	for dir, editors := range dirs {
+		rollUp := &KnowledgeDiffusionDirectoryResult{
+			Files:              editors.files,
+			UniqueEditorsCount: len(editors.editors),
+			RecentEditorsCount: len(editors.recent),
+			TopAuthor:          -1,
+		}
+		for author, edits := range editors.recent {
+			rollUp.RecentEdits += edits
+			if rollUp.TopAuthor < 0 || edits > editors.recent[rollUp.TopAuthor] ||
+				(edits == editors.recent[rollUp.TopAuthor] && author < rollUp.TopAuthor) {
+				rollUp.TopAuthor = author
+			}
+		}
+		if rollUp.RecentEdits > 0 {
+			rollUp.SiloScore = float32(editors.recent[rollUp.TopAuthor]) / float32(rollUp.RecentEdits)
+			rollUp.Silo = rollUp.SiloScore > kd.SiloThreshold
+		}
+		result[dir] = rollUp
+	}
+	return result
+}
+
 // Fork clones this pipeline item.
 func (kd *KnowledgeDiffusionAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(kd, n)
 }
 
+// AnonymizePaths replaces the names of the files, see core.PathAnonymizer.
+func (kd *KnowledgeDiffusionAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	diffusion := result.(KnowledgeDiffusionResult)
+	files := make(map[string]*KnowledgeDiffusionFileResult, len(diffusion.Files))
+	for file, stats := range diffusion.Files {
+		anonymized := *stats
+		anonymized.Names = anonymizeNames(stats.Names, anonymize)
+		files[anonymize(file)] = &anonymized
+	}
+	diffusion.Files = files
+	if diffusion.Directories != nil {
+		directories := make(map[string]*KnowledgeDiffusionDirectoryResult, len(diffusion.Directories))
+		for dir, rollUp := range diffusion.Directories {
+			directories[anonymize(dir)] = rollUp
+		}
+		diffusion.Directories = directories
+	}
+	return diffusion
+}
+
 // Serialize converts the analysis result as returned by Finalize() to text or bytes.
 func (kd *KnowledgeDiffusionAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
 	kdResult := result.(KnowledgeDiffusionResult)
@@ -289,19 +456,21 @@ func (kd *KnowledgeDiffusionAnalysis) Deserialize(pbmessage []byte) (interface{}
 	distribution := make(map[int]int, len(message.Distribution))
 
 	for fileName, pbFile := range message.Files {
-		editorsOverTime := make(map[int]int, len(pbFile.UniqueEditorsOverTime))
-		for tick, count := range pbFile.UniqueEditorsOverTime {
+		editorsOverTime := make(map[int]int, len(pbFile.GetUniqueEditorsOverTime()))
+		for tick, count := range pbFile.GetUniqueEditorsOverTime() {
 			editorsOverTime[int(tick)] = int(count)
 		}
-		authors := make([]int, len(pbFile.Authors))
-		for i, a := range pbFile.Authors {
+		authors := make([]int, len(pbFile.GetAuthors()))
+		for i, a := range pbFile.GetAuthors() {
 			authors[i] = int(a)
 		}
 		files[fileName] = &KnowledgeDiffusionFileResult{
-			UniqueEditorsCount:    int(pbFile.UniqueEditorsCount),
+			UniqueEditorsCount:    int(pbFile.GetUniqueEditorsCount()),
 			UniqueEditorsOverTime: editorsOverTime,
-			RecentEditorsCount:    int(pbFile.RecentEditorsCount),
+			RecentEditorsCount:    int(pbFile.GetRecentEditorsCount()),
 			Authors:               authors,
+			FileId:                int(pbFile.GetFileId()),
+			Names:                 pbFile.GetNames(),
 		}
 	}
 
@@ -309,10 +478,29 @@ func (kd *KnowledgeDiffusionAnalysis) Deserialize(pbmessage []byte) (interface{}
 		distribution[int(editorCount)] = int(fileCount)
 	}
 
+	var directories map[string]*KnowledgeDiffusionDirectoryResult
+	if message.DirsDepth > 0 {
+		directories = make(map[string]*KnowledgeDiffusionDirectoryResult, len(message.Directories))
+		for dir, pbDir := range message.Directories {
+			directories[dir] = &KnowledgeDiffusionDirectoryResult{
+				Files:              int(pbDir.Files),
+				UniqueEditorsCount: int(pbDir.UniqueEditorsCount),
+				RecentEditorsCount: int(pbDir.RecentEditorsCount),
+				RecentEdits:        int(pbDir.RecentEdits),
+				TopAuthor:          int(pbDir.TopAuthor),
+				SiloScore:          pbDir.SiloScore,
+				Silo:               pbDir.Silo,
+			}
+		}
+	}
+
 	result := KnowledgeDiffusionResult{
 		Files:              files,
 		Distribution:       distribution,
 		WindowMonths:       int(message.WindowMonths),
+		Directories:        directories,
+		DirsDepth:          int(message.DirsDepth),
+		SiloThreshold:      message.SiloThreshold,
 		reversedPeopleDict: message.DevIndex,
 		tickSize:           time.Duration(message.TickSize),
 	}
@@ -351,6 +539,8 @@ func (kd *KnowledgeDiffusionAnalysis) serializeText(result *KnowledgeDiffusionRe
 			fmt.Fprintf(writer, "%d: %d", tick, f.UniqueEditorsOverTime[tick])
 		}
 		fmt.Fprintln(writer, "}")
+		fmt.Fprintf(writer, "        id: %d\n", f.FileId)
+		fmt.Fprintf(writer, "        names: %s\n", formatNames(f.Names))
 	}
 
 	// Distribution histogram.
@@ -364,6 +554,24 @@ func (kd *KnowledgeDiffusionAnalysis) serializeText(result *KnowledgeDiffusionRe
 		fmt.Fprintf(writer, "      %d: %d\n", count, result.Distribution[count])
 	}
 
+	if result.Directories != nil {
+		fmt.Fprintf(writer, "    dirs_depth: %d\n", result.DirsDepth)
+		fmt.Fprintf(writer, "    silo_threshold: %.2f\n", result.SiloThreshold)
+		fmt.Fprintln(writer, "    directories:")
+		dirs := make([]string, 0, len(result.Directories))
+		for dir := range result.Directories {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			d := result.Directories[dir]
+			fmt.Fprintf(writer, "      %s: {files: %d, unique_editors: %d, recent_editors: %d, "+
+				"recent_edits: %d, top_author: %d, silo_score: %.4f, silo: %t}\n",
+				yaml.SafeString(dir), d.Files, d.UniqueEditorsCount, d.RecentEditorsCount,
+				d.RecentEdits, d.TopAuthor, d.SiloScore, d.Silo)
+		}
+	}
+
 	fmt.Fprintln(writer, "    people:")
 	for _, person := range result.reversedPeopleDict {
 		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
@@ -373,9 +581,11 @@ func (kd *KnowledgeDiffusionAnalysis) serializeText(result *KnowledgeDiffusionRe
 
 func (kd *KnowledgeDiffusionAnalysis) serializeBinary(result *KnowledgeDiffusionResult, writer io.Writer) error {
 	message := pb.KnowledgeDiffusionResults{
-		DevIndex:     result.reversedPeopleDict,
-		TickSize:     int64(result.tickSize),
-		WindowMonths: int32(result.WindowMonths),
+		DevIndex:      result.reversedPeopleDict,
+		TickSize:      int64(result.tickSize),
+		WindowMonths:  int32(result.WindowMonths),
+		DirsDepth:     int32(result.DirsDepth),
+		SiloThreshold: result.SiloThreshold,
 	}
 
 	message.Files = make(map[string]*pb.KnowledgeDiffusionFileData, len(result.Files))
@@ -385,6 +595,8 @@ func (kd *KnowledgeDiffusionAnalysis) serializeBinary(result *KnowledgeDiffusion
 			RecentEditorsCount:    int32(f.RecentEditorsCount),
 			UniqueEditorsOverTime: make(map[int32]int32, len(f.UniqueEditorsOverTime)),
 			Authors:               make([]int32, len(f.Authors)),
+			FileId:                int32(f.FileId),
+			Names:                 f.Names,
 		}
 		for tick, count := range f.UniqueEditorsOverTime {
 			pbFile.UniqueEditorsOverTime[int32(tick)] = int32(count)
@@ -400,6 +612,21 @@ func (kd *KnowledgeDiffusionAnalysis) serializeBinary(result *KnowledgeDiffusion
 		message.Distribution[int32(editorCount)] = int32(fileCount)
 	}
 
+	if result.Directories != nil {
+		message.Directories = make(map[string]*pb.KnowledgeDiffusionDirectoryData, len(result.Directories))
+		for dir, d := range result.Directories {
+			message.Directories[dir] = &pb.KnowledgeDiffusionDirectoryData{
+				Files:              int32(d.Files),
+				UniqueEditorsCount: int32(d.UniqueEditorsCount),
+				RecentEditorsCount: int32(d.RecentEditorsCount),
+				RecentEdits:        int32(d.RecentEdits),
+				TopAuthor:          int32(d.TopAuthor),
+				SiloScore:          d.SiloScore,
+				Silo:               d.Silo,
+			}
+		}
+	}
+
 	serialized, err := proto.Marshal(&message)
 	if err != nil {
 		return err
@@ -419,10 +646,28 @@ func (kd *KnowledgeDiffusionAnalysis) MergeResults(
 		Files:              make(map[string]*KnowledgeDiffusionFileResult),
 		Distribution:       make(map[int]int),
 		WindowMonths:       kdr1.WindowMonths,
+		DirsDepth:          kdr1.DirsDepth,
+		SiloThreshold:      kdr1.SiloThreshold,
 		reversedPeopleDict: kdr1.reversedPeopleDict,
 		tickSize:           kdr1.tickSize,
 	}
 
+	// The edits are not kept: the directory of the result with more recent edits wins.
+	if kdr1.Directories != nil || kdr2.Directories != nil {
+		if kdr1.Directories == nil {
+			merged.DirsDepth, merged.SiloThreshold = kdr2.DirsDepth, kdr2.SiloThreshold
+		}
+		merged.Directories = map[string]*KnowledgeDiffusionDirectoryResult{}
+		for dir, d := range kdr1.Directories {
+			merged.Directories[dir] = d
+		}
+		for dir, d := range kdr2.Directories {
+			if existing, ok := merged.Directories[dir]; !ok || d.RecentEdits > existing.RecentEdits {
+				merged.Directories[dir] = d
+			}
+		}
+	}
+
 	// Merge files: union of authors per file.
 	for name, f := range kdr1.Files {
 		merged.Files[name] = f
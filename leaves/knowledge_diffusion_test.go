@@ -99,9 +99,31 @@ func TestKnowledgeDiffusionRegistration(t *testing.T) {
 func TestKnowledgeDiffusionListConfigurationOptions(t *testing.T) {
 	kd := KnowledgeDiffusionAnalysis{}
 	opts := kd.ListConfigurationOptions()
-	assert.Len(t, opts, 1)
+	assert.Len(t, opts, 3)
 	assert.Equal(t, ConfigKnowledgeDiffusionWindowMonths, opts[0].Name)
 	assert.Equal(t, "knowledge-diffusion-window", opts[0].Flag)
+	assert.Equal(t, ConfigKnowledgeDiffusionDirsDepth, opts[1].Name)
+	assert.Equal(t, "knowledge-diffusion-dirs-depth", opts[1].Flag)
+	assert.Equal(t, ConfigKnowledgeDiffusionSiloThreshold, opts[2].Name)
+	assert.Equal(t, DefaultKnowledgeDiffusionSiloThreshold, opts[2].Default)
+}
+
+func TestKnowledgeDiffusionConfigureDirectories(t *testing.T) {
+	kd := KnowledgeDiffusionAnalysis{}
+	assert.Nil(t, kd.Configure(map[string]interface{}{
+		ConfigKnowledgeDiffusionDirsDepth:     2,
+		ConfigKnowledgeDiffusionSiloThreshold: float32(0.5),
+	}))
+	assert.Equal(t, 2, kd.DirsDepth)
+	assert.Equal(t, float32(0.5), kd.SiloThreshold)
+	assert.NotNil(t, kd.Configure(map[string]interface{}{ConfigKnowledgeDiffusionDirsDepth: -1}))
+	assert.NotNil(t, kd.Configure(map[string]interface{}{
+		ConfigKnowledgeDiffusionSiloThreshold: float32(0)}))
+	assert.NotNil(t, kd.Configure(map[string]interface{}{
+		ConfigKnowledgeDiffusionSiloThreshold: float32(1.5)}))
+	kd.SiloThreshold = 0
+	assert.Nil(t, kd.Initialize(test.Repository))
+	assert.Equal(t, DefaultKnowledgeDiffusionSiloThreshold, kd.SiloThreshold)
 }
 
 func TestKnowledgeDiffusionConfigure(t *testing.T) {
@@ -250,6 +272,41 @@ func TestKnowledgeDiffusionConsumeRename(t *testing.T) {
 	assert.Contains(t, kd.fileAuthors["new.go"], 1) // renaming author
 }
 
+func TestKnowledgeDiffusionFileIdentities(t *testing.T) {
+	kd := KnowledgeDiffusionAnalysis{}
+	kd.Initialize(test.Repository)
+	tracker := items.FileIdentityTracker{}
+	tracker.Initialize(test.Repository)
+	for tick, changes := range []object.Changes{
+		{makeInsertChange("util.go"), makeInsertChange("old.go")},
+		{makeRenameChange("old.go", "new.go")},
+	} {
+		deps := map[string]interface{}{
+			items.DependencyTreeChanges: changes,
+			identity.DependencyAuthor:   0,
+			items.DependencyTick:        tick,
+		}
+		identities, err := tracker.Consume(deps)
+		assert.NoError(t, err)
+		deps[items.DependencyFileIdentities] = identities[items.DependencyFileIdentities]
+		kd.Consume(deps)
+	}
+	result := kd.Finalize().(KnowledgeDiffusionResult)
+	assert.Equal(t, 1, result.Files["new.go"].FileId)
+	assert.Equal(t, []string{"old.go", "new.go"}, result.Files["new.go"].Names)
+	assert.Equal(t, 0, result.Files["util.go"].FileId)
+	assert.Equal(t, []string{"util.go"}, result.Files["util.go"].Names)
+
+	var buf bytes.Buffer
+	assert.NoError(t, kd.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), "        id: 1\n        names: [\"old.go\", \"new.go\"]\n")
+	buf.Reset()
+	assert.NoError(t, kd.Serialize(result, true, &buf))
+	deserialized, err := kd.Deserialize(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, result.Files["new.go"], deserialized.(KnowledgeDiffusionResult).Files["new.go"])
+}
+
 func TestKnowledgeDiffusionConsumeSameAuthorMultipleTicks(t *testing.T) {
 	kd := KnowledgeDiffusionAnalysis{}
 	kd.Initialize(test.Repository)
@@ -390,6 +447,72 @@ func TestKnowledgeDiffusionFinalizeAllRecent(t *testing.T) {
 	assert.Equal(t, 2, f.RecentEditorsCount)
 }
 
+func TestKnowledgeDiffusionFinalizeDirectories(t *testing.T) {
+	kd := KnowledgeDiffusionAnalysis{DirsDepth: 1, SiloThreshold: 0.7}
+	kd.Initialize(test.Repository)
+	kd.tickSize = 24 * time.Hour
+	consume := func(change *object.Change, author, tick int) {
+		_, err := kd.Consume(map[string]interface{}{
+			items.DependencyTreeChanges: object.Changes{change},
+			identity.DependencyAuthor:   author,
+			items.DependencyTick:        tick,
+		})
+		assert.Nil(t, err)
+	}
+	// the edits of author 0 in "src" at tick 0 are outside the window
+	consume(makeInsertChange("src/a.go"), 0, 0)
+	consume(makeInsertChange("src/b.go"), 0, 0)
+	consume(makeInsertChange("README.md"), 2, 0)
+	consume(makeModifyChange("src/a.go"), 1, 190)
+	consume(makeModifyChange("src/a.go"), 1, 195)
+	consume(makeModifyChange("src/b.go"), 1, 198)
+	consume(makeModifyChange("src/b.go"), 2, 200)
+	consume(makeInsertChange("docs/x.md"), 0, 190)
+	consume(makeModifyChange("docs/x.md"), 1, 200)
+
+	result := kd.Finalize().(KnowledgeDiffusionResult)
+	assert.Equal(t, 1, result.DirsDepth)
+	assert.Equal(t, float32(0.7), result.SiloThreshold)
+	assert.Equal(t, map[string]*KnowledgeDiffusionDirectoryResult{
+		"src": {
+			Files: 2, UniqueEditorsCount: 3, RecentEditorsCount: 2, RecentEdits: 4,
+			TopAuthor: 1, SiloScore: 0.75, Silo: true,
+		},
+		"docs": {
+			Files: 1, UniqueEditorsCount: 2, RecentEditorsCount: 2, RecentEdits: 2,
+			TopAuthor: 0, SiloScore: 0.5,
+		},
+		"/": {Files: 1, UniqueEditorsCount: 1, TopAuthor: -1},
+	}, result.Directories)
+
+	anonymized := kd.AnonymizePaths(result, func(name string) string {
+		return "x" + name
+	}).(KnowledgeDiffusionResult)
+	assert.Contains(t, anonymized.Directories, "xsrc")
+	assert.Contains(t, result.Directories, "src")
+
+	var buf bytes.Buffer
+	assert.Nil(t, kd.Serialize(result, false, &buf))
+	output := buf.String()
+	assert.Contains(t, output, "    dirs_depth: 1\n    silo_threshold: 0.70\n    directories:\n")
+	assert.Contains(t, output, `      "src": {files: 2, unique_editors: 3, recent_editors: 2, `+
+		"recent_edits: 4, top_author: 1, silo_score: 0.7500, silo: true}\n")
+
+	buf.Reset()
+	assert.Nil(t, kd.Serialize(result, true, &buf))
+	restored, err := kd.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.Directories, restored.(KnowledgeDiffusionResult).Directories)
+
+	// the roll-up is disabled by default
+	kd = KnowledgeDiffusionAnalysis{}
+	kd.Initialize(test.Repository)
+	consume(makeInsertChange("src/a.go"), 0, 0)
+	result = kd.Finalize().(KnowledgeDiffusionResult)
+	assert.Nil(t, result.Directories)
+	assert.Nil(t, kd.fileAuthors["src/a.go"][0].EditTicks)
+}
+
 func TestKnowledgeDiffusionSerializeText(t *testing.T) {
 	kd := KnowledgeDiffusionAnalysis{}
 	result := KnowledgeDiffusionResult{
@@ -611,3 +734,20 @@ func TestKnowledgeDiffusionDeserializeError(t *testing.T) {
 	_, err := kd.Deserialize([]byte("invalid protobuf"))
 	assert.NotNil(t, err)
 }
+
+func TestKnowledgeDiffusionAnonymizePaths(t *testing.T) {
+	stats := &KnowledgeDiffusionFileResult{UniqueEditorsCount: 2, Authors: []int{0, 1}, Names: []string{"main.go"}}
+	result := KnowledgeDiffusionResult{
+		Files:        map[string]*KnowledgeDiffusionFileResult{"main.go": stats},
+		Distribution: map[int]int{2: 1},
+	}
+	anonymized := (&KnowledgeDiffusionAnalysis{}).AnonymizePaths(result, func(path string) string {
+		return "x/" + path
+	}).(KnowledgeDiffusionResult)
+	assert.Equal(t, map[string]*KnowledgeDiffusionFileResult{"x/main.go": {
+		UniqueEditorsCount: 2, Authors: []int{0, 1}, Names: []string{"x/main.go"},
+	}}, anonymized.Files)
+	assert.Equal(t, []string{"main.go"}, stats.Names)
+	assert.Equal(t, result.Distribution, anonymized.Distribution)
+	assert.Contains(t, result.Files, "main.go")
+}
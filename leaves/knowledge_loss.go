@@ -0,0 +1,404 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigKnowledgeLossInactiveDays is the name of the option to set
+	// KnowledgeLossAnalysis.InactiveDays.
+	ConfigKnowledgeLossInactiveDays = "KnowledgeLoss.InactiveDays"
+	// DefaultKnowledgeLossInactiveDays is the default value of ConfigKnowledgeLossInactiveDays.
+	DefaultKnowledgeLossInactiveDays = 180
+)
+
+// KnowledgeLossAnalysis measures how much of the living code is owned by the departed
+// contributors. A developer departs when they have not committed for longer than InactiveDays;
+// the lines which they last touched are orphaned. The orphaned and the total alive lines are
+// snapshotted at each tick, overall and per directory, the same way as in BusFactorAnalysis.
+type KnowledgeLossAnalysis struct {
+	core.NoopMerger
+	// InactiveDays is the number of days without commits after which a developer is departed.
+	InactiveDays int
+
+	// inactiveTicks is InactiveDays converted to ticks.
+	inactiveTicks int
+	// lastActive maps developer indexes to the last ticks when they committed.
+	lastActive map[int]int
+	// fileResolver is used to scan the alive lines at the tick boundaries.
+	fileResolver core.FileIdResolver
+	// snapshots stores the per-tick orphaned lines.
+	snapshots map[int]*KnowledgeLossSnapshot
+	// lastTick tracks the most recent tick seen.
+	lastTick int
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// KnowledgeLossCounts are the alive lines and the lines of them owned by the departed developers.
+type KnowledgeLossCounts struct {
+	TotalLines    int64
+	OrphanedLines int64
+}
+
+// Fraction returns the share of the orphaned lines, 0 if there are no lines.
+func (counts KnowledgeLossCounts) Fraction() float64 {
+	if counts.TotalLines == 0 {
+		return 0
+	}
+	return float64(counts.OrphanedLines) / float64(counts.TotalLines)
+}
+
+// KnowledgeLossSnapshot stores the orphaned lines at a single tick.
+type KnowledgeLossSnapshot struct {
+	KnowledgeLossCounts
+	// Directories maps the directories to their own lines.
+	Directories map[string]KnowledgeLossCounts
+}
+
+// KnowledgeLossResult is returned by KnowledgeLossAnalysis.Finalize().
+type KnowledgeLossResult struct {
+	// Snapshots maps the ticks to the orphaned lines at the end of each of them.
+	Snapshots map[int]*KnowledgeLossSnapshot
+	// Departed maps the developers who are departed at the last tick to their last active ticks.
+	Departed map[int]int
+	// InactiveDays is the inactivity window which was used.
+	InactiveDays int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize is the duration of each tick.
+	tickSize time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (kl *KnowledgeLossAnalysis) Name() string {
+	return "KnowledgeLoss"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (kl *KnowledgeLossAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (kl *KnowledgeLossAnalysis) Requires() []string {
+	return []string{linehistory.DependencyLineHistory, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (kl *KnowledgeLossAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigKnowledgeLossInactiveDays,
+		Description: "Number of days without commits after which the lines of a developer are orphaned.",
+		Flag:        "knowledge-loss-inactive-days",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultKnowledgeLossInactiveDays,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (kl *KnowledgeLossAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		kl.l = l
+	}
+	if val, exists := facts[ConfigKnowledgeLossInactiveDays].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("invalid --knowledge-loss-inactive-days %d: must not be negative", val)
+		}
+		kl.InactiveDays = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		kl.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		kl.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*KnowledgeLossAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (kl *KnowledgeLossAnalysis) Flag() string {
+	return "knowledge-loss"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (kl *KnowledgeLossAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (kl *KnowledgeLossAnalysis) Description() string {
+	return "Measures the share of the living code owned by the developers who have been inactive " +
+		"for longer than the window (the orphaned lines) over time and per directory."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (kl *KnowledgeLossAnalysis) Initialize(repository *git.Repository) error {
+	kl.l = core.NewLogger()
+	if kl.InactiveDays < 0 {
+		return fmt.Errorf("invalid --knowledge-loss-inactive-days %d: must not be negative",
+			kl.InactiveDays)
+	}
+	if kl.tickSize == 0 {
+		kl.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	kl.inactiveTicks = int(math.Ceil(
+		float64(time.Duration(kl.InactiveDays)*24*time.Hour) / float64(kl.tickSize)))
+	kl.lastActive = map[int]int{}
+	kl.fileResolver = nil
+	kl.snapshots = map[int]*KnowledgeLossSnapshot{}
+	kl.lastTick = -1
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// It records the activity of the author and snapshots the orphaned lines when a new tick begins.
+func (kl *KnowledgeLossAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	kl.fileResolver = deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges).Resolver
+	tick := deps[items.DependencyTick].(int)
+	if tick > kl.lastTick {
+		if kl.lastTick >= 0 {
+			kl.takeSnapshot(kl.lastTick)
+		}
+		kl.lastTick = tick
+	}
+	if author := deps[identity.DependencyAuthor].(int); author != core.AuthorMissing {
+		if last, exists := kl.lastActive[author]; !exists || tick > last {
+			kl.lastActive[author] = tick
+		}
+	}
+	return nil, nil
+}
+
+// isDeparted returns whether the developer has been inactive for longer than the window at
+// the tick. The developers without any recorded activity are departed.
+func (kl *KnowledgeLossAnalysis) isDeparted(author int, tick int) bool {
+	last, exists := kl.lastActive[author]
+	return !exists || tick-last > kl.inactiveTicks
+}
+
+// takeSnapshot scans all files and counts the orphaned lines at the given tick.
+func (kl *KnowledgeLossAnalysis) takeSnapshot(tick int) {
+	if kl.fileResolver == nil {
+		return
+	}
+	snapshot := &KnowledgeLossSnapshot{Directories: map[string]KnowledgeLossCounts{}}
+	kl.fileResolver.ForEachFile(func(id core.FileId, name string) {
+		dir := subsystemOf(name)
+		counts := snapshot.Directories[dir]
+		previousLine, previousAuthor := -1, core.AuthorId(core.AuthorMissing)
+		kl.fileResolver.ScanFile(id, func(line int, _ core.TickNumber, author core.AuthorId) {
+			if previousLine >= 0 && line > previousLine && previousAuthor < core.AuthorMissing {
+				length := int64(line - previousLine)
+				counts.TotalLines += length
+				if kl.isDeparted(int(previousAuthor), tick) {
+					counts.OrphanedLines += length
+				}
+			}
+			previousLine, previousAuthor = line, author
+		})
+		snapshot.Directories[dir] = counts
+	})
+	for dir, counts := range snapshot.Directories {
+		if counts.TotalLines == 0 {
+			delete(snapshot.Directories, dir)
+			continue
+		}
+		snapshot.TotalLines += counts.TotalLines
+		snapshot.OrphanedLines += counts.OrphanedLines
+	}
+	kl.snapshots[tick] = snapshot
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (kl *KnowledgeLossAnalysis) Finalize() interface{} {
+	if kl.lastTick >= 0 {
+		kl.takeSnapshot(kl.lastTick)
+	}
+	departed := map[int]int{}
+	for dev, last := range kl.lastActive {
+		if kl.isDeparted(dev, kl.lastTick) {
+			departed[dev] = last
+		}
+	}
+	return KnowledgeLossResult{
+		Snapshots:          kl.snapshots,
+		Departed:           departed,
+		InactiveDays:       kl.InactiveDays,
+		reversedPeopleDict: kl.reversedPeopleDict,
+		tickSize:           kl.tickSize,
+	}
+}
+
+// Fork clones this pipeline item.
+func (kl *KnowledgeLossAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(kl, n)
+}
+
+// AnonymizePaths replaces the names of the directories, see core.PathAnonymizer.
+func (kl *KnowledgeLossAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	knowledgeLoss := result.(KnowledgeLossResult)
+	snapshots := make(map[int]*KnowledgeLossSnapshot, len(knowledgeLoss.Snapshots))
+	for tick, snapshot := range knowledgeLoss.Snapshots {
+		dirs := make(map[string]KnowledgeLossCounts, len(snapshot.Directories))
+		for dir, counts := range snapshot.Directories {
+			dirs[anonymize(dir)] = counts
+		}
+		snapshots[tick] = &KnowledgeLossSnapshot{
+			KnowledgeLossCounts: snapshot.KnowledgeLossCounts, Directories: dirs,
+		}
+	}
+	knowledgeLoss.Snapshots = snapshots
+	return knowledgeLoss
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (kl *KnowledgeLossAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	knowledgeLoss, ok := result.(KnowledgeLossResult)
+	if !ok {
+		return fmt.Errorf("result is not a knowledge loss result: '%v'", result)
+	}
+	if binary {
+		return kl.serializeBinary(&knowledgeLoss, writer)
+	}
+	kl.serializeText(&knowledgeLoss, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to KnowledgeLossResult.
+func (kl *KnowledgeLossAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.KnowledgeLossResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := KnowledgeLossResult{
+		Snapshots:          make(map[int]*KnowledgeLossSnapshot, len(message.Snapshots)),
+		Departed:           make(map[int]int, len(message.Departed)),
+		InactiveDays:       int(message.InactiveDays),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for tick, pbSnapshot := range message.Snapshots {
+		snapshot := &KnowledgeLossSnapshot{
+			KnowledgeLossCounts: KnowledgeLossCounts{
+				TotalLines: pbSnapshot.GetTotalLines(), OrphanedLines: pbSnapshot.GetOrphanedLines(),
+			},
+			Directories: make(map[string]KnowledgeLossCounts, len(pbSnapshot.GetDirectories())),
+		}
+		for dir, counts := range pbSnapshot.GetDirectories() {
+			snapshot.Directories[dir] = KnowledgeLossCounts{
+				TotalLines: counts.GetTotalLines(), OrphanedLines: counts.GetOrphanedLines(),
+			}
+		}
+		result.Snapshots[int(tick)] = snapshot
+	}
+	for dev, last := range message.Departed {
+		result.Departed[int(dev)] = int(last)
+	}
+	return result, nil
+}
+
+func (kl *KnowledgeLossAnalysis) serializeText(result *KnowledgeLossResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  knowledge_loss:")
+	fmt.Fprintln(writer, "    inactive_days:", result.InactiveDays)
+	ticks := make([]int, 0, len(result.Snapshots))
+	for tick := range result.Snapshots {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	fmt.Fprintln(writer, "    ticks:")
+	for _, tick := range ticks {
+		snapshot := result.Snapshots[tick]
+		fmt.Fprintf(writer, "      %d:\n", tick)
+		fmt.Fprintf(writer, "        total_lines: %d\n", snapshot.TotalLines)
+		fmt.Fprintf(writer, "        orphaned_lines: %d\n", snapshot.OrphanedLines)
+		fmt.Fprintf(writer, "        fraction: %.4f\n", snapshot.Fraction())
+		fmt.Fprintln(writer, "        directories:")
+		dirs := make([]string, 0, len(snapshot.Directories))
+		for dir := range snapshot.Directories {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			counts := snapshot.Directories[dir]
+			fmt.Fprintf(writer, "          %s: {total_lines: %d, orphaned_lines: %d, fraction: %.4f}\n",
+				yaml.SafeString(dir), counts.TotalLines, counts.OrphanedLines, counts.Fraction())
+		}
+	}
+	fmt.Fprintln(writer, "    departed:")
+	devs := make([]int, 0, len(result.Departed))
+	for dev := range result.Departed {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d: %d\n", dev, result.Departed[dev])
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (kl *KnowledgeLossAnalysis) serializeBinary(result *KnowledgeLossResult, writer io.Writer) error {
+	message := pb.KnowledgeLossResults{
+		Snapshots:    make(map[int32]*pb.KnowledgeLossSnapshot, len(result.Snapshots)),
+		Departed:     make(map[int32]int32, len(result.Departed)),
+		InactiveDays: int32(result.InactiveDays),
+		DevIndex:     result.reversedPeopleDict,
+		TickSize:     int64(result.tickSize),
+	}
+	for tick, snapshot := range result.Snapshots {
+		pbSnapshot := &pb.KnowledgeLossSnapshot{
+			TotalLines:    snapshot.TotalLines,
+			OrphanedLines: snapshot.OrphanedLines,
+			Directories:   make(map[string]*pb.KnowledgeLossCounts, len(snapshot.Directories)),
+		}
+		for dir, counts := range snapshot.Directories {
+			pbSnapshot.Directories[dir] = &pb.KnowledgeLossCounts{
+				TotalLines: counts.TotalLines, OrphanedLines: counts.OrphanedLines,
+			}
+		}
+		message.Snapshots[int32(tick)] = pbSnapshot
+	}
+	for dev, last := range result.Departed {
+		message.Departed[int32(dev)] = int32(last)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&KnowledgeLossAnalysis{})
+}
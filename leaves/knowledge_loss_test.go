@@ -0,0 +1,152 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeLossMeta(t *testing.T) {
+	kl := &KnowledgeLossAnalysis{}
+	assert.Equal(t, "KnowledgeLoss", kl.Name())
+	assert.Len(t, kl.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory, identity.DependencyAuthor,
+		items.DependencyTick}, kl.Requires())
+	assert.Equal(t, "knowledge-loss", kl.Flag())
+	assert.Equal(t, core.CostHeavy, kl.Cost())
+	opts := kl.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigKnowledgeLossInactiveDays, opts[0].Name)
+	assert.Equal(t, DefaultKnowledgeLossInactiveDays, opts[0].Default)
+	require.NoError(t, kl.Configure(map[string]interface{}{
+		ConfigKnowledgeLossInactiveDays:                 30,
+		items.FactTickSize:                              12 * time.Hour,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice"},
+	}))
+	assert.Equal(t, 30, kl.InactiveDays)
+	assert.Equal(t, 12*time.Hour, kl.tickSize)
+	assert.Equal(t, []string{"Alice"}, kl.reversedPeopleDict)
+	require.NoError(t, kl.Initialize(nil))
+	assert.Equal(t, 60, kl.inactiveTicks)
+	assert.Error(t, kl.Configure(map[string]interface{}{ConfigKnowledgeLossInactiveDays: -1}))
+	kl.InactiveDays = -1
+	assert.Error(t, kl.Initialize(nil))
+	summoned := core.Registry.Summon(kl.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, kl.Name(), summoned[0].Name())
+}
+
+func TestKnowledgeLossConsumeFinalize(t *testing.T) {
+	kl := &KnowledgeLossAnalysis{InactiveDays: 2}
+	require.NoError(t, kl.Initialize(nil))
+	resolver := &testFileIdResolver{
+		Names: []string{"src/a.go", "README.md"},
+		Segments: [][]testLineSegment{
+			{{0, 0}, {10, 0}, {15, 0}},
+			{{0, 0}, {4, 0}},
+		},
+		Authors: [][]core.AuthorId{
+			{0, 1, 0},
+			{1, 0},
+		},
+	}
+	consume := func(author, tick int) {
+		_, err := kl.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Resolver: resolver},
+			identity.DependencyAuthor:         author,
+			items.DependencyTick:              tick,
+		})
+		require.NoError(t, err)
+	}
+	consume(0, 0)
+	consume(1, 0)
+	consume(1, 2)
+	consume(core.AuthorMissing, 5)
+	consume(1, 5)
+
+	result := kl.Finalize().(KnowledgeLossResult)
+	assert.Equal(t, map[int]*KnowledgeLossSnapshot{
+		0: {
+			KnowledgeLossCounts: KnowledgeLossCounts{TotalLines: 19},
+			Directories: map[string]KnowledgeLossCounts{
+				"src": {TotalLines: 15}, "/": {TotalLines: 4},
+			},
+		},
+		// the window is not exceeded yet
+		2: {
+			KnowledgeLossCounts: KnowledgeLossCounts{TotalLines: 19},
+			Directories: map[string]KnowledgeLossCounts{
+				"src": {TotalLines: 15}, "/": {TotalLines: 4},
+			},
+		},
+		5: {
+			KnowledgeLossCounts: KnowledgeLossCounts{TotalLines: 19, OrphanedLines: 10},
+			Directories: map[string]KnowledgeLossCounts{
+				"src": {TotalLines: 15, OrphanedLines: 10}, "/": {TotalLines: 4},
+			},
+		},
+	}, result.Snapshots)
+	assert.Equal(t, map[int]int{0: 0}, result.Departed)
+	assert.Equal(t, 2, result.InactiveDays)
+	assert.Equal(t, 24*time.Hour, result.tickSize)
+	assert.InDelta(t, 10.0/19, result.Snapshots[5].Fraction(), 1e-9)
+	assert.Equal(t, 0.0, KnowledgeLossCounts{}.Fraction())
+
+	anonymized := kl.AnonymizePaths(result, func(name string) string {
+		return "x" + name
+	}).(KnowledgeLossResult)
+	assert.Contains(t, anonymized.Snapshots[5].Directories, "xsrc")
+	assert.Contains(t, result.Snapshots[5].Directories, "src")
+}
+
+func TestKnowledgeLossSerialize(t *testing.T) {
+	kl := &KnowledgeLossAnalysis{}
+	result := KnowledgeLossResult{
+		Snapshots: map[int]*KnowledgeLossSnapshot{
+			5: {
+				KnowledgeLossCounts: KnowledgeLossCounts{TotalLines: 20, OrphanedLines: 10},
+				Directories: map[string]KnowledgeLossCounts{
+					"src": {TotalLines: 16, OrphanedLines: 10}, "/": {TotalLines: 4},
+				},
+			},
+		},
+		Departed:           map[int]int{0: 1},
+		InactiveDays:       2,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, kl.Serialize(result, false, buffer))
+	assert.Equal(t, `  knowledge_loss:
+    inactive_days: 2
+    ticks:
+      5:
+        total_lines: 20
+        orphaned_lines: 10
+        fraction: 0.5000
+        directories:
+          "/": {total_lines: 4, orphaned_lines: 0, fraction: 0.0000}
+          "src": {total_lines: 16, orphaned_lines: 10, fraction: 0.6250}
+    departed:
+      0: 1
+    people:
+    - "Alice"
+    - "Bob"
+    tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, kl.Serialize(result, true, buffer))
+	restored, err := kl.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, kl.Serialize(nil, false, buffer))
+}
@@ -0,0 +1,342 @@
+package leaves
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+// ConfigLineHistoryDumperStreamTo is the name of the option to set LineHistoryDumper.StreamTo.
+const ConfigLineHistoryDumperStreamTo = "LineHistoryDumper.StreamTo"
+
+// LineHistoryDumper records the line history of the repository in the versioned binary
+// format which LineHistoryLoader replays with --history-line-load. This way the line-history
+// based analyses such as burndown or bus factor can run without the Git repository.
+type LineHistoryDumper struct {
+	core.NoopMerger
+
+	// StreamTo is the path to the file where the commits are streamed as they are consumed
+	// instead of keeping them in memory, see linehistory.DumpStreamWriter.
+	StreamTo string
+
+	// peopleResolver references the identities of the authors.
+	peopleResolver core.IdentityResolver
+	// fileResolver references the current state of LineHistory.
+	fileResolver core.FileIdResolver
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	commits []LineHistoryDumperCommit
+	// alive are the files which were inserted and not deleted yet.
+	alive map[core.FileId]bool
+	// streamFile and stream are open while the commits are streamed to StreamTo.
+	streamFile *os.File
+	stream     *linehistory.DumpStreamWriter
+	streamed   int
+
+	l core.Logger
+}
+
+// LineHistoryDumperCommit is the line history of a single commit.
+type LineHistoryDumperCommit struct {
+	Hash plumbing.Hash
+	// When is the author time.
+	When   time.Time
+	Tick   int
+	Author int
+	// Changes are the line ownership changes made by the commit.
+	Changes []core.LineHistoryChange
+	// Names are the names of the changed files after the commit.
+	Names map[core.FileId]string
+}
+
+// LineHistoryDumperResult is returned by LineHistoryDumper.Finalize().
+type LineHistoryDumperResult struct {
+	Commits []LineHistoryDumperCommit
+	// Files are the names of the files which exist after the last commit.
+	Files map[core.FileId]string
+	// Stream is the path to the streamed dump which contains the commits, if any.
+	Stream string
+
+	reversedPeopleDict []string
+	tickSize           time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (dumper *LineHistoryDumper) Name() string {
+	return "LineHistoryDumper"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (dumper *LineHistoryDumper) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (dumper *LineHistoryDumper) Requires() []string {
+	return []string{linehistory.DependencyLineHistory, identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (dumper *LineHistoryDumper) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{{
+		Name: ConfigLineHistoryDumperStreamTo,
+		Description: "Stream the line history to this file as the commits are analysed, " +
+			"so that huge histories fit in memory. --history-line-load replays the file.",
+		Flag:    "history-line-dump-stream",
+		Type:    core.PathConfigurationOption,
+		Default: "",
+	}}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (dumper *LineHistoryDumper) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		dumper.l = l
+	}
+	if val, exists := facts[core.FactIdentityResolver].(core.IdentityResolver); exists {
+		dumper.peopleResolver = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		dumper.tickSize = val
+	}
+	if val, exists := facts[ConfigLineHistoryDumperStreamTo].(string); exists {
+		dumper.StreamTo = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*LineHistoryDumper) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (dumper *LineHistoryDumper) Flag() string {
+	return "history-line-dump"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (dumper *LineHistoryDumper) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (dumper *LineHistoryDumper) Description() string {
+	return "Dumps the line history in the binary format for --history-line-load. Requires --pb " +
+		"unless --history-line-dump-stream is set."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (dumper *LineHistoryDumper) Initialize(repository *git.Repository) error {
+	dumper.l = core.NewLogger()
+	if dumper.peopleResolver == nil {
+		dumper.peopleResolver = core.NewIdentityResolver(nil, nil)
+	}
+	if dumper.tickSize == 0 {
+		dumper.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	dumper.fileResolver = nil
+	dumper.commits = nil
+	dumper.alive = map[core.FileId]bool{}
+	dumper.streamed = 0
+	if dumper.streamFile != nil {
+		_ = dumper.streamFile.Close()
+		dumper.streamFile, dumper.stream = nil, nil
+	}
+	if dumper.StreamTo != "" {
+		file, err := os.Create(dumper.StreamTo)
+		if err != nil {
+			return err
+		}
+		stream, err := linehistory.NewDumpStreamWriter(
+			file, dumper.peopleResolver.CopyNames(false), int64(dumper.tickSize))
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		dumper.streamFile, dumper.stream = file, stream
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (dumper *LineHistoryDumper) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges)
+	dumper.fileResolver = changes.Resolver
+	// the commits without changes are kept because they advance the ticks
+	dumped := LineHistoryDumperCommit{
+		Hash:    commit.Hash,
+		When:    commit.Author.When,
+		Tick:    deps[items.DependencyTick].(int),
+		Author:  deps[identity.DependencyAuthor].(int),
+		Changes: append([]core.LineHistoryChange(nil), changes.Changes...),
+		Names:   map[core.FileId]string{},
+	}
+	for _, change := range changes.Changes {
+		if _, exists := dumped.Names[change.FileId]; !exists && changes.Resolver != nil {
+			dumped.Names[change.FileId] = changes.Resolver.NameOf(change.FileId)
+		}
+		dumper.alive[change.FileId] = !change.IsDelete()
+	}
+	if commit.NumParents() > 1 && changes.Resolver != nil {
+		// the files of the merged branches which LineHistory dropped disappear without a change
+		ids := make([]core.FileId, 0, len(dumper.alive))
+		for id, alive := range dumper.alive {
+			if alive {
+				ids = append(ids, id)
+			}
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		for _, id := range ids {
+			if merged, _, exists := changes.Resolver.MergedWith(id); !exists || merged != id {
+				dumped.Changes = append(dumped.Changes, core.NewLineHistoryDeletion(
+					id, core.AuthorId(dumped.Author), core.TickNumber(dumped.Tick)))
+				dumper.alive[id] = false
+			}
+		}
+	}
+	if dumper.stream != nil {
+		if err := dumper.stream.WriteCommit(lineHistoryCommitToPb(dumped)); err != nil {
+			return nil, fmt.Errorf("failed to write to %s: %v", dumper.StreamTo, err)
+		}
+		dumper.streamed++
+		return nil, nil
+	}
+	dumper.commits = append(dumper.commits, dumped)
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (dumper *LineHistoryDumper) Finalize() interface{} {
+	result := LineHistoryDumperResult{
+		Commits:            dumper.commits,
+		Files:              map[core.FileId]string{},
+		reversedPeopleDict: dumper.peopleResolver.CopyNames(false),
+		tickSize:           dumper.tickSize,
+	}
+	if dumper.fileResolver != nil {
+		dumper.fileResolver.ForEachFile(func(id core.FileId, name string) {
+			result.Files[id] = name
+		})
+	}
+	if dumper.stream != nil {
+		result.Stream = dumper.StreamTo
+		err := dumper.stream.Finish(filesToPb(result.Files))
+		if closeErr := dumper.streamFile.Close(); err == nil {
+			err = closeErr
+		}
+		dumper.streamFile, dumper.stream = nil, nil
+		if err != nil {
+			return fmt.Errorf("failed to write to %s: %v", dumper.StreamTo, err)
+		}
+		dumper.l.Infof("streamed the line history of %d commits to %s", dumper.streamed, dumper.StreamTo)
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (dumper *LineHistoryDumper) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(dumper, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to Protocol Buffers.
+// The text format is supported only for the streamed dumps, it references the stream file,
+// because the dump is intended for LineHistoryLoader.
+func (dumper *LineHistoryDumper) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	if err, ok := result.(error); ok {
+		return err
+	}
+	dump, ok := result.(LineHistoryDumperResult)
+	if !ok {
+		return fmt.Errorf("result is not a line history dumper result: '%v'", result)
+	}
+	if !binary {
+		if dump.Stream == "" {
+			return errors.New("the line history dump supports only the binary format, use --pb")
+		}
+		_, err := fmt.Fprintf(writer, "  stream: %s\n", yaml.SafeString(dump.Stream))
+		return err
+	}
+	// the streamed commits are not repeated
+	message := pb.LineHistoryDumpResults{
+		Version:  linehistory.DumpFormatVersion,
+		Commits:  make([]*pb.LineHistoryCommit, len(dump.Commits)),
+		Files:    filesToPb(dump.Files),
+		DevIndex: dump.reversedPeopleDict,
+		TickSize: int64(dump.tickSize),
+	}
+	for i, commit := range dump.Commits {
+		message.Commits[i] = lineHistoryCommitToPb(commit)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func filesToPb(files map[core.FileId]string) map[int32]string {
+	result := make(map[int32]string, len(files))
+	for id, name := range files {
+		result[int32(id)] = name
+	}
+	return result
+}
+
+func lineHistoryCommitToPb(commit LineHistoryDumperCommit) *pb.LineHistoryCommit {
+	pbCommit := &pb.LineHistoryCommit{
+		Hash:         commit.Hash.String(),
+		WhenUnixTime: commit.When.Unix(),
+		Tick:         int32(commit.Tick),
+		Author:       int32(commit.Author),
+		Changes:      make([]*pb.LineHistoryChange, len(commit.Changes)),
+		Names:        make(map[int32]string, len(commit.Names)),
+	}
+	for j, change := range commit.Changes {
+		delta := int64(change.Delta)
+		if change.IsDelete() {
+			// math.MinInt depends on the platform
+			delta = math.MinInt64
+		}
+		pbCommit.Changes[j] = &pb.LineHistoryChange{
+			FileId:     int32(change.FileId),
+			PrevAuthor: int32(change.PrevAuthor),
+			PrevTick:   int32(change.PrevTick),
+			CurrAuthor: int32(change.CurrAuthor),
+			CurrTick:   int32(change.CurrTick),
+			Delta:      delta,
+			Kind:       int32(change.Kind),
+		}
+	}
+	for id, name := range commit.Names {
+		pbCommit.Names[int32(id)] = name
+	}
+	return pbCommit
+}
+
+func init() {
+	core.Registry.Register(&LineHistoryDumper{})
+}
@@ -0,0 +1,209 @@
+package leaves
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineHistoryDumperMeta(t *testing.T) {
+	dumper := &LineHistoryDumper{}
+	assert.Equal(t, "LineHistoryDumper", dumper.Name())
+	assert.Len(t, dumper.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory, identity.DependencyAuthor, items.DependencyTick},
+		dumper.Requires())
+	assert.Equal(t, "history-line-dump", dumper.Flag())
+	assert.Equal(t, core.CostHeavy, dumper.Cost())
+	opts := dumper.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigLineHistoryDumperStreamTo, opts[0].Name)
+	assert.Equal(t, "history-line-dump-stream", opts[0].Flag)
+	require.NoError(t, dumper.Configure(map[string]interface{}{
+		items.FactTickSize:              time.Hour,
+		ConfigLineHistoryDumperStreamTo: "history.stream",
+	}))
+	assert.Equal(t, time.Hour, dumper.tickSize)
+	assert.Equal(t, "history.stream", dumper.StreamTo)
+}
+
+func TestLineHistoryDumperRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&LineHistoryDumper{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "LineHistoryDumper", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&LineHistoryDumper{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+// consumeTestLineHistory feeds the history of two files, the second is dropped by the merge.
+func consumeTestLineHistory(t *testing.T, dumper *LineHistoryDumper) {
+	resolver := &testFileIdResolver{Names: []string{"a.go", "b.go"}}
+	when := time.Unix(1600000000, 0)
+	for i, commit := range []struct {
+		tick, author int
+		parents      int
+		changes      []core.LineHistoryChange
+	}{
+		{0, 0, 1, []core.LineHistoryChange{
+			{FileId: 0, CurrAuthor: 0, PrevAuthor: 0, Delta: 10},
+			{FileId: 1, CurrAuthor: 0, PrevAuthor: 0, Delta: 3, Kind: core.LineKindComment},
+		}},
+		{1, 1, 1, nil},
+		{2, 1, 2, []core.LineHistoryChange{
+			{FileId: 0, CurrAuthor: 1, CurrTick: 2, PrevAuthor: 0, PrevTick: 0, Delta: -4},
+		}},
+	} {
+		parents := make([]plumbing.Hash, commit.parents)
+		_, err := dumper.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{
+				Hash:         plumbing.NewHash(string(rune('a'+i)) + "000000000000000000000000000000000000000"),
+				Author:       object.Signature{When: when.Add(time.Duration(i) * time.Hour)},
+				ParentHashes: parents,
+			},
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{
+				Changes: commit.changes, Resolver: resolver,
+			},
+			items.DependencyTick:      commit.tick,
+			identity.DependencyAuthor: commit.author,
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestLineHistoryDumperSerialize(t *testing.T) {
+	dumper := &LineHistoryDumper{}
+	require.NoError(t, dumper.Configure(map[string]interface{}{
+		core.FactIdentityResolver: core.NewIdentityResolver([]string{"one", "two"}, nil),
+	}))
+	require.NoError(t, dumper.Initialize(nil))
+	consumeTestLineHistory(t, dumper)
+	result := dumper.Finalize().(LineHistoryDumperResult)
+	require.Len(t, result.Commits, 3)
+	assert.Equal(t, map[core.FileId]string{0: "a.go", 1: "b.go"}, result.Commits[0].Names)
+	assert.Len(t, result.Commits[1].Changes, 0)
+	// testFileIdResolver reports that no file survives the merge
+	require.Len(t, result.Commits[2].Changes, 3)
+	assert.True(t, result.Commits[2].Changes[1].IsDelete())
+	assert.True(t, result.Commits[2].Changes[2].IsDelete())
+	assert.Equal(t, map[core.FileId]string{0: "a.go", 1: "b.go"}, result.Files)
+
+	buffer := &bytes.Buffer{}
+	assert.Error(t, dumper.Serialize(result, false, buffer))
+	require.NoError(t, dumper.Serialize(result, true, buffer))
+	message := pb.LineHistoryDumpResults{}
+	require.NoError(t, proto.Unmarshal(buffer.Bytes(), &message))
+	assert.Equal(t, int32(linehistory.DumpFormatVersion), message.Version)
+	assert.Equal(t, []string{"one", "two"}, message.DevIndex)
+	assert.Equal(t, int64(24*time.Hour), message.TickSize)
+	require.Len(t, message.Commits, 3)
+	assert.Equal(t, int32(core.LineKindComment), message.Commits[0].Changes[1].Kind)
+	assert.Equal(t, int64(1600007200), message.Commits[2].WhenUnixTime)
+	assert.Equal(t, int32(2), message.Commits[2].Tick)
+	assert.Equal(t, int32(1), message.Commits[2].Author)
+	assert.Equal(t, &pb.LineHistoryChange{CurrAuthor: 1, CurrTick: 2, Delta: -4},
+		message.Commits[2].Changes[0])
+	assert.Equal(t, int64(math.MinInt64), message.Commits[2].Changes[1].Delta)
+}
+
+func TestLineHistoryDumperLoad(t *testing.T) {
+	dumper := &LineHistoryDumper{}
+	require.NoError(t, dumper.Configure(map[string]interface{}{
+		core.FactIdentityResolver: core.NewIdentityResolver([]string{"one", "two"}, nil),
+		items.FactTickSize:        time.Hour,
+	}))
+	require.NoError(t, dumper.Initialize(nil))
+	consumeTestLineHistory(t, dumper)
+	buffer := &bytes.Buffer{}
+	require.NoError(t, dumper.Serialize(dumper.Finalize(), true, buffer))
+	results := pb.AnalysisResults{
+		Header:   &pb.Metadata{},
+		Contents: map[string][]byte{dumper.Name(): buffer.Bytes()},
+	}
+	serialized, err := proto.Marshal(&results)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "history.pb")
+	require.NoError(t, os.WriteFile(path, serialized, 0o644))
+	checkLoadedLineHistory(t, path)
+}
+
+func TestLineHistoryDumperStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.stream")
+	dumper := &LineHistoryDumper{}
+	require.NoError(t, dumper.Configure(map[string]interface{}{
+		core.FactIdentityResolver:       core.NewIdentityResolver([]string{"one", "two"}, nil),
+		items.FactTickSize:              time.Hour,
+		ConfigLineHistoryDumperStreamTo: path,
+	}))
+	require.NoError(t, dumper.Initialize(nil))
+	consumeTestLineHistory(t, dumper)
+	assert.Len(t, dumper.commits, 0)
+	result := dumper.Finalize().(LineHistoryDumperResult)
+	assert.Len(t, result.Commits, 0)
+	assert.Equal(t, path, result.Stream)
+	buffer := &bytes.Buffer{}
+	require.NoError(t, dumper.Serialize(result, false, buffer))
+	assert.Equal(t, "  stream: \""+path+"\"\n", buffer.String())
+	buffer.Reset()
+	require.NoError(t, dumper.Serialize(result, true, buffer))
+	message := pb.LineHistoryDumpResults{}
+	require.NoError(t, proto.Unmarshal(buffer.Bytes(), &message))
+	assert.Len(t, message.Commits, 0)
+	assert.Equal(t, map[int32]string{0: "a.go", 1: "b.go"}, message.Files)
+	checkLoadedLineHistory(t, path)
+
+	dumper.StreamTo = filepath.Join(path, "missing", "history.stream")
+	assert.Error(t, dumper.Initialize(nil))
+}
+
+// checkLoadedLineHistory replays the dump of consumeTestLineHistory() with LineHistoryLoader.
+func checkLoadedLineHistory(t *testing.T, path string) {
+	loader := &linehistory.LineHistoryLoader{}
+	facts := map[string]interface{}{linehistory.ConfigLinesLoadFrom: path}
+	require.NoError(t, loader.Configure(facts))
+	assert.Equal(t, time.Hour, facts[items.FactTickSize])
+	assert.Equal(t, []string{"one", "two"}, facts[identity.FactIdentityDetectorReversedPeopleDict])
+	commits := facts[core.ConfigPipelineCommits].([]*object.Commit)
+	require.Len(t, commits, 3)
+	assert.Equal(t, int64(1600003600), commits[1].Author.When.Unix())
+	resolver := facts[core.FactLineHistoryResolver].(core.FileIdResolver)
+
+	require.NoError(t, loader.Initialize(nil))
+	deps, err := loader.Consume(nil)
+	require.NoError(t, err)
+	assert.Len(t, deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges).Changes, 2)
+	files := map[core.FileId]string{}
+	resolver.ForEachFile(func(id core.FileId, name string) {
+		files[id] = name
+	})
+	assert.Equal(t, map[core.FileId]string{0: "a.go", 1: "b.go"}, files)
+	_, err = loader.Consume(nil)
+	require.NoError(t, err)
+	deps, err = loader.Consume(nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deps[items.DependencyTick])
+	assert.Equal(t, 1, deps[identity.DependencyAuthor])
+	files = map[core.FileId]string{}
+	resolver.ForEachFile(func(id core.FileId, name string) {
+		files[id] = name
+	})
+	assert.Len(t, files, 0)
+}
@@ -103,6 +103,11 @@ func (analyser *LineDumper) Flag() string {
 	return "linedump"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (analyser *LineDumper) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (analyser *LineDumper) Description() string {
 	return "Dumps raw history of line changes by authors"
@@ -213,9 +218,13 @@ func (analyser *LineDumper) serializeText(result LineDumperResult, writer io.Wri
 		hash := commit.CommitHash.String()
 		_, _ = fmt.Fprintf(writer, "    %s: |-\n", hash)
 		for _, change := range commit.Changes {
-			_, _ = fmt.Fprintf(writer, "      %d %6d %5d %6d %5d %d\n", change.FileId,
+			_, _ = fmt.Fprintf(writer, "      %d %6d %5d %6d %5d %d", change.FileId,
 				change.PrevAuthor, change.PrevTick,
 				change.CurrAuthor, change.CurrTick, change.Delta)
+			if change.Kind != core.LineKindCode {
+				_, _ = fmt.Fprintf(writer, " %d", change.Kind)
+			}
+			_, _ = fmt.Fprintln(writer)
 		}
 	}
 
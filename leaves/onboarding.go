@@ -12,6 +12,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/gogo/protobuf/proto"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
 	"github.com/meko-christian/hercules/internal/pb"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
@@ -32,6 +33,9 @@ type onboardingTickMetrics struct {
 	MeaningfulLinesAdded   int
 	MeaningfulLinesRemoved int
 	MeaningfulLinesChanged int
+
+	// Touched maps the other authors to the number of their lines changed or removed
+	Touched map[int]int
 }
 
 // OnboardingSnapshot captures metrics at a specific milestone
@@ -56,6 +60,16 @@ type AuthorOnboardingData struct {
 
 	// Indexed by window days (e.g., 7, 30, 90)
 	Snapshots map[int]*OnboardingSnapshot
+
+	// Mentors maps the existing authors to the number of their lines which this author
+	// changed or removed in the first MentorshipDays days
+	Mentors map[int]int
+	// DirectoriesReached is the number of distinct directories touched in the first MentorshipDays days
+	DirectoriesReached int
+	// FirstTouchTick is the tick of the first change of the lines written by another author, -1 if none
+	FirstTouchTick int
+	// LastActivityTick is the tick of the last commit
+	LastActivityTick int
 }
 
 // CohortStats contains aggregated statistics for a cohort
@@ -65,6 +79,17 @@ type CohortStats struct {
 
 	// Averaged across all authors in cohort
 	AverageSnapshots map[int]*OnboardingSnapshot
+
+	// Indexed by retention months (e.g., 3, 6, 12)
+	Retention map[int]*CohortRetention
+}
+
+// CohortRetention is the share of a cohort's authors still active some months after their first commit.
+// The authors who joined less than that many months before the end of the history are not eligible.
+type CohortRetention struct {
+	Eligible int
+	Retained int
+	Fraction float32
 }
 
 // OnboardingResult is returned by OnboardingAnalysis.Finalize()
@@ -73,6 +98,8 @@ type OnboardingResult struct {
 	Cohorts             map[string]*CohortStats
 	WindowDays          []int
 	MeaningfulThreshold int
+	MentorshipDays      int
+	RetentionMonths     []int
 	reversedPeopleDict  []string
 	tickSize            time.Duration
 }
@@ -86,9 +113,15 @@ type OnboardingAnalysis struct {
 	WindowDays []int
 	// MeaningfulThreshold is minimum lines for "meaningful" commit
 	MeaningfulThreshold int
+	// MentorshipDays is the number of days since the first commit to collect the mentors and the directories
+	MentorshipDays int
+	// RetentionMonths specifies the milestone months for the cohort retention (e.g., [3, 6, 12])
+	RetentionMonths []int
 
 	// author -> tick -> metrics
-	authorTimeline     map[int]map[int]*onboardingTickMetrics
+	authorTimeline map[int]map[int]*onboardingTickMetrics
+	// lastTick is the latest tick of the history
+	lastTick           int
 	reversedPeopleDict []string
 	tickSize           time.Duration
 
@@ -100,6 +133,10 @@ const (
 	ConfigOnboardingWindows = "Onboarding.Windows"
 	// ConfigOnboardingMeaningfulThreshold is the name of the option to set OnboardingAnalysis.MeaningfulThreshold
 	ConfigOnboardingMeaningfulThreshold = "Onboarding.MeaningfulThreshold"
+	// ConfigOnboardingMentorshipDays is the name of the option to set OnboardingAnalysis.MentorshipDays
+	ConfigOnboardingMentorshipDays = "Onboarding.MentorshipDays"
+	// ConfigOnboardingRetentionMonths is the name of the option to set OnboardingAnalysis.RetentionMonths
+	ConfigOnboardingRetentionMonths = "Onboarding.RetentionMonths"
 )
 
 // Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
@@ -119,6 +156,7 @@ func (oa *OnboardingAnalysis) Requires() []string {
 		items.DependencyTick,
 		items.DependencyLineStats,
 		items.DependencyTreeChanges,
+		linehistory.DependencyLineHistory,
 	}
 }
 
@@ -139,6 +177,20 @@ func (oa *OnboardingAnalysis) ListConfigurationOptions() []core.ConfigurationOpt
 			Type:        core.IntConfigurationOption,
 			Default:     10,
 		},
+		{
+			Name:        ConfigOnboardingMentorshipDays,
+			Description: "Number of days since the first commit to collect whose lines each newcomer changed and which directories they reached.",
+			Flag:        "onboarding-mentorship-days",
+			Type:        core.IntConfigurationOption,
+			Default:     30,
+		},
+		{
+			Name:        ConfigOnboardingRetentionMonths,
+			Description: "Comma-separated list of months since the first commit to measure the cohort retention (e.g., '3,6,12').",
+			Flag:        "onboarding-retention-months",
+			Type:        core.StringConfigurationOption,
+			Default:     "3,6,12",
+		},
 	}
 	return options[:]
 }
@@ -149,25 +201,28 @@ func (oa *OnboardingAnalysis) Configure(facts map[string]interface{}) error {
 		oa.l = l
 	}
 	if val, exists := facts[ConfigOnboardingWindows].(string); exists {
-		// Parse comma-separated window days
-		parts := strings.Split(val, ",")
-		oa.WindowDays = make([]int, 0, len(parts))
-		for _, p := range parts {
-			p = strings.TrimSpace(p)
-			if p == "" {
-				continue
-			}
-			days, err := strconv.Atoi(p)
-			if err != nil {
-				return fmt.Errorf("invalid window days value '%s': %w", p, err)
-			}
-			oa.WindowDays = append(oa.WindowDays, days)
+		days, err := parseOnboardingMilestones(val, "window days")
+		if err != nil {
+			return err
 		}
-		sort.Ints(oa.WindowDays)
+		oa.WindowDays = days
+	}
+	if val, exists := facts[ConfigOnboardingRetentionMonths].(string); exists {
+		months, err := parseOnboardingMilestones(val, "retention months")
+		if err != nil {
+			return err
+		}
+		oa.RetentionMonths = months
 	}
 	if val, exists := facts[ConfigOnboardingMeaningfulThreshold].(int); exists {
 		oa.MeaningfulThreshold = val
 	}
+	if val, exists := facts[ConfigOnboardingMentorshipDays].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--onboarding-mentorship-days must not be negative, got %d", val)
+		}
+		oa.MentorshipDays = val
+	}
 	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		oa.reversedPeopleDict = val
 	}
@@ -177,6 +232,25 @@ func (oa *OnboardingAnalysis) Configure(facts map[string]interface{}) error {
 	return nil
 }
 
+// parseOnboardingMilestones parses the comma-separated list of numbers and sorts it.
+func parseOnboardingMilestones(val, what string) ([]int, error) {
+	parts := strings.Split(val, ",")
+	milestones := make([]int, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		milestone, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value '%s': %w", what, p, err)
+		}
+		milestones = append(milestones, milestone)
+	}
+	sort.Ints(milestones)
+	return milestones, nil
+}
+
 // ConfigureUpstream configures the upstream dependencies.
 func (*OnboardingAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
 	return nil
@@ -187,6 +261,11 @@ func (oa *OnboardingAnalysis) Flag() string {
 	return "onboarding"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (oa *OnboardingAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (oa *OnboardingAnalysis) Description() string {
 	return "Measures how quickly new contributors ramp up: time-to-first-change, breadth-of-files in first N days, convergence to stable contribution patterns."
@@ -196,6 +275,7 @@ func (oa *OnboardingAnalysis) Description() string {
 func (oa *OnboardingAnalysis) Initialize(repository *git.Repository) error {
 	oa.l = core.NewLogger()
 	oa.authorTimeline = map[int]map[int]*onboardingTickMetrics{}
+	oa.lastTick = 0
 	oa.OneShotMergeProcessor.Initialize()
 
 	// Set defaults if not configured
@@ -205,6 +285,12 @@ func (oa *OnboardingAnalysis) Initialize(repository *git.Repository) error {
 	if oa.MeaningfulThreshold == 0 {
 		oa.MeaningfulThreshold = 10
 	}
+	if oa.MentorshipDays == 0 {
+		oa.MentorshipDays = 30
+	}
+	if len(oa.RetentionMonths) == 0 {
+		oa.RetentionMonths = []int{3, 6, 12}
+	}
 
 	return nil
 }
@@ -222,6 +308,7 @@ func (oa *OnboardingAnalysis) getOrCreateTickMetrics(author, tick int) *onboardi
 		metrics = &onboardingTickMetrics{
 			Files:           map[string]bool{},
 			MeaningfulFiles: map[string]bool{},
+			Touched:         map[int]int{},
 		}
 		timeline[tick] = metrics
 	}
@@ -239,6 +326,9 @@ func (oa *OnboardingAnalysis) Consume(deps map[string]interface{}) (map[string]i
 	tick := deps[items.DependencyTick].(int)
 	treeChanges := deps[items.DependencyTreeChanges].(object.Changes)
 	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	if tick > oa.lastTick {
+		oa.lastTick = tick
+	}
 
 	if len(treeChanges) == 0 {
 		return nil, nil
@@ -273,6 +363,17 @@ func (oa *OnboardingAnalysis) Consume(deps map[string]interface{}) (map[string]i
 		metrics.MeaningfulCommits++
 	}
 
+	// The lines of the other authors which this commit changed or removed
+	if lineHistory, exists := deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges); exists {
+		for _, change := range lineHistory.Changes {
+			if change.IsDelete() || change.Delta >= 0 || change.PrevAuthor == core.AuthorMissing ||
+				int(change.PrevAuthor) == author {
+				continue
+			}
+			metrics.Touched[int(change.PrevAuthor)] -= change.Delta
+		}
+	}
+
 	return nil, nil
 }
 
@@ -405,11 +506,33 @@ func (oa *OnboardingAnalysis) Finalize() interface{} {
 			}
 		}
 
-		authors[authorID] = &AuthorOnboardingData{
-			FirstCommitTick: firstTick,
-			JoinCohort:      joinCohort,
-			Snapshots:       snapshots,
+		authorData := &AuthorOnboardingData{
+			FirstCommitTick:  firstTick,
+			JoinCohort:       joinCohort,
+			Snapshots:        snapshots,
+			Mentors:          map[int]int{},
+			FirstTouchTick:   -1,
+			LastActivityTick: sortedTicks[len(sortedTicks)-1],
 		}
+		mentorshipTick := firstTick + oa.MentorshipDays*ticksPerDay
+		directories := map[string]bool{}
+		for _, tick := range sortedTicks {
+			tickMetrics := timeline[tick]
+			if len(tickMetrics.Touched) > 0 && authorData.FirstTouchTick < 0 {
+				authorData.FirstTouchTick = tick
+			}
+			if tick > mentorshipTick {
+				continue
+			}
+			for mentor, lines := range tickMetrics.Touched {
+				authorData.Mentors[mentor] += lines
+			}
+			for file := range tickMetrics.Files {
+				directories[subsystemOf(file)] = true
+			}
+		}
+		authorData.DirectoriesReached = len(directories)
+		authors[authorID] = authorData
 
 		cohortGroups[joinCohort] = append(cohortGroups[joinCohort], authorID)
 	}
@@ -473,6 +596,7 @@ func (oa *OnboardingAnalysis) finalizeCohorts(
 			Cohort:           cohort,
 			AuthorCount:      authorCount,
 			AverageSnapshots: averageSnapshots,
+			Retention:        oa.cohortRetention(authors, authorIDs),
 		}
 	}
 
@@ -481,11 +605,63 @@ func (oa *OnboardingAnalysis) finalizeCohorts(
 		Cohorts:             cohorts,
 		WindowDays:          oa.WindowDays,
 		MeaningfulThreshold: oa.MeaningfulThreshold,
+		MentorshipDays:      oa.MentorshipDays,
+		RetentionMonths:     oa.RetentionMonths,
 		reversedPeopleDict:  oa.reversedPeopleDict,
 		tickSize:            oa.tickSize,
 	}
 }
 
+// monthTicks returns the number of ticks in the specified number of average months.
+func (oa *OnboardingAnalysis) monthTicks(months int) int {
+	// Average month ≈ 30.44 days
+	monthDuration := time.Duration(float64(30.44*24) * float64(time.Hour))
+	return int(time.Duration(months) * monthDuration / oa.tickSize)
+}
+
+// cohortRetention computes the share of the cohort's authors active at each of RetentionMonths.
+// An author is active if they committed at or after that many months since their first commit.
+func (oa *OnboardingAnalysis) cohortRetention(
+	authors map[int]*AuthorOnboardingData, authorIDs []int,
+) map[int]*CohortRetention {
+	retention := make(map[int]*CohortRetention, len(oa.RetentionMonths))
+	for _, months := range oa.RetentionMonths {
+		ticks := oa.monthTicks(months)
+		stats := &CohortRetention{}
+		for _, authorID := range authorIDs {
+			author := authors[authorID]
+			milestone := author.FirstCommitTick + ticks
+			if milestone > oa.lastTick {
+				continue // not observed for long enough
+			}
+			stats.Eligible++
+			if author.LastActivityTick >= milestone {
+				stats.Retained++
+			}
+		}
+		if stats.Eligible > 0 {
+			stats.Fraction = float32(stats.Retained) / float32(stats.Eligible)
+		}
+		retention[months] = stats
+	}
+	return retention
+}
+
+// sortedMentors returns the mentors and their line counts, the most touched first.
+func sortedMentors(mentors map[int]int) [][2]int {
+	result := make([][2]int, 0, len(mentors))
+	for mentor, lines := range mentors {
+		result = append(result, [2]int{mentor, lines})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i][1] != result[j][1] {
+			return result[i][1] > result[j][1]
+		}
+		return result[i][0] < result[j][0]
+	})
+	return result
+}
+
 // Fork clones this pipeline item.
 func (oa *OnboardingAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(oa, n)
@@ -502,6 +678,12 @@ func (oa *OnboardingAnalysis) serializeText(result *OnboardingResult, writer io.
 	}
 	fmt.Fprintf(writer, "    window_days: [%s]\n", strings.Join(windowStrs, ", "))
 	fmt.Fprintf(writer, "    meaningful_threshold: %d\n", result.MeaningfulThreshold)
+	fmt.Fprintf(writer, "    mentorship_days: %d\n", result.MentorshipDays)
+	monthStrs := make([]string, len(result.RetentionMonths))
+	for i, months := range result.RetentionMonths {
+		monthStrs[i] = strconv.Itoa(months)
+	}
+	fmt.Fprintf(writer, "    retention_months: [%s]\n", strings.Join(monthStrs, ", "))
 
 	// Authors (sorted by ID)
 	authorIDs := make([]int, 0, len(result.Authors))
@@ -519,6 +701,15 @@ func (oa *OnboardingAnalysis) serializeText(result *OnboardingResult, writer io.
 		fmt.Fprintf(writer, "      %d:\n", authorID)
 		fmt.Fprintf(writer, "        first_commit_tick: %d\n", author.FirstCommitTick)
 		fmt.Fprintf(writer, "        join_cohort: %s\n", yaml.SafeString(author.JoinCohort))
+		fmt.Fprintf(writer, "        last_activity_tick: %d\n", author.LastActivityTick)
+		fmt.Fprintf(writer, "        first_touch_tick: %d\n", author.FirstTouchTick)
+		fmt.Fprintf(writer, "        directories_reached: %d\n", author.DirectoriesReached)
+		mentors := sortedMentors(author.Mentors)
+		edges := make([]string, len(mentors))
+		for i, mentor := range mentors {
+			edges[i] = fmt.Sprintf("[%d, %d]", mentor[0], mentor[1])
+		}
+		fmt.Fprintf(writer, "        mentors: [%s]\n", strings.Join(edges, ", "))
 
 		// Snapshots (sorted by days)
 		windowDays := make([]int, 0, len(author.Snapshots))
@@ -563,6 +754,20 @@ func (oa *OnboardingAnalysis) serializeText(result *OnboardingResult, writer io.
 				days, snap.DaysSinceJoin, snap.TotalCommits, snap.TotalFiles, snap.TotalLines,
 				snap.MeaningfulCommits, snap.MeaningfulFiles, snap.MeaningfulLines)
 		}
+
+		// Retention (sorted by months)
+		months := make([]int, 0, len(cohort.Retention))
+		for m := range cohort.Retention {
+			months = append(months, m)
+		}
+		sort.Ints(months)
+
+		fmt.Fprintln(writer, "        retention:")
+		for _, m := range months {
+			stats := cohort.Retention[m]
+			fmt.Fprintf(writer, "          %d: {eligible: %d, retained: %d, fraction: %.4f}\n",
+				m, stats.Eligible, stats.Retained, stats.Fraction)
+		}
 	}
 
 	// People
@@ -581,11 +786,16 @@ func (oa *OnboardingAnalysis) serializeBinary(result *OnboardingResult, writer i
 		TickSize:            int64(result.tickSize),
 		WindowDays:          make([]int32, len(result.WindowDays)),
 		MeaningfulThreshold: int32(result.MeaningfulThreshold),
+		MentorshipDays:      int32(result.MentorshipDays),
+		RetentionMonths:     make([]int32, len(result.RetentionMonths)),
 	}
 
 	for i, days := range result.WindowDays {
 		message.WindowDays[i] = int32(days)
 	}
+	for i, months := range result.RetentionMonths {
+		message.RetentionMonths[i] = int32(months)
+	}
 
 	// Authors
 	message.Authors = make(map[int32]*pb.AuthorOnboardingData, len(result.Authors))
@@ -595,9 +805,16 @@ func (oa *OnboardingAnalysis) serializeBinary(result *OnboardingResult, writer i
 		}
 
 		pbAuthor := &pb.AuthorOnboardingData{
-			FirstCommitTick: int32(author.FirstCommitTick),
-			JoinCohort:      author.JoinCohort,
-			Snapshots:       make(map[int32]*pb.OnboardingSnapshot, len(author.Snapshots)),
+			FirstCommitTick:    int32(author.FirstCommitTick),
+			JoinCohort:         author.JoinCohort,
+			Snapshots:          make(map[int32]*pb.OnboardingSnapshot, len(author.Snapshots)),
+			Mentors:            make(map[int32]int32, len(author.Mentors)),
+			DirectoriesReached: int32(author.DirectoriesReached),
+			FirstTouchTick:     int32(author.FirstTouchTick),
+			LastActivityTick:   int32(author.LastActivityTick),
+		}
+		for mentor, lines := range author.Mentors {
+			pbAuthor.Mentors[int32(mentor)] = int32(lines)
 		}
 
 		for days, snap := range author.Snapshots {
@@ -622,6 +839,15 @@ func (oa *OnboardingAnalysis) serializeBinary(result *OnboardingResult, writer i
 			Cohort:           cohort.Cohort,
 			AuthorCount:      int32(cohort.AuthorCount),
 			AverageSnapshots: make(map[int32]*pb.OnboardingAverageSnapshot, len(cohort.AverageSnapshots)),
+			Retention:        make(map[int32]*pb.CohortRetention, len(cohort.Retention)),
+		}
+
+		for months, stats := range cohort.Retention {
+			pbCohort.Retention[int32(months)] = &pb.CohortRetention{
+				Eligible: int32(stats.Eligible),
+				Retained: int32(stats.Retained),
+				Fraction: stats.Fraction,
+			}
 		}
 
 		for days, snap := range cohort.AverageSnapshots {
@@ -670,6 +896,8 @@ func (oa *OnboardingAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 		Cohorts:             make(map[string]*CohortStats, len(message.Cohorts)),
 		WindowDays:          make([]int, len(message.WindowDays)),
 		MeaningfulThreshold: int(message.MeaningfulThreshold),
+		MentorshipDays:      int(message.MentorshipDays),
+		RetentionMonths:     make([]int, len(message.RetentionMonths)),
 		reversedPeopleDict:  message.DevIndex,
 		tickSize:            time.Duration(message.TickSize),
 	}
@@ -677,6 +905,9 @@ func (oa *OnboardingAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 	for i, days := range message.WindowDays {
 		result.WindowDays[i] = int(days)
 	}
+	for i, months := range message.RetentionMonths {
+		result.RetentionMonths[i] = int(months)
+	}
 
 	// Authors
 	for authorID, pbAuthor := range message.Authors {
@@ -685,20 +916,27 @@ func (oa *OnboardingAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 		}
 
 		author := &AuthorOnboardingData{
-			FirstCommitTick: int(pbAuthor.FirstCommitTick),
-			JoinCohort:      pbAuthor.JoinCohort,
-			Snapshots:       make(map[int]*OnboardingSnapshot, len(pbAuthor.Snapshots)),
+			FirstCommitTick:    int(pbAuthor.GetFirstCommitTick()),
+			JoinCohort:         pbAuthor.GetJoinCohort(),
+			Snapshots:          make(map[int]*OnboardingSnapshot, len(pbAuthor.GetSnapshots())),
+			Mentors:            make(map[int]int, len(pbAuthor.GetMentors())),
+			DirectoriesReached: int(pbAuthor.GetDirectoriesReached()),
+			FirstTouchTick:     int(pbAuthor.GetFirstTouchTick()),
+			LastActivityTick:   int(pbAuthor.GetLastActivityTick()),
+		}
+		for mentor, lines := range pbAuthor.GetMentors() {
+			author.Mentors[int(mentor)] = int(lines)
 		}
 
-		for days, pbSnap := range pbAuthor.Snapshots {
+		for days, pbSnap := range pbAuthor.GetSnapshots() {
 			author.Snapshots[int(days)] = &OnboardingSnapshot{
-				DaysSinceJoin:     int(pbSnap.DaysSinceJoin),
-				TotalCommits:      int(pbSnap.TotalCommits),
-				TotalFiles:        int(pbSnap.TotalFiles),
-				TotalLines:        int(pbSnap.TotalLines),
-				MeaningfulCommits: int(pbSnap.MeaningfulCommits),
-				MeaningfulFiles:   int(pbSnap.MeaningfulFiles),
-				MeaningfulLines:   int(pbSnap.MeaningfulLines),
+				DaysSinceJoin:     int(pbSnap.GetDaysSinceJoin()),
+				TotalCommits:      int(pbSnap.GetTotalCommits()),
+				TotalFiles:        int(pbSnap.GetTotalFiles()),
+				TotalLines:        int(pbSnap.GetTotalLines()),
+				MeaningfulCommits: int(pbSnap.GetMeaningfulCommits()),
+				MeaningfulFiles:   int(pbSnap.GetMeaningfulFiles()),
+				MeaningfulLines:   int(pbSnap.GetMeaningfulLines()),
 			}
 		}
 
@@ -708,20 +946,29 @@ func (oa *OnboardingAnalysis) Deserialize(pbmessage []byte) (interface{}, error)
 	// Cohorts
 	for cohortName, pbCohort := range message.Cohorts {
 		cohort := &CohortStats{
-			Cohort:           pbCohort.Cohort,
-			AuthorCount:      int(pbCohort.AuthorCount),
-			AverageSnapshots: make(map[int]*OnboardingSnapshot, len(pbCohort.AverageSnapshots)),
+			Cohort:           pbCohort.GetCohort(),
+			AuthorCount:      int(pbCohort.GetAuthorCount()),
+			AverageSnapshots: make(map[int]*OnboardingSnapshot, len(pbCohort.GetAverageSnapshots())),
+			Retention:        make(map[int]*CohortRetention, len(pbCohort.GetRetention())),
+		}
+
+		for months, pbStats := range pbCohort.GetRetention() {
+			cohort.Retention[int(months)] = &CohortRetention{
+				Eligible: int(pbStats.GetEligible()),
+				Retained: int(pbStats.GetRetained()),
+				Fraction: pbStats.GetFraction(),
+			}
 		}
 
-		for days, pbSnap := range pbCohort.AverageSnapshots {
+		for days, pbSnap := range pbCohort.GetAverageSnapshots() {
 			cohort.AverageSnapshots[int(days)] = &OnboardingSnapshot{
-				DaysSinceJoin:     int(pbSnap.DaysSinceJoin),
-				TotalCommits:      int(pbSnap.AvgTotalCommits),
-				TotalFiles:        int(pbSnap.AvgTotalFiles),
-				TotalLines:        int(pbSnap.AvgTotalLines),
-				MeaningfulCommits: int(pbSnap.AvgMeaningfulCommits),
-				MeaningfulFiles:   int(pbSnap.AvgMeaningfulFiles),
-				MeaningfulLines:   int(pbSnap.AvgMeaningfulLines),
+				DaysSinceJoin:     int(pbSnap.GetDaysSinceJoin()),
+				TotalCommits:      int(pbSnap.GetAvgTotalCommits()),
+				TotalFiles:        int(pbSnap.GetAvgTotalFiles()),
+				TotalLines:        int(pbSnap.GetAvgTotalLines()),
+				MeaningfulCommits: int(pbSnap.GetAvgMeaningfulCommits()),
+				MeaningfulFiles:   int(pbSnap.GetAvgMeaningfulFiles()),
+				MeaningfulLines:   int(pbSnap.GetAvgMeaningfulLines()),
 			}
 		}
 
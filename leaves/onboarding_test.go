@@ -9,6 +9,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
 	items "github.com/meko-christian/hercules/internal/plumbing"
 	"github.com/meko-christian/hercules/internal/plumbing/identity"
 	"github.com/meko-christian/hercules/internal/test"
@@ -425,3 +426,109 @@ func TestOnboardingAnalysis_Serialization(t *testing.T) {
 		}
 	})
 }
+
+func TestOnboardingAnalysis_Mentorship(t *testing.T) {
+	oa := &OnboardingAnalysis{tickSize: 24 * time.Hour}
+	require.NoError(t, oa.Configure(map[string]interface{}{ConfigOnboardingMentorshipDays: 10}))
+	assert.Error(t, oa.Configure(map[string]interface{}{ConfigOnboardingMentorshipDays: -1}))
+	require.NoError(t, oa.Initialize(test.Repository))
+	assert.Equal(t, 10, oa.MentorshipDays)
+	assert.Contains(t, oa.Requires(), linehistory.DependencyLineHistory)
+
+	consume := func(author, tick int, files map[string]int, changes ...core.LineHistoryChange) {
+		deps := makeTestDeps(author, tick, files)
+		deps[linehistory.DependencyLineHistory] = core.LineHistoryChanges{Changes: changes}
+		_, err := oa.Consume(deps)
+		require.NoError(t, err)
+	}
+	change := func(author, prevAuthor core.AuthorId, delta int) core.LineHistoryChange {
+		return core.LineHistoryChange{CurrAuthor: author, PrevAuthor: prevAuthor, Delta: delta}
+	}
+	consume(0, 0, map[string]int{"src/a.go": 20}, change(0, core.AuthorMissing, 20))
+	consume(2, 1, map[string]int{"README.md": 5}, change(2, core.AuthorMissing, 5))
+	// author 1 joins at tick 2 and touches the lines of 0 and 2, but not their own
+	consume(1, 2, map[string]int{"docs/b.md": 3}, change(1, core.AuthorMissing, 3))
+	consume(1, 4, map[string]int{"src/a.go": 4, "README.md": 1},
+		change(1, 0, -4), change(1, 1, 4), change(1, 2, -1), change(1, 1, -3))
+	consume(1, 9, map[string]int{"src/c/d.go": 2}, change(1, 0, -2),
+		core.NewLineHistoryDeletion(0, 1, 9))
+	// outside of the mentorship window
+	consume(1, 20, map[string]int{"lib/e.go": 1}, change(1, 2, -7))
+
+	result := oa.Finalize().(OnboardingResult)
+	assert.Equal(t, 10, result.MentorshipDays)
+	author := result.Authors[1]
+	assert.Equal(t, map[int]int{0: 6, 2: 1}, author.Mentors)
+	assert.Equal(t, 4, author.DirectoriesReached)
+	assert.Equal(t, 4, author.FirstTouchTick)
+	assert.Equal(t, -1, result.Authors[0].FirstTouchTick)
+	assert.Equal(t, map[int]int{}, result.Authors[0].Mentors)
+	assert.Equal(t, [][2]int{{0, 6}, {2, 1}}, sortedMentors(author.Mentors))
+
+	var buf bytes.Buffer
+	require.NoError(t, oa.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), "    mentorship_days: 10\n")
+	assert.Contains(t, buf.String(), "        first_touch_tick: 4\n        directories_reached: 4\n"+
+		"        mentors: [[0, 6], [2, 1]]\n")
+	assert.Contains(t, buf.String(), "        mentors: []\n")
+
+	buf.Reset()
+	require.NoError(t, oa.Serialize(result, true, &buf))
+	restored, err := oa.Deserialize(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result.MentorshipDays, restored.(OnboardingResult).MentorshipDays)
+	assert.Equal(t, author, restored.(OnboardingResult).Authors[1])
+}
+
+func TestOnboardingAnalysis_Retention(t *testing.T) {
+	oa := &OnboardingAnalysis{tickSize: 24 * time.Hour}
+	require.NoError(t, oa.Configure(map[string]interface{}{ConfigOnboardingRetentionMonths: "6, 3"}))
+	assert.Equal(t, []int{3, 6}, oa.RetentionMonths)
+	err := oa.Configure(map[string]interface{}{ConfigOnboardingRetentionMonths: "3,x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid retention months")
+	require.NoError(t, oa.Initialize(test.Repository))
+	assert.Equal(t, 91, oa.monthTicks(3))
+
+	consume := func(author, tick int) {
+		_, err := oa.Consume(makeTestDeps(author, tick, map[string]int{"a.go": 1}))
+		require.NoError(t, err)
+	}
+	// all the authors join in January 1970; 3 months are 91 ticks and 6 months are 182 ticks
+	consume(0, 0)
+	consume(1, 1)
+	consume(2, 10)
+	consume(1, 50)
+	consume(0, 95)
+	consume(2, 120)
+	// the history ends before author 3 can be observed for 3 months
+	consume(3, 30)
+	consume(0, 101)
+
+	result := oa.Finalize().(OnboardingResult)
+	assert.Equal(t, []int{3, 6}, result.RetentionMonths)
+	assert.Equal(t, 101, result.Authors[0].LastActivityTick)
+	assert.Equal(t, 50, result.Authors[1].LastActivityTick)
+	cohort := result.Cohorts["1970-01"]
+	require.NotNil(t, cohort)
+	assert.Equal(t, map[int]*CohortRetention{
+		3: {Eligible: 3, Retained: 2, Fraction: float32(2) / 3},
+		6: {},
+	}, cohort.Retention)
+
+	var buf bytes.Buffer
+	require.NoError(t, oa.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), "    retention_months: [3, 6]\n")
+	assert.Contains(t, buf.String(), "        last_activity_tick: 101\n")
+	assert.Contains(t, buf.String(), "        retention:\n"+
+		"          3: {eligible: 3, retained: 2, fraction: 0.6667}\n"+
+		"          6: {eligible: 0, retained: 0, fraction: 0.0000}\n")
+
+	buf.Reset()
+	require.NoError(t, oa.Serialize(result, true, &buf))
+	restored, err := oa.Deserialize(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result.RetentionMonths, restored.(OnboardingResult).RetentionMonths)
+	assert.Equal(t, cohort.Retention, restored.(OnboardingResult).Cohorts["1970-01"].Retention)
+	assert.Equal(t, 101, restored.(OnboardingResult).Authors[0].LastActivityTick)
+}
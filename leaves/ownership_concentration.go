@@ -34,6 +34,10 @@ type OwnershipConcentrationAnalysis struct {
 	peopleResolver core.IdentityResolver
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
 	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations.
+	organizations []string
+	// teams references IdentityDetector.Teams.
+	teams []string
 	// tickSize references TicksSinceStart.TickSize.
 	tickSize time.Duration
 	// snapshots stores per-tick concentration snapshots.
@@ -70,6 +74,10 @@ type OwnershipConcentrationResult struct {
 	SubsystemConcentration map[string]*SubsystemConcentration
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
 	reversedPeopleDict []string
+	// organizations references IdentityDetector.Organizations, it is empty unless enabled.
+	organizations []string
+	// teams references IdentityDetector.Teams, it is empty unless the identity provider sets it.
+	teams []string
 	// tickSize is the duration of each tick.
 	tickSize time.Duration
 }
@@ -106,6 +114,12 @@ func (oc *OwnershipConcentrationAnalysis) Configure(facts map[string]interface{}
 	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
 		oc.reversedPeopleDict = val
 	}
+	if val, exists := facts[identity.FactIdentityDetectorOrganizations].([]string); exists {
+		oc.organizations = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorTeams].([]string); exists {
+		oc.teams = val
+	}
 	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
 		oc.tickSize = val
 	}
@@ -125,6 +139,11 @@ func (oc *OwnershipConcentrationAnalysis) Flag() string {
 	return "ownership-concentration"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (oc *OwnershipConcentrationAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (oc *OwnershipConcentrationAnalysis) Description() string {
 	return "Computes Gini coefficient and HHI of code ownership concentration over time."
@@ -247,6 +266,64 @@ func computeHHI(authorLines map[int]int64, totalLines int64) float64 {
 	return hhi
 }
 
+// organizationLines sums the alive lines of the developers per organization.
+func (result *OwnershipConcentrationResult) organizationLines(authorLines map[int]int64) map[string]int64 {
+	orgLines := map[string]int64{}
+	for author, lines := range authorLines {
+		org := identity.OrganizationUnknown
+		if author >= 0 && author < len(result.organizations) && result.organizations[author] != "" {
+			org = result.organizations[author]
+		}
+		orgLines[org] += lines
+	}
+	return orgLines
+}
+
+// organizationConcentration computes Gini and HHI of the ownership by organizations
+// instead of by developers.
+func (result *OwnershipConcentrationResult) organizationConcentration(
+	snapshot *OwnershipConcentrationSnapshot,
+) (gini, hhi float64) {
+	return groupConcentration(result.organizationLines(snapshot.AuthorLines), snapshot.TotalLines)
+}
+
+// teamOf returns the team of the developer. The developers without a team form their own teams
+// named after them.
+func (result *OwnershipConcentrationResult) teamOf(author int) string {
+	if author >= 0 && author < len(result.teams) && result.teams[author] != "" {
+		return result.teams[author]
+	}
+	if author >= 0 && author < len(result.reversedPeopleDict) {
+		return core.IdentityLabel(result.reversedPeopleDict[author])
+	}
+	return fmt.Sprintf("#%d", author)
+}
+
+// teamLines sums the alive lines of the developers per team.
+func (result *OwnershipConcentrationResult) teamLines(authorLines map[int]int64) map[string]int64 {
+	teamLines := map[string]int64{}
+	for author, lines := range authorLines {
+		teamLines[result.teamOf(author)] += lines
+	}
+	return teamLines
+}
+
+// teamConcentration computes Gini and HHI of the ownership by teams instead of by developers.
+func (result *OwnershipConcentrationResult) teamConcentration(
+	snapshot *OwnershipConcentrationSnapshot,
+) (gini, hhi float64) {
+	return groupConcentration(result.teamLines(snapshot.AuthorLines), snapshot.TotalLines)
+}
+
+// groupConcentration computes Gini and HHI of the lines owned by the groups of developers.
+func groupConcentration(groupLines map[string]int64, totalLines int64) (gini, hhi float64) {
+	indexed := make(map[int]int64, len(groupLines))
+	for _, lines := range groupLines {
+		indexed[len(indexed)] = lines
+	}
+	return computeGini(indexed, totalLines), computeHHI(indexed, totalLines)
+}
+
 // computeSubsystemConcentration computes Gini and HHI per directory prefix at the final tick.
 func (oc *OwnershipConcentrationAnalysis) computeSubsystemConcentration() map[string]*SubsystemConcentration {
 	if oc.fileResolver == nil {
@@ -307,6 +384,8 @@ func (oc *OwnershipConcentrationAnalysis) Finalize() interface{} {
 		Snapshots:              oc.snapshots,
 		SubsystemConcentration: oc.computeSubsystemConcentration(),
 		reversedPeopleDict:     oc.reversedPeopleDict,
+		organizations:          oc.organizations,
+		teams:                  oc.teams,
 		tickSize:               oc.tickSize,
 	}
 }
@@ -336,8 +415,8 @@ func (oc *OwnershipConcentrationAnalysis) Deserialize(pbmessage []byte) (interfa
 
 	snapshots := make(map[int]*OwnershipConcentrationSnapshot, len(message.Snapshots))
 	for tick, pbSnapshot := range message.Snapshots {
-		authorLines := make(map[int]int64, len(pbSnapshot.AuthorLines))
-		for authorID, lines := range pbSnapshot.AuthorLines {
+		authorLines := make(map[int]int64, len(pbSnapshot.GetAuthorLines()))
+		for authorID, lines := range pbSnapshot.GetAuthorLines() {
 			dev := int(authorID)
 			if authorID == -1 {
 				dev = core.AuthorMissing
@@ -345,9 +424,9 @@ func (oc *OwnershipConcentrationAnalysis) Deserialize(pbmessage []byte) (interfa
 			authorLines[dev] = lines
 		}
 		snapshots[int(tick)] = &OwnershipConcentrationSnapshot{
-			Gini:        pbSnapshot.Gini,
-			HHI:         pbSnapshot.Hhi,
-			TotalLines:  pbSnapshot.TotalLines,
+			Gini:        pbSnapshot.GetGini(),
+			HHI:         pbSnapshot.GetHhi(),
+			TotalLines:  pbSnapshot.GetTotalLines(),
 			AuthorLines: authorLines,
 		}
 	}
@@ -364,6 +443,8 @@ func (oc *OwnershipConcentrationAnalysis) Deserialize(pbmessage []byte) (interfa
 		Snapshots:              snapshots,
 		SubsystemConcentration: subsystemConc,
 		reversedPeopleDict:     message.DevIndex,
+		organizations:          message.Organizations,
+		teams:                  message.Teams,
 		tickSize:               time.Duration(message.TickSize),
 	}
 	return result, nil
@@ -381,8 +462,17 @@ func (oc *OwnershipConcentrationAnalysis) serializeText(result *OwnershipConcent
 	fmt.Fprintln(writer, "    per_tick:")
 	for _, tick := range ticks {
 		snapshot := result.Snapshots[tick]
-		fmt.Fprintf(writer, "      %d: {gini: %.4f, hhi: %.4f, total_lines: %d}\n",
+		fmt.Fprintf(writer, "      %d: {gini: %.4f, hhi: %.4f, total_lines: %d",
 			tick, snapshot.Gini, snapshot.HHI, snapshot.TotalLines)
+		if len(result.organizations) > 0 {
+			orgGini, orgHHI := result.organizationConcentration(snapshot)
+			fmt.Fprintf(writer, ", org_gini: %.4f, org_hhi: %.4f", orgGini, orgHHI)
+		}
+		if len(result.teams) > 0 {
+			teamGini, teamHHI := result.teamConcentration(snapshot)
+			fmt.Fprintf(writer, ", team_gini: %.4f, team_hhi: %.4f", teamGini, teamHHI)
+		}
+		fmt.Fprintln(writer, "}")
 	}
 
 	if len(result.SubsystemConcentration) > 0 {
@@ -398,17 +488,60 @@ func (oc *OwnershipConcentrationAnalysis) serializeText(result *OwnershipConcent
 		}
 	}
 
+	if len(result.organizations) > 0 && len(ticks) > 0 {
+		snapshot := result.Snapshots[ticks[len(ticks)-1]]
+		fmt.Fprintln(writer, "    per_organization:")
+		writeGroupShares(writer, result.organizationLines(snapshot.AuthorLines), snapshot.TotalLines)
+	}
+	if len(result.teams) > 0 && len(ticks) > 0 {
+		snapshot := result.Snapshots[ticks[len(ticks)-1]]
+		fmt.Fprintln(writer, "    per_team:")
+		writeGroupShares(writer, result.teamLines(snapshot.AuthorLines), snapshot.TotalLines)
+	}
+
 	fmt.Fprintln(writer, "    people:")
 	for _, person := range result.reversedPeopleDict {
 		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
 	}
+	if len(result.organizations) > 0 {
+		fmt.Fprintln(writer, "    organizations:")
+		for _, org := range result.organizations {
+			fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(org))
+		}
+	}
+	if len(result.teams) > 0 {
+		fmt.Fprintln(writer, "    teams:")
+		for _, team := range result.teams {
+			fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(team))
+		}
+	}
 	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
 }
 
+// writeGroupShares writes the alive lines and their shares of the groups of developers sorted
+// by the group name.
+func writeGroupShares(writer io.Writer, groupLines map[string]int64, totalLines int64) {
+	groups := make([]string, 0, len(groupLines))
+	for group := range groupLines {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		share := 0.0
+		if totalLines > 0 {
+			share = float64(groupLines[group]) / float64(totalLines)
+		}
+		fmt.Fprintf(writer, "      %s: {lines: %d, share: %.4f}\n",
+			yaml.SafeString(group), groupLines[group], share)
+	}
+}
+
 func (oc *OwnershipConcentrationAnalysis) serializeBinary(result *OwnershipConcentrationResult, writer io.Writer) error {
 	message := pb.OwnershipConcentrationResults{
-		DevIndex: result.reversedPeopleDict,
-		TickSize: int64(result.tickSize),
+		DevIndex:      result.reversedPeopleDict,
+		Organizations: result.organizations,
+		Teams:         result.teams,
+		TickSize:      int64(result.tickSize),
 	}
 
 	message.Snapshots = make(map[int32]*pb.OwnershipConcentrationTickSnapshot, len(result.Snapshots))
@@ -455,8 +588,16 @@ func (oc *OwnershipConcentrationAnalysis) MergeResults(
 		Snapshots:              make(map[int]*OwnershipConcentrationSnapshot),
 		SubsystemConcentration: make(map[string]*SubsystemConcentration),
 		reversedPeopleDict:     ocr1.reversedPeopleDict,
+		organizations:          ocr1.organizations,
+		teams:                  ocr1.teams,
 		tickSize:               ocr1.tickSize,
 	}
+	if len(merged.organizations) == 0 {
+		merged.organizations = ocr2.organizations
+	}
+	if len(merged.teams) == 0 {
+		merged.teams = ocr2.teams
+	}
 
 	// Merge snapshots: take the snapshot with the larger total lines for overlapping ticks
 	for tick, snapshot := range ocr1.Snapshots {
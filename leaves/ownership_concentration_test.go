@@ -344,3 +344,76 @@ func TestOwnershipConcentrationMergeResults(t *testing.T) {
 	assert.InDelta(t, 0.3, merged.SubsystemConcentration["src"].Gini, 0.001)
 	assert.InDelta(t, 0.0, merged.SubsystemConcentration["docs"].Gini, 0.001)
 }
+
+func TestOwnershipConcentrationOrganizations(t *testing.T) {
+	oc := OwnershipConcentrationAnalysis{}
+	assert.Nil(t, oc.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorOrganizations: []string{"acme.com", "acme.com", "other.org"},
+	}))
+	assert.Equal(t, []string{"acme.com", "acme.com", "other.org"}, oc.organizations)
+	result := OwnershipConcentrationResult{
+		Snapshots: map[int]*OwnershipConcentrationSnapshot{
+			5: {Gini: 0.25, HHI: 0.38, TotalLines: 200, AuthorLines: map[int]int64{0: 50, 1: 50, 2: 100}},
+		},
+		reversedPeopleDict: []string{"Alice", "Bob", "Charlie"},
+		organizations:      oc.organizations,
+		tickSize:           24 * time.Hour,
+	}
+	gini, hhi := result.organizationConcentration(result.Snapshots[5])
+	assert.InDelta(t, 0.0, gini, 0.001)
+	assert.InDelta(t, 0.5, hhi, 0.001)
+
+	var buf bytes.Buffer
+	assert.Nil(t, oc.Serialize(result, false, &buf))
+	output := buf.String()
+	assert.Contains(t, output, "org_gini: 0.0000, org_hhi: 0.5000}")
+	assert.Contains(t, output, "    per_organization:\n      \"acme.com\": {lines: 100, share: 0.5000}\n")
+	assert.Contains(t, output, "    organizations:\n    - \"acme.com\"\n")
+
+	buf.Reset()
+	assert.Nil(t, oc.Serialize(result, true, &buf))
+	result2, err := oc.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.organizations, result2.(OwnershipConcentrationResult).organizations)
+}
+
+func TestOwnershipConcentrationTeams(t *testing.T) {
+	oc := OwnershipConcentrationAnalysis{}
+	assert.Nil(t, oc.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorTeams: []string{"core", "core", "", "docs"},
+	}))
+	assert.Equal(t, []string{"core", "core", "", "docs"}, oc.teams)
+	result := OwnershipConcentrationResult{
+		Snapshots: map[int]*OwnershipConcentrationSnapshot{
+			5: {Gini: 0.25, HHI: 0.3, TotalLines: 200,
+				AuthorLines: map[int]int64{0: 50, 1: 50, 2: 60, 3: 40}},
+		},
+		reversedPeopleDict: []string{"Alice", "Bob", "Charlie|charlie@example.com", "Dave"},
+		teams:              oc.teams,
+		tickSize:           24 * time.Hour,
+	}
+	assert.Equal(t, map[string]int64{"core": 100, "Charlie": 60, "docs": 40},
+		result.teamLines(result.Snapshots[5].AuthorLines))
+	assert.Equal(t, "#7", result.teamOf(7))
+	gini, hhi := result.teamConcentration(result.Snapshots[5])
+	assert.InDelta(t, 0.2, gini, 0.001)
+	assert.InDelta(t, 0.38, hhi, 0.001)
+
+	var buf bytes.Buffer
+	assert.Nil(t, oc.Serialize(result, false, &buf))
+	output := buf.String()
+	assert.Contains(t, output, "total_lines: 200, team_gini: 0.2000, team_hhi: 0.3800}")
+	assert.NotContains(t, output, "org_gini")
+	assert.Contains(t, output, "    per_team:\n      \"Charlie\": {lines: 60, share: 0.3000}\n"+
+		"      \"core\": {lines: 100, share: 0.5000}\n      \"docs\": {lines: 40, share: 0.2000}\n")
+	assert.Contains(t, output, "    teams:\n    - \"core\"\n    - \"core\"\n    - \"\"\n    - \"docs\"\n")
+
+	buf.Reset()
+	assert.Nil(t, oc.Serialize(result, true, &buf))
+	result2, err := oc.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.teams, result2.(OwnershipConcentrationResult).teams)
+
+	merged := oc.MergeResults(OwnershipConcentrationResult{}, result, nil, nil)
+	assert.Equal(t, result.teams, merged.(OwnershipConcentrationResult).teams)
+}
@@ -76,6 +76,11 @@ func (rp *RefactoringProxy) Flag() string {
 	return "refactoring-proxy"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (rp *RefactoringProxy) Cost() core.CostClass {
+	return core.CostCheap
+}
+
 // Description explains what the analysis does
 func (rp *RefactoringProxy) Description() string {
 	return "Tracks rename/move rate over time to distinguish refactoring phases from feature work."
@@ -306,6 +311,14 @@ func (rp *RefactoringProxy) Deserialize(pbmessage []byte) (interface{}, error) {
 		return nil, err
 	}
 
+	if len(message.RenameRatios) != len(message.Ticks) || len(message.TotalChanges) != len(message.Ticks) {
+		return nil, fmt.Errorf("RefactoringProxy PB message integrity violation: %d ticks, %d ratios, %d totals",
+			len(message.Ticks), len(message.RenameRatios), len(message.TotalChanges))
+	}
+	if len(message.RenameRatios) != len(message.Ticks) || len(message.TotalChanges) != len(message.Ticks) {
+		return nil, fmt.Errorf("RefactoringProxy PB message integrity violation: %d ticks, %d ratios, %d totals",
+			len(message.Ticks), len(message.RenameRatios), len(message.TotalChanges))
+	}
 	result := RefactoringProxyResult{
 		Ticks:         make([]int, len(message.Ticks)),
 		RenameRatios:  make([]float64, len(message.RenameRatios)),
@@ -0,0 +1,266 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+// RegexMetric counts the matches of a regular expression in the files of the specified languages.
+type RegexMetric struct {
+	// Name identifies the metric in the results.
+	Name string
+	// Languages are the names of the languages as detected by enry; empty means all the languages.
+	Languages []string
+	// Pattern is the regular expression which matches are counted.
+	Pattern *regexp.Regexp
+}
+
+// matchesLanguage returns true if the metric applies to the files in the specified language.
+func (metric RegexMetric) matchesLanguage(lang string) bool {
+	if len(metric.Languages) == 0 {
+		return true
+	}
+	for _, candidate := range metric.Languages {
+		if strings.EqualFold(candidate, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegexMetricsResult carries the time series of the regex metrics. The counts are always
+// in the order of Metrics.
+type RegexMetricsResult struct {
+	// Metrics are the names of the counted metrics.
+	Metrics []string
+	// Ticks maps ticks to subsystems (directories) to the number of matches in their files
+	// at the end of the tick. Only the changed subsystems are recorded.
+	Ticks map[int]map[string][]int
+	// Totals are the number of matches in the alive files after the last commit.
+	Totals []int
+
+	tickSize time.Duration
+}
+
+// regexMetricsCounter tracks the matches of several RegexMetric-s in each file and aggregates
+// them by directory. It is embedded in the leaves which ship the curated sets of metrics.
+type regexMetricsCounter struct {
+	metrics []RegexMetric
+	// files maps file names to the number of matches of each metric.
+	files map[string][]int
+	// subsystems maps directories to the sum of their files' matches.
+	subsystems map[string][]int
+	// ticks maps ticks to directories to the matches at the end of the tick.
+	ticks map[int]map[string][]int
+}
+
+func newRegexMetricsCounter(metrics []RegexMetric) *regexMetricsCounter {
+	return &regexMetricsCounter{
+		metrics:    metrics,
+		files:      map[string][]int{},
+		subsystems: map[string][]int{},
+		ticks:      map[int]map[string][]int{},
+	}
+}
+
+// count returns the number of matches of each metric in the file contents.
+func (counter *regexMetricsCounter) count(data []byte, lang string) []int {
+	counts := make([]int, len(counter.metrics))
+	for i, metric := range counter.metrics {
+		if metric.matchesLanguage(lang) {
+			counts[i] = len(metric.Pattern.FindAllIndex(data, -1))
+		}
+	}
+	return counts
+}
+
+// update adds the signed counts of a file to its directory and records the directory
+// in the current tick.
+func (counter *regexMetricsCounter) update(tick int, name string, counts []int, sign int) {
+	dir := subsystemOf(name)
+	totals := counter.subsystems[dir]
+	if totals == nil {
+		totals = make([]int, len(counter.metrics))
+		counter.subsystems[dir] = totals
+	}
+	for i, value := range counts {
+		totals[i] += sign * value
+	}
+	tickCounts := counter.ticks[tick]
+	if tickCounts == nil {
+		tickCounts = map[string][]int{}
+		counter.ticks[tick] = tickCounts
+	}
+	tickCounts[dir] = append([]int(nil), totals...)
+}
+
+// consume updates the counts of the changed files.
+func (counter *regexMetricsCounter) consume(tick int, treeDiff object.Changes,
+	cache map[plumbing.Hash]*items.CachedBlob, langs map[plumbing.Hash]string) error {
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return err
+		}
+		if action != merkletrie.Insert {
+			if old, exists := counter.files[change.From.Name]; exists {
+				delete(counter.files, change.From.Name)
+				counter.update(tick, change.From.Name, old, -1)
+			}
+		}
+		if action == merkletrie.Delete {
+			continue
+		}
+		blob := cache[change.To.TreeEntry.Hash]
+		if blob == nil {
+			continue
+		}
+		if _, err := blob.CountLines(); err != nil {
+			// binary
+			continue
+		}
+		counts := counter.count(blob.Data, langs[change.To.TreeEntry.Hash])
+		counter.files[change.To.Name] = counts
+		counter.update(tick, change.To.Name, counts, 1)
+	}
+	return nil
+}
+
+// result builds the RegexMetricsResult from the current state.
+func (counter *regexMetricsCounter) result(tickSize time.Duration) RegexMetricsResult {
+	result := RegexMetricsResult{
+		Metrics:  make([]string, len(counter.metrics)),
+		Ticks:    counter.ticks,
+		Totals:   make([]int, len(counter.metrics)),
+		tickSize: tickSize,
+	}
+	for i, metric := range counter.metrics {
+		result.Metrics[i] = metric.Name
+	}
+	for _, counts := range counter.subsystems {
+		for i, value := range counts {
+			result.Totals[i] += value
+		}
+	}
+	return result
+}
+
+// serializeRegexMetricsText outputs YAML format under the specified key.
+func serializeRegexMetricsText(key string, result *RegexMetricsResult, writer io.Writer) {
+	writeCounts := func(counts []int) {
+		fmt.Fprint(writer, "[")
+		for i, value := range counts {
+			if i > 0 {
+				fmt.Fprint(writer, ", ")
+			}
+			fmt.Fprintf(writer, "%d", value)
+		}
+		fmt.Fprintln(writer, "]")
+	}
+	fmt.Fprintf(writer, "  %s:\n", key)
+	fmt.Fprintf(writer, "    tick_size: %d\n", int(result.tickSize.Seconds()))
+	fmt.Fprint(writer, "    metrics: [")
+	for i, name := range result.Metrics {
+		if i > 0 {
+			fmt.Fprint(writer, ", ")
+		}
+		fmt.Fprint(writer, yaml.SafeString(name))
+	}
+	fmt.Fprintln(writer, "]")
+	fmt.Fprint(writer, "    totals: ")
+	writeCounts(result.Totals)
+	fmt.Fprintln(writer, "    ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		fmt.Fprintf(writer, "      %d:\n", tick)
+		subsystems := result.Ticks[tick]
+		dirs := make([]string, 0, len(subsystems))
+		for dir := range subsystems {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+		for _, dir := range dirs {
+			fmt.Fprintf(writer, "        %s: ", yaml.SafeString(dir))
+			writeCounts(subsystems[dir])
+		}
+	}
+}
+
+func intsToInt32s(values []int) []int32 {
+	result := make([]int32, len(values))
+	for i, value := range values {
+		result[i] = int32(value)
+	}
+	return result
+}
+
+func int32sToInts(values []int32) []int {
+	result := make([]int, len(values))
+	for i, value := range values {
+		result[i] = int(value)
+	}
+	return result
+}
+
+// serializeRegexMetricsBinary outputs Protocol Buffers format
+func serializeRegexMetricsBinary(result *RegexMetricsResult, writer io.Writer) error {
+	message := pb.RegexMetricsResults{
+		Metrics:  result.Metrics,
+		Ticks:    make(map[int32]*pb.RegexMetricsTick, len(result.Ticks)),
+		Totals:   intsToInt32s(result.Totals),
+		TickSize: int64(result.tickSize),
+	}
+	for tick, subsystems := range result.Ticks {
+		pbTick := &pb.RegexMetricsTick{
+			Subsystems: make(map[string]*pb.RegexMetricsCounts, len(subsystems)),
+		}
+		for dir, counts := range subsystems {
+			pbTick.Subsystems[dir] = &pb.RegexMetricsCounts{Counts: intsToInt32s(counts)}
+		}
+		message.Ticks[int32(tick)] = pbTick
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// deserializeRegexMetrics converts the protobuf bytes to RegexMetricsResult.
+func deserializeRegexMetrics(pbmessage []byte) (RegexMetricsResult, error) {
+	message := pb.RegexMetricsResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return RegexMetricsResult{}, err
+	}
+	result := RegexMetricsResult{
+		Metrics:  message.Metrics,
+		Ticks:    make(map[int]map[string][]int, len(message.Ticks)),
+		Totals:   int32sToInts(message.Totals),
+		tickSize: time.Duration(message.TickSize),
+	}
+	for tick, pbTick := range message.Ticks {
+		subsystems := make(map[string][]int, len(pbTick.GetSubsystems()))
+		for dir, counts := range pbTick.GetSubsystems() {
+			subsystems[dir] = int32sToInts(counts.GetCounts())
+		}
+		result.Ticks[int(tick)] = subsystems
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package leaves
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexMetricsCounter(t *testing.T) {
+	counter := newRegexMetricsCounter([]RegexMetric{
+		{Name: "todo", Pattern: regexp.MustCompile(`TODO`)},
+		{Name: "go_panic", Languages: []string{"go"}, Pattern: regexp.MustCompile(`\bpanic\(`)},
+	})
+
+	commit := newCommentDensityCommit().
+		change("", "src/a.go", "// TODO\npanic(1)\npanic(2)\n", "Go", 0).
+		change("", "src/b.py", "# TODO TODO\npanic(1)\n", "Python", 0)
+	require.NoError(t, counter.consume(0, commit.changes, commit.cache, commit.langs))
+	assert.Equal(t, []int{3, 2}, counter.ticks[0]["src"])
+
+	commit = newCommentDensityCommit().
+		change("src/a.go", "lib/a.go", "panic(1)\n", "Go", 0).
+		change("src/b.py", "", "", "Python", 0)
+	require.NoError(t, counter.consume(2, commit.changes, commit.cache, commit.langs))
+
+	result := counter.result(time.Hour)
+	assert.Equal(t, []string{"todo", "go_panic"}, result.Metrics)
+	assert.Equal(t, []int{0, 1}, result.Totals)
+	assert.Equal(t, map[int]map[string][]int{
+		0: {"src": {3, 2}},
+		2: {"src": {0, 0}, "lib": {0, 1}},
+	}, result.Ticks)
+}
+
+func TestRegexMetricsSerialize(t *testing.T) {
+	result := RegexMetricsResult{
+		Metrics:  []string{"a", "b"},
+		Ticks:    map[int]map[string][]int{0: {"src": {1, 2}, "/": {0, 1}}, 4: {"src": {3, 2}}},
+		Totals:   []int{3, 3},
+		tickSize: 24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	serializeRegexMetricsText("metrics", &result, buffer)
+	assert.Equal(t, `  metrics:
+    tick_size: 86400
+    metrics: ["a", "b"]
+    totals: [3, 3]
+    ticks:
+      0:
+        "/": [0, 1]
+        "src": [1, 2]
+      4:
+        "src": [3, 2]
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, serializeRegexMetricsBinary(&result, buffer))
+	restored, err := deserializeRegexMetrics(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+}
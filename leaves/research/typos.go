@@ -120,6 +120,11 @@ func (tdb *TyposDatasetBuilder) Flag() string {
 	return "typos-dataset"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (tdb *TyposDatasetBuilder) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (tdb *TyposDatasetBuilder) Description() string {
 	return "Extracts typo-fix identifier pairs from source code in commit diffs."
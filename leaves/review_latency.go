@@ -0,0 +1,427 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+// ReviewLatencyAnalysis treats the merges into the main line as the integration events and
+// measures how long the merged branches lived: the lead time from the first commit of the branch
+// to the merge and the review wait from the last commit of the branch to the merge.
+// These are the "lead time for changes" metrics calculated from the history alone, without
+// the hosting API. The branch commits are those reachable from the merged parent without
+// passing through the main line; the author of the branch is the author of its tip.
+type ReviewLatencyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// commits maps the hashes of the analysed commits to the commits.
+	commits map[plumbing.Hash]*object.Commit
+	// mainline is the first-parent chain of the head.
+	mainline map[plumbing.Hash]bool
+	// resolve references IdentityDetector.ResolveSignature.
+	resolve      func(object.Signature) int
+	integrations []Integration
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// Integration is the merge of a branch into the main line.
+type Integration struct {
+	// Hash is the hash of the merge commit.
+	Hash string
+	// Author is the author of the tip of the merged branch.
+	Author int
+	// Tick is the tick of the merge commit.
+	Tick int
+	// Commits is the number of the commits in the merged branch.
+	Commits int
+	// LeadTime is the number of seconds between the author date of the first commit
+	// of the branch and the commit date of the merge.
+	LeadTime int
+	// ReviewWait is the number of seconds between the commit date of the last commit
+	// of the branch and the commit date of the merge.
+	ReviewWait int
+}
+
+// ReviewLatencyStats is the distribution of the lead times and of the review waits
+// of several integrations.
+type ReviewLatencyStats struct {
+	Merges           int
+	Commits          int
+	MedianLeadTime   int
+	P90LeadTime      int
+	MedianReviewWait int
+	P90ReviewWait    int
+}
+
+// ReviewLatencyResult is returned by ReviewLatencyAnalysis.Finalize().
+type ReviewLatencyResult struct {
+	// Ticks maps the ticks of the merges to the stats.
+	Ticks map[int]ReviewLatencyStats
+	// People maps the developer indexes to the stats of their branches.
+	People map[int]ReviewLatencyStats
+	// Integrations are sorted by tick and by hash.
+	Integrations []Integration
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	tickSize           time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (rl *ReviewLatencyAnalysis) Name() string {
+	return "ReviewLatency"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (rl *ReviewLatencyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (rl *ReviewLatencyAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTick}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (rl *ReviewLatencyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	return []core.ConfigurationOption{}
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (rl *ReviewLatencyAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		rl.l = l
+	}
+	if val, exists := facts[identity.FactIdentityDetectorResolveSignature].(func(object.Signature) int); exists {
+		rl.resolve = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		rl.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		rl.tickSize = val
+	}
+	if commits, exists := facts[core.ConfigPipelineCommits].([]*object.Commit); exists {
+		rl.commits = make(map[plumbing.Hash]*object.Commit, len(commits))
+		for _, commit := range commits {
+			rl.commits[commit.Hash] = commit
+		}
+		rl.mainline = mainlineOf(commits, rl.commits)
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*ReviewLatencyAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (rl *ReviewLatencyAnalysis) Flag() string {
+	return "review-latency"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (rl *ReviewLatencyAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (rl *ReviewLatencyAnalysis) Description() string {
+	return "Measures the lead time from the first commit of each merged branch to its merge into " +
+		"the main line and the review wait after the last commit, per tick and per author."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (rl *ReviewLatencyAnalysis) Initialize(repository *git.Repository) error {
+	rl.l = core.NewLogger()
+	if rl.tickSize == 0 {
+		rl.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	rl.integrations = nil
+	rl.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (rl *ReviewLatencyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !rl.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	if commit.NumParents() < 2 || !rl.mainline[commit.Hash] {
+		return nil, nil
+	}
+	tick := deps[items.DependencyTick].(int)
+	merged := commit.Committer.When
+	for _, parent := range commit.ParentHashes[1:] {
+		branch := rl.branchOf(parent)
+		if len(branch) == 0 {
+			continue
+		}
+		first, last := branch[0].Author.When, branch[0].Committer.When
+		for _, c := range branch[1:] {
+			if c.Author.When.Before(first) {
+				first = c.Author.When
+			}
+			if c.Committer.When.After(last) {
+				last = c.Committer.When
+			}
+		}
+		author := core.AuthorMissing
+		if rl.resolve != nil {
+			author = rl.resolve(branch[0].Author)
+		}
+		rl.integrations = append(rl.integrations, Integration{
+			Hash:       commit.Hash.String(),
+			Author:     author,
+			Tick:       tick,
+			Commits:    len(branch),
+			LeadTime:   secondsBetween(first, merged),
+			ReviewWait: secondsBetween(last, merged),
+		})
+	}
+	return nil, nil
+}
+
+// branchOf returns the analysed commits which are reachable from the tip without passing
+// through the main line. The tip goes first.
+func (rl *ReviewLatencyAnalysis) branchOf(tip plumbing.Hash) []*object.Commit {
+	var branch []*object.Commit
+	visited := map[plumbing.Hash]bool{}
+	queue := []plumbing.Hash{tip}
+	for len(queue) > 0 {
+		hash := queue[0]
+		queue = queue[1:]
+		if visited[hash] || rl.mainline[hash] {
+			continue
+		}
+		visited[hash] = true
+		commit := rl.commits[hash]
+		if commit == nil {
+			continue
+		}
+		branch = append(branch, commit)
+		queue = append(queue, commit.ParentHashes...)
+	}
+	return branch
+}
+
+// secondsBetween returns the whole number of seconds from `begin` to `end`, 0 if `end` is earlier
+// due to the clock skew.
+func secondsBetween(begin, end time.Time) int {
+	if seconds := int(end.Sub(begin) / time.Second); seconds > 0 {
+		return seconds
+	}
+	return 0
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (rl *ReviewLatencyAnalysis) Finalize() interface{} {
+	result := ReviewLatencyResult{
+		Ticks:              map[int]ReviewLatencyStats{},
+		People:             map[int]ReviewLatencyStats{},
+		Integrations:       append([]Integration{}, rl.integrations...),
+		reversedPeopleDict: rl.reversedPeopleDict,
+		tickSize:           rl.tickSize,
+	}
+	sort.Slice(result.Integrations, func(i, j int) bool {
+		if result.Integrations[i].Tick != result.Integrations[j].Tick {
+			return result.Integrations[i].Tick < result.Integrations[j].Tick
+		}
+		return result.Integrations[i].Hash < result.Integrations[j].Hash
+	})
+	ticks := map[int][]Integration{}
+	people := map[int][]Integration{}
+	for _, integration := range result.Integrations {
+		ticks[integration.Tick] = append(ticks[integration.Tick], integration)
+		people[integration.Author] = append(people[integration.Author], integration)
+	}
+	for tick, integrations := range ticks {
+		result.Ticks[tick] = newReviewLatencyStats(integrations)
+	}
+	for dev, integrations := range people {
+		result.People[dev] = newReviewLatencyStats(integrations)
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (rl *ReviewLatencyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(rl, n)
+}
+
+func newReviewLatencyStats(integrations []Integration) ReviewLatencyStats {
+	stats := ReviewLatencyStats{Merges: len(integrations)}
+	leadTimes := make([]int, len(integrations))
+	reviewWaits := make([]int, len(integrations))
+	for i, integration := range integrations {
+		stats.Commits += integration.Commits
+		leadTimes[i] = integration.LeadTime
+		reviewWaits[i] = integration.ReviewWait
+	}
+	sort.Ints(leadTimes)
+	sort.Ints(reviewWaits)
+	stats.MedianLeadTime = nearestRank(leadTimes, 0.5)
+	stats.P90LeadTime = nearestRank(leadTimes, 0.9)
+	stats.MedianReviewWait = nearestRank(reviewWaits, 0.5)
+	stats.P90ReviewWait = nearestRank(reviewWaits, 0.9)
+	return stats
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (rl *ReviewLatencyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	latencyResult, ok := result.(ReviewLatencyResult)
+	if !ok {
+		return fmt.Errorf("result is not a review latency result: '%v'", result)
+	}
+	if binary {
+		return rl.serializeBinary(&latencyResult, writer)
+	}
+	rl.serializeText(&latencyResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to ReviewLatencyResult.
+func (rl *ReviewLatencyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.ReviewLatencyResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	fromPB := func(stats *pb.ReviewLatencyStats) ReviewLatencyStats {
+		return ReviewLatencyStats{
+			Merges:           int(stats.Merges),
+			Commits:          int(stats.Commits),
+			MedianLeadTime:   int(stats.MedianLeadTime),
+			P90LeadTime:      int(stats.P90LeadTime),
+			MedianReviewWait: int(stats.MedianReviewWait),
+			P90ReviewWait:    int(stats.P90ReviewWait),
+		}
+	}
+	result := ReviewLatencyResult{
+		Ticks:              make(map[int]ReviewLatencyStats, len(message.Ticks)),
+		People:             make(map[int]ReviewLatencyStats, len(message.People)),
+		Integrations:       make([]Integration, len(message.Integrations)),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for tick, stats := range message.Ticks {
+		result.Ticks[int(tick)] = fromPB(stats)
+	}
+	for dev, stats := range message.People {
+		result.People[int(dev)] = fromPB(stats)
+	}
+	for i, integration := range message.Integrations {
+		result.Integrations[i] = Integration{
+			Hash: integration.Hash, Author: int(integration.Author), Tick: int(integration.Tick),
+			Commits: int(integration.Commits), LeadTime: int(integration.LeadTime),
+			ReviewWait: int(integration.ReviewWait),
+		}
+	}
+	return result, nil
+}
+
+func (rl *ReviewLatencyAnalysis) serializeText(result *ReviewLatencyResult, writer io.Writer) {
+	formatStats := func(stats ReviewLatencyStats) string {
+		return fmt.Sprintf("{merges: %d, commits: %d, median_lead_time: %d, p90_lead_time: %d, "+
+			"median_review_wait: %d, p90_review_wait: %d}",
+			stats.Merges, stats.Commits, stats.MedianLeadTime, stats.P90LeadTime,
+			stats.MedianReviewWait, stats.P90ReviewWait)
+	}
+	writeStats := func(title string, stats map[int]ReviewLatencyStats) {
+		fmt.Fprintf(writer, "    %s:\n", title)
+		keys := make([]int, 0, len(stats))
+		for key := range stats {
+			keys = append(keys, key)
+		}
+		sort.Ints(keys)
+		for _, key := range keys {
+			fmt.Fprintf(writer, "      %d: %s\n", key, formatStats(stats[key]))
+		}
+	}
+	fmt.Fprintln(writer, "  review_latency:")
+	writeStats("ticks", result.Ticks)
+	writeStats("developers", result.People)
+	if len(result.Integrations) == 0 {
+		fmt.Fprintln(writer, "    integrations: []")
+	} else {
+		fmt.Fprintln(writer, "    integrations:")
+		for _, integration := range result.Integrations {
+			fmt.Fprintf(writer, "      - {hash: %s, author: %d, tick: %d, commits: %d, lead_time: %d, review_wait: %d}\n",
+				yaml.SafeString(integration.Hash), integration.Author, integration.Tick,
+				integration.Commits, integration.LeadTime, integration.ReviewWait)
+		}
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (rl *ReviewLatencyAnalysis) serializeBinary(result *ReviewLatencyResult, writer io.Writer) error {
+	toPB := func(stats ReviewLatencyStats) *pb.ReviewLatencyStats {
+		return &pb.ReviewLatencyStats{
+			Merges:           int32(stats.Merges),
+			Commits:          int32(stats.Commits),
+			MedianLeadTime:   int64(stats.MedianLeadTime),
+			P90LeadTime:      int64(stats.P90LeadTime),
+			MedianReviewWait: int64(stats.MedianReviewWait),
+			P90ReviewWait:    int64(stats.P90ReviewWait),
+		}
+	}
+	message := pb.ReviewLatencyResults{
+		Ticks:        make(map[int32]*pb.ReviewLatencyStats, len(result.Ticks)),
+		People:       make(map[int32]*pb.ReviewLatencyStats, len(result.People)),
+		Integrations: make([]*pb.Integration, len(result.Integrations)),
+		DevIndex:     result.reversedPeopleDict,
+		TickSize:     int64(result.tickSize),
+	}
+	for tick, stats := range result.Ticks {
+		message.Ticks[int32(tick)] = toPB(stats)
+	}
+	for dev, stats := range result.People {
+		message.People[int32(dev)] = toPB(stats)
+	}
+	for i, integration := range result.Integrations {
+		message.Integrations[i] = &pb.Integration{
+			Hash: integration.Hash, Author: int32(integration.Author), Tick: int32(integration.Tick),
+			Commits: int32(integration.Commits), LeadTime: int64(integration.LeadTime),
+			ReviewWait: int64(integration.ReviewWait),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&ReviewLatencyAnalysis{})
+}
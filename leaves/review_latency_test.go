@@ -0,0 +1,133 @@
+package leaves
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewLatencyMeta(t *testing.T) {
+	rl := &ReviewLatencyAnalysis{}
+	assert.Equal(t, "ReviewLatency", rl.Name())
+	assert.Equal(t, "review-latency", rl.Flag())
+	assert.Len(t, rl.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTick}, rl.Requires())
+	assert.Len(t, rl.ListConfigurationOptions(), 0)
+	summoned := core.Registry.Summon(rl.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, rl.Name(), summoned[0].Name())
+}
+
+// fixtureReviewLatencyCommits creates the main line c0 - c1 - m1 - c2 - m2. m1 merges b2 - b1
+// forked from c0 and m2 merges d2 which is d1 forked from c1 with m1 merged back.
+func fixtureReviewLatencyCommits() []*object.Commit {
+	begin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var commits []*object.Commit
+	commit := func(name, email string, authored, committed int, parents ...*object.Commit) *object.Commit {
+		c := &object.Commit{
+			Hash:      plumbing.NewHash(fmt.Sprintf("%040x", len(commits)+1)),
+			Message:   name,
+			Author:    object.Signature{Email: email, When: begin.Add(time.Duration(authored) * time.Hour)},
+			Committer: object.Signature{Email: email, When: begin.Add(time.Duration(committed) * time.Hour)},
+		}
+		for _, parent := range parents {
+			c.ParentHashes = append(c.ParentHashes, parent.Hash)
+		}
+		commits = append(commits, c)
+		return c
+	}
+	c0 := commit("c0", "alice@example.com", 0, 0)
+	b1 := commit("b1", "bob@example.com", 1, 2, c0)
+	c1 := commit("c1", "alice@example.com", 3, 3, c0)
+	b2 := commit("b2", "bob@example.com", 4, 5, b1)
+	m1 := commit("m1", "alice@example.com", 10, 10, c1, b2)
+	d1 := commit("d1", "carol@example.com", 11, 11, c1)
+	d2 := commit("d2", "carol@example.com", 12, 12, d1, m1)
+	c2 := commit("c2", "alice@example.com", 13, 13, m1)
+	commit("m2", "alice@example.com", 20, 20, c2, d2)
+	return commits
+}
+
+func TestReviewLatencyConsume(t *testing.T) {
+	commits := fixtureReviewLatencyCommits()
+	rl := &ReviewLatencyAnalysis{}
+	require.NoError(t, rl.Configure(map[string]interface{}{
+		core.ConfigPipelineCommits:                      commits,
+		identity.FactIdentityDetectorResolveSignature:   resolveSelfMergeDeveloper,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice", "Bob", "Carol"},
+	}))
+	require.NoError(t, rl.Initialize(nil))
+	for i, commit := range commits {
+		deps := map[string]interface{}{
+			core.DependencyCommit:     commit,
+			identity.DependencyAuthor: 0,
+			items.DependencyTick:      i / 5,
+		}
+		_, err := rl.Consume(deps)
+		require.NoError(t, err)
+		// the merges are consumed once
+		_, err = rl.Consume(deps)
+		require.NoError(t, err)
+	}
+	result := rl.Finalize().(ReviewLatencyResult)
+	assert.Equal(t, []Integration{
+		{Hash: commits[4].Hash.String(), Author: 1, Tick: 0, Commits: 2, LeadTime: 9 * 3600, ReviewWait: 5 * 3600},
+		{Hash: commits[8].Hash.String(), Author: 2, Tick: 1, Commits: 2, LeadTime: 9 * 3600, ReviewWait: 8 * 3600},
+	}, result.Integrations)
+	assert.Equal(t, map[int]ReviewLatencyStats{
+		0: {Merges: 1, Commits: 2, MedianLeadTime: 9 * 3600, P90LeadTime: 9 * 3600,
+			MedianReviewWait: 5 * 3600, P90ReviewWait: 5 * 3600},
+		1: {Merges: 1, Commits: 2, MedianLeadTime: 9 * 3600, P90LeadTime: 9 * 3600,
+			MedianReviewWait: 8 * 3600, P90ReviewWait: 8 * 3600},
+	}, result.Ticks)
+	assert.Len(t, result.People, 2)
+	assert.Equal(t, 1, result.People[1].Merges)
+	assert.Equal(t, 1, result.People[2].Merges)
+}
+
+func TestReviewLatencySerialize(t *testing.T) {
+	rl := &ReviewLatencyAnalysis{}
+	stats := ReviewLatencyStats{
+		Merges: 1, Commits: 2, MedianLeadTime: 3600, P90LeadTime: 3600, MedianReviewWait: 60, P90ReviewWait: 60,
+	}
+	result := ReviewLatencyResult{
+		Ticks:              map[int]ReviewLatencyStats{3: stats},
+		People:             map[int]ReviewLatencyStats{0: stats},
+		Integrations:       []Integration{{Hash: "abc", Tick: 3, Commits: 2, LeadTime: 3600, ReviewWait: 60}},
+		reversedPeopleDict: []string{"Alice"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, rl.Serialize(result, false, buffer))
+	assert.Equal(t, `  review_latency:
+    ticks:
+      3: {merges: 1, commits: 2, median_lead_time: 3600, p90_lead_time: 3600, median_review_wait: 60, p90_review_wait: 60}
+    developers:
+      0: {merges: 1, commits: 2, median_lead_time: 3600, p90_lead_time: 3600, median_review_wait: 60, p90_review_wait: 60}
+    integrations:
+      - {hash: "abc", author: 0, tick: 3, commits: 2, lead_time: 3600, review_wait: 60}
+    people:
+    - "Alice"
+    tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, rl.Serialize(result, true, buffer))
+	restored, err := rl.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	buffer.Reset()
+	require.NoError(t, rl.Serialize(ReviewLatencyResult{}, false, buffer))
+	assert.Contains(t, buffer.String(), "    integrations: []\n")
+	assert.Error(t, rl.Serialize(nil, false, buffer))
+}
@@ -0,0 +1,334 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigRewriteRatioWindowDays is the name of the option to set the number of days after which
+	// the changes of the added lines are no longer considered a rewrite.
+	ConfigRewriteRatioWindowDays = "RewriteRatio.WindowDays"
+	// DefaultRewriteRatioWindowDays is the default value of ConfigRewriteRatioWindowDays.
+	DefaultRewriteRatioWindowDays = 21
+)
+
+// RewriteRatioAnalysis measures the share of the added lines which are deleted or modified
+// shortly after, per author and per subsystem (directory). A high rewrite ratio is a proxy
+// for rework and unstable requirements.
+type RewriteRatioAnalysis struct {
+	core.NoopMerger
+	// WindowDays is the number of days since the addition of a line during which its deletion
+	// or modification counts as a rewrite.
+	WindowDays int
+
+	// windowTicks is WindowDays converted to ticks.
+	windowTicks int
+	people      map[int]*RewriteStats
+	subsystems  map[string]*RewriteStats
+	total       RewriteStats
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// tickSize references TicksSinceStart.TickSize.
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// RewriteStats counts the added lines and those of them which were rewritten within the window.
+type RewriteStats struct {
+	Added     int64
+	Rewritten int64
+}
+
+// Ratio returns the share of the rewritten lines among the added lines.
+func (stats RewriteStats) Ratio() float64 {
+	if stats.Added == 0 {
+		return 0
+	}
+	return float64(stats.Rewritten) / float64(stats.Added)
+}
+
+// RewriteRatioResult is returned by RewriteRatioAnalysis.Finalize().
+type RewriteRatioResult struct {
+	// People maps developer indexes to their stats. The rewritten lines are attributed to
+	// the developers who added them.
+	People map[int]RewriteStats
+	// Subsystems maps directories to their stats.
+	Subsystems map[string]RewriteStats
+	// Total is the stats of the whole repository.
+	Total RewriteStats
+	// WindowDays is the rewrite window.
+	WindowDays int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	tickSize           time.Duration
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (rr *RewriteRatioAnalysis) Name() string {
+	return "RewriteRatio"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (rr *RewriteRatioAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (rr *RewriteRatioAnalysis) Requires() []string {
+	return []string{linehistory.DependencyLineHistory, identity.DependencyAuthor}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (rr *RewriteRatioAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigRewriteRatioWindowDays,
+		Description: "Number of days after the addition of a line during which its change counts as a rewrite.",
+		Flag:        "rewrite-window",
+		Type:        core.IntConfigurationOption,
+		Default:     DefaultRewriteRatioWindowDays,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (rr *RewriteRatioAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		rr.l = l
+	}
+	if val, exists := facts[ConfigRewriteRatioWindowDays].(int); exists {
+		rr.WindowDays = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		rr.reversedPeopleDict = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		rr.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*RewriteRatioAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (rr *RewriteRatioAnalysis) Flag() string {
+	return "rewrite-ratio"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (rr *RewriteRatioAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (rr *RewriteRatioAnalysis) Description() string {
+	return "Measures the share of the added lines which are deleted or modified within " +
+		"the specified number of days, per developer and per directory."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (rr *RewriteRatioAnalysis) Initialize(repository *git.Repository) error {
+	rr.l = core.NewLogger()
+	if rr.WindowDays <= 0 {
+		rr.WindowDays = DefaultRewriteRatioWindowDays
+	}
+	if rr.tickSize == 0 {
+		rr.tickSize = items.DefaultTicksSinceStartTickSize * time.Hour
+	}
+	rr.windowTicks = int(time.Duration(rr.WindowDays) * 24 * time.Hour / rr.tickSize)
+	rr.people = map[int]*RewriteStats{}
+	rr.subsystems = map[string]*RewriteStats{}
+	rr.total = RewriteStats{}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (rr *RewriteRatioAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	changes := deps[linehistory.DependencyLineHistory].(core.LineHistoryChanges)
+	for _, change := range changes.Changes {
+		if change.IsDelete() || change.Delta == 0 {
+			continue
+		}
+		var added, rewritten int64
+		if change.Delta > 0 {
+			added = int64(change.Delta)
+		} else if age := int(change.CurrTick - change.PrevTick); age >= 0 && age <= rr.windowTicks {
+			rewritten = int64(-change.Delta)
+		} else {
+			continue
+		}
+		rr.total.Added += added
+		rr.total.Rewritten += rewritten
+		dir := subsystemOf(changes.Resolver.NameOf(change.FileId))
+		stats := rr.subsystems[dir]
+		if stats == nil {
+			stats = &RewriteStats{}
+			rr.subsystems[dir] = stats
+		}
+		stats.Added += added
+		stats.Rewritten += rewritten
+		// the author of the deleted lines is PrevAuthor, the deleting author is CurrAuthor
+		if change.PrevAuthor == core.AuthorMissing {
+			continue
+		}
+		stats = rr.people[int(change.PrevAuthor)]
+		if stats == nil {
+			stats = &RewriteStats{}
+			rr.people[int(change.PrevAuthor)] = stats
+		}
+		stats.Added += added
+		stats.Rewritten += rewritten
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (rr *RewriteRatioAnalysis) Finalize() interface{} {
+	result := RewriteRatioResult{
+		People:             make(map[int]RewriteStats, len(rr.people)),
+		Subsystems:         make(map[string]RewriteStats, len(rr.subsystems)),
+		Total:              rr.total,
+		WindowDays:         rr.WindowDays,
+		reversedPeopleDict: rr.reversedPeopleDict,
+		tickSize:           rr.tickSize,
+	}
+	for dev, stats := range rr.people {
+		result.People[dev] = *stats
+	}
+	for dir, stats := range rr.subsystems {
+		result.Subsystems[dir] = *stats
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (rr *RewriteRatioAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(rr, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (rr *RewriteRatioAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	rewriteResult, ok := result.(RewriteRatioResult)
+	if !ok {
+		return fmt.Errorf("result is not a rewrite ratio result: '%v'", result)
+	}
+	if binary {
+		return rr.serializeBinary(&rewriteResult, writer)
+	}
+	rr.serializeText(&rewriteResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to RewriteRatioResult.
+func (rr *RewriteRatioAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.RewriteRatioResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	fromPB := func(stats *pb.RewriteStats) RewriteStats {
+		if stats == nil {
+			return RewriteStats{}
+		}
+		return RewriteStats{Added: stats.Added, Rewritten: stats.Rewritten}
+	}
+	result := RewriteRatioResult{
+		People:             make(map[int]RewriteStats, len(message.People)),
+		Subsystems:         make(map[string]RewriteStats, len(message.Subsystems)),
+		Total:              fromPB(message.Total),
+		WindowDays:         int(message.WindowDays),
+		reversedPeopleDict: message.DevIndex,
+		tickSize:           time.Duration(message.TickSize),
+	}
+	for dev, stats := range message.People {
+		result.People[int(dev)] = fromPB(stats)
+	}
+	for dir, stats := range message.Subsystems {
+		result.Subsystems[dir] = fromPB(stats)
+	}
+	return result, nil
+}
+
+func (rr *RewriteRatioAnalysis) serializeText(result *RewriteRatioResult, writer io.Writer) {
+	formatStats := func(stats RewriteStats) string {
+		return fmt.Sprintf("{added: %d, rewritten: %d, ratio: %.4f}",
+			stats.Added, stats.Rewritten, stats.Ratio())
+	}
+	fmt.Fprintln(writer, "  rewrite_ratio:")
+	fmt.Fprintf(writer, "    window_days: %d\n", result.WindowDays)
+	fmt.Fprintf(writer, "    total: %s\n", formatStats(result.Total))
+	fmt.Fprintln(writer, "    developers:")
+	devs := make([]int, 0, len(result.People))
+	for dev := range result.People {
+		devs = append(devs, dev)
+	}
+	sort.Ints(devs)
+	for _, dev := range devs {
+		fmt.Fprintf(writer, "      %d: %s\n", dev, formatStats(result.People[dev]))
+	}
+	fmt.Fprintln(writer, "    subsystems:")
+	dirs := make([]string, 0, len(result.Subsystems))
+	for dir := range result.Subsystems {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		fmt.Fprintf(writer, "      %s: %s\n", yaml.SafeString(dir), formatStats(result.Subsystems[dir]))
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+	fmt.Fprintln(writer, "    tick_size:", int(result.tickSize.Seconds()))
+}
+
+func (rr *RewriteRatioAnalysis) serializeBinary(result *RewriteRatioResult, writer io.Writer) error {
+	toPB := func(stats RewriteStats) *pb.RewriteStats {
+		return &pb.RewriteStats{Added: stats.Added, Rewritten: stats.Rewritten}
+	}
+	message := pb.RewriteRatioResults{
+		People:     make(map[int32]*pb.RewriteStats, len(result.People)),
+		Subsystems: make(map[string]*pb.RewriteStats, len(result.Subsystems)),
+		Total:      toPB(result.Total),
+		DevIndex:   result.reversedPeopleDict,
+		WindowDays: int32(result.WindowDays),
+		TickSize:   int64(result.tickSize),
+	}
+	for dev, stats := range result.People {
+		message.People[int32(dev)] = toPB(stats)
+	}
+	for dir, stats := range result.Subsystems {
+		message.Subsystems[dir] = toPB(stats)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&RewriteRatioAnalysis{})
+}
@@ -0,0 +1,133 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/linehistory"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewriteRatioMeta(t *testing.T) {
+	rr := &RewriteRatioAnalysis{}
+	assert.Equal(t, "RewriteRatio", rr.Name())
+	assert.Len(t, rr.Provides(), 0)
+	assert.Equal(t, []string{linehistory.DependencyLineHistory, identity.DependencyAuthor}, rr.Requires())
+	assert.Equal(t, "rewrite-ratio", rr.Flag())
+	assert.Equal(t, core.CostHeavy, rr.Cost())
+	opts := rr.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigRewriteRatioWindowDays, opts[0].Name)
+	assert.Equal(t, DefaultRewriteRatioWindowDays, opts[0].Default)
+	require.NoError(t, rr.Configure(map[string]interface{}{
+		ConfigRewriteRatioWindowDays:                    7,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice"},
+		items.FactTickSize:                              12 * time.Hour,
+	}))
+	assert.Equal(t, 7, rr.WindowDays)
+	assert.Equal(t, []string{"Alice"}, rr.reversedPeopleDict)
+	require.NoError(t, rr.Initialize(nil))
+	assert.Equal(t, 14, rr.windowTicks)
+
+	rr = &RewriteRatioAnalysis{}
+	require.NoError(t, rr.Initialize(nil))
+	assert.Equal(t, DefaultRewriteRatioWindowDays, rr.WindowDays)
+	assert.Equal(t, DefaultRewriteRatioWindowDays, rr.windowTicks)
+}
+
+func TestRewriteRatioRegistration(t *testing.T) {
+	summoned := core.Registry.Summon((&RewriteRatioAnalysis{}).Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "RewriteRatio", summoned[0].Name())
+	matched := false
+	for _, tp := range core.Registry.GetLeaves() {
+		if tp.Flag() == (&RewriteRatioAnalysis{}).Flag() {
+			matched = true
+			break
+		}
+	}
+	assert.True(t, matched)
+}
+
+func TestRewriteRatioConsume(t *testing.T) {
+	rr := &RewriteRatioAnalysis{WindowDays: 10}
+	require.NoError(t, rr.Initialize(nil))
+	resolver := &testFileIdResolver{Names: []string{"src/a.go", "README.md"}}
+	consume := func(changes ...core.LineHistoryChange) {
+		_, err := rr.Consume(map[string]interface{}{
+			linehistory.DependencyLineHistory: core.LineHistoryChanges{Changes: changes, Resolver: resolver},
+		})
+		require.NoError(t, err)
+	}
+	consume(
+		core.LineHistoryChange{FileId: 0, CurrTick: 0, PrevTick: 0, CurrAuthor: 0, PrevAuthor: 0, Delta: 100},
+		core.LineHistoryChange{FileId: 1, CurrTick: 0, PrevTick: 0, CurrAuthor: 1, PrevAuthor: 1, Delta: 10},
+	)
+	consume(
+		// Bob rewrites Alice's lines within the window
+		core.LineHistoryChange{FileId: 0, CurrTick: 5, PrevTick: 0, CurrAuthor: 1, PrevAuthor: 0, Delta: -20},
+		core.LineHistoryChange{FileId: 0, CurrTick: 5, PrevTick: 5, CurrAuthor: 1, PrevAuthor: 1, Delta: 20},
+		core.LineHistoryChange{FileId: 1, CurrTick: 5, PrevTick: 0, CurrAuthor: 1, PrevAuthor: 1, Delta: -5},
+		core.LineHistoryChange{FileId: 1, CurrTick: 5, PrevTick: 0, CurrAuthor: core.AuthorMissing,
+			PrevAuthor: core.AuthorMissing, Delta: 4},
+	)
+	consume(
+		// outside of the window
+		core.LineHistoryChange{FileId: 0, CurrTick: 30, PrevTick: 0, CurrAuthor: 1, PrevAuthor: 0, Delta: -50},
+		core.LineHistoryChange{FileId: 0, CurrTick: 30, PrevTick: 5, CurrAuthor: 0, PrevAuthor: 1, Delta: -10},
+		core.NewLineHistoryDeletion(1, 0, 30),
+	)
+	result := rr.Finalize().(RewriteRatioResult)
+	assert.Equal(t, RewriteStats{Added: 134, Rewritten: 25}, result.Total)
+	assert.Equal(t, map[int]RewriteStats{
+		0: {Added: 100, Rewritten: 20},
+		1: {Added: 30, Rewritten: 5},
+	}, result.People)
+	assert.Equal(t, map[string]RewriteStats{
+		"src": {Added: 120, Rewritten: 20},
+		"/":   {Added: 14, Rewritten: 5},
+	}, result.Subsystems)
+	assert.Equal(t, 10, result.WindowDays)
+	assert.InDelta(t, 0.2, result.People[0].Ratio(), 1e-6)
+	assert.Equal(t, 0.0, RewriteStats{}.Ratio())
+}
+
+func TestRewriteRatioSerialize(t *testing.T) {
+	rr := &RewriteRatioAnalysis{}
+	result := RewriteRatioResult{
+		People:             map[int]RewriteStats{0: {Added: 100, Rewritten: 20}, 1: {Added: 30, Rewritten: 5}},
+		Subsystems:         map[string]RewriteStats{"src": {Added: 130, Rewritten: 25}},
+		Total:              RewriteStats{Added: 130, Rewritten: 25},
+		WindowDays:         21,
+		reversedPeopleDict: []string{"Alice", "Bob"},
+		tickSize:           24 * time.Hour,
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, rr.Serialize(result, false, buffer))
+	assert.Equal(t, `  rewrite_ratio:
+    window_days: 21
+    total: {added: 130, rewritten: 25, ratio: 0.1923}
+    developers:
+      0: {added: 100, rewritten: 20, ratio: 0.2000}
+      1: {added: 30, rewritten: 5, ratio: 0.1667}
+    subsystems:
+      "src": {added: 130, rewritten: 25, ratio: 0.1923}
+    people:
+    - "Alice"
+    - "Bob"
+    tick_size: 86400
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, rr.Serialize(result, true, buffer))
+	restored, err := rr.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+
+	assert.Error(t, rr.Serialize(nil, false, buffer))
+}
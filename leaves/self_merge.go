@@ -0,0 +1,519 @@
+package leaves
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigSelfMergeReviewsPath is the name of the option to set SelfMergeAnalysis.ReviewsPath.
+	ConfigSelfMergeReviewsPath = "SelfMerge.ReviewsPath"
+)
+
+// SelfMergeAnalysis measures how many changes landed on the main line without anybody
+// except the author involved - the governance risk which complements the bus factor.
+// A merge commit is self-merged if its author merged their own branch; any other commit
+// is self-merged if the author committed it themselves. The hosting data loaded from ReviewsPath,
+// e.g. the pull request mergers and approvers, takes precedence. The commits which reached
+// the main line through a merge are represented by that merge.
+type SelfMergeAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// ReviewsPath is the JSON file with the hosting data about the landed changes, keyed
+	// by the commit hashes, see SelfMergeReview.
+	ReviewsPath string
+
+	// reviews maps the commit hashes to the hosting data.
+	reviews map[string]SelfMergeReview
+	// commits maps the hashes of the analysed commits to the commits.
+	commits map[plumbing.Hash]*object.Commit
+	// mainline is the first-parent chain of the head, nil if the commits are unknown.
+	mainline map[plumbing.Hash]bool
+	// resolve references IdentityDetector.ResolveSignature.
+	resolve func(object.Signature) int
+	result  SelfMergeResult
+
+	l core.Logger
+}
+
+// SelfMergeReview is the hosting data about a landed change, e.g. exported from the pull
+// request API. The developers are written as emails, names or "Name <email>".
+type SelfMergeReview struct {
+	// MergedBy is the developer who merged the change.
+	MergedBy string `json:"merged_by"`
+	// Approvers are the developers who approved the change.
+	Approvers []string `json:"approvers"`
+}
+
+// SelfMergeCounts are the numbers of the landed changes by who else was involved.
+type SelfMergeCounts struct {
+	// Changes is the number of the landed changes.
+	Changes int
+	// SelfMerged is the number of changes which nobody except the author merged or approved.
+	SelfMerged int
+	// SingleApprover is the number of changes which exactly one other developer merged or approved.
+	SingleApprover int
+	// Unknown is the number of changes merged by somebody who is not a known developer, e.g. a bot.
+	Unknown int
+}
+
+// SelfMergeResult is returned by SelfMergeAnalysis.Finalize().
+type SelfMergeResult struct {
+	// Total are the counts over the whole history.
+	Total SelfMergeCounts
+	// Months maps "YYYY-MM" of the author dates to the counts.
+	Months map[string]SelfMergeCounts
+	// Subsystems maps the directories to the counts of the changes which touched them.
+	Subsystems map[string]SelfMergeCounts
+}
+
+// selfMergeKind is the category of a landed change.
+type selfMergeKind int
+
+const (
+	selfMergeKindReviewed selfMergeKind = iota
+	selfMergeKindSelf
+	selfMergeKindSingle
+	selfMergeKindUnknown
+)
+
+// add counts one more change of the specified kind.
+func (counts SelfMergeCounts) add(kind selfMergeKind) SelfMergeCounts {
+	counts.Changes++
+	switch kind {
+	case selfMergeKindSelf:
+		counts.SelfMerged++
+	case selfMergeKindSingle:
+		counts.SingleApprover++
+	case selfMergeKindUnknown:
+		counts.Unknown++
+	}
+	return counts
+}
+
+// merge sums the counts.
+func (counts SelfMergeCounts) merge(other SelfMergeCounts) SelfMergeCounts {
+	counts.Changes += other.Changes
+	counts.SelfMerged += other.SelfMerged
+	counts.SingleApprover += other.SingleApprover
+	counts.Unknown += other.Unknown
+	return counts
+}
+
+// SelfMergeRate returns the share of the self-merged changes among those with the known merger.
+func (counts SelfMergeCounts) SelfMergeRate() float64 {
+	if known := counts.Changes - counts.Unknown; known > 0 {
+		return float64(counts.SelfMerged) / float64(known)
+	}
+	return 0
+}
+
+// SingleApproverRate returns the share of the single-approver changes among those
+// with the known merger.
+func (counts SelfMergeCounts) SingleApproverRate() float64 {
+	if known := counts.Changes - counts.Unknown; known > 0 {
+		return float64(counts.SingleApprover) / float64(known)
+	}
+	return 0
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (sm *SelfMergeAnalysis) Name() string {
+	return "SelfMerge"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (sm *SelfMergeAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (sm *SelfMergeAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (sm *SelfMergeAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigSelfMergeReviewsPath,
+		Description: "JSON file with the hosting data about the landed changes: " +
+			"{\"<commit hash>\": {\"merged_by\": \"<email>\", \"approvers\": [\"<email>\", ...]}}.",
+		Flag:    "self-merge-reviews",
+		Type:    core.PathConfigurationOption,
+		Default: "",
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (sm *SelfMergeAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		sm.l = l
+	}
+	if val, exists := facts[ConfigSelfMergeReviewsPath].(string); exists {
+		sm.ReviewsPath = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorResolveSignature].(func(object.Signature) int); exists {
+		sm.resolve = val
+	}
+	if commits, exists := facts[core.ConfigPipelineCommits].([]*object.Commit); exists {
+		sm.commits = make(map[plumbing.Hash]*object.Commit, len(commits))
+		for _, commit := range commits {
+			sm.commits[commit.Hash] = commit
+		}
+		sm.mainline = mainlineOf(commits, sm.commits)
+	}
+	sm.reviews = nil
+	if sm.ReviewsPath != "" {
+		data, err := os.ReadFile(sm.ReviewsPath)
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(data, &sm.reviews); err != nil {
+			return fmt.Errorf("%s: invalid self-merge reviews: %v", sm.ReviewsPath, err)
+		}
+	}
+	return nil
+}
+
+// mainlineOf follows the first parents from the head - the latest commit which is not a parent
+// of any other. The commits are in the `git log` order or reversed with --first-parent.
+// `index` maps the hashes of the commits to the commits.
+func mainlineOf(commits []*object.Commit, index map[plumbing.Hash]*object.Commit) map[plumbing.Hash]bool {
+	parents := map[plumbing.Hash]bool{}
+	for _, commit := range commits {
+		for _, parent := range commit.ParentHashes {
+			parents[parent] = true
+		}
+	}
+	var head *object.Commit
+	for _, commit := range commits {
+		if !parents[commit.Hash] && (head == nil || commit.Committer.When.After(head.Committer.When)) {
+			head = commit
+		}
+	}
+	if head == nil {
+		return nil
+	}
+	mainline := map[plumbing.Hash]bool{}
+	for commit := head; commit != nil && !mainline[commit.Hash]; {
+		mainline[commit.Hash] = true
+		if len(commit.ParentHashes) == 0 {
+			break
+		}
+		commit = index[commit.ParentHashes[0]]
+	}
+	return mainline
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*SelfMergeAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (sm *SelfMergeAnalysis) Flag() string {
+	return "self-merge"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (sm *SelfMergeAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (sm *SelfMergeAnalysis) Description() string {
+	return "Calculates the rate of the self-merged and single-approver changes per month and " +
+		"per subsystem from the merge commits, the committers and the hosting data."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (sm *SelfMergeAnalysis) Initialize(repository *git.Repository) error {
+	sm.l = core.NewLogger()
+	sm.result = SelfMergeResult{
+		Months:     map[string]SelfMergeCounts{},
+		Subsystems: map[string]SelfMergeCounts{},
+	}
+	sm.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (sm *SelfMergeAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !sm.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	if sm.mainline != nil && !sm.mainline[commit.Hash] {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	merger := author
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	if commit.NumParents() > 1 {
+		// the author of the merged branch's tip wrote the change and the merge's author merged it
+		if branch, err := sm.parent(commit, 1); err == nil {
+			author = sm.resolveSignature(branch.Author)
+		} else {
+			sm.l.Warnf("self-merge: failed to load the merged parent of %s: %v", commit.Hash, err)
+		}
+		// the files which the merge brought to the main line
+		changes = nil
+		if first, err := sm.parent(commit, 0); err == nil {
+			changes, err = diffCommits(core.ContextFromDeps(deps), first, commit)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		merger = sm.resolveSignature(commit.Committer)
+	}
+	kind := sm.classify(commit.Hash, author, merger)
+	sm.result.Total = sm.result.Total.add(kind)
+	month := commit.Author.When.UTC().Format("2006-01")
+	sm.result.Months[month] = sm.result.Months[month].add(kind)
+	subsystems := map[string]bool{}
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		subsystems[subsystemOf(name)] = true
+	}
+	for dir := range subsystems {
+		sm.result.Subsystems[dir] = sm.result.Subsystems[dir].add(kind)
+	}
+	return nil, nil
+}
+
+// classify determines who else except the author merged or approved the change.
+func (sm *SelfMergeAnalysis) classify(hash plumbing.Hash, author, merger int) selfMergeKind {
+	var approvers []string
+	if review, exists := sm.reviews[hash.String()]; exists {
+		if review.MergedBy != "" {
+			merger = sm.resolveSignature(parseSelfMergeDeveloper(review.MergedBy))
+		}
+		approvers = review.Approvers
+	}
+	if author == core.AuthorMissing {
+		return selfMergeKindUnknown
+	}
+	others := map[int]bool{}
+	if merger != core.AuthorMissing && merger != author {
+		others[merger] = true
+	}
+	for _, approver := range approvers {
+		if id := sm.resolveSignature(parseSelfMergeDeveloper(approver)); id != core.AuthorMissing && id != author {
+			others[id] = true
+		}
+	}
+	switch {
+	case len(others) == 1:
+		return selfMergeKindSingle
+	case len(others) > 1:
+		return selfMergeKindReviewed
+	case merger == core.AuthorMissing:
+		return selfMergeKindUnknown
+	}
+	return selfMergeKindSelf
+}
+
+// parseSelfMergeDeveloper converts "Name <email>", an email or a name to the signature.
+func parseSelfMergeDeveloper(developer string) object.Signature {
+	developer = strings.TrimSpace(developer)
+	if open := strings.LastIndexByte(developer, '<'); open >= 0 && strings.HasSuffix(developer, ">") {
+		return object.Signature{
+			Name:  strings.TrimSpace(developer[:open]),
+			Email: developer[open+1 : len(developer)-1],
+		}
+	}
+	return object.Signature{Name: developer, Email: developer}
+}
+
+// resolveSignature finds the developer index of the signature.
+func (sm *SelfMergeAnalysis) resolveSignature(signature object.Signature) int {
+	if sm.resolve == nil {
+		return core.AuthorMissing
+	}
+	return sm.resolve(signature)
+}
+
+// parent returns the specified parent of the commit, preferably among the analysed commits.
+func (sm *SelfMergeAnalysis) parent(commit *object.Commit, index int) (*object.Commit, error) {
+	if parent, exists := sm.commits[commit.ParentHashes[index]]; exists {
+		return parent, nil
+	}
+	return commit.Parent(index)
+}
+
+// diffCommits returns the changes between the trees of the commits.
+func diffCommits(ctx context.Context, from, to *object.Commit) (object.Changes, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, err
+	}
+	return object.DiffTreeContext(ctx, fromTree, toTree)
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (sm *SelfMergeAnalysis) Finalize() interface{} {
+	return sm.result
+}
+
+// Fork clones this pipeline item.
+func (sm *SelfMergeAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(sm, n)
+}
+
+// AnonymizePaths replaces the names of the subsystems, see core.PathAnonymizer.
+func (sm *SelfMergeAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	selfMerge := result.(SelfMergeResult)
+	subsystems := make(map[string]SelfMergeCounts, len(selfMerge.Subsystems))
+	for dir, counts := range selfMerge.Subsystems {
+		subsystems[anonymize(dir)] = counts
+	}
+	selfMerge.Subsystems = subsystems
+	return selfMerge
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (sm *SelfMergeAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	selfMergeResult, ok := result.(SelfMergeResult)
+	if !ok {
+		return fmt.Errorf("result is not a self-merge result: '%v'", result)
+	}
+	if binary {
+		return sm.serializeBinary(&selfMergeResult, writer)
+	}
+	sm.serializeText(&selfMergeResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to SelfMergeResult.
+func (sm *SelfMergeAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.SelfMergeResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	fromPb := func(counts *pb.SelfMergeCounts) SelfMergeCounts {
+		return SelfMergeCounts{
+			Changes:        int(counts.GetChanges()),
+			SelfMerged:     int(counts.GetSelfMerged()),
+			SingleApprover: int(counts.GetSingleApprover()),
+			Unknown:        int(counts.GetUnknown()),
+		}
+	}
+	result := SelfMergeResult{
+		Total:      fromPb(message.Total),
+		Months:     make(map[string]SelfMergeCounts, len(message.Months)),
+		Subsystems: make(map[string]SelfMergeCounts, len(message.Subsystems)),
+	}
+	for month, counts := range message.Months {
+		result.Months[month] = fromPb(counts)
+	}
+	for dir, counts := range message.Subsystems {
+		result.Subsystems[dir] = fromPb(counts)
+	}
+	return result, nil
+}
+
+// MergeResults combines two SelfMergeResult-s together.
+func (sm *SelfMergeAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	smr1 := r1.(SelfMergeResult)
+	smr2 := r2.(SelfMergeResult)
+	merged := SelfMergeResult{
+		Total:      smr1.Total.merge(smr2.Total),
+		Months:     map[string]SelfMergeCounts{},
+		Subsystems: map[string]SelfMergeCounts{},
+	}
+	for _, result := range [...]*SelfMergeResult{&smr1, &smr2} {
+		for month, counts := range result.Months {
+			merged.Months[month] = merged.Months[month].merge(counts)
+		}
+		for dir, counts := range result.Subsystems {
+			merged.Subsystems[dir] = merged.Subsystems[dir].merge(counts)
+		}
+	}
+	return merged
+}
+
+func (sm *SelfMergeAnalysis) serializeText(result *SelfMergeResult, writer io.Writer) {
+	format := func(counts SelfMergeCounts) string {
+		return fmt.Sprintf("{changes: %d, self_merged: %d, single_approver: %d, unknown: %d, "+
+			"self_merge_rate: %.4f, single_approver_rate: %.4f}",
+			counts.Changes, counts.SelfMerged, counts.SingleApprover, counts.Unknown,
+			counts.SelfMergeRate(), counts.SingleApproverRate())
+	}
+	writeCounts := func(title string, counts map[string]SelfMergeCounts) {
+		fmt.Fprintf(writer, "    %s:\n", title)
+		keys := make([]string, 0, len(counts))
+		for key := range counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(writer, "      %s: %s\n", yaml.SafeString(key), format(counts[key]))
+		}
+	}
+	fmt.Fprintln(writer, "  self_merge:")
+	fmt.Fprintf(writer, "    total: %s\n", format(result.Total))
+	writeCounts("months", result.Months)
+	writeCounts("subsystems", result.Subsystems)
+}
+
+func (sm *SelfMergeAnalysis) serializeBinary(result *SelfMergeResult, writer io.Writer) error {
+	toPb := func(counts SelfMergeCounts) *pb.SelfMergeCounts {
+		return &pb.SelfMergeCounts{
+			Changes:        int32(counts.Changes),
+			SelfMerged:     int32(counts.SelfMerged),
+			SingleApprover: int32(counts.SingleApprover),
+			Unknown:        int32(counts.Unknown),
+		}
+	}
+	message := pb.SelfMergeResults{
+		Total:      toPb(result.Total),
+		Months:     make(map[string]*pb.SelfMergeCounts, len(result.Months)),
+		Subsystems: make(map[string]*pb.SelfMergeCounts, len(result.Subsystems)),
+	}
+	for month, counts := range result.Months {
+		message.Months[month] = toPb(counts)
+	}
+	for dir, counts := range result.Subsystems {
+		message.Subsystems[dir] = toPb(counts)
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&SelfMergeAnalysis{})
+}
@@ -0,0 +1,231 @@
+package leaves
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var selfMergeDevelopers = map[string]int{
+	"alice@example.com": 0, "bob@example.com": 1, "carol@example.com": 2,
+}
+
+func resolveSelfMergeDeveloper(signature object.Signature) int {
+	if id, exists := selfMergeDevelopers[strings.ToLower(signature.Email)]; exists {
+		return id
+	}
+	return core.AuthorMissing
+}
+
+// fixtureSelfMergeRepository creates the main line c0 - c1 - c2 - m - c3, where m merges
+// the branch commit b1 forked from c1. It returns the commits in the topological order:
+// c0, c1, b1, c2, m, c3.
+func fixtureSelfMergeRepository(t *testing.T) (*git.Repository, []*object.Commit) {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	when := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	signature := func(name string) *object.Signature {
+		when = when.AddDate(0, 0, 1)
+		email := strings.ToLower(name) + "@example.com"
+		if name == "GitHub" {
+			email = "noreply@github.com"
+		}
+		return &object.Signature{Name: name, Email: email, When: when}
+	}
+	var commits []*object.Commit
+	commit := func(file, author, committer string, parents ...plumbing.Hash) plumbing.Hash {
+		require.NoError(t, util.WriteFile(fs, file, []byte(file+"\n"), 0o644))
+		require.NoError(t, worktree.AddWithOptions(&git.AddOptions{All: true}))
+		hash, err := worktree.Commit("commit", &git.CommitOptions{
+			Author: signature(author), Committer: signature(committer), Parents: parents,
+		})
+		require.NoError(t, err)
+		obj, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		commits = append(commits, obj)
+		return hash
+	}
+	commit("README.md", "Alice", "Alice")
+	c1 := commit("src/a.go", "Bob", "Alice")
+	b1 := commit("lib/b.go", "Carol", "Carol")
+	require.NoError(t, worktree.Reset(&git.ResetOptions{Commit: c1, Mode: git.HardReset}))
+	c2 := commit("src/c.go", "Alice", "Alice")
+	require.NoError(t, util.WriteFile(fs, "lib/b.go", []byte("lib/b.go\n"), 0o644))
+	commit("lib/b.go", "Carol", "GitHub", c2, b1)
+	when = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+	commit("src/d.go", "Bob", "GitHub")
+	return repository, commits
+}
+
+func TestSelfMergeMeta(t *testing.T) {
+	sm := &SelfMergeAnalysis{}
+	assert.Equal(t, "SelfMerge", sm.Name())
+	assert.Equal(t, "self-merge", sm.Flag())
+	assert.Len(t, sm.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTreeChanges}, sm.Requires())
+	opts := sm.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigSelfMergeReviewsPath, opts[0].Name)
+	assert.Equal(t, "self-merge-reviews", opts[0].Flag)
+	summoned := core.Registry.Summon(sm.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, sm.Name(), summoned[0].Name())
+}
+
+func TestSelfMergeConfigure(t *testing.T) {
+	_, commits := fixtureSelfMergeRepository(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reviews.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"`+commits[3].Hash.String()+
+		`": {"merged_by": "bob@example.com", "approvers": ["Carol <carol@example.com>"]}}`), 0o644))
+	sm := &SelfMergeAnalysis{}
+	require.NoError(t, sm.Configure(map[string]interface{}{
+		ConfigSelfMergeReviewsPath: path,
+		core.ConfigPipelineCommits: commits,
+	}))
+	assert.Equal(t, map[string]SelfMergeReview{commits[3].Hash.String(): {
+		MergedBy: "bob@example.com", Approvers: []string{"Carol <carol@example.com>"},
+	}}, sm.reviews)
+	assert.Len(t, sm.commits, 6)
+	assert.Equal(t, map[plumbing.Hash]bool{
+		commits[0].Hash: true, commits[1].Hash: true, commits[3].Hash: true,
+		commits[4].Hash: true, commits[5].Hash: true,
+	}, sm.mainline)
+	// the order of `git log`
+	reversed := make([]*object.Commit, len(commits))
+	for i, commit := range commits {
+		reversed[len(commits)-1-i] = commit
+	}
+	mainline := sm.mainline
+	require.NoError(t, sm.Configure(map[string]interface{}{core.ConfigPipelineCommits: reversed}))
+	assert.Equal(t, mainline, sm.mainline)
+
+	require.NoError(t, os.WriteFile(path, []byte("[]"), 0o644))
+	assert.Error(t, sm.Configure(map[string]interface{}{ConfigSelfMergeReviewsPath: path}))
+	assert.Error(t, sm.Configure(map[string]interface{}{
+		ConfigSelfMergeReviewsPath: filepath.Join(dir, "missing.json"),
+	}))
+}
+
+func TestParseSelfMergeDeveloper(t *testing.T) {
+	assert.Equal(t, object.Signature{Name: "Carol Smith", Email: "carol@example.com"},
+		parseSelfMergeDeveloper(" Carol Smith <carol@example.com> "))
+	assert.Equal(t, object.Signature{Name: "carol", Email: "carol"}, parseSelfMergeDeveloper("carol"))
+}
+
+func TestSelfMergeConsume(t *testing.T) {
+	repository, commits := fixtureSelfMergeRepository(t)
+	path := filepath.Join(t.TempDir(), "reviews.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"`+commits[3].Hash.String()+
+		`": {"merged_by": "bob@example.com", "approvers": ["Carol <carol@example.com>", "alice"]}}`), 0o644))
+	sm := &SelfMergeAnalysis{}
+	require.NoError(t, sm.Configure(map[string]interface{}{
+		ConfigSelfMergeReviewsPath:                    path,
+		core.ConfigPipelineCommits:                    commits,
+		identity.FactIdentityDetectorResolveSignature: resolveSelfMergeDeveloper,
+	}))
+	require.NoError(t, sm.Initialize(repository))
+	treediff := &items.TreeDiff{}
+	require.NoError(t, treediff.Initialize(repository))
+	for i, commit := range commits {
+		deps := map[string]interface{}{
+			core.DependencyCommit:       commit,
+			core.DependencyIndex:        i,
+			identity.DependencyAuthor:   resolveSelfMergeDeveloper(commit.Author),
+			items.DependencyTreeChanges: object.Changes{},
+		}
+		if sm.mainline[commit.Hash] {
+			changes, err := treediff.Consume(deps)
+			require.NoError(t, err)
+			deps[items.DependencyTreeChanges] = changes[items.DependencyTreeChanges]
+		}
+		_, err := sm.Consume(deps)
+		require.NoError(t, err)
+	}
+	result := sm.Finalize().(SelfMergeResult)
+	assert.Equal(t, SelfMergeCounts{Changes: 5, SelfMerged: 2, SingleApprover: 1, Unknown: 1}, result.Total)
+	assert.Equal(t, map[string]SelfMergeCounts{
+		"2020-01": {Changes: 4, SelfMerged: 2, SingleApprover: 1},
+		"2020-02": {Changes: 1, Unknown: 1},
+	}, result.Months)
+	assert.Equal(t, map[string]SelfMergeCounts{
+		"/":   {Changes: 1, SelfMerged: 1},
+		"src": {Changes: 3, SingleApprover: 1, Unknown: 1},
+		"lib": {Changes: 1, SelfMerged: 1},
+	}, result.Subsystems)
+	assert.Equal(t, 0.5, result.Total.SelfMergeRate())
+	assert.Equal(t, 0.25, result.Total.SingleApproverRate())
+	assert.Equal(t, 0.0, SelfMergeCounts{Changes: 1, Unknown: 1}.SelfMergeRate())
+}
+
+func fixtureSelfMergeResult() SelfMergeResult {
+	return SelfMergeResult{
+		Total: SelfMergeCounts{Changes: 4, SelfMerged: 2, SingleApprover: 1, Unknown: 1},
+		Months: map[string]SelfMergeCounts{
+			"2020-01": {Changes: 3, SelfMerged: 2, SingleApprover: 1},
+			"2020-02": {Changes: 1, Unknown: 1},
+		},
+		Subsystems: map[string]SelfMergeCounts{
+			"/":   {Changes: 1, SelfMerged: 1},
+			"src": {Changes: 3, SelfMerged: 1, SingleApprover: 1, Unknown: 1},
+		},
+	}
+}
+
+func TestSelfMergeSerialize(t *testing.T) {
+	sm := &SelfMergeAnalysis{}
+	result := fixtureSelfMergeResult()
+	buffer := &bytes.Buffer{}
+	require.NoError(t, sm.Serialize(result, false, buffer))
+	assert.Equal(t, `  self_merge:
+    total: {changes: 4, self_merged: 2, single_approver: 1, unknown: 1, self_merge_rate: 0.6667, single_approver_rate: 0.3333}
+    months:
+      "2020-01": {changes: 3, self_merged: 2, single_approver: 1, unknown: 0, self_merge_rate: 0.6667, single_approver_rate: 0.3333}
+      "2020-02": {changes: 1, self_merged: 0, single_approver: 0, unknown: 1, self_merge_rate: 0.0000, single_approver_rate: 0.0000}
+    subsystems:
+      "/": {changes: 1, self_merged: 1, single_approver: 0, unknown: 0, self_merge_rate: 1.0000, single_approver_rate: 0.0000}
+      "src": {changes: 3, self_merged: 1, single_approver: 1, unknown: 1, self_merge_rate: 0.5000, single_approver_rate: 0.5000}
+`, buffer.String())
+	assert.Error(t, sm.Serialize(nil, false, buffer))
+
+	buffer.Reset()
+	require.NoError(t, sm.Serialize(result, true, buffer))
+	deserialized, err := sm.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestSelfMergeMergeAndAnonymize(t *testing.T) {
+	sm := &SelfMergeAnalysis{}
+	r1 := fixtureSelfMergeResult()
+	r2 := SelfMergeResult{
+		Total:      SelfMergeCounts{Changes: 1, SingleApprover: 1},
+		Months:     map[string]SelfMergeCounts{"2020-02": {Changes: 1, SingleApprover: 1}},
+		Subsystems: map[string]SelfMergeCounts{"lib": {Changes: 1, SingleApprover: 1}},
+	}
+	merged := sm.MergeResults(r1, r2, nil, nil).(SelfMergeResult)
+	assert.Equal(t, SelfMergeCounts{Changes: 5, SelfMerged: 2, SingleApprover: 2, Unknown: 1}, merged.Total)
+	assert.Equal(t, SelfMergeCounts{Changes: 2, SingleApprover: 1, Unknown: 1}, merged.Months["2020-02"])
+	assert.Len(t, merged.Subsystems, 3)
+
+	anonymized := sm.AnonymizePaths(r2, func(name string) string { return "x" + name }).(SelfMergeResult)
+	assert.Equal(t, map[string]SelfMergeCounts{"xlib": {Changes: 1, SingleApprover: 1}}, anonymized.Subsystems)
+}
@@ -84,6 +84,11 @@ func (shotness *ShotnessAnalysis) Flag() string {
 	return "shotness"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (shotness *ShotnessAnalysis) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Features returns the Hercules features required to deploy this leaf.
 func (shotness *ShotnessAnalysis) Features() []string {
 	return []string{}
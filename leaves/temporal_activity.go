@@ -35,6 +35,12 @@ type TemporalActivityAnalysis struct {
 	core.NoopMerger
 	core.OneShotMergeProcessor
 
+	// Timezone selects the time zone of the weekdays, hours, months and weeks:
+	// TemporalActivityTimezoneLocal (default) keeps the offsets of the commits,
+	// TemporalActivityTimezoneUTC converts them to UTC, TemporalActivityTimezoneAuthor converts
+	// them to the dominant offset of each author and any other value is an IANA time zone name.
+	Timezone string
+
 	// activities maps developer index to their temporal activity (aggregated totals)
 	activities map[int]*DeveloperTemporalActivity
 	// ticks maps tick index to developer index to temporal activity for that tick
@@ -43,10 +49,41 @@ type TemporalActivityAnalysis struct {
 	reversedPeopleDict []string
 	// tickSize references TicksSinceStart.TickSize
 	tickSize time.Duration
+	// location is the time zone to convert the commit times to, nil keeps them as they are
+	location *time.Location
+	// offsets maps developer index to UTC offset in minutes to the number of commits
+	offsets map[int]map[int]int
+	// days maps developer index to the set of the active calendar days since the Unix epoch
+	days map[int]map[int64]bool
+	// pending are the commits waiting for the dominant offsets in TemporalActivityTimezoneAuthor mode
+	pending []temporalActivityCommit
 
 	l core.Logger
 }
 
+const (
+	// ConfigTemporalActivityTimezone is the name of the option to set TemporalActivityAnalysis.Timezone.
+	ConfigTemporalActivityTimezone = "TemporalActivity.Timezone"
+	// TemporalActivityTimezoneLocal keeps the time zones of the commits.
+	TemporalActivityTimezoneLocal = "local"
+	// TemporalActivityTimezoneUTC converts the commit times to UTC.
+	TemporalActivityTimezoneUTC = "utc"
+	// TemporalActivityTimezoneAuthor converts the commit times to the dominant UTC offset of each author.
+	TemporalActivityTimezoneAuthor = "author"
+	// TemporalActivityWorkdayStart is the first hour of the working day.
+	TemporalActivityWorkdayStart = 9
+	// TemporalActivityWorkdayEnd is the first hour after the working day.
+	TemporalActivityWorkdayEnd = 18
+)
+
+// temporalActivityCommit is a commit consumed before the dominant offset of its author is known.
+type temporalActivityCommit struct {
+	Author int
+	Tick   int
+	When   time.Time
+	Lines  int
+}
+
 // TemporalDimension stores both commit counts and line change counts for a temporal dimension.
 type TemporalDimension struct {
 	Commits []int // Number of commits
@@ -71,6 +108,18 @@ type TemporalActivityTick struct {
 	Week    int // ISO week (0-52, week 1-53 stored as 0-52)
 }
 
+// TemporalActivitySummary stores the work-life balance indicators of one developer.
+// The hours and the days are in the time zone of TemporalActivityResult.Timezone.
+type TemporalActivitySummary struct {
+	// WeekendShare is the share of the commits on Saturdays and Sundays.
+	WeekendShare float32
+	// AfterHoursShare is the share of the commits before TemporalActivityWorkdayStart
+	// or from TemporalActivityWorkdayEnd.
+	AfterHoursShare float32
+	// LongestStreak is the longest run of consecutive calendar days with commits.
+	LongestStreak int
+}
+
 // TemporalActivityResult is returned by TemporalActivityAnalysis.Finalize().
 type TemporalActivityResult struct {
 	// Activities maps developer index to temporal activity (aggregated totals)
@@ -79,6 +128,12 @@ type TemporalActivityResult struct {
 	Ticks map[int]map[int]*TemporalActivityTick
 	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict
 	reversedPeopleDict []string
+	// Timezone of the weekdays, hours, months and weeks, see TemporalActivityAnalysis.Timezone
+	Timezone string
+	// Offsets maps developer index to the dominant UTC offset of their commits in minutes
+	Offsets map[int]int
+	// Summaries maps developer index to their work-life balance indicators
+	Summaries map[int]*TemporalActivitySummary
 	// tickSize is the duration of each tick
 	tickSize time.Duration
 }
@@ -104,7 +159,14 @@ func (ta *TemporalActivityAnalysis) Requires() []string {
 
 // ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
 func (ta *TemporalActivityAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
-	return []core.ConfigurationOption{}
+	return []core.ConfigurationOption{{
+		Name: ConfigTemporalActivityTimezone,
+		Description: "Time zone of the hours: \"local\" keeps the commit offsets, \"utc\", " +
+			"\"author\" uses the dominant offset of each author, or an IANA time zone name.",
+		Flag:    "temporal-activity-timezone",
+		Type:    core.StringConfigurationOption,
+		Default: TemporalActivityTimezoneLocal,
+	}}
 }
 
 // Configure sets the properties previously published by ListConfigurationOptions().
@@ -118,9 +180,31 @@ func (ta *TemporalActivityAnalysis) Configure(facts map[string]interface{}) erro
 	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
 		ta.tickSize = val
 	}
+	if val, exists := facts[ConfigTemporalActivityTimezone].(string); exists {
+		if _, err := temporalActivityLocation(val); err != nil {
+			return err
+		}
+		ta.Timezone = val
+	}
 	return nil
 }
 
+// temporalActivityLocation returns the time zone to convert the commit times to,
+// nil for TemporalActivityTimezoneLocal and TemporalActivityTimezoneAuthor.
+func temporalActivityLocation(timezone string) (*time.Location, error) {
+	switch timezone {
+	case "", TemporalActivityTimezoneLocal, TemporalActivityTimezoneAuthor:
+		return nil, nil
+	case TemporalActivityTimezoneUTC:
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("--temporal-activity-timezone: unknown time zone %q: %w", timezone, err)
+	}
+	return location, nil
+}
+
 // ConfigureUpstream configures the upstream dependencies.
 func (*TemporalActivityAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
 	return nil
@@ -131,6 +215,11 @@ func (ta *TemporalActivityAnalysis) Flag() string {
 	return "temporal-activity"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ta *TemporalActivityAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (ta *TemporalActivityAnalysis) Description() string {
 	return "Calculates commit and line change activity by weekday, hour, month, and ISO week."
@@ -150,6 +239,17 @@ func (ta *TemporalActivityAnalysis) Initialize(repository *git.Repository) error
 	ta.l = core.NewLogger()
 	ta.activities = map[int]*DeveloperTemporalActivity{}
 	ta.ticks = map[int]map[int]*TemporalActivityTick{}
+	ta.offsets = map[int]map[int]int{}
+	ta.days = map[int]map[int64]bool{}
+	ta.pending = nil
+	if ta.Timezone == "" {
+		ta.Timezone = TemporalActivityTimezoneLocal
+	}
+	location, err := temporalActivityLocation(ta.Timezone)
+	if err != nil {
+		return err
+	}
+	ta.location = location
 	ta.OneShotMergeProcessor.Initialize()
 	return nil
 }
@@ -164,8 +264,38 @@ func (ta *TemporalActivityAnalysis) Consume(deps map[string]interface{}) (map[st
 	author := deps[identity.DependencyAuthor].(int)
 	tick := deps[items.DependencyTick].(int)
 
-	// Extract temporal components from commit timestamp
+	// Calculate line changes
+	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	totalLines := 0
+	for _, stats := range lineStats {
+		totalLines += stats.Added + stats.Removed
+	}
+
 	commitTime := commit.Author.When
+	_, offset := commitTime.Zone()
+	authorOffsets := ta.offsets[author]
+	if authorOffsets == nil {
+		authorOffsets = map[int]int{}
+		ta.offsets[author] = authorOffsets
+	}
+	authorOffsets[offset/60]++
+	if ta.Timezone == TemporalActivityTimezoneAuthor {
+		// the dominant offset is known only after all the commits
+		ta.pending = append(ta.pending, temporalActivityCommit{
+			Author: author, Tick: tick, When: commitTime, Lines: totalLines,
+		})
+		return nil, nil
+	}
+	if ta.location != nil {
+		commitTime = commitTime.In(ta.location)
+	}
+	ta.record(author, tick, commitTime, totalLines)
+	return nil, nil
+}
+
+// record updates the activity of the author with the commit at the given time.
+func (ta *TemporalActivityAnalysis) record(author, tick int, commitTime time.Time, totalLines int) {
+	// Extract temporal components from commit timestamp
 	weekday := int(commitTime.Weekday()) // Sunday=0, Monday=1, ..., Saturday=6
 	hour := commitTime.Hour()            // 0-23
 	month := int(commitTime.Month()) - 1 // January=0, ..., December=11
@@ -191,13 +321,6 @@ func (ta *TemporalActivityAnalysis) Consume(deps map[string]interface{}) (map[st
 		ta.activities[author] = activity
 	}
 
-	// Calculate line changes
-	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
-	totalLines := 0
-	for _, stats := range lineStats {
-		totalLines += stats.Added + stats.Removed
-	}
-
 	// Update aggregated temporal counters with both commits and lines
 	activity.Weekdays.Commits[weekday] += 1
 	activity.Weekdays.Lines[weekday] += totalLines
@@ -211,6 +334,13 @@ func (ta *TemporalActivityAnalysis) Consume(deps map[string]interface{}) (map[st
 	activity.Weeks.Commits[weekIndex] += 1
 	activity.Weeks.Lines[weekIndex] += totalLines
 
+	days := ta.days[author]
+	if days == nil {
+		days = map[int64]bool{}
+		ta.days[author] = days
+	}
+	days[temporalActivityDay(commitTime)] = true
+
 	// Store per-tick data for date range filtering
 	tickDevs := ta.ticks[tick]
 	if tickDevs == nil {
@@ -230,20 +360,90 @@ func (ta *TemporalActivityAnalysis) Consume(deps map[string]interface{}) (map[st
 	}
 	tickActivity.Commits += 1
 	tickActivity.Lines += totalLines
-
-	return nil, nil
 }
 
 // Finalize returns the result of the analysis. Further Consume() calls are not expected.
 func (ta *TemporalActivityAnalysis) Finalize() interface{} {
+	offsets := ta.dominantOffsets()
+	for _, commit := range ta.pending {
+		zone := time.FixedZone("", offsets[commit.Author]*60)
+		ta.record(commit.Author, commit.Tick, commit.When.In(zone), commit.Lines)
+	}
+	ta.pending = nil
+	summaries := make(map[int]*TemporalActivitySummary, len(ta.activities))
+	for dev, activity := range ta.activities {
+		summaries[dev] = newTemporalActivitySummary(activity, longestTemporalActivityStreak(ta.days[dev]))
+	}
 	return TemporalActivityResult{
 		Activities:         ta.activities,
 		Ticks:              ta.ticks,
+		Timezone:           ta.Timezone,
+		Offsets:            offsets,
+		Summaries:          summaries,
 		reversedPeopleDict: ta.reversedPeopleDict,
 		tickSize:           ta.tickSize,
 	}
 }
 
+// temporalActivityDay returns the number of the calendar day of the time since the Unix epoch.
+func temporalActivityDay(when time.Time) int64 {
+	year, month, day := when.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60)
+}
+
+// longestTemporalActivityStreak returns the length of the longest run of consecutive days.
+func longestTemporalActivityStreak(days map[int64]bool) int {
+	longest := 0
+	for day := range days {
+		if days[day-1] {
+			continue // not the start of a run
+		}
+		length := 1
+		for days[day+int64(length)] {
+			length++
+		}
+		if length > longest {
+			longest = length
+		}
+	}
+	return longest
+}
+
+// newTemporalActivitySummary derives the work-life balance indicators from the histograms.
+func newTemporalActivitySummary(activity *DeveloperTemporalActivity, longestStreak int) *TemporalActivitySummary {
+	summary := &TemporalActivitySummary{LongestStreak: longestStreak}
+	total, afterHours := 0, 0
+	for hour, commits := range activity.Hours.Commits {
+		total += commits
+		if hour < TemporalActivityWorkdayStart || hour >= TemporalActivityWorkdayEnd {
+			afterHours += commits
+		}
+	}
+	if total == 0 {
+		return summary
+	}
+	weekend := activity.Weekdays.Commits[int(time.Saturday)] + activity.Weekdays.Commits[int(time.Sunday)]
+	summary.WeekendShare = float32(weekend) / float32(total)
+	summary.AfterHoursShare = float32(afterHours) / float32(total)
+	return summary
+}
+
+// dominantOffsets returns the most frequent UTC offset in minutes of each developer's commits.
+// The ties are resolved in favor of the smaller offset.
+func (ta *TemporalActivityAnalysis) dominantOffsets() map[int]int {
+	result := make(map[int]int, len(ta.offsets))
+	for dev, offsets := range ta.offsets {
+		best, bestCommits := 0, 0
+		for offset, commits := range offsets {
+			if commits > bestCommits || (commits == bestCommits && offset < best) {
+				best, bestCommits = offset, commits
+			}
+		}
+		result[dev] = best
+	}
+	return result
+}
+
 // Fork clones this pipeline item.
 func (ta *TemporalActivityAnalysis) Fork(n int) []core.PipelineItem {
 	return core.ForkSamePipelineItem(ta, n)
@@ -270,6 +470,9 @@ func (ta *TemporalActivityAnalysis) Deserialize(pbmessage []byte) (interface{},
 
 	activities := map[int]*DeveloperTemporalActivity{}
 	for devID, pbActivity := range message.Activities {
+		if pbActivity == nil {
+			pbActivity = &pb.DeveloperTemporalActivity{}
+		}
 		// Handle AuthorMissing special case
 		dev := int(devID)
 		if devID == -1 {
@@ -331,26 +534,54 @@ func (ta *TemporalActivityAnalysis) Deserialize(pbmessage []byte) (interface{},
 	ticks := map[int]map[int]*TemporalActivityTick{}
 	for tickID, pbTickDevs := range message.Ticks {
 		tickDevs := map[int]*TemporalActivityTick{}
-		for devID, pbTick := range pbTickDevs.Devs {
+		for devID, pbTick := range pbTickDevs.GetDevs() {
 			dev := int(devID)
 			if devID == -1 {
 				dev = core.AuthorMissing
 			}
 			tickDevs[dev] = &TemporalActivityTick{
-				Commits: int(pbTick.Commits),
-				Lines:   int(pbTick.Lines),
-				Weekday: int(pbTick.Weekday),
-				Hour:    int(pbTick.Hour),
-				Month:   int(pbTick.Month),
-				Week:    int(pbTick.Week),
+				Commits: int(pbTick.GetCommits()),
+				Lines:   int(pbTick.GetLines()),
+				Weekday: int(pbTick.GetWeekday()),
+				Hour:    int(pbTick.GetHour()),
+				Month:   int(pbTick.GetMonth()),
+				Week:    int(pbTick.GetWeek()),
 			}
 		}
 		ticks[int(tickID)] = tickDevs
 	}
 
+	offsets := make(map[int]int, len(message.Offsets))
+	for devID, offset := range message.Offsets {
+		dev := int(devID)
+		if devID == -1 {
+			dev = core.AuthorMissing
+		}
+		offsets[dev] = int(offset)
+	}
+	summaries := make(map[int]*TemporalActivitySummary, len(message.Summaries))
+	for devID, pbSummary := range message.Summaries {
+		dev := int(devID)
+		if devID == -1 {
+			dev = core.AuthorMissing
+		}
+		summaries[dev] = &TemporalActivitySummary{
+			WeekendShare:    pbSummary.GetWeekendShare(),
+			AfterHoursShare: pbSummary.GetAfterHoursShare(),
+			LongestStreak:   int(pbSummary.GetLongestStreak()),
+		}
+	}
+	timezone := message.Timezone
+	if timezone == "" {
+		timezone = TemporalActivityTimezoneLocal
+	}
+
 	result := TemporalActivityResult{
 		Activities:         activities,
 		Ticks:              ticks,
+		Timezone:           timezone,
+		Offsets:            offsets,
+		Summaries:          summaries,
 		reversedPeopleDict: message.DevIndex,
 		tickSize:           time.Duration(message.TickSize),
 	}
@@ -359,6 +590,9 @@ func (ta *TemporalActivityAnalysis) Deserialize(pbmessage []byte) (interface{},
 
 func (ta *TemporalActivityAnalysis) serializeText(result *TemporalActivityResult, writer io.Writer) {
 	fmt.Fprintln(writer, "  temporal_activity:")
+	if result.Timezone != "" {
+		fmt.Fprintf(writer, "    timezone: %s\n", yaml.SafeString(result.Timezone))
+	}
 	fmt.Fprintln(writer, "    activities:")
 
 	// Sort developers for consistent output
@@ -450,6 +684,40 @@ func (ta *TemporalActivityAnalysis) serializeText(result *TemporalActivityResult
 		fmt.Fprintln(writer, "]")
 	}
 
+	if len(result.Offsets) > 0 {
+		fmt.Fprintln(writer, "    offsets:")
+		devs = devs[:0]
+		for dev := range result.Offsets {
+			devs = append(devs, dev)
+		}
+		sort.Ints(devs)
+		for _, dev := range devs {
+			devID := dev
+			if dev == core.AuthorMissing {
+				devID = -1
+			}
+			fmt.Fprintf(writer, "      %d: %d\n", devID, result.Offsets[dev])
+		}
+	}
+
+	if len(result.Summaries) > 0 {
+		fmt.Fprintln(writer, "    summaries:")
+		devs = devs[:0]
+		for dev := range result.Summaries {
+			devs = append(devs, dev)
+		}
+		sort.Ints(devs)
+		for _, dev := range devs {
+			devID := dev
+			if dev == core.AuthorMissing {
+				devID = -1
+			}
+			summary := result.Summaries[dev]
+			fmt.Fprintf(writer, "      %d: {weekend_share: %.4f, after_hours_share: %.4f, longest_streak: %d}\n",
+				devID, summary.WeekendShare, summary.AfterHoursShare, summary.LongestStreak)
+		}
+	}
+
 	// Output people dictionary
 	fmt.Fprintln(writer, "    people:")
 	for _, person := range result.reversedPeopleDict {
@@ -460,7 +728,28 @@ func (ta *TemporalActivityAnalysis) serializeText(result *TemporalActivityResult
 func (ta *TemporalActivityAnalysis) serializeBinary(result *TemporalActivityResult, writer io.Writer) error {
 	message := pb.TemporalActivityResults{}
 	message.DevIndex = result.reversedPeopleDict
+	message.Timezone = result.Timezone
 	message.Activities = make(map[int32]*pb.DeveloperTemporalActivity)
+	message.Offsets = make(map[int32]int32, len(result.Offsets))
+	for dev, offset := range result.Offsets {
+		devID := int32(dev)
+		if dev == core.AuthorMissing {
+			devID = -1
+		}
+		message.Offsets[devID] = int32(offset)
+	}
+	message.Summaries = make(map[int32]*pb.TemporalActivitySummary, len(result.Summaries))
+	for dev, summary := range result.Summaries {
+		devID := int32(dev)
+		if dev == core.AuthorMissing {
+			devID = -1
+		}
+		message.Summaries[devID] = &pb.TemporalActivitySummary{
+			WeekendShare:    summary.WeekendShare,
+			AfterHoursShare: summary.AfterHoursShare,
+			LongestStreak:   int32(summary.LongestStreak),
+		}
+	}
 
 	for dev, activity := range result.Activities {
 		devID := int32(dev)
@@ -568,10 +857,21 @@ func (ta *TemporalActivityAnalysis) MergeResults(
 	merged := TemporalActivityResult{
 		Activities:         make(map[int]*DeveloperTemporalActivity),
 		Ticks:              make(map[int]map[int]*TemporalActivityTick),
+		Timezone:           tar1.Timezone,
+		Offsets:            make(map[int]int),
+		Summaries:          make(map[int]*TemporalActivitySummary),
 		reversedPeopleDict: tar1.reversedPeopleDict, // Use first dict, should be same
 		tickSize:           tar1.tickSize,
 	}
 
+	// The commit counts per offset are not kept, so the first offset wins
+	for dev, offset := range tar2.Offsets {
+		merged.Offsets[dev] = offset
+	}
+	for dev, offset := range tar1.Offsets {
+		merged.Offsets[dev] = offset
+	}
+
 	// Merge activities from both results
 	allDevs := make(map[int]bool)
 	for dev := range tar1.Activities {
@@ -630,6 +930,15 @@ func (ta *TemporalActivityAnalysis) MergeResults(
 		}
 
 		merged.Activities[dev] = mergedActivity
+
+		// The active days are not kept, so the longest streak is the longest of the two
+		longestStreak := 0
+		for _, summaries := range []map[int]*TemporalActivitySummary{tar1.Summaries, tar2.Summaries} {
+			if summary, exists := summaries[dev]; exists && summary.LongestStreak > longestStreak {
+				longestStreak = summary.LongestStreak
+			}
+		}
+		merged.Summaries[dev] = newTemporalActivitySummary(mergedActivity, longestStreak)
 	}
 
 	// Merge ticks from both results
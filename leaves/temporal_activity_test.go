@@ -22,7 +22,9 @@ func TestTemporalActivityMeta(t *testing.T) {
 		assert.Contains(t, ta.Requires(), name)
 	}
 	opts := ta.ListConfigurationOptions()
-	assert.Len(t, opts, 0)
+	assert.Len(t, opts, 1)
+	assert.Equal(t, ConfigTemporalActivityTimezone, opts[0].Name)
+	assert.Equal(t, TemporalActivityTimezoneLocal, opts[0].Default)
 	assert.Equal(t, ta.Flag(), "temporal-activity")
 	assert.Equal(t, ta.Description(), "Calculates commit and line change activity by weekday, hour, month, and ISO week.")
 }
@@ -122,6 +124,131 @@ func TestTemporalActivityConsume(t *testing.T) {
 	assert.Equal(t, 14, tickData.Hour)
 }
 
+func TestTemporalActivityTimezone(t *testing.T) {
+	ta := TemporalActivityAnalysis{}
+	assert.Nil(t, ta.Configure(map[string]interface{}{ConfigTemporalActivityTimezone: "Europe/Berlin"}))
+	assert.Equal(t, "Europe/Berlin", ta.Timezone)
+	assert.NotNil(t, ta.Configure(map[string]interface{}{ConfigTemporalActivityTimezone: "Mars/Olympus"}))
+	assert.Equal(t, "Europe/Berlin", ta.Timezone)
+
+	plus2 := time.FixedZone("", 2*3600)
+	minus5 := time.FixedZone("", -5*3600)
+	commits := []struct {
+		author int
+		time   time.Time
+	}{
+		// Tuesday 23:30 at +02:00 is Tuesday 21:30 UTC
+		{0, time.Date(2023, time.January, 3, 23, 30, 0, 0, plus2)},
+		{0, time.Date(2023, time.January, 4, 9, 0, 0, 0, plus2)},
+		// a commit while travelling, Wednesday 20:00 at -05:00 is Thursday 03:00 at +02:00
+		{0, time.Date(2023, time.January, 4, 20, 0, 0, 0, minus5)},
+		{1, time.Date(2023, time.January, 4, 20, 0, 0, 0, minus5)},
+	}
+	consume := func(timezone string) TemporalActivityResult {
+		ta := TemporalActivityAnalysis{Timezone: timezone}
+		assert.Nil(t, ta.Initialize(test.Repository))
+		for i, c := range commits {
+			_, err := ta.Consume(map[string]interface{}{
+				core.DependencyIsMerge:    false,
+				core.DependencyCommit:     &object.Commit{Author: object.Signature{When: c.time}},
+				identity.DependencyAuthor: c.author,
+				items.DependencyTick:      i,
+				items.DependencyLineStats: map[object.ChangeEntry]items.LineStats{},
+			})
+			assert.Nil(t, err)
+		}
+		return ta.Finalize().(TemporalActivityResult)
+	}
+
+	result := consume("")
+	assert.Equal(t, TemporalActivityTimezoneLocal, result.Timezone)
+	assert.Equal(t, map[int]int{0: 120, 1: -300}, result.Offsets)
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[23])
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[20])
+	assert.Equal(t, 20, result.Ticks[2][0].Hour)
+
+	result = consume(TemporalActivityTimezoneUTC)
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[21])
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[7])
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[1])
+	assert.Equal(t, 4, result.Ticks[2][0].Weekday)
+
+	result = consume(TemporalActivityTimezoneAuthor)
+	assert.Equal(t, TemporalActivityTimezoneAuthor, result.Timezone)
+	assert.Equal(t, []int{0, 0, 1, 1, 1, 0, 0}, result.Activities[0].Weekdays.Commits)
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[23])
+	assert.Equal(t, 1, result.Activities[0].Hours.Commits[3])
+	assert.Equal(t, 3, result.Ticks[2][0].Hour)
+	assert.Equal(t, 4, result.Ticks[2][0].Weekday)
+	assert.Equal(t, 20, result.Ticks[3][1].Hour)
+
+	result = consume("Asia/Tokyo")
+	// 21:30 UTC is 06:30 on Wednesday in Tokyo
+	assert.Equal(t, 3, result.Ticks[0][0].Weekday)
+	assert.Equal(t, 6, result.Ticks[0][0].Hour)
+
+	var buf bytes.Buffer
+	ta = TemporalActivityAnalysis{}
+	result = consume(TemporalActivityTimezoneAuthor)
+	assert.Nil(t, ta.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(), "  temporal_activity:\n    timezone: \"author\"\n")
+	assert.Contains(t, buf.String(), "    offsets:\n      0: 120\n      1: -300\n")
+	buf.Reset()
+	assert.Nil(t, ta.Serialize(result, true, &buf))
+	restored, err := ta.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.Timezone, restored.(TemporalActivityResult).Timezone)
+	assert.Equal(t, result.Offsets, restored.(TemporalActivityResult).Offsets)
+}
+
+func TestTemporalActivitySummaries(t *testing.T) {
+	ta := TemporalActivityAnalysis{}
+	assert.Nil(t, ta.Initialize(test.Repository))
+	times := []time.Time{
+		time.Date(2023, time.January, 5, 10, 0, 0, 0, time.UTC),  // Thursday
+		time.Date(2023, time.January, 5, 22, 0, 0, 0, time.UTC),  // Thursday, after hours
+		time.Date(2023, time.January, 6, 9, 0, 0, 0, time.UTC),   // Friday
+		time.Date(2023, time.January, 7, 8, 59, 0, 0, time.UTC),  // Saturday, after hours
+		time.Date(2023, time.January, 10, 18, 0, 0, 0, time.UTC), // Tuesday, after hours
+	}
+	for i, when := range times {
+		_, err := ta.Consume(map[string]interface{}{
+			core.DependencyIsMerge:    false,
+			core.DependencyCommit:     &object.Commit{Author: object.Signature{When: when}},
+			identity.DependencyAuthor: 0,
+			items.DependencyTick:      i,
+			items.DependencyLineStats: map[object.ChangeEntry]items.LineStats{},
+		})
+		assert.Nil(t, err)
+	}
+	result := ta.Finalize().(TemporalActivityResult)
+	assert.Equal(t, map[int]*TemporalActivitySummary{
+		0: {WeekendShare: 0.2, AfterHoursShare: 0.6, LongestStreak: 3},
+	}, result.Summaries)
+
+	var buf bytes.Buffer
+	assert.Nil(t, ta.Serialize(result, false, &buf))
+	assert.Contains(t, buf.String(),
+		"    summaries:\n      0: {weekend_share: 0.2000, after_hours_share: 0.6000, longest_streak: 3}\n")
+	buf.Reset()
+	assert.Nil(t, ta.Serialize(result, true, &buf))
+	restored, err := ta.Deserialize(buf.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, result.Summaries, restored.(TemporalActivityResult).Summaries)
+
+	other := TemporalActivityResult{
+		Activities: map[int]*DeveloperTemporalActivity{0: result.Activities[0]},
+		Summaries:  map[int]*TemporalActivitySummary{0: {LongestStreak: 4}},
+	}
+	merged := ta.MergeResults(result, other, &core.CommonAnalysisResult{},
+		&core.CommonAnalysisResult{}).(TemporalActivityResult)
+	assert.Equal(t, &TemporalActivitySummary{WeekendShare: 0.2, AfterHoursShare: 0.6, LongestStreak: 4},
+		merged.Summaries[0])
+	assert.Equal(t, &TemporalActivitySummary{}, newTemporalActivitySummary(&DeveloperTemporalActivity{
+		Weekdays: newTemporalDimension(7), Hours: newTemporalDimension(24),
+	}, 0))
+}
+
 func TestTemporalActivityMultipleCommits(t *testing.T) {
 	ta := TemporalActivityAnalysis{}
 	assert.Nil(t, ta.Initialize(test.Repository))
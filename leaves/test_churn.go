@@ -0,0 +1,454 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigTestChurnRatio is the name of the option to set the minimum ratio of the test file
+	// commits to the production file commits which marks the test as brittle.
+	ConfigTestChurnRatio = "TestChurn.Ratio"
+	// ConfigTestChurnMinCommits is the name of the option to set the minimum number of the test
+	// file commits to mark it as brittle.
+	ConfigTestChurnMinCommits = "TestChurn.MinCommits"
+)
+
+var (
+	jvmTestFileRe   = regexp.MustCompile(`^(.+?)(Test|Tests|IT|Spec)\.(java|kt|scala|groovy)$`)
+	jsTestFileRe    = regexp.MustCompile(`^(.+)\.(test|spec)\.(js|jsx|mjs|cjs|ts|tsx)$`)
+	testDirectories = map[string]bool{
+		"test": true, "tests": true, "__tests__": true, "spec": true, "testing": true,
+	}
+)
+
+// testedFiles returns whether the file contains tests and the candidate paths of the production
+// file it covers, in the order of preference. The tests in the test directories which do not
+// follow any naming convention have no candidates.
+func testedFiles(name string) (candidates []string, isTest bool) {
+	dir, base := path.Split(name)
+	dir = strings.TrimSuffix(dir, "/")
+	parent, dirBase := path.Split(dir)
+	inTestDir := testDirectories[dirBase]
+	// the same directory and, if the tests live in a separate directory, the parent
+	addCandidates := func(file string) {
+		candidates = append(candidates, path.Join(dir, file))
+		if inTestDir {
+			candidates = append(candidates, path.Join(parent, file))
+		}
+	}
+	switch {
+	case strings.HasSuffix(base, "_test.go"):
+		addCandidates(strings.TrimSuffix(base, "_test.go") + ".go")
+	case strings.HasSuffix(base, ".py") && (strings.HasPrefix(base, "test_") || strings.HasSuffix(base, "_test.py")):
+		file := strings.TrimSuffix(strings.TrimPrefix(base, "test_"), "_test.py")
+		addCandidates(strings.TrimSuffix(file, ".py") + ".py")
+	case strings.HasSuffix(base, "_spec.rb") || strings.HasSuffix(base, "_test.rb"):
+		file := strings.TrimSuffix(strings.TrimSuffix(base, "_spec.rb"), "_test.rb") + ".rb"
+		addCandidates(file)
+		for _, prefix := range []string{"spec/", "test/"} {
+			if strings.HasPrefix(dir+"/", prefix) {
+				candidates = append(candidates, path.Join("lib", strings.TrimPrefix(dir+"/", prefix), file))
+			}
+		}
+	default:
+		if match := jsTestFileRe.FindStringSubmatch(base); match != nil {
+			addCandidates(match[1] + "." + match[3])
+		} else if match := jvmTestFileRe.FindStringSubmatch(base); match != nil {
+			file := match[1] + "." + match[3]
+			if strings.Contains("/"+dir+"/", "/src/test/") {
+				candidates = append(candidates, path.Join(
+					strings.Replace("/"+dir, "/src/test/", "/src/main/", 1), file)[1:])
+			}
+			addCandidates(file)
+		} else {
+			for _, component := range strings.Split(dir, "/") {
+				if testDirectories[component] {
+					return nil, true
+				}
+			}
+			return nil, strings.Contains("/"+dir+"/", "/src/test/")
+		}
+	}
+	return candidates, true
+}
+
+// testChurnFileStats are the number of commits and the churned lines of a file.
+type testChurnFileStats struct {
+	Commits int
+	Churn   int
+}
+
+// TestChurnTick is the churn of the test and production files during a tick.
+type TestChurnTick struct {
+	TestChurn       int
+	ProductionChurn int
+}
+
+// TestChurnSuite is the test file paired with the production file it covers.
+type TestChurnSuite struct {
+	TestFile          string
+	ProductionFile    string
+	TestCommits       int
+	ProductionCommits int
+	TestChurn         int
+	ProductionChurn   int
+	// Ratio is TestCommits divided by ProductionCommits.
+	Ratio float64
+	// Brittle indicates whether the test changes far more often than the code under test.
+	Brittle bool
+}
+
+// TestChurnResult is returned by TestChurnAnalysis.Finalize().
+type TestChurnResult struct {
+	// Ticks maps ticks to the churn of the test and production files.
+	Ticks map[int]TestChurnTick
+	// Suites are the alive test files with the found production files, sorted by Ratio
+	// in descending order.
+	Suites []TestChurnSuite
+	// RatioThreshold is the minimum Ratio of the brittle suites.
+	RatioThreshold float64
+	// MinCommits is the minimum TestCommits of the brittle suites.
+	MinCommits int
+
+	tickSize time.Duration
+}
+
+// TestChurnAnalysis compares the churn of the test files with the churn of the production files
+// they cover to find the test suites which change far more often than the code under test -
+// a proxy for flaky and brittle tests.
+type TestChurnAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+
+	// RatioThreshold is the minimum ratio of the test commits to the production commits
+	// to mark the suite as brittle.
+	RatioThreshold float64
+	// MinCommits is the minimum number of the test commits to mark the suite as brittle.
+	MinCommits int
+
+	// files maps the alive file names to their stats.
+	files    map[string]*testChurnFileStats
+	ticks    map[int]*TestChurnTick
+	tickSize time.Duration
+
+	l core.Logger
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (tc *TestChurnAnalysis) Name() string {
+	return "TestChurn"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (tc *TestChurnAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (tc *TestChurnAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges, items.DependencyLineStats, items.DependencyTick}
+}
+
+// Flag for the command line switch which enables this analysis.
+func (tc *TestChurnAnalysis) Flag() string {
+	return "test-churn"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (tc *TestChurnAnalysis) Cost() core.CostClass {
+	return core.CostMedium
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (tc *TestChurnAnalysis) Description() string {
+	return "Compares the churn of the test files with the production files they cover and flags " +
+		"the test suites which change far more often than the code under test."
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (tc *TestChurnAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{
+		{
+			Name:        ConfigTestChurnRatio,
+			Description: "Minimum ratio of the test file commits to the production file commits to flag the test.",
+			Flag:        "test-churn-ratio",
+			Type:        core.FloatConfigurationOption,
+			Default:     float32(3),
+		}, {
+			Name:        ConfigTestChurnMinCommits,
+			Description: "Minimum number of the test file commits to flag the test.",
+			Flag:        "test-churn-min-commits",
+			Type:        core.IntConfigurationOption,
+			Default:     5,
+		},
+	}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (tc *TestChurnAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		tc.l = l
+	}
+	if val, exists := facts[ConfigTestChurnRatio].(float32); exists {
+		tc.RatioThreshold = float64(val)
+	}
+	if val, exists := facts[ConfigTestChurnMinCommits].(int); exists {
+		tc.MinCommits = val
+	}
+	if val, exists := facts[items.FactTickSize].(time.Duration); exists {
+		tc.tickSize = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*TestChurnAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (tc *TestChurnAnalysis) Initialize(repository *git.Repository) error {
+	tc.l = core.NewLogger()
+	tc.files = map[string]*testChurnFileStats{}
+	tc.ticks = map[int]*TestChurnTick{}
+	tc.OneShotMergeProcessor.Initialize()
+	if tc.RatioThreshold <= 0 {
+		tc.RatioThreshold = 3
+	}
+	if tc.MinCommits <= 0 {
+		tc.MinCommits = 5
+	}
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (tc *TestChurnAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !tc.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	tick := deps[items.DependencyTick].(int)
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	lineStats := deps[items.DependencyLineStats].(map[object.ChangeEntry]items.LineStats)
+	tickStats := tc.ticks[tick]
+	if tickStats == nil && len(treeDiff) > 0 {
+		tickStats = &TestChurnTick{}
+		tc.ticks[tick] = tickStats
+	}
+	for _, change := range treeDiff {
+		action, err := change.Action()
+		if err != nil {
+			return nil, err
+		}
+		entry := change.To
+		if action == merkletrie.Delete {
+			entry = change.From
+		}
+		stats := lineStats[entry]
+		churn := stats.Added + stats.Removed + stats.Changed
+		if _, isTest := testedFiles(entry.Name); isTest {
+			tickStats.TestChurn += churn
+		} else {
+			tickStats.ProductionChurn += churn
+		}
+		if action == merkletrie.Delete {
+			delete(tc.files, change.From.Name)
+			continue
+		}
+		fileStats := tc.files[change.From.Name]
+		if action == merkletrie.Insert || fileStats == nil {
+			fileStats = &testChurnFileStats{}
+		} else if change.From.Name != change.To.Name {
+			delete(tc.files, change.From.Name)
+		}
+		fileStats.Commits++
+		fileStats.Churn += churn
+		tc.files[change.To.Name] = fileStats
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (tc *TestChurnAnalysis) Finalize() interface{} {
+	result := TestChurnResult{
+		Ticks:          make(map[int]TestChurnTick, len(tc.ticks)),
+		RatioThreshold: tc.RatioThreshold,
+		MinCommits:     tc.MinCommits,
+		tickSize:       tc.tickSize,
+	}
+	for tick, stats := range tc.ticks {
+		result.Ticks[tick] = *stats
+	}
+	for name, stats := range tc.files {
+		candidates, isTest := testedFiles(name)
+		if !isTest {
+			continue
+		}
+		for _, candidate := range candidates {
+			production, exists := tc.files[candidate]
+			if !exists || candidate == name {
+				continue
+			}
+			suite := TestChurnSuite{
+				TestFile:          name,
+				ProductionFile:    candidate,
+				TestCommits:       stats.Commits,
+				ProductionCommits: production.Commits,
+				TestChurn:         stats.Churn,
+				ProductionChurn:   production.Churn,
+				Ratio:             float64(stats.Commits) / float64(production.Commits),
+			}
+			suite.Brittle = suite.Ratio >= tc.RatioThreshold && suite.TestCommits >= tc.MinCommits
+			result.Suites = append(result.Suites, suite)
+			break
+		}
+	}
+	sort.Slice(result.Suites, func(i, j int) bool {
+		if result.Suites[i].Ratio != result.Suites[j].Ratio {
+			return result.Suites[i].Ratio > result.Suites[j].Ratio
+		}
+		return result.Suites[i].TestFile < result.Suites[j].TestFile
+	})
+	return result
+}
+
+// Fork clones this pipeline item.
+func (tc *TestChurnAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(tc, n)
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (tc *TestChurnAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	churnResult, ok := result.(TestChurnResult)
+	if !ok {
+		return fmt.Errorf("result is not a TestChurnResult: '%v'", result)
+	}
+	if binary {
+		return tc.serializeBinary(&churnResult, writer)
+	}
+	tc.serializeText(&churnResult, writer)
+	return nil
+}
+
+// serializeText outputs YAML format
+func (tc *TestChurnAnalysis) serializeText(result *TestChurnResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  test_churn:")
+	fmt.Fprintf(writer, "    ratio_threshold: %.2f\n", result.RatioThreshold)
+	fmt.Fprintf(writer, "    min_commits: %d\n", result.MinCommits)
+	fmt.Fprintf(writer, "    tick_size: %d\n", int(result.tickSize.Seconds()))
+	fmt.Fprintln(writer, "    # [test churn, production churn]")
+	fmt.Fprintln(writer, "    ticks:")
+	ticks := make([]int, 0, len(result.Ticks))
+	for tick := range result.Ticks {
+		ticks = append(ticks, tick)
+	}
+	sort.Ints(ticks)
+	for _, tick := range ticks {
+		stats := result.Ticks[tick]
+		fmt.Fprintf(writer, "      %d: [%d, %d]\n", tick, stats.TestChurn, stats.ProductionChurn)
+	}
+	fmt.Fprintln(writer, "    suites:")
+	for _, suite := range result.Suites {
+		fmt.Fprintf(writer, "      - test: %s\n", yaml.SafeString(suite.TestFile))
+		fmt.Fprintf(writer, "        production: %s\n", yaml.SafeString(suite.ProductionFile))
+		fmt.Fprintf(writer, "        commits: [%d, %d]\n", suite.TestCommits, suite.ProductionCommits)
+		fmt.Fprintf(writer, "        churn: [%d, %d]\n", suite.TestChurn, suite.ProductionChurn)
+		fmt.Fprintf(writer, "        ratio: %.4f\n", suite.Ratio)
+		fmt.Fprintf(writer, "        brittle: %t\n", suite.Brittle)
+	}
+}
+
+// serializeBinary outputs Protocol Buffers format
+func (tc *TestChurnAnalysis) serializeBinary(result *TestChurnResult, writer io.Writer) error {
+	message := pb.TestChurnResults{
+		Ticks:          make(map[int32]*pb.TestChurnTick, len(result.Ticks)),
+		Suites:         make([]*pb.TestChurnSuite, len(result.Suites)),
+		RatioThreshold: float32(result.RatioThreshold),
+		MinCommits:     int32(result.MinCommits),
+		TickSize:       int64(result.tickSize),
+	}
+	for tick, stats := range result.Ticks {
+		message.Ticks[int32(tick)] = &pb.TestChurnTick{
+			TestChurn:       int32(stats.TestChurn),
+			ProductionChurn: int32(stats.ProductionChurn),
+		}
+	}
+	for i, suite := range result.Suites {
+		message.Suites[i] = &pb.TestChurnSuite{
+			TestFile:          suite.TestFile,
+			ProductionFile:    suite.ProductionFile,
+			TestCommits:       int32(suite.TestCommits),
+			ProductionCommits: int32(suite.ProductionCommits),
+			TestChurn:         int32(suite.TestChurn),
+			ProductionChurn:   int32(suite.ProductionChurn),
+			Ratio:             float32(suite.Ratio),
+			Brittle:           suite.Brittle,
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+// Deserialize converts the specified protobuf bytes to TestChurnResult.
+func (tc *TestChurnAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TestChurnResults{}
+	err := proto.Unmarshal(pbmessage, &message)
+	if err != nil {
+		return nil, err
+	}
+	result := TestChurnResult{
+		Ticks:          make(map[int]TestChurnTick, len(message.Ticks)),
+		Suites:         make([]TestChurnSuite, len(message.Suites)),
+		RatioThreshold: float64(message.RatioThreshold),
+		MinCommits:     int(message.MinCommits),
+		tickSize:       time.Duration(message.TickSize),
+	}
+	for tick, stats := range message.Ticks {
+		result.Ticks[int(tick)] = TestChurnTick{
+			TestChurn:       int(stats.GetTestChurn()),
+			ProductionChurn: int(stats.GetProductionChurn()),
+		}
+	}
+	for i, suite := range message.Suites {
+		result.Suites[i] = TestChurnSuite{
+			TestFile:          suite.TestFile,
+			ProductionFile:    suite.ProductionFile,
+			TestCommits:       int(suite.TestCommits),
+			ProductionCommits: int(suite.ProductionCommits),
+			TestChurn:         int(suite.TestChurn),
+			ProductionChurn:   int(suite.ProductionChurn),
+			Ratio:             float64(suite.Ratio),
+			Brittle:           suite.Brittle,
+		}
+	}
+	return result, nil
+}
+
+func init() {
+	core.Registry.Register(&TestChurnAnalysis{})
+}
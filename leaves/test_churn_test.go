@@ -0,0 +1,110 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestedFiles(t *testing.T) {
+	for name, expected := range map[string][]string{
+		"pkg/a_test.go":                       {"pkg/a.go"},
+		"a_test.go":                           {"a.go"},
+		"app/test_views.py":                   {"app/views.py"},
+		"app/tests/views_test.py":             {"app/tests/views.py", "app/views.py"},
+		"src/__tests__/button.test.tsx":       {"src/__tests__/button.tsx", "src/button.tsx"},
+		"src/button.spec.js":                  {"src/button.js"},
+		"src/test/java/org/x/ParserTest.java": {"src/main/java/org/x/Parser.java", "src/test/java/org/x/Parser.java"},
+		"spec/models/user_spec.rb":            {"spec/models/user.rb", "lib/models/user.rb"},
+	} {
+		candidates, isTest := testedFiles(name)
+		assert.True(t, isTest, name)
+		assert.Equal(t, expected, candidates, name)
+	}
+	candidates, isTest := testedFiles("tests/integration/fixtures.py")
+	assert.True(t, isTest)
+	assert.Nil(t, candidates)
+	for _, name := range []string{"pkg/a.go", "README.md", "src/testing.py", "lib/contest.rb"} {
+		_, isTest := testedFiles(name)
+		assert.False(t, isTest, name)
+	}
+}
+
+func TestTestChurnMeta(t *testing.T) {
+	tc := &TestChurnAnalysis{}
+	assert.Equal(t, "TestChurn", tc.Name())
+	assert.Len(t, tc.Provides(), 0)
+	assert.Equal(t, []string{items.DependencyTreeChanges, items.DependencyLineStats, items.DependencyTick},
+		tc.Requires())
+	assert.Equal(t, "test-churn", tc.Flag())
+	assert.Equal(t, core.CostMedium, tc.Cost())
+	assert.Len(t, tc.ListConfigurationOptions(), 2)
+	require.NoError(t, tc.Configure(map[string]interface{}{
+		ConfigTestChurnRatio:      float32(2),
+		ConfigTestChurnMinCommits: 3,
+		items.FactTickSize:        time.Hour,
+	}))
+	assert.Equal(t, 2.0, tc.RatioThreshold)
+	assert.Equal(t, 3, tc.MinCommits)
+	assert.Equal(t, time.Hour, tc.tickSize)
+	summoned := core.Registry.Summon(tc.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, "TestChurn", summoned[0].Name())
+}
+
+func TestTestChurnConsume(t *testing.T) {
+	tc := &TestChurnAnalysis{MinCommits: 3}
+	require.NoError(t, tc.Initialize(nil))
+	commit := newCommentDensityCommit().
+		change("", "pkg/a.go", "", "Go", 10).
+		change("", "pkg/a_test.go", "", "Go", 20).
+		change("", "pkg/b.go", "", "Go", 5).
+		change("", "pkg/b_test.go", "", "Go", 5)
+	_, err := tc.Consume(commit.deps(0))
+	require.NoError(t, err)
+	for tick := 1; tick <= 3; tick++ {
+		commit = newCommentDensityCommit().change("pkg/a_test.go", "pkg/a_test.go", "", "Go", 2)
+		_, err = tc.Consume(commit.deps(tick))
+		require.NoError(t, err)
+	}
+	commit = newCommentDensityCommit().
+		change("pkg/b.go", "pkg/c.go", "", "Go", 1).
+		change("pkg/b_test.go", "pkg/c_test.go", "", "Go", 1)
+	_, err = tc.Consume(commit.deps(4))
+	require.NoError(t, err)
+
+	result := tc.Finalize().(TestChurnResult)
+	assert.Equal(t, TestChurnTick{TestChurn: 25, ProductionChurn: 15}, result.Ticks[0])
+	assert.Equal(t, TestChurnTick{TestChurn: 2}, result.Ticks[3])
+	assert.Equal(t, TestChurnTick{TestChurn: 1, ProductionChurn: 1}, result.Ticks[4])
+	require.Len(t, result.Suites, 2)
+	assert.Equal(t, TestChurnSuite{
+		TestFile: "pkg/a_test.go", ProductionFile: "pkg/a.go", TestCommits: 4, ProductionCommits: 1,
+		TestChurn: 26, ProductionChurn: 10, Ratio: 4, Brittle: true,
+	}, result.Suites[0])
+	assert.Equal(t, TestChurnSuite{
+		TestFile: "pkg/c_test.go", ProductionFile: "pkg/c.go", TestCommits: 2, ProductionCommits: 2,
+		TestChurn: 6, ProductionChurn: 6, Ratio: 1,
+	}, result.Suites[1])
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, tc.Serialize(result, false, buffer))
+	assert.Contains(t, buffer.String(), `      - test: "pkg/a_test.go"
+        production: "pkg/a.go"
+        commits: [4, 1]
+        churn: [26, 10]
+        ratio: 4.0000
+        brittle: true
+`)
+	buffer.Reset()
+	require.NoError(t, tc.Serialize(result, true, buffer))
+	restored, err := tc.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, restored)
+	assert.Error(t, tc.Serialize(nil, false, buffer))
+}
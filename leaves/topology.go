@@ -0,0 +1,466 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigTopologyManifests is the name of the option to set TopologyAnalysis.Manifests.
+	ConfigTopologyManifests = "Topology.Manifests"
+)
+
+// TopologyManifests are the default names of the files which mark the root directory of a project.
+var TopologyManifests = []string{
+	"go.mod", "package.json", "Cargo.toml", "pom.xml", "build.gradle", "build.gradle.kts",
+	"pyproject.toml", "setup.py", "composer.json", "Gemfile", "mix.exs", "CMakeLists.txt",
+}
+
+// TopologyAnalysis detects the projects of a monorepo by their manifest files in the last commit
+// and counts how often the projects change together. The result is the machine-readable map of
+// the repository: the projects, their sizes and the co-change edges between them.
+type TopologyAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Manifests are the names of the files which mark the root directory of a project.
+	Manifests []string
+
+	// dirs are the directories of the changed files, indexed by dirIndex.
+	dirs     []string
+	dirIndex map[string]int
+	// commits are the indexes of the changed directories in each commit.
+	commits [][]int
+	// lastCommit is the latest consumed commit, the projects are detected in its tree.
+	lastCommit *object.Commit
+
+	l core.Logger
+}
+
+// TopologyProject is a project of the monorepo.
+type TopologyProject struct {
+	// Path is the root directory of the project, "/" for the repository root. The root project
+	// owns the files which do not belong to any other project and may have no manifests.
+	Path string
+	// Manifests are the names of the manifest files found in Path.
+	Manifests []string
+	// Files is the number of files in the last commit.
+	Files int
+	// Lines is the number of lines in the last commit, binary files are not counted.
+	Lines int
+	// Commits is the number of commits which changed the project.
+	Commits int
+}
+
+// TopologyEdge is the number of commits which changed both projects.
+type TopologyEdge struct {
+	// First and Second are the indexes in TopologyResult.Projects, First < Second.
+	First, Second int
+	Commits       int
+}
+
+// TopologyResult is returned by TopologyAnalysis.Finalize().
+type TopologyResult struct {
+	// Projects are sorted by Path.
+	Projects []TopologyProject
+	// Edges are sorted by Commits in descending order.
+	Edges []TopologyEdge
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (ta *TopologyAnalysis) Name() string {
+	return "Topology"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (ta *TopologyAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (ta *TopologyAnalysis) Requires() []string {
+	return []string{items.DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (ta *TopologyAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name:        ConfigTopologyManifests,
+		Description: "Names of the files which mark the root directory of a project. Separated with commas \",\".",
+		Flag:        "topology-manifests",
+		Type:        core.StringsConfigurationOption,
+		Default:     TopologyManifests,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (ta *TopologyAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		ta.l = l
+	}
+	if val, exists := facts[ConfigTopologyManifests].([]string); exists {
+		ta.Manifests = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*TopologyAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (ta *TopologyAnalysis) Flag() string {
+	return "topology"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (ta *TopologyAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (ta *TopologyAnalysis) Description() string {
+	return "Detects the projects of a monorepo by their manifest files (go.mod, package.json, etc.) " +
+		"and reports their sizes and how often they change together."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (ta *TopologyAnalysis) Initialize(repository *git.Repository) error {
+	ta.l = core.NewLogger()
+	if len(ta.Manifests) == 0 {
+		ta.Manifests = TopologyManifests
+	}
+	ta.dirs = nil
+	ta.dirIndex = map[string]int{}
+	ta.commits = nil
+	ta.lastCommit = nil
+	ta.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (ta *TopologyAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	ta.lastCommit = deps[core.DependencyCommit].(*object.Commit)
+	if !ta.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	treeDiff := deps[items.DependencyTreeChanges].(object.Changes)
+	if len(treeDiff) == 0 || len(treeDiff) > CouplesMaximumMeaningfulContextSize {
+		return nil, nil
+	}
+	seen := map[int]bool{}
+	var changed []int
+	for _, change := range treeDiff {
+		name := change.To.Name
+		if name == "" {
+			name = change.From.Name
+		}
+		dir := subsystemOf(name)
+		index, exists := ta.dirIndex[dir]
+		if !exists {
+			index = len(ta.dirs)
+			ta.dirIndex[dir] = index
+			ta.dirs = append(ta.dirs, dir)
+		}
+		if !seen[index] {
+			seen[index] = true
+			changed = append(changed, index)
+		}
+	}
+	ta.commits = append(ta.commits, changed)
+	return nil, nil
+}
+
+// topologyProjectOf returns the deepest project which contains the directory.
+func topologyProjectOf(dir string, projects map[string]int) int {
+	for {
+		if index, exists := projects[dir]; exists {
+			return index
+		}
+		if dir == "/" {
+			return -1
+		}
+		dir = subsystemOf(dir)
+	}
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (ta *TopologyAnalysis) Finalize() interface{} {
+	result := TopologyResult{}
+	manifestNames := map[string]bool{}
+	for _, name := range ta.Manifests {
+		manifestNames[strings.TrimSpace(name)] = true
+	}
+	manifests := map[string][]string{"/": nil}
+	var tree *object.Tree
+	if ta.lastCommit != nil {
+		var err error
+		if tree, err = ta.lastCommit.Tree(); err != nil {
+			ta.l.Errorf("Failed to get the tree of %s: %v", ta.lastCommit.Hash.String(), err)
+			tree = nil
+		}
+	}
+	if tree != nil {
+		_ = tree.Files().ForEach(func(file *object.File) error {
+			if manifestNames[path.Base(file.Name)] {
+				dir := subsystemOf(file.Name)
+				manifests[dir] = append(manifests[dir], path.Base(file.Name))
+			}
+			return nil
+		})
+	}
+	paths := make([]string, 0, len(manifests))
+	for dir := range manifests {
+		paths = append(paths, dir)
+	}
+	sort.Strings(paths)
+	projects := make(map[string]int, len(paths))
+	result.Projects = make([]TopologyProject, len(paths))
+	for i, dir := range paths {
+		sort.Strings(manifests[dir])
+		projects[dir] = i
+		result.Projects[i] = TopologyProject{Path: dir, Manifests: manifests[dir]}
+	}
+	if tree != nil {
+		_ = tree.Files().ForEach(func(file *object.File) error {
+			project := &result.Projects[topologyProjectOf(subsystemOf(file.Name), projects)]
+			project.Files++
+			blob := items.CachedBlob{Blob: file.Blob}
+			if err := blob.Cache(); err != nil {
+				return nil
+			}
+			if lines, err := blob.CountLines(); err == nil {
+				project.Lines += lines
+			}
+			return nil
+		})
+	}
+
+	dirProjects := make([]int, len(ta.dirs))
+	for i, dir := range ta.dirs {
+		dirProjects[i] = topologyProjectOf(dir, projects)
+	}
+	edges := map[[2]int]int{}
+	for _, dirs := range ta.commits {
+		seen := map[int]bool{}
+		var changed []int
+		for _, dir := range dirs {
+			if project := dirProjects[dir]; !seen[project] {
+				seen[project] = true
+				changed = append(changed, project)
+			}
+		}
+		sort.Ints(changed)
+		for i, first := range changed {
+			result.Projects[first].Commits++
+			for _, second := range changed[i+1:] {
+				edges[[2]int{first, second}]++
+			}
+		}
+	}
+	for key, commits := range edges {
+		result.Edges = append(result.Edges, TopologyEdge{First: key[0], Second: key[1], Commits: commits})
+	}
+	sortTopologyEdges(result.Edges)
+	return result
+}
+
+// sortTopologyEdges orders the edges by the number of commits in descending order, then by
+// the project indexes.
+func sortTopologyEdges(edges []TopologyEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Commits != edges[j].Commits {
+			return edges[i].Commits > edges[j].Commits
+		}
+		if edges[i].First != edges[j].First {
+			return edges[i].First < edges[j].First
+		}
+		return edges[i].Second < edges[j].Second
+	})
+}
+
+// Fork clones this pipeline item.
+func (ta *TopologyAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(ta, n)
+}
+
+// AnonymizePaths replaces the paths of the projects, see core.PathAnonymizer.
+func (ta *TopologyAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	topology := result.(TopologyResult)
+	projects := make([]TopologyProject, len(topology.Projects))
+	for i, project := range topology.Projects {
+		project.Path = anonymize(project.Path)
+		projects[i] = project
+	}
+	topology.Projects = projects
+	return topology
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (ta *TopologyAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	topology, ok := result.(TopologyResult)
+	if !ok {
+		return fmt.Errorf("result is not a topology result: '%v'", result)
+	}
+	if binary {
+		return ta.serializeBinary(&topology, writer)
+	}
+	ta.serializeText(&topology, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to TopologyResult.
+func (ta *TopologyAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.TopologyResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := TopologyResult{Projects: make([]TopologyProject, len(message.Projects))}
+	for i, project := range message.Projects {
+		result.Projects[i] = TopologyProject{
+			Path:      project.Path,
+			Manifests: project.Manifests,
+			Files:     int(project.Files),
+			Lines:     int(project.Lines),
+			Commits:   int(project.Commits),
+		}
+	}
+	for _, edge := range message.Edges {
+		if edge.First < 0 || int(edge.First) >= len(result.Projects) ||
+			edge.Second < 0 || int(edge.Second) >= len(result.Projects) {
+			return nil, fmt.Errorf("topology edge %d-%d references a missing project", edge.First, edge.Second)
+		}
+		result.Edges = append(result.Edges, TopologyEdge{
+			First: int(edge.First), Second: int(edge.Second), Commits: int(edge.Commits),
+		})
+	}
+	return result, nil
+}
+
+// MergeResults combines two TopologyResult-s together. The projects with the same path are
+// joined and their sizes and commits are summed.
+func (ta *TopologyAnalysis) MergeResults(r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult) interface{} {
+	topology1 := r1.(TopologyResult)
+	topology2 := r2.(TopologyResult)
+	merged := map[string]*TopologyProject{}
+	for _, topology := range [...]TopologyResult{topology1, topology2} {
+		for _, project := range topology.Projects {
+			mergedProject := merged[project.Path]
+			if mergedProject == nil {
+				mergedProject = &TopologyProject{Path: project.Path}
+				merged[project.Path] = mergedProject
+			}
+			mergedProject.Files += project.Files
+			mergedProject.Lines += project.Lines
+			mergedProject.Commits += project.Commits
+			for _, manifest := range project.Manifests {
+				found := false
+				for _, existing := range mergedProject.Manifests {
+					found = found || existing == manifest
+				}
+				if !found {
+					mergedProject.Manifests = append(mergedProject.Manifests, manifest)
+				}
+			}
+		}
+	}
+	result := TopologyResult{Projects: make([]TopologyProject, 0, len(merged))}
+	for _, project := range merged {
+		sort.Strings(project.Manifests)
+		result.Projects = append(result.Projects, *project)
+	}
+	sort.Slice(result.Projects, func(i, j int) bool {
+		return result.Projects[i].Path < result.Projects[j].Path
+	})
+	indexes := make(map[string]int, len(result.Projects))
+	for i, project := range result.Projects {
+		indexes[project.Path] = i
+	}
+	edges := map[[2]int]int{}
+	for _, topology := range [...]TopologyResult{topology1, topology2} {
+		for _, edge := range topology.Edges {
+			first := indexes[topology.Projects[edge.First].Path]
+			second := indexes[topology.Projects[edge.Second].Path]
+			if first > second {
+				first, second = second, first
+			}
+			edges[[2]int{first, second}] += edge.Commits
+		}
+	}
+	for key, commits := range edges {
+		result.Edges = append(result.Edges, TopologyEdge{First: key[0], Second: key[1], Commits: commits})
+	}
+	sortTopologyEdges(result.Edges)
+	return result
+}
+
+func (ta *TopologyAnalysis) serializeText(result *TopologyResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  topology:")
+	fmt.Fprintln(writer, "    projects:")
+	for _, project := range result.Projects {
+		fmt.Fprintf(writer, "      - path: %s\n", yaml.SafeString(project.Path))
+		manifests := make([]string, len(project.Manifests))
+		for i, manifest := range project.Manifests {
+			manifests[i] = yaml.SafeString(manifest)
+		}
+		fmt.Fprintf(writer, "        manifests: [%s]\n", strings.Join(manifests, ", "))
+		fmt.Fprintf(writer, "        files: %d\n", project.Files)
+		fmt.Fprintf(writer, "        lines: %d\n", project.Lines)
+		fmt.Fprintf(writer, "        commits: %d\n", project.Commits)
+	}
+	fmt.Fprintln(writer, "    edges:")
+	for _, edge := range result.Edges {
+		fmt.Fprintf(writer, "      - [%s, %s, %d]\n",
+			yaml.SafeString(result.Projects[edge.First].Path),
+			yaml.SafeString(result.Projects[edge.Second].Path), edge.Commits)
+	}
+}
+
+func (ta *TopologyAnalysis) serializeBinary(result *TopologyResult, writer io.Writer) error {
+	message := pb.TopologyResults{
+		Projects: make([]*pb.TopologyProject, len(result.Projects)),
+		Edges:    make([]*pb.TopologyEdge, len(result.Edges)),
+	}
+	for i, project := range result.Projects {
+		message.Projects[i] = &pb.TopologyProject{
+			Path:      project.Path,
+			Manifests: project.Manifests,
+			Files:     int32(project.Files),
+			Lines:     int32(project.Lines),
+			Commits:   int32(project.Commits),
+		}
+	}
+	for i, edge := range result.Edges {
+		message.Edges[i] = &pb.TopologyEdge{
+			First: int32(edge.First), Second: int32(edge.Second), Commits: int32(edge.Commits),
+		}
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&TopologyAnalysis{})
+}
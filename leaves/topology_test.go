@@ -0,0 +1,170 @@
+package leaves
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixtureTopologyRepository creates a monorepo with the Go project services/api and the JS project
+// web. It returns the commits in the order of the analysis.
+func fixtureTopologyRepository(t *testing.T) []*object.Commit {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	require.NoError(t, err)
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+	when := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var commits []*object.Commit
+	commit := func(files ...string) {
+		for _, file := range files {
+			require.NoError(t, util.WriteFile(fs, file, []byte(file+"\n"+strconv.Itoa(len(commits))+"\n"), 0o644))
+		}
+		require.NoError(t, worktree.AddWithOptions(&git.AddOptions{All: true}))
+		when = when.AddDate(0, 0, 1)
+		signature := &object.Signature{Name: "Alice", Email: "alice@example.com", When: when}
+		hash, err := worktree.Commit("commit", &git.CommitOptions{Author: signature, Committer: signature})
+		require.NoError(t, err)
+		obj, err := repository.CommitObject(hash)
+		require.NoError(t, err)
+		commits = append(commits, obj)
+	}
+	commit("README.md")
+	commit("services/api/go.mod", "services/api/main.go")
+	commit("web/package.json", "web/src/app.js", "services/api/main.go")
+	commit("web/src/app.js", "README.md")
+	return commits
+}
+
+func consumeTopology(t *testing.T, ta *TopologyAnalysis, commits []*object.Commit) {
+	var previous *object.Tree
+	for _, commit := range commits {
+		tree, err := commit.Tree()
+		require.NoError(t, err)
+		changes, err := object.DiffTree(previous, tree)
+		require.NoError(t, err)
+		_, err = ta.Consume(map[string]interface{}{
+			core.DependencyCommit:       commit,
+			items.DependencyTreeChanges: changes,
+		})
+		require.NoError(t, err)
+		previous = tree
+	}
+}
+
+func TestTopologyMeta(t *testing.T) {
+	ta := &TopologyAnalysis{}
+	assert.Equal(t, "Topology", ta.Name())
+	assert.Equal(t, "topology", ta.Flag())
+	assert.Len(t, ta.Provides(), 0)
+	assert.Equal(t, []string{items.DependencyTreeChanges}, ta.Requires())
+	opts := ta.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigTopologyManifests, opts[0].Name)
+	assert.Equal(t, "topology-manifests", opts[0].Flag)
+	require.NoError(t, ta.Configure(map[string]interface{}{ConfigTopologyManifests: []string{"go.mod"}}))
+	assert.Equal(t, []string{"go.mod"}, ta.Manifests)
+	summoned := core.Registry.Summon(ta.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, ta.Name(), summoned[0].Name())
+}
+
+func TestTopologyFinalize(t *testing.T) {
+	ta := &TopologyAnalysis{}
+	require.NoError(t, ta.Initialize(nil))
+	consumeTopology(t, ta, fixtureTopologyRepository(t))
+	result := ta.Finalize().(TopologyResult)
+	assert.Equal(t, []TopologyProject{
+		{Path: "/", Files: 1, Lines: 2, Commits: 2},
+		{Path: "services/api", Manifests: []string{"go.mod"}, Files: 2, Lines: 4, Commits: 2},
+		{Path: "web", Manifests: []string{"package.json"}, Files: 2, Lines: 4, Commits: 2},
+	}, result.Projects)
+	assert.Equal(t, []TopologyEdge{
+		{First: 0, Second: 2, Commits: 1},
+		{First: 1, Second: 2, Commits: 1},
+	}, result.Edges)
+
+	ta.Manifests = []string{"package.json"}
+	result = ta.Finalize().(TopologyResult)
+	require.Len(t, result.Projects, 2)
+	assert.Equal(t, TopologyProject{Path: "/", Files: 3, Lines: 6, Commits: 4}, result.Projects[0])
+	assert.Equal(t, []TopologyEdge{{First: 0, Second: 1, Commits: 2}}, result.Edges)
+}
+
+func TestTopologySerialize(t *testing.T) {
+	ta := &TopologyAnalysis{}
+	result := TopologyResult{
+		Projects: []TopologyProject{
+			{Path: "/", Files: 1, Lines: 2, Commits: 2},
+			{Path: "web", Manifests: []string{"package.json"}, Files: 2, Lines: 4, Commits: 2},
+		},
+		Edges: []TopologyEdge{{First: 0, Second: 1, Commits: 1}},
+	}
+	buffer := &bytes.Buffer{}
+	require.NoError(t, ta.Serialize(result, false, buffer))
+	assert.Equal(t, `  topology:
+    projects:
+      - path: "/"
+        manifests: []
+        files: 1
+        lines: 2
+        commits: 2
+      - path: "web"
+        manifests: ["package.json"]
+        files: 2
+        lines: 4
+        commits: 2
+    edges:
+      - ["/", "web", 1]
+`, buffer.String())
+	buffer.Reset()
+	require.NoError(t, ta.Serialize(result, true, buffer))
+	deserialized, err := ta.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+	assert.Error(t, ta.Serialize(42, false, buffer))
+
+	anonymized := ta.AnonymizePaths(result, func(path string) string { return "x" + path }).(TopologyResult)
+	assert.Equal(t, "xweb", anonymized.Projects[1].Path)
+	assert.Equal(t, "web", result.Projects[1].Path)
+}
+
+func TestTopologyMergeResults(t *testing.T) {
+	ta := &TopologyAnalysis{}
+	r1 := TopologyResult{
+		Projects: []TopologyProject{
+			{Path: "/", Files: 1, Commits: 2},
+			{Path: "web", Manifests: []string{"package.json"}, Files: 2, Commits: 2},
+		},
+		Edges: []TopologyEdge{{First: 0, Second: 1, Commits: 1}},
+	}
+	r2 := TopologyResult{
+		Projects: []TopologyProject{
+			{Path: "/", Files: 3, Commits: 1},
+			{Path: "api", Manifests: []string{"go.mod"}, Files: 1, Commits: 4},
+			{Path: "web", Manifests: []string{"package.json"}, Files: 1, Commits: 3},
+		},
+		Edges: []TopologyEdge{{First: 1, Second: 2, Commits: 2}, {First: 0, Second: 2, Commits: 1}},
+	}
+	merged := ta.MergeResults(r1, r2, nil, nil).(TopologyResult)
+	assert.Equal(t, []TopologyProject{
+		{Path: "/", Files: 4, Commits: 3},
+		{Path: "api", Manifests: []string{"go.mod"}, Files: 1, Commits: 4},
+		{Path: "web", Manifests: []string{"package.json"}, Files: 3, Commits: 5},
+	}, merged.Projects)
+	assert.Equal(t, []TopologyEdge{
+		{First: 0, Second: 2, Commits: 2},
+		{First: 1, Second: 2, Commits: 2},
+	}, merged.Edges)
+}
@@ -76,6 +76,11 @@ func (saver *UASTChangesSaver) Flag() string {
 	return "dump-uast-changes"
 }
 
+// Cost returns the approximate per-commit run time category of this analysis.
+func (saver *UASTChangesSaver) Cost() core.CostClass {
+	return core.CostHeavy
+}
+
 // Description returns the text which explains what the analysis is doing.
 func (saver *UASTChangesSaver) Description() string {
 	return "Saves tree-sitter ASTs and file contents on disk for each modified file."
@@ -0,0 +1,628 @@
+package leaves
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gogo/protobuf/proto"
+	"github.com/meko-christian/hercules/internal/core"
+	"github.com/meko-christian/hercules/internal/join"
+	"github.com/meko-christian/hercules/internal/pb"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/meko-christian/hercules/internal/yaml"
+)
+
+const (
+	// ConfigWorkingSetOverlapTop is the name of the option to set WorkingSetOverlapAnalysis.Top.
+	ConfigWorkingSetOverlapTop = "WorkingSetOverlap.Top"
+	// DefaultWorkingSetOverlapTop is the default number of the reported pairs and subsystems.
+	DefaultWorkingSetOverlapTop = 20
+)
+
+// WorkingSetOverlapAnalysis collects the files which each developer touches per month and
+// measures how much the working sets of every pair of developers overlap - the Jaccard index
+// of the two sets of files. The pairs and the subsystems with the most overlap are where
+// the developers step on each other's toes, and the candidates to split the ownership.
+type WorkingSetOverlapAnalysis struct {
+	core.NoopMerger
+	core.OneShotMergeProcessor
+	// Top is the number of the reported pairs per month, the pairs overall and the subsystems.
+	// 0 reports all.
+	Top int
+
+	// workingSets maps months to developers to the touched files.
+	workingSets map[string]map[int]map[string]bool
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+	// commitAuthors references IdentityDetector.CommitAuthors.
+	commitAuthors map[plumbing.Hash][]identity.CommitAuthor
+
+	l core.Logger
+}
+
+// WorkingSetOverlapResult is returned by WorkingSetOverlapAnalysis.Finalize().
+type WorkingSetOverlapResult struct {
+	// WorkingSets maps months ("YYYY-MM") to developers to the sorted touched files.
+	WorkingSets map[string]map[int][]string
+	// Top is the number of the reported pairs and subsystems, 0 means all.
+	Top int
+
+	// reversedPeopleDict references IdentityDetector.ReversedPeopleDict.
+	reversedPeopleDict []string
+}
+
+// DeveloperOverlap is the overlap of the working sets of two developers in the same month.
+type DeveloperOverlap struct {
+	// Month is "YYYY-MM".
+	Month string
+	// Developers are the indexes of the developers in the ascending order.
+	Developers [2]int
+	// Shared is the number of files touched by both developers.
+	Shared int
+	// Union is the number of files touched by either developer.
+	Union int
+}
+
+// Jaccard returns Shared / Union.
+func (overlap DeveloperOverlap) Jaccard() float64 {
+	if overlap.Union == 0 {
+		return 0
+	}
+	return float64(overlap.Shared) / float64(overlap.Union)
+}
+
+// PairOverlap is the overlap of the working sets of two developers over all the months.
+type PairOverlap struct {
+	// Developers are the indexes of the developers in the ascending order.
+	Developers [2]int
+	// Months is the number of months when both developers were active.
+	Months int
+	// Shared is the sum of DeveloperOverlap.Shared over the months.
+	Shared int
+	// MeanJaccard is the mean Jaccard index over the months when both developers were active.
+	MeanJaccard float64
+}
+
+// SubsystemContention measures how many files in a directory are touched by more than one
+// developer in the same month.
+type SubsystemContention struct {
+	// Subsystem is the directory, "/" is the root.
+	Subsystem string
+	// Files is the sum over the months of the number of touched files.
+	Files int
+	// Contended is the sum over the months of the number of files touched by several developers.
+	Contended int
+	// Developers is the number of distinct developers who touched the subsystem.
+	Developers int
+}
+
+// Ratio returns Contended / Files.
+func (contention SubsystemContention) Ratio() float64 {
+	if contention.Files == 0 {
+		return 0
+	}
+	return float64(contention.Contended) / float64(contention.Files)
+}
+
+// Name of this PipelineItem. Uniquely identifies the type, used for mapping keys, etc.
+func (wso *WorkingSetOverlapAnalysis) Name() string {
+	return "WorkingSetOverlap"
+}
+
+// Provides returns the list of names of entities which are produced by this PipelineItem.
+func (wso *WorkingSetOverlapAnalysis) Provides() []string {
+	return []string{}
+}
+
+// Requires returns the list of names of entities which are needed by this PipelineItem.
+func (wso *WorkingSetOverlapAnalysis) Requires() []string {
+	return []string{identity.DependencyAuthor, items.DependencyTreeChanges}
+}
+
+// ListConfigurationOptions returns the list of changeable public properties of this PipelineItem.
+func (wso *WorkingSetOverlapAnalysis) ListConfigurationOptions() []core.ConfigurationOption {
+	options := [...]core.ConfigurationOption{{
+		Name: ConfigWorkingSetOverlapTop,
+		Description: "Number of the developer pairs per month, the pairs overall and the subsystems " +
+			"to report in --working-set-overlap. 0 reports all.",
+		Flag:    "overlap-top",
+		Type:    core.IntConfigurationOption,
+		Default: DefaultWorkingSetOverlapTop,
+	}}
+	return options[:]
+}
+
+// Configure sets the properties previously published by ListConfigurationOptions().
+func (wso *WorkingSetOverlapAnalysis) Configure(facts map[string]interface{}) error {
+	if l, exists := facts[core.ConfigLogger].(core.Logger); exists {
+		wso.l = l
+	}
+	if val, exists := facts[ConfigWorkingSetOverlapTop].(int); exists {
+		if val < 0 {
+			return fmt.Errorf("--overlap-top must not be negative, got %d", val)
+		}
+		wso.Top = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorReversedPeopleDict].([]string); exists {
+		wso.reversedPeopleDict = val
+	}
+	if val, exists := facts[identity.FactIdentityDetectorCommitAuthors].(map[plumbing.Hash][]identity.CommitAuthor); exists {
+		wso.commitAuthors = val
+	}
+	return nil
+}
+
+// ConfigureUpstream configures the upstream dependencies.
+func (*WorkingSetOverlapAnalysis) ConfigureUpstream(facts map[string]interface{}) error {
+	return nil
+}
+
+// Flag for the command line switch which enables this analysis.
+func (wso *WorkingSetOverlapAnalysis) Flag() string {
+	return "working-set-overlap"
+}
+
+// Cost returns the approximate per-commit run time category of this analysis.
+func (wso *WorkingSetOverlapAnalysis) Cost() core.CostClass {
+	return core.CostCheap
+}
+
+// Description returns the text which explains what the analysis is doing.
+func (wso *WorkingSetOverlapAnalysis) Description() string {
+	return "Measures the monthly overlap of the files touched by each pair of developers and " +
+		"finds the subsystems where the developers step on each other's toes."
+}
+
+// Initialize resets the temporary caches and prepares this PipelineItem for a series of Consume()
+// calls. The repository which is going to be analysed is supplied as an argument.
+func (wso *WorkingSetOverlapAnalysis) Initialize(repository *git.Repository) error {
+	wso.l = core.NewLogger()
+	wso.workingSets = map[string]map[int]map[string]bool{}
+	wso.OneShotMergeProcessor.Initialize()
+	return nil
+}
+
+// Consume runs this PipelineItem on the next commit data.
+// `deps` contain all the results from upstream PipelineItem-s as requested by Requires().
+// Additionally, DependencyCommit is always present there and represents the analysed *object.Commit.
+// This function returns the mapping with analysis results. The keys must be the same as
+// in Provides(). If there was an error, nil is returned.
+func (wso *WorkingSetOverlapAnalysis) Consume(deps map[string]interface{}) (map[string]interface{}, error) {
+	if !wso.ShouldConsumeCommit(deps) {
+		return nil, nil
+	}
+	author := deps[identity.DependencyAuthor].(int)
+	commit := deps[core.DependencyCommit].(*object.Commit)
+	changes := deps[items.DependencyTreeChanges].(object.Changes)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	authors := []identity.CommitAuthor{{ID: author, Share: 1}}
+	if val, exists := wso.commitAuthors[commit.Hash]; exists {
+		authors = val
+	}
+	month := commit.Author.When.UTC().Format("2006-01")
+	developers := wso.workingSets[month]
+	if developers == nil {
+		developers = map[int]map[string]bool{}
+		wso.workingSets[month] = developers
+	}
+	for _, coauthor := range authors {
+		if coauthor.ID == core.AuthorMissing {
+			continue
+		}
+		files := developers[coauthor.ID]
+		if files == nil {
+			files = map[string]bool{}
+			developers[coauthor.ID] = files
+		}
+		for _, change := range changes {
+			name := change.To.Name
+			if name == "" {
+				name = change.From.Name
+			}
+			files[name] = true
+		}
+	}
+	return nil, nil
+}
+
+// Finalize returns the result of the analysis. Further Consume() calls are not expected.
+func (wso *WorkingSetOverlapAnalysis) Finalize() interface{} {
+	result := WorkingSetOverlapResult{
+		WorkingSets:        make(map[string]map[int][]string, len(wso.workingSets)),
+		Top:                wso.Top,
+		reversedPeopleDict: wso.reversedPeopleDict,
+	}
+	for month, developers := range wso.workingSets {
+		sets := make(map[int][]string, len(developers))
+		for dev, files := range developers {
+			sets[dev] = sortedFileSet(files)
+		}
+		result.WorkingSets[month] = sets
+	}
+	return result
+}
+
+// Fork clones this pipeline item.
+func (wso *WorkingSetOverlapAnalysis) Fork(n int) []core.PipelineItem {
+	return core.ForkSamePipelineItem(wso, n)
+}
+
+// sortedFileSet returns the files in the set in the ascending order.
+func sortedFileSet(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isPerson returns whether the developer is a single person: the collapsed occasional
+// developers are excluded.
+func (result *WorkingSetOverlapResult) isPerson(dev int) bool {
+	return dev < 0 || dev >= len(result.reversedPeopleDict) ||
+		result.reversedPeopleDict[dev] != identity.OthersName
+}
+
+// sortedMonths returns the months of WorkingSets in the chronological order.
+func (result *WorkingSetOverlapResult) sortedMonths() []string {
+	months := make([]string, 0, len(result.WorkingSets))
+	for month := range result.WorkingSets {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	return months
+}
+
+// monthOverlaps calculates the overlaps of the developer pairs who touched at least one
+// common file in the month.
+func (result *WorkingSetOverlapResult) monthOverlaps(month string) []DeveloperOverlap {
+	developers := result.WorkingSets[month]
+	editors := map[string][]int{}
+	for dev, files := range developers {
+		if !result.isPerson(dev) {
+			continue
+		}
+		for _, file := range files {
+			editors[file] = append(editors[file], dev)
+		}
+	}
+	shared := map[[2]int]int{}
+	for _, devs := range editors {
+		sort.Ints(devs)
+		for i := range devs {
+			for j := i + 1; j < len(devs); j++ {
+				shared[[2]int{devs[i], devs[j]}]++
+			}
+		}
+	}
+	overlaps := make([]DeveloperOverlap, 0, len(shared))
+	for pair, count := range shared {
+		overlaps = append(overlaps, DeveloperOverlap{
+			Month:      month,
+			Developers: pair,
+			Shared:     count,
+			Union:      len(developers[pair[0]]) + len(developers[pair[1]]) - count,
+		})
+	}
+	sort.Slice(overlaps, func(i, j int) bool {
+		ji, jj := overlaps[i].Jaccard(), overlaps[j].Jaccard()
+		if ji != jj {
+			return ji > jj
+		}
+		if overlaps[i].Shared != overlaps[j].Shared {
+			return overlaps[i].Shared > overlaps[j].Shared
+		}
+		if overlaps[i].Developers[0] != overlaps[j].Developers[0] {
+			return overlaps[i].Developers[0] < overlaps[j].Developers[0]
+		}
+		return overlaps[i].Developers[1] < overlaps[j].Developers[1]
+	})
+	return overlaps
+}
+
+// Overlaps calculates the overlaps of the developer pairs per month, in the chronological
+// order and then by the descending Jaccard index. Only the pairs with the common files are
+// included.
+func (result *WorkingSetOverlapResult) Overlaps() []DeveloperOverlap {
+	var overlaps []DeveloperOverlap
+	for _, month := range result.sortedMonths() {
+		overlaps = append(overlaps, result.monthOverlaps(month)...)
+	}
+	return overlaps
+}
+
+// Pairs aggregates the overlaps of the developer pairs over the months, by the descending
+// mean Jaccard index. The months when both developers were active but touched different files
+// count as zero overlap.
+func (result *WorkingSetOverlapResult) Pairs() []PairOverlap {
+	sums := map[[2]int]*PairOverlap{}
+	for _, overlap := range result.Overlaps() {
+		pair := sums[overlap.Developers]
+		if pair == nil {
+			pair = &PairOverlap{Developers: overlap.Developers}
+			sums[overlap.Developers] = pair
+		}
+		pair.Shared += overlap.Shared
+		pair.MeanJaccard += overlap.Jaccard()
+	}
+	pairs := make([]PairOverlap, 0, len(sums))
+	for _, pair := range sums {
+		for _, developers := range result.WorkingSets {
+			if len(developers[pair.Developers[0]]) > 0 && len(developers[pair.Developers[1]]) > 0 {
+				pair.Months++
+			}
+		}
+		pair.MeanJaccard /= float64(pair.Months)
+		pairs = append(pairs, *pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].MeanJaccard != pairs[j].MeanJaccard {
+			return pairs[i].MeanJaccard > pairs[j].MeanJaccard
+		}
+		if pairs[i].Shared != pairs[j].Shared {
+			return pairs[i].Shared > pairs[j].Shared
+		}
+		if pairs[i].Developers[0] != pairs[j].Developers[0] {
+			return pairs[i].Developers[0] < pairs[j].Developers[0]
+		}
+		return pairs[i].Developers[1] < pairs[j].Developers[1]
+	})
+	return pairs
+}
+
+// Subsystems calculates the contention of the directories, by the descending number of
+// the contended files.
+func (result *WorkingSetOverlapResult) Subsystems() []SubsystemContention {
+	contentions := map[string]*SubsystemContention{}
+	developers := map[string]map[int]bool{}
+	for _, sets := range result.WorkingSets {
+		editors := map[string]int{}
+		for dev, files := range sets {
+			if !result.isPerson(dev) {
+				continue
+			}
+			for _, file := range files {
+				editors[file]++
+				dir := subsystemOf(file)
+				if developers[dir] == nil {
+					developers[dir] = map[int]bool{}
+				}
+				developers[dir][dev] = true
+			}
+		}
+		for file, count := range editors {
+			dir := subsystemOf(file)
+			contention := contentions[dir]
+			if contention == nil {
+				contention = &SubsystemContention{Subsystem: dir}
+				contentions[dir] = contention
+			}
+			contention.Files++
+			if count > 1 {
+				contention.Contended++
+			}
+		}
+	}
+	subsystems := make([]SubsystemContention, 0, len(contentions))
+	for dir, contention := range contentions {
+		contention.Developers = len(developers[dir])
+		subsystems = append(subsystems, *contention)
+	}
+	sort.Slice(subsystems, func(i, j int) bool {
+		if subsystems[i].Contended != subsystems[j].Contended {
+			return subsystems[i].Contended > subsystems[j].Contended
+		}
+		ri, rj := subsystems[i].Ratio(), subsystems[j].Ratio()
+		if ri != rj {
+			return ri > rj
+		}
+		return subsystems[i].Subsystem < subsystems[j].Subsystem
+	})
+	return subsystems
+}
+
+// AnonymizePaths replaces the names of the files, see core.PathAnonymizer.
+func (wso *WorkingSetOverlapAnalysis) AnonymizePaths(result interface{}, anonymize func(string) string) interface{} {
+	overlap := result.(WorkingSetOverlapResult)
+	workingSets := make(map[string]map[int][]string, len(overlap.WorkingSets))
+	for month, developers := range overlap.WorkingSets {
+		sets := make(map[int][]string, len(developers))
+		for dev, files := range developers {
+			anonymized := make([]string, len(files))
+			for i, file := range files {
+				anonymized[i] = anonymize(file)
+			}
+			sort.Strings(anonymized)
+			sets[dev] = anonymized
+		}
+		workingSets[month] = sets
+	}
+	overlap.WorkingSets = workingSets
+	return overlap
+}
+
+// Serialize converts the analysis result as returned by Finalize() to text or bytes.
+// The text format is YAML and the bytes format is Protocol Buffers.
+func (wso *WorkingSetOverlapAnalysis) Serialize(result interface{}, binary bool, writer io.Writer) error {
+	overlapResult, ok := result.(WorkingSetOverlapResult)
+	if !ok {
+		return fmt.Errorf("result is not a working set overlap result: '%v'", result)
+	}
+	if binary {
+		return wso.serializeBinary(&overlapResult, writer)
+	}
+	wso.serializeText(&overlapResult, writer)
+	return nil
+}
+
+// Deserialize converts the specified protobuf bytes to WorkingSetOverlapResult.
+func (wso *WorkingSetOverlapAnalysis) Deserialize(pbmessage []byte) (interface{}, error) {
+	message := pb.WorkingSetOverlapResults{}
+	if err := proto.Unmarshal(pbmessage, &message); err != nil {
+		return nil, err
+	}
+	result := WorkingSetOverlapResult{
+		WorkingSets:        make(map[string]map[int][]string, len(message.Months)),
+		Top:                int(message.Top),
+		reversedPeopleDict: message.DevIndex,
+	}
+	for month, developers := range message.Months {
+		sets := make(map[int][]string, len(developers.GetDevelopers()))
+		for dev, workingSet := range developers.GetDevelopers() {
+			files := make([]string, 0, len(workingSet.GetFiles()))
+			for _, index := range workingSet.GetFiles() {
+				if index < 0 || int(index) >= len(message.Files) {
+					return nil, fmt.Errorf("invalid file index %d in the working set of %d in %s",
+						index, dev, month)
+				}
+				files = append(files, message.Files[index])
+			}
+			sets[int(dev)] = files
+		}
+		result.WorkingSets[month] = sets
+	}
+	return result, nil
+}
+
+// MergeResults combines two WorkingSetOverlapResult-s together.
+func (wso *WorkingSetOverlapAnalysis) MergeResults(
+	r1, r2 interface{}, c1, c2 *core.CommonAnalysisResult,
+) interface{} {
+	wsr1 := r1.(WorkingSetOverlapResult)
+	wsr2 := r2.(WorkingSetOverlapResult)
+	merged := WorkingSetOverlapResult{Top: wsr1.Top}
+	if wsr2.Top > merged.Top {
+		merged.Top = wsr2.Top
+	}
+	var mergedIndex map[string]join.JoinedIndex
+	mergedIndex, merged.reversedPeopleDict = join.PeopleIdentities(
+		wsr1.reversedPeopleDict, wsr2.reversedPeopleDict)
+	workingSets := map[string]map[int]map[string]bool{}
+	for _, result := range [...]*WorkingSetOverlapResult{&wsr1, &wsr2} {
+		for month, developers := range result.WorkingSets {
+			sets := workingSets[month]
+			if sets == nil {
+				sets = map[int]map[string]bool{}
+				workingSets[month] = sets
+			}
+			for dev, files := range developers {
+				if dev < 0 || dev >= len(result.reversedPeopleDict) {
+					continue
+				}
+				final := mergedIndex[result.reversedPeopleDict[dev]].Final
+				if sets[final] == nil {
+					sets[final] = map[string]bool{}
+				}
+				for _, file := range files {
+					sets[final][file] = true
+				}
+			}
+		}
+	}
+	merged.WorkingSets = make(map[string]map[int][]string, len(workingSets))
+	for month, developers := range workingSets {
+		sets := make(map[int][]string, len(developers))
+		for dev, files := range developers {
+			sets[dev] = sortedFileSet(files)
+		}
+		merged.WorkingSets[month] = sets
+	}
+	return merged
+}
+
+// limit returns the first Top items count, or all if Top is 0.
+func (result *WorkingSetOverlapResult) limit(count int) int {
+	if result.Top > 0 && result.Top < count {
+		return result.Top
+	}
+	return count
+}
+
+func (wso *WorkingSetOverlapAnalysis) serializeText(result *WorkingSetOverlapResult, writer io.Writer) {
+	fmt.Fprintln(writer, "  working_set_overlap:")
+	fmt.Fprintf(writer, "    top: %d\n", result.Top)
+	fmt.Fprintln(writer, "    months:")
+	for _, month := range result.sortedMonths() {
+		overlaps := result.monthOverlaps(month)
+		if len(overlaps) == 0 {
+			continue
+		}
+		fmt.Fprintf(writer, "      %q:\n", month)
+		for _, overlap := range overlaps[:result.limit(len(overlaps))] {
+			fmt.Fprintf(writer, "      - {developers: [%d, %d], shared: %d, union: %d, jaccard: %.4f}\n",
+				overlap.Developers[0], overlap.Developers[1], overlap.Shared, overlap.Union,
+				overlap.Jaccard())
+		}
+	}
+	fmt.Fprintln(writer, "    pairs:")
+	pairs := result.Pairs()
+	for _, pair := range pairs[:result.limit(len(pairs))] {
+		fmt.Fprintf(writer, "    - {developers: [%d, %d], months: %d, shared: %d, mean_jaccard: %.4f}\n",
+			pair.Developers[0], pair.Developers[1], pair.Months, pair.Shared, pair.MeanJaccard)
+	}
+	fmt.Fprintln(writer, "    subsystems:")
+	subsystems := result.Subsystems()
+	for _, contention := range subsystems[:result.limit(len(subsystems))] {
+		fmt.Fprintf(writer, "      %s: {files: %d, contended: %d, developers: %d, ratio: %.4f}\n",
+			yaml.SafeString(contention.Subsystem), contention.Files, contention.Contended,
+			contention.Developers, contention.Ratio())
+	}
+	fmt.Fprintln(writer, "    people:")
+	for _, person := range result.reversedPeopleDict {
+		fmt.Fprintf(writer, "    - %s\n", yaml.SafeString(person))
+	}
+}
+
+func (wso *WorkingSetOverlapAnalysis) serializeBinary(result *WorkingSetOverlapResult, writer io.Writer) error {
+	message := pb.WorkingSetOverlapResults{
+		Months:   make(map[string]*pb.MonthlyWorkingSets, len(result.WorkingSets)),
+		DevIndex: result.reversedPeopleDict,
+		Top:      int32(result.Top),
+	}
+	// visit the working sets in a stable order so that the file indexes do not change between runs
+	fileIndex := map[string]int32{}
+	for _, month := range result.sortedMonths() {
+		developers := result.WorkingSets[month]
+		sets := &pb.MonthlyWorkingSets{
+			Developers: make(map[int32]*pb.WorkingSet, len(developers)),
+		}
+		devs := make([]int, 0, len(developers))
+		for dev := range developers {
+			devs = append(devs, dev)
+		}
+		sort.Ints(devs)
+		for _, dev := range devs {
+			files := developers[dev]
+			workingSet := &pb.WorkingSet{Files: make([]int32, len(files))}
+			for i, file := range files {
+				index, exists := fileIndex[file]
+				if !exists {
+					index = int32(len(message.Files))
+					fileIndex[file] = index
+					message.Files = append(message.Files, file)
+				}
+				workingSet.Files[i] = index
+			}
+			sets.Developers[int32(dev)] = workingSet
+		}
+		message.Months[month] = sets
+	}
+	serialized, err := proto.Marshal(&message)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(serialized)
+	return err
+}
+
+func init() {
+	core.Registry.Register(&WorkingSetOverlapAnalysis{})
+}
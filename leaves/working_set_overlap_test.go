@@ -0,0 +1,182 @@
+package leaves
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/meko-christian/hercules/internal/core"
+	items "github.com/meko-christian/hercules/internal/plumbing"
+	"github.com/meko-christian/hercules/internal/plumbing/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingSetOverlapMeta(t *testing.T) {
+	wso := &WorkingSetOverlapAnalysis{}
+	assert.Equal(t, "WorkingSetOverlap", wso.Name())
+	assert.Equal(t, "working-set-overlap", wso.Flag())
+	assert.Len(t, wso.Provides(), 0)
+	assert.Equal(t, []string{identity.DependencyAuthor, items.DependencyTreeChanges}, wso.Requires())
+	opts := wso.ListConfigurationOptions()
+	require.Len(t, opts, 1)
+	assert.Equal(t, ConfigWorkingSetOverlapTop, opts[0].Name)
+	assert.Equal(t, "overlap-top", opts[0].Flag)
+	assert.Equal(t, DefaultWorkingSetOverlapTop, opts[0].Default)
+	summoned := core.Registry.Summon(wso.Name())
+	require.Len(t, summoned, 1)
+	assert.Equal(t, wso.Name(), summoned[0].Name())
+}
+
+func TestWorkingSetOverlapConfigure(t *testing.T) {
+	wso := &WorkingSetOverlapAnalysis{}
+	require.NoError(t, wso.Configure(map[string]interface{}{
+		ConfigWorkingSetOverlapTop:                      5,
+		identity.FactIdentityDetectorReversedPeopleDict: []string{"Alice", "Bob"},
+	}))
+	assert.Equal(t, 5, wso.Top)
+	assert.Equal(t, []string{"Alice", "Bob"}, wso.reversedPeopleDict)
+	assert.Error(t, wso.Configure(map[string]interface{}{ConfigWorkingSetOverlapTop: -1}))
+}
+
+func TestWorkingSetOverlapConsume(t *testing.T) {
+	wso := &WorkingSetOverlapAnalysis{}
+	hash := plumbing.NewHash("5c0e755dd85ac74584d9988cc361eccf02ce1a48")
+	require.NoError(t, wso.Configure(map[string]interface{}{
+		identity.FactIdentityDetectorCommitAuthors: map[plumbing.Hash][]identity.CommitAuthor{
+			hash: {{ID: 1, Share: 0.5}, {ID: 2, Share: 0.5}},
+		},
+	}))
+	require.NoError(t, wso.Initialize(nil))
+	consume := func(author int, month time.Month, hash plumbing.Hash, files ...string) {
+		changes := make(object.Changes, len(files))
+		for i, file := range files {
+			changes[i] = &object.Change{To: object.ChangeEntry{Name: file}}
+		}
+		// the deleted file
+		if len(files) > 0 && files[0] == "gone.go" {
+			changes[0] = &object.Change{From: object.ChangeEntry{Name: files[0]}}
+		}
+		_, err := wso.Consume(map[string]interface{}{
+			core.DependencyCommit: &object.Commit{Hash: hash, Author: object.Signature{
+				When: time.Date(2024, month, 10, 0, 0, 0, 0, time.UTC),
+			}},
+			identity.DependencyAuthor:   author,
+			items.DependencyTreeChanges: changes,
+		})
+		require.NoError(t, err)
+	}
+	consume(0, 1, plumbing.ZeroHash, "src/a.go", "src/b.go")
+	consume(0, 1, plumbing.ZeroHash, "src/a.go")
+	consume(1, 1, hash, "src/a.go")
+	consume(0, 2, plumbing.ZeroHash, "gone.go")
+	consume(core.AuthorMissing, 2, plumbing.ZeroHash, "src/a.go")
+	consume(2, 3, plumbing.ZeroHash)
+	assert.Equal(t, map[string]map[int][]string{
+		"2024-01": {0: {"src/a.go", "src/b.go"}, 1: {"src/a.go"}, 2: {"src/a.go"}},
+		"2024-02": {0: {"gone.go"}},
+	}, wso.Finalize().(WorkingSetOverlapResult).WorkingSets)
+}
+
+func fixtureWorkingSetOverlapResult() WorkingSetOverlapResult {
+	return WorkingSetOverlapResult{
+		WorkingSets: map[string]map[int][]string{
+			"2024-01": {
+				0: {"README.md", "src/a.go", "src/b.go"},
+				1: {"src/a.go", "src/b.go"},
+				2: {"lib/c.go"},
+				3: {"src/a.go"},
+			},
+			"2024-02": {
+				0: {"src/a.go"},
+				1: {"lib/c.go"},
+				2: {"lib/c.go", "lib/d.go"},
+			},
+		},
+		Top:                2,
+		reversedPeopleDict: []string{"alice", "bob", "carol", identity.OthersName},
+	}
+}
+
+func TestWorkingSetOverlapPairs(t *testing.T) {
+	result := fixtureWorkingSetOverlapResult()
+	assert.Equal(t, []DeveloperOverlap{
+		{Month: "2024-01", Developers: [2]int{0, 1}, Shared: 2, Union: 3},
+		{Month: "2024-02", Developers: [2]int{1, 2}, Shared: 1, Union: 2},
+	}, result.Overlaps())
+	assert.InDelta(t, 2.0/3, result.Overlaps()[0].Jaccard(), 1e-9)
+	pairs := result.Pairs()
+	require.Len(t, pairs, 2)
+	assert.Equal(t, [2]int{0, 1}, pairs[0].Developers)
+	assert.Equal(t, 2, pairs[0].Months)
+	assert.Equal(t, 2, pairs[0].Shared)
+	assert.InDelta(t, 1.0/3, pairs[0].MeanJaccard, 1e-9)
+	assert.Equal(t, PairOverlap{Developers: [2]int{1, 2}, Months: 2, Shared: 1, MeanJaccard: 0.25},
+		pairs[1])
+	assert.Equal(t, []SubsystemContention{
+		{Subsystem: "src", Files: 3, Contended: 2, Developers: 2},
+		{Subsystem: "lib", Files: 3, Contended: 1, Developers: 2},
+		{Subsystem: "/", Files: 1, Contended: 0, Developers: 1},
+	}, result.Subsystems())
+	assert.Equal(t, 0.0, DeveloperOverlap{}.Jaccard())
+	assert.Equal(t, 0.0, SubsystemContention{}.Ratio())
+}
+
+func TestWorkingSetOverlapSerialize(t *testing.T) {
+	wso := &WorkingSetOverlapAnalysis{}
+	result := fixtureWorkingSetOverlapResult()
+	buffer := &bytes.Buffer{}
+	require.NoError(t, wso.Serialize(result, false, buffer))
+	assert.Equal(t, `  working_set_overlap:
+    top: 2
+    months:
+      "2024-01":
+      - {developers: [0, 1], shared: 2, union: 3, jaccard: 0.6667}
+      "2024-02":
+      - {developers: [1, 2], shared: 1, union: 2, jaccard: 0.5000}
+    pairs:
+    - {developers: [0, 1], months: 2, shared: 2, mean_jaccard: 0.3333}
+    - {developers: [1, 2], months: 2, shared: 1, mean_jaccard: 0.2500}
+    subsystems:
+      "src": {files: 3, contended: 2, developers: 2, ratio: 0.6667}
+      "lib": {files: 3, contended: 1, developers: 2, ratio: 0.3333}
+    people:
+    - "alice"
+    - "bob"
+    - "carol"
+    - "<others>"
+`, buffer.String())
+	assert.Error(t, wso.Serialize(nil, false, buffer))
+
+	buffer.Reset()
+	require.NoError(t, wso.Serialize(result, true, buffer))
+	deserialized, err := wso.Deserialize(buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, result, deserialized)
+}
+
+func TestWorkingSetOverlapMergeAndAnonymize(t *testing.T) {
+	wso := &WorkingSetOverlapAnalysis{}
+	r1 := fixtureWorkingSetOverlapResult()
+	r2 := WorkingSetOverlapResult{
+		WorkingSets: map[string]map[int][]string{
+			"2024-02": {0: {"lib/c.go"}, 1: {"src/z.go"}},
+		},
+		Top:                5,
+		reversedPeopleDict: []string{"carol", "dave"},
+	}
+	merged := wso.MergeResults(r1, r2, nil, nil).(WorkingSetOverlapResult)
+	assert.Equal(t, 5, merged.Top)
+	assert.Equal(t, []string{"alice", "bob", "carol", identity.OthersName, "dave"},
+		merged.reversedPeopleDict)
+	assert.Equal(t, []string{"lib/c.go", "lib/d.go"}, merged.WorkingSets["2024-02"][2])
+	assert.Equal(t, []string{"src/z.go"}, merged.WorkingSets["2024-02"][4])
+	assert.Equal(t, r1.WorkingSets["2024-01"], merged.WorkingSets["2024-01"])
+
+	anonymized := wso.AnonymizePaths(r2, func(name string) string { return "x/" + name }).(WorkingSetOverlapResult)
+	assert.Equal(t, map[string]map[int][]string{
+		"2024-02": {0: {"x/lib/c.go"}, 1: {"x/src/z.go"}},
+	}, anonymized.WorkingSets)
+}
@@ -94,6 +94,8 @@ def parse_args() -> Namespace:
         choices=[
             "burndown-project",
             "burndown-file",
+            "burndown-directory",
+            "burndown-language",
             "burndown-person",
             "burndown-repository",
             "burndown-repos-combined",
@@ -190,6 +192,14 @@ def main() -> None:
         "Burndown stats for files were not collected. Re-run hercules with "
         "--burndown --burndown-files."
     )
+    burndown_directories_warning = (
+        "Burndown stats for directories were not collected. Re-run hercules with "
+        "--burndown --burndown-dirs-depth."
+    )
+    burndown_languages_warning = (
+        "Burndown stats for languages were not collected. Re-run hercules with "
+        "--burndown --burndown-languages."
+    )
     burndown_people_warning = (
         "Burndown stats for people were not collected. Re-run hercules with "
         "--burndown --burndown-people."
@@ -230,6 +240,7 @@ def main() -> None:
                 *reader.get_project_burndown(),
                 resample=args.resample,
                 interpolation_progress=True,
+                resampled=reader.get_burndown_resample(),
             ),
         )
 
@@ -240,10 +251,50 @@ def main() -> None:
             print(burndown_warning)
             return
         try:
-            plot_many_burndown(args, "file", full_header, reader.get_files_burndown())
+            plot_many_burndown(
+                args,
+                "file",
+                full_header,
+                reader.get_files_burndown(),
+                resampled=reader.get_burndown_resample(),
+            )
         except KeyError:
             print("files: " + burndown_files_warning)
 
+    def directories_burndown():
+        try:
+            full_header = header + reader.get_burndown_parameters()
+        except KeyError:
+            print(burndown_warning)
+            return
+        try:
+            plot_many_burndown(
+                args,
+                "directory",
+                full_header,
+                reader.get_directories_burndown(),
+                resampled=reader.get_burndown_resample(),
+            )
+        except KeyError:
+            print("directories: " + burndown_directories_warning)
+
+    def languages_burndown():
+        try:
+            full_header = header + reader.get_burndown_parameters()
+        except KeyError:
+            print(burndown_warning)
+            return
+        try:
+            plot_many_burndown(
+                args,
+                "language",
+                full_header,
+                reader.get_languages_burndown(),
+                resampled=reader.get_burndown_resample(),
+            )
+        except KeyError:
+            print("languages: " + burndown_languages_warning)
+
     def people_burndown():
         try:
             full_header = header + reader.get_burndown_parameters()
@@ -252,7 +303,11 @@ def main() -> None:
             return
         try:
             plot_many_burndown(
-                args, "person", full_header, reader.get_people_burndown()
+                args,
+                "person",
+                full_header,
+                reader.get_people_burndown(),
+                resampled=reader.get_burndown_resample(),
             )
         except KeyError:
             print("people: " + burndown_people_warning)
@@ -265,7 +320,11 @@ def main() -> None:
             return
         try:
             plot_many_burndown(
-                args, "repository", full_header, reader.get_repositories_burndown()
+                args,
+                "repository",
+                full_header,
+                reader.get_repositories_burndown(),
+                resampled=reader.get_burndown_resample(),
             )
         except (KeyError, AttributeError):
             print("repositories: burndown data not available or repositories not tracked")
@@ -571,6 +630,8 @@ def main() -> None:
         "run-times": run_times,
         "burndown-project": project_burndown,
         "burndown-file": files_burndown,
+        "burndown-directory": directories_burndown,
+        "burndown-language": languages_burndown,
         "burndown-person": people_burndown,
         "burndown-repository": repositories_burndown,
         "burndown-repos-combined": repositories_burndown_combined,
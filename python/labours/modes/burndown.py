@@ -114,7 +114,7 @@ def plot_burndown(
     deploy_plot(title, output, args.background)
 
 
-def plot_many_burndown(args: Namespace, target: str, header, parts):
+def plot_many_burndown(args: Namespace, target: str, header, parts, resampled: str = ""):
     if not args.output:
         print("Warning: output not set, showing %d plots." % len(parts))
     stdout = io.StringIO()
@@ -126,7 +126,11 @@ def plot_many_burndown(args: Namespace, target: str, header, parts):
                 import os
                 display_name = os.path.basename(name.rstrip("/"))
             plot_burndown(
-                args, target, *load_burndown(header, display_name, matrix, args.resample)
+                args,
+                target,
+                *load_burndown(
+                    header, display_name, matrix, args.resample, resampled=resampled
+                ),
             )
     sys.stdout.write(stdout.getvalue())
 
@@ -312,6 +316,7 @@ def load_burndown(
     resample: str,
     report_survival: bool = True,
     interpolation_progress: bool = False,
+    resampled: str = "",
 ) -> Tuple[str, numpy.ndarray, 'DatetimeIndex', List[int], int, int, str]:
     pandas = import_pandas()
 
@@ -325,6 +330,26 @@ def load_burndown(
         if kmf is not None:
             print_survival_function(kmf, sampling)
     finish = start + timedelta(seconds=matrix.shape[1] * sampling * tick)
+    if resampled:
+        # hercules --burndown-resample: each band and each sample is already a calendar period
+        if resample not in ("no", "raw"):
+            print("already resampled to %s by hercules" % resampled)
+        freq = {"month": "M", "quarter": "Q", "year": "Y"}[resampled]
+        periods = pandas.period_range(
+            pandas.Period(start, freq=freq), periods=matrix.shape[1] + 1, freq=freq
+        )
+        # the samples are the states at the end of each period
+        date_range_sampling = periods[1:].start_time
+        labels = [str(period) for period in periods[: matrix.shape[0]]]
+        return (
+            name,
+            matrix,
+            date_range_sampling,
+            labels,
+            granularity,
+            sampling,
+            "A" if resampled == "year" else "M",
+        )
     if resample not in ("no", "raw"):
         print("resampling to %s, please wait..." % resample)
         # Interpolate the day x day matrix.
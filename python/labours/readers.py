@@ -27,12 +27,21 @@ class Reader(object):
     def get_burndown_parameters(self):
         raise NotImplementedError
 
+    def get_burndown_resample(self):
+        raise NotImplementedError
+
     def get_project_burndown(self):
         raise NotImplementedError
 
     def get_files_burndown(self):
         raise NotImplementedError
 
+    def get_directories_burndown(self):
+        raise NotImplementedError
+
+    def get_languages_burndown(self):
+        raise NotImplementedError
+
     def get_people_burndown(self):
         raise NotImplementedError
 
@@ -99,6 +108,9 @@ class YamlReader(Reader):
         header = self.data["Burndown"]
         return header["sampling"], header["granularity"], header["tick_size"]
 
+    def get_burndown_resample(self):
+        return self.data["Burndown"].get("resample", "")
+
     def get_project_burndown(self):
         return (
             self.data["hercules"]["repository"],
@@ -111,6 +123,18 @@ class YamlReader(Reader):
             for p in self.data["Burndown"]["files"].items()
         ]
 
+    def get_directories_burndown(self):
+        return [
+            (p[0], self._parse_burndown_matrix(p[1]).T)
+            for p in self.data["Burndown"]["directories"].items()
+        ]
+
+    def get_languages_burndown(self):
+        return [
+            (p[0], self._parse_burndown_matrix(p[1]).T)
+            for p in self.data["Burndown"]["languages"].items()
+        ]
+
     def get_people_burndown(self):
         return [
             (p[0], self._parse_burndown_matrix(p[1]).T)
@@ -273,6 +297,7 @@ class YamlReader(Reader):
                 "churn_normalized": float(file_entry["normalized"]["churn"]),
                 "coupling_normalized": float(file_entry["normalized"]["coupling"]),
                 "ownership_normalized": float(file_entry["normalized"]["ownership"]),
+                "language": str(file_entry.get("language", "")),
             })
         window_days = int(hr_data.get("window_days", 90))
         return files, window_days
@@ -394,12 +419,27 @@ class ProtobufReader(Reader):
         burndown = self.contents["Burndown"]
         return burndown.sampling, burndown.granularity, burndown.tick_size / 1000000000
 
+    def get_burndown_resample(self) -> str:
+        return self.contents["Burndown"].resample
+
     def get_project_burndown(self) -> Tuple[str, numpy.ndarray]:
         return self._parse_burndown_matrix(self.contents["Burndown"].project)
 
     def get_files_burndown(self):
         return [self._parse_burndown_matrix(i) for i in self.contents["Burndown"].files]
 
+    def get_directories_burndown(self):
+        directories = self.contents["Burndown"].directories
+        if not directories:
+            raise KeyError
+        return [self._parse_burndown_matrix(i) for i in directories]
+
+    def get_languages_burndown(self):
+        languages = self.contents["Burndown"].languages
+        if not languages:
+            raise KeyError
+        return [self._parse_burndown_matrix(i) for i in languages]
+
     def get_people_burndown(self) -> List[Any]:
         return [
             self._parse_burndown_matrix(i) for i in self.contents["Burndown"].people
@@ -572,6 +612,7 @@ class ProtobufReader(Reader):
                 "churn_normalized": float(file_entry.churn_normalized),
                 "coupling_normalized": float(file_entry.coupling_normalized),
                 "ownership_normalized": float(file_entry.ownership_normalized),
+                "language": str(file_entry.language),
             })
         window_days = int(hr.window_days)
         return files, window_days